@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/charmstore"
+	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/devices"
@@ -948,15 +949,48 @@ func (c *Client) UnsetApplicationConfig(branchName, application string, options
 // ResolveUnitErrors clears errors on one or more units.
 // Either specify one or more units, or all.
 func (c *Client) ResolveUnitErrors(units []string, all, retry bool) error {
+	return c.ResolveUnitErrorsFiltered(units, ResolveFilter{}, all, retry)
+}
+
+// ResolveFilter selects further units in error to be resolved by
+// ResolveUnitErrorsFiltered.
+type ResolveFilter struct {
+	// Applications resolves the units in error of these applications.
+	Applications []string
+
+	// Hooks restricts the units resolved to those in error in one of
+	// these hooks.
+	Hooks []string
+}
+
+// ResolveUnitErrorsFiltered clears errors on the given units, all units,
+// or the units of the filter's applications, optionally only where the
+// units are in error in one of the filter's hooks.
+func (c *Client) ResolveUnitErrorsFiltered(units []string, filter ResolveFilter, all, retry bool) error {
 	if len(units) > 0 && all {
 		return errors.NotSupportedf("specifying units with all=true")
 	}
+	if len(filter.Applications) > 0 && all {
+		return errors.NotSupportedf("specifying applications with all=true")
+	}
 	if len(units) != set.NewStrings(units...).Size() {
 		return errors.New("duplicate unit specified")
 	}
+	if len(filter.Applications) > 0 || len(filter.Hooks) > 0 {
+		if apiVersion := c.BestAPIVersion(); apiVersion < 16 {
+			return errors.NotSupportedf("resolving by application or hook for Application facade v%v", apiVersion)
+		}
+	}
+	for _, name := range filter.Applications {
+		if !names.IsValidApplication(name) {
+			return errors.NotValidf("application name %q", name)
+		}
+	}
 	args := params.UnitsResolved{
-		All:   all,
-		Retry: retry,
+		All:          all,
+		Retry:        retry,
+		Applications: filter.Applications,
+		Hooks:        filter.Hooks,
 	}
 	if !all {
 		entities := make([]params.Entity, len(units))
@@ -1007,6 +1041,100 @@ func (c *Client) ApplicationsInfo(applications []names.ApplicationTag) ([]params
 	return out.Results, nil
 }
 
+// ConsumedApplicationsInfo retrieves information about the specified
+// applications consumed from offers, including the diagnostics of
+// their cross model relations.
+func (c *Client) ConsumedApplicationsInfo(applications []names.ApplicationTag) ([]params.ConsumedApplicationInfoResult, error) {
+	if apiVersion := c.BestAPIVersion(); apiVersion < 13 {
+		return nil, errors.NotSupportedf("ConsumedApplicationsInfo for Application facade v%v", apiVersion)
+	}
+	all := make([]params.Entity, len(applications))
+	for i, one := range applications {
+		all[i] = params.Entity{Tag: one.String()}
+	}
+	in := params.Entities{Entities: all}
+	var out params.ConsumedApplicationInfoResults
+	err := c.facade.FacadeCall("ConsumedApplicationsInfo", in, &out)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if resultsLen := len(out.Results); resultsLen != len(applications) {
+		return nil, errors.Errorf("expected %d results, got %d", len(applications), resultsLen)
+	}
+	return out.Results, nil
+}
+
+// UnitsHookHistory returns the hooks recently run on each of the
+// given units, most recent first.
+func (c *Client) UnitsHookHistory(units []names.UnitTag) ([]params.HookHistoryResult, error) {
+	if apiVersion := c.BestAPIVersion(); apiVersion < 14 {
+		return nil, errors.NotSupportedf("UnitsHookHistory for Application facade v%v", apiVersion)
+	}
+	all := make([]params.Entity, len(units))
+	for i, one := range units {
+		all[i] = params.Entity{Tag: one.String()}
+	}
+	in := params.Entities{Entities: all}
+	var out params.HookHistoryResults
+	err := c.facade.FacadeCall("UnitsHookHistory", in, &out)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if resultsLen := len(out.Results); resultsLen != len(units) {
+		return nil, errors.Errorf("expected %d results, got %d", len(units), resultsLen)
+	}
+	return out.Results, nil
+}
+
+// SetUnitsTrace arranges for the hook tool calls made by each of the
+// given units' charms to be traced until the given time. A zero time
+// stops tracing. Only the size of each tool's output is traced unless
+// includeOutput is true.
+func (c *Client) SetUnitsTrace(units []names.UnitTag, until time.Time, includeOutput bool) ([]params.ErrorResult, error) {
+	if apiVersion := c.BestAPIVersion(); apiVersion < 15 {
+		return nil, errors.NotSupportedf("SetUnitsTrace for Application facade v%v", apiVersion)
+	}
+	all := make([]params.UnitTraceArg, len(units))
+	for i, one := range units {
+		all[i] = params.UnitTraceArg{Tag: one.String(), Until: until, IncludeOutput: includeOutput}
+	}
+	in := params.UnitTraceArgs{Args: all}
+	var out params.ErrorResults
+	err := c.facade.FacadeCall("SetUnitsTrace", in, &out)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if resultsLen := len(out.Results); resultsLen != len(units) {
+		return nil, errors.Errorf("expected %d results, got %d", len(units), resultsLen)
+	}
+	return out.Results, nil
+}
+
+// FlushDeferredHooks has the units of each of the given applications
+// run the hooks deferred by their maintenance windows now.
+func (c *Client) FlushDeferredHooks(applications []string) ([]params.ErrorResult, error) {
+	if apiVersion := c.BestAPIVersion(); apiVersion < 17 {
+		return nil, errors.NotSupportedf("FlushDeferredHooks for Application facade v%v", apiVersion)
+	}
+	entities := make([]params.Entity, len(applications))
+	for i, name := range applications {
+		if !names.IsValidApplication(name) {
+			return nil, errors.NotValidf("application name %q", name)
+		}
+		entities[i].Tag = names.NewApplicationTag(name).String()
+	}
+	in := params.Entities{Entities: entities}
+	var out params.ErrorResults
+	err := c.facade.FacadeCall("FlushDeferredHooks", in, &out)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if resultsLen := len(out.Results); resultsLen != len(applications) {
+		return nil, errors.Errorf("expected %d results, got %d", len(applications), resultsLen)
+	}
+	return out.Results, nil
+}
+
 // MergeBindings merges an operator-defined bindings list with the existing
 // application bindings.
 func (c *Client) MergeBindings(req params.ApplicationMergeBindingsArgs) error {
@@ -1035,6 +1163,10 @@ type UnitInfo struct {
 	Leader          bool
 	RelationData    []EndpointRelationData
 
+	// ResourceLimits holds the resources the unit's hooks may use, or nil
+	// if they are not limited. It is only set for IAAS models.
+	ResourceLimits *coreapplication.ResourceLimits
+
 	// The following are for CAAS models.
 	ProviderId string
 	Address    string
@@ -1097,6 +1229,13 @@ func unitInfoFromParams(in params.UnitInfoResult) UnitInfo {
 	for _, p := range in.Result.OpenedPorts {
 		info.OpenedPorts = append(info.OpenedPorts, p)
 	}
+	if limits := in.Result.ResourceLimits; limits != nil {
+		info.ResourceLimits = &coreapplication.ResourceLimits{
+			MemoryMB:  limits.MemoryMB,
+			CPUShares: limits.CPUShares,
+			Tasks:     limits.Tasks,
+		}
+	}
 	for _, inRd := range in.Result.RelationData {
 		erd := EndpointRelationData{
 			Endpoint:        inRd.Endpoint,
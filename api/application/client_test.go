@@ -20,6 +20,7 @@ import (
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/charmstore"
+	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/instance"
@@ -1272,6 +1273,58 @@ func (s *applicationSuite) TestResolveUnitErrorsAll(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *applicationSuite) TestResolveUnitErrorsFiltered(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 16,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Check(request, gc.Equals, "ResolveUnitErrors")
+			c.Assert(a, jc.DeepEquals, params.UnitsResolved{
+				Retry:        true,
+				Applications: []string{"mysql"},
+				Hooks:        []string{"config-changed"},
+				Tags:         params.Entities{Entities: []params.Entity{}},
+			})
+			result := response.(*params.ErrorResults)
+			result.Results = make([]params.ErrorResult, 0)
+			return nil
+		},
+	}
+	client := application.NewClient(apiCaller)
+	err := client.ResolveUnitErrorsFiltered(nil, application.ResolveFilter{
+		Applications: []string{"mysql"},
+		Hooks:        []string{"config-changed"},
+	}, false, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestResolveUnitErrorsFilteredPriorV16(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fail()
+		return nil
+	})
+	err := client.ResolveUnitErrorsFiltered(nil, application.ResolveFilter{
+		Hooks: []string{"config-changed"},
+	}, true, false)
+	c.Assert(err, gc.ErrorMatches, "resolving by application or hook for Application facade v8 not supported")
+}
+
+func (s *applicationSuite) TestResolveUnitErrorsFilteredInvalidApplication(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		BestVersion: 16,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fail()
+			return nil
+		},
+	})
+	err := client.ResolveUnitErrorsFiltered(nil, application.ResolveFilter{
+		Applications: []string{"mysql/0"},
+	}, false, false)
+	c.Assert(err, gc.ErrorMatches, `application name "mysql/0" not valid`)
+}
+
 func (s *applicationSuite) TestScaleApplication(c *gc.C) {
 	apiCaller := basetesting.APICallerFunc(
 		func(objType string, version int, id, request string, a, response interface{}) error {
@@ -1637,8 +1690,9 @@ func (s *applicationSuite) TestUnitsInfo(c *gc.C) {
 							},
 						},
 					}},
-					ProviderId: "provider-id",
-					Address:    "192.168.1.1",
+					ResourceLimits: &params.ResourceLimits{MemoryMB: 512, CPUShares: 256},
+					ProviderId:     "provider-id",
+					Address:        "192.168.1.1",
 				}},
 			}
 			return nil
@@ -1676,8 +1730,9 @@ func (s *applicationSuite) TestUnitsInfo(c *gc.C) {
 					},
 				},
 			}},
-			ProviderId: "provider-id",
-			Address:    "192.168.1.1",
+			ResourceLimits: &coreapplication.ResourceLimits{MemoryMB: 512, CPUShares: 256},
+			ProviderId:     "provider-id",
+			Address:        "192.168.1.1",
 		},
 	})
 }
@@ -1705,3 +1760,189 @@ func (s *applicationSuite) TestUnitssInfoResultMismatch(c *gc.C) {
 	)
 	c.Assert(err, gc.ErrorMatches, "expected 2 results, got 3")
 }
+
+func (s *applicationSuite) TestConsumedApplicationsInfoPriorV13(c *gc.C) {
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 12,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			return nil
+		},
+	}
+	client := application.NewClient(apiCaller)
+	_, err := client.ConsumedApplicationsInfo(nil)
+	c.Assert(err, gc.ErrorMatches, "ConsumedApplicationsInfo for Application facade v12 not supported")
+	c.Assert(called, jc.IsFalse)
+}
+
+func (s *applicationSuite) TestConsumedApplicationsInfo(c *gc.C) {
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 13,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Assert(request, gc.Equals, "ConsumedApplicationsInfo")
+			c.Assert(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "application-db2"}},
+			})
+			result, ok := response.(*params.ConsumedApplicationInfoResults)
+			c.Assert(ok, jc.IsTrue)
+			result.Results = []params.ConsumedApplicationInfoResult{{
+				Result: &params.ConsumedApplicationInfo{
+					Name:     "db2",
+					OfferURL: "fred/prod.db2",
+					Diagnostics: &params.RemoteApplicationDiagnostics{
+						SettingsSyncLag: time.Second,
+					},
+				},
+			}}
+			return nil
+		},
+	}
+	client := application.NewClient(apiCaller)
+	results, err := client.ConsumedApplicationsInfo([]names.ApplicationTag{names.NewApplicationTag("db2")})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(results, jc.DeepEquals, []params.ConsumedApplicationInfoResult{{
+		Result: &params.ConsumedApplicationInfo{
+			Name:     "db2",
+			OfferURL: "fred/prod.db2",
+			Diagnostics: &params.RemoteApplicationDiagnostics{
+				SettingsSyncLag: time.Second,
+			},
+		},
+	}})
+}
+
+func (s *applicationSuite) TestUnitsHookHistoryPriorV14(c *gc.C) {
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 13,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			return nil
+		},
+	}
+	client := application.NewClient(apiCaller)
+	_, err := client.UnitsHookHistory(nil)
+	c.Assert(err, gc.ErrorMatches, "UnitsHookHistory for Application facade v13 not supported")
+	c.Assert(called, jc.IsFalse)
+}
+
+func (s *applicationSuite) TestUnitsHookHistory(c *gc.C) {
+	started := time.Date(2020, 7, 1, 10, 0, 0, 0, time.UTC)
+	execution := params.HookExecution{
+		Hook:     "install",
+		Started:  started,
+		Finished: started.Add(time.Minute),
+		Result:   "succeeded",
+	}
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 14,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Assert(request, gc.Equals, "UnitsHookHistory")
+			c.Assert(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "unit-mysql-0"}},
+			})
+			result, ok := response.(*params.HookHistoryResults)
+			c.Assert(ok, jc.IsTrue)
+			result.Results = []params.HookHistoryResult{{
+				Executions: []params.HookExecution{execution},
+			}}
+			return nil
+		},
+	}
+	client := application.NewClient(apiCaller)
+	results, err := client.UnitsHookHistory([]names.UnitTag{names.NewUnitTag("mysql/0")})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(results, jc.DeepEquals, []params.HookHistoryResult{{
+		Executions: []params.HookExecution{execution},
+	}})
+}
+
+func (s *applicationSuite) TestSetUnitsTracePriorV15(c *gc.C) {
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 14,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			return nil
+		},
+	}
+	client := application.NewClient(apiCaller)
+	_, err := client.SetUnitsTrace(nil, time.Time{}, false)
+	c.Assert(err, gc.ErrorMatches, "SetUnitsTrace for Application facade v14 not supported")
+	c.Assert(called, jc.IsFalse)
+}
+
+func (s *applicationSuite) TestSetUnitsTrace(c *gc.C) {
+	until := time.Date(2020, 7, 1, 10, 10, 0, 0, time.UTC)
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 15,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Assert(request, gc.Equals, "SetUnitsTrace")
+			c.Assert(a, jc.DeepEquals, params.UnitTraceArgs{
+				Args: []params.UnitTraceArg{{Tag: "unit-mysql-0", Until: until, IncludeOutput: true}},
+			})
+			result, ok := response.(*params.ErrorResults)
+			c.Assert(ok, jc.IsTrue)
+			result.Results = []params.ErrorResult{{}}
+			return nil
+		},
+	}
+	client := application.NewClient(apiCaller)
+	results, err := client.SetUnitsTrace([]names.UnitTag{names.NewUnitTag("mysql/0")}, until, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(results, jc.DeepEquals, []params.ErrorResult{{}})
+}
+
+func (s *applicationSuite) TestFlushDeferredHooksPriorV17(c *gc.C) {
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 16,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			return nil
+		},
+	}
+	client := application.NewClient(apiCaller)
+	_, err := client.FlushDeferredHooks([]string{"mysql"})
+	c.Assert(err, gc.ErrorMatches, "FlushDeferredHooks for Application facade v16 not supported")
+	c.Assert(called, jc.IsFalse)
+}
+
+func (s *applicationSuite) TestFlushDeferredHooks(c *gc.C) {
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 17,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Assert(request, gc.Equals, "FlushDeferredHooks")
+			c.Assert(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "application-mysql"}},
+			})
+			result, ok := response.(*params.ErrorResults)
+			c.Assert(ok, jc.IsTrue)
+			result.Results = []params.ErrorResult{{}}
+			return nil
+		},
+	}
+	client := application.NewClient(apiCaller)
+	results, err := client.FlushDeferredHooks([]string{"mysql"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(results, jc.DeepEquals, []params.ErrorResult{{}})
+}
+
+func (s *applicationSuite) TestFlushDeferredHooksInvalidApplication(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{BestVersion: 17})
+	_, err := client.FlushDeferredHooks([]string{"mysql/0"})
+	c.Assert(err, gc.ErrorMatches, `application name "mysql/0" not valid`)
+}
@@ -4,6 +4,8 @@
 package applicationoffers
 
 import (
+	"time"
+
 	"github.com/juju/charm/v7"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -54,6 +56,71 @@ func (c *Client) Offer(modelUUID, application string, endpoints []string, offerN
 	return out.Results, nil
 }
 
+// OfferWithLimits prepares application's endpoints for consumption,
+// restricting how the resulting offer may be consumed.
+func (c *Client) OfferWithLimits(
+	modelUUID, application string, endpoints []string, offerName string, desc string, limits crossmodel.OfferLimits,
+) ([]params.ErrorResult, error) {
+	if bestVer := c.BestAPIVersion(); bestVer < 4 {
+		return nil, errors.NotSupportedf("offer limits for ApplicationOffers facade v%d", bestVer)
+	}
+	ep := make(map[string]string)
+	for _, name := range endpoints {
+		ep[name] = name
+	}
+	paramsLimits := offerLimitsToParams(limits)
+	offers := []params.AddApplicationOffer{
+		{
+			ModelTag:               names.NewModelTag(modelUUID).String(),
+			ApplicationName:        application,
+			ApplicationDescription: desc,
+			Endpoints:              ep,
+			OfferName:              offerName,
+			Limits:                 &paramsLimits,
+		},
+	}
+	out := params.ErrorResults{}
+	if err := c.facade.FacadeCall("Offer", params.AddApplicationOffers{Offers: offers}, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return out.Results, nil
+}
+
+// SetOfferLimits replaces the limits on how the offer at the given URL
+// may be consumed.
+func (c *Client) SetOfferLimits(urlStr string, limits crossmodel.OfferLimits) error {
+	if bestVer := c.BestAPIVersion(); bestVer < 4 {
+		return errors.NotSupportedf("SetOfferLimits for ApplicationOffers facade v%d", bestVer)
+	}
+	url, err := crossmodel.ParseOfferURL(urlStr)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if url.Source != "" {
+		return errors.NotSupportedf("setting limits for application offers on another controller")
+	}
+
+	args := params.SetOfferLimitsArgs{
+		Args: []params.SetOfferLimitsArg{{
+			OfferURL: urlStr,
+			Limits:   offerLimitsToParams(limits),
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetOfferLimits", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+func offerLimitsToParams(limits crossmodel.OfferLimits) params.OfferLimits {
+	return params.OfferLimits{
+		MaxConnections:     limits.MaxConnections,
+		MaxRelations:       limits.MaxRelations,
+		AllowedControllers: limits.AllowedControllers,
+	}
+}
+
 // ListOffers gets all remote applications that have been offered from this Juju model.
 // Each returned application satisfies at least one of the the specified filters.
 func (c *Client) ListOffers(filters ...crossmodel.ApplicationOfferFilter) ([]*crossmodel.ApplicationOfferDetails, error) {
@@ -118,6 +185,13 @@ func offerParamsToDetails(offer params.ApplicationOfferAdminDetails) (*crossmode
 		OfferURL:               offer.OfferURL,
 		Endpoints:              eps,
 	}
+	if offer.Limits != nil {
+		result.Limits = crossmodel.OfferLimits{
+			MaxConnections:     offer.Limits.MaxConnections,
+			MaxRelations:       offer.Limits.MaxRelations,
+			AllowedControllers: offer.Limits.AllowedControllers,
+		}
+	}
 	for _, oc := range offer.Connections {
 		modelTag, err := names.ParseModelTag(oc.SourceModelTag)
 		if err != nil {
@@ -132,6 +206,7 @@ func offerParamsToDetails(offer params.ApplicationOfferAdminDetails) (*crossmode
 			Message:         oc.Status.Info,
 			Since:           oc.Status.Since,
 			IngressSubnets:  oc.IngressSubnets,
+			Diagnostics:     diagnosticsFromParams(oc.Diagnostics),
 		})
 	}
 	for _, u := range offer.Users {
@@ -206,7 +281,7 @@ func (c *Client) ApplicationOffer(urlStr string) (*crossmodel.ApplicationOfferDe
 
 	found := params.ApplicationOffersResults{}
 
-	err = c.facade.FacadeCall("ApplicationOffers", params.OfferURLs{[]string{urlStr}, bakery.LatestVersion}, &found)
+	err = c.facade.FacadeCall("ApplicationOffers", params.OfferURLs{OfferURLs: []string{urlStr}, BakeryVersion: bakery.LatestVersion}, &found)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -254,7 +329,9 @@ func (c *Client) FindApplicationOffers(filters ...crossmodel.ApplicationOfferFil
 }
 
 // GetConsumeDetails returns details necessary to consue an offer at a given URL.
-func (c *Client) GetConsumeDetails(urlStr string) (params.ConsumeOfferDetails, error) {
+// The UUID of the controller hosting the consuming model, if known, is declared
+// by the returned macaroon so the offer's allowed controllers can be checked.
+func (c *Client) GetConsumeDetails(urlStr, consumingControllerUUID string) (params.ConsumeOfferDetails, error) {
 
 	url, err := crossmodel.ParseOfferURL(urlStr)
 	if err != nil {
@@ -266,7 +343,14 @@ func (c *Client) GetConsumeDetails(urlStr string) (params.ConsumeOfferDetails, e
 
 	found := params.ConsumeOfferDetailsResults{}
 
-	err = c.facade.FacadeCall("GetConsumeDetails", params.OfferURLs{[]string{urlStr}, bakery.LatestVersion}, &found)
+	args := params.OfferURLs{
+		OfferURLs:     []string{urlStr},
+		BakeryVersion: bakery.LatestVersion,
+	}
+	if consumingControllerUUID != "" {
+		args.ConsumingControllerTag = names.NewControllerTag(consumingControllerUUID).String()
+	}
+	err = c.facade.FacadeCall("GetConsumeDetails", args, &found)
 	if err != nil {
 		return params.ConsumeOfferDetails{}, errors.Trace(err)
 	}
@@ -287,6 +371,40 @@ func (c *Client) GetConsumeDetails(urlStr string) (params.ConsumeOfferDetails, e
 	}, nil
 }
 
+// CreateOfferInvitation creates an invitation to consume the offer at the
+// given URL, which can be redeemed once by another model before it expires.
+func (c *Client) CreateOfferInvitation(urlStr string, expiry time.Duration) (params.OfferInvitation, error) {
+	if bestVer := c.BestAPIVersion(); bestVer < 3 {
+		return params.OfferInvitation{}, errors.NotSupportedf("CreateOfferInvitations for ApplicationOffers facade v%d", bestVer)
+	}
+	url, err := crossmodel.ParseOfferURL(urlStr)
+	if err != nil {
+		return params.OfferInvitation{}, errors.Trace(err)
+	}
+	if url.Source != "" {
+		return params.OfferInvitation{}, errors.NotSupportedf("inviting to application offers on another controller")
+	}
+
+	args := params.CreateOfferInvitationArgs{
+		Args: []params.CreateOfferInvitationArg{{
+			OfferURL: urlStr,
+			Expiry:   expiry,
+		}},
+		BakeryVersion: bakery.LatestVersion,
+	}
+	var results params.OfferInvitationResults
+	if err := c.facade.FacadeCall("CreateOfferInvitations", args, &results); err != nil {
+		return params.OfferInvitation{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.OfferInvitation{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return params.OfferInvitation{}, errors.Trace(err)
+	}
+	return *results.Results[0].Result, nil
+}
+
 // DestroyOffers removes the specified application offers.
 func (c *Client) DestroyOffers(force bool, offerURLs ...string) error {
 	if len(offerURLs) == 0 {
@@ -318,3 +436,16 @@ func (c *Client) DestroyOffers(force bool, offerURLs ...string) error {
 	}
 	return result.Combine()
 }
+
+func diagnosticsFromParams(diagnostics *params.RemoteApplicationDiagnostics) *crossmodel.RemoteApplicationDiagnostics {
+	if diagnostics == nil {
+		return nil
+	}
+	return &crossmodel.RemoteApplicationDiagnostics{
+		LastRemoteEvent:  diagnostics.LastRemoteEvent,
+		MacaroonExpiry:   diagnostics.MacaroonExpiry,
+		SettingsSyncLag:  diagnostics.SettingsSyncLag,
+		IngressAddresses: diagnostics.IngressAddresses,
+		Updated:          diagnostics.Updated,
+	}
+}
@@ -614,6 +614,7 @@ func (s *crossmodelMockSuite) TestGetConsumeDetails(c *gc.C) {
 			args, ok := a.(params.OfferURLs)
 			c.Assert(ok, jc.IsTrue)
 			c.Assert(args.OfferURLs, jc.DeepEquals, []string{"me/prod.app"})
+			c.Assert(args.ConsumingControllerTag, gc.Equals, testing.ControllerTag.String())
 			if results, ok := result.(*params.ConsumeOfferDetailsResults); ok {
 				result := params.ConsumeOfferDetailsResult{
 					ConsumeOfferDetails: params.ConsumeOfferDetails{
@@ -627,7 +628,7 @@ func (s *crossmodelMockSuite) TestGetConsumeDetails(c *gc.C) {
 			return nil
 		})
 	client := applicationoffers.NewClient(apiCaller)
-	details, err := client.GetConsumeDetails("me/prod.app")
+	details, err := client.GetConsumeDetails("me/prod.app", testing.ControllerTag.Id())
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(called, jc.IsTrue)
 	c.Assert(details, jc.DeepEquals, params.ConsumeOfferDetails{
@@ -647,10 +648,183 @@ func (s *crossmodelMockSuite) TestGetConsumeDetailsBadURL(c *gc.C) {
 			return errors.New("should not be called")
 		})
 	client := applicationoffers.NewClient(apiCaller)
-	_, err := client.GetConsumeDetails("badurl")
+	_, err := client.GetConsumeDetails("badurl", "")
 	c.Assert(err, gc.ErrorMatches, "application offer URL is missing application")
 }
 
+func (s *crossmodelMockSuite) TestCreateOfferInvitation(c *gc.C) {
+	mac, err := apitesting.NewMacaroon("id")
+	c.Assert(err, jc.ErrorIsNil)
+	expires := time.Date(2020, 6, 4, 10, 0, 0, 0, time.UTC)
+	invitation := params.OfferInvitation{
+		Offer:          &params.ApplicationOfferDetails{OfferURL: "me/prod.app"},
+		Macaroon:       mac,
+		ControllerInfo: &params.ExternalControllerInfo{Addrs: []string{"1.2.3.4"}},
+		Expires:        expires,
+	}
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				called = true
+				c.Assert(request, gc.Equals, "CreateOfferInvitations")
+				args, ok := a.(params.CreateOfferInvitationArgs)
+				c.Assert(ok, jc.IsTrue)
+				c.Assert(args.Args, jc.DeepEquals, []params.CreateOfferInvitationArg{{
+					OfferURL: "me/prod.app",
+					Expiry:   72 * time.Hour,
+				}})
+				if results, ok := result.(*params.OfferInvitationResults); ok {
+					results.Results = []params.OfferInvitationResult{{Result: &invitation}}
+				}
+				return nil
+			},
+		),
+		BestVersion: 3,
+	}
+	client := applicationoffers.NewClient(apiCaller)
+	result, err := client.CreateOfferInvitation("me/prod.app", 72*time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(result, jc.DeepEquals, invitation)
+}
+
+func (s *crossmodelMockSuite) TestCreateOfferInvitationNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Fail()
+				return nil
+			},
+		),
+		BestVersion: 2,
+	}
+	client := applicationoffers.NewClient(apiCaller)
+	_, err := client.CreateOfferInvitation("me/prod.app", time.Hour)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *crossmodelMockSuite) TestOfferWithLimits(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				called = true
+				c.Assert(request, gc.Equals, "Offer")
+				args, ok := a.(params.AddApplicationOffers)
+				c.Assert(ok, jc.IsTrue)
+				c.Assert(args.Offers, gc.HasLen, 1)
+				c.Assert(args.Offers[0].OfferName, gc.Equals, "hosted-mysql")
+				c.Assert(args.Offers[0].Limits, jc.DeepEquals, &params.OfferLimits{
+					MaxConnections: 2,
+					MaxRelations:   4,
+				})
+				if results, ok := result.(*params.ErrorResults); ok {
+					results.Results = []params.ErrorResult{{}}
+				}
+				return nil
+			},
+		),
+		BestVersion: 4,
+	}
+	client := applicationoffers.NewClient(apiCaller)
+	results, err := client.OfferWithLimits("uuid", "mysql", []string{"db"}, "hosted-mysql", "",
+		jujucrossmodel.OfferLimits{MaxConnections: 2, MaxRelations: 4})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *crossmodelMockSuite) TestOfferWithLimitsNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Fail()
+				return nil
+			},
+		),
+		BestVersion: 3,
+	}
+	client := applicationoffers.NewClient(apiCaller)
+	_, err := client.OfferWithLimits("uuid", "mysql", []string{"db"}, "hosted-mysql", "",
+		jujucrossmodel.OfferLimits{MaxConnections: 2})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *crossmodelMockSuite) TestSetOfferLimits(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				called = true
+				c.Assert(request, gc.Equals, "SetOfferLimits")
+				args, ok := a.(params.SetOfferLimitsArgs)
+				c.Assert(ok, jc.IsTrue)
+				c.Assert(args.Args, jc.DeepEquals, []params.SetOfferLimitsArg{{
+					OfferURL: "me/prod.app",
+					Limits: params.OfferLimits{
+						MaxConnections:     3,
+						AllowedControllers: []string{"deadbeef-0bad-400d-8000-4b1d0d06f00d"},
+					},
+				}})
+				if results, ok := result.(*params.ErrorResults); ok {
+					results.Results = []params.ErrorResult{{
+						Error: &params.Error{Message: "fail"},
+					}}
+				}
+				return nil
+			},
+		),
+		BestVersion: 4,
+	}
+	client := applicationoffers.NewClient(apiCaller)
+	err := client.SetOfferLimits("me/prod.app", jujucrossmodel.OfferLimits{
+		MaxConnections:     3,
+		AllowedControllers: []string{"deadbeef-0bad-400d-8000-4b1d0d06f00d"},
+	})
+	c.Assert(err, gc.ErrorMatches, "fail")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *crossmodelMockSuite) TestSetOfferLimitsNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Fail()
+				return nil
+			},
+		),
+		BestVersion: 3,
+	}
+	client := applicationoffers.NewClient(apiCaller)
+	err := client.SetOfferLimits("me/prod.app", jujucrossmodel.OfferLimits{MaxRelations: 1})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *crossmodelMockSuite) TestDestroyOffers(c *gc.C) {
 	var called bool
 	apiCaller := basetesting.BestVersionCaller{
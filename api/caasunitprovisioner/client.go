@@ -171,6 +171,8 @@ type ProvisioningInfo struct {
 	DeploymentInfo       DeploymentInfo
 	PodSpec              string
 	RawK8sSpec           string
+	HelmChart            []byte
+	HelmValues           map[string]interface{}
 	Constraints          constraints.Value
 	Filesystems          []storage.KubernetesFilesystemParams
 	Devices              []devices.KubernetesDeviceParams
@@ -202,6 +204,8 @@ func (c *Client) ProvisioningInfo(appName string) (*ProvisioningInfo, error) {
 	info := &ProvisioningInfo{
 		PodSpec:              result.PodSpec,
 		RawK8sSpec:           result.RawK8sSpec,
+		HelmChart:            result.HelmChart,
+		HelmValues:           result.HelmValues,
 		Constraints:          result.Constraints,
 		Tags:                 result.Tags,
 		OperatorImagePath:    result.OperatorImagePath,
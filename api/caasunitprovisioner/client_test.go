@@ -114,6 +114,27 @@ func (s *unitprovisionerSuite) TestProvisioningInfo(c *gc.C) {
 	})
 }
 
+func (s *unitprovisionerSuite) TestProvisioningInfoHelmChart(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "ProvisioningInfo")
+		*(result.(*params.KubernetesProvisioningInfoResults)) = params.KubernetesProvisioningInfoResults{
+			Results: []params.KubernetesProvisioningInfoResult{{
+				Result: &params.KubernetesProvisioningInfo{
+					HelmChart:  []byte("chart-data"),
+					HelmValues: map[string]interface{}{"image.tag": "10.6"},
+				},
+			}},
+		}
+		return nil
+	})
+
+	client := caasunitprovisioner.NewClient(apiCaller)
+	info, err := client.ProvisioningInfo("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.HelmChart, jc.DeepEquals, []byte("chart-data"))
+	c.Assert(info.HelmValues, jc.DeepEquals, map[string]interface{}{"image.tag": "10.6"})
+}
+
 func (s *unitprovisionerSuite) TestProvisioningInfoError(c *gc.C) {
 	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
 		*(result.(*params.KubernetesProvisioningInfoResults)) = params.KubernetesProvisioningInfoResults{
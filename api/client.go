@@ -527,6 +527,12 @@ func openCharmArgs(curl *charm.URL) (string, url.Values) {
 	return "/charms", query
 }
 
+// OpenResource streams out the current revision of the named
+// application resource from the controller via the API.
+func (c *Client) OpenResource(application, name string) (io.ReadCloser, error) {
+	return c.OpenURI(fmt.Sprintf("/applications/%s/resources/%s", application, name), nil)
+}
+
 // OpenURI performs a GET on a Juju HTTP endpoint returning the
 func (c *Client) OpenURI(uri string, query url.Values) (io.ReadCloser, error) {
 	return openURI(c.st, uri, query)
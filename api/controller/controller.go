@@ -5,6 +5,7 @@ package controller
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
@@ -289,6 +290,15 @@ type MigrationSpec struct {
 	TargetUser            string
 	TargetPassword        string
 	TargetMacaroons       []macaroon.Slice
+
+	// KeepSource is how long the model is kept, dormant, on the
+	// source controller after a successful migration so that the
+	// migration can be reverted.
+	KeepSource time.Duration
+
+	// Revert is true if the model is being moved back to the
+	// controller it was migrated from, where it has been kept.
+	Revert bool
 }
 
 // Validate performs sanity checks on the migration configuration it
@@ -309,6 +319,12 @@ func (s *MigrationSpec) Validate() error {
 	if s.TargetPassword == "" && len(s.TargetMacaroons) == 0 {
 		return errors.NotValidf("missing authentication secrets")
 	}
+	if s.KeepSource < 0 {
+		return errors.NotValidf("negative keep-source duration")
+	}
+	if s.Revert && s.KeepSource > 0 {
+		return errors.NotValidf("keep-source when reverting a migration")
+	}
 	return nil
 }
 
@@ -322,13 +338,130 @@ func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
 	if err := spec.Validate(); err != nil {
 		return "", errors.Annotatef(err, "client-side validation failed")
 	}
+	if (spec.KeepSource > 0 || spec.Revert) && c.BestAPIVersion() < 13 {
+		return "", errors.NotSupportedf("keeping or reverting migrated models")
+	}
 
-	macsJSON, err := macaroonsToJSON(spec.TargetMacaroons)
+	args, err := migrationSpecToArgs(spec)
 	if err != nil {
-		return "", errors.Annotatef(err, "client-side validation failed")
+		return "", errors.Trace(err)
+	}
+	response := params.InitiateMigrationResults{}
+	if err := c.facade.FacadeCall("InitiateMigration", args, &response); err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return "", errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return "", errors.Trace(result.Error)
+	}
+	return result.MigrationId, nil
+}
+
+// MigrationPrecheckReport holds the blocking and warning findings of
+// the source and target controllers for a proposed migration.
+type MigrationPrecheckReport struct {
+	Source params.MigrationPrecheckReport
+	Target params.MigrationPrecheckReport
+}
+
+// MigrationPrechecks runs all of the migration prechecks for the
+// specified model without starting a migration, returning every
+// problem found by the source and target controllers.
+func (c *Client) MigrationPrechecks(spec MigrationSpec) (MigrationPrecheckReport, error) {
+	var report MigrationPrecheckReport
+	if c.BestAPIVersion() < 10 {
+		return report, errors.NotSupportedf("MigrationPrechecks")
+	}
+	if err := spec.Validate(); err != nil {
+		return report, errors.Annotatef(err, "client-side validation failed")
+	}
+	args, err := migrationSpecToArgs(spec)
+	if err != nil {
+		return report, errors.Trace(err)
+	}
+	response := params.MigrationPrecheckResults{}
+	if err := c.facade.FacadeCall("MigrationPrechecks", args, &response); err != nil {
+		return report, errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return report, errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return report, errors.Trace(result.Error)
+	}
+	report.Source = result.Source
+	report.Target = result.Target
+	return report, nil
+}
+
+// ExportModel returns the serialized form of the specified model along
+// with the charms, agent binaries and resources it uses. The model is
+// not changed.
+func (c *Client) ExportModel(modelUUID string) (params.SerializedModel, error) {
+	var empty params.SerializedModel
+	if c.BestAPIVersion() < 11 {
+		return empty, errors.NotSupportedf("ExportModel")
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewModelTag(modelUUID).String()}},
+	}
+	var results params.SerializedModelResults
+	if err := c.facade.FacadeCall("ExportModel", args, &results); err != nil {
+		return empty, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return empty, errors.New("unexpected number of results returned")
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return empty, errors.Trace(result.Error)
+	}
+	return *result.Result, nil
+}
+
+// ModelMigrations returns the most recent migration of each model which
+// has been migrated, or has had a migration attempted, from the
+// controller.
+func (c *Client) ModelMigrations() ([]params.ModelMigrationReport, error) {
+	if c.BestAPIVersion() < 12 {
+		return nil, errors.NotSupportedf("ModelMigrations")
+	}
+	var results params.ModelMigrationReports
+	if err := c.facade.FacadeCall("ModelMigrations", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Migrations, nil
+}
+
+// EnableMaintenanceMode puts the controller into maintenance mode,
+// rejecting changes across all of its models. The message is shown to
+// users whose requests are rejected, and in status output.
+func (c *Client) EnableMaintenanceMode(message string) error {
+	if c.BestAPIVersion() < 14 {
+		return errors.NotSupportedf("EnableMaintenanceMode")
+	}
+	args := params.MaintenanceModeArgs{Message: message}
+	return errors.Trace(c.facade.FacadeCall("EnableMaintenanceMode", args, nil))
+}
+
+// DisableMaintenanceMode takes the controller out of maintenance mode.
+func (c *Client) DisableMaintenanceMode() error {
+	if c.BestAPIVersion() < 14 {
+		return errors.NotSupportedf("DisableMaintenanceMode")
 	}
+	return errors.Trace(c.facade.FacadeCall("DisableMaintenanceMode", nil, nil))
+}
 
-	args := params.InitiateMigrationArgs{
+func migrationSpecToArgs(spec MigrationSpec) (params.InitiateMigrationArgs, error) {
+	macsJSON, err := macaroonsToJSON(spec.TargetMacaroons)
+	if err != nil {
+		return params.InitiateMigrationArgs{}, errors.Annotatef(err, "client-side validation failed")
+	}
+	return params.InitiateMigrationArgs{
 		Specs: []params.MigrationSpec{{
 			ModelTag: names.NewModelTag(spec.ModelUUID).String(),
 			TargetInfo: params.MigrationTargetInfo{
@@ -340,20 +473,10 @@ func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
 				Password:        spec.TargetPassword,
 				Macaroons:       macsJSON,
 			},
+			KeepSource: spec.KeepSource,
+			Revert:     spec.Revert,
 		}},
-	}
-	response := params.InitiateMigrationResults{}
-	if err := c.facade.FacadeCall("InitiateMigration", args, &response); err != nil {
-		return "", errors.Trace(err)
-	}
-	if len(response.Results) != 1 {
-		return "", errors.New("unexpected number of results returned")
-	}
-	result := response.Results[0]
-	if result.Error != nil {
-		return "", errors.Trace(result.Error)
-	}
-	return result.MigrationId, nil
+	}, nil
 }
 
 func macaroonsToJSON(macs []macaroon.Slice) (string, error) {
@@ -5,6 +5,7 @@ package controller_test
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
@@ -125,10 +126,63 @@ func specToArgs(spec controller.MigrationSpec) params.InitiateMigrationArgs {
 				Password:        spec.TargetPassword,
 				Macaroons:       string(macsJSON),
 			},
+			KeepSource: spec.KeepSource,
+			Revert:     spec.Revert,
 		}},
 	}
 }
 
+func (s *Suite) TestInitiateMigrationKeepSource(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 13,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			out := result.(*params.InitiateMigrationResults)
+			*out = params.InitiateMigrationResults{
+				Results: []params.InitiateMigrationResult{{MigrationId: "id"}},
+			}
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	spec := makeSpec()
+	spec.KeepSource = 24 * time.Hour
+	id, err := client.InitiateMigration(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(id, gc.Equals, "id")
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.InitiateMigration", []interface{}{specToArgs(spec)}},
+	})
+}
+
+func (s *Suite) TestInitiateMigrationRevertNotSupported(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 12,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	spec := makeSpec()
+	spec.Revert = true
+	_, err := client.InitiateMigration(spec)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	stub.CheckNoCalls(c)
+}
+
+func (s *Suite) TestInitiateMigrationKeepSourceWithRevert(c *gc.C) {
+	client, stub := makeInitiateMigrationClient(params.InitiateMigrationResults{})
+	spec := makeSpec()
+	spec.KeepSource = time.Hour
+	spec.Revert = true
+	_, err := client.InitiateMigration(spec)
+	c.Check(err, gc.ErrorMatches, "client-side validation failed: keep-source when reverting a migration not valid")
+	c.Check(stub.Calls(), gc.HasLen, 0)
+}
+
 func (s *Suite) TestInitiateMigrationError(c *gc.C) {
 	client, _ := makeInitiateMigrationClient(params.InitiateMigrationResults{
 		Results: []params.InitiateMigrationResult{{
@@ -172,6 +226,207 @@ func (s *Suite) TestInitiateMigrationValidationError(c *gc.C) {
 	c.Check(stub.Calls(), gc.HasLen, 0) // API call shouldn't have happened
 }
 
+func (s *Suite) TestMigrationPrechecks(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 10,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			out := result.(*params.MigrationPrecheckResults)
+			*out = params.MigrationPrecheckResults{
+				Results: []params.MigrationPrecheckResult{{
+					Source: params.MigrationPrecheckReport{Warnings: []string{"warning"}},
+					Target: params.MigrationPrecheckReport{Blockers: []string{"blocker"}},
+				}},
+			}
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	spec := makeSpec()
+	report, err := client.MigrationPrechecks(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(report, jc.DeepEquals, controller.MigrationPrecheckReport{
+		Source: params.MigrationPrecheckReport{Warnings: []string{"warning"}},
+		Target: params.MigrationPrecheckReport{Blockers: []string{"blocker"}},
+	})
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.MigrationPrechecks", []interface{}{specToArgs(spec)}},
+	})
+}
+
+func (s *Suite) TestMigrationPrechecksError(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 10,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			out := result.(*params.MigrationPrecheckResults)
+			*out = params.MigrationPrecheckResults{
+				Results: []params.MigrationPrecheckResult{{
+					Error: apiservererrors.ServerError(errors.New("boom")),
+				}},
+			}
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.MigrationPrechecks(makeSpec())
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *Suite) TestMigrationPrechecksNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{BestVersion: 9}
+	client := controller.NewClient(apiCaller)
+	_, err := client.MigrationPrechecks(makeSpec())
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *Suite) TestExportModel(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 11,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			out := result.(*params.SerializedModelResults)
+			*out = params.SerializedModelResults{
+				Results: []params.SerializedModelResult{{
+					Result: &params.SerializedModel{
+						Bytes:  []byte("model"),
+						Charms: []string{"cs:mysql-1"},
+						Tools: []params.SerializedModelTools{{
+							Version: "2.8.0-focal-amd64",
+							URI:     "/tools/2.8.0-focal-amd64",
+						}},
+					},
+				}},
+			}
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	serialized, err := client.ExportModel(coretesting.ModelTag.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(serialized, jc.DeepEquals, params.SerializedModel{
+		Bytes:  []byte("model"),
+		Charms: []string{"cs:mysql-1"},
+		Tools: []params.SerializedModelTools{{
+			Version: "2.8.0-focal-amd64",
+			URI:     "/tools/2.8.0-focal-amd64",
+		}},
+	})
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.ExportModel", []interface{}{params.Entities{
+			Entities: []params.Entity{{Tag: coretesting.ModelTag.String()}},
+		}}},
+	})
+}
+
+func (s *Suite) TestExportModelError(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 11,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			out := result.(*params.SerializedModelResults)
+			*out = params.SerializedModelResults{
+				Results: []params.SerializedModelResult{{
+					Error: apiservererrors.ServerError(errors.New("boom")),
+				}},
+			}
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.ExportModel(coretesting.ModelTag.Id())
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *Suite) TestExportModelNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{BestVersion: 10}
+	client := controller.NewClient(apiCaller)
+	_, err := client.ExportModel(coretesting.ModelTag.Id())
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *Suite) TestModelMigrations(c *gc.C) {
+	start := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	reports := []params.ModelMigrationReport{{
+		MigrationId:      coretesting.ModelTag.Id() + ":0",
+		ModelTag:         coretesting.ModelTag.String(),
+		ModelName:        "prod",
+		TargetController: "target",
+		Phase:            "ABORTDONE",
+		FailedPhase:      "VALIDATION",
+		StatusMessage:    "aborted",
+		Start:            start,
+		PhaseChanged:     start,
+	}}
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 12,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			out := result.(*params.ModelMigrationReports)
+			*out = params.ModelMigrationReports{Migrations: reports}
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	out, err := client.ModelMigrations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(out, jc.DeepEquals, reports)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.ModelMigrations", []interface{}{nil}},
+	})
+}
+
+func (s *Suite) TestModelMigrationsNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{BestVersion: 11}
+	client := controller.NewClient(apiCaller)
+	_, err := client.ModelMigrations()
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *Suite) TestEnableMaintenanceMode(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 14,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	err := client.EnableMaintenanceMode("upgrading storage")
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.EnableMaintenanceMode", []interface{}{params.MaintenanceModeArgs{Message: "upgrading storage"}}},
+	})
+}
+
+func (s *Suite) TestDisableMaintenanceMode(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 14,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	err := client.DisableMaintenanceMode()
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.DisableMaintenanceMode", []interface{}{nil}},
+	})
+}
+
+func (s *Suite) TestMaintenanceModeNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{BestVersion: 13}
+	client := controller.NewClient(apiCaller)
+	err := client.EnableMaintenanceMode("")
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	err = client.DisableMaintenanceMode()
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *Suite) TestHostedModelConfigs_CallError(c *gc.C) {
 	apiCaller := apitesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
 		return errors.New("boom")
@@ -381,6 +381,59 @@ func (c *Client) WatchRelationChanges(relationToken, applicationToken string, ma
 	return w, nil
 }
 
+// RedeemOfferInvitation redeems the offer invitation authorised by the
+// specified macaroon on behalf of the consuming model, on the specified
+// controller, returning a macaroon which allows that model to consume
+// the offer.
+func (c *Client) RedeemOfferInvitation(sourceModelUUID, sourceControllerUUID string, mac *macaroon.Macaroon) (*macaroon.Macaroon, error) {
+	if bestVer := c.BestAPIVersion(); bestVer < 3 {
+		return nil, errors.NotSupportedf("RedeemOfferInvitations for CrossModelRelations facade v%d", bestVer)
+	}
+	args := params.RedeemOfferInvitationArgs{
+		Args: []params.RedeemOfferInvitationArg{{
+			SourceModelTag:      names.NewModelTag(sourceModelUUID).String(),
+			SourceControllerTag: names.NewControllerTag(sourceControllerUUID).String(),
+			Macaroons:           macaroon.Slice{mac},
+			BakeryVersion:       bakery.LatestVersion,
+		}},
+	}
+	var results params.MacaroonResults
+	if err := c.facade.FacadeCall("RedeemOfferInvitations", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results[0].Result, nil
+}
+
+// CheckOfferConnection checks whether a model on the specified controller
+// would be allowed to relate to the offer, given the limits configured on it.
+func (c *Client) CheckOfferConnection(offerUUID, sourceModelUUID, sourceControllerUUID string, macs macaroon.Slice) error {
+	if bestVer := c.BestAPIVersion(); bestVer < 4 {
+		return errors.NotSupportedf("CheckOfferConnections for CrossModelRelations facade v%d", bestVer)
+	}
+	arg := params.CheckOfferConnectionArg{
+		OfferUUID:      offerUUID,
+		SourceModelTag: names.NewModelTag(sourceModelUUID).String(),
+		Macaroons:      macs,
+		BakeryVersion:  bakery.LatestVersion,
+	}
+	if sourceControllerUUID != "" {
+		arg.SourceControllerTag = names.NewControllerTag(sourceControllerUUID).String()
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("CheckOfferConnections", params.CheckOfferConnectionArgs{
+		Args: []params.CheckOfferConnectionArg{arg},
+	}, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
 // relationUnitSettings returns the relation unit settings for the given relation units in the remote model.
 func (c *Client) relationUnitSettings(unitNames []string, relationToken string, macs macaroon.Slice) ([]params.SettingsResult, error) {
 	var (
@@ -278,6 +278,97 @@ func (s *CrossModelRelationsSuite) TestRegisterRemoteRelationDischargeRequired(c
 	apitesting.MacaroonEquals(c, ms[0], dischargeMac[0])
 }
 
+func (s *CrossModelRelationsSuite) TestRedeemOfferInvitation(c *gc.C) {
+	mac, err := apitesting.NewMacaroon("id")
+	c.Assert(err, jc.ErrorIsNil)
+	consumeMac, err := apitesting.NewMacaroon("consume")
+	c.Assert(err, jc.ErrorIsNil)
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "CrossModelRelations")
+			c.Check(version, gc.Equals, 3)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "RedeemOfferInvitations")
+			c.Check(arg, jc.DeepEquals, params.RedeemOfferInvitationArgs{Args: []params.RedeemOfferInvitationArg{{
+				SourceModelTag:      coretesting.ModelTag.String(),
+				SourceControllerTag: coretesting.ControllerTag.String(),
+				Macaroons:           macaroon.Slice{mac},
+				BakeryVersion:       bakery.LatestVersion,
+			}}})
+			c.Assert(result, gc.FitsTypeOf, &params.MacaroonResults{})
+			*(result.(*params.MacaroonResults)) = params.MacaroonResults{
+				Results: []params.MacaroonResult{{Result: consumeMac}},
+			}
+			return nil
+		}),
+		BestVersion: 3,
+	}
+	client := crossmodelrelations.NewClientWithCache(apiCaller, s.cache)
+	result, err := client.RedeemOfferInvitation(coretesting.ModelTag.Id(), coretesting.ControllerTag.Id(), mac)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, consumeMac)
+}
+
+func (s *CrossModelRelationsSuite) TestRedeemOfferInvitationNotSupported(c *gc.C) {
+	mac, err := apitesting.NewMacaroon("id")
+	c.Assert(err, jc.ErrorIsNil)
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fail()
+			return nil
+		}),
+		BestVersion: 2,
+	}
+	client := crossmodelrelations.NewClientWithCache(apiCaller, s.cache)
+	_, err = client.RedeemOfferInvitation(coretesting.ModelTag.Id(), coretesting.ControllerTag.Id(), mac)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *CrossModelRelationsSuite) TestCheckOfferConnection(c *gc.C) {
+	mac, err := apitesting.NewMacaroon("id")
+	c.Assert(err, jc.ErrorIsNil)
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "CrossModelRelations")
+			c.Check(version, gc.Equals, 4)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "CheckOfferConnections")
+			c.Check(arg, jc.DeepEquals, params.CheckOfferConnectionArgs{Args: []params.CheckOfferConnectionArg{{
+				OfferUUID:           "offer-uuid",
+				SourceModelTag:      coretesting.ModelTag.String(),
+				SourceControllerTag: coretesting.ControllerTag.String(),
+				Macaroons:           macaroon.Slice{mac},
+				BakeryVersion:       bakery.LatestVersion,
+			}}})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{
+					Error: &params.Error{Message: "limit reached", Code: params.CodeQuotaLimitExceeded},
+				}},
+			}
+			return nil
+		}),
+		BestVersion: 4,
+	}
+	client := crossmodelrelations.NewClientWithCache(apiCaller, s.cache)
+	err = client.CheckOfferConnection("offer-uuid", coretesting.ModelTag.Id(), coretesting.ControllerTag.Id(), macaroon.Slice{mac})
+	c.Assert(err, gc.ErrorMatches, "limit reached")
+	c.Assert(err, jc.Satisfies, params.IsCodeQuotaLimitExceeded)
+}
+
+func (s *CrossModelRelationsSuite) TestCheckOfferConnectionNotSupported(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fail()
+			return nil
+		}),
+		BestVersion: 3,
+	}
+	client := crossmodelrelations.NewClientWithCache(apiCaller, s.cache)
+	err := client.CheckOfferConnection("offer-uuid", coretesting.ModelTag.Id(), "", nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *CrossModelRelationsSuite) TestWatchRelationChanges(c *gc.C) {
 	remoteRelationToken := "token"
 	mac, err := apitesting.NewMacaroon("id")
@@ -18,8 +18,8 @@ var facadeVersions = map[string]int{
 	"AllModelWatcher":              2,
 	"AllWatcher":                   1,
 	"Annotations":                  2,
-	"Application":                  12,
-	"ApplicationOffers":            2,
+	"Application":                  17,
+	"ApplicationOffers":            4,
 	"ApplicationScaler":            1,
 	"Backups":                      2,
 	"Block":                        2,
@@ -38,11 +38,11 @@ var facadeVersions = map[string]int{
 	"Cleaner":                      2,
 	"Client":                       2,
 	"Cloud":                        7,
-	"Controller":                   9,
+	"Controller":                   14,
 	"CredentialManager":            1,
 	"CredentialValidator":          2,
 	"CrossController":              1,
-	"CrossModelRelations":          2,
+	"CrossModelRelations":          4,
 	"Deployer":                     1,
 	"DiskManager":                  2,
 	"EntityWatcher":                2,
@@ -67,7 +67,7 @@ var facadeVersions = map[string]int{
 	"MachineActions":               1,
 	"MachineManager":               6,
 	"MachineUndertaker":            1,
-	"Machiner":                     4,
+	"Machiner":                     5,
 	"MeterStatus":                  2,
 	"MetricsAdder":                 2,
 	"MetricsDebug":                 2,
@@ -76,7 +76,7 @@ var facadeVersions = map[string]int{
 	"MigrationMaster":              2,
 	"MigrationMinion":              1,
 	"MigrationStatusWatcher":       1,
-	"MigrationTarget":              1,
+	"MigrationTarget":              3,
 	"ModelConfig":                  2,
 	"ModelGeneration":              4,
 	"ModelManager":                 8,
@@ -92,23 +92,24 @@ var facadeVersions = map[string]int{
 	"Reboot":                       2,
 	"RelationStatusWatcher":        1,
 	"RelationUnitsWatcher":         1,
-	"RemoteRelations":              2,
+	"RemoteRelations":              3,
 	"RemoteRelationWatcher":        1,
 	"Resources":                    1,
 	"ResourcesHookContext":         1,
 	"Resumer":                      2,
 	"RetryStrategy":                1,
+	"Secrets":                      1,
 	"Singular":                     2,
 	"Spaces":                       6,
 	"SSHClient":                    2,
 	"StatusHistory":                2,
-	"Storage":                      6,
-	"StorageProvisioner":           4,
+	"Storage":                      8,
+	"StorageProvisioner":           5,
 	"StringsWatcher":               1,
 	"Subnets":                      4,
 	"Undertaker":                   1,
 	"UnitAssigner":                 1,
-	"Uniter":                       16,
+	"Uniter":                       22,
 	"Upgrader":                     1,
 	"UpgradeSeries":                3,
 	"UpgradeSteps":                 2,
@@ -42,6 +42,26 @@ func (m *Machine) Refresh() error {
 	return nil
 }
 
+// Status returns the status of the machine.
+func (m *Machine) Status() (params.StatusResult, error) {
+	var results params.StatusResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: m.tag.String()}},
+	}
+	err := m.st.facade.FacadeCall("Status", args, &results)
+	if err != nil {
+		return params.StatusResult{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.StatusResult{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.StatusResult{}, result.Error
+	}
+	return result, nil
+}
+
 // SetStatus sets the status of the machine.
 func (m *Machine) SetStatus(status status.Status, info string, data map[string]interface{}) error {
 	var result params.ErrorResults
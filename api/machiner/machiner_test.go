@@ -90,6 +90,20 @@ func (s *machinerSuite) TestSetStatus(c *gc.C) {
 	c.Assert(statusInfo.Since, gc.NotNil)
 }
 
+func (s *machinerSuite) TestStatus(c *gc.C) {
+	machine, err := s.machiner.Machine(names.NewMachineTag("1"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = machine.SetStatus(status.Started, "blah", map[string]interface{}{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := machine.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Status, gc.Equals, status.Started.String())
+	c.Assert(result.Info, gc.Equals, "blah")
+	c.Assert(result.Data, jc.DeepEquals, map[string]interface{}{"foo": "bar"})
+}
+
 func (s *machinerSuite) TestEnsureDead(c *gc.C) {
 	c.Assert(s.machine.Life(), gc.Equals, state.Alive)
 
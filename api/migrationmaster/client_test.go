@@ -95,6 +95,7 @@ func (s *ClientSuite) TestMigrationStatus(c *gc.C) {
 					Password:      "secret",
 					Macaroons:     string(macsJSON),
 				},
+				KeepSource: time.Hour,
 			},
 			MigrationId:      "id",
 			Phase:            "IMPORT",
@@ -122,6 +123,7 @@ func (s *ClientSuite) TestMigrationStatus(c *gc.C) {
 			AuthTag:       names.NewUserTag("admin"),
 			Password:      "secret",
 		},
+		KeepSource: time.Hour,
 	})
 }
 
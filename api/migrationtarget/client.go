@@ -43,14 +43,48 @@ type Client struct {
 }
 
 func (c *Client) Prechecks(model coremigration.ModelInfo) error {
-	args := params.MigrationModelInfo{
+	args := migrationModelInfoToParams(model)
+	return errors.Trace(c.caller.FacadeCall("Prechecks", args, nil))
+}
+
+// PrecheckReport runs the target controller's migration prechecks
+// without stopping at the first problem, and returns every problem
+// which would prevent the migration.
+func (c *Client) PrecheckReport(model coremigration.ModelInfo) (params.MigrationPrecheckReport, error) {
+	var report params.MigrationPrecheckReport
+	if c.caller.BestAPIVersion() < 2 {
+		return report, errors.NotSupportedf("PrecheckReport")
+	}
+	args := migrationModelInfoToParams(model)
+	err := c.caller.FacadeCall("PrecheckReport", args, &report)
+	return report, errors.Trace(err)
+}
+
+// RevertPrechecks checks that the target controller is keeping the
+// model dormant after migrating it away, and that the kept model
+// matches the given fingerprint of the model being moved back, so that
+// the migration can be reverted.
+func (c *Client) RevertPrechecks(model coremigration.ModelInfo, fingerprint string) error {
+	if c.caller.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("RevertPrechecks")
+	}
+	args := params.RevertPrechecksArgs{
+		Model:       migrationModelInfoToParams(model),
+		Fingerprint: fingerprint,
+	}
+	return errors.Trace(c.caller.FacadeCall("RevertPrechecks", args, nil))
+}
+
+func migrationModelInfoToParams(model coremigration.ModelInfo) params.MigrationModelInfo {
+	return params.MigrationModelInfo{
 		UUID:                   model.UUID,
 		Name:                   model.Name,
 		OwnerTag:               model.Owner.String(),
 		AgentVersion:           model.AgentVersion,
 		ControllerAgentVersion: model.ControllerAgentVersion,
+		CloudName:              model.CloudName,
+		CloudRegion:            model.CloudRegion,
 	}
-	return errors.Trace(c.caller.FacadeCall("Prechecks", args, nil))
 }
 
 // Import takes a serialized model and imports it into the target
@@ -72,6 +106,16 @@ func (c *Client) Activate(modelUUID string) error {
 	return errors.Trace(c.caller.FacadeCall("Activate", args, nil))
 }
 
+// Reactivate makes a model that the target controller has been keeping
+// dormant since migrating it away ready to use again.
+func (c *Client) Reactivate(modelUUID string) error {
+	if c.caller.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("Reactivate")
+	}
+	args := params.ModelArgs{ModelTag: names.NewModelTag(modelUUID).String()}
+	return errors.Trace(c.caller.FacadeCall("Reactivate", args, nil))
+}
+
 // UploadCharm sends the content to the API server using an HTTP post in order
 // to add the charm binary to the model specified.
 func (c *Client) UploadCharm(modelUUID string, curl *charm.URL, content io.ReadSeeker) (*charm.URL, error) {
@@ -78,6 +78,123 @@ func (s *ClientSuite) TestPrechecks(c *gc.C) {
 	})
 }
 
+func (s *ClientSuite) TestPrecheckReport(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, id, arg)
+			*(result.(*params.MigrationPrecheckReport)) = params.MigrationPrecheckReport{
+				Blockers: []string{"upgrade in progress"},
+			}
+			return nil
+		}),
+		BestVersion: 2,
+	}
+	client := migrationtarget.NewClient(apiCaller)
+
+	ownerTag := names.NewUserTag("owner")
+	vers := version.MustParse("1.2.3")
+	report, err := client.PrecheckReport(coremigration.ModelInfo{
+		UUID:         "uuid",
+		Owner:        ownerTag,
+		Name:         "name",
+		AgentVersion: vers,
+		CloudName:    "aws",
+		CloudRegion:  "us-east-1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report.Blockers, jc.DeepEquals, []string{"upgrade in progress"})
+
+	expectedArg := params.MigrationModelInfo{
+		UUID:         "uuid",
+		Name:         "name",
+		OwnerTag:     ownerTag.String(),
+		AgentVersion: vers,
+		CloudName:    "aws",
+		CloudRegion:  "us-east-1",
+	}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.PrecheckReport", []interface{}{"", expectedArg}},
+	})
+}
+
+func (s *ClientSuite) TestPrecheckReportNotSupported(c *gc.C) {
+	client, stub := s.getClientAndStub(c)
+	_, err := client.PrecheckReport(coremigration.ModelInfo{UUID: "uuid"})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	stub.CheckNoCalls(c)
+}
+
+func (s *ClientSuite) TestRevertPrechecks(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, id, arg)
+			return errors.New("boom")
+		}),
+		BestVersion: 3,
+	}
+	client := migrationtarget.NewClient(apiCaller)
+
+	ownerTag := names.NewUserTag("owner")
+	vers := version.MustParse("1.2.3")
+	err := client.RevertPrechecks(coremigration.ModelInfo{
+		UUID:         "uuid",
+		Owner:        ownerTag,
+		Name:         "name",
+		AgentVersion: vers,
+	}, "fingerprint")
+	c.Assert(err, gc.ErrorMatches, "boom")
+
+	expectedArg := params.RevertPrechecksArgs{
+		Model: params.MigrationModelInfo{
+			UUID:         "uuid",
+			Name:         "name",
+			OwnerTag:     ownerTag.String(),
+			AgentVersion: vers,
+		},
+		Fingerprint: "fingerprint",
+	}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.RevertPrechecks", []interface{}{"", expectedArg}},
+	})
+}
+
+func (s *ClientSuite) TestRevertPrechecksNotSupported(c *gc.C) {
+	client, stub := s.getClientAndStub(c)
+	err := client.RevertPrechecks(coremigration.ModelInfo{UUID: "uuid"}, "")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	stub.CheckNoCalls(c)
+}
+
+func (s *ClientSuite) TestReactivate(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, id, arg)
+			return errors.New("boom")
+		}),
+		BestVersion: 3,
+	}
+	client := migrationtarget.NewClient(apiCaller)
+
+	uuid := "fake"
+	err := client.Reactivate(uuid)
+	c.Assert(err, gc.ErrorMatches, "boom")
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.Reactivate", []interface{}{"", params.ModelArgs{
+			ModelTag: names.NewModelTag(uuid).String(),
+		}}},
+	})
+}
+
+func (s *ClientSuite) TestReactivateNotSupported(c *gc.C) {
+	client, stub := s.getClientAndStub(c)
+	err := client.Reactivate("fake")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	stub.CheckNoCalls(c)
+}
+
 func (s *ClientSuite) TestImport(c *gc.C) {
 	client, stub := s.getClientAndStub(c)
 
@@ -284,6 +284,30 @@ func (c *Client) SetRemoteApplicationStatus(applicationName string, status statu
 	return results.OneError()
 }
 
+// SetRemoteApplicationDiagnostics records the cross model relation
+// diagnostics for the specified remote application.
+func (c *Client) SetRemoteApplicationDiagnostics(applicationName string, diagnostics crossmodel.RemoteApplicationDiagnostics) error {
+	if c.facade.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("recording remote application diagnostics")
+	}
+	args := params.SetRemoteApplicationsDiagnosticsArgs{Args: []params.SetRemoteApplicationDiagnosticsArg{{
+		Tag: names.NewApplicationTag(applicationName).String(),
+		Diagnostics: params.RemoteApplicationDiagnostics{
+			LastRemoteEvent:  diagnostics.LastRemoteEvent,
+			MacaroonExpiry:   diagnostics.MacaroonExpiry,
+			SettingsSyncLag:  diagnostics.SettingsSyncLag,
+			IngressAddresses: diagnostics.IngressAddresses,
+			Updated:          diagnostics.Updated,
+		},
+	}}}
+	var results params.ErrorResults
+	err := c.facade.FacadeCall("SetRemoteApplicationsDiagnostics", args, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
 // UpdateControllerForModel ensures that there is an external controller record
 // for the input info, associated with the input model ID.
 func (c *Client) UpdateControllerForModel(controller crossmodel.ControllerInfo, modelUUID string) error {
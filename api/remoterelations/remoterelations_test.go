@@ -4,6 +4,9 @@
 package remoterelations_test
 
 import (
+	"time"
+
+	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -426,6 +429,61 @@ func (s *remoteRelationsSuite) TestSetRemoteApplicationStatus(c *gc.C) {
 	c.Check(callCount, gc.Equals, 1)
 }
 
+func (s *remoteRelationsSuite) TestSetRemoteApplicationDiagnostics(c *gc.C) {
+	lastEvent := time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC)
+	var callCount int
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "RemoteRelations")
+			c.Check(version, gc.Equals, 3)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "SetRemoteApplicationsDiagnostics")
+			c.Assert(arg, jc.DeepEquals, params.SetRemoteApplicationsDiagnosticsArgs{
+				Args: []params.SetRemoteApplicationDiagnosticsArg{{
+					Tag: names.NewApplicationTag("mysql").String(),
+					Diagnostics: params.RemoteApplicationDiagnostics{
+						LastRemoteEvent:  &lastEvent,
+						SettingsSyncLag:  time.Second,
+						IngressAddresses: []string{"10.0.0.1"},
+						Updated:          lastEvent,
+					},
+				}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{
+					Error: &params.Error{Message: "FAIL"},
+				}},
+			}
+			callCount++
+			return nil
+		}),
+		BestVersion: 3,
+	}
+	client := remoterelations.NewClient(apiCaller)
+	err := client.SetRemoteApplicationDiagnostics("mysql", crossmodel.RemoteApplicationDiagnostics{
+		LastRemoteEvent:  &lastEvent,
+		SettingsSyncLag:  time.Second,
+		IngressAddresses: []string{"10.0.0.1"},
+		Updated:          lastEvent,
+	})
+	c.Check(err, gc.ErrorMatches, "FAIL")
+	c.Check(callCount, gc.Equals, 1)
+}
+
+func (s *remoteRelationsSuite) TestSetRemoteApplicationDiagnosticsNotSupported(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		}),
+		BestVersion: 2,
+	}
+	client := remoterelations.NewClient(apiCaller)
+	err := client.SetRemoteApplicationDiagnostics("mysql", crossmodel.RemoteApplicationDiagnostics{})
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 type facadeCallFunc = func(objType string, version int, id, request string, arg, result interface{}) error
 
 func (s *remoteRelationsSuite) TestUpdateControllerForModelResultCount(c *gc.C) {
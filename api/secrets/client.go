@@ -0,0 +1,50 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package secrets provides a client for the Secrets facade, used to
+// inspect and rotate charm secrets.
+package secrets
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client is the api client for the Secrets facade.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a secrets api client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "Secrets")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// ListSecrets lists the secrets in the model, optionally including
+// their latest values.
+func (c *Client) ListSecrets(showSecrets bool) ([]params.ListSecretResult, error) {
+	arg := params.ListSecretsArgs{
+		ShowSecrets: showSecrets,
+	}
+	var response params.ListSecretResults
+	if err := c.facade.FacadeCall("ListSecrets", arg, &response); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return response.Results, nil
+}
+
+// RotateSecret asks the owner of the secret to rotate it now.
+func (c *Client) RotateSecret(uri string) error {
+	arg := params.SecretURIArgs{
+		URIs: []string{uri},
+	}
+	var response params.ErrorResults
+	if err := c.facade.FacadeCall("RotateSecrets", arg, &response); err != nil {
+		return errors.Trace(err)
+	}
+	return response.OneError()
+}
@@ -0,0 +1,88 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/secrets"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&SecretsSuite{})
+
+type SecretsSuite struct {
+	coretesting.BaseSuite
+}
+
+func (s *SecretsSuite) TestNewClient(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return nil
+	})
+	client := secrets.NewClient(apiCaller)
+	c.Assert(client, gc.NotNil)
+}
+
+func (s *SecretsSuite) TestListSecrets(c *gc.C) {
+	now := time.Now()
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Secrets")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "ListSecrets")
+		c.Check(arg, jc.DeepEquals, params.ListSecretsArgs{ShowSecrets: true})
+		c.Assert(result, gc.FitsTypeOf, &params.ListSecretResults{})
+		*(result.(*params.ListSecretResults)) = params.ListSecretResults{
+			Results: []params.ListSecretResult{{
+				URI:            "secret:7fa6cc1c-3f6b-4a04-8b27-9f3a1b9b5e21",
+				OwnerTag:       "application-mariadb",
+				LatestRevision: 2,
+				RotatePolicy:   "never",
+				CreateTime:     now,
+				UpdateTime:     now,
+				Value: &params.SecretValueResult{
+					Data: map[string]string{"password": "secret"},
+				},
+			}},
+		}
+		return nil
+	})
+	client := secrets.NewClient(apiCaller)
+	result, err := client.ListSecrets(true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, []params.ListSecretResult{{
+		URI:            "secret:7fa6cc1c-3f6b-4a04-8b27-9f3a1b9b5e21",
+		OwnerTag:       "application-mariadb",
+		LatestRevision: 2,
+		RotatePolicy:   "never",
+		CreateTime:     now,
+		UpdateTime:     now,
+		Value: &params.SecretValueResult{
+			Data: map[string]string{"password": "secret"},
+		},
+	}})
+}
+
+func (s *SecretsSuite) TestRotateSecret(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Secrets")
+		c.Check(request, gc.Equals, "RotateSecrets")
+		c.Check(arg, jc.DeepEquals, params.SecretURIArgs{URIs: []string{"secret:7fa6cc1c-3f6b-4a04-8b27-9f3a1b9b5e21"}})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{
+				Error: &params.Error{Message: "boom"},
+			}},
+		}
+		return nil
+	})
+	client := secrets.NewClient(apiCaller)
+	err := client.RotateSecret("secret:7fa6cc1c-3f6b-4a04-8b27-9f3a1b9b5e21")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -82,6 +82,22 @@ func (c *Client) ListPools(providers, names []string) ([]params.StoragePool, err
 	return results.Results[0].Result, nil
 }
 
+// PoolUsage returns the storage allocated from each of the named pools.
+func (c *Client) PoolUsage(names []string) ([]params.StoragePoolUsageResult, error) {
+	if c.BestAPIVersion() < 8 {
+		return nil, errors.NotSupportedf("storage pool usage by this version of Juju")
+	}
+	args := params.StoragePoolUsageArgs{Pools: names}
+	var results params.StoragePoolUsageResults
+	if err := c.facade.FacadeCall("PoolUsage", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(names) {
+		return nil, errors.Errorf("expected %d results, got %d", len(names), len(results.Results))
+	}
+	return results.Results, nil
+}
+
 // CreatePool creates pool with specified parameters.
 func (c *Client) CreatePool(pname, provider string, attrs map[string]interface{}) error {
 	// Older facade did not support bulk calls.
@@ -237,6 +253,28 @@ func (c *Client) Attach(unitId string, storageIds []string) ([]params.ErrorResul
 	return out.Results, nil
 }
 
+// Resize requests that the specified storage instance be grown to
+// the given size, in MiB.
+func (c *Client) Resize(storageId string, size uint64) error {
+	if c.BestAPIVersion() < 7 {
+		return errors.New("resizing storage is not supported by this version of Juju")
+	}
+	if !names.IsValidStorage(storageId) {
+		return errors.NotValidf("storage ID %q", storageId)
+	}
+	args := params.ResizeStorageArgs{
+		Storage: []params.ResizeStorageParams{{
+			StorageTag: names.NewStorageTag(storageId).String(),
+			Size:       size,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("Resize", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
 // Remove removes the specified storage entities from the model,
 // optionally destroying them.
 func (c *Client) Remove(storageIds []string, destroyAttachments, destroyStorage bool, force *bool, maxWait *time.Duration) ([]params.ErrorResult, error) {
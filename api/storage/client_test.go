@@ -891,6 +891,47 @@ func (s *storageMockSuite) TestAttach(c *gc.C) {
 	c.Assert(results[1].Error, jc.DeepEquals, &params.Error{Message: "qux"})
 }
 
+func (s *storageMockSuite) TestResize(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "Resize")
+				c.Check(a, jc.DeepEquals, params.ResizeStorageArgs{[]params.ResizeStorageParams{{
+					StorageTag: "storage-bar-1",
+					Size:       2048,
+				}}})
+				c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+				results := result.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{
+					{Error: &params.Error{Message: "qux"}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 7,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.Resize("bar/1", 2048)
+	c.Check(err, gc.ErrorMatches, "qux")
+}
+
+func (s *storageMockSuite) TestResizeNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Fatalf("unexpected API call")
+				return nil
+			},
+		),
+		BestVersion: 6,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.Resize("bar/1", 2048)
+	c.Check(err, gc.ErrorMatches, "resizing storage is not supported by this version of Juju")
+}
+
 func (s *storageMockSuite) TestAttachArityMismatch(c *gc.C) {
 	apiCaller := basetesting.APICallerFunc(
 		func(objType string, version int, id, request string, a, result interface{}) error {
@@ -1077,3 +1118,46 @@ func (s *storageMockSuite) TestUpdatePoolFacadeCallError(c *gc.C) {
 	err := storageClient.UpdatePool("", "", nil)
 	c.Assert(errors.Cause(err), gc.ErrorMatches, msg)
 }
+
+func (s *storageMockSuite) TestPoolUsage(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "PoolUsage")
+				c.Check(a, jc.DeepEquals, params.StoragePoolUsageArgs{Pools: []string{"ceph", "missing"}})
+				c.Assert(result, gc.FitsTypeOf, &params.StoragePoolUsageResults{})
+				results := result.(*params.StoragePoolUsageResults)
+				results.Results = []params.StoragePoolUsageResult{
+					{Result: &params.StoragePoolUsage{Name: "ceph", MaxVolumes: 5}},
+					{Error: &params.Error{Message: "not found"}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 8,
+	}
+	client := storage.NewClient(apiCaller)
+	results, err := client.PoolUsage([]string{"ceph", "missing"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.StoragePoolUsageResult{
+		{Result: &params.StoragePoolUsage{Name: "ceph", MaxVolumes: 5}},
+		{Error: &params.Error{Message: "not found"}},
+	})
+}
+
+func (s *storageMockSuite) TestPoolUsageNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Fatalf("unexpected API call")
+				return nil
+			},
+		),
+		BestVersion: 7,
+	}
+	client := storage.NewClient(apiCaller)
+	_, err := client.PoolUsage([]string{"ceph"})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
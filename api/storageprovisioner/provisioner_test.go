@@ -6,6 +6,7 @@ package storageprovisioner_test
 import (
 	"errors"
 
+	jujuerrors "github.com/juju/errors"
 	"github.com/juju/names/v4"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -517,6 +518,61 @@ func (s *provisionerSuite) TestRemoveVolumeParams(c *gc.C) {
 	}})
 }
 
+func (s *provisionerSuite) TestVolumeResizeParams(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "StorageProvisioner")
+			c.Check(version, gc.Equals, 5)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "VolumeResizeParams")
+			c.Check(arg, gc.DeepEquals, params.Entities{Entities: []params.Entity{{Tag: "volume-100"}}})
+			c.Assert(result, gc.FitsTypeOf, &params.VolumeResizeParamsResults{})
+			*(result.(*params.VolumeResizeParamsResults)) = params.VolumeResizeParamsResults{
+				Results: []params.VolumeResizeParamsResult{{
+					Result: params.VolumeResizeParams{
+						VolumeTag: "volume-100",
+						VolumeId:  "bar",
+						Provider:  "foo",
+						Size:      2048,
+					},
+				}},
+			}
+			return nil
+		}),
+		BestVersion: 5,
+	}
+
+	st, err := storageprovisioner.NewState(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	resizeParams, err := st.VolumeResizeParams([]names.VolumeTag{names.NewVolumeTag("100")})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(resizeParams, jc.DeepEquals, []params.VolumeResizeParamsResult{{
+		Result: params.VolumeResizeParams{
+			VolumeTag: "volume-100",
+			VolumeId:  "bar",
+			Provider:  "foo",
+			Size:      2048,
+		},
+	}})
+}
+
+func (s *provisionerSuite) TestVolumeResizeParamsNotSupported(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		}),
+		BestVersion: 4,
+	}
+
+	st, err := storageprovisioner.NewState(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st.VolumeResizeParams([]names.VolumeTag{names.NewVolumeTag("100")})
+	c.Check(err, jc.Satisfies, jujuerrors.IsNotSupported)
+	_, err = st.WatchVolumeResizes(names.NewMachineTag("123"))
+	c.Check(err, jc.Satisfies, jujuerrors.IsNotSupported)
+}
+
 func (s *provisionerSuite) TestFilesystemParams(c *gc.C) {
 	var callCount int
 	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
@@ -0,0 +1,238 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/core/watcher"
+)
+
+// SecretUpsertArgs holds the attributes used when creating or updating
+// a secret. Nil attributes are left unchanged on update, and a non
+// empty value adds a new revision.
+type SecretUpsertArgs struct {
+	RotatePolicy *secrets.RotatePolicy
+	ExpireTime   *time.Time
+	Description  *string
+	Label        *string
+	Value        secrets.SecretData
+}
+
+func (st *State) checkSecretsSupported(method string) error {
+	if st.BestAPIVersion() < 18 {
+		return errors.NotImplementedf("%s() (need V18+)", method)
+	}
+	return nil
+}
+
+// CreateSecret creates a secret owned by the unit's application and
+// returns its URI.
+func (st *State) CreateSecret(args *SecretUpsertArgs) (string, error) {
+	if err := st.checkSecretsSupported("CreateSecret"); err != nil {
+		return "", err
+	}
+	arg := params.CreateSecretArg{
+		UnitTag:    st.unitTag.String(),
+		ExpireTime: args.ExpireTime,
+		Data:       args.Value,
+	}
+	if args.RotatePolicy != nil {
+		arg.RotatePolicy = string(*args.RotatePolicy)
+	}
+	if args.Description != nil {
+		arg.Description = *args.Description
+	}
+	if args.Label != nil {
+		arg.Label = *args.Label
+	}
+	var results params.StringResults
+	err := st.facade.FacadeCall("CreateSecrets", params.CreateSecretArgs{
+		Args: []params.CreateSecretArg{arg},
+	}, &results)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return "", err
+	}
+	return results.Results[0].Result, nil
+}
+
+// UpdateSecret updates a secret owned by the unit's application.
+func (st *State) UpdateSecret(uri string, args *SecretUpsertArgs) error {
+	if err := st.checkSecretsSupported("UpdateSecret"); err != nil {
+		return err
+	}
+	arg := params.UpdateSecretArg{
+		UnitTag:     st.unitTag.String(),
+		URI:         uri,
+		ExpireTime:  args.ExpireTime,
+		Description: args.Description,
+		Label:       args.Label,
+		Data:        args.Value,
+	}
+	if args.RotatePolicy != nil {
+		policy := string(*args.RotatePolicy)
+		arg.RotatePolicy = &policy
+	}
+	var results params.ErrorResults
+	err := st.facade.FacadeCall("UpdateSecrets", params.UpdateSecretArgs{
+		Args: []params.UpdateSecretArg{arg},
+	}, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// GetSecretValue returns the latest value of a secret.
+func (st *State) GetSecretValue(uri string) (secrets.SecretData, error) {
+	if err := st.checkSecretsSupported("GetSecretValue"); err != nil {
+		return nil, err
+	}
+	var results params.SecretValueResults
+	err := st.facade.FacadeCall("GetSecretValues", params.GetSecretValueArgs{
+		Args: []params.GetSecretValueArg{{
+			UnitTag: st.unitTag.String(),
+			URI:     uri,
+		}},
+	}, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, err
+	}
+	return results.Results[0].Data, nil
+}
+
+// GrantSecret gives an application or unit access to a secret owned
+// by the unit's application.
+func (st *State) GrantSecret(uri string, subject names.Tag) error {
+	return st.grantRevokeSecret("SecretsGrant", uri, subject)
+}
+
+// RevokeSecret removes an application or unit's access to a secret
+// owned by the unit's application.
+func (st *State) RevokeSecret(uri string, subject names.Tag) error {
+	return st.grantRevokeSecret("SecretsRevoke", uri, subject)
+}
+
+func (st *State) grantRevokeSecret(method, uri string, subject names.Tag) error {
+	if err := st.checkSecretsSupported(method); err != nil {
+		return err
+	}
+	var results params.ErrorResults
+	err := st.facade.FacadeCall(method, params.GrantRevokeSecretArgs{
+		Args: []params.GrantRevokeSecretArg{{
+			UnitTag:     st.unitTag.String(),
+			URI:         uri,
+			SubjectTags: []string{subject.String()},
+			Role:        string(secrets.RoleView),
+		}},
+	}, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// WatchSecretsRotationChanges returns a watcher notifying of the URIs
+// of secrets owned by the unit's application whose rotation schedule
+// has changed.
+func (st *State) WatchSecretsRotationChanges() (watcher.StringsWatcher, error) {
+	if err := st.checkSecretsSupported("WatchSecretsRotationChanges"); err != nil {
+		return nil, err
+	}
+	var results params.StringsWatchResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: st.unitTag.String()}},
+	}
+	err := st.facade.FacadeCall("WatchSecretsRotationChanges", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewStringsWatcher(st.facade.RawAPICaller(), result)
+	return w, nil
+}
+
+// SecretsRotationInfo returns when each of the given secrets, owned by
+// the unit's application, is next due to be rotated.
+func (st *State) SecretsRotationInfo(uris []string) ([]secrets.RotationInfo, error) {
+	if err := st.checkSecretsSupported("SecretsRotationInfo"); err != nil {
+		return nil, err
+	}
+	args := params.GetSecretValueArgs{
+		Args: make([]params.GetSecretValueArg, len(uris)),
+	}
+	for i, uri := range uris {
+		args.Args[i] = params.GetSecretValueArg{
+			UnitTag: st.unitTag.String(),
+			URI:     uri,
+		}
+	}
+	var results params.SecretRotationInfoResults
+	err := st.facade.FacadeCall("SecretsRotationInfo", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(uris) {
+		return nil, errors.Errorf("expected %d results, got %d", len(uris), len(results.Results))
+	}
+	info := make([]secrets.RotationInfo, len(uris))
+	for i, result := range results.Results {
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		uri, err := secrets.ParseURI(result.Result.URI)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		info[i] = secrets.RotationInfo{
+			URI:            uri,
+			RotatePolicy:   secrets.RotatePolicy(result.Result.RotatePolicy),
+			NextRotateTime: result.Result.NextRotateTime,
+		}
+	}
+	return info, nil
+}
+
+// SecretRotated records that the secret was rotated at the given time.
+func (st *State) SecretRotated(uri string, when time.Time) error {
+	if err := st.checkSecretsSupported("SecretRotated"); err != nil {
+		return err
+	}
+	var results params.ErrorResults
+	err := st.facade.FacadeCall("SecretsRotated", params.SecretRotatedArgs{
+		Args: []params.SecretRotatedArg{{
+			UnitTag: st.unitTag.String(),
+			URI:     uri,
+			When:    when,
+		}},
+	}, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
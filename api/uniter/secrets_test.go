@@ -0,0 +1,193 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/uniter"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/secrets"
+	coretesting "github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&secretsSuite{})
+
+type secretsSuite struct {
+	coretesting.BaseSuite
+}
+
+func (s *secretsSuite) newState(c *gc.C, request string, expectedArg, response interface{}) *uniter.State {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, req string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Uniter")
+		c.Check(version, gc.Equals, 18)
+		c.Check(id, gc.Equals, "")
+		c.Check(req, gc.Equals, request)
+		c.Check(arg, jc.DeepEquals, expectedArg)
+		switch r := result.(type) {
+		case *params.StringResults:
+			*r = response.(params.StringResults)
+		case *params.ErrorResults:
+			*r = response.(params.ErrorResults)
+		case *params.SecretValueResults:
+			*r = response.(params.SecretValueResults)
+		case *params.SecretRotationInfoResults:
+			*r = response.(params.SecretRotationInfoResults)
+		default:
+			c.Fatalf("unexpected result type %T", result)
+		}
+		return nil
+	})
+	caller := testing.BestVersionCaller{apiCaller, 18}
+	return uniter.NewState(caller, names.NewUnitTag("mariadb/0"))
+}
+
+func (s *secretsSuite) TestCreateSecret(c *gc.C) {
+	policy := secrets.RotateDaily
+	description := "my secret"
+	expire := time.Now()
+	st := s.newState(c, "CreateSecrets", params.CreateSecretArgs{
+		Args: []params.CreateSecretArg{{
+			UnitTag:      "unit-mariadb-0",
+			RotatePolicy: "daily",
+			ExpireTime:   &expire,
+			Description:  "my secret",
+			Data:         map[string]string{"password": "secret"},
+		}},
+	}, params.StringResults{
+		Results: []params.StringResult{{Result: "secret:7fa6cc1c-3f6b-4a04-8b27-9f3a1b9b5e21"}},
+	})
+	uri, err := st.CreateSecret(&uniter.SecretUpsertArgs{
+		RotatePolicy: &policy,
+		ExpireTime:   &expire,
+		Description:  &description,
+		Value:        secrets.SecretData{"password": "secret"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(uri, gc.Equals, "secret:7fa6cc1c-3f6b-4a04-8b27-9f3a1b9b5e21")
+}
+
+func (s *secretsSuite) TestUpdateSecret(c *gc.C) {
+	policy := secrets.RotateNever
+	never := "never"
+	st := s.newState(c, "UpdateSecrets", params.UpdateSecretArgs{
+		Args: []params.UpdateSecretArg{{
+			UnitTag:      "unit-mariadb-0",
+			URI:          "secret:7fa6cc1c-3f6b-4a04-8b27-9f3a1b9b5e21",
+			RotatePolicy: &never,
+			Data:         map[string]string{"password": "another"},
+		}},
+	}, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+	err := st.UpdateSecret("secret:7fa6cc1c-3f6b-4a04-8b27-9f3a1b9b5e21", &uniter.SecretUpsertArgs{
+		RotatePolicy: &policy,
+		Value:        secrets.SecretData{"password": "another"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *secretsSuite) TestGetSecretValue(c *gc.C) {
+	st := s.newState(c, "GetSecretValues", params.GetSecretValueArgs{
+		Args: []params.GetSecretValueArg{{
+			UnitTag: "unit-mariadb-0",
+			URI:     "secret:7fa6cc1c-3f6b-4a04-8b27-9f3a1b9b5e21",
+		}},
+	}, params.SecretValueResults{
+		Results: []params.SecretValueResult{{
+			Data: map[string]string{"password": "secret"},
+		}},
+	})
+	value, err := st.GetSecretValue("secret:7fa6cc1c-3f6b-4a04-8b27-9f3a1b9b5e21")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value, jc.DeepEquals, secrets.SecretData{"password": "secret"})
+}
+
+func (s *secretsSuite) TestGrantSecret(c *gc.C) {
+	st := s.newState(c, "SecretsGrant", params.GrantRevokeSecretArgs{
+		Args: []params.GrantRevokeSecretArg{{
+			UnitTag:     "unit-mariadb-0",
+			URI:         "secret:7fa6cc1c-3f6b-4a04-8b27-9f3a1b9b5e21",
+			SubjectTags: []string{"application-wordpress"},
+			Role:        "view",
+		}},
+	}, params.ErrorResults{
+		Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+	})
+	err := st.GrantSecret("secret:7fa6cc1c-3f6b-4a04-8b27-9f3a1b9b5e21", names.NewApplicationTag("wordpress"))
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *secretsSuite) TestRevokeSecret(c *gc.C) {
+	st := s.newState(c, "SecretsRevoke", params.GrantRevokeSecretArgs{
+		Args: []params.GrantRevokeSecretArg{{
+			UnitTag:     "unit-mariadb-0",
+			URI:         "secret:7fa6cc1c-3f6b-4a04-8b27-9f3a1b9b5e21",
+			SubjectTags: []string{"unit-wordpress-0"},
+			Role:        "view",
+		}},
+	}, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+	err := st.RevokeSecret("secret:7fa6cc1c-3f6b-4a04-8b27-9f3a1b9b5e21", names.NewUnitTag("wordpress/0"))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *secretsSuite) TestSecretsRotationInfo(c *gc.C) {
+	next := time.Now()
+	st := s.newState(c, "SecretsRotationInfo", params.GetSecretValueArgs{
+		Args: []params.GetSecretValueArg{{
+			UnitTag: "unit-mariadb-0",
+			URI:     "secret:7fa6cc1c-3f6b-4a04-8b27-9f3a1b9b5e21",
+		}},
+	}, params.SecretRotationInfoResults{
+		Results: []params.SecretRotationInfoResult{{
+			Result: &params.SecretRotationInfo{
+				URI:            "secret:7fa6cc1c-3f6b-4a04-8b27-9f3a1b9b5e21",
+				RotatePolicy:   "hourly",
+				NextRotateTime: &next,
+			},
+		}},
+	})
+	info, err := st.SecretsRotationInfo([]string{"secret:7fa6cc1c-3f6b-4a04-8b27-9f3a1b9b5e21"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, []secrets.RotationInfo{{
+		URI:            &secrets.URI{ID: "7fa6cc1c-3f6b-4a04-8b27-9f3a1b9b5e21"},
+		RotatePolicy:   secrets.RotateHourly,
+		NextRotateTime: &next,
+	}})
+}
+
+func (s *secretsSuite) TestSecretRotated(c *gc.C) {
+	now := time.Now()
+	st := s.newState(c, "SecretsRotated", params.SecretRotatedArgs{
+		Args: []params.SecretRotatedArg{{
+			UnitTag: "unit-mariadb-0",
+			URI:     "secret:7fa6cc1c-3f6b-4a04-8b27-9f3a1b9b5e21",
+			When:    now,
+		}},
+	}, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+	err := st.SecretRotated("secret:7fa6cc1c-3f6b-4a04-8b27-9f3a1b9b5e21", now)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *secretsSuite) TestSecretsNeedV18(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
+		c.Fatalf("unexpected api call")
+		return nil
+	})
+	caller := testing.BestVersionCaller{apiCaller, 17}
+	st := uniter.NewState(caller, names.NewUnitTag("mariadb/0"))
+	_, err := st.GetSecretValue("secret:7fa6cc1c-3f6b-4a04-8b27-9f3a1b9b5e21")
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+	c.Assert(err, gc.ErrorMatches, `GetSecretValue\(\) \(need V18\+\) not implemented`)
+}
@@ -4,6 +4,8 @@
 package uniter
 
 import (
+	"time"
+
 	"github.com/juju/charm/v7"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
@@ -11,6 +13,7 @@ import (
 	"github.com/juju/juju/api/common"
 	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/status"
@@ -654,6 +657,153 @@ func (u *Unit) LogActionMessage(tag names.ActionTag, message string) error {
 	return result.OneError()
 }
 
+// RecordHookExecution adds a hook execution to the unit's hook history.
+func (u *Unit) RecordHookExecution(execution status.HookExecution) error {
+	// Older controllers don't keep hook history.
+	if u.st.facade.BestAPIVersion() < 17 {
+		return errors.NotImplementedf("RecordHookExecution() (need V17+)")
+	}
+
+	var result params.ErrorResults
+	args := params.HookExecutionArgs{
+		Args: []params.HookExecutionArg{{
+			Tag: u.tag.String(),
+			Execution: params.HookExecution{
+				Hook:     execution.Hook,
+				Started:  execution.Started,
+				Finished: execution.Finished,
+				Result:   string(execution.Result),
+				Message:  execution.Message,
+			},
+		}},
+	}
+	err := u.st.facade.FacadeCall("RecordHookExecutions", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
+// SetHealthCheck records the latest result of the named workload
+// health check for the unit.
+func (u *Unit) SetHealthCheck(check status.HealthCheck) error {
+	// Older controllers don't keep health checks.
+	if u.st.facade.BestAPIVersion() < 19 {
+		return errors.NotImplementedf("SetHealthCheck() (need V19+)")
+	}
+
+	var result params.ErrorResults
+	args := params.SetHealthCheckArgs{
+		Args: []params.SetHealthCheckArg{{
+			Tag:     u.tag.String(),
+			Name:    check.Name,
+			Status:  string(check.Status),
+			Message: check.Message,
+		}},
+	}
+	err := u.st.facade.FacadeCall("SetHealthChecks", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
+// TraceUntil returns the time until which the hook tool calls made by
+// the unit's charm are traced, and whether their output is traced. A
+// zero time means the unit is not traced.
+func (u *Unit) TraceUntil() (until time.Time, includeOutput bool, err error) {
+	// Older controllers can't trace units.
+	if u.st.facade.BestAPIVersion() < 20 {
+		return time.Time{}, false, nil
+	}
+
+	var results params.UnitTraceResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err = u.st.facade.FacadeCall("TraceUntil", args, &results)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	if len(results.Results) != 1 {
+		return time.Time{}, false, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return time.Time{}, false, result.Error
+	}
+	return result.Until, result.IncludeOutput, nil
+}
+
+// ResourceLimits returns the resources the unit's agent, charm
+// workload and hooks may use. Older controllers don't support resource
+// limits, so nothing is limited.
+func (u *Unit) ResourceLimits() (application.ResourceLimits, error) {
+	if u.st.facade.BestAPIVersion() < 21 {
+		return application.ResourceLimits{}, nil
+	}
+
+	var results params.ResourceLimitsResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("ResourceLimits", args, &results)
+	if err != nil {
+		return application.ResourceLimits{}, err
+	}
+	if len(results.Results) != 1 {
+		return application.ResourceLimits{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return application.ResourceLimits{}, result.Error
+	}
+	return application.ResourceLimits{
+		MemoryMB:  result.Result.MemoryMB,
+		CPUShares: result.Result.CPUShares,
+		Tasks:     result.Result.Tasks,
+	}, nil
+}
+
+// WatchResourceLimits returns a watcher that notifies when the
+// application config, which holds the unit's resource limits, changes.
+func (u *Unit) WatchResourceLimits() (watcher.NotifyWatcher, error) {
+	if u.st.facade.BestAPIVersion() < 21 {
+		return nil, errors.NotImplementedf("WatchResourceLimits() (need V21+)")
+	}
+	return common.Watch(u.st.facade, "WatchResourceLimits", u.tag)
+}
+
+// MaintenanceWindow returns the maintenance window of the unit's
+// application, and when the hooks it deferred were last flushed. Older
+// controllers don't support maintenance windows, so there is none.
+func (u *Unit) MaintenanceWindow() (application.MaintenanceWindow, time.Time, error) {
+	if u.st.facade.BestAPIVersion() < 22 {
+		return application.MaintenanceWindow{}, time.Time{}, nil
+	}
+
+	var results params.MaintenanceWindowResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("MaintenanceWindow", args, &results)
+	if err != nil {
+		return application.MaintenanceWindow{}, time.Time{}, err
+	}
+	if len(results.Results) != 1 {
+		return application.MaintenanceWindow{}, time.Time{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return application.MaintenanceWindow{}, time.Time{}, result.Error
+	}
+	window := application.MaintenanceWindow{
+		Schedule: result.Result.Schedule,
+		Duration: result.Result.Duration,
+	}
+	return window, result.Result.Flushed, nil
+}
+
 // UpgradeSeriesStatus returns the upgrade series status of a unit from remote state
 func (u *Unit) UpgradeSeriesStatus() (model.UpgradeSeriesStatus, error) {
 	res, err := u.st.UpgradeSeriesUnitStatus()
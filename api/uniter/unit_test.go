@@ -17,6 +17,7 @@ import (
 	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/status"
@@ -100,6 +101,230 @@ func (s *unitSuite) TestSetUnitStatus(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "biff")
 }
 
+func (s *unitSuite) TestRecordHookExecution(c *gc.C) {
+	started := time.Date(2020, 7, 1, 10, 0, 0, 0, time.UTC)
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Assert(objType, gc.Equals, "Uniter")
+		c.Assert(request, gc.Equals, "RecordHookExecutions")
+		c.Assert(arg, gc.DeepEquals, params.HookExecutionArgs{
+			Args: []params.HookExecutionArg{{
+				Tag: "unit-mysql-0",
+				Execution: params.HookExecution{
+					Hook:     "install",
+					Started:  started,
+					Finished: started.Add(time.Minute),
+					Result:   "failed",
+					Message:  "exit status 1",
+				},
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{&params.Error{Message: "biff"}}},
+		}
+		return nil
+	})
+	caller := basetesting.BestVersionCaller{apiCaller, 17}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+
+	unit := uniter.CreateUnit(client, names.NewUnitTag("mysql/0"))
+	err := unit.RecordHookExecution(status.HookExecution{
+		Hook:     "install",
+		Started:  started,
+		Finished: started.Add(time.Minute),
+		Result:   status.HookFailed,
+		Message:  "exit status 1",
+	})
+	c.Assert(err, gc.ErrorMatches, "biff")
+}
+
+func (s *unitSuite) TestRecordHookExecutionPriorV17(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected api call %q", request)
+		return nil
+	})
+	caller := basetesting.BestVersionCaller{apiCaller, 16}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+
+	unit := uniter.CreateUnit(client, names.NewUnitTag("mysql/0"))
+	err := unit.RecordHookExecution(status.HookExecution{Hook: "install"})
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
+func (s *unitSuite) TestSetHealthCheck(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Assert(objType, gc.Equals, "Uniter")
+		c.Assert(request, gc.Equals, "SetHealthChecks")
+		c.Assert(arg, gc.DeepEquals, params.SetHealthCheckArgs{
+			Args: []params.SetHealthCheckArg{{
+				Tag:     "unit-mysql-0",
+				Name:    "replication",
+				Status:  "warn",
+				Message: "lagging",
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{&params.Error{Message: "biff"}}},
+		}
+		return nil
+	})
+	caller := basetesting.BestVersionCaller{apiCaller, 19}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+
+	unit := uniter.CreateUnit(client, names.NewUnitTag("mysql/0"))
+	err := unit.SetHealthCheck(status.HealthCheck{
+		Name:    "replication",
+		Status:  status.HealthWarn,
+		Message: "lagging",
+	})
+	c.Assert(err, gc.ErrorMatches, "biff")
+}
+
+func (s *unitSuite) TestSetHealthCheckPriorV19(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected api call %q", request)
+		return nil
+	})
+	caller := basetesting.BestVersionCaller{apiCaller, 18}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+
+	unit := uniter.CreateUnit(client, names.NewUnitTag("mysql/0"))
+	err := unit.SetHealthCheck(status.HealthCheck{Name: "replication", Status: status.HealthPass})
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
+func (s *unitSuite) TestTraceUntil(c *gc.C) {
+	until := time.Date(2020, 7, 1, 10, 10, 0, 0, time.UTC)
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Assert(objType, gc.Equals, "Uniter")
+		c.Assert(request, gc.Equals, "TraceUntil")
+		c.Assert(arg, gc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "unit-mysql-0"}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.UnitTraceResults{})
+		*(result.(*params.UnitTraceResults)) = params.UnitTraceResults{
+			Results: []params.UnitTraceResult{{Until: until, IncludeOutput: true}},
+		}
+		return nil
+	})
+	caller := basetesting.BestVersionCaller{apiCaller, 20}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+
+	unit := uniter.CreateUnit(client, names.NewUnitTag("mysql/0"))
+	result, includeOutput, err := unit.TraceUntil()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.Equals, until)
+	c.Assert(includeOutput, jc.IsTrue)
+}
+
+func (s *unitSuite) TestTraceUntilPriorV20(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected api call %q", request)
+		return nil
+	})
+	caller := basetesting.BestVersionCaller{apiCaller, 19}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+
+	unit := uniter.CreateUnit(client, names.NewUnitTag("mysql/0"))
+	result, includeOutput, err := unit.TraceUntil()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.IsZero(), jc.IsTrue)
+	c.Assert(includeOutput, jc.IsFalse)
+}
+
+func (s *unitSuite) TestResourceLimits(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Assert(objType, gc.Equals, "Uniter")
+		c.Assert(request, gc.Equals, "ResourceLimits")
+		c.Assert(arg, gc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "unit-mysql-0"}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ResourceLimitsResults{})
+		*(result.(*params.ResourceLimitsResults)) = params.ResourceLimitsResults{
+			Results: []params.ResourceLimitsResult{{
+				Result: params.ResourceLimits{MemoryMB: 512, Tasks: 100},
+			}},
+		}
+		return nil
+	})
+	caller := basetesting.BestVersionCaller{apiCaller, 21}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+
+	unit := uniter.CreateUnit(client, names.NewUnitTag("mysql/0"))
+	limits, err := unit.ResourceLimits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(limits, gc.Equals, application.ResourceLimits{MemoryMB: 512, Tasks: 100})
+}
+
+func (s *unitSuite) TestResourceLimitsPriorV21(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected api call %q", request)
+		return nil
+	})
+	caller := basetesting.BestVersionCaller{apiCaller, 20}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+
+	unit := uniter.CreateUnit(client, names.NewUnitTag("mysql/0"))
+	limits, err := unit.ResourceLimits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(limits.IsZero(), jc.IsTrue)
+}
+
+func (s *unitSuite) TestWatchResourceLimitsPriorV21(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected api call %q", request)
+		return nil
+	})
+	caller := basetesting.BestVersionCaller{apiCaller, 20}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+
+	unit := uniter.CreateUnit(client, names.NewUnitTag("mysql/0"))
+	_, err := unit.WatchResourceLimits()
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
+func (s *unitSuite) TestMaintenanceWindow(c *gc.C) {
+	flushed := time.Date(2020, 7, 1, 2, 30, 0, 0, time.UTC)
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Assert(objType, gc.Equals, "Uniter")
+		c.Assert(request, gc.Equals, "MaintenanceWindow")
+		c.Assert(arg, gc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "unit-mysql-0"}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.MaintenanceWindowResults{})
+		*(result.(*params.MaintenanceWindowResults)) = params.MaintenanceWindowResults{
+			Results: []params.MaintenanceWindowResult{{
+				Result: params.MaintenanceWindow{Schedule: "0 2 * * *", Duration: time.Hour, Flushed: flushed},
+			}},
+		}
+		return nil
+	})
+	caller := basetesting.BestVersionCaller{apiCaller, 22}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+
+	unit := uniter.CreateUnit(client, names.NewUnitTag("mysql/0"))
+	window, gotFlushed, err := unit.MaintenanceWindow()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(window, gc.Equals, application.MaintenanceWindow{Schedule: "0 2 * * *", Duration: time.Hour})
+	c.Assert(gotFlushed, gc.Equals, flushed)
+}
+
+func (s *unitSuite) TestMaintenanceWindowPriorV22(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected api call %q", request)
+		return nil
+	})
+	caller := basetesting.BestVersionCaller{apiCaller, 21}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+
+	unit := uniter.CreateUnit(client, names.NewUnitTag("mysql/0"))
+	window, flushed, err := unit.MaintenanceWindow()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(window.IsZero(), jc.IsTrue)
+	c.Assert(flushed.IsZero(), jc.IsTrue)
+}
+
 func (s *unitSuite) TestUnitStatus(c *gc.C) {
 	now := time.Now()
 	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
@@ -21,6 +21,7 @@ import (
 	"github.com/juju/juju/apiserver/observer"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/auditlog"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/rpc"
@@ -317,16 +318,29 @@ func (a *admin) maybeEmitRedirectError(modelUUID string, authTag names.Tag) erro
 	}
 	defer func() { _ = st.Release() }()
 
-	// If the model exists on this controller then no redirect is possible.
-	if _, err := st.Model(); err == nil || !errors.IsNotFound(err) {
+	var mig state.ModelMigration
+	if _, err := st.Model(); err == nil {
+		// If the model exists on this controller then no redirect is
+		// possible, unless the model is only being kept dormant after
+		// being migrated to another controller.
+		mig, err = st.LatestMigration()
+		if errors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if phase, err := mig.Phase(); err != nil || phase != coremigration.DORMANT {
+			return errors.Trace(err)
+		}
+	} else if !errors.IsNotFound(err) {
 		return nil
-	}
-
-	// Check if the model was not found due to
-	// being migrated to another controller.
-	mig, err := st.CompletedMigration()
-	if err != nil && !errors.IsNotFound(err) {
-		return errors.Trace(err)
+	} else {
+		// Check if the model was not found due to
+		// being migrated to another controller.
+		mig, err = st.CompletedMigration()
+		if err != nil && !errors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
 	}
 
 	// If a user is trying to access a migrated model to which they are not
@@ -623,6 +623,63 @@ func (s *loginSuite) TestMigratedModelLogin(c *gc.C) {
 	c.Assert(ok, gc.Equals, true)
 }
 
+func (s *loginSuite) TestDormantModelLogin(c *gc.C) {
+	modelOwner := s.Factory.MakeUser(c, &factory.UserParams{
+		Password: "secret",
+	})
+	modelState := s.Factory.MakeModel(c, &factory.ModelParams{
+		Owner: modelOwner.UserTag(),
+	})
+	defer modelState.Close()
+	model, err := modelState.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	controllerTag := names.NewControllerTag(utils.MustNewUUID().String())
+
+	// Migrate the model, keeping it dormant on this controller.
+	mig, err := modelState.CreateMigration(state.MigrationSpec{
+		InitiatedBy: names.NewUserTag("admin"),
+		TargetInfo: migration.TargetInfo{
+			ControllerTag:   controllerTag,
+			ControllerAlias: "target",
+			Addrs:           []string{"1.2.3.4:5555"},
+			CACert:          coretesting.CACert,
+			AuthTag:         names.NewUserTag("user2"),
+			Password:        "secret",
+		},
+		KeepSource: time.Hour,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	for _, phase := range []migration.Phase{
+		migration.IMPORT,
+		migration.PROCESSRELATIONS,
+		migration.VALIDATION,
+		migration.SUCCESS,
+		migration.LOGTRANSFER,
+		migration.DORMANT,
+	} {
+		c.Assert(mig.SetPhase(phase), jc.ErrorIsNil)
+	}
+
+	info := s.newServer(c)
+	info.ModelTag = model.ModelTag()
+
+	// Users of the dormant model are redirected to the controller
+	// it was migrated to.
+	info.Tag = modelOwner.Tag()
+	info.Password = "secret"
+	_, err = api.Open(info, fastDialOpts)
+	redirErr, ok := errors.Cause(err).(*api.RedirectError)
+	c.Assert(ok, gc.Equals, true)
+	c.Assert(redirErr.ControllerTag, gc.Equals, controllerTag)
+
+	// Once the migration is reverted, they can use the model again.
+	c.Assert(mig.SetPhase(migration.REVERTED), jc.ErrorIsNil)
+	st, err := api.Open(info, fastDialOpts)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(st.Close(), jc.ErrorIsNil)
+}
+
 func (s *loginSuite) TestAnonymousModelLogin(c *gc.C) {
 	info := s.newServer(c)
 	conn := s.openAPIWithoutLogin(c, info)
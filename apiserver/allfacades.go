@@ -67,6 +67,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/modelmanager" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/payloads"
 	"github.com/juju/juju/apiserver/facades/client/resources"
+	"github.com/juju/juju/apiserver/facades/client/secrets"
 	"github.com/juju/juju/apiserver/facades/client/spaces"    // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/sshclient" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/storage"
@@ -156,9 +157,16 @@ func AllFacades() *facade.Registry {
 	reg("Application", 10, application.NewFacadeV10) // --force and --no-wait parameters
 	reg("Application", 11, application.NewFacadeV11) // Get call returns the endpoint bindings
 	reg("Application", 12, application.NewFacadeV12) // Adds UnitsInfo()
+	reg("Application", 13, application.NewFacadeV13) // Adds ConsumedApplicationsInfo()
+	reg("Application", 14, application.NewFacadeV14) // Adds UnitsHookHistory()
+	reg("Application", 15, application.NewFacadeV15) // Adds SetUnitsTrace()
+	reg("Application", 16, application.NewFacadeV16) // Adds ResolveUnitErrors filters
+	reg("Application", 17, application.NewFacadeV17) // Adds FlushDeferredHooks()
 
 	reg("ApplicationOffers", 1, applicationoffers.NewOffersAPI)
 	reg("ApplicationOffers", 2, applicationoffers.NewOffersAPIV2)
+	reg("ApplicationOffers", 3, applicationoffers.NewOffersAPIV3) // Adds CreateOfferInvitations
+	reg("ApplicationOffers", 4, applicationoffers.NewOffersAPIV4) // Adds SetOfferLimits
 	reg("ApplicationScaler", 1, applicationscaler.NewAPI)
 	reg("Backups", 1, backups.NewFacade)
 	reg("Backups", 2, backups.NewFacadeV2)
@@ -199,8 +207,15 @@ func AllFacades() *facade.Registry {
 	reg("Controller", 7, controller.NewControllerAPIv7)
 	reg("Controller", 8, controller.NewControllerAPIv8)
 	reg("Controller", 9, controller.NewControllerAPIv9)
+	reg("Controller", 10, controller.NewControllerAPIv10) // Adds MigrationPrechecks
+	reg("Controller", 11, controller.NewControllerAPIv11) // Adds ExportModel
+	reg("Controller", 12, controller.NewControllerAPIv12) // Adds ModelMigrations
+	reg("Controller", 13, controller.NewControllerAPIv13) // Adds keep-source and revert migration options
+	reg("Controller", 14, controller.NewControllerAPIv14) // Adds EnableMaintenanceMode and DisableMaintenanceMode
 	reg("CrossModelRelations", 1, crossmodelrelations.NewStateCrossModelRelationsAPIV1)
-	reg("CrossModelRelations", 2, crossmodelrelations.NewStateCrossModelRelationsAPI) // Adds WatchRelationChanges, removes WatchRelationUnits
+	reg("CrossModelRelations", 2, crossmodelrelations.NewStateCrossModelRelationsAPIV2) // Adds WatchRelationChanges, removes WatchRelationUnits
+	reg("CrossModelRelations", 3, crossmodelrelations.NewStateCrossModelRelationsAPIV3) // Adds RedeemOfferInvitations
+	reg("CrossModelRelations", 4, crossmodelrelations.NewStateCrossModelRelationsAPI)   // Adds CheckOfferConnections
 	reg("CrossController", 1, crosscontroller.NewStateCrossControllerAPI)
 	reg("CredentialManager", 1, credentialmanager.NewCredentialManagerAPI)
 	reg("CredentialValidator", 1, credentialvalidator.NewCredentialValidatorAPIv1)
@@ -246,7 +261,8 @@ func AllFacades() *facade.Registry {
 	reg("Machiner", 1, machine.NewMachinerAPIV1)
 	reg("Machiner", 2, machine.NewMachinerAPIV2) // Adds RecordAgentStartTime.
 	reg("Machiner", 3, machine.NewMachinerAPIV3) // Relies on agent-set origin in SetObservedNetworkConfig.
-	reg("Machiner", 4, machine.NewMachinerAPI)   // Removes SetProviderNetworkConfig.
+	reg("Machiner", 4, machine.NewMachinerAPIV4) // Removes SetProviderNetworkConfig.
+	reg("Machiner", 5, machine.NewMachinerAPI)   // Adds Status.
 
 	reg("MeterStatus", 1, meterstatus.NewMeterStatusFacadeV1)
 	reg("MeterStatus", 2, meterstatus.NewMeterStatusFacade)
@@ -258,7 +274,9 @@ func AllFacades() *facade.Registry {
 	reg("MigrationMaster", 1, migrationmaster.NewMigrationMasterFacade)
 	reg("MigrationMaster", 2, migrationmaster.NewMigrationMasterFacadeV2)
 	reg("MigrationMinion", 1, migrationminion.NewFacade)
-	reg("MigrationTarget", 1, migrationtarget.NewFacade)
+	reg("MigrationTarget", 1, migrationtarget.NewFacadeV1)
+	reg("MigrationTarget", 2, migrationtarget.NewFacadeV2) // Adds PrecheckReport
+	reg("MigrationTarget", 3, migrationtarget.NewFacade)   // Adds RevertPrechecks and Reactivate
 
 	reg("ModelConfig", 1, modelconfig.NewFacadeV1)
 	reg("ModelConfig", 2, modelconfig.NewFacadeV2)
@@ -297,13 +315,15 @@ func AllFacades() *facade.Registry {
 	reg("ProxyUpdater", 2, proxyupdater.NewFacadeV2)
 	reg("Reboot", 2, reboot.NewRebootAPI)
 	reg("RemoteRelations", 1, remoterelations.NewAPIv1)
-	reg("RemoteRelations", 2, remoterelations.NewAPI) // Adds UpdateControllersForModels and WatchLocalRelationChanges.
+	reg("RemoteRelations", 2, remoterelations.NewAPIv2) // Adds UpdateControllersForModels and WatchLocalRelationChanges.
+	reg("RemoteRelations", 3, remoterelations.NewAPI)   // Adds SetRemoteApplicationsDiagnostics.
 
 	reg("Resources", 1, resources.NewPublicFacade)
 	reg("ResourcesHookContext", 1, resourceshookcontext.NewStateFacade)
 
 	reg("Resumer", 2, resumer.NewResumerAPI)
 	reg("RetryStrategy", 1, retrystrategy.NewRetryStrategyAPI)
+	reg("Secrets", 1, secrets.NewSecretsAPI)
 	reg("Singular", 2, singular.NewExternalFacade)
 
 	reg("SSHClient", 1, sshclient.NewFacade)
@@ -320,10 +340,13 @@ func AllFacades() *facade.Registry {
 	reg("Storage", 3, storage.NewStorageAPIV3)
 	reg("Storage", 4, storage.NewStorageAPIV4) // changes Destroy() method signature.
 	reg("Storage", 5, storage.NewStorageAPIV5) // Update and Delete storage pools and CreatePool bulk calls.
-	reg("Storage", 6, storage.NewStorageAPI)   // modify Remove to support force and maxWait; add DetachStorage to support force and maxWait.
+	reg("Storage", 6, storage.NewStorageAPIV6) // modify Remove to support force and maxWait; add DetachStorage to support force and maxWait.
+	reg("Storage", 7, storage.NewStorageAPIV7) // add Resize.
+	reg("Storage", 8, storage.NewStorageAPI)   // add PoolUsage.
 
 	reg("StorageProvisioner", 3, storageprovisioner.NewFacadeV3)
 	reg("StorageProvisioner", 4, storageprovisioner.NewFacadeV4)
+	reg("StorageProvisioner", 5, storageprovisioner.NewFacadeV5)
 	reg("Subnets", 2, subnets.NewAPIv2)
 	reg("Subnets", 3, subnets.NewAPIv3)
 	reg("Subnets", 4, subnets.NewAPI) // Adds SubnetsByCIDR; removes AllSpaces.
@@ -342,7 +365,13 @@ func AllFacades() *facade.Registry {
 	reg("Uniter", 13, uniter.NewUniterAPIV13)
 	reg("Uniter", 14, uniter.NewUniterAPIV14)
 	reg("Uniter", 15, uniter.NewUniterAPIV15)
-	reg("Uniter", 16, uniter.NewUniterAPI)
+	reg("Uniter", 16, uniter.NewUniterAPIV16)
+	reg("Uniter", 17, uniter.NewUniterAPIV17) // Adds RecordHookExecutions.
+	reg("Uniter", 18, uniter.NewUniterAPIV18) // Adds secrets.
+	reg("Uniter", 19, uniter.NewUniterAPIV19) // Adds SetHealthChecks.
+	reg("Uniter", 20, uniter.NewUniterAPIV20) // Adds TraceUntil.
+	reg("Uniter", 21, uniter.NewUniterAPIV21) // Adds ResourceLimits and WatchResourceLimits.
+	reg("Uniter", 22, uniter.NewUniterAPI)    // Adds MaintenanceWindow.
 
 	reg("Upgrader", 1, upgrader.NewUpgraderFacade)
 
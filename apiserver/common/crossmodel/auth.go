@@ -28,6 +28,10 @@ const (
 	offeruuidKey   = "offer-uuid"
 	sourcemodelKey = "source-model-uuid"
 	relationKey    = "relation-key"
+	invitationKey  = "offer-invitation"
+
+	consumingControllerKey = "consuming-controller-uuid"
+	consumingModelKey      = "consuming-model-uuid"
 
 	offerPermissionCaveat = "has-offer-permission"
 
@@ -215,7 +219,37 @@ func (a *AuthContext) offerPermissionYaml(sourceModelUUID, username, offerURL, r
 }
 
 // CreateConsumeOfferMacaroon creates a macaroon that authorises access to the specified offer.
-func (a *AuthContext) CreateConsumeOfferMacaroon(ctx context.Context, offer *params.ApplicationOfferDetails, username string, version bakery.Version) (*bakery.Macaroon, error) {
+// If the UUID of the consuming controller is known, it is declared by the macaroon so that
+// the offer's allowed controllers can be checked against it.
+func (a *AuthContext) CreateConsumeOfferMacaroon(
+	ctx context.Context, offer *params.ApplicationOfferDetails, username, consumingControllerUUID string, version bakery.Version,
+) (*bakery.Macaroon, error) {
+	var caveats []checkers.Caveat
+	if consumingControllerUUID != "" {
+		caveats = append(caveats, checkers.DeclaredCaveat(consumingControllerKey, consumingControllerUUID))
+	}
+	return a.createConsumeOfferMacaroon(ctx, offer, username, caveats, version)
+}
+
+// CreateInvitedConsumeOfferMacaroon creates a macaroon that authorises the
+// model which redeemed an offer invitation to consume the offer. The
+// consuming model is declared by the macaroon, so that relations to the
+// offer can only be registered on behalf of that model.
+func (a *AuthContext) CreateInvitedConsumeOfferMacaroon(
+	ctx context.Context, offer *params.ApplicationOfferDetails, username, consumingModelUUID, consumingControllerUUID string, version bakery.Version,
+) (*bakery.Macaroon, error) {
+	caveats := []checkers.Caveat{
+		checkers.DeclaredCaveat(consumingModelKey, consumingModelUUID),
+	}
+	if consumingControllerUUID != "" {
+		caveats = append(caveats, checkers.DeclaredCaveat(consumingControllerKey, consumingControllerUUID))
+	}
+	return a.createConsumeOfferMacaroon(ctx, offer, username, caveats, version)
+}
+
+func (a *AuthContext) createConsumeOfferMacaroon(
+	ctx context.Context, offer *params.ApplicationOfferDetails, username string, extraCaveats []checkers.Caveat, version bakery.Version,
+) (*bakery.Macaroon, error) {
 	sourceModelTag, err := names.ParseModelTag(offer.SourceModelTag)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -226,15 +260,69 @@ func (a *AuthContext) CreateConsumeOfferMacaroon(ctx context.Context, offer *par
 		return nil, errors.Trace(err)
 	}
 
+	caveats := []checkers.Caveat{
+		checkers.TimeBeforeCaveat(expiryTime),
+		checkers.DeclaredCaveat(sourcemodelKey, sourceModelTag.Id()),
+		checkers.DeclaredCaveat(offeruuidKey, offer.OfferUUID),
+		checkers.DeclaredCaveat(usernameKey, username),
+	}
+	caveats = append(caveats, extraCaveats...)
+	return bakery.NewMacaroon(ctx, version, caveats, crossModelConsumeOp(offer.OfferUUID))
+}
+
+// ConsumingControllerUUID returns the UUID of the consuming controller
+// declared by the attributes of verified offer macaroons, or "" if the
+// macaroons don't declare it.
+func ConsumingControllerUUID(attr map[string]string) string {
+	return attr[consumingControllerKey]
+}
+
+// ConsumingModelUUID returns the UUID of the consuming model declared
+// by the attributes of verified offer macaroons, or "" if the macaroons
+// don't declare it. Only macaroons minted by redeeming an offer
+// invitation declare the consuming model.
+func ConsumingModelUUID(attr map[string]string) string {
+	return attr[consumingModelKey]
+}
+
+// CreateOfferInvitationMacaroon creates a macaroon that authorises the
+// redemption of the specified offer invitation until it expires.
+func (a *AuthContext) CreateOfferInvitationMacaroon(
+	ctx context.Context, offerUUID, invitationId string, expires time.Time, version bakery.Version,
+) (*bakery.Macaroon, error) {
+	bakery, err := a.offerBakery.ExpireStorageAfter(expires.Sub(a.clock.Now()))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	return bakery.NewMacaroon(
 		ctx,
 		version,
 		[]checkers.Caveat{
-			checkers.TimeBeforeCaveat(expiryTime),
-			checkers.DeclaredCaveat(sourcemodelKey, sourceModelTag.Id()),
-			checkers.DeclaredCaveat(offeruuidKey, offer.OfferUUID),
-			checkers.DeclaredCaveat(usernameKey, username),
-		}, crossModelConsumeOp(offer.OfferUUID))
+			checkers.TimeBeforeCaveat(expires),
+			checkers.DeclaredCaveat(offeruuidKey, offerUUID),
+			checkers.DeclaredCaveat(invitationKey, invitationId),
+		}, crossModelRedeemOp(invitationId))
+}
+
+// CheckOfferInvitationMacaroons verifies that the specified macaroons
+// allow an offer invitation to be redeemed, returning the ids of the
+// invitation and the offer.
+func (a *AuthContext) CheckOfferInvitationMacaroons(ctx context.Context, mac macaroon.Slice) (invitationId, offerUUID string, _ error) {
+	declared := checkers.InferDeclared(charmstore.MacaroonNamespace, mac)
+	invitationId, ok := declared[invitationKey]
+	if !ok {
+		return "", "", apiservererrors.ErrPerm
+	}
+	offerUUID, ok = declared[offeruuidKey]
+	if !ok {
+		return "", "", apiservererrors.ErrPerm
+	}
+	if _, err := a.offerBakery.Auth(mac).Allow(ctx, crossModelRedeemOp(invitationId)); err != nil {
+		logger.Debugf("invalid offer invitation macaroon: %v", err)
+		return "", "", apiservererrors.ErrPerm
+	}
+	return invitationId, offerUUID, nil
 }
 
 // CreateRemoteRelationMacaroon creates a macaroon that authorises access to the specified relation.
@@ -283,6 +371,7 @@ type authenticator struct {
 const (
 	consumeOp = "consume"
 	relateOp  = "relate"
+	redeemOp  = "redeem"
 )
 
 func crossModelConsumeOp(offerUUID string) bakery.Op {
@@ -299,6 +388,13 @@ func crossModelRelateOp(relationID string) bakery.Op {
 	}
 }
 
+func crossModelRedeemOp(invitationId string) bakery.Op {
+	return bakery.Op{
+		Entity: invitationId,
+		Action: redeemOp,
+	}
+}
+
 // Authenticator returns an instance used to authenticate macaroons used to
 // access the specified offer.
 func (a *AuthContext) Authenticator(sourceModelUUID, offerUUID string) *authenticator {
@@ -210,7 +210,7 @@ func (s *authSuite) TestCreateConsumeOfferMacaroon(c *gc.C) {
 		SourceModelTag: coretesting.ModelTag.String(),
 		OfferUUID:      "mysql-uuid",
 	}
-	mac, err := s.authContext.CreateConsumeOfferMacaroon(context.TODO(), offer, "mary", bakery.LatestVersion)
+	mac, err := s.authContext.CreateConsumeOfferMacaroon(context.TODO(), offer, "mary", "", bakery.LatestVersion)
 	c.Assert(err, jc.ErrorIsNil)
 	cav := mac.M().Caveats()
 	c.Assert(cav, gc.HasLen, 4)
@@ -220,6 +220,29 @@ func (s *authSuite) TestCreateConsumeOfferMacaroon(c *gc.C) {
 	c.Assert(cav[3].Id, jc.DeepEquals, []byte("declared username mary"))
 }
 
+func (s *authSuite) TestCreateConsumeOfferMacaroonWithController(c *gc.C) {
+	offer := &params.ApplicationOfferDetails{
+		SourceModelTag: coretesting.ModelTag.String(),
+		OfferUUID:      "mysql-uuid",
+	}
+	mac, err := s.authContext.CreateConsumeOfferMacaroon(
+		context.TODO(), offer, "mary", coretesting.ControllerTag.Id(), bakery.LatestVersion)
+	c.Assert(err, jc.ErrorIsNil)
+	cav := mac.M().Caveats()
+	c.Assert(cav, gc.HasLen, 5)
+	c.Assert(cav[4].Id, jc.DeepEquals, []byte("declared consuming-controller-uuid "+coretesting.ControllerTag.Id()))
+
+	attr, err := s.authContext.Authenticator(
+		coretesting.ModelTag.Id(), "mysql-uuid").CheckOfferMacaroons(
+		context.TODO(),
+		"mysql-uuid",
+		macaroon.Slice{mac.M()},
+		bakery.LatestVersion,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(crossmodel.ConsumingControllerUUID(attr), gc.Equals, coretesting.ControllerTag.Id())
+}
+
 func (s *authSuite) TestCreateRemoteRelationMacaroon(c *gc.C) {
 	mac, err := s.authContext.CreateRemoteRelationMacaroon(
 		context.TODO(),
@@ -234,6 +257,48 @@ func (s *authSuite) TestCreateRemoteRelationMacaroon(c *gc.C) {
 	c.Assert(cav[4].Id, jc.DeepEquals, []byte("declared relation-key mediawiki:db mysql:server"))
 }
 
+func (s *authSuite) TestCreateOfferInvitationMacaroon(c *gc.C) {
+	expires := time.Now().Add(time.Hour)
+	mac, err := s.authContext.CreateOfferInvitationMacaroon(context.TODO(), "mysql-uuid", "deadbeef", expires, bakery.LatestVersion)
+	c.Assert(err, jc.ErrorIsNil)
+	cav := mac.M().Caveats()
+	c.Assert(cav, gc.HasLen, 3)
+	c.Assert(bytes.HasPrefix(cav[0].Id, []byte("time-before")), jc.IsTrue)
+	c.Assert(cav[1].Id, jc.DeepEquals, []byte("declared offer-uuid mysql-uuid"))
+	c.Assert(cav[2].Id, jc.DeepEquals, []byte("declared offer-invitation deadbeef"))
+}
+
+func (s *authSuite) TestCheckOfferInvitationMacaroons(c *gc.C) {
+	mac, err := s.authContext.CreateOfferInvitationMacaroon(
+		context.TODO(), "mysql-uuid", "deadbeef", time.Now().Add(time.Hour), bakery.LatestVersion)
+	c.Assert(err, jc.ErrorIsNil)
+	invitationId, offerUUID, err := s.authContext.CheckOfferInvitationMacaroons(context.TODO(), macaroon.Slice{mac.M()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(invitationId, gc.Equals, "deadbeef")
+	c.Assert(offerUUID, gc.Equals, "mysql-uuid")
+}
+
+func (s *authSuite) TestCheckOfferInvitationMacaroonsExpired(c *gc.C) {
+	mac, err := s.authContext.CreateOfferInvitationMacaroon(
+		context.TODO(), "mysql-uuid", "deadbeef", time.Now().Add(-time.Minute), bakery.LatestVersion)
+	c.Assert(err, jc.ErrorIsNil)
+	_, _, err = s.authContext.CheckOfferInvitationMacaroons(context.TODO(), macaroon.Slice{mac.M()})
+	c.Assert(err, gc.Equals, apiservererrors.ErrPerm)
+}
+
+func (s *authSuite) TestCheckOfferInvitationMacaroonsConsumeMacaroon(c *gc.C) {
+	mac, err := s.bakery.NewMacaroon(
+		context.TODO(),
+		bakery.LatestVersion,
+		[]checkers.Caveat{
+			checkers.DeclaredCaveat("offer-uuid", "mysql-uuid"),
+			checkers.DeclaredCaveat("offer-invitation", "deadbeef"),
+		}, bakery.Op{"consume", "mysql-uuid"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, _, err = s.authContext.CheckOfferInvitationMacaroons(context.TODO(), macaroon.Slice{mac.M()})
+	c.Assert(err, gc.Equals, apiservererrors.ErrPerm)
+}
+
 func (s *authSuite) TestCheckOfferMacaroons(c *gc.C) {
 	mac, err := s.bakery.NewMacaroon(
 		context.TODO(),
@@ -313,7 +378,7 @@ func (s *authSuite) TestCheckOfferMacaroonsDischargeRequired(c *gc.C) {
 		SourceModelTag: coretesting.ModelTag.String(),
 		OfferUUID:      "mysql-uuid",
 	}
-	mac, err := authContext.CreateConsumeOfferMacaroon(context.TODO(), offer, "mary", bakery.LatestVersion)
+	mac, err := authContext.CreateConsumeOfferMacaroon(context.TODO(), offer, "mary", "", bakery.LatestVersion)
 	c.Assert(err, jc.ErrorIsNil)
 
 	_, err = authContext.Authenticator(
@@ -577,3 +577,47 @@ func (c CacheShim) Application(name string) (CachedApplication, error) {
 	}
 	return &app, nil
 }
+
+// RemoteApplicationDiagnostics returns the cross model relation
+// diagnostics recorded for the named remote application, or nil
+// if none have been recorded.
+func RemoteApplicationDiagnostics(backend Backend, appName string) (*params.RemoteApplicationDiagnostics, error) {
+	app, err := backend.RemoteApplication(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	diagnostics, err := app.Diagnostics()
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return DiagnosticsToParams(diagnostics), nil
+}
+
+// DiagnosticsToParams converts remote application diagnostics into
+// their API representation.
+func DiagnosticsToParams(diagnostics *crossmodel.RemoteApplicationDiagnostics) *params.RemoteApplicationDiagnostics {
+	if diagnostics == nil {
+		return nil
+	}
+	return &params.RemoteApplicationDiagnostics{
+		LastRemoteEvent:  diagnostics.LastRemoteEvent,
+		MacaroonExpiry:   diagnostics.MacaroonExpiry,
+		SettingsSyncLag:  diagnostics.SettingsSyncLag,
+		IngressAddresses: diagnostics.IngressAddresses,
+		Updated:          diagnostics.Updated,
+	}
+}
+
+// DiagnosticsFromParams converts the API representation of remote
+// application diagnostics.
+func DiagnosticsFromParams(diagnostics params.RemoteApplicationDiagnostics) crossmodel.RemoteApplicationDiagnostics {
+	return crossmodel.RemoteApplicationDiagnostics{
+		LastRemoteEvent:  diagnostics.LastRemoteEvent,
+		MacaroonExpiry:   diagnostics.MacaroonExpiry,
+		SettingsSyncLag:  diagnostics.SettingsSyncLag,
+		IngressAddresses: diagnostics.IngressAddresses,
+		Updated:          diagnostics.Updated,
+	}
+}
@@ -274,4 +274,12 @@ type RemoteApplication interface {
 	// remote application to terminated and leave it in a state
 	// enabling it to be removed cleanly.
 	TerminateOperation(string) state.ModelOperation
+
+	// Diagnostics returns the cross model relation diagnostics
+	// recorded for the remote application.
+	Diagnostics() (*crossmodel.RemoteApplicationDiagnostics, error)
+
+	// SetDiagnostics records the cross model relation diagnostics
+	// for the remote application.
+	SetDiagnostics(crossmodel.RemoteApplicationDiagnostics) error
 }
@@ -0,0 +1,62 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package secretbackend holds the registry of backends which may be
+// used to store the content of charm secrets.
+package secretbackend
+
+import (
+	"sync"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/state"
+)
+
+// Factory returns a secrets backend for the model of the given state.
+type Factory func(*state.State) (secrets.Backend, error)
+
+var (
+	mu        sync.Mutex
+	factories = make(map[string]Factory)
+)
+
+func init() {
+	Register(secrets.InternalBackendType, func(st *state.State) (secrets.Backend, error) {
+		return state.NewSecretContentStore(st), nil
+	})
+}
+
+// Register makes a secrets backend available with the given type.
+// Registering the same type twice panics.
+func Register(backendType string, f Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+	if _, ok := factories[backendType]; ok {
+		panic(errors.Errorf("secret backend %q already registered", backendType))
+	}
+	factories[backendType] = f
+}
+
+// New returns the secrets backend with the given type.
+func New(backendType string, st *state.State) (secrets.Backend, error) {
+	mu.Lock()
+	f, ok := factories[backendType]
+	mu.Unlock()
+	if !ok {
+		return nil, errors.NotFoundf("secret backend %q", backendType)
+	}
+	backend, err := f(st)
+	return backend, errors.Trace(err)
+}
+
+// ForModel returns the secrets backend configured for the controller
+// hosting the model of the given state.
+func ForModel(st *state.State) (secrets.Backend, error) {
+	cfg, err := st.ControllerConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return New(cfg.SecretBackend(), st)
+}
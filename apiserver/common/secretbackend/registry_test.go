@@ -0,0 +1,58 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secretbackend_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common/secretbackend"
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/state"
+)
+
+type registrySuite struct{}
+
+var _ = gc.Suite(&registrySuite{})
+
+type fakeBackend struct {
+	secrets.Backend
+}
+
+func (fakeBackend) Type() string {
+	return "fake"
+}
+
+func (s *registrySuite) TestRegisterAndNew(c *gc.C) {
+	secretbackend.Register("fake", func(*state.State) (secrets.Backend, error) {
+		return fakeBackend{}, nil
+	})
+	backend, err := secretbackend.New("fake", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(backend.Type(), gc.Equals, "fake")
+
+	c.Assert(func() {
+		secretbackend.Register("fake", nil)
+	}, gc.PanicMatches, `secret backend "fake" already registered`)
+}
+
+func (s *registrySuite) TestNewUnknown(c *gc.C) {
+	_, err := secretbackend.New("unknown", nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `secret backend "unknown" not found`)
+}
+
+func (s *registrySuite) TestInternalRegistered(c *gc.C) {
+	backend, err := secretbackend.New(secrets.InternalBackendType, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(backend.Type(), gc.Equals, secrets.InternalBackendType)
+}
+
+func (s *registrySuite) TestValidBackendTypesRegistered(c *gc.C) {
+	for _, backendType := range secrets.BackendTypes() {
+		_, err := secretbackend.New(backendType, nil)
+		c.Check(err, jc.ErrorIsNil, gc.Commentf("backend %q", backendType))
+	}
+}
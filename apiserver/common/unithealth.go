@@ -0,0 +1,28 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
+)
+
+// HealthChecksToParams converts unit health checks into their
+// API representation.
+func HealthChecksToParams(checks []status.HealthCheck) []params.HealthCheck {
+	if len(checks) == 0 {
+		return nil
+	}
+	result := make([]params.HealthCheck, len(checks))
+	for i, check := range checks {
+		since := check.Since
+		result[i] = params.HealthCheck{
+			Name:    check.Name,
+			Status:  string(check.Status),
+			Message: check.Message,
+			Since:   &since,
+		}
+	}
+	return result
+}
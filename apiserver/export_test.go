@@ -29,6 +29,8 @@ var (
 
 	GUIURLPathPrefix       = guiURLPathPrefix
 	DashboardURLPathPrefix = dashboardURLPathPrefix
+
+	MaintenanceModeMethodsOnly = maintenanceModeMethodsOnly
 )
 
 func APIHandlerWithEntity(entity state.Entity) *apiHandler {
@@ -93,6 +95,15 @@ func TestingMigratingRoot() rpc.Root {
 	return restrictRoot(r, migrationClientMethodsOnly)
 }
 
+// TestingMaintenanceModeRoot returns a restricted srvRoot as if the
+// controller were in maintenance mode with the given message.
+func TestingMaintenanceModeRoot(message string) rpc.Root {
+	r := TestingAPIRoot(AllFacades())
+	return restrictRoot(r, maintenanceModeMethodsOnly(func() (string, bool) {
+		return message, true
+	}))
+}
+
 // TestingAnonymousRoot returns a restricted srvRoot as if
 // logged in anonymously.
 func TestingAnonymousRoot() rpc.Root {
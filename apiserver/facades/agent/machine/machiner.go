@@ -24,6 +24,7 @@ var logger = loggo.GetLogger("juju.apiserver.machine")
 type MachinerAPI struct {
 	*common.LifeGetter
 	*common.StatusSetter
+	*common.StatusGetter
 	*common.DeadEnsurer
 	*common.AgentEntityWatcher
 	*common.APIAddresser
@@ -49,6 +50,7 @@ func NewMachinerAPI(st *state.State, resources facade.Resources, authorizer faca
 	return &MachinerAPI{
 		LifeGetter:         common.NewLifeGetter(st, getCanRead),
 		StatusSetter:       common.NewStatusSetter(st, getCanModify),
+		StatusGetter:       common.NewStatusGetter(st, getCanRead),
 		DeadEnsurer:        common.NewDeadEnsurer(st, nil, getCanModify),
 		AgentEntityWatcher: common.NewAgentEntityWatcher(st, resources, getCanRead),
 		APIAddresser:       common.NewAPIAddresser(st, resources),
@@ -166,6 +168,12 @@ type MachinerAPIV2 struct {
 // MachinerAPIV3 implements the V3 API used by the machiner worker.
 // It removes SetProviderNetworkConfig.
 type MachinerAPIV3 struct {
+	*MachinerAPIV4
+}
+
+// MachinerAPIV4 implements the V4 API used by the machiner worker.
+// It doesn't have Status.
+type MachinerAPIV4 struct {
 	*MachinerAPI
 }
 
@@ -205,7 +213,7 @@ func (api *MachinerAPIV2) SetObservedNetworkConfig(args params.SetMachineNetwork
 func NewMachinerAPIV3(
 	st *state.State, resources facade.Resources, authorizer facade.Authorizer,
 ) (*MachinerAPIV3, error) {
-	api, err := NewMachinerAPI(st, resources, authorizer)
+	api, err := NewMachinerAPIV4(st, resources, authorizer)
 	if err != nil {
 		return nil, err
 	}
@@ -213,6 +221,18 @@ func NewMachinerAPIV3(
 	return &MachinerAPIV3{api}, nil
 }
 
+// NewMachinerAPIV4 creates a new instance of the V4 Machiner API.
+func NewMachinerAPIV4(
+	st *state.State, resources facade.Resources, authorizer facade.Authorizer,
+) (*MachinerAPIV4, error) {
+	api, err := NewMachinerAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MachinerAPIV4{api}, nil
+}
+
 // SetProviderNetworkConfig is no-op.
 // This method stub is here, because the method was removed from the common
 // networking API.
@@ -226,3 +246,6 @@ func (api *MachinerAPIV3) SetProviderNetworkConfig(args params.Entities) (params
 
 // RecordAgentStartTime is not available in V1.
 func (api *MachinerAPIV1) RecordAgentStartTime(_, _ struct{}) {}
+
+// Status is not available in V4.
+func (api *MachinerAPIV4) Status(_, _ struct{}) {}
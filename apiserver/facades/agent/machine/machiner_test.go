@@ -102,6 +102,32 @@ func (s *machinerSuite) TestSetStatus(c *gc.C) {
 	c.Assert(statusInfo.Message, gc.Equals, "not really")
 }
 
+func (s *machinerSuite) TestStatus(c *gc.C) {
+	now := time.Now()
+	err := s.machine1.SetStatus(status.StatusInfo{
+		Status:  status.Started,
+		Message: "blah",
+		Data:    map[string]interface{}{"foo": "bar"},
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "machine-1"},
+		{Tag: "machine-0"},
+		{Tag: "machine-42"},
+	}}
+	result, err := s.machiner.Status(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Status, gc.Equals, status.Started.String())
+	c.Assert(result.Results[0].Info, gc.Equals, "blah")
+	c.Assert(result.Results[0].Data, jc.DeepEquals, map[string]interface{}{"foo": "bar"})
+	c.Assert(result.Results[1].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+	c.Assert(result.Results[2].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+}
+
 func (s *machinerSuite) TestLife(c *gc.C) {
 	err := s.machine1.EnsureDead()
 	c.Assert(err, jc.ErrorIsNil)
@@ -14,12 +14,13 @@ import (
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/watcher"
 )
 
-// Right now, these are defined as constants, but the plan is to maybe make
-// them configurable in the future
+// These are the defaults, which can be changed for each application
+// through its hook retry application config.
 const (
 	MinRetryTime    = 5 * time.Second
 	MaxRetryTime    = 5 * time.Minute
@@ -90,25 +91,74 @@ func (h *RetryStrategyAPI) RetryStrategy(args params.Entities) (params.RetryStra
 		}
 		err = apiservererrors.ErrPerm
 		if canAccess(tag) {
-			// Right now the only real configurable value is ShouldRetry,
-			// which is taken from the model.
-			// The rest are hardcoded.
-			results.Results[i].Result = &params.RetryStrategy{
-				ShouldRetry:     config.AutomaticallyRetryHooks(),
-				MinRetryTime:    MinRetryTime,
-				MaxRetryTime:    MaxRetryTime,
-				JitterRetryTime: JitterRetryTime,
-				RetryTimeFactor: RetryTimeFactor,
-			}
-			err = nil
+			results.Results[i].Result, err = h.retryStrategy(tag, config.AutomaticallyRetryHooks())
 		}
 		results.Results[i].Error = apiservererrors.ServerError(err)
 	}
 	return results, nil
 }
 
-// WatchRetryStrategy watches for changes to the model. Currently we only allow
-// changes to the boolean that determines whether retries should be attempted or not.
+// retryStrategy returns the retry strategy for the agent, applying its
+// application's hook retry policy to the model's defaults.
+func (h *RetryStrategyAPI) retryStrategy(tag names.Tag, shouldRetry bool) (*params.RetryStrategy, error) {
+	strategy := &params.RetryStrategy{
+		ShouldRetry:     shouldRetry,
+		MinRetryTime:    MinRetryTime,
+		MaxRetryTime:    MaxRetryTime,
+		JitterRetryTime: JitterRetryTime,
+		RetryTimeFactor: RetryTimeFactor,
+	}
+	app, err := h.application(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	attrs, err := app.ApplicationConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	policy, err := application.ParseHookRetryPolicy(attrs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	strategy.MaxRetryAttempts = policy.MaxAttempts
+	if policy.MinInterval > 0 {
+		strategy.MinRetryTime = policy.MinInterval
+	}
+	if policy.MaxInterval > 0 {
+		strategy.MaxRetryTime = policy.MaxInterval
+	}
+	if strategy.MinRetryTime > strategy.MaxRetryTime {
+		// Only one of the intervals was set, outside the default.
+		if policy.MinInterval > 0 {
+			strategy.MaxRetryTime = strategy.MinRetryTime
+		} else {
+			strategy.MinRetryTime = strategy.MaxRetryTime
+		}
+	}
+	return strategy, nil
+}
+
+// application returns the application of the unit or application agent.
+func (h *RetryStrategyAPI) application(tag names.Tag) (*state.Application, error) {
+	var name string
+	switch tag := tag.(type) {
+	case names.UnitTag:
+		var err error
+		if name, err = names.UnitApplication(tag.Id()); err != nil {
+			return nil, errors.Trace(err)
+		}
+	case names.ApplicationTag:
+		name = tag.Name
+	default:
+		return nil, errors.NotValidf("tag %q", tag)
+	}
+	app, err := h.st.Application(name)
+	return app, errors.Trace(err)
+}
+
+// WatchRetryStrategy watches for changes to the model config, which
+// determines whether retries should be attempted or not, and to the
+// config of the agent's application, which holds its hook retry policy.
 func (h *RetryStrategyAPI) WatchRetryStrategy(args params.Entities) (params.NotifyWatchResults, error) {
 	results := params.NotifyWatchResults{
 		Results: make([]params.NotifyWatchResult, len(args.Entities)),
@@ -125,18 +175,27 @@ func (h *RetryStrategyAPI) WatchRetryStrategy(args params.Entities) (params.Noti
 		}
 		err = apiservererrors.ErrPerm
 		if canAccess(tag) {
-			watch := h.model.WatchForModelConfigChanges()
-			// Consume the initial event. Technically, API calls to Watch
-			// 'transmit' the initial event in the Watch response. But
-			// NotifyWatchers have no state to transmit.
-			if _, ok := <-watch.Changes(); ok {
-				results.Results[i].NotifyWatcherId = h.resources.Register(watch)
-				err = nil
-			} else {
-				err = watcher.EnsureErr(watch)
-			}
+			results.Results[i].NotifyWatcherId, err = h.watchRetryStrategy(tag)
 		}
 		results.Results[i].Error = apiservererrors.ServerError(err)
 	}
 	return results, nil
 }
+
+func (h *RetryStrategyAPI) watchRetryStrategy(tag names.Tag) (string, error) {
+	app, err := h.application(tag)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	watch := common.NewMultiNotifyWatcher(
+		h.model.WatchForModelConfigChanges(),
+		app.WatchApplicationConfig(),
+	)
+	// Consume the initial event. Technically, API calls to Watch
+	// 'transmit' the initial event in the Watch response. But
+	// NotifyWatchers have no state to transmit.
+	if _, ok := <-watch.Changes(); ok {
+		return h.resources.Register(watch), nil
+	}
+	return "", watcher.EnsureErr(watch)
+}
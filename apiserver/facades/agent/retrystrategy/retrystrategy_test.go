@@ -5,8 +5,12 @@
 package retrystrategy_test
 
 import (
+	"time"
+
+	"github.com/juju/schema"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/environschema.v1"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facades/agent/retrystrategy"
@@ -131,6 +135,57 @@ func (s *retryStrategySuite) assertRetryStrategy(c *gc.C, tag string) {
 	c.Assert(r.Results[0].Result, jc.DeepEquals, expected)
 }
 
+func (s *retryStrategySuite) TestRetryStrategyApplicationPolicy(c *gc.C) {
+	s.setHookRetryPolicy(c, map[string]interface{}{
+		"hook-retry-max-attempts": 3,
+		"hook-retry-min-interval": "10s",
+		"hook-retry-max-interval": "1m",
+	})
+	args := params.Entities{Entities: []params.Entity{{Tag: s.unit.Tag().String()}}}
+	r, err := s.strategy.RetryStrategy(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Results, gc.HasLen, 1)
+	c.Assert(r.Results[0].Error, gc.IsNil)
+	c.Assert(r.Results[0].Result, jc.DeepEquals, &params.RetryStrategy{
+		ShouldRetry:      true,
+		MinRetryTime:     10 * time.Second,
+		MaxRetryTime:     time.Minute,
+		JitterRetryTime:  retrystrategy.JitterRetryTime,
+		RetryTimeFactor:  retrystrategy.RetryTimeFactor,
+		MaxRetryAttempts: 3,
+	})
+}
+
+func (s *retryStrategySuite) TestRetryStrategyMinIntervalAboveDefaultMax(c *gc.C) {
+	s.setHookRetryPolicy(c, map[string]interface{}{
+		"hook-retry-min-interval": "10m",
+	})
+	args := params.Entities{Entities: []params.Entity{{Tag: s.unit.Tag().String()}}}
+	r, err := s.strategy.RetryStrategy(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Results, gc.HasLen, 1)
+	c.Assert(r.Results[0].Error, gc.IsNil)
+	c.Assert(r.Results[0].Result.MinRetryTime, gc.Equals, 10*time.Minute)
+	c.Assert(r.Results[0].Result.MaxRetryTime, gc.Equals, 10*time.Minute)
+}
+
+func (s *retryStrategySuite) setHookRetryPolicy(c *gc.C, attrs map[string]interface{}) {
+	app, err := s.unit.Application()
+	c.Assert(err, jc.ErrorIsNil)
+	fields := environschema.Fields{
+		"hook-retry-max-attempts": {Type: environschema.Tint},
+		"hook-retry-min-interval": {Type: environschema.Tstring},
+		"hook-retry-max-interval": {Type: environschema.Tstring},
+	}
+	defaults := schema.Defaults{
+		"hook-retry-max-attempts": schema.Omit,
+		"hook-retry-min-interval": schema.Omit,
+		"hook-retry-max-interval": schema.Omit,
+	}
+	err = app.UpdateApplicationConfig(attrs, nil, fields, defaults)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *retryStrategySuite) setRetryStrategy(c *gc.C, automaticallyRetryHooks bool) {
 	err := s.Model.UpdateModelConfig(map[string]interface{}{"automatically-retry-hooks": automaticallyRetryHooks}, nil)
 	c.Assert(err, jc.ErrorIsNil)
@@ -193,4 +248,7 @@ func (s *retryStrategySuite) TestWatchRetryStrategy(c *gc.C) {
 	s.setRetryStrategy(c, false)
 	c.Assert(err, jc.ErrorIsNil)
 	wc.AssertOneChange()
+
+	s.setHookRetryPolicy(c, map[string]interface{}{"hook-retry-max-attempts": 5})
+	wc.AssertOneChange()
 }
@@ -14,6 +14,7 @@ import (
 var (
 	GetZone                = &getZone
 	WatchStorageAttachment = watchStorageAttachment
+	NewSecretsAPI          = newSecretsAPI
 
 	_ meterstatus.MeterStatus = (*UniterAPI)(nil)
 )
@@ -23,6 +24,7 @@ type (
 	StorageStateInterface      storageInterface
 	StorageVolumeInterface     = storageVolumeInterface
 	StorageFilesystemInterface = storageFilesystemInterface
+	SecretsState               = secretsState
 )
 
 func NewStorageAPI(
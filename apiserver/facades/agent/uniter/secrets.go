@@ -0,0 +1,538 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/common"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// secretsState describes the secrets store methods used by SecretsAPI.
+type secretsState interface {
+	CreateSecret(*secrets.URI, state.CreateSecretParams) (*secrets.SecretMetadata, error)
+	UpdateSecret(*secrets.URI, state.UpdateSecretParams) (*secrets.SecretMetadata, error)
+	GetSecret(*secrets.URI) (*secrets.SecretMetadata, error)
+	GetSecretValueRef(*secrets.URI, int) (*secrets.ValueRef, error)
+	GrantSecretAccess(*secrets.URI, state.SecretAccessParams) error
+	RevokeSecretAccess(*secrets.URI, names.Tag) error
+	SecretAccess(*secrets.URI, names.Tag) (secrets.SecretRole, error)
+	SecretRotated(*secrets.URI, time.Time) error
+	WatchSecretsRotationChanges(names.ApplicationTag) state.StringsWatcher
+}
+
+// SecretsAPI lets units create and use charm secrets. Secrets are
+// owned by the unit's application and only its leader may change them.
+type SecretsAPI struct {
+	secrets           secretsState
+	newBackend        func() (secrets.Backend, error)
+	backendFor        func(backendType string) (secrets.Backend, error)
+	resources         facade.Resources
+	leadershipChecker leadership.Checker
+	accessUnit        common.GetAuthFunc
+	clock             clock.Clock
+}
+
+// newSecretsAPI creates a new server-side secrets API. newBackend
+// returns the backend used to store new secret content, and backendFor
+// returns the backend holding existing content of the given type.
+func newSecretsAPI(
+	secretsState secretsState,
+	newBackend func() (secrets.Backend, error),
+	backendFor func(string) (secrets.Backend, error),
+	resources facade.Resources,
+	leadershipChecker leadership.Checker,
+	accessUnit common.GetAuthFunc,
+	clock clock.Clock,
+) *SecretsAPI {
+	return &SecretsAPI{
+		secrets:           secretsState,
+		newBackend:        newBackend,
+		backendFor:        backendFor,
+		resources:         resources,
+		leadershipChecker: leadershipChecker,
+		accessUnit:        accessUnit,
+		clock:             clock,
+	}
+}
+
+// authUnit returns the tag of the given unit if the caller may act
+// for it.
+func (s *SecretsAPI) authUnit(canAccess common.AuthFunc, unitTag string) (names.UnitTag, error) {
+	tag, err := names.ParseUnitTag(unitTag)
+	if err != nil || !canAccess(tag) {
+		return names.UnitTag{}, apiservererrors.ErrPerm
+	}
+	return tag, nil
+}
+
+// ownerOf returns the application owning secrets created by the unit.
+func ownerOf(unitTag names.UnitTag) names.ApplicationTag {
+	appName, _ := names.UnitApplication(unitTag.Id())
+	return names.NewApplicationTag(appName)
+}
+
+// authOwner checks that the unit is the leader of the application
+// owning the secret, and returns the secret's metadata.
+func (s *SecretsAPI) authOwner(unitTag names.UnitTag, uri *secrets.URI) (*secrets.SecretMetadata, error) {
+	md, err := s.secrets.GetSecret(uri)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	owner := ownerOf(unitTag)
+	if md.OwnerTag != owner.String() {
+		return nil, apiservererrors.ErrPerm
+	}
+	if err := s.checkLeader(unitTag); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return md, nil
+}
+
+// authManage checks that the unit may update and share the secret,
+// and returns the secret's metadata. The leader of the owning
+// application may always do so; otherwise the unit must have been
+// granted the manage role, either itself or through its application,
+// in which case it must be the application's leader.
+func (s *SecretsAPI) authManage(unitTag names.UnitTag, uri *secrets.URI) (*secrets.SecretMetadata, error) {
+	md, err := s.secrets.GetSecret(uri)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app := ownerOf(unitTag)
+	if md.OwnerTag == app.String() {
+		return md, errors.Trace(s.checkLeader(unitTag))
+	}
+	role, err := s.secrets.SecretAccess(uri, unitTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if role.Allowed(secrets.RoleManage) {
+		return md, nil
+	}
+	role, err = s.secrets.SecretAccess(uri, app)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !role.Allowed(secrets.RoleManage) {
+		return nil, apiservererrors.ErrPerm
+	}
+	if err := s.checkLeader(unitTag); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return md, nil
+}
+
+func (s *SecretsAPI) checkLeader(unitTag names.UnitTag) error {
+	token := s.leadershipChecker.LeadershipCheck(ownerOf(unitTag).Id(), unitTag.Id())
+	return token.Check(0, nil)
+}
+
+// CreateSecrets creates new secrets owned by the application of each
+// unit, which must be its leader, and returns their URIs.
+func (s *SecretsAPI) CreateSecrets(args params.CreateSecretArgs) (params.StringResults, error) {
+	result := params.StringResults{
+		Results: make([]params.StringResult, len(args.Args)),
+	}
+	canAccess, err := s.accessUnit()
+	if err != nil {
+		return params.StringResults{}, err
+	}
+	for i, arg := range args.Args {
+		uri, err := s.createSecret(canAccess, arg)
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		result.Results[i].Result = uri.String()
+	}
+	return result, nil
+}
+
+func (s *SecretsAPI) createSecret(canAccess common.AuthFunc, arg params.CreateSecretArg) (*secrets.URI, error) {
+	unitTag, err := s.authUnit(canAccess, arg.UnitTag)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkLeader(unitTag); err != nil {
+		return nil, errors.Trace(err)
+	}
+	policy := secrets.RotatePolicy(arg.RotatePolicy)
+	if policy == "" {
+		policy = secrets.RotateNever
+	}
+	if !policy.IsValid() {
+		return nil, errors.NotValidf("secret rotate policy %q", arg.RotatePolicy)
+	}
+	data := secrets.SecretData(arg.Data)
+	if err := data.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	backend, err := s.newBackend()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	uri, err := secrets.NewURI()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	contentID, err := backend.SaveContent(uri, 1, data)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	_, err = s.secrets.CreateSecret(uri, state.CreateSecretParams{
+		Owner:        ownerOf(unitTag),
+		Description:  arg.Description,
+		Label:        arg.Label,
+		RotatePolicy: policy,
+		ExpireTime:   arg.ExpireTime,
+		ValueRef: secrets.ValueRef{
+			BackendType: backend.Type(),
+			ID:          contentID,
+		},
+	})
+	if err != nil {
+		if deleteErr := backend.DeleteContent(contentID); deleteErr != nil {
+			logger.Warningf("cannot delete content of secret %q: %v", uri, deleteErr)
+		}
+		return nil, errors.Trace(err)
+	}
+	return uri, nil
+}
+
+// UpdateSecrets updates secrets owned by the application of each unit,
+// which must be its leader, or which the unit has been granted the
+// manage role on. Supplying data adds a new revision.
+func (s *SecretsAPI) UpdateSecrets(args params.UpdateSecretArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := s.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		err := s.updateSecret(canAccess, arg)
+		result.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return result, nil
+}
+
+func (s *SecretsAPI) updateSecret(canAccess common.AuthFunc, arg params.UpdateSecretArg) error {
+	unitTag, err := s.authUnit(canAccess, arg.UnitTag)
+	if err != nil {
+		return err
+	}
+	uri, err := secrets.ParseURI(arg.URI)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	md, err := s.authManage(unitTag, uri)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	p := state.UpdateSecretParams{
+		Description: arg.Description,
+		Label:       arg.Label,
+		ExpireTime:  arg.ExpireTime,
+	}
+	if arg.RotatePolicy != nil {
+		policy := secrets.RotatePolicy(*arg.RotatePolicy)
+		if !policy.IsValid() {
+			return errors.NotValidf("secret rotate policy %q", *arg.RotatePolicy)
+		}
+		p.RotatePolicy = &policy
+	}
+	if len(arg.Data) == 0 {
+		_, err := s.secrets.UpdateSecret(uri, p)
+		return errors.Trace(err)
+	}
+
+	data := secrets.SecretData(arg.Data)
+	if err := data.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	backend, err := s.newBackend()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	contentID, err := backend.SaveContent(uri, md.LatestRevision+1, data)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	p.ValueRef = &secrets.ValueRef{
+		BackendType: backend.Type(),
+		ID:          contentID,
+	}
+	if _, err := s.secrets.UpdateSecret(uri, p); err != nil {
+		if deleteErr := backend.DeleteContent(contentID); deleteErr != nil {
+			logger.Warningf("cannot delete content of secret %q: %v", uri, deleteErr)
+		}
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// GetSecretValues returns the latest value of each secret. A unit may
+// read secrets owned by its application, and secrets which it or its
+// application have been granted access to.
+func (s *SecretsAPI) GetSecretValues(args params.GetSecretValueArgs) (params.SecretValueResults, error) {
+	result := params.SecretValueResults{
+		Results: make([]params.SecretValueResult, len(args.Args)),
+	}
+	canAccess, err := s.accessUnit()
+	if err != nil {
+		return params.SecretValueResults{}, err
+	}
+	for i, arg := range args.Args {
+		data, err := s.getSecretValue(canAccess, arg)
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		result.Results[i].Data = data
+	}
+	return result, nil
+}
+
+func (s *SecretsAPI) getSecretValue(canAccess common.AuthFunc, arg params.GetSecretValueArg) (secrets.SecretData, error) {
+	unitTag, err := s.authUnit(canAccess, arg.UnitTag)
+	if err != nil {
+		return nil, err
+	}
+	uri, err := secrets.ParseURI(arg.URI)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	md, err := s.secrets.GetSecret(uri)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if md.OwnerTag != ownerOf(unitTag).String() {
+		if err := s.checkCanView(uri, unitTag); err != nil {
+			return nil, err
+		}
+	}
+	if md.LatestExpireTime != nil && !s.clock.Now().Before(*md.LatestExpireTime) {
+		return nil, errors.Errorf("secret %q expired at %s", uri, md.LatestExpireTime.Format(time.RFC3339))
+	}
+	ref, err := s.secrets.GetSecretValueRef(uri, md.LatestRevision)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	backend, err := s.backendFor(ref.BackendType)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	data, err := backend.GetContent(ref.ID)
+	return data, errors.Trace(err)
+}
+
+// checkCanView returns ErrPerm unless the unit, or its application,
+// has been granted at least view access to the secret.
+func (s *SecretsAPI) checkCanView(uri *secrets.URI, unitTag names.UnitTag) error {
+	for _, subject := range []names.Tag{unitTag, ownerOf(unitTag)} {
+		role, err := s.secrets.SecretAccess(uri, subject)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if role.Allowed(secrets.RoleView) {
+			return nil
+		}
+	}
+	return apiservererrors.ErrPerm
+}
+
+// SecretsGrant grants applications or units access to secrets owned
+// by the application of each unit, which must be its leader, or which
+// the unit has been granted the manage role on.
+func (s *SecretsAPI) SecretsGrant(args params.GrantRevokeSecretArgs) (params.ErrorResults, error) {
+	return s.secretsGrantRevoke(args, func(uri *secrets.URI, subject names.Tag, role secrets.SecretRole) error {
+		return s.secrets.GrantSecretAccess(uri, state.SecretAccessParams{
+			Subject: subject,
+			Role:    role,
+		})
+	})
+}
+
+// SecretsRevoke revokes access to secrets owned by the application of
+// each unit, which must be its leader, or which the unit has been
+// granted the manage role on.
+func (s *SecretsAPI) SecretsRevoke(args params.GrantRevokeSecretArgs) (params.ErrorResults, error) {
+	return s.secretsGrantRevoke(args, func(uri *secrets.URI, subject names.Tag, _ secrets.SecretRole) error {
+		return s.secrets.RevokeSecretAccess(uri, subject)
+	})
+}
+
+type grantRevokeFunc func(*secrets.URI, names.Tag, secrets.SecretRole) error
+
+func (s *SecretsAPI) secretsGrantRevoke(args params.GrantRevokeSecretArgs, op grantRevokeFunc) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := s.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		err := s.grantRevoke(canAccess, arg, op)
+		result.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return result, nil
+}
+
+func (s *SecretsAPI) grantRevoke(canAccess common.AuthFunc, arg params.GrantRevokeSecretArg, op grantRevokeFunc) error {
+	unitTag, err := s.authUnit(canAccess, arg.UnitTag)
+	if err != nil {
+		return err
+	}
+	uri, err := secrets.ParseURI(arg.URI)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	role := secrets.SecretRole(arg.Role)
+	if role == secrets.RoleNone {
+		role = secrets.RoleView
+	}
+	if !role.IsValid() {
+		return errors.NotValidf("secret role %q", arg.Role)
+	}
+	if _, err := s.authManage(unitTag, uri); err != nil {
+		return errors.Trace(err)
+	}
+	for _, tagStr := range arg.SubjectTags {
+		subject, err := names.ParseTag(tagStr)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := op(uri, subject, role); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// WatchSecretsRotationChanges returns a watcher for each unit notifying
+// of the URIs of secrets owned by its application whose rotation
+// schedule has changed.
+func (s *SecretsAPI) WatchSecretsRotationChanges(args params.Entities) (params.StringsWatchResults, error) {
+	result := params.StringsWatchResults{
+		Results: make([]params.StringsWatchResult, len(args.Entities)),
+	}
+	canAccess, err := s.accessUnit()
+	if err != nil {
+		return params.StringsWatchResults{}, err
+	}
+	for i, entity := range args.Entities {
+		id, changes, err := s.watchRotationChanges(canAccess, entity.Tag)
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		result.Results[i].StringsWatcherId = id
+		result.Results[i].Changes = changes
+	}
+	return result, nil
+}
+
+func (s *SecretsAPI) watchRotationChanges(canAccess common.AuthFunc, tag string) (string, []string, error) {
+	unitTag, err := s.authUnit(canAccess, tag)
+	if err != nil {
+		return "", nil, err
+	}
+	w := s.secrets.WatchSecretsRotationChanges(ownerOf(unitTag))
+	// Consume the initial event.
+	if changes, ok := <-w.Changes(); ok {
+		return s.resources.Register(w), changes, nil
+	}
+	return "", nil, watcher.EnsureErr(w)
+}
+
+// SecretsRotationInfo returns when each secret owned by the application
+// of the unit is next due to be rotated.
+func (s *SecretsAPI) SecretsRotationInfo(args params.GetSecretValueArgs) (params.SecretRotationInfoResults, error) {
+	result := params.SecretRotationInfoResults{
+		Results: make([]params.SecretRotationInfoResult, len(args.Args)),
+	}
+	canAccess, err := s.accessUnit()
+	if err != nil {
+		return params.SecretRotationInfoResults{}, err
+	}
+	for i, arg := range args.Args {
+		info, err := s.rotationInfo(canAccess, arg)
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		result.Results[i].Result = info
+	}
+	return result, nil
+}
+
+func (s *SecretsAPI) rotationInfo(canAccess common.AuthFunc, arg params.GetSecretValueArg) (*params.SecretRotationInfo, error) {
+	unitTag, err := s.authUnit(canAccess, arg.UnitTag)
+	if err != nil {
+		return nil, err
+	}
+	uri, err := secrets.ParseURI(arg.URI)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	md, err := s.secrets.GetSecret(uri)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if md.OwnerTag != ownerOf(unitTag).String() {
+		return nil, apiservererrors.ErrPerm
+	}
+	return &params.SecretRotationInfo{
+		URI:            uri.String(),
+		RotatePolicy:   string(md.RotatePolicy),
+		NextRotateTime: md.NextRotateTime,
+	}, nil
+}
+
+// SecretsRotated records that secrets owned by the application of each
+// unit, which must be its leader, have been rotated.
+func (s *SecretsAPI) SecretsRotated(args params.SecretRotatedArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := s.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		err := s.secretRotated(canAccess, arg)
+		result.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return result, nil
+}
+
+func (s *SecretsAPI) secretRotated(canAccess common.AuthFunc, arg params.SecretRotatedArg) error {
+	unitTag, err := s.authUnit(canAccess, arg.UnitTag)
+	if err != nil {
+		return err
+	}
+	uri, err := secrets.ParseURI(arg.URI)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := s.authOwner(unitTag, uri); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(s.secrets.SecretRotated(uri, arg.When))
+}
@@ -0,0 +1,512 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/agent/uniter"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing"
+)
+
+type secretsSuite struct {
+	testing.BaseSuite
+
+	clock             *testclock.Clock
+	resources         *common.Resources
+	secrets           *mockSecretsState
+	backend           *mockSecretsBackend
+	leadershipChecker *fakeLeadershipChecker
+	authTag           names.Tag
+	api               *uniter.SecretsAPI
+}
+
+var _ = gc.Suite(&secretsSuite{})
+
+func (s *secretsSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	s.resources = common.NewResources()
+	s.secrets = newMockSecretsState()
+	s.backend = &mockSecretsBackend{content: make(map[string]secrets.SecretData)}
+	s.leadershipChecker = &fakeLeadershipChecker{isLeader: true}
+	s.authTag = names.NewUnitTag("mariadb/0")
+
+	getCanAccess := func() (common.AuthFunc, error) {
+		return func(tag names.Tag) bool {
+			return tag == s.authTag
+		}, nil
+	}
+	s.api = uniter.NewSecretsAPI(
+		s.secrets,
+		func() (secrets.Backend, error) { return s.backend, nil },
+		func(backendType string) (secrets.Backend, error) {
+			c.Assert(backendType, gc.Equals, "mock")
+			return s.backend, nil
+		},
+		s.resources, s.leadershipChecker, getCanAccess, s.clock,
+	)
+}
+
+func (s *secretsSuite) createSecret(c *gc.C, arg params.CreateSecretArg) string {
+	if arg.UnitTag == "" {
+		arg.UnitTag = s.authTag.String()
+	}
+	results, err := s.api.CreateSecrets(params.CreateSecretArgs{
+		Args: []params.CreateSecretArg{arg},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	return results.Results[0].Result
+}
+
+func (s *secretsSuite) TestCreateSecrets(c *gc.C) {
+	expire := s.clock.Now().Add(time.Hour)
+	uriStr := s.createSecret(c, params.CreateSecretArg{
+		RotatePolicy: "daily",
+		ExpireTime:   &expire,
+		Description:  "my secret",
+		Label:        "foobar",
+		Data:         map[string]string{"password": "secret"},
+	})
+	uri, err := secrets.ParseURI(uriStr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	p := s.secrets.created[uri.ID]
+	c.Assert(p, jc.DeepEquals, state.CreateSecretParams{
+		Owner:        names.NewApplicationTag("mariadb"),
+		Description:  "my secret",
+		Label:        "foobar",
+		RotatePolicy: secrets.RotateDaily,
+		ExpireTime:   &expire,
+		ValueRef:     secrets.ValueRef{BackendType: "mock", ID: uri.ID + "/1"},
+	})
+	c.Assert(s.backend.content[uri.ID+"/1"], jc.DeepEquals, secrets.SecretData{"password": "secret"})
+}
+
+func (s *secretsSuite) TestCreateSecretsErrors(c *gc.C) {
+	results, err := s.api.CreateSecrets(params.CreateSecretArgs{
+		Args: []params.CreateSecretArg{{
+			UnitTag: "unit-other-0",
+			Data:    map[string]string{"password": "secret"},
+		}, {
+			UnitTag:      s.authTag.String(),
+			RotatePolicy: "sometimes",
+			Data:         map[string]string{"password": "secret"},
+		}, {
+			UnitTag: s.authTag.String(),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "permission denied")
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `secret rotate policy "sometimes" not valid`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, "empty secret data not valid")
+	c.Assert(s.backend.content, gc.HasLen, 0)
+}
+
+func (s *secretsSuite) TestCreateSecretsNotLeader(c *gc.C) {
+	s.leadershipChecker.isLeader = false
+	results, err := s.api.CreateSecrets(params.CreateSecretArgs{
+		Args: []params.CreateSecretArg{{
+			UnitTag: s.authTag.String(),
+			Data:    map[string]string{"password": "secret"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `"mariadb/0" is not leader of "mariadb"`)
+	c.Assert(s.backend.content, gc.HasLen, 0)
+}
+
+func (s *secretsSuite) TestUpdateSecretsAddsRevision(c *gc.C) {
+	uri := s.createSecret(c, params.CreateSecretArg{
+		Data: map[string]string{"password": "secret"},
+	})
+	description := "new description"
+	policy := "hourly"
+	results, err := s.api.UpdateSecrets(params.UpdateSecretArgs{
+		Args: []params.UpdateSecretArg{{
+			UnitTag:      s.authTag.String(),
+			URI:          uri,
+			Description:  &description,
+			RotatePolicy: &policy,
+			Data:         map[string]string{"password": "another"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+
+	values, err := s.api.GetSecretValues(params.GetSecretValueArgs{
+		Args: []params.GetSecretValueArg{{UnitTag: s.authTag.String(), URI: uri}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values.Results, jc.DeepEquals, []params.SecretValueResult{{
+		Data: map[string]string{"password": "another"},
+	}})
+	md, err := s.secrets.GetSecret(mustParseURI(c, uri))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(md.Description, gc.Equals, "new description")
+	c.Assert(md.RotatePolicy, gc.Equals, secrets.RotateHourly)
+	c.Assert(md.LatestRevision, gc.Equals, 2)
+}
+
+func (s *secretsSuite) TestUpdateSecretsNotOwner(c *gc.C) {
+	uri := s.createSecret(c, params.CreateSecretArg{
+		Data: map[string]string{"password": "secret"},
+	})
+	s.authTag = names.NewUnitTag("wordpress/0")
+	results, err := s.api.UpdateSecrets(params.UpdateSecretArgs{
+		Args: []params.UpdateSecretArg{{
+			UnitTag: s.authTag.String(),
+			URI:     uri,
+			Data:    map[string]string{"password": "another"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), gc.ErrorMatches, "permission denied")
+}
+
+func (s *secretsSuite) grant(c *gc.C, uri, subject, role string) {
+	results, err := s.api.SecretsGrant(params.GrantRevokeSecretArgs{
+		Args: []params.GrantRevokeSecretArg{{
+			UnitTag:     s.authTag.String(),
+			URI:         uri,
+			SubjectTags: []string{subject},
+			Role:        role,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+}
+
+func (s *secretsSuite) TestUpdateSecretsViewRole(c *gc.C) {
+	uri := s.createSecret(c, params.CreateSecretArg{
+		Data: map[string]string{"password": "secret"},
+	})
+	s.grant(c, uri, "unit-wordpress-0", "view")
+	s.authTag = names.NewUnitTag("wordpress/0")
+	results, err := s.api.UpdateSecrets(params.UpdateSecretArgs{
+		Args: []params.UpdateSecretArg{{
+			UnitTag: s.authTag.String(),
+			URI:     uri,
+			Data:    map[string]string{"password": "another"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), gc.ErrorMatches, "permission denied")
+}
+
+func (s *secretsSuite) TestManageRole(c *gc.C) {
+	uri := s.createSecret(c, params.CreateSecretArg{
+		Data: map[string]string{"password": "secret"},
+	})
+	s.grant(c, uri, "unit-wordpress-0", "manage")
+
+	// A unit granted the manage role may update the secret and share
+	// it, without leading its application.
+	s.leadershipChecker.isLeader = false
+	s.authTag = names.NewUnitTag("wordpress/0")
+	results, err := s.api.UpdateSecrets(params.UpdateSecretArgs{
+		Args: []params.UpdateSecretArg{{
+			UnitTag: s.authTag.String(),
+			URI:     uri,
+			Data:    map[string]string{"password": "another"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+	s.grant(c, uri, "application-mysql", "view")
+
+	role, err := s.secrets.SecretAccess(mustParseURI(c, uri), names.NewApplicationTag("mysql"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(role, gc.Equals, secrets.RoleView)
+	md, err := s.secrets.GetSecret(mustParseURI(c, uri))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(md.LatestRevision, gc.Equals, 2)
+}
+
+func (s *secretsSuite) TestManageRoleApplicationNeedsLeader(c *gc.C) {
+	uri := s.createSecret(c, params.CreateSecretArg{
+		Data: map[string]string{"password": "secret"},
+	})
+	s.grant(c, uri, "application-wordpress", "manage")
+
+	s.leadershipChecker.isLeader = false
+	s.authTag = names.NewUnitTag("wordpress/1")
+	results, err := s.api.SecretsGrant(params.GrantRevokeSecretArgs{
+		Args: []params.GrantRevokeSecretArg{{
+			UnitTag:     s.authTag.String(),
+			URI:         uri,
+			SubjectTags: []string{"application-mysql"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), gc.ErrorMatches, `"wordpress/1" is not leader of "wordpress"`)
+
+	s.leadershipChecker.isLeader = true
+	s.grant(c, uri, "application-mysql", "view")
+}
+
+func (s *secretsSuite) TestGetSecretValuesGranted(c *gc.C) {
+	uri := s.createSecret(c, params.CreateSecretArg{
+		Data: map[string]string{"password": "secret"},
+	})
+	wordpress := names.NewUnitTag("wordpress/0")
+	get := func() params.SecretValueResult {
+		s.authTag = wordpress
+		defer func() { s.authTag = names.NewUnitTag("mariadb/0") }()
+		values, err := s.api.GetSecretValues(params.GetSecretValueArgs{
+			Args: []params.GetSecretValueArg{{UnitTag: wordpress.String(), URI: uri}},
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		return values.Results[0]
+	}
+	c.Assert(get().Error, gc.ErrorMatches, "permission denied")
+
+	results, err := s.api.SecretsGrant(params.GrantRevokeSecretArgs{
+		Args: []params.GrantRevokeSecretArg{{
+			UnitTag:     s.authTag.String(),
+			URI:         uri,
+			SubjectTags: []string{"application-wordpress"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+	c.Assert(get(), jc.DeepEquals, params.SecretValueResult{
+		Data: map[string]string{"password": "secret"},
+	})
+
+	results, err = s.api.SecretsRevoke(params.GrantRevokeSecretArgs{
+		Args: []params.GrantRevokeSecretArg{{
+			UnitTag:     s.authTag.String(),
+			URI:         uri,
+			SubjectTags: []string{"application-wordpress"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+	c.Assert(get().Error, gc.ErrorMatches, "permission denied")
+}
+
+func (s *secretsSuite) TestGrantInvalidRole(c *gc.C) {
+	uri := s.createSecret(c, params.CreateSecretArg{
+		Data: map[string]string{"password": "secret"},
+	})
+	results, err := s.api.SecretsGrant(params.GrantRevokeSecretArgs{
+		Args: []params.GrantRevokeSecretArg{{
+			UnitTag:     s.authTag.String(),
+			URI:         uri,
+			SubjectTags: []string{"application-wordpress"},
+			Role:        "owner",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), gc.ErrorMatches, `secret role "owner" not valid`)
+}
+
+func (s *secretsSuite) TestGetSecretValuesExpired(c *gc.C) {
+	expire := s.clock.Now().Add(time.Hour)
+	uri := s.createSecret(c, params.CreateSecretArg{
+		ExpireTime: &expire,
+		Data:       map[string]string{"password": "secret"},
+	})
+	s.clock.Advance(time.Hour)
+	values, err := s.api.GetSecretValues(params.GetSecretValueArgs{
+		Args: []params.GetSecretValueArg{{UnitTag: s.authTag.String(), URI: uri}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values.Results[0].Error, gc.ErrorMatches, `secret ".*" expired at 2020-01-01T01:00:00Z`)
+}
+
+func (s *secretsSuite) TestWatchSecretsRotationChanges(c *gc.C) {
+	w := &mockStringsWatcher{changes: make(chan []string, 1)}
+	w.changes <- []string{"secret:1234"}
+	s.secrets.watcher = w
+	results, err := s.api.WatchSecretsRotationChanges(params.Entities{
+		Entities: []params.Entity{{Tag: s.authTag.String()}, {Tag: "unit-other-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0], jc.DeepEquals, params.StringsWatchResult{
+		StringsWatcherId: "1",
+		Changes:          []string{"secret:1234"},
+	})
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, "permission denied")
+	c.Assert(s.secrets.watched, jc.DeepEquals, []names.ApplicationTag{names.NewApplicationTag("mariadb")})
+	c.Assert(s.resources.Get("1"), gc.Equals, w)
+}
+
+func (s *secretsSuite) TestSecretsRotationInfoAndRotated(c *gc.C) {
+	uri := s.createSecret(c, params.CreateSecretArg{
+		RotatePolicy: "hourly",
+		Data:         map[string]string{"password": "secret"},
+	})
+	now := s.clock.Now()
+	results, err := s.api.SecretsRotated(params.SecretRotatedArgs{
+		Args: []params.SecretRotatedArg{{UnitTag: s.authTag.String(), URI: uri, When: now}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+
+	info, err := s.api.SecretsRotationInfo(params.GetSecretValueArgs{
+		Args: []params.GetSecretValueArg{{UnitTag: s.authTag.String(), URI: uri}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	next := now.Add(time.Hour)
+	c.Assert(info.Results, jc.DeepEquals, []params.SecretRotationInfoResult{{
+		Result: &params.SecretRotationInfo{
+			URI:            uri,
+			RotatePolicy:   "hourly",
+			NextRotateTime: &next,
+		},
+	}})
+}
+
+func mustParseURI(c *gc.C, s string) *secrets.URI {
+	uri, err := secrets.ParseURI(s)
+	c.Assert(err, jc.ErrorIsNil)
+	return uri
+}
+
+type mockSecretsState struct {
+	uniter.SecretsState
+
+	created map[string]state.CreateSecretParams
+	md      map[string]*secrets.SecretMetadata
+	refs    map[string]secrets.ValueRef
+	access  map[string]secrets.SecretRole
+	watcher state.StringsWatcher
+	watched []names.ApplicationTag
+}
+
+func newMockSecretsState() *mockSecretsState {
+	return &mockSecretsState{
+		created: make(map[string]state.CreateSecretParams),
+		md:      make(map[string]*secrets.SecretMetadata),
+		refs:    make(map[string]secrets.ValueRef),
+		access:  make(map[string]secrets.SecretRole),
+	}
+}
+
+func (m *mockSecretsState) CreateSecret(uri *secrets.URI, p state.CreateSecretParams) (*secrets.SecretMetadata, error) {
+	m.created[uri.ID] = p
+	md := &secrets.SecretMetadata{
+		URI:              uri,
+		OwnerTag:         p.Owner.String(),
+		Description:      p.Description,
+		Label:            p.Label,
+		LatestRevision:   1,
+		LatestExpireTime: p.ExpireTime,
+		RotatePolicy:     p.RotatePolicy,
+	}
+	m.md[uri.ID] = md
+	m.refs[uri.ID+"/1"] = p.ValueRef
+	return md, nil
+}
+
+func (m *mockSecretsState) UpdateSecret(uri *secrets.URI, p state.UpdateSecretParams) (*secrets.SecretMetadata, error) {
+	md, ok := m.md[uri.ID]
+	if !ok {
+		return nil, errors.NotFoundf("secret %q", uri)
+	}
+	if p.Description != nil {
+		md.Description = *p.Description
+	}
+	if p.RotatePolicy != nil {
+		md.RotatePolicy = *p.RotatePolicy
+	}
+	if p.ValueRef != nil {
+		md.LatestRevision++
+		md.LatestExpireTime = p.ExpireTime
+		m.refs[secretRevisionKey(uri, md.LatestRevision)] = *p.ValueRef
+	}
+	return md, nil
+}
+
+func (m *mockSecretsState) GetSecret(uri *secrets.URI) (*secrets.SecretMetadata, error) {
+	md, ok := m.md[uri.ID]
+	if !ok {
+		return nil, errors.NotFoundf("secret %q", uri)
+	}
+	return md, nil
+}
+
+func (m *mockSecretsState) GetSecretValueRef(uri *secrets.URI, revision int) (*secrets.ValueRef, error) {
+	ref, ok := m.refs[secretRevisionKey(uri, revision)]
+	if !ok {
+		return nil, errors.NotFoundf("secret %q revision %d", uri, revision)
+	}
+	return &ref, nil
+}
+
+func (m *mockSecretsState) GrantSecretAccess(uri *secrets.URI, p state.SecretAccessParams) error {
+	m.access[uri.ID+"#"+p.Subject.String()] = p.Role
+	return nil
+}
+
+func (m *mockSecretsState) RevokeSecretAccess(uri *secrets.URI, subject names.Tag) error {
+	delete(m.access, uri.ID+"#"+subject.String())
+	return nil
+}
+
+func (m *mockSecretsState) SecretAccess(uri *secrets.URI, subject names.Tag) (secrets.SecretRole, error) {
+	return m.access[uri.ID+"#"+subject.String()], nil
+}
+
+func (m *mockSecretsState) SecretRotated(uri *secrets.URI, when time.Time) error {
+	md, ok := m.md[uri.ID]
+	if !ok {
+		return errors.NotFoundf("secret %q", uri)
+	}
+	md.NextRotateTime = md.RotatePolicy.NextRotateTime(when)
+	return nil
+}
+
+func (m *mockSecretsState) WatchSecretsRotationChanges(owner names.ApplicationTag) state.StringsWatcher {
+	m.watched = append(m.watched, owner)
+	return m.watcher
+}
+
+func secretRevisionKey(uri *secrets.URI, revision int) string {
+	return fmt.Sprintf("%s/%d", uri.ID, revision)
+}
+
+type mockSecretsBackend struct {
+	content map[string]secrets.SecretData
+}
+
+func (m *mockSecretsBackend) Type() string {
+	return "mock"
+}
+
+func (m *mockSecretsBackend) SaveContent(uri *secrets.URI, revision int, data secrets.SecretData) (string, error) {
+	id := secretRevisionKey(uri, revision)
+	m.content[id] = data
+	return id, nil
+}
+
+func (m *mockSecretsBackend) GetContent(id string) (secrets.SecretData, error) {
+	data, ok := m.content[id]
+	if !ok {
+		return nil, errors.NotFoundf("secret content %q", id)
+	}
+	return data, nil
+}
+
+func (m *mockSecretsBackend) DeleteContent(id string) error {
+	delete(m.content, id)
+	return nil
+}
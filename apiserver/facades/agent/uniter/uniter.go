@@ -18,6 +18,7 @@ import (
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/common/cloudspec"
+	"github.com/juju/juju/apiserver/common/secretbackend"
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facade"
 	leadershipapiserver "github.com/juju/juju/apiserver/facades/agent/leadership"
@@ -25,10 +26,12 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/caas"
 	k8sspecs "github.com/juju/juju/caas/kubernetes/provider/specs"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/cache"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/life"
 	corenetwork "github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/secrets"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/network"
@@ -39,8 +42,8 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.uniter")
 
-// UniterAPI implements the latest version (v16) of the Uniter API, which adds
-// LXDProfileAPIv2.
+// UniterAPI implements the latest version (v18) of the Uniter API, which adds
+// the secrets API.
 type UniterAPI struct {
 	*common.LifeGetter
 	*StatusAPI
@@ -66,6 +69,7 @@ type UniterAPI struct {
 	accessMachine       common.GetAuthFunc
 	containerBrokerFunc caas.NewContainerBrokerFunc
 	*StorageAPI
+	*SecretsAPI
 
 	// cacheModel is used to access data from the cache in lieu of going
 	// to the database.
@@ -79,11 +83,47 @@ type UniterAPI struct {
 	cloudSpec       cloudspec.CloudSpecAPI
 }
 
+// UniterAPIV21 implements version (v21) of the Uniter API, which adds
+// ResourceLimits.
+type UniterAPIV21 struct {
+	UniterAPI
+}
+
+// UniterAPIV20 implements version (v20) of the Uniter API, which adds
+// TraceUntil.
+type UniterAPIV20 struct {
+	UniterAPIV21
+}
+
+// UniterAPIV19 implements version (v19) of the Uniter API, which adds
+// SetHealthChecks.
+type UniterAPIV19 struct {
+	UniterAPIV20
+}
+
+// UniterAPIV18 implements version (v18) of the Uniter API, which adds
+// secrets.
+type UniterAPIV18 struct {
+	UniterAPIV19
+}
+
+// UniterAPIV17 implements version (v17) of the Uniter API, which adds
+// RecordHookExecutions.
+type UniterAPIV17 struct {
+	UniterAPIV18
+}
+
+// UniterAPIV16 implements version (v16) of the Uniter API, which adds
+// LXDProfileAPIv2.
+type UniterAPIV16 struct {
+	UniterAPIV17
+}
+
 // UniterAPIV15 implements version (v15) of the Uniter API, which adds
 // the State, CommitHookChanges, ReadLocalApplicationSettings calls and changes
 // WatchActionNotifications to notify on action changes.
 type UniterAPIV15 struct {
-	UniterAPI
+	UniterAPIV16
 }
 
 // UniterAPIV14 implements version (v14) of the Uniter API,
@@ -219,6 +259,17 @@ func NewUniterAPI(context facade.Context) (*UniterAPI, error) {
 		return nil, err
 	}
 
+	secretsAPI := newSecretsAPI(
+		state.NewSecretsStore(st),
+		func() (secrets.Backend, error) {
+			return secretbackend.ForModel(st)
+		},
+		func(backendType string) (secrets.Backend, error) {
+			return secretbackend.New(backendType, st)
+		},
+		resources, leadershipChecker, accessUnit, aClock,
+	)
+
 	return &UniterAPI{
 		LifeGetter:                 common.NewLifeGetter(st, accessUnitOrApplication),
 		DeadEnsurer:                common.NewDeadEnsurer(st, common.RevokeLeadershipFunc(leadershipRevoker), accessUnit),
@@ -249,17 +300,84 @@ func NewUniterAPI(context facade.Context) (*UniterAPI, error) {
 		accessCloudSpec:   accessCloudSpec,
 		cloudSpec:         cloudSpec,
 		StorageAPI:        storageAPI,
+		SecretsAPI:        secretsAPI,
+	}, nil
+}
+
+// NewUniterAPIV21 creates an instance of the V21 uniter API.
+func NewUniterAPIV21(context facade.Context) (*UniterAPIV21, error) {
+	uniterAPI, err := NewUniterAPI(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV21{
+		UniterAPI: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV20 creates an instance of the V20 uniter API.
+func NewUniterAPIV20(context facade.Context) (*UniterAPIV20, error) {
+	uniterAPI, err := NewUniterAPIV21(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV20{
+		UniterAPIV21: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV19 creates an instance of the V19 uniter API.
+func NewUniterAPIV19(context facade.Context) (*UniterAPIV19, error) {
+	uniterAPI, err := NewUniterAPIV20(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV19{
+		UniterAPIV20: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV18 creates an instance of the V18 uniter API.
+func NewUniterAPIV18(context facade.Context) (*UniterAPIV18, error) {
+	uniterAPI, err := NewUniterAPIV19(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV18{
+		UniterAPIV19: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV17 creates an instance of the V17 uniter API.
+func NewUniterAPIV17(context facade.Context) (*UniterAPIV17, error) {
+	uniterAPI, err := NewUniterAPIV18(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV17{
+		UniterAPIV18: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV16 creates an instance of the V16 uniter API.
+func NewUniterAPIV16(context facade.Context) (*UniterAPIV16, error) {
+	uniterAPI, err := NewUniterAPIV17(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV16{
+		UniterAPIV17: *uniterAPI,
 	}, nil
 }
 
 // NewUniterAPIV15 creates an instance of the V15 uniter API.
 func NewUniterAPIV15(context facade.Context) (*UniterAPIV15, error) {
-	uniterAPI, err := NewUniterAPI(context)
+	uniterAPI, err := NewUniterAPIV16(context)
 	if err != nil {
 		return nil, err
 	}
 	return &UniterAPIV15{
-		UniterAPI: *uniterAPI,
+		UniterAPIV16: *uniterAPI,
 	}, nil
 }
 
@@ -3710,3 +3828,313 @@ func (u *UniterAPIV15) CanApplyLXDProfile(_ struct{}) {}
 func (u *UniterAPI) CanApplyLXDProfile(args params.Entities) (params.BoolResults, error) {
 	return u.lxdProfileAPI.CanApplyLXDProfile(args)
 }
+
+// RecordHookExecutions isn't on the v16 API.
+func (u *UniterAPIV16) RecordHookExecutions(_ struct{}) {}
+
+// RecordHookExecutions adds the given hook executions to the hook
+// history of each unit.
+func (u *UniterAPI) RecordHookExecutions(args params.HookExecutionArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		resultItem := &result.Results[i]
+		tag, err := names.ParseUnitTag(arg.Tag)
+		if err != nil {
+			resultItem.Error = apiservererrors.ServerError(err)
+			continue
+		}
+		if !canAccess(tag) {
+			resultItem.Error = apiservererrors.ServerError(apiservererrors.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			resultItem.Error = apiservererrors.ServerError(err)
+			continue
+		}
+		err = unit.RecordHookExecution(status.HookExecution{
+			Hook:     arg.Execution.Hook,
+			Started:  arg.Execution.Started,
+			Finished: arg.Execution.Finished,
+			Result:   status.HookResult(arg.Execution.Result),
+			Message:  arg.Execution.Message,
+		})
+		if err != nil {
+			resultItem.Error = apiservererrors.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
+// CreateSecrets isn't on the v17 API.
+func (u *UniterAPIV17) CreateSecrets(_ struct{}) {}
+
+// UpdateSecrets isn't on the v17 API.
+func (u *UniterAPIV17) UpdateSecrets(_ struct{}) {}
+
+// GetSecretValues isn't on the v17 API.
+func (u *UniterAPIV17) GetSecretValues(_ struct{}) {}
+
+// SecretsGrant isn't on the v17 API.
+func (u *UniterAPIV17) SecretsGrant(_ struct{}) {}
+
+// SecretsRevoke isn't on the v17 API.
+func (u *UniterAPIV17) SecretsRevoke(_ struct{}) {}
+
+// WatchSecretsRotationChanges isn't on the v17 API.
+func (u *UniterAPIV17) WatchSecretsRotationChanges(_ struct{}) {}
+
+// SecretsRotationInfo isn't on the v17 API.
+func (u *UniterAPIV17) SecretsRotationInfo(_ struct{}) {}
+
+// SecretsRotated isn't on the v17 API.
+func (u *UniterAPIV17) SecretsRotated(_ struct{}) {}
+
+// SetHealthChecks isn't on the v18 API.
+func (u *UniterAPIV18) SetHealthChecks(_ struct{}) {}
+
+// SetHealthChecks records the latest result of the given workload
+// health checks for each unit.
+func (u *UniterAPI) SetHealthChecks(args params.SetHealthCheckArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		resultItem := &result.Results[i]
+		tag, err := names.ParseUnitTag(arg.Tag)
+		if err != nil {
+			resultItem.Error = apiservererrors.ServerError(err)
+			continue
+		}
+		if !canAccess(tag) {
+			resultItem.Error = apiservererrors.ServerError(apiservererrors.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			resultItem.Error = apiservererrors.ServerError(err)
+			continue
+		}
+		err = unit.SetHealthCheck(status.HealthCheck{
+			Name:    arg.Name,
+			Status:  status.HealthStatus(arg.Status),
+			Message: arg.Message,
+		})
+		if err != nil {
+			resultItem.Error = apiservererrors.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
+// TraceUntil isn't on the v19 API.
+func (u *UniterAPIV19) TraceUntil(_ struct{}) {}
+
+// TraceUntil returns, for each unit, the time until which the hook
+// tool calls made by the unit's charm are traced, and whether their
+// output is traced. A zero time means the unit is not traced.
+func (u *UniterAPI) TraceUntil(args params.Entities) (params.UnitTraceResults, error) {
+	result := params.UnitTraceResults{
+		Results: make([]params.UnitTraceResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.UnitTraceResults{}, err
+	}
+	for i, entity := range args.Entities {
+		resultItem := &result.Results[i]
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			resultItem.Error = apiservererrors.ServerError(err)
+			continue
+		}
+		if !canAccess(tag) {
+			resultItem.Error = apiservererrors.ServerError(apiservererrors.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			resultItem.Error = apiservererrors.ServerError(err)
+			continue
+		}
+		resultItem.Until, resultItem.IncludeOutput, err = unit.TraceUntil()
+		if err != nil {
+			resultItem.Error = apiservererrors.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
+// ResourceLimits isn't on the v20 API.
+func (u *UniterAPIV20) ResourceLimits(_ struct{}) {}
+
+// ResourceLimits returns, for each unit, the resources its agent,
+// charm workload and hooks may use, as set in the application config.
+// A zero value means that resource is not limited.
+func (u *UniterAPI) ResourceLimits(args params.Entities) (params.ResourceLimitsResults, error) {
+	result := params.ResourceLimitsResults{
+		Results: make([]params.ResourceLimitsResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ResourceLimitsResults{}, err
+	}
+	for i, entity := range args.Entities {
+		resultItem := &result.Results[i]
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			resultItem.Error = apiservererrors.ServerError(err)
+			continue
+		}
+		if !canAccess(tag) {
+			resultItem.Error = apiservererrors.ServerError(apiservererrors.ErrPerm)
+			continue
+		}
+		limits, err := u.unitResourceLimits(tag)
+		if err != nil {
+			resultItem.Error = apiservererrors.ServerError(err)
+			continue
+		}
+		resultItem.Result = params.ResourceLimits{
+			MemoryMB:  limits.MemoryMB,
+			CPUShares: limits.CPUShares,
+			Tasks:     limits.Tasks,
+		}
+	}
+	return result, nil
+}
+
+// WatchResourceLimits isn't on the v20 API.
+func (u *UniterAPIV20) WatchResourceLimits(_, _ struct{}) {}
+
+// WatchResourceLimits returns a NotifyWatcher for each unit that
+// notifies when the application config, which holds the unit's
+// resource limits, changes.
+func (u *UniterAPI) WatchResourceLimits(args params.Entities) (params.NotifyWatchResults, error) {
+	result := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.NotifyWatchResults{}, err
+	}
+	for i, entity := range args.Entities {
+		resultItem := &result.Results[i]
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			resultItem.Error = apiservererrors.ServerError(err)
+			continue
+		}
+		if !canAccess(tag) {
+			resultItem.Error = apiservererrors.ServerError(apiservererrors.ErrPerm)
+			continue
+		}
+		watcherId, err := u.watchOneUnitResourceLimits(tag)
+		resultItem.NotifyWatcherId = watcherId
+		resultItem.Error = apiservererrors.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) watchOneUnitResourceLimits(tag names.UnitTag) (string, error) {
+	unit, err := u.getUnit(tag)
+	if err != nil {
+		return "", err
+	}
+	w, err := unit.WatchApplicationConfigSettings()
+	if err != nil {
+		return "", err
+	}
+	// Consume the initial event.
+	if _, ok := <-w.Changes(); ok {
+		return u.resources.Register(w), nil
+	}
+	return "", watcher.EnsureErr(w)
+}
+
+func (u *UniterAPI) unitResourceLimits(tag names.UnitTag) (application.ResourceLimits, error) {
+	unit, err := u.getUnit(tag)
+	if err != nil {
+		return application.ResourceLimits{}, err
+	}
+	app, err := unit.Application()
+	if err != nil {
+		return application.ResourceLimits{}, err
+	}
+	config, err := app.ApplicationConfig()
+	if err != nil {
+		return application.ResourceLimits{}, err
+	}
+	return application.ParseResourceLimits(config)
+}
+
+// MaintenanceWindow isn't on the v21 API.
+func (u *UniterAPIV21) MaintenanceWindow(_ struct{}) {}
+
+// MaintenanceWindow returns, for each unit, the maintenance window of
+// its application, during which the unit defers non-essential hooks,
+// and when the deferred hooks were last flushed. A window that is only
+// partly configured is ignored.
+func (u *UniterAPI) MaintenanceWindow(args params.Entities) (params.MaintenanceWindowResults, error) {
+	result := params.MaintenanceWindowResults{
+		Results: make([]params.MaintenanceWindowResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.MaintenanceWindowResults{}, err
+	}
+	for i, entity := range args.Entities {
+		resultItem := &result.Results[i]
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			resultItem.Error = apiservererrors.ServerError(err)
+			continue
+		}
+		if !canAccess(tag) {
+			resultItem.Error = apiservererrors.ServerError(apiservererrors.ErrPerm)
+			continue
+		}
+		window, err := u.unitMaintenanceWindow(tag)
+		if err != nil {
+			resultItem.Error = apiservererrors.ServerError(err)
+			continue
+		}
+		resultItem.Result = window
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) unitMaintenanceWindow(tag names.UnitTag) (params.MaintenanceWindow, error) {
+	unit, err := u.getUnit(tag)
+	if err != nil {
+		return params.MaintenanceWindow{}, err
+	}
+	app, err := unit.Application()
+	if err != nil {
+		return params.MaintenanceWindow{}, err
+	}
+	config, err := app.ApplicationConfig()
+	if err != nil {
+		return params.MaintenanceWindow{}, err
+	}
+	window, err := application.ParseMaintenanceWindow(config)
+	if err != nil {
+		logger.Warningf("ignoring maintenance window of application %q: %v", app.Name(), err)
+		return params.MaintenanceWindow{}, nil
+	}
+	return params.MaintenanceWindow{
+		Schedule: window.Schedule,
+		Duration: window.Duration,
+		Flushed:  app.DeferredHooksFlushed(),
+	}, nil
+}
@@ -995,6 +995,200 @@ func (s *uniterSuite) TestSetWorkloadVersion(c *gc.C) {
 	c.Assert(newVersion, gc.Equals, "shiro")
 }
 
+func (s *uniterSuite) TestRecordHookExecutions(c *gc.C) {
+	started := time.Date(2020, 7, 1, 10, 0, 0, 0, time.UTC)
+	execution := params.HookExecution{
+		Hook:     "install",
+		Started:  started,
+		Finished: started.Add(time.Minute),
+		Result:   "failed",
+		Message:  "exit status 1",
+	}
+	args := params.HookExecutionArgs{Args: []params.HookExecutionArg{
+		{Tag: "unit-mysql-0", Execution: execution},
+		{Tag: "unit-wordpress-0", Execution: execution},
+		{Tag: "unit-foo-42", Execution: execution},
+	}}
+	result, err := s.uniter.RecordHookExecutions(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{apiservertesting.ErrUnauthorized},
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	history, err := s.wordpressUnit.HookHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, jc.DeepEquals, []status.HookExecution{{
+		Hook:     "install",
+		Started:  started,
+		Finished: started.Add(time.Minute),
+		Result:   status.HookFailed,
+		Message:  "exit status 1",
+	}})
+}
+
+func (s *uniterSuite) TestSetHealthChecks(c *gc.C) {
+	args := params.SetHealthCheckArgs{Args: []params.SetHealthCheckArg{
+		{Tag: "unit-mysql-0", Name: "db", Status: "pass"},
+		{Tag: "unit-wordpress-0", Name: "db", Status: "warn", Message: "slow queries"},
+		{Tag: "unit-wordpress-0", Name: "db", Status: "unknown"},
+		{Tag: "unit-foo-42", Name: "db", Status: "pass"},
+	}}
+	result, err := s.uniter.SetHealthChecks(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 4)
+	c.Assert(result.Results[0].Error, gc.DeepEquals, apiservertesting.ErrUnauthorized)
+	c.Assert(result.Results[1].Error, gc.IsNil)
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `health status "unknown" not valid`)
+	c.Assert(result.Results[3].Error, gc.DeepEquals, apiservertesting.ErrUnauthorized)
+
+	checks, err := s.wordpressUnit.HealthChecks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(checks, gc.HasLen, 1)
+	c.Assert(checks[0].Name, gc.Equals, "db")
+	c.Assert(checks[0].Status, gc.Equals, status.HealthWarn)
+	c.Assert(checks[0].Message, gc.Equals, "slow queries")
+}
+
+func (s *uniterSuite) TestTraceUntil(c *gc.C) {
+	until := time.Date(2020, 7, 1, 10, 10, 0, 0, time.UTC)
+	err := s.wordpressUnit.SetTraceUntil(until, true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-foo-42"},
+	}}
+	result, err := s.uniter.TraceUntil(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.UnitTraceResults{
+		Results: []params.UnitTraceResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Until: until, IncludeOutput: true},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *uniterSuite) TestResourceLimits(c *gc.C) {
+	schema := environschema.Fields{
+		coreapplication.UnitMemoryLimitKey: environschema.Attr{Type: environschema.Tstring},
+		coreapplication.UnitCPUSharesKey:   environschema.Attr{Type: environschema.Tint},
+	}
+	err := s.wordpress.UpdateApplicationConfig(coreapplication.ConfigAttributes{
+		coreapplication.UnitMemoryLimitKey: "1G",
+		coreapplication.UnitCPUSharesKey:   256,
+	}, nil, schema, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-foo-42"},
+	}}
+	result, err := s.uniter.ResourceLimits(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ResourceLimitsResults{
+		Results: []params.ResourceLimitsResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Result: params.ResourceLimits{MemoryMB: 1024, CPUShares: 256}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *uniterSuite) TestWatchResourceLimits(c *gc.C) {
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-foo-42"},
+	}}
+	result, err := s.uniter.WatchResourceLimits(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.NotifyWatchResults{
+		Results: []params.NotifyWatchResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{NotifyWatcherId: "1"},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// Verify the resource was registered and stop when done
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	// Check that the Watch has consumed the initial event ("returned" in
+	// the Watch call)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	schema := environschema.Fields{
+		coreapplication.UnitMemoryLimitKey: environschema.Attr{Type: environschema.Tstring},
+	}
+	err = s.wordpress.UpdateApplicationConfig(coreapplication.ConfigAttributes{
+		coreapplication.UnitMemoryLimitKey: "1G",
+	}, nil, schema, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+func (s *uniterSuite) TestMaintenanceWindow(c *gc.C) {
+	schema := environschema.Fields{
+		coreapplication.MaintenanceWindowKey:         environschema.Attr{Type: environschema.Tstring},
+		coreapplication.MaintenanceWindowDurationKey: environschema.Attr{Type: environschema.Tstring},
+	}
+	err := s.wordpress.UpdateApplicationConfig(coreapplication.ConfigAttributes{
+		coreapplication.MaintenanceWindowKey:         "0 2 * * *",
+		coreapplication.MaintenanceWindowDurationKey: "1h",
+	}, nil, schema, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.wordpress.FlushDeferredHooks()
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-foo-42"},
+	}}
+	result, err := s.uniter.MaintenanceWindow(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.MaintenanceWindowResults{
+		Results: []params.MaintenanceWindowResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Result: params.MaintenanceWindow{
+				Schedule: "0 2 * * *",
+				Duration: time.Hour,
+				Flushed:  s.wordpress.DeferredHooksFlushed(),
+			}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *uniterSuite) TestMaintenanceWindowPartlyConfigured(c *gc.C) {
+	schema := environschema.Fields{
+		coreapplication.MaintenanceWindowKey: environschema.Attr{Type: environschema.Tstring},
+	}
+	err := s.wordpress.UpdateApplicationConfig(coreapplication.ConfigAttributes{
+		coreapplication.MaintenanceWindowKey: "0 2 * * *",
+	}, nil, schema, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{{Tag: "unit-wordpress-0"}}}
+	result, err := s.uniter.MaintenanceWindow(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.MaintenanceWindowResults{
+		Results: []params.MaintenanceWindowResult{{}},
+	})
+}
+
 func (s *uniterSuite) TestCharmModifiedVersion(c *gc.C) {
 	args := params.Entities{Entities: []params.Entity{
 		{Tag: "application-mysql"},
@@ -11,9 +11,13 @@ import (
 	"math"
 	"net"
 	"reflect"
+	"strings"
+	"time"
 
 	"github.com/juju/charm/v7"
+	"github.com/juju/charm/v7/hooks"
 	csparams "github.com/juju/charmrepo/v5/csclient/params"
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/names/v4"
@@ -23,7 +27,10 @@ import (
 	"gopkg.in/macaroon.v2"
 	goyaml "gopkg.in/yaml.v2"
 
+	jujuapi "github.com/juju/juju/api"
+	"github.com/juju/juju/api/crossmodelrelations"
 	"github.com/juju/juju/apiserver/common"
+	commoncrossmodel "github.com/juju/juju/apiserver/common/crossmodel"
 	"github.com/juju/juju/apiserver/common/storagecommon"
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facade"
@@ -37,6 +44,7 @@ import (
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
@@ -100,6 +108,36 @@ type APIv11 struct {
 // APIv12 provides the Application API facade for version 12.
 // It adds the UnitsInfo method.
 type APIv12 struct {
+	*APIv13
+}
+
+// APIv13 provides the Application API facade for version 13.
+// It adds the ConsumedApplicationsInfo method.
+type APIv13 struct {
+	*APIv14
+}
+
+// APIv14 provides the Application API facade for version 14.
+// It adds the UnitsHookHistory method.
+type APIv14 struct {
+	*APIv15
+}
+
+// APIv15 provides the Application API facade for version 15.
+// It adds the SetUnitsTrace method.
+type APIv15 struct {
+	*APIv16
+}
+
+// APIv16 provides the Application API facade for version 16.
+// It adds the application and hook filters to ResolveUnitErrors.
+type APIv16 struct {
+	*APIv17
+}
+
+// APIv17 provides the Application API facade for version 17.
+// It adds the FlushDeferredHooks method.
+type APIv17 struct {
 	*APIBase
 }
 
@@ -207,13 +245,53 @@ func NewFacadeV11(ctx facade.Context) (*APIv11, error) {
 }
 
 func NewFacadeV12(ctx facade.Context) (*APIv12, error) {
-	api, err := newFacadeBase(ctx)
+	api, err := NewFacadeV13(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv12{api}, nil
 }
 
+func NewFacadeV13(ctx facade.Context) (*APIv13, error) {
+	api, err := NewFacadeV14(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv13{api}, nil
+}
+
+func NewFacadeV14(ctx facade.Context) (*APIv14, error) {
+	api, err := NewFacadeV15(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv14{api}, nil
+}
+
+func NewFacadeV15(ctx facade.Context) (*APIv15, error) {
+	api, err := NewFacadeV16(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv15{api}, nil
+}
+
+func NewFacadeV16(ctx facade.Context) (*APIv16, error) {
+	api, err := NewFacadeV17(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv16{api}, nil
+}
+
+func NewFacadeV17(ctx facade.Context) (*APIv17, error) {
+	api, err := newFacadeBase(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv17{api}, nil
+}
+
 type caasBrokerInterface interface {
 	ValidateStorageClass(config map[string]interface{}) error
 	Version() (*version.Number, error)
@@ -451,7 +529,8 @@ func (api *APIBase) Deploy(args params.ApplicationsDeploy) (params.ErrorResults,
 
 func applicationConfigSchema(modelType state.ModelType) (environschema.Fields, schema.Defaults, error) {
 	if modelType != state.ModelTypeCAAS {
-		return trustFields, trustDefaults, nil
+		fields, defaults := iaasConfigSchema()
+		return fields, defaults, nil
 	}
 	// TODO(caas) - get the schema from the provider
 	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
@@ -693,6 +772,12 @@ func deployApplication(
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if _, err := application.ParseResourceLimits(applicationConfig.Attributes()); err != nil {
+		return errors.Trace(err)
+	}
+	if err := k8s.ValidatePodDisruptionBudgetConfig(applicationConfig.Attributes()); err != nil {
+		return errors.Trace(err)
+	}
 
 	var settings = make(charm.Settings)
 	if len(charmYamlConfig) > 0 {
@@ -1784,6 +1869,16 @@ func (api *APIBase) ScaleApplications(args params.ScaleApplicationsParams) (para
 				return nil, errors.NotSupportedf("scale a %q application", charm.DeploymentDaemon)
 			}
 		}
+		appConfig, err := app.ApplicationConfig()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if appConfig.GetInt(k8s.AutoscaleMaxConfigKey, 0) > 0 {
+			// The horizontal pod autoscaler owns the scale; changing
+			// it here would only be undone.
+			return nil, errors.NewNotSupported(nil, fmt.Sprintf(
+				"application %q is autoscaled, disable autoscaling with \"juju set-autoscale %s --disable\" before scaling it", name, name))
+		}
 
 		var info params.ScaleApplicationInfo
 		if arg.ScaleChange != 0 {
@@ -1892,6 +1987,9 @@ func (api *APIBase) AddRelation(args params.AddRelation) (_ params.AddRelationRe
 	if err != nil {
 		return params.AddRelationResults{}, errors.Trace(err)
 	}
+	if err := api.checkOfferConnections(inEps); err != nil {
+		return params.AddRelationResults{}, errors.Trace(err)
+	}
 	if rel, err = api.backend.AddRelation(inEps...); err != nil {
 		return params.AddRelationResults{}, errors.Trace(err)
 	}
@@ -1917,6 +2015,66 @@ func (api *APIBase) AddRelation(args params.AddRelation) (_ params.AddRelationRe
 	return params.AddRelationResults{Endpoints: outEps}, nil
 }
 
+// checkOfferConnection asks the controller hosting an offer whether
+// a relation to it from the specified model would be accepted.
+// It is a variable so it can be replaced in tests.
+var checkOfferConnection = func(apiInfo *jujuapi.Info, offerUUID, sourceModelUUID, sourceControllerUUID string, mac *macaroon.Macaroon) error {
+	conn, err := jujuapi.Open(apiInfo, jujuapi.DialOpts{
+		Timeout:    2 * time.Second,
+		RetryDelay: 500 * time.Millisecond,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = conn.Close() }()
+	client := crossmodelrelations.NewClient(conn)
+	return client.CheckOfferConnection(offerUUID, sourceModelUUID, sourceControllerUUID, macaroon.Slice{mac})
+}
+
+// checkOfferConnections checks any offers consumed by the endpoints
+// against the limits configured on them, so that a relation which
+// would be refused by the offering model is rejected up front.
+// The offering model enforces the limits regardless, so problems
+// contacting its controller are logged rather than returned.
+func (api *APIBase) checkOfferConnections(eps []state.Endpoint) error {
+	for _, ep := range eps {
+		remoteApp, err := api.backend.RemoteApplication(ep.ApplicationName)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		mac, err := remoteApp.Macaroon()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if mac == nil || remoteApp.OfferUUID() == "" {
+			continue
+		}
+		sourceModelTag := remoteApp.SourceModel()
+		addrs, caCert, err := api.backend.ControllerInfoForModel(sourceModelTag.Id())
+		if err != nil {
+			logger.Warningf("cannot get controller info for offer %q: %v", remoteApp.Name(), err)
+			continue
+		}
+		apiInfo := &jujuapi.Info{
+			Addrs:    addrs,
+			CACert:   caCert,
+			ModelTag: sourceModelTag,
+		}
+		err = checkOfferConnection(
+			apiInfo, remoteApp.OfferUUID(), api.model.ModelTag().Id(), api.backend.ControllerTag().Id(), mac)
+		switch {
+		case err == nil:
+		case params.IsCodeQuotaLimitExceeded(err), params.IsCodeForbidden(err):
+			return err
+		default:
+			logger.Warningf("cannot check limits for offer %q: %v", remoteApp.Name(), err)
+		}
+	}
+	return nil
+}
+
 // DestroyRelation removes the relation between the
 // specified endpoints or an id.
 func (api *APIBase) DestroyRelation(args params.DestroyRelation) (err error) {
@@ -2317,6 +2475,21 @@ func (api *APIBase) setApplicationConfig(arg params.ApplicationConfigSet) error
 	}
 
 	if len(appConfigAttrs) > 0 {
+		if err := validateResourceLimits(appConfigAttrs, configSchema, defaults); err != nil {
+			return errors.Trace(err)
+		}
+		if err := validateHookRetryPolicy(appConfigAttrs, configSchema, defaults); err != nil {
+			return errors.Trace(err)
+		}
+		if err := validatePodDisruptionBudget(appConfigAttrs, configSchema, defaults); err != nil {
+			return errors.Trace(err)
+		}
+		if err := validateMaintenanceWindow(app, appConfigAttrs, configSchema, defaults); err != nil {
+			return errors.Trace(err)
+		}
+		if err := validateAutoscale(app, appConfigAttrs, configSchema, defaults); err != nil {
+			return errors.Trace(err)
+		}
 		if err := app.UpdateApplicationConfig(appConfigAttrs, nil, configSchema, defaults); err != nil {
 			return errors.Annotate(err, "updating application config values")
 		}
@@ -2425,17 +2598,13 @@ func (api *APIBase) unsetApplicationConfig(arg params.ApplicationUnset) error {
 func (u *APIv5) ResolveUnitErrors(_, _ struct{}) {}
 
 // ResolveUnitErrors marks errors on the specified units as resolved.
+// The units may be given by tag, by application, or as all the units
+// in error, and can be restricted to those in error in given hooks.
 func (api *APIBase) ResolveUnitErrors(p params.UnitsResolved) (params.ErrorResults, error) {
-	if p.All {
-		unitsWithErrors, err := api.backend.UnitsInError()
-		if err != nil {
+	if p.All || len(p.Applications) > 0 {
+		if err := api.resolveUnitsInError(p); err != nil {
 			return params.ErrorResults{}, errors.Trace(err)
 		}
-		for _, u := range unitsWithErrors {
-			if err := u.Resolve(p.Retry); err != nil {
-				return params.ErrorResults{}, errors.Annotatef(err, "resolve error for unit %q", u.UnitTag().Id())
-			}
-		}
 	}
 
 	var result params.ErrorResults
@@ -2458,12 +2627,78 @@ func (api *APIBase) ResolveUnitErrors(p params.UnitsResolved) (params.ErrorResul
 			result.Results[i].Error = apiservererrors.ServerError(err)
 			continue
 		}
+		if len(p.Hooks) > 0 {
+			failed, err := failedInHooks(unit, p.Hooks)
+			if err == nil && !failed {
+				err = errors.Errorf("unit %q is not in error in hook %s", tag.Id(), strings.Join(p.Hooks, ", "))
+			}
+			if err != nil {
+				result.Results[i].Error = apiservererrors.ServerError(err)
+				continue
+			}
+		}
 		err = unit.Resolve(p.Retry)
 		result.Results[i].Error = apiservererrors.ServerError(err)
 	}
 	return result, nil
 }
 
+// resolveUnitsInError resolves the units in error that are selected by
+// the All, Applications and Hooks parameters.
+func (api *APIBase) resolveUnitsInError(p params.UnitsResolved) error {
+	applications := set.NewStrings(p.Applications...)
+	for _, name := range p.Applications {
+		if _, err := api.backend.Application(name); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	unitsWithErrors, err := api.backend.UnitsInError()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, u := range unitsWithErrors {
+		if !p.All && !applications.Contains(u.ApplicationName()) {
+			continue
+		}
+		if len(p.Hooks) > 0 {
+			failed, err := failedInHooks(u, p.Hooks)
+			if err != nil {
+				return errors.Annotatef(err, "getting status of unit %q", u.UnitTag().Id())
+			}
+			if !failed {
+				continue
+			}
+		}
+		if err := u.Resolve(p.Retry); err != nil {
+			return errors.Annotatef(err, "resolve error for unit %q", u.UnitTag().Id())
+		}
+	}
+	return nil
+}
+
+// failedInHooks reports whether the unit is in error in one of the
+// given hooks. A relation hook kind, such as relation-changed, matches
+// that hook for every relation.
+func failedInHooks(unit Unit, hookNames []string) (bool, error) {
+	agentStatus, err := unit.AgentStatus()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if agentStatus.Status != status.Error {
+		return false, nil
+	}
+	failed, _ := agentStatus.Data["hook"].(string)
+	for _, name := range hookNames {
+		if failed == name {
+			return true, nil
+		}
+		if hooks.Kind(name).IsRelation() && strings.HasSuffix(failed, "-"+name) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // ApplicationInfo isn't on the v8 API.
 func (u *APIv8) ApplicationInfo(_, _ struct{}) {}
 
@@ -2726,6 +2961,13 @@ func (api *APIBase) UnitsInfo(in params.Entities) (params.UnitInfoResults, error
 				result.OpenedPorts = container.Ports()
 			}
 		}
+		if api.modelType != state.ModelTypeCAAS {
+			result.ResourceLimits, err = unitResourceLimits(app)
+			if err != nil {
+				out[i].Error = apiservererrors.ServerError(err)
+				continue
+			}
+		}
 		result.RelationData, err = api.relationData(app, unit)
 		if err != nil {
 			out[i].Error = apiservererrors.ServerError(err)
@@ -2864,3 +3106,197 @@ func (api *APIBase) crossModelRelationData(rel Relation, appName string, erd *pa
 	}
 	return nil
 }
+
+// ConsumedApplicationsInfo isn't on the v12 API.
+func (u *APIv12) ConsumedApplicationsInfo(_, _ struct{}) {}
+
+// ConsumedApplicationsInfo returns information about applications
+// consumed from offers, including the diagnostics of their cross
+// model relations.
+func (api *APIBase) ConsumedApplicationsInfo(in params.Entities) (params.ConsumedApplicationInfoResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.ConsumedApplicationInfoResults{}, errors.Trace(err)
+	}
+	out := make([]params.ConsumedApplicationInfoResult, len(in.Entities))
+	for i, one := range in.Entities {
+		tag, err := names.ParseApplicationTag(one.Tag)
+		if err != nil {
+			out[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		info, err := api.consumedApplicationInfo(tag.Name)
+		if err != nil {
+			out[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		out[i].Result = info
+	}
+	return params.ConsumedApplicationInfoResults{Results: out}, nil
+}
+
+func (api *APIBase) consumedApplicationInfo(name string) (*params.ConsumedApplicationInfo, error) {
+	app, err := api.backend.RemoteApplication(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	offerURL, _ := app.URL()
+	info := &params.ConsumedApplicationInfo{
+		Name:           app.Name(),
+		OfferURL:       offerURL,
+		OfferUUID:      app.OfferUUID(),
+		SourceModelTag: app.SourceModel().String(),
+		Life:           life.Value(app.Life().String()),
+	}
+	appStatus, err := app.Status()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	info.Status = params.EntityStatus{
+		Status: appStatus.Status,
+		Info:   appStatus.Message,
+		Data:   appStatus.Data,
+		Since:  appStatus.Since,
+	}
+	eps, err := app.Endpoints()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, ep := range eps {
+		info.Endpoints = append(info.Endpoints, params.RemoteEndpoint{
+			Name:      ep.Name,
+			Interface: ep.Interface,
+			Role:      ep.Role,
+			Limit:     ep.Limit,
+		})
+	}
+	diagnostics, err := app.Diagnostics()
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	info.Diagnostics = commoncrossmodel.DiagnosticsToParams(diagnostics)
+	return info, nil
+}
+
+// UnitsHookHistory isn't on the v13 API.
+func (u *APIv13) UnitsHookHistory(_, _ struct{}) {}
+
+// UnitsHookHistory returns the hooks recently run on each unit, with
+// their timings and results, most recent first.
+func (api *APIBase) UnitsHookHistory(in params.Entities) (params.HookHistoryResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.HookHistoryResults{}, errors.Trace(err)
+	}
+	out := make([]params.HookHistoryResult, len(in.Entities))
+	for i, one := range in.Entities {
+		tag, err := names.ParseUnitTag(one.Tag)
+		if err != nil {
+			out[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		unit, err := api.backend.Unit(tag.Id())
+		if err != nil {
+			out[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		history, err := unit.HookHistory()
+		if err != nil {
+			out[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		executions := make([]params.HookExecution, len(history))
+		for j, execution := range history {
+			executions[j] = params.HookExecution{
+				Hook:     execution.Hook,
+				Started:  execution.Started,
+				Finished: execution.Finished,
+				Result:   string(execution.Result),
+				Message:  execution.Message,
+			}
+		}
+		out[i].Executions = executions
+	}
+	return params.HookHistoryResults{Results: out}, nil
+}
+
+// SetUnitsTrace isn't on the v14 API.
+func (u *APIv14) SetUnitsTrace(_, _ struct{}) {}
+
+// maxUnitTraceDuration is how far ahead a unit's tracing may be set
+// to end. The allowance covers clock skew between the client, which
+// computes the end time, and the controller.
+const (
+	maxUnitTraceDuration   = 24 * time.Hour
+	unitTraceSkewAllowance = time.Minute
+)
+
+// SetUnitsTrace sets until when the hook tool calls made by each
+// unit's charm are traced, and whether their output is traced. A zero
+// time stops tracing the unit. Tracing may last at most 24 hours, and
+// only model admins may trace the tools' output, as it may include
+// secrets.
+func (api *APIBase) SetUnitsTrace(in params.UnitTraceArgs) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	// The API has no clock, so the wall clock bounds the end time.
+	latest := time.Now().Add(maxUnitTraceDuration + unitTraceSkewAllowance)
+	out := make([]params.ErrorResult, len(in.Args))
+	for i, arg := range in.Args {
+		if arg.IncludeOutput {
+			if err := api.checkPermission(api.model.ModelTag(), permission.AdminAccess); err != nil {
+				out[i].Error = apiservererrors.ServerError(err)
+				continue
+			}
+		}
+		if arg.Until.After(latest) {
+			out[i].Error = apiservererrors.ServerError(errors.NotValidf("trace duration longer than %s", maxUnitTraceDuration))
+			continue
+		}
+		tag, err := names.ParseUnitTag(arg.Tag)
+		if err != nil {
+			out[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		unit, err := api.backend.Unit(tag.Id())
+		if err != nil {
+			out[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		if err := unit.SetTraceUntil(arg.Until, arg.IncludeOutput); err != nil {
+			out[i].Error = apiservererrors.ServerError(err)
+		}
+	}
+	return params.ErrorResults{Results: out}, nil
+}
+
+// FlushDeferredHooks isn't on the v16 API.
+func (u *APIv16) FlushDeferredHooks(_, _ struct{}) {}
+
+// FlushDeferredHooks has the units of each application run the hooks
+// deferred by the application's maintenance window now, rather than
+// when the window closes.
+func (api *APIBase) FlushDeferredHooks(in params.Entities) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	out := make([]params.ErrorResult, len(in.Entities))
+	for i, entity := range in.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			out[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		app, err := api.backend.Application(tag.Id())
+		if err != nil {
+			out[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		if err := app.FlushDeferredHooks(); err != nil {
+			out[i].Error = apiservererrors.ServerError(err)
+		}
+	}
+	return params.ErrorResults{Results: out}, nil
+}
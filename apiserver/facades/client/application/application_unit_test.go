@@ -17,7 +17,9 @@ import (
 	"github.com/juju/utils"
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/macaroon.v2"
 
+	"github.com/juju/juju/api"
 	apitesting "github.com/juju/juju/api/testing"
 	"github.com/juju/juju/apiserver/common"
 	apiservererrors "github.com/juju/juju/apiserver/errors"
@@ -30,6 +32,7 @@ import (
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
@@ -59,7 +62,7 @@ type ApplicationSuite struct {
 	env          environs.Environ
 	blockChecker mockBlockChecker
 	authorizer   apiservertesting.FakeAuthorizer
-	api          *application.APIv12
+	api          *application.APIv17
 	deployParams map[string]application.DeployApplicationParams
 }
 
@@ -90,7 +93,7 @@ func (s *ApplicationSuite) setAPIUser(c *gc.C, user names.UserTag) {
 		s.caasBroker,
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = &application.APIv12{api}
+	s.api = &application.APIv17{api}
 }
 
 func (s *ApplicationSuite) SetUpTest(c *gc.C) {
@@ -1021,7 +1024,7 @@ func (s *ApplicationSuite) TestScaleApplicationsCAASModel(c *gc.C) {
 		}},
 	})
 	app := s.backend.applications["postgresql"]
-	app.CheckCall(c, 1, "Scale", 5)
+	app.CheckCall(c, 2, "Scale", 5)
 }
 
 func (s *ApplicationSuite) TestScaleApplicationsNotAllowedForOperator(c *gc.C) {
@@ -1100,7 +1103,25 @@ func (s *ApplicationSuite) TestScaleApplicationsCAASModelScaleChange(c *gc.C) {
 		}},
 	})
 	app := s.backend.applications["postgresql"]
-	app.CheckCall(c, 1, "ChangeScale", 5)
+	app.CheckCall(c, 2, "ChangeScale", 5)
+}
+
+func (s *ApplicationSuite) TestScaleApplicationsNotAllowedWhenAutoscaled(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	s.backend.applications["postgresql"].config = coreapplication.ConfigAttributes{
+		"kubernetes-autoscale-max": 10,
+	}
+	result, err := s.api.ScaleApplications(params.ScaleApplicationsParams{
+		Applications: []params.ScaleApplicationParams{{
+			ApplicationTag: "application-postgresql",
+			Scale:          5,
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches,
+		`application "postgresql" is autoscaled, disable autoscaling with "juju set-autoscale postgresql --disable" before scaling it`)
+	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "Charm", "ApplicationConfig")
 }
 
 func (s *ApplicationSuite) TestScaleApplicationsCAASModelScaleArgCheck(c *gc.C) {
@@ -1637,6 +1658,57 @@ func (s *ApplicationSuite) TestRemoteRelationDisAllowedCIDR(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `CIDR "0.0.0.0/0" not allowed`)
 }
 
+func (s *ApplicationSuite) setupLimitedOffer(c *gc.C, checkErr error) *[]string {
+	mac, err := apitesting.NewMacaroon("test")
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.remoteApplications["hosted-db2"] = &mockRemoteApplication{
+		name:           "hosted-db2",
+		sourceModelTag: names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f00d"),
+		offerUUID:      "offer-uuid",
+		mac:            mac,
+	}
+	s.endpoints = []state.Endpoint{
+		{ApplicationName: "postgresql"},
+		{ApplicationName: "hosted-db2"},
+	}
+	var checked []string
+	s.PatchValue(application.CheckOfferConnection, func(
+		apiInfo *api.Info, offerUUID, sourceModelUUID, sourceControllerUUID string, m *macaroon.Macaroon,
+	) error {
+		c.Check(apiInfo.ModelTag.Id(), gc.Equals, "deadbeef-0bad-400d-8000-4b1d0d06f00d")
+		c.Check(apiInfo.Addrs, jc.DeepEquals, []string{"10.0.0.1:17070"})
+		c.Check(sourceModelUUID, gc.Equals, s.model.ModelTag().Id())
+		c.Check(sourceControllerUUID, gc.Equals, coretesting.ControllerTag.Id())
+		c.Check(m, jc.DeepEquals, mac)
+		checked = append(checked, offerUUID)
+		return checkErr
+	})
+	return &checked
+}
+
+func (s *ApplicationSuite) TestAddRelationOfferLimitReached(c *gc.C) {
+	checked := s.setupLimitedOffer(c, &params.Error{
+		Code:    params.CodeQuotaLimitExceeded,
+		Message: `offer "hosted-db2" is limited to 1 relations`,
+	})
+	_, err := s.api.AddRelation(params.AddRelation{Endpoints: []string{"postgresql", "hosted-db2"}})
+	c.Assert(err, gc.ErrorMatches, `offer "hosted-db2" is limited to 1 relations`)
+	c.Assert(err, jc.Satisfies, params.IsCodeQuotaLimitExceeded)
+	c.Assert(*checked, jc.DeepEquals, []string{"offer-uuid"})
+	s.backend.CheckCallNames(c, "InferEndpoints", "RemoteApplication", "RemoteApplication", "ControllerInfoForModel")
+}
+
+func (s *ApplicationSuite) TestAddRelationOfferCheckUnavailable(c *gc.C) {
+	checked := s.setupLimitedOffer(c, errors.New("connection refused"))
+	_, err := s.api.AddRelation(params.AddRelation{Endpoints: []string{"postgresql", "hosted-db2"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*checked, jc.DeepEquals, []string{"offer-uuid"})
+	s.backend.CheckCallNames(c,
+		"InferEndpoints", "RemoteApplication", "RemoteApplication", "ControllerInfoForModel",
+		"AddRelation", "SaveEgressNetworks",
+	)
+}
+
 func (s *ApplicationSuite) TestSetApplicationConfigExplicitMaster(c *gc.C) {
 	s.testSetApplicationConfig(c, model.GenerationMaster)
 }
@@ -1708,6 +1780,162 @@ func (s *ApplicationSuite) TestSetApplicationConfigBranch(c *gc.C) {
 	s.backend.generation.CheckCall(c, 0, "AssignApplication", "postgresql")
 }
 
+func (s *ApplicationSuite) TestSetApplicationConfigResourceLimits(c *gc.C) {
+	result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "postgresql",
+			Config: map[string]string{
+				"unit-memory-limit": "2G",
+				"unit-cpu-shares":   "512",
+			},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "UpdateApplicationConfig")
+	c.Assert(app.Calls()[0].Args[0], jc.DeepEquals, coreapplication.ConfigAttributes{
+		"unit-memory-limit": "2G",
+		"unit-cpu-shares":   "512",
+	})
+}
+
+func (s *ApplicationSuite) TestSetApplicationConfigInvalidResourceLimits(c *gc.C) {
+	result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "postgresql",
+			Config: map[string]string{
+				"unit-cpu-shares": "1",
+			},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, "unit-cpu-shares 1 outside range 2-262144 not valid")
+	s.backend.applications["postgresql"].CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestSetApplicationConfigHookRetryPolicy(c *gc.C) {
+	result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "postgresql",
+			Config: map[string]string{
+				"hook-retry-max-attempts": "5",
+				"hook-retry-max-interval": "1m",
+			},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "UpdateApplicationConfig")
+	c.Assert(app.Calls()[0].Args[0], jc.DeepEquals, coreapplication.ConfigAttributes{
+		"hook-retry-max-attempts": "5",
+		"hook-retry-max-interval": "1m",
+	})
+}
+
+func (s *ApplicationSuite) TestSetApplicationConfigInvalidHookRetryPolicy(c *gc.C) {
+	result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "postgresql",
+			Config: map[string]string{
+				"hook-retry-min-interval": "10m",
+				"hook-retry-max-interval": "1m",
+			},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, "hook-retry-min-interval 10m0s greater than hook-retry-max-interval 1m0s not valid")
+	s.backend.applications["postgresql"].CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestSetApplicationConfigMaintenanceWindow(c *gc.C) {
+	app := s.backend.applications["postgresql"]
+	app.config = coreapplication.ConfigAttributes{
+		"maintenance-window": "0 2 * * *",
+	}
+	result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "postgresql",
+			Config: map[string]string{
+				"maintenance-window-duration": "1h",
+			},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	app.CheckCallNames(c, "ApplicationConfig", "UpdateApplicationConfig")
+	c.Assert(app.Calls()[1].Args[0], jc.DeepEquals, coreapplication.ConfigAttributes{
+		"maintenance-window-duration": "1h",
+	})
+}
+
+func (s *ApplicationSuite) TestSetApplicationConfigInvalidMaintenanceWindow(c *gc.C) {
+	for i, config := range []map[string]string{{
+		"maintenance-window": "0 2 * * *",
+	}, {
+		"maintenance-window":          "at night",
+		"maintenance-window-duration": "1h",
+	}} {
+		c.Logf("test %d", i)
+		app := s.backend.applications["postgresql"]
+		app.ResetCalls()
+		result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+			Args: []params.ApplicationConfigSet{{
+				ApplicationName: "postgresql",
+				Config:          config,
+			}}})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(result.OneError(), gc.ErrorMatches, "maintenance-window .* not valid")
+		app.CheckCallNames(c, "ApplicationConfig")
+	}
+}
+
+func (s *ApplicationSuite) TestSetApplicationConfigInvalidPodDisruptionBudget(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "postgresql",
+			Config: map[string]string{
+				"kubernetes-pod-disruption-max-unavailable": "0",
+			},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, `kubernetes-pod-disruption-max-unavailable "0" not valid`)
+	s.backend.applications["postgresql"].CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestSetApplicationConfigAutoscale(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	app := s.backend.applications["postgresql"]
+	app.config = coreapplication.ConfigAttributes{
+		"kubernetes-autoscale-max": 10,
+	}
+	result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "postgresql",
+			Config: map[string]string{
+				"kubernetes-autoscale-min": "2",
+			},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	app.CheckCallNames(c, "ApplicationConfig", "UpdateApplicationConfig")
+}
+
+func (s *ApplicationSuite) TestSetApplicationConfigInvalidAutoscale(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	app := s.backend.applications["postgresql"]
+	app.config = coreapplication.ConfigAttributes{
+		"kubernetes-autoscale-max": 3,
+	}
+	result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "postgresql",
+			Config: map[string]string{
+				"kubernetes-autoscale-min": "5",
+			},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, "kubernetes-autoscale-max 3 less than kubernetes-autoscale-min 5 not valid")
+	app.CheckCallNames(c, "ApplicationConfig")
+}
+
 func (s *ApplicationSuite) TestBlockSetApplicationConfig(c *gc.C) {
 	s.blockChecker.SetErrors(errors.New("blocked"))
 	_, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{})
@@ -1803,6 +2031,73 @@ func (s *ApplicationSuite) TestResolveUnitErrorsAll(c *gc.C) {
 	unit.CheckCall(c, 0, "Resolve", true)
 }
 
+func (s *ApplicationSuite) TestResolveUnitErrorsApplication(c *gc.C) {
+	p := params.UnitsResolved{
+		Applications: []string{"redis"},
+	}
+	_, err := s.api.ResolveUnitErrors(p)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.backend.applications["postgresql"].units[0].CheckCallNames(c, "ApplicationName")
+	unit := s.backend.applications["redis"].units[0]
+	unit.CheckCallNames(c, "ApplicationName", "Resolve")
+	unit.CheckCall(c, 1, "Resolve", false)
+}
+
+func (s *ApplicationSuite) TestResolveUnitErrorsApplicationNotFound(c *gc.C) {
+	p := params.UnitsResolved{
+		Applications: []string{"mysql"},
+	}
+	_, err := s.api.ResolveUnitErrors(p)
+	c.Assert(err, gc.ErrorMatches, `application "mysql" not found`)
+	s.backend.applications["redis"].units[0].CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestResolveUnitErrorsHooks(c *gc.C) {
+	s.backend.applications["postgresql"].units[0].agentStatus = status.StatusInfo{
+		Status: status.Error,
+		Data:   map[string]interface{}{"hook": "config-changed"},
+	}
+	s.backend.applications["redis"].units[0].agentStatus = status.StatusInfo{
+		Status: status.Error,
+		Data:   map[string]interface{}{"hook": "db-relation-changed"},
+	}
+	p := params.UnitsResolved{
+		All:   true,
+		Hooks: []string{"relation-changed"},
+	}
+	_, err := s.api.ResolveUnitErrors(p)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.backend.applications["postgresql"].units[0].CheckCallNames(c, "AgentStatus")
+	s.backend.applications["redis"].units[0].CheckCallNames(c, "AgentStatus", "Resolve")
+}
+
+func (s *ApplicationSuite) TestResolveUnitErrorsHooksByTag(c *gc.C) {
+	s.backend.applications["postgresql"].units[0].agentStatus = status.StatusInfo{
+		Status: status.Error,
+		Data:   map[string]interface{}{"hook": "config-changed"},
+	}
+	s.backend.applications["postgresql"].units[1].agentStatus = status.StatusInfo{
+		Status: status.Error,
+		Data:   map[string]interface{}{"hook": "install"},
+	}
+	p := params.UnitsResolved{
+		Tags: params.Entities{
+			Entities: []params.Entity{{Tag: "unit-postgresql-0"}, {Tag: "unit-postgresql-1"}},
+		},
+		Hooks: []string{"config-changed"},
+	}
+	result, err := s.api.ResolveUnitErrors(p)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `unit "postgresql/1" is not in error in hook config-changed`)
+
+	s.backend.applications["postgresql"].units[0].CheckCallNames(c, "AgentStatus", "Resolve")
+	s.backend.applications["postgresql"].units[1].CheckCallNames(c, "AgentStatus")
+}
+
 func (s *ApplicationSuite) TestBlockResolveUnitErrors(c *gc.C) {
 	s.blockChecker.SetErrors(errors.New("blocked"))
 	_, err := s.api.ResolveUnitErrors(params.UnitsResolved{})
@@ -1974,3 +2269,195 @@ func (s *ApplicationSuite) TestUnitsInfo(c *gc.C) {
 		Message: `unit "mysql/0" not found`,
 	})
 }
+
+func (s *ApplicationSuite) TestUnitsInfoResourceLimits(c *gc.C) {
+	s.backend.machines = map[string]*mockMachine{"0": {}}
+	s.backend.applications["postgresql"].config = coreapplication.ConfigAttributes{
+		"trust":             false,
+		"unit-memory-limit": "512M",
+		"unit-tasks-limit":  100,
+	}
+
+	result, err := s.api.UnitsInfo(params.Entities{[]params.Entity{{Tag: "unit-postgresql-0"}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Result.ResourceLimits, jc.DeepEquals, &params.ResourceLimits{
+		MemoryMB: 512,
+		Tasks:    100,
+	})
+}
+
+func (s *ApplicationSuite) TestUnitsHookHistory(c *gc.C) {
+	started := time.Date(2020, 7, 1, 10, 0, 0, 0, time.UTC)
+	s.backend.applications["postgresql"].units[0].hookHistory = []status.HookExecution{{
+		Hook:     "config-changed",
+		Started:  started.Add(time.Minute),
+		Finished: started.Add(2 * time.Minute),
+		Result:   status.HookTimedOut,
+		Message:  "config-changed hook timed out after 1m0s",
+	}, {
+		Hook:     "install",
+		Started:  started,
+		Finished: started.Add(30 * time.Second),
+		Result:   status.HookSucceeded,
+	}}
+
+	entities := []params.Entity{{Tag: "unit-postgresql-0"}, {"unit-mysql-0"}, {"application-postgresql"}}
+	result, err := s.api.UnitsHookHistory(params.Entities{entities})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, len(entities))
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Executions, jc.DeepEquals, []params.HookExecution{{
+		Hook:     "config-changed",
+		Started:  started.Add(time.Minute),
+		Finished: started.Add(2 * time.Minute),
+		Result:   "timed-out",
+		Message:  "config-changed hook timed out after 1m0s",
+	}, {
+		Hook:     "install",
+		Started:  started,
+		Finished: started.Add(30 * time.Second),
+		Result:   "succeeded",
+	}})
+	c.Assert(result.Results[1].Error, jc.DeepEquals, &params.Error{
+		Code:    "not found",
+		Message: `unit "mysql/0" not found`,
+	})
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `"application-postgresql" is not a valid unit tag`)
+}
+
+func (s *ApplicationSuite) TestSetUnitsTrace(c *gc.C) {
+	until := time.Date(2020, 7, 1, 10, 10, 0, 0, time.UTC)
+	args := []params.UnitTraceArg{
+		{Tag: "unit-postgresql-0", Until: until, IncludeOutput: true},
+		{Tag: "unit-mysql-0", Until: until},
+		{Tag: "application-postgresql"},
+	}
+	result, err := s.api.SetUnitsTrace(params.UnitTraceArgs{args})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, len(args))
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, jc.DeepEquals, &params.Error{
+		Code:    "not found",
+		Message: `unit "mysql/0" not found`,
+	})
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `"application-postgresql" is not a valid unit tag`)
+	s.backend.applications["postgresql"].units[0].CheckCall(c, 0, "SetTraceUntil", until, true)
+}
+
+func (s *ApplicationSuite) TestSetUnitsTraceTooLong(c *gc.C) {
+	until := time.Now().Add(25 * time.Hour)
+	result, err := s.api.SetUnitsTrace(params.UnitTraceArgs{[]params.UnitTraceArg{
+		{Tag: "unit-postgresql-0", Until: until},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, "trace duration longer than 24h0m0s not valid")
+	s.backend.applications["postgresql"].units[0].CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestSetUnitsTraceIncludeOutputNeedsAdmin(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("write"))
+	until := time.Now().Add(time.Hour)
+	result, err := s.api.SetUnitsTrace(params.UnitTraceArgs{[]params.UnitTraceArg{
+		{Tag: "unit-postgresql-0", Until: until, IncludeOutput: true},
+		{Tag: "unit-postgresql-0", Until: until},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, "permission denied")
+	c.Assert(result.Results[1].Error, gc.IsNil)
+	s.backend.applications["postgresql"].units[0].CheckCall(c, 0, "SetTraceUntil", until, false)
+}
+
+func (s *ApplicationSuite) TestSetUnitsTracePermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	_, err := s.api.SetUnitsTrace(params.UnitTraceArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *ApplicationSuite) TestFlushDeferredHooks(c *gc.C) {
+	args := []params.Entity{
+		{Tag: "application-postgresql"},
+		{Tag: "application-mysql"},
+		{Tag: "unit-postgresql-0"},
+	}
+	result, err := s.api.FlushDeferredHooks(params.Entities{args})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, len(args))
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, jc.DeepEquals, &params.Error{
+		Code:    "not found",
+		Message: `application "mysql" not found`,
+	})
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `"unit-postgresql-0" is not a valid application tag`)
+	s.backend.applications["postgresql"].CheckCallNames(c, "FlushDeferredHooks")
+}
+
+func (s *ApplicationSuite) TestFlushDeferredHooksPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	_, err := s.api.FlushDeferredHooks(params.Entities{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *ApplicationSuite) TestBlockFlushDeferredHooks(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.FlushDeferredHooks(params.Entities{})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+}
+
+func (s *ApplicationSuite) TestConsumedApplicationsInfo(c *gc.C) {
+	lastEvent := time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC)
+	s.backend.remoteApplications["hosted-db2"] = &mockRemoteApplication{
+		name:           "hosted-db2",
+		sourceModelTag: coretesting.ModelTag,
+		offerURL:       "fred/prod.db2",
+		offerUUID:      "offer-uuid",
+		endpoints: []state.Endpoint{{
+			ApplicationName: "hosted-db2",
+			Relation:        charm.Relation{Name: "db", Interface: "db2", Role: charm.RoleProvider},
+		}},
+		status: status.StatusInfo{Status: status.Active, Message: "ready"},
+		diagnostics: &crossmodel.RemoteApplicationDiagnostics{
+			LastRemoteEvent:  &lastEvent,
+			SettingsSyncLag:  time.Second,
+			IngressAddresses: []string{"10.0.0.1"},
+			Updated:          lastEvent,
+		},
+	}
+
+	entities := []params.Entity{{Tag: "application-hosted-db2"}, {"application-mysql"}}
+	result, err := s.api.ConsumedApplicationsInfo(params.Entities{entities})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, len(entities))
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Result, jc.DeepEquals, &params.ConsumedApplicationInfo{
+		Name:           "hosted-db2",
+		OfferURL:       "fred/prod.db2",
+		OfferUUID:      "offer-uuid",
+		SourceModelTag: coretesting.ModelTag.String(),
+		Life:           life.Alive,
+		Status:         params.EntityStatus{Status: status.Active, Info: "ready"},
+		Endpoints: []params.RemoteEndpoint{{
+			Name: "db", Interface: "db2", Role: charm.RoleProvider,
+		}},
+		Diagnostics: &params.RemoteApplicationDiagnostics{
+			LastRemoteEvent:  &lastEvent,
+			SettingsSyncLag:  time.Second,
+			IngressAddresses: []string{"10.0.0.1"},
+			Updated:          lastEvent,
+		},
+	})
+	c.Assert(result.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *ApplicationSuite) TestConsumedApplicationsInfoNoDiagnostics(c *gc.C) {
+	entities := []params.Entity{{Tag: "application-hosted-db2"}}
+	result, err := s.api.ConsumedApplicationsInfo(params.Entities{entities})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Result.Diagnostics, gc.IsNil)
+}
@@ -0,0 +1,53 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+	"gopkg.in/juju/environschema.v1"
+
+	k8s "github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/core/application"
+)
+
+var autoscaleKeys = []string{
+	k8s.AutoscaleMinConfigKey,
+	k8s.AutoscaleMaxConfigKey,
+	k8s.AutoscaleCPUConfigKey,
+}
+
+// validateAutoscale returns an error if the given application config
+// changes leave the application with autoscaling settings that cannot
+// be applied. The settings may be changed separately, so the changes
+// are checked together with the application's current config.
+func validateAutoscale(app Application, attrs map[string]interface{}, configSchema environschema.Fields, defaults schema.Defaults) error {
+	changed := false
+	for _, key := range autoscaleKeys {
+		if _, ok := attrs[key]; ok {
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	current, err := app.ApplicationConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	merged := make(map[string]interface{})
+	for _, key := range autoscaleKeys {
+		if value, ok := current[key]; ok {
+			merged[key] = value
+		}
+		if value, ok := attrs[key]; ok {
+			merged[key] = value
+		}
+	}
+	cfg, err := application.NewConfig(merged, configSchema, defaults)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(k8s.ValidateAutoscaleConfig(cfg.Attributes()))
+}
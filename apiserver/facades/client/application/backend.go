@@ -13,8 +13,11 @@ import (
 	"github.com/juju/schema"
 	"github.com/juju/version"
 	"gopkg.in/juju/environschema.v1"
+	"gopkg.in/macaroon.v2"
 
+	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/common/storagecommon"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/constraints"
@@ -48,6 +51,7 @@ type Backend interface {
 	SaveController(info crossmodel.ControllerInfo, modelUUID string) (ExternalController, error)
 	ControllerTag() names.ControllerTag
 	ControllerConfig() (controller.Config, error)
+	ControllerInfoForModel(modelUUID string) (addrs []string, caCert string, _ error)
 	Resources() (Resources, error)
 	OfferConnectionForRelation(string) (OfferConnection, error)
 	SaveEgressNetworks(relationKey string, cidrs []string) (state.RelationNetworks, error)
@@ -82,6 +86,7 @@ type Application interface {
 	DestroyOperation() *state.DestroyApplicationOperation
 	EndpointBindings() (Bindings, error)
 	Endpoints() ([]state.Endpoint, error)
+	FlushDeferredHooks() error
 	IsExposed() bool
 	IsPrincipal() bool
 	IsRemote() bool
@@ -168,10 +173,13 @@ type Unit interface {
 	IsPrincipal() bool
 	Life() state.Life
 	Resolve(retryHooks bool) error
+	AgentStatus() (status.StatusInfo, error)
 	AgentTools() (*tools.Tools, error)
 
 	AssignedMachineId() (string, error)
 	WorkloadVersion() (string, error)
+	HookHistory() ([]status.HookExecution, error)
+	SetTraceUntil(time.Time, bool) error
 	AssignWithPolicy(state.AssignmentPolicy) error
 	AssignWithPlacement(*instance.Placement) error
 	ContainerInfo() (state.CloudContainer, error)
@@ -218,6 +226,24 @@ func (s stateShim) SaveController(controllerInfo crossmodel.ControllerInfo, mode
 	return api.Save(controllerInfo, modelUUID)
 }
 
+// ControllerInfoForModel returns the API addresses and CA certificate
+// of the controller hosting the specified model.
+func (s stateShim) ControllerInfoForModel(modelUUID string) ([]string, string, error) {
+	results, err := common.NewStateControllerConfig(s.State).ControllerAPIInfoForModels(params.Entities{
+		Entities: []params.Entity{{Tag: names.NewModelTag(modelUUID).String()}},
+	})
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, "", errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	return results.Results[0].Addresses, results.Results[0].CACert, nil
+}
+
 type storageInterface interface {
 	storagecommon.StorageAccess
 	VolumeAccess() storagecommon.VolumeAccess
@@ -295,6 +321,12 @@ type remoteApplicationShim struct {
 type RemoteApplication interface {
 	Name() string
 	SourceModel() names.ModelTag
+	URL() (string, bool)
+	OfferUUID() string
+	Macaroon() (*macaroon.Macaroon, error)
+	Life() state.Life
+	Status() (status.StatusInfo, error)
+	Diagnostics() (*crossmodel.RemoteApplicationDiagnostics, error)
 	Endpoints() ([]state.Endpoint, error)
 	AddEndpoints(eps []charm.Relation) error
 	Bindings() map[string]string
@@ -9,6 +9,7 @@ var (
 	ParseSettingsCompatible = parseSettingsCompatible
 	NewStateStorage         = &newStateStorage
 	GetStorageState         = getStorageState
+	CheckOfferConnection    = &checkOfferConnection
 )
 
 func GetState(st *state.State) Backend {
@@ -19,6 +20,6 @@ func GetModel(m *state.Model) Model {
 	return modelShim{m}
 }
 
-func SetModelType(api *APIv12, modelType state.ModelType) {
+func SetModelType(api *APIv17, modelType state.ModelType) {
 	api.modelType = modelType
 }
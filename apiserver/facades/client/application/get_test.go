@@ -29,7 +29,7 @@ import (
 type getSuite struct {
 	jujutesting.JujuConnSuite
 
-	applicationAPI *application.APIv12
+	applicationAPI *application.APIv17
 	authorizer     apiservertesting.FakeAuthorizer
 }
 
@@ -61,12 +61,12 @@ func (s *getSuite) SetUpTest(c *gc.C) {
 		nil, // CAAS Broker not used in this suite.
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.applicationAPI = &application.APIv12{api}
+	s.applicationAPI = &application.APIv17{api}
 }
 
 func (s *getSuite) TestClientApplicationGetSmokeTestV4(c *gc.C) {
 	s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
-	v4 := &application.APIv4{&application.APIv5{&application.APIv6{&application.APIv7{&application.APIv8{&application.APIv9{&application.APIv10{&application.APIv11{s.applicationAPI}}}}}}}}
+	v4 := &application.APIv4{&application.APIv5{&application.APIv6{&application.APIv7{&application.APIv8{&application.APIv9{&application.APIv10{&application.APIv11{&application.APIv12{&application.APIv13{&application.APIv14{&application.APIv15{&application.APIv16{s.applicationAPI}}}}}}}}}}}}}
 	results, err := v4.Get(params.ApplicationGet{ApplicationName: "wordpress"})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, gc.DeepEquals, params.ApplicationGetResults{
@@ -86,7 +86,7 @@ func (s *getSuite) TestClientApplicationGetSmokeTestV4(c *gc.C) {
 
 func (s *getSuite) TestClientApplicationGetSmokeTestV5(c *gc.C) {
 	s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
-	v5 := &application.APIv5{&application.APIv6{&application.APIv7{&application.APIv8{&application.APIv9{&application.APIv10{&application.APIv11{s.applicationAPI}}}}}}}
+	v5 := &application.APIv5{&application.APIv6{&application.APIv7{&application.APIv8{&application.APIv9{&application.APIv10{&application.APIv11{&application.APIv12{&application.APIv13{&application.APIv14{&application.APIv15{&application.APIv16{s.applicationAPI}}}}}}}}}}}}
 	results, err := v5.Get(params.ApplicationGet{ApplicationName: "wordpress"})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, gc.DeepEquals, params.ApplicationGetResults{
@@ -123,6 +123,46 @@ func (s *getSuite) TestClientApplicationGetIAASModelSmokeTest(c *gc.C) {
 			},
 		},
 		ApplicationConfig: map[string]interface{}{
+			"hook-retry-max-attempts": map[string]interface{}{
+				"description": "The most automatic retries of a failed hook, 0 for no limit",
+				"source":      "unset",
+				"type":        environschema.Tint,
+			},
+			"hook-retry-max-interval": map[string]interface{}{
+				"description": "The longest wait between retries of a failed hook, such as 5m",
+				"source":      "unset",
+				"type":        environschema.Tstring,
+			},
+			"hook-retry-min-interval": map[string]interface{}{
+				"description": "The wait before a failed hook is first retried, such as 10s",
+				"source":      "unset",
+				"type":        environschema.Tstring,
+			},
+			"maintenance-window": map[string]interface{}{
+				"description": "A cron expression for when hooks start being deferred",
+				"source":      "unset",
+				"type":        environschema.Tstring,
+			},
+			"maintenance-window-duration": map[string]interface{}{
+				"description": "How long hooks are deferred for, such as 1h",
+				"source":      "unset",
+				"type":        environschema.Tstring,
+			},
+			"unit-cpu-shares": map[string]interface{}{
+				"description": "The relative CPU weight given to the hooks of each unit, from 2 to 262144",
+				"source":      "unset",
+				"type":        environschema.Tint,
+			},
+			"unit-memory-limit": map[string]interface{}{
+				"description": "The most memory the hooks of each unit, and the processes they start, may use, such as 512M or 2G",
+				"source":      "unset",
+				"type":        environschema.Tstring,
+			},
+			"unit-tasks-limit": map[string]interface{}{
+				"description": "The most processes and threads the hooks of each unit may run",
+				"source":      "unset",
+				"type":        environschema.Tint,
+			},
 			"trust": map[string]interface{}{
 				"default":     false,
 				"description": "Does this application have access to trusted credentials",
@@ -215,7 +255,7 @@ func (s *getSuite) TestClientApplicationGetCAASModelSmokeTest(c *gc.C) {
 		nil, // CAAS Broker not used in this suite.
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	apiV8 := &application.APIv8{&application.APIv9{&application.APIv10{&application.APIv11{&application.APIv12{api}}}}}
+	apiV8 := &application.APIv8{&application.APIv9{&application.APIv10{&application.APIv11{&application.APIv12{&application.APIv13{&application.APIv14{&application.APIv15{&application.APIv16{&application.APIv17{api}}}}}}}}}}
 
 	results, err := apiV8.Get(params.ApplicationGet{ApplicationName: "dashboard4miner"})
 	c.Assert(err, jc.ErrorIsNil)
@@ -292,6 +332,46 @@ var getTests = []struct {
 			},
 		},
 		ApplicationConfig: map[string]interface{}{
+			"hook-retry-max-attempts": map[string]interface{}{
+				"description": "The most automatic retries of a failed hook, 0 for no limit",
+				"source":      "unset",
+				"type":        "int",
+			},
+			"hook-retry-max-interval": map[string]interface{}{
+				"description": "The longest wait between retries of a failed hook, such as 5m",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"hook-retry-min-interval": map[string]interface{}{
+				"description": "The wait before a failed hook is first retried, such as 10s",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"maintenance-window": map[string]interface{}{
+				"description": "A cron expression for when hooks start being deferred",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"maintenance-window-duration": map[string]interface{}{
+				"description": "How long hooks are deferred for, such as 1h",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"unit-cpu-shares": map[string]interface{}{
+				"description": "The relative CPU weight given to the hooks of each unit, from 2 to 262144",
+				"source":      "unset",
+				"type":        "int",
+			},
+			"unit-memory-limit": map[string]interface{}{
+				"description": "The most memory the hooks of each unit, and the processes they start, may use, such as 512M or 2G",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"unit-tasks-limit": map[string]interface{}{
+				"description": "The most processes and threads the hooks of each unit may run",
+				"source":      "unset",
+				"type":        "int",
+			},
 			"trust": map[string]interface{}{
 				"value":       false,
 				"default":     false,
@@ -353,6 +433,46 @@ var getTests = []struct {
 			},
 		},
 		ApplicationConfig: map[string]interface{}{
+			"hook-retry-max-attempts": map[string]interface{}{
+				"description": "The most automatic retries of a failed hook, 0 for no limit",
+				"source":      "unset",
+				"type":        "int",
+			},
+			"hook-retry-max-interval": map[string]interface{}{
+				"description": "The longest wait between retries of a failed hook, such as 5m",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"hook-retry-min-interval": map[string]interface{}{
+				"description": "The wait before a failed hook is first retried, such as 10s",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"maintenance-window": map[string]interface{}{
+				"description": "A cron expression for when hooks start being deferred",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"maintenance-window-duration": map[string]interface{}{
+				"description": "How long hooks are deferred for, such as 1h",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"unit-cpu-shares": map[string]interface{}{
+				"description": "The relative CPU weight given to the hooks of each unit, from 2 to 262144",
+				"source":      "unset",
+				"type":        "int",
+			},
+			"unit-memory-limit": map[string]interface{}{
+				"description": "The most memory the hooks of each unit, and the processes they start, may use, such as 512M or 2G",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"unit-tasks-limit": map[string]interface{}{
+				"description": "The most processes and threads the hooks of each unit may run",
+				"source":      "unset",
+				"type":        "int",
+			},
 			"trust": map[string]interface{}{
 				"value":       false,
 				"default":     false,
@@ -373,6 +493,46 @@ var getTests = []struct {
 		CharmConfig: map[string]interface{}{},
 		Series:      "quantal",
 		ApplicationConfig: map[string]interface{}{
+			"hook-retry-max-attempts": map[string]interface{}{
+				"description": "The most automatic retries of a failed hook, 0 for no limit",
+				"source":      "unset",
+				"type":        "int",
+			},
+			"hook-retry-max-interval": map[string]interface{}{
+				"description": "The longest wait between retries of a failed hook, such as 5m",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"hook-retry-min-interval": map[string]interface{}{
+				"description": "The wait before a failed hook is first retried, such as 10s",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"maintenance-window": map[string]interface{}{
+				"description": "A cron expression for when hooks start being deferred",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"maintenance-window-duration": map[string]interface{}{
+				"description": "How long hooks are deferred for, such as 1h",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"unit-cpu-shares": map[string]interface{}{
+				"description": "The relative CPU weight given to the hooks of each unit, from 2 to 262144",
+				"source":      "unset",
+				"type":        "int",
+			},
+			"unit-memory-limit": map[string]interface{}{
+				"description": "The most memory the hooks of each unit, and the processes they start, may use, such as 512M or 2G",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"unit-tasks-limit": map[string]interface{}{
+				"description": "The most processes and threads the hooks of each unit may run",
+				"source":      "unset",
+				"type":        "int",
+			},
 			"trust": map[string]interface{}{
 				"value":       false,
 				"default":     false,
@@ -0,0 +1,102 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/application"
+)
+
+var limitsFields = environschema.Fields{
+	application.UnitMemoryLimitKey: {
+		Description: "The most memory the hooks of each unit, and the processes they start, may use, such as 512M or 2G",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+	application.UnitCPUSharesKey: {
+		Description: "The relative CPU weight given to the hooks of each unit, from 2 to 262144",
+		Type:        environschema.Tint,
+		Group:       environschema.JujuGroup,
+	},
+	application.UnitTasksLimitKey: {
+		Description: "The most processes and threads the hooks of each unit may run",
+		Type:        environschema.Tint,
+		Group:       environschema.JujuGroup,
+	},
+}
+
+var limitsDefaults = schema.Defaults{
+	application.UnitMemoryLimitKey: schema.Omit,
+	application.UnitCPUSharesKey:   schema.Omit,
+	application.UnitTasksLimitKey:  schema.Omit,
+}
+
+// iaasConfigSchema returns the application config schema and defaults
+// for IAAS models: trust, the unit resource limits, the hook retry
+// policy and the maintenance window.
+func iaasConfigSchema() (environschema.Fields, schema.Defaults) {
+	fields := make(environschema.Fields)
+	defaults := make(schema.Defaults)
+	for name, field := range trustFields {
+		fields[name] = field
+	}
+	for name, field := range limitsFields {
+		fields[name] = field
+	}
+	for name, field := range retryFields {
+		fields[name] = field
+	}
+	for name, field := range maintenanceFields {
+		fields[name] = field
+	}
+	for key, value := range trustDefaults {
+		defaults[key] = value
+	}
+	for key, value := range limitsDefaults {
+		defaults[key] = value
+	}
+	for key, value := range retryDefaults {
+		defaults[key] = value
+	}
+	for key, value := range maintenanceDefaults {
+		defaults[key] = value
+	}
+	return fields, defaults
+}
+
+// validateResourceLimits returns an error if the given application
+// config changes hold resource limits that cannot be applied.
+func validateResourceLimits(attrs map[string]interface{}, configSchema environschema.Fields, defaults schema.Defaults) error {
+	cfg, err := application.NewConfig(attrs, configSchema, defaults)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = application.ParseResourceLimits(cfg.Attributes())
+	return errors.Trace(err)
+}
+
+// unitResourceLimits returns the resource limits applied to each unit
+// of the application, or nil if there are none.
+func unitResourceLimits(app Application) (*params.ResourceLimits, error) {
+	attrs, err := app.ApplicationConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	limits, err := application.ParseResourceLimits(attrs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if limits.IsZero() {
+		return nil, nil
+	}
+	return &params.ResourceLimits{
+		MemoryMB:  limits.MemoryMB,
+		CPUShares: limits.CPUShares,
+		Tasks:     limits.Tasks,
+	}, nil
+}
@@ -0,0 +1,62 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/core/application"
+)
+
+var maintenanceFields = environschema.Fields{
+	application.MaintenanceWindowKey: {
+		Description: "A cron expression for when hooks start being deferred",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+	application.MaintenanceWindowDurationKey: {
+		Description: "How long hooks are deferred for, such as 1h",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+}
+
+var maintenanceDefaults = schema.Defaults{
+	application.MaintenanceWindowKey:         schema.Omit,
+	application.MaintenanceWindowDurationKey: schema.Omit,
+}
+
+// validateMaintenanceWindow returns an error if the given application
+// config changes leave the application with a maintenance window that
+// cannot be applied. The schedule and duration of the window may be
+// changed separately, so the changes are checked together with the
+// application's current config.
+func validateMaintenanceWindow(app Application, attrs map[string]interface{}, configSchema environschema.Fields, defaults schema.Defaults) error {
+	_, scheduleChanged := attrs[application.MaintenanceWindowKey]
+	_, durationChanged := attrs[application.MaintenanceWindowDurationKey]
+	if !scheduleChanged && !durationChanged {
+		return nil
+	}
+	current, err := app.ApplicationConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	merged := make(map[string]interface{})
+	for _, key := range []string{application.MaintenanceWindowKey, application.MaintenanceWindowDurationKey} {
+		if value, ok := current[key]; ok {
+			merged[key] = value
+		}
+		if value, ok := attrs[key]; ok {
+			merged[key] = value
+		}
+	}
+	cfg, err := application.NewConfig(merged, configSchema, defaults)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = application.ParseMaintenanceWindow(cfg.Attributes())
+	return errors.Trace(err)
+}
@@ -219,6 +219,11 @@ func (a *mockApplication) ApplicationConfig() (coreapplication.ConfigAttributes,
 	return a.config, a.NextErr()
 }
 
+func (a *mockApplication) FlushDeferredHooks() error {
+	a.MethodCall(a, "FlushDeferredHooks")
+	return a.NextErr()
+}
+
 func (a *mockApplication) UpdateApplicationConfig(
 	changes coreapplication.ConfigAttributes,
 	reset []string,
@@ -306,6 +311,8 @@ type mockRemoteApplication struct {
 	offerUUID      string
 	offerURL       string
 	mac            *macaroon.Macaroon
+	status         status.StatusInfo
+	diagnostics    *crossmodel.RemoteApplicationDiagnostics
 }
 
 func (m *mockRemoteApplication) Name() string {
@@ -316,6 +323,33 @@ func (m *mockRemoteApplication) SourceModel() names.ModelTag {
 	return m.sourceModelTag
 }
 
+func (m *mockRemoteApplication) URL() (string, bool) {
+	return m.offerURL, m.offerURL != ""
+}
+
+func (m *mockRemoteApplication) OfferUUID() string {
+	return m.offerUUID
+}
+
+func (m *mockRemoteApplication) Macaroon() (*macaroon.Macaroon, error) {
+	return m.mac, nil
+}
+
+func (m *mockRemoteApplication) Life() state.Life {
+	return state.Alive
+}
+
+func (m *mockRemoteApplication) Status() (status.StatusInfo, error) {
+	return m.status, nil
+}
+
+func (m *mockRemoteApplication) Diagnostics() (*crossmodel.RemoteApplicationDiagnostics, error) {
+	if m.diagnostics == nil {
+		return nil, errors.NotFoundf("diagnostics")
+	}
+	return m.diagnostics, nil
+}
+
 func (m *mockRemoteApplication) Endpoints() ([]state.Endpoint, error) {
 	return m.endpoints, nil
 }
@@ -435,6 +469,7 @@ func (m *mockBackend) Unit(name string) (application.Unit, error) {
 func (m *mockBackend) UnitsInError() ([]application.Unit, error) {
 	return []application.Unit{
 		m.applications["postgresql"].units[0],
+		m.applications["redis"].units[0],
 	}, nil
 }
 
@@ -584,6 +619,27 @@ func (m *mockMachine) PublicAddress() (network.SpaceAddress, error) {
 	}, nil
 }
 
+func (m *mockBackend) AddRelation(endpoints ...state.Endpoint) (application.Relation, error) {
+	m.MethodCall(m, "AddRelation", endpoints)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.relations[123], nil
+}
+
+func (m *mockBackend) SaveEgressNetworks(relationKey string, cidrs []string) (state.RelationNetworks, error) {
+	m.MethodCall(m, "SaveEgressNetworks", relationKey, cidrs)
+	return nil, m.NextErr()
+}
+
+func (m *mockBackend) ControllerInfoForModel(modelUUID string) ([]string, string, error) {
+	m.MethodCall(m, "ControllerInfoForModel", modelUUID)
+	if err := m.NextErr(); err != nil {
+		return nil, "", err
+	}
+	return []string{"10.0.0.1:17070"}, coretesting.CACert, nil
+}
+
 func (m *mockBackend) InferEndpoints(endpoints ...string) ([]state.Endpoint, error) {
 	m.MethodCall(m, "InferEndpoints", endpoints)
 	if err := m.NextErr(); err != nil {
@@ -834,11 +890,11 @@ func (r *mockRelation) Unit(name string) (application.RelationUnit, error) {
 
 func (r *mockRelation) Endpoint(name string) (state.Endpoint, error) {
 	r.MethodCall(r, "Endpoint")
-	if name != "postgresql" {
+	if name != "postgresql" && name != "hosted-db2" {
 		return state.Endpoint{}, errors.NotFoundf("endpoint for %q", name)
 	}
 	return state.Endpoint{
-		ApplicationName: "postgresql",
+		ApplicationName: name,
 		Relation:        charm.Relation{Name: "db"},
 	}, nil
 }
@@ -896,10 +952,12 @@ func (m mockRelationUnit) Settings() (map[string]interface{}, error) {
 type mockUnit struct {
 	application.Unit
 	jtesting.Stub
-	tag        names.UnitTag
-	machineId  string
-	name       string
-	agentTools *tools.Tools
+	tag         names.UnitTag
+	machineId   string
+	name        string
+	agentTools  *tools.Tools
+	agentStatus status.StatusInfo
+	hookHistory []status.HookExecution
 }
 
 func (u *mockUnit) Tag() names.Tag {
@@ -936,6 +994,11 @@ func (u *mockUnit) Resolve(retryHooks bool) error {
 	return u.NextErr()
 }
 
+func (u *mockUnit) AgentStatus() (status.StatusInfo, error) {
+	u.MethodCall(u, "AgentStatus")
+	return u.agentStatus, u.NextErr()
+}
+
 func (u *mockUnit) AssignedMachineId() (string, error) {
 	u.MethodCall(u, "AssignedMachineId")
 	return u.machineId, u.NextErr()
@@ -957,6 +1020,16 @@ func (u *mockUnit) WorkloadVersion() (string, error) {
 	return "666", nil
 }
 
+func (u *mockUnit) HookHistory() ([]status.HookExecution, error) {
+	u.MethodCall(u, "HookHistory")
+	return u.hookHistory, u.NextErr()
+}
+
+func (u *mockUnit) SetTraceUntil(until time.Time, includeOutput bool) error {
+	u.MethodCall(u, "SetTraceUntil", until, includeOutput)
+	return u.NextErr()
+}
+
 func (u *mockUnit) ContainerInfo() (state.CloudContainer, error) {
 	return mockCloudContainer{}, nil
 }
@@ -0,0 +1,23 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+	"gopkg.in/juju/environschema.v1"
+
+	k8s "github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/core/application"
+)
+
+// validatePodDisruptionBudget returns an error if the given application
+// config changes hold a pod disruption budget that cannot be applied.
+func validatePodDisruptionBudget(attrs map[string]interface{}, configSchema environschema.Fields, defaults schema.Defaults) error {
+	cfg, err := application.NewConfig(attrs, configSchema, defaults)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(k8s.ValidatePodDisruptionBudgetConfig(cfg.Attributes()))
+}
@@ -0,0 +1,47 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/core/application"
+)
+
+var retryFields = environschema.Fields{
+	application.HookRetryMaxAttemptsKey: {
+		Description: "The most automatic retries of a failed hook, 0 for no limit",
+		Type:        environschema.Tint,
+		Group:       environschema.JujuGroup,
+	},
+	application.HookRetryMinIntervalKey: {
+		Description: "The wait before a failed hook is first retried, such as 10s",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+	application.HookRetryMaxIntervalKey: {
+		Description: "The longest wait between retries of a failed hook, such as 5m",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+}
+
+var retryDefaults = schema.Defaults{
+	application.HookRetryMaxAttemptsKey: schema.Omit,
+	application.HookRetryMinIntervalKey: schema.Omit,
+	application.HookRetryMaxIntervalKey: schema.Omit,
+}
+
+// validateHookRetryPolicy returns an error if the given application
+// config changes hold a hook retry policy that cannot be applied.
+func validateHookRetryPolicy(attrs map[string]interface{}, configSchema environschema.Fields, defaults schema.Defaults) error {
+	cfg, err := application.NewConfig(attrs, configSchema, defaults)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = application.ParseHookRetryPolicy(cfg.Attributes())
+	return errors.Trace(err)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/loggo"
 	"github.com/juju/names/v4"
 	"github.com/juju/txn"
+	"gopkg.in/macaroon-bakery.v2/bakery"
 
 	"github.com/juju/juju/apiserver/common"
 	commoncrossmodel "github.com/juju/juju/apiserver/common/crossmodel"
@@ -20,6 +21,7 @@ import (
 	jujucrossmodel "github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/stateenvirons"
 )
 
@@ -40,6 +42,16 @@ type OffersAPIV2 struct {
 	*OffersAPI
 }
 
+// OffersAPIV3 implements the cross model interface V3.
+type OffersAPIV3 struct {
+	*OffersAPIV2
+}
+
+// OffersAPIV4 implements the cross model interface V4.
+type OffersAPIV4 struct {
+	*OffersAPIV3
+}
+
 // createAPI returns a new application offers OffersAPI facade.
 func createOffersAPI(
 	getApplicationOffers func(interface{}) jujucrossmodel.ApplicationOffers,
@@ -119,6 +131,24 @@ func NewOffersAPIV2(ctx facade.Context) (*OffersAPIV2, error) {
 	return &OffersAPIV2{OffersAPI: apiV1}, nil
 }
 
+// NewOffersAPIV3 returns a new application offers OffersAPIV3 facade.
+func NewOffersAPIV3(ctx facade.Context) (*OffersAPIV3, error) {
+	apiV2, err := NewOffersAPIV2(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &OffersAPIV3{OffersAPIV2: apiV2}, nil
+}
+
+// NewOffersAPIV4 returns a new application offers OffersAPIV4 facade.
+func NewOffersAPIV4(ctx facade.Context) (*OffersAPIV4, error) {
+	apiV3, err := NewOffersAPIV3(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &OffersAPIV4{OffersAPIV3: apiV3}, nil
+}
+
 // Offer makes application endpoints available for consumption at a specified URL.
 func (api *OffersAPI) Offer(all params.AddApplicationOffers) (params.ErrorResults, error) {
 	result := make([]params.ErrorResult, len(all.Offers))
@@ -161,6 +191,10 @@ func (api *OffersAPI) makeAddOfferArgsFromParams(backend Backend, addOfferParams
 		Owner:                  api.Authorizer.GetAuthTag().Id(),
 		HasRead:                []string{common.EveryoneTagName},
 	}
+	if addOfferParams.Limits != nil {
+		limits := offerLimitsFromParams(*addOfferParams.Limits)
+		result.Limits = &limits
+	}
 	if result.OfferName == "" {
 		result.OfferName = result.ApplicationName
 	}
@@ -244,8 +278,23 @@ func (api *OffersAPI) modifyOneOfferAccess(modelUUID string, isControllerAdmin b
 	}
 	offerTag := names.NewApplicationOfferTag(url.ApplicationName)
 
+	if err := api.checkCanModifyOffer(backend, isControllerAdmin, offerTag); err != nil {
+		return errors.Trace(err)
+	}
+
+	targetUserTag, err := names.ParseUserTag(arg.UserTag)
+	if err != nil {
+		return errors.Annotate(err, "could not modify offer access")
+	}
+	return api.changeOfferAccess(backend, offerTag, targetUserTag, arg.Action, offerAccess)
+}
+
+// checkCanModifyOffer returns an error if the authenticated user
+// does not have admin access to the specified offer.
+func (api *OffersAPI) checkCanModifyOffer(backend Backend, isControllerAdmin bool, offerTag names.ApplicationOfferTag) error {
 	canModifyOffer := isControllerAdmin
 	if !canModifyOffer {
+		var err error
 		if canModifyOffer, err = api.Authorizer.HasPermission(permission.AdminAccess, backend.ModelTag()); err != nil {
 			return errors.Trace(err)
 		}
@@ -267,12 +316,7 @@ func (api *OffersAPI) modifyOneOfferAccess(modelUUID string, isControllerAdmin b
 	if !canModifyOffer {
 		return apiservererrors.ErrPerm
 	}
-
-	targetUserTag, err := names.ParseUserTag(arg.UserTag)
-	if err != nil {
-		return errors.Annotate(err, "could not modify offer access")
-	}
-	return api.changeOfferAccess(backend, offerTag, targetUserTag, arg.Action, offerAccess)
+	return nil
 }
 
 // changeOfferAccess performs the requested access grant or revoke action for the
@@ -440,11 +484,22 @@ func (api *OffersAPI) FindApplicationOffers(filters params.OfferFilters) (params
 }
 
 // GetConsumeDetails returns the details necessary to pass to another model to
-// consume the specified offers represented by the urls.
+// consume the specified offers represented by the urls. If the consuming
+// controller is given, the macaroons returned declare it so that offers which
+// only accept relations from some controllers can check it.
 func (api *OffersAPI) GetConsumeDetails(args params.OfferURLs) (params.ConsumeOfferDetailsResults, error) {
 	var consumeResults params.ConsumeOfferDetailsResults
 	results := make([]params.ConsumeOfferDetailsResult, len(args.OfferURLs))
 
+	var consumingControllerUUID string
+	if args.ConsumingControllerTag != "" {
+		controllerTag, err := names.ParseControllerTag(args.ConsumingControllerTag)
+		if err != nil {
+			return consumeResults, apiservererrors.ServerError(err)
+		}
+		consumingControllerUUID = controllerTag.Id()
+	}
+
 	offers, err := api.ApplicationOffers(args)
 	if err != nil {
 		return consumeResults, apiservererrors.ServerError(err)
@@ -470,7 +525,8 @@ func (api *OffersAPI) GetConsumeDetails(args params.OfferURLs) (params.ConsumeOf
 		offerDetails := &offer.ApplicationOfferDetails
 		results[i].Offer = offerDetails
 		results[i].ControllerInfo = controllerInfo
-		offerMacaroon, err := api.authContext.CreateConsumeOfferMacaroon(api.ctx, offerDetails, api.Authorizer.GetAuthTag().Id(), args.BakeryVersion)
+		offerMacaroon, err := api.authContext.CreateConsumeOfferMacaroon(
+			api.ctx, offerDetails, api.Authorizer.GetAuthTag().Id(), consumingControllerUUID, args.BakeryVersion)
 		if err != nil {
 			results[i].Error = apiservererrors.ServerError(err)
 			continue
@@ -481,6 +537,173 @@ func (api *OffersAPI) GetConsumeDetails(args params.OfferURLs) (params.ConsumeOf
 	return consumeResults, nil
 }
 
+// CreateOfferInvitations creates invitations to consume the specified
+// offers, which can be redeemed once by another model before they expire.
+// Only offer admins can create invitations.
+func (api *OffersAPIV3) CreateOfferInvitations(args params.CreateOfferInvitationArgs) (params.OfferInvitationResults, error) {
+	result := params.OfferInvitationResults{
+		Results: make([]params.OfferInvitationResult, len(args.Args)),
+	}
+	if len(args.Args) == 0 {
+		return result, nil
+	}
+
+	isControllerAdmin, err := api.Authorizer.HasPermission(permission.SuperuserAccess, api.ControllerModel.ControllerTag())
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+
+	offerURLs := make([]string, len(args.Args))
+	for i, arg := range args.Args {
+		offerURLs[i] = arg.OfferURL
+	}
+	models, err := api.getModelsFromOffers(offerURLs...)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+
+	addrs, caCert, err := api.getControllerInfo()
+	if err != nil {
+		return result, apiservererrors.ServerError(err)
+	}
+	controllerInfo := &params.ExternalControllerInfo{
+		ControllerTag: api.ControllerModel.ControllerTag().String(),
+		Addrs:         addrs,
+		CACert:        caCert,
+	}
+
+	for i, arg := range args.Args {
+		if models[i].err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(models[i].err)
+			continue
+		}
+		invitation, err := api.createOneOfferInvitation(models[i].model.UUID(), isControllerAdmin, arg, args.BakeryVersion)
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		invitation.ControllerInfo = controllerInfo
+		result.Results[i].Result = invitation
+	}
+	return result, nil
+}
+
+func (api *OffersAPIV3) createOneOfferInvitation(
+	modelUUID string, isControllerAdmin bool, arg params.CreateOfferInvitationArg, version bakery.Version,
+) (*params.OfferInvitation, error) {
+	if arg.Expiry <= 0 {
+		return nil, errors.NotValidf("expiry %v", arg.Expiry)
+	}
+	backend, releaser, err := api.StatePool.Get(modelUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer releaser()
+
+	url, err := jujucrossmodel.ParseOfferURL(arg.OfferURL)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	offerTag := names.NewApplicationOfferTag(url.ApplicationName)
+	if err := api.checkCanModifyOffer(backend, isControllerAdmin, offerTag); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	offers, err := api.ApplicationOffers(params.OfferURLs{OfferURLs: []string{arg.OfferURL}})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if offers.Results[0].Error != nil {
+		return nil, offers.Results[0].Error
+	}
+	offerDetails := offers.Results[0].Result.ApplicationOfferDetails
+	// The invitee is not told who else has access to the offer.
+	offerDetails.Users = nil
+
+	invitation, err := backend.AddOfferInvitation(state.AddOfferInvitationParams{
+		OfferUUID: offerDetails.OfferUUID,
+		CreatedBy: api.Authorizer.GetAuthTag().(names.UserTag),
+		Expiry:    arg.Expiry,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	mac, err := api.authContext.CreateOfferInvitationMacaroon(
+		api.ctx, offerDetails.OfferUUID, invitation.Id(), invitation.Expires(), version)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &params.OfferInvitation{
+		Offer:    &offerDetails,
+		Macaroon: mac.M(),
+		Expires:  invitation.Expires(),
+	}, nil
+}
+
+// SetOfferLimits replaces the limits on how the specified offers may be
+// consumed. Only offer admins can set limits.
+func (api *OffersAPIV4) SetOfferLimits(args params.SetOfferLimitsArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	if len(args.Args) == 0 {
+		return result, nil
+	}
+
+	isControllerAdmin, err := api.Authorizer.HasPermission(permission.SuperuserAccess, api.ControllerModel.ControllerTag())
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+
+	offerURLs := make([]string, len(args.Args))
+	for i, arg := range args.Args {
+		offerURLs[i] = arg.OfferURL
+	}
+	models, err := api.getModelsFromOffers(offerURLs...)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+
+	for i, arg := range args.Args {
+		if models[i].err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(models[i].err)
+			continue
+		}
+		err := api.setOneOfferLimits(models[i].model.UUID(), isControllerAdmin, arg)
+		result.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return result, nil
+}
+
+func (api *OffersAPIV4) setOneOfferLimits(modelUUID string, isControllerAdmin bool, arg params.SetOfferLimitsArg) error {
+	backend, releaser, err := api.StatePool.Get(modelUUID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer releaser()
+
+	url, err := jujucrossmodel.ParseOfferURL(arg.OfferURL)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if url.HasEndpoint() {
+		return errors.Errorf("remote application %q shouldn't include endpoint", url)
+	}
+	offerTag := names.NewApplicationOfferTag(url.ApplicationName)
+	if err := api.checkCanModifyOffer(backend, isControllerAdmin, offerTag); err != nil {
+		return errors.Trace(err)
+	}
+	return api.GetApplicationOffers(backend).SetOfferLimits(url.ApplicationName, offerLimitsFromParams(arg.Limits))
+}
+
+func offerLimitsFromParams(limits params.OfferLimits) jujucrossmodel.OfferLimits {
+	return jujucrossmodel.OfferLimits{
+		MaxConnections:     limits.MaxConnections,
+		MaxRelations:       limits.MaxRelations,
+		AllowedControllers: limits.AllowedControllers,
+	}
+}
+
 // RemoteApplicationInfo returns information about the requested remote application.
 // This call currently has no client side API, only there for the GUI at this stage.
 func (api *OffersAPI) RemoteApplicationInfo(args params.OfferURLs) (params.RemoteApplicationInfoResults, error) {
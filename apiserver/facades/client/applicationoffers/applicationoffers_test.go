@@ -6,6 +6,7 @@ package applicationoffers_test
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/juju/charm/v7"
 	"github.com/juju/errors"
@@ -30,7 +31,7 @@ import (
 
 type applicationOffersSuite struct {
 	baseSuite
-	api *applicationoffers.OffersAPIV2
+	api *applicationoffers.OffersAPIV4
 }
 
 var _ = gc.Suite(&applicationOffersSuite{})
@@ -58,7 +59,11 @@ func (s *applicationOffersSuite) SetUpTest(c *gc.C) {
 		s.mockState, s.mockStatePool, s.authorizer, resources, s.authContext,
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = &applicationoffers.OffersAPIV2{OffersAPI: apiV1}
+	s.api = &applicationoffers.OffersAPIV4{
+		OffersAPIV3: &applicationoffers.OffersAPIV3{
+			OffersAPIV2: &applicationoffers.OffersAPIV2{OffersAPI: apiV1},
+		},
+	}
 }
 
 func (s *applicationOffersSuite) assertOffer(c *gc.C, expectedErr error) {
@@ -206,6 +211,90 @@ func (s *applicationOffersSuite) TestOfferError(c *gc.C) {
 	s.applicationOffers.CheckCallNames(c, addOffersBackendCall)
 }
 
+func (s *applicationOffersSuite) TestOfferWithLimits(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("admin")
+	applicationName := "test"
+	s.addApplication(c, applicationName)
+	one := params.AddApplicationOffer{
+		ModelTag:        testing.ModelTag.String(),
+		OfferName:       "offer-test",
+		ApplicationName: applicationName,
+		Endpoints:       map[string]string{"db": "db"},
+		Limits: &params.OfferLimits{
+			MaxConnections:     2,
+			MaxRelations:       3,
+			AllowedControllers: []string{testing.ControllerTag.Id()},
+		},
+	}
+	all := params.AddApplicationOffers{Offers: []params.AddApplicationOffer{one}}
+	s.applicationOffers.addOffer = func(offer jujucrossmodel.AddApplicationOfferArgs) (*jujucrossmodel.ApplicationOffer, error) {
+		c.Assert(offer.Limits, jc.DeepEquals, &jujucrossmodel.OfferLimits{
+			MaxConnections:     2,
+			MaxRelations:       3,
+			AllowedControllers: []string{testing.ControllerTag.Id()},
+		})
+		return &jujucrossmodel.ApplicationOffer{}, nil
+	}
+	ch := &mockCharm{meta: &charm.Meta{Description: "A pretty popular blog engine"}}
+	s.mockState.applications = map[string]crossmodel.Application{
+		applicationName: &mockApplication{charm: ch, bindings: map[string]string{"db": "myspace"}},
+	}
+
+	errs, err := s.api.Offer(all)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs.Results, gc.HasLen, 1)
+	c.Assert(errs.Results[0].Error, gc.IsNil)
+	s.applicationOffers.CheckCallNames(c, addOffersBackendCall)
+}
+
+func (s *applicationOffersSuite) TestSetOfferLimits(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("admin")
+	s.setupOffers(c, "", false)
+	s.applicationOffers.setOfferLimits = func(offerName string, limits jujucrossmodel.OfferLimits) error {
+		return nil
+	}
+	results, err := s.api.SetOfferLimits(params.SetOfferLimitsArgs{
+		Args: []params.SetOfferLimitsArg{{
+			OfferURL: "fred/prod.hosted-db2",
+			Limits:   params.OfferLimits{MaxConnections: 5},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	s.applicationOffers.CheckCall(c, 0, setOfferLimitsCall, "hosted-db2", jujucrossmodel.OfferLimits{MaxConnections: 5})
+}
+
+func (s *applicationOffersSuite) TestSetOfferLimitsPermission(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("mary")
+	s.setupOffers(c, "", false)
+	s.mockState.users["mary"] = &mockUser{"mary"}
+	results, err := s.api.SetOfferLimits(params.SetOfferLimitsArgs{
+		Args: []params.SetOfferLimitsArg{{
+			OfferURL: "fred/prod.hosted-db2",
+			Limits:   params.OfferLimits{MaxConnections: 5},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "permission denied")
+	s.applicationOffers.CheckNoCalls(c)
+}
+
+func (s *applicationOffersSuite) TestSetOfferLimitsRejectsEndpoints(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("admin")
+	s.setupOffers(c, "", false)
+	results, err := s.api.SetOfferLimits(params.SetOfferLimitsArgs{
+		Args: []params.SetOfferLimitsArg{{
+			OfferURL: "fred/prod.hosted-db2:db",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `remote application "fred/prod.hosted-db2:db" shouldn't include endpoint`)
+	s.applicationOffers.CheckNoCalls(c)
+}
+
 func (s *applicationOffersSuite) assertList(c *gc.C, expectedErr error, expectedCIDRS []string) {
 	s.mockState.users["mary"] = &mockUser{"mary"}
 	s.mockState.CreateOfferAccess(
@@ -361,7 +450,7 @@ func (s *applicationOffersSuite) assertShow(c *gc.C, url string, expected []para
 	s.mockState.CreateOfferAccess(
 		names.NewApplicationOfferTag("hosted-db2"),
 		names.NewUserTag("mary"), permission.ConsumeAccess)
-	filter := params.OfferURLs{[]string{url}, bakery.LatestVersion}
+	filter := params.OfferURLs{OfferURLs: []string{url}, BakeryVersion: bakery.LatestVersion}
 
 	found, err := s.api.ApplicationOffers(filter)
 	c.Assert(err, jc.ErrorIsNil)
@@ -426,6 +515,43 @@ func (s *applicationOffersSuite) TestShow(c *gc.C) {
 	s.assertShow(c, "prod.hosted-db2", expected)
 }
 
+func (s *applicationOffersSuite) TestShowDiagnostics(c *gc.C) {
+	s.setupOffers(c, "", false)
+	rel := s.mockState.relations["hosted-db2:db wordpress:db"].(*mockRelation)
+	rel.remoteEndpoint = &state.Endpoint{
+		ApplicationName: "remote-wordpress",
+		Relation:        charm.Relation{Name: "db", Interface: "db2", Role: "requirer"},
+	}
+	lastEvent := time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC)
+	expiry := lastEvent.Add(24 * time.Hour)
+	s.mockState.remoteApps = map[string]crossmodel.RemoteApplication{
+		"remote-wordpress": &mockRemoteApplication{
+			diagnostics: &jujucrossmodel.RemoteApplicationDiagnostics{
+				LastRemoteEvent:  &lastEvent,
+				MacaroonExpiry:   &expiry,
+				SettingsSyncLag:  time.Second,
+				IngressAddresses: []string{"10.0.0.1"},
+				Updated:          lastEvent,
+			},
+		},
+	}
+	s.authorizer.Tag = names.NewUserTag("admin")
+	filter := params.OfferURLs{OfferURLs: []string{"fred/prod.hosted-db2"}, BakeryVersion: bakery.LatestVersion}
+
+	found, err := s.api.ApplicationOffers(filter)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found.Results, gc.HasLen, 1)
+	c.Assert(found.Results[0].Error, gc.IsNil)
+	c.Assert(found.Results[0].Result.Connections, gc.HasLen, 1)
+	c.Assert(found.Results[0].Result.Connections[0].Diagnostics, jc.DeepEquals, &params.RemoteApplicationDiagnostics{
+		LastRemoteEvent:  &lastEvent,
+		MacaroonExpiry:   &expiry,
+		SettingsSyncLag:  time.Second,
+		IngressAddresses: []string{"10.0.0.1"},
+		Updated:          lastEvent,
+	})
+}
+
 func (s *applicationOffersSuite) TestShowNoPermission(c *gc.C) {
 	s.mockState.users["someone"] = &mockUser{"someone"}
 	user := names.NewUserTag("someone")
@@ -472,7 +598,7 @@ func (s *applicationOffersSuite) TestShowPermission(c *gc.C) {
 
 func (s *applicationOffersSuite) TestShowError(c *gc.C) {
 	url := "fred/prod.hosted-db2"
-	filter := params.OfferURLs{[]string{url}, bakery.LatestVersion}
+	filter := params.OfferURLs{OfferURLs: []string{url}, BakeryVersion: bakery.LatestVersion}
 	msg := "fail"
 
 	s.applicationOffers.listOffers = func(filters ...jujucrossmodel.ApplicationOfferFilter) ([]jujucrossmodel.ApplicationOffer, error) {
@@ -487,7 +613,7 @@ func (s *applicationOffersSuite) TestShowError(c *gc.C) {
 
 func (s *applicationOffersSuite) TestShowNotFound(c *gc.C) {
 	urls := []string{"fred/prod.hosted-db2"}
-	filter := params.OfferURLs{urls, bakery.LatestVersion}
+	filter := params.OfferURLs{OfferURLs: urls, BakeryVersion: bakery.LatestVersion}
 
 	s.applicationOffers.listOffers = func(filters ...jujucrossmodel.ApplicationOfferFilter) ([]jujucrossmodel.ApplicationOffer, error) {
 		return nil, nil
@@ -503,7 +629,7 @@ func (s *applicationOffersSuite) TestShowNotFound(c *gc.C) {
 
 func (s *applicationOffersSuite) TestShowRejectsEndpoints(c *gc.C) {
 	urls := []string{"fred/prod.hosted-db2:db"}
-	filter := params.OfferURLs{urls, bakery.LatestVersion}
+	filter := params.OfferURLs{OfferURLs: urls, BakeryVersion: bakery.LatestVersion}
 	s.mockState.model = &mockModel{uuid: testing.ModelTag.Id(), name: "prod", owner: "fred", modelType: state.ModelTypeIAAS}
 
 	found, err := s.api.ApplicationOffers(filter)
@@ -514,7 +640,7 @@ func (s *applicationOffersSuite) TestShowRejectsEndpoints(c *gc.C) {
 
 func (s *applicationOffersSuite) TestShowErrorMsgMultipleURLs(c *gc.C) {
 	urls := []string{"fred/prod.hosted-mysql", "fred/test.hosted-db2"}
-	filter := params.OfferURLs{urls, bakery.LatestVersion}
+	filter := params.OfferURLs{OfferURLs: urls, BakeryVersion: bakery.LatestVersion}
 
 	s.applicationOffers.listOffers = func(filters ...jujucrossmodel.ApplicationOfferFilter) ([]jujucrossmodel.ApplicationOffer, error) {
 		return nil, nil
@@ -556,7 +682,7 @@ func (s *applicationOffersSuite) TestShowFoundMultiple(c *gc.C) {
 		Endpoints:              map[string]charm.Relation{"db2": {Name: "db2"}},
 	}
 
-	filter := params.OfferURLs{[]string{url, url2}, bakery.LatestVersion}
+	filter := params.OfferURLs{OfferURLs: []string{url, url2}, BakeryVersion: bakery.LatestVersion}
 
 	s.applicationOffers.listOffers = func(filters ...jujucrossmodel.ApplicationOfferFilter) ([]jujucrossmodel.ApplicationOffer, error) {
 		c.Assert(filters, gc.HasLen, 1)
@@ -1054,7 +1180,7 @@ func (s *applicationOffersSuite) TestFindMissingModelInMultipleFilters(c *gc.C)
 
 type consumeSuite struct {
 	baseSuite
-	api *applicationoffers.OffersAPIV2
+	api *applicationoffers.OffersAPIV3
 }
 
 var _ = gc.Suite(&consumeSuite{})
@@ -1081,7 +1207,7 @@ func (s *consumeSuite) SetUpTest(c *gc.C) {
 		s.mockState, s.mockStatePool, s.authorizer, resources, s.authContext,
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = &applicationoffers.OffersAPIV2{OffersAPI: apiV1}
+	s.api = &applicationoffers.OffersAPIV3{OffersAPIV2: &applicationoffers.OffersAPIV2{OffersAPI: apiV1}}
 }
 
 func (s *consumeSuite) TestConsumeDetailsRejectsEndpoints(c *gc.C) {
@@ -1163,6 +1289,36 @@ func (s *consumeSuite) TestConsumeDetailsWithPermission(c *gc.C) {
 	c.Check(cav[3].Condition, gc.Equals, "declared username someone")
 }
 
+func (s *consumeSuite) TestConsumeDetailsDeclaresConsumingController(c *gc.C) {
+	s.setupOffer()
+	st := s.mockStatePool.st[testing.ModelTag.Id()]
+	st.(*mockState).users["someone"] = &mockUser{"someone"}
+	apiUser := names.NewUserTag("someone")
+	offer := names.NewApplicationOfferTag("hosted-mysql")
+	err := st.CreateOfferAccess(offer, apiUser, permission.ConsumeAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.authorizer.Tag = apiUser
+	results, err := s.api.GetConsumeDetails(params.OfferURLs{
+		OfferURLs:              []string{"fred/prod.hosted-mysql"},
+		ConsumingControllerTag: "controller-deadbeef-1bad-500d-9000-4b1d0d06f00d",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	cav := s.bakery.caveats[string(results.Results[0].Macaroon.Id())]
+	c.Assert(cav, gc.HasLen, 5)
+	c.Check(cav[4].Condition, gc.Equals, "declared consuming-controller-uuid deadbeef-1bad-500d-9000-4b1d0d06f00d")
+}
+
+func (s *consumeSuite) TestConsumeDetailsInvalidConsumingController(c *gc.C) {
+	_, err := s.api.GetConsumeDetails(params.OfferURLs{
+		OfferURLs:              []string{"fred/prod.hosted-mysql"},
+		ConsumingControllerTag: "model-deadbeef-0bad-400d-8000-4b1d0d06f00d",
+	})
+	c.Assert(err, gc.ErrorMatches, `"model-deadbeef-0bad-400d-8000-4b1d0d06f00d" is not a valid controller tag`)
+}
+
 func (s *consumeSuite) TestConsumeDetailsDefaultEndpoint(c *gc.C) {
 	s.setupOffer()
 
@@ -1202,6 +1358,79 @@ func (s *consumeSuite) TestConsumeDetailsDefaultEndpoint(c *gc.C) {
 	})
 }
 
+func (s *consumeSuite) TestCreateOfferInvitations(c *gc.C) {
+	s.setupOffer()
+	st := s.mockStatePool.st[testing.ModelTag.Id()].(*mockState)
+	s.authorizer.Tag = names.NewUserTag("admin")
+
+	results, err := s.api.CreateOfferInvitations(params.CreateOfferInvitationArgs{
+		Args: []params.CreateOfferInvitationArg{{
+			OfferURL: "fred/prod.hosted-mysql",
+			Expiry:   72 * time.Hour,
+		}},
+		BakeryVersion: bakery.LatestVersion,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	invitation := results.Results[0].Result
+	c.Assert(invitation.Offer.OfferUUID, gc.Equals, "hosted-mysql-uuid")
+	c.Assert(invitation.Offer.OfferURL, gc.Equals, "fred/prod.hosted-mysql")
+	c.Assert(invitation.Offer.Users, gc.HasLen, 0)
+	c.Assert(invitation.ControllerInfo, jc.DeepEquals, &params.ExternalControllerInfo{
+		ControllerTag: testing.ControllerTag.String(),
+		Addrs:         []string{"192.168.1.1:17070"},
+		CACert:        testing.CACert,
+	})
+	expires := time.Date(2020, 6, 4, 10, 0, 0, 0, time.UTC)
+	c.Assert(invitation.Expires, gc.Equals, expires)
+	c.Assert(st.invitations, jc.DeepEquals, []state.AddOfferInvitationParams{{
+		OfferUUID: "hosted-mysql-uuid",
+		CreatedBy: names.NewUserTag("admin"),
+		Expiry:    72 * time.Hour,
+	}})
+
+	cav := s.bakery.caveats[string(invitation.Macaroon.Id())]
+	c.Check(cav, gc.HasLen, 3)
+	c.Check(cav[0].Condition, gc.Equals, "time-before "+expires.Format(time.RFC3339Nano))
+	c.Check(cav[1].Condition, gc.Equals, "declared offer-uuid hosted-mysql-uuid")
+	c.Check(cav[2].Condition, gc.Equals, "declared offer-invitation deadbeef")
+}
+
+func (s *consumeSuite) TestCreateOfferInvitationsNoPermission(c *gc.C) {
+	s.setupOffer()
+	st := s.mockStatePool.st[testing.ModelTag.Id()].(*mockState)
+	st.users["someone"] = &mockUser{"someone"}
+	apiUser := names.NewUserTag("someone")
+	err := st.CreateOfferAccess(names.NewApplicationOfferTag("hosted-mysql"), apiUser, permission.ConsumeAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.authorizer.Tag = apiUser
+	results, err := s.api.CreateOfferInvitations(params.CreateOfferInvitationArgs{
+		Args: []params.CreateOfferInvitationArg{{
+			OfferURL: "fred/prod.hosted-mysql",
+			Expiry:   time.Hour,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "permission denied")
+	c.Assert(st.invitations, gc.HasLen, 0)
+}
+
+func (s *consumeSuite) TestCreateOfferInvitationsInvalidExpiry(c *gc.C) {
+	s.setupOffer()
+	s.authorizer.Tag = names.NewUserTag("admin")
+	results, err := s.api.CreateOfferInvitations(params.CreateOfferInvitationArgs{
+		Args: []params.CreateOfferInvitationArg{{
+			OfferURL: "fred/prod.hosted-mysql",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "expiry 0s not valid")
+}
+
 func (s *consumeSuite) setupOffer() {
 	modelUUID := testing.ModelTag.Id()
 	offerName := "hosted-mysql"
@@ -1231,6 +1460,7 @@ func (s *consumeSuite) setupOffer() {
 	st.applications["mysql"] = &mockApplication{
 		name:     "mysql",
 		charm:    &mockCharm{meta: &charm.Meta{Description: "A pretty popular database"}},
+		curl:     charm.MustParseURL("mysql-2"),
 		bindings: map[string]string{"database": "myspace"},
 		endpoints: []state.Endpoint{
 			{Relation: charm.Relation{Name: "juju-info", Role: "provider", Interface: "juju-info", Limit: 0, Scope: "global"}},
@@ -1342,7 +1572,7 @@ func (s *consumeSuite) assertDestroyOffersNoForce(c *gc.C, api destroyOffers) {
 	})
 
 	urls := []string{"fred/prod.hosted-db2"}
-	filter := params.OfferURLs{urls, bakery.LatestVersion}
+	filter := params.OfferURLs{OfferURLs: urls, BakeryVersion: bakery.LatestVersion}
 	found, err := s.api.ApplicationOffers(filter)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(found.Results, gc.HasLen, 1)
@@ -1383,7 +1613,7 @@ func (s *consumeSuite) TestDestroyOffersForce(c *gc.C) {
 	})
 
 	urls := []string{"fred/prod.hosted-db2"}
-	filter := params.OfferURLs{urls, bakery.LatestVersion}
+	filter := params.OfferURLs{OfferURLs: urls, BakeryVersion: bakery.LatestVersion}
 	found, err := s.api.ApplicationOffers(filter)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(found.Results, gc.HasLen, 1)
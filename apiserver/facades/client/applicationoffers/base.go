@@ -174,12 +174,36 @@ func (api *BaseAPI) applicationOffersFromModel(
 			if err := api.getOfferAdminDetails(backend, app, &offer); err != nil {
 				logger.Warningf("cannot get offer admin details: %v", err)
 			}
+			if limits := appOffer.Limits; !limits.IsZero() {
+				offer.Limits = &params.OfferLimits{
+					MaxConnections:     limits.MaxConnections,
+					MaxRelations:       limits.MaxRelations,
+					AllowedControllers: limits.AllowedControllers,
+				}
+			}
 		}
 		results = append(results, offer)
 	}
 	return results, nil
 }
 
+// connectionDiagnostics returns the cross model relation diagnostics
+// recorded for the consuming side of an offer connection, as seen from
+// the offering model.
+func connectionDiagnostics(backend Backend, appName string, rel crossmodel.Relation) (*params.RemoteApplicationDiagnostics, error) {
+	for _, ep := range rel.Endpoints() {
+		if ep.ApplicationName == appName {
+			continue
+		}
+		diagnostics, err := crossmodel.RemoteApplicationDiagnostics(backend, ep.ApplicationName)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		return diagnostics, errors.Trace(err)
+	}
+	return nil, nil
+}
+
 func (api *BaseAPI) getOfferAdminDetails(backend Backend, app crossmodel.Application, offer *params.ApplicationOfferAdminDetails) error {
 	curl, _ := app.CharmURL()
 	conns, err := backend.OfferConnections(offer.OfferUUID)
@@ -220,6 +244,10 @@ func (api *BaseAPI) getOfferAdminDetails(backend Backend, app crossmodel.Applica
 		if err == nil {
 			connDetails.IngressSubnets = relIngress.CIDRS()
 		}
+		connDetails.Diagnostics, err = connectionDiagnostics(backend, app.Name(), rel)
+		if err != nil {
+			return errors.Trace(err)
+		}
 		offer.Connections = append(offer.Connections, connDetails)
 	}
 
@@ -38,14 +38,17 @@ const (
 	listOffersCall  = "listOffersCall"
 	updateOfferCall = "updateOfferCall"
 	removeOfferCall = "removeOfferCall"
+
+	setOfferLimitsCall = "setOfferLimitsCall"
 )
 
 type stubApplicationOffers struct {
 	jtesting.Stub
 	jujucrossmodel.ApplicationOffers
 
-	addOffer   func(offer jujucrossmodel.AddApplicationOfferArgs) (*jujucrossmodel.ApplicationOffer, error)
-	listOffers func(filters ...jujucrossmodel.ApplicationOfferFilter) ([]jujucrossmodel.ApplicationOffer, error)
+	addOffer       func(offer jujucrossmodel.AddApplicationOfferArgs) (*jujucrossmodel.ApplicationOffer, error)
+	listOffers     func(filters ...jujucrossmodel.ApplicationOfferFilter) ([]jujucrossmodel.ApplicationOffer, error)
+	setOfferLimits func(offerName string, limits jujucrossmodel.OfferLimits) error
 }
 
 func (m *stubApplicationOffers) AddOffer(offer jujucrossmodel.AddApplicationOfferArgs) (*jujucrossmodel.ApplicationOffer, error) {
@@ -63,6 +66,11 @@ func (m *stubApplicationOffers) UpdateOffer(offer jujucrossmodel.AddApplicationO
 	panic("not implemented")
 }
 
+func (m *stubApplicationOffers) SetOfferLimits(offerName string, limits jujucrossmodel.OfferLimits) error {
+	m.AddCall(setOfferLimitsCall, offerName, limits)
+	return m.setOfferLimits(offerName, limits)
+}
+
 func (m *stubApplicationOffers) Remove(url string, force bool) error {
 	m.AddCall(removeOfferCall)
 	panic("not implemented")
@@ -223,8 +231,9 @@ func (m *mockSubnet) AvailabilityZones() []string {
 
 type mockRelation struct {
 	crossmodel.Relation
-	id       int
-	endpoint state.Endpoint
+	id             int
+	endpoint       state.Endpoint
+	remoteEndpoint *state.Endpoint
 }
 
 func (m *mockRelation) Status() (status.StatusInfo, error) {
@@ -238,6 +247,26 @@ func (m *mockRelation) Endpoint(appName string) (state.Endpoint, error) {
 	return m.endpoint, nil
 }
 
+func (m *mockRelation) Endpoints() []state.Endpoint {
+	endpoints := []state.Endpoint{m.endpoint}
+	if m.remoteEndpoint != nil {
+		endpoints = append(endpoints, *m.remoteEndpoint)
+	}
+	return endpoints
+}
+
+type mockRemoteApplication struct {
+	crossmodel.RemoteApplication
+	diagnostics *jujucrossmodel.RemoteApplicationDiagnostics
+}
+
+func (m *mockRemoteApplication) Diagnostics() (*jujucrossmodel.RemoteApplicationDiagnostics, error) {
+	if m.diagnostics == nil {
+		return nil, errors.NotFoundf("diagnostics")
+	}
+	return m.diagnostics, nil
+}
+
 type mockOfferConnection struct {
 	modelUUID   string
 	username    string
@@ -302,11 +331,34 @@ type mockState struct {
 	users             map[string]applicationoffers.User
 	applications      map[string]crossmodel.Application
 	applicationOffers map[string]jujucrossmodel.ApplicationOffer
+	remoteApps        map[string]crossmodel.RemoteApplication
 	spaces            map[string]applicationoffers.Space
 	relations         map[string]crossmodel.Relation
 	connections       []applicationoffers.OfferConnection
 	accessPerms       map[offerAccess]permission.Access
 	relationNetworks  state.RelationNetworks
+	invitations       []state.AddOfferInvitationParams
+}
+
+func (m *mockState) AddOfferInvitation(args state.AddOfferInvitationParams) (applicationoffers.OfferInvitation, error) {
+	m.invitations = append(m.invitations, args)
+	return &mockOfferInvitation{
+		id:      "deadbeef",
+		expires: time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC).Add(args.Expiry),
+	}, nil
+}
+
+type mockOfferInvitation struct {
+	id      string
+	expires time.Time
+}
+
+func (m *mockOfferInvitation) Id() string {
+	return m.id
+}
+
+func (m *mockOfferInvitation) Expires() time.Time {
+	return m.expires
 }
 
 func (m *mockState) GetAddressAndCertGetter() common.AddressAndCertGetter {
@@ -329,6 +381,14 @@ func (m *mockState) Application(name string) (crossmodel.Application, error) {
 	return app, nil
 }
 
+func (m *mockState) RemoteApplication(name string) (crossmodel.RemoteApplication, error) {
+	app, ok := m.remoteApps[name]
+	if !ok {
+		return nil, errors.NotFoundf("remote application %q", name)
+	}
+	return app, nil
+}
+
 func (m *mockState) ApplicationOffer(name string) (*jujucrossmodel.ApplicationOffer, error) {
 	offer, ok := m.applicationOffers[name]
 	if !ok {
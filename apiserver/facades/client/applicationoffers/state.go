@@ -4,6 +4,8 @@
 package applicationoffers
 
 import (
+	"time"
+
 	"github.com/juju/charm/v7"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
@@ -67,6 +69,7 @@ type Backend interface {
 	UpdateOfferAccess(offer names.ApplicationOfferTag, user names.UserTag, access permission.Access) error
 	RemoveOfferAccess(offer names.ApplicationOfferTag, user names.UserTag) error
 	GetOfferUsers(offerUUID string) (map[string]permission.Access, error)
+	AddOfferInvitation(state.AddOfferInvitationParams) (OfferInvitation, error)
 
 	// GetModelCallContext gets everything that is needed to make cloud calls on behalf of the state current model.
 	GetModelCallContext() context.ProviderCallContext
@@ -102,6 +105,14 @@ func (s stateShim) GetOfferUsers(offerUUID string) (map[string]permission.Access
 	return s.st.GetOfferUsers(offerUUID)
 }
 
+func (s stateShim) AddOfferInvitation(args state.AddOfferInvitationParams) (OfferInvitation, error) {
+	invitation, err := s.st.AddOfferInvitation(args)
+	if err != nil {
+		return nil, err
+	}
+	return invitation, nil
+}
+
 func (s *stateShim) SpaceByName(name string) (Space, error) {
 	sp, err := s.st.SpaceByName(name)
 	return &spaceShim{sp}, err
@@ -212,6 +223,12 @@ type OfferConnection interface {
 	RelationId() int
 }
 
+// OfferInvitation describes an invitation to consume an offer.
+type OfferInvitation interface {
+	Id() string
+	Expires() time.Time
+}
+
 type offerConnectionShim struct {
 	*state.OfferConnection
 }
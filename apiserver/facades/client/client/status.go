@@ -237,6 +237,9 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 	if context.relations, context.relationsById, err = fetchRelations(c.api.stateAccessor); err != nil {
 		return noStatus, errors.Annotate(err, "could not fetch relations")
 	}
+	if context.healthChecks, err = c.api.stateAccessor.AllUnitHealthChecks(); err != nil {
+		return noStatus, errors.Annotate(err, "could not fetch unit health checks")
+	}
 	if len(context.allAppsUnitsCharmBindings.applications) > 0 {
 		if context.leaders, err = c.api.leadershipReader.Leaders(); err != nil {
 			return noStatus, errors.Annotate(err, "could not fetch leaders")
@@ -245,6 +248,10 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 	if context.controllerTimestamp, err = c.api.stateAccessor.ControllerTimestamp(); err != nil {
 		return noStatus, errors.Annotate(err, "could not fetch controller timestamp")
 	}
+	controllerConfig, err := c.api.stateAccessor.ControllerConfig()
+	if err != nil {
+		return noStatus, errors.Annotate(err, "could not fetch controller config")
+	}
 	context.branches = fetchBranches(c.api.modelCache)
 
 	logger.Tracef("Applications: %v", context.allAppsUnitsCharmBindings.applications)
@@ -397,6 +404,9 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 		Relations:           context.processRelations(),
 		ControllerTimestamp: context.controllerTimestamp,
 		Branches:            context.processBranches(),
+
+		ControllerMaintenance:        controllerConfig.MaintenanceMode(),
+		ControllerMaintenanceMessage: controllerConfig.MaintenanceMessage(),
 	}, nil
 }
 
@@ -539,6 +549,9 @@ type statusContext struct {
 	// opened ports by subnet.
 	openPortsBySubnet map[string][]state.MachineSubnetPorts
 
+	// health checks: unit name -> health checks reported by the unit
+	healthChecks map[string][]status.HealthCheck
+
 	// offers: offer name -> offer
 	offers map[string]offerStatus
 
@@ -1461,6 +1474,7 @@ func (context *statusContext) processUnit(unit *state.Unit, applicationCharm str
 	if leader := context.leaders[unit.ApplicationName()]; leader == unit.Name() {
 		result.Leader = true
 	}
+	result.HealthChecks = common.HealthChecksToParams(context.healthChecks[unit.Name()])
 	return result
 }
 
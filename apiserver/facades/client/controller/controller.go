@@ -57,10 +57,44 @@ type ControllerAPI struct {
 	multiwatcherFactory multiwatcher.Factory
 }
 
+// ControllerAPIv13 provides the v13 Controller API. The only difference
+// between this and v14 is that v13 doesn't have the
+// EnableMaintenanceMode and DisableMaintenanceMode methods.
+type ControllerAPIv13 struct {
+	*ControllerAPI
+}
+
+// ControllerAPIv12 provides the v12 Controller API. The only difference
+// between this and v13 is that v12 ignores the keep-source and revert
+// options of InitiateMigration.
+type ControllerAPIv12 struct {
+	*ControllerAPIv13
+}
+
+// ControllerAPIv11 provides the v11 Controller API. The only difference
+// between this and v12 is that v11 doesn't have the ModelMigrations
+// method.
+type ControllerAPIv11 struct {
+	*ControllerAPIv12
+}
+
+// ControllerAPIv10 provides the v10 Controller API. The only difference
+// between this and v11 is that v10 doesn't have the ExportModel method.
+type ControllerAPIv10 struct {
+	*ControllerAPIv11
+}
+
+// ControllerAPIv9 provides the v9 Controller API. The only difference
+// between this and v10 is that v9 doesn't have the MigrationPrechecks
+// method.
+type ControllerAPIv9 struct {
+	*ControllerAPIv10
+}
+
 // ControllerAPIv8 provides the v8 Controller API. The only difference
 // between this and v9 is that v8 doesn't have the model summary watchers.
 type ControllerAPIv8 struct {
-	*ControllerAPI
+	*ControllerAPIv9
 }
 
 // ControllerAPIv7 provides the v7 Controller API. The only difference
@@ -95,10 +129,10 @@ type ControllerAPIv3 struct {
 
 // LatestAPI is used for testing purposes to create the latest
 // controller API.
-var LatestAPI = NewControllerAPIv9
+var LatestAPI = NewControllerAPIv14
 
-// NewControllerAPIv9 creates a new ControllerAPIv9.
-func NewControllerAPIv9(ctx facade.Context) (*ControllerAPI, error) {
+// NewControllerAPIv14 creates a new ControllerAPIv14.
+func NewControllerAPIv14(ctx facade.Context) (*ControllerAPI, error) {
 	st := ctx.State()
 	authorizer := ctx.Auth()
 	pool := ctx.StatePool()
@@ -120,6 +154,51 @@ func NewControllerAPIv9(ctx facade.Context) (*ControllerAPI, error) {
 	)
 }
 
+// NewControllerAPIv13 creates a new ControllerAPIv13.
+func NewControllerAPIv13(ctx facade.Context) (*ControllerAPIv13, error) {
+	v14, err := NewControllerAPIv14(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv13{v14}, nil
+}
+
+// NewControllerAPIv12 creates a new ControllerAPIv12.
+func NewControllerAPIv12(ctx facade.Context) (*ControllerAPIv12, error) {
+	v13, err := NewControllerAPIv13(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv12{v13}, nil
+}
+
+// NewControllerAPIv11 creates a new ControllerAPIv11.
+func NewControllerAPIv11(ctx facade.Context) (*ControllerAPIv11, error) {
+	v12, err := NewControllerAPIv12(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv11{v12}, nil
+}
+
+// NewControllerAPIv10 creates a new ControllerAPIv10.
+func NewControllerAPIv10(ctx facade.Context) (*ControllerAPIv10, error) {
+	v11, err := NewControllerAPIv11(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv10{v11}, nil
+}
+
+// NewControllerAPIv9 creates a new ControllerAPIv9.
+func NewControllerAPIv9(ctx facade.Context) (*ControllerAPIv9, error) {
+	v10, err := NewControllerAPIv10(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv9{v10}, nil
+}
+
 // NewControllerAPIv8 creates a new ControllerAPIv8.
 func NewControllerAPIv8(ctx facade.Context) (*ControllerAPIv8, error) {
 	v9, err := NewControllerAPIv9(ctx)
@@ -600,17 +679,35 @@ func (c *ControllerAPI) InitiateMigration(reqArgs params.InitiateMigrationArgs)
 	return out, nil
 }
 
+// InitiateMigration attempts to begin the migration of one or
+// more models to other controllers. Models are never kept on this
+// controller after they are migrated, and migrations can't be
+// reverted.
+func (c *ControllerAPIv12) InitiateMigration(reqArgs params.InitiateMigrationArgs) (
+	params.InitiateMigrationResults, error,
+) {
+	specs := make([]params.MigrationSpec, len(reqArgs.Specs))
+	for i, spec := range reqArgs.Specs {
+		spec.KeepSource = 0
+		spec.Revert = false
+		specs[i] = spec
+	}
+	return c.ControllerAPIv13.InitiateMigration(params.InitiateMigrationArgs{Specs: specs})
+}
+
 func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string, error) {
-	modelTag, err := names.ParseModelTag(spec.ModelTag)
+	modelTag, targetInfo, err := c.migrationSpecInfo(spec)
 	if err != nil {
-		return "", errors.Annotate(err, "model tag")
+		return "", errors.Trace(err)
 	}
-
-	// Ensure the model exists.
-	if modelExists, err := c.state.ModelExists(modelTag.Id()); err != nil {
-		return "", errors.Annotate(err, "reading model")
-	} else if !modelExists {
-		return "", errors.NotFoundf("model")
+	if spec.KeepSource < 0 {
+		return "", errors.NotValidf("negative keep-source duration")
+	}
+	if spec.Revert && spec.KeepSource > 0 {
+		return "", errors.NotValidf("keep-source when reverting a migration")
+	}
+	if err := c.checkMigrationLimit(); err != nil {
+		return "", errors.Trace(err)
 	}
 
 	hostedState, err := c.statePool.Get(modelTag.Id())
@@ -619,23 +716,235 @@ func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string,
 	}
 	defer hostedState.Release()
 
+	// Check if the migration is likely to succeed.
+	if err := runMigrationPrechecks(hostedState.State, c.statePool.SystemState(), &targetInfo, c.presence, spec.Revert); err != nil {
+		return "", errors.Trace(err)
+	}
+
+	// Trigger the migration.
+	mig, err := hostedState.CreateMigration(state.MigrationSpec{
+		InitiatedBy: c.apiUser,
+		TargetInfo:  targetInfo,
+		KeepSource:  spec.KeepSource,
+		Revert:      spec.Revert,
+	})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return mig.Id(), nil
+}
+
+// checkMigrationLimit returns an error satisfying
+// errors.IsQuotaLimitExceeded if the controller is already running as
+// many migrations as its max-concurrent-migrations config allows.
+func (c *ControllerAPI) checkMigrationLimit() error {
+	cfg, err := c.state.ControllerConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	running, err := c.state.RunningMigrationCount()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if max := cfg.MaxConcurrentMigrations(); running >= max {
+		return errors.QuotaLimitExceededf("controller already running %d model migrations (%s is %d)",
+			running, corecontroller.MaxConcurrentMigrations, max)
+	}
+	return nil
+}
+
+// MigrationPrechecks runs every source and target controller check for
+// each of the specified migrations without initiating them. Unlike
+// InitiateMigration, all problems found are reported rather than just
+// the first.
+func (c *ControllerAPI) MigrationPrechecks(reqArgs params.InitiateMigrationArgs) (
+	params.MigrationPrecheckResults, error,
+) {
+	out := params.MigrationPrecheckResults{
+		Results: make([]params.MigrationPrecheckResult, len(reqArgs.Specs)),
+	}
+	if err := c.checkIsSuperUser(); err != nil {
+		return out, errors.Trace(err)
+	}
+
+	for i, spec := range reqArgs.Specs {
+		result := &out.Results[i]
+		result.ModelTag = spec.ModelTag
+		source, target, err := c.precheckOneMigration(spec)
+		if err != nil {
+			result.Error = apiservererrors.ServerError(err)
+			continue
+		}
+		result.Source = source
+		result.Target = target
+	}
+	return out, nil
+}
+
+// ControllerAPIv9 doesn't have the MigrationPrechecks method.
+func (c *ControllerAPIv9) MigrationPrechecks(_, _ struct{}) {}
+
+func (c *ControllerAPI) precheckOneMigration(spec params.MigrationSpec) (
+	params.MigrationPrecheckReport, params.MigrationPrecheckReport, error,
+) {
+	var empty params.MigrationPrecheckReport
+	modelTag, targetInfo, err := c.migrationSpecInfo(spec)
+	if err != nil {
+		return empty, empty, errors.Trace(err)
+	}
+
+	hostedState, err := c.statePool.Get(modelTag.Id())
+	if err != nil {
+		return empty, empty, errors.Trace(err)
+	}
+	defer hostedState.Release()
+
+	return runMigrationPrecheckReports(hostedState.State, c.statePool.SystemState(), &targetInfo, c.presence)
+}
+
+// ExportModel returns the serialized form of each of the specified
+// models, along with the charms, agent binaries and resources they use,
+// so that they can be imported into a controller which can't be reached
+// from this one. The models themselves are not changed.
+func (c *ControllerAPI) ExportModel(args params.Entities) (params.SerializedModelResults, error) {
+	results := params.SerializedModelResults{
+		Results: make([]params.SerializedModelResult, len(args.Entities)),
+	}
+	if err := c.checkIsSuperUser(); err != nil {
+		return results, errors.Trace(err)
+	}
+
+	for i, arg := range args.Entities {
+		serialized, err := c.exportOneModel(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = &serialized
+	}
+	return results, nil
+}
+
+// ControllerAPIv10 doesn't have the ExportModel method.
+func (c *ControllerAPIv10) ExportModel(_, _ struct{}) {}
+
+func (c *ControllerAPI) exportOneModel(tag string) (params.SerializedModel, error) {
+	var empty params.SerializedModel
+	modelTag, err := names.ParseModelTag(tag)
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	if modelTag.Id() == c.state.ControllerModelUUID() {
+		return empty, errors.NotSupportedf("exporting the controller model")
+	}
+	st, err := c.statePool.Get(modelTag.Id())
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	defer st.Release()
+
+	model, err := st.Export()
+	if err != nil {
+		return empty, errors.Annotate(err, "exporting model")
+	}
+	return common.SerializeModel(model)
+}
+
+// ModelMigrations returns the most recent migration of each model which
+// has been migrated, or has had a migration attempted, from this
+// controller, including models which have since been removed after a
+// successful migration.
+func (c *ControllerAPI) ModelMigrations() (params.ModelMigrationReports, error) {
+	var results params.ModelMigrationReports
+	if err := c.checkIsSuperUser(); err != nil {
+		return results, errors.Trace(err)
+	}
+
+	migs, err := c.state.LatestMigrations()
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	results.Migrations = make([]params.ModelMigrationReport, len(migs))
+	for i, mig := range migs {
+		report, err := makeModelMigrationReport(mig)
+		if err != nil {
+			return results, errors.Annotatef(err, "migration %q", mig.Id())
+		}
+		results.Migrations[i] = report
+	}
+	return results, nil
+}
+
+// ControllerAPIv11 doesn't have the ModelMigrations method.
+func (c *ControllerAPIv11) ModelMigrations(_, _ struct{}) {}
+
+func makeModelMigrationReport(mig state.ModelMigration) (params.ModelMigrationReport, error) {
+	var report params.ModelMigrationReport
+	phase, err := mig.Phase()
+	if err != nil {
+		return report, errors.Trace(err)
+	}
+	target, err := mig.TargetInfo()
+	if err != nil {
+		return report, errors.Trace(err)
+	}
+	targetName := target.ControllerAlias
+	if targetName == "" {
+		targetName = target.ControllerTag.Id()
+	}
+	report = params.ModelMigrationReport{
+		MigrationId:      mig.Id(),
+		ModelTag:         names.NewModelTag(mig.ModelUUID()).String(),
+		ModelName:        mig.ModelName(),
+		TargetController: targetName,
+		Phase:            phase.String(),
+		StatusMessage:    mig.StatusMessage(),
+		Start:            mig.StartTime(),
+		PhaseChanged:     mig.PhaseChangedTime(),
+	}
+	if failed := mig.FailedPhase(); failed != coremigration.UNKNOWN {
+		report.FailedPhase = failed.String()
+	}
+	if end := mig.EndTime(); !end.IsZero() {
+		report.End = &end
+	}
+	return report, nil
+}
+
+// migrationSpecInfo validates the migration spec, ensuring the model
+// exists, and returns the model tag and target controller details.
+func (c *ControllerAPI) migrationSpecInfo(spec params.MigrationSpec) (names.ModelTag, coremigration.TargetInfo, error) {
+	var modelTag names.ModelTag
+	var targetInfo coremigration.TargetInfo
+	modelTag, err := names.ParseModelTag(spec.ModelTag)
+	if err != nil {
+		return modelTag, targetInfo, errors.Annotate(err, "model tag")
+	}
+
+	// Ensure the model exists.
+	if modelExists, err := c.state.ModelExists(modelTag.Id()); err != nil {
+		return modelTag, targetInfo, errors.Annotate(err, "reading model")
+	} else if !modelExists {
+		return modelTag, targetInfo, errors.NotFoundf("model")
+	}
+
 	// Construct target info.
 	specTarget := spec.TargetInfo
 	controllerTag, err := names.ParseControllerTag(specTarget.ControllerTag)
 	if err != nil {
-		return "", errors.Annotate(err, "controller tag")
+		return modelTag, targetInfo, errors.Annotate(err, "controller tag")
 	}
 	authTag, err := names.ParseUserTag(specTarget.AuthTag)
 	if err != nil {
-		return "", errors.Annotate(err, "auth tag")
+		return modelTag, targetInfo, errors.Annotate(err, "auth tag")
 	}
 	var macs []macaroon.Slice
 	if specTarget.Macaroons != "" {
 		if err := json.Unmarshal([]byte(specTarget.Macaroons), &macs); err != nil {
-			return "", errors.Annotate(err, "invalid macaroons")
+			return modelTag, targetInfo, errors.Annotate(err, "invalid macaroons")
 		}
 	}
-	targetInfo := coremigration.TargetInfo{
+	targetInfo = coremigration.TargetInfo{
 		ControllerTag:   controllerTag,
 		ControllerAlias: specTarget.ControllerAlias,
 		Addrs:           specTarget.Addrs,
@@ -644,21 +953,7 @@ func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string,
 		Password:        specTarget.Password,
 		Macaroons:       macs,
 	}
-
-	// Check if the migration is likely to succeed.
-	if err := runMigrationPrechecks(hostedState.State, c.statePool.SystemState(), &targetInfo, c.presence); err != nil {
-		return "", errors.Trace(err)
-	}
-
-	// Trigger the migration.
-	mig, err := hostedState.CreateMigration(state.MigrationSpec{
-		InitiatedBy: c.apiUser,
-		TargetInfo:  targetInfo,
-	})
-	if err != nil {
-		return "", errors.Trace(err)
-	}
-	return mig.Id(), nil
+	return modelTag, targetInfo, nil
 }
 
 // ModifyControllerAccess changes the model access granted to users.
@@ -713,6 +1008,12 @@ func (c *ControllerAPI) ConfigSet(args params.ControllerConfigSet) error {
 	if err := c.state.UpdateControllerConfig(args.Config, nil); err != nil {
 		return errors.Trace(err)
 	}
+	return errors.Trace(c.publishControllerConfig())
+}
+
+// publishControllerConfig tells the API servers that the controller
+// config has changed.
+func (c *ControllerAPI) publishControllerConfig() error {
 	// TODO(thumper): add a version to controller config to allow for
 	// simultaneous updates and races in publishing, potentially across
 	// HA servers.
@@ -735,10 +1036,48 @@ func (c *ControllerAPI) ConfigSet(args params.ControllerConfigSet) error {
 // ConfigSet isn't on the v4 API.
 func (c *ControllerAPIv4) ConfigSet(_, _ struct{}) {}
 
+// EnableMaintenanceMode puts the controller into maintenance mode,
+// in which user API calls that would change anything in any of its
+// models are rejected with the given message. Read-only calls and
+// agents carry on working. Enabling maintenance mode again replaces
+// the message.
+func (c *ControllerAPI) EnableMaintenanceMode(args params.MaintenanceModeArgs) error {
+	if err := c.checkIsSuperUser(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := c.state.SetMaintenanceMode(true, args.Message); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("maintenance mode enabled by %s: %s", c.apiUser.Id(), args.Message)
+	return errors.Trace(c.publishControllerConfig())
+}
+
+// DisableMaintenanceMode takes the controller out of maintenance mode.
+func (c *ControllerAPI) DisableMaintenanceMode() error {
+	if err := c.checkIsSuperUser(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := c.state.SetMaintenanceMode(false, ""); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("maintenance mode disabled by %s", c.apiUser.Id())
+	return errors.Trace(c.publishControllerConfig())
+}
+
+// Mask the maintenance mode methods from the v13 API.
+
+// EnableMaintenanceMode isn't on the v13 API.
+func (c *ControllerAPIv13) EnableMaintenanceMode(_, _ struct{}) {}
+
+// DisableMaintenanceMode isn't on the v13 API.
+func (c *ControllerAPIv13) DisableMaintenanceMode(_, _ struct{}) {}
+
 // runMigrationPrechecks runs prechecks on the migration and updates
 // information in targetInfo as needed based on information
-// retrieved from the target controller.
-var runMigrationPrechecks = func(st, ctlrSt *state.State, targetInfo *coremigration.TargetInfo, presence facade.Presence) error {
+// retrieved from the target controller. When reverting a migration
+// the target controller must be keeping the model from when it was
+// migrated away.
+var runMigrationPrechecks = func(st, ctlrSt *state.State, targetInfo *coremigration.TargetInfo, presence facade.Presence, revert bool) error {
 	// Check model and source controller.
 	backend, err := migration.PrecheckShim(st, ctlrSt)
 	if err != nil {
@@ -779,10 +1118,79 @@ var runMigrationPrechecks = func(st, ctlrSt *state.State, targetInfo *coremigrat
 			return errors.New("controller API version is too old")
 		}
 	}
-	err = client.Prechecks(modelInfo)
+	if revert {
+		var fingerprint string
+		fingerprint, err = migration.ExportModelFingerprint(st)
+		if err != nil {
+			return errors.Annotate(err, "summarising model")
+		}
+		err = client.RevertPrechecks(modelInfo, fingerprint)
+		if errors.IsNotSupported(err) {
+			return errors.New("target controller does not support reverting migrations")
+		}
+	} else {
+		err = client.Prechecks(modelInfo)
+	}
 	return errors.Annotate(err, "target prechecks failed")
 }
 
+// runMigrationPrecheckReports runs all of the migration prechecks
+// against the source and target controllers without stopping at the
+// first problem, returning the blocking and warning findings of each.
+// Nothing is changed on either controller.
+var runMigrationPrecheckReports = func(st, ctlrSt *state.State, targetInfo *coremigration.TargetInfo, presence facade.Presence) (
+	params.MigrationPrecheckReport, params.MigrationPrecheckReport, error,
+) {
+	var source, target params.MigrationPrecheckReport
+
+	// Check model and source controller.
+	backend, err := migration.PrecheckShim(st, ctlrSt)
+	if err != nil {
+		return source, target, errors.Annotate(err, "creating backend")
+	}
+	modelPresence := presence.ModelPresence(st.ModelUUID())
+	controllerPresence := presence.ModelPresence(ctlrSt.ModelUUID())
+	sourceReport, err := migration.SourcePrecheckReport(backend, modelPresence, controllerPresence)
+	if err != nil {
+		return source, target, errors.Annotate(err, "source prechecks failed")
+	}
+	source.Blockers = sourceReport.Blockers
+	source.Warnings = sourceReport.Warnings
+
+	// Check target controller.
+	conn, err := api.Open(targetToAPIInfo(targetInfo), migration.ControllerDialOpts())
+	if err != nil {
+		return source, target, errors.Annotate(err, "connect to target controller")
+	}
+	defer conn.Close()
+	modelInfo, srcUserList, err := makeModelInfo(st, ctlrSt)
+	if err != nil {
+		return source, target, errors.Trace(err)
+	}
+	dstUserList, err := getTargetControllerUsers(conn)
+	if err != nil {
+		return source, target, errors.Trace(err)
+	}
+	if err = srcUserList.checkCompatibilityWith(dstUserList); err != nil {
+		target.Blockers = append(target.Blockers, err.Error())
+	}
+	client := migrationtarget.NewClient(conn)
+	targetReport, err := client.PrecheckReport(modelInfo)
+	if errors.IsNotSupported(err) {
+		// Older target controllers can only report the first problem
+		// they find.
+		if err := client.Prechecks(modelInfo); err != nil {
+			target.Blockers = append(target.Blockers, err.Error())
+		}
+		return source, target, nil
+	} else if err != nil {
+		return source, target, errors.Annotate(err, "target prechecks failed")
+	}
+	target.Blockers = append(target.Blockers, targetReport.Blockers...)
+	target.Warnings = targetReport.Warnings
+	return source, target, nil
+}
+
 // userList encapsulates information about the users who have been granted
 // access to a model or the users known to a particular controller.
 type userList struct {
@@ -897,6 +1305,8 @@ func makeModelInfo(st, ctlrSt *state.State) (coremigration.ModelInfo, userList,
 		Owner:                  model.Owner(),
 		AgentVersion:           agentVersion,
 		ControllerAgentVersion: controllerVersion,
+		CloudName:              model.CloudName(),
+		CloudRegion:            model.CloudRegion(),
 	}, ul, nil
 }
 
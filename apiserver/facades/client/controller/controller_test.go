@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/juju/clock"
+	"github.com/juju/description/v2"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/names/v4"
@@ -29,6 +30,7 @@ import (
 	"github.com/juju/juju/cloud"
 	corecontroller "github.com/juju/juju/controller"
 	"github.com/juju/juju/core/cache"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/environs"
 	environscloudspec "github.com/juju/juju/environs/cloudspec"
@@ -499,6 +501,95 @@ func (s *controllerSuite) TestInitiateMigration(c *gc.C) {
 	}
 }
 
+func (s *controllerSuite) makeKeepSourceSpec(c *gc.C, st *state.State) params.MigrationSpec {
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	return params.MigrationSpec{
+		ModelTag: model.ModelTag().String(),
+		TargetInfo: params.MigrationTargetInfo{
+			ControllerTag: randomControllerTag(),
+			Addrs:         []string{"1.1.1.1:1111"},
+			CACert:        "cert1",
+			AuthTag:       names.NewUserTag("admin1").String(),
+			Password:      "secret1",
+		},
+		KeepSource: 24 * time.Hour,
+	}
+}
+
+func (s *controllerSuite) TestInitiateMigrationKeepSource(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	controller.SetPrecheckResult(s, nil)
+
+	out, err := s.controller.InitiateMigration(params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{s.makeKeepSourceSpec(c, st)},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Assert(out.Results[0].Error, gc.IsNil)
+
+	mig, err := st.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig.KeepSource(), gc.Equals, 24*time.Hour)
+	c.Check(mig.Revert(), jc.IsFalse)
+}
+
+func (s *controllerSuite) TestInitiateMigrationKeepSourceWithRevert(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	controller.SetPrecheckResult(s, nil)
+
+	spec := s.makeKeepSourceSpec(c, st)
+	spec.Revert = true
+	out, err := s.controller.InitiateMigration(params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{spec},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Check(out.Results[0].Error, gc.ErrorMatches, "keep-source when reverting a migration not valid")
+}
+
+func (s *controllerSuite) TestInitiateMigrationV12IgnoresKeepSource(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	controller.SetPrecheckResult(s, nil)
+
+	api, err := controller.NewControllerAPIv12(s.context)
+	c.Assert(err, jc.ErrorIsNil)
+	out, err := api.InitiateMigration(params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{s.makeKeepSourceSpec(c, st)},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Assert(out.Results[0].Error, gc.IsNil)
+
+	mig, err := st.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig.KeepSource(), gc.Equals, time.Duration(0))
+}
+
+func (s *controllerSuite) TestInitiateMigrationLimit(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		corecontroller.MaxConcurrentMigrations: 1,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	st1 := s.Factory.MakeModel(c, nil)
+	defer st1.Close()
+	st2 := s.Factory.MakeModel(c, nil)
+	defer st2.Close()
+	controller.SetPrecheckResult(s, nil)
+
+	out, err := s.controller.InitiateMigration(params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{s.makeKeepSourceSpec(c, st1), s.makeKeepSourceSpec(c, st2)},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 2)
+	c.Check(out.Results[0].Error, gc.IsNil)
+	c.Check(out.Results[1].Error, gc.ErrorMatches, `controller already running 1 model migrations \(max-concurrent-migrations is 1\)`)
+	c.Check(out.Results[1].Error.Code, gc.Equals, params.CodeQuotaLimitExceeded)
+}
+
 func (s *controllerSuite) TestInitiateMigrationSpecError(c *gc.C) {
 	// Create a hosted model to migrate.
 	st := s.Factory.MakeModel(c, nil)
@@ -617,6 +708,149 @@ func (s *controllerSuite) TestInitiateMigrationPrecheckFail(c *gc.C) {
 	c.Check(active, jc.IsFalse)
 }
 
+func (s *controllerSuite) TestMigrationPrechecks(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	source := params.MigrationPrecheckReport{
+		Warnings: []string{"unit foo/0 workload is blocked: waiting"},
+	}
+	target := params.MigrationPrecheckReport{
+		Blockers: []string{`cloud "dummy" not found on target controller`},
+	}
+	controller.SetPrecheckReports(s, source, target, nil)
+
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{
+			{
+				ModelTag: m.ModelTag().String(),
+				TargetInfo: params.MigrationTargetInfo{
+					ControllerTag: randomControllerTag(),
+					Addrs:         []string{"1.1.1.1:1111"},
+					CACert:        "cert1",
+					AuthTag:       names.NewUserTag("admin1").String(),
+					Password:      "secret1",
+				},
+			}, {
+				ModelTag: randomModelTag(), // Doesn't exist.
+			},
+		},
+	}
+	out, err := s.controller.MigrationPrechecks(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 2)
+
+	c.Check(out.Results[0], jc.DeepEquals, params.MigrationPrecheckResult{
+		ModelTag: m.ModelTag().String(),
+		Source:   source,
+		Target:   target,
+	})
+	c.Check(out.Results[1].ModelTag, gc.Equals, args.Specs[1].ModelTag)
+	c.Check(out.Results[1].Error, gc.ErrorMatches, "model not found")
+
+	// Nothing was changed.
+	active, err := st.IsMigrationActive()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(active, jc.IsFalse)
+}
+
+func (s *controllerSuite) TestExportModel(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	f := factory.NewFactory(st, s.StatePool)
+	app := f.MakeApplication(c, nil)
+	curl, _ := app.CharmURL()
+
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	out, err := s.controller.ExportModel(params.Entities{
+		Entities: []params.Entity{
+			{Tag: m.ModelTag().String()},
+			{Tag: s.Model.ModelTag().String()},
+			{Tag: randomModelTag()},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 3)
+
+	c.Assert(out.Results[0].Error, gc.IsNil)
+	serialized := out.Results[0].Result
+	c.Assert(serialized, gc.NotNil)
+	model, err := description.Deserialize(serialized.Bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(model.Tag(), gc.Equals, m.ModelTag())
+	c.Check(serialized.Charms, jc.DeepEquals, []string{curl.String()})
+
+	c.Check(out.Results[1].Error, gc.ErrorMatches, "exporting the controller model not supported")
+	c.Check(out.Results[2].Error, gc.ErrorMatches, "model .* not found")
+
+	// Nothing was changed.
+	active, err := st.IsMigrationActive()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(active, jc.IsFalse)
+}
+
+func (s *controllerSuite) TestModelMigrations(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	out, err := s.controller.ModelMigrations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(out.Migrations, gc.HasLen, 0)
+
+	targetTag := names.NewControllerTag(utils.MustNewUUID().String())
+	mig, err := st.CreateMigration(state.MigrationSpec{
+		InitiatedBy: names.NewUserTag("admin"),
+		TargetInfo: coremigration.TargetInfo{
+			ControllerTag: targetTag,
+			Addrs:         []string{"1.1.1.1:1111"},
+			CACert:        "cert",
+			AuthTag:       names.NewUserTag("admin"),
+			Password:      "secret",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig.SetPhase(coremigration.IMPORT), jc.ErrorIsNil)
+	c.Assert(mig.SetStatusMessage("import failed"), jc.ErrorIsNil)
+	c.Assert(mig.SetPhase(coremigration.ABORT), jc.ErrorIsNil)
+	c.Assert(mig.SetPhase(coremigration.ABORTDONE), jc.ErrorIsNil)
+
+	out, err = s.controller.ModelMigrations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Migrations, gc.HasLen, 1)
+	report := out.Migrations[0]
+	c.Check(report.MigrationId, gc.Equals, mig.Id())
+	c.Check(report.ModelTag, gc.Equals, m.ModelTag().String())
+	c.Check(report.ModelName, gc.Equals, m.Name())
+	c.Check(report.TargetController, gc.Equals, targetTag.Id())
+	c.Check(report.Phase, gc.Equals, "ABORTDONE")
+	c.Check(report.FailedPhase, gc.Equals, "IMPORT")
+	c.Check(report.StatusMessage, gc.Equals, "import failed")
+	c.Check(report.End, gc.NotNil)
+}
+
+func (s *controllerSuite) TestModelMigrationsByNonAdmin(c *gc.C) {
+	anAuthoriser := apiservertesting.FakeAuthorizer{
+		Tag: names.NewLocalUserTag("bob"),
+	}
+	endPoint, err := controller.LatestAPI(
+		facadetest.Context{
+			State_:     s.State,
+			Resources_: s.resources,
+			Auth_:      anAuthoriser,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = endPoint.ModelMigrations()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 func randomControllerTag() string {
 	uuid := utils.MustNewUUID().String()
 	return names.NewControllerTag(uuid).String()
@@ -1024,6 +1258,71 @@ func (s *controllerSuite) TestConfigSetPublishesEvent(c *gc.C) {
 	c.Assert(config.Features().SortedValues(), jc.DeepEquals, []string{"bar", "foo"})
 }
 
+func (s *controllerSuite) TestEnableMaintenanceMode(c *gc.C) {
+	done := make(chan struct{})
+	var config corecontroller.Config
+	s.hub.Subscribe(pscontroller.ConfigChanged, func(topic string, data pscontroller.ConfigChangedMessage, err error) {
+		c.Check(err, jc.ErrorIsNil)
+		config = data.Config
+		close(done)
+	})
+
+	err := s.controller.EnableMaintenanceMode(params.MaintenanceModeArgs{Message: "upgrading storage"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	select {
+	case <-done:
+	case <-time.After(testing.LongWait):
+		c.Fatal("no event sent")
+	}
+	c.Check(config.MaintenanceMode(), jc.IsTrue)
+	c.Check(config.MaintenanceMessage(), gc.Equals, "upgrading storage")
+
+	stored, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(stored.MaintenanceMode(), jc.IsTrue)
+}
+
+func (s *controllerSuite) TestDisableMaintenanceMode(c *gc.C) {
+	err := s.State.SetMaintenanceMode(true, "upgrading storage")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.controller.DisableMaintenanceMode()
+	c.Assert(err, jc.ErrorIsNil)
+
+	stored, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(stored.MaintenanceMode(), jc.IsFalse)
+	c.Check(stored.MaintenanceMessage(), gc.Equals, "")
+}
+
+func (s *controllerSuite) TestMaintenanceModeRequiresSuperUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Access: permission.ReadAccess,
+	})
+	endpoint, err := controller.NewControllerAPIv14(
+		facadetest.Context{
+			State_:     s.State,
+			Resources_: s.resources,
+			Auth_: apiservertesting.FakeAuthorizer{
+				Tag: user.Tag(),
+			},
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = endpoint.EnableMaintenanceMode(params.MaintenanceModeArgs{Message: "upgrading storage"})
+	c.Check(err, gc.ErrorMatches, "permission denied")
+	err = endpoint.DisableMaintenanceMode()
+	c.Check(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *controllerSuite) TestConfigSetRejectsMaintenanceMode(c *gc.C) {
+	err := s.controller.ConfigSet(params.ControllerConfigSet{Config: map[string]interface{}{
+		"maintenance-mode": true,
+	}})
+	c.Assert(err, gc.ErrorMatches, `can't change "maintenance-mode" after bootstrap`)
+}
+
 func (s *controllerSuite) TestMongoVersion(c *gc.C) {
 	result, err := s.controller.MongoVersion()
 	c.Assert(err, jc.ErrorIsNil)
@@ -5,6 +5,7 @@ package controller
 
 import (
 	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/state"
 )
@@ -14,7 +15,15 @@ type patcher interface {
 }
 
 func SetPrecheckResult(p patcher, err error) {
-	p.PatchValue(&runMigrationPrechecks, func(*state.State, *state.State, *migration.TargetInfo, facade.Presence) error {
+	p.PatchValue(&runMigrationPrechecks, func(*state.State, *state.State, *migration.TargetInfo, facade.Presence, bool) error {
 		return err
 	})
 }
+
+func SetPrecheckReports(p patcher, source, target params.MigrationPrecheckReport, err error) {
+	p.PatchValue(&runMigrationPrecheckReports, func(*state.State, *state.State, *migration.TargetInfo, facade.Presence) (
+		params.MigrationPrecheckReport, params.MigrationPrecheckReport, error,
+	) {
+		return source, target, err
+	})
+}
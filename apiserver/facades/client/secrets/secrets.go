@@ -0,0 +1,149 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package secrets implements the API endpoint used by Juju clients
+// to inspect and rotate charm secrets.
+package secrets
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/common/secretbackend"
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/permission"
+	coresecrets "github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/state"
+)
+
+// SecretsState describes the secrets store methods used by SecretsAPI.
+type SecretsState interface {
+	ListSecrets(state.SecretsFilter) ([]*coresecrets.SecretMetadata, error)
+	GetSecretValueRef(*coresecrets.URI, int) (*coresecrets.ValueRef, error)
+	TriggerSecretRotation(*coresecrets.URI) error
+}
+
+// SecretsAPI is the backend for the Secrets facade.
+type SecretsAPI struct {
+	authorizer facade.Authorizer
+	modelTag   names.ModelTag
+	secrets    SecretsState
+	backendFor func(backendType string) (coresecrets.Backend, error)
+}
+
+// NewSecretsAPI creates a SecretsAPI.
+func NewSecretsAPI(context facade.Context) (*SecretsAPI, error) {
+	st := context.State()
+	return NewTestAPI(
+		context.Auth(),
+		names.NewModelTag(st.ModelUUID()),
+		state.NewSecretsStore(st),
+		func(backendType string) (coresecrets.Backend, error) {
+			return secretbackend.New(backendType, st)
+		},
+	)
+}
+
+// NewTestAPI is used for testing.
+func NewTestAPI(
+	authorizer facade.Authorizer,
+	modelTag names.ModelTag,
+	secrets SecretsState,
+	backendFor func(string) (coresecrets.Backend, error),
+) (*SecretsAPI, error) {
+	if !authorizer.AuthClient() {
+		return nil, apiservererrors.ErrPerm
+	}
+	return &SecretsAPI{
+		authorizer: authorizer,
+		modelTag:   modelTag,
+		secrets:    secrets,
+		backendFor: backendFor,
+	}, nil
+}
+
+func (s *SecretsAPI) checkCanAccess(access permission.Access) error {
+	canAccess, err := s.authorizer.HasPermission(access, s.modelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canAccess {
+		return apiservererrors.ErrPerm
+	}
+	return nil
+}
+
+// ListSecrets lists the secrets in the model. Showing secret values
+// requires model admin access.
+func (s *SecretsAPI) ListSecrets(arg params.ListSecretsArgs) (params.ListSecretResults, error) {
+	result := params.ListSecretResults{}
+	access := permission.ReadAccess
+	if arg.ShowSecrets {
+		access = permission.AdminAccess
+	}
+	if err := s.checkCanAccess(access); err != nil {
+		return result, errors.Trace(err)
+	}
+	metadata, err := s.secrets.ListSecrets(state.SecretsFilter{})
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Results = make([]params.ListSecretResult, len(metadata))
+	for i, md := range metadata {
+		item := params.ListSecretResult{
+			URI:              md.URI.String(),
+			OwnerTag:         md.OwnerTag,
+			Description:      md.Description,
+			Label:            md.Label,
+			LatestRevision:   md.LatestRevision,
+			LatestExpireTime: md.LatestExpireTime,
+			RotatePolicy:     string(md.RotatePolicy),
+			NextRotateTime:   md.NextRotateTime,
+			CreateTime:       md.CreateTime,
+			UpdateTime:       md.UpdateTime,
+		}
+		if arg.ShowSecrets {
+			data, err := s.secretValue(md)
+			item.Value = &params.SecretValueResult{
+				Data:  data,
+				Error: apiservererrors.ServerError(err),
+			}
+		}
+		result.Results[i] = item
+	}
+	return result, nil
+}
+
+func (s *SecretsAPI) secretValue(md *coresecrets.SecretMetadata) (coresecrets.SecretData, error) {
+	ref, err := s.secrets.GetSecretValueRef(md.URI, md.LatestRevision)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	backend, err := s.backendFor(ref.BackendType)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	data, err := backend.GetContent(ref.ID)
+	return data, errors.Trace(err)
+}
+
+// RotateSecrets asks the owners of the given secrets to rotate them
+// now, regardless of their rotate policy.
+func (s *SecretsAPI) RotateSecrets(args params.SecretURIArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.URIs)),
+	}
+	if err := s.checkCanAccess(permission.AdminAccess); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	for i, uriStr := range args.URIs {
+		uri, err := coresecrets.ParseURI(uriStr)
+		if err == nil {
+			err = s.secrets.TriggerSecretRotation(uri)
+		}
+		result.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return result, nil
+}
@@ -0,0 +1,163 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facades/client/secrets"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coresecrets "github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type SecretsSuite struct {
+	coretesting.BaseSuite
+
+	authorizer apiservertesting.FakeAuthorizer
+	state      *mockSecretsState
+	backend    *mockBackend
+}
+
+var _ = gc.Suite(&SecretsSuite{})
+
+func (s *SecretsSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+	s.state = &mockSecretsState{}
+	s.backend = &mockBackend{}
+}
+
+func (s *SecretsSuite) newAPI(c *gc.C) *secrets.SecretsAPI {
+	api, err := secrets.NewTestAPI(s.authorizer, coretesting.ModelTag, s.state,
+		func(backendType string) (coresecrets.Backend, error) {
+			c.Assert(backendType, gc.Equals, "mock")
+			return s.backend, nil
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *SecretsSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewUnitTag("mariadb/0")
+	_, err := secrets.NewTestAPI(s.authorizer, coretesting.ModelTag, s.state, nil)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *SecretsSuite) assertListSecrets(c *gc.C, showSecrets bool) {
+	now := time.Now().Round(time.Second).UTC()
+	next := now.Add(time.Hour)
+	uri, err := coresecrets.NewURI()
+	c.Assert(err, jc.ErrorIsNil)
+	s.state.metadata = []*coresecrets.SecretMetadata{{
+		URI:            uri,
+		OwnerTag:       "application-mariadb",
+		Description:    "my secret",
+		Label:          "foobar",
+		LatestRevision: 2,
+		RotatePolicy:   coresecrets.RotateHourly,
+		NextRotateTime: &next,
+		CreateTime:     now,
+		UpdateTime:     now,
+	}}
+
+	results, err := s.newAPI(c).ListSecrets(params.ListSecretsArgs{ShowSecrets: showSecrets})
+	c.Assert(err, jc.ErrorIsNil)
+	expected := params.ListSecretResult{
+		URI:            uri.String(),
+		OwnerTag:       "application-mariadb",
+		Description:    "my secret",
+		Label:          "foobar",
+		LatestRevision: 2,
+		RotatePolicy:   "hourly",
+		NextRotateTime: &next,
+		CreateTime:     now,
+		UpdateTime:     now,
+	}
+	if showSecrets {
+		expected.Value = &params.SecretValueResult{
+			Data: map[string]string{"password": "secret"},
+		}
+		c.Assert(s.state.valueRefs, jc.DeepEquals, []string{uri.ID + "/2"})
+	}
+	c.Assert(results.Results, jc.DeepEquals, []params.ListSecretResult{expected})
+}
+
+func (s *SecretsSuite) TestListSecrets(c *gc.C) {
+	s.assertListSecrets(c, false)
+}
+
+func (s *SecretsSuite) TestListSecretsShowSecrets(c *gc.C) {
+	s.assertListSecrets(c, true)
+}
+
+func (s *SecretsSuite) TestListSecretsShowSecretsRequiresAdmin(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	s.authorizer.HasWriteTag = names.NewUserTag("bob")
+	_, err := s.newAPI(c).ListSecrets(params.ListSecretsArgs{ShowSecrets: true})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *SecretsSuite) TestRotateSecrets(c *gc.C) {
+	uri, err := coresecrets.NewURI()
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := s.newAPI(c).RotateSecrets(params.SecretURIArgs{
+		URIs: []string{uri.String(), "foo"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `secret URI "foo" not valid`)
+	c.Assert(s.state.rotated, jc.DeepEquals, []string{uri.String()})
+}
+
+func (s *SecretsSuite) TestRotateSecretsRequiresAdmin(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	s.authorizer.HasWriteTag = names.NewUserTag("bob")
+	_, err := s.newAPI(c).RotateSecrets(params.SecretURIArgs{URIs: []string{"secret:1234"}})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type mockSecretsState struct {
+	metadata  []*coresecrets.SecretMetadata
+	valueRefs []string
+	rotated   []string
+}
+
+func (m *mockSecretsState) ListSecrets(filter state.SecretsFilter) ([]*coresecrets.SecretMetadata, error) {
+	return m.metadata, nil
+}
+
+func (m *mockSecretsState) GetSecretValueRef(uri *coresecrets.URI, revision int) (*coresecrets.ValueRef, error) {
+	if revision != 2 {
+		return nil, errors.NotFoundf("secret revision %d", revision)
+	}
+	id := fmt.Sprintf("%s/%d", uri.ID, revision)
+	m.valueRefs = append(m.valueRefs, id)
+	return &coresecrets.ValueRef{BackendType: "mock", ID: id}, nil
+}
+
+func (m *mockSecretsState) TriggerSecretRotation(uri *coresecrets.URI) error {
+	m.rotated = append(m.rotated, uri.String())
+	return nil
+}
+
+type mockBackend struct {
+	coresecrets.Backend
+}
+
+func (m *mockBackend) GetContent(id string) (coresecrets.SecretData, error) {
+	return coresecrets.SecretData{"password": "secret"}, nil
+}
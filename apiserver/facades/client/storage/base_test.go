@@ -75,7 +75,11 @@ func (s *baseStorageSuite) SetUpTest(c *gc.C) {
 	s.apiv3 = &storage.StorageAPIv3{
 		StorageAPIv4: storage.StorageAPIv4{
 			StorageAPIv5: storage.StorageAPIv5{
-				StorageAPI: *newAPI,
+				StorageAPIv6: storage.StorageAPIv6{
+					StorageAPIv7: storage.StorageAPIv7{
+						StorageAPI: *newAPI,
+					},
+				},
 			},
 		},
 	}
@@ -112,6 +116,8 @@ const (
 	destroyStorageInstanceCall              = "destroyStorageInstance"
 	releaseStorageInstanceCall              = "releaseStorageInstance"
 	addExistingFilesystemCall               = "addExistingFilesystem"
+	resizeStorageInstanceCall               = "resizeStorageInstance"
+	storagePoolUsageCall                    = "storagePoolUsage"
 )
 
 func (s *baseStorageSuite) constructState() *mockState {
@@ -320,6 +326,20 @@ func (s *baseStorageSuite) constructStorageAccessor() *mockStorageAccessor {
 			s.stub.AddCall(addExistingFilesystemCall, f, v, storageName)
 			return s.storageTag, s.stub.NextErr()
 		},
+		resizeStorageInstance: func(tag names.StorageTag, size uint64) error {
+			s.stub.AddCall(resizeStorageInstanceCall, tag, size)
+			return s.stub.NextErr()
+		},
+		storagePoolUsage: func(pool string) (*state.StoragePoolUsage, error) {
+			s.stub.AddCall(storagePoolUsageCall, pool)
+			return &state.StoragePoolUsage{
+				Pool:  pool,
+				Total: state.StorageAllocation{Count: 2, Size: 3072},
+				Applications: map[string]state.StorageAllocation{
+					"mysql": {Count: 2, Size: 3072},
+				},
+			}, s.stub.NextErr()
+		},
 	}
 }
 
@@ -71,6 +71,8 @@ type mockStorageAccessor struct {
 	attachStorage                       func(names.StorageTag, names.UnitTag) error
 	detachStorage                       func(names.StorageTag, names.UnitTag, bool) error
 	addExistingFilesystem               func(state.FilesystemInfo, *state.VolumeInfo, string) (names.StorageTag, error)
+	resizeStorageInstance               func(names.StorageTag, uint64) error
+	storagePoolUsage                    func(string) (*state.StoragePoolUsage, error)
 }
 
 func (st *mockStorageAccessor) VolumeAccess() storage.StorageVolume {
@@ -169,6 +171,14 @@ func (st *mockStorageAccessor) AttachStorage(storage names.StorageTag, unit name
 	return st.attachStorage(storage, unit)
 }
 
+func (st *mockStorageAccessor) ResizeStorageInstance(storage names.StorageTag, size uint64) error {
+	return st.resizeStorageInstance(storage, size)
+}
+
+func (st *mockStorageAccessor) StoragePoolUsage(pool string) (*state.StoragePoolUsage, error) {
+	return st.storagePoolUsage(pool)
+}
+
 func (st *mockStorageAccessor) DetachStorage(storage names.StorageTag, unit names.UnitTag, force bool, maxWait time.Duration) error {
 	return st.detachStorage(storage, unit, force)
 }
@@ -0,0 +1,71 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+)
+
+type poolUsageSuite struct {
+	baseStorageSuite
+}
+
+var _ = gc.Suite(&poolUsageSuite{})
+
+func (s *poolUsageSuite) TestPoolUsage(c *gc.C) {
+	var err error
+	s.baseStorageSuite.pools["ceph"], err = storage.NewConfig("ceph", provider.LoopProviderType, map[string]interface{}{
+		"max-size":    "10G",
+		"max-volumes": 5,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.registry.Providers["loop"] = nil
+
+	results, err := s.api.PoolUsage(params.StoragePoolUsageArgs{
+		Pools: []string{"ceph", "loop", "missing"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	applications := map[string]params.StorageAllocation{
+		"mysql": {Count: 2, Size: 3072},
+	}
+	c.Assert(results.Results, jc.DeepEquals, []params.StoragePoolUsageResult{{
+		Result: &params.StoragePoolUsage{
+			Name:         "ceph",
+			Total:        params.StorageAllocation{Count: 2, Size: 3072},
+			Applications: applications,
+			MaxSize:      10240,
+			MaxVolumes:   5,
+		},
+	}, {
+		Result: &params.StoragePoolUsage{
+			Name:         "loop",
+			Total:        params.StorageAllocation{Count: 2, Size: 3072},
+			Applications: applications,
+		},
+	}, {
+		Error: &params.Error{
+			Code:    params.CodeNotFound,
+			Message: `storage pool "missing" not found`,
+		},
+	}})
+	s.assertCalls(c, []string{storagePoolUsageCall, storagePoolUsageCall})
+}
+
+func (s *poolUsageSuite) TestPoolUsageError(c *gc.C) {
+	s.registry.Providers["loop"] = nil
+	s.stub.SetErrors(errors.New("boom"))
+	results, err := s.api.PoolUsage(params.StoragePoolUsageArgs{
+		Pools: []string{"loop"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.StoragePoolUsageResult{{
+		Error: &params.Error{Message: "boom"},
+	}})
+}
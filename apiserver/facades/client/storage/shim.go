@@ -59,8 +59,15 @@ type storageInterface interface {
 	// DestroyStorageInstance destroys the storage instance with the specified tag.
 	DestroyStorageInstance(names.StorageTag, bool, bool, time.Duration) error
 
+	// ResizeStorageInstance requests that the storage instance with
+	// the specified tag be grown to the given size, in MiB.
+	ResizeStorageInstance(names.StorageTag, uint64) error
+
 	// ReleaseStorageInstance releases the storage instance with the specified tag.
 	ReleaseStorageInstance(names.StorageTag, bool, bool, time.Duration) error
+
+	// StoragePoolUsage returns the storage allocated from the named pool.
+	StoragePoolUsage(string) (*state.StoragePoolUsage, error)
 }
 
 type storageVolume interface {
@@ -31,7 +31,7 @@ import (
 	"github.com/juju/juju/storage/poolmanager"
 )
 
-// StorageAPI implements the latest version (v6) of the Storage API.
+// StorageAPI implements the latest version (v8) of the Storage API.
 type StorageAPI struct {
 	backend       backend
 	storageAccess storageAccess
@@ -42,9 +42,19 @@ type StorageAPI struct {
 	modelType     state.ModelType
 }
 
+// APIv7 implements the storage v7 API.
+type StorageAPIv7 struct {
+	StorageAPI
+}
+
+// APIv6 implements the storage v6 API.
+type StorageAPIv6 struct {
+	StorageAPIv7
+}
+
 // APIv5 implements the storage v5 API.
 type StorageAPIv5 struct {
-	StorageAPI
+	StorageAPIv6
 }
 
 // APIv4 implements the storage v4 API adding AddToUnit, Import and Remove (replacing Destroy)
@@ -105,14 +115,36 @@ func newStorageAPI(
 	}
 }
 
+// NewStorageAPIV7 returns a new storage v7 API facade.
+func NewStorageAPIV7(context facade.Context) (*StorageAPIv7, error) {
+	storageAPI, err := NewStorageAPI(context)
+	if err != nil {
+		return nil, err
+	}
+	return &StorageAPIv7{
+		StorageAPI: *storageAPI,
+	}, nil
+}
+
+// NewStorageAPIV6 returns a new storage v6 API facade.
+func NewStorageAPIV6(context facade.Context) (*StorageAPIv6, error) {
+	storageAPI, err := NewStorageAPIV7(context)
+	if err != nil {
+		return nil, err
+	}
+	return &StorageAPIv6{
+		StorageAPIv7: *storageAPI,
+	}, nil
+}
+
 // NewStorageAPIV5 returns a new storage v5 API facade.
 func NewStorageAPIV5(context facade.Context) (*StorageAPIv5, error) {
-	storageAPI, err := NewStorageAPI(context)
+	storageAPI, err := NewStorageAPIV6(context)
 	if err != nil {
 		return nil, err
 	}
 	return &StorageAPIv5{
-		StorageAPI: *storageAPI,
+		StorageAPIv6: *storageAPI,
 	}, nil
 }
 
@@ -455,6 +487,64 @@ func filterPools(
 	return all
 }
 
+// PoolUsage returns the storage allocated from each of the specified
+// storage pools, broken down by application, along with the pools'
+// max-size and max-volumes limits.
+func (a *StorageAPI) PoolUsage(args params.StoragePoolUsageArgs) (params.StoragePoolUsageResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.StoragePoolUsageResults{}, errors.Trace(err)
+	}
+	results := make([]params.StoragePoolUsageResult, len(args.Pools))
+	for i, name := range args.Pools {
+		usage, err := a.poolUsage(name)
+		if err != nil {
+			results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		results[i].Result = usage
+	}
+	return params.StoragePoolUsageResults{Results: results}, nil
+}
+
+func (a *StorageAPI) poolUsage(name string) (*params.StoragePoolUsage, error) {
+	var maxSize uint64
+	var maxVolumes int
+	pool, err := a.poolManager.Get(name)
+	if errors.IsNotFound(err) {
+		// A storage provider type may be used directly as a pool.
+		if _, err := a.registry.StorageProvider(storage.ProviderType(name)); err != nil {
+			return nil, errors.NotFoundf("storage pool %q", name)
+		}
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	} else {
+		maxSize, maxVolumes = pool.MaxSize(), pool.MaxVolumes()
+	}
+	usage, err := a.storageAccess.StoragePoolUsage(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := &params.StoragePoolUsage{
+		Name: name,
+		Total: params.StorageAllocation{
+			Count: usage.Total.Count,
+			Size:  usage.Total.Size,
+		},
+		MaxSize:    maxSize,
+		MaxVolumes: maxVolumes,
+	}
+	if len(usage.Applications) > 0 {
+		result.Applications = make(map[string]params.StorageAllocation)
+		for application, allocation := range usage.Applications {
+			result.Applications[application] = params.StorageAllocation{
+				Count: allocation.Count,
+				Size:  allocation.Size,
+			}
+		}
+	}
+	return result, nil
+}
+
 func (a *StorageAPI) validatePoolListFilter(filter params.StoragePoolFilter) error {
 	if err := a.validateProviderCriteria(filter.Providers); err != nil {
 		return errors.Trace(err)
@@ -1055,6 +1145,38 @@ func (a *StorageAPI) attachStorage(storageTag names.StorageTag, unitTag names.Un
 	return a.storageAccess.AttachStorage(storageTag, unitTag)
 }
 
+// Resize requests that the specified storage instances be grown to
+// the given sizes, in MiB. The storage provisioner responsible for
+// each instance's backing volume performs the resize.
+// A "CHANGE" block can block this operation.
+func (a *StorageAPI) Resize(args params.ResizeStorageArgs) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.backend)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	resizeOne := func(arg params.ResizeStorageParams) error {
+		storageTag, err := names.ParseStorageTag(arg.StorageTag)
+		if err != nil {
+			return err
+		}
+		if arg.Size == 0 {
+			return errors.NotValidf("size 0")
+		}
+		return a.storageAccess.ResizeStorageInstance(storageTag, arg.Size)
+	}
+
+	result := make([]params.ErrorResult, len(args.Storage))
+	for i, arg := range args.Storage {
+		result[i].Error = apiservererrors.ServerError(resizeOne(arg))
+	}
+	return params.ErrorResults{Results: result}, nil
+}
+
 // Import imports existing storage into the model.
 // A "CHANGE" block can block this operation.
 func (a *StorageAPI) Import(args params.BulkImportStorageParams) (params.ImportStorageResults, error) {
@@ -1214,6 +1336,12 @@ func (a *StorageAPI) UpdatePool(p params.StoragePoolArgs) (params.ErrorResults,
 // code in rpc/rpcreflect/type.go:newMethod skips 2-argument methods,
 // so this removes the method as far as the RPC machinery is concerned.
 
+// Added in v8 api version
+func (*StorageAPIv7) PoolUsage(_, _ struct{}) {}
+
+// Added in v7 api version
+func (*StorageAPIv6) Resize(_, _ struct{}) {}
+
 // Added in v6 api version
 func (*StorageAPIv5) DetachStorage(_, _ struct{}) {}
 
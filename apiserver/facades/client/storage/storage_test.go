@@ -371,7 +371,11 @@ func (s *storageSuite) TestDestroyV3(c *gc.C) {
 
 func (s *storageSuite) TestDetachV5(c *gc.C) {
 	apiv5 := &facadestorage.StorageAPIv5{
-		StorageAPI: *s.api,
+		StorageAPIv6: facadestorage.StorageAPIv6{
+			StorageAPIv7: facadestorage.StorageAPIv7{
+				StorageAPI: *s.api,
+			},
+		},
 	}
 	results, err := apiv5.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{
 		{StorageTag: "storage-data-0", UnitTag: "unit-mysql-0"},
@@ -442,7 +446,11 @@ func (s *storageSuite) TestDetachV6(c *gc.C) {
 
 func (s *storageSuite) TestDetachSpecifiedNotFound(c *gc.C) {
 	apiv5 := &facadestorage.StorageAPIv5{
-		StorageAPI: *s.api,
+		StorageAPIv6: facadestorage.StorageAPIv6{
+			StorageAPIv7: facadestorage.StorageAPIv7{
+				StorageAPI: *s.api,
+			},
+		},
 	}
 	results, err := apiv5.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{
 		{StorageTag: "storage-data-0", UnitTag: "unit-foo-42"},
@@ -512,7 +520,11 @@ func (s *storageSuite) TestDetachAttachmentNotFoundConcurrentv5(c *gc.C) {
 		)
 	}
 	apiv5 := &facadestorage.StorageAPIv5{
-		StorageAPI: *s.api,
+		StorageAPIv6: facadestorage.StorageAPIv6{
+			StorageAPIv7: facadestorage.StorageAPIv7{
+				StorageAPI: *s.api,
+			},
+		},
 	}
 	results, err := apiv5.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{
 		{StorageTag: "storage-data-0"},
@@ -568,7 +580,11 @@ func (s *storageSuite) TestDetachAttachmentNotFoundConcurrentV6(c *gc.C) {
 
 func (s *storageSuite) TestDetachNoAttachmentsStorageNotFoundv5(c *gc.C) {
 	apiv5 := &facadestorage.StorageAPIv5{
-		StorageAPI: *s.api,
+		StorageAPIv6: facadestorage.StorageAPIv6{
+			StorageAPIv7: facadestorage.StorageAPIv7{
+				StorageAPI: *s.api,
+			},
+		},
 	}
 	results, err := apiv5.Detach(params.StorageAttachmentIds{[]params.StorageAttachmentId{
 		{StorageTag: "storage-foo-42"},
@@ -628,6 +644,36 @@ func (s *storageSuite) TestAttach(c *gc.C) {
 	})
 }
 
+func (s *storageSuite) TestResize(c *gc.C) {
+	s.stub.SetErrors(nil, errors.NotSupportedf("shrinking volume"))
+	results, err := s.api.Resize(params.ResizeStorageArgs{[]params.ResizeStorageParams{
+		{StorageTag: "storage-data-0", Size: 2048},
+		{StorageTag: "storage-data-0", Size: 10},
+		{StorageTag: "storage-data-0", Size: 0},
+		{StorageTag: "volume-0", Size: 2048},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{Error: nil},
+		{Error: &params.Error{Code: params.CodeNotSupported, Message: `shrinking volume not supported`}},
+		{Error: &params.Error{Message: `size 0 not valid`}},
+		{Error: &params.Error{Message: `"volume-0" is not a valid storage tag`}},
+	})
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{getBlockForTypeCall, []interface{}{state.ChangeBlock}},
+		{resizeStorageInstanceCall, []interface{}{s.storageTag, uint64(2048)}},
+		{resizeStorageInstanceCall, []interface{}{s.storageTag, uint64(10)}},
+	})
+}
+
+func (s *storageSuite) TestResizeBlocked(c *gc.C) {
+	s.addBlock(c, state.ChangeBlock, "TestResizeBlocked")
+	_, err := s.api.Resize(params.ResizeStorageArgs{[]params.ResizeStorageParams{
+		{StorageTag: "storage-data-0", Size: 2048},
+	}})
+	s.assertBlocked(c, err, "TestResizeBlocked")
+}
+
 func (s *storageSuite) TestImportFilesystem(c *gc.C) {
 	s.state.modelTag = coretesting.ModelTag
 	filesystemSource := filesystemImporter{&dummy.FilesystemSource{}}
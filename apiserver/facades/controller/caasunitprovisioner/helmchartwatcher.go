@@ -0,0 +1,138 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasunitprovisioner
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/tomb.v2"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// helmChartWatcher notifies when anything an application's Helm chart
+// is rendered from changes: the charm (which changes when a resource is
+// updated) and the config of the charm the application currently has.
+// The charm is checked whenever the application changes, so upgrading
+// to or from a charm with a chart is noticed too.
+type helmChartWatcher struct {
+	tomb    tomb.Tomb
+	st      CAASUnitProvisionerState
+	appName string
+	changes chan struct{}
+}
+
+func newHelmChartWatcher(st CAASUnitProvisionerState, appName string) *helmChartWatcher {
+	w := &helmChartWatcher{
+		st:      st,
+		appName: appName,
+		changes: make(chan struct{}),
+	}
+	w.tomb.Go(w.loop)
+	return w
+}
+
+func (w *helmChartWatcher) loop() error {
+	app, err := w.st.Application(w.appName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	appWatcher := app.Watch()
+	defer watcher.Stop(appWatcher, &w.tomb)
+
+	var (
+		charmURL      string
+		configWatcher state.NotifyWatcher
+		configChanges <-chan struct{}
+		out           chan struct{}
+	)
+	defer func() {
+		if configWatcher != nil {
+			watcher.Stop(configWatcher, &w.tomb)
+		}
+	}()
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case _, ok := <-appWatcher.Changes():
+			if !ok {
+				return watcher.EnsureErr(appWatcher)
+			}
+			app, err := w.st.Application(w.appName)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			ch, _, err := app.Charm()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			_, hasChart := helmChartResource(ch.Meta())
+			url := ch.URL().String()
+			if url == charmURL {
+				// Other changes to the application only matter
+				// when it's deployed from a chart.
+				if hasChart {
+					out = w.changes
+				}
+				continue
+			}
+			// The charm config is kept per charm, so the config
+			// watcher is replaced whenever the charm changes. The
+			// first event, for the initial charm, is the watcher's
+			// initial event.
+			charmURL = url
+			out = w.changes
+			if configWatcher != nil {
+				watcher.Stop(configWatcher, &w.tomb)
+				configWatcher, configChanges = nil, nil
+			}
+			if !hasChart {
+				continue
+			}
+			if configWatcher, err = app.WatchCharmConfig(); err != nil {
+				return errors.Trace(err)
+			}
+			// The chart is rendered with the config as it is now,
+			// so the config watcher's initial event isn't needed.
+			if _, ok := <-configWatcher.Changes(); !ok {
+				return watcher.EnsureErr(configWatcher)
+			}
+			configChanges = configWatcher.Changes()
+		case _, ok := <-configChanges:
+			if !ok {
+				return watcher.EnsureErr(configWatcher)
+			}
+			out = w.changes
+		case out <- struct{}{}:
+			out = nil
+		}
+	}
+}
+
+// Changes is part of the state.NotifyWatcher interface.
+func (w *helmChartWatcher) Changes() <-chan struct{} {
+	return w.changes
+}
+
+// Kill is part of the state.NotifyWatcher interface.
+func (w *helmChartWatcher) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait is part of the state.NotifyWatcher interface.
+func (w *helmChartWatcher) Wait() error {
+	return w.tomb.Wait()
+}
+
+// Stop is part of the state.NotifyWatcher interface.
+func (w *helmChartWatcher) Stop() error {
+	w.Kill()
+	return w.Wait()
+}
+
+// Err is part of the state.NotifyWatcher interface.
+func (w *helmChartWatcher) Err() error {
+	return w.tomb.Err()
+}
@@ -4,7 +4,10 @@
 package caasunitprovisioner_test
 
 import (
+	"io"
+	"io/ioutil"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/juju/charm/v7"
@@ -20,6 +23,7 @@ import (
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/resource"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
 	"github.com/juju/juju/storage"
@@ -34,6 +38,19 @@ type mockState struct {
 	applicationsWatcher *statetesting.MockStringsWatcher
 	model               mockModel
 	unit                mockUnit
+	resources           map[string]string
+}
+
+func (st *mockState) OpenResource(applicationID, name string) (resource.Resource, io.ReadCloser, error) {
+	st.MethodCall(st, "OpenResource", applicationID, name)
+	if err := st.NextErr(); err != nil {
+		return resource.Resource{}, nil, err
+	}
+	content, ok := st.resources[name]
+	if !ok {
+		return resource.Resource{}, nil, errors.NotFoundf("resource %q", name)
+	}
+	return resource.Resource{}, ioutil.NopCloser(strings.NewReader(content)), nil
 }
 
 func (st *mockState) WatchApplications() state.StringsWatcher {
@@ -142,8 +159,13 @@ func (m *mockModel) Containers(providerIds ...string) ([]state.CloudContainer, e
 
 type mockApplication struct {
 	testing.Stub
-	life         state.Life
-	scaleWatcher *statetesting.MockNotifyWatcher
+	mu                 sync.Mutex
+	life               state.Life
+	scaleWatcher       *statetesting.MockNotifyWatcher
+	charmConfigWatcher *statetesting.MockNotifyWatcher
+	appConfigWatcher   *statetesting.MockNotifyWatcher
+	watcher            *statetesting.MockNotifyWatcher
+	charmConfig        charm.Settings
 
 	tag        names.Tag
 	scale      int
@@ -210,17 +232,53 @@ func (a *mockApplication) StorageConstraints() (map[string]state.StorageConstrai
 
 type mockCharm struct {
 	meta charm.Meta
+	url  *charm.URL
 }
 
 func (m *mockCharm) Meta() *charm.Meta {
 	return &m.meta
 }
 
+func (m *mockCharm) URL() *charm.URL {
+	if m.url == nil {
+		return charm.MustParseURL("cs:gitlab-1")
+	}
+	return m.url
+}
+
 func (a *mockApplication) Charm() (caasunitprovisioner.Charm, bool, error) {
 	a.MethodCall(a, "Charm")
+	a.mu.Lock()
+	defer a.mu.Unlock()
 	return a.charm, false, nil
 }
 
+func (a *mockApplication) setCharm(ch *mockCharm) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.charm = ch
+}
+
+func (a *mockApplication) CharmConfig(branchName string) (charm.Settings, error) {
+	a.MethodCall(a, "CharmConfig", branchName)
+	return a.charmConfig, a.NextErr()
+}
+
+func (a *mockApplication) WatchCharmConfig() (state.NotifyWatcher, error) {
+	a.MethodCall(a, "WatchCharmConfig")
+	return a.charmConfigWatcher, a.NextErr()
+}
+
+func (a *mockApplication) WatchApplicationConfig() state.NotifyWatcher {
+	a.MethodCall(a, "WatchApplicationConfig")
+	return a.appConfigWatcher
+}
+
+func (a *mockApplication) Watch() state.NotifyWatcher {
+	a.MethodCall(a, "Watch")
+	return a.watcher
+}
+
 func (a *mockApplication) GetPlacement() string {
 	a.MethodCall(a, "GetPlacement")
 	return "placement"
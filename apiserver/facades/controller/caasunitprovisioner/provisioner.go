@@ -5,6 +5,8 @@ package caasunitprovisioner
 
 import (
 	"fmt"
+	"io"
+	"io/ioutil"
 	"sort"
 	"time"
 
@@ -23,11 +25,14 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/caas/kubernetes/provider/helm"
 	"github.com/juju/juju/cloudconfig/podcfg"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/resource"
 	"github.com/juju/juju/state"
 	stateerrors "github.com/juju/juju/state/errors"
 	"github.com/juju/juju/state/stateenvirons"
@@ -194,12 +199,76 @@ func (f *Facade) watchPodSpec(model Model, tagString string) (string, error) {
 	if err != nil {
 		return "", errors.Trace(err)
 	}
+	// The application is provisioned again whenever its application
+	// config changes, as well as when the pod spec does.
+	if w, err = f.watchProvisioningChanges(tag, w); err != nil {
+		return "", errors.Trace(err)
+	}
 	if _, ok := <-w.Changes(); ok {
 		return f.resources.Register(w), nil
 	}
 	return "", watcher.EnsureErr(w)
 }
 
+// watchProvisioningChanges returns a watcher combining the pod spec
+// watcher with a watcher for the application config. Applications
+// deployed from a Helm chart are also rendered again whenever the charm
+// config or the charm (which changes when a resource is updated)
+// changes.
+func (f *Facade) watchProvisioningChanges(tag names.ApplicationTag, podSpecWatcher state.NotifyWatcher) (state.NotifyWatcher, error) {
+	app, err := f.state.Application(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return common.NewMultiNotifyWatcher(
+		podSpecWatcher,
+		newHelmChartWatcher(f.state, tag.Id()),
+		app.WatchApplicationConfig(),
+	), nil
+}
+
+// helmChartResource returns the name of the charm's Helm chart
+// resource, if it has one.
+func helmChartResource(meta *charm.Meta) (string, bool) {
+	var names []string
+	for name, res := range meta.Resources {
+		if resource.IsHelmChart(res) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return "", false
+	}
+	// Only one chart is deployed; pick a stable one if a charm
+	// declares several.
+	sort.Strings(names)
+	return names[0], true
+}
+
+// helmChart returns the content of the application's Helm chart and
+// the values to render it with, built from the charm config.
+func (f *Facade) helmChart(app Application, resourceName string) ([]byte, map[string]interface{}, error) {
+	_, reader, err := f.state.OpenResource(app.Name(), resourceName)
+	if errors.IsNotFound(err) {
+		return nil, nil, errors.NotFoundf("helm chart resource %q for application %s", resourceName, app.Name())
+	} else if err != nil {
+		return nil, nil, errors.Annotatef(err, "opening helm chart resource %q", resourceName)
+	}
+	defer reader.Close()
+	chart, err := ioutil.ReadAll(io.LimitReader(reader, helm.MaxChartSize+1))
+	if err != nil {
+		return nil, nil, errors.Annotatef(err, "reading helm chart resource %q", resourceName)
+	}
+	if len(chart) > helm.MaxChartSize {
+		return nil, nil, errors.NotValidf("helm chart resource %q larger than %d bytes", resourceName, helm.MaxChartSize)
+	}
+	settings, err := app.CharmConfig(model.GenerationMaster)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return chart, settings, nil
+}
+
 // ApplicationsScale returns the scaling info for specified applications in this model.
 func (f *Facade) ApplicationsScale(args params.Entities) (params.IntResults, error) {
 	results := params.IntResults{
@@ -294,13 +363,23 @@ func (f *Facade) provisioningInfo(model Model, tagString string) (*params.Kubern
 		return nil, errors.Trace(err)
 	}
 	// First the pod spec.
-	podSpec, err := model.PodSpec(appTag)
-	if err != nil {
-		return nil, errors.Trace(err)
-	}
-	rawSpec, err := model.RawK8sSpec(appTag)
-	if err != nil {
+	var podSpec, rawSpec string
+	podSpec, err = model.PodSpec(appTag)
+	if errors.IsNotFound(err) {
+		// Applications deployed from a Helm chart don't need a
+		// spec to be set.
+		if hasChart, chartErr := f.hasHelmChart(appTag); chartErr != nil {
+			return nil, errors.Trace(chartErr)
+		} else if !hasChart {
+			return nil, errors.Trace(err)
+		}
+	} else if err != nil {
 		return nil, errors.Trace(err)
+	} else {
+		rawSpec, err = model.RawK8sSpec(appTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
 	}
 	if podSpec != "" && rawSpec != "" {
 		// This should never happen.
@@ -371,6 +450,13 @@ func (f *Facade) provisioningInfo(model Model, tagString string) (*params.Kubern
 		OperatorImagePath:    operatorImagePath,
 		CharmModifiedVersion: app.CharmModifiedVersion(),
 	}
+	if podSpec == "" && rawSpec == "" {
+		if name, ok := helmChartResource(ch.Meta()); ok {
+			if info.HelmChart, info.HelmValues, err = f.helmChart(app, name); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+	}
 	deployInfo := ch.Meta().Deployment
 	if deployInfo != nil {
 		info.DeploymentInfo = &params.KubernetesDeploymentInfo{
@@ -381,6 +467,19 @@ func (f *Facade) provisioningInfo(model Model, tagString string) (*params.Kubern
 	return info, nil
 }
 
+func (f *Facade) hasHelmChart(appTag names.ApplicationTag) (bool, error) {
+	app, err := f.state.Application(appTag.Id())
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	ch, _, err := app.Charm()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	_, ok := helmChartResource(ch.Meta())
+	return ok, nil
+}
+
 func filesystemParams(
 	app Application,
 	cons state.StorageConstraints,
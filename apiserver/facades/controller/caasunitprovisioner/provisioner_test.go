@@ -5,11 +5,14 @@ package caasunitprovisioner_test
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/juju/charm/v7"
+	charmresource "github.com/juju/charm/v7/resource"
 	"github.com/juju/clock"
 	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/worker/v2/workertest"
@@ -44,6 +47,8 @@ type CAASProvisionerSuite struct {
 	devices             *mockDeviceBackend
 	applicationsChanges chan []string
 	podSpecChanges      chan struct{}
+	appConfigChanges    chan struct{}
+	appChanges          chan struct{}
 	scaleChanges        chan struct{}
 
 	resources  *common.Resources
@@ -62,14 +67,19 @@ func (s *CAASProvisionerSuite) SetUpTest(c *gc.C) {
 
 	s.applicationsChanges = make(chan []string, 1)
 	s.podSpecChanges = make(chan struct{}, 1)
+	s.appConfigChanges = make(chan struct{}, 1)
+	s.appChanges = make(chan struct{}, 1)
 	s.scaleChanges = make(chan struct{}, 1)
 	s.isRawK8sSpec = boolptr(false)
 	s.st = &mockState{
 		application: mockApplication{
-			tag:          names.NewApplicationTag("gitlab"),
-			life:         state.Alive,
-			scaleWatcher: statetesting.NewMockNotifyWatcher(s.scaleChanges),
-			scale:        5,
+			tag:              names.NewApplicationTag("gitlab"),
+			life:             state.Alive,
+			scaleWatcher:     statetesting.NewMockNotifyWatcher(s.scaleChanges),
+			appConfigWatcher: statetesting.NewMockNotifyWatcher(s.appConfigChanges),
+			watcher:          statetesting.NewMockNotifyWatcher(s.appChanges),
+			scale:            5,
+			charm:            &mockCharm{},
 		},
 		applicationsWatcher: statetesting.NewMockStringsWatcher(s.applicationsChanges),
 		model: mockModel{
@@ -91,6 +101,8 @@ func (s *CAASProvisionerSuite) SetUpTest(c *gc.C) {
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.applicationsWatcher) })
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.application.scaleWatcher) })
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.model.podSpecWatcher) })
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.application.appConfigWatcher) })
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.application.watcher) })
 
 	s.resources = common.NewResources()
 	s.authorizer = &apiservertesting.FakeAuthorizer{
@@ -128,8 +140,22 @@ func (s *CAASProvisionerSuite) TestWatchApplications(c *gc.C) {
 	c.Assert(resource, gc.Equals, s.st.applicationsWatcher)
 }
 
+func (s *CAASProvisionerSuite) checkApplicationCallNames(c *gc.C, expected ...string) {
+	// The Helm chart watcher calls the application concurrently
+	// with the facade, so only the calls made are checked.
+	var names []string
+	for _, call := range s.st.application.Calls() {
+		names = append(names, call.FuncName)
+	}
+	sort.Strings(names)
+	sort.Strings(expected)
+	c.Check(names, jc.DeepEquals, expected)
+}
+
 func (s *CAASProvisionerSuite) TestWatchPodSpec(c *gc.C) {
 	s.podSpecChanges <- struct{}{}
+	s.appConfigChanges <- struct{}{}
+	s.appChanges <- struct{}{}
 
 	results, err := s.facade.WatchPodSpec(params.Entities{
 		Entities: []params.Entity{
@@ -145,8 +171,108 @@ func (s *CAASProvisionerSuite) TestWatchPodSpec(c *gc.C) {
 	})
 
 	c.Assert(results.Results[0].NotifyWatcherId, gc.Equals, "1")
-	resource := s.resources.Get("1")
-	c.Assert(resource, gc.Equals, s.st.model.podSpecWatcher)
+	w, ok := s.resources.Get("1").(state.NotifyWatcher)
+	c.Assert(ok, jc.IsTrue)
+	defer workertest.CleanKill(c, w)
+
+	// Application config changes are reported as well as pod spec changes.
+	s.appConfigChanges <- struct{}{}
+	select {
+	case <-w.Changes():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for application config change")
+	}
+	s.checkApplicationCallNames(c, "Watch", "Charm", "WatchApplicationConfig")
+}
+
+func (s *CAASProvisionerSuite) setHelmChartCharm() {
+	s.st.application.setCharm(&mockCharm{
+		url: charm.MustParseURL("cs:gitlab-2"),
+		meta: charm.Meta{
+			Resources: map[string]charmresource.Meta{
+				"chart": {
+					Name: "chart",
+					Type: charmresource.TypeFile,
+					Path: "mariadb.helm.tgz",
+				},
+			},
+		},
+	})
+}
+
+func (s *CAASProvisionerSuite) TestWatchPodSpecHelmChart(c *gc.C) {
+	s.setHelmChartCharm()
+	configChanges := make(chan struct{}, 1)
+	s.st.application.charmConfigWatcher = statetesting.NewMockNotifyWatcher(configChanges)
+	s.podSpecChanges <- struct{}{}
+	configChanges <- struct{}{}
+	s.appChanges <- struct{}{}
+	s.appConfigChanges <- struct{}{}
+
+	results, err := s.facade.WatchPodSpec(params.Entities{
+		Entities: []params.Entity{{Tag: "application-gitlab"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].NotifyWatcherId, gc.Equals, "1")
+
+	// The combined watcher is registered, not the pod spec watcher.
+	w, ok := s.resources.Get("1").(state.NotifyWatcher)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(w, gc.Not(gc.Equals), s.st.model.podSpecWatcher)
+	defer workertest.CleanKill(c, w)
+
+	configChanges <- struct{}{}
+	select {
+	case <-w.Changes():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for config change")
+	}
+
+	// Other changes to the application are reported too.
+	s.appChanges <- struct{}{}
+	select {
+	case <-w.Changes():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for application change")
+	}
+	s.checkApplicationCallNames(c, "Watch", "Charm", "WatchCharmConfig", "WatchApplicationConfig", "Charm")
+}
+
+func (s *CAASProvisionerSuite) TestWatchPodSpecUpgradeToHelmChart(c *gc.C) {
+	configChanges := make(chan struct{}, 1)
+	s.st.application.charmConfigWatcher = statetesting.NewMockNotifyWatcher(configChanges)
+	s.podSpecChanges <- struct{}{}
+	s.appChanges <- struct{}{}
+	s.appConfigChanges <- struct{}{}
+
+	results, err := s.facade.WatchPodSpec(params.Entities{
+		Entities: []params.Entity{{Tag: "application-gitlab"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	w, ok := s.resources.Get("1").(state.NotifyWatcher)
+	c.Assert(ok, jc.IsTrue)
+	defer workertest.CleanKill(c, w)
+
+	// Upgrading to a charm with a chart is reported, and the new
+	// charm's config is watched from then on.
+	s.setHelmChartCharm()
+	configChanges <- struct{}{}
+	s.appChanges <- struct{}{}
+	select {
+	case <-w.Changes():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for charm change")
+	}
+	configChanges <- struct{}{}
+	select {
+	case <-w.Changes():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for config change")
+	}
+	s.checkApplicationCallNames(c, "Watch", "Charm", "WatchApplicationConfig", "Charm", "WatchCharmConfig")
 }
 
 func (s *CAASProvisionerSuite) TestWatchApplicationsScale(c *gc.C) {
@@ -292,6 +418,51 @@ func (s *CAASProvisionerSuite) TestProvisioningInfoRawK8sSpec(c *gc.C) {
 	s.assertProvisioningInfo(c, true)
 }
 
+func (s *CAASProvisionerSuite) TestProvisioningInfoHelmChart(c *gc.C) {
+	s.setHelmChartCharm()
+	s.st.application.charmConfig = charm.Settings{"image.tag": "10.6"}
+	s.st.resources = map[string]string{"chart": "chart-data"}
+	s.st.model.SetErrors(errors.NotFoundf("k8s spec for application gitlab"))
+
+	results, err := s.facade.ProvisioningInfo(params.Entities{
+		Entities: []params.Entity{{Tag: "application-gitlab"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	obtained := results.Results[0].Result
+	c.Assert(obtained.PodSpec, gc.Equals, "")
+	c.Assert(obtained.RawK8sSpec, gc.Equals, "")
+	c.Assert(obtained.HelmChart, jc.DeepEquals, []byte("chart-data"))
+	c.Assert(obtained.HelmValues, jc.DeepEquals, map[string]interface{}{"image.tag": "10.6"})
+	s.st.model.CheckCallNames(c, "PodSpec", "ModelConfig")
+	s.st.CheckCallNames(c, "Model", "Application", "Application", "ControllerConfig", "ResolveConstraints", "OpenResource")
+	s.st.CheckCall(c, 5, "OpenResource", "gitlab", "chart")
+	s.st.application.CheckCall(c, len(s.st.application.Calls())-1, "CharmConfig", "master")
+}
+
+func (s *CAASProvisionerSuite) TestProvisioningInfoHelmChartNotUploaded(c *gc.C) {
+	s.setHelmChartCharm()
+	s.st.model.SetErrors(errors.NotFoundf("k8s spec for application gitlab"))
+
+	results, err := s.facade.ProvisioningInfo(params.Entities{
+		Entities: []params.Entity{{Tag: "application-gitlab"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeNotFound)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `helm chart resource "chart" for application gitlab not found`)
+}
+
+func (s *CAASProvisionerSuite) TestProvisioningInfoNoSpecNoHelmChart(c *gc.C) {
+	s.st.model.SetErrors(errors.NotFoundf("k8s spec for application gitlab"))
+
+	results, err := s.facade.ProvisioningInfo(params.Entities{
+		Entities: []params.Entity{{Tag: "application-gitlab"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeNotFound)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `k8s spec for application gitlab not found`)
+}
+
 func (s *CAASProvisionerSuite) TestApplicationScale(c *gc.C) {
 	results, err := s.facade.ApplicationsScale(params.Entities{
 		Entities: []params.Entity{
@@ -4,6 +4,7 @@
 package caasunitprovisioner
 
 import (
+	"io"
 	"time"
 
 	"github.com/juju/charm/v7"
@@ -16,6 +17,7 @@ import (
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/resource"
 	"github.com/juju/juju/state"
 )
 
@@ -30,6 +32,7 @@ type CAASUnitProvisionerState interface {
 	Model() (Model, error)
 	WatchApplications() state.StringsWatcher
 	ResolveConstraints(cons constraints.Value) (constraints.Value, error)
+	OpenResource(applicationID, name string) (resource.Resource, io.ReadCloser, error)
 }
 
 // StorageBackend provides the subset of backend storage
@@ -92,6 +95,10 @@ type Application interface {
 	Charm() (Charm, bool, error)
 	ClearResources() error
 	CharmModifiedVersion() int
+	CharmConfig(branchName string) (charm.Settings, error)
+	WatchCharmConfig() (state.NotifyWatcher, error)
+	WatchApplicationConfig() state.NotifyWatcher
+	Watch() state.NotifyWatcher
 }
 
 type stateShim struct {
@@ -106,6 +113,14 @@ func (s stateShim) Application(id string) (Application, error) {
 	return applicationShim{app}, nil
 }
 
+func (s stateShim) OpenResource(applicationID, name string) (resource.Resource, io.ReadCloser, error) {
+	resources, err := s.State.Resources()
+	if err != nil {
+		return resource.Resource{}, nil, errors.Trace(err)
+	}
+	return resources.OpenResource(applicationID, name)
+}
+
 func (s stateShim) Model() (Model, error) {
 	model, err := s.State.Model()
 	if err != nil {
@@ -136,6 +151,7 @@ func (a applicationShim) Charm() (Charm, bool, error) {
 
 type Charm interface {
 	Meta() *charm.Meta
+	URL() *charm.URL
 }
 
 type Unit interface {
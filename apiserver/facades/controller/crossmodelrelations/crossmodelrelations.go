@@ -50,6 +50,16 @@ type CrossModelRelationsAPI struct {
 	offerStatusWatcher    offerStatusWatcherFunc
 }
 
+// CrossModelRelationsAPIV3 does not have CheckOfferConnections.
+type CrossModelRelationsAPIV3 struct {
+	*CrossModelRelationsAPI
+}
+
+// CrossModelRelationsAPIV2 does not have RedeemOfferInvitations.
+type CrossModelRelationsAPIV2 struct {
+	*CrossModelRelationsAPI
+}
+
 // CrossModelRelationsAPIV1 has WatchRelationUnits rather than WatchRelationChanges.
 type CrossModelRelationsAPIV1 struct {
 	*CrossModelRelationsAPI
@@ -84,6 +94,26 @@ func NewStateCrossModelRelationsAPI(ctx facade.Context) (*CrossModelRelationsAPI
 	)
 }
 
+// NewStateCrossModelRelationsAPIV3 creates a new server-side
+// CrossModelRelations v3 API facade backed by state.
+func NewStateCrossModelRelationsAPIV3(ctx facade.Context) (*CrossModelRelationsAPIV3, error) {
+	api, err := NewStateCrossModelRelationsAPI(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &CrossModelRelationsAPIV3{api}, nil
+}
+
+// NewStateCrossModelRelationsAPIV2 creates a new server-side
+// CrossModelRelations v2 API facade backed by state.
+func NewStateCrossModelRelationsAPIV2(ctx facade.Context) (*CrossModelRelationsAPIV2, error) {
+	api, err := NewStateCrossModelRelationsAPI(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &CrossModelRelationsAPIV2{api}, nil
+}
+
 // NewStateCrossModelRelationsAPIV1 creates a new server-side
 // CrossModelRelations v1 API facade backed by state.
 func NewStateCrossModelRelationsAPIV1(ctx facade.Context) (*CrossModelRelationsAPIV1, error) {
@@ -187,6 +217,133 @@ func (api *CrossModelRelationsAPI) RegisterRemoteRelations(
 	return results, nil
 }
 
+// RedeemOfferInvitations redeems invitations to consume offers on behalf
+// of the specified consuming models, returning for each a macaroon which
+// allows the invitation's invitee to consume the offer.
+func (api *CrossModelRelationsAPI) RedeemOfferInvitations(
+	args params.RedeemOfferInvitationArgs,
+) (params.MacaroonResults, error) {
+	results := params.MacaroonResults{
+		Results: make([]params.MacaroonResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		mac, err := api.redeemOfferInvitation(arg)
+		results.Results[i].Result = mac
+		results.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *CrossModelRelationsAPI) redeemOfferInvitation(arg params.RedeemOfferInvitationArg) (*macaroon.Macaroon, error) {
+	sourceModelTag, err := names.ParseModelTag(arg.SourceModelTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Older consumers don't say which controller they're on.
+	var sourceControllerUUID string
+	if arg.SourceControllerTag != "" {
+		sourceControllerTag, err := names.ParseControllerTag(arg.SourceControllerTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		sourceControllerUUID = sourceControllerTag.Id()
+	}
+	invitationId, offerUUID, err := api.authCtxt.CheckOfferInvitationMacaroons(api.ctx, arg.Macaroons)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	invitation, err := api.st.RedeemOfferInvitation(invitationId, sourceModelTag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if invitation.OfferUUID() != offerUUID {
+		return nil, apiservererrors.ErrPerm
+	}
+	offer := &params.ApplicationOfferDetails{
+		SourceModelTag: names.NewModelTag(api.st.ModelUUID()).String(),
+		OfferUUID:      offerUUID,
+	}
+	// The invitation is a bearer token, so the macaroon it's redeemed
+	// for is bound to the model that redeemed it.
+	mac, err := api.authCtxt.CreateInvitedConsumeOfferMacaroon(
+		api.ctx, offer, invitation.Invitee().Id(), sourceModelTag.Id(), sourceControllerUUID, arg.BakeryVersion)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return mac.M(), nil
+}
+
+// CheckOfferConnections checks whether the specified consuming models
+// may relate to offers, without recording any connection. This allows
+// consumers to report an offer's limits being reached as soon as a
+// relation is added, rather than when it is registered.
+func (api *CrossModelRelationsAPI) CheckOfferConnections(
+	args params.CheckOfferConnectionArgs,
+) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := api.checkOfferConnection(arg)
+		results.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *CrossModelRelationsAPI) checkOfferConnection(arg params.CheckOfferConnectionArg) error {
+	sourceModelTag, err := names.ParseModelTag(arg.SourceModelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	sourceControllerTag, err := names.ParseControllerTag(arg.SourceControllerTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	appOffer, err := api.st.ApplicationOfferForUUID(arg.OfferUUID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	auth := api.authCtxt.Authenticator(api.st.ModelUUID(), appOffer.OfferUUID)
+	attr, err := auth.CheckOfferMacaroons(api.ctx, appOffer.OfferUUID, arg.Macaroons, arg.BakeryVersion)
+	if err != nil {
+		return err
+	}
+	if attr["username"] == "" {
+		return apiservererrors.ErrPerm
+	}
+	if err := checkConsumingModel(attr, sourceModelTag.Id()); err != nil {
+		return errors.Trace(err)
+	}
+	sourceControllerUUID, err := consumingController(attr, sourceControllerTag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return api.st.CheckOfferConnectionAllowed(appOffer.OfferUUID, sourceModelTag.Id(), sourceControllerUUID)
+}
+
+// consumingController returns the UUID of the consuming controller
+// declared by the offer macaroons, which is what the offer's allowed
+// controllers are checked against. The caller's own report of its
+// controller can't be trusted, so it is rejected if it conflicts.
+func consumingController(attr map[string]string, reportedUUID string) (string, error) {
+	declaredUUID := commoncrossmodel.ConsumingControllerUUID(attr)
+	if reportedUUID != "" && declaredUUID != "" && reportedUUID != declaredUUID {
+		return "", apiservererrors.ErrPerm
+	}
+	return declaredUUID, nil
+}
+
+// checkConsumingModel checks that the offer macaroons, if they were
+// minted by redeeming an offer invitation, were minted for the model
+// the caller is acting on behalf of.
+func checkConsumingModel(attr map[string]string, sourceModelUUID string) error {
+	declaredUUID := commoncrossmodel.ConsumingModelUUID(attr)
+	if declaredUUID != "" && declaredUUID != sourceModelUUID {
+		return apiservererrors.ErrPerm
+	}
+	return nil
+}
+
 func (api *CrossModelRelationsAPI) registerRemoteRelation(relation params.RegisterRemoteRelationArg) (*params.RemoteRelationDetails, error) {
 	logger.Debugf("register remote relation %+v", relation)
 	// TODO(wallyworld) - do this as a transaction so the result is atomic
@@ -255,7 +412,39 @@ func (api *CrossModelRelationsAPI) registerRemoteRelation(relation params.Regist
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	_, err = api.st.AddRemoteApplication(state.AddRemoteApplicationParams{
+	// Older consumers don't say which controller they're on.
+	var reportedControllerUUID string
+	if relation.SourceControllerTag != "" {
+		sourceControllerTag, err := names.ParseControllerTag(relation.SourceControllerTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		reportedControllerUUID = sourceControllerTag.Id()
+	}
+	if err := checkConsumingModel(attr, sourceModelTag.Id()); err != nil {
+		return nil, errors.Trace(err)
+	}
+	sourceControllerUUID, err := consumingController(attr, reportedControllerUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// Check the offer's limits allow the relation before anything is
+	// created for it. The relation may already have been registered,
+	// in which case it's already counted.
+	localRel, err := api.st.EndpointsRelation(*localEndpoint, remoteEndpoint)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	newRelation := err != nil
+	if newRelation {
+		err := api.st.CheckOfferConnectionAllowed(appOffer.OfferUUID, sourceModelTag.Id(), sourceControllerUUID)
+		if err != nil {
+			return nil, errors.Annotate(err, "adding offer connection details")
+		}
+	}
+
+	remoteApp, err := api.st.AddRemoteApplication(state.AddRemoteApplicationParams{
 		Name:            uniqueRemoteApplicationName,
 		OfferUUID:       relation.OfferUUID,
 		SourceModel:     sourceModelTag,
@@ -267,28 +456,41 @@ func (api *CrossModelRelationsAPI) registerRemoteRelation(relation params.Regist
 	if err != nil && !errors.IsAlreadyExists(err) {
 		return nil, errors.Annotatef(err, "adding remote application %v", uniqueRemoteApplicationName)
 	}
+	newRemoteApp := err == nil
 	logger.Debugf("added remote application %v to local model with token %v from model %v", uniqueRemoteApplicationName, relation.ApplicationToken, sourceModelTag.Id())
 
 	// Now add the relation if it doesn't already exist.
-	localRel, err := api.st.EndpointsRelation(*localEndpoint, remoteEndpoint)
-	if err != nil && !errors.IsNotFound(err) {
-		return nil, errors.Trace(err)
-	}
-	if err != nil { // not found
+	if newRelation {
 		localRel, err = api.st.AddRelation(*localEndpoint, remoteEndpoint)
 		// Again, if it already exists, that's fine.
-		if err != nil && !errors.IsAlreadyExists(err) {
+		if errors.IsAlreadyExists(err) {
+			newRelation = false
+			localRel, err = api.st.EndpointsRelation(*localEndpoint, remoteEndpoint)
+		}
+		if err != nil {
 			return nil, errors.Annotate(err, "adding remote relation")
 		}
 		logger.Debugf("added relation %v to model %v", localRel.Tag().Id(), api.st.ModelUUID())
 	}
 	_, err = api.st.AddOfferConnection(state.AddOfferConnectionParams{
 		SourceModelUUID: sourceModelTag.Id(), Username: username,
-		OfferUUID:   appOffer.OfferUUID,
-		RelationId:  localRel.Id(),
-		RelationKey: localRel.Tag().Id(),
+		OfferUUID:            appOffer.OfferUUID,
+		RelationId:           localRel.Id(),
+		RelationKey:          localRel.Tag().Id(),
+		SourceControllerUUID: sourceControllerUUID,
 	})
 	if err != nil && !errors.IsAlreadyExists(err) {
+		// The limits were checked above, but another relation may have
+		// been added since. Don't leave behind what was created here.
+		if newRemoteApp {
+			if destroyErr := remoteApp.Destroy(); destroyErr != nil {
+				logger.Warningf("cannot remove remote application %v: %v", uniqueRemoteApplicationName, destroyErr)
+			}
+		} else if newRelation {
+			if destroyErr := localRel.Destroy(); destroyErr != nil {
+				logger.Warningf("cannot remove relation %v: %v", localRel.Tag().Id(), destroyErr)
+			}
+		}
 		return nil, errors.Annotate(err, "adding offer connection details")
 	}
 	api.relationToOffer[localRel.Tag().Id()] = relation.OfferUUID
@@ -441,6 +643,21 @@ func (api *CrossModelRelationsAPI) WatchRelationChanges(remoteRelationArgs param
 // WatchRelationChanges doesn't exist before the v2 API.
 func (api *CrossModelRelationsAPIV1) WatchRelationChanges(_, _ struct{}) {}
 
+// RedeemOfferInvitations isn't on the v1 API.
+func (api *CrossModelRelationsAPIV1) RedeemOfferInvitations(_, _ struct{}) {}
+
+// RedeemOfferInvitations isn't on the v2 API.
+func (api *CrossModelRelationsAPIV2) RedeemOfferInvitations(_, _ struct{}) {}
+
+// CheckOfferConnections isn't on the v1 API.
+func (api *CrossModelRelationsAPIV1) CheckOfferConnections(_, _ struct{}) {}
+
+// CheckOfferConnections isn't on the v2 API.
+func (api *CrossModelRelationsAPIV2) CheckOfferConnections(_, _ struct{}) {}
+
+// CheckOfferConnections isn't on the v3 API.
+func (api *CrossModelRelationsAPIV3) CheckOfferConnections(_, _ struct{}) {}
+
 // RelationUnitSettings returns the relation unit settings for the
 // given relation units. (Removed in v2 of the API, the events
 // returned by WatchRelationChanges include the full settings.)
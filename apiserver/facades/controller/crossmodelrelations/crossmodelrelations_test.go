@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/juju/charm/v7"
+	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -288,6 +289,295 @@ func (s *crossmodelRelationsSuite) TestRegisterRemoteRelationsIdempotent(c *gc.C
 	s.assertRegisterRemoteRelations(c)
 }
 
+func (s *crossmodelRelationsSuite) addOffer(c *gc.C, extraCaveats ...checkers.Caveat) *macaroon.Macaroon {
+	app := &mockApplication{}
+	app.eps = []state.Endpoint{{
+		ApplicationName: "offeredapp",
+		Relation:        charm.Relation{Name: "local"},
+	}}
+	s.st.applications["offeredapp"] = app
+	s.st.offers = map[string]*crossmodel.ApplicationOffer{
+		"offer-uuid": {
+			OfferUUID:       "offer-uuid",
+			OfferName:       "offered",
+			ApplicationName: "offeredapp",
+		}}
+	mac, err := s.bakery.NewMacaroon(
+		context.TODO(),
+		bakery.LatestVersion,
+		append([]checkers.Caveat{
+			checkers.DeclaredCaveat("source-model-uuid", s.st.ModelUUID()),
+			checkers.DeclaredCaveat("offer-uuid", "offer-uuid"),
+			checkers.DeclaredCaveat("username", "mary"),
+		}, extraCaveats...), bakery.Op{"offer-uuid", "consume"})
+	c.Assert(err, jc.ErrorIsNil)
+	return mac.M()
+}
+
+func (s *crossmodelRelationsSuite) TestRegisterRemoteRelationsWithSourceController(c *gc.C) {
+	mac := s.addOffer(c, checkers.DeclaredCaveat("consuming-controller-uuid", coretesting.ControllerTag.Id()))
+	results, err := s.api.RegisterRemoteRelations(params.RegisterRemoteRelationArgs{
+		Relations: []params.RegisterRemoteRelationArg{{
+			ApplicationToken:    "app-token",
+			SourceModelTag:      coretesting.ModelTag.String(),
+			SourceControllerTag: coretesting.ControllerTag.String(),
+			RelationToken:       "rel-token",
+			RemoteEndpoint:      params.RemoteEndpoint{Name: "remote"},
+			OfferUUID:           "offer-uuid",
+			LocalEndpointName:   "local",
+			Macaroons:           macaroon.Slice{mac},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(s.st.offerConnections, gc.HasLen, 1)
+	c.Assert(s.st.offerConnections[0].sourceControllerUUID, gc.Equals, coretesting.ControllerTag.Id())
+	s.st.CheckCall(c, 1, "CheckOfferConnectionAllowed", "offer-uuid", coretesting.ModelTag.Id(), coretesting.ControllerTag.Id())
+}
+
+func (s *crossmodelRelationsSuite) TestRegisterRemoteRelationsUndeclaredSourceController(c *gc.C) {
+	// The controller reported by the caller isn't trusted; only one
+	// declared by the offer macaroons is checked and recorded.
+	mac := s.addOffer(c)
+	results, err := s.api.RegisterRemoteRelations(params.RegisterRemoteRelationArgs{
+		Relations: []params.RegisterRemoteRelationArg{{
+			ApplicationToken:    "app-token",
+			SourceModelTag:      coretesting.ModelTag.String(),
+			SourceControllerTag: coretesting.ControllerTag.String(),
+			RelationToken:       "rel-token",
+			RemoteEndpoint:      params.RemoteEndpoint{Name: "remote"},
+			OfferUUID:           "offer-uuid",
+			LocalEndpointName:   "local",
+			Macaroons:           macaroon.Slice{mac},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(s.st.offerConnections, gc.HasLen, 1)
+	c.Assert(s.st.offerConnections[0].sourceControllerUUID, gc.Equals, "")
+	s.st.CheckCall(c, 1, "CheckOfferConnectionAllowed", "offer-uuid", coretesting.ModelTag.Id(), "")
+}
+
+func (s *crossmodelRelationsSuite) TestRegisterRemoteRelationsConflictingSourceController(c *gc.C) {
+	mac := s.addOffer(c, checkers.DeclaredCaveat("consuming-controller-uuid", "deadbeef-2bad-500d-9000-4b1d0d06f00d"))
+	results, err := s.api.RegisterRemoteRelations(params.RegisterRemoteRelationArgs{
+		Relations: []params.RegisterRemoteRelationArg{{
+			ApplicationToken:    "app-token",
+			SourceModelTag:      coretesting.ModelTag.String(),
+			SourceControllerTag: coretesting.ControllerTag.String(),
+			RelationToken:       "rel-token",
+			RemoteEndpoint:      params.RemoteEndpoint{Name: "remote"},
+			OfferUUID:           "offer-uuid",
+			LocalEndpointName:   "local",
+			Macaroons:           macaroon.Slice{mac},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "permission denied")
+	c.Assert(s.st.remoteApplications, gc.HasLen, 0)
+	s.st.CheckCallNames(c, "Application")
+}
+
+func (s *crossmodelRelationsSuite) TestRegisterRemoteRelationsOtherConsumingModel(c *gc.C) {
+	// Macaroons minted by redeeming an invitation can only be used
+	// by the model that redeemed it.
+	mac := s.addOffer(c, checkers.DeclaredCaveat("consuming-model-uuid", "deadbeef-0bad-400d-8000-4b1d0d06f00e"))
+	results, err := s.api.RegisterRemoteRelations(params.RegisterRemoteRelationArgs{
+		Relations: []params.RegisterRemoteRelationArg{{
+			ApplicationToken:  "app-token",
+			SourceModelTag:    coretesting.ModelTag.String(),
+			RelationToken:     "rel-token",
+			RemoteEndpoint:    params.RemoteEndpoint{Name: "remote"},
+			OfferUUID:         "offer-uuid",
+			LocalEndpointName: "local",
+			Macaroons:         macaroon.Slice{mac},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "permission denied")
+	c.Assert(s.st.remoteApplications, gc.HasLen, 0)
+	s.st.CheckCallNames(c, "Application")
+}
+
+func (s *crossmodelRelationsSuite) TestRegisterRemoteRelationsLimitReached(c *gc.C) {
+	mac := s.addOffer(c)
+	s.st.offerConnectionErr = errors.QuotaLimitExceededf(`offer "offered" is limited to 1 relations`)
+	results, err := s.api.RegisterRemoteRelations(params.RegisterRemoteRelationArgs{
+		Relations: []params.RegisterRemoteRelationArg{{
+			ApplicationToken:  "app-token",
+			SourceModelTag:    coretesting.ModelTag.String(),
+			RelationToken:     "rel-token",
+			RemoteEndpoint:    params.RemoteEndpoint{Name: "remote"},
+			OfferUUID:         "offer-uuid",
+			LocalEndpointName: "local",
+			Macaroons:         macaroon.Slice{mac},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `adding offer connection details: offer "offered" is limited to 1 relations`)
+	c.Assert(results.Results[0].Error.Code, gc.Equals, params.CodeQuotaLimitExceeded)
+	// Nothing is created when the limits are already reached.
+	c.Assert(s.st.remoteApplications, gc.HasLen, 0)
+	c.Assert(s.st.relations, gc.HasLen, 0)
+}
+
+func (s *crossmodelRelationsSuite) TestRegisterRemoteRelationsLimitReachedConcurrently(c *gc.C) {
+	mac := s.addOffer(c)
+	s.st.addOfferConnectionErr = errors.QuotaLimitExceededf(`offer "offered" is limited to 1 relations`)
+	results, err := s.api.RegisterRemoteRelations(params.RegisterRemoteRelationArgs{
+		Relations: []params.RegisterRemoteRelationArg{{
+			ApplicationToken:  "app-token",
+			SourceModelTag:    coretesting.ModelTag.String(),
+			RelationToken:     "rel-token",
+			RemoteEndpoint:    params.RemoteEndpoint{Name: "remote"},
+			OfferUUID:         "offer-uuid",
+			LocalEndpointName: "local",
+			Macaroons:         macaroon.Slice{mac},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `adding offer connection details: offer "offered" is limited to 1 relations`)
+	// The remote application added for the relation is removed again.
+	s.st.remoteApplications["remote-apptoken"].CheckCallNames(c, "Destroy")
+}
+
+func (s *crossmodelRelationsSuite) TestCheckOfferConnections(c *gc.C) {
+	mac := s.addOffer(c, checkers.DeclaredCaveat("consuming-controller-uuid", coretesting.ControllerTag.Id()))
+	s.st.offerConnectionErr = errors.QuotaLimitExceededf(`offer "offered" is limited to 1 connected models`)
+	results, err := s.api.CheckOfferConnections(params.CheckOfferConnectionArgs{
+		Args: []params.CheckOfferConnectionArg{{
+			OfferUUID:           "offer-uuid",
+			SourceModelTag:      coretesting.ModelTag.String(),
+			SourceControllerTag: coretesting.ControllerTag.String(),
+			Macaroons:           macaroon.Slice{mac},
+		}, {
+			OfferUUID:           "offer-uuid",
+			SourceModelTag:      coretesting.ModelTag.String(),
+			SourceControllerTag: "controller",
+			Macaroons:           macaroon.Slice{mac},
+		}, {
+			OfferUUID:           "missing",
+			SourceModelTag:      coretesting.ModelTag.String(),
+			SourceControllerTag: coretesting.ControllerTag.String(),
+			Macaroons:           macaroon.Slice{mac},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `offer "offered" is limited to 1 connected models`)
+	c.Assert(results.Results[0].Error.Code, gc.Equals, params.CodeQuotaLimitExceeded)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `"controller" is not a valid tag`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `offer missing not found`)
+	s.st.CheckCalls(c, []testing.StubCall{
+		{"CheckOfferConnectionAllowed", []interface{}{"offer-uuid", coretesting.ModelTag.Id(), coretesting.ControllerTag.Id()}},
+	})
+}
+
+func (s *crossmodelRelationsSuite) TestCheckOfferConnectionsOtherConsumingModel(c *gc.C) {
+	mac := s.addOffer(c, checkers.DeclaredCaveat("consuming-model-uuid", "deadbeef-0bad-400d-8000-4b1d0d06f00e"))
+	results, err := s.api.CheckOfferConnections(params.CheckOfferConnectionArgs{
+		Args: []params.CheckOfferConnectionArg{{
+			OfferUUID:           "offer-uuid",
+			SourceModelTag:      coretesting.ModelTag.String(),
+			SourceControllerTag: coretesting.ControllerTag.String(),
+			Macaroons:           macaroon.Slice{mac},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "permission denied")
+	s.st.CheckNoCalls(c)
+}
+
+func (s *crossmodelRelationsSuite) TestCheckOfferConnectionsNoConsumePermission(c *gc.C) {
+	s.addOffer(c)
+	mac, err := s.bakery.NewMacaroon(
+		context.TODO(),
+		bakery.LatestVersion,
+		[]checkers.Caveat{
+			checkers.DeclaredCaveat("offer-uuid", "offer-uuid"),
+		}, bakery.Op{"offer-uuid", "consume"})
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := s.api.CheckOfferConnections(params.CheckOfferConnectionArgs{
+		Args: []params.CheckOfferConnectionArg{{
+			OfferUUID:           "offer-uuid",
+			SourceModelTag:      coretesting.ModelTag.String(),
+			SourceControllerTag: coretesting.ControllerTag.String(),
+			Macaroons:           macaroon.Slice{mac.M()},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.NotNil)
+	s.st.CheckNoCalls(c)
+}
+
+func (s *crossmodelRelationsSuite) redeemOfferInvitation(c *gc.C, invitationId, offerUUID string) params.MacaroonResult {
+	mac, err := s.bakery.NewMacaroon(
+		context.TODO(),
+		bakery.LatestVersion,
+		[]checkers.Caveat{
+			checkers.DeclaredCaveat("offer-uuid", offerUUID),
+			checkers.DeclaredCaveat("offer-invitation", invitationId),
+		}, bakery.Op{invitationId, "redeem"})
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := s.api.RedeemOfferInvitations(params.RedeemOfferInvitationArgs{
+		Args: []params.RedeemOfferInvitationArg{{
+			SourceModelTag:      "model-deadbeef-0bad-400d-8000-4b1d0d06f00e",
+			SourceControllerTag: coretesting.ControllerTag.String(),
+			Macaroons:           macaroon.Slice{mac.M()},
+			BakeryVersion:       bakery.LatestVersion,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	return results.Results[0]
+}
+
+func (s *crossmodelRelationsSuite) TestRedeemOfferInvitations(c *gc.C) {
+	s.st.offerInvitations["deadbeef"] = &mockOfferInvitation{id: "deadbeef", offerUUID: "hosted-db2-uuid"}
+	result := s.redeemOfferInvitation(c, "deadbeef", "hosted-db2-uuid")
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Result, gc.NotNil)
+	s.st.CheckCalls(c, []testing.StubCall{
+		{"RedeemOfferInvitation", []interface{}{"deadbeef", "deadbeef-0bad-400d-8000-4b1d0d06f00e"}},
+	})
+
+	cav := result.Result.Caveats()
+	c.Assert(cav, gc.HasLen, 6)
+	c.Assert(bytes.HasPrefix(cav[0].Id, []byte("time-before")), jc.IsTrue)
+	c.Assert(cav[1].Id, jc.DeepEquals, []byte("declared source-model-uuid "+coretesting.ModelTag.Id()))
+	c.Assert(cav[2].Id, jc.DeepEquals, []byte("declared offer-uuid hosted-db2-uuid"))
+	c.Assert(cav[3].Id, jc.DeepEquals, []byte("declared username deadbeef@invitation"))
+	c.Assert(cav[4].Id, jc.DeepEquals, []byte("declared consuming-model-uuid deadbeef-0bad-400d-8000-4b1d0d06f00e"))
+	c.Assert(cav[5].Id, jc.DeepEquals, []byte("declared consuming-controller-uuid "+coretesting.ControllerTag.Id()))
+}
+
+func (s *crossmodelRelationsSuite) TestRedeemOfferInvitationsAlreadyRedeemed(c *gc.C) {
+	s.st.offerInvitations["deadbeef"] = &mockOfferInvitation{
+		id: "deadbeef", offerUUID: "hosted-db2-uuid", redeemedBy: coretesting.ModelTag.Id(),
+	}
+	result := s.redeemOfferInvitation(c, "deadbeef", "hosted-db2-uuid")
+	c.Assert(result.Error, gc.ErrorMatches, "invitation has already been redeemed")
+	c.Assert(result.Result, gc.IsNil)
+}
+
+func (s *crossmodelRelationsSuite) TestRedeemOfferInvitationsWrongOffer(c *gc.C) {
+	s.st.offerInvitations["deadbeef"] = &mockOfferInvitation{id: "deadbeef", offerUUID: "hosted-db2-uuid"}
+	result := s.redeemOfferInvitation(c, "deadbeef", "hosted-mysql-uuid")
+	c.Assert(result.Error, gc.ErrorMatches, "permission denied")
+	c.Assert(result.Result, gc.IsNil)
+}
+
+func (s *crossmodelRelationsSuite) TestRedeemOfferInvitationsNoInvitation(c *gc.C) {
+	mac, err := s.bakery.NewMacaroon(
+		context.TODO(),
+		bakery.LatestVersion,
+		[]checkers.Caveat{
+			checkers.DeclaredCaveat("offer-uuid", "hosted-db2-uuid"),
+			checkers.DeclaredCaveat("username", "mary"),
+		}, bakery.Op{"hosted-db2-uuid", "consume"})
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := s.api.RedeemOfferInvitations(params.RedeemOfferInvitationArgs{
+		Args: []params.RedeemOfferInvitationArg{{
+			SourceModelTag: "model-deadbeef-0bad-400d-8000-4b1d0d06f00e",
+			Macaroons:      macaroon.Slice{mac.M()},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "permission denied")
+	s.st.CheckNoCalls(c)
+}
+
 func (s *crossmodelRelationsSuite) TestRelationUnitSettings(c *gc.C) {
 	djangoRelationUnit := newMockRelationUnit()
 	djangoRelationUnit.settings["key"] = "value"
@@ -54,6 +54,9 @@ type mockState struct {
 	remoteEntities        map[names.Tag]string
 	firewallRules         map[corefirewall.WellKnownServiceType]*state.FirewallRule
 	ingressNetworks       map[string][]string
+	offerInvitations      map[string]*mockOfferInvitation
+	offerConnectionErr    error
+	addOfferConnectionErr error
 	migrationActive       bool
 }
 
@@ -69,6 +72,7 @@ func newMockState() *mockState {
 		offerConnectionsByKey: make(map[string]*mockOfferConnection),
 		firewallRules:         make(map[corefirewall.WellKnownServiceType]*state.FirewallRule),
 		ingressNetworks:       make(map[string][]string),
+		offerInvitations:      make(map[string]*mockOfferInvitation),
 	}
 }
 
@@ -113,18 +117,43 @@ func (st *mockState) AddOfferConnection(arg state.AddOfferConnectionParams) (cro
 	if _, ok := st.offerConnections[arg.RelationId]; ok {
 		return nil, errors.AlreadyExistsf("offer connection for relation %d", arg.RelationId)
 	}
+	if st.addOfferConnectionErr != nil {
+		return nil, st.addOfferConnectionErr
+	}
+	if st.offerConnectionErr != nil {
+		return nil, st.offerConnectionErr
+	}
 	oc := &mockOfferConnection{
-		sourcemodelUUID: arg.SourceModelUUID,
-		relationId:      arg.RelationId,
-		relationKey:     arg.RelationKey,
-		username:        arg.Username,
-		offerUUID:       arg.OfferUUID,
+		sourcemodelUUID:      arg.SourceModelUUID,
+		sourceControllerUUID: arg.SourceControllerUUID,
+		relationId:           arg.RelationId,
+		relationKey:          arg.RelationKey,
+		username:             arg.Username,
+		offerUUID:            arg.OfferUUID,
 	}
 	st.offerConnections[arg.RelationId] = oc
 	st.offerConnectionsByKey[arg.RelationKey] = oc
 	return oc, nil
 }
 
+func (st *mockState) CheckOfferConnectionAllowed(offerUUID, sourceModelUUID, sourceControllerUUID string) error {
+	st.MethodCall(st, "CheckOfferConnectionAllowed", offerUUID, sourceModelUUID, sourceControllerUUID)
+	return st.offerConnectionErr
+}
+
+func (st *mockState) RedeemOfferInvitation(id, sourceModelUUID string) (crossmodelrelations.OfferInvitation, error) {
+	st.MethodCall(st, "RedeemOfferInvitation", id, sourceModelUUID)
+	invitation, ok := st.offerInvitations[id]
+	if !ok {
+		return nil, errors.NotFoundf("offer invitation %q", id)
+	}
+	if invitation.redeemedBy != "" && invitation.redeemedBy != sourceModelUUID {
+		return nil, errors.New("invitation has already been redeemed")
+	}
+	invitation.redeemedBy = sourceModelUUID
+	return invitation, nil
+}
+
 func (st *mockState) FirewallRule(service corefirewall.WellKnownServiceType) (*state.FirewallRule, error) {
 	if r, ok := st.firewallRules[service]; ok {
 		return r, nil
@@ -528,13 +557,28 @@ func (a *mockApplication) Status() (status.StatusInfo, error) {
 	return status.StatusInfo{Status: status.Terminated}, nil
 }
 
+type mockOfferInvitation struct {
+	id         string
+	offerUUID  string
+	redeemedBy string
+}
+
+func (m *mockOfferInvitation) OfferUUID() string {
+	return m.offerUUID
+}
+
+func (m *mockOfferInvitation) Invitee() names.UserTag {
+	return names.NewUserTag(m.id + "@invitation")
+}
+
 type mockOfferConnection struct {
 	crossmodelrelations.OfferConnection
-	sourcemodelUUID string
-	relationId      int
-	relationKey     string
-	username        string
-	offerUUID       string
+	sourcemodelUUID      string
+	sourceControllerUUID string
+	relationId           int
+	relationKey          string
+	username             string
+	offerUUID            string
 }
 
 func (m *mockOfferConnection) OfferUUID() string {
@@ -30,6 +30,14 @@ type CrossModelRelationsState interface {
 	// IsMigrationActive returns true if the current model is
 	// in the process of being migrated to another controller.
 	IsMigrationActive() (bool, error)
+
+	// RedeemOfferInvitation redeems the offer invitation with the
+	// given id on behalf of the specified consuming model.
+	RedeemOfferInvitation(id, sourceModelUUID string) (OfferInvitation, error)
+
+	// CheckOfferConnectionAllowed returns an error if the offer's
+	// limits do not allow a new relation from the specified model.
+	CheckOfferConnectionAllowed(offerUUID, sourceModelUUID, sourceControllerUUID string) error
 }
 
 // TODO - CAAS(ericclaudejones): This should contain state alone, model will be
@@ -52,6 +60,18 @@ func (st stateShim) OfferConnectionForRelation(relationKey string) (OfferConnect
 	return st.st.OfferConnectionForRelation(relationKey)
 }
 
+func (st stateShim) RedeemOfferInvitation(id, sourceModelUUID string) (OfferInvitation, error) {
+	invitation, err := st.st.RedeemOfferInvitation(id, sourceModelUUID)
+	if err != nil {
+		return nil, err
+	}
+	return invitation, nil
+}
+
+func (st stateShim) CheckOfferConnectionAllowed(offerUUID, sourceModelUUID, sourceControllerUUID string) error {
+	return st.st.CheckOfferConnectionAllowed(offerUUID, sourceModelUUID, sourceControllerUUID)
+}
+
 // IsMigrationActive returns true if the current model is
 // in the process of being migrated to another controller.
 func (st stateShim) IsMigrationActive() (bool, error) {
@@ -71,3 +91,8 @@ func (st stateShim) Model() (Model, error) {
 type OfferConnection interface {
 	OfferUUID() string
 }
+
+type OfferInvitation interface {
+	OfferUUID() string
+	Invitee() names.UserTag
+}
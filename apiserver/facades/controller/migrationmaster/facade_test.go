@@ -126,6 +126,8 @@ func (s *Suite) TestMigrationStatus(c *gc.C) {
 	exp.Phase().Return(coremigration.IMPORT, nil)
 	exp.ModelUUID().Return(s.modelUUID)
 	exp.Id().Return("ID")
+	exp.KeepSource().Return(24 * time.Hour)
+	exp.Revert().Return(false)
 	now := time.Now()
 	exp.PhaseChangedTime().Return(now)
 
@@ -146,6 +148,7 @@ func (s *Suite) TestMigrationStatus(c *gc.C) {
 				Password:      password,
 				Macaroons:     `[[{"l":"location","i":"id","s64":"qYAr8nQmJzPWKDppxigFtWaNv0dbzX7cJaligz98LLo"}]]`,
 			},
+			KeepSource: 24 * time.Hour,
 		},
 		MigrationId:      "ID",
 		Phase:            "IMPORT",
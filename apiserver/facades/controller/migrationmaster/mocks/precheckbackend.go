@@ -8,6 +8,8 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	charm "github.com/juju/charm/v7"
+	cloud "github.com/juju/juju/cloud"
 	migration "github.com/juju/juju/migration"
 	resource "github.com/juju/juju/resource"
 	state "github.com/juju/juju/state"
@@ -113,6 +115,36 @@ func (mr *MockPrecheckBackendMockRecorder) AllRelations() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllRelations", reflect.TypeOf((*MockPrecheckBackend)(nil).AllRelations))
 }
 
+// Charm mocks base method
+func (m *MockPrecheckBackend) Charm(arg0 *charm.URL) (migration.PrecheckCharm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Charm", arg0)
+	ret0, _ := ret[0].(migration.PrecheckCharm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Charm indicates an expected call of Charm
+func (mr *MockPrecheckBackendMockRecorder) Charm(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Charm", reflect.TypeOf((*MockPrecheckBackend)(nil).Charm), arg0)
+}
+
+// Cloud mocks base method
+func (m *MockPrecheckBackend) Cloud(arg0 string) (cloud.Cloud, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cloud", arg0)
+	ret0, _ := ret[0].(cloud.Cloud)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Cloud indicates an expected call of Cloud
+func (mr *MockPrecheckBackendMockRecorder) Cloud(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cloud", reflect.TypeOf((*MockPrecheckBackend)(nil).Cloud), arg0)
+}
+
 // CloudCredential mocks base method
 func (m *MockPrecheckBackend) CloudCredential(arg0 names_v3.CloudCredentialTag) (state.Credential, error) {
 	m.ctrl.T.Helper()
@@ -217,3 +249,18 @@ func (mr *MockPrecheckBackendMockRecorder) NeedsCleanup() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NeedsCleanup", reflect.TypeOf((*MockPrecheckBackend)(nil).NeedsCleanup))
 }
+
+// SecretsCount mocks base method
+func (m *MockPrecheckBackend) SecretsCount() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SecretsCount")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SecretsCount indicates an expected call of SecretsCount
+func (mr *MockPrecheckBackendMockRecorder) SecretsCount() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecretsCount", reflect.TypeOf((*MockPrecheckBackend)(nil).SecretsCount))
+}
@@ -67,6 +67,20 @@ func (mr *MockModelMigrationMockRecorder) EndTime() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EndTime", reflect.TypeOf((*MockModelMigration)(nil).EndTime))
 }
 
+// FailedPhase mocks base method
+func (m *MockModelMigration) FailedPhase() migration.Phase {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailedPhase")
+	ret0, _ := ret[0].(migration.Phase)
+	return ret0
+}
+
+// FailedPhase indicates an expected call of FailedPhase
+func (mr *MockModelMigrationMockRecorder) FailedPhase() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailedPhase", reflect.TypeOf((*MockModelMigration)(nil).FailedPhase))
+}
+
 // Id mocks base method
 func (m *MockModelMigration) Id() string {
 	m.ctrl.T.Helper()
@@ -95,6 +109,20 @@ func (mr *MockModelMigrationMockRecorder) InitiatedBy() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InitiatedBy", reflect.TypeOf((*MockModelMigration)(nil).InitiatedBy))
 }
 
+// KeepSource mocks base method
+func (m *MockModelMigration) KeepSource() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KeepSource")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// KeepSource indicates an expected call of KeepSource
+func (mr *MockModelMigrationMockRecorder) KeepSource() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KeepSource", reflect.TypeOf((*MockModelMigration)(nil).KeepSource))
+}
+
 // MinionReports mocks base method
 func (m *MockModelMigration) MinionReports() (*state.MinionReports, error) {
 	m.ctrl.T.Helper()
@@ -110,6 +138,20 @@ func (mr *MockModelMigrationMockRecorder) MinionReports() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MinionReports", reflect.TypeOf((*MockModelMigration)(nil).MinionReports))
 }
 
+// ModelName mocks base method
+func (m *MockModelMigration) ModelName() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModelName")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ModelName indicates an expected call of ModelName
+func (mr *MockModelMigrationMockRecorder) ModelName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModelName", reflect.TypeOf((*MockModelMigration)(nil).ModelName))
+}
+
 // ModelUUID mocks base method
 func (m *MockModelMigration) ModelUUID() string {
 	m.ctrl.T.Helper()
@@ -181,6 +223,20 @@ func (mr *MockModelMigrationMockRecorder) Refresh() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockModelMigration)(nil).Refresh))
 }
 
+// Revert mocks base method
+func (m *MockModelMigration) Revert() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revert")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Revert indicates an expected call of Revert
+func (mr *MockModelMigrationMockRecorder) Revert() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revert", reflect.TypeOf((*MockModelMigration)(nil).Revert))
+}
+
 // SetPhase mocks base method
 func (m *MockModelMigration) SetPhase(arg0 migration.Phase) error {
 	m.ctrl.T.Helper()
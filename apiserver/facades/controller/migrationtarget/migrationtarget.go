@@ -37,6 +37,16 @@ type API struct {
 	getCAASBroker stateenvirons.NewCAASBrokerFunc
 }
 
+// APIV2 implements the API V2.
+type APIV2 struct {
+	*API
+}
+
+// APIV1 implements the API V1.
+type APIV1 struct {
+	*APIV2
+}
+
 // NewFacade is used for API registration.
 func NewFacade(ctx facade.Context) (*API, error) {
 	return NewAPI(
@@ -45,6 +55,24 @@ func NewFacade(ctx facade.Context) (*API, error) {
 		stateenvirons.GetNewCAASBrokerFunc(caas.New))
 }
 
+// NewFacadeV2 is used for API registration.
+func NewFacadeV2(ctx facade.Context) (*APIV2, error) {
+	api, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV2{api}, nil
+}
+
+// NewFacadeV1 is used for API registration.
+func NewFacadeV1(ctx facade.Context) (*APIV1, error) {
+	api, err := NewFacadeV2(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV1{api}, nil
+}
+
 // NewAPI returns a new API. Accepts a NewEnvironFunc and context.ProviderCallContext
 // for testing purposes.
 func NewAPI(ctx facade.Context, getEnviron stateenvirons.NewEnvironFunc, getCAASBroker stateenvirons.NewCAASBrokerFunc) (*API, error) {
@@ -82,7 +110,7 @@ func checkAuth(authorizer facade.Authorizer, st *state.State) error {
 // Prechecks ensure that the target controller is ready to accept a
 // model migration.
 func (api *API) Prechecks(model params.MigrationModelInfo) error {
-	ownerTag, err := names.ParseUserTag(model.OwnerTag)
+	modelInfo, err := migrationModelInfoFromParams(model)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -98,17 +126,142 @@ func (api *API) Prechecks(model params.MigrationModelInfo) error {
 	return migration.TargetPrecheck(
 		backend,
 		migration.PoolShim(api.pool),
-		coremigration.ModelInfo{
-			UUID:                   model.UUID,
-			Name:                   model.Name,
-			Owner:                  ownerTag,
-			AgentVersion:           model.AgentVersion,
-			ControllerAgentVersion: model.ControllerAgentVersion,
-		},
+		modelInfo,
 		api.presence.ModelPresence(controllerState.ModelUUID()),
 	)
 }
 
+// PrecheckReport runs the same checks as Prechecks, but reports every
+// problem which would prevent the migration rather than just the first.
+// Nothing is changed on the target controller.
+func (api *API) PrecheckReport(model params.MigrationModelInfo) (params.MigrationPrecheckReport, error) {
+	modelInfo, err := migrationModelInfoFromParams(model)
+	if err != nil {
+		return params.MigrationPrecheckReport{}, errors.Trace(err)
+	}
+	controllerState := api.pool.SystemState()
+	backend, err := migration.PrecheckShim(api.state, controllerState)
+	if err != nil {
+		return params.MigrationPrecheckReport{}, errors.Annotate(err, "creating backend")
+	}
+	report, err := migration.TargetPrecheckReport(
+		backend,
+		migration.PoolShim(api.pool),
+		modelInfo,
+		api.presence.ModelPresence(controllerState.ModelUUID()),
+	)
+	if err != nil {
+		return params.MigrationPrecheckReport{}, errors.Trace(err)
+	}
+	return params.MigrationPrecheckReport{
+		Blockers: report.Blockers,
+		Warnings: report.Warnings,
+	}, nil
+}
+
+// PrecheckReport isn't on the v1 API.
+func (api *APIV1) PrecheckReport(_, _ struct{}) {}
+
+// RevertPrechecks ensures that the model being migrated to this
+// controller is one that was migrated away from it and is still being
+// kept, dormant, so that the migration can be reverted. The kept model
+// must not have diverged from the model being moved back, since
+// reverting would otherwise lose the changes made since the migration.
+func (api *API) RevertPrechecks(args params.RevertPrechecksArgs) error {
+	modelInfo, err := migrationModelInfoFromParams(args.Model)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	m, release, err := api.getDormantModel(modelInfo.UUID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer release()
+	if m.Name() != modelInfo.Name || m.Owner() != modelInfo.Owner {
+		return errors.Errorf("model %s is kept as %s/%s, not %s/%s",
+			modelInfo.UUID, m.Owner().Id(), m.Name(), modelInfo.Owner.Id(), modelInfo.Name)
+	}
+	kept, err := migration.ExportModelFingerprint(m.State())
+	if err != nil {
+		return errors.Annotate(err, "summarising kept model")
+	}
+	if kept != args.Fingerprint {
+		return errors.Errorf("model %s has changed since it was migrated away, "+
+			"reverting the migration would lose those changes", modelInfo.UUID)
+	}
+	return nil
+}
+
+// RevertPrechecks isn't on the v2 API.
+func (api *APIV2) RevertPrechecks(_, _ struct{}) {}
+
+// Reactivate ends the dormancy of a model kept by this controller
+// after it was migrated away, reverting that migration. The model is
+// ready to use again once its agents have been moved back.
+func (api *API) Reactivate(args params.ModelArgs) error {
+	tag, err := names.ParseModelTag(args.ModelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	m, release, err := api.getDormantModel(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer release()
+	mig, err := m.State().LatestMigration()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(mig.SetPhase(coremigration.REVERTED))
+}
+
+// Reactivate isn't on the v2 API.
+func (api *APIV2) Reactivate(_, _ struct{}) {}
+
+// getDormantModel returns the model with the given UUID if this
+// controller is keeping it, dormant, after a migration.
+func (api *API) getDormantModel(modelUUID string) (*state.Model, func(), error) {
+	notDormant := errors.Errorf("model %s is not being kept on this controller after a migration", modelUUID)
+	m, ph, err := api.pool.GetModel(modelUUID)
+	if errors.IsNotFound(err) {
+		return nil, nil, notDormant
+	} else if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	mig, err := m.State().LatestMigration()
+	if errors.IsNotFound(err) {
+		ph.Release()
+		return nil, nil, notDormant
+	} else if err != nil {
+		ph.Release()
+		return nil, nil, errors.Trace(err)
+	}
+	if phase, err := mig.Phase(); err != nil {
+		ph.Release()
+		return nil, nil, errors.Trace(err)
+	} else if phase != coremigration.DORMANT {
+		ph.Release()
+		return nil, nil, notDormant
+	}
+	return m, func() { ph.Release() }, nil
+}
+
+func migrationModelInfoFromParams(model params.MigrationModelInfo) (coremigration.ModelInfo, error) {
+	ownerTag, err := names.ParseUserTag(model.OwnerTag)
+	if err != nil {
+		return coremigration.ModelInfo{}, errors.Trace(err)
+	}
+	return coremigration.ModelInfo{
+		UUID:                   model.UUID,
+		Name:                   model.Name,
+		Owner:                  ownerTag,
+		AgentVersion:           model.AgentVersion,
+		ControllerAgentVersion: model.ControllerAgentVersion,
+		CloudName:              model.CloudName,
+		CloudRegion:            model.CloudRegion,
+	}, nil
+}
+
 // Import takes a serialized Juju model, deserializes it, and
 // recreates it in the receiving controller.
 func (api *API) Import(serialized params.SerializedModel) error {
@@ -4,6 +4,7 @@
 package migrationtarget_test
 
 import (
+	"fmt"
 	"io/ioutil"
 	"time"
 
@@ -28,9 +29,11 @@ import (
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/lease"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/migration"
 	"github.com/juju/juju/provider/dummy"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/stateenvirons"
@@ -76,7 +79,7 @@ func (s *Suite) SetUpTest(c *gc.C) {
 }
 
 func (s *Suite) TestFacadeRegistered(c *gc.C) {
-	aFactory, err := apiserver.AllFacades().GetFactory("MigrationTarget", 1)
+	aFactory, err := apiserver.AllFacades().GetFactory("MigrationTarget", 3)
 	c.Assert(err, jc.ErrorIsNil)
 
 	api, err := aFactory(&facadetest.Context{
@@ -88,6 +91,32 @@ func (s *Suite) TestFacadeRegistered(c *gc.C) {
 	c.Assert(api, gc.FitsTypeOf, new(migrationtarget.API))
 }
 
+func (s *Suite) TestFacadeV2Registered(c *gc.C) {
+	aFactory, err := apiserver.AllFacades().GetFactory("MigrationTarget", 2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, err := aFactory(&facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      s.authorizer,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api, gc.FitsTypeOf, new(migrationtarget.APIV2))
+}
+
+func (s *Suite) TestFacadeV1Registered(c *gc.C) {
+	aFactory, err := apiserver.AllFacades().GetFactory("MigrationTarget", 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, err := aFactory(&facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      s.authorizer,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api, gc.FitsTypeOf, new(migrationtarget.APIV1))
+}
+
 func (s *Suite) TestNotUser(c *gc.C) {
 	s.authorizer.Tag = names.NewMachineTag("0")
 	_, err := s.newAPI(nil, nil)
@@ -142,6 +171,44 @@ func (s *Suite) TestPrechecksFail(c *gc.C) {
 	c.Assert(err, gc.NotNil)
 }
 
+func (s *Suite) TestPrecheckReport(c *gc.C) {
+	api := s.mustNewAPI(c)
+	args := params.MigrationModelInfo{
+		UUID:                   "uuid",
+		Name:                   "some-model",
+		OwnerTag:               names.NewUserTag("someone").String(),
+		AgentVersion:           s.controllerVersion(c),
+		ControllerAgentVersion: s.controllerVersion(c),
+	}
+	report, err := api.PrecheckReport(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report, jc.DeepEquals, params.MigrationPrecheckReport{})
+}
+
+func (s *Suite) TestPrecheckReportProblems(c *gc.C) {
+	controllerVersion := s.controllerVersion(c)
+
+	// Set the model version ahead of the controller.
+	modelVersion := controllerVersion
+	modelVersion.Minor++
+
+	api := s.mustNewAPI(c)
+	args := params.MigrationModelInfo{
+		UUID:                   "uuid",
+		Name:                   "some-model",
+		OwnerTag:               names.NewUserTag("someone").String(),
+		AgentVersion:           modelVersion,
+		ControllerAgentVersion: controllerVersion,
+		CloudName:              "no-such-cloud",
+	}
+	report, err := api.PrecheckReport(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report.Blockers, jc.DeepEquals, []string{
+		fmt.Sprintf("model has higher version than target controller (%s > %s)", modelVersion, controllerVersion),
+		`cloud "no-such-cloud" not found on target controller`,
+	})
+}
+
 func (s *Suite) TestImport(c *gc.C) {
 	api := s.mustNewAPI(c)
 	tag := s.importModel(c, api)
@@ -254,6 +321,157 @@ func (s *Suite) TestActivateNotImportingModel(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `migration mode for the model is not importing`)
 }
 
+func (s *Suite) makeDormantModel(c *gc.C) *state.State {
+	st := s.Factory.MakeModel(c, nil)
+	s.AddCleanup(func(*gc.C) { st.Close() })
+	mig, err := st.CreateMigration(state.MigrationSpec{
+		InitiatedBy: names.NewUserTag("admin"),
+		TargetInfo: coremigration.TargetInfo{
+			ControllerTag: names.NewControllerTag(utils.MustNewUUID().String()),
+			Addrs:         []string{"1.2.3.4:5555"},
+			CACert:        "cert",
+			AuthTag:       names.NewUserTag("user"),
+			Password:      "password",
+		},
+		KeepSource: time.Hour,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	for _, phase := range []coremigration.Phase{
+		coremigration.IMPORT,
+		coremigration.PROCESSRELATIONS,
+		coremigration.VALIDATION,
+		coremigration.SUCCESS,
+		coremigration.LOGTRANSFER,
+		coremigration.DORMANT,
+	} {
+		c.Assert(mig.SetPhase(phase), jc.ErrorIsNil)
+	}
+	return st
+}
+
+func (s *Suite) fingerprint(c *gc.C, st *state.State) string {
+	fingerprint, err := migration.ExportModelFingerprint(st)
+	c.Assert(err, jc.ErrorIsNil)
+	return fingerprint
+}
+
+func (s *Suite) TestRevertPrechecks(c *gc.C) {
+	st := s.makeDormantModel(c)
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	api := s.mustNewAPI(c)
+	err = api.RevertPrechecks(params.RevertPrechecksArgs{
+		Model: params.MigrationModelInfo{
+			UUID:         model.UUID(),
+			Name:         model.Name(),
+			OwnerTag:     model.Owner().String(),
+			AgentVersion: s.controllerVersion(c),
+		},
+		Fingerprint: s.fingerprint(c, st),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *Suite) TestRevertPrechecksModelChanged(c *gc.C) {
+	st := s.makeDormantModel(c)
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	fingerprint := s.fingerprint(c, st)
+
+	// Stand in for a change made to the model after it was migrated
+	// away by changing the kept copy instead.
+	f := factory.NewFactory(st, s.StatePool)
+	f.MakeApplication(c, nil)
+
+	api := s.mustNewAPI(c)
+	err = api.RevertPrechecks(params.RevertPrechecksArgs{
+		Model: params.MigrationModelInfo{
+			UUID:         model.UUID(),
+			Name:         model.Name(),
+			OwnerTag:     model.Owner().String(),
+			AgentVersion: s.controllerVersion(c),
+		},
+		Fingerprint: fingerprint,
+	})
+	c.Assert(err, gc.ErrorMatches, `model .* has changed since it was migrated away, reverting the migration would lose those changes`)
+}
+
+func (s *Suite) TestRevertPrechecksDifferentName(c *gc.C) {
+	st := s.makeDormantModel(c)
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	api := s.mustNewAPI(c)
+	err = api.RevertPrechecks(params.RevertPrechecksArgs{
+		Model: params.MigrationModelInfo{
+			UUID:         model.UUID(),
+			Name:         "renamed",
+			OwnerTag:     model.Owner().String(),
+			AgentVersion: s.controllerVersion(c),
+		},
+		Fingerprint: s.fingerprint(c, st),
+	})
+	c.Assert(err, gc.ErrorMatches, `model .* is kept as .*, not .*/renamed`)
+}
+
+func (s *Suite) TestRevertPrechecksNotDormant(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	api := s.mustNewAPI(c)
+	err = api.RevertPrechecks(params.RevertPrechecksArgs{
+		Model: params.MigrationModelInfo{
+			UUID:         model.UUID(),
+			Name:         model.Name(),
+			OwnerTag:     model.Owner().String(),
+			AgentVersion: s.controllerVersion(c),
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `model .* is not being kept on this controller after a migration`)
+}
+
+func (s *Suite) TestRevertPrechecksMissingModel(c *gc.C) {
+	api := s.mustNewAPI(c)
+	err := api.RevertPrechecks(params.RevertPrechecksArgs{
+		Model: params.MigrationModelInfo{
+			UUID:         utils.MustNewUUID().String(),
+			Name:         "some-model",
+			OwnerTag:     names.NewUserTag("someone").String(),
+			AgentVersion: s.controllerVersion(c),
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `model .* is not being kept on this controller after a migration`)
+}
+
+func (s *Suite) TestReactivate(c *gc.C) {
+	st := s.makeDormantModel(c)
+
+	api := s.mustNewAPI(c)
+	err := api.Reactivate(params.ModelArgs{ModelTag: names.NewModelTag(st.ModelUUID()).String()})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(model.MigrationMode(), gc.Equals, state.MigrationModeNone)
+	mig, err := st.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	phase, err := mig.Phase()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(phase, gc.Equals, coremigration.REVERTED)
+}
+
+func (s *Suite) TestReactivateNotDormant(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	api := s.mustNewAPI(c)
+	err := api.Reactivate(params.ModelArgs{ModelTag: names.NewModelTag(st.ModelUUID()).String()})
+	c.Assert(err, gc.ErrorMatches, `model .* is not being kept on this controller after a migration`)
+}
+
 func (s *Suite) TestLatestLogTime(c *gc.C) {
 	st := s.Factory.MakeModel(c, nil)
 	defer st.Close()
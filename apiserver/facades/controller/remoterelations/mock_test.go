@@ -344,6 +344,7 @@ type mockRemoteApplication struct {
 	message       string
 	eps           []charm.Relation
 	consumerproxy bool
+	diagnostics   *crossmodel.RemoteApplicationDiagnostics
 }
 
 func newMockRemoteApplication(name, url string) *mockRemoteApplication {
@@ -410,6 +411,12 @@ func (r *mockRemoteApplication) TerminateOperation(message string) state.ModelOp
 	return &mockOperation{message: message}
 }
 
+func (r *mockRemoteApplication) SetDiagnostics(info crossmodel.RemoteApplicationDiagnostics) error {
+	r.MethodCall(r, "SetDiagnostics", info)
+	r.diagnostics = &info
+	return r.NextErr()
+}
+
 type mockOperation struct {
 	state.ModelOperation
 	message string
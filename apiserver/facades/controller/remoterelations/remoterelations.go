@@ -23,6 +23,11 @@ type APIv1 struct {
 	*API
 }
 
+// APIv2 provides access to version 2 of the remote relations API facade.
+type APIv2 struct {
+	*API
+}
+
 // API provides access to the remote relations API facade.
 type API struct {
 	*common.ControllerConfigAPI
@@ -40,6 +45,15 @@ func NewAPIv1(ctx facade.Context) (*APIv1, error) {
 	return &APIv1{api}, nil
 }
 
+// NewAPIv2 creates a new server-side API facade backed by global state.
+func NewAPIv2(ctx facade.Context) (*APIv2, error) {
+	api, err := NewAPI(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv2{api}, nil
+}
+
 // NewAPI creates a new server-side API facade backed by global state.
 func NewAPI(ctx facade.Context) (*API, error) {
 	return NewRemoteRelationsAPI(
@@ -503,6 +517,34 @@ func (api *API) SetRemoteApplicationsStatus(args params.SetStatus) (params.Error
 	return result, nil
 }
 
+// SetRemoteApplicationsDiagnostics is not available via the V1 API.
+func (u *APIv1) SetRemoteApplicationsDiagnostics(_, _ struct{}) {}
+
+// SetRemoteApplicationsDiagnostics is not available via the V2 API.
+func (u *APIv2) SetRemoteApplicationsDiagnostics(_, _ struct{}) {}
+
+// SetRemoteApplicationsDiagnostics records the cross model relation
+// diagnostics for the specified remote applications.
+func (api *API) SetRemoteApplicationsDiagnostics(args params.SetRemoteApplicationsDiagnosticsArgs) (params.ErrorResults, error) {
+	var result params.ErrorResults
+	result.Results = make([]params.ErrorResult, len(args.Args))
+	for i, arg := range args.Args {
+		remoteAppTag, err := names.ParseApplicationTag(arg.Tag)
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		app, err := api.st.RemoteApplication(remoteAppTag.Id())
+		if err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		err = app.SetDiagnostics(commoncrossmodel.DiagnosticsFromParams(arg.Diagnostics))
+		result.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return result, nil
+}
+
 // UpdateControllersForModels is not available via the V1 API.
 func (u *APIv1) UpdateControllersForModels(_, _ struct{}) {}
 
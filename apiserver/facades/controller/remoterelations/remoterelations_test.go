@@ -4,6 +4,8 @@
 package remoterelations_test
 
 import (
+	"time"
+
 	"github.com/juju/charm/v7"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
@@ -549,6 +551,38 @@ func (s *remoteRelationsSuite) TestSetRemoteApplicationsStatusTerminated(c *gc.C
 	s.st.CheckCall(c, 1, "ApplyOperation", &mockOperation{message: "killer whales"})
 }
 
+func (s *remoteRelationsSuite) TestSetRemoteApplicationsDiagnostics(c *gc.C) {
+	remoteApp := newMockRemoteApplication("db2", "url")
+	s.st.remoteApplications["db2"] = remoteApp
+	lastEvent := time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC)
+	result, err := s.api.SetRemoteApplicationsDiagnostics(params.SetRemoteApplicationsDiagnosticsArgs{
+		Args: []params.SetRemoteApplicationDiagnosticsArg{{
+			Tag: names.NewApplicationTag("db2").String(),
+			Diagnostics: params.RemoteApplicationDiagnostics{
+				LastRemoteEvent:  &lastEvent,
+				SettingsSyncLag:  time.Second,
+				IngressAddresses: []string{"10.0.0.1"},
+				Updated:          lastEvent,
+			},
+		}, {
+			Tag: names.NewApplicationTag("unknown").String(),
+		}, {
+			Tag: "machine-0",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `"machine-0" is not a valid application tag`)
+	c.Assert(remoteApp.diagnostics, jc.DeepEquals, &crossmodel.RemoteApplicationDiagnostics{
+		LastRemoteEvent:  &lastEvent,
+		SettingsSyncLag:  time.Second,
+		IngressAddresses: []string{"10.0.0.1"},
+		Updated:          lastEvent,
+	})
+}
+
 func (s *remoteRelationsSuite) TestUpdateControllersForModels(c *gc.C) {
 	mod1 := utils.MustNewUUID().String()
 	c1 := names.NewControllerTag(utils.MustNewUUID().String())
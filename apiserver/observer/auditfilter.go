@@ -113,10 +113,13 @@ func (l *bufferedLog) flush() error {
 // interesting if it's a call to a method that isn't listed. If one of
 // the entries is "ReadOnlyMethods", any method matching the fixed
 // list of read-only methods below will also be considered
-// uninteresting.
+// uninteresting. Methods in the always-audited list can't be excluded.
 func MakeInterestingRequestFilter(excludeMethods set.Strings) func(auditlog.Request) bool {
 	return func(req auditlog.Request) bool {
 		methodName := fmt.Sprintf("%s.%s", req.Facade, req.Method)
+		if alwaysAuditedMethods.Contains(methodName) {
+			return true
+		}
 		if excludeMethods.Contains(methodName) {
 			return false
 		}
@@ -127,6 +130,13 @@ func MakeInterestingRequestFilter(excludeMethods set.Strings) func(auditlog.Requ
 	}
 }
 
+// alwaysAuditedMethods are recorded in the audit log regardless of
+// the configured exclusions.
+var alwaysAuditedMethods = set.NewStrings(
+	"Controller.EnableMaintenanceMode",
+	"Controller.DisableMaintenanceMode",
+)
+
 var readonlyMethods = set.NewStrings(
 	// Collected by running read-only commands.
 	"Action.Actions",
@@ -95,3 +95,13 @@ func (s *auditFilterSuite) TestOnlyExcludeReadonlyMethodsIfWeShould(c *gc.C) {
 	// Doesn't allow the readonly methods unless they've included the special key.
 	c.Assert(f1(auditlog.Request{Facade: "Client", Method: "FullStatus"}), jc.IsTrue)
 }
+
+func (s *auditFilterSuite) TestMaintenanceModeMethodsAlwaysInteresting(c *gc.C) {
+	f1 := observer.MakeInterestingRequestFilter(set.NewStrings(
+		"ReadOnlyMethods",
+		"Controller.EnableMaintenanceMode",
+		"Controller.DisableMaintenanceMode",
+	))
+	c.Assert(f1(auditlog.Request{Facade: "Controller", Method: "EnableMaintenanceMode"}), jc.IsTrue)
+	c.Assert(f1(auditlog.Request{Facade: "Controller", Method: "DisableMaintenanceMode"}), jc.IsTrue)
+}
@@ -171,6 +171,7 @@ const (
 	CodeAlreadyExists             = "already exists"
 	CodeUpgradeInProgress         = "upgrade in progress"
 	CodeMigrationInProgress       = "model migration in progress"
+	CodeMaintenanceMode           = "controller in maintenance mode"
 	CodeActionNotAvailable        = "action no longer available"
 	CodeOperationBlocked          = "operation is blocked"
 	CodeLeadershipClaimDenied     = "leadership claim denied"
@@ -320,6 +321,10 @@ func IsCodeUpgradeInProgress(err error) bool {
 	return ErrCode(err) == CodeUpgradeInProgress
 }
 
+func IsCodeMaintenanceMode(err error) bool {
+	return ErrCode(err) == CodeMaintenanceMode
+}
+
 func IsCodeOperationBlocked(err error) bool {
 	return ErrCode(err) == CodeOperationBlocked
 }
@@ -473,11 +473,55 @@ type UnitResult struct {
 	Leader          bool                   `json:"leader,omitempty"`
 	RelationData    []EndpointRelationData `json:"relation-data,omitempty"`
 
+	// ResourceLimits holds the resources the unit's hooks may use,
+	// for IAAS models.
+	ResourceLimits *ResourceLimits `json:"resource-limits,omitempty"`
+
 	// The following are for CAAS models.
 	ProviderId string `json:"provider-id,omitempty"`
 	Address    string `json:"address,omitempty"`
 }
 
+// ResourceLimits holds the resources a unit's hooks may use. A zero
+// value means that resource is not limited.
+type ResourceLimits struct {
+	MemoryMB  uint64 `json:"memory-mb,omitempty"`
+	CPUShares uint64 `json:"cpu-shares,omitempty"`
+	Tasks     uint64 `json:"tasks,omitempty"`
+}
+
+// ResourceLimitsResult holds a unit's resource limits or an error.
+type ResourceLimitsResult struct {
+	Result ResourceLimits `json:"result"`
+	Error  *Error         `json:"error,omitempty"`
+}
+
+// ResourceLimitsResults holds a slice of ResourceLimitsResult.
+type ResourceLimitsResults struct {
+	Results []ResourceLimitsResult `json:"results"`
+}
+
+// MaintenanceWindow holds an application's maintenance window, during
+// which its units defer non-essential hooks, and when the deferred hooks
+// were last flushed. An empty schedule means there is no window.
+type MaintenanceWindow struct {
+	Schedule string        `json:"schedule,omitempty"`
+	Duration time.Duration `json:"duration,omitempty"`
+	Flushed  time.Time     `json:"flushed,omitempty"`
+}
+
+// MaintenanceWindowResult holds a unit's maintenance window or an
+// error.
+type MaintenanceWindowResult struct {
+	Result MaintenanceWindow `json:"result"`
+	Error  *Error            `json:"error,omitempty"`
+}
+
+// MaintenanceWindowResults holds a slice of MaintenanceWindowResult.
+type MaintenanceWindowResults struct {
+	Results []MaintenanceWindowResult `json:"results"`
+}
+
 // UnitInfoResults holds an unit info result or a retrieval error.
 type UnitInfoResult struct {
 	Result *UnitResult `json:"result,omitempty"`
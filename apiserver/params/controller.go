@@ -100,6 +100,14 @@ type ControllerConfigSet struct {
 	Config map[string]interface{} `json:"config"`
 }
 
+// MaintenanceModeArgs holds the arguments for
+// Controller.EnableMaintenanceMode.
+type MaintenanceModeArgs struct {
+	// Message is shown to users while the controller is in
+	// maintenance mode.
+	Message string `json:"message"`
+}
+
 // ControllerAction is an action that can be performed on a model.
 type ControllerAction string
 
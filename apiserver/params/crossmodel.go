@@ -4,6 +4,8 @@
 package params
 
 import (
+	"time"
+
 	"github.com/juju/charm/v7"
 	"gopkg.in/macaroon-bakery.v2/bakery"
 	"gopkg.in/macaroon.v2"
@@ -94,16 +96,38 @@ type ApplicationOfferAdminDetails struct {
 	ApplicationName string            `json:"application-name"`
 	CharmURL        string            `json:"charm-url"`
 	Connections     []OfferConnection `json:"connections,omitempty"`
+	Limits          *OfferLimits      `json:"limits,omitempty"`
+}
+
+// OfferLimits restrict how an offer may be consumed.
+// Zero values mean no limit.
+type OfferLimits struct {
+	MaxConnections     int      `json:"max-connections,omitempty"`
+	MaxRelations       int      `json:"max-relations,omitempty"`
+	AllowedControllers []string `json:"allowed-controllers,omitempty"`
+}
+
+// SetOfferLimitsArgs holds the parameters for setting the limits on
+// how offers may be consumed.
+type SetOfferLimitsArgs struct {
+	Args []SetOfferLimitsArg `json:"args"`
+}
+
+// SetOfferLimitsArg holds the limits to set on an offer.
+type SetOfferLimitsArg struct {
+	OfferURL string      `json:"offer-url"`
+	Limits   OfferLimits `json:"limits"`
 }
 
 // OfferConnection holds details about a connection to an offer.
 type OfferConnection struct {
-	SourceModelTag string       `json:"source-model-tag"`
-	RelationId     int          `json:"relation-id"`
-	Username       string       `json:"username"`
-	Endpoint       string       `json:"endpoint"`
-	Status         EntityStatus `json:"status"`
-	IngressSubnets []string     `json:"ingress-subnets"`
+	SourceModelTag string                        `json:"source-model-tag"`
+	RelationId     int                           `json:"relation-id"`
+	Username       string                        `json:"username"`
+	Endpoint       string                        `json:"endpoint"`
+	Status         EntityStatus                  `json:"status"`
+	IngressSubnets []string                      `json:"ingress-subnets"`
+	Diagnostics    *RemoteApplicationDiagnostics `json:"diagnostics,omitempty"`
 }
 
 // QueryApplicationOffersResults is a result of searching application offers.
@@ -124,6 +148,7 @@ type AddApplicationOffer struct {
 	ApplicationName        string            `json:"application-name"`
 	ApplicationDescription string            `json:"application-description"`
 	Endpoints              map[string]string `json:"endpoints"`
+	Limits                 *OfferLimits      `json:"limits,omitempty"`
 }
 
 // DestroyApplicationOffers holds parameters for the DestroyOffers call.
@@ -172,6 +197,11 @@ type OfferURLs struct {
 
 	// BakeryVersion is the version of the bakery used to mint macaroons.
 	BakeryVersion bakery.Version `json:"bakery-version,omitempty"`
+
+	// ConsumingControllerTag is the tag of the controller hosting the
+	// model which will consume the offers, if known. It is declared by
+	// the macaroons returned by GetConsumeDetails.
+	ConsumingControllerTag string `json:"consuming-controller-tag,omitempty"`
 }
 
 // ConsumeApplicationArg holds the arguments for consuming a remote application.
@@ -516,6 +546,10 @@ type RegisterRemoteRelationArg struct {
 
 	// BakeryVersion is the version of the bakery used to mint macaroons.
 	BakeryVersion bakery.Version `json:"bakery-version,omitempty"`
+
+	// SourceControllerTag is the tag of the controller hosting
+	// the application.
+	SourceControllerTag string `json:"source-controller-tag,omitempty"`
 }
 
 // RegisterRemoteRelationArgs holds args used to add remote relations.
@@ -523,6 +557,32 @@ type RegisterRemoteRelationArgs struct {
 	Relations []RegisterRemoteRelationArg `json:"relations"`
 }
 
+// CheckOfferConnectionArgs holds args used to check whether
+// relations may be made to offers.
+type CheckOfferConnectionArgs struct {
+	Args []CheckOfferConnectionArg `json:"args"`
+}
+
+// CheckOfferConnectionArg holds the details of a model
+// wanting to relate to an offer.
+type CheckOfferConnectionArg struct {
+	// OfferUUID is the UUID of the offer.
+	OfferUUID string `json:"offer-uuid"`
+
+	// SourceModelTag is the tag of the consuming model.
+	SourceModelTag string `json:"source-model-tag"`
+
+	// SourceControllerTag is the tag of the controller
+	// hosting the consuming model.
+	SourceControllerTag string `json:"source-controller-tag"`
+
+	// Macaroons are used for authentication.
+	Macaroons macaroon.Slice `json:"macaroons,omitempty"`
+
+	// BakeryVersion is the version of the bakery used to mint macaroons.
+	BakeryVersion bakery.Version `json:"bakery-version,omitempty"`
+}
+
 // RegisterRemoteRelationResult holds a remote relation details and an error.
 type RegisterRemoteRelationResult struct {
 	Result *RemoteRelationDetails `json:"result,omitempty"`
@@ -613,6 +673,69 @@ type ConsumeOfferDetailsResults struct {
 	Results []ConsumeOfferDetailsResult `json:"results,omitempty"`
 }
 
+// CreateOfferInvitationArgs holds the arguments for creating
+// invitations to consume application offers.
+type CreateOfferInvitationArgs struct {
+	Args []CreateOfferInvitationArg `json:"args"`
+
+	// BakeryVersion is the version of the bakery used to mint macaroons.
+	BakeryVersion bakery.Version `json:"bakery-version,omitempty"`
+}
+
+// CreateOfferInvitationArg holds the arguments for creating
+// an invitation to consume an application offer.
+type CreateOfferInvitationArg struct {
+	// OfferURL is the URL of the offer.
+	OfferURL string `json:"offer-url"`
+
+	// Expiry is how long the invitation can be redeemed for.
+	Expiry time.Duration `json:"expiry"`
+}
+
+// OfferInvitation contains the details necessary to redeem
+// an invitation to consume an application offer.
+type OfferInvitation struct {
+	Offer          *ApplicationOfferDetails `json:"offer"`
+	Macaroon       *macaroon.Macaroon       `json:"macaroon"`
+	ControllerInfo *ExternalControllerInfo  `json:"external-controller"`
+	Expires        time.Time                `json:"expires"`
+}
+
+// OfferInvitationResult contains an offer invitation or an error.
+type OfferInvitationResult struct {
+	Result *OfferInvitation `json:"result,omitempty"`
+	Error  *Error           `json:"error,omitempty"`
+}
+
+// OfferInvitationResults represents the result of a
+// CreateOfferInvitations call.
+type OfferInvitationResults struct {
+	Results []OfferInvitationResult `json:"results"`
+}
+
+// RedeemOfferInvitationArgs holds the arguments for redeeming
+// invitations to consume application offers.
+type RedeemOfferInvitationArgs struct {
+	Args []RedeemOfferInvitationArg `json:"args"`
+}
+
+// RedeemOfferInvitationArg holds the arguments for redeeming
+// an invitation to consume an application offer.
+type RedeemOfferInvitationArg struct {
+	// SourceModelTag is the tag of the model consuming the offer.
+	SourceModelTag string `json:"source-model-tag"`
+
+	// SourceControllerTag is the tag of the controller hosting the
+	// model consuming the offer.
+	SourceControllerTag string `json:"source-controller-tag,omitempty"`
+
+	// Macaroons are the macaroons from the invitation.
+	Macaroons macaroon.Slice `json:"macaroons"`
+
+	// BakeryVersion is the version of the bakery used to mint macaroons.
+	BakeryVersion bakery.Version `json:"bakery-version,omitempty"`
+}
+
 // RemoteEntities identifies multiple remote entities.
 type RemoteEntities struct {
 	Tokens []string `json:"tokens"`
@@ -662,6 +785,68 @@ type UpdateControllersForModelsParams struct {
 	Changes []UpdateControllerForModel `json:"changes"`
 }
 
+// RemoteApplicationDiagnostics holds diagnostic information about the
+// cross model relation traffic for a remote application.
+type RemoteApplicationDiagnostics struct {
+	// LastRemoteEvent is when an event from the remote model was
+	// last processed successfully.
+	LastRemoteEvent *time.Time `json:"last-remote-event,omitempty"`
+
+	// MacaroonExpiry is when the earliest expiring macaroon used
+	// to talk to the remote model expires.
+	MacaroonExpiry *time.Time `json:"macaroon-expiry,omitempty"`
+
+	// SettingsSyncLag is how long the most recent relation settings
+	// change took to be applied to the other model.
+	SettingsSyncLag time.Duration `json:"settings-sync-lag"`
+
+	// IngressAddresses are the ingress addresses advertised by the
+	// remote units participating in relations.
+	IngressAddresses []string `json:"ingress-addresses,omitempty"`
+
+	// Updated is when the diagnostics were last recorded.
+	Updated time.Time `json:"updated"`
+}
+
+// SetRemoteApplicationDiagnosticsArg holds the diagnostics to record
+// for a remote application.
+type SetRemoteApplicationDiagnosticsArg struct {
+	Tag         string                       `json:"tag"`
+	Diagnostics RemoteApplicationDiagnostics `json:"diagnostics"`
+}
+
+// SetRemoteApplicationsDiagnosticsArgs holds the diagnostics to record
+// for a set of remote applications.
+type SetRemoteApplicationsDiagnosticsArgs struct {
+	Args []SetRemoteApplicationDiagnosticsArg `json:"args"`
+}
+
+// ConsumedApplicationInfo holds the details of an application consumed
+// from an offer, as seen from the consuming model.
+type ConsumedApplicationInfo struct {
+	Name           string                        `json:"name"`
+	OfferURL       string                        `json:"offer-url,omitempty"`
+	OfferUUID      string                        `json:"offer-uuid"`
+	SourceModelTag string                        `json:"source-model-tag"`
+	Life           life.Value                    `json:"life"`
+	Status         EntityStatus                  `json:"status"`
+	Endpoints      []RemoteEndpoint              `json:"endpoints"`
+	Diagnostics    *RemoteApplicationDiagnostics `json:"diagnostics,omitempty"`
+}
+
+// ConsumedApplicationInfoResult holds the details of a consumed
+// application, or an error.
+type ConsumedApplicationInfoResult struct {
+	Result *ConsumedApplicationInfo `json:"result,omitempty"`
+	Error  *Error                   `json:"error,omitempty"`
+}
+
+// ConsumedApplicationInfoResults holds a set of consumed application
+// results.
+type ConsumedApplicationInfoResults struct {
+	Results []ConsumedApplicationInfoResult `json:"results"`
+}
+
 // OfferAction is an action that can be performed on an offer.
 type OfferAction string
 
@@ -0,0 +1,31 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"time"
+)
+
+// HealthCheck holds the latest result of a workload health check
+// reported by a unit.
+type HealthCheck struct {
+	Name    string     `json:"name"`
+	Status  string     `json:"status"`
+	Message string     `json:"message,omitempty"`
+	Since   *time.Time `json:"since,omitempty"`
+}
+
+// SetHealthCheckArgs holds the args for recording unit health checks.
+type SetHealthCheckArgs struct {
+	Args []SetHealthCheckArg `json:"args"`
+}
+
+// SetHealthCheckArg holds the result of a single health check to
+// record for a unit.
+type SetHealthCheckArg struct {
+	Tag     string `json:"tag"`
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
@@ -20,6 +20,8 @@ type KubernetesProvisioningInfo struct {
 	DeploymentInfo       *KubernetesDeploymentInfo    `json:"deployment-info,omitempty"`
 	PodSpec              string                       `json:"pod-spec"`
 	RawK8sSpec           string                       `json:"raw-k8s-spec,omitempty"`
+	HelmChart            []byte                       `json:"helm-chart,omitempty"`
+	HelmValues           map[string]interface{}       `json:"helm-values,omitempty"`
 	Constraints          constraints.Value            `json:"constraints"`
 	Tags                 map[string]string            `json:"tags,omitempty"`
 	Filesystems          []KubernetesFilesystemParams `json:"filesystems,omitempty"`
@@ -25,6 +25,15 @@ type InitiateMigrationArgs struct {
 type MigrationSpec struct {
 	ModelTag   string              `json:"model-tag"`
 	TargetInfo MigrationTargetInfo `json:"target-info"`
+
+	// KeepSource holds how long the model is kept, dormant, on the
+	// source controller after a successful migration so that the
+	// migration can be reverted.
+	KeepSource time.Duration `json:"keep-source,omitempty"`
+
+	// Revert is true if the model is being moved back to the
+	// controller it was migrated from, where it has been kept.
+	Revert bool `json:"revert,omitempty"`
 }
 
 // MigrationTargetInfo holds the details required to connect to and
@@ -74,6 +83,40 @@ type SerializedModel struct {
 	Resources []SerializedModelResource `json:"resources"`
 }
 
+// SerializedModelResults holds the results of exporting a number of
+// models.
+type SerializedModelResults struct {
+	Results []SerializedModelResult `json:"results"`
+}
+
+// SerializedModelResult holds a single exported model or an error.
+type SerializedModelResult struct {
+	Result *SerializedModel `json:"result,omitempty"`
+	Error  *Error           `json:"error,omitempty"`
+}
+
+// ModelMigrationReports holds the most recent migration of each model
+// which has been migrated, or has had a migration attempted, from a
+// controller.
+type ModelMigrationReports struct {
+	Migrations []ModelMigrationReport `json:"migrations"`
+}
+
+// ModelMigrationReport describes the progress or outcome of a model
+// migration.
+type ModelMigrationReport struct {
+	MigrationId      string     `json:"migration-id"`
+	ModelTag         string     `json:"model-tag"`
+	ModelName        string     `json:"model-name"`
+	TargetController string     `json:"target-controller"`
+	Phase            string     `json:"phase"`
+	FailedPhase      string     `json:"failed-phase,omitempty"`
+	StatusMessage    string     `json:"status-message"`
+	Start            time.Time  `json:"start"`
+	PhaseChanged     time.Time  `json:"phase-changed"`
+	End              *time.Time `json:"end,omitempty"`
+}
+
 // SerializedModelTools holds the version and URI for a given tools
 // version.
 type SerializedModelTools struct {
@@ -133,6 +176,40 @@ type MigrationModelInfo struct {
 	OwnerTag               string         `json:"owner-tag"`
 	AgentVersion           version.Number `json:"agent-version"`
 	ControllerAgentVersion version.Number `json:"controller-agent-version"`
+	CloudName              string         `json:"cloud-name,omitempty"`
+	CloudRegion            string         `json:"cloud-region,omitempty"`
+}
+
+// RevertPrechecksArgs holds the details of a model being moved back to
+// the controller it was migrated away from.
+type RevertPrechecksArgs struct {
+	Model MigrationModelInfo `json:"model"`
+
+	// Fingerprint summarises the model's content, so the copy kept by
+	// the controller can be checked against it.
+	Fingerprint string `json:"fingerprint"`
+}
+
+// MigrationPrecheckReport holds every problem found by a complete
+// run of migration prechecks.
+type MigrationPrecheckReport struct {
+	Blockers []string `json:"blockers,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// MigrationPrecheckResults holds the results of running migration
+// prechecks for a number of models.
+type MigrationPrecheckResults struct {
+	Results []MigrationPrecheckResult `json:"results"`
+}
+
+// MigrationPrecheckResult holds the problems found by the source and
+// target controllers when checking whether a model could be migrated.
+type MigrationPrecheckResult struct {
+	ModelTag string                  `json:"model-tag"`
+	Source   MigrationPrecheckReport `json:"source"`
+	Target   MigrationPrecheckReport `json:"target"`
+	Error    *Error                  `json:"error,omitempty"`
 }
 
 // MigrationStatus reports the current status of a model migration.
@@ -271,8 +271,9 @@ type UnitInfo struct {
 	Principal      string      `json:"principal"`
 	Subordinate    bool        `json:"subordinate"`
 	// Workload and agent state are modelled separately.
-	WorkloadStatus StatusInfo `json:"workload-status"`
-	AgentStatus    StatusInfo `json:"agent-status"`
+	WorkloadStatus StatusInfo    `json:"workload-status"`
+	AgentStatus    StatusInfo    `json:"agent-status"`
+	HealthChecks   []HealthCheck `json:"health-checks,omitempty"`
 }
 
 // EntityId returns a unique identifier for a unit across
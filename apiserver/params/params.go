@@ -384,6 +384,14 @@ type UnitsResolved struct {
 	Tags  Entities `json:"tags,omitempty"`
 	Retry bool     `json:"retry,omitempty"`
 	All   bool     `json:"all,omitempty"`
+
+	// Applications resolves the units in error of the named
+	// applications.
+	Applications []string `json:"applications,omitempty"`
+
+	// Hooks, if set, restricts the units resolved to those in error
+	// in one of the named hooks.
+	Hooks []string `json:"hooks,omitempty"`
 }
 
 // AddApplicationUnitsResults holds the names of the units added by the
@@ -15,6 +15,10 @@ type RetryStrategy struct {
 	MaxRetryTime    time.Duration `json:"max-retry-time"`
 	JitterRetryTime bool          `json:"jitter-retry-time"`
 	RetryTimeFactor int64         `json:"retry-time-factor"`
+
+	// MaxRetryAttempts is the most times a failed hook is retried
+	// before the unit is left in error. Zero means no limit.
+	MaxRetryAttempts int `json:"max-retry-attempts,omitempty"`
 }
 
 // RetryStrategyResult holds a RetryStrategy or an error.
@@ -0,0 +1,141 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"time"
+)
+
+// CreateSecretArgs holds the args for creating secrets.
+type CreateSecretArgs struct {
+	Args []CreateSecretArg `json:"args"`
+}
+
+// CreateSecretArg holds the args for creating a secret owned by
+// the application of the calling unit.
+type CreateSecretArg struct {
+	UnitTag      string            `json:"unit-tag"`
+	RotatePolicy string            `json:"rotate-policy,omitempty"`
+	ExpireTime   *time.Time        `json:"expire-time,omitempty"`
+	Description  string            `json:"description,omitempty"`
+	Label        string            `json:"label,omitempty"`
+	Data         map[string]string `json:"data"`
+}
+
+// UpdateSecretArgs holds the args for updating secrets.
+type UpdateSecretArgs struct {
+	Args []UpdateSecretArg `json:"args"`
+}
+
+// UpdateSecretArg holds the args for updating a secret. Nil fields
+// are left unchanged; non empty data adds a new revision.
+type UpdateSecretArg struct {
+	UnitTag      string            `json:"unit-tag"`
+	URI          string            `json:"uri"`
+	RotatePolicy *string           `json:"rotate-policy,omitempty"`
+	ExpireTime   *time.Time        `json:"expire-time,omitempty"`
+	Description  *string           `json:"description,omitempty"`
+	Label        *string           `json:"label,omitempty"`
+	Data         map[string]string `json:"data,omitempty"`
+}
+
+// GetSecretValueArgs holds the args for getting secret values.
+type GetSecretValueArgs struct {
+	Args []GetSecretValueArg `json:"args"`
+}
+
+// GetSecretValueArg holds the args for getting the latest value
+// of a secret on behalf of a unit.
+type GetSecretValueArg struct {
+	UnitTag string `json:"unit-tag"`
+	URI     string `json:"uri"`
+}
+
+// SecretValueResult holds a secret value or an error.
+type SecretValueResult struct {
+	Data  map[string]string `json:"data,omitempty"`
+	Error *Error            `json:"error,omitempty"`
+}
+
+// SecretValueResults holds secret value results.
+type SecretValueResults struct {
+	Results []SecretValueResult `json:"results"`
+}
+
+// GrantRevokeSecretArgs holds the args for changing access to secrets.
+type GrantRevokeSecretArgs struct {
+	Args []GrantRevokeSecretArg `json:"args"`
+}
+
+// GrantRevokeSecretArg holds the args for changing access to a secret
+// owned by the application of the calling unit.
+type GrantRevokeSecretArg struct {
+	UnitTag     string   `json:"unit-tag"`
+	URI         string   `json:"uri"`
+	SubjectTags []string `json:"subject-tags"`
+	Role        string   `json:"role,omitempty"`
+}
+
+// SecretRotationInfo holds when a secret is due to be rotated.
+type SecretRotationInfo struct {
+	URI            string     `json:"uri"`
+	RotatePolicy   string     `json:"rotate-policy"`
+	NextRotateTime *time.Time `json:"next-rotate-time,omitempty"`
+}
+
+// SecretRotationInfoResult holds the rotation info for a secret
+// or an error.
+type SecretRotationInfoResult struct {
+	Result *SecretRotationInfo `json:"result,omitempty"`
+	Error  *Error              `json:"error,omitempty"`
+}
+
+// SecretRotationInfoResults holds secret rotation info results.
+type SecretRotationInfoResults struct {
+	Results []SecretRotationInfoResult `json:"results"`
+}
+
+// SecretRotatedArgs holds the args for recording secret rotations.
+type SecretRotatedArgs struct {
+	Args []SecretRotatedArg `json:"args"`
+}
+
+// SecretRotatedArg records that a unit rotated a secret at the
+// given time.
+type SecretRotatedArg struct {
+	UnitTag string    `json:"unit-tag"`
+	URI     string    `json:"uri"`
+	When    time.Time `json:"when"`
+}
+
+// SecretURIArgs holds the URIs of secrets.
+type SecretURIArgs struct {
+	URIs []string `json:"uris"`
+}
+
+// ListSecretsArgs holds the args for listing secrets.
+type ListSecretsArgs struct {
+	ShowSecrets bool `json:"show-secrets"`
+}
+
+// ListSecretResult holds the metadata, and optionally the latest value,
+// of a secret.
+type ListSecretResult struct {
+	URI              string             `json:"uri"`
+	OwnerTag         string             `json:"owner-tag"`
+	Description      string             `json:"description,omitempty"`
+	Label            string             `json:"label,omitempty"`
+	LatestRevision   int                `json:"latest-revision"`
+	LatestExpireTime *time.Time         `json:"latest-expire-time,omitempty"`
+	RotatePolicy     string             `json:"rotate-policy"`
+	NextRotateTime   *time.Time         `json:"next-rotate-time,omitempty"`
+	CreateTime       time.Time          `json:"create-time"`
+	UpdateTime       time.Time          `json:"update-time"`
+	Value            *SecretValueResult `json:"value,omitempty"`
+}
+
+// ListSecretResults holds secret metadata results.
+type ListSecretResults struct {
+	Results []ListSecretResult `json:"results"`
+}
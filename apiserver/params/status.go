@@ -32,6 +32,12 @@ type FullStatus struct {
 	Relations           []RelationStatus                   `json:"relations"`
 	ControllerTimestamp *time.Time                         `json:"controller-timestamp"`
 	Branches            map[string]BranchStatus            `json:"branches"`
+
+	// ControllerMaintenance is true when the controller is in
+	// maintenance mode, and ControllerMaintenanceMessage holds the
+	// reason given when it was enabled.
+	ControllerMaintenance        bool   `json:"controller-maintenance,omitempty"`
+	ControllerMaintenanceMessage string `json:"controller-maintenance-message,omitempty"`
 }
 
 // IsEmpty checks all collections on FullStatus to determine if the status is empty.
@@ -219,6 +225,10 @@ type UnitStatus struct {
 	Subordinates  map[string]UnitStatus `json:"subordinates"`
 	Leader        bool                  `json:"leader,omitempty"`
 
+	// HealthChecks holds the workload health checks reported by
+	// the unit's charm, sorted by name.
+	HealthChecks []HealthCheck `json:"health-checks,omitempty"`
+
 	// The following are for CAAS models.
 	ProviderId string `json:"provider-id,omitempty"`
 	Address    string `json:"address,omitempty"`
@@ -305,6 +315,66 @@ type StatusHistoryPruneArgs struct {
 	MaxHistoryMB   int           `json:"max-history-mb"`
 }
 
+// HookExecution holds the timing and outcome of a single run of a
+// hook on a unit.
+type HookExecution struct {
+	Hook     string    `json:"hook"`
+	Started  time.Time `json:"started"`
+	Finished time.Time `json:"finished"`
+	Result   string    `json:"result"`
+	Message  string    `json:"message,omitempty"`
+}
+
+// HookExecutionArg holds a hook execution to record for a unit.
+type HookExecutionArg struct {
+	Tag       string        `json:"tag"`
+	Execution HookExecution `json:"execution"`
+}
+
+// HookExecutionArgs holds hook executions to record.
+type HookExecutionArgs struct {
+	Args []HookExecutionArg `json:"args"`
+}
+
+// HookHistoryResult holds the hook executions recorded for a unit,
+// most recent first, or an error.
+type HookHistoryResult struct {
+	Executions []HookExecution `json:"executions"`
+	Error      *Error          `json:"error,omitempty"`
+}
+
+// HookHistoryResults holds a slice of HookHistoryResult.
+type HookHistoryResults struct {
+	Results []HookHistoryResult `json:"results"`
+}
+
+// UnitTraceArg sets until when the hook tool calls made by a unit's
+// charm are traced. A zero Until stops tracing. Only the size of each
+// tool's output is traced unless IncludeOutput is set.
+type UnitTraceArg struct {
+	Tag           string    `json:"tag"`
+	Until         time.Time `json:"until"`
+	IncludeOutput bool      `json:"include-output,omitempty"`
+}
+
+// UnitTraceArgs holds the traces to set.
+type UnitTraceArgs struct {
+	Args []UnitTraceArg `json:"args"`
+}
+
+// UnitTraceResult holds until when a unit is traced, or an error.
+// A zero Until means the unit is not traced.
+type UnitTraceResult struct {
+	Until         time.Time `json:"until"`
+	IncludeOutput bool      `json:"include-output,omitempty"`
+	Error         *Error    `json:"error,omitempty"`
+}
+
+// UnitTraceResults holds a slice of UnitTraceResult.
+type UnitTraceResults struct {
+	Results []UnitTraceResult `json:"results"`
+}
+
 // StatusResult holds an entity status, extra information, or an
 // error.
 type StatusResult struct {
@@ -98,6 +98,7 @@ type StorageAttachment struct {
 
 	Kind     StorageKind `json:"kind"`
 	Location string      `json:"location"`
+	Size     uint64      `json:"size,omitempty"`
 	Life     life.Value  `json:"life"`
 }
 
@@ -369,6 +370,27 @@ type VolumeAttachmentParamsResults struct {
 	Results []VolumeAttachmentParamsResult `json:"results,omitempty"`
 }
 
+// VolumeResizeParams holds the parameters for resizing a provisioned
+// storage volume.
+type VolumeResizeParams struct {
+	VolumeTag string `json:"volume-tag"`
+	VolumeId  string `json:"volume-id"`
+	Pool      string `json:"pool"`
+	Provider  string `json:"provider"`
+	Size      uint64 `json:"size"`
+}
+
+// VolumeResizeParamsResult holds resize parameters for a volume.
+type VolumeResizeParamsResult struct {
+	Result VolumeResizeParams `json:"result"`
+	Error  *Error             `json:"error,omitempty"`
+}
+
+// VolumeResizeParamsResults holds resize parameters for multiple volumes.
+type VolumeResizeParamsResults struct {
+	Results []VolumeResizeParamsResult `json:"results,omitempty"`
+}
+
 // Filesystem identifies and describes a storage filesystem in the model.
 type Filesystem struct {
 	FilesystemTag string         `json:"filesystem-tag"`
@@ -644,6 +666,40 @@ type StoragePoolsResults struct {
 	Results []StoragePoolsResult `json:"results,omitempty"`
 }
 
+// StoragePoolUsageArgs holds the names of the storage pools
+// for which to report usage.
+type StoragePoolUsageArgs struct {
+	Pools []string `json:"pools"`
+}
+
+// StorageAllocation holds the number and total size, in MiB,
+// of the volumes and filesystems allocated from a storage pool.
+type StorageAllocation struct {
+	Count int    `json:"count"`
+	Size  uint64 `json:"size"`
+}
+
+// StoragePoolUsage holds the storage allocated from a storage pool,
+// along with the pool's limits.
+type StoragePoolUsage struct {
+	Name         string                       `json:"name"`
+	Total        StorageAllocation            `json:"total"`
+	Applications map[string]StorageAllocation `json:"applications,omitempty"`
+	MaxSize      uint64                       `json:"max-size,omitempty"`
+	MaxVolumes   int                          `json:"max-volumes,omitempty"`
+}
+
+// StoragePoolUsageResult holds the usage of a storage pool, or an error.
+type StoragePoolUsageResult struct {
+	Result *StoragePoolUsage `json:"result,omitempty"`
+	Error  *Error            `json:"error,omitempty"`
+}
+
+// StoragePoolUsageResults holds a collection of storage pool usage results.
+type StoragePoolUsageResults struct {
+	Results []StoragePoolUsageResult `json:"results"`
+}
+
 // VolumeFilter holds a filter for volume list API call.
 type VolumeFilter struct {
 	// Machines are machine tags to filter on.
@@ -951,3 +1007,19 @@ type AddStorageDetails struct {
 	// of the added storage instances.
 	StorageTags []string `json:"storage-tags"`
 }
+
+// ResizeStorageParams holds the parameters for growing a storage instance.
+type ResizeStorageParams struct {
+	// StorageTag is the string representation of the tag of the
+	// storage instance to resize.
+	StorageTag string `json:"storage-tag"`
+
+	// Size is the new size of the storage, in MiB.
+	Size uint64 `json:"size"`
+}
+
+// ResizeStorageArgs holds the parameters for growing a collection of
+// storage instances.
+type ResizeStorageArgs struct {
+	Storage []ResizeStorageParams `json:"storage"`
+}
@@ -4,8 +4,10 @@
 package apiserver
 
 import (
+	"bytes"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"mime"
 	"net/http"
 	"path"
@@ -16,6 +18,7 @@ import (
 	"github.com/juju/names/v4"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/caas/kubernetes/provider/helm"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/resource/api"
 	"github.com/juju/juju/state"
@@ -167,14 +170,39 @@ func (h *ResourcesHandler) readResource(backend ResourcesBackend, req *http.Requ
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	data := req.Body
+	if resource.IsHelmChart(res.Meta) {
+		if data, err = validateHelmChart(req.Body); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
 	return &uploadedResource{
 		Application: uReq.Application,
 		PendingID:   uReq.PendingID,
 		Resource:    chRes,
-		Data:        req.Body,
+		Data:        data,
 	}, nil
 }
 
+// validateHelmChart checks that the uploaded chart can be deployed,
+// so that charts using unsupported Helm features are rejected now
+// rather than when the application's units are provisioned. It
+// returns a reader for the chart.
+func validateHelmChart(r io.Reader) (io.ReadCloser, error) {
+	data, err := ioutil.ReadAll(io.LimitReader(r, helm.MaxChartSize+1))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	chart, err := helm.Load(bytes.NewReader(data))
+	if err == nil {
+		err = chart.Validate()
+	}
+	if err != nil {
+		return nil, errors.NewBadRequest(err, "invalid helm chart")
+	}
+	return ioutil.NopCloser(bytes.NewReader(data)), nil
+}
+
 // updateResource returns a copy of the provided resource, updated with
 // the given information.
 func updateResource(res charmresource.Resource, fp charmresource.Fingerprint, size int64) (charmresource.Resource, error) {
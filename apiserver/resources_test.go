@@ -189,6 +189,24 @@ func (s *ResourcesHandlerSuite) TestPutExtensionMismatch(c *gc.C) {
 	s.checkResp(c, http.StatusInternalServerError, "application/json", expected)
 }
 
+func (s *ResourcesHandlerSuite) TestPutInvalidHelmChart(c *gc.C) {
+	content := "<some data>"
+	stored, _ := newResource(c, "spam", "", "")
+	stored.Path = "spam" + resource.HelmChartSuffix
+	s.backend.ReturnGetResource = stored
+
+	req, _ := newUploadRequest(c, "spam", "a-application", content)
+	s.handler.ServeHTTP(s.recorder, req)
+
+	c.Assert(s.recorder.Code, gc.Equals, http.StatusBadRequest)
+	var result params.ErrorResult
+	err := json.NewDecoder(s.recorder.Body).Decode(&result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.NotNil)
+	c.Check(result.Error.Code, gc.Equals, params.CodeBadRequest)
+	c.Check(result.Error.Message, gc.Matches, "invalid helm chart: reading helm chart: .*")
+}
+
 func (s *ResourcesHandlerSuite) TestPutWithPending(c *gc.C) {
 	uploadContent := "<some data>"
 	res, _ := newResource(c, "spam", "a-user", uploadContent)
@@ -0,0 +1,180 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"github.com/juju/collections/set"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// maintenanceModeMethodsOnly returns a check function, for use with
+// restrictRoot, that blocks all but the read-only methods while the
+// controller is in maintenance mode. The maintenance function is
+// called on every method lookup, so connections made before
+// maintenance mode was enabled are restricted too.
+func maintenanceModeMethodsOnly(maintenance func() (string, bool)) func(string, string) error {
+	return func(facadeName, methodName string) error {
+		message, enabled := maintenance()
+		if !enabled || IsMethodAllowedDuringMaintenance(facadeName, methodName) {
+			return nil
+		}
+		return maintenanceModeError(message)
+	}
+}
+
+func maintenanceModeError(message string) error {
+	errMessage := "controller is in maintenance mode, changes are not allowed"
+	if message != "" {
+		errMessage += ": " + message
+	}
+	return &params.Error{
+		Code:    params.CodeMaintenanceMode,
+		Message: errMessage,
+	}
+}
+
+// IsMethodAllowedDuringMaintenance reports whether the method can be
+// called by users while the controller is in maintenance mode.
+func IsMethodAllowedDuringMaintenance(facadeName, methodName string) bool {
+	methods, ok := allowedMethodsDuringMaintenance[facadeName]
+	if !ok {
+		return false
+	}
+	return methods.Contains(methodName)
+}
+
+// allowedMethodsDuringMaintenance stores the read-only api calls that
+// are not blocked for user logins while the controller is in
+// maintenance mode, as well as their respective facade names. It is kept
+// apart from the audit log's read-only methods, but must include all of
+// the client methods that list treats as read-only.
+var allowedMethodsDuringMaintenance = map[string]set.Strings{
+	"Action": set.NewStrings(
+		"Actions",
+		"ApplicationsCharmsActions",
+		"FindActionsByNames",
+		"FindActionTagsByPrefix",
+		"ListAll",
+		"ListPending",
+		"ListRunning",
+		"ListComplete",
+		"ListOperations",
+		"Operations",
+	),
+	"Application": set.NewStrings(
+		"ApplicationsInfo",
+		"CharmConfig",
+		"ConsumedApplicationsInfo",
+		"Get",
+		"GetConstraints",
+		"UnitsHookHistory",
+		"UnitsInfo",
+	),
+	"ApplicationOffers": set.NewStrings(
+		"ApplicationOffers",
+		"FindApplicationOffers",
+		"ListApplicationOffers",
+	),
+	"Backups": set.NewStrings(
+		"Info",
+		"List",
+	),
+	"Block": set.NewStrings(
+		"List",
+	),
+	"Charms": set.NewStrings(
+		"CharmInfo",
+		"List",
+	),
+	"Client": set.NewStrings(
+		"FullStatus", // for "juju status"
+		"GetModelConstraints",
+		"StatusHistory",
+	),
+	"Cloud": set.NewStrings(
+		"Cloud",
+		"CloudInfo",
+		"Clouds",
+		"CredentialContents",
+		"DefaultCloud",
+		"UserCredentials",
+	),
+	"Controller": set.NewStrings(
+		"AllModels",
+		"ControllerConfig",
+		"ControllerVersion",
+		"GetControllerAccess",
+		"IdentityProviderURL",
+		"ModelConfig",
+		"ModelMigrations",
+		"ModelStatus",
+		"MongoVersion",
+		"ListBlockedModels",
+		"MigrationPrechecks",
+		// So that maintenance mode can be ended, and its message
+		// changed.
+		"EnableMaintenanceMode",
+		"DisableMaintenanceMode",
+	),
+	"FirewallRules": set.NewStrings(
+		"ListFirewallRules",
+	),
+	"ImageManager": set.NewStrings(
+		"ListImages",
+	),
+	"ImageMetadata": set.NewStrings(
+		"List",
+	),
+	"KeyManager": set.NewStrings(
+		"ListKeys",
+	),
+	"MetricsDebug": set.NewStrings(
+		"GetMetrics",
+	),
+	"ModelConfig": set.NewStrings(
+		"ModelGet",
+	),
+	"ModelManager": set.NewStrings(
+		"ListModels",
+		"ListModelSummaries",
+		"ModelDefaults",
+		"ModelInfo",
+	),
+	"Payloads": set.NewStrings(
+		"List",
+	),
+	"Pinger": set.NewStrings(
+		"Ping",
+	),
+	"Resources": set.NewStrings(
+		"ListResources",
+	),
+	"Spaces": set.NewStrings(
+		"ListSpaces",
+		"ShowSpace",
+	),
+	"SSHClient": set.NewStrings( // allow all SSH client related calls
+		"PublicAddress",
+		"PrivateAddress",
+		"BestAPIVersion",
+		"AllAddresses",
+		"PublicKeys",
+		"Proxy",
+	),
+	"Storage": set.NewStrings(
+		// for "juju status --storage"
+		"ListFilesystems",
+		"ListPools",
+		"ListStorageDetails",
+		"ListVolumes",
+		"PoolUsage",
+	),
+	"Subnets": set.NewStrings(
+		"ListSubnets",
+	),
+	"UserManager": set.NewStrings(
+		"UserInfo",
+	),
+}
@@ -0,0 +1,94 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"github.com/juju/collections/set"
+	"github.com/juju/rpcreflect"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver"
+	"github.com/juju/juju/apiserver/observer"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/testing"
+)
+
+type restrictMaintenanceSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&restrictMaintenanceSuite{})
+
+func (r *restrictMaintenanceSuite) TestAllowedMethods(c *gc.C) {
+	root := apiserver.TestingMaintenanceModeRoot("upgrading storage")
+	checkAllowed := func(facade, method string, version int) {
+		caller, err := root.FindMethod(facade, version, method)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(caller, gc.NotNil)
+	}
+	checkAllowed("Client", "FullStatus", 1)
+	checkAllowed("ModelManager", "ListModels", 5)
+	checkAllowed("SSHClient", "PublicAddress", 1)
+	checkAllowed("Pinger", "Ping", 1)
+	checkAllowed("Controller", "DisableMaintenanceMode", 14)
+	checkAllowed("Controller", "EnableMaintenanceMode", 14)
+	checkAllowed("Controller", "MigrationPrechecks", 14)
+	checkAllowed("Application", "UnitsInfo", 17)
+	checkAllowed("Application", "UnitsHookHistory", 17)
+	checkAllowed("Storage", "PoolUsage", 8)
+	checkAllowed("Application", "CharmConfig", 17)
+	checkAllowed("ApplicationOffers", "FindApplicationOffers", 4)
+	checkAllowed("Spaces", "ShowSpace", 6)
+	checkAllowed("Cloud", "CredentialContents", 7)
+	checkAllowed("Cloud", "UserCredentials", 7)
+}
+
+func (r *restrictMaintenanceSuite) TestReadOnlyMethodsAllowed(c *gc.C) {
+	// Every client method the audit log treats as read-only must be
+	// allowed. The hook context facades are only used by agents, which
+	// aren't restricted during maintenance.
+	agentFacades := set.NewStrings("PayloadsHookContext", "ResourcesHookContext")
+	readOnly := observer.MakeInterestingRequestFilter(set.NewStrings(controller.ReadOnlyMethodsWildcard))
+	for _, details := range apiserver.AllFacades().ListDetails() {
+		if agentFacades.Contains(details.Name) {
+			continue
+		}
+		for _, method := range rpcreflect.ObjTypeOf(details.Type).MethodNames() {
+			req := auditlog.Request{Facade: details.Name, Method: method}
+			if readOnly(req) {
+				continue
+			}
+			c.Check(
+				apiserver.IsMethodAllowedDuringMaintenance(details.Name, method), jc.IsTrue,
+				gc.Commentf("%s.%s", details.Name, method),
+			)
+		}
+	}
+}
+
+func (r *restrictMaintenanceSuite) TestFindDisallowedMethod(c *gc.C) {
+	root := apiserver.TestingMaintenanceModeRoot("upgrading storage")
+	caller, err := root.FindMethod("Application", 12, "Deploy")
+	c.Assert(err, gc.ErrorMatches, "controller is in maintenance mode, changes are not allowed: upgrading storage")
+	c.Assert(params.IsCodeMaintenanceMode(err), jc.IsTrue)
+	c.Assert(caller, gc.IsNil)
+}
+
+func (r *restrictMaintenanceSuite) TestNoMessage(c *gc.C) {
+	root := apiserver.TestingMaintenanceModeRoot("")
+	_, err := root.FindMethod("Client", 1, "ModelSet")
+	c.Assert(err, gc.ErrorMatches, "controller is in maintenance mode, changes are not allowed")
+}
+
+func (r *restrictMaintenanceSuite) TestNotInMaintenanceMode(c *gc.C) {
+	root := apiserver.TestingRestrictedRoot(apiserver.MaintenanceModeMethodsOnly(func() (string, bool) {
+		return "", false
+	}))
+	caller, err := root.FindMethod("Application", 12, "Deploy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(caller, gc.NotNil)
+}
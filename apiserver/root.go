@@ -240,8 +240,8 @@ func restrictAPIRoot(
 }
 
 // restrictAPIRootDuringMaintenance restricts the API root during
-// maintenance events (upgrade, restore, or migration), depending
-// on the authenticated client.
+// maintenance events (upgrade, restore, migration, or controller
+// maintenance mode), depending on the authenticated client.
 func restrictAPIRootDuringMaintenance(
 	srv *Server,
 	apiRoot rpc.Root,
@@ -281,8 +281,10 @@ func restrictAPIRootDuringMaintenance(
 		return nil, errors.Errorf("%s blocked because upgrade is in progress", describeLogin())
 	}
 
-	// For user logins, we limit access during migrations.
+	// For user logins, we limit access during migrations, and while
+	// the controller is in maintenance mode.
 	if _, ok := authTag.(names.UserTag); ok {
+		apiRoot = restrictRoot(apiRoot, maintenanceModeMethodsOnly(srv.shared.maintenanceMode))
 		switch model.MigrationMode() {
 		case state.MigrationModeImporting:
 			// The user is not able to access a model that is currently being
@@ -147,6 +147,14 @@ func (c *sharedServerContext) featureEnabled(flag string) bool {
 	return c.features.Contains(flag)
 }
 
+// maintenanceMode returns the maintenance message, and whether the
+// controller is in maintenance mode.
+func (c *sharedServerContext) maintenanceMode() (string, bool) {
+	c.configMutex.RLock()
+	defer c.configMutex.RUnlock()
+	return c.controllerConfig.MaintenanceMessage(), c.controllerConfig.MaintenanceMode()
+}
+
 func (c *sharedServerContext) maxDebugLogDuration() time.Duration {
 	c.configMutex.RLock()
 	defer c.configMutex.RUnlock()
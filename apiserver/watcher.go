@@ -340,6 +340,7 @@ func (aw *SrvAllWatcher) translateUnit(info multiwatcher.EntityInfo) params.Enti
 		Subordinate:    orig.Subordinate,
 		WorkloadStatus: aw.translateStatus(orig.WorkloadStatus),
 		AgentStatus:    aw.translateStatus(orig.AgentStatus),
+		HealthChecks:   common.HealthChecksToParams(orig.HealthChecks),
 	}
 }
 
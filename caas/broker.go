@@ -133,6 +133,9 @@ type ServiceParams struct {
 	// RawK8sSpec is the raw spec used to to apply to the cluster.
 	RawK8sSpec string
 
+	// HelmChart is the Helm chart to render and apply to the cluster.
+	HelmChart *HelmChartParams
+
 	// ResourceTags is a set of tags to set on the created service.
 	ResourceTags map[string]string
 
@@ -153,6 +156,17 @@ type ServiceParams struct {
 	CharmModifiedVersion int
 }
 
+// HelmChartParams holds a Helm chart to deploy and the values used to
+// render it.
+type HelmChartParams struct {
+	// Archive is the packaged chart.
+	Archive []byte
+
+	// Values are merged over the chart's default values when
+	// rendering. They are built from the charm config.
+	Values map[string]interface{}
+}
+
 // OperatorState is returned by the OperatorExists call.
 type OperatorState struct {
 	// Exists is true if the operator exists in the cluster.
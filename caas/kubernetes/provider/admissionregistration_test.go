@@ -108,6 +108,10 @@ func (s *K8sBrokerSuite) assertMutatingWebhookConfigurations(c *gc.C, cfgs []k8s
 			Return(statefulSetArg, nil),
 		s.mockStatefulSets.EXPECT().Update(gomock.Any(), statefulSetArg, metav1.UpdateOptions{}).
 			Return(nil, nil),
+		s.mockHorizontalPodAutoscalers.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(metav1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+		s.mockPodDisruptionBudgets.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(metav1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	}...)
 	gomock.InOrder(assertCalls...)
 
@@ -399,6 +403,10 @@ func (s *K8sBrokerSuite) assertValidatingWebhookConfigurations(c *gc.C, cfgs []k
 			Return(statefulSetArg, nil),
 		s.mockStatefulSets.EXPECT().Create(gomock.Any(), statefulSetArg, metav1.CreateOptions{}).
 			Return(nil, nil),
+		s.mockHorizontalPodAutoscalers.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(metav1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+		s.mockPodDisruptionBudgets.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(metav1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	}...)
 	gomock.InOrder(assertCalls...)
 
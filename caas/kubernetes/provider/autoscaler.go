@@ -0,0 +1,184 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/juju/errors"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider/constants"
+	"github.com/juju/juju/caas/kubernetes/provider/utils"
+	k8sannotations "github.com/juju/juju/core/annotations"
+	"github.com/juju/juju/core/application"
+)
+
+// autoscaleParams holds the autoscaling settings from the application config.
+type autoscaleParams struct {
+	min, max int
+	cpu      int
+}
+
+// getAutoscaleParams returns the autoscaling settings for an application,
+// or nil if autoscaling is not enabled. Autoscaling is enabled by setting
+// the maximum number of pods.
+func getAutoscaleParams(config application.ConfigAttributes) (*autoscaleParams, error) {
+	max := config.GetInt(AutoscaleMaxConfigKey, 0)
+	if max == 0 {
+		return nil, nil
+	}
+	p := &autoscaleParams{
+		min: config.GetInt(AutoscaleMinConfigKey, 1),
+		max: max,
+		cpu: config.GetInt(AutoscaleCPUConfigKey, 0),
+	}
+	if p.min < 1 {
+		return nil, errors.NotValidf("%s %d", AutoscaleMinConfigKey, p.min)
+	}
+	if p.max < p.min {
+		return nil, errors.NotValidf("%s %d less than %s %d", AutoscaleMaxConfigKey, p.max, AutoscaleMinConfigKey, p.min)
+	}
+	if p.cpu < 0 || p.cpu > 100 {
+		return nil, errors.NotValidf("%s %d", AutoscaleCPUConfigKey, p.cpu)
+	}
+	return p, nil
+}
+
+// ValidateAutoscaleConfig returns an error if the autoscaling settings
+// in the application config cannot be applied.
+func ValidateAutoscaleConfig(config application.ConfigAttributes) error {
+	_, err := getAutoscaleParams(config)
+	return errors.Trace(err)
+}
+
+// clamp returns the number of pods within the autoscaling range. The
+// application's scale is kept in sync with the replica count chosen by
+// the autoscaler, so this only matters before the first sync.
+func (p *autoscaleParams) clamp(numPods int32) int32 {
+	if numPods < int32(p.min) {
+		return int32(p.min)
+	}
+	if numPods > int32(p.max) {
+		return int32(p.max)
+	}
+	return numPods
+}
+
+func (k *kubernetesClient) getHorizontalPodAutoscalerLabels(appName string) map[string]string {
+	return map[string]string{
+		constants.LabelApplication: appName,
+	}
+}
+
+// ensureHorizontalPodAutoscaler creates or updates the horizontal pod
+// autoscaler for the application's workload when autoscaling is enabled,
+// and removes it when it is not.
+func (k *kubernetesClient) ensureHorizontalPodAutoscaler(
+	appName, deploymentName string,
+	deploymentType caas.DeploymentType,
+	annotations k8sannotations.Annotation,
+	autoscale *autoscaleParams,
+) (cleanUps []func(), err error) {
+	if autoscale == nil {
+		return nil, errors.Trace(k.deleteHorizontalPodAutoscaler(deploymentName))
+	}
+	var kind string
+	switch deploymentType {
+	case caas.DeploymentStateful:
+		kind = "StatefulSet"
+	case caas.DeploymentStateless:
+		kind = "Deployment"
+	default:
+		return nil, errors.NotSupportedf("autoscaling %s applications", deploymentType)
+	}
+	minReplicas := int32(autoscale.min)
+	spec := &autoscalingv1.HorizontalPodAutoscaler{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        deploymentName,
+			Labels:      k.getHorizontalPodAutoscalerLabels(appName),
+			Annotations: annotations,
+		},
+		Spec: autoscalingv1.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv1.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       kind,
+				Name:       deploymentName,
+			},
+			MinReplicas: &minReplicas,
+			MaxReplicas: int32(autoscale.max),
+		},
+	}
+	if autoscale.cpu > 0 {
+		cpu := int32(autoscale.cpu)
+		spec.Spec.TargetCPUUtilizationPercentage = &cpu
+	}
+	out, err := k.createHorizontalPodAutoscaler(spec)
+	if err == nil {
+		logger.Debugf("horizontal pod autoscaler %q created", out.GetName())
+		cleanUps = append(cleanUps, func() { _ = k.deleteHorizontalPodAutoscaler(out.GetName()) })
+		return cleanUps, nil
+	}
+	if !errors.IsAlreadyExists(err) {
+		return cleanUps, errors.Trace(err)
+	}
+	existing, err := k.getHorizontalPodAutoscaler(deploymentName)
+	if err != nil {
+		return cleanUps, errors.Trace(err)
+	}
+	spec.SetResourceVersion(existing.GetResourceVersion())
+	_, err = k.updateHorizontalPodAutoscaler(spec)
+	logger.Debugf("updating horizontal pod autoscaler %q", spec.GetName())
+	return cleanUps, errors.Trace(err)
+}
+
+func (k *kubernetesClient) createHorizontalPodAutoscaler(spec *autoscalingv1.HorizontalPodAutoscaler) (*autoscalingv1.HorizontalPodAutoscaler, error) {
+	utils.PurifyResource(spec)
+	out, err := k.client().AutoscalingV1().HorizontalPodAutoscalers(k.namespace).Create(context.TODO(), spec, v1.CreateOptions{})
+	if k8serrors.IsAlreadyExists(err) {
+		return nil, errors.AlreadyExistsf("horizontal pod autoscaler %q", spec.GetName())
+	}
+	return out, errors.Trace(err)
+}
+
+func (k *kubernetesClient) getHorizontalPodAutoscaler(name string) (*autoscalingv1.HorizontalPodAutoscaler, error) {
+	out, err := k.client().AutoscalingV1().HorizontalPodAutoscalers(k.namespace).Get(context.TODO(), name, v1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil, errors.NotFoundf("horizontal pod autoscaler %q", name)
+	}
+	return out, errors.Trace(err)
+}
+
+func (k *kubernetesClient) updateHorizontalPodAutoscaler(spec *autoscalingv1.HorizontalPodAutoscaler) (*autoscalingv1.HorizontalPodAutoscaler, error) {
+	out, err := k.client().AutoscalingV1().HorizontalPodAutoscalers(k.namespace).Update(context.TODO(), spec, v1.UpdateOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil, errors.NotFoundf("horizontal pod autoscaler %q", spec.GetName())
+	}
+	return out, errors.Trace(err)
+}
+
+func (k *kubernetesClient) deleteHorizontalPodAutoscaler(name string) error {
+	err := k.client().AutoscalingV1().HorizontalPodAutoscalers(k.namespace).Delete(context.TODO(), name, v1.DeleteOptions{
+		PropagationPolicy: &constants.DefaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) deleteHorizontalPodAutoscalers(appName string) error {
+	err := k.client().AutoscalingV1().HorizontalPodAutoscalers(k.namespace).DeleteCollection(context.TODO(), v1.DeleteOptions{
+		PropagationPolicy: &constants.DefaultPropagationPolicy,
+	}, v1.ListOptions{
+		LabelSelector: utils.LabelSetToSelector(k.getHorizontalPodAutoscalerLabels(appName)).String(),
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
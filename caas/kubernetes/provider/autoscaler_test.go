@@ -0,0 +1,156 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/golang/mock/gomock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider"
+	k8sannotations "github.com/juju/juju/core/annotations"
+	"github.com/juju/juju/core/application"
+)
+
+type autoscalerSuite struct{}
+
+var _ = gc.Suite(&autoscalerSuite{})
+
+func (*autoscalerSuite) TestAutoscaleReplicas(c *gc.C) {
+	for i, t := range []struct {
+		config   application.ConfigAttributes
+		numPods  int32
+		expected int32
+		err      string
+	}{{
+		config:   application.ConfigAttributes{},
+		numPods:  20,
+		expected: 20,
+	}, {
+		config:   application.ConfigAttributes{"kubernetes-autoscale-min": 2, "kubernetes-autoscale-max": 10},
+		numPods:  1,
+		expected: 2,
+	}, {
+		config:   application.ConfigAttributes{"kubernetes-autoscale-max": 10},
+		numPods:  20,
+		expected: 10,
+	}, {
+		config:   application.ConfigAttributes{"kubernetes-autoscale-min": 2, "kubernetes-autoscale-max": 10},
+		numPods:  5,
+		expected: 5,
+	}, {
+		config: application.ConfigAttributes{"kubernetes-autoscale-min": 0, "kubernetes-autoscale-max": 10},
+		err:    `kubernetes-autoscale-min 0 not valid`,
+	}, {
+		config: application.ConfigAttributes{"kubernetes-autoscale-min": 5, "kubernetes-autoscale-max": 2},
+		err:    `kubernetes-autoscale-max 2 less than kubernetes-autoscale-min 5 not valid`,
+	}, {
+		config: application.ConfigAttributes{"kubernetes-autoscale-max": 2, "kubernetes-autoscale-cpu": 120},
+		err:    `kubernetes-autoscale-cpu 120 not valid`,
+	}} {
+		c.Logf("test %d", i)
+		numPods, err := provider.AutoscaleReplicas(t.config, t.numPods)
+		if t.err != "" {
+			c.Check(err, gc.ErrorMatches, t.err)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(numPods, gc.Equals, t.expected)
+	}
+}
+
+func (s *K8sBrokerSuite) horizontalPodAutoscalerArg(kind string, min, max int32, cpu *int32) *autoscalingv1.HorizontalPodAutoscaler {
+	return &autoscalingv1.HorizontalPodAutoscaler{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "app-name",
+			Labels:      map[string]string{"juju-app": "app-name"},
+			Annotations: map[string]string{"fred": "mary"},
+		},
+		Spec: autoscalingv1.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv1.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       kind,
+				Name:       "app-name",
+			},
+			MinReplicas:                    &min,
+			MaxReplicas:                    max,
+			TargetCPUUtilizationPercentage: cpu,
+		},
+	}
+}
+
+func (s *K8sBrokerSuite) TestEnsureHorizontalPodAutoscalerCreate(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	cpu := int32(70)
+	hpa := s.horizontalPodAutoscalerArg("Deployment", 2, 10, &cpu)
+	gomock.InOrder(
+		s.mockHorizontalPodAutoscalers.EXPECT().Create(gomock.Any(), hpa, v1.CreateOptions{}).
+			Return(hpa, nil),
+	)
+
+	cleanUps, err := s.broker.EnsureHorizontalPodAutoscaler("app-name", "app-name", caas.DeploymentStateless,
+		k8sannotations.New(map[string]string{"fred": "mary"}),
+		application.ConfigAttributes{
+			"kubernetes-autoscale-min": 2,
+			"kubernetes-autoscale-max": 10,
+			"kubernetes-autoscale-cpu": 70,
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cleanUps, gc.HasLen, 1)
+}
+
+func (s *K8sBrokerSuite) TestEnsureHorizontalPodAutoscalerUpdate(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	hpa := s.horizontalPodAutoscalerArg("StatefulSet", 1, 5, nil)
+	existing := s.horizontalPodAutoscalerArg("StatefulSet", 1, 3, nil)
+	existing.SetResourceVersion("42")
+	updated := s.horizontalPodAutoscalerArg("StatefulSet", 1, 5, nil)
+	updated.SetResourceVersion("42")
+	gomock.InOrder(
+		s.mockHorizontalPodAutoscalers.EXPECT().Create(gomock.Any(), hpa, v1.CreateOptions{}).
+			Return(nil, s.k8sAlreadyExistsError()),
+		s.mockHorizontalPodAutoscalers.EXPECT().Get(gomock.Any(), "app-name", v1.GetOptions{}).
+			Return(existing, nil),
+		s.mockHorizontalPodAutoscalers.EXPECT().Update(gomock.Any(), updated, v1.UpdateOptions{}).
+			Return(updated, nil),
+	)
+
+	cleanUps, err := s.broker.EnsureHorizontalPodAutoscaler("app-name", "app-name", caas.DeploymentStateful,
+		k8sannotations.New(map[string]string{"fred": "mary"}),
+		application.ConfigAttributes{"kubernetes-autoscale-max": 5},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cleanUps, gc.HasLen, 0)
+}
+
+func (s *K8sBrokerSuite) TestEnsureHorizontalPodAutoscalerDisabled(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockHorizontalPodAutoscalers.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+	)
+
+	_, err := s.broker.EnsureHorizontalPodAutoscaler("app-name", "app-name", caas.DeploymentStateless, nil, application.ConfigAttributes{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureHorizontalPodAutoscalerDaemonSet(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	_, err := s.broker.EnsureHorizontalPodAutoscaler("app-name", "app-name", caas.DeploymentDaemon, nil,
+		application.ConfigAttributes{"kubernetes-autoscale-max": 5},
+	)
+	c.Assert(err, gc.ErrorMatches, `autoscaling daemon applications not supported`)
+}
@@ -86,6 +86,11 @@ type BaseSuite struct {
 
 	mockDiscovery *mocks.MockDiscoveryInterface
 
+	mockPodDisruptionBudgets     *mocks.MockPodDisruptionBudgetInterface
+	mockHorizontalPodAutoscalers *mocks.MockHorizontalPodAutoscalerInterface
+
+	mockSelfSubjectAccessReviews *mocks.MockSelfSubjectAccessReviewInterface
+
 	watchers []k8swatcher.KubernetesNotifyWatcher
 }
 
@@ -322,6 +327,21 @@ func (s *BaseSuite) setupK8sRestClient(
 	s.mockDiscovery = mocks.NewMockDiscoveryInterface(ctrl)
 	s.k8sClient.EXPECT().Discovery().AnyTimes().Return(s.mockDiscovery)
 
+	mockPolicyV1beta1 := mocks.NewMockPolicyV1beta1Interface(ctrl)
+	s.k8sClient.EXPECT().PolicyV1beta1().AnyTimes().Return(mockPolicyV1beta1)
+	s.mockPodDisruptionBudgets = mocks.NewMockPodDisruptionBudgetInterface(ctrl)
+	mockPolicyV1beta1.EXPECT().PodDisruptionBudgets(namespace).AnyTimes().Return(s.mockPodDisruptionBudgets)
+
+	mockAutoscalingV1 := mocks.NewMockAutoscalingV1Interface(ctrl)
+	s.k8sClient.EXPECT().AutoscalingV1().AnyTimes().Return(mockAutoscalingV1)
+	s.mockHorizontalPodAutoscalers = mocks.NewMockHorizontalPodAutoscalerInterface(ctrl)
+	mockAutoscalingV1.EXPECT().HorizontalPodAutoscalers(namespace).AnyTimes().Return(s.mockHorizontalPodAutoscalers)
+
+	mockAuthorizationV1 := mocks.NewMockAuthorizationV1Interface(ctrl)
+	s.k8sClient.EXPECT().AuthorizationV1().AnyTimes().Return(mockAuthorizationV1)
+	s.mockSelfSubjectAccessReviews = mocks.NewMockSelfSubjectAccessReviewInterface(ctrl)
+	mockAuthorizationV1.EXPECT().SelfSubjectAccessReviews().AnyTimes().Return(s.mockSelfSubjectAccessReviews)
+
 	return func(cfg *rest.Config) (kubernetes.Interface, apiextensionsclientset.Interface, dynamic.Interface, error) {
 			c.Assert(cfg.Username, gc.Equals, "fred")
 			c.Assert(cfg.Password, gc.Equals, "secret")
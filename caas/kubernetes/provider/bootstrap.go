@@ -308,8 +308,11 @@ func (c *controllerStack) Deploy() (err error) {
 	// creating namespace for controller stack, this namespace will be removed by broker.DestroyController if bootstrap failed.
 	nsName := c.broker.GetCurrentNamespace()
 	c.ctx.Infof("Creating k8s resources for controller %q", nsName)
-	if err = c.broker.createNamespace(nsName); err != nil {
-		return errors.Annotate(err, "creating namespace for controller stack")
+	// namespace-scoped controllers use the existing namespace.
+	if !c.broker.namespaceScoped {
+		if err = c.broker.createNamespace(nsName); err != nil {
+			return errors.Annotate(err, "creating namespace for controller stack")
+		}
 	}
 
 	defer func() {
@@ -28,6 +28,12 @@ const (
 	ingressSSLRedirectKey    = "kubernetes-ingress-ssl-redirect"
 	ingressSSLPassthroughKey = "kubernetes-ingress-ssl-passthrough"
 	ingressAllowHTTPKey      = "kubernetes-ingress-allow-http"
+
+	podDisruptionMaxUnavailableKey = "kubernetes-pod-disruption-max-unavailable"
+
+	AutoscaleMinConfigKey = "kubernetes-autoscale-min"
+	AutoscaleMaxConfigKey = "kubernetes-autoscale-max"
+	AutoscaleCPUConfigKey = "kubernetes-autoscale-cpu"
 )
 
 var configFields = environschema.Fields{
@@ -86,6 +92,26 @@ var configFields = environschema.Fields{
 		Type:        environschema.Tbool,
 		Group:       environschema.ProviderGroup,
 	},
+	podDisruptionMaxUnavailableKey: {
+		Description: "number or percentage of pods that may be unavailable during voluntary disruptions, such as node drains",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	AutoscaleMinConfigKey: {
+		Description: "minimum number of pods when autoscaling is enabled",
+		Type:        environschema.Tint,
+		Group:       environschema.ProviderGroup,
+	},
+	AutoscaleMaxConfigKey: {
+		Description: "maximum number of pods; setting it enables autoscaling",
+		Type:        environschema.Tint,
+		Group:       environschema.ProviderGroup,
+	},
+	AutoscaleCPUConfigKey: {
+		Description: "target average CPU utilisation percentage when autoscaling",
+		Type:        environschema.Tint,
+		Group:       environschema.ProviderGroup,
+	},
 }
 
 var schemaDefaults = schema.Defaults{
@@ -95,6 +121,12 @@ var schemaDefaults = schema.Defaults{
 	ingressSSLRedirectKey:    defaultIngressSSLRedirect,
 	ingressSSLPassthroughKey: defaultIngressSSLPassthrough,
 	ingressAllowHTTPKey:      defaultIngressAllowHTTPKey,
+
+	podDisruptionMaxUnavailableKey: schema.Omit,
+
+	AutoscaleMinConfigKey: schema.Omit,
+	AutoscaleMaxConfigKey: schema.Omit,
+	AutoscaleCPUConfigKey: schema.Omit,
 }
 
 // ConfigSchema returns the configuration schema for
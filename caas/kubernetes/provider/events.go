@@ -5,13 +5,18 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/juju/errors"
 	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/client-go/informers"
+	informercore "k8s.io/client-go/informers/core/v1"
 
+	"github.com/juju/juju/caas/kubernetes/provider/constants"
 	"github.com/juju/juju/core/watcher"
 )
 
@@ -29,6 +34,9 @@ const (
 	BackOffStartContainer   = "BackOff"
 	ExceededGracePeriod     = "ExceededGracePeriod"
 
+	// Container termination reason
+	oomKilledReason = "OOMKilled"
+
 	// Pod event reason list
 	FailedToKillPod                = "FailedKillPod"
 	FailedToCreatePodContainer     = "FailedCreatePodContainer"
@@ -71,3 +79,189 @@ func (k *kubernetesClient) watchEvents(objName string, objKind string) (watcher.
 	)
 	return k.newWatcher(factory.Core().V1().Events().Informer(), objName, k.clock)
 }
+
+// eventTime returns the time the event was last seen.
+func eventTime(evt core.Event) v1.Time {
+	if !evt.LastTimestamp.IsZero() {
+		return evt.LastTimestamp
+	}
+	if !evt.EventTime.IsZero() {
+		return v1.NewTime(evt.EventTime.Time)
+	}
+	return evt.FirstTimestamp
+}
+
+// latestEvent returns the most recently seen event of the given type,
+// or of any type if eventType is empty, or nil if there is none.
+func latestEvent(events []core.Event, eventType string) *core.Event {
+	var latest *core.Event
+	for i, evt := range events {
+		if eventType != "" && evt.Type != eventType {
+			continue
+		}
+		if latest == nil {
+			latest = &events[i]
+			continue
+		}
+		if !eventTime(evt).Time.Before(eventTime(*latest).Time) {
+			latest = &events[i]
+		}
+	}
+	return latest
+}
+
+// latestWarningEvent returns the most recently seen warning event, or nil
+// if there are no warnings.
+func latestWarningEvent(events []core.Event) *core.Event {
+	return latestEvent(events, core.EventTypeWarning)
+}
+
+// currentWarningEvent returns the most recently seen warning event, or nil
+// if there are no warnings newer than the most recent normal event, eg a
+// FailedCreate followed by a SuccessfulCreate.
+func currentWarningEvent(events []core.Event) *core.Event {
+	warning := latestWarningEvent(events)
+	if warning == nil {
+		return nil
+	}
+	normal := latestEvent(events, core.EventTypeNormal)
+	if normal != nil && !eventTime(*warning).Time.After(eventTime(*normal).Time) {
+		return nil
+	}
+	return warning
+}
+
+// warningMessage formats a warning event for a status message,
+// eg "BackOff: Back-off pulling image "mariadb"".
+func warningMessage(evt *core.Event) string {
+	if evt.Reason == "" {
+		return evt.Message
+	}
+	return evt.Reason + ": " + evt.Message
+}
+
+// withWarning attaches the warning event to the status message, unless
+// the message already reports it.
+func withWarning(message string, evt *core.Event) string {
+	if message == "" {
+		return warningMessage(evt)
+	}
+	if strings.Contains(message, evt.Message) {
+		return message
+	}
+	return message + " (" + warningMessage(evt) + ")"
+}
+
+// jujuManagedLabels are the labels Juju puts on the objects it creates.
+var jujuManagedLabels = []string{
+	constants.LabelApplication,
+	constants.LabelOperator,
+	constants.LabelModelOperator,
+	constants.LabelStorage,
+}
+
+// IsJujuManagedObject returns true if the object involved in an event was
+// created by Juju. Objects which no longer exist are reported as not managed.
+// Nodes are reported as managed while they run any of the model's pods, so
+// node events such as the kernel OOM killer killing a workload are seen.
+func (k *kubernetesClient) IsJujuManagedObject(ref core.ObjectReference) (bool, error) {
+	var (
+		obj v1.Object
+		err error
+	)
+	ctx := context.TODO()
+	switch ref.Kind {
+	case "Pod":
+		obj, err = k.client().CoreV1().Pods(k.namespace).Get(ctx, ref.Name, v1.GetOptions{})
+	case "Service":
+		obj, err = k.client().CoreV1().Services(k.namespace).Get(ctx, ref.Name, v1.GetOptions{})
+	case "PersistentVolumeClaim":
+		obj, err = k.client().CoreV1().PersistentVolumeClaims(k.namespace).Get(ctx, ref.Name, v1.GetOptions{})
+	case "StatefulSet":
+		obj, err = k.client().AppsV1().StatefulSets(k.namespace).Get(ctx, ref.Name, v1.GetOptions{})
+	case "Deployment":
+		obj, err = k.client().AppsV1().Deployments(k.namespace).Get(ctx, ref.Name, v1.GetOptions{})
+	case "DaemonSet":
+		obj, err = k.client().AppsV1().DaemonSets(k.namespace).Get(ctx, ref.Name, v1.GetOptions{})
+	case "ReplicaSet":
+		obj, err = k.client().AppsV1().ReplicaSets(k.namespace).Get(ctx, ref.Name, v1.GetOptions{})
+	case "HorizontalPodAutoscaler":
+		obj, err = k.client().AutoscalingV1().HorizontalPodAutoscalers(k.namespace).Get(ctx, ref.Name, v1.GetOptions{})
+	case "PodDisruptionBudget":
+		obj, err = k.client().PolicyV1beta1().PodDisruptionBudgets(k.namespace).Get(ctx, ref.Name, v1.GetOptions{})
+	case "Node":
+		return k.runsJujuManagedPods(ref.Name)
+	default:
+		return false, nil
+	}
+	if k8serrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	labels := obj.GetLabels()
+	for _, label := range jujuManagedLabels {
+		if _, ok := labels[label]; ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// runsJujuManagedPods returns true if any pods created by Juju in the
+// model's namespace are running on the node.
+func (k *kubernetesClient) runsJujuManagedPods(nodeName string) (bool, error) {
+	pods, err := k.client().CoreV1().Pods(k.namespace).List(context.TODO(), v1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", nodeName).String(),
+	})
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	for _, pod := range pods.Items {
+		for _, label := range jujuManagedLabels {
+			if _, ok := pod.Labels[label]; ok {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// NodeEventInformer returns an informer for the events recorded against
+// the cluster's nodes, or nil if the model is limited to its namespace.
+// Node events are kept in the default namespace rather than the model's.
+func (k *kubernetesClient) NodeEventInformer() informercore.EventInformer {
+	if k.namespaceScoped {
+		return nil
+	}
+	factory := informers.NewSharedInformerFactoryWithOptions(k.client(), InformerResyncPeriod,
+		informers.WithNamespace(v1.NamespaceDefault),
+		informers.WithTweakListOptions(func(o *v1.ListOptions) {
+			o.FieldSelector = fields.OneTermEqualSelector("involvedObject.kind", "Node").String()
+		}),
+	)
+	return factory.Core().V1().Events()
+}
+
+// oomKilledMessage returns a message naming the pod's containers which
+// were last terminated by the OOM killer, or "" if there are none.
+func oomKilledMessage(pod core.Pod) string {
+	var names []string
+	for _, cs := range pod.Status.ContainerStatuses {
+		terminated := cs.State.Terminated
+		if terminated == nil {
+			terminated = cs.LastTerminationState.Terminated
+		}
+		if terminated != nil && terminated.Reason == oomKilledReason {
+			names = append(names, fmt.Sprintf("%q", cs.Name))
+		}
+	}
+	switch len(names) {
+	case 0:
+		return ""
+	case 1:
+		return fmt.Sprintf("%s: container %s ran out of memory", oomKilledReason, names[0])
+	}
+	return fmt.Sprintf("%s: containers %s ran out of memory", oomKilledReason, strings.Join(names, ", "))
+}
@@ -0,0 +1,166 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"time"
+
+	"github.com/golang/mock/gomock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	appsv1 "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/core/status"
+)
+
+func (s *K8sBrokerSuite) TestIsJujuManagedObject(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockPods.EXPECT().Get(gomock.Any(), "mariadb-0", v1.GetOptions{}).
+			Return(&core.Pod{ObjectMeta: v1.ObjectMeta{Labels: map[string]string{"juju-app": "mariadb"}}}, nil),
+		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "mariadb-operator", v1.GetOptions{}).
+			Return(&appsv1.StatefulSet{ObjectMeta: v1.ObjectMeta{Labels: map[string]string{"juju-operator": "mariadb"}}}, nil),
+		s.mockPersistentVolumeClaims.EXPECT().Get(gomock.Any(), "database-mariadb-0", v1.GetOptions{}).
+			Return(&core.PersistentVolumeClaim{ObjectMeta: v1.ObjectMeta{Labels: map[string]string{"juju-storage": "database"}}}, nil),
+	)
+
+	for _, ref := range []core.ObjectReference{
+		{Kind: "Pod", Name: "mariadb-0"},
+		{Kind: "StatefulSet", Name: "mariadb-operator"},
+		{Kind: "PersistentVolumeClaim", Name: "database-mariadb-0"},
+	} {
+		managed, err := s.broker.IsJujuManagedObject(ref)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(managed, jc.IsTrue, gc.Commentf("%s %s", ref.Kind, ref.Name))
+	}
+}
+
+func (s *K8sBrokerSuite) TestIsJujuManagedObjectNotManaged(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockPods.EXPECT().Get(gomock.Any(), "other-0", v1.GetOptions{}).
+			Return(&core.Pod{ObjectMeta: v1.ObjectMeta{Labels: map[string]string{"app": "other"}}}, nil),
+		s.mockDeployments.EXPECT().Get(gomock.Any(), "gone", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+	)
+
+	for _, ref := range []core.ObjectReference{
+		{Kind: "Pod", Name: "other-0"},
+		{Kind: "Deployment", Name: "gone"},
+		{Kind: "Namespace", Name: "kube-system"},
+	} {
+		managed, err := s.broker.IsJujuManagedObject(ref)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(managed, jc.IsFalse, gc.Commentf("%s %s", ref.Kind, ref.Name))
+	}
+}
+
+func (s *K8sBrokerSuite) TestIsJujuManagedObjectNode(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockPods.EXPECT().List(gomock.Any(), listOptionsFieldSelectorMatcher("spec.nodeName=node-1")).
+			Return(&core.PodList{Items: []core.Pod{
+				{ObjectMeta: v1.ObjectMeta{Labels: map[string]string{"app": "other"}}},
+				{ObjectMeta: v1.ObjectMeta{Labels: map[string]string{"juju-app": "mariadb"}}},
+			}}, nil),
+		s.mockPods.EXPECT().List(gomock.Any(), listOptionsFieldSelectorMatcher("spec.nodeName=node-2")).
+			Return(&core.PodList{Items: []core.Pod{
+				{ObjectMeta: v1.ObjectMeta{Labels: map[string]string{"app": "other"}}},
+			}}, nil),
+	)
+
+	// A node is managed while it runs any of the model's pods.
+	managed, err := s.broker.IsJujuManagedObject(core.ObjectReference{Kind: "Node", Name: "node-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(managed, jc.IsTrue)
+	managed, err = s.broker.IsJujuManagedObject(core.ObjectReference{Kind: "Node", Name: "node-2"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(managed, jc.IsFalse)
+}
+
+func (s *K8sBrokerSuite) assertStatusFromEvents(c *gc.C, events []core.Event, jujuStatus status.Status, expectedMessage string, expectedStatus status.Status) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	s.mockEvents.EXPECT().List(gomock.Any(),
+		listOptionsFieldSelectorMatcher("involvedObject.name=mariadb,involvedObject.kind=StatefulSet"),
+	).Return(&core.EventList{Items: events}, nil)
+
+	message, st, err := s.broker.GetStatusFromEvents("mariadb", "StatefulSet", jujuStatus)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(message, gc.Equals, expectedMessage)
+	c.Assert(st, gc.Equals, expectedStatus)
+}
+
+func (s *K8sBrokerSuite) TestStatusFromEventsFailedCreate(c *gc.C) {
+	now := time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC)
+	s.assertStatusFromEvents(c, []core.Event{{
+		Type:          core.EventTypeNormal,
+		Reason:        "SuccessfulCreate",
+		Message:       "create Pod mariadb-0 in StatefulSet mariadb successful",
+		LastTimestamp: v1.NewTime(now.Add(-time.Minute)),
+	}, {
+		Type:          core.EventTypeWarning,
+		Reason:        "FailedCreate",
+		Message:       `pods "mariadb-1" is forbidden: exceeded quota`,
+		LastTimestamp: v1.NewTime(now),
+	}}, status.Waiting,
+		`FailedCreate: pods "mariadb-1" is forbidden: exceeded quota`, status.Blocked,
+	)
+}
+
+func (s *K8sBrokerSuite) TestStatusFromEventsIgnoresWarningFollowedByNormal(c *gc.C) {
+	now := time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC)
+	s.assertStatusFromEvents(c, []core.Event{{
+		Type:          core.EventTypeWarning,
+		Reason:        "FailedCreate",
+		Message:       `pods "mariadb-1" is forbidden: exceeded quota`,
+		LastTimestamp: v1.NewTime(now.Add(-time.Minute)),
+	}, {
+		Type:          core.EventTypeNormal,
+		Reason:        "SuccessfulCreate",
+		Message:       "create Pod mariadb-1 in StatefulSet mariadb successful",
+		LastTimestamp: v1.NewTime(now),
+	}}, status.Waiting, "", status.Waiting)
+}
+
+func (s *K8sBrokerSuite) TestStatusFromEventsWarningNotBlocking(c *gc.C) {
+	now := time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC)
+	s.assertStatusFromEvents(c, []core.Event{{
+		Type:          core.EventTypeWarning,
+		Reason:        "FailedCreate",
+		Message:       `pods "mariadb-1" is forbidden: exceeded quota`,
+		LastTimestamp: v1.NewTime(now.Add(-2 * time.Minute)),
+	}, {
+		Type:          core.EventTypeNormal,
+		Reason:        "SuccessfulCreate",
+		Message:       "create Pod mariadb-1 in StatefulSet mariadb successful",
+		LastTimestamp: v1.NewTime(now.Add(-time.Minute)),
+	}, {
+		Type:          core.EventTypeWarning,
+		Reason:        "RecreatingFailedPod",
+		Message:       "StatefulSet default/mariadb is recreating failed Pod mariadb-1",
+		LastTimestamp: v1.NewTime(now),
+	}}, status.Waiting,
+		"RecreatingFailedPod: StatefulSet default/mariadb is recreating failed Pod mariadb-1", status.Waiting,
+	)
+}
+
+func (s *K8sBrokerSuite) TestStatusFromEventsReady(c *gc.C) {
+	now := time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC)
+	s.assertStatusFromEvents(c, []core.Event{{
+		Type:          core.EventTypeWarning,
+		Reason:        "FailedCreate",
+		Message:       `pods "mariadb-1" is forbidden: exceeded quota`,
+		LastTimestamp: v1.NewTime(now),
+	}}, status.Active, "", status.Active)
+}
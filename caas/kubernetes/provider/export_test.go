@@ -12,14 +12,19 @@ import (
 	core "k8s.io/api/core/v1"
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider/utils"
 	"github.com/juju/juju/caas/specs"
 	"github.com/juju/juju/cloud"
 	jujucloud "github.com/juju/juju/cloud"
 	"github.com/juju/juju/cloudconfig/podcfg"
+	k8sannotations "github.com/juju/juju/core/annotations"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/storage"
@@ -144,6 +149,39 @@ func (k *kubernetesClient) ConfigurePodFiles(
 	return k.configurePodFiles(appName, annotations, workloadSpec, containers, cfgMapName)
 }
 
+func (k *kubernetesClient) EnsurePodDisruptionBudget(
+	appName, deploymentName string, annotations k8sannotations.Annotation, config application.ConfigAttributes,
+) ([]func(), error) {
+	selector := &v1.LabelSelector{MatchLabels: utils.LabelsForApp(appName)}
+	return k.ensurePodDisruptionBudget(appName, deploymentName, annotations, selector, config)
+}
+
+func (k *kubernetesClient) EnsureRawWorkloadPodDisruptionBudget(
+	appName, deploymentName string, deploymentType caas.DeploymentType,
+	annotations k8sannotations.Annotation, config application.ConfigAttributes,
+) ([]func(), error) {
+	return k.ensureRawWorkloadPodDisruptionBudget(appName, deploymentName, deploymentType, annotations, config)
+}
+
+func (k *kubernetesClient) EnsureHorizontalPodAutoscaler(
+	appName, deploymentName string, deploymentType caas.DeploymentType,
+	annotations k8sannotations.Annotation, config application.ConfigAttributes,
+) ([]func(), error) {
+	autoscale, err := getAutoscaleParams(config)
+	if err != nil {
+		return nil, err
+	}
+	return k.ensureHorizontalPodAutoscaler(appName, deploymentName, deploymentType, annotations, autoscale)
+}
+
+func AutoscaleReplicas(config application.ConfigAttributes, numPods int32) (int32, error) {
+	autoscale, err := getAutoscaleParams(config)
+	if err != nil || autoscale == nil {
+		return numPods, err
+	}
+	return autoscale.clamp(numPods), nil
+}
+
 func (k *kubernetesClient) DeleteClusterScopeResourcesModelTeardown(ctx context.Context, wg *sync.WaitGroup, errChan chan<- error) {
 	k.deleteClusterScopeResourcesModelTeardown(ctx, wg, errChan)
 }
@@ -152,6 +190,10 @@ func (k *kubernetesClient) DeleteNamespaceModelTeardown(ctx context.Context, wg
 	k.deleteNamespaceModelTeardown(ctx, wg, errChan)
 }
 
+func (k *kubernetesClient) GetStatusFromEvents(name, kind string, jujuStatus status.Status) (string, status.Status, error) {
+	return k.getStatusFromEvents(name, kind, jujuStatus)
+}
+
 func StorageProvider(k8sClient kubernetes.Interface, namespace string) storage.Provider {
 	return &storageProvider{&kubernetesClient{clientUnlocked: k8sClient, namespace: namespace}}
 }
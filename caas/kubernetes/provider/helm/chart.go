@@ -0,0 +1,205 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package helm loads packaged Helm charts and renders them into
+// Kubernetes manifests that can be applied by the raw k8s spec
+// deployer.
+//
+// Charts are rendered with Go's text/template rather than the Helm
+// engine, so only a subset of Helm is supported:
+//
+//   - The chart must not have dependencies (a charts/ directory).
+//   - Templates see .Values, .Release.Name, .Release.Namespace,
+//     .Release.Service, .Chart.Name, .Chart.Version,
+//     .Chart.AppVersion, .Template.Name and .Template.BasePath, along
+//     with .Capabilities.KubeVersion, .Capabilities.APIVersions.Has
+//     and .Files (Get, GetBytes, Glob, Lines, AsConfig and AsSecrets).
+//   - Missing values render as nothing, as they do with Helm.
+//   - The template functions are the text/template builtins plus
+//     include, tpl, required, default, empty, coalesce, ternary,
+//     toYaml, fromYaml, toJson, quote, squote, indent, nindent, trim,
+//     trimSuffix, trimPrefix, trunc, lower, upper, replace, contains,
+//     join, b64enc, sha256sum, toString, hasKey, list and dict. Other
+//     Sprig functions and lookup are not available.
+//   - Hooks and tests (objects annotated with helm.sh/hook) are not
+//     applied, since there is no release lifecycle to run them in.
+//
+// Chart.Validate reports charts using an unsupported function, and is
+// called when a chart resource is uploaded.
+package helm
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/yaml.v2"
+)
+
+var logger = loggo.GetLogger("juju.kubernetes.provider.helm")
+
+// MaxChartSize is the largest packaged chart that will be loaded.
+const MaxChartSize = 1 << 20
+
+// Metadata holds the fields of Chart.yaml used when rendering.
+type Metadata struct {
+	Name       string `yaml:"name"`
+	Version    string `yaml:"version"`
+	AppVersion string `yaml:"appVersion,omitempty"`
+}
+
+// Chart is a loaded Helm chart.
+type Chart struct {
+	// Metadata is the chart's Chart.yaml.
+	Metadata Metadata
+
+	// Values holds the chart's default values from values.yaml.
+	Values map[string]interface{}
+
+	// Templates maps each template path, relative to the chart
+	// directory, to its content.
+	Templates map[string]string
+
+	// Files maps the path of each of the chart's other files,
+	// relative to the chart directory, to its content. Templates
+	// read them through .Files.
+	Files map[string][]byte
+}
+
+// Load reads a packaged (gzipped tar) chart.
+func Load(r io.Reader) (*Chart, error) {
+	data, err := ioutil.ReadAll(io.LimitReader(r, MaxChartSize+1))
+	if err != nil {
+		return nil, errors.Annotate(err, "reading helm chart")
+	}
+	if len(data) > MaxChartSize {
+		return nil, errors.NotValidf("helm chart larger than %d bytes", MaxChartSize)
+	}
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Annotate(err, "reading helm chart")
+	}
+	defer gz.Close()
+
+	files := make(map[string][]byte)
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Annotate(err, "reading helm chart")
+		}
+		if hdr.Typeflag != tar.TypeReg && hdr.Typeflag != tar.TypeRegA {
+			continue
+		}
+		// Packaged charts hold everything under a top-level
+		// directory named after the chart.
+		name := path.Clean(hdr.Name)
+		parts := strings.SplitN(name, "/", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		content, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, errors.Annotatef(err, "reading %q from helm chart", name)
+		}
+		files[parts[1]] = content
+	}
+	return newChart(files)
+}
+
+func newChart(files map[string][]byte) (*Chart, error) {
+	chartYAML, ok := files["Chart.yaml"]
+	if !ok {
+		return nil, errors.NotValidf("helm chart without Chart.yaml")
+	}
+	ch := &Chart{
+		Values:    make(map[string]interface{}),
+		Templates: make(map[string]string),
+		Files:     make(map[string][]byte),
+	}
+	if err := yaml.Unmarshal(chartYAML, &ch.Metadata); err != nil {
+		return nil, errors.Annotate(err, "parsing Chart.yaml")
+	}
+	if ch.Metadata.Name == "" {
+		return nil, errors.NotValidf("helm chart without a name")
+	}
+	if valuesYAML, ok := files["values.yaml"]; ok {
+		var values map[string]interface{}
+		if err := yaml.Unmarshal(valuesYAML, &values); err != nil {
+			return nil, errors.Annotate(err, "parsing values.yaml")
+		}
+		ch.Values = normaliseMap(values)
+	}
+	for name, content := range files {
+		switch {
+		case strings.HasPrefix(name, "charts/"):
+			return nil, errors.NotSupportedf("helm chart dependencies")
+		case strings.HasPrefix(name, "templates/"):
+			ch.Templates[name] = string(content)
+		case name != "Chart.yaml" && name != "values.yaml":
+			ch.Files[name] = content
+		}
+	}
+	return ch, nil
+}
+
+// templateNames returns the names of the templates that produce
+// manifests, in the order they are rendered. Partials (whose names
+// start with an underscore) and non-YAML files such as NOTES.txt are
+// only available to other templates.
+func (ch *Chart) templateNames() []string {
+	var names []string
+	for name := range ch.Templates {
+		base := path.Base(name)
+		if strings.HasPrefix(base, "_") {
+			continue
+		}
+		if ext := path.Ext(base); ext != ".yaml" && ext != ".yml" {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// normaliseMap converts the map[interface{}]interface{} values produced
+// by the yaml package into map[string]interface{} so they can be merged
+// and marshalled as JSON.
+func normaliseMap(in map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(in))
+	for k, v := range in {
+		out[k] = normaliseValue(v)
+	}
+	return out
+}
+
+func normaliseValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			out[toString(k)] = normaliseValue(val)
+		}
+		return out
+	case map[string]interface{}:
+		return normaliseMap(v)
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, val := range v {
+			out[i] = normaliseValue(val)
+		}
+		return out
+	}
+	return v
+}
@@ -0,0 +1,146 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package helm_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/caas/kubernetes/provider/helm"
+)
+
+type chartSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&chartSuite{})
+
+// packageChart builds a packaged chart holding the given files under
+// the chart's directory.
+func packageChart(c *gc.C, dir string, files map[string]string) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		content := files[name]
+		err := tw.WriteHeader(&tar.Header{
+			Name:     dir + "/" + name,
+			Mode:     0644,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = tw.Write([]byte(content))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(tw.Close(), jc.ErrorIsNil)
+	c.Assert(gz.Close(), jc.ErrorIsNil)
+	return buf.Bytes()
+}
+
+var testChartFiles = map[string]string{
+	"Chart.yaml": `
+apiVersion: v2
+name: mariadb
+version: 1.2.3
+appVersion: "10.5"
+`,
+	"values.yaml": `
+replicaCount: 1
+image:
+  repository: mariadb
+  tag: "10.5"
+service:
+  port: 3306
+`,
+	"templates/_helpers.tpl": `{{- define "mariadb.fullname" -}}
+{{ .Release.Name }}-{{ .Chart.Name }}
+{{- end -}}`,
+	"templates/deployment.yaml": `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ include "mariadb.fullname" . }}
+spec:
+  replicas: {{ .Values.replicaCount }}
+  template:
+    spec:
+      containers:
+      - name: mariadb
+        image: {{ .Values.image.repository }}:{{ .Values.image.tag }}
+        env:
+        - name: MARIADB_DATABASE
+          value: {{ .Values.database | default "juju" | quote }}
+`,
+	"templates/service.yaml": `{{- if .Values.service.enabled }}
+apiVersion: v1
+kind: Service
+metadata:
+  name: {{ include "mariadb.fullname" . }}
+  namespace: {{ .Release.Namespace }}
+spec:
+  ports:
+  - port: {{ .Values.service.port }}
+{{- end }}
+`,
+	"templates/NOTES.txt": `Installed {{ .Chart.Name }}.`,
+}
+
+func (s *chartSuite) TestLoad(c *gc.C) {
+	ch, err := helm.Load(bytes.NewReader(packageChart(c, "mariadb", testChartFiles)))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ch.Metadata, jc.DeepEquals, helm.Metadata{
+		Name:       "mariadb",
+		Version:    "1.2.3",
+		AppVersion: "10.5",
+	})
+	c.Check(ch.Values, jc.DeepEquals, map[string]interface{}{
+		"replicaCount": 1,
+		"image": map[string]interface{}{
+			"repository": "mariadb",
+			"tag":        "10.5",
+		},
+		"service": map[string]interface{}{
+			"port": 3306,
+		},
+	})
+	c.Check(ch.Templates, gc.HasLen, 4)
+}
+
+func (s *chartSuite) TestLoadMissingChartYAML(c *gc.C) {
+	_, err := helm.Load(bytes.NewReader(packageChart(c, "mariadb", map[string]string{
+		"values.yaml": "a: b",
+	})))
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, "helm chart without Chart.yaml not valid")
+}
+
+func (s *chartSuite) TestLoadDependenciesNotSupported(c *gc.C) {
+	_, err := helm.Load(bytes.NewReader(packageChart(c, "mariadb", map[string]string{
+		"Chart.yaml":               "name: mariadb",
+		"charts/common/Chart.yaml": "name: common",
+	})))
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *chartSuite) TestLoadNotAnArchive(c *gc.C) {
+	_, err := helm.Load(bytes.NewReader([]byte("not a chart")))
+	c.Assert(err, gc.ErrorMatches, "reading helm chart: .*")
+}
+
+func (s *chartSuite) TestLoadTooLarge(c *gc.C) {
+	_, err := helm.Load(bytes.NewReader(make([]byte, helm.MaxChartSize+1)))
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
@@ -0,0 +1,544 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package helm
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"regexp"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// Release identifies an installation of a chart.
+type Release struct {
+	// Name is the release name, which is the application name.
+	Name string
+
+	// Namespace is the namespace the chart is installed into.
+	Namespace string
+}
+
+// Capabilities describes the cluster a chart is rendered for, and is
+// available to templates as .Capabilities.
+type Capabilities struct {
+	// KubeVersion is the cluster's Kubernetes version, for example
+	// "v1.18.3".
+	KubeVersion string
+
+	// APIVersions holds the API group versions served by the
+	// cluster, for example "apps/v1".
+	APIVersions []string
+}
+
+// hookAnnotation marks the objects Helm only creates for hooks and
+// tests; they aren't applied.
+const hookAnnotation = "helm.sh/hook"
+
+// missingValueFunc names the function added to the end of each
+// pipeline whose value is printed, so that missing values print as
+// nothing as they do with Helm rather than as "<no value>".
+const missingValueFunc = "jujuMissingValue"
+
+// Render renders the chart's templates into a multi-document YAML
+// manifest. The values are merged over the chart's defaults before
+// rendering; a key containing dots, such as "image.tag", sets the
+// nested value. Hooks and tests are left out.
+func (ch *Chart) Render(release Release, caps Capabilities, values map[string]interface{}) (string, error) {
+	renderValues := MergeValues(ch.Values, values)
+	data := map[string]interface{}{
+		"Values": renderValues,
+		"Release": map[string]interface{}{
+			"Name":      release.Name,
+			"Namespace": release.Namespace,
+			"Service":   "Juju",
+		},
+		"Chart": map[string]interface{}{
+			"Name":       ch.Metadata.Name,
+			"Version":    ch.Metadata.Version,
+			"AppVersion": ch.Metadata.AppVersion,
+		},
+		"Capabilities": newCapabilities(caps),
+		"Files":        files(ch.Files),
+	}
+
+	t, err := ch.parse()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+
+	var out bytes.Buffer
+	for _, name := range ch.templateNames() {
+		data["Template"] = map[string]interface{}{
+			"Name":     path.Join(ch.Metadata.Name, name),
+			"BasePath": path.Join(ch.Metadata.Name, "templates"),
+		}
+		var buf bytes.Buffer
+		if err := t.ExecuteTemplate(&buf, name, data); err != nil {
+			return "", errors.Annotatef(err, "rendering template %q", name)
+		}
+		manifest, err := withoutHooks(buf.String())
+		if err != nil {
+			return "", errors.Annotatef(err, "parsing rendered template %q", name)
+		}
+		if manifest == "" {
+			logger.Debugf("template %q rendered no objects", name)
+			continue
+		}
+		fmt.Fprintf(&out, "---\n# Source: %s/%s\n%s\n", ch.Metadata.Name, name, manifest)
+	}
+	return out.String(), nil
+}
+
+var documentSeparator = regexp.MustCompile(`(?m)^---[ \t]*(\n|$)`)
+
+// withoutHooks returns the manifest without the documents that
+// describe hooks or tests, or are empty.
+func withoutHooks(manifest string) (string, error) {
+	var docs []string
+	for _, doc := range documentSeparator.Split(manifest, -1) {
+		doc = strings.TrimSpace(doc)
+		if doc == "" {
+			continue
+		}
+		var obj struct {
+			Kind     string `yaml:"kind"`
+			Metadata struct {
+				Name        string            `yaml:"name"`
+				Annotations map[string]string `yaml:"annotations"`
+			} `yaml:"metadata"`
+		}
+		if err := yaml.Unmarshal([]byte(doc), &obj); err != nil {
+			return "", errors.Trace(err)
+		}
+		if hook, ok := obj.Metadata.Annotations[hookAnnotation]; ok {
+			logger.Debugf("not applying %s %q, it is a %q hook", obj.Kind, obj.Metadata.Name, hook)
+			continue
+		}
+		docs = append(docs, doc)
+	}
+	return strings.Join(docs, "\n---\n"), nil
+}
+
+// Validate checks that the chart only uses the supported subset of
+// Helm: every template must parse using the functions listed in the
+// package documentation. It doesn't render the templates, since that
+// needs the values the chart will be deployed with.
+func (ch *Chart) Validate() error {
+	_, err := ch.parse()
+	return errors.Trace(err)
+}
+
+// parse parses the chart's templates. Calls to functions that aren't
+// supported are reported as errors here rather than when rendering.
+func (ch *Chart) parse() (*template.Template, error) {
+	t := template.New(ch.Metadata.Name).Option("missingkey=zero")
+	t.Funcs(funcMap(t))
+	for name, content := range ch.Templates {
+		if _, err := t.New(name).Parse(content); err != nil {
+			return nil, errors.NewNotSupported(err, fmt.Sprintf("parsing template %q", name))
+		}
+	}
+	for _, tmpl := range t.Templates() {
+		printMissingValuesAsEmpty(tmpl.Tree)
+	}
+	return t, nil
+}
+
+// printMissingValuesAsEmpty adds a call to missingValueFunc to the end
+// of each pipeline in the tree whose value is printed. text/template
+// prints missing values as "<no value>", while Helm charts expect them
+// to print as nothing.
+func printMissingValuesAsEmpty(tree *parse.Tree) {
+	if tree == nil || tree.Root == nil {
+		return
+	}
+	var walk func(node parse.Node)
+	walk = func(node parse.Node) {
+		switch n := node.(type) {
+		case *parse.ListNode:
+			if n == nil {
+				return
+			}
+			for _, child := range n.Nodes {
+				walk(child)
+			}
+		case *parse.ActionNode:
+			// Pipelines declaring or assigning variables print
+			// nothing.
+			if len(n.Pipe.Decl) > 0 {
+				return
+			}
+			n.Pipe.Cmds = append(n.Pipe.Cmds, &parse.CommandNode{
+				NodeType: parse.NodeCommand,
+				Pos:      n.Pos,
+				Args: []parse.Node{
+					parse.NewIdentifier(missingValueFunc).SetTree(tree).SetPos(n.Pos),
+				},
+			})
+		case *parse.IfNode:
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.RangeNode:
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.WithNode:
+			walk(n.List)
+			walk(n.ElseList)
+		}
+	}
+	walk(tree.Root)
+}
+
+// missingValue is the function called at the end of each printed
+// pipeline. The value of a missing key is nil.
+func missingValue(v interface{}) interface{} {
+	if v == nil {
+		return ""
+	}
+	return v
+}
+
+// kubeVersion is .Capabilities.KubeVersion.
+type kubeVersion struct {
+	Version    string
+	GitVersion string
+	Major      string
+	Minor      string
+}
+
+// String returns the version, as Helm prints it.
+func (v kubeVersion) String() string {
+	return v.Version
+}
+
+// apiVersions is .Capabilities.APIVersions.
+type apiVersions []string
+
+// Has reports whether the cluster serves the API group version.
+func (v apiVersions) Has(version string) bool {
+	for _, served := range v {
+		if served == version {
+			return true
+		}
+	}
+	return false
+}
+
+func newCapabilities(caps Capabilities) map[string]interface{} {
+	version := kubeVersion{
+		Version:    caps.KubeVersion,
+		GitVersion: caps.KubeVersion,
+	}
+	parts := strings.SplitN(strings.TrimPrefix(caps.KubeVersion, "v"), ".", 3)
+	if len(parts) >= 2 {
+		version.Major, version.Minor = parts[0], parts[1]
+	}
+	return map[string]interface{}{
+		"KubeVersion": version,
+		"APIVersions": apiVersions(caps.APIVersions),
+	}
+}
+
+// files is .Files, the chart's files other than its templates,
+// Chart.yaml and values.yaml.
+type files map[string][]byte
+
+// Get returns the content of the named file, or an empty string if
+// there is no such file.
+func (f files) Get(name string) string {
+	return string(f[name])
+}
+
+// GetBytes returns the content of the named file.
+func (f files) GetBytes(name string) []byte {
+	return f[name]
+}
+
+// Lines returns the lines of the named file.
+func (f files) Lines(name string) []string {
+	content := f.Get(name)
+	if content == "" {
+		return []string{}
+	}
+	return strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+}
+
+// Glob returns the files with names matching the pattern.
+func (f files) Glob(pattern string) (files, error) {
+	out := make(files)
+	for name, content := range f {
+		matched, err := path.Match(pattern, name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if matched {
+			out[name] = content
+		}
+	}
+	return out, nil
+}
+
+// AsConfig returns the files as the YAML data of a ConfigMap, keyed on
+// their base names.
+func (f files) AsConfig() string {
+	return f.asData(func(content []byte) string { return string(content) })
+}
+
+// AsSecrets returns the files as the YAML data of a Secret, keyed on
+// their base names.
+func (f files) AsSecrets() string {
+	return f.asData(func(content []byte) string {
+		return base64.StdEncoding.EncodeToString(content)
+	})
+}
+
+func (f files) asData(encode func([]byte) string) string {
+	if len(f) == 0 {
+		return ""
+	}
+	data := make(map[string]string, len(f))
+	for name, content := range f {
+		data[path.Base(name)] = encode(content)
+	}
+	out, err := yaml.Marshal(data)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSuffix(string(out), "\n")
+}
+
+// MergeValues returns a copy of the defaults with the overrides
+// applied. Nested maps are merged; a key containing dots sets the
+// corresponding nested value.
+func MergeValues(defaults, overrides map[string]interface{}) map[string]interface{} {
+	out := copyMap(defaults)
+	for k, v := range overrides {
+		dest := out
+		parts := strings.Split(k, ".")
+		for _, part := range parts[:len(parts)-1] {
+			next, ok := dest[part].(map[string]interface{})
+			if !ok {
+				next = make(map[string]interface{})
+				dest[part] = next
+			}
+			dest = next
+		}
+		key := parts[len(parts)-1]
+		existing, existingIsMap := dest[key].(map[string]interface{})
+		override, overrideIsMap := normaliseValue(v).(map[string]interface{})
+		if existingIsMap && overrideIsMap {
+			dest[key] = MergeValues(existing, override)
+			continue
+		}
+		dest[key] = normaliseValue(v)
+	}
+	return out
+}
+
+func copyMap(in map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(in))
+	for k, v := range in {
+		if m, ok := v.(map[string]interface{}); ok {
+			v = copyMap(m)
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// funcMap returns the subset of the Helm template functions supported
+// when rendering charts. Keep the list in the package documentation in
+// step with it.
+func funcMap(t *template.Template) template.FuncMap {
+	return template.FuncMap{
+		"include": func(name string, data interface{}) (string, error) {
+			var buf bytes.Buffer
+			if err := t.ExecuteTemplate(&buf, name, data); err != nil {
+				return "", err
+			}
+			return buf.String(), nil
+		},
+		"required": func(msg string, v interface{}) (interface{}, error) {
+			if empty(v) {
+				return nil, errors.New(msg)
+			}
+			return v, nil
+		},
+		"default": func(d interface{}, given ...interface{}) interface{} {
+			if len(given) == 0 || empty(given[0]) {
+				return d
+			}
+			return given[0]
+		},
+		"empty": empty,
+		"toYaml": func(v interface{}) string {
+			data, err := yaml.Marshal(v)
+			if err != nil {
+				return ""
+			}
+			return strings.TrimSuffix(string(data), "\n")
+		},
+		"toJson": func(v interface{}) string {
+			data, err := json.Marshal(v)
+			if err != nil {
+				return ""
+			}
+			return string(data)
+		},
+		"quote": func(v ...interface{}) string {
+			out := make([]string, len(v))
+			for i, s := range v {
+				out[i] = fmt.Sprintf("%q", toString(s))
+			}
+			return strings.Join(out, " ")
+		},
+		"squote": func(v ...interface{}) string {
+			out := make([]string, len(v))
+			for i, s := range v {
+				out[i] = "'" + toString(s) + "'"
+			}
+			return strings.Join(out, " ")
+		},
+		"indent": indent,
+		"nindent": func(spaces int, s string) string {
+			return "\n" + indent(spaces, s)
+		},
+		"trim":       strings.TrimSpace,
+		"trimSuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+		"trimPrefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+		"trunc": func(n int, s string) string {
+			if n >= 0 && len(s) > n {
+				return s[:n]
+			}
+			return s
+		},
+		"lower":    strings.ToLower,
+		"upper":    strings.ToUpper,
+		"replace":  func(old, new, s string) string { return strings.Replace(s, old, new, -1) },
+		"contains": func(substr, s string) bool { return strings.Contains(s, substr) },
+		"b64enc":   func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) },
+		"toString": toString,
+		"hasKey": func(m map[string]interface{}, key string) bool {
+			_, ok := m[key]
+			return ok
+		},
+		"tpl": func(text string, data interface{}) (string, error) {
+			// Templates rendered by tpl can use the chart's named
+			// templates.
+			clone, err := t.Clone()
+			if err != nil {
+				return "", err
+			}
+			tmpl, err := clone.New("tpl").Parse(text)
+			if err != nil {
+				return "", err
+			}
+			printMissingValuesAsEmpty(tmpl.Tree)
+			var buf bytes.Buffer
+			if err := tmpl.Execute(&buf, data); err != nil {
+				return "", err
+			}
+			return buf.String(), nil
+		},
+		missingValueFunc: missingValue,
+		"ternary": func(ifTrue, ifFalse interface{}, condition bool) interface{} {
+			if condition {
+				return ifTrue
+			}
+			return ifFalse
+		},
+		"coalesce": func(v ...interface{}) interface{} {
+			for _, val := range v {
+				if !empty(val) {
+					return val
+				}
+			}
+			return nil
+		},
+		"join": func(sep string, v interface{}) string {
+			var out []string
+			rv := reflect.ValueOf(v)
+			if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+				return toString(v)
+			}
+			for i := 0; i < rv.Len(); i++ {
+				out = append(out, toString(rv.Index(i).Interface()))
+			}
+			return strings.Join(out, sep)
+		},
+		"sha256sum": func(s string) string {
+			hash := sha256.Sum256([]byte(s))
+			return hex.EncodeToString(hash[:])
+		},
+		"fromYaml": func(s string) map[string]interface{} {
+			var out map[string]interface{}
+			if err := yaml.Unmarshal([]byte(s), &out); err != nil {
+				return map[string]interface{}{"Error": err.Error()}
+			}
+			return normaliseMap(out)
+		},
+		"list": func(v ...interface{}) []interface{} { return v },
+		"dict": func(v ...interface{}) map[string]interface{} {
+			out := make(map[string]interface{})
+			for i := 0; i+1 < len(v); i += 2 {
+				out[toString(v[i])] = v[i+1]
+			}
+			return out
+		},
+	}
+}
+
+func indent(spaces int, s string) string {
+	pad := strings.Repeat(" ", spaces)
+	return pad + strings.Replace(s, "\n", "\n"+pad, -1)
+}
+
+func toString(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case fmt.Stringer:
+		return v.String()
+	}
+	return fmt.Sprint(v)
+}
+
+// empty reports whether the value is the zero value for its type, as
+// the Helm "empty" and "default" functions do.
+func empty(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return rv.Len() == 0
+	case reflect.Bool:
+		return !rv.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return rv.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return rv.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return rv.IsNil()
+	}
+	return false
+}
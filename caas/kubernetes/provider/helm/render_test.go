@@ -0,0 +1,273 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package helm_test
+
+import (
+	"bytes"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/caas/kubernetes/provider/helm"
+)
+
+type renderSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&renderSuite{})
+
+func (s *renderSuite) loadChart(c *gc.C) *helm.Chart {
+	ch, err := helm.Load(bytes.NewReader(packageChart(c, "mariadb", testChartFiles)))
+	c.Assert(err, jc.ErrorIsNil)
+	return ch
+}
+
+func (s *renderSuite) TestRenderDefaults(c *gc.C) {
+	out, err := s.loadChart(c).Render(helm.Release{Name: "db", Namespace: "test"}, helm.Capabilities{}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `
+---
+# Source: mariadb/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: db-mariadb
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - name: mariadb
+        image: mariadb:10.5
+        env:
+        - name: MARIADB_DATABASE
+          value: "juju"
+`[1:])
+}
+
+func (s *renderSuite) TestRenderWithValues(c *gc.C) {
+	out, err := s.loadChart(c).Render(helm.Release{Name: "db", Namespace: "test"}, helm.Capabilities{}, map[string]interface{}{
+		"database":        "wiki",
+		"image.tag":       "10.6",
+		"service.enabled": true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `
+---
+# Source: mariadb/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: db-mariadb
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - name: mariadb
+        image: mariadb:10.6
+        env:
+        - name: MARIADB_DATABASE
+          value: "wiki"
+---
+# Source: mariadb/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: db-mariadb
+  namespace: test
+spec:
+  ports:
+  - port: 3306
+`[1:])
+}
+
+func (s *renderSuite) TestRenderRequired(c *gc.C) {
+	ch := &helm.Chart{
+		Metadata: helm.Metadata{Name: "test"},
+		Templates: map[string]string{
+			"templates/cm.yaml": `data: {{ required "password is required" .Values.password }}`,
+		},
+	}
+	_, err := ch.Render(helm.Release{Name: "app"}, helm.Capabilities{}, nil)
+	c.Assert(err, gc.ErrorMatches, `rendering template "templates/cm.yaml": .*password is required`)
+}
+
+func (s *renderSuite) TestRenderToYaml(c *gc.C) {
+	ch := &helm.Chart{
+		Metadata: helm.Metadata{Name: "test"},
+		Templates: map[string]string{
+			"templates/cm.yaml": `data:{{ .Values.data | toYaml | nindent 2 }}`,
+		},
+	}
+	out, err := ch.Render(helm.Release{Name: "app"}, helm.Capabilities{}, map[string]interface{}{
+		"data": map[string]interface{}{"b": "2", "a": "1"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `
+---
+# Source: test/templates/cm.yaml
+data:
+  a: "1"
+  b: "2"
+`[1:])
+}
+
+func (s *renderSuite) TestRenderMissingValues(c *gc.C) {
+	ch := &helm.Chart{
+		Metadata: helm.Metadata{Name: "test"},
+		Templates: map[string]string{
+			"templates/cm.yaml": `data:
+  missing: "{{ .Values.missing }}"
+  nested: "{{ .Values.image.tag }}"
+  literal: "{{ .Values.literal }}"
+{{- with .Values.image }}
+  image: "{{ .repository }}"
+{{- end }}`,
+		},
+	}
+	out, err := ch.Render(helm.Release{Name: "app"}, helm.Capabilities{}, map[string]interface{}{
+		"image":   map[string]interface{}{"pullPolicy": "Always"},
+		"literal": "<no value>",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `
+---
+# Source: test/templates/cm.yaml
+data:
+  missing: ""
+  nested: ""
+  literal: "<no value>"
+  image: ""
+`[1:])
+}
+
+func (s *renderSuite) TestRenderSkipsHooks(c *gc.C) {
+	ch := &helm.Chart{
+		Metadata: helm.Metadata{Name: "test"},
+		Templates: map[string]string{
+			"templates/cm.yaml": `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ .Release.Name }}
+---
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: {{ .Release.Name }}-migrate
+  annotations:
+    "helm.sh/hook": pre-install`,
+			"templates/tests/test-connection.yaml": `apiVersion: v1
+kind: Pod
+metadata:
+  name: {{ .Release.Name }}-test
+  annotations:
+    "helm.sh/hook": test`,
+		},
+	}
+	out, err := ch.Render(helm.Release{Name: "app"}, helm.Capabilities{}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `
+---
+# Source: test/templates/cm.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app
+`[1:])
+}
+
+func (s *renderSuite) TestRenderTpl(c *gc.C) {
+	ch := &helm.Chart{
+		Metadata: helm.Metadata{Name: "test"},
+		Templates: map[string]string{
+			"templates/_helpers.tpl": `{{- define "test.name" -}}{{ .Release.Name }}-test{{- end -}}`,
+			"templates/cm.yaml":      `data: {{ tpl .Values.greeting . }}`,
+		},
+	}
+	out, err := ch.Render(helm.Release{Name: "app"}, helm.Capabilities{}, map[string]interface{}{
+		"greeting": `hello {{ include "test.name" . }}{{ .Values.missing }}`,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `
+---
+# Source: test/templates/cm.yaml
+data: hello app-test
+`[1:])
+}
+
+func (s *renderSuite) TestRenderFilesAndCapabilities(c *gc.C) {
+	ch := &helm.Chart{
+		Metadata: helm.Metadata{Name: "test"},
+		Templates: map[string]string{
+			"templates/cm.yaml": `kubeVersion: {{ .Capabilities.KubeVersion.Major }}.{{ .Capabilities.KubeVersion.Minor }}
+hasIngress: {{ .Capabilities.APIVersions.Has "networking.k8s.io/v1" }}
+template: {{ .Template.Name }}
+config: {{ .Files.Get "config/my.cnf" | quote }}
+data:
+{{ (.Files.Glob "config/*").AsConfig | indent 2 }}`,
+		},
+		Files: map[string][]byte{
+			"config/my.cnf": []byte("[mysqld]"),
+		},
+	}
+	out, err := ch.Render(helm.Release{Name: "app"}, helm.Capabilities{
+		KubeVersion: "v1.18.3",
+		APIVersions: []string{"v1", "apps/v1", "networking.k8s.io/v1"},
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `
+---
+# Source: test/templates/cm.yaml
+kubeVersion: 1.18
+hasIngress: true
+template: test/templates/cm.yaml
+config: "[mysqld]"
+data:
+  my.cnf: '[mysqld]'
+`[1:])
+}
+
+func (s *renderSuite) TestValidate(c *gc.C) {
+	c.Assert(s.loadChart(c).Validate(), jc.ErrorIsNil)
+}
+
+func (s *renderSuite) TestValidateUnsupportedFunction(c *gc.C) {
+	ch := &helm.Chart{
+		Metadata: helm.Metadata{Name: "test"},
+		Templates: map[string]string{
+			"templates/cm.yaml": `data: {{ .Values.name | semverCompare ">=1.0" }}`,
+		},
+	}
+	err := ch.Validate()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, `parsing template "templates/cm.yaml": .*function "semverCompare" not defined`)
+}
+
+func (s *renderSuite) TestMergeValues(c *gc.C) {
+	defaults := map[string]interface{}{
+		"image": map[string]interface{}{
+			"repository": "mariadb",
+			"tag":        "10.5",
+		},
+		"replicas": 1,
+	}
+	merged := helm.MergeValues(defaults, map[string]interface{}{
+		"image.tag": "10.6",
+		"resources": map[interface{}]interface{}{"cpu": "100m"},
+	})
+	c.Assert(merged, jc.DeepEquals, map[string]interface{}{
+		"image": map[string]interface{}{
+			"repository": "mariadb",
+			"tag":        "10.6",
+		},
+		"replicas":  1,
+		"resources": map[string]interface{}{"cpu": "100m"},
+	})
+	// The defaults are not changed.
+	c.Assert(defaults["image"].(map[string]interface{})["tag"], gc.Equals, "10.5")
+}
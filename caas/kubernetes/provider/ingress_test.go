@@ -100,6 +100,10 @@ func (s *K8sBrokerSuite) assertIngressResources(c *gc.C, IngressResources []k8ss
 				Return(statefulSetArg, nil),
 			s.mockStatefulSets.EXPECT().Create(gomock.Any(), statefulSetArg, v1.CreateOptions{}).
 				Return(nil, nil),
+			s.mockHorizontalPodAutoscalers.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+				Return(s.k8sNotFoundError()),
+			s.mockPodDisruptionBudgets.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+				Return(s.k8sNotFoundError()),
 		}...)
 	}
 	gomock.InOrder(assertCalls...)
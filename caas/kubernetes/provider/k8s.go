@@ -43,6 +43,7 @@ import (
 
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/caas/kubernetes/provider/constants"
+	"github.com/juju/juju/caas/kubernetes/provider/helm"
 	k8sspecs "github.com/juju/juju/caas/kubernetes/provider/specs"
 	"github.com/juju/juju/caas/kubernetes/provider/utils"
 	k8swatcher "github.com/juju/juju/caas/kubernetes/provider/watcher"
@@ -109,6 +110,10 @@ type kubernetesClient struct {
 	// creating k8s resources.
 	namespace string
 
+	// namespaceScoped is true when the namespace is an existing one
+	// adopted by the model, and only namespaced resources may be used.
+	namespaceScoped bool
+
 	annotations k8sannotations.Annotation
 
 	lock                        sync.Mutex
@@ -149,10 +154,14 @@ type kubernetesClient struct {
 //go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/dynamic_mock.go -mock_names=Interface=MockDynamicInterface k8s.io/client-go/dynamic Interface,ResourceInterface,NamespaceableResourceInterface
 //go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/admissionregistration_mock.go k8s.io/client-go/kubernetes/typed/admissionregistration/v1beta1  AdmissionregistrationV1beta1Interface,MutatingWebhookConfigurationInterface,ValidatingWebhookConfigurationInterface
 //go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/serviceaccountinformer_mock.go k8s.io/client-go/informers/core/v1 ServiceAccountInformer
+//go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/eventinformer_mock.go k8s.io/client-go/informers/core/v1 EventInformer
 //go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/serviceaccountlister_mock.go k8s.io/client-go/listers/core/v1 ServiceAccountLister,ServiceAccountNamespaceLister
 //go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/sharedindexinformer_mock.go k8s.io/client-go/tools/cache SharedIndexInformer
 //go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/restclient_mock.go -mock_names=Interface=MockRestClientInterface k8s.io/client-go/rest Interface
 //go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/serviceaccount_mock.go k8s.io/client-go/kubernetes/typed/core/v1 ServiceAccountInterface
+//go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/policyv1beta1_mock.go k8s.io/client-go/kubernetes/typed/policy/v1beta1 PolicyV1beta1Interface,PodDisruptionBudgetInterface
+//go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/autoscalingv1_mock.go k8s.io/client-go/kubernetes/typed/autoscaling/v1 AutoscalingV1Interface,HorizontalPodAutoscalerInterface
+//go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/authorizationv1_mock.go k8s.io/client-go/kubernetes/typed/authorization/v1 AuthorizationV1Interface,SelfSubjectAccessReviewInterface
 
 // NewK8sClientFunc defines a function which returns a k8s client based on the supplied config.
 type NewK8sClientFunc func(c *rest.Config) (kubernetes.Interface, apiextensionsclientset.Interface, dynamic.Interface, error)
@@ -200,6 +209,7 @@ func newK8sBroker(
 			informers.WithNamespace(namespace),
 		),
 		namespace:         namespace,
+		namespaceScoped:   newCfg.namespaceScoped(),
 		modelUUID:         modelUUID,
 		newWatcher:        newWatcher,
 		newStringsWatcher: newStringsWatcher,
@@ -333,6 +343,10 @@ func (k *kubernetesClient) PrepareForBootstrap(ctx environs.BootstrapContext, co
 Please bootstrap again and choose a different controller name.`, controllerName),
 	)
 
+	if k.namespaceScoped {
+		return errors.Trace(k.prepareForNamespaceScopedBootstrap())
+	}
+
 	k.namespace = DecideControllerNamespace(controllerName)
 
 	// ensure no existing namespace has the same name.
@@ -364,6 +378,9 @@ Please bootstrap again and choose a different controller name.`, controllerName)
 
 // Create implements environs.BootstrapEnviron.
 func (k *kubernetesClient) Create(envcontext.ProviderCallContext, environs.CreateParams) error {
+	if k.namespaceScoped {
+		return k.adoptNamespace()
+	}
 	// must raise errors.AlreadyExistsf if it's already exist.
 	return k.createNamespace(k.namespace)
 }
@@ -393,7 +410,12 @@ func (k *kubernetesClient) Bootstrap(
 		logger.Debugf("controller pod config: \n%+v", pcfg)
 
 		// validate hosted model name if we need to create it.
-		if hostedModelName, has := pcfg.GetHostedModel(); has {
+		hostedModelName, hasHostedModel := pcfg.GetHostedModel()
+		if hasHostedModel && k.namespaceScoped {
+			// the hosted model would inherit the controller's namespace.
+			return errors.NotSupportedf("adding model %q when bootstrapping a %s controller", hostedModelName, NamespaceScopedKey)
+		}
+		if hasHostedModel {
 			_, err := k.getNamespaceByName(hostedModelName)
 			if err == nil {
 				return errors.NewAlreadyExists(nil,
@@ -410,6 +432,11 @@ please choose a different hosted model name then try again.`, hostedModelName),
 
 		// we use controller name to name controller namespace in bootstrap time.
 		setControllerNamespace := func(controllerName string, broker *kubernetesClient) error {
+			if broker.namespaceScoped {
+				// the existing namespace was checked in PrepareForBootstrap.
+				_ = broker.addAnnotations(constants.AnnotationControllerIsControllerKey, "true")
+				return nil
+			}
 			nsName := DecideControllerNamespace(controllerName)
 
 			_, err := broker.GetNamespace(nsName)
@@ -457,6 +484,13 @@ func (k *kubernetesClient) DestroyController(ctx envcontext.ProviderCallContext,
 			Add(constants.AnnotationControllerUUIDKey, controllerUUID).
 			Add(constants.AnnotationControllerIsControllerKey, "true"),
 	)
+	if k.namespaceScoped {
+		// The controller stack shares the existing namespace, so it's
+		// removed by itself rather than by deleting the namespace.
+		if err := k.DeleteService(JujuControllerStackName); err != nil {
+			return errors.Annotate(err, "removing controller stack")
+		}
+	}
 	return k.Destroy(ctx)
 }
 
@@ -488,6 +522,13 @@ func (k *kubernetesClient) Destroy(callbacks envcontext.ProviderCallContext) (er
 		}
 	}()
 
+	if k.namespaceScoped {
+		// The namespace was not created by Juju, so it's left in place
+		// and there are no cluster-scoped resources to remove.
+		// Application resources are removed with the applications.
+		return errors.Annotatef(k.deleteModelOperator(), "tearing down model in namespace %q", k.namespace)
+	}
+
 	errChan := make(chan error, 1)
 	done := make(chan struct{})
 
@@ -737,31 +778,44 @@ func (k *kubernetesClient) DeleteService(appName string) (err error) {
 	if err := k.deleteAllServiceAccountResources(appName); err != nil {
 		return errors.Trace(err)
 	}
-	// Order matters: delete custom resources first then custom resource definitions.
-	if err := k.deleteCustomResourcesForApp(appName); err != nil {
-		return errors.Trace(err)
-	}
-	if err := k.deleteCustomResourceDefinitionsForApp(appName); err != nil {
-		return errors.Trace(err)
+	// Namespace-scoped models never create cluster-scoped resources.
+	if !k.namespaceScoped {
+		// Order matters: delete custom resources first then custom resource definitions.
+		if err := k.deleteCustomResourcesForApp(appName); err != nil {
+			return errors.Trace(err)
+		}
+		if err := k.deleteCustomResourceDefinitionsForApp(appName); err != nil {
+			return errors.Trace(err)
+		}
+
+		if err := k.deleteMutatingWebhookConfigurationsForApp(appName); err != nil {
+			return errors.Trace(err)
+		}
+		if err := k.deleteValidatingWebhookConfigurationsForApp(appName); err != nil {
+			return errors.Trace(err)
+		}
 	}
 
-	if err := k.deleteMutatingWebhookConfigurationsForApp(appName); err != nil {
+	if err := k.deleteIngressResources(appName); err != nil {
 		return errors.Trace(err)
 	}
-	if err := k.deleteValidatingWebhookConfigurationsForApp(appName); err != nil {
+
+	if err := k.deleteDaemonSets(appName); err != nil {
 		return errors.Trace(err)
 	}
 
-	if err := k.deleteIngressResources(appName); err != nil {
+	if err := k.deletePodDisruptionBudgets(appName); err != nil {
 		return errors.Trace(err)
 	}
-
-	if err := k.deleteDaemonSets(appName); err != nil {
+	if err := k.deleteHorizontalPodAutoscalers(appName); err != nil {
 		return errors.Trace(err)
 	}
 	return nil
 }
 
+// zoneTopologyKey is the node label holding the node's availability zone.
+const zoneTopologyKey = "failure-domain.beta.kubernetes.io/zone"
+
 func processConstraints(pod *core.PodSpec, appName string, cons constraints.Value) error {
 	// TODO(caas): Allow constraints to be set at the container level.
 	if mem := cons.Mem; mem != nil {
@@ -845,10 +899,23 @@ func processConstraints(pod *core.PodSpec, appName string, cons constraints.Valu
 		nodeSelector := &affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0]
 		nodeSelector.MatchExpressions = append(nodeSelector.MatchExpressions,
 			core.NodeSelectorRequirement{
-				Key:      "failure-domain.beta.kubernetes.io/zone",
+				Key:      zoneTopologyKey,
 				Operator: core.NodeSelectorOpIn,
 				Values:   zones,
 			})
+
+		// Spread the pods evenly across the zones so that losing a
+		// single zone, or draining its nodes, leaves replicas running.
+		if len(zones) > 1 {
+			pod.TopologySpreadConstraints = append(pod.TopologySpreadConstraints, core.TopologySpreadConstraint{
+				MaxSkew:           1,
+				TopologyKey:       zoneTopologyKey,
+				WhenUnsatisfiable: core.DoNotSchedule,
+				LabelSelector: &v1.LabelSelector{
+					MatchLabels: utils.LabelsForApp(appName),
+				},
+			})
+		}
 	}
 	return nil
 }
@@ -884,13 +951,71 @@ func (k *kubernetesClient) applyRawK8sSpec(
 	}
 	annotations := utils.ResourceTagsToAnnotations(params.ResourceTags)
 
+	// Namespace-scoped models never create cluster-scoped resources.
 	builder := k8sspecs.New(
 		deploymentName, k.namespace, params.Deployment, k.k8sConfig(),
-		labelGetter, annotations, k.newRestClient,
+		labelGetter, annotations, k.newRestClient, k.namespaceScoped,
 	)
 	ctx, cancel := context.WithTimeout(context.Background(), applyRawSpecTimeoutSeconds*time.Second)
 	defer cancel()
-	return builder.Deploy(ctx, params.RawK8sSpec, true)
+	err = builder.Deploy(ctx, params.RawK8sSpec, true)
+	if errors.IsForbidden(err) {
+		return errors.Annotatef(err, "application %q in namespace-scoped model %q", appName, k.CurrentModel())
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	_, err = k.ensureRawWorkloadPodDisruptionBudget(appName, deploymentName, params.Deployment.DeploymentType, annotations.Copy(), config)
+	return errors.Annotate(err, "creating or updating pod disruption budget")
+}
+
+// applyHelmChart renders the application's Helm chart and applies the
+// resulting objects in the same way as a raw k8s spec, so they are
+// labelled, upgraded and removed like other Juju managed objects.
+func (k *kubernetesClient) applyHelmChart(
+	appName, deploymentName string,
+	statusCallback caas.StatusCallbackFunc,
+	params *caas.ServiceParams,
+	numUnits int,
+	config application.ConfigAttributes,
+) error {
+	chart, err := helm.Load(bytes.NewReader(params.HelmChart.Archive))
+	if err != nil {
+		return errors.Annotate(err, "loading helm chart")
+	}
+	caps, err := k.helmCapabilities()
+	if err != nil {
+		return errors.Annotate(err, "getting cluster capabilities")
+	}
+	manifest, err := chart.Render(helm.Release{Name: appName, Namespace: k.namespace}, caps, params.HelmChart.Values)
+	if err != nil {
+		return errors.Annotatef(err, "rendering helm chart %q", chart.Metadata.Name)
+	}
+	logger.Debugf("rendered helm chart %q version %q for %q", chart.Metadata.Name, chart.Metadata.Version, appName)
+	params.RawK8sSpec = manifest
+	return k.applyRawK8sSpec(appName, deploymentName, statusCallback, params, numUnits, config)
+}
+
+// helmCapabilities returns the cluster's version and API group versions,
+// which charts see as .Capabilities.
+func (k *kubernetesClient) helmCapabilities() (helm.Capabilities, error) {
+	discovery := k.client().Discovery()
+	k8sver, err := discovery.ServerVersion()
+	if err != nil {
+		return helm.Capabilities{}, errors.Trace(err)
+	}
+	groups, err := discovery.ServerGroups()
+	if err != nil {
+		return helm.Capabilities{}, errors.Trace(err)
+	}
+	caps := helm.Capabilities{KubeVersion: k8sver.GitVersion}
+	for _, group := range groups.Groups {
+		for _, v := range group.Versions {
+			caps.APIVersions = append(caps.APIVersions, v.GroupVersion)
+		}
+	}
+	return caps, nil
 }
 
 // EnsureService creates or updates a service for pods with the given params.
@@ -921,8 +1046,10 @@ func (k *kubernetesClient) EnsureService(
 		return k.ensureService(appName, deploymentName, statusCallback, params, numUnits, config)
 	} else if len(params.RawK8sSpec) > 0 {
 		return k.applyRawK8sSpec(appName, deploymentName, statusCallback, params, numUnits, config)
+	} else if params.HelmChart != nil {
+		return k.applyHelmChart(appName, deploymentName, statusCallback, params, numUnits, config)
 	}
-	return errors.NewNotSupported(nil, "currently only k8s-raw-set and k8s-spec-set are supported")
+	return errors.NewNotSupported(nil, "currently only k8s-raw-set, k8s-spec-set and helm charts are supported")
 }
 
 func (k *kubernetesClient) ensureService(
@@ -956,6 +1083,9 @@ func (k *kubernetesClient) ensureService(
 	if err != nil {
 		return errors.Annotatef(err, "parsing unit spec for %s", appName)
 	}
+	if err := k.checkNamespaceScopedWorkload(appName, workloadSpec); err != nil {
+		return errors.Trace(err)
+	}
 
 	annotations := utils.ResourceTagsToAnnotations(params.ResourceTags)
 
@@ -1126,7 +1256,16 @@ func (k *kubernetesClient) ensureService(
 		}
 	}
 
+	autoscale, err := getAutoscaleParams(config)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	numPods := int32(numUnits)
+	if autoscale != nil {
+		// Don't fight the autoscaler by asking for a replica count
+		// outside of the range it manages.
+		numPods = autoscale.clamp(numPods)
+	}
 	workloadResourceAnnotations := annotations.Copy().
 		// To solve https://bugs.launchpad.net/juju/+bug/1875481/comments/23 (`jujud caas-unit-init --upgrade`
 		// does NOT work on containers are not using root as default USER),
@@ -1161,6 +1300,19 @@ func (k *kubernetesClient) ensureService(
 		// This should never happened because we have validated both in this method and in `charm.v6`.
 		return errors.NotSupportedf("deployment type %q", params.Deployment.DeploymentType)
 	}
+
+	hpaCleanUps, err := k.ensureHorizontalPodAutoscaler(appName, deploymentName, params.Deployment.DeploymentType, annotations.Copy(), autoscale)
+	cleanups = append(cleanups, hpaCleanUps...)
+	if err != nil {
+		return errors.Annotate(err, "creating or updating horizontal pod autoscaler")
+	}
+
+	pdbSelector := &v1.LabelSelector{MatchLabels: utils.LabelsForApp(appName)}
+	pdbCleanUps, err := k.ensurePodDisruptionBudget(appName, deploymentName, annotations.Copy(), pdbSelector, config)
+	cleanups = append(cleanups, pdbCleanUps...)
+	if err != nil {
+		return errors.Annotate(err, "creating or updating pod disruption budget")
+	}
 	return nil
 }
 
@@ -2136,21 +2288,53 @@ func (k *kubernetesClient) getPODStatus(pod core.Pod, now time.Time) (string, st
 		}
 	}
 
-	if statusMessage == "" {
+	needsAttention := podNeedsAttention(pod)
+	if statusMessage == "" || needsAttention {
 		// If there are any events for this pod we can use the
 		// most recent to set the status.
 		eventList, err := k.getEvents(pod.Name, "Pod")
 		if err != nil {
 			return "", "", time.Time{}, errors.Trace(err)
 		}
-		// Take the most recent event.
-		if count := len(eventList); count > 0 {
+		if warning := latestWarningEvent(eventList); warning != nil && needsAttention {
+			// Surface why the pod is not running, eg image pull
+			// back offs or scheduling failures.
+			statusMessage = withWarning(statusMessage, warning)
+		} else if count := len(eventList); count > 0 && statusMessage == "" {
+			// Take the most recent event.
 			statusMessage = eventList[count-1].Message
 		}
 	}
+	// Kubernetes records no event when a container is OOM killed, only
+	// the reason it was terminated.
+	if oomKilled := oomKilledMessage(pod); oomKilled != "" && needsAttention {
+		if statusMessage == "" {
+			statusMessage = oomKilled
+		} else if !strings.Contains(statusMessage, oomKilledReason) {
+			statusMessage += " (" + oomKilled + ")"
+		}
+	}
 	return statusMessage, jujuStatus, since, nil
 }
 
+// podNeedsAttention returns true if the pod is not being terminated and is
+// not running, or has containers which are not running.
+func podNeedsAttention(pod core.Pod) bool {
+	if pod.DeletionTimestamp != nil {
+		return false
+	}
+	switch pod.Status.Phase {
+	case core.PodPending, core.PodFailed:
+		return true
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.State.Waiting != nil || cs.State.Terminated != nil {
+			return true
+		}
+	}
+	return false
+}
+
 func (k *kubernetesClient) getStatefulSetStatus(ss *apps.StatefulSet) (string, status.Status, error) {
 	terminated := ss.DeletionTimestamp != nil
 	jujuStatus := status.Waiting
@@ -2193,15 +2377,20 @@ func (k *kubernetesClient) getStatusFromEvents(name, kind string, jujuStatus sta
 		return "", "", errors.Trace(err)
 	}
 	var statusMessage string
-	// Take the most recent event.
-	if count := len(events); count > 0 {
-		evt := events[count-1]
-		if jujuStatus == "" {
-			if evt.Type == core.EventTypeWarning && evt.Reason == "FailedCreate" {
-				jujuStatus = status.Blocked
-				statusMessage = evt.Message
-			}
-		}
+	if jujuStatus != "" && jujuStatus != status.Waiting {
+		return statusMessage, jujuStatus, nil
+	}
+	// Attach the most recent warning while the workload is not ready,
+	// unless the workload has since reported progress.
+	if warning := currentWarningEvent(events); warning != nil {
+		statusMessage = warningMessage(warning)
+	}
+	// The workload is blocked while the most recent event is a failure
+	// to create its pods.
+	if latest := latestEvent(events, ""); latest != nil &&
+		latest.Type == core.EventTypeWarning && latest.Reason == "FailedCreate" {
+		jujuStatus = status.Blocked
+		statusMessage = warningMessage(latest)
 	}
 	return statusMessage, jujuStatus, nil
 }
@@ -1936,6 +1936,18 @@ func (s *K8sBrokerSuite) TestDeleteServiceForApplication(c *gc.C) {
 			s.deleteOptions(v1.DeletePropagationForeground, ""),
 			v1.ListOptions{LabelSelector: "juju-app=test"},
 		).Return(nil),
+
+		// delete all pod disruption budgets.
+		s.mockPodDisruptionBudgets.EXPECT().DeleteCollection(gomock.Any(),
+			s.deleteOptions(v1.DeletePropagationForeground, ""),
+			v1.ListOptions{LabelSelector: "juju-app=test"},
+		).Return(nil),
+
+		// delete all horizontal pod autoscalers.
+		s.mockHorizontalPodAutoscalers.EXPECT().DeleteCollection(gomock.Any(),
+			s.deleteOptions(v1.DeletePropagationForeground, ""),
+			v1.ListOptions{LabelSelector: "juju-app=test"},
+		).Return(nil),
 	)
 
 	err := s.broker.DeleteService("test")
@@ -2054,6 +2066,10 @@ func (s *K8sBrokerSuite) TestEnsureServiceNoStorage(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Create(gomock.Any(), deploymentArg, v1.CreateOptions{}).
 			Return(nil, nil),
+		s.mockHorizontalPodAutoscalers.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+		s.mockPodDisruptionBudgets.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -2112,6 +2128,30 @@ func (s *K8sBrokerSuite) TestEnsureServiceStatelessWithScalePolicyInvalid(c *gc.
 	c.Assert(err, gc.ErrorMatches, `ScalePolicy is only supported for stateful applications`)
 }
 
+func (s *K8sBrokerSuite) TestEnsureServiceHelmChartInvalid(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+	)
+
+	params := &caas.ServiceParams{
+		HelmChart: &caas.HelmChartParams{
+			Archive: []byte("not a chart"),
+		},
+	}
+	var statusMessage string
+	err := s.broker.EnsureService("app-name", func(_ string, st status.Status, message string, _ map[string]interface{}) error {
+		c.Check(st, gc.Equals, status.Error)
+		statusMessage = message
+		return nil
+	}, params, 1, nil)
+	c.Assert(err, gc.ErrorMatches, `loading helm chart: reading helm chart: .*`)
+	c.Assert(statusMessage, gc.Equals, err.Error())
+}
+
 func (s *K8sBrokerSuite) TestEnsureServiceWithExtraServicesConfigMapAndSecretsCreate(c *gc.C) {
 	ctrl := s.setupController(c)
 	defer ctrl.Finish()
@@ -2344,6 +2384,10 @@ password: shhhh`[1:],
 			Return(nil, s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Create(gomock.Any(), deploymentArg, v1.CreateOptions{}).
 			Return(nil, nil),
+		s.mockHorizontalPodAutoscalers.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+		s.mockPodDisruptionBudgets.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -2606,6 +2650,10 @@ password: shhhh`[1:],
 			Return(nil, s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Create(gomock.Any(), deploymentArg, v1.CreateOptions{}).
 			Return(nil, nil),
+		s.mockHorizontalPodAutoscalers.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+		s.mockPodDisruptionBudgets.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -3054,6 +3102,10 @@ func (s *K8sBrokerSuite) TestEnsureServiceNoStorageStateful(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockStatefulSets.EXPECT().Create(gomock.Any(), statefulSetArg, v1.CreateOptions{}).
 			Return(nil, nil),
+		s.mockHorizontalPodAutoscalers.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+		s.mockPodDisruptionBudgets.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -3140,6 +3192,10 @@ func (s *K8sBrokerSuite) TestEnsureServiceCustomType(c *gc.C) {
 			Return(statefulSetArg, nil),
 		s.mockStatefulSets.EXPECT().Create(gomock.Any(), statefulSetArg, v1.CreateOptions{}).
 			Return(nil, nil),
+		s.mockHorizontalPodAutoscalers.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+		s.mockPodDisruptionBudgets.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -3345,6 +3401,10 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithServiceAccountNewRoleCreate(c *gc.
 			Return(nil, s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Create(gomock.Any(), deploymentArg, v1.CreateOptions{}).
 			Return(nil, nil),
+		s.mockHorizontalPodAutoscalers.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+		s.mockPodDisruptionBudgets.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -3517,6 +3577,10 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithServiceAccountNewRoleUpdate(c *gc.
 			Return(nil, s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Create(gomock.Any(), deploymentArg, v1.CreateOptions{}).
 			Return(nil, nil),
+		s.mockHorizontalPodAutoscalers.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+		s.mockPodDisruptionBudgets.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -3707,6 +3771,10 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithServiceAccountNewClusterRoleCreate
 			Return(nil, s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Create(gomock.Any(), deploymentArg, v1.CreateOptions{}).
 			Return(nil, nil),
+		s.mockHorizontalPodAutoscalers.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+		s.mockPodDisruptionBudgets.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -3895,6 +3963,10 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithServiceAccountNewClusterRoleUpdate
 			Return(nil, s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Create(gomock.Any(), deploymentArg, v1.CreateOptions{}).
 			Return(nil, nil),
+		s.mockHorizontalPodAutoscalers.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+		s.mockPodDisruptionBudgets.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -4158,6 +4230,10 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithServiceAccountAndK8sServiceAccount
 			Return(nil, s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Create(gomock.Any(), deploymentArg, v1.CreateOptions{}).
 			Return(nil, nil),
+		s.mockHorizontalPodAutoscalers.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+		s.mockPodDisruptionBudgets.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -4430,6 +4506,10 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithServiceAccountAndK8sServiceAccount
 			Return(nil, s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Create(gomock.Any(), deploymentArg, v1.CreateOptions{}).
 			Return(nil, nil),
+		s.mockHorizontalPodAutoscalers.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+		s.mockPodDisruptionBudgets.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -4754,6 +4834,10 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithServiceAccountAndK8sServiceAccount
 			Return(nil, s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Create(gomock.Any(), deploymentArg, v1.CreateOptions{}).
 			Return(nil, nil),
+		s.mockHorizontalPodAutoscalers.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+		s.mockPodDisruptionBudgets.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -4824,6 +4908,10 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithStorage(c *gc.C) {
 			Return(&storagev1.StorageClass{ObjectMeta: v1.ObjectMeta{Name: "workload-storage"}}, nil),
 		s.mockStatefulSets.EXPECT().Create(gomock.Any(), statefulSetArg, v1.CreateOptions{}).
 			Return(nil, nil),
+		s.mockHorizontalPodAutoscalers.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+		s.mockPodDisruptionBudgets.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -4930,6 +5018,10 @@ func (s *K8sBrokerSuite) TestEnsureServiceForDeploymentWithDevices(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Create(gomock.Any(), deploymentArg, v1.CreateOptions{}).
 			Return(deploymentArg, nil),
+		s.mockHorizontalPodAutoscalers.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+		s.mockPodDisruptionBudgets.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -5063,6 +5155,10 @@ func (s *K8sBrokerSuite) TestEnsureServiceForDeploymentWithStorageCreate(c *gc.C
 			Return(nil, s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Create(gomock.Any(), deploymentArg, v1.CreateOptions{}).
 			Return(deploymentArg, nil),
+		s.mockHorizontalPodAutoscalers.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+		s.mockPodDisruptionBudgets.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -5212,6 +5308,10 @@ func (s *K8sBrokerSuite) TestEnsureServiceForDeploymentWithStorageUpdate(c *gc.C
 			Return(pvc, nil),
 		s.mockDeployments.EXPECT().Update(gomock.Any(), deploymentArg, v1.UpdateOptions{}).
 			Return(deploymentArg, nil),
+		s.mockHorizontalPodAutoscalers.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+		s.mockPodDisruptionBudgets.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -5377,6 +5477,10 @@ func (s *K8sBrokerSuite) TestEnsureServiceForDaemonSetWithStorageCreate(c *gc.C)
 			Return(pvc, nil),
 		s.mockDaemonSets.EXPECT().Create(gomock.Any(), daemonSetArg, v1.CreateOptions{}).
 			Return(daemonSetArg, nil),
+		s.mockHorizontalPodAutoscalers.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+		s.mockPodDisruptionBudgets.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -5552,6 +5656,10 @@ func (s *K8sBrokerSuite) TestEnsureServiceForDaemonSetWithStorageUpdate(c *gc.C)
 		}).Return(&appsv1.DaemonSetList{Items: []appsv1.DaemonSet{*daemonSetArg}}, nil),
 		s.mockDaemonSets.EXPECT().Update(gomock.Any(), daemonSetArg, v1.UpdateOptions{}).
 			Return(daemonSetArg, nil),
+		s.mockHorizontalPodAutoscalers.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+		s.mockPodDisruptionBudgets.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -5680,6 +5788,10 @@ func (s *K8sBrokerSuite) TestEnsureServiceForDaemonSetWithDevicesAndConstraintsC
 			Return(nil, s.k8sNotFoundError()),
 		s.mockDaemonSets.EXPECT().Create(gomock.Any(), daemonSetArg, v1.CreateOptions{}).
 			Return(daemonSetArg, nil),
+		s.mockHorizontalPodAutoscalers.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+		s.mockPodDisruptionBudgets.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -5802,6 +5914,10 @@ func (s *K8sBrokerSuite) TestEnsureServiceForDaemonSetWithDevicesAndConstraintsU
 		}).Return(&appsv1.DaemonSetList{Items: []appsv1.DaemonSet{*daemonSetArg}}, nil),
 		s.mockDaemonSets.EXPECT().Update(gomock.Any(), daemonSetArg, v1.UpdateOptions{}).
 			Return(daemonSetArg, nil),
+		s.mockHorizontalPodAutoscalers.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+		s.mockPodDisruptionBudgets.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -5880,6 +5996,10 @@ func (s *K8sBrokerSuite) TestEnsureServiceForStatefulSetWithDevices(c *gc.C) {
 			Return(&storagev1.StorageClass{ObjectMeta: v1.ObjectMeta{Name: "workload-storage"}}, nil),
 		s.mockStatefulSets.EXPECT().Create(gomock.Any(), statefulSetArg, v1.CreateOptions{}).
 			Return(statefulSetArg, nil),
+		s.mockHorizontalPodAutoscalers.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+		s.mockPodDisruptionBudgets.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -5961,6 +6081,10 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithConstraints(c *gc.C) {
 			Return(&storagev1.StorageClass{ObjectMeta: v1.ObjectMeta{Name: "workload-storage"}}, nil),
 		s.mockStatefulSets.EXPECT().Create(gomock.Any(), statefulSetArg, v1.CreateOptions{}).
 			Return(nil, nil),
+		s.mockHorizontalPodAutoscalers.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+		s.mockPodDisruptionBudgets.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -6049,6 +6173,10 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithNodeAffinity(c *gc.C) {
 			Return(&storagev1.StorageClass{ObjectMeta: v1.ObjectMeta{Name: "workload-storage"}}, nil),
 		s.mockStatefulSets.EXPECT().Create(gomock.Any(), statefulSetArg, v1.CreateOptions{}).
 			Return(nil, nil),
+		s.mockHorizontalPodAutoscalers.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+		s.mockPodDisruptionBudgets.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -6102,6 +6230,14 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithZones(c *gc.C) {
 			},
 		},
 	}
+	podSpec.TopologySpreadConstraints = []core.TopologySpreadConstraint{{
+		MaxSkew:           1,
+		TopologyKey:       "failure-domain.beta.kubernetes.io/zone",
+		WhenUnsatisfiable: core.DoNotSchedule,
+		LabelSelector: &v1.LabelSelector{
+			MatchLabels: map[string]string{"juju-app": "app-name"},
+		},
+	}}
 	statefulSetArg := unitStatefulSetArg(2, "workload-storage", podSpec)
 	ociImageSecret := s.getOCIImageSecret(c, nil)
 	gomock.InOrder(
@@ -6129,6 +6265,10 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithZones(c *gc.C) {
 			Return(&storagev1.StorageClass{ObjectMeta: v1.ObjectMeta{Name: "workload-storage"}}, nil),
 		s.mockStatefulSets.EXPECT().Create(gomock.Any(), statefulSetArg, v1.CreateOptions{}).
 			Return(nil, nil),
+		s.mockHorizontalPodAutoscalers.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+		s.mockPodDisruptionBudgets.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -6290,6 +6430,122 @@ func (s *K8sBrokerSuite) TestUnits(c *gc.C) {
 	}})
 }
 
+func (s *K8sBrokerSuite) TestUnitsWithWarningEvent(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	podList := &core.PodList{
+		Items: []core.Pod{{
+			ObjectMeta: v1.ObjectMeta{
+				Name: "pod-name",
+				UID:  types.UID("uuid"),
+			},
+			Status: core.PodStatus{
+				Phase: core.PodPending,
+				ContainerStatuses: []core.ContainerStatus{{
+					State: core.ContainerState{
+						Waiting: &core.ContainerStateWaiting{Reason: "ImagePullBackOff"},
+					},
+				}},
+			},
+			Spec: core.PodSpec{
+				Containers: []core.Container{{}},
+			},
+		}},
+	}
+	now := s.clock.Now()
+	eventList := &core.EventList{
+		Items: []core.Event{{
+			Type:          core.EventTypeWarning,
+			Reason:        "BackOff",
+			Message:       `Back-off pulling image "mariadb"`,
+			LastTimestamp: v1.NewTime(now.Add(-time.Second)),
+		}, {
+			Type:          core.EventTypeWarning,
+			Reason:        "Failed",
+			Message:       `Failed to pull image "mariadb"`,
+			LastTimestamp: v1.NewTime(now.Add(-time.Minute)),
+		}, {
+			Type:          core.EventTypeNormal,
+			Reason:        "Pulling",
+			Message:       `Pulling image "mariadb"`,
+			LastTimestamp: v1.NewTime(now),
+		}},
+	}
+	gomock.InOrder(
+		s.mockPods.EXPECT().List(gomock.Any(), v1.ListOptions{LabelSelector: "juju-app=app-name"}).Return(podList, nil),
+		s.mockEvents.EXPECT().List(gomock.Any(),
+			listOptionsFieldSelectorMatcher("involvedObject.name=pod-name,involvedObject.kind=Pod"),
+		).Return(eventList, nil),
+	)
+
+	units, err := s.broker.Units("app-name", caas.ModeWorkload)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, jc.DeepEquals, []caas.Unit{{
+		Id: "uuid",
+		Status: status.StatusInfo{
+			Status:  "allocating",
+			Message: `BackOff: Back-off pulling image "mariadb"`,
+			Since:   &now,
+		},
+	}})
+}
+
+func (s *K8sBrokerSuite) TestUnitsOOMKilled(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	podList := &core.PodList{
+		Items: []core.Pod{{
+			ObjectMeta: v1.ObjectMeta{
+				Name: "pod-name",
+				UID:  types.UID("uuid"),
+			},
+			Status: core.PodStatus{
+				Phase: core.PodRunning,
+				ContainerStatuses: []core.ContainerStatus{{
+					Name: "mariadb",
+					State: core.ContainerState{
+						Waiting: &core.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+					},
+					LastTerminationState: core.ContainerState{
+						Terminated: &core.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137},
+					},
+				}},
+			},
+			Spec: core.PodSpec{
+				Containers: []core.Container{{}},
+			},
+		}},
+	}
+	now := s.clock.Now()
+	eventList := &core.EventList{
+		Items: []core.Event{{
+			Type:          core.EventTypeWarning,
+			Reason:        "BackOff",
+			Message:       "Back-off restarting failed container",
+			LastTimestamp: v1.NewTime(now.Add(-time.Second)),
+		}},
+	}
+	gomock.InOrder(
+		s.mockPods.EXPECT().List(gomock.Any(), v1.ListOptions{LabelSelector: "juju-app=app-name"}).Return(podList, nil),
+		s.mockEvents.EXPECT().List(gomock.Any(),
+			listOptionsFieldSelectorMatcher("involvedObject.name=pod-name,involvedObject.kind=Pod"),
+		).Return(eventList, nil),
+	)
+
+	units, err := s.broker.Units("app-name", caas.ModeWorkload)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, jc.DeepEquals, []caas.Unit{{
+		Id: "uuid",
+		Status: status.StatusInfo{
+			Status:  "running",
+			Message: `BackOff: Back-off restarting failed container (OOMKilled: container "mariadb" ran out of memory)`,
+			Since:   &now,
+		},
+	}})
+}
+
 func (s *K8sBrokerSuite) TestWatchService(c *gc.C) {
 	ctrl := s.setupController(c)
 	defer ctrl.Finish()
@@ -344,6 +344,17 @@ func (k *kubernetesClient) modelOperatorDeploymentExists(operatorName string) (b
 	return true, nil
 }
 
+// deleteModelOperator removes the model operator's resources.
+func (k *kubernetesClient) deleteModelOperator() error {
+	if err := k.deleteDeployment(modelOperatorName); err != nil {
+		return errors.Trace(err)
+	}
+	if err := k.deleteService(modelOperatorName); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(k.deleteConfigMap(modelOperatorName, ""))
+}
+
 func modelOperatorLabels(operatorName string) map[string]string {
 	return map[string]string{
 		constants.LabelModelOperator: operatorName,
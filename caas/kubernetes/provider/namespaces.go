@@ -37,7 +37,16 @@ func checkNamespaceOwnedByJuju(ns *core.Namespace, annotationMap map[string]stri
 }
 
 // Namespaces returns names of the namespaces on the cluster.
+// A namespace-scoped model can only see its own namespace.
 func (k *kubernetesClient) Namespaces() ([]string, error) {
+	if k.namespaceScoped {
+		// Listing pods checks the credential can access the namespace.
+		_, err := k.client().CoreV1().Pods(k.namespace).List(context.TODO(), v1.ListOptions{Limit: 1})
+		if err != nil {
+			return nil, errors.Annotatef(err, "accessing namespace %q", k.namespace)
+		}
+		return []string{k.namespace}, nil
+	}
 	namespaces := k.client().CoreV1().Namespaces()
 	ns, err := namespaces.List(context.TODO(), v1.ListOptions{})
 	if err != nil {
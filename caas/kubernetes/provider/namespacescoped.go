@@ -0,0 +1,170 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	k8sspecs "github.com/juju/juju/caas/kubernetes/provider/specs"
+)
+
+// namespacedPermissions are the permissions a namespace-scoped model needs
+// in its namespace to run operators and applications.
+var namespacedPermissions = []authorizationv1.ResourceAttributes{
+	{Verb: "watch", Resource: "pods"},
+	{Verb: "create", Resource: "pods", Subresource: "exec"},
+	{Verb: "create", Resource: "services"},
+	{Verb: "create", Resource: "configmaps"},
+	{Verb: "create", Resource: "secrets"},
+	{Verb: "create", Resource: "serviceaccounts"},
+	{Verb: "create", Resource: "persistentvolumeclaims"},
+	{Verb: "create", Group: "apps", Resource: "statefulsets"},
+	{Verb: "create", Group: "apps", Resource: "deployments"},
+	{Verb: "create", Group: "rbac.authorization.k8s.io", Resource: "roles"},
+	{Verb: "create", Group: "rbac.authorization.k8s.io", Resource: "rolebindings"},
+}
+
+// clusterReadPermissions are the cluster-scoped permissions a
+// namespace-scoped model needs; storage classes are looked up when
+// provisioning operator and workload storage.
+var clusterReadPermissions = []authorizationv1.ResourceAttributes{
+	{Verb: "get", Group: "storage.k8s.io", Resource: "storageclasses"},
+}
+
+// permissionString formats a permission the way kubectl auth can-i takes it,
+// eg "create statefulsets.apps".
+func permissionString(attr authorizationv1.ResourceAttributes) string {
+	resource := attr.Resource
+	if attr.Subresource != "" {
+		resource += "/" + attr.Subresource
+	}
+	if attr.Group != "" {
+		resource += "." + attr.Group
+	}
+	return attr.Verb + " " + resource
+}
+
+// missingPermissions returns the permissions which are not granted to the
+// credential used by the broker.
+func (k *kubernetesClient) missingPermissions(attrs []authorizationv1.ResourceAttributes) ([]string, error) {
+	var missing []string
+	for _, attr := range attrs {
+		attr := attr
+		review := &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{ResourceAttributes: &attr},
+		}
+		out, err := k.client().AuthorizationV1().SelfSubjectAccessReviews().Create(context.TODO(), review, v1.CreateOptions{})
+		if err != nil {
+			return nil, errors.Annotatef(err, "checking permission to %s", permissionString(attr))
+		}
+		if !out.Status.Allowed {
+			missing = append(missing, permissionString(attr))
+		}
+	}
+	return missing, nil
+}
+
+// adoptNamespace checks that the existing namespace of a namespace-scoped
+// model can be used to deploy applications.
+func (k *kubernetesClient) adoptNamespace() error {
+	var attrs []authorizationv1.ResourceAttributes
+	for _, attr := range namespacedPermissions {
+		attr.Namespace = k.namespace
+		attrs = append(attrs, attr)
+	}
+	attrs = append(attrs, clusterReadPermissions...)
+	missing, err := k.missingPermissions(attrs)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(missing) > 0 {
+		return errors.NewForbidden(nil, fmt.Sprintf(
+			"cannot use namespace %q, missing permissions: %s", k.namespace, strings.Join(missing, ", "),
+		))
+	}
+	logger.Debugf("adopted existing namespace %q", k.namespace)
+	return nil
+}
+
+// prepareForNamespaceScopedBootstrap checks that a namespace-scoped
+// controller can be bootstrapped into the configured existing namespace.
+func (k *kubernetesClient) prepareForNamespaceScopedBootstrap() error {
+	cfg, err := providerInstance.newConfig(k.Config())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if cfg.namespace() == "" {
+		return errors.NewNotValid(nil, fmt.Sprintf(
+			"bootstrapping a %s controller requires %q to be set", NamespaceScopedKey, NamespaceKey,
+		))
+	}
+	k.namespace = cfg.namespace()
+	if err := k.adoptNamespace(); err != nil {
+		return errors.Trace(err)
+	}
+	_, err = k.validateOperatorStorage()
+	return errors.Trace(err)
+}
+
+// requiresClusterRBAC returns true if the RBAC spec has global roles,
+// which are created as cluster roles.
+func requiresClusterRBAC(rbac k8sspecs.K8sRBACSpecConverter) bool {
+	getMeta := func(string) v1.ObjectMeta { return v1.ObjectMeta{} }
+	getRoleMeta := func(string, string, int) v1.ObjectMeta { return v1.ObjectMeta{} }
+	getBindingMeta := func(_, _ k8sspecs.NameGetter) v1.ObjectMeta { return v1.ObjectMeta{} }
+	_, _, clusterRoles, _, clusterRoleBindings := rbac.ToK8s(
+		getMeta, getRoleMeta, getRoleMeta, getBindingMeta, getBindingMeta,
+	)
+	return len(clusterRoles) > 0 || len(clusterRoleBindings) > 0
+}
+
+// clusterScopedPermissions returns the cluster-scoped permissions needed
+// to deploy the workload.
+func clusterScopedPermissions(spec *workloadSpec) []string {
+	required := set.NewStrings()
+	if len(spec.CustomResourceDefinitions) > 0 {
+		required.Add("create customresourcedefinitions.apiextensions.k8s.io")
+	}
+	if len(spec.CustomResources) > 0 {
+		required.Add("get customresourcedefinitions.apiextensions.k8s.io")
+	}
+	if len(spec.MutatingWebhookConfigurations) > 0 {
+		required.Add("create mutatingwebhookconfigurations.admissionregistration.k8s.io")
+	}
+	if len(spec.ValidatingWebhookConfigurations) > 0 {
+		required.Add("create validatingwebhookconfigurations.admissionregistration.k8s.io")
+	}
+	for _, sa := range spec.ServiceAccounts {
+		if requiresClusterRBAC(sa) {
+			required.Add("create clusterroles.rbac.authorization.k8s.io")
+			required.Add("create clusterrolebindings.rbac.authorization.k8s.io")
+		}
+	}
+	return required.SortedValues()
+}
+
+// checkNamespaceScopedWorkload returns an error listing the missing
+// permissions if the workload needs cluster-scoped resources, which are
+// never created by namespace-scoped models. Raw k8s specs, including
+// rendered Helm charts, are checked by the spec deployer instead.
+func (k *kubernetesClient) checkNamespaceScopedWorkload(appName string, spec *workloadSpec) error {
+	if !k.namespaceScoped {
+		return nil
+	}
+	missing := clusterScopedPermissions(spec)
+	if len(missing) == 0 {
+		return nil
+	}
+	return errors.NewForbidden(nil, fmt.Sprintf(
+		"application %q needs cluster-scoped resources which are not available in namespace-scoped model %q, missing permissions: %s",
+		appName, k.CurrentModel(), strings.Join(missing, ", "),
+	))
+}
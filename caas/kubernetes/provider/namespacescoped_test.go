@@ -0,0 +1,203 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"context"
+
+	"github.com/golang/mock/gomock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	core "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider"
+	k8sspecs "github.com/juju/juju/caas/kubernetes/provider/specs"
+	"github.com/juju/juju/caas/specs"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs"
+	envcontext "github.com/juju/juju/environs/context"
+	envtesting "github.com/juju/juju/environs/testing"
+)
+
+func (s *K8sBrokerSuite) setupNamespaceScopedController(c *gc.C) *gomock.Controller {
+	cfg, err := s.cfg.Apply(map[string]interface{}{
+		provider.NamespaceScopedKey: true,
+		provider.NamespaceKey:       "team-a",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.cfg = cfg
+	s.namespace = "team-a"
+	return s.setupController(c)
+}
+
+// expectAccessReviews answers the broker's self subject access reviews,
+// denying the permissions for the given resources.
+func (s *K8sBrokerSuite) expectAccessReviews(c *gc.C, denied ...string) {
+	s.mockSelfSubjectAccessReviews.EXPECT().Create(gomock.Any(), gomock.Any(), v1.CreateOptions{}).
+		DoAndReturn(func(_ context.Context, review *authorizationv1.SelfSubjectAccessReview, _ v1.CreateOptions) (*authorizationv1.SelfSubjectAccessReview, error) {
+			attrs := review.Spec.ResourceAttributes
+			if attrs.Resource == "storageclasses" {
+				c.Check(attrs.Namespace, gc.Equals, "")
+			} else {
+				c.Check(attrs.Namespace, gc.Equals, "team-a")
+			}
+			review.Status.Allowed = true
+			for _, resource := range denied {
+				if attrs.Resource == resource {
+					review.Status.Allowed = false
+				}
+			}
+			return review, nil
+		}).Times(12)
+}
+
+func (s *K8sBrokerSuite) TestCreateNamespaceScoped(c *gc.C) {
+	ctrl := s.setupNamespaceScopedController(c)
+	defer ctrl.Finish()
+
+	c.Assert(s.broker.GetCurrentNamespace(), gc.Equals, "team-a")
+	s.expectAccessReviews(c)
+
+	err := s.broker.Create(&envcontext.CloudCallContext{}, environs.CreateParams{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestCreateNamespaceScopedMissingPermissions(c *gc.C) {
+	ctrl := s.setupNamespaceScopedController(c)
+	defer ctrl.Finish()
+
+	s.expectAccessReviews(c, "roles", "statefulsets", "storageclasses")
+
+	err := s.broker.Create(&envcontext.CloudCallContext{}, environs.CreateParams{})
+	c.Assert(err, jc.Satisfies, errors.IsForbidden)
+	c.Assert(err, gc.ErrorMatches, `cannot use namespace "team-a", missing permissions: `+
+		`create statefulsets.apps, create roles.rbac.authorization.k8s.io, get storageclasses.storage.k8s.io`)
+}
+
+func (s *K8sBrokerSuite) TestDestroyNamespaceScoped(c *gc.C) {
+	ctrl := s.setupNamespaceScopedController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockDeployments.EXPECT().Delete(gomock.Any(), "modeloperator", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(nil),
+		s.mockServices.EXPECT().Delete(gomock.Any(), "modeloperator", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(nil),
+		s.mockConfigMaps.EXPECT().Delete(gomock.Any(), "modeloperator", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+	)
+
+	err := s.broker.Destroy(envcontext.NewCloudCallContext())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceNamespaceScopedClusterResources(c *gc.C) {
+	ctrl := s.setupNamespaceScopedController(c)
+	defer ctrl.Finish()
+
+	podSpec := getBasicPodspec()
+	podSpec.ServiceAccount = &specs.PrimeServiceAccountSpecV3{
+		ServiceAccountSpecV3: specs.ServiceAccountSpecV3{
+			Roles: []specs.Role{{
+				Global: true,
+				Rules: []specs.PolicyRule{{
+					APIGroups: []string{""},
+					Resources: []string{"pods"},
+					Verbs:     []string{"get", "watch", "list"},
+				}},
+			}},
+		},
+	}
+	podSpec.ProviderPod = &k8sspecs.K8sPodSpec{
+		KubernetesResources: &k8sspecs.KubernetesResources{
+			CustomResourceDefinitions: []k8sspecs.K8sCustomResourceDefinitionSpec{{
+				Meta: k8sspecs.Meta{Name: "tfjobs.kubeflow.org"},
+				Spec: apiextensionsv1beta1.CustomResourceDefinitionSpec{
+					Group:   "kubeflow.org",
+					Version: "v1alpha2",
+					Scope:   "Namespaced",
+					Names: apiextensionsv1beta1.CustomResourceDefinitionNames{
+						Plural:   "tfjobs",
+						Kind:     "TFJob",
+						Singular: "tfjob",
+					},
+				},
+			}},
+		},
+	}
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec: podSpec,
+		Deployment: caas.DeploymentParams{
+			DeploymentType: caas.DeploymentStateful,
+		},
+		OperatorImagePath: "operator/image-path",
+	}
+	err := s.broker.EnsureService("app-name", func(_ string, _ status.Status, _ string, _ map[string]interface{}) error {
+		return nil
+	}, params, 2, application.ConfigAttributes{})
+	c.Assert(err, jc.Satisfies, errors.IsForbidden)
+	c.Assert(err, gc.ErrorMatches, `application "app-name" needs cluster-scoped resources which are not available `+
+		`in namespace-scoped model "test", missing permissions: `+
+		`create clusterrolebindings.rbac.authorization.k8s.io, create clusterroles.rbac.authorization.k8s.io, `+
+		`create customresourcedefinitions.apiextensions.k8s.io`)
+}
+
+func (s *K8sBrokerSuite) TestNamespacesNamespaceScoped(c *gc.C) {
+	ctrl := s.setupNamespaceScopedController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockPods.EXPECT().List(gomock.Any(), v1.ListOptions{Limit: 1}).
+			Return(&core.PodList{}, nil),
+	)
+
+	namespaces, err := s.broker.Namespaces()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(namespaces, jc.DeepEquals, []string{"team-a"})
+}
+
+func (s *K8sBrokerSuite) TestPrepareForBootstrapNamespaceScoped(c *gc.C) {
+	ctrl := s.setupNamespaceScopedController(c)
+	defer ctrl.Finish()
+
+	s.setupOperatorStorageConfig(c)
+	s.expectAccessReviews(c)
+	sc := &storagev1.StorageClass{ObjectMeta: v1.ObjectMeta{Name: "some-storage"}}
+	gomock.InOrder(
+		s.mockStorageClass.EXPECT().Get(gomock.Any(), "team-a-some-storage", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStorageClass.EXPECT().Get(gomock.Any(), "some-storage", v1.GetOptions{}).
+			Return(sc, nil),
+	)
+
+	ctx := envtesting.BootstrapContext(c)
+	c.Assert(s.broker.PrepareForBootstrap(ctx, "ctrl-1"), jc.ErrorIsNil)
+	c.Assert(s.broker.GetCurrentNamespace(), gc.Equals, "team-a")
+}
+
+func (s *K8sBrokerSuite) TestPrepareForBootstrapNamespaceScopedWithoutNamespace(c *gc.C) {
+	cfg, err := s.cfg.Apply(map[string]interface{}{provider.NamespaceScopedKey: true})
+	c.Assert(err, jc.ErrorIsNil)
+	s.cfg = cfg
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	ctx := envtesting.BootstrapContext(c)
+	err = s.broker.PrepareForBootstrap(ctx, "ctrl-1")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `bootstrapping a namespace-scoped controller requires "namespace" to be set`)
+}
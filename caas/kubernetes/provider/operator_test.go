@@ -1028,6 +1028,9 @@ func (s *K8sBrokerSuite) TestOperator(c *gc.C) {
 			Return(&ss, nil),
 		s.mockPods.EXPECT().List(gomock.Any(), v1.ListOptions{LabelSelector: "juju-operator=test"}).
 			Return(&core.PodList{Items: []core.Pod{opPod}}, nil),
+		s.mockEvents.EXPECT().List(gomock.Any(),
+			listOptionsFieldSelectorMatcher("involvedObject.name=test-operator,involvedObject.kind=Pod"),
+		).Return(&core.EventList{}, nil),
 		s.mockConfigMaps.EXPECT().Get(gomock.Any(), "test-operator-config", v1.GetOptions{}).
 			Return(&cm, nil),
 	)
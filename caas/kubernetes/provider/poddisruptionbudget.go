@@ -0,0 +1,220 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"k8s.io/api/policy/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider/constants"
+	"github.com/juju/juju/caas/kubernetes/provider/utils"
+	k8sannotations "github.com/juju/juju/core/annotations"
+	"github.com/juju/juju/core/application"
+)
+
+func (k *kubernetesClient) getPodDisruptionBudgetLabels(appName string) map[string]string {
+	return map[string]string{
+		constants.LabelApplication: appName,
+	}
+}
+
+// parseMaxUnavailable parses the max-unavailable application config value,
+// which is either a positive number of pods or a percentage such as "25%".
+// Zero is rejected because it would block node drains indefinitely.
+func parseMaxUnavailable(value string) (intstr.IntOrString, error) {
+	value = strings.TrimSpace(value)
+	if strings.HasSuffix(value, "%") {
+		percent, err := strconv.Atoi(strings.TrimSuffix(value, "%"))
+		if err != nil || percent <= 0 || percent > 100 {
+			return intstr.IntOrString{}, errors.NotValidf("%s %q", podDisruptionMaxUnavailableKey, value)
+		}
+		return intstr.FromString(value), nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		return intstr.IntOrString{}, errors.NotValidf("%s %q", podDisruptionMaxUnavailableKey, value)
+	}
+	return intstr.FromInt(n), nil
+}
+
+// ValidatePodDisruptionBudgetConfig returns an error if the pod disruption
+// budget settings in the application config cannot be applied.
+func ValidatePodDisruptionBudgetConfig(config application.ConfigAttributes) error {
+	value := config.GetString(podDisruptionMaxUnavailableKey, "")
+	if value == "" {
+		return nil
+	}
+	_, err := parseMaxUnavailable(value)
+	return errors.Trace(err)
+}
+
+// ensurePodDisruptionBudget creates or updates the pod disruption budget
+// for the pods chosen by the selector when max-unavailable is configured,
+// and removes it when it is not.
+func (k *kubernetesClient) ensurePodDisruptionBudget(
+	appName, deploymentName string, annotations k8sannotations.Annotation,
+	selector *v1.LabelSelector, config application.ConfigAttributes,
+) (cleanUps []func(), err error) {
+	value := config.GetString(podDisruptionMaxUnavailableKey, "")
+	if value == "" {
+		return nil, errors.Trace(k.deletePodDisruptionBudget(deploymentName))
+	}
+	maxUnavailable, err := parseMaxUnavailable(value)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	spec := &v1beta1.PodDisruptionBudget{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        deploymentName,
+			Labels:      k.getPodDisruptionBudgetLabels(appName),
+			Annotations: annotations,
+		},
+		Spec: v1beta1.PodDisruptionBudgetSpec{
+			MaxUnavailable: &maxUnavailable,
+			Selector:       selector,
+		},
+	}
+	out, err := k.createPodDisruptionBudget(spec)
+	if err == nil {
+		logger.Debugf("pod disruption budget %q created", out.GetName())
+		cleanUps = append(cleanUps, func() { _ = k.deletePodDisruptionBudget(out.GetName()) })
+		return cleanUps, nil
+	}
+	if !errors.IsAlreadyExists(err) {
+		return cleanUps, errors.Trace(err)
+	}
+	existing, err := k.getPodDisruptionBudget(deploymentName)
+	if err != nil {
+		return cleanUps, errors.Trace(err)
+	}
+	spec.SetResourceVersion(existing.GetResourceVersion())
+	_, err = k.updatePodDisruptionBudget(spec)
+	logger.Debugf("updating pod disruption budget %q", spec.GetName())
+	return cleanUps, errors.Trace(err)
+}
+
+// ensureRawWorkloadPodDisruptionBudget creates or updates the pod
+// disruption budget for an application deployed from a raw k8s spec or
+// a helm chart. The pods of such workloads don't carry Juju's labels, so
+// the budget selects the same pods as the workload does.
+func (k *kubernetesClient) ensureRawWorkloadPodDisruptionBudget(
+	appName, deploymentName string, deploymentType caas.DeploymentType,
+	annotations k8sannotations.Annotation, config application.ConfigAttributes,
+) ([]func(), error) {
+	var selector *v1.LabelSelector
+	if config.GetString(podDisruptionMaxUnavailableKey, "") != "" {
+		var err error
+		if selector, err = k.rawWorkloadSelector(appName, deploymentName, deploymentType); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return k.ensurePodDisruptionBudget(appName, deploymentName, annotations, selector, config)
+}
+
+// rawWorkloadSelector returns the pod selector of the workload deployed
+// for an application from a raw k8s spec. The workload named after the
+// application is used if there is one, otherwise the spec must have
+// only one workload.
+func (k *kubernetesClient) rawWorkloadSelector(
+	appName, deploymentName string, deploymentType caas.DeploymentType,
+) (*v1.LabelSelector, error) {
+	listOptions := v1.ListOptions{
+		LabelSelector: utils.LabelSetToSelector(k.getlabelsForApp(appName, true)).String(),
+	}
+	ctx := context.TODO()
+	selectors := make(map[string]*v1.LabelSelector)
+	switch deploymentType {
+	case caas.DeploymentStateful:
+		out, err := k.client().AppsV1().StatefulSets(k.namespace).List(ctx, listOptions)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, item := range out.Items {
+			selectors[item.Name] = item.Spec.Selector
+		}
+	case caas.DeploymentDaemon:
+		out, err := k.client().AppsV1().DaemonSets(k.namespace).List(ctx, listOptions)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, item := range out.Items {
+			selectors[item.Name] = item.Spec.Selector
+		}
+	default:
+		out, err := k.client().AppsV1().Deployments(k.namespace).List(ctx, listOptions)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, item := range out.Items {
+			selectors[item.Name] = item.Spec.Selector
+		}
+	}
+	if selector, ok := selectors[deploymentName]; ok {
+		return selector, nil
+	}
+	switch len(selectors) {
+	case 0:
+		return nil, errors.NotFoundf("workload for %q", appName)
+	case 1:
+		for _, selector := range selectors {
+			return selector, nil
+		}
+	}
+	return nil, errors.NotValidf("pod disruption budget for %d workloads of %q", len(selectors), appName)
+}
+
+func (k *kubernetesClient) createPodDisruptionBudget(spec *v1beta1.PodDisruptionBudget) (*v1beta1.PodDisruptionBudget, error) {
+	utils.PurifyResource(spec)
+	out, err := k.client().PolicyV1beta1().PodDisruptionBudgets(k.namespace).Create(context.TODO(), spec, v1.CreateOptions{})
+	if k8serrors.IsAlreadyExists(err) {
+		return nil, errors.AlreadyExistsf("pod disruption budget %q", spec.GetName())
+	}
+	return out, errors.Trace(err)
+}
+
+func (k *kubernetesClient) getPodDisruptionBudget(name string) (*v1beta1.PodDisruptionBudget, error) {
+	out, err := k.client().PolicyV1beta1().PodDisruptionBudgets(k.namespace).Get(context.TODO(), name, v1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil, errors.NotFoundf("pod disruption budget %q", name)
+	}
+	return out, errors.Trace(err)
+}
+
+func (k *kubernetesClient) updatePodDisruptionBudget(spec *v1beta1.PodDisruptionBudget) (*v1beta1.PodDisruptionBudget, error) {
+	out, err := k.client().PolicyV1beta1().PodDisruptionBudgets(k.namespace).Update(context.TODO(), spec, v1.UpdateOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil, errors.NotFoundf("pod disruption budget %q", spec.GetName())
+	}
+	return out, errors.Trace(err)
+}
+
+func (k *kubernetesClient) deletePodDisruptionBudget(name string) error {
+	err := k.client().PolicyV1beta1().PodDisruptionBudgets(k.namespace).Delete(context.TODO(), name, v1.DeleteOptions{
+		PropagationPolicy: &constants.DefaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) deletePodDisruptionBudgets(appName string) error {
+	err := k.client().PolicyV1beta1().PodDisruptionBudgets(k.namespace).DeleteCollection(context.TODO(), v1.DeleteOptions{
+		PropagationPolicy: &constants.DefaultPropagationPolicy,
+	}, v1.ListOptions{
+		LabelSelector: utils.LabelSetToSelector(k.getPodDisruptionBudgetLabels(appName)).String(),
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
@@ -0,0 +1,158 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/golang/mock/gomock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	appsv1 "k8s.io/api/apps/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/juju/juju/caas"
+	k8sannotations "github.com/juju/juju/core/annotations"
+	"github.com/juju/juju/core/application"
+)
+
+func (s *K8sBrokerSuite) podDisruptionBudgetArg(maxUnavailable intstr.IntOrString) *policyv1beta1.PodDisruptionBudget {
+	return &policyv1beta1.PodDisruptionBudget{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "app-name",
+			Labels:      map[string]string{"juju-app": "app-name"},
+			Annotations: map[string]string{"fred": "mary"},
+		},
+		Spec: policyv1beta1.PodDisruptionBudgetSpec{
+			MaxUnavailable: &maxUnavailable,
+			Selector: &v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-app": "app-name"},
+			},
+		},
+	}
+}
+
+func (s *K8sBrokerSuite) TestEnsurePodDisruptionBudgetCreate(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	pdb := s.podDisruptionBudgetArg(intstr.FromInt(1))
+	gomock.InOrder(
+		s.mockPodDisruptionBudgets.EXPECT().Create(gomock.Any(), pdb, v1.CreateOptions{}).
+			Return(pdb, nil),
+	)
+
+	cleanUps, err := s.broker.EnsurePodDisruptionBudget("app-name", "app-name",
+		k8sannotations.New(map[string]string{"fred": "mary"}),
+		application.ConfigAttributes{"kubernetes-pod-disruption-max-unavailable": "1"},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cleanUps, gc.HasLen, 1)
+}
+
+func (s *K8sBrokerSuite) TestEnsurePodDisruptionBudgetUpdate(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	pdb := s.podDisruptionBudgetArg(intstr.FromString("25%"))
+	existing := s.podDisruptionBudgetArg(intstr.FromInt(1))
+	existing.SetResourceVersion("42")
+	updated := s.podDisruptionBudgetArg(intstr.FromString("25%"))
+	updated.SetResourceVersion("42")
+	gomock.InOrder(
+		s.mockPodDisruptionBudgets.EXPECT().Create(gomock.Any(), pdb, v1.CreateOptions{}).
+			Return(nil, s.k8sAlreadyExistsError()),
+		s.mockPodDisruptionBudgets.EXPECT().Get(gomock.Any(), "app-name", v1.GetOptions{}).
+			Return(existing, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Update(gomock.Any(), updated, v1.UpdateOptions{}).
+			Return(updated, nil),
+	)
+
+	cleanUps, err := s.broker.EnsurePodDisruptionBudget("app-name", "app-name",
+		k8sannotations.New(map[string]string{"fred": "mary"}),
+		application.ConfigAttributes{"kubernetes-pod-disruption-max-unavailable": "25%"},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cleanUps, gc.HasLen, 0)
+}
+
+func (s *K8sBrokerSuite) TestEnsurePodDisruptionBudgetUnset(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockPodDisruptionBudgets.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(nil),
+	)
+
+	_, err := s.broker.EnsurePodDisruptionBudget("app-name", "app-name", nil, application.ConfigAttributes{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsurePodDisruptionBudgetInvalid(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	for _, value := range []string{"0", "-1", "0%", "101%", "some", "1.5"} {
+		_, err := s.broker.EnsurePodDisruptionBudget("app-name", "app-name", nil,
+			application.ConfigAttributes{"kubernetes-pod-disruption-max-unavailable": value},
+		)
+		c.Check(err, gc.ErrorMatches, `kubernetes-pod-disruption-max-unavailable ".*" not valid`)
+	}
+}
+
+func (s *K8sBrokerSuite) TestEnsureRawWorkloadPodDisruptionBudget(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	selector := &v1.LabelSelector{MatchLabels: map[string]string{"app.kubernetes.io/name": "mariadb"}}
+	pdb := s.podDisruptionBudgetArg(intstr.FromInt(1))
+	pdb.Spec.Selector = selector
+	gomock.InOrder(
+		s.mockDeployments.EXPECT().List(gomock.Any(), v1.ListOptions{LabelSelector: "juju-app=app-name"}).
+			Return(&appsv1.DeploymentList{Items: []appsv1.Deployment{{
+				ObjectMeta: v1.ObjectMeta{Name: "mariadb"},
+				Spec:       appsv1.DeploymentSpec{Selector: selector},
+			}}}, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Create(gomock.Any(), pdb, v1.CreateOptions{}).
+			Return(pdb, nil),
+	)
+
+	_, err := s.broker.EnsureRawWorkloadPodDisruptionBudget("app-name", "app-name", caas.DeploymentStateless,
+		k8sannotations.New(map[string]string{"fred": "mary"}),
+		application.ConfigAttributes{"kubernetes-pod-disruption-max-unavailable": "1"},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureRawWorkloadPodDisruptionBudgetManyWorkloads(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().List(gomock.Any(), v1.ListOptions{LabelSelector: "juju-app=app-name"}).
+			Return(&appsv1.StatefulSetList{Items: []appsv1.StatefulSet{
+				{ObjectMeta: v1.ObjectMeta{Name: "primary"}},
+				{ObjectMeta: v1.ObjectMeta{Name: "replica"}},
+			}}, nil),
+	)
+
+	_, err := s.broker.EnsureRawWorkloadPodDisruptionBudget("app-name", "app-name", caas.DeploymentStateful, nil,
+		application.ConfigAttributes{"kubernetes-pod-disruption-max-unavailable": "1"},
+	)
+	c.Assert(err, gc.ErrorMatches, `pod disruption budget for 2 workloads of "app-name" not valid`)
+}
+
+func (s *K8sBrokerSuite) TestEnsureRawWorkloadPodDisruptionBudgetUnset(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockPodDisruptionBudgets.EXPECT().Delete(gomock.Any(), "app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(nil),
+	)
+
+	_, err := s.broker.EnsureRawWorkloadPodDisruptionBudget("app-name", "app-name", caas.DeploymentStateless, nil, application.ConfigAttributes{})
+	c.Assert(err, jc.ErrorIsNil)
+}
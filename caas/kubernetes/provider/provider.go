@@ -126,15 +126,26 @@ func (p kubernetesEnvironProvider) Open(args environs.OpenParams) (caas.Broker,
 	// Guinea Pig broker to hunt for the namespace where a controller lives. We
 	// disregard this one in favour of a new one pinned to the correct
 	// controller namespace when we find it.
+	cfg, err := p.newConfig(args.Config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	namespace := args.Config.Name()
+	if cfg.namespaceScoped() && cfg.namespace() != "" {
+		namespace = cfg.namespace()
+	}
+
 	broker, err := newK8sBroker(
-		args.ControllerUUID, k8sRestConfig, args.Config, args.Config.Name(), newK8sClient, newRestClient,
+		args.ControllerUUID, k8sRestConfig, args.Config, namespace, newK8sClient, newRestClient,
 		k8swatcher.NewKubernetesNotifyWatcher, k8swatcher.NewKubernetesStringsWatcher, randomPrefix,
 		jujuclock.WallClock)
 	if err != nil {
 		return nil, err
 	}
 
-	if args.Config.Name() != environsbootstrap.ControllerModelName {
+	// A namespace-scoped controller lives in the configured namespace, and
+	// cannot list the cluster's namespaces to look for it anyway.
+	if args.Config.Name() != environsbootstrap.ControllerModelName || cfg.namespaceScoped() {
 		return broker, nil
 	}
 
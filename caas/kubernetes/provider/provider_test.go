@@ -128,3 +128,19 @@ func (s *providerSuite) TestValidate(c *gc.C) {
 	validAttrs := validCfg.AllAttrs()
 	c.Assert(config.AllAttrs(), gc.DeepEquals, validAttrs)
 }
+
+func (s *providerSuite) TestValidateNamespaceRequiresNamespaceScoped(c *gc.C) {
+	config := fakeConfig(c, coretesting.Attrs{"namespace": "team-a"})
+	_, err := s.provider.Validate(config, nil)
+	c.Assert(err, gc.ErrorMatches, `invalid k8s provider config: "namespace" can only be set for namespace-scoped models`)
+}
+
+func (s *providerSuite) TestOpenNamespaceScoped(c *gc.C) {
+	config := fakeConfig(c, coretesting.Attrs{"namespace-scoped": true, "namespace": "team-a"})
+	broker, err := s.provider.Open(environs.OpenParams{
+		Cloud:  fakeCloudSpec(),
+		Config: config,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(broker.GetCurrentNamespace(), gc.Equals, "team-a")
+}
@@ -21,6 +21,15 @@ const (
 	// OperatorStorageKey is the model config attribute used to specify
 	// the storage class for provisioning operator storage.
 	OperatorStorageKey = "operator-storage"
+
+	// NamespaceScopedKey is the model config attribute used to specify
+	// that the model uses an existing namespace and may not create
+	// cluster-scoped resources.
+	NamespaceScopedKey = "namespace-scoped"
+
+	// NamespaceKey is the model config attribute used to specify the
+	// existing namespace adopted by a namespace-scoped model.
+	NamespaceKey = "namespace"
 )
 
 var (
@@ -47,6 +56,18 @@ var configSchema = environschema.Fields{
 		Group:       environschema.AccountGroup,
 		Immutable:   true,
 	},
+	NamespaceScopedKey: {
+		Description: "Whether the model adopts an existing namespace and is restricted to namespaced resources.",
+		Type:        environschema.Tbool,
+		Group:       environschema.AccountGroup,
+		Immutable:   true,
+	},
+	NamespaceKey: {
+		Description: "The existing namespace used by a namespace-scoped model, defaults to the model name.",
+		Type:        environschema.Tstring,
+		Group:       environschema.AccountGroup,
+		Immutable:   true,
+	},
 }
 
 var providerConfigFields = func() schema.Fields {
@@ -60,6 +81,8 @@ var providerConfigFields = func() schema.Fields {
 var providerConfigDefaults = schema.Defaults{
 	WorkloadStorageKey: "",
 	OperatorStorageKey: "",
+	NamespaceScopedKey: schema.Omit,
+	NamespaceKey:       schema.Omit,
 }
 
 type brokerConfig struct {
@@ -75,6 +98,16 @@ func (c *brokerConfig) operatorStorage() string {
 	return c.attrs[OperatorStorageKey].(string)
 }
 
+func (c *brokerConfig) namespaceScoped() bool {
+	scoped, _ := c.attrs[NamespaceScopedKey].(bool)
+	return scoped
+}
+
+func (c *brokerConfig) namespace() string {
+	namespace, _ := c.attrs[NamespaceKey].(string)
+	return namespace
+}
+
 func (p kubernetesEnvironProvider) Validate(cfg, old *config.Config) (*config.Config, error) {
 	newCfg, err := validateConfig(cfg, old)
 	if err != nil {
@@ -123,5 +156,8 @@ func validateConfig(cfg, old *config.Config) (*brokerConfig, error) {
 	}
 
 	bcfg := &brokerConfig{cfg, validated}
+	if bcfg.namespace() != "" && !bcfg.namespaceScoped() {
+		return nil, fmt.Errorf("%q can only be set for %s models", NamespaceKey, NamespaceScopedKey)
+	}
 	return bcfg, nil
 }
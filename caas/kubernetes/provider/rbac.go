@@ -180,14 +180,19 @@ func (k *kubernetesClient) deleteAllServiceAccountResources(appName string) erro
 	if err := k.deleteRoleBindings(selectorNamespaced); err != nil {
 		return errors.Trace(err)
 	}
-	if err := k.deleteClusterRoleBindings(selectorGlobal); err != nil {
-		return errors.Trace(err)
+	// Namespace-scoped models never create cluster roles.
+	if !k.namespaceScoped {
+		if err := k.deleteClusterRoleBindings(selectorGlobal); err != nil {
+			return errors.Trace(err)
+		}
 	}
 	if err := k.deleteRoles(selectorNamespaced); err != nil {
 		return errors.Trace(err)
 	}
-	if err := k.deleteClusterRoles(selectorGlobal); err != nil {
-		return errors.Trace(err)
+	if !k.namespaceScoped {
+		if err := k.deleteClusterRoles(selectorGlobal); err != nil {
+			return errors.Trace(err)
+		}
 	}
 	if err := k.deleteServiceAccounts(selectorNamespaced); err != nil {
 		return errors.Trace(err)
@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
 	"sync"
 
 	"github.com/juju/errors"
@@ -133,6 +134,7 @@ type deployer struct {
 	labelGetter          func(isNamespaced bool) map[string]string
 	annotations          k8sannotations.Annotation
 	newRestClient        NewK8sRestClientFunc
+	namespaceScoped      bool
 
 	resources []resourceInfo
 
@@ -147,7 +149,8 @@ type DeployerInterface interface {
 // NewK8sRestClientFunc defines a function which returns a k8s rest client based on the supplied config.
 type NewK8sRestClientFunc func(c *rest.Config) (rest.Interface, error)
 
-// New constructs deployer interface.
+// New constructs deployer interface. A namespace-scoped deployer
+// refuses to deploy specs containing cluster-scoped resources.
 func New(
 	deploymentName string,
 	namespace string,
@@ -156,12 +159,13 @@ func New(
 	labelGetter func(isNamespaced bool) map[string]string,
 	annotations k8sannotations.Annotation,
 	newRestClient NewK8sRestClientFunc,
+	namespaceScoped bool,
 ) DeployerInterface {
 	// TODO(caas): disable scale or parse the unstructuredJSON further to set workload resource replicas.
 	return newDeployer(
 		deploymentName, namespace,
 		deploymentParams, cfg, labelGetter, annotations,
-		newRestClient, getRestMapper,
+		newRestClient, getRestMapper, namespaceScoped,
 	)
 }
 
@@ -174,6 +178,7 @@ func newDeployer(
 	annotations k8sannotations.Annotation,
 	newRestClient NewK8sRestClientFunc,
 	restMapperGetter func(c rest.Interface) meta.RESTMapper,
+	namespaceScoped bool,
 ) DeployerInterface {
 	return &deployer{
 		deploymentName:       deploymentName,
@@ -184,6 +189,7 @@ func newDeployer(
 		annotations:          annotations,
 		newRestClient:        newRestClient,
 		restMapperGetter:     restMapperGetter,
+		namespaceScoped:      namespaceScoped,
 	}
 }
 
@@ -210,6 +216,9 @@ func (d *deployer) validate() error {
 	if err := d.validateWorkload(); err != nil {
 		return errors.Trace(err)
 	}
+	if err := d.validateScope(); err != nil {
+		return errors.Trace(err)
+	}
 	// TODO(caas): check if service resource type matches the raw service spec.
 	// TODO(caas): get the API scheme and do validation further.
 	return nil
@@ -281,6 +290,26 @@ func (d *deployer) clientWithGroupVersion(gv apischema.GroupVersion) (rest.Inter
 	return d.newRestClient(cfg)
 }
 
+// validateScope returns an error satisfying errors.IsForbidden if a
+// namespace-scoped deployer is asked to deploy cluster-scoped resources.
+func (d *deployer) validateScope() error {
+	if !d.namespaceScoped {
+		return nil
+	}
+	var clusterScoped []string
+	for _, resource := range d.resources {
+		if resource.mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+			clusterScoped = append(clusterScoped, fmt.Sprintf("%s/%s", resource.mapping.GroupVersionKind.Kind, resource.name))
+		}
+	}
+	if len(clusterScoped) == 0 {
+		return nil
+	}
+	return errors.NewForbidden(nil, fmt.Sprintf(
+		"cluster-scoped resources %s cannot be deployed in namespace %q", strings.Join(clusterScoped, ", "), d.namespace,
+	))
+}
+
 // load parses the raw k8s spec into a slice of resource info.
 func (d *deployer) load() (err error) {
 	defer func() {
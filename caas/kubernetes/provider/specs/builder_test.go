@@ -14,10 +14,12 @@ import (
 	"strings"
 
 	"github.com/golang/mock/gomock"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	appsv1 "k8s.io/api/apps/v1"
 	core "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -48,6 +50,7 @@ type builderSuite struct {
 	builder k8sspecs.DeployerInterface
 
 	namespace        string
+	namespaceScoped  bool
 	deploymentParams *caas.DeploymentParams
 	labels           map[string]string
 	annotations      k8sannotations.Annotation
@@ -118,6 +121,7 @@ func (s *builderSuite) SetUpTest(c *gc.C) {
 
 func (s *builderSuite) TearDownTest(c *gc.C) {
 	s.namespace = ""
+	s.namespaceScoped = false
 	s.deploymentParams = nil
 	s.mockRestClients = nil
 	s.labels = nil
@@ -170,6 +174,7 @@ func (s *builderSuite) setupRestClients(c *gc.C, restClientAssertions ...restCli
 		func(rest.Interface) meta.RESTMapper {
 			return s.mockRestMapper
 		},
+		s.namespaceScoped,
 	)
 	return ctrl
 }
@@ -357,6 +362,67 @@ func (s *builderSuite) TestDeployDeploymentTypeMismatchFailed(c *gc.C) {
 	c.Assert(s.builder.Deploy(ctx, rawK8sSpec, true), gc.ErrorMatches, `empty "daemonsets" resource definition not valid`)
 }
 
+func (s *builderSuite) TestDeployNamespaceScopedClusterResources(c *gc.C) {
+	gvApps := appsv1.SchemeGroupVersion
+	gvCore := core.SchemeGroupVersion
+	gvRbac := rbacv1.SchemeGroupVersion
+
+	s.namespaceScoped = true
+	ctrl := s.setupRestClients(c,
+		restClientSetUpAction{
+			gv: gvApps, doAssert: func(restC *providermocks.MockRestClientInterface) {},
+		},
+		restClientSetUpAction{
+			gv: gvCore, doAssert: func(restC *providermocks.MockRestClientInterface) {},
+		},
+		restClientSetUpAction{
+			gv: gvRbac, doAssert: func(restC *providermocks.MockRestClientInterface) {},
+		},
+	)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithTimeout(context.Background(), testing.LongWait)
+	defer cancel()
+
+	gomock.InOrder(
+		s.mockRestMapper.EXPECT().
+			RESTMapping(apischema.GroupKind{Kind: "Deployment", Group: "apps"}, "v1").
+			DoAndReturn(
+				func(gk apischema.GroupKind, version string) (*meta.RESTMapping, error) {
+					return restMapping(meta.RESTScopeNameNamespace, gk, version), nil
+				},
+			),
+		s.mockRestMapper.EXPECT().
+			RESTMapping(apischema.GroupKind{Kind: "Service"}, "v1").
+			DoAndReturn(
+				func(gk apischema.GroupKind, version string) (*meta.RESTMapping, error) {
+					return restMapping(meta.RESTScopeNameNamespace, gk, version), nil
+				},
+			),
+		s.mockRestMapper.EXPECT().
+			RESTMapping(apischema.GroupKind{Kind: "ClusterRole", Group: "rbac.authorization.k8s.io"}, "v1").
+			DoAndReturn(
+				func(gk apischema.GroupKind, version string) (*meta.RESTMapping, error) {
+					return restMapping(meta.RESTScopeNameRoot, gk, version), nil
+				},
+			),
+	)
+	spec := rawK8sSpec + `
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: reader
+rules:
+- apiGroups: [""]
+  resources: ["pods"]
+  verbs: ["get"]
+`[1:]
+	err := s.builder.Deploy(ctx, spec, true)
+	c.Assert(err, gc.ErrorMatches, `cluster-scoped resources ClusterRole/reader cannot be deployed in namespace "test"`)
+	c.Assert(err, jc.Satisfies, errors.IsForbidden)
+}
+
 func objBody(c *gc.C, object interface{}) io.ReadCloser {
 	output, err := json.MarshalIndent(object, "", "")
 	c.Assert(err, jc.ErrorIsNil)
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/schema"
 	core "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/juju/juju/caas/kubernetes/provider/constants"
@@ -222,7 +223,10 @@ type volumeSource struct {
 	client *kubernetesClient
 }
 
-var _ storage.VolumeSource = (*volumeSource)(nil)
+var (
+	_ storage.VolumeSource  = (*volumeSource)(nil)
+	_ storage.VolumeResizer = (*volumeSource)(nil)
+)
 
 // CreateVolumes is specified on the storage.VolumeSource interface.
 func (v *volumeSource) CreateVolumes(ctx jujucontext.ProviderCallContext, params []storage.VolumeParams) (_ []storage.CreateVolumesResult, err error) {
@@ -325,6 +329,66 @@ func (v *volumeSource) DetachVolumes(ctx jujucontext.ProviderCallContext, attach
 	return make([]error, len(attachParams)), nil
 }
 
+// ResizeVolumes is specified on the storage.VolumeResizer interface.
+// The persistent volume claim bound to each volume is updated to request
+// the new size; the storage class must allow volume expansion for the
+// underlying volume to grow.
+func (v *volumeSource) ResizeVolumes(ctx jujucontext.ProviderCallContext, params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	logger.Debugf("resize k8s volumes: %v", params)
+	results := make([]storage.ResizeVolumesResult, len(params))
+	for i, arg := range params {
+		info, err := v.resizeVolume(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "resizing volume %v", arg.VolumeId)
+			continue
+		}
+		results[i].VolumeInfo = info
+	}
+	return results, nil
+}
+
+func (v *volumeSource) resizeVolume(arg storage.VolumeResizeParams) (*storage.VolumeInfo, error) {
+	vol, err := v.client.client().CoreV1().PersistentVolumes().Get(context.TODO(), arg.VolumeId, v1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil, errors.NotFoundf("volume %q", arg.VolumeId)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if vol.Spec.ClaimRef == nil {
+		return nil, errors.NotSupportedf("resizing unclaimed volume %q", arg.VolumeId)
+	}
+	claimRef := vol.Spec.ClaimRef
+	pClaims := v.client.client().CoreV1().PersistentVolumeClaims(claimRef.Namespace)
+	pvc, err := pClaims.Get(context.TODO(), claimRef.Name, v1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil, errors.NotFoundf("volume claim %q", claimRef.Name)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	requested, err := resource.ParseQuantity(fmt.Sprintf("%dMi", arg.Size))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	current, ok := pvc.Spec.Resources.Requests[core.ResourceStorage]
+	if ok && requested.Cmp(current) < 0 {
+		return nil, errors.NotSupportedf("shrinking volume from %v to %v", current.String(), requested.String())
+	}
+	if !ok || requested.Cmp(current) > 0 {
+		if pvc.Spec.Resources.Requests == nil {
+			pvc.Spec.Resources.Requests = core.ResourceList{}
+		}
+		pvc.Spec.Resources.Requests[core.ResourceStorage] = requested
+		if _, err := pClaims.Update(context.TODO(), pvc, v1.UpdateOptions{}); err != nil {
+			return nil, errors.Annotatef(err, "updating volume claim %v", claimRef.Name)
+		}
+	}
+	return &storage.VolumeInfo{
+		VolumeId:   vol.Name,
+		Size:       arg.Size,
+		Persistent: vol.Spec.PersistentVolumeReclaimPolicy == core.PersistentVolumeReclaimRetain,
+	}, nil
+}
+
 func foreachVolume(volumeIds []string, f func(string) error) []error {
 	results := make([]error, len(volumeIds))
 	var wg sync.WaitGroup
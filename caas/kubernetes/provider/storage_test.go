@@ -187,6 +187,82 @@ func (s *storageSuite) TestDescribeVolumes(c *gc.C) {
 	}})
 }
 
+func (s *storageSuite) TestResizeVolumes(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	pvc := &core.PersistentVolumeClaim{
+		ObjectMeta: v1.ObjectMeta{Name: "vol-1-pvc", Namespace: "test"},
+		Spec: core.PersistentVolumeClaimSpec{
+			Resources: core.ResourceRequirements{
+				Requests: core.ResourceList{core.ResourceStorage: resource.MustParse("100Mi")},
+			},
+		},
+	}
+	updated := *pvc
+	updated.Spec.Resources.Requests = core.ResourceList{core.ResourceStorage: resource.MustParse("200Mi")}
+	gomock.InOrder(
+		s.mockPersistentVolumes.EXPECT().Get(gomock.Any(), "vol-1", v1.GetOptions{}).
+			Return(&core.PersistentVolume{
+				ObjectMeta: v1.ObjectMeta{Name: "vol-1"},
+				Spec: core.PersistentVolumeSpec{
+					PersistentVolumeReclaimPolicy: core.PersistentVolumeReclaimRetain,
+					ClaimRef:                      &core.ObjectReference{Namespace: "test", Name: "vol-1-pvc"},
+				}}, nil),
+		s.mockPersistentVolumeClaims.EXPECT().Get(gomock.Any(), "vol-1-pvc", v1.GetOptions{}).
+			Return(pvc, nil),
+		s.mockPersistentVolumeClaims.EXPECT().Update(gomock.Any(), &updated, v1.UpdateOptions{}).
+			Return(&updated, nil),
+	)
+
+	p := s.k8sProvider(c, ctrl)
+	vs, err := p.VolumeSource(&storage.Config{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := vs.(storage.VolumeResizer).ResizeVolumes(&context.CloudCallContext{}, []storage.VolumeResizeParams{{
+		VolumeId: "vol-1",
+		Size:     200,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.ResizeVolumesResult{{
+		VolumeInfo: &storage.VolumeInfo{VolumeId: "vol-1", Size: 200, Persistent: true},
+	}})
+}
+
+func (s *storageSuite) TestResizeVolumesShrink(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockPersistentVolumes.EXPECT().Get(gomock.Any(), "vol-1", v1.GetOptions{}).
+			Return(&core.PersistentVolume{
+				ObjectMeta: v1.ObjectMeta{Name: "vol-1"},
+				Spec: core.PersistentVolumeSpec{
+					ClaimRef: &core.ObjectReference{Namespace: "test", Name: "vol-1-pvc"},
+				}}, nil),
+		s.mockPersistentVolumeClaims.EXPECT().Get(gomock.Any(), "vol-1-pvc", v1.GetOptions{}).
+			Return(&core.PersistentVolumeClaim{
+				Spec: core.PersistentVolumeClaimSpec{
+					Resources: core.ResourceRequirements{
+						Requests: core.ResourceList{core.ResourceStorage: resource.MustParse("1Gi")},
+					},
+				},
+			}, nil),
+	)
+
+	p := s.k8sProvider(c, ctrl)
+	vs, err := p.VolumeSource(&storage.Config{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := vs.(storage.VolumeResizer).ResizeVolumes(&context.CloudCallContext{}, []storage.VolumeResizeParams{{
+		VolumeId: "vol-1",
+		Size:     200,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, `resizing volume vol-1: shrinking volume from 1Gi to 200Mi not supported`)
+}
+
 func (s *storageSuite) TestValidateStorageProvider(c *gc.C) {
 	for _, t := range []struct {
 		providerType storage.ProviderType
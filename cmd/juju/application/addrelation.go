@@ -182,7 +182,15 @@ func (c *addRelationCommand) maybeConsumeOffer(targetClient applicationAddRelati
 
 	// Get the details of the remote offer - this will fail with a permission
 	// error if the user isn't authorised to consume the offer.
-	consumeDetails, err := sourceClient.GetConsumeDetails(c.remoteEndpoint.AsLocal().String())
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	controllerUUID, err := c.ControllerUUID(c.ClientStore(), controllerName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	consumeDetails, err := sourceClient.GetConsumeDetails(c.remoteEndpoint.AsLocal().String(), controllerUUID)
 	if err != nil {
 		return errors.Trace(err)
 	}
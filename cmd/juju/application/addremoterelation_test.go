@@ -47,7 +47,7 @@ func (s *AddRemoteRelationSuiteNewAPI) TestAddRelationRemoteApplications(c *gc.C
 
 func (s *AddRemoteRelationSuiteNewAPI) TestAddRelationToOneRemoteApplication(c *gc.C) {
 	s.assertAddedRelation(c, "applicationname", "othermodel.applicationname2")
-	s.mockAPI.CheckCall(c, 1, "GetConsumeDetails", "othermodel.applicationname2")
+	s.mockAPI.CheckCall(c, 1, "GetConsumeDetails", "othermodel.applicationname2", testing.ControllerTag.Id())
 	s.mockAPI.CheckCall(c, 2, "Consume",
 		crossmodel.ConsumeApplicationArgs{
 			Offer: params.ApplicationOfferDetails{
@@ -68,7 +68,7 @@ func (s *AddRemoteRelationSuiteNewAPI) TestAddRelationToOneRemoteApplication(c *
 
 func (s *AddRemoteRelationSuiteNewAPI) TestAddRelationAnyRemoteApplication(c *gc.C) {
 	s.assertAddedRelation(c, "othermodel.applicationname2", "applicationname")
-	s.mockAPI.CheckCall(c, 1, "GetConsumeDetails", "othermodel.applicationname2")
+	s.mockAPI.CheckCall(c, 1, "GetConsumeDetails", "othermodel.applicationname2", testing.ControllerTag.Id())
 	s.mockAPI.CheckCall(c, 2, "Consume",
 		crossmodel.ConsumeApplicationArgs{
 			Offer: params.ApplicationOfferDetails{
@@ -253,8 +253,8 @@ func (m *mockAddRelationAPI) Consume(arg crossmodel.ConsumeApplicationArgs) (str
 	return arg.ApplicationAlias, nil
 }
 
-func (m *mockAddRelationAPI) GetConsumeDetails(url string) (params.ConsumeOfferDetails, error) {
-	m.AddCall("GetConsumeDetails", url)
+func (m *mockAddRelationAPI) GetConsumeDetails(url, consumingControllerUUID string) (params.ConsumeOfferDetails, error) {
+	m.AddCall("GetConsumeDetails", url, consumingControllerUUID)
 	return params.ConsumeOfferDetails{
 		Offer: &params.ApplicationOfferDetails{
 			OfferName: "hosted-mysql",
@@ -116,6 +116,7 @@ type bundleDeploySpec struct {
 	targetModelName string
 	targetModelUUID string
 	controllerName  string
+	controllerUUID  string
 	accountUser     string
 }
 
@@ -259,8 +260,9 @@ type bundleHandler struct {
 	targetModelName string
 	targetModelUUID string
 
-	// Controller name required for consuming a offer when deploying a bundle.
+	// Controller name and UUID required for consuming a offer when deploying a bundle.
 	controllerName string
+	controllerUUID string
 
 	// accountUser holds the user of the account associated with the
 	// current controller.
@@ -300,6 +302,7 @@ func makeBundleHandler(bundleData *charm.BundleData, spec bundleDeploySpec) *bun
 		targetModelName: spec.targetModelName,
 		targetModelUUID: spec.targetModelUUID,
 		controllerName:  spec.controllerName,
+		controllerUUID:  spec.controllerUUID,
 		accountUser:     spec.accountUser,
 	}
 }
@@ -1156,7 +1159,7 @@ func (h *bundleHandler) consumeOffer(change *bundlechanges.ConsumeOfferChange) e
 	// target) controller, as the names of controllers might not match and we
 	// end up with an error stating that the controller doesn't exist, even
 	// though it's correct.
-	consumeDetails, err := controllerOfferAPI.GetConsumeDetails(url.AsLocal().String())
+	consumeDetails, err := controllerOfferAPI.GetConsumeDetails(url.AsLocal().String(), h.controllerUUID)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -6,13 +6,18 @@ package application
 import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"github.com/juju/names/v4"
+	"gopkg.in/macaroon.v2"
 
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/application"
 	"github.com/juju/juju/api/applicationoffers"
+	"github.com/juju/juju/api/crossmodelrelations"
 	"github.com/juju/juju/apiserver/params"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/block"
+	crossmodelcmd "github.com/juju/juju/cmd/juju/crossmodel"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/core/crossmodel"
 )
@@ -27,14 +32,21 @@ The remote offer is identified by providing a path to the offer:
     [<model owner>/]<model name>.<application name>
         for an application in another model in this controller (if owner isn't specified it's assumed to be the logged-in user)
 
+Alternatively, the offer can be identified by a token created with
+"juju offer-invite". The token can only be redeemed once and grants
+access to the offer without needing an account on the controller
+hosting the offer.
+
 Examples:
     $ juju consume othermodel.mysql
     $ juju consume owner/othermodel.mysql
     $ juju consume anothercontroller:owner/othermodel.mysql
+    $ juju consume --token <token> mysql
 
 See also:
     add-relation
-    offer`[1:]
+    offer
+    offer-invite`[1:]
 
 // NewConsumeCommand returns a command to add remote offers to
 // the model.
@@ -48,32 +60,43 @@ type consumeCommand struct {
 	modelcmd.ModelCommandBase
 	sourceAPI         applicationConsumeDetailsAPI
 	targetAPI         applicationConsumeAPI
+	redeemAPI         offerInvitationRedeemAPI
 	remoteApplication string
 	applicationAlias  string
+	token             string
 }
 
 // Info implements cmd.Command.
 func (c *consumeCommand) Info() *cmd.Info {
 	return jujucmd.Info(&cmd.Info{
 		Name:    "consume",
-		Args:    "<remote offer path> [<local application name>]",
+		Args:    "(<remote offer path> | --token <token>) [<local application name>]",
 		Purpose: usageConsumeSummary,
 		Doc:     usageConsumeDetails,
 	})
 }
 
+// SetFlags implements cmd.Command.
+func (c *consumeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.token, "token", "", "Consume the offer using a token created by offer-invite")
+}
+
 // Init implements cmd.Command.
 func (c *consumeCommand) Init(args []string) error {
-	if len(args) == 0 {
-		return errors.New("no remote offer specified")
+	if c.token == "" {
+		if len(args) == 0 {
+			return errors.New("no remote offer specified")
+		}
+		c.remoteApplication = args[0]
+		args = args[1:]
 	}
-	c.remoteApplication = args[0]
-	if len(args) > 1 {
-		if !names.IsValidApplication(args[1]) {
-			return errors.Errorf("invalid application name %q", args[1])
+	if len(args) > 0 {
+		if !names.IsValidApplication(args[0]) {
+			return errors.Errorf("invalid application name %q", args[0])
 		}
-		c.applicationAlias = args[1]
-		return cmd.CheckEmpty(args[2:])
+		c.applicationAlias = args[0]
+		return cmd.CheckEmpty(args[1:])
 	}
 	return nil
 }
@@ -109,9 +132,30 @@ func (c *consumeCommand) getSourceAPI(url *crossmodel.OfferURL) (applicationCons
 	return applicationoffers.NewClient(root), nil
 }
 
+func (c *consumeCommand) getRedeemAPI(info *params.ExternalControllerInfo, modelTag names.ModelTag) (offerInvitationRedeemAPI, error) {
+	if c.redeemAPI != nil {
+		return c.redeemAPI, nil
+	}
+	// Invitations are redeemed anonymously, as the consuming
+	// user needn't have an account on the offering controller.
+	conn, err := api.Open(&api.Info{
+		Addrs:    info.Addrs,
+		CACert:   info.CACert,
+		ModelTag: modelTag,
+		Tag:      names.NewUserTag(api.AnonymousUsername),
+	}, api.DefaultDialOpts())
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot connect to the offering controller")
+	}
+	return crossmodelrelations.NewClient(conn), nil
+}
+
 // Run adds the requested remote offer to the model. Implements
 // cmd.Command.
 func (c *consumeCommand) Run(ctx *cmd.Context) error {
+	if c.token != "" {
+		return c.consumeInvitation(ctx)
+	}
 	accountDetails, err := c.CurrentAccountDetails()
 	if err != nil {
 		return errors.Trace(err)
@@ -133,7 +177,15 @@ func (c *consumeCommand) Run(ctx *cmd.Context) error {
 	}
 	defer sourceClient.Close()
 
-	consumeDetails, err := sourceClient.GetConsumeDetails(url.AsLocal().String())
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	controllerUUID, err := c.ControllerUUID(c.ClientStore(), controllerName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	consumeDetails, err := sourceClient.GetConsumeDetails(url.AsLocal().String(), controllerUUID)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -145,7 +197,55 @@ func (c *consumeCommand) Run(ctx *cmd.Context) error {
 	}
 	offerURL.Source = url.Source
 	consumeDetails.Offer.OfferURL = offerURL.String()
+	return c.consume(ctx, consumeDetails, url.AsLocal().String())
+}
 
+// consumeInvitation redeems the invitation held by the token
+// and adds the offer it grants access to to the model.
+func (c *consumeCommand) consumeInvitation(ctx *cmd.Context) error {
+	invitation, err := crossmodelcmd.DecodeOfferInvitation(c.token)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	sourceModelTag, err := names.ParseModelTag(invitation.Offer.SourceModelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, modelDetails, err := c.ModelDetails()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	controllerUUID, err := c.ControllerUUID(c.ClientStore(), controllerName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	redeemClient, err := c.getRedeemAPI(invitation.ControllerInfo, sourceModelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer redeemClient.Close()
+
+	mac, err := redeemClient.RedeemOfferInvitation(modelDetails.ModelUUID, controllerUUID, invitation.Macaroon)
+	if errors.IsNotSupported(err) {
+		return errors.New("consume --token is not supported by the controller hosting the offer")
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot redeem invitation to consume %v", invitation.Offer.OfferURL)
+	}
+	c.remoteApplication = invitation.Offer.OfferURL
+	return c.consume(ctx, params.ConsumeOfferDetails{
+		Offer:          invitation.Offer,
+		Macaroon:       mac,
+		ControllerInfo: invitation.ControllerInfo,
+	}, invitation.Offer.OfferURL)
+}
+
+// consume adds the offer with the specified details to the model.
+func (c *consumeCommand) consume(ctx *cmd.Context, consumeDetails params.ConsumeOfferDetails, offerURL string) error {
 	targetClient, err := c.getTargetAPI()
 	if err != nil {
 		return errors.Trace(err)
@@ -171,7 +271,7 @@ func (c *consumeCommand) Run(ctx *cmd.Context) error {
 	}
 	localName, err := targetClient.Consume(arg)
 	if err != nil {
-		return block.ProcessBlockedError(errors.Annotatef(err, "could not consume %v", url.AsLocal().String()), block.BlockChange)
+		return block.ProcessBlockedError(errors.Annotatef(err, "could not consume %v", offerURL), block.BlockChange)
 	}
 	ctx.Infof("Added %s as %s", c.remoteApplication, localName)
 	return nil
@@ -184,5 +284,10 @@ type applicationConsumeAPI interface {
 
 type applicationConsumeDetailsAPI interface {
 	Close() error
-	GetConsumeDetails(string) (params.ConsumeOfferDetails, error)
+	GetConsumeDetails(url, consumingControllerUUID string) (params.ConsumeOfferDetails, error)
+}
+
+type offerInvitationRedeemAPI interface {
+	Close() error
+	RedeemOfferInvitation(sourceModelUUID, sourceControllerUUID string, mac *macaroon.Macaroon) (*macaroon.Macaroon, error)
 }
@@ -10,10 +10,12 @@ import (
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/macaroon.v2"
 
 	apitesting "github.com/juju/juju/api/testing"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/application"
+	crossmodelcmd "github.com/juju/juju/cmd/juju/crossmodel"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/jujuclient"
@@ -37,7 +39,7 @@ func (s *ConsumeSuite) SetUpTest(c *gc.C) {
 	controllerName := "test-master"
 	s.store = jujuclient.NewMemStore()
 	s.store.CurrentControllerName = controllerName
-	s.store.Controllers[controllerName] = jujuclient.ControllerDetails{}
+	s.store.Controllers[controllerName] = jujuclient.ControllerDetails{ControllerUUID: coretesting.ControllerTag.Id()}
 	s.store.Models[controllerName] = &jujuclient.ControllerModels{
 		CurrentModel: "bob/test",
 		Models: map[string]jujuclient.ModelDetails{
@@ -107,7 +109,7 @@ func (s *ConsumeSuite) assertSuccessModelDotApplication(c *gc.C, alias string) {
 	mac, err := apitesting.NewMacaroon("id")
 	c.Assert(err, jc.ErrorIsNil)
 	s.mockAPI.CheckCalls(c, []testing.StubCall{
-		{"GetConsumeDetails", []interface{}{"bob/booster.uke"}},
+		{"GetConsumeDetails", []interface{}{"bob/booster.uke", coretesting.ControllerTag.Id()}},
 		{"Consume", []interface{}{crossmodel.ConsumeApplicationArgs{
 			Offer:            params.ApplicationOfferDetails{OfferName: "an offer", OfferURL: "ctrl:bob/booster.uke"},
 			ApplicationAlias: alias,
@@ -134,6 +136,84 @@ func (s *ConsumeSuite) TestSuccessModelDotApplicationWithAlias(c *gc.C) {
 	s.assertSuccessModelDotApplication(c, "alias")
 }
 
+func (s *ConsumeSuite) makeToken(c *gc.C) string {
+	mac, err := apitesting.NewMacaroon("invitation")
+	c.Assert(err, jc.ErrorIsNil)
+	token, err := crossmodelcmd.EncodeOfferInvitation(params.OfferInvitation{
+		Offer: &params.ApplicationOfferDetails{
+			SourceModelTag: coretesting.ModelTag.String(),
+			OfferName:      "hosted-mysql",
+			OfferURL:       "fred/prod.hosted-mysql",
+		},
+		Macaroon: mac,
+		ControllerInfo: &params.ExternalControllerInfo{
+			ControllerTag: coretesting.ControllerTag.String(),
+			Addrs:         []string{"192.168.1:1234"},
+			CACert:        coretesting.CACert,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return token
+}
+
+func (s *ConsumeSuite) runConsumeWithToken(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, application.NewConsumeWithTokenCommandForTest(s.store, s.mockAPI, s.mockAPI), args...)
+}
+
+func (s *ConsumeSuite) TestTokenTooManyArguments(c *gc.C) {
+	_, err := s.runConsumeWithToken(c, "--token", s.makeToken(c), "alias", "something else")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["something else"\]`)
+}
+
+func (s *ConsumeSuite) TestInvalidToken(c *gc.C) {
+	_, err := s.runConsumeWithToken(c, "--token", "invalid")
+	c.Assert(err, gc.ErrorMatches, "offer invitation token not valid")
+}
+
+func (s *ConsumeSuite) TestConsumeWithToken(c *gc.C) {
+	s.mockAPI.localName = "hosted-mysql"
+	ctx, err := s.runConsumeWithToken(c, "--token", s.makeToken(c), "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+
+	invitationMac, err := apitesting.NewMacaroon("invitation")
+	c.Assert(err, jc.ErrorIsNil)
+	mac, err := apitesting.NewMacaroon("id")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"RedeemOfferInvitation", []interface{}{"test-uuid", coretesting.ControllerTag.Id(), invitationMac}},
+		{"Consume", []interface{}{crossmodel.ConsumeApplicationArgs{
+			Offer: params.ApplicationOfferDetails{
+				SourceModelTag: coretesting.ModelTag.String(),
+				OfferName:      "hosted-mysql",
+				OfferURL:       "fred/prod.hosted-mysql",
+			},
+			ApplicationAlias: "mysql",
+			Macaroon:         mac,
+			ControllerInfo: &crossmodel.ControllerInfo{
+				ControllerTag: coretesting.ControllerTag,
+				Addrs:         []string{"192.168.1:1234"},
+				CACert:        coretesting.CACert,
+			},
+		}}},
+		{"Close", nil},
+		{"Close", nil},
+	})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Added fred/prod.hosted-mysql as hosted-mysql\n")
+}
+
+func (s *ConsumeSuite) TestConsumeWithTokenRedeemError(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New("invitation has expired"))
+	_, err := s.runConsumeWithToken(c, "--token", s.makeToken(c))
+	c.Assert(err, gc.ErrorMatches, "cannot redeem invitation to consume fred/prod.hosted-mysql: invitation has expired")
+	s.mockAPI.CheckCallNames(c, "RedeemOfferInvitation", "Close")
+}
+
+func (s *ConsumeSuite) TestConsumeWithTokenNotSupported(c *gc.C) {
+	s.mockAPI.SetErrors(errors.NotSupportedf("RedeemOfferInvitation"))
+	_, err := s.runConsumeWithToken(c, "--token", s.makeToken(c))
+	c.Assert(err, gc.ErrorMatches, "consume --token is not supported by the controller hosting the offer")
+}
+
 type mockConsumeAPI struct {
 	*testing.Stub
 
@@ -150,8 +230,8 @@ func (a *mockConsumeAPI) Consume(arg crossmodel.ConsumeApplicationArgs) (string,
 	return a.localName, a.NextErr()
 }
 
-func (a *mockConsumeAPI) GetConsumeDetails(url string) (params.ConsumeOfferDetails, error) {
-	a.MethodCall(a, "GetConsumeDetails", url)
+func (a *mockConsumeAPI) GetConsumeDetails(url, consumingControllerUUID string) (params.ConsumeOfferDetails, error) {
+	a.MethodCall(a, "GetConsumeDetails", url, consumingControllerUUID)
 	mac, err := apitesting.NewMacaroon("id")
 	if err != nil {
 		return params.ConsumeOfferDetails{}, err
@@ -167,3 +247,11 @@ func (a *mockConsumeAPI) GetConsumeDetails(url string) (params.ConsumeOfferDetai
 		},
 	}, a.NextErr()
 }
+
+func (a *mockConsumeAPI) RedeemOfferInvitation(sourceModelUUID, sourceControllerUUID string, mac *macaroon.Macaroon) (*macaroon.Macaroon, error) {
+	a.MethodCall(a, "RedeemOfferInvitation", sourceModelUUID, sourceControllerUUID, mac)
+	if err := a.NextErr(); err != nil {
+		return nil, err
+	}
+	return apitesting.NewMacaroon("id")
+}
@@ -101,7 +101,7 @@ type OfferAPI interface {
 }
 
 type ConsumeDetails interface {
-	GetConsumeDetails(url string) (apiparams.ConsumeOfferDetails, error)
+	GetConsumeDetails(url, consumingControllerUUID string) (apiparams.ConsumeOfferDetails, error)
 	Close() error
 }
 
@@ -878,6 +878,9 @@ func (c *DeployCommand) deployBundle(spec bundleDeploySpec) (rErr error) {
 		return errors.Trace(err)
 	}
 	spec.controllerName = controllerName
+	if spec.controllerUUID, err = c.ControllerUUID(c.ClientStore(), controllerName); err != nil {
+		return errors.Trace(err)
+	}
 	accountDetails, err := c.CurrentAccountDetails()
 	if err != nil {
 		return errors.Trace(err)
@@ -853,7 +853,7 @@ func (s *DeploySuite) TestDeployBundleWithSAAS(c *gc.C) {
 	}).Returns([]string{"wordpress/0"}, error(nil))
 
 	s.fakeAPI.Call("GetConsumeDetails",
-		"admin/default.mysql",
+		"admin/default.mysql", coretesting.ControllerTag.Id(),
 	).Returns(params.ConsumeOfferDetails{
 		Offer: &params.ApplicationOfferDetails{
 			OfferName: "mysql",
@@ -2376,8 +2376,8 @@ func (f *fakeDeployAPI) Offer(modelUUID, application string, endpoints []string,
 	return results[0].([]params.ErrorResult), jujutesting.TypeAssertError(results[1])
 }
 
-func (f *fakeDeployAPI) GetConsumeDetails(offerURL string) (params.ConsumeOfferDetails, error) {
-	results := f.MethodCall(f, "GetConsumeDetails", offerURL)
+func (f *fakeDeployAPI) GetConsumeDetails(offerURL, consumingControllerUUID string) (params.ConsumeOfferDetails, error) {
+	results := f.MethodCall(f, "GetConsumeDetails", offerURL, consumingControllerUUID)
 	return results[0].(params.ConsumeOfferDetails), jujutesting.TypeAssertError(results[1])
 }
 
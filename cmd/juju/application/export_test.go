@@ -224,6 +224,18 @@ func NewConsumeCommandForTest(
 	return modelcmd.Wrap(c)
 }
 
+// NewConsumeWithTokenCommandForTest returns a ConsumeCommand with the
+// specified api for redeeming offer invitations.
+func NewConsumeWithTokenCommandForTest(
+	store jujuclient.ClientStore,
+	redeemAPI offerInvitationRedeemAPI,
+	targetAPI applicationConsumeAPI,
+) cmd.Command {
+	c := &consumeCommand{redeemAPI: redeemAPI, targetAPI: targetAPI}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
 // NewSetSeriesCommandForTest returns a SetSeriesCommand with the specified api.
 func NewSetSeriesCommandForTest(
 	seriesAPI setSeriesAPI,
@@ -272,6 +284,15 @@ func NewScaleCommandForTest(api scaleApplicationAPI, store jujuclient.ClientStor
 	return modelcmd.Wrap(cmd)
 }
 
+// NewSetAutoscaleCommandForTest returns a set-autoscale command with the api provided as specified.
+func NewSetAutoscaleCommandForTest(api setAutoscaleAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &setAutoscaleCommand{newAPIFunc: func() (setAutoscaleAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 func NewBundleDiffCommandForTest(api base.APICallCloser, charmStore BundleResolver, store jujuclient.ClientStore) modelcmd.ModelCommand {
 	cmd := &bundleDiffCommand{
 		_apiRoot:    api,
@@ -297,6 +318,14 @@ func NewShowUnitCommandForTest(api UnitsInfoAPI, store jujuclient.ClientStore) c
 	return modelcmd.Wrap(cmd)
 }
 
+func NewHookHistoryCommandForTest(api HookHistoryAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &hookHistoryCommand{newAPIFunc: func() (HookHistoryAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 // RepoSuiteBaseSuite allows the patching of the supported juju suite for
 // each test.
 type RepoSuiteBaseSuite struct {
@@ -309,3 +338,35 @@ func (s *RepoSuiteBaseSuite) SetUpTest(c *gc.C) {
 		return defaultSupportedJujuSeries, nil
 	})
 }
+
+func NewShowSaasCommandForTest(api ShowSaasAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &showSaasCommand{newAPIFunc: func() (ShowSaasAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewTraceUnitCommandForTest(api TraceUnitAPI, logAPI TraceLogAPI, clock jujuclock.Clock, store jujuclient.ClientStore) cmd.Command {
+	cmd := &traceUnitCommand{
+		clock: clock,
+		newAPIFunc: func() (TraceUnitAPI, error) {
+			return api, nil
+		},
+		newLogAPIFunc: func() (TraceLogAPI, error) {
+			return logAPI, nil
+		},
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewFlushDeferredHooksCommandForTest(api FlushDeferredHooksAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &flushDeferredHooksCommand{
+		newAPIFunc: func() (FlushDeferredHooksAPI, error) {
+			return api, nil
+		},
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
@@ -0,0 +1,121 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const flushDeferredHooksDoc = `
+Runs the hooks deferred by an application's maintenance window now.
+
+While an application's maintenance window is open, its units only run
+the hooks needed to install, start, stop and remove them, and those of
+relations. Charm upgrades and the config-changed and update-status hooks
+are deferred until the window closes. The window is set with the
+maintenance-window and maintenance-window-duration application config
+options, and "juju status" shows how many hooks each unit has deferred.
+
+This command has the application's units run their deferred hooks
+straight away. Hooks are deferred again from the next start of the
+window.
+
+Examples:
+    juju config mysql maintenance-window="0 2 * * *" maintenance-window-duration=1h
+    juju flush-deferred-hooks mysql
+
+See also:
+    config
+    status
+`
+
+// NewFlushDeferredHooksCommand returns a command that has the units of
+// applications run the hooks deferred by their maintenance windows.
+func NewFlushDeferredHooksCommand() cmd.Command {
+	c := &flushDeferredHooksCommand{}
+	c.newAPIFunc = func() (FlushDeferredHooksAPI, error) {
+		root, err := c.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(c)
+}
+
+// FlushDeferredHooksAPI defines the API methods that the
+// flush-deferred-hooks command uses.
+type FlushDeferredHooksAPI interface {
+	Close() error
+	FlushDeferredHooks([]string) ([]params.ErrorResult, error)
+}
+
+type flushDeferredHooksCommand struct {
+	modelcmd.ModelCommandBase
+
+	applications []string
+	newAPIFunc   func() (FlushDeferredHooksAPI, error)
+}
+
+// Info implements Command.Info.
+func (c *flushDeferredHooksCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "flush-deferred-hooks",
+		Args:    "<application name> [<application name> ...]",
+		Purpose: "Runs the hooks deferred by an application's maintenance window now.",
+		Doc:     flushDeferredHooksDoc,
+	})
+}
+
+// Init implements Command.Init.
+func (c *flushDeferredHooksCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf("an application name must be supplied")
+	}
+	for _, name := range args {
+		if !names.IsValidApplication(name) {
+			return errors.NotValidf("application name %q", name)
+		}
+	}
+	c.applications = args
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *flushDeferredHooksCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	results, err := client.FlushDeferredHooks(c.applications)
+	if errors.IsNotSupported(err) {
+		return errors.New("flushing deferred hooks is not supported by this version of Juju")
+	}
+	if err := block.ProcessBlockedError(err, block.BlockChange); err != nil {
+		return errors.Trace(err)
+	}
+	anyFailed := false
+	for i, name := range c.applications {
+		if err := results[i].Error; err != nil {
+			anyFailed = true
+			ctx.Infof("flushing deferred hooks of application %s failed: %s", name, err)
+			continue
+		}
+		ctx.Infof("flushing deferred hooks of application %s", name)
+	}
+	if anyFailed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient"
+	jujutesting "github.com/juju/juju/testing"
+)
+
+type FlushDeferredHooksSuite struct {
+	jujutesting.FakeJujuXDGDataHomeSuite
+	store *jujuclient.MemStore
+	api   *mockFlushDeferredHooksAPI
+}
+
+var _ = gc.Suite(&FlushDeferredHooksSuite{})
+
+func (s *FlushDeferredHooksSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Models["testing"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"admin/controller": {},
+		},
+		CurrentModel: "admin/controller",
+	}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	s.api = &mockFlushDeferredHooksAPI{}
+}
+
+func (s *FlushDeferredHooksSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := application.NewFlushDeferredHooksCommandForTest(s.api, s.store)
+	return cmdtesting.RunCommand(c, command, args...)
+}
+
+func (s *FlushDeferredHooksSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "an application name must be supplied",
+	}, {
+		args: []string{"mysql/0"},
+		err:  `application name "mysql/0" not valid`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.run(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *FlushDeferredHooksSuite) TestFlush(c *gc.C) {
+	s.api.results = []params.ErrorResult{{}, {}}
+	ctx, err := s.run(c, "mysql", "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+flushing deferred hooks of application mysql
+flushing deferred hooks of application wordpress
+`[1:])
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"FlushDeferredHooks", []interface{}{[]string{"mysql", "wordpress"}}},
+		{"Close", nil},
+	})
+}
+
+func (s *FlushDeferredHooksSuite) TestResultError(c *gc.C) {
+	s.api.results = []params.ErrorResult{{
+		Error: &params.Error{Message: `application "mysql" not found`, Code: params.CodeNotFound},
+	}, {}}
+	ctx, err := s.run(c, "mysql", "wordpress")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+flushing deferred hooks of application mysql failed: application "mysql" not found
+flushing deferred hooks of application wordpress
+`[1:])
+}
+
+func (s *FlushDeferredHooksSuite) TestNotSupported(c *gc.C) {
+	s.api.SetErrors(errors.NotSupportedf("FlushDeferredHooks for Application facade v16"))
+	_, err := s.run(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, "flushing deferred hooks is not supported by this version of Juju")
+}
+
+type mockFlushDeferredHooksAPI struct {
+	testing.Stub
+	results []params.ErrorResult
+}
+
+func (m *mockFlushDeferredHooksAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
+
+func (m *mockFlushDeferredHooksAPI) FlushDeferredHooks(applications []string) ([]params.ErrorResult, error) {
+	m.MethodCall(m, "FlushDeferredHooks", applications)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.results, nil
+}
@@ -0,0 +1,201 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+const hookHistoryDoc = `
+Shows the most recent hook executions recorded for a unit, together with
+when each hook started, how long it ran for and whether it succeeded,
+failed or was stopped after exceeding its timeout.
+
+By default the most recent executions are listed first. Use --sort duration
+to list the slowest hooks first when tracking down slow charms.
+
+Examples:
+    juju hook-history mysql/0
+    juju hook-history mysql/0 --sort duration --limit 10
+    juju hook-history mysql/0 --format yaml
+
+See also:
+    show-unit
+    show-status-log
+`
+
+const (
+	hookHistorySortTime     = "time"
+	hookHistorySortDuration = "duration"
+)
+
+// NewHookHistoryCommand returns a command that displays the hook
+// execution history of a unit.
+func NewHookHistoryCommand() cmd.Command {
+	c := &hookHistoryCommand{}
+	c.newAPIFunc = func() (HookHistoryAPI, error) {
+		root, err := c.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(c)
+}
+
+// HookHistoryAPI defines the API methods that the hook-history command uses.
+type HookHistoryAPI interface {
+	Close() error
+	UnitsHookHistory([]names.UnitTag) ([]params.HookHistoryResult, error)
+}
+
+type hookHistoryCommand struct {
+	modelcmd.ModelCommandBase
+
+	out     cmd.Output
+	unit    string
+	sortBy  string
+	limit   int
+	isoTime bool
+
+	newAPIFunc func() (HookHistoryAPI, error)
+}
+
+// HookExecution defines the serialization behaviour of a hook execution.
+type HookExecution struct {
+	Hook     string `yaml:"hook" json:"hook"`
+	Started  string `yaml:"started" json:"started"`
+	Finished string `yaml:"finished" json:"finished"`
+	Duration string `yaml:"duration" json:"duration"`
+	Result   string `yaml:"result" json:"result"`
+	Message  string `yaml:"message,omitempty" json:"message,omitempty"`
+}
+
+// Info implements Command.Info.
+func (c *hookHistoryCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "hook-history",
+		Args:    "<unit name>",
+		Purpose: "Displays the hook execution history of a unit.",
+		Doc:     hookHistoryDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *hookHistoryCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": c.formatTabular,
+	})
+	f.StringVar(&c.sortBy, "sort", hookHistorySortTime, `Sort by "time" (most recent first) or "duration" (slowest first)`)
+	f.IntVar(&c.limit, "limit", 0, "Show at most this many hook executions (0 shows all)")
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+}
+
+// Init implements Command.Init.
+func (c *hookHistoryCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf("a unit name must be supplied")
+	}
+	c.unit, args = args[0], args[1:]
+	if !names.IsValidUnit(c.unit) {
+		return errors.NotValidf("unit name %q", c.unit)
+	}
+	switch c.sortBy {
+	case hookHistorySortTime, hookHistorySortDuration:
+	default:
+		return errors.NotValidf("sort %q", c.sortBy)
+	}
+	if c.limit < 0 {
+		return errors.NotValidf("negative limit %d", c.limit)
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.Run.
+func (c *hookHistoryCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	results, err := client.UnitsHookHistory([]names.UnitTag{names.NewUnitTag(c.unit)})
+	if errors.IsNotSupported(err) {
+		return errors.New("hook history is not supported by this version of Juju")
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if results[0].Error != nil {
+		return results[0].Error
+	}
+
+	executions := results[0].Executions
+	if c.sortBy == hookHistorySortDuration {
+		sort.SliceStable(executions, func(i, j int) bool {
+			return executions[i].Finished.Sub(executions[i].Started) > executions[j].Finished.Sub(executions[j].Started)
+		})
+	}
+	if c.limit > 0 && len(executions) > c.limit {
+		executions = executions[:c.limit]
+	}
+	if len(executions) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No hook executions recorded for unit %q.", c.unit)
+		return nil
+	}
+
+	formatted := make([]HookExecution, len(executions))
+	for i, one := range executions {
+		formatted[i] = HookExecution{
+			Hook:     one.Hook,
+			Started:  common.FormatTime(&one.Started, c.isoTime),
+			Finished: common.FormatTime(&one.Finished, c.isoTime),
+			Duration: formatHookDuration(one.Finished.Sub(one.Started)),
+			Result:   one.Result,
+			Message:  one.Message,
+		}
+	}
+	return c.out.Write(ctx, formatted)
+}
+
+func (c *hookHistoryCommand) formatTabular(writer io.Writer, value interface{}) error {
+	executions, ok := value.([]HookExecution)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", executions, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Hook", "Started", "Duration", "Result", "Message")
+	for _, one := range executions {
+		w.Println(one.Hook, one.Started, one.Duration, one.Result, one.Message)
+	}
+	return tw.Flush()
+}
+
+// formatHookDuration rounds hook durations so that they are readable
+// while still showing the difference between fast hooks.
+func formatHookDuration(d time.Duration) string {
+	if d < time.Second {
+		return fmt.Sprint(d.Round(time.Millisecond))
+	}
+	return fmt.Sprint(d.Round(100 * time.Millisecond))
+}
@@ -0,0 +1,173 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient"
+	jujutesting "github.com/juju/juju/testing"
+)
+
+type HookHistorySuite struct {
+	jujutesting.FakeJujuXDGDataHomeSuite
+	store *jujuclient.MemStore
+
+	api *mockHookHistoryAPI
+}
+
+var _ = gc.Suite(&HookHistorySuite{})
+
+func (s *HookHistorySuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Models["testing"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"admin/controller": {},
+		},
+		CurrentModel: "admin/controller",
+	}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+
+	started := time.Date(2020, 7, 1, 10, 0, 0, 0, time.UTC)
+	s.api = &mockHookHistoryAPI{
+		results: []params.HookHistoryResult{{
+			Executions: []params.HookExecution{{
+				Hook:     "config-changed",
+				Started:  started.Add(5 * time.Minute),
+				Finished: started.Add(5*time.Minute + 1500*time.Millisecond),
+				Result:   "failed",
+				Message:  "exit status 1",
+			}, {
+				Hook:     "start",
+				Started:  started.Add(4 * time.Minute),
+				Finished: started.Add(4*time.Minute + 250*time.Millisecond),
+				Result:   "succeeded",
+			}, {
+				Hook:     "install",
+				Started:  started,
+				Finished: started.Add(3*time.Minute + 20*time.Second),
+				Result:   "timed-out",
+				Message:  "hook timed out after 3m20s",
+			}},
+		}},
+	}
+}
+
+func (s *HookHistorySuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, application.NewHookHistoryCommandForTest(s.api, s.store), args...)
+}
+
+func (s *HookHistorySuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "a unit name must be supplied",
+	}, {
+		args: []string{"mysql"},
+		err:  `unit name "mysql" not valid`,
+	}, {
+		args: []string{"mysql/0", "mysql/1"},
+		err:  `unrecognized args: \["mysql/1"\]`,
+	}, {
+		args: []string{"mysql/0", "--sort", "name"},
+		err:  `sort "name" not valid`,
+	}, {
+		args: []string{"mysql/0", "--limit", "-1"},
+		err:  `negative limit -1 not valid`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.run(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *HookHistorySuite) TestTabular(c *gc.C) {
+	ctx, err := s.run(c, "mysql/0", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Hook            Started               Duration  Result     Message
+config-changed  2020-07-01 10:05:00Z  1.5s      failed     exit status 1
+start           2020-07-01 10:04:00Z  250ms     succeeded  
+install         2020-07-01 10:00:00Z  3m20s     timed-out  hook timed out after 3m20s
+
+`[1:])
+	s.api.CheckCall(c, 0, "UnitsHookHistory", []names.UnitTag{names.NewUnitTag("mysql/0")})
+	s.api.CheckCall(c, 1, "Close")
+}
+
+func (s *HookHistorySuite) TestSortByDurationWithLimit(c *gc.C) {
+	ctx, err := s.run(c, "mysql/0", "--utc", "--sort", "duration", "--limit", "2", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- hook: install
+  started: 2020-07-01 10:00:00Z
+  finished: 2020-07-01 10:03:20Z
+  duration: 3m20s
+  result: timed-out
+  message: hook timed out after 3m20s
+- hook: config-changed
+  started: 2020-07-01 10:05:00Z
+  finished: 2020-07-01 10:05:01Z
+  duration: 1.5s
+  result: failed
+  message: exit status 1
+`[1:])
+}
+
+func (s *HookHistorySuite) TestNoHistory(c *gc.C) {
+	s.api.results = []params.HookHistoryResult{{}}
+	ctx, err := s.run(c, "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No hook executions recorded for unit \"mysql/0\".\n")
+}
+
+func (s *HookHistorySuite) TestResultError(c *gc.C) {
+	s.api.results = []params.HookHistoryResult{{
+		Error: &params.Error{Message: `unit "mysql/0" not found`, Code: params.CodeNotFound},
+	}}
+	_, err := s.run(c, "mysql/0")
+	c.Assert(err, gc.ErrorMatches, `unit "mysql/0" not found`)
+}
+
+func (s *HookHistorySuite) TestNotSupported(c *gc.C) {
+	s.api.SetErrors(errors.NotSupportedf("UnitsHookHistory for Application facade v13"))
+	_, err := s.run(c, "mysql/0")
+	c.Assert(err, gc.ErrorMatches, "hook history is not supported by this version of Juju")
+}
+
+type mockHookHistoryAPI struct {
+	testing.Stub
+	results []params.HookHistoryResult
+}
+
+func (m *mockHookHistoryAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
+
+func (m *mockHookHistoryAPI) UnitsHookHistory(units []names.UnitTag) ([]params.HookHistoryResult, error) {
+	m.MethodCall(m, "UnitsHookHistory", units)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.results, nil
+}
@@ -5,6 +5,7 @@ package application
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -27,16 +28,45 @@ type resolvedCommand struct {
 	applicationResolveAPI applicationResolveAPI
 	clientAPI             clientAPI
 
-	UnitNames []string
-	NoRetry   bool
-	All       bool
+	UnitNames    []string
+	NoRetry      bool
+	All          bool
+	Applications []string
+	Hooks        []string
 }
 
+const resolvedDoc = `
+Marks the given units, every unit in error with --all, or the units in
+error of the applications given with --application as resolved. Failed
+hooks are re-executed unless --no-retry is given.
+
+With --hook, only the units in error in one of the given hooks are
+resolved. A relation hook such as relation-changed matches that hook on
+every relation.
+
+Failed hooks are retried automatically. How often they are retried can
+be set for each application with the hook-retry-max-attempts,
+hook-retry-min-interval and hook-retry-max-interval application config
+settings.
+
+Examples:
+
+    juju resolved mysql/0
+    juju resolved --all --no-retry
+    juju resolved --application mysql --hook config-changed
+    juju resolved --all --hook relation-changed,relation-joined
+
+See also:
+    config
+    status
+`
+
 func (c *resolvedCommand) Info() *cmd.Info {
 	return jujucmd.Info(&cmd.Info{
 		Name:    "resolved",
 		Args:    "[<unit> ...]",
 		Purpose: "Marks unit errors resolved and re-executes failed hooks.",
+		Doc:     resolvedDoc,
 		Aliases: []string{"resolve"},
 	})
 }
@@ -45,13 +75,25 @@ func (c *resolvedCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.BoolVar(&c.NoRetry, "no-retry", false, "Do not re-execute failed hooks on the unit")
 	f.BoolVar(&c.All, "all", false, "Marks all units in error as resolved")
+	f.Var(cmd.NewAppendStringsValue(&c.Applications), "application", "Marks the units in error of these comma delimited applications as resolved")
+	f.Var(cmd.NewAppendStringsValue(&c.Hooks), "hook", "Only resolve units in error in these comma delimited hooks")
 }
 
 func (c *resolvedCommand) Init(args []string) error {
+	c.Applications = splitCommaDelimited(c.Applications)
+	c.Hooks = splitCommaDelimited(c.Hooks)
+	for _, name := range c.Applications {
+		if !names.IsValidApplication(name) {
+			return errors.NotValidf("application name %q", name)
+		}
+	}
 	if c.All {
 		if len(args) > 0 {
 			return errors.NotSupportedf("specifying unit names(s) with --all")
 		}
+		if len(c.Applications) > 0 {
+			return errors.NotSupportedf("specifying applications with --all")
+		}
 		return nil
 	}
 	if len(args) > 0 {
@@ -61,16 +103,31 @@ func (c *resolvedCommand) Init(args []string) error {
 				return errors.NotValidf("unit name %q", u)
 			}
 		}
-	} else {
+	} else if len(c.Applications) == 0 {
 		return errors.Errorf("no unit specified")
 	}
 	return nil
 }
 
+// splitCommaDelimited returns the values given to a repeatable flag
+// that also accepts comma delimited lists.
+func splitCommaDelimited(values []string) []string {
+	var result []string
+	for _, value := range values {
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				result = append(result, v)
+			}
+		}
+	}
+	return result
+}
+
 type applicationResolveAPI interface {
 	Close() error
 	BestAPIVersion() int
 	ResolveUnitErrors(units []string, all, retry bool) error
+	ResolveUnitErrorsFiltered(units []string, filter application.ResolveFilter, all, retry bool) error
 }
 
 type clientAPI interface {
@@ -104,6 +161,17 @@ func (c *resolvedCommand) Run(ctx *cmd.Context) error {
 	}
 	defer applicationResolveAPI.Close()
 
+	if len(c.Applications) > 0 || len(c.Hooks) > 0 {
+		if applicationResolveAPI.BestAPIVersion() < 16 {
+			return errors.Errorf("resolving by application or hook not supported by this version of Juju")
+		}
+		filter := application.ResolveFilter{
+			Applications: c.Applications,
+			Hooks:        c.Hooks,
+		}
+		return block.ProcessBlockedError(applicationResolveAPI.ResolveUnitErrorsFiltered(c.UnitNames, filter, c.All, !c.NoRetry), block.BlockChange)
+	}
+
 	if applicationResolveAPI.BestAPIVersion() >= 6 {
 		return block.ProcessBlockedError(applicationResolveAPI.ResolveUnitErrors(c.UnitNames, c.All, !c.NoRetry), block.BlockChange)
 	}
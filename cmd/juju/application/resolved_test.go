@@ -9,6 +9,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	apiapplication "github.com/juju/juju/api/application"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/application"
 	"github.com/juju/juju/jujuclient/jujuclienttesting"
@@ -42,6 +43,7 @@ var resolvedTests = []struct {
 	all         bool
 	legacyUnits []string
 	units       []string
+	filter      *apiapplication.ResolveFilter
 }{
 	{
 		err: `no unit specified`,
@@ -79,6 +81,39 @@ var resolvedTests = []struct {
 		args:  []string{"jeremy-fisher/98", "jeremy-fisher/99"},
 		units: []string{"jeremy-fisher/98", "jeremy-fisher/99"},
 		retry: true,
+	}, {
+		args: []string{"--application", "jeremy/fisher"},
+		err:  `application name "jeremy/fisher" not valid`,
+	}, {
+		args: []string{"--all", "--application", "jeremy-fisher"},
+		err:  `specifying applications with --all not supported`,
+	}, {
+		args: []string{"--hook", "config-changed"},
+		err:  `no unit specified`,
+	}, {
+		args:  []string{"--application", "jeremy-fisher,peter", "--hook", "install", "--hook", "config-changed"},
+		retry: true,
+		filter: &apiapplication.ResolveFilter{
+			Applications: []string{"jeremy-fisher", "peter"},
+			Hooks:        []string{"install", "config-changed"},
+		},
+	}, {
+		args: []string{"--all", "--hook", "relation-changed", "--no-retry"},
+		all:  true,
+		filter: &apiapplication.ResolveFilter{
+			Hooks: []string{"relation-changed"},
+		},
+	}, {
+		args:  []string{"jeremy-fisher/99", "--hook", "start"},
+		units: []string{"jeremy-fisher/99"},
+		retry: true,
+		filter: &apiapplication.ResolveFilter{
+			Hooks: []string{"start"},
+		},
+	}, {
+		apiVersion: 15,
+		args:       []string{"--application", "jeremy-fisher"},
+		err:        `resolving by application or hook not supported by this version of Juju`,
 	},
 }
 
@@ -89,7 +124,7 @@ func (s *ResolvedSuite) TestResolved(c *gc.C) {
 		if t.apiVersion > 0 {
 			s.mockAPI.version = t.apiVersion
 		} else {
-			s.mockAPI.version = 6
+			s.mockAPI.version = 16
 		}
 		err := s.runResolved(c, t.args)
 		if t.err != "" {
@@ -108,6 +143,9 @@ func (s *ResolvedSuite) TestResolved(c *gc.C) {
 			for j, legacyUnit := range t.legacyUnits {
 				s.mockAPI.CheckCall(c, j+1, "Resolved", legacyUnit, t.retry)
 			}
+		} else if t.filter != nil {
+			s.mockAPI.CheckCallNames(c, "BestAPIVersion", "ResolveUnitErrorsFiltered", "Close")
+			s.mockAPI.CheckCall(c, 1, "ResolveUnitErrorsFiltered", t.units, *t.filter, t.all, t.retry)
 		} else {
 			s.mockAPI.CheckCallNames(c, "BestAPIVersion", "ResolveUnitErrors", "Close")
 			s.mockAPI.CheckCall(c, 1, "ResolveUnitErrors", t.units, t.all, t.retry)
@@ -136,6 +174,11 @@ func (s mockResolveAPI) ResolveUnitErrors(units []string, all, retry bool) error
 	return nil
 }
 
+func (s mockResolveAPI) ResolveUnitErrorsFiltered(units []string, filter apiapplication.ResolveFilter, all, retry bool) error {
+	s.MethodCall(s, "ResolveUnitErrorsFiltered", units, filter, all, retry)
+	return nil
+}
+
 func (s mockResolveAPI) Resolved(unit string, retry bool) error {
 	s.MethodCall(s, "Resolved", unit, retry)
 	return nil
@@ -0,0 +1,175 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/application"
+	k8s "github.com/juju/juju/caas/kubernetes/provider"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/model"
+)
+
+// NewSetAutoscaleCommand returns a command which configures the
+// autoscaling of an application's units.
+func NewSetAutoscaleCommand() modelcmd.ModelCommand {
+	cmd := &setAutoscaleCommand{}
+	cmd.newAPIFunc = func() (setAutoscaleAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// setAutoscaleCommand is responsible for configuring application autoscaling.
+type setAutoscaleCommand struct {
+	modelcmd.ModelCommandBase
+	modelcmd.CAASOnlyCommand
+
+	newAPIFunc      func() (setAutoscaleAPI, error)
+	applicationName string
+	min             int
+	max             int
+	cpu             string
+	disable         bool
+}
+
+const setAutoscaleDoc = `
+Scale a k8s application automatically between a minimum and maximum number
+of units, based on the CPU utilisation of its pods. Setting the maximum
+enables autoscaling; the minimum defaults to 1 unit.
+
+While autoscaling is enabled, the number of units Juju records for the
+application follows the autoscaler, and "juju scale-application" is
+refused. Use --disable to return to manual scaling; the application keeps
+its current number of units.
+
+Only the given settings are changed.
+
+Examples:
+
+    juju set-autoscale mariadb --min 2 --max 10 --cpu 70%
+    juju set-autoscale mariadb --max 20
+    juju set-autoscale mariadb --disable
+
+See also:
+    scale-application
+`
+
+// Info implements cmd.Command.
+func (c *setAutoscaleCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "set-autoscale",
+		Args:    "<application>",
+		Purpose: "Configure automatic scaling of application units.",
+		Doc:     setAutoscaleDoc,
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *setAutoscaleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.IntVar(&c.min, "min", 0, "Minimum number of units")
+	f.IntVar(&c.max, "max", 0, "Maximum number of units")
+	f.StringVar(&c.cpu, "cpu", "", "Target average CPU utilisation, as a percentage")
+	f.BoolVar(&c.disable, "disable", false, "Disable autoscaling")
+}
+
+func (c *setAutoscaleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf("no application specified")
+	}
+	c.applicationName = args[0]
+	if !names.IsValidApplication(c.applicationName) {
+		return errors.Errorf("invalid application name %q", c.applicationName)
+	}
+	if err := cmd.CheckEmpty(args[1:]); err != nil {
+		return err
+	}
+	if c.disable {
+		if c.min != 0 || c.max != 0 || c.cpu != "" {
+			return errors.New("--disable cannot be used with --min, --max or --cpu")
+		}
+		return nil
+	}
+	if c.min == 0 && c.max == 0 && c.cpu == "" {
+		return errors.New("specify --min, --max or --cpu, or --disable")
+	}
+	if c.min < 0 {
+		return errors.New("--min must be a positive integer")
+	}
+	if c.max < 0 {
+		return errors.New("--max must be a positive integer")
+	}
+	if c.min > 0 && c.max > 0 && c.max < c.min {
+		return errors.New("--max must not be less than --min")
+	}
+	if c.cpu != "" {
+		cpu, err := strconv.Atoi(strings.TrimSuffix(c.cpu, "%"))
+		if err != nil || cpu <= 0 || cpu > 100 {
+			return errors.Errorf("invalid --cpu %q, expected a percentage between 1%% and 100%%", c.cpu)
+		}
+		c.cpu = strconv.Itoa(cpu)
+	}
+	return nil
+}
+
+type setAutoscaleAPI interface {
+	Close() error
+	BestAPIVersion() int
+	SetApplicationConfig(branchName, application string, config map[string]string) error
+	UnsetApplicationConfig(branchName, application string, options []string) error
+}
+
+// Run implements cmd.Command.
+func (c *setAutoscaleCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if client.BestAPIVersion() < 6 {
+		return errors.New("autoscaling applications is not supported by this controller")
+	}
+
+	if c.disable {
+		err := client.UnsetApplicationConfig(model.GenerationMaster, c.applicationName, []string{
+			k8s.AutoscaleMinConfigKey, k8s.AutoscaleMaxConfigKey, k8s.AutoscaleCPUConfigKey,
+		})
+		if err != nil {
+			return block.ProcessBlockedError(errors.Annotatef(err, "could not disable autoscaling of %q", c.applicationName), block.BlockChange)
+		}
+		ctx.Infof("Autoscaling of %v disabled", c.applicationName)
+		return nil
+	}
+
+	config := make(map[string]string)
+	if c.min > 0 {
+		config[k8s.AutoscaleMinConfigKey] = strconv.Itoa(c.min)
+	}
+	if c.max > 0 {
+		config[k8s.AutoscaleMaxConfigKey] = strconv.Itoa(c.max)
+	}
+	if c.cpu != "" {
+		config[k8s.AutoscaleCPUConfigKey] = c.cpu
+	}
+	if err := client.SetApplicationConfig(model.GenerationMaster, c.applicationName, config); err != nil {
+		return block.ProcessBlockedError(errors.Annotatef(err, "could not set autoscaling of %q", c.applicationName), block.BlockChange)
+	}
+	ctx.Infof("Autoscaling of %v updated", c.applicationName)
+	return nil
+}
@@ -0,0 +1,141 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type SetAutoscaleSuite struct {
+	testing.IsolationSuite
+
+	mockAPI *mockSetAutoscaleAPI
+}
+
+var _ = gc.Suite(&SetAutoscaleSuite{})
+
+type mockSetAutoscaleAPI struct {
+	*testing.Stub
+	version int
+}
+
+func (s mockSetAutoscaleAPI) Close() error {
+	s.MethodCall(s, "Close")
+	return s.NextErr()
+}
+
+func (s mockSetAutoscaleAPI) BestAPIVersion() int {
+	return s.version
+}
+
+func (s mockSetAutoscaleAPI) SetApplicationConfig(branchName, application string, config map[string]string) error {
+	s.MethodCall(s, "SetApplicationConfig", branchName, application, config)
+	return s.NextErr()
+}
+
+func (s mockSetAutoscaleAPI) UnsetApplicationConfig(branchName, application string, options []string) error {
+	s.MethodCall(s, "UnsetApplicationConfig", branchName, application, options)
+	return s.NextErr()
+}
+
+func (s *SetAutoscaleSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockSetAutoscaleAPI{Stub: &testing.Stub{}, version: 12}
+}
+
+func (s *SetAutoscaleSuite) runSetAutoscale(c *gc.C, args ...string) (*cmd.Context, error) {
+	store := jujuclienttesting.MinimalStore()
+	store.Models["arthur"] = &jujuclient.ControllerModels{
+		CurrentModel: "king/sword",
+		Models: map[string]jujuclient.ModelDetails{"king/sword": {
+			ModelType: model.CAAS,
+		}},
+	}
+	return cmdtesting.RunCommand(c, NewSetAutoscaleCommandForTest(s.mockAPI, store), args...)
+}
+
+func (s *SetAutoscaleSuite) TestSetAutoscale(c *gc.C) {
+	ctx, err := s.runSetAutoscale(c, "foo", "--min", "2", "--max", "10", "--cpu", "70%")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(strings.TrimSpace(cmdtesting.Stderr(ctx)), gc.Equals, "Autoscaling of foo updated")
+	s.mockAPI.CheckCall(c, 0, "SetApplicationConfig", model.GenerationMaster, "foo", map[string]string{
+		"kubernetes-autoscale-min": "2",
+		"kubernetes-autoscale-max": "10",
+		"kubernetes-autoscale-cpu": "70",
+	})
+}
+
+func (s *SetAutoscaleSuite) TestSetAutoscaleOnlyGivenSettings(c *gc.C) {
+	_, err := s.runSetAutoscale(c, "foo", "--max", "5")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCall(c, 0, "SetApplicationConfig", model.GenerationMaster, "foo", map[string]string{
+		"kubernetes-autoscale-max": "5",
+	})
+}
+
+func (s *SetAutoscaleSuite) TestDisableAutoscale(c *gc.C) {
+	ctx, err := s.runSetAutoscale(c, "foo", "--disable")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(strings.TrimSpace(cmdtesting.Stderr(ctx)), gc.Equals, "Autoscaling of foo disabled")
+	s.mockAPI.CheckCall(c, 0, "UnsetApplicationConfig", model.GenerationMaster, "foo", []string{
+		"kubernetes-autoscale-min", "kubernetes-autoscale-max", "kubernetes-autoscale-cpu",
+	})
+}
+
+func (s *SetAutoscaleSuite) TestSetAutoscaleBlocked(c *gc.C) {
+	s.mockAPI.SetErrors(&params.Error{Code: params.CodeOperationBlocked, Message: "nope"})
+	_, err := s.runSetAutoscale(c, "foo", "--max", "5")
+	c.Assert(err.Error(), jc.Contains, `could not set autoscaling of "foo": nope`)
+	c.Assert(err.Error(), jc.Contains, `All operations that change model have been disabled for the current model.`)
+}
+
+func (s *SetAutoscaleSuite) TestUnsupportedController(c *gc.C) {
+	s.mockAPI.version = 5
+	_, err := s.runSetAutoscale(c, "foo", "--max", "5")
+	c.Assert(err, gc.ErrorMatches, "autoscaling applications is not supported by this controller")
+	s.mockAPI.CheckCallNames(c, "Close")
+}
+
+func (s *SetAutoscaleSuite) TestInvalidArgs(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{},
+		err:  "no application specified",
+	}, {
+		args: []string{"foo"},
+		err:  "specify --min, --max or --cpu, or --disable",
+	}, {
+		args: []string{"foo", "--max", "5", "--disable"},
+		err:  "--disable cannot be used with --min, --max or --cpu",
+	}, {
+		args: []string{"foo", "--min", "5", "--max", "2"},
+		err:  "--max must not be less than --min",
+	}, {
+		args: []string{"foo", "--min", "-1"},
+		err:  "--min must be a positive integer",
+	}, {
+		args: []string{"foo", "--cpu", "150%"},
+		err:  `invalid --cpu "150%", expected a percentage between 1% and 100%`,
+	}, {
+		args: []string{"foo", "--max", "5", "bar"},
+		err:  `unrecognized args: \["bar"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.runSetAutoscale(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
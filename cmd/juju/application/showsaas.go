@@ -0,0 +1,201 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	crossmodelcmd "github.com/juju/juju/cmd/juju/crossmodel"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/life"
+)
+
+const showSaasDoc = `
+The command takes the names of consumed (SAAS) applications as arguments
+and shows the offer they were consumed from, their status and endpoints.
+
+With --diagnostics, the health of the cross model relations to the offer
+is also shown, as last recorded by the controller: when an event from the
+offering model was last processed, when the macaroons used to talk to the
+offering model expire, how long the last relation settings change took to
+reach the other model, and the ingress addresses of the remote units.
+
+Examples:
+    juju show-saas mysql
+    juju show-saas --diagnostics mysql
+
+See also:
+    consume
+    remove-saas
+    show-offer
+`
+
+// NewShowSaasCommand returns a command that displays information
+// about consumed applications.
+func NewShowSaasCommand() cmd.Command {
+	s := &showSaasCommand{}
+	s.newAPIFunc = func() (ShowSaasAPI, error) {
+		root, err := s.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(s)
+}
+
+// showSaasCommand displays information about consumed applications.
+type showSaasCommand struct {
+	modelcmd.ModelCommandBase
+
+	out         cmd.Output
+	saasNames   []string
+	diagnostics bool
+	newAPIFunc  func() (ShowSaasAPI, error)
+}
+
+// ShowSaasAPI defines the API methods that the show-saas command uses.
+type ShowSaasAPI interface {
+	Close() error
+	ConsumedApplicationsInfo([]names.ApplicationTag) ([]params.ConsumedApplicationInfoResult, error)
+}
+
+// Info implements Command.Info.
+func (c *showSaasCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "show-saas",
+		Args:    "<saas-application-name> [<saas-application-name>...]",
+		Purpose: "Displays information about consumed applications (SAAS).",
+		Doc:     showSaasDoc,
+	})
+}
+
+// Init implements Command.Init.
+func (c *showSaasCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf("no SAAS application names specified")
+	}
+	for _, arg := range args {
+		if !names.IsValidApplication(arg) {
+			return errors.Errorf("invalid SAAS application name %q", arg)
+		}
+	}
+	c.saasNames = args
+	return nil
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *showSaasCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.diagnostics, "diagnostics", false, "Show the health of the cross model relations")
+	c.out.AddFlags(f, "yaml", cmd.DefaultFormatters.Formatters())
+}
+
+// Run implements Command.Run.
+func (c *showSaasCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	tags := make([]names.ApplicationTag, len(c.saasNames))
+	for i, name := range c.saasNames {
+		tags[i] = names.NewApplicationTag(name)
+	}
+	results, err := client.ConsumedApplicationsInfo(tags)
+	if errors.IsNotSupported(err) {
+		return errors.New("show-saas is not supported by this version of Juju")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+
+	var errs params.ErrorResults
+	output := make(map[string]SaasInfo)
+	for _, result := range results {
+		if result.Error != nil {
+			errs.Results = append(errs.Results, params.ErrorResult{result.Error})
+			continue
+		}
+		info, err := c.formatSaasInfo(*result.Result)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		output[result.Result.Name] = info
+	}
+	if len(errs.Results) > 0 {
+		return errs.Combine()
+	}
+	return c.out.Write(ctx, output)
+}
+
+// SaasInfo defines the serialization behaviour of the consumed
+// application information.
+type SaasInfo struct {
+	URL             string                                      `yaml:"url,omitempty" json:"url,omitempty"`
+	OfferUUID       string                                      `yaml:"offer-uuid" json:"offer-uuid"`
+	SourceModelUUID string                                      `yaml:"source-model-uuid" json:"source-model-uuid"`
+	Life            string                                      `yaml:"life,omitempty" json:"life,omitempty"`
+	Status          SaasStatus                                  `yaml:"status" json:"status"`
+	Endpoints       map[string]crossmodelcmd.RemoteEndpoint     `yaml:"endpoints,omitempty" json:"endpoints,omitempty"`
+	Diagnostics     *crossmodelcmd.RemoteApplicationDiagnostics `yaml:"diagnostics,omitempty" json:"diagnostics,omitempty"`
+}
+
+// SaasStatus defines the serialization behaviour of the status
+// of a consumed application.
+type SaasStatus struct {
+	Current string `yaml:"current" json:"current"`
+	Message string `yaml:"message,omitempty" json:"message,omitempty"`
+	Since   string `yaml:"since,omitempty" json:"since,omitempty"`
+}
+
+func (c *showSaasCommand) formatSaasInfo(details params.ConsumedApplicationInfo) (SaasInfo, error) {
+	modelTag, err := names.ParseModelTag(details.SourceModelTag)
+	if err != nil {
+		return SaasInfo{}, errors.Trace(err)
+	}
+	info := SaasInfo{
+		URL:             details.OfferURL,
+		OfferUUID:       details.OfferUUID,
+		SourceModelUUID: modelTag.Id(),
+		Status: SaasStatus{
+			Current: details.Status.Status.String(),
+			Message: details.Status.Info,
+		},
+	}
+	if details.Life != "" && details.Life != life.Alive {
+		info.Life = string(details.Life)
+	}
+	if details.Status.Since != nil {
+		info.Status.Since = common.FormatTime(details.Status.Since, true)
+	}
+	if len(details.Endpoints) > 0 {
+		info.Endpoints = make(map[string]crossmodelcmd.RemoteEndpoint)
+		for _, ep := range details.Endpoints {
+			info.Endpoints[ep.Name] = crossmodelcmd.RemoteEndpoint{
+				Name:      ep.Name,
+				Interface: ep.Interface,
+				Role:      string(ep.Role),
+			}
+		}
+	}
+	if c.diagnostics && details.Diagnostics != nil {
+		info.Diagnostics = crossmodelcmd.ConvertDiagnostics(&crossmodel.RemoteApplicationDiagnostics{
+			LastRemoteEvent:  details.Diagnostics.LastRemoteEvent,
+			MacaroonExpiry:   details.Diagnostics.MacaroonExpiry,
+			SettingsSyncLag:  details.Diagnostics.SettingsSyncLag,
+			IngressAddresses: details.Diagnostics.IngressAddresses,
+			Updated:          details.Diagnostics.Updated,
+		})
+	}
+	return info, nil
+}
@@ -0,0 +1,157 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"time"
+
+	"github.com/juju/charm/v7"
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ShowSaasSuite struct {
+	testing.IsolationSuite
+
+	mockAPI *mockShowSaasAPI
+}
+
+var _ = gc.Suite(&ShowSaasSuite{})
+
+func (s *ShowSaasSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	lastEvent := time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC)
+	expiry := lastEvent.Add(24 * time.Hour)
+	s.mockAPI = &mockShowSaasAPI{
+		Stub: &testing.Stub{},
+		results: []params.ConsumedApplicationInfoResult{{
+			Result: &params.ConsumedApplicationInfo{
+				Name:           "mysql",
+				OfferURL:       "fred/prod.mysql",
+				OfferUUID:      "offer-uuid",
+				SourceModelTag: coretesting.ModelTag.String(),
+				Life:           life.Alive,
+				Status:         params.EntityStatus{Status: status.Active, Info: "ready", Since: &lastEvent},
+				Endpoints: []params.RemoteEndpoint{{
+					Name: "db", Interface: "mysql", Role: charm.RoleProvider,
+				}},
+				Diagnostics: &params.RemoteApplicationDiagnostics{
+					LastRemoteEvent:  &lastEvent,
+					MacaroonExpiry:   &expiry,
+					SettingsSyncLag:  2 * time.Second,
+					IngressAddresses: []string{"10.0.0.1"},
+					Updated:          lastEvent.Add(time.Minute),
+				},
+			},
+		}},
+	}
+}
+
+func (s *ShowSaasSuite) runShowSaas(c *gc.C, args ...string) (*cmd.Context, error) {
+	store := jujuclienttesting.MinimalStore()
+	return cmdtesting.RunCommand(c, application.NewShowSaasCommandForTest(s.mockAPI, store), args...)
+}
+
+func (s *ShowSaasSuite) TestNoArguments(c *gc.C) {
+	_, err := s.runShowSaas(c)
+	c.Assert(err, gc.ErrorMatches, "no SAAS application names specified")
+}
+
+func (s *ShowSaasSuite) TestInvalidName(c *gc.C) {
+	_, err := s.runShowSaas(c, "mysql/0")
+	c.Assert(err, gc.ErrorMatches, `invalid SAAS application name "mysql/0"`)
+}
+
+func (s *ShowSaasSuite) TestShow(c *gc.C) {
+	ctx, err := s.runShowSaas(c, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+mysql:
+  url: fred/prod.mysql
+  offer-uuid: offer-uuid
+  source-model-uuid: deadbeef-0bad-400d-8000-4b1d0d06f00d
+  status:
+    current: active
+    message: ready
+    since: 2020-06-01 10:00:00Z
+  endpoints:
+    db:
+      interface: mysql
+      role: provider
+`[1:])
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"ConsumedApplicationsInfo", []interface{}{[]names.ApplicationTag{names.NewApplicationTag("mysql")}}},
+		{"Close", nil},
+	})
+}
+
+func (s *ShowSaasSuite) TestShowDiagnostics(c *gc.C) {
+	ctx, err := s.runShowSaas(c, "mysql", "--diagnostics")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+mysql:
+  url: fred/prod.mysql
+  offer-uuid: offer-uuid
+  source-model-uuid: deadbeef-0bad-400d-8000-4b1d0d06f00d
+  status:
+    current: active
+    message: ready
+    since: 2020-06-01 10:00:00Z
+  endpoints:
+    db:
+      interface: mysql
+      role: provider
+  diagnostics:
+    last-remote-event: 2020-06-01 10:00:00Z
+    macaroon-expiry: 2020-06-02 10:00:00Z
+    settings-sync-lag: 2s
+    ingress-addresses:
+    - 10.0.0.1
+    updated: 2020-06-01 10:01:00Z
+`[1:])
+}
+
+func (s *ShowSaasSuite) TestShowError(c *gc.C) {
+	s.mockAPI.results = []params.ConsumedApplicationInfoResult{{
+		Error: &params.Error{Code: params.CodeNotFound, Message: `remote application "mysql" not found`},
+	}}
+	_, err := s.runShowSaas(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, `remote application "mysql" not found`)
+}
+
+func (s *ShowSaasSuite) TestShowNotSupported(c *gc.C) {
+	s.mockAPI.SetErrors(errors.NotSupportedf("ConsumedApplicationsInfo for Application facade v12"))
+	_, err := s.runShowSaas(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, "show-saas is not supported by this version of Juju")
+}
+
+type mockShowSaasAPI struct {
+	*testing.Stub
+	results []params.ConsumedApplicationInfoResult
+}
+
+func (s *mockShowSaasAPI) Close() error {
+	s.MethodCall(s, "Close")
+	return s.NextErr()
+}
+
+func (s *mockShowSaasAPI) ConsumedApplicationsInfo(tags []names.ApplicationTag) ([]params.ConsumedApplicationInfoResult, error) {
+	s.MethodCall(s, "ConsumedApplicationsInfo", tags)
+	if err := s.NextErr(); err != nil {
+		return nil, err
+	}
+	return s.results, nil
+}
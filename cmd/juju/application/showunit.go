@@ -4,6 +4,7 @@
 package application
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/juju/cmd"
@@ -23,6 +24,13 @@ The command takes deployed unit names as an argument.
 Optionally, relation data for only a specified endpoint
 or related unit may be shown, or just the application data. 
 
+For units of IAAS applications with resource limits, the memory, CPU
+shares and number of tasks the unit's hooks may use are also shown. The
+limits hold the hooks and any processes they start, but not the unit
+agent, nor services the charm runs through systemd. Limits are set with
+the unit-memory-limit, unit-cpu-shares and unit-tasks-limit application
+config options.
+
 Examples:
     juju show-unit mysql/0
     juju show-unit mysql/0 wordpress/1
@@ -203,11 +211,22 @@ type UnitInfo struct {
 	Leader          bool           `yaml:"leader" json:"leader"`
 	RelationData    []RelationData `yaml:"relation-info,omitempty" json:"relation-info,omitempty"`
 
+	// The following are for IAAS models.
+	ResourceLimits *ResourceLimitsInfo `yaml:"resource-limits,omitempty" json:"resource-limits,omitempty"`
+
 	// The following are for CAAS models.
 	ProviderId string `yaml:"provider-id,omitempty" json:"provider-id,omitempty"`
 	Address    string `yaml:"address,omitempty" json:"address,omitempty"`
 }
 
+// ResourceLimitsInfo defines the serialization behaviour of the
+// resources a unit's hooks may use.
+type ResourceLimitsInfo struct {
+	Memory    string `yaml:"memory,omitempty" json:"memory,omitempty"`
+	CPUShares uint64 `yaml:"cpu-shares,omitempty" json:"cpu-shares,omitempty"`
+	Tasks     uint64 `yaml:"tasks,omitempty" json:"tasks,omitempty"`
+}
+
 func (c *showUnitCommand) createUnitInfo(details application.UnitInfo) (names.UnitTag, UnitInfo, error) {
 	tag, err := names.ParseUnitTag(details.Tag)
 	if err != nil {
@@ -224,6 +243,15 @@ func (c *showUnitCommand) createUnitInfo(details application.UnitInfo) (names.Un
 		ProviderId:      details.ProviderId,
 		Address:         details.Address,
 	}
+	if limits := details.ResourceLimits; limits != nil {
+		info.ResourceLimits = &ResourceLimitsInfo{
+			CPUShares: limits.CPUShares,
+			Tasks:     limits.Tasks,
+		}
+		if limits.MemoryMB != 0 {
+			info.ResourceLimits.Memory = fmt.Sprintf("%dM", limits.MemoryMB)
+		}
+	}
 	for _, rdparams := range details.RelationData {
 		if c.endpoint != "" && rdparams.Endpoint != c.endpoint {
 			continue
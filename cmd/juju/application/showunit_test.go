@@ -15,6 +15,7 @@ import (
 
 	apiapplication "github.com/juju/juju/api/application"
 	"github.com/juju/juju/cmd/juju/application"
+	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/jujuclient"
 	_ "github.com/juju/juju/provider/dummy"
 	jujutesting "github.com/juju/juju/testing"
@@ -209,6 +210,30 @@ wordpress/0:
 	})
 }
 
+func (s *ShowUnitSuite) TestShowResourceLimits(c *gc.C) {
+	s.mockAPI.unitsInfoFunc = func([]names.UnitTag) ([]apiapplication.UnitInfo, error) {
+		return []apiapplication.UnitInfo{{
+			Tag:            "unit-wordpress-0",
+			Machine:        "0",
+			Charm:          "charm-wordpress",
+			ResourceLimits: &coreapplication.ResourceLimits{MemoryMB: 512, Tasks: 100},
+		}}, nil
+	}
+	s.assertRunShow(c, showUnitTest{
+		args: []string{"wordpress/0"},
+		stdout: `
+wordpress/0:
+  machine: "0"
+  opened-ports: []
+  charm: charm-wordpress
+  leader: false
+  resource-limits:
+    memory: 512M
+    tasks: 100
+`[1:],
+	})
+}
+
 func (s *ShowUnitSuite) TestShowAppOnly(c *gc.C) {
 	s.mockAPI.unitsInfoFunc = func([]names.UnitTag) ([]apiapplication.UnitInfo, error) {
 		return []apiapplication.UnitInfo{
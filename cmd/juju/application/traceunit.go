@@ -0,0 +1,244 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	jujuclock "github.com/juju/clock"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/api/common"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	jujucommon "github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const traceUnitDoc = `
+Traces the hook tool calls made by a unit's charm for a while.
+
+While a unit is traced, the unit agent logs every hook tool call the
+charm makes: the tool's arguments, its exit code, how long it took and
+how much output it wrote. The trace is sent to the controller with the
+rest of the unit's logs, under the juju.jujuc.trace module, and can be
+followed with debug-log:
+
+    juju debug-log --include unit-mysql-0 --include-module juju.jujuc.trace
+
+The unit agent only logs the trace if the model's logging-config logs
+the juju.jujuc.trace module at INFO or below, for example:
+
+    juju model-config logging-config="<root>=WARNING;juju.jujuc.trace=INFO"
+
+Use --include-output to also trace the data the tools return, such as
+relation settings and config values, and the settings passed to
+relation-set, leader-set and state-set. Anyone who can read the model's
+logs can read the trace. The arguments and output of the secret tools
+are never traced.
+
+Tracing starts with the next hook the unit runs and stops once --duration
+has passed, or when the command is run again with --stop.
+
+Use --download to save the trace recorded so far to a file, or to stdout
+if the file is "-".
+
+Examples:
+    juju trace-unit mysql/0
+    juju trace-unit mysql/0 --duration 1h
+    juju trace-unit mysql/0 --include-output
+    juju trace-unit mysql/0 --stop
+    juju trace-unit mysql/0 --download mysql-0.trace
+
+See also:
+    debug-log
+    hook-history
+`
+
+const (
+	// traceModule is the logging module the unit agent traces hook
+	// tool calls to.
+	traceModule = "juju.jujuc.trace"
+
+	defaultTraceDuration = 10 * time.Minute
+	maxTraceDuration     = 24 * time.Hour
+)
+
+// NewTraceUnitCommand returns a command that traces the hook tool calls
+// made by a unit's charm.
+func NewTraceUnitCommand() cmd.Command {
+	c := &traceUnitCommand{clock: jujuclock.WallClock}
+	c.newAPIFunc = func() (TraceUnitAPI, error) {
+		root, err := c.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	c.newLogAPIFunc = func() (TraceLogAPI, error) {
+		return c.NewAPIClient()
+	}
+	return modelcmd.Wrap(c)
+}
+
+// TraceUnitAPI defines the API methods that the trace-unit command uses
+// to start and stop tracing.
+type TraceUnitAPI interface {
+	Close() error
+	SetUnitsTrace([]names.UnitTag, time.Time, bool) ([]params.ErrorResult, error)
+}
+
+// TraceLogAPI defines the API methods that the trace-unit command uses
+// to download a trace.
+type TraceLogAPI interface {
+	Close() error
+	WatchDebugLog(common.DebugLogParams) (<-chan common.LogMessage, error)
+}
+
+type traceUnitCommand struct {
+	modelcmd.ModelCommandBase
+
+	unit          string
+	duration      time.Duration
+	includeOutput bool
+	stop          bool
+	download      string
+	isoTime       bool
+
+	clock         jujuclock.Clock
+	newAPIFunc    func() (TraceUnitAPI, error)
+	newLogAPIFunc func() (TraceLogAPI, error)
+}
+
+// Info implements Command.Info.
+func (c *traceUnitCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "trace-unit",
+		Args:    "<unit name>",
+		Purpose: "Traces the hook tool calls made by a unit's charm.",
+		Doc:     traceUnitDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *traceUnitCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.DurationVar(&c.duration, "duration", defaultTraceDuration, "How long to trace the unit for")
+	f.BoolVar(&c.includeOutput, "include-output", false, "Trace the data returned by hook tools, not only its size")
+	f.BoolVar(&c.stop, "stop", false, "Stop tracing the unit")
+	f.StringVar(&c.download, "download", "", `Save the trace recorded so far to this file ("-" for stdout)`)
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+}
+
+// Init implements Command.Init.
+func (c *traceUnitCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf("a unit name must be supplied")
+	}
+	c.unit, args = args[0], args[1:]
+	if !names.IsValidUnit(c.unit) {
+		return errors.NotValidf("unit name %q", c.unit)
+	}
+	if c.stop && c.download != "" {
+		return errors.New("--stop and --download cannot be used together")
+	}
+	if c.includeOutput && (c.stop || c.download != "") {
+		return errors.New("--include-output can only be used when starting a trace")
+	}
+	if c.duration <= 0 {
+		return errors.NotValidf("duration %v", c.duration)
+	}
+	if c.duration > maxTraceDuration {
+		return errors.NotValidf("duration %v longer than %v", c.duration, maxTraceDuration)
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.Run.
+func (c *traceUnitCommand) Run(ctx *cmd.Context) error {
+	if c.download != "" {
+		return errors.Trace(c.downloadTrace(ctx))
+	}
+
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	var until time.Time
+	if !c.stop {
+		until = c.clock.Now().Add(c.duration)
+	}
+	results, err := client.SetUnitsTrace([]names.UnitTag{names.NewUnitTag(c.unit)}, until, c.includeOutput)
+	if errors.IsNotSupported(err) {
+		return errors.New("tracing units is not supported by this version of Juju")
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if results[0].Error != nil {
+		return results[0].Error
+	}
+
+	if c.stop {
+		ctx.Infof("Stopped tracing unit %q.", c.unit)
+		return nil
+	}
+	ctx.Infof("Tracing hook tool calls made by unit %q until %s.\nFollow the trace with:\n    juju debug-log --include %s --include-module %s\n"+
+		"The trace is only logged if logging-config logs %s at INFO.",
+		c.unit, jujucommon.FormatTime(&until, c.isoTime), names.NewUnitTag(c.unit), traceModule, traceModule)
+	return nil
+}
+
+// downloadTrace writes the trace recorded for the unit to the
+// download file.
+func (c *traceUnitCommand) downloadTrace(ctx *cmd.Context) error {
+	client, err := c.newLogAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	messages, err := client.WatchDebugLog(common.DebugLogParams{
+		IncludeEntity: []string{names.NewUnitTag(c.unit).String()},
+		IncludeModule: []string{traceModule},
+		Replay:        true,
+		NoTail:        true,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	var out io.Writer = ctx.Stdout
+	if c.download != "-" {
+		f, err := os.OpenFile(ctx.AbsPath(c.download), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer f.Close()
+		out = f
+	}
+	count := 0
+	for msg := range messages {
+		_, err := fmt.Fprintf(out, "%s %s %s\n", jujucommon.FormatTime(&msg.Timestamp, c.isoTime), msg.Severity, msg.Message)
+		if err != nil {
+			return errors.Annotate(err, "writing trace")
+		}
+		count++
+	}
+	if count == 0 {
+		ctx.Infof("No trace recorded for unit %q.", c.unit)
+	} else if c.download != "-" {
+		ctx.Infof("Saved %d hook tool calls to %s.", count, c.download)
+	}
+	return nil
+}
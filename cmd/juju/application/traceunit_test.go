@@ -0,0 +1,236 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient"
+	jujutesting "github.com/juju/juju/testing"
+)
+
+type TraceUnitSuite struct {
+	jujutesting.FakeJujuXDGDataHomeSuite
+	store *jujuclient.MemStore
+
+	now    time.Time
+	api    *mockTraceUnitAPI
+	logAPI *mockTraceLogAPI
+}
+
+var _ = gc.Suite(&TraceUnitSuite{})
+
+func (s *TraceUnitSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Models["testing"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"admin/controller": {},
+		},
+		CurrentModel: "admin/controller",
+	}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+
+	s.now = time.Date(2020, 7, 1, 10, 0, 0, 0, time.UTC)
+	s.api = &mockTraceUnitAPI{results: []params.ErrorResult{{}}}
+	s.logAPI = &mockTraceLogAPI{messages: []common.LogMessage{{
+		Entity:    "unit-mysql-0",
+		Timestamp: s.now.Add(time.Minute),
+		Severity:  "INFO",
+		Module:    "juju.jujuc.trace",
+		Message:   `config-get port (exit 0, 2ms) stdout: "3306\n"`,
+	}, {
+		Entity:    "unit-mysql-0",
+		Timestamp: s.now.Add(2 * time.Minute),
+		Severity:  "INFO",
+		Module:    "juju.jujuc.trace",
+		Message:   `status-set active (exit 0, 5ms)`,
+	}}}
+}
+
+func (s *TraceUnitSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := application.NewTraceUnitCommandForTest(s.api, s.logAPI, testclock.NewClock(s.now), s.store)
+	return cmdtesting.RunCommand(c, command, args...)
+}
+
+func (s *TraceUnitSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "a unit name must be supplied",
+	}, {
+		args: []string{"mysql"},
+		err:  `unit name "mysql" not valid`,
+	}, {
+		args: []string{"mysql/0", "mysql/1"},
+		err:  `unrecognized args: \["mysql/1"\]`,
+	}, {
+		args: []string{"mysql/0", "--stop", "--download", "trace"},
+		err:  "--stop and --download cannot be used together",
+	}, {
+		args: []string{"mysql/0", "--stop", "--include-output"},
+		err:  "--include-output can only be used when starting a trace",
+	}, {
+		args: []string{"mysql/0", "--duration", "0s"},
+		err:  "duration 0s not valid",
+	}, {
+		args: []string{"mysql/0", "--duration", "48h"},
+		err:  "duration 48h0m0s longer than 24h0m0s not valid",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.run(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *TraceUnitSuite) TestStart(c *gc.C) {
+	ctx, err := s.run(c, "mysql/0", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+Tracing hook tool calls made by unit "mysql/0" until 2020-07-01 10:10:00Z.
+Follow the trace with:
+    juju debug-log --include unit-mysql-0 --include-module juju.jujuc.trace
+The trace is only logged if logging-config logs juju.jujuc.trace at INFO.
+`[1:])
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"SetUnitsTrace", []interface{}{[]names.UnitTag{names.NewUnitTag("mysql/0")}, s.now.Add(10 * time.Minute), false}},
+		{"Close", nil},
+	})
+}
+
+func (s *TraceUnitSuite) TestStartWithDuration(c *gc.C) {
+	_, err := s.run(c, "mysql/0", "--duration", "1h")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "SetUnitsTrace", []names.UnitTag{names.NewUnitTag("mysql/0")}, s.now.Add(time.Hour), false)
+}
+
+func (s *TraceUnitSuite) TestStartIncludeOutput(c *gc.C) {
+	_, err := s.run(c, "mysql/0", "--include-output")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "SetUnitsTrace", []names.UnitTag{names.NewUnitTag("mysql/0")}, s.now.Add(10*time.Minute), true)
+}
+
+func (s *TraceUnitSuite) TestStop(c *gc.C) {
+	ctx, err := s.run(c, "mysql/0", "--stop")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Stopped tracing unit \"mysql/0\".\n")
+	s.api.CheckCall(c, 0, "SetUnitsTrace", []names.UnitTag{names.NewUnitTag("mysql/0")}, time.Time{}, false)
+}
+
+func (s *TraceUnitSuite) TestResultError(c *gc.C) {
+	s.api.results = []params.ErrorResult{{
+		Error: &params.Error{Message: `unit "mysql/0" not found`, Code: params.CodeNotFound},
+	}}
+	_, err := s.run(c, "mysql/0")
+	c.Assert(err, gc.ErrorMatches, `unit "mysql/0" not found`)
+}
+
+func (s *TraceUnitSuite) TestNotSupported(c *gc.C) {
+	s.api.SetErrors(errors.NotSupportedf("SetUnitsTrace for Application facade v14"))
+	_, err := s.run(c, "mysql/0")
+	c.Assert(err, gc.ErrorMatches, "tracing units is not supported by this version of Juju")
+}
+
+func (s *TraceUnitSuite) TestDownload(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "mysql-0.trace")
+	ctx, err := s.run(c, "mysql/0", "--utc", "--download", path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Saved 2 hook tool calls to "+path+".\n")
+
+	data, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, `
+2020-07-01 10:01:00Z INFO config-get port (exit 0, 2ms) stdout: "3306\n"
+2020-07-01 10:02:00Z INFO status-set active (exit 0, 5ms)
+`[1:])
+	s.logAPI.CheckCalls(c, []testing.StubCall{
+		{"WatchDebugLog", []interface{}{common.DebugLogParams{
+			IncludeEntity: []string{"unit-mysql-0"},
+			IncludeModule: []string{"juju.jujuc.trace"},
+			Replay:        true,
+			NoTail:        true,
+		}}},
+		{"Close", nil},
+	})
+	s.api.CheckNoCalls(c)
+}
+
+func (s *TraceUnitSuite) TestDownloadToStdout(c *gc.C) {
+	ctx, err := s.run(c, "mysql/0", "--utc", "--download", "-")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+2020-07-01 10:01:00Z INFO config-get port (exit 0, 2ms) stdout: "3306\n"
+2020-07-01 10:02:00Z INFO status-set active (exit 0, 5ms)
+`[1:])
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "")
+}
+
+func (s *TraceUnitSuite) TestDownloadNoTrace(c *gc.C) {
+	s.logAPI.messages = nil
+	ctx, err := s.run(c, "mysql/0", "--download", "-")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No trace recorded for unit \"mysql/0\".\n")
+}
+
+type mockTraceUnitAPI struct {
+	testing.Stub
+	results []params.ErrorResult
+}
+
+func (m *mockTraceUnitAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
+
+func (m *mockTraceUnitAPI) SetUnitsTrace(units []names.UnitTag, until time.Time, includeOutput bool) ([]params.ErrorResult, error) {
+	m.MethodCall(m, "SetUnitsTrace", units, until, includeOutput)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.results, nil
+}
+
+type mockTraceLogAPI struct {
+	testing.Stub
+	messages []common.LogMessage
+}
+
+func (m *mockTraceLogAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
+
+func (m *mockTraceLogAPI) WatchDebugLog(args common.DebugLogParams) (<-chan common.LogMessage, error) {
+	m.MethodCall(m, "WatchDebugLog", args)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	messages := make(chan common.LogMessage, len(m.messages))
+	for _, msg := range m.messages {
+		messages <- msg
+	}
+	close(messages)
+	return messages, nil
+}
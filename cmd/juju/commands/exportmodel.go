@@ -0,0 +1,185 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"os"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/version"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/migration"
+)
+
+func newExportModelCommand() modelcmd.ModelCommand {
+	return modelcmd.Wrap(&exportModelCommand{})
+}
+
+// exportModelCommand writes a model, and the binaries it uses, to a
+// model archive which can be imported into another controller.
+type exportModelCommand struct {
+	modelcmd.ModelCommandBase
+	output string
+
+	// Overridden by tests
+	exportAPI   exportModelAPI
+	downloadAPI modelDownloadAPI
+}
+
+type exportModelAPI interface {
+	ExportModel(modelUUID string) (params.SerializedModel, error)
+	ControllerAgentVersion() (version.Number, error)
+	Close() error
+}
+
+type modelDownloadAPI interface {
+	migration.CharmDownloader
+	migration.ToolsDownloader
+	migration.ResourceDownloader
+	Close() error
+}
+
+const exportModelDoc = `
+export-model writes a model to a file, along with the charms, agent
+binaries and resources used by the model. The file can then be
+imported into another controller with the import-model command, even
+when the two controllers can't reach each other.
+
+The model is left untouched on its current controller. Changes made to
+the model after it has been exported are not included in the file, so
+the model should be quiescent while it is moved.
+
+Note that only hosted models can be exported. Controller models can
+not be exported.
+
+Examples:
+
+    juju export-model --output model.tar
+    juju export-model -m mymodel --output mymodel.tar
+
+See also:
+    import-model
+    migrate
+`
+
+// Info implements cmd.Command.
+func (c *exportModelCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "export-model",
+		Purpose: "Write a model to a file for import into another controller.",
+		Doc:     exportModelDoc,
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *exportModelCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.output, "output", "", "The file to write the model to")
+}
+
+// Init implements cmd.Command.
+func (c *exportModelCommand) Init(args []string) error {
+	if c.output == "" {
+		return errors.New("--output must be specified")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements cmd.Command.
+func (c *exportModelCommand) Run(ctx *cmd.Context) (err error) {
+	modelName, details, err := c.ModelDetails()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	exportAPI, err := c.getExportAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = exportAPI.Close() }()
+	agentVersion, err := exportAPI.ControllerAgentVersion()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	serialized, err := exportAPI.ExportModel(details.ModelUUID)
+	if errors.IsNotSupported(err) {
+		return errors.New("export-model is not supported by this version of Juju")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+
+	downloadAPI, err := c.getDownloadAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = downloadAPI.Close() }()
+
+	path := ctx.AbsPath(c.output)
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = errors.Trace(closeErr)
+		}
+		if err != nil {
+			_ = os.Remove(path)
+		}
+	}()
+	err = migration.WriteModelArchive(f, migration.ExportArchiveConfig{
+		Model:                  serialized,
+		ControllerAgentVersion: agentVersion,
+		CharmDownloader:        downloadAPI,
+		ToolsDownloader:        downloadAPI,
+		ResourceDownloader:     downloadAPI,
+	})
+	if err != nil {
+		return errors.Annotatef(err, "exporting model %q", modelName)
+	}
+	ctx.Infof("Model %q exported to %s", modelName, c.output)
+	return nil
+}
+
+func (c *exportModelCommand) getExportAPI() (exportModelAPI, error) {
+	if c.exportAPI != nil {
+		return c.exportAPI, nil
+	}
+	root, err := c.NewControllerAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &exportModelClient{Client: controller.NewClient(root), conn: root}, nil
+}
+
+func (c *exportModelCommand) getDownloadAPI() (modelDownloadAPI, error) {
+	if c.downloadAPI != nil {
+		return c.downloadAPI, nil
+	}
+	return c.NewAPIClient()
+}
+
+// exportModelClient adds the controller's agent version to the
+// controller API client.
+type exportModelClient struct {
+	*controller.Client
+	conn api.Connection
+}
+
+// ControllerAgentVersion returns the agent version of the controller
+// the model is exported from.
+func (c *exportModelClient) ControllerAgentVersion() (version.Number, error) {
+	v, ok := c.conn.ServerVersion()
+	if !ok {
+		return version.Zero, errors.New("controller agent version not known")
+	}
+	return v, nil
+}
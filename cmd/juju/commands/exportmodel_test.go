@@ -0,0 +1,204 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/charm/v7"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/description/v2"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	coremodel "github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/testing"
+)
+
+type ExportModelSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api        *fakeExportModelAPI
+	downloader *fakeModelDownloadAPI
+	store      *jujuclient.MemStore
+	output     string
+}
+
+var _ = gc.Suite(&ExportModelSuite{})
+
+func (s *ExportModelSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		ModelUUID: testing.ModelTag.Id(),
+		ModelType: coremodel.IAAS,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+
+	s.api = &fakeExportModelAPI{
+		serialized:   makeSerializedModel(c),
+		agentVersion: version.MustParse("2.8.1"),
+	}
+	s.downloader = &fakeModelDownloadAPI{}
+	s.output = filepath.Join(c.MkDir(), "model.tar")
+}
+
+func makeSerializedModel(c *gc.C) params.SerializedModel {
+	model := description.NewModel(description.ModelArgs{
+		Type:  "iaas",
+		Owner: names.NewUserTag("admin"),
+		Config: map[string]interface{}{
+			"name":          "mymodel",
+			"uuid":          testing.ModelTag.Id(),
+			"agent-version": "2.8.0",
+		},
+		Cloud:       "aws",
+		CloudRegion: "us-east-1",
+	})
+	model.SetStatus(description.StatusArgs{Value: "available"})
+	bytes, err := description.Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+	return params.SerializedModel{
+		Bytes:  bytes,
+		Charms: []string{"cs:trusty/postgresql-42"},
+		Tools: []params.SerializedModelTools{{
+			Version: "2.8.0-trusty-amd64",
+			URI:     "/tools/2.8.0-trusty-amd64",
+		}},
+	}
+}
+
+func (s *ExportModelSuite) makeCommand() modelcmd.ModelCommand {
+	cmd := &exportModelCommand{
+		exportAPI:   s.api,
+		downloadAPI: s.downloader,
+	}
+	cmd.SetClientStore(s.store)
+	return modelcmd.Wrap(cmd)
+}
+
+func (s *ExportModelSuite) TestInitNoOutput(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.makeCommand())
+	c.Assert(err, gc.ErrorMatches, "--output must be specified")
+}
+
+func (s *ExportModelSuite) TestInitTooManyArgs(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.makeCommand(), "--output", s.output, "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *ExportModelSuite) TestExport(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.makeCommand(), "--output", s.output)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Model \"admin/mymodel\" exported to "+s.output+"\n")
+	c.Check(s.api.modelUUID, gc.Equals, testing.ModelTag.Id())
+	c.Check(s.api.closed, jc.IsTrue)
+	c.Check(s.downloader.closed, jc.IsTrue)
+
+	f, err := os.Open(s.output)
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	archive, err := migration.ReadModelArchive(f)
+	c.Assert(err, jc.ErrorIsNil)
+	defer archive.Close()
+	c.Check(archive.ControllerAgentVersion, gc.Equals, version.MustParse("2.8.1"))
+	c.Check(archive.Model.Bytes, jc.DeepEquals, s.api.serialized.Bytes)
+
+	reader, err := archive.OpenCharm(charm.MustParseURL("cs:trusty/postgresql-42"))
+	c.Assert(err, jc.ErrorIsNil)
+	defer reader.Close()
+	content, err := ioutil.ReadAll(reader)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(content), gc.Equals, "charm:cs:trusty/postgresql-42")
+}
+
+func (s *ExportModelSuite) TestExportNotSupported(c *gc.C) {
+	s.api.err = errors.NotSupportedf("ExportModel")
+	_, err := cmdtesting.RunCommand(c, s.makeCommand(), "--output", s.output)
+	c.Assert(err, gc.ErrorMatches, "export-model is not supported by this version of Juju")
+	_, err = os.Stat(s.output)
+	c.Check(os.IsNotExist(err), jc.IsTrue)
+}
+
+func (s *ExportModelSuite) TestExportError(c *gc.C) {
+	s.api.err = errors.New("boom")
+	_, err := cmdtesting.RunCommand(c, s.makeCommand(), "--output", s.output)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *ExportModelSuite) TestExportDownloadFailureRemovesFile(c *gc.C) {
+	s.downloader.err = errors.New("no charm")
+	_, err := cmdtesting.RunCommand(c, s.makeCommand(), "--output", s.output)
+	c.Assert(err, gc.ErrorMatches, `exporting model "admin/mymodel": .*no charm`)
+	_, err = os.Stat(s.output)
+	c.Check(os.IsNotExist(err), jc.IsTrue)
+}
+
+type fakeExportModelAPI struct {
+	serialized   params.SerializedModel
+	agentVersion version.Number
+	err          error
+	modelUUID    string
+	closed       bool
+}
+
+func (a *fakeExportModelAPI) ExportModel(modelUUID string) (params.SerializedModel, error) {
+	a.modelUUID = modelUUID
+	return a.serialized, a.err
+}
+
+func (a *fakeExportModelAPI) ControllerAgentVersion() (version.Number, error) {
+	return a.agentVersion, nil
+}
+
+func (a *fakeExportModelAPI) Close() error {
+	a.closed = true
+	return nil
+}
+
+type fakeModelDownloadAPI struct {
+	err    error
+	closed bool
+}
+
+func (d *fakeModelDownloadAPI) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	return d.open("charm:" + curl.String())
+}
+
+func (d *fakeModelDownloadAPI) OpenURI(uri string, _ url.Values) (io.ReadCloser, error) {
+	return d.open("tools:" + uri)
+}
+
+func (d *fakeModelDownloadAPI) OpenResource(application, name string) (io.ReadCloser, error) {
+	return d.open("resource:" + application + "/" + name)
+}
+
+func (d *fakeModelDownloadAPI) open(content string) (io.ReadCloser, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+	return ioutil.NopCloser(strings.NewReader(content)), nil
+}
+
+func (d *fakeModelDownloadAPI) Close() error {
+	d.closed = true
+	return nil
+}
@@ -55,6 +55,7 @@ Currently available charm hook tools are:
     config-get               print application configuration
     credential-get           access cloud credentials
     goal-state               print the status of the charm's peers and related units
+    health-set               record the result of a workload health check
     is-leader                print application leadership status
     juju-log                 write a message to the juju log
     juju-reboot              Reboot the host machine
@@ -73,6 +74,11 @@ Currently available charm hook tools are:
     relation-ids             list all relation ids with the given relation name
     relation-list            list relation units
     relation-set             set relation settings
+    secret-add               add a new secret
+    secret-get               get the value of a secret
+    secret-grant             grant access to a secret
+    secret-revoke            revoke access to a secret
+    secret-update            update an existing secret
     state-delete             delete server-side-state key value pair
     state-get                print server-side-state value
     state-set                set server-side-state values
@@ -104,6 +110,7 @@ var expectedCommands = []string{
 	"config-get",
 	"credential-get",
 	"goal-state",
+	"health-set",
 	"is-leader",
 	"juju-log",
 	"juju-reboot",
@@ -126,6 +133,11 @@ var expectedCommands = []string{
 	"relation-list",
 	"relation-set",
 	"resource-get",
+	"secret-add",
+	"secret-get",
+	"secret-grant",
+	"secret-revoke",
+	"secret-update",
 	"state-delete",
 	"state-get",
 	"state-set",
@@ -0,0 +1,136 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"os"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/migrationtarget"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/migration"
+)
+
+func newImportModelCommand() cmd.Command {
+	return modelcmd.WrapController(&importModelCommand{})
+}
+
+// importModelCommand imports a model archive written by export-model
+// into a controller.
+type importModelCommand struct {
+	modelcmd.ControllerCommandBase
+	filename string
+
+	// Overridden by tests
+	importAPI importModelAPI
+}
+
+type importModelAPI interface {
+	migration.ImportTarget
+	Close() error
+}
+
+const importModelDoc = `
+import-model imports a model written to a file by the export-model
+command into the current controller. The model is checked, imported and
+activated in the same way as the model of a migration started with the
+migrate command. If any of these steps fail the import is aborted and
+nothing is left behind on the controller.
+
+The model keeps the name, owner and UUID it had on its original
+controller, so the original model should be destroyed before its
+agents are moved to the new controller.
+
+The model's machine and unit agents are not told about the new
+controller, as it may not be reachable from the original one. Each
+agent's configuration needs to be updated with the addresses and CA
+certificate of the new controller before the agents will connect to it.
+
+Examples:
+
+    juju import-model model.tar
+    juju import-model -c mycontroller model.tar
+
+See also:
+    export-model
+    migrate
+`
+
+// Info implements cmd.Command.
+func (c *importModelCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "import-model",
+		Args:    "<file>",
+		Purpose: "Import a model written by export-model into a controller.",
+		Doc:     importModelDoc,
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *importModelCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+}
+
+// Init implements cmd.Command.
+func (c *importModelCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("file not specified")
+	}
+	c.filename, args = args[0], args[1:]
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements cmd.Command.
+func (c *importModelCommand) Run(ctx *cmd.Context) error {
+	f, err := os.Open(ctx.AbsPath(c.filename))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = f.Close() }()
+	archive, err := migration.ReadModelArchive(f)
+	if err != nil {
+		return errors.Annotatef(err, "reading %s", c.filename)
+	}
+	defer func() { _ = archive.Close() }()
+
+	importAPI, err := c.getImportAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = importAPI.Close() }()
+	info, err := migration.ImportModelArchive(archive, importAPI)
+	if err != nil {
+		return errors.Annotatef(err, "importing model %q", info.Name)
+	}
+	ctx.Infof("Imported model %q", info.Name)
+	return nil
+}
+
+func (c *importModelCommand) getImportAPI() (importModelAPI, error) {
+	if c.importAPI != nil {
+		return c.importAPI, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &importModelClient{Client: migrationtarget.NewClient(root), conn: root}, nil
+}
+
+// importModelClient closes the connection used by the migration
+// target API client.
+type importModelClient struct {
+	*migrationtarget.Client
+	conn api.Connection
+}
+
+// Close closes the API connection.
+func (c *importModelClient) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,187 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/charm/v7"
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/tools"
+)
+
+type ImportModelSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api      *fakeImportModelAPI
+	store    *jujuclient.MemStore
+	filename string
+}
+
+var _ = gc.Suite(&ImportModelSuite{})
+
+func (s *ImportModelSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	s.api = &fakeImportModelAPI{}
+
+	s.filename = filepath.Join(c.MkDir(), "model.tar")
+	f, err := os.Create(s.filename)
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	downloader := &fakeModelDownloadAPI{}
+	err = migration.WriteModelArchive(f, migration.ExportArchiveConfig{
+		Model:                  makeSerializedModel(c),
+		ControllerAgentVersion: version.MustParse("2.8.1"),
+		CharmDownloader:        downloader,
+		ToolsDownloader:        downloader,
+		ResourceDownloader:     downloader,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ImportModelSuite) makeCommand() cmd.Command {
+	cmd := &importModelCommand{
+		importAPI: s.api,
+	}
+	cmd.SetClientStore(s.store)
+	return modelcmd.WrapController(cmd)
+}
+
+func (s *ImportModelSuite) TestInitNoFile(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.makeCommand())
+	c.Assert(err, gc.ErrorMatches, "file not specified")
+}
+
+func (s *ImportModelSuite) TestInitTooManyArgs(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.makeCommand(), s.filename, "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *ImportModelSuite) TestImport(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.makeCommand(), s.filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Imported model \"mymodel\"\n")
+	s.api.CheckCallNames(c,
+		"Prechecks",
+		"Import",
+		"UploadCharm",
+		"UploadTools",
+		"CheckMachines",
+		"Activate",
+		"AdoptResources",
+		"Close",
+	)
+	info := s.api.Calls()[0].Args[0].(coremigration.ModelInfo)
+	c.Check(info.UUID, gc.Equals, testing.ModelTag.Id())
+	c.Check(info.ControllerAgentVersion, gc.Equals, version.MustParse("2.8.1"))
+}
+
+func (s *ImportModelSuite) TestImportFailureAborts(c *gc.C) {
+	s.api.SetErrors(nil, nil, nil, nil, nil, errors.New("boom"))
+	_, err := cmdtesting.RunCommand(c, s.makeCommand(), s.filename)
+	c.Assert(err, gc.ErrorMatches, `importing model "mymodel": model activation failed: boom`)
+	s.api.CheckCallNames(c,
+		"Prechecks",
+		"Import",
+		"UploadCharm",
+		"UploadTools",
+		"CheckMachines",
+		"Activate",
+		"Abort",
+		"Close",
+	)
+}
+
+func (s *ImportModelSuite) TestImportInvalidFile(c *gc.C) {
+	err := ioutil.WriteFile(s.filename, []byte("not a model"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = cmdtesting.RunCommand(c, s.makeCommand(), s.filename)
+	c.Assert(err, gc.ErrorMatches, "reading .*model.tar: .*")
+	s.api.CheckNoCalls(c)
+}
+
+type fakeImportModelAPI struct {
+	jujutesting.Stub
+}
+
+func (a *fakeImportModelAPI) Prechecks(info coremigration.ModelInfo) error {
+	a.AddCall("Prechecks", info)
+	return a.NextErr()
+}
+
+func (a *fakeImportModelAPI) Import(bytes []byte) error {
+	a.AddCall("Import", bytes)
+	return a.NextErr()
+}
+
+func (a *fakeImportModelAPI) Abort(modelUUID string) error {
+	a.AddCall("Abort", modelUUID)
+	return a.NextErr()
+}
+
+func (a *fakeImportModelAPI) Activate(modelUUID string) error {
+	a.AddCall("Activate", modelUUID)
+	return a.NextErr()
+}
+
+func (a *fakeImportModelAPI) CheckMachines(modelUUID string) ([]error, error) {
+	a.AddCall("CheckMachines", modelUUID)
+	return nil, a.NextErr()
+}
+
+func (a *fakeImportModelAPI) AdoptResources(modelUUID string) error {
+	a.AddCall("AdoptResources", modelUUID)
+	return a.NextErr()
+}
+
+func (a *fakeImportModelAPI) UploadCharm(modelUUID string, curl *charm.URL, _ io.ReadSeeker) (*charm.URL, error) {
+	a.AddCall("UploadCharm", modelUUID, curl)
+	return curl, a.NextErr()
+}
+
+func (a *fakeImportModelAPI) UploadTools(modelUUID string, _ io.ReadSeeker, v version.Binary, _ ...string) (tools.List, error) {
+	a.AddCall("UploadTools", modelUUID, v)
+	return tools.List{{Version: v}}, a.NextErr()
+}
+
+func (a *fakeImportModelAPI) UploadResource(modelUUID string, res resource.Resource, _ io.ReadSeeker) error {
+	a.AddCall("UploadResource", modelUUID, res.Name)
+	return a.NextErr()
+}
+
+func (a *fakeImportModelAPI) SetPlaceholderResource(modelUUID string, res resource.Resource) error {
+	a.AddCall("SetPlaceholderResource", modelUUID, res.Name)
+	return a.NextErr()
+}
+
+func (a *fakeImportModelAPI) SetUnitResource(modelUUID, unitName string, res resource.Resource) error {
+	a.AddCall("SetUnitResource", modelUUID, unitName, res.Name)
+	return a.NextErr()
+}
+
+func (a *fakeImportModelAPI) Close() error {
+	a.AddCall("Close")
+	return a.NextErr()
+}
@@ -42,6 +42,7 @@ import (
 	"github.com/juju/juju/cmd/juju/model"
 	"github.com/juju/juju/cmd/juju/resource"
 	rcmd "github.com/juju/juju/cmd/juju/romulus/commands"
+	"github.com/juju/juju/cmd/juju/secrets"
 	"github.com/juju/juju/cmd/juju/setmeterstatus"
 	"github.com/juju/juju/cmd/juju/space"
 	"github.com/juju/juju/cmd/juju/status"
@@ -283,6 +284,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(crossmodel.NewOfferCommand())
 	r.Register(crossmodel.NewRemoveOfferCommand())
 	r.Register(crossmodel.NewShowOfferedEndpointCommand())
+	r.Register(crossmodel.NewOfferInviteCommand())
+	r.Register(crossmodel.NewSetOfferLimitsCommand())
 	r.Register(crossmodel.NewListEndpointsCommand())
 	r.Register(crossmodel.NewFindEndpointsCommand())
 	r.Register(application.NewConsumeCommand())
@@ -315,6 +318,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(newDebugLogCommand(nil))
 	r.Register(newDebugHooksCommand(nil))
 	r.Register(newDebugCodeCommand(nil))
+	r.Register(newReplayHookCommand(nil))
 
 	// Configuration commands.
 	r.Register(model.NewModelGetConstraintsCommand())
@@ -391,6 +395,9 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	}
 
 	r.Register(newMigrateCommand())
+	r.Register(newMigrationStatusCommand())
+	r.Register(newExportModelCommand())
+	r.Register(newImportModelCommand())
 	r.Register(model.NewExportBundleCommand())
 
 	if featureflag.Enabled(feature.DeveloperMode) {
@@ -426,7 +433,11 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(application.NewApplicationSetConstraintsCommand())
 	r.Register(application.NewBundleDiffCommand())
 	r.Register(application.NewShowApplicationCommand())
+	r.Register(application.NewShowSaasCommand())
 	r.Register(application.NewShowUnitCommand())
+	r.Register(application.NewHookHistoryCommand())
+	r.Register(application.NewTraceUnitCommand())
+	r.Register(application.NewFlushDeferredHooksCommand())
 
 	// Operation protection commands
 	r.Register(block.NewDisableCommand())
@@ -439,13 +450,19 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(storage.NewPoolCreateCommand())
 	r.Register(storage.NewPoolListCommand())
 	r.Register(storage.NewPoolRemoveCommand())
+	r.Register(storage.NewPoolShowCommand())
 	r.Register(storage.NewPoolUpdateCommand())
 	r.Register(storage.NewShowCommand())
 	r.Register(storage.NewRemoveStorageCommandWithAPI())
 	r.Register(storage.NewDetachStorageCommandWithAPI())
 	r.Register(storage.NewAttachStorageCommandWithAPI())
+	r.Register(storage.NewResizeStorageCommandWithAPI())
 	r.Register(storage.NewImportFilesystemCommand(storage.NewStorageImporter, nil))
 
+	// Manage secrets
+	r.Register(secrets.NewListSecretsCommand())
+	r.Register(secrets.NewRotateSecretCommand())
+
 	// Manage spaces
 	r.Register(space.NewAddCommand())
 	r.Register(space.NewListCommand())
@@ -468,6 +485,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(controller.NewRegisterCommand())
 	r.Register(controller.NewUnregisterCommand(jujuclient.NewFileClientStore()))
 	r.Register(controller.NewEnableDestroyControllerCommand())
+	r.Register(controller.NewEnableMaintenanceModeCommand())
+	r.Register(controller.NewDisableMaintenanceModeCommand())
 	r.Register(controller.NewShowControllerCommand())
 	r.Register(controller.NewConfigCommand())
 
@@ -500,6 +519,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(caas.NewUpdateCAASCommand(&cloudToCommandAdapter{}))
 	r.Register(caas.NewRemoveCAASCommand(&cloudToCommandAdapter{}))
 	r.Register(application.NewScaleApplicationCommand())
+	r.Register(application.NewSetAutoscaleCommand())
 
 	// Manage Application Credential Access
 	r.Register(application.NewTrustCommand())
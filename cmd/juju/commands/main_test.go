@@ -515,18 +515,22 @@ var commandNames = []string{
 	"detach-storage",
 	"diff-bundle",
 	"disable-command",
+	"disable-maintenance-mode",
 	"disable-user",
 	"disabled-commands",
 	"download-backup",
 	"enable-command",
 	"enable-destroy-controller",
 	"enable-ha",
+	"enable-maintenance-mode",
 	"enable-user",
 	"exec",
 	"export-bundle",
+	"export-model",
 	"expose",
 	"find-offers",
 	"firewall-rules",
+	"flush-deferred-hooks",
 	"get-constraints",
 	"get-model-constraints",
 	"grant",
@@ -534,9 +538,11 @@ var commandNames = []string{
 	"gui",
 	"help",
 	"help-tool",
+	"hook-history",
 	"hook-tool",
 	"hook-tools",
 	"import-filesystem",
+	"import-model",
 	"import-ssh-key",
 	"kill-controller",
 	"list-actions",
@@ -556,6 +562,7 @@ var commandNames = []string{
 	"list-plans",
 	"list-regions",
 	"list-resources",
+	"list-secrets",
 	"list-spaces",
 	"list-ssh-keys",
 	"list-storage",
@@ -568,12 +575,14 @@ var commandNames = []string{
 	"machines",
 	"metrics",
 	"migrate",
+	"migration-status",
 	"model-config",
 	"model-default",
 	"model-defaults",
 	"models",
 	"move-to-space",
 	"offer",
+	"offer-invite",
 	"offers",
 	"payloads",
 	"plans",
@@ -599,6 +608,8 @@ var commandNames = []string{
 	"remove-unit",
 	"remove-user",
 	"rename-space",
+	"replay-hook",
+	"resize-storage",
 	"resolved",
 	"resolve",
 	"resources",
@@ -607,9 +618,12 @@ var commandNames = []string{
 	"retry-provisioning",
 	"revoke",
 	"revoke-cloud",
+	"rotate-secret",
 	"run",
 	"scale-application",
 	"scp",
+	"secrets",
+	"set-autoscale",
 	"set-credential",
 	"set-constraints",
 	"set-default-credential",
@@ -617,6 +631,7 @@ var commandNames = []string{
 	"set-firewall-rule",
 	"set-meter-status",
 	"set-model-constraints",
+	"set-offer-limits",
 	"set-plan",
 	"set-series",
 	"set-wallet",
@@ -630,9 +645,11 @@ var commandNames = []string{
 	"show-machine",
 	"show-model",
 	"show-offer",
+	"show-saas",
 	"show-status",
 	"show-status-log",
 	"show-storage",
+	"show-storage-pool",
 	"show-space",
 	"show-unit",
 	"show-user",
@@ -649,6 +666,7 @@ var commandNames = []string{
 	"switch",
 	"sync-agent-binaries",
 	"sync-tools",
+	"trace-unit",
 	"trust",
 	"unexpose",
 	"unregister",
@@ -4,28 +4,42 @@
 package commands
 
 import (
+	"fmt"
+	"path"
 	"strings"
+	"time"
 
+	"github.com/juju/clock"
 	"github.com/juju/cmd"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"github.com/juju/names/v4"
 	"gopkg.in/macaroon-bakery.v2/httpbakery"
 	"gopkg.in/macaroon.v2"
 
 	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/controller"
 	"github.com/juju/juju/api/modelmanager"
 	"github.com/juju/juju/api/usermanager"
 	"github.com/juju/juju/apiserver/params"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/jujuclient"
 )
 
+// migrationPollInterval is how often the progress of the migrations
+// started by a bulk migration is checked.
+const migrationPollInterval = 5 * time.Second
+
 func newMigrateCommand() modelcmd.ModelCommand {
 	var cmd migrateCommand
 	cmd.newAPIRoot = cmd.CommandBase.NewAPIRoot
+	cmd.clock = clock.WallClock
+	cmd.pollInterval = migrationPollInterval
 	return modelcmd.Wrap(&cmd, modelcmd.WrapSkipModelFlags)
 }
 
@@ -33,17 +47,27 @@ func newMigrateCommand() modelcmd.ModelCommand {
 type migrateCommand struct {
 	modelcmd.ModelCommandBase
 	targetController string
+	dryRun           bool
+	allModels        bool
+	maxConcurrent    int
+	keepSource       time.Duration
+	revert           bool
 
 	// Overridden by tests
-	newAPIRoot func(jujuclient.ClientStore, string, string) (api.Connection, error)
-	migAPI     map[string]migrateAPI
-	modelAPI   modelInfoAPI
-	userAPI    userListAPI
+	newAPIRoot   func(jujuclient.ClientStore, string, string) (api.Connection, error)
+	migAPI       map[string]migrateAPI
+	modelAPI     modelInfoAPI
+	userAPI      userListAPI
+	clock        clock.Clock
+	pollInterval time.Duration
 }
 
 type migrateAPI interface {
 	InitiateMigration(spec controller.MigrationSpec) (string, error)
+	MigrationPrechecks(spec controller.MigrationSpec) (controller.MigrationPrecheckReport, error)
 	IdentityProviderURL() (string, error)
+	AllModels() ([]base.UserModel, error)
+	ModelMigrations() ([]params.ModelMigrationReport, error)
 	Close() error
 }
 
@@ -78,9 +102,49 @@ for details of how to do this.
 
 This command only starts a model migration - it does not wait for its
 completion. The progress of a migration can be tracked using the
-"status" command and by consulting the logs.
+"migration-status" or "status" commands and by consulting the logs.
+
+Many models can be migrated at once by passing --all-models, or a
+model name containing the wildcards "*", "?" or "[...]", in place of
+the model name. The controller model is never included. At most
+--max-concurrent migrations are run at the same time, and no more than
+the controller's max-concurrent-migrations config allows, counting
+migrations started by others. The command waits for all of them to
+complete before reporting which models could not be migrated and the
+phase each migration failed in. A model whose migration fails stays on
+its current controller, and the migration of the remaining models
+carries on. Use "<controller>:*" to migrate the
+models of a controller other than the current one.
+
+The --dry-run option runs all of the checks made by the source and
+target controllers before a migration is started, without starting it
+or changing anything. Rather than stopping at the first problem, every
+blocking problem and warning found is reported. Checks include unit
+agent and workload status, relations, agent versions, cloud and
+credential compatibility and charm availability.
+
+The --keep-source option keeps the model on the source controller for
+the given time after a successful migration. The kept model is dormant:
+it can't be changed, and its agents remain connected to the target
+controller. While the model is kept, the migration can be reverted by
+running "migrate --revert" against the target controller, naming the
+source controller as the target. This moves the model's agents back to
+the source controller, where the model becomes active again, and
+removes the model from the controller it was migrated to. Once the
+time has passed, the model is removed from the source controller and
+the migration can no longer be reverted.
+
+Examples:
+
+    juju migrate mymodel target-controller
+    juju migrate --dry-run mymodel target-controller
+    juju migrate --keep-source 24h mymodel target-controller
+    juju migrate --revert -c target-controller mymodel source-controller
+    juju migrate --all-models --max-concurrent 10 target-controller
+    juju migrate 'prod-*' target-controller
 
 See also:
+    migration-status
     login
     controllers
     status
@@ -90,14 +154,29 @@ See also:
 func (c *migrateCommand) Info() *cmd.Info {
 	return jujucmd.Info(&cmd.Info{
 		Name:    "migrate",
-		Args:    "<model-name> <target-controller-name>",
+		Args:    "(<model-name>|--all-models) <target-controller-name>",
 		Purpose: "Migrate a hosted model to another controller.",
 		Doc:     migrateDoc,
 	})
 }
 
+// SetFlags implements cmd.Command.
+func (c *migrateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.dryRun, "dry-run", false, "Check whether the model can be migrated without starting the migration")
+	f.BoolVar(&c.allModels, "all-models", false, "Migrate all of the controller's hosted models")
+	f.IntVar(&c.maxConcurrent, "max-concurrent", 5, "The maximum number of models to migrate at the same time")
+	f.DurationVar(&c.keepSource, "keep-source", 0, "How long to keep the model on the source controller after a successful migration, so that the migration can be reverted")
+	f.BoolVar(&c.revert, "revert", false, "Move the model back to the controller it was migrated from, where it is being kept")
+}
+
 // Init implements cmd.Command.
 func (c *migrateCommand) Init(args []string) error {
+	if c.allModels {
+		// All models is the same as a pattern matching every model
+		// name, so prepend one to the arguments.
+		args = append([]string{"*"}, args...)
+	}
 	if len(args) < 1 {
 		return errors.New("model not specified")
 	}
@@ -107,6 +186,20 @@ func (c *migrateCommand) Init(args []string) error {
 	if len(args) > 2 {
 		return errors.New("too many arguments specified")
 	}
+	if c.maxConcurrent < 1 {
+		return errors.New("--max-concurrent must be at least 1")
+	}
+	if c.keepSource < 0 {
+		return errors.New("--keep-source must not be negative")
+	}
+	if c.revert {
+		if c.keepSource > 0 {
+			return errors.New("--keep-source cannot be used with --revert")
+		}
+		if c.dryRun {
+			return errors.New("--dry-run cannot be used with --revert")
+		}
+	}
 
 	if err := c.SetModelIdentifier(args[0], false); err != nil {
 		return errors.Trace(err)
@@ -126,11 +219,23 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if isModelPattern(modelName) {
+		if c.dryRun {
+			return errors.New("--dry-run can only be used to migrate a single model")
+		}
+		if c.revert {
+			return errors.New("--revert can only be used with a single model")
+		}
+		return c.migrateModels(ctx, modelName, spec)
+	}
 	uuids, err := c.ModelUUIDs([]string{modelName})
 	if err != nil {
 		return errors.Trace(err)
 	}
 	spec.ModelUUID = uuids[0]
+	if c.dryRun {
+		return c.runPrechecks(ctx, modelName, spec)
+	}
 	if err := c.checkMigrationFeasibility(spec); err != nil {
 		return errors.Trace(err)
 	}
@@ -147,10 +252,241 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return err
 	}
+	if c.revert {
+		ctx.Infof("Migration back to controller %q started with ID %q", c.targetController, id)
+		return nil
+	}
 	ctx.Infof("Migration started with ID %q", id)
 	return nil
 }
 
+// runPrechecks asks the source controller to run every migration
+// precheck, on both itself and the target controller, and reports the
+// findings without starting the migration.
+func (c *migrateCommand) runPrechecks(ctx *cmd.Context, modelName string, spec *controller.MigrationSpec) error {
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return err
+	}
+	api, err := c.getMigrationAPI(controllerName)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = api.Close() }()
+	report, err := api.MigrationPrechecks(*spec)
+	if errors.IsNotSupported(err) {
+		return errors.New("migrate --dry-run is not supported by this version of Juju")
+	} else if err != nil {
+		return err
+	}
+
+	var blockers int
+	for _, side := range []struct {
+		name   string
+		report params.MigrationPrecheckReport
+	}{
+		{"source", report.Source},
+		{"target", report.Target},
+	} {
+		for _, msg := range side.report.Blockers {
+			fmt.Fprintf(ctx.Stdout, "blocker (%s): %s\n", side.name, msg)
+		}
+		for _, msg := range side.report.Warnings {
+			fmt.Fprintf(ctx.Stdout, "warning (%s): %s\n", side.name, msg)
+		}
+		blockers += len(side.report.Blockers)
+	}
+	if blockers > 0 {
+		return errors.Errorf("migration of model %q to controller %q would be blocked by %d problem(s)",
+			modelName, c.targetController, blockers)
+	}
+	ctx.Infof("Model %q can be migrated to controller %q", modelName, c.targetController)
+	return nil
+}
+
+// isModelPattern reports whether the model name is a pattern which
+// selects a number of models to migrate.
+func isModelPattern(modelName string) bool {
+	return strings.ContainsAny(modelName, "*?[")
+}
+
+// modelMigrationFailure records a model which could not be migrated.
+type modelMigrationFailure struct {
+	model   string
+	phase   string
+	message string
+}
+
+// migrateModels migrates every hosted model matching the pattern,
+// running at most maxConcurrent migrations at a time, and waits for
+// them to complete. The failure of one migration doesn't stop the
+// others.
+func (c *migrateCommand) migrateModels(ctx *cmd.Context, pattern string, spec *controller.MigrationSpec) error {
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return err
+	}
+	api, err := c.getMigrationAPI(controllerName)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = api.Close() }()
+	models, err := c.matchingModels(api, pattern)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(models) == 0 {
+		return errors.Errorf("no hosted models match %q", pattern)
+	}
+	ctx.Infof("Migrating %d model(s) to controller %q, at most %d at a time",
+		len(models), c.targetController, c.maxConcurrent)
+
+	var (
+		pending  = models
+		running  = make(map[string]string) // migration ID -> model name
+		failures []modelMigrationFailure
+	)
+	for len(pending) > 0 || len(running) > 0 {
+		for len(pending) > 0 && len(running) < c.maxConcurrent {
+			model := pending[0]
+			name := jujuclient.JoinOwnerModelName(names.NewUserTag(model.Owner), model.Name)
+			modelSpec := *spec
+			modelSpec.ModelUUID = model.UUID
+			id, err := c.startMigration(api, &modelSpec)
+			if params.IsCodeQuotaLimitExceeded(err) {
+				// The controller is running as many migrations as
+				// it allows, which may include some started by
+				// others; try again once one has completed.
+				logger.Debugf("migration of model %q deferred: %v", name, err)
+				break
+			}
+			pending = pending[1:]
+			if err != nil {
+				ctx.Infof("Migration of model %q could not be started: %v", name, err)
+				failures = append(failures, modelMigrationFailure{
+					model:   name,
+					message: err.Error(),
+				})
+				continue
+			}
+			ctx.Infof("Migration of model %q started with ID %q", name, id)
+			running[id] = name
+		}
+		if len(pending) == 0 && len(running) == 0 {
+			break
+		}
+
+		<-c.clock.After(c.pollInterval)
+		reports, err := api.ModelMigrations()
+		if err != nil {
+			return errors.Annotate(err, `checking migration progress (use "juju migration-status" to follow the migrations already started)`)
+		}
+		for _, report := range reports {
+			name, ok := running[report.MigrationId]
+			if !ok {
+				continue
+			}
+			phase, _ := coremigration.ParsePhase(report.Phase)
+			// A model kept on this controller has been migrated, even
+			// though it won't be removed until the keep-source time
+			// has passed.
+			if !phase.IsTerminal() && phase != coremigration.DORMANT {
+				continue
+			}
+			delete(running, report.MigrationId)
+			if phase == coremigration.DONE || phase == coremigration.REAPFAILED || phase == coremigration.DORMANT {
+				ctx.Infof("Model %q migrated", name)
+				continue
+			}
+			ctx.Infof("Migration of model %q failed in phase %s: %s", name, report.FailedPhase, report.StatusMessage)
+			failures = append(failures, modelMigrationFailure{
+				model:   name,
+				phase:   report.FailedPhase,
+				message: report.StatusMessage,
+			})
+		}
+	}
+
+	if len(failures) == 0 {
+		ctx.Infof("All %d model(s) migrated to controller %q", len(models), c.targetController)
+		return nil
+	}
+	tw := output.TabWriter(ctx.Stdout)
+	w := output.Wrapper{tw}
+	w.Println("Model", "Failed in", "Message")
+	for _, failure := range failures {
+		phase := failure.phase
+		if phase == "" {
+			phase = "not started"
+		}
+		w.Println(failure.model, phase, failure.message)
+	}
+	if err := tw.Flush(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Errorf("%d of %d model migration(s) failed; the failed models remain on controller %q",
+		len(failures), len(models), controllerName)
+}
+
+// matchingModels returns the hosted models whose name, or owner
+// qualified name if the pattern contains a "/", matches the pattern.
+func (c *migrateCommand) matchingModels(api migrateAPI, pattern string) ([]base.UserModel, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, errors.Annotatef(err, "invalid model pattern %q", pattern)
+	}
+	allModels, err := api.AllModels()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var (
+		models []base.UserModel
+		tags   []names.ModelTag
+	)
+	for _, model := range allModels {
+		name := model.Name
+		if strings.Contains(pattern, "/") {
+			name = model.Owner + "/" + model.Name
+		}
+		if ok, _ := path.Match(pattern, name); ok {
+			models = append(models, model)
+			tags = append(tags, names.NewModelTag(model.UUID))
+		}
+	}
+	if len(models) == 0 {
+		return nil, nil
+	}
+
+	// Leave out the controller model, which can't be migrated.
+	modelAPI, err := c.getModelAPI()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() { _ = modelAPI.Close() }()
+	results, err := modelAPI.ModelInfo(tags)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var hosted []base.UserModel
+	for i, result := range results {
+		if result.Error != nil {
+			return nil, errors.Annotatef(result.Error, "model %q", models[i].Name)
+		}
+		if !result.Result.IsController {
+			hosted = append(hosted, models[i])
+		}
+	}
+	return hosted, nil
+}
+
+// startMigration checks that the migration described by the spec is
+// feasible and, if so, starts it.
+func (c *migrateCommand) startMigration(api migrateAPI, spec *controller.MigrationSpec) (string, error) {
+	if err := c.checkMigrationFeasibility(spec); err != nil {
+		return "", errors.Trace(err)
+	}
+	return api.InitiateMigration(*spec)
+}
+
 func (c *migrateCommand) getMigrationSpec() (*controller.MigrationSpec, error) {
 	store := c.ClientStore()
 
@@ -181,6 +517,8 @@ func (c *migrateCommand) getMigrationSpec() (*controller.MigrationSpec, error) {
 		TargetUser:            accountInfo.User,
 		TargetPassword:        accountInfo.Password,
 		TargetMacaroons:       macs,
+		KeepSource:            c.keepSource,
+		Revert:                c.revert,
 	}, nil
 }
 
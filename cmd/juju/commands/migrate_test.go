@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -417,6 +418,257 @@ func (s *MigrateSuite) TestControllerDoesNotExist(c *gc.C) {
 	c.Check(s.api.specSeen, gc.IsNil) // API shouldn't have been called
 }
 
+func (s *MigrateSuite) TestDryRun(c *gc.C) {
+	ctx, err := s.makeAndRun(c, "--dry-run", "model", "target")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Model \"model\" can be migrated to controller \"target\"\n")
+	c.Check(s.api.specSeen, gc.IsNil) // Migration shouldn't have been started.
+	c.Check(s.api.precheckSeen, jc.DeepEquals, &controller.MigrationSpec{
+		ModelUUID:             modelUUID,
+		TargetControllerUUID:  targetControllerUUID,
+		TargetControllerAlias: "target",
+		TargetAddrs:           []string{"1.2.3.4:5"},
+		TargetCACert:          "cert",
+		TargetUser:            "targetuser",
+		TargetPassword:        "secret",
+	})
+}
+
+func (s *MigrateSuite) TestDryRunProblems(c *gc.C) {
+	s.api.precheckReport = controller.MigrationPrecheckReport{
+		Source: params.MigrationPrecheckReport{
+			Blockers: []string{"unit mysql/0 not idle or executing (lost)"},
+			Warnings: []string{"unit mysql/1 workload is blocked: no db"},
+		},
+		Target: params.MigrationPrecheckReport{
+			Blockers: []string{`cloud "aws" not found on target controller`},
+		},
+	}
+	ctx, err := s.makeAndRun(c, "--dry-run", "model", "target")
+	c.Assert(err, gc.ErrorMatches, `migration of model "model" to controller "target" would be blocked by 2 problem\(s\)`)
+
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+blocker (source): unit mysql/0 not idle or executing (lost)
+warning (source): unit mysql/1 workload is blocked: no db
+blocker (target): cloud "aws" not found on target controller
+`[1:])
+	c.Check(s.api.specSeen, gc.IsNil)
+}
+
+func (s *MigrateSuite) TestDryRunNotSupported(c *gc.C) {
+	s.api.precheckErr = errors.NotSupportedf("MigrationPrechecks")
+	_, err := s.makeAndRun(c, "--dry-run", "model", "target")
+	c.Assert(err, gc.ErrorMatches, "migrate --dry-run is not supported by this version of Juju")
+}
+
+func (s *MigrateSuite) setUpBulkModels() {
+	userList := []params.ModelUserInfo{{
+		UserName: "admin",
+		Access:   params.ModelAdminAccess,
+	}}
+	s.api.allModels = []base.UserModel{{
+		Name:  "controller",
+		UUID:  "controller-uuid",
+		Owner: "admin",
+	}, {
+		Name:  "prod-1",
+		UUID:  "prod-1-uuid",
+		Owner: "alpha",
+	}, {
+		Name:  "prod-2",
+		UUID:  "prod-2-uuid",
+		Owner: "sourceuser",
+	}, {
+		Name:  "prod-3",
+		UUID:  "prod-3-uuid",
+		Owner: "sourceuser",
+	}, {
+		Name:  "staging",
+		UUID:  "staging-uuid",
+		Owner: "sourceuser",
+	}}
+	s.modelAPI.modelInfo = []params.ModelInfo{{
+		Name:         "controller",
+		UUID:         "controller-uuid",
+		IsController: true,
+		Users:        userList,
+	}, {
+		Name:  "prod-1",
+		UUID:  "prod-1-uuid",
+		Users: userList,
+	}, {
+		Name:  "prod-2",
+		UUID:  "prod-2-uuid",
+		Users: userList,
+	}, {
+		Name:  "prod-3",
+		UUID:  "prod-3-uuid",
+		Users: userList,
+	}, {
+		Name:  "staging",
+		UUID:  "staging-uuid",
+		Users: userList,
+	}}
+}
+
+func (s *MigrateSuite) TestAllModels(c *gc.C) {
+	s.setUpBulkModels()
+	ctx, err := s.makeAndRun(c, "--all-models", "--max-concurrent", "2", "target")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.api.started, jc.DeepEquals, []string{"prod-1-uuid", "prod-2-uuid", "prod-3-uuid", "staging-uuid"})
+	c.Check(s.api.maxRunning, gc.Equals, 2)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `
+Migrating 4 model(s) to controller "target", at most 2 at a time
+Migration of model "alpha/prod-1" started with ID "prod-1-uuid:0"
+Migration of model "sourceuser/prod-2" started with ID "prod-2-uuid:0"
+Model "alpha/prod-1" migrated
+Model "sourceuser/prod-2" migrated
+Migration of model "sourceuser/prod-3" started with ID "prod-3-uuid:0"
+Migration of model "sourceuser/staging" started with ID "staging-uuid:0"
+Model "sourceuser/prod-3" migrated
+Model "sourceuser/staging" migrated
+All 4 model(s) migrated to controller "target"
+`[1:])
+}
+
+func (s *MigrateSuite) TestModelPattern(c *gc.C) {
+	s.setUpBulkModels()
+	_, err := s.makeAndRun(c, "prod-*", "target")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.api.started, jc.DeepEquals, []string{"prod-1-uuid", "prod-2-uuid", "prod-3-uuid"})
+	c.Check(s.api.maxRunning, gc.Equals, 3)
+}
+
+func (s *MigrateSuite) TestModelPatternWithOwner(c *gc.C) {
+	s.setUpBulkModels()
+	_, err := s.makeAndRun(c, "sourceuser/prod-*", "target")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.api.started, jc.DeepEquals, []string{"prod-2-uuid", "prod-3-uuid"})
+}
+
+func (s *MigrateSuite) TestModelPatternNoMatches(c *gc.C) {
+	s.setUpBulkModels()
+	_, err := s.makeAndRun(c, "dev-*", "target")
+	c.Assert(err, gc.ErrorMatches, `no hosted models match "dev-\*"`)
+}
+
+func (s *MigrateSuite) TestModelPatternFailures(c *gc.C) {
+	s.setUpBulkModels()
+	s.api.initiateErrs = map[string]error{
+		"prod-1-uuid": errors.New("prechecks failed"),
+	}
+	s.api.failedPhases = map[string]string{
+		"prod-3-uuid:0": "VALIDATION",
+	}
+	ctx, err := s.makeAndRun(c, "--max-concurrent", "1", "prod-*", "target")
+	c.Assert(err, gc.ErrorMatches, `2 of 3 model migration\(s\) failed; the failed models remain on controller "source"`)
+
+	// A failed migration doesn't stop the others.
+	c.Check(s.api.started, jc.DeepEquals, []string{"prod-2-uuid", "prod-3-uuid"})
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Model              Failed in    Message
+alpha/prod-1       not started  prechecks failed
+sourceuser/prod-3  VALIDATION   aborted, removing model from target controller: boom
+`[1:])
+}
+
+func (s *MigrateSuite) TestAllModelsControllerLimit(c *gc.C) {
+	s.setUpBulkModels()
+	s.api.controllerLimit = 2
+	_, err := s.makeAndRun(c, "--all-models", "--max-concurrent", "5", "target")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Migrations refused by the controller wait rather than failing.
+	c.Check(s.api.started, jc.DeepEquals, []string{"prod-1-uuid", "prod-2-uuid", "prod-3-uuid", "staging-uuid"})
+	c.Check(s.api.maxRunning, gc.Equals, 2)
+}
+
+func (s *MigrateSuite) TestAllModelsTooManyArgs(c *gc.C) {
+	_, err := s.makeAndRun(c, "--all-models", "model", "target")
+	c.Assert(err, gc.ErrorMatches, "too many arguments specified")
+}
+
+func (s *MigrateSuite) TestMaxConcurrentInvalid(c *gc.C) {
+	_, err := s.makeAndRun(c, "--all-models", "--max-concurrent", "0", "target")
+	c.Assert(err, gc.ErrorMatches, "--max-concurrent must be at least 1")
+}
+
+func (s *MigrateSuite) TestDryRunModelPattern(c *gc.C) {
+	_, err := s.makeAndRun(c, "--dry-run", "--all-models", "target")
+	c.Assert(err, gc.ErrorMatches, "--dry-run can only be used to migrate a single model")
+}
+
+func (s *MigrateSuite) TestKeepSource(c *gc.C) {
+	ctx, err := s.makeAndRun(c, "--keep-source", "24h", "model", "target")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stderr(ctx), gc.Matches, "Migration started with ID \"uuid:0\"\n")
+	c.Check(s.api.specSeen, jc.DeepEquals, &controller.MigrationSpec{
+		ModelUUID:             modelUUID,
+		TargetControllerUUID:  targetControllerUUID,
+		TargetControllerAlias: "target",
+		TargetAddrs:           []string{"1.2.3.4:5"},
+		TargetCACert:          "cert",
+		TargetUser:            "targetuser",
+		TargetPassword:        "secret",
+		KeepSource:            24 * time.Hour,
+	})
+}
+
+func (s *MigrateSuite) TestKeepSourceNegative(c *gc.C) {
+	_, err := s.makeAndRun(c, "--keep-source", "-1h", "model", "target")
+	c.Assert(err, gc.ErrorMatches, "--keep-source must not be negative")
+}
+
+func (s *MigrateSuite) TestModelPatternKeepSource(c *gc.C) {
+	s.setUpBulkModels()
+	s.api.donePhase = "DORMANT"
+	ctx, err := s.makeAndRun(c, "--keep-source", "1h", "sourceuser/prod-*", "target")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Kept models count as migrated without waiting for them to be
+	// removed from the source controller.
+	c.Check(s.api.started, jc.DeepEquals, []string{"prod-2-uuid", "prod-3-uuid"})
+	c.Check(cmdtesting.Stderr(ctx), jc.Contains, "All 2 model(s) migrated to controller \"target\"\n")
+}
+
+func (s *MigrateSuite) TestRevert(c *gc.C) {
+	ctx, err := s.makeAndRun(c, "--revert", "model", "target")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Migration back to controller \"target\" started with ID \"uuid:0\"\n")
+	c.Check(s.api.specSeen, jc.DeepEquals, &controller.MigrationSpec{
+		ModelUUID:             modelUUID,
+		TargetControllerUUID:  targetControllerUUID,
+		TargetControllerAlias: "target",
+		TargetAddrs:           []string{"1.2.3.4:5"},
+		TargetCACert:          "cert",
+		TargetUser:            "targetuser",
+		TargetPassword:        "secret",
+		Revert:                true,
+	})
+}
+
+func (s *MigrateSuite) TestRevertWithKeepSource(c *gc.C) {
+	_, err := s.makeAndRun(c, "--revert", "--keep-source", "1h", "model", "target")
+	c.Assert(err, gc.ErrorMatches, "--keep-source cannot be used with --revert")
+}
+
+func (s *MigrateSuite) TestRevertDryRun(c *gc.C) {
+	_, err := s.makeAndRun(c, "--revert", "--dry-run", "model", "target")
+	c.Assert(err, gc.ErrorMatches, "--dry-run cannot be used with --revert")
+}
+
+func (s *MigrateSuite) TestRevertModelPattern(c *gc.C) {
+	_, err := s.makeAndRun(c, "--revert", "prod-*", "target")
+	c.Assert(err, gc.ErrorMatches, "--revert can only be used with a single model")
+	c.Check(s.api.specSeen, gc.IsNil)
+}
+
 func (s *MigrateSuite) makeAndRun(c *gc.C, args ...string) (*cmd.Context, error) {
 	return cmdtesting.RunCommand(c, s.makeCommand(), args...)
 }
@@ -433,6 +685,7 @@ func (s *MigrateSuite) makeCommand() modelcmd.ModelCommand {
 	}
 	inner.modelAPI = s.modelAPI
 	inner.userAPI = s.userAPI
+	inner.pollInterval = 0
 	inner.newAPIRoot = func(jujuclient.ClientStore, string, string) (api.Connection, error) {
 		return s.targetControllerAPI, nil
 	}
@@ -440,13 +693,79 @@ func (s *MigrateSuite) makeCommand() modelcmd.ModelCommand {
 }
 
 type fakeMigrateAPI struct {
-	specSeen    *controller.MigrationSpec
-	identityURL string
+	specSeen       *controller.MigrationSpec
+	identityURL    string
+	precheckSeen   *controller.MigrationSpec
+	precheckErr    error
+	precheckReport controller.MigrationPrecheckReport
+
+	// Used by bulk migrations.
+	allModels    []base.UserModel
+	initiateErrs map[string]error
+	failedPhases map[string]string
+	donePhase    string
+	started      []string
+	running      []string
+	maxRunning   int
+	// controllerLimit is the number of migrations the fake controller
+	// runs at the same time, if set.
+	controllerLimit int
 }
 
 func (a *fakeMigrateAPI) InitiateMigration(spec controller.MigrationSpec) (string, error) {
 	a.specSeen = &spec
-	return "uuid:0", nil
+	if a.allModels == nil {
+		return "uuid:0", nil
+	}
+	if err := a.initiateErrs[spec.ModelUUID]; err != nil {
+		return "", err
+	}
+	if a.controllerLimit > 0 && len(a.running) >= a.controllerLimit {
+		return "", &params.Error{
+			Code:    params.CodeQuotaLimitExceeded,
+			Message: "controller already running model migrations",
+		}
+	}
+	id := spec.ModelUUID + ":0"
+	a.started = append(a.started, spec.ModelUUID)
+	a.running = append(a.running, id)
+	if len(a.running) > a.maxRunning {
+		a.maxRunning = len(a.running)
+	}
+	return id, nil
+}
+
+func (a *fakeMigrateAPI) AllModels() ([]base.UserModel, error) {
+	return a.allModels, nil
+}
+
+// ModelMigrations reports every running migration as having
+// completed, aborting those with a failed phase.
+func (a *fakeMigrateAPI) ModelMigrations() ([]params.ModelMigrationReport, error) {
+	donePhase := a.donePhase
+	if donePhase == "" {
+		donePhase = "DONE"
+	}
+	var reports []params.ModelMigrationReport
+	for _, id := range a.running {
+		report := params.ModelMigrationReport{
+			MigrationId: id,
+			Phase:       donePhase,
+		}
+		if phase, ok := a.failedPhases[id]; ok {
+			report.Phase = "ABORTDONE"
+			report.FailedPhase = phase
+			report.StatusMessage = "aborted, removing model from target controller: boom"
+		}
+		reports = append(reports, report)
+	}
+	a.running = nil
+	return reports, nil
+}
+
+func (a *fakeMigrateAPI) MigrationPrechecks(spec controller.MigrationSpec) (controller.MigrationPrecheckReport, error) {
+	a.precheckSeen = &spec
+	return a.precheckReport, a.precheckErr
 }
 
 func (a *fakeMigrateAPI) IdentityProviderURL() (string, error) {
@@ -467,31 +786,33 @@ func (m *fakeModelAPI) ListModels(user string) ([]base.UserModel, error) {
 }
 
 func (m *fakeModelAPI) ModelInfo(tags []names.ModelTag) ([]params.ModelInfoResult, error) {
-	var (
-		mi  *params.ModelInfo
-		err *params.Error
-	)
-
-	modelUUID := tags[0].Id()
-	for _, model := range m.modelInfo {
-		if model.UUID == modelUUID {
-			mi = &model
-			break
+	results := make([]params.ModelInfoResult, len(tags))
+	for i, tag := range tags {
+		var (
+			mi  *params.ModelInfo
+			err *params.Error
+		)
+
+		modelUUID := tag.Id()
+		for _, model := range m.modelInfo {
+			if model.UUID == modelUUID {
+				model := model
+				mi = &model
+				break
+			}
 		}
-	}
 
-	if mi == nil {
-		err = &params.Error{
-			Code: params.CodeNotFound,
+		if mi == nil {
+			err = &params.Error{
+				Code: params.CodeNotFound,
+			}
 		}
-	}
-
-	return []params.ModelInfoResult{
-		{
+		results[i] = params.ModelInfoResult{
 			Result: mi,
 			Error:  err,
-		},
-	}, nil
+		}
+	}
+	return results, nil
 }
 
 func (m *fakeModelAPI) Close() error {
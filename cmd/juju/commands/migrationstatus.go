@@ -0,0 +1,237 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"sort"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	coremigration "github.com/juju/juju/core/migration"
+)
+
+func newMigrationStatusCommand() cmd.Command {
+	return modelcmd.WrapController(&migrationStatusCommand{})
+}
+
+// migrationStatusCommand reports on the model migrations started from
+// a controller.
+type migrationStatusCommand struct {
+	modelcmd.ControllerCommandBase
+	out     cmd.Output
+	pattern string
+	isoTime bool
+
+	// Overridden by tests
+	api migrationStatusAPI
+}
+
+type migrationStatusAPI interface {
+	ModelMigrations() ([]params.ModelMigrationReport, error)
+	Close() error
+}
+
+const migrationStatusDoc = `
+migration-status shows the progress of the most recent migration of
+each model that has been migrated, or has had a migration attempted,
+from the controller. Models which have been migrated successfully are
+included, even though they have been removed from the controller.
+
+The status of each migration is one of:
+
+    migrating   the migration is in progress
+    aborting    the migration failed and is being rolled back
+    failed      the migration failed; the model remains on this controller
+    migrated    the model is now managed by the target controller
+    kept        the model is managed by the target controller, and is
+                kept on this controller so the migration can be reverted
+    reverted    the model was moved back to this controller by reverting
+                the migration
+
+For failed migrations, the phase the migration failed in is shown.
+
+A model name pattern, which may contain the wildcards "*", "?" and
+"[...]", limits the output to the matching models.
+
+Examples:
+
+    juju migration-status
+    juju migration-status 'prod-*'
+    juju migration-status --format yaml
+
+See also:
+    migrate
+`
+
+// Info implements cmd.Command.
+func (c *migrationStatusCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "migration-status",
+		Args:    "[<model-name-pattern>]",
+		Purpose: "Show the progress of the model migrations started from a controller.",
+		Doc:     migrationStatusDoc,
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *migrationStatusCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatMigrationStatusTabular,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *migrationStatusCommand) Init(args []string) error {
+	if len(args) > 0 {
+		c.pattern, args = args[0], args[1:]
+		if _, err := path.Match(c.pattern, ""); err != nil {
+			return errors.Annotatef(err, "invalid model pattern %q", c.pattern)
+		}
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements cmd.Command.
+func (c *migrationStatusCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = api.Close() }()
+	reports, err := api.ModelMigrations()
+	if errors.IsNotSupported(err) {
+		return errors.New("migration-status is not supported by this version of Juju")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+
+	var status migrationStatusSet
+	for _, report := range reports {
+		if c.pattern != "" {
+			if ok, _ := path.Match(c.pattern, report.ModelName); !ok {
+				continue
+			}
+		}
+		status.Migrations = append(status.Migrations, c.formatReport(report))
+	}
+	sort.Slice(status.Migrations, func(i, j int) bool {
+		return status.Migrations[i].Model < status.Migrations[j].Model
+	})
+	if len(status.Migrations) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No model migrations found.")
+		return nil
+	}
+	return c.out.Write(ctx, status)
+}
+
+func (c *migrationStatusCommand) getAPI() (migrationStatusAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return controller.NewClient(root), nil
+}
+
+// migrationStatusSet holds the migrations reported by the
+// migration-status command.
+type migrationStatusSet struct {
+	Migrations []migrationStatus `yaml:"migrations" json:"migrations"`
+}
+
+// migrationStatus holds the details of a single model migration.
+type migrationStatus struct {
+	Model        string `yaml:"model" json:"model"`
+	MigrationId  string `yaml:"migration-id" json:"migration-id"`
+	Target       string `yaml:"target-controller" json:"target-controller"`
+	Status       string `yaml:"status" json:"status"`
+	Phase        string `yaml:"phase" json:"phase"`
+	FailedPhase  string `yaml:"failed-phase,omitempty" json:"failed-phase,omitempty"`
+	Message      string `yaml:"message,omitempty" json:"message,omitempty"`
+	Started      string `yaml:"started" json:"started"`
+	PhaseChanged string `yaml:"phase-changed" json:"phase-changed"`
+	Ended        string `yaml:"ended,omitempty" json:"ended,omitempty"`
+}
+
+func (c *migrationStatusCommand) formatReport(report params.ModelMigrationReport) migrationStatus {
+	status := migrationStatus{
+		Model:        report.ModelName,
+		MigrationId:  report.MigrationId,
+		Target:       report.TargetController,
+		Status:       migrationStatusName(report.Phase),
+		Phase:        report.Phase,
+		FailedPhase:  report.FailedPhase,
+		Message:      report.StatusMessage,
+		Started:      common.FormatTime(&report.Start, c.isoTime),
+		PhaseChanged: common.FormatTime(&report.PhaseChanged, c.isoTime),
+	}
+	if report.End != nil {
+		status.Ended = common.FormatTime(report.End, c.isoTime)
+	}
+	return status
+}
+
+// migrationStatusName summarises a migration phase.
+func migrationStatusName(phaseName string) string {
+	phase, _ := coremigration.ParsePhase(phaseName)
+	switch phase {
+	case coremigration.DONE, coremigration.REAPFAILED:
+		return "migrated"
+	case coremigration.DORMANT:
+		return "kept"
+	case coremigration.REVERTED:
+		return "reverted"
+	case coremigration.ABORT:
+		return "aborting"
+	case coremigration.ABORTDONE:
+		return "failed"
+	}
+	return "migrating"
+}
+
+func formatMigrationStatusTabular(writer io.Writer, value interface{}) error {
+	status, ok := value.(migrationStatusSet)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", status, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Model", "Target", "Status", "Phase", "Since", "Message")
+	counts := make(map[string]int)
+	for _, mig := range status.Migrations {
+		phase := mig.Phase
+		if mig.FailedPhase != "" {
+			phase = mig.FailedPhase
+		}
+		w.Println(mig.Model, mig.Target, mig.Status, phase, mig.PhaseChanged, mig.Message)
+		counts[mig.Status]++
+	}
+	if err := tw.Flush(); err != nil {
+		return errors.Trace(err)
+	}
+	fmt.Fprintf(writer, "\n%d migrating, %d aborting, %d failed, %d migrated",
+		counts["migrating"], counts["aborting"], counts["failed"], counts["migrated"])
+	// Only controllers keeping migrated models report on them.
+	if counts["kept"] > 0 || counts["reverted"] > 0 {
+		fmt.Fprintf(writer, ", %d kept, %d reverted", counts["kept"], counts["reverted"])
+	}
+	return nil
+}
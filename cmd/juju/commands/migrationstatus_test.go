@@ -0,0 +1,171 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type MigrationStatusSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api   *fakeMigrationStatusAPI
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&MigrationStatusSuite{})
+
+func (s *MigrationStatusSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+
+	start := time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	s.api = &fakeMigrationStatusAPI{
+		reports: []params.ModelMigrationReport{{
+			MigrationId:      "uuid-1:0",
+			ModelName:        "prod-1",
+			TargetController: "target",
+			Phase:            "IMPORT",
+			StatusMessage:    "importing",
+			Start:            start,
+			PhaseChanged:     start.Add(time.Minute),
+		}, {
+			MigrationId:      "uuid-3:1",
+			ModelName:        "staging",
+			TargetController: "target",
+			Phase:            "DONE",
+			StatusMessage:    "successful",
+			Start:            start,
+			PhaseChanged:     end,
+			End:              &end,
+		}, {
+			MigrationId:      "uuid-2:0",
+			ModelName:        "prod-2",
+			TargetController: "target",
+			Phase:            "ABORTDONE",
+			FailedPhase:      "VALIDATION",
+			StatusMessage:    "aborted, removing model from target controller: machine sanity check failed",
+			Start:            start,
+			PhaseChanged:     end,
+			End:              &end,
+		}},
+	}
+}
+
+func (s *MigrationStatusSuite) makeCommand() cmd.Command {
+	cmd := &migrationStatusCommand{
+		api: s.api,
+	}
+	cmd.SetClientStore(s.store)
+	return modelcmd.WrapController(cmd)
+}
+
+func (s *MigrationStatusSuite) TestTabular(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.makeCommand(), "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Model    Target  Status     Phase       Since                 Message
+prod-1   target  migrating  IMPORT      2020-06-01 10:01:00Z  importing
+prod-2   target  failed     VALIDATION  2020-06-01 11:00:00Z  aborted, removing model from target controller: machine sanity check failed
+staging  target  migrated   DONE        2020-06-01 11:00:00Z  successful
+
+1 migrating, 0 aborting, 1 failed, 1 migrated
+`[1:])
+}
+
+func (s *MigrationStatusSuite) TestKeptAndReverted(c *gc.C) {
+	start := time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC)
+	s.api.reports = []params.ModelMigrationReport{{
+		MigrationId:      "uuid-1:0",
+		ModelName:        "prod-1",
+		TargetController: "target",
+		Phase:            "DORMANT",
+		StatusMessage:    "successful, model kept on source controller until 2020-06-02T10:00:00Z so the migration can be reverted",
+		Start:            start,
+		PhaseChanged:     start,
+	}, {
+		MigrationId:      "uuid-2:0",
+		ModelName:        "prod-2",
+		TargetController: "target",
+		Phase:            "REVERTED",
+		Start:            start,
+		PhaseChanged:     start,
+		End:              &start,
+	}}
+	ctx, err := cmdtesting.RunCommand(c, s.makeCommand(), "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Model   Target  Status    Phase     Since                 Message
+prod-1  target  kept      DORMANT   2020-06-01 10:00:00Z  successful, model kept on source controller until 2020-06-02T10:00:00Z so the migration can be reverted
+prod-2  target  reverted  REVERTED  2020-06-01 10:00:00Z  
+
+0 migrating, 0 aborting, 0 failed, 0 migrated, 1 kept, 1 reverted
+`[1:])
+}
+
+func (s *MigrationStatusSuite) TestPattern(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.makeCommand(), "--utc", "--format", "yaml", "prod-2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+migrations:
+- model: prod-2
+  migration-id: uuid-2:0
+  target-controller: target
+  status: failed
+  phase: ABORTDONE
+  failed-phase: VALIDATION
+  message: 'aborted, removing model from target controller: machine sanity check failed'
+  started: 2020-06-01 10:00:00Z
+  phase-changed: 2020-06-01 11:00:00Z
+  ended: 2020-06-01 11:00:00Z
+`[1:])
+}
+
+func (s *MigrationStatusSuite) TestNoMigrations(c *gc.C) {
+	s.api.reports = nil
+	ctx, err := cmdtesting.RunCommand(c, s.makeCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "No model migrations found.\n")
+}
+
+func (s *MigrationStatusSuite) TestInvalidPattern(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.makeCommand(), "prod-[")
+	c.Assert(err, gc.ErrorMatches, `invalid model pattern "prod-\[": syntax error in pattern`)
+}
+
+func (s *MigrationStatusSuite) TestNotSupported(c *gc.C) {
+	s.api.err = errors.NotSupportedf("ModelMigrations")
+	_, err := cmdtesting.RunCommand(c, s.makeCommand())
+	c.Assert(err, gc.ErrorMatches, "migration-status is not supported by this version of Juju")
+}
+
+type fakeMigrationStatusAPI struct {
+	reports []params.ModelMigrationReport
+	err     error
+}
+
+func (a *fakeMigrationStatusAPI) ModelMigrations() ([]params.ModelMigrationReport, error) {
+	return a.reports, a.err
+}
+
+func (a *fakeMigrationStatusAPI) Close() error {
+	return nil
+}
@@ -0,0 +1,370 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+	"github.com/juju/utils/ssh"
+
+	"github.com/juju/juju/agent"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/paths"
+	jujussh "github.com/juju/juju/network/ssh"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+	"github.com/juju/juju/worker/uniter/runner/replay"
+)
+
+const replayHookDoc = `
+Replays a hook locally, using the context the hook last ran with on a unit.
+
+Use --capture to ask the unit agent to record the context of the next run
+of a hook: the hook's environment, the application config, leadership and
+leader settings, the charm state and the settings of the relation the hook
+ran for. Each request covers a single run of the hook, so --capture needs
+to be used again to record a later run. The recorded context may contain
+credentials; it is only readable by root on the unit.
+
+The replay-hook command runs the hook from a local copy of the
+charm with the recorded context, answering every hook tool call from it
+instead of the controller. Nothing is written back to the model;
+changes made by hook tools only last for the replay.
+
+Once the hook completes, the hook tool calls it made are listed along with
+their exit codes and how long they took. The hook's own output is written
+to stderr.
+
+Use --from-history to fetch the recorded context from the unit over SSH, and --save to keep a copy of it. A saved context can be
+replayed again with --context, without access to the model. Units of
+kubernetes models can't be reached over SSH, so only --context can be
+used with them.
+
+The hook tools are provided by the jujuc binary, which is looked for next
+to the juju binary and then on the PATH, unless --jujuc is supplied.
+
+Examples:
+    juju replay-hook mysql/0 config-changed --capture
+    juju replay-hook mysql/0 config-changed --from-history --charm-dir ./mysql
+    juju replay-hook mysql/0 db-relation-changed --from-history --save db.yaml
+    juju replay-hook mysql/0 db-relation-changed --context db.yaml
+
+See also:
+    debug-hooks
+    hook-history
+`
+
+func newReplayHookCommand(hostChecker jujussh.ReachableChecker) cmd.Command {
+	c := new(replayHookCommand)
+	c.setHostChecker(hostChecker)
+	return modelcmd.Wrap(c)
+}
+
+// replayHookCommand replays a hook locally, with the hook context it
+// last ran with on a unit.
+type replayHookCommand struct {
+	SSHCommon
+
+	out         cmd.Output
+	hook        string
+	capture     bool
+	fromHistory bool
+	contextFile string
+	charmDir    string
+	saveFile    string
+	jujucPath   string
+
+	fetchSnapshot  func(*cmd.Context) ([]byte, error)
+	requestCapture func(*cmd.Context) error
+	runHook        func(replay.Config) ([]jujuc.ToolCall, error)
+}
+
+// ToolCall defines the serialization behaviour of a replayed hook tool call.
+type ToolCall struct {
+	Command  string   `yaml:"command" json:"command"`
+	Args     []string `yaml:"args,omitempty" json:"args,omitempty"`
+	Code     int      `yaml:"exit-code" json:"exit-code"`
+	Duration string   `yaml:"duration" json:"duration"`
+	Stdout   string   `yaml:"stdout,omitempty" json:"stdout,omitempty"`
+	Stderr   string   `yaml:"stderr,omitempty" json:"stderr,omitempty"`
+}
+
+// Info implements Command.Info.
+func (c *replayHookCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "replay-hook",
+		Args:    "<unit name> <hook name>",
+		Purpose: "Replay a hook locally with the context it last ran with.",
+		Doc:     replayHookDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *replayHookCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.SSHCommon.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatToolCallsTabular,
+	})
+	f.BoolVar(&c.capture, "capture", false, "Ask the unit to record the context of the next run of the hook")
+	f.BoolVar(&c.fromHistory, "from-history", false, "Fetch the recorded hook context from the unit")
+	f.StringVar(&c.contextFile, "context", "", "Replay the hook with a context previously saved with --save")
+	f.StringVar(&c.charmDir, "charm-dir", "", "The local charm directory to run the hook from (defaults to the current directory)")
+	f.StringVar(&c.saveFile, "save", "", "Save the fetched hook context to this file")
+	f.StringVar(&c.jujucPath, "jujuc", "", "The jujuc binary providing the hook tools")
+}
+
+// AllowInterspersedFlags is part of the cmd.Command interface. Unlike
+// ssh, replay-hook passes no arguments through, so flags may follow
+// the unit and hook names.
+func (c *replayHookCommand) AllowInterspersedFlags() bool {
+	return true
+}
+
+// Init implements Command.Init.
+func (c *replayHookCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.Errorf("no unit name specified")
+	case 1:
+		return errors.Errorf("no hook name specified")
+	}
+	c.Target, c.hook, args = args[0], args[1], args[2:]
+	if !names.IsValidUnit(c.Target) {
+		return errors.Errorf("%q is not a valid unit name", c.Target)
+	}
+	if c.capture {
+		if c.fromHistory || c.contextFile != "" || c.saveFile != "" {
+			return errors.New("--capture cannot be combined with --from-history, --context or --save")
+		}
+		// The hook name is used in a path on the unit.
+		if !validHookName.MatchString(c.hook) {
+			return errors.Errorf("%q is not a valid hook name", c.hook)
+		}
+		return cmd.CheckEmpty(args)
+	}
+	if c.fromHistory == (c.contextFile != "") {
+		return errors.New("exactly one of --capture, --from-history and --context must be specified")
+	}
+	if c.saveFile != "" && !c.fromHistory {
+		return errors.New("--save can only be used with --from-history")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// IncompatibleModel is called with the error given when the command is
+// run on a kubernetes model. Replaying a saved context needs no access
+// to the model, so is allowed, but --capture and --from-history reach
+// the unit's agent directory over SSH, which units of kubernetes models
+// don't support.
+func (c *replayHookCommand) IncompatibleModel(err error) error {
+	if err == nil || c.contextFile != "" {
+		return nil
+	}
+	return errors.Errorf("cannot use --capture or --from-history with units of kubernetes models; " +
+		"their agents are not reachable over SSH")
+}
+
+// Run implements Command.Run.
+func (c *replayHookCommand) Run(ctx *cmd.Context) error {
+	if c.capture {
+		request := c.requestCapture
+		if request == nil {
+			request = c.requestRemoteCapture
+		}
+		if err := request(ctx); err != nil {
+			return errors.Trace(err)
+		}
+		ctx.Infof("The next run of the %s hook on %s will be recorded.", c.hook, c.Target)
+		ctx.Infof("Once it has run, replay it with:\n    juju replay-hook %s %s --from-history", c.Target, c.hook)
+		return nil
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+	if c.fromHistory {
+		fetch := c.fetchSnapshot
+		if fetch == nil {
+			fetch = c.fetchRemoteSnapshot
+		}
+		data, err = fetch(ctx)
+	} else {
+		data, err = ioutil.ReadFile(ctx.AbsPath(c.contextFile))
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	snap, err := replay.ParseSnapshot(data)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if snap.Unit != c.Target || snap.Hook != c.hook {
+		return errors.Errorf("context was captured for %s hook on unit %q, not %s hook on unit %q",
+			snap.Hook, snap.Unit, c.hook, c.Target)
+	}
+	if c.saveFile != "" {
+		if err := ioutil.WriteFile(ctx.AbsPath(c.saveFile), data, 0600); err != nil {
+			return errors.Annotate(err, "saving hook context")
+		}
+	}
+
+	charmDir := ctx.Dir
+	if c.charmDir != "" {
+		charmDir = ctx.AbsPath(c.charmDir)
+	}
+	jujucPath, err := c.findJujuc(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	run := c.runHook
+	if run == nil {
+		run = replay.Run
+	}
+	ctx.Infof("Replaying %s hook for %s, captured %s", snap.Hook, snap.Unit, snap.Captured.Format(time.RFC3339))
+	calls, hookErr := run(replay.Config{
+		Snapshot:  snap,
+		CharmDir:  charmDir,
+		JujucPath: jujucPath,
+		Stdout:    ctx.Stderr,
+		Stderr:    ctx.Stderr,
+	})
+	if hookErr != nil && !isExitError(hookErr) {
+		return errors.Trace(hookErr)
+	}
+
+	formatted := make([]ToolCall, len(calls))
+	for i, call := range calls {
+		formatted[i] = ToolCall{
+			Command:  call.CommandName,
+			Args:     call.Args,
+			Code:     call.Code,
+			Duration: call.Duration.Round(time.Millisecond).String(),
+			Stdout:   string(call.Stdout),
+			Stderr:   string(call.Stderr),
+		}
+	}
+	if len(formatted) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No hook tools were called.")
+	} else if err := c.out.Write(ctx, formatted); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(hookErr)
+}
+
+// validHookName matches the names hooks may have, which excludes any
+// name that could escape the unit's replay directory.
+var validHookName = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
+
+// requestRemoteCapture asks the unit agent, over SSH, to record the
+// context of the next run of the hook.
+func (c *replayHookCommand) requestRemoteCapture(ctx *cmd.Context) error {
+	path := c.unitPath(replay.CaptureRequestPath)
+	// install creates the request file along with any missing parent
+	// directories without needing a shell on the unit.
+	args := []string{"sudo", "install", "-D", "-m", "0600", "/dev/null", path}
+	if err := c.runOnUnit(ctx, args, nil); err != nil {
+		return errors.Annotatef(err, "requesting capture of %s hook on %s", c.hook, c.Target)
+	}
+	return nil
+}
+
+// fetchRemoteSnapshot reads the recorded hook context from the unit's
+// agent directory over SSH.
+func (c *replayHookCommand) fetchRemoteSnapshot(ctx *cmd.Context) ([]byte, error) {
+	var stdout bytes.Buffer
+	path := c.unitPath(replay.SnapshotPath)
+	if err := c.runOnUnit(ctx, []string{"sudo", "cat", path}, &stdout); err != nil {
+		return nil, errors.Annotatef(err, "fetching %s hook context from %s (was it recorded with --capture?)", c.hook, c.Target)
+	}
+	return stdout.Bytes(), nil
+}
+
+// unitPath returns the path on the unit that the supplied function
+// gives for the hook, relative to the unit's agent directory. Only
+// units of machine models are supported; see IncompatibleModel.
+func (c *replayHookCommand) unitPath(path func(baseDir, hookName string) string) string {
+	agentDir := agent.Dir(paths.NixDataDir, names.NewUnitTag(c.Target))
+	// The path is used on the unit, so must use forward slashes
+	// even when the client runs on Windows.
+	return filepath.ToSlash(path(agentDir, c.hook))
+}
+
+// runOnUnit runs the command on the target unit over SSH.
+func (c *replayHookCommand) runOnUnit(ctx *cmd.Context, args []string, stdout io.Writer) error {
+	if err := c.initRun(); err != nil {
+		return errors.Trace(err)
+	}
+	defer c.cleanupRun()
+
+	target, err := c.resolveTarget(c.Target)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	options, err := c.getSSHOptions(false, target)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	command := ssh.Command(target.userHost(), args, options)
+	command.Stdout = stdout
+	command.Stderr = ctx.Stderr
+	return command.Run()
+}
+
+// findJujuc returns the path of the jujuc binary to provide the hook
+// tools with.
+func (c *replayHookCommand) findJujuc(ctx *cmd.Context) (string, error) {
+	if c.jujucPath != "" {
+		return ctx.AbsPath(c.jujucPath), nil
+	}
+	if juju, err := getJujuExecutable(); err == nil {
+		path := filepath.Join(filepath.Dir(juju), "jujuc")
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	path, err := exec.LookPath("jujuc")
+	if err != nil {
+		return "", errors.New("cannot find the jujuc binary; use --jujuc to specify its location")
+	}
+	return path, nil
+}
+
+func isExitError(err error) bool {
+	_, ok := errors.Cause(err).(*exec.ExitError)
+	return ok
+}
+
+func formatToolCallsTabular(writer io.Writer, value interface{}) error {
+	calls, ok := value.([]ToolCall)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", calls, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("#", "Tool call", "Exit", "Duration")
+	for i, call := range calls {
+		formatted := replay.FormatArgs(jujuc.ToolCall{
+			CommandName: call.Command,
+			Args:        call.Args,
+		})
+		w.Println(fmt.Sprint(i+1), formatted, fmt.Sprint(call.Code), call.Duration)
+	}
+	return tw.Flush()
+}
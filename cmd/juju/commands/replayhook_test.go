@@ -0,0 +1,226 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+	"github.com/juju/juju/worker/uniter/runner/replay"
+)
+
+type ReplayHookSuite struct {
+	testing.IsolationSuite
+
+	snapshot  []byte
+	config    replay.Config
+	calls     []jujuc.ToolCall
+	hookErr   error
+	requested bool
+}
+
+var _ = gc.Suite(&ReplayHookSuite{})
+
+func (s *ReplayHookSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.snapshot = []byte(`
+unit: mysql/0
+hook: config-changed
+captured: 2020-05-01T10:00:00Z
+config:
+  port: 3306
+`[1:])
+	s.config = replay.Config{}
+	s.calls = []jujuc.ToolCall{{
+		CommandName: "config-get",
+		Args:        []string{"port"},
+		Stdout:      []byte("3306\n"),
+		Duration:    12 * time.Millisecond,
+	}, {
+		CommandName: "status-set",
+		Args:        []string{"active", "all good"},
+		Duration:    3 * time.Millisecond,
+	}}
+	s.hookErr = nil
+	s.requested = false
+}
+
+func (s *ReplayHookSuite) newCommand() *replayHookCommand {
+	c := &replayHookCommand{}
+	c.fetchSnapshot = func(*cmd.Context) ([]byte, error) {
+		return s.snapshot, nil
+	}
+	c.requestCapture = func(*cmd.Context) error {
+		s.requested = true
+		return nil
+	}
+	c.runHook = func(config replay.Config) ([]jujuc.ToolCall, error) {
+		s.config = config
+		return s.calls, s.hookErr
+	}
+	return c
+}
+
+func (s *ReplayHookSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, s.newCommand(), args...)
+}
+
+func (s *ReplayHookSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no unit name specified",
+	}, {
+		args: []string{"mysql/0"},
+		err:  "no hook name specified",
+	}, {
+		args: []string{"mysql", "install", "--from-history"},
+		err:  `"mysql" is not a valid unit name`,
+	}, {
+		args: []string{"mysql/0", "install"},
+		err:  "exactly one of --capture, --from-history and --context must be specified",
+	}, {
+		args: []string{"mysql/0", "install", "--from-history", "--context", "ctx.yaml"},
+		err:  "exactly one of --capture, --from-history and --context must be specified",
+	}, {
+		args: []string{"mysql/0", "install", "--capture", "--from-history"},
+		err:  "--capture cannot be combined with --from-history, --context or --save",
+	}, {
+		args: []string{"mysql/0", "install", "--capture", "--context", "ctx.yaml"},
+		err:  "--capture cannot be combined with --from-history, --context or --save",
+	}, {
+		args: []string{"mysql/0", "../install", "--capture"},
+		err:  `"../install" is not a valid hook name`,
+	}, {
+		args: []string{"mysql/0", "install", "--context", "ctx.yaml", "--save", "other.yaml"},
+		err:  "--save can only be used with --from-history",
+	}, {
+		args: []string{"mysql/0", "install", "--from-history", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := cmdtesting.InitCommand(s.newCommand(), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ReplayHookSuite) TestRunCapture(c *gc.C) {
+	ctx, err := s.run(c, "mysql/0", "db-relation-changed", "--capture")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.requested, jc.IsTrue)
+	c.Assert(s.config, jc.DeepEquals, replay.Config{})
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+The next run of the db-relation-changed hook on mysql/0 will be recorded.
+Once it has run, replay it with:
+    juju replay-hook mysql/0 db-relation-changed --from-history
+`[1:])
+}
+
+func (s *ReplayHookSuite) TestRunFromHistory(c *gc.C) {
+	ctx, err := s.run(c, "mysql/0", "config-changed", "--from-history", "--charm-dir", "charm", "--jujuc", "/bin/jujuc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+#  Tool call                     Exit  Duration
+1  config-get port               0     12ms
+2  status-set active 'all good'  0     3ms
+
+`[1:])
+	c.Assert(s.config.Snapshot.Unit, gc.Equals, "mysql/0")
+	c.Assert(s.config.Snapshot.Config, jc.DeepEquals, map[string]interface{}{"port": 3306})
+	c.Assert(s.config.CharmDir, gc.Equals, filepath.Join(ctx.Dir, "charm"))
+	c.Assert(s.config.JujucPath, gc.Equals, "/bin/jujuc")
+}
+
+func (s *ReplayHookSuite) TestRunYAML(c *gc.C) {
+	ctx, err := s.run(c, "mysql/0", "config-changed", "--from-history", "--jujuc", "/bin/jujuc", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- command: config-get
+  args:
+  - port
+  exit-code: 0
+  duration: 12ms
+  stdout: |
+    3306
+- command: status-set
+  args:
+  - active
+  - all good
+  exit-code: 0
+  duration: 3ms
+`[1:])
+}
+
+func (s *ReplayHookSuite) TestRunSaveAndReplayContext(c *gc.C) {
+	dir := c.MkDir()
+	saved := filepath.Join(dir, "saved.yaml")
+	_, err := s.run(c, "mysql/0", "config-changed", "--from-history", "--jujuc", "/bin/jujuc", "--save", saved)
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := ioutil.ReadFile(saved)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data, jc.DeepEquals, s.snapshot)
+
+	s.snapshot = nil
+	_, err = s.run(c, "mysql/0", "config-changed", "--context", saved, "--jujuc", "/bin/jujuc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.config.Snapshot.Hook, gc.Equals, "config-changed")
+}
+
+func (s *ReplayHookSuite) newKubernetesCommand() cmd.Command {
+	command := modelcmd.Wrap(s.newCommand())
+	command.SetClientStore(minimalStore(model.CAAS))
+	return command
+}
+
+func (s *ReplayHookSuite) TestKubernetesModelRemoteUnsupported(c *gc.C) {
+	for _, flag := range []string{"--capture", "--from-history"} {
+		_, err := cmdtesting.RunCommand(c, s.newKubernetesCommand(), "mysql/0", "config-changed", flag)
+		c.Check(err, gc.ErrorMatches, "cannot use --capture or --from-history with units of kubernetes models; .*")
+	}
+	c.Assert(s.requested, jc.IsFalse)
+}
+
+func (s *ReplayHookSuite) TestKubernetesModelReplayContext(c *gc.C) {
+	saved := filepath.Join(c.MkDir(), "saved.yaml")
+	err := ioutil.WriteFile(saved, s.snapshot, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = cmdtesting.RunCommand(c, s.newKubernetesCommand(), "mysql/0", "config-changed", "--context", saved, "--jujuc", "/bin/jujuc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.config.Snapshot.Hook, gc.Equals, "config-changed")
+}
+
+func (s *ReplayHookSuite) TestRunWrongHook(c *gc.C) {
+	_, err := s.run(c, "mysql/0", "install", "--from-history", "--jujuc", "/bin/jujuc")
+	c.Assert(err, gc.ErrorMatches, `context was captured for config-changed hook on unit "mysql/0", not install hook on unit "mysql/0"`)
+}
+
+func (s *ReplayHookSuite) TestRunHookFails(c *gc.C) {
+	s.hookErr = errors.Annotate(&exec.ExitError{}, "running config-changed hook")
+	ctx, err := s.run(c, "mysql/0", "config-changed", "--from-history", "--jujuc", "/bin/jujuc")
+	c.Assert(err, gc.ErrorMatches, "running config-changed hook: .*")
+	// The tool calls made before the hook failed are still shown.
+	c.Assert(cmdtesting.Stdout(ctx), jc.Contains, "config-get port")
+}
+
+func (s *ReplayHookSuite) TestRunCannotStartHook(c *gc.C) {
+	s.hookErr = errors.New("boom")
+	ctx, err := s.run(c, "mysql/0", "config-changed", "--from-history", "--jujuc", "/bin/jujuc")
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+}
@@ -93,6 +93,24 @@ func NewEnableDestroyControllerCommandForTest(api removeBlocksAPI, store jujucli
 	return modelcmd.WrapController(c)
 }
 
+// NewEnableMaintenanceModeCommandForTest returns an
+// enableMaintenanceModeCommand with the API mocked out.
+func NewEnableMaintenanceModeCommandForTest(api maintenanceModeAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &enableMaintenanceModeCommand{}
+	c.api = api
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewDisableMaintenanceModeCommandForTest returns a
+// disableMaintenanceModeCommand with the API mocked out.
+func NewDisableMaintenanceModeCommandForTest(api maintenanceModeAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &disableMaintenanceModeCommand{}
+	c.api = api
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
 // NewDestroyCommandForTest returns a DestroyCommand with the controller and
 // client endpoints mocked out.
 func NewDestroyCommandForTest(
@@ -0,0 +1,142 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewEnableMaintenanceModeCommand returns a command that puts the
+// controller into maintenance mode.
+func NewEnableMaintenanceModeCommand() cmd.Command {
+	return modelcmd.WrapController(&enableMaintenanceModeCommand{})
+}
+
+// NewDisableMaintenanceModeCommand returns a command that takes the
+// controller out of maintenance mode.
+func NewDisableMaintenanceModeCommand() cmd.Command {
+	return modelcmd.WrapController(&disableMaintenanceModeCommand{})
+}
+
+type maintenanceModeAPI interface {
+	Close() error
+	EnableMaintenanceMode(message string) error
+	DisableMaintenanceMode() error
+}
+
+type maintenanceModeCommandBase struct {
+	modelcmd.ControllerCommandBase
+	api maintenanceModeAPI
+}
+
+func (c *maintenanceModeCommandBase) getAPI() (maintenanceModeAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewControllerAPIClient()
+}
+
+type enableMaintenanceModeCommand struct {
+	maintenanceModeCommandBase
+	message string
+}
+
+var enableMaintenanceModeDoc = `
+Puts the controller into maintenance mode. While in maintenance mode,
+the controller rejects every API call that would change any of its
+models, returning an error that includes the given message. Read-only
+calls, such as "juju status", continue to work, as do the Juju agents.
+
+The "juju status" output of every model on the controller shows that
+the controller is in maintenance mode, along with the message.
+
+Enabling and disabling maintenance mode are always recorded in the
+controller's audit log.
+
+Running the command again while already in maintenance mode replaces
+the message.
+
+Examples:
+    juju enable-maintenance-mode --message "storage migration, back at 14:00 UTC"
+
+See also:
+    disable-maintenance-mode
+    disable-command
+`
+
+// Info implements Command.Info.
+func (c *enableMaintenanceModeCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "enable-maintenance-mode",
+		Purpose: "Put the controller into read-only maintenance mode.",
+		Doc:     enableMaintenanceModeDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *enableMaintenanceModeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.maintenanceModeCommandBase.SetFlags(f)
+	f.StringVar(&c.message, "message", "", "Reason for the maintenance, shown to users")
+}
+
+// Run implements Command.Run.
+func (c *enableMaintenanceModeCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+	if err := client.EnableMaintenanceMode(c.message); err != nil {
+		return maintenanceModeError(err)
+	}
+	ctx.Infof("Maintenance mode enabled")
+	return nil
+}
+
+type disableMaintenanceModeCommand struct {
+	maintenanceModeCommandBase
+}
+
+var disableMaintenanceModeDoc = `
+Takes the controller out of maintenance mode, so that its models can be
+changed again.
+
+See also:
+    enable-maintenance-mode
+`
+
+// Info implements Command.Info.
+func (c *disableMaintenanceModeCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "disable-maintenance-mode",
+		Purpose: "Take the controller out of maintenance mode.",
+		Doc:     disableMaintenanceModeDoc,
+	})
+}
+
+// Run implements Command.Run.
+func (c *disableMaintenanceModeCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+	if err := client.DisableMaintenanceMode(); err != nil {
+		return maintenanceModeError(err)
+	}
+	ctx.Infof("Maintenance mode disabled")
+	return nil
+}
+
+func maintenanceModeError(err error) error {
+	if errors.IsNotSupported(err) {
+		return errors.New("maintenance mode is not supported by this controller, upgrade the controller first")
+	}
+	return errors.Trace(err)
+}
@@ -0,0 +1,104 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/jujuclient"
+)
+
+type maintenanceModeSuite struct {
+	baseControllerSuite
+	api   *fakeMaintenanceModeAPI
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&maintenanceModeSuite{})
+
+func (s *maintenanceModeSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+
+	s.api = &fakeMaintenanceModeAPI{}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "fake"
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{}
+}
+
+func (s *maintenanceModeSuite) TestEnable(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c,
+		controller.NewEnableMaintenanceModeCommandForTest(s.api, s.store),
+		"--message", "storage migration")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Maintenance mode enabled\n")
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"EnableMaintenanceMode", []interface{}{"storage migration"}},
+		{"Close", nil},
+	})
+}
+
+func (s *maintenanceModeSuite) TestEnableNoMessage(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, controller.NewEnableMaintenanceModeCommandForTest(s.api, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "EnableMaintenanceMode", "")
+}
+
+func (s *maintenanceModeSuite) TestEnableUnrecognizedArg(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, controller.NewEnableMaintenanceModeCommandForTest(s.api, s.store), "whoops")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["whoops"\]`)
+	s.api.CheckNoCalls(c)
+}
+
+func (s *maintenanceModeSuite) TestEnableError(c *gc.C) {
+	s.api.SetErrors(apiservererrors.ErrPerm)
+	_, err := cmdtesting.RunCommand(c, controller.NewEnableMaintenanceModeCommandForTest(s.api, s.store))
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *maintenanceModeSuite) TestEnableNotSupported(c *gc.C) {
+	s.api.SetErrors(errors.NotSupportedf("EnableMaintenanceMode"))
+	_, err := cmdtesting.RunCommand(c, controller.NewEnableMaintenanceModeCommandForTest(s.api, s.store))
+	c.Assert(err, gc.ErrorMatches, "maintenance mode is not supported by this controller, upgrade the controller first")
+}
+
+func (s *maintenanceModeSuite) TestDisable(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, controller.NewDisableMaintenanceModeCommandForTest(s.api, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Maintenance mode disabled\n")
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"DisableMaintenanceMode", nil},
+		{"Close", nil},
+	})
+}
+
+func (s *maintenanceModeSuite) TestDisableError(c *gc.C) {
+	s.api.SetErrors(apiservererrors.ErrPerm)
+	_, err := cmdtesting.RunCommand(c, controller.NewDisableMaintenanceModeCommandForTest(s.api, s.store))
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type fakeMaintenanceModeAPI struct {
+	jujutesting.Stub
+}
+
+func (f *fakeMaintenanceModeAPI) Close() error {
+	f.AddCall("Close")
+	return nil
+}
+
+func (f *fakeMaintenanceModeAPI) EnableMaintenanceMode(message string) error {
+	f.AddCall("EnableMaintenanceMode", message)
+	return f.NextErr()
+}
+
+func (f *fakeMaintenanceModeAPI) DisableMaintenanceMode() error {
+	f.AddCall("DisableMaintenanceMode")
+	return f.NextErr()
+}
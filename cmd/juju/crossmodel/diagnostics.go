@@ -0,0 +1,69 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/core/crossmodel"
+)
+
+// RemoteApplicationDiagnostics defines the serialization behaviour
+// of the diagnostics recorded for a cross model relation.
+type RemoteApplicationDiagnostics struct {
+	// LastRemoteEvent is when an event from the remote model
+	// was last processed.
+	LastRemoteEvent string `yaml:"last-remote-event,omitempty" json:"last-remote-event,omitempty"`
+
+	// MacaroonExpiry is when the macaroons used to talk to the
+	// remote model expire.
+	MacaroonExpiry string `yaml:"macaroon-expiry,omitempty" json:"macaroon-expiry,omitempty"`
+
+	// SettingsSyncLag is how long the last relation settings change
+	// took to reach the other model.
+	SettingsSyncLag string `yaml:"settings-sync-lag,omitempty" json:"settings-sync-lag,omitempty"`
+
+	// IngressAddresses are the addresses advertised by the remote units.
+	IngressAddresses []string `yaml:"ingress-addresses,omitempty" json:"ingress-addresses,omitempty"`
+
+	// Updated is when the diagnostics were recorded.
+	Updated string `yaml:"updated" json:"updated"`
+}
+
+// ConvertDiagnostics converts cross model relation diagnostics
+// into their ui-formatted representation.
+func ConvertDiagnostics(diagnostics *crossmodel.RemoteApplicationDiagnostics) *RemoteApplicationDiagnostics {
+	if diagnostics == nil {
+		return nil
+	}
+	result := &RemoteApplicationDiagnostics{
+		LastRemoteEvent:  formatOptionalTime(diagnostics.LastRemoteEvent),
+		MacaroonExpiry:   formatOptionalTime(diagnostics.MacaroonExpiry),
+		IngressAddresses: diagnostics.IngressAddresses,
+		Updated:          common.FormatTime(&diagnostics.Updated, true),
+	}
+	if diagnostics.SettingsSyncLag > 0 {
+		result.SettingsSyncLag = diagnostics.SettingsSyncLag.String()
+	}
+	return result
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return common.FormatTime(t, true)
+}
+
+// valueOrDash returns the value, or "-" if it is empty, for use
+// in tabular output.
+func valueOrDash(values ...string) string {
+	value := strings.Join(values, ",")
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -70,3 +70,19 @@ func NewRemoveCommandForTest(store jujuclient.ClientStore, api RemoveAPI) cmd.Co
 	aCmd.SetClientStore(store)
 	return modelcmd.WrapController(aCmd)
 }
+
+func NewOfferInviteCommandForTest(store jujuclient.ClientStore, api InviteAPI) cmd.Command {
+	aCmd := &inviteCommand{newAPIFunc: func(controllerName string) (InviteAPI, error) {
+		return api, nil
+	}}
+	aCmd.SetClientStore(store)
+	return modelcmd.WrapController(aCmd)
+}
+
+func NewSetOfferLimitsCommandForTest(store jujuclient.ClientStore, api SetOfferLimitsAPI) cmd.Command {
+	aCmd := &setOfferLimitsCommand{newAPIFunc: func(controllerName string) (SetOfferLimitsAPI, error) {
+		return api, nil
+	}}
+	aCmd.SetClientStore(store)
+	return modelcmd.WrapController(aCmd)
+}
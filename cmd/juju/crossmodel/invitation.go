@@ -0,0 +1,40 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// EncodeOfferInvitation returns the token used to pass
+// an invitation to consume an offer to another user.
+func EncodeOfferInvitation(invitation params.OfferInvitation) (string, error) {
+	data, err := json.Marshal(invitation)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeOfferInvitation returns the invitation to consume
+// an offer held by the specified token.
+func DecodeOfferInvitation(token string) (params.OfferInvitation, error) {
+	var invitation params.OfferInvitation
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return invitation, errors.NotValidf("offer invitation token")
+	}
+	if err := json.Unmarshal(data, &invitation); err != nil {
+		return invitation, errors.NotValidf("offer invitation token")
+	}
+	if invitation.Offer == nil || invitation.Macaroon == nil || invitation.ControllerInfo == nil {
+		return invitation, errors.NotValidf("offer invitation token")
+	}
+	return invitation, nil
+}
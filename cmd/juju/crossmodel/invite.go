@@ -0,0 +1,151 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/crossmodel"
+)
+
+const defaultInvitationExpiry = 72 * time.Hour
+
+const inviteCommandDoc = `
+Creates a token which allows a model on any controller to consume the
+offer, without the consuming user needing an account on this controller.
+
+The token can be redeemed once, by passing it to "juju consume --token",
+and only until it expires. Redeeming the token grants consume access to
+the offer to a user created for the invitation; that access can be
+revoked like any other with "juju revoke".
+
+Only offer admins can create invitations.
+
+Offers are normally specified by their URL. It's also possible to specify
+just the offer name, in which case the offer is considered to reside in
+the current model.
+
+Examples:
+    juju offer-invite fred/prod.hosted-mysql
+    juju offer-invite hosted-mysql --expires 24h
+
+See also:
+    consume
+    offer
+    revoke
+`
+
+// NewOfferInviteCommand returns a command used to create
+// invitations to consume an offer.
+func NewOfferInviteCommand() cmd.Command {
+	inviteCmd := &inviteCommand{}
+	inviteCmd.newAPIFunc = func(controllerName string) (InviteAPI, error) {
+		return inviteCmd.NewRemoteEndpointsAPI(controllerName)
+	}
+	return modelcmd.WrapController(inviteCmd)
+}
+
+type inviteCommand struct {
+	RemoteEndpointsCommandBase
+
+	newAPIFunc func(string) (InviteAPI, error)
+	url        string
+	expires    time.Duration
+}
+
+// InviteAPI defines the API methods that the offer invite command uses.
+type InviteAPI interface {
+	Close() error
+	CreateOfferInvitation(url string, expiry time.Duration) (params.OfferInvitation, error)
+}
+
+// Info implements Command.Info.
+func (c *inviteCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "offer-invite",
+		Args:    "<offer-url>",
+		Purpose: "Creates a single use token to consume an offer.",
+		Doc:     inviteCommandDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *inviteCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.RemoteEndpointsCommandBase.SetFlags(f)
+	f.DurationVar(&c.expires, "expires", defaultInvitationExpiry, "How long the token can be redeemed for")
+}
+
+// Init implements Command.Init.
+func (c *inviteCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no offer specified")
+	}
+	c.url = args[0]
+	if c.expires <= 0 {
+		return errors.Errorf("expiry %v must be positive", c.expires)
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements Command.Run.
+func (c *inviteCommand) Run(ctx *cmd.Context) error {
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	url, err := crossmodel.ParseOfferURL(c.url)
+	if err != nil {
+		currentModel, err := c.ClientStore().CurrentModel(controllerName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		url, err = makeURLFromCurrentModel(c.url, controllerName, currentModel)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if url.HasEndpoint() {
+		return errors.Errorf("offer %q shouldn't include endpoint", c.url)
+	}
+	if url.Source != "" {
+		controllerName = url.Source
+	}
+	if url.User == "" {
+		accountDetails, err := c.CurrentAccountDetails()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		url.User = accountDetails.User
+	}
+
+	api, err := c.newAPIFunc(controllerName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	invitation, err := api.CreateOfferInvitation(url.AsLocal().String(), c.expires)
+	if errors.IsNotSupported(err) {
+		return errors.New("offer-invite is not supported by this version of Juju")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	token, err := EncodeOfferInvitation(invitation)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Invitation to consume %s expires at %s.", url.AsLocal(), common.FormatTime(&invitation.Expires, true))
+	ctx.Infof(`Redeem it with "juju consume --token <token>".`)
+	fmt.Fprintln(ctx.Stdout, token)
+	return nil
+}
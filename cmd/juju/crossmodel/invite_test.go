@@ -0,0 +1,130 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/testing"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/crossmodel"
+)
+
+type inviteSuite struct {
+	BaseCrossModelSuite
+	mockAPI *mockInviteAPI
+}
+
+var _ = gc.Suite(&inviteSuite{})
+
+func (s *inviteSuite) SetUpTest(c *gc.C) {
+	s.BaseCrossModelSuite.SetUpTest(c)
+	mac, err := apitesting.NewMacaroon("id")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI = &mockInviteAPI{
+		Stub: &testing.Stub{},
+		invitation: params.OfferInvitation{
+			Offer:          &params.ApplicationOfferDetails{OfferURL: "fred/prod.hosted-mysql", OfferUUID: "offer-uuid"},
+			Macaroon:       mac,
+			ControllerInfo: &params.ExternalControllerInfo{Addrs: []string{"1.2.3.4:17070"}},
+			Expires:        time.Date(2020, 6, 4, 10, 0, 0, 0, time.UTC),
+		},
+	}
+}
+
+func (s *inviteSuite) runInvite(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, crossmodel.NewOfferInviteCommandForTest(s.store, s.mockAPI), args...)
+}
+
+func (s *inviteSuite) TestInitNoOffer(c *gc.C) {
+	_, err := s.runInvite(c)
+	c.Assert(err, gc.ErrorMatches, "no offer specified")
+}
+
+func (s *inviteSuite) TestInitBadExpiry(c *gc.C) {
+	_, err := s.runInvite(c, "fred/prod.hosted-mysql", "--expires", "0s")
+	c.Assert(err, gc.ErrorMatches, "expiry 0s must be positive")
+}
+
+func (s *inviteSuite) TestInitTooManyArgs(c *gc.C) {
+	_, err := s.runInvite(c, "fred/prod.hosted-mysql", "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *inviteSuite) TestInvite(c *gc.C) {
+	ctx, err := s.runInvite(c, "fred/prod.hosted-mysql", "--expires", "24h")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"CreateOfferInvitation", []interface{}{"fred/prod.hosted-mysql", 24 * time.Hour}},
+		{"Close", nil},
+	})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+Invitation to consume fred/prod.hosted-mysql expires at 2020-06-04 10:00:00Z.
+Redeem it with "juju consume --token <token>".
+`[1:])
+
+	token := strings.TrimSpace(cmdtesting.Stdout(ctx))
+	invitation, err := crossmodel.DecodeOfferInvitation(token)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(invitation.Offer, jc.DeepEquals, s.mockAPI.invitation.Offer)
+	c.Assert(invitation.ControllerInfo, jc.DeepEquals, s.mockAPI.invitation.ControllerInfo)
+	c.Assert(invitation.Macaroon.Id(), jc.DeepEquals, []byte("id"))
+	c.Assert(invitation.Expires.Equal(s.mockAPI.invitation.Expires), jc.IsTrue)
+}
+
+func (s *inviteSuite) TestInviteDefaultExpiryCurrentModel(c *gc.C) {
+	_, err := s.runInvite(c, "hosted-mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCall(c, 0, "CreateOfferInvitation", "fred/test.hosted-mysql", 72*time.Hour)
+}
+
+func (s *inviteSuite) TestInviteUnqualifiedModel(c *gc.C) {
+	_, err := s.runInvite(c, "prod.hosted-mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCall(c, 0, "CreateOfferInvitation", "bob/prod.hosted-mysql", 72*time.Hour)
+}
+
+func (s *inviteSuite) TestInviteEndpoint(c *gc.C) {
+	_, err := s.runInvite(c, "fred/prod.hosted-mysql:db")
+	c.Assert(err, gc.ErrorMatches, `offer "fred/prod.hosted-mysql:db" shouldn't include endpoint`)
+}
+
+func (s *inviteSuite) TestInviteNotSupported(c *gc.C) {
+	s.mockAPI.SetErrors(errors.NotSupportedf("CreateOfferInvitations for ApplicationOffers facade v2"))
+	_, err := s.runInvite(c, "fred/prod.hosted-mysql")
+	c.Assert(err, gc.ErrorMatches, "offer-invite is not supported by this version of Juju")
+}
+
+func (s *inviteSuite) TestDecodeInvalidToken(c *gc.C) {
+	_, err := crossmodel.DecodeOfferInvitation("not a token")
+	c.Assert(err, gc.ErrorMatches, "offer invitation token not valid")
+	_, err = crossmodel.DecodeOfferInvitation("e30")
+	c.Assert(err, gc.ErrorMatches, "offer invitation token not valid")
+}
+
+type mockInviteAPI struct {
+	*testing.Stub
+	invitation params.OfferInvitation
+}
+
+func (m *mockInviteAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
+
+func (m *mockInviteAPI) CreateOfferInvitation(url string, expiry time.Duration) (params.OfferInvitation, error) {
+	m.MethodCall(m, "CreateOfferInvitation", url, expiry)
+	if err := m.NextErr(); err != nil {
+		return params.OfferInvitation{}, err
+	}
+	return m.invitation, nil
+}
@@ -224,6 +224,15 @@ type ListOfferItem struct {
 
 	// Users are the users who can consume the offer.
 	Users map[string]OfferUser `yaml:"users,omitempty" json:"users,omitempty"`
+
+	// Limits restrict how the offer may be consumed.
+	Limits *offerLimits `yaml:"limits,omitempty" json:"limits,omitempty"`
+}
+
+type offerLimits struct {
+	MaxConnections     int      `yaml:"max-connections,omitempty" json:"max-connections,omitempty"`
+	MaxRelations       int      `yaml:"max-relations,omitempty" json:"max-relations,omitempty"`
+	AllowedControllers []string `yaml:"allowed-controllers,omitempty" json:"allowed-controllers,omitempty"`
 }
 
 type offeredApplications map[string]ListOfferItem
@@ -273,6 +282,13 @@ func convertOfferToListItem(url *crossmodel.OfferURL, offer *crossmodel.Applicat
 		Endpoints:       convertCharmEndpoints(offer.Endpoints...),
 		Users:           convertUsers(offer.Users...),
 	}
+	if !offer.Limits.IsZero() {
+		item.Limits = &offerLimits{
+			MaxConnections:     offer.Limits.MaxConnections,
+			MaxRelations:       offer.Limits.MaxRelations,
+			AllowedControllers: offer.Limits.AllowedControllers,
+		}
+	}
 	for _, conn := range offer.Connections {
 		item.Connections = append(item.Connections, offerConnectionDetails{
 			SourceModelUUID: conn.SourceModelUUID,
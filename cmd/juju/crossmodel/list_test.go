@@ -284,6 +284,37 @@ hosted-db2:
 	)
 }
 
+func (s *ListSuite) TestListYAMLLimits(c *gc.C) {
+	s.applications[0].Limits = model.OfferLimits{
+		MaxConnections:     2,
+		AllowedControllers: []string{"deadbeef-1bad-500d-9000-4b1d0d06f00d"},
+	}
+
+	s.assertValidList(
+		c,
+		[]string{"--format", "yaml"},
+		`
+hosted-db2:
+  application: app-hosted-db2
+  store: myctrl
+  charm: cs:db2-5
+  offer-url: myctrl:fred/model.hosted-db2
+  endpoints:
+    log:
+      interface: http
+      role: provider
+    mysql:
+      interface: db2
+      role: requirer
+  limits:
+    max-connections: 2
+    allowed-controllers:
+    - deadbeef-1bad-500d-9000-4b1d0d06f00d
+`[1:],
+		"",
+	)
+}
+
 func (s *ListSuite) createOfferItem(name, store string, connections []model.OfferConnection) *model.ApplicationOfferDetails {
 	return &model.ApplicationOfferDetails{
 		ApplicationName: "app-" + name,
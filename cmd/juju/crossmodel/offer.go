@@ -32,10 +32,18 @@ $ juju offer mysql:db
 $ juju offer mymodel.mysql:db
 $ juju offer db2:db hosted-db2
 $ juju offer db2:db,log hosted-db2
+$ juju offer mysql:db --max-connections 3 --max-relations 5
+
+The number of models which may relate to the offer, and the total number
+of relations to it, can be limited with --max-connections and
+--max-relations. Relations can also be restricted to models hosted on
+particular controllers by listing their UUIDs with --allowed-controllers.
+Limits can be changed later with "juju set-offer-limits".
 
 See also:
     consume
     relate
+    set-offer-limits
 `
 )
 
@@ -66,6 +74,11 @@ type offerCommand struct {
 
 	// QualifiedModelName stores the name of the model hosting the offer.
 	QualifiedModelName string
+
+	// Limits restrict how the offer may be consumed.
+	Limits jujucrossmodel.OfferLimits
+
+	allowedControllers string
 }
 
 // NewApplicationOffersAPI returns an application offers api for the root api endpoint
@@ -99,12 +112,21 @@ func (c *offerCommand) Init(args []string) error {
 		argCount = 2
 		c.OfferName = args[1]
 	}
+	if c.allowedControllers != "" {
+		c.Limits.AllowedControllers = strings.Split(c.allowedControllers, ",")
+	}
+	if err := c.Limits.Validate(); err != nil {
+		return errors.Trace(err)
+	}
 	return cmd.CheckEmpty(args[argCount:])
 }
 
 // SetFlags implements Command.SetFlags.
 func (c *offerCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ControllerCommandBase.SetFlags(f)
+	f.IntVar(&c.Limits.MaxConnections, "max-connections", 0, "Maximum number of models which may relate to the offer")
+	f.IntVar(&c.Limits.MaxRelations, "max-relations", 0, "Maximum number of relations to the offer")
+	f.StringVar(&c.allowedControllers, "allowed-controllers", "", "Comma separated UUIDs of the controllers whose models may relate to the offer")
 }
 
 // Run implements Command.Run.
@@ -151,8 +173,15 @@ func (c *offerCommand) Run(ctx *cmd.Context) error {
 		c.OfferName = c.Application
 	}
 	// TODO (anastasiamac 2015-11-16) Add a sensible way for user to specify long-ish (at times) description when offering
-	results, err := api.Offer(modelDetails.ModelUUID, c.Application, c.Endpoints, c.OfferName, "")
-	if err != nil {
+	var results []params.ErrorResult
+	if c.Limits.IsZero() {
+		results, err = api.Offer(modelDetails.ModelUUID, c.Application, c.Endpoints, c.OfferName, "")
+	} else {
+		results, err = api.OfferWithLimits(modelDetails.ModelUUID, c.Application, c.Endpoints, c.OfferName, "", c.Limits)
+	}
+	if errors.IsNotSupported(err) {
+		return errors.New("offer limits are not supported by this version of Juju")
+	} else if err != nil {
 		return err
 	}
 	if err := (params.ErrorResults{results}).Combine(); err != nil {
@@ -173,6 +202,9 @@ func (c *offerCommand) Run(ctx *cmd.Context) error {
 type OfferAPI interface {
 	Close() error
 	Offer(modelUUID, application string, endpoints []string, offerName string, desc string) ([]params.ErrorResult, error)
+	OfferWithLimits(
+		modelUUID, application string, endpoints []string, offerName string, desc string, limits jujucrossmodel.OfferLimits,
+	) ([]params.ErrorResult, error)
 }
 
 // applicationParse is used to split an application string
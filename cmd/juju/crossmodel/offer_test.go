@@ -13,6 +13,7 @@ import (
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/crossmodel"
+	jujucrossmodel "github.com/juju/juju/core/crossmodel"
 )
 
 type offerSuite struct {
@@ -107,6 +108,37 @@ func (s *offerSuite) TestOfferMultipleEndpoints(c *gc.C) {
 	s.assertOfferOutput(c, "test", "tst", "tst", []string{"db", "admin"})
 }
 
+func (s *offerSuite) TestOfferWithLimits(c *gc.C) {
+	s.args = []string{"tst:db", "--max-connections", "2", "--max-relations", "3",
+		"--allowed-controllers", "deadbeef-1bad-500d-9000-4b1d0d06f00d"}
+	s.assertOfferOutput(c, "test", "tst", "tst", []string{"db"})
+	c.Assert(s.mockAPI.limits["tst"], jc.DeepEquals, jujucrossmodel.OfferLimits{
+		MaxConnections:     2,
+		MaxRelations:       3,
+		AllowedControllers: []string{"deadbeef-1bad-500d-9000-4b1d0d06f00d"},
+	})
+}
+
+func (s *offerSuite) TestOfferWithoutLimits(c *gc.C) {
+	s.args = []string{"tst:db"}
+	s.assertOfferOutput(c, "test", "tst", "tst", []string{"db"})
+	_, ok := s.mockAPI.limits["tst"]
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *offerSuite) TestOfferInvalidLimits(c *gc.C) {
+	s.args = []string{"tst:db", "--max-relations", "-1"}
+	s.assertOfferErrorOutput(c, `max relations -1 not valid`)
+	s.args = []string{"tst:db", "--allowed-controllers", "foo"}
+	s.assertOfferErrorOutput(c, `allowed controller "foo" not valid`)
+}
+
+func (s *offerSuite) TestOfferLimitsNotSupported(c *gc.C) {
+	s.args = []string{"tst:db", "--max-relations", "1"}
+	s.mockAPI.limitsNotSupported = true
+	s.assertOfferErrorOutput(c, `offer limits are not supported by this version of Juju`)
+}
+
 func (s *offerSuite) assertOfferOutput(c *gc.C, expectedModel, expectedOffer, expectedApplication string, endpoints []string) {
 	_, err := s.runOffer(c, s.args...)
 	c.Assert(err, jc.ErrorIsNil)
@@ -114,11 +146,13 @@ func (s *offerSuite) assertOfferOutput(c *gc.C, expectedModel, expectedOffer, ex
 }
 
 type mockOfferAPI struct {
-	errCall, errData bool
-	modelUUID        string
-	offers           map[string][]string
-	applications     map[string]string
-	descs            map[string]string
+	errCall, errData   bool
+	limitsNotSupported bool
+	modelUUID          string
+	offers             map[string][]string
+	applications       map[string]string
+	descs              map[string]string
+	limits             map[string]jujucrossmodel.OfferLimits
 }
 
 func newMockOfferAPI() *mockOfferAPI {
@@ -126,6 +160,7 @@ func newMockOfferAPI() *mockOfferAPI {
 	mock.offers = make(map[string][]string)
 	mock.descs = make(map[string]string)
 	mock.applications = make(map[string]string)
+	mock.limits = make(map[string]jujucrossmodel.OfferLimits)
 	return mock
 }
 
@@ -151,3 +186,20 @@ func (s *mockOfferAPI) Offer(modelUUID, application string, endpoints []string,
 	s.descs[offerName] = desc
 	return result, nil
 }
+
+func (s *mockOfferAPI) OfferWithLimits(
+	modelUUID, application string, endpoints []string, offerName, desc string, limits jujucrossmodel.OfferLimits,
+) ([]params.ErrorResult, error) {
+	if s.limitsNotSupported {
+		return nil, errors.NotSupportedf("offer limits")
+	}
+	result, err := s.Offer(modelUUID, application, endpoints, offerName, desc)
+	if err != nil {
+		return nil, err
+	}
+	if offerName == "" {
+		offerName = application
+	}
+	s.limits[offerName] = limits
+	return result, nil
+}
@@ -0,0 +1,147 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/crossmodel"
+)
+
+const setOfferLimitsCommandDoc = `
+Sets the limits on how an offer may be consumed, replacing any limits
+previously set. Limits which are not specified are removed.
+
+--max-connections limits the number of models which may relate to the
+offer, and --max-relations limits the total number of relations to it.
+--allowed-controllers restricts relations to models hosted on the
+controllers with the given UUIDs.
+
+Limits only apply to new relations; existing relations are not removed
+if they exceed a newly set limit. A consumer adding a relation which
+would exceed the limits is refused with an error.
+
+Only offer admins can set limits.
+
+Offers are normally specified by their URL. It's also possible to specify
+just the offer name, in which case the offer is considered to reside in
+the current model.
+
+Examples:
+    juju set-offer-limits fred/prod.hosted-mysql --max-connections 3
+    juju set-offer-limits hosted-mysql --max-relations 10 --allowed-controllers deadbeef-1bad-500d-9000-4b1d0d06f00d
+    juju set-offer-limits hosted-mysql
+
+See also:
+    offer
+    offers
+`
+
+// NewSetOfferLimitsCommand returns a command used to set
+// the limits on how an offer may be consumed.
+func NewSetOfferLimitsCommand() cmd.Command {
+	limitsCmd := &setOfferLimitsCommand{}
+	limitsCmd.newAPIFunc = func(controllerName string) (SetOfferLimitsAPI, error) {
+		return limitsCmd.NewRemoteEndpointsAPI(controllerName)
+	}
+	return modelcmd.WrapController(limitsCmd)
+}
+
+type setOfferLimitsCommand struct {
+	RemoteEndpointsCommandBase
+
+	newAPIFunc         func(string) (SetOfferLimitsAPI, error)
+	url                string
+	limits             crossmodel.OfferLimits
+	allowedControllers string
+}
+
+// SetOfferLimitsAPI defines the API methods that the set offer limits command uses.
+type SetOfferLimitsAPI interface {
+	Close() error
+	SetOfferLimits(url string, limits crossmodel.OfferLimits) error
+}
+
+// Info implements Command.Info.
+func (c *setOfferLimitsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "set-offer-limits",
+		Args:    "<offer-url>",
+		Purpose: "Sets the limits on how an offer may be consumed.",
+		Doc:     setOfferLimitsCommandDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *setOfferLimitsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.RemoteEndpointsCommandBase.SetFlags(f)
+	f.IntVar(&c.limits.MaxConnections, "max-connections", 0, "Maximum number of models which may relate to the offer")
+	f.IntVar(&c.limits.MaxRelations, "max-relations", 0, "Maximum number of relations to the offer")
+	f.StringVar(&c.allowedControllers, "allowed-controllers", "", "Comma separated UUIDs of the controllers whose models may relate to the offer")
+}
+
+// Init implements Command.Init.
+func (c *setOfferLimitsCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no offer specified")
+	}
+	c.url = args[0]
+	if c.allowedControllers != "" {
+		c.limits.AllowedControllers = strings.Split(c.allowedControllers, ",")
+	}
+	if err := c.limits.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements Command.Run.
+func (c *setOfferLimitsCommand) Run(ctx *cmd.Context) error {
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	url, err := crossmodel.ParseOfferURL(c.url)
+	if err != nil {
+		currentModel, err := c.ClientStore().CurrentModel(controllerName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		url, err = makeURLFromCurrentModel(c.url, controllerName, currentModel)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if url.HasEndpoint() {
+		return errors.Errorf("offer %q shouldn't include endpoint", c.url)
+	}
+	if url.Source != "" {
+		controllerName = url.Source
+	}
+	if url.User == "" {
+		accountDetails, err := c.CurrentAccountDetails()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		url.User = accountDetails.User
+	}
+
+	api, err := c.newAPIFunc(controllerName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	err = api.SetOfferLimits(url.AsLocal().String(), c.limits)
+	if errors.IsNotSupported(err) {
+		return errors.New("set-offer-limits is not supported by this version of Juju")
+	}
+	return errors.Trace(err)
+}
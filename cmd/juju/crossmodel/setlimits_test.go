@@ -0,0 +1,98 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/crossmodel"
+	jujucrossmodel "github.com/juju/juju/core/crossmodel"
+)
+
+type setOfferLimitsSuite struct {
+	BaseCrossModelSuite
+	mockAPI *mockSetOfferLimitsAPI
+}
+
+var _ = gc.Suite(&setOfferLimitsSuite{})
+
+func (s *setOfferLimitsSuite) SetUpTest(c *gc.C) {
+	s.BaseCrossModelSuite.SetUpTest(c)
+	s.mockAPI = &mockSetOfferLimitsAPI{Stub: &testing.Stub{}}
+}
+
+func (s *setOfferLimitsSuite) runSetOfferLimits(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, crossmodel.NewSetOfferLimitsCommandForTest(s.store, s.mockAPI), args...)
+}
+
+func (s *setOfferLimitsSuite) TestInitNoOffer(c *gc.C) {
+	_, err := s.runSetOfferLimits(c)
+	c.Assert(err, gc.ErrorMatches, "no offer specified")
+}
+
+func (s *setOfferLimitsSuite) TestInitTooManyArgs(c *gc.C) {
+	_, err := s.runSetOfferLimits(c, "fred/prod.hosted-mysql", "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *setOfferLimitsSuite) TestInitInvalidLimits(c *gc.C) {
+	_, err := s.runSetOfferLimits(c, "fred/prod.hosted-mysql", "--max-connections", "-1")
+	c.Assert(err, gc.ErrorMatches, "max connections -1 not valid")
+	_, err = s.runSetOfferLimits(c, "fred/prod.hosted-mysql", "--allowed-controllers", "foo,bar")
+	c.Assert(err, gc.ErrorMatches, `allowed controller "foo" not valid`)
+}
+
+func (s *setOfferLimitsSuite) TestSetOfferLimits(c *gc.C) {
+	_, err := s.runSetOfferLimits(c, "fred/prod.hosted-mysql",
+		"--max-connections", "2", "--max-relations", "4",
+		"--allowed-controllers", "deadbeef-1bad-500d-9000-4b1d0d06f00d,deadbeef-2bad-500d-9000-4b1d0d06f00d")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"SetOfferLimits", []interface{}{"fred/prod.hosted-mysql", jujucrossmodel.OfferLimits{
+			MaxConnections: 2,
+			MaxRelations:   4,
+			AllowedControllers: []string{
+				"deadbeef-1bad-500d-9000-4b1d0d06f00d",
+				"deadbeef-2bad-500d-9000-4b1d0d06f00d",
+			},
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *setOfferLimitsSuite) TestClearOfferLimitsCurrentModel(c *gc.C) {
+	_, err := s.runSetOfferLimits(c, "hosted-mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCall(c, 0, "SetOfferLimits", "fred/test.hosted-mysql", jujucrossmodel.OfferLimits{})
+}
+
+func (s *setOfferLimitsSuite) TestSetOfferLimitsEndpoint(c *gc.C) {
+	_, err := s.runSetOfferLimits(c, "fred/prod.hosted-mysql:db")
+	c.Assert(err, gc.ErrorMatches, `offer "fred/prod.hosted-mysql:db" shouldn't include endpoint`)
+}
+
+func (s *setOfferLimitsSuite) TestSetOfferLimitsNotSupported(c *gc.C) {
+	s.mockAPI.SetErrors(errors.NotSupportedf("SetOfferLimits for ApplicationOffers facade v3"))
+	_, err := s.runSetOfferLimits(c, "fred/prod.hosted-mysql", "--max-relations", "1")
+	c.Assert(err, gc.ErrorMatches, "set-offer-limits is not supported by this version of Juju")
+}
+
+type mockSetOfferLimitsAPI struct {
+	*testing.Stub
+}
+
+func (m *mockSetOfferLimitsAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
+
+func (m *mockSetOfferLimitsAPI) SetOfferLimits(url string, limits jujucrossmodel.OfferLimits) error {
+	m.MethodCall(m, "SetOfferLimits", url, limits)
+	return m.NextErr()
+}
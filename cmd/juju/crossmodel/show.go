@@ -38,6 +38,11 @@ the model 'default' on an accessible controller named 'controller':
 
     juju show-offer controller:default.prod
 
+To also show the health of the connections to the offer, as last
+recorded by the offering controller:
+
+    juju show-offer --diagnostics default.prod
+
 See also:
   find-offers
 `
@@ -45,8 +50,9 @@ See also:
 type showCommand struct {
 	RemoteEndpointsCommandBase
 
-	url        string
-	out        cmd.Output
+	url         string
+	diagnostics bool
+	out         cmd.Output
 	newAPIFunc func(string) (ShowAPI, error)
 }
 
@@ -82,6 +88,7 @@ func (c *showCommand) Info() *cmd.Info {
 // SetFlags implements Command.SetFlags.
 func (c *showCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.RemoteEndpointsCommandBase.SetFlags(f)
+	f.BoolVar(&c.diagnostics, "diagnostics", false, "Show the health of the connections to the offer")
 	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
 		"yaml":    cmd.FormatYaml,
 		"json":    cmd.FormatJson,
@@ -128,7 +135,7 @@ func (c *showCommand) Run(ctx *cmd.Context) (err error) {
 		return err
 	}
 
-	output, err := convertOffers(controllerName, names.NewUserTag(loggedInUser), found)
+	output, err := convertOffers(controllerName, names.NewUserTag(loggedInUser), c.diagnostics, found)
 	if err != nil {
 		return err
 	}
@@ -161,12 +168,27 @@ type ShowOfferedApplication struct {
 
 	// Users are the users who can access the offer.
 	Users map[string]OfferUser `yaml:"users,omitempty" json:"users,omitempty"`
+
+	// Connections holds the diagnostics of the connections to the offer.
+	// They are only included when requested.
+	Connections []OfferConnectionDiagnostics `yaml:"connections,omitempty" json:"connections,omitempty"`
+}
+
+// OfferConnectionDiagnostics defines the serialization behaviour of
+// the diagnostics for a connection to an application offer.
+type OfferConnectionDiagnostics struct {
+	SourceModelUUID string                        `yaml:"source-model-uuid" json:"source-model-uuid"`
+	Username        string                        `yaml:"username" json:"username"`
+	RelationId      int                           `yaml:"relation-id" json:"relation-id"`
+	Endpoint        string                        `yaml:"endpoint" json:"endpoint"`
+	Diagnostics     *RemoteApplicationDiagnostics `yaml:"diagnostics,omitempty" json:"diagnostics,omitempty"`
 }
 
 // convertOffers takes any number of api-formatted remote applications and
-// creates a collection of ui-formatted offers.
+// creates a collection of ui-formatted offers, optionally including the
+// diagnostics of their connections.
 func convertOffers(
-	store string, loggedInUser names.UserTag, offers ...*crossmodel.ApplicationOfferDetails,
+	store string, loggedInUser names.UserTag, diagnostics bool, offers ...*crossmodel.ApplicationOfferDetails,
 ) (map[string]ShowOfferedApplication, error) {
 	if len(offers) == 0 {
 		return nil, nil
@@ -182,6 +204,9 @@ func convertOffers(
 		if one.ApplicationDescription != "" {
 			app.Description = one.ApplicationDescription
 		}
+		if diagnostics {
+			app.Connections = convertConnectionDiagnostics(one.Connections...)
+		}
 		url, err := crossmodel.ParseOfferURL(one.OfferURL)
 		if err != nil {
 			return nil, err
@@ -194,6 +219,20 @@ func convertOffers(
 	return output, nil
 }
 
+func convertConnectionDiagnostics(connections ...crossmodel.OfferConnection) []OfferConnectionDiagnostics {
+	var output []OfferConnectionDiagnostics
+	for _, one := range connections {
+		output = append(output, OfferConnectionDiagnostics{
+			SourceModelUUID: one.SourceModelUUID,
+			Username:        one.Username,
+			RelationId:      one.RelationId,
+			Endpoint:        one.Endpoint,
+			Diagnostics:     ConvertDiagnostics(one.Diagnostics),
+		})
+	}
+	return output
+}
+
 func convertUsers(users ...crossmodel.OfferUserDetails) map[string]OfferUser {
 	if len(users) == 0 {
 		return nil
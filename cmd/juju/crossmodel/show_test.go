@@ -4,6 +4,8 @@
 package crossmodel_test
 
 import (
+	"time"
+
 	"github.com/juju/charm/v7"
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -139,6 +141,90 @@ test-master  fred/model.db2  consume  IBM DB2 Express Server Edition is an entry
 	)
 }
 
+func (s *showSuite) setDiagnostics() {
+	lastEvent := time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC)
+	expiry := lastEvent.Add(24 * time.Hour)
+	s.mockAPI.connections = []jujucrossmodel.OfferConnection{{
+		SourceModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		Username:        "mary",
+		RelationId:      2,
+		Endpoint:        "db2",
+		Diagnostics: &jujucrossmodel.RemoteApplicationDiagnostics{
+			LastRemoteEvent:  &lastEvent,
+			MacaroonExpiry:   &expiry,
+			SettingsSyncLag:  1500 * time.Millisecond,
+			IngressAddresses: []string{"10.0.0.1", "10.0.0.2"},
+			Updated:          lastEvent.Add(time.Minute),
+		},
+	}}
+}
+
+func (s *showSuite) TestShowDiagnosticsNotRequested(c *gc.C) {
+	s.setDiagnostics()
+	s.assertShowYaml(c, "fred/model.db2")
+}
+
+func (s *showSuite) TestShowDiagnosticsYaml(c *gc.C) {
+	s.setDiagnostics()
+	s.assertShow(
+		c,
+		[]string{"fred/model.db2", "--format", "yaml", "--diagnostics"},
+		`
+test-master:fred/model.db2:
+  description: IBM DB2 Express Server Edition is an entry level database system
+  access: consume
+  endpoints:
+    db2:
+      interface: http
+      role: requirer
+    log:
+      interface: http
+      role: provider
+  users:
+    bob:
+      display-name: Bob
+      access: consume
+  connections:
+  - source-model-uuid: deadbeef-0bad-400d-8000-4b1d0d06f00d
+    username: mary
+    relation-id: 2
+    endpoint: db2
+    diagnostics:
+      last-remote-event: 2020-06-01 10:00:00Z
+      macaroon-expiry: 2020-06-02 10:00:00Z
+      settings-sync-lag: 1.5s
+      ingress-addresses:
+      - 10.0.0.1
+      - 10.0.0.2
+      updated: 2020-06-01 10:01:00Z
+`[1:],
+	)
+}
+
+func (s *showSuite) TestShowDiagnosticsTabular(c *gc.C) {
+	s.setDiagnostics()
+	s.mockAPI.connections = append(s.mockAPI.connections, jujucrossmodel.OfferConnection{
+		SourceModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		Username:        "bob",
+		RelationId:      3,
+		Endpoint:        "log",
+	})
+	s.assertShow(
+		c,
+		[]string{"fred/model.db2", "--format", "tabular", "--diagnostics"},
+		`
+Store        URL             Access   Description                                 Endpoint  Interface  Role
+test-master  fred/model.db2  consume  IBM DB2 Express Server Edition is an entry  db2       http       requirer
+                                      level database system                       log       http       provider
+
+URL                         Relation id  Source model                          Last remote event     Macaroon expiry       Sync lag  Ingress addresses
+test-master:fred/model.db2  2            deadbeef-0bad-400d-8000-4b1d0d06f00d  2020-06-01 10:00:00Z  2020-06-02 10:00:00Z  1.5s      10.0.0.1,10.0.0.2
+test-master:fred/model.db2  3            deadbeef-0bad-400d-8000-4b1d0d06f00d  -                     -                     -         -
+
+`[1:],
+	)
+}
+
 func (s *showSuite) assertShow(c *gc.C, args []string, expected string) {
 	context, err := s.runShow(c, args...)
 	c.Assert(err, jc.ErrorIsNil)
@@ -155,6 +241,7 @@ func (s *showSuite) assertShowError(c *gc.C, args []string, expected string) {
 type mockShowAPI struct {
 	controllerName string
 	msg, desc      string
+	connections    []jujucrossmodel.OfferConnection
 }
 
 func (s mockShowAPI) Close() error {
@@ -181,5 +268,6 @@ func (s mockShowAPI) ApplicationOffer(url string) (*jujucrossmodel.ApplicationOf
 		Users: []jujucrossmodel.OfferUserDetails{{
 			UserName: "bob", DisplayName: "Bob", Access: "consume",
 		}},
+		Connections: s.connections,
 	}, nil
 }
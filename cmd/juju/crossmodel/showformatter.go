@@ -78,6 +78,42 @@ func formatOfferedEndpointsTabular(writer io.Writer, all map[string]ShowOfferedA
 		}
 	}
 	tw.Flush()
+	return formatConnectionDiagnosticsTabular(writer, all)
+}
+
+// formatConnectionDiagnosticsTabular returns a tabular summary of the
+// diagnostics of the connections to the offers, if there are any.
+func formatConnectionDiagnosticsTabular(writer io.Writer, all map[string]ShowOfferedApplication) error {
+	var urls []string
+	for urlStr, one := range all {
+		if len(one.Connections) > 0 {
+			urls = append(urls, urlStr)
+		}
+	}
+	if len(urls) == 0 {
+		return nil
+	}
+	sort.Strings(urls)
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println()
+	w.Println("URL", "Relation id", "Source model", "Last remote event", "Macaroon expiry", "Sync lag", "Ingress addresses")
+	for _, urlStr := range urls {
+		for _, conn := range all[urlStr].Connections {
+			diagnostics := conn.Diagnostics
+			if diagnostics == nil {
+				diagnostics = &RemoteApplicationDiagnostics{}
+			}
+			w.Println(urlStr, conn.RelationId, conn.SourceModelUUID,
+				valueOrDash(diagnostics.LastRemoteEvent),
+				valueOrDash(diagnostics.MacaroonExpiry),
+				valueOrDash(diagnostics.SettingsSyncLag),
+				valueOrDash(diagnostics.IngressAddresses...),
+			)
+		}
+	}
+	tw.Flush()
 	return nil
 }
 
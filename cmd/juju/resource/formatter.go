@@ -47,7 +47,7 @@ func (crf *charmResourcesFormatter) format() []FormattedCharmResource {
 func FormatCharmResource(res charmresource.Resource) FormattedCharmResource {
 	return FormattedCharmResource{
 		Name:        res.Name,
-		Type:        res.Type.String(),
+		Type:        resource.TypeName(res.Meta),
 		Path:        res.Path,
 		Description: res.Description,
 		Revision:    res.Revision,
@@ -64,7 +64,7 @@ func FormatAppResource(res resource.Resource) FormattedAppResource {
 		ID:               res.ID,
 		ApplicationID:    res.ApplicationID,
 		Name:             res.Name,
-		Type:             res.Type.String(),
+		Type:             resource.TypeName(res.Meta),
 		Path:             res.Path,
 		Description:      res.Description,
 		Origin:           res.Origin.String(),
@@ -42,6 +42,15 @@ func (s *CharmFormatterSuite) TestFormatCharmResource(c *gc.C) {
 	})
 }
 
+func (s *CharmFormatterSuite) TestFormatCharmResourceHelmChart(c *gc.C) {
+	res := charmRes(c, "chart", ".helm.tgz", "X", "chartdata")
+
+	formatted := resourcecmd.FormatCharmResource(res)
+
+	c.Check(formatted.Type, gc.Equals, "helm-chart")
+	c.Check(formatted.Path, gc.Equals, "chart.helm.tgz")
+}
+
 var _ = gc.Suite(&SvcFormatterSuite{})
 
 type SvcFormatterSuite struct {
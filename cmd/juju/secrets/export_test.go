@@ -0,0 +1,27 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"github.com/juju/cmd"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+func NewListSecretsCommandForTest(api ListSecretsAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &listSecretsCommand{newAPIFunc: func() (ListSecretsAPI, error) {
+		return api, nil
+	}}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewRotateSecretCommandForTest(api RotateSecretAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &rotateSecretCommand{newAPIFunc: func() (RotateSecretAPI, error) {
+		return api, nil
+	}}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
@@ -0,0 +1,188 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"io"
+	"sort"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/secrets"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+const listSecretsDoc = `
+Displays the secrets owned by applications in the model, along with
+their current revision and rotation schedule.
+
+Secret values are hidden unless --show-secrets is given, which requires
+admin access to the model.
+
+Examples:
+    juju secrets
+    juju secrets --format yaml
+    juju secrets --show-secrets --format yaml
+
+See also:
+    rotate-secret
+`
+
+// ListSecretsAPI defines the API methods that the secrets command uses.
+type ListSecretsAPI interface {
+	Close() error
+	ListSecrets(showSecrets bool) ([]params.ListSecretResult, error)
+}
+
+// NewListSecretsCommand returns a command to list secrets.
+func NewListSecretsCommand() cmd.Command {
+	c := &listSecretsCommand{}
+	c.newAPIFunc = func() (ListSecretsAPI, error) {
+		root, err := c.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return secrets.NewClient(root), nil
+	}
+	return modelcmd.Wrap(c)
+}
+
+type listSecretsCommand struct {
+	modelcmd.ModelCommandBase
+
+	out         cmd.Output
+	showSecrets bool
+	isoTime     bool
+
+	newAPIFunc func() (ListSecretsAPI, error)
+}
+
+// SecretDisplayDetails defines the serialization behaviour of a secret.
+type SecretDisplayDetails struct {
+	URI            string            `yaml:"uri" json:"uri"`
+	Owner          string            `yaml:"owner" json:"owner"`
+	Description    string            `yaml:"description,omitempty" json:"description,omitempty"`
+	Label          string            `yaml:"label,omitempty" json:"label,omitempty"`
+	Revision       int               `yaml:"revision" json:"revision"`
+	Expires        string            `yaml:"expires,omitempty" json:"expires,omitempty"`
+	RotatePolicy   string            `yaml:"rotate-policy" json:"rotate-policy"`
+	NextRotateTime string            `yaml:"next-rotate,omitempty" json:"next-rotate,omitempty"`
+	CreateTime     string            `yaml:"created" json:"created"`
+	UpdateTime     string            `yaml:"updated" json:"updated"`
+	Value          map[string]string `yaml:"value,omitempty" json:"value,omitempty"`
+	Error          string            `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+// Info implements cmd.Command.
+func (c *listSecretsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "secrets",
+		Purpose: "Lists secrets in the model.",
+		Doc:     listSecretsDoc,
+		Aliases: []string{"list-secrets"},
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *listSecretsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": c.formatTabular,
+	})
+	f.BoolVar(&c.showSecrets, "show-secrets", false, "Show secret values")
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+}
+
+// Init implements cmd.Command.
+func (c *listSecretsCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements cmd.Command.
+func (c *listSecretsCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	results, err := client.ListSecrets(c.showSecrets)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No secrets to display.")
+		return nil
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].OwnerTag != results[j].OwnerTag {
+			return results[i].OwnerTag < results[j].OwnerTag
+		}
+		return results[i].URI < results[j].URI
+	})
+
+	details := make([]SecretDisplayDetails, len(results))
+	for i, r := range results {
+		details[i] = SecretDisplayDetails{
+			URI:            r.URI,
+			Owner:          ownerName(r.OwnerTag),
+			Description:    r.Description,
+			Label:          r.Label,
+			Revision:       r.LatestRevision,
+			Expires:        formatOptionalTime(r.LatestExpireTime, c.isoTime),
+			RotatePolicy:   r.RotatePolicy,
+			NextRotateTime: formatOptionalTime(r.NextRotateTime, c.isoTime),
+			CreateTime:     common.FormatTime(&r.CreateTime, c.isoTime),
+			UpdateTime:     common.FormatTime(&r.UpdateTime, c.isoTime),
+		}
+		if r.Value != nil {
+			details[i].Value = r.Value.Data
+			if r.Value.Error != nil {
+				details[i].Error = r.Value.Error.Error()
+			}
+		}
+	}
+	return c.out.Write(ctx, details)
+}
+
+func formatOptionalTime(t *time.Time, formatISO bool) string {
+	if t == nil {
+		return ""
+	}
+	return common.FormatTime(t, formatISO)
+}
+
+// ownerName returns the name of the application owning a secret,
+// falling back to the raw tag if it cannot be parsed.
+func ownerName(ownerTag string) string {
+	tag, err := names.ParseTag(ownerTag)
+	if err != nil {
+		return ownerTag
+	}
+	return tag.Id()
+}
+
+func (c *listSecretsCommand) formatTabular(writer io.Writer, value interface{}) error {
+	secrets, ok := value.([]SecretDisplayDetails)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", secrets, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("URI", "Owner", "Revision", "Rotate", "Next rotation", "Label", "Updated")
+	for _, s := range secrets {
+		w.Println(s.URI, s.Owner, s.Revision, s.RotatePolicy, s.NextRotateTime, s.Label, s.UpdateTime)
+	}
+	return tw.Flush()
+}
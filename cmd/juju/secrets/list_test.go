@@ -0,0 +1,133 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/secrets"
+	"github.com/juju/juju/jujuclient"
+	jujutesting "github.com/juju/juju/testing"
+)
+
+const secretURI = "secret:7fa6cc1c-3f6b-4a04-8b27-9f3a1b9b5e21"
+
+type baseSecretsSuite struct {
+	jujutesting.FakeJujuXDGDataHomeSuite
+	store *jujuclient.MemStore
+}
+
+func (s *baseSecretsSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Models["testing"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"admin/controller": {},
+		},
+		CurrentModel: "admin/controller",
+	}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+}
+
+type ListSecretsSuite struct {
+	baseSecretsSuite
+	api *mockListSecretsAPI
+}
+
+var _ = gc.Suite(&ListSecretsSuite{})
+
+func (s *ListSecretsSuite) SetUpTest(c *gc.C) {
+	s.baseSecretsSuite.SetUpTest(c)
+
+	created := time.Date(2020, 7, 1, 10, 0, 0, 0, time.UTC)
+	next := created.Add(24 * time.Hour)
+	s.api = &mockListSecretsAPI{
+		results: []params.ListSecretResult{{
+			URI:            secretURI,
+			OwnerTag:       "application-mysql",
+			Label:          "root-password",
+			LatestRevision: 2,
+			RotatePolicy:   "daily",
+			NextRotateTime: &next,
+			CreateTime:     created,
+			UpdateTime:     created.Add(time.Hour),
+		}},
+	}
+}
+
+func (s *ListSecretsSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, secrets.NewListSecretsCommandForTest(s.api, s.store), args...)
+}
+
+func (s *ListSecretsSuite) TestInitErrors(c *gc.C) {
+	_, err := s.run(c, "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *ListSecretsSuite) TestListTabular(c *gc.C) {
+	ctx, err := s.run(c, "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "ListSecrets", false)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+URI                                          Owner  Revision  Rotate  Next rotation         Label          Updated
+secret:7fa6cc1c-3f6b-4a04-8b27-9f3a1b9b5e21  mysql  2         daily   2020-07-02 10:00:00Z  root-password  2020-07-01 11:00:00Z
+
+`[1:])
+}
+
+func (s *ListSecretsSuite) TestListYAMLWithValues(c *gc.C) {
+	s.api.results[0].RotatePolicy = "never"
+	s.api.results[0].NextRotateTime = nil
+	s.api.results[0].Value = &params.SecretValueResult{
+		Data: map[string]string{"password": "c2VjcmV0"},
+	}
+	ctx, err := s.run(c, "--show-secrets", "--format", "yaml", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "ListSecrets", true)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- uri: secret:7fa6cc1c-3f6b-4a04-8b27-9f3a1b9b5e21
+  owner: mysql
+  label: root-password
+  revision: 2
+  rotate-policy: never
+  created: 2020-07-01 10:00:00Z
+  updated: 2020-07-01 11:00:00Z
+  value:
+    password: c2VjcmV0
+`[1:])
+}
+
+func (s *ListSecretsSuite) TestListNone(c *gc.C) {
+	s.api.results = nil
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No secrets to display.\n")
+}
+
+type mockListSecretsAPI struct {
+	testing.Stub
+	results []params.ListSecretResult
+}
+
+func (m *mockListSecretsAPI) Close() error {
+	return nil
+}
+
+func (m *mockListSecretsAPI) ListSecrets(showSecrets bool) ([]params.ListSecretResult, error) {
+	m.MethodCall(m, "ListSecrets", showSecrets)
+	return m.results, m.NextErr()
+}
@@ -0,0 +1,87 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/secrets"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	coresecrets "github.com/juju/juju/core/secrets"
+)
+
+const rotateSecretDoc = `
+Asks the application owning a secret to rotate it now, regardless of its
+rotate policy. The secret-rotate hook is run on the application's leader
+unit, which is expected to update the secret with a new value.
+
+Examples:
+    juju rotate-secret secret:7fa6cc1c-3f6b-4a04-8b27-9f3a1b9b5e21
+
+See also:
+    secrets
+`
+
+// RotateSecretAPI defines the API methods that the rotate-secret
+// command uses.
+type RotateSecretAPI interface {
+	Close() error
+	RotateSecret(uri string) error
+}
+
+// NewRotateSecretCommand returns a command to rotate a secret.
+func NewRotateSecretCommand() cmd.Command {
+	c := &rotateSecretCommand{}
+	c.newAPIFunc = func() (RotateSecretAPI, error) {
+		root, err := c.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return secrets.NewClient(root), nil
+	}
+	return modelcmd.Wrap(c)
+}
+
+type rotateSecretCommand struct {
+	modelcmd.ModelCommandBase
+
+	uri string
+
+	newAPIFunc func() (RotateSecretAPI, error)
+}
+
+// Info implements cmd.Command.
+func (c *rotateSecretCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "rotate-secret",
+		Args:    "<secret URI>",
+		Purpose: "Asks the owner of a secret to rotate it.",
+		Doc:     rotateSecretDoc,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *rotateSecretCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("a secret URI must be supplied")
+	}
+	c.uri, args = args[0], args[1:]
+	if _, err := coresecrets.ParseURI(c.uri); err != nil {
+		return errors.Trace(err)
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements cmd.Command.
+func (c *rotateSecretCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	return errors.Trace(client.RotateSecret(c.uri))
+}
@@ -0,0 +1,80 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/secrets"
+)
+
+type RotateSecretSuite struct {
+	baseSecretsSuite
+	api *mockRotateSecretAPI
+}
+
+var _ = gc.Suite(&RotateSecretSuite{})
+
+func (s *RotateSecretSuite) SetUpTest(c *gc.C) {
+	s.baseSecretsSuite.SetUpTest(c)
+	s.api = &mockRotateSecretAPI{}
+}
+
+func (s *RotateSecretSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, secrets.NewRotateSecretCommandForTest(s.api, s.store), args...)
+}
+
+func (s *RotateSecretSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "a secret URI must be supplied",
+	}, {
+		args: []string{"foo"},
+		err:  `secret URI "foo" not valid`,
+	}, {
+		args: []string{secretURI, "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.run(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+func (s *RotateSecretSuite) TestRotate(c *gc.C) {
+	_, err := s.run(c, secretURI)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"RotateSecret", []interface{}{secretURI}},
+		{"Close", nil},
+	})
+}
+
+func (s *RotateSecretSuite) TestRotateError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := s.run(c, secretURI)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockRotateSecretAPI struct {
+	testing.Stub
+}
+
+func (m *mockRotateSecretAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
+
+func (m *mockRotateSecretAPI) RotateSecret(uri string) error {
+	m.MethodCall(m, "RotateSecret", uri)
+	return m.NextErr()
+}
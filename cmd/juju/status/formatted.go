@@ -48,7 +48,12 @@ type modelStatus struct {
 }
 
 type controllerStatus struct {
-	Timestamp string `json:"timestamp,omitempty" yaml:"timestamp,omitempty"`
+	Timestamp   string             `json:"timestamp,omitempty" yaml:"timestamp,omitempty"`
+	Maintenance *maintenanceStatus `json:"maintenance,omitempty" yaml:"maintenance,omitempty"`
+}
+
+type maintenanceStatus struct {
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
 }
 
 type networkInterface struct {
@@ -230,6 +235,15 @@ type unitStatus struct {
 	ProviderId    string                `json:"provider-id,omitempty" yaml:"provider-id,omitempty"`
 	Subordinates  map[string]unitStatus `json:"subordinates,omitempty" yaml:"subordinates,omitempty"`
 	Branch        string                `json:"branch,omitempty" yaml:"branch,omitempty"`
+
+	Health       status.HealthStatus          `json:"health,omitempty" yaml:"health,omitempty"`
+	HealthChecks map[string]healthCheckStatus `json:"health-checks,omitempty" yaml:"health-checks,omitempty"`
+}
+
+type healthCheckStatus struct {
+	Status  status.HealthStatus `json:"status" yaml:"status"`
+	Message string              `json:"message,omitempty" yaml:"message,omitempty"`
+	Since   string              `json:"since,omitempty" yaml:"since,omitempty"`
 }
 
 func (s *formattedStatus) applicationScale(name string) (string, bool) {
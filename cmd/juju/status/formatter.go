@@ -29,6 +29,7 @@ type statusFormatter struct {
 	relations              map[int]params.RelationStatus
 	storage                *storage.CombinedStorage
 	isoTime, showRelations bool
+	showHealth             bool
 
 	// Ideally this map should not be here.  It is used to facilitate
 	// getting an active branch ref number for a subordinate unit.
@@ -45,6 +46,7 @@ func NewStatusFormatter(status *params.FullStatus, isoTime bool) *statusFormatte
 			status:        status,
 			isoTime:       isoTime,
 			showRelations: true,
+			showHealth:    true,
 		})
 }
 
@@ -55,6 +57,7 @@ type newStatusFormatterParams struct {
 	outputName             string
 	activeBranch           string
 	isoTime, showRelations bool
+	showHealth             bool
 }
 
 func newStatusFormatter(p newStatusFormatterParams) *statusFormatter {
@@ -65,6 +68,7 @@ func newStatusFormatter(p newStatusFormatterParams) *statusFormatter {
 		relations:      make(map[int]params.RelationStatus),
 		isoTime:        p.isoTime,
 		showRelations:  p.showRelations,
+		showHealth:     p.showHealth,
 		outputName:     p.outputName,
 		activeBranch:   p.activeBranch,
 	}
@@ -114,6 +118,14 @@ func (sf *statusFormatter) format() (formattedStatus, error) {
 			Timestamp: common.FormatTimeAsTimestamp(sf.status.ControllerTimestamp, sf.isoTime),
 		}
 	}
+	if sf.status.ControllerMaintenance {
+		if out.Controller == nil {
+			out.Controller = &controllerStatus{}
+		}
+		out.Controller.Maintenance = &maintenanceStatus{
+			Message: sf.status.ControllerMaintenanceMessage,
+		}
+	}
 	for k, m := range sf.status.Machines {
 		out.Machines[k] = sf.formatMachine(m)
 	}
@@ -437,6 +449,10 @@ func (sf *statusFormatter) formatUnit(info unitFormatInfo) unitStatus {
 		}
 	}
 
+	if sf.showHealth && len(info.unit.HealthChecks) > 0 {
+		out.Health, out.HealthChecks = sf.formatHealthChecks(info.unit.HealthChecks)
+	}
+
 	for k, m := range info.unit.Subordinates {
 		out.Subordinates[k] = sf.formatUnit(unitFormatInfo{
 			unit:            m,
@@ -449,6 +465,26 @@ func (sf *statusFormatter) formatUnit(info unitFormatInfo) unitStatus {
 	return out
 }
 
+func (sf *statusFormatter) formatHealthChecks(in []params.HealthCheck) (status.HealthStatus, map[string]healthCheckStatus) {
+	checks := make([]status.HealthCheck, len(in))
+	out := make(map[string]healthCheckStatus)
+	for i, check := range in {
+		checks[i] = status.HealthCheck{
+			Name:   check.Name,
+			Status: status.HealthStatus(check.Status),
+		}
+		result := healthCheckStatus{
+			Status:  status.HealthStatus(check.Status),
+			Message: check.Message,
+		}
+		if check.Since != nil {
+			result.Since = common.FormatTime(check.Since, sf.isoTime)
+		}
+		out[check.Name] = result
+	}
+	return status.AggregateHealth(checks), out
+}
+
 func (sf *statusFormatter) getStatusInfoContents(inst params.DetailedStatus) statusInfoContents {
 	// TODO(perrito66) add status validation.
 	info := statusInfoContents{
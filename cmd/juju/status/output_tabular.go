@@ -41,6 +41,16 @@ func FormatTabular(writer io.Writer, forceColor bool, value interface{}) error {
 		return errors.Errorf("expected value of type %T, got %T", fs, value)
 	}
 
+	// A controller in maintenance mode rejects changes, so say so
+	// before anything else.
+	if cs := fs.Controller; cs != nil && cs.Maintenance != nil {
+		banner := "Controller in maintenance mode, changes are not allowed"
+		if cs.Maintenance.Message != "" {
+			banner += ": " + cs.Maintenance.Message
+		}
+		fmt.Fprintf(writer, "%s\n\n", banner)
+	}
+
 	// To format things into columns.
 	tw := output.TabWriter(writer)
 	if forceColor {
@@ -85,6 +95,7 @@ func FormatTabular(writer io.Writer, forceColor bool, value interface{}) error {
 
 	if len(fs.Applications) > 0 {
 		printApplications(tw, fs)
+		printHealthChecks(tw, fs)
 	}
 
 	if fs.Model.Type != caasModelType && len(fs.Machines) > 0 {
@@ -264,6 +275,56 @@ func printApplications(tw *ansiterm.TabWriter, fs formattedStatus) {
 	endSection(tw)
 }
 
+// printHealthChecks prints the workload health checks reported by
+// each unit, with the unit's overall health on its first row.
+func printHealthChecks(tw *ansiterm.TabWriter, fs formattedStatus) {
+	units := make(map[string]unitStatus)
+	collect := func(name string, u unitStatus, _ int) {
+		if len(u.HealthChecks) > 0 {
+			units[name] = u
+		}
+	}
+	for _, app := range fs.Applications {
+		for name, u := range app.Units {
+			collect(name, u, 0)
+			recurseUnits(u, 0, collect)
+		}
+	}
+	if len(units) == 0 {
+		return
+	}
+
+	w := startSection(tw, false, "Unit", "Health", "Check", "Status", "Since", "Message")
+	for _, unitName := range naturalsort.Sort(stringKeysFromMap(units)) {
+		u := units[unitName]
+		for i, checkName := range naturalsort.Sort(stringKeysFromMap(u.HealthChecks)) {
+			check := u.HealthChecks[checkName]
+			if i == 0 {
+				w.Print(unitName)
+				w.PrintColor(healthColor(u.Health), u.Health)
+			} else {
+				w.Print("", "")
+			}
+			w.Print(checkName)
+			w.PrintColor(healthColor(check.Status), check.Status)
+			w.Println(check.Since, check.Message)
+		}
+	}
+	endSection(tw)
+}
+
+func healthColor(health status.HealthStatus) *ansiterm.Context {
+	switch health {
+	case status.HealthPass:
+		return output.GoodHighlight
+	case status.HealthWarn:
+		return output.WarningHighlight
+	case status.HealthFail:
+		return output.ErrorHighlight
+	}
+	return nil
+}
+
 func printBranches(tw *ansiterm.TabWriter, branches map[string]branchStatus) {
 	w := startSection(tw, false, "Branch", "Ref", "Created", "Created By")
 	for _, branchName := range naturalsort.Sort(stringKeysFromMap(branches)) {
@@ -453,6 +514,11 @@ func FormatMachineTabular(writer io.Writer, forceColor bool, value interface{})
 // the agent is currently executing.
 // The hook name or action is extracted from the agent message.
 func agentDoing(agentStatus statusInfoContents) string {
+	// An idle agent only has a message when it has hooks deferred by
+	// the application's maintenance window.
+	if agentStatus.Current == status.Idle {
+		return agentStatus.Message
+	}
 	if agentStatus.Current != status.Executing {
 		return ""
 	}
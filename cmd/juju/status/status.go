@@ -68,6 +68,9 @@ type statusCommand struct {
 
 	// storage indicates if 'storage' section is displayed
 	storage bool
+
+	// health indicates if 'health' section is displayed
+	health bool
 }
 
 var usageSummary = `
@@ -98,8 +101,8 @@ The '--format' option allows you to specify how the status report is formatted.
   --format=tabular  (default)
                     Display information about all aspects of the model in a 
                     human-centric manner. Omits some information by default.
-                    Use the '--relations' and '--storage' options to include
-                    all available information.
+                    Use the '--relations', '--storage' and '--health' options
+                    to include all available information.
 
   --format=line
   --format=short
@@ -131,6 +134,9 @@ Examples:
     # Include information about storage and relations in output
     juju status --storage --relations
 
+    # Include the workload health checks reported by each unit
+    juju status --health
+
     # Provide output as valid JSON
     juju status --format=json
 
@@ -164,6 +170,7 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.color, "color", false, "Use ANSI color codes in tabular output")
 	f.BoolVar(&c.relations, "relations", false, "Show 'relations' section in tabular output")
 	f.BoolVar(&c.storage, "storage", false, "Show 'storage' section in tabular output")
+	f.BoolVar(&c.health, "health", false, "Show 'health' section in tabular output")
 
 	f.IntVar(&c.retryCount, "retry-count", 3, "Number of times to retry API failures")
 	f.DurationVar(&c.retryDelay, "retry-delay", 100*time.Millisecond, "Time to wait between retry attempts")
@@ -172,6 +179,7 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 		ignoredFlagForNonTabularFormat := set.NewStrings(
 			"relations",
 			"storage",
+			"health",
 		)
 		provided := set.NewStrings()
 		f.Visit(func(flag *gnuflag.Flag) {
@@ -313,9 +321,11 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 
 	showRelations := c.relations
 	showStorage := c.storage
+	showHealth := c.health
 	if c.out.Name() != "tabular" {
 		showRelations = true
 		showStorage = true
+		showHealth = true
 		providedIgnoredFlags := c.checkProvidedIgnoredFlagF()
 		if !providedIgnoredFlags.IsEmpty() {
 			// For non-tabular formats this is redundant and needs to be mentioned to the user.
@@ -334,6 +344,7 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 		outputName:     c.out.Name(),
 		isoTime:        c.isoTime,
 		showRelations:  showRelations,
+		showHealth:     showHealth,
 		activeBranch:   activeBranch,
 	}
 	if showStorage {
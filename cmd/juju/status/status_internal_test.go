@@ -5112,6 +5112,50 @@ foo/1  maintenance  executing                                  (backup database)
 `[1:])
 }
 
+func (s *StatusSuite) TestFormatTabularDeferredHooks(c *gc.C) {
+	status := formattedStatus{
+		Applications: map[string]applicationStatus{
+			"foo": {
+				Units: map[string]unitStatus{
+					"foo/0": {
+						JujuStatusInfo: statusInfoContents{
+							Current: status.Idle,
+							Message: "deferred: 2 hooks until 03:00",
+						},
+						WorkloadStatusInfo: statusInfoContents{
+							Current: status.Active,
+							Message: "ready",
+						},
+					},
+					"foo/1": {
+						JujuStatusInfo: statusInfoContents{
+							Current: status.Idle,
+						},
+						WorkloadStatusInfo: statusInfoContents{
+							Current: status.Active,
+							Message: "ready",
+						},
+					},
+				},
+			},
+		},
+	}
+	out := &bytes.Buffer{}
+	err := FormatTabular(out, false, status)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.String(), gc.Equals, `
+Model  Controller  Cloud/Region  Version
+                                 
+
+App  Version  Status  Scale  Charm  Store  Rev  OS  Notes
+foo                       2                  0      
+
+Unit   Workload  Agent  Machine  Public address  Ports  Message
+foo/0  active    idle                                   (deferred: 2 hooks until 03:00) ready
+foo/1  active    idle                                   ready
+`[1:])
+}
+
 func (s *StatusSuite) TestFormatTabularCAASModel(c *gc.C) {
 	status := formattedStatus{
 		Model: modelStatus{
@@ -5309,6 +5353,83 @@ foo/1   up            things are looking up
 `[1:])
 }
 
+func (s *StatusSuite) TestFormatTabularHealthChecks(c *gc.C) {
+	fs := formattedStatus{
+		Applications: map[string]applicationStatus{
+			"foo": {
+				Units: map[string]unitStatus{
+					"foo/0": {
+						Health: status.HealthWarn,
+						HealthChecks: map[string]healthCheckStatus{
+							"db":          {Status: status.HealthPass, Since: "01 Apr 15 01:23+10:00"},
+							"replication": {Status: status.HealthWarn, Message: "lagging by 30s", Since: "01 Apr 15 01:24+10:00"},
+						},
+						Subordinates: map[string]unitStatus{
+							"logging/0": {
+								Health: status.HealthFail,
+								HealthChecks: map[string]healthCheckStatus{
+									"disk": {Status: status.HealthFail, Message: "disk full", Since: "01 Apr 15 01:25+10:00"},
+								},
+							},
+						},
+					},
+					"foo/1": {},
+				},
+			},
+		},
+	}
+	out := &bytes.Buffer{}
+	err := FormatTabular(out, false, fs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.String(), gc.Equals, `
+Model  Controller  Cloud/Region  Version
+                                 
+
+App  Version  Status  Scale  Charm  Store  Rev  OS  Notes
+foo                     0/2                  0      
+
+Unit         Workload  Agent  Machine  Public address  Ports  Message
+foo/0                                                         
+  logging/0                                                   
+foo/1                                                         
+
+Unit       Health  Check        Status  Since                  Message
+foo/0      warn    db           pass    01 Apr 15 01:23+10:00  
+                   replication  warn    01 Apr 15 01:24+10:00  lagging by 30s
+logging/0  fail    disk         fail    01 Apr 15 01:25+10:00  disk full
+`[1:])
+}
+
+func (s *StatusSuite) TestFormatUnitHealthChecks(c *gc.C) {
+	since := time.Date(2015, 4, 1, 1, 23, 0, 0, time.UTC)
+	unit := params.UnitStatus{
+		HealthChecks: []params.HealthCheck{
+			{Name: "db", Status: "pass", Since: &since},
+			{Name: "replication", Status: "warn", Message: "lagging by 30s", Since: &since},
+		},
+	}
+
+	sf := newStatusFormatter(newStatusFormatterParams{
+		status:  &params.FullStatus{},
+		isoTime: true,
+	})
+	out := sf.formatUnit(unitFormatInfo{unit: unit, unitName: "foo/0", applicationName: "foo"})
+	c.Assert(out.Health, gc.Equals, status.HealthStatus(""))
+	c.Assert(out.HealthChecks, gc.IsNil)
+
+	sf = newStatusFormatter(newStatusFormatterParams{
+		status:     &params.FullStatus{},
+		isoTime:    true,
+		showHealth: true,
+	})
+	out = sf.formatUnit(unitFormatInfo{unit: unit, unitName: "foo/0", applicationName: "foo"})
+	c.Assert(out.Health, gc.Equals, status.HealthWarn)
+	c.Assert(out.HealthChecks, jc.DeepEquals, map[string]healthCheckStatus{
+		"db":          {Status: status.HealthPass, Since: "2015-04-01 01:23:00Z"},
+		"replication": {Status: status.HealthWarn, Message: "lagging by 30s", Since: "2015-04-01 01:23:00Z"},
+	})
+}
+
 //
 // Filtering Feature
 //
@@ -5974,6 +6095,43 @@ func (s *StatusSuite) TestControllerTimestampInFullStatus(c *gc.C) {
 	})
 }
 
+func (s *StatusSuite) TestControllerMaintenanceInFullStatus(c *gc.C) {
+	status := &params.FullStatus{
+		Model: params.ModelStatusInfo{
+			CloudTag: "cloud-dummy",
+		},
+		ControllerMaintenance:        true,
+		ControllerMaintenanceMessage: "storage migration",
+	}
+	formatter := NewStatusFormatter(status, true)
+	formatted, err := formatter.format()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(formatted.Controller, jc.DeepEquals, &controllerStatus{
+		Maintenance: &maintenanceStatus{Message: "storage migration"},
+	})
+}
+
+func (s *StatusSuite) TestFormatTabularControllerMaintenance(c *gc.C) {
+	status := formattedStatus{
+		Model: modelStatus{
+			Name:       "default",
+			Controller: "kontroll",
+		},
+		Controller: &controllerStatus{
+			Maintenance: &maintenanceStatus{Message: "storage migration"},
+		},
+	}
+	out := &bytes.Buffer{}
+	err := FormatTabular(out, false, status)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.String(), gc.Equals, `
+Controller in maintenance mode, changes are not allowed: storage migration
+
+Model    Controller  Cloud/Region  Version
+default  kontroll                  
+`[1:])
+}
+
 func (s *StatusSuite) TestTabularNoRelations(c *gc.C) {
 	ctx := s.FilteringTestSetup(c)
 	defer s.resetContext(c, ctx)
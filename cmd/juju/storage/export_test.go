@@ -23,6 +23,14 @@ func NewPoolListCommandForTest(api PoolListAPI, store jujuclient.ClientStore) cm
 	return modelcmd.Wrap(cmd)
 }
 
+func NewPoolShowCommandForTest(api PoolShowAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &poolShowCommand{newAPIFunc: func() (PoolShowAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 func NewPoolCreateCommandForTest(api PoolCreateAPI, store jujuclient.ClientStore) cmd.Command {
 	cmd := &poolCreateCommand{newAPIFunc: func() (PoolCreateAPI, error) {
 		return api, nil
@@ -91,3 +99,10 @@ func NewDetachStorageCommandForTest(new NewEntityDetacherCloserFunc, store jujuc
 	cmd.newEntityDetacherCloser = new
 	return modelcmd.Wrap(cmd)
 }
+
+func NewResizeStorageCommandForTest(new NewStorageResizerCloserFunc, store jujuclient.ClientStore) cmd.Command {
+	cmd := &resizeStorageCommand{}
+	cmd.SetClientStore(store)
+	cmd.newStorageResizerCloser = new
+	return modelcmd.Wrap(cmd)
+}
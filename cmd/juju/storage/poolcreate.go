@@ -45,14 +45,22 @@ configuration as space-separated pairs, e.g. tags, size, path, etc.
 For Kubernetes models, the provider type defaults to "kubernetes"
 unless otherwise specified.
 
+The allocation of storage from a pool may be limited with the max-size
+attribute, the total size of the storage allocated from the pool, and
+the max-volumes attribute, the number of volumes and filesystems
+allocated from the pool. Storage that would exceed the limits is not
+provisioned.
+
 Examples:
 
     juju create-storage-pool ebsrotary ebs volume-type=standard
+    juju create-storage-pool ceph-limited ceph max-size=500G max-volumes=20
     juju create-storage-pool gcepd storage-provisioner=kubernetes.io/gce-pd [storage-mode=RWX|RWO|ROX] parameters.type=pd-standard
 
 See also:
     remove-storage-pool
     update-storage-pool
+    show-storage-pool
     storage-pools
 `
 
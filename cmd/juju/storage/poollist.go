@@ -5,6 +5,7 @@ package storage
 
 import (
 	"github.com/juju/cmd"
+	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 
 	"github.com/juju/juju/apiserver/params"
@@ -19,8 +20,26 @@ type PoolCommandBase struct {
 
 // PoolInfo defines the serialization behaviour of the storage pool information.
 type PoolInfo struct {
-	Provider string                 `yaml:"provider" json:"provider"`
-	Attrs    map[string]interface{} `yaml:"attrs,omitempty" json:"attrs,omitempty"`
+	Provider  string                 `yaml:"provider" json:"provider"`
+	Attrs     map[string]interface{} `yaml:"attrs,omitempty" json:"attrs,omitempty"`
+	Allocated *PoolUsageInfo         `yaml:"allocated,omitempty" json:"allocated,omitempty"`
+}
+
+// PoolUsageInfo defines the serialization behaviour of the storage
+// allocated from a storage pool. Sizes are in MiB.
+type PoolUsageInfo struct {
+	Count        int                           `yaml:"count" json:"count"`
+	Size         uint64                        `yaml:"size" json:"size"`
+	MaxVolumes   int                           `yaml:"max-volumes,omitempty" json:"max-volumes,omitempty"`
+	MaxSize      uint64                        `yaml:"max-size,omitempty" json:"max-size,omitempty"`
+	Applications map[string]PoolAllocationInfo `yaml:"applications,omitempty" json:"applications,omitempty"`
+}
+
+// PoolAllocationInfo defines the serialization behaviour of the storage
+// allocated from a storage pool to an application. Sizes are in MiB.
+type PoolAllocationInfo struct {
+	Count int    `yaml:"count" json:"count"`
+	Size  uint64 `yaml:"size" json:"size"`
 }
 
 func formatPoolInfo(all []params.StoragePool) map[string]PoolInfo {
@@ -34,6 +53,39 @@ func formatPoolInfo(all []params.StoragePool) map[string]PoolInfo {
 	return output
 }
 
+// addPoolUsage records the usage reported for each pool in the
+// formatted pool information. Per-application allocations are
+// included only if withApplications is true.
+func addPoolUsage(pools map[string]PoolInfo, usage []params.StoragePoolUsageResult, withApplications bool) error {
+	for _, result := range usage {
+		if result.Error != nil {
+			return errors.Trace(result.Error)
+		}
+		pool, ok := pools[result.Result.Name]
+		if !ok {
+			continue
+		}
+		info := &PoolUsageInfo{
+			Count:      result.Result.Total.Count,
+			Size:       result.Result.Total.Size,
+			MaxVolumes: result.Result.MaxVolumes,
+			MaxSize:    result.Result.MaxSize,
+		}
+		if withApplications && len(result.Result.Applications) > 0 {
+			info.Applications = make(map[string]PoolAllocationInfo)
+			for application, allocation := range result.Result.Applications {
+				info.Applications[application] = PoolAllocationInfo{
+					Count: allocation.Count,
+					Size:  allocation.Size,
+				}
+			}
+		}
+		pool.Allocated = info
+		pools[result.Result.Name] = pool
+	}
+	return nil
+}
+
 const poolListCommandDoc = `
 The user can filter on pool type, name.
 
@@ -109,6 +161,20 @@ func (c *poolListCommand) Run(ctx *cmd.Context) (err error) {
 		return nil
 	}
 	output := formatPoolInfo(result)
+	poolNames := make([]string, len(result))
+	for i, pool := range result {
+		poolNames[i] = pool.Name
+	}
+	usage, err := api.PoolUsage(poolNames)
+	if errors.IsNotSupported(err) {
+		// Older controllers do not report pool usage.
+		return c.out.Write(ctx, output)
+	} else if err != nil {
+		return err
+	}
+	if err := addPoolUsage(output, usage, false); err != nil {
+		return err
+	}
 	return c.out.Write(ctx, output)
 }
 
@@ -116,4 +182,5 @@ func (c *poolListCommand) Run(ctx *cmd.Context) (err error) {
 type PoolListAPI interface {
 	Close() error
 	ListPools(providers, names []string) ([]params.StoragePool, error)
+	PoolUsage(names []string) ([]params.StoragePoolUsageResult, error)
 }
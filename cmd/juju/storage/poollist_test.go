@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	goyaml "gopkg.in/yaml.v2"
@@ -110,6 +111,50 @@ xyz   testType  a=true b=maybe c=well
 `[1:])
 }
 
+func (s *poolListSuite) TestPoolListTabularWithUsage(c *gc.C) {
+	s.mockAPI.attrs = map[string]interface{}{"max-volumes": "5", "max-size": "10G"}
+	s.mockAPI.usage = map[string]params.StoragePoolUsage{
+		"abc": {
+			Name:       "abc",
+			Total:      params.StorageAllocation{Count: 2, Size: 3072},
+			MaxVolumes: 5,
+			MaxSize:    10240,
+		},
+	}
+	s.assertValidList(
+		c,
+		[]string{"--name", "xyz", "--name", "abc", "--format", "tabular"},
+		`
+Name  Provider  Allocated           Attrs
+abc   testType  2/5 (3.0GiB/10GiB)  max-size=10G max-volumes=5
+xyz   testType  0 (0B)              max-size=10G max-volumes=5
+
+`[1:])
+}
+
+func (s *poolListSuite) TestPoolListYAMLWithUsage(c *gc.C) {
+	s.mockAPI.usage = map[string]params.StoragePoolUsage{
+		"abc": {
+			Name:  "abc",
+			Total: params.StorageAllocation{Count: 2, Size: 3072},
+			Applications: map[string]params.StorageAllocation{
+				"mysql": {Count: 2, Size: 3072},
+			},
+		},
+	}
+	s.mockAPI.attrs = nil
+	s.assertValidList(
+		c,
+		[]string{"--name", "abc", "--format", "yaml"},
+		`
+abc:
+  provider: testType
+  allocated:
+    count: 2
+    size: 3072
+`[1:])
+}
+
 type unmarshaller func(in []byte, out interface{}) (err error)
 
 func (s *poolListSuite) assertUnmarshalledOutput(c *gc.C, unmarshall unmarshaller, args ...string) {
@@ -155,7 +200,7 @@ func (s poolListSuite) expect(c *gc.C, types, names []string) map[string]storage
 	c.Assert(err, jc.ErrorIsNil)
 	result := make(map[string]storage.PoolInfo, len(all))
 	for _, one := range all {
-		result[one.Name] = storage.PoolInfo{one.Provider, one.Attrs}
+		result[one.Name] = storage.PoolInfo{Provider: one.Provider, Attrs: one.Attrs}
 	}
 	return result
 }
@@ -170,6 +215,7 @@ func (s *poolListSuite) assertValidList(c *gc.C, args []string, expected string)
 
 type mockPoolListAPI struct {
 	attrs map[string]interface{}
+	usage map[string]params.StoragePoolUsage
 }
 
 func (s mockPoolListAPI) Close() error {
@@ -192,6 +238,21 @@ func (s mockPoolListAPI) ListPools(types []string, names []string) ([]params.Sto
 	return results, nil
 }
 
+func (s mockPoolListAPI) PoolUsage(names []string) ([]params.StoragePoolUsageResult, error) {
+	if s.usage == nil {
+		return nil, errors.NotSupportedf("storage pool usage")
+	}
+	results := make([]params.StoragePoolUsageResult, len(names))
+	for i, name := range names {
+		usage, ok := s.usage[name]
+		if !ok {
+			usage = params.StoragePoolUsage{Name: name}
+		}
+		results[i].Result = &usage
+	}
+	return results, nil
+}
+
 func (s mockPoolListAPI) createTestPoolInstance(aname, atype string) params.StoragePool {
 	return params.StoragePool{
 		Name:     aname,
@@ -9,6 +9,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/dustin/go-humanize"
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/cmd/output"
@@ -32,13 +33,20 @@ func formatPoolsTabular(writer io.Writer, pools map[string]PoolInfo) {
 		fmt.Fprintln(tw, strings.Join(values, "\t"))
 	}
 
-	print("Name", "Provider", "Attrs")
-
+	// Usage is only shown if the controller reports it.
+	var withUsage bool
 	poolNames := make([]string, 0, len(pools))
-	for name := range pools {
+	for name, pool := range pools {
 		poolNames = append(poolNames, name)
+		withUsage = withUsage || pool.Allocated != nil
 	}
 	sort.Strings(poolNames)
+
+	if withUsage {
+		print("Name", "Provider", "Allocated", "Attrs")
+	} else {
+		print("Name", "Provider", "Attrs")
+	}
 	for _, name := range poolNames {
 		pool := pools[name]
 		// order by key for deterministic return
@@ -51,7 +59,28 @@ func formatPoolsTabular(writer io.Writer, pools map[string]PoolInfo) {
 		for i, key := range keys {
 			attrs[i] = fmt.Sprintf("%v=%v", key, pool.Attrs[key])
 		}
-		print(name, pool.Provider, strings.Join(attrs, " "))
+		if withUsage {
+			print(name, pool.Provider, formatPoolAllocated(pool.Allocated), strings.Join(attrs, " "))
+		} else {
+			print(name, pool.Provider, strings.Join(attrs, " "))
+		}
 	}
 	tw.Flush()
 }
+
+// formatPoolAllocated returns a summary of the storage allocated from
+// a pool, e.g. "2/5 (3.0GiB/10GiB)" where the pool has limits.
+func formatPoolAllocated(usage *PoolUsageInfo) string {
+	if usage == nil {
+		return "-"
+	}
+	count := fmt.Sprint(usage.Count)
+	if usage.MaxVolumes > 0 {
+		count = fmt.Sprintf("%d/%d", usage.Count, usage.MaxVolumes)
+	}
+	size := humanize.IBytes(usage.Size * humanize.MiByte)
+	if usage.MaxSize > 0 {
+		size += "/" + humanize.IBytes(usage.MaxSize*humanize.MiByte)
+	}
+	return fmt.Sprintf("%s (%s)", count, size)
+}
@@ -0,0 +1,111 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/storage"
+)
+
+const poolShowCommandDoc = `
+Show the details of a storage pool, including the storage allocated
+from the pool and a breakdown of that storage by application.
+
+Sizes are reported in MiB. If the pool has max-size or max-volumes
+attributes, the limits are shown alongside the allocated storage.
+
+Examples:
+    juju show-storage-pool ceph
+
+See also:
+    create-storage-pool
+    storage-pools
+`
+
+// NewPoolShowCommand returns a command that shows the details of a
+// storage pool, including its usage.
+func NewPoolShowCommand() cmd.Command {
+	cmd := &poolShowCommand{}
+	cmd.newAPIFunc = func() (PoolShowAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// poolShowCommand shows the details of a storage pool.
+type poolShowCommand struct {
+	PoolCommandBase
+	newAPIFunc func() (PoolShowAPI, error)
+	poolName   string
+	out        cmd.Output
+}
+
+// Init implements Command.Init.
+func (c *poolShowCommand) Init(args []string) (err error) {
+	if len(args) < 1 {
+		return errors.New("show-storage-pool requires a storage pool name")
+	}
+	if !storage.IsValidPoolName(args[0]) {
+		return errors.NotValidf("pool name %q", args[0])
+	}
+	c.poolName = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Info implements Command.Info.
+func (c *poolShowCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "show-storage-pool",
+		Purpose: "Shows storage pool information and usage.",
+		Doc:     poolShowCommandDoc,
+		Args:    "<name>",
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *poolShowCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+}
+
+// Run implements Command.Run.
+func (c *poolShowCommand) Run(ctx *cmd.Context) (err error) {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	pools, err := api.ListPools(nil, []string{c.poolName})
+	if err != nil {
+		return err
+	}
+	if len(pools) == 0 {
+		return errors.NotFoundf("storage pool %q", c.poolName)
+	}
+	output := formatPoolInfo(pools)
+	usage, err := api.PoolUsage([]string{c.poolName})
+	if errors.IsNotSupported(err) {
+		ctx.Infof("storage pool usage is not supported by this version of Juju")
+		return c.out.Write(ctx, output)
+	} else if err != nil {
+		return err
+	}
+	if err := addPoolUsage(output, usage, true); err != nil {
+		return err
+	}
+	return c.out.Write(ctx, output)
+}
+
+// PoolShowAPI defines the API methods that the show-storage-pool
+// command uses.
+type PoolShowAPI interface {
+	PoolListAPI
+}
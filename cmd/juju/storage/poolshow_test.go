@@ -0,0 +1,88 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+)
+
+type poolShowSuite struct {
+	SubStorageSuite
+	mockAPI *mockPoolListAPI
+}
+
+var _ = gc.Suite(&poolShowSuite{})
+
+func (s *poolShowSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+
+	s.mockAPI = &mockPoolListAPI{
+		attrs: map[string]interface{}{"max-volumes": "5"},
+	}
+}
+
+func (s *poolShowSuite) runPoolShow(c *gc.C, args []string) (*cmd.Context, error) {
+	args = append(args, []string{"-m", "controller"}...)
+	return cmdtesting.RunCommand(c, storage.NewPoolShowCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *poolShowSuite) TestPoolShow(c *gc.C) {
+	s.mockAPI.usage = map[string]params.StoragePoolUsage{
+		"ceph": {
+			Name:  "ceph",
+			Total: params.StorageAllocation{Count: 3, Size: 4096},
+			Applications: map[string]params.StorageAllocation{
+				"mysql":      {Count: 2, Size: 3072},
+				"postgresql": {Count: 1, Size: 1024},
+			},
+			MaxVolumes: 5,
+		},
+	}
+	ctx, err := s.runPoolShow(c, []string{"ceph"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+ceph:
+  provider: testType
+  attrs:
+    max-volumes: "5"
+  allocated:
+    count: 3
+    size: 4096
+    max-volumes: 5
+    applications:
+      mysql:
+        count: 2
+        size: 3072
+      postgresql:
+        count: 1
+        size: 1024
+`[1:])
+}
+
+func (s *poolShowSuite) TestPoolShowUsageNotSupported(c *gc.C) {
+	ctx, err := s.runPoolShow(c, []string{"ceph"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+ceph:
+  provider: testType
+  attrs:
+    max-volumes: "5"
+`[1:])
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "storage pool usage is not supported by this version of Juju\n")
+}
+
+func (s *poolShowSuite) TestPoolShowInitErrors(c *gc.C) {
+	_, err := s.runPoolShow(c, []string{})
+	c.Assert(err, gc.ErrorMatches, "show-storage-pool requires a storage pool name")
+	_, err = s.runPoolShow(c, []string{"0ceph"})
+	c.Assert(err, gc.ErrorMatches, `pool name "0ceph" not valid`)
+	_, err = s.runPoolShow(c, []string{"ceph", "extra"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
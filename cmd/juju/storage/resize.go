@@ -0,0 +1,138 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewResizeStorageCommandWithAPI returns a command
+// used to resize storage instances.
+func NewResizeStorageCommandWithAPI() cmd.Command {
+	cmd := &resizeStorageCommand{}
+	cmd.newStorageResizerCloser = func() (StorageResizerCloser, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// NewResizeStorageCommand returns a command used to
+// resize storage instances.
+func NewResizeStorageCommand(new NewStorageResizerCloserFunc) cmd.Command {
+	cmd := &resizeStorageCommand{}
+	cmd.newStorageResizerCloser = new
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	resizeStorageCommandDoc = `
+Grow an existing storage instance to the specified size.
+
+The size is given with an optional unit suffix (M, G, T, P or E);
+when no suffix is given, the size is in MiB. Storage can only be
+grown; shrinking is not supported. The volume backing the storage
+is resized while attached, and units with the storage attached are
+notified so that they can grow the filesystem if required.
+
+Resizing is supported for volumes provided by the loop provider and
+by Kubernetes storage classes that allow volume expansion.
+
+Examples:
+    juju resize-storage pgdata/0 --size 20G
+`
+
+	resizeStorageCommandArgs = `<storage> --size <size>`
+)
+
+// resizeStorageCommand resizes a storage instance.
+type resizeStorageCommand struct {
+	StorageCommandBase
+	newStorageResizerCloser NewStorageResizerCloserFunc
+	storageId               string
+	sizeArg                 string
+	size                    uint64
+}
+
+// Info implements Command.Info.
+func (c *resizeStorageCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "resize-storage",
+		Purpose: "Grows existing storage to a new size.",
+		Doc:     resizeStorageCommandDoc,
+		Args:    resizeStorageCommandArgs,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *resizeStorageCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	f.StringVar(&c.sizeArg, "size", "", "The new size of the storage")
+}
+
+// Init implements Command.Init.
+func (c *resizeStorageCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("resize-storage requires a storage ID")
+	}
+	if !names.IsValidStorage(args[0]) {
+		return errors.NotValidf("storage ID %q", args[0])
+	}
+	c.storageId = args[0]
+	if c.sizeArg == "" {
+		return errors.New("--size must be specified")
+	}
+	size, err := utils.ParseSize(c.sizeArg)
+	if err != nil {
+		return errors.Annotate(err, "cannot parse size")
+	}
+	if size == 0 {
+		return errors.New("--size must be greater than zero")
+	}
+	c.size = size
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements Command.Run.
+func (c *resizeStorageCommand) Run(ctx *cmd.Context) error {
+	resizer, err := c.newStorageResizerCloser()
+	if err != nil {
+		return err
+	}
+	defer resizer.Close()
+
+	if err := resizer.Resize(c.storageId, c.size); err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "resize storage")
+		}
+		return block.ProcessBlockedError(errors.Annotatef(err, "could not resize storage %v", c.storageId), block.BlockChange)
+	}
+	ctx.Infof("resizing %s to %dMiB", c.storageId, c.size)
+	return nil
+}
+
+// NewStorageResizerCloserFunc is the type of a function that returns a
+// StorageResizerCloser.
+type NewStorageResizerCloserFunc func() (StorageResizerCloser, error)
+
+// StorageResizerCloser extends StorageResizer with a Closer method.
+type StorageResizerCloser interface {
+	StorageResizer
+	Close() error
+}
+
+// StorageResizer defines an interface for resizing the storage
+// instance with the specified ID.
+type StorageResizer interface {
+	Resize(storageId string, size uint64) error
+}
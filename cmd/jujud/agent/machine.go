@@ -500,6 +500,9 @@ func (a *MachineAgent) Run(ctx *cmd.Context) (err error) {
 		return errors.Trace(err)
 	}
 	a.machineLock = machineLock
+	if err := a.prometheusRegistry.Register(machineLock); err != nil {
+		return errors.Annotate(err, "registering machine lock collector")
+	}
 	a.dbUpgradeComplete = upgradedatabase.NewLock(agentConfig)
 	a.upgradeComplete = upgradesteps.NewLock(agentConfig)
 
@@ -65,6 +65,7 @@ import (
 	"github.com/juju/juju/worker/logger"
 	"github.com/juju/juju/worker/logsender"
 	"github.com/juju/juju/worker/machineactions"
+	"github.com/juju/juju/worker/machinelockalarm"
 	"github.com/juju/juju/worker/machiner"
 	"github.com/juju/juju/worker/migrationflag"
 	"github.com/juju/juju/worker/migrationminion"
@@ -959,6 +960,7 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			APICallerName: apiCallerName,
 			Clock:         config.Clock,
 			Logger:        loggo.GetLogger("juju.worker.deployer"),
+			MachineLock:   config.MachineLock,
 
 			UnitEngineConfig: config.UnitEngineConfig,
 			SetupLogging:     config.SetupLogging,
@@ -975,6 +977,16 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			Clock:         config.Clock,
 		})),
 
+		// The machine lock alarm sets the machine's status while a
+		// worker has held the machine lock for too long.
+		machineLockAlarmName: ifNotMigrating(machinelockalarm.Manifold(machinelockalarm.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			MachineLock:   config.MachineLock,
+			Clock:         config.Clock,
+			Logger:        loggo.GetLogger("juju.worker.machinelockalarm"),
+		})),
+
 		// The storageProvisioner worker manages provisioning
 		// (deprovisioning), and attachment (detachment) of first-class
 		// volumes and filesystems.
@@ -1123,6 +1135,7 @@ const (
 	proxyConfigUpdater            = "proxy-config-updater"
 	apiAddressUpdaterName         = "api-address-updater"
 	machinerName                  = "machiner"
+	machineLockAlarmName          = "machine-lock-alarm"
 	logSenderName                 = "log-sender"
 	deployerName                  = "deployer"
 	authenticationWorkerName      = "ssh-authkeys-updater"
@@ -86,6 +86,7 @@ func (ms *ManifoldsSuite) TestManifoldNamesIAAS(c *gc.C) {
 			"log-sender",
 			"logging-config-updater",
 			"machine-action-runner",
+			"machine-lock-alarm",
 			"machiner",
 			"mgo-txn-resumer",
 			"migration-fortress",
@@ -695,6 +696,18 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"upgrade-steps-gate",
 	},
 
+	"machine-lock-alarm": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
 	"machiner": {
 		"agent",
 		"api-caller",
@@ -31,6 +31,7 @@ import (
 	"github.com/juju/juju/worker/applicationscaler"
 	"github.com/juju/juju/worker/caasbroker"
 	"github.com/juju/juju/worker/caasenvironupgrader"
+	"github.com/juju/juju/worker/caaseventlogger"
 	"github.com/juju/juju/worker/caasfirewaller"
 	"github.com/juju/juju/worker/caasmodeloperator"
 	"github.com/juju/juju/worker/caasoperatorprovisioner"
@@ -478,6 +479,12 @@ func CAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			Logger:                 config.LoggingContext.GetLogger("juju.worker.caas"),
 		})),
 
+		caasEventLoggerName: ifNotMigrating(caaseventlogger.Manifold(caaseventlogger.ManifoldConfig{
+			BrokerName: caasBrokerTrackerName,
+			Clock:      config.Clock,
+			Logger:     config.LoggingContext.GetLogger("juju.kubernetes.events"),
+		})),
+
 		caasFirewallerName: ifNotMigrating(caasfirewaller.Manifold(
 			caasfirewaller.ManifoldConfig{
 				APICallerName:  apiCallerName,
@@ -662,6 +669,7 @@ const (
 	instanceMutaterName      = "instance-mutater"
 
 	caasAdmissionName           = "caas-admission"
+	caasEventLoggerName         = "caas-event-logger"
 	caasFirewallerName          = "caas-firewaller"
 	caasModelOperatorName       = "caas-model-operator"
 	caasOperatorProvisionerName = "caas-operator-provisioner"
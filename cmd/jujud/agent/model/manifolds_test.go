@@ -86,6 +86,7 @@ func (s *ManifoldsSuite) TestCAASNames(c *gc.C) {
 		"api-caller",
 		"api-config-watcher",
 		"caas-broker-tracker",
+		"caas-event-logger",
 		"caas-firewaller",
 		"caas-model-operator",
 		"caas-operator-provisioner",
@@ -218,6 +219,17 @@ var expectedCAASModelManifoldsWithDependencies = map[string][]string{
 
 	"caas-broker-tracker": {"agent", "api-caller", "is-responsible-flag"},
 
+	"caas-event-logger": {
+		"agent",
+		"api-caller",
+		"caas-broker-tracker",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag"},
+
 	"caas-firewaller": {
 		"agent",
 		"api-caller",
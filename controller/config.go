@@ -20,6 +20,7 @@ import (
 	"gopkg.in/macaroon-bakery.v2/bakery"
 
 	"github.com/juju/juju/core/resources"
+	"github.com/juju/juju/core/secrets"
 	"github.com/juju/juju/pki"
 )
 
@@ -199,6 +200,25 @@ const (
 	// when writing to the raft log by setting this value to true.
 	NonSyncedWritesToRaftLog = "non-synced-writes-to-raft-log"
 
+	// MaintenanceMode is true when the controller has been put into
+	// maintenance mode, in which user API calls that would change
+	// anything are rejected. It is set with the enable-maintenance-mode
+	// command rather than as controller config.
+	MaintenanceMode = "maintenance-mode"
+
+	// MaintenanceMessage is the message shown to users while the
+	// controller is in maintenance mode.
+	MaintenanceMessage = "maintenance-message"
+
+	// SecretBackend is the type of backend used to store the content
+	// of charm secrets.
+	SecretBackend = "secret-backend"
+
+	// MaxConcurrentMigrations is the maximum number of model migrations
+	// the controller runs at the same time. Further migrations are
+	// refused until one of those running completes.
+	MaxConcurrentMigrations = "max-concurrent-migrations"
+
 	// Attribute Defaults
 
 	// DefaultAgentRateLimitMax allows the first 10 agents to connect without any
@@ -246,6 +266,14 @@ const (
 	// mongo in focal or later.
 	DefaultJujuDBSnapChannel = "4.0/stable"
 
+	// DefaultSecretBackend is the default backend for storing secret
+	// content, which keeps it in the controller database.
+	DefaultSecretBackend = "internal"
+
+	// DefaultMaxConcurrentMigrations is the default number of model
+	// migrations the controller runs at the same time.
+	DefaultMaxConcurrentMigrations = 5
+
 	// DefaultMaxDebugLogDuration is the default duration that debug-log commands
 	// can run before being terminated by the API server.
 	DefaultMaxDebugLogDuration = 24 * time.Hour
@@ -361,6 +389,10 @@ var (
 		MaxCharmStateSize,
 		MaxAgentStateSize,
 		NonSyncedWritesToRaftLog,
+		MaintenanceMode,
+		MaintenanceMessage,
+		SecretBackend,
+		MaxConcurrentMigrations,
 	}
 
 	// For backwards compatibility, we must include "anything", "juju-apiserver"
@@ -406,6 +438,8 @@ var (
 		MaxCharmStateSize,
 		MaxAgentStateSize,
 		NonSyncedWritesToRaftLog,
+		SecretBackend,
+		MaxConcurrentMigrations,
 	)
 
 	// DefaultAuditLogExcludeMethods is the default list of methods to
@@ -843,6 +877,41 @@ func (c Config) NonSyncedWritesToRaftLog() bool {
 	return DefaultNonSyncedWritesToRaftLog
 }
 
+// MaintenanceMode returns true if the controller is in maintenance
+// mode.
+func (c Config) MaintenanceMode() bool {
+	if v, ok := c[MaintenanceMode]; ok {
+		return v.(bool)
+	}
+	return false
+}
+
+// MaintenanceMessage returns the message shown to users while the
+// controller is in maintenance mode.
+func (c Config) MaintenanceMessage() string {
+	return c.asString(MaintenanceMessage)
+}
+
+// SecretBackend returns the type of backend used to store the content
+// of charm secrets.
+func (c Config) SecretBackend() string {
+	return c.asString(SecretBackend)
+}
+
+// MaxConcurrentMigrations returns the maximum number of model migrations
+// the controller runs at the same time.
+func (c Config) MaxConcurrentMigrations() int {
+	switch v := c[MaxConcurrentMigrations].(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	default:
+		// nil type shows up here
+	}
+	return DefaultMaxConcurrentMigrations
+}
+
 // Validate ensures that config is a valid configuration.
 func Validate(c Config) error {
 	if v, ok := c[IdentityPublicKey].(string); ok {
@@ -880,6 +949,14 @@ func Validate(c Config) error {
 		return errors.Errorf("controller-uuid: expected UUID, got string(%q)", uuid)
 	}
 
+	if v, ok := c[SecretBackend].(string); ok && !secrets.IsValidBackendType(v) {
+		return errors.NotValidf("%s %q", SecretBackend, v)
+	}
+
+	if v, ok := c[MaxConcurrentMigrations].(int); ok && v < 1 {
+		return errors.Errorf("%s must be at least 1, got %d", MaxConcurrentMigrations, v)
+	}
+
 	if v, ok := c[AgentRateLimitMax].(int); ok {
 		if v < 0 {
 			return errors.NotValidf("negative %s (%d)", AgentRateLimitMax, v)
@@ -1138,6 +1215,10 @@ var configChecker = schema.FieldMap(schema.Fields{
 	MaxCharmStateSize:        schema.ForceInt(),
 	MaxAgentStateSize:        schema.ForceInt(),
 	NonSyncedWritesToRaftLog: schema.Bool(),
+	MaintenanceMode:          schema.Bool(),
+	MaintenanceMessage:       schema.String(),
+	SecretBackend:            schema.String(),
+	MaxConcurrentMigrations:  schema.ForceInt(),
 }, schema.Defaults{
 	AgentRateLimitMax:        schema.Omit,
 	AgentRateLimitRate:       schema.Omit,
@@ -1178,6 +1259,10 @@ var configChecker = schema.FieldMap(schema.Fields{
 	MaxCharmStateSize:        DefaultMaxCharmStateSize,
 	MaxAgentStateSize:        DefaultMaxAgentStateSize,
 	NonSyncedWritesToRaftLog: DefaultNonSyncedWritesToRaftLog,
+	MaintenanceMode:          schema.Omit,
+	MaintenanceMessage:       schema.Omit,
+	SecretBackend:            DefaultSecretBackend,
+	MaxConcurrentMigrations:  schema.Omit,
 })
 
 // ConfigSchema holds information on all the fields defined by
@@ -1346,4 +1431,20 @@ Use "caas-image-repo" instead.`,
 		Type:        environschema.Tbool,
 		Description: `Do not perform fsync calls after appending entries to the raft log. Disabling sync improves performance at the cost of reliability`,
 	},
+	MaintenanceMode: {
+		Type:        environschema.Tbool,
+		Description: `Whether the controller is in maintenance mode, rejecting user API calls that would make changes`,
+	},
+	MaintenanceMessage: {
+		Type:        environschema.Tstring,
+		Description: `The message shown to users while the controller is in maintenance mode`,
+	},
+	SecretBackend: {
+		Type:        environschema.Tstring,
+		Description: `The type of backend used to store the content of charm secrets`,
+	},
+	MaxConcurrentMigrations: {
+		Type:        environschema.Tint,
+		Description: `The maximum number of model migrations the controller runs at the same time`,
+	},
 }
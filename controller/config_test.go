@@ -339,6 +339,12 @@ var newConfigTests = []struct {
 		controller.NonSyncedWritesToRaftLog: "I live dangerously",
 	},
 	expectError: `non-synced-writes-to-raft-log: expected bool, got string\("I live dangerously"\)`,
+}, {
+	about: "max-concurrent-migrations must be at least 1",
+	config: controller.Config{
+		controller.MaxConcurrentMigrations: 0,
+	},
+	expectError: `max-concurrent-migrations must be at least 1, got 0`,
 }, {}}
 
 func (s *ConfigSuite) TestNewConfig(c *gc.C) {
@@ -756,3 +762,75 @@ func (s *ConfigSuite) TestJujuDBSnapChannel(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(cfg.JujuDBSnapChannel(), gc.Equals, "latest/candidate")
 }
+
+func (s *ConfigSuite) TestSecretBackend(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.SecretBackend(), gc.Equals, controller.DefaultSecretBackend)
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"secret-backend": "internal",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.SecretBackend(), gc.Equals, "internal")
+
+	_, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"secret-backend": "vault",
+		},
+	)
+	c.Assert(err, gc.ErrorMatches, `secret-backend "vault" not valid`)
+}
+
+func (s *ConfigSuite) TestMaintenanceMode(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.MaintenanceMode(), jc.IsFalse)
+	c.Check(cfg.MaintenanceMessage(), gc.Equals, "")
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"maintenance-mode":    true,
+			"maintenance-message": "upgrading storage",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.MaintenanceMode(), jc.IsTrue)
+	c.Check(cfg.MaintenanceMessage(), gc.Equals, "upgrading storage")
+}
+
+func (s *ConfigSuite) TestMaxConcurrentMigrations(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.MaxConcurrentMigrations(), gc.Equals, controller.DefaultMaxConcurrentMigrations)
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"max-concurrent-migrations": "10",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.MaxConcurrentMigrations(), gc.Equals, 10)
+}
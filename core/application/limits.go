@@ -0,0 +1,102 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+)
+
+const (
+	// UnitMemoryLimitKey is the application config key holding the
+	// most memory the hooks of each unit of an IAAS application may
+	// use, for example "512M" or "2G".
+	UnitMemoryLimitKey = "unit-memory-limit"
+
+	// UnitCPUSharesKey is the application config key holding the
+	// relative CPU weight given to the hooks of each unit of an IAAS
+	// application.
+	UnitCPUSharesKey = "unit-cpu-shares"
+
+	// UnitTasksLimitKey is the application config key holding the
+	// most processes and threads the hooks of each unit of an IAAS
+	// application may run at once.
+	UnitTasksLimitKey = "unit-tasks-limit"
+)
+
+const (
+	minCPUShares = 2
+	maxCPUShares = 262144
+)
+
+// ResourceLimits describes the resources the hooks of a single unit,
+// and the processes they start, may use. The unit agent is not limited,
+// and nor are services a charm runs through systemd, which systemd
+// starts in their own units rather than the hook's. A zero value means
+// that resource is not limited.
+type ResourceLimits struct {
+	// MemoryMB is the most memory the unit's hooks may use, in megabytes.
+	MemoryMB uint64
+
+	// CPUShares is the relative CPU weight given to the unit's hooks.
+	CPUShares uint64
+
+	// Tasks is the most processes and threads the unit's hooks may run.
+	Tasks uint64
+}
+
+// IsZero reports whether no resource is limited.
+func (l ResourceLimits) IsZero() bool {
+	return l == ResourceLimits{}
+}
+
+// ParseResourceLimits returns the unit resource limits held in the
+// given application config attributes.
+func ParseResourceLimits(attrs ConfigAttributes) (ResourceLimits, error) {
+	var limits ResourceLimits
+	if memory := attrs.GetString(UnitMemoryLimitKey, ""); memory != "" {
+		mb, err := utils.ParseSize(memory)
+		if err != nil {
+			return ResourceLimits{}, errors.Annotatef(err, "parsing %s", UnitMemoryLimitKey)
+		}
+		limits.MemoryMB = mb
+	}
+
+	shares, err := uintAttr(attrs, UnitCPUSharesKey)
+	if err != nil {
+		return ResourceLimits{}, errors.Trace(err)
+	}
+	if shares != 0 && (shares < minCPUShares || shares > maxCPUShares) {
+		return ResourceLimits{}, errors.NotValidf("%s %d outside range %d-%d", UnitCPUSharesKey, shares, minCPUShares, maxCPUShares)
+	}
+	limits.CPUShares = shares
+
+	if limits.Tasks, err = uintAttr(attrs, UnitTasksLimitKey); err != nil {
+		return ResourceLimits{}, errors.Trace(err)
+	}
+	return limits, nil
+}
+
+// uintAttr returns the non-negative integer attribute with the given
+// name, or zero if it is not set. Values read back from the database
+// or from JSON may be of any numeric type.
+func uintAttr(attrs ConfigAttributes, name string) (uint64, error) {
+	var value int64
+	switch v := attrs.Get(name, nil).(type) {
+	case nil:
+		return 0, nil
+	case int:
+		value = int64(v)
+	case int64:
+		value = v
+	case float64:
+		value = int64(v)
+	default:
+		return 0, errors.NotValidf("%s value of type %T", name, v)
+	}
+	if value < 0 {
+		return 0, errors.NotValidf("negative %s %d", name, value)
+	}
+	return uint64(value), nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/application"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type LimitsSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&LimitsSuite{})
+
+func (s *LimitsSuite) TestParseResourceLimits(c *gc.C) {
+	for i, test := range []struct {
+		attrs    application.ConfigAttributes
+		expected application.ResourceLimits
+	}{{
+		attrs: application.ConfigAttributes{"trust": true},
+	}, {
+		attrs: application.ConfigAttributes{
+			"unit-memory-limit": "2G",
+			"unit-cpu-shares":   512,
+			"unit-tasks-limit":  int64(100),
+		},
+		expected: application.ResourceLimits{MemoryMB: 2048, CPUShares: 512, Tasks: 100},
+	}, {
+		attrs:    application.ConfigAttributes{"unit-tasks-limit": float64(64)},
+		expected: application.ResourceLimits{Tasks: 64},
+	}, {
+		attrs:    application.ConfigAttributes{"unit-memory-limit": ""},
+		expected: application.ResourceLimits{},
+	}} {
+		c.Logf("test %d", i)
+		limits, err := application.ParseResourceLimits(test.attrs)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(limits, gc.Equals, test.expected)
+		c.Check(limits.IsZero(), gc.Equals, test.expected == application.ResourceLimits{})
+	}
+}
+
+func (s *LimitsSuite) TestParseResourceLimitsErrors(c *gc.C) {
+	for i, test := range []struct {
+		attrs application.ConfigAttributes
+		err   string
+	}{{
+		attrs: application.ConfigAttributes{"unit-memory-limit": "lots"},
+		err:   `parsing unit-memory-limit: .*`,
+	}, {
+		attrs: application.ConfigAttributes{"unit-cpu-shares": 1},
+		err:   `unit-cpu-shares 1 outside range 2-262144 not valid`,
+	}, {
+		attrs: application.ConfigAttributes{"unit-cpu-shares": 300000},
+		err:   `unit-cpu-shares 300000 outside range 2-262144 not valid`,
+	}, {
+		attrs: application.ConfigAttributes{"unit-tasks-limit": -1},
+		err:   `negative unit-tasks-limit -1 not valid`,
+	}, {
+		attrs: application.ConfigAttributes{"unit-tasks-limit": "many"},
+		err:   `unit-tasks-limit value of type string not valid`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := application.ParseResourceLimits(test.attrs)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/robfig/cron.v2"
+)
+
+const (
+	// MaintenanceWindowKey is the application config key holding a cron
+	// expression for when the application's maintenance window starts,
+	// for example "0 2 * * *". Times are in UTC unless the expression
+	// starts with a location, such as "TZ=Europe/London 0 2 * * *".
+	MaintenanceWindowKey = "maintenance-window"
+
+	// MaintenanceWindowDurationKey is the application config key holding
+	// how long the application's maintenance window lasts, for example
+	// "1h".
+	MaintenanceWindowDurationKey = "maintenance-window-duration"
+)
+
+// maxWindowOverlaps bounds how many overlapping starts of a maintenance
+// window are followed when working out when the window ends.
+const maxWindowOverlaps = 1440
+
+// MaintenanceWindow describes when an application's units defer their
+// non-essential hooks, so that they don't disturb the workload at its
+// busiest. The zero value means the application has no window.
+type MaintenanceWindow struct {
+	// Schedule is a cron expression for when the window starts.
+	Schedule string
+
+	// Duration is how long the window lasts.
+	Duration time.Duration
+}
+
+// ParseMaintenanceWindow returns the maintenance window held in the
+// given application config attributes.
+func ParseMaintenanceWindow(attrs ConfigAttributes) (MaintenanceWindow, error) {
+	var window MaintenanceWindow
+	window.Schedule = strings.TrimSpace(attrs.GetString(MaintenanceWindowKey, ""))
+	duration, err := durationAttr(attrs, MaintenanceWindowDurationKey)
+	if err != nil {
+		return MaintenanceWindow{}, errors.Trace(err)
+	}
+	window.Duration = duration
+
+	switch {
+	case window.Schedule == "" && window.Duration == 0:
+		return MaintenanceWindow{}, nil
+	case window.Schedule == "":
+		return MaintenanceWindow{}, errors.NotValidf("%s without %s", MaintenanceWindowDurationKey, MaintenanceWindowKey)
+	case window.Duration == 0:
+		return MaintenanceWindow{}, errors.NotValidf("%s without %s", MaintenanceWindowKey, MaintenanceWindowDurationKey)
+	}
+	if _, err := parseSchedule(window.Schedule); err != nil {
+		return MaintenanceWindow{}, errors.NotValidf("%s %q: %v", MaintenanceWindowKey, window.Schedule, err)
+	}
+	return window, nil
+}
+
+// IsZero reports whether the application has no maintenance window.
+func (w MaintenanceWindow) IsZero() bool {
+	return w.Schedule == "" || w.Duration <= 0
+}
+
+// ActiveAt reports whether the maintenance window is open at the given
+// time and, if it is, when the latest start of the window was and when
+// the window closes. Starts of the window that overlap are treated as
+// one longer window.
+func (w MaintenanceWindow) ActiveAt(now time.Time) (start, end time.Time, active bool) {
+	if w.IsZero() {
+		return time.Time{}, time.Time{}, false
+	}
+	schedule, err := parseSchedule(w.Schedule)
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	start = schedule.Next(now.Add(-w.Duration))
+	if start.IsZero() || start.After(now) {
+		return time.Time{}, time.Time{}, false
+	}
+	end = start.Add(w.Duration)
+	for last, i := start, 0; i < maxWindowOverlaps; i++ {
+		next := schedule.Next(last)
+		if next.IsZero() || next.After(end) {
+			break
+		}
+		if !next.After(now) {
+			start = next
+		}
+		end = next.Add(w.Duration)
+		last = next
+	}
+	return start, end, true
+}
+
+// parseSchedule parses the cron expression of a maintenance window.
+// The expression is in UTC unless it names a location.
+func parseSchedule(spec string) (cron.Schedule, error) {
+	if !strings.HasPrefix(spec, "TZ=") {
+		spec = "TZ=UTC " + spec
+	}
+	return cron.Parse(spec)
+}
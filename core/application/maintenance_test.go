@@ -0,0 +1,123 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/application"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type MaintenanceSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&MaintenanceSuite{})
+
+func (s *MaintenanceSuite) TestParseMaintenanceWindow(c *gc.C) {
+	for i, test := range []struct {
+		attrs    application.ConfigAttributes
+		expected application.MaintenanceWindow
+	}{{
+		attrs: application.ConfigAttributes{"trust": true},
+	}, {
+		attrs: application.ConfigAttributes{
+			"maintenance-window":          "0 2 * * *",
+			"maintenance-window-duration": "1h",
+		},
+		expected: application.MaintenanceWindow{Schedule: "0 2 * * *", Duration: time.Hour},
+	}, {
+		attrs: application.ConfigAttributes{
+			"maintenance-window":          "TZ=Europe/London 30 9 * * 1-5",
+			"maintenance-window-duration": "90m",
+		},
+		expected: application.MaintenanceWindow{Schedule: "TZ=Europe/London 30 9 * * 1-5", Duration: 90 * time.Minute},
+	}} {
+		c.Logf("test %d", i)
+		window, err := application.ParseMaintenanceWindow(test.attrs)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(window, gc.Equals, test.expected)
+	}
+}
+
+func (s *MaintenanceSuite) TestParseMaintenanceWindowErrors(c *gc.C) {
+	for i, test := range []struct {
+		attrs application.ConfigAttributes
+		err   string
+	}{{
+		attrs: application.ConfigAttributes{"maintenance-window": "0 2 * * *"},
+		err:   `maintenance-window without maintenance-window-duration not valid`,
+	}, {
+		attrs: application.ConfigAttributes{"maintenance-window-duration": "1h"},
+		err:   `maintenance-window-duration without maintenance-window not valid`,
+	}, {
+		attrs: application.ConfigAttributes{
+			"maintenance-window":          "0 2 * * *",
+			"maintenance-window-duration": "later",
+		},
+		err: `parsing maintenance-window-duration: .*`,
+	}, {
+		attrs: application.ConfigAttributes{
+			"maintenance-window":          "0 2 * *",
+			"maintenance-window-duration": "1h",
+		},
+		err: `maintenance-window "0 2 \* \*": .* not valid`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := application.ParseMaintenanceWindow(test.attrs)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *MaintenanceSuite) TestActiveAt(c *gc.C) {
+	window := application.MaintenanceWindow{Schedule: "0 2 * * *", Duration: time.Hour}
+	day := time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC)
+	start := day.Add(2 * time.Hour)
+	end := day.Add(3 * time.Hour)
+
+	for i, test := range []struct {
+		now    time.Time
+		active bool
+	}{
+		{now: day.Add(time.Hour)},
+		{now: start, active: true},
+		{now: start.Add(59 * time.Minute), active: true},
+		{now: end},
+		{now: day.Add(4 * time.Hour)},
+	} {
+		c.Logf("test %d", i)
+		gotStart, gotEnd, active := window.ActiveAt(test.now)
+		c.Check(active, gc.Equals, test.active)
+		if test.active {
+			c.Check(gotStart, gc.Equals, start)
+			c.Check(gotEnd, gc.Equals, end)
+		}
+	}
+}
+
+func (s *MaintenanceSuite) TestActiveAtOverlapping(c *gc.C) {
+	window := application.MaintenanceWindow{Schedule: "0 * * * *", Duration: 90 * time.Minute}
+	now := time.Date(2020, 7, 1, 2, 10, 0, 0, time.UTC)
+	start, end, active := window.ActiveAt(now)
+	c.Assert(active, jc.IsTrue)
+	c.Assert(start, gc.Equals, time.Date(2020, 7, 1, 2, 0, 0, 0, time.UTC))
+	c.Assert(end.After(now.Add(90*time.Minute)), jc.IsTrue)
+}
+
+func (s *MaintenanceSuite) TestActiveAtLocation(c *gc.C) {
+	window := application.MaintenanceWindow{Schedule: "TZ=Asia/Tokyo 0 9 * * *", Duration: time.Hour}
+	_, _, active := window.ActiveAt(time.Date(2020, 7, 1, 0, 30, 0, 0, time.UTC))
+	c.Assert(active, jc.IsTrue)
+	_, _, active = window.ActiveAt(time.Date(2020, 7, 1, 9, 30, 0, 0, time.UTC))
+	c.Assert(active, jc.IsFalse)
+}
+
+func (s *MaintenanceSuite) TestActiveAtNoWindow(c *gc.C) {
+	_, _, active := application.MaintenanceWindow{}.ActiveAt(time.Now())
+	c.Assert(active, jc.IsFalse)
+}
@@ -0,0 +1,82 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+)
+
+const (
+	// HookRetryMaxAttemptsKey is the application config key holding the
+	// most times a failed hook is automatically retried before the unit
+	// is left in error for an operator to resolve. Zero means the hook
+	// is retried until it succeeds.
+	HookRetryMaxAttemptsKey = "hook-retry-max-attempts"
+
+	// HookRetryMinIntervalKey is the application config key holding how
+	// long to wait before the first automatic retry of a failed hook,
+	// for example "10s".
+	HookRetryMinIntervalKey = "hook-retry-min-interval"
+
+	// HookRetryMaxIntervalKey is the application config key holding the
+	// longest wait between automatic retries of a failed hook. The wait
+	// doubles after each retry until it reaches this.
+	HookRetryMaxIntervalKey = "hook-retry-max-interval"
+)
+
+// HookRetryPolicy describes how the failed hooks of an application's
+// units are automatically retried. Zero values mean the model's defaults
+// are used.
+type HookRetryPolicy struct {
+	// MaxAttempts is the most times a failed hook is retried.
+	MaxAttempts int
+
+	// MinInterval is the wait before the first retry.
+	MinInterval time.Duration
+
+	// MaxInterval is the longest wait between retries.
+	MaxInterval time.Duration
+}
+
+// ParseHookRetryPolicy returns the hook retry policy held in the given
+// application config attributes.
+func ParseHookRetryPolicy(attrs ConfigAttributes) (HookRetryPolicy, error) {
+	var policy HookRetryPolicy
+	attempts, err := uintAttr(attrs, HookRetryMaxAttemptsKey)
+	if err != nil {
+		return HookRetryPolicy{}, errors.Trace(err)
+	}
+	policy.MaxAttempts = int(attempts)
+
+	if policy.MinInterval, err = durationAttr(attrs, HookRetryMinIntervalKey); err != nil {
+		return HookRetryPolicy{}, errors.Trace(err)
+	}
+	if policy.MaxInterval, err = durationAttr(attrs, HookRetryMaxIntervalKey); err != nil {
+		return HookRetryPolicy{}, errors.Trace(err)
+	}
+	if policy.MinInterval > 0 && policy.MaxInterval > 0 && policy.MinInterval > policy.MaxInterval {
+		return HookRetryPolicy{}, errors.NotValidf("%s %v greater than %s %v",
+			HookRetryMinIntervalKey, policy.MinInterval, HookRetryMaxIntervalKey, policy.MaxInterval)
+	}
+	return policy, nil
+}
+
+// durationAttr returns the non-negative duration attribute with the
+// given name, or zero if it is not set.
+func durationAttr(attrs ConfigAttributes, name string) (time.Duration, error) {
+	value := attrs.GetString(name, "")
+	if value == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, errors.Annotatef(err, "parsing %s", name)
+	}
+	if d < 0 {
+		return 0, errors.NotValidf("negative %s %v", name, d)
+	}
+	return d, nil
+}
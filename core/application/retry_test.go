@@ -0,0 +1,77 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/application"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type RetrySuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&RetrySuite{})
+
+func (s *RetrySuite) TestParseHookRetryPolicy(c *gc.C) {
+	for i, test := range []struct {
+		attrs    application.ConfigAttributes
+		expected application.HookRetryPolicy
+	}{{
+		attrs: application.ConfigAttributes{"trust": true},
+	}, {
+		attrs: application.ConfigAttributes{
+			"hook-retry-max-attempts": 5,
+			"hook-retry-min-interval": "10s",
+			"hook-retry-max-interval": "2m",
+		},
+		expected: application.HookRetryPolicy{
+			MaxAttempts: 5,
+			MinInterval: 10 * time.Second,
+			MaxInterval: 2 * time.Minute,
+		},
+	}, {
+		attrs:    application.ConfigAttributes{"hook-retry-max-attempts": int64(3)},
+		expected: application.HookRetryPolicy{MaxAttempts: 3},
+	}, {
+		attrs:    application.ConfigAttributes{"hook-retry-max-interval": "1m"},
+		expected: application.HookRetryPolicy{MaxInterval: time.Minute},
+	}} {
+		c.Logf("test %d", i)
+		policy, err := application.ParseHookRetryPolicy(test.attrs)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(policy, gc.Equals, test.expected)
+	}
+}
+
+func (s *RetrySuite) TestParseHookRetryPolicyErrors(c *gc.C) {
+	for i, test := range []struct {
+		attrs application.ConfigAttributes
+		err   string
+	}{{
+		attrs: application.ConfigAttributes{"hook-retry-max-attempts": -1},
+		err:   `negative hook-retry-max-attempts -1 not valid`,
+	}, {
+		attrs: application.ConfigAttributes{"hook-retry-min-interval": "soon"},
+		err:   `parsing hook-retry-min-interval: .*`,
+	}, {
+		attrs: application.ConfigAttributes{"hook-retry-max-interval": "-1m"},
+		err:   `negative hook-retry-max-interval -1m0s not valid`,
+	}, {
+		attrs: application.ConfigAttributes{
+			"hook-retry-min-interval": "5m",
+			"hook-retry-max-interval": "1m",
+		},
+		err: `hook-retry-min-interval 5m0s greater than hook-retry-max-interval 1m0s not valid`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := application.ParseHookRetryPolicy(test.attrs)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
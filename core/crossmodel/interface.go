@@ -5,6 +5,8 @@ package crossmodel
 
 import (
 	"github.com/juju/charm/v7"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
 	"gopkg.in/macaroon.v2"
 
 	"github.com/juju/juju/apiserver/params"
@@ -29,6 +31,46 @@ type ApplicationOffer struct {
 	// Endpoints is the collection of endpoint names offered (internal->published).
 	// The map allows for advertised endpoint names to be aliased.
 	Endpoints map[string]charm.Relation
+
+	// Limits restrict how the offer may be consumed.
+	Limits OfferLimits
+}
+
+// OfferLimits restrict how an offer may be consumed.
+// Zero values mean no limit.
+type OfferLimits struct {
+	// MaxConnections is the maximum number of models
+	// which may be related to the offer.
+	MaxConnections int
+
+	// MaxRelations is the maximum number of relations to the offer.
+	MaxRelations int
+
+	// AllowedControllers are the UUIDs of the controllers hosting
+	// the models which may relate to the offer. If empty, models
+	// on any controller may relate to it.
+	AllowedControllers []string
+}
+
+// Validate returns an error if the limits are not valid.
+func (l OfferLimits) Validate() error {
+	if l.MaxConnections < 0 {
+		return errors.NotValidf("max connections %d", l.MaxConnections)
+	}
+	if l.MaxRelations < 0 {
+		return errors.NotValidf("max relations %d", l.MaxRelations)
+	}
+	for _, uuid := range l.AllowedControllers {
+		if !names.IsValidController(uuid) {
+			return errors.NotValidf("allowed controller %q", uuid)
+		}
+	}
+	return nil
+}
+
+// IsZero returns true if the limits impose no restrictions.
+func (l OfferLimits) IsZero() bool {
+	return l.MaxConnections == 0 && l.MaxRelations == 0 && len(l.AllowedControllers) == 0
 }
 
 // AddApplicationOfferArgs contains parameters used to create an application offer.
@@ -56,6 +98,10 @@ type AddApplicationOfferArgs struct {
 	// Icon is an icon to display when browsing the ApplicationOffers, which by default
 	// comes from the charm.
 	Icon []byte
+
+	// Limits restrict how the offer may be consumed. When updating
+	// an offer, nil leaves any existing limits unchanged.
+	Limits *OfferLimits
 }
 
 // ConsumeApplicationArgs contains parameters used to consume an offer.
@@ -138,6 +184,9 @@ type ApplicationOffers interface {
 	// ListOffers returns the offers satisfying the specified filter.
 	ListOffers(filter ...ApplicationOfferFilter) ([]ApplicationOffer, error)
 
+	// SetOfferLimits replaces the limits on how the named offer may be consumed.
+	SetOfferLimits(offerName string, limits OfferLimits) error
+
 	// Remove removes the application offer at the specified URL.
 	Remove(offerName string, force bool) error
 
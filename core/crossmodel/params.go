@@ -41,6 +41,9 @@ type ApplicationOfferDetails struct {
 
 	// Users are the users able to access the offer.
 	Users []OfferUserDetails
+
+	// Limits restrict how the offer may be consumed.
+	Limits OfferLimits
 }
 
 // OfferUserDetails holds the details about a user's access to an offer.
@@ -80,4 +83,33 @@ type OfferConnection struct {
 
 	// IngressSubnets is the list of subnets from which traffic will originate.
 	IngressSubnets []string
+
+	// Diagnostics holds the cross model relation diagnostics recorded
+	// for the consuming side of the connection, if any.
+	Diagnostics *RemoteApplicationDiagnostics
+}
+
+// RemoteApplicationDiagnostics holds diagnostic information about the
+// cross model relation traffic for a remote application, as recorded
+// by the remote relations worker.
+type RemoteApplicationDiagnostics struct {
+	// LastRemoteEvent is when an event from the remote model was
+	// last processed successfully.
+	LastRemoteEvent *time.Time
+
+	// MacaroonExpiry is when the earliest expiring macaroon used to
+	// talk to the remote model expires, if any of them expire.
+	MacaroonExpiry *time.Time
+
+	// SettingsSyncLag is how long it took the most recent relation
+	// settings change to be applied to the other model, measured
+	// from when the change was first observed.
+	SettingsSyncLag time.Duration
+
+	// IngressAddresses are the ingress addresses advertised by the
+	// remote units participating in relations.
+	IngressAddresses []string
+
+	// Updated is when the diagnostics were last recorded.
+	Updated time.Time
 }
@@ -6,7 +6,6 @@ package machinelock
 import (
 	"fmt"
 	"runtime/debug"
-	"sort"
 	"sync"
 	"time"
 
@@ -51,6 +50,11 @@ type Config struct {
 	Clock       Clock
 	Logger      Logger
 	LogFilename string
+
+	// ShareQueueWith is an optional lock, created by New for another
+	// agent running in the same process, whose wait queue the new
+	// lock joins so that the agents' workers are ordered together.
+	ShareQueueWith Lock
 }
 
 // Validate ensures that all the required config values are set.
@@ -79,7 +83,7 @@ func New(config Config) (*lock, error) {
 		// This isn't a fatal error so  continue if priming fails.
 		_ = fmt.Sprintf("failed to create prime logfile in %s, because: %v", config.LogFilename, err)
 	}
-	lock := &lock{
+	l := &lock{
 		agent:       config.AgentName,
 		clock:       config.Clock,
 		logger:      config.Logger,
@@ -91,11 +95,14 @@ func New(config Config) (*lock, error) {
 			Delay: 250 * time.Millisecond,
 			// Cancel is added in Acquire.
 		},
-		waiting: make(map[int]*info),
+		queue:   newWaitQueue(),
 		history: deque.NewWithMaxLen(1000),
 	}
-	lock.setStartMessage()
-	return lock, nil
+	if shared, ok := config.ShareQueueWith.(*lock); ok {
+		l.queue = shared.queue
+	}
+	l.setStartMessage()
+	return l, nil
 }
 
 func (c *lock) setStartMessage() {
@@ -121,6 +128,9 @@ type Spec struct {
 	NoCancel bool
 	Worker   string
 	Comment  string
+	// Priority orders the workers in this process waiting for the
+	// lock. It defaults to PriorityNormal.
+	Priority Priority
 }
 
 // Validate ensures that a Cancel channel and a Worker name are defined.
@@ -144,34 +154,29 @@ func (c *lock) Acquire(spec Spec) (func(), error) {
 		return nil, errors.Trace(err)
 	}
 	current := &info{
+		agent:     c.agent,
 		worker:    spec.Worker,
 		comment:   spec.Comment,
+		priority:  spec.Priority,
 		stack:     string(debug.Stack()),
 		requested: c.clock.Now(),
 	}
-	c.mu.Lock()
-
-	id := c.next
-	c.next++
-	c.waiting[id] = current
+	w := c.queue.join(current)
 
-	mSpec := c.spec
-	mSpec.Cancel = spec.Cancel
-
-	c.mu.Unlock()
 	c.logger.Debugf("acquire machine lock for %s (%s)", spec.Worker, spec.Comment)
-	releaser, err := c.acquire(mSpec)
+	releaser, err := c.acquireInTurn(w, spec.Cancel)
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	// Remove from the waiting map.
-	delete(c.waiting, id)
+	if err == nil {
+		current.acquired = c.clock.Now()
+	}
+	c.queue.leave(w, err == nil)
 
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	c.logger.Debugf("machine lock acquired for %s (%s)", spec.Worker, spec.Comment)
 	c.holder = current
-	current.acquired = c.clock.Now()
 	return func() {
 		// We need to acquire the mutex before we call the releaser
 		// to ensure that we move the current to the history before
@@ -185,11 +190,98 @@ func (c *lock) Acquire(spec Spec) (func(), error) {
 		c.writeLogEntry()
 		c.logger.Debugf("machine lock released for %s (%s)", spec.Worker, spec.Comment)
 		releaser.Release()
+		c.queue.released(current)
 		c.history.PushFront(current)
 		c.holder = nil
 	}, nil
 }
 
+// acquireInTurn acquires the underlying mutex once there are no higher
+// priority waiters in the queue. If a higher priority waiter joins the
+// queue while we are trying, we stop and let it go first.
+func (c *lock) acquireInTurn(w *waiter, cancel <-chan struct{}) (mutex.Releaser, error) {
+	for {
+		yield, changed := c.queue.attempt(w)
+		if yield == nil {
+			select {
+			case <-changed:
+				continue
+			case <-cancel:
+				return nil, mutex.ErrCancelled
+			}
+		}
+
+		mSpec := c.spec
+		stop := make(chan struct{})
+		mSpec.Cancel = anyClosed(cancel, yield, stop)
+		releaser, err := c.acquire(mSpec)
+		close(stop)
+		c.queue.attempted(w)
+		if err == nil {
+			return releaser, nil
+		}
+		select {
+		case <-cancel:
+			return nil, err
+		default:
+		}
+		select {
+		case <-yield:
+			c.logger.Debugf("machine lock for %s (%s) yielding to higher priority", w.info.worker, w.info.comment)
+		default:
+			return nil, err
+		}
+	}
+}
+
+// anyClosed returns a channel that is closed when either of the first
+// two channels is closed, unless stop is closed first.
+func anyClosed(a, b, stop <-chan struct{}) <-chan struct{} {
+	result := make(chan struct{})
+	go func() {
+		select {
+		case <-a:
+		case <-b:
+		case <-stop:
+			return
+		}
+		close(result)
+	}()
+	return result
+}
+
+// HolderInfo describes the worker holding the machine lock.
+type HolderInfo struct {
+	Agent   string
+	Worker  string
+	Comment string
+	Held    time.Duration
+}
+
+// String returns a short description of the holder.
+func (h HolderInfo) String() string {
+	msg := h.Agent + ": " + h.Worker
+	if h.Comment != "" {
+		msg += " (" + h.Comment + ")"
+	}
+	return msg
+}
+
+// Holder returns the worker holding the machine lock, if any of the
+// agents in this process hold it.
+func (c *lock) Holder() (HolderInfo, bool) {
+	holder := c.queue.currentHolder()
+	if holder == nil {
+		return HolderInfo{}, false
+	}
+	return HolderInfo{
+		Agent:   holder.agent,
+		Worker:  holder.worker,
+		Comment: holder.comment,
+		Held:    c.clock.Now().Sub(holder.acquired),
+	}, true
+}
+
 func (c *lock) writeLogEntry() {
 	// At the time this method is called, the holder is still set and the lock's
 	// mutex is held.
@@ -216,10 +308,14 @@ func (c *lock) writeLogEntry() {
 }
 
 type info struct {
+	// agent is the agent whose worker wants or has the lock.
+	agent string
 	// worker is the worker that wants or has the lock.
 	worker string
 	// comment is provided by the worker to say what they are doing.
 	comment string
+	// priority orders the worker in the wait queue.
+	priority Priority
 	// stack trace for additional debugging
 	stack string
 
@@ -239,10 +335,11 @@ type lock struct {
 
 	spec mutex.Spec
 
+	// queue is shared with the other agents in this process.
+	queue *waitQueue
+
 	mu      sync.Mutex
-	next    int
 	holder  *info
-	waiting map[int]*info
 	history *deque.Deque
 }
 
@@ -264,8 +361,10 @@ func contains(opts []ReportOption, opt ReportOption) bool {
 }
 
 type reportInfo struct {
-	Worker  string `yaml:"worker"`
-	Comment string `yaml:"comment,omitempty"`
+	Agent    string `yaml:"agent,omitempty"`
+	Worker   string `yaml:"worker"`
+	Comment  string `yaml:"comment,omitempty"`
+	Priority string `yaml:"priority,omitempty"`
 
 	Requested string `yaml:"requested,omitempty"`
 	Acquired  string `yaml:"acquired,omitempty"`
@@ -291,19 +390,20 @@ func (c *lock) Report(opts ...ReportOption) (string, error) {
 	defer c.mu.Unlock()
 	now := c.clock.Now()
 
+	// The holder and waiters include the workers of the other agents
+	// in this process, as they are all queued for the same lock.
 	r := report{
-		Holder: displayInfo(c.holder, includeStack, detailsYAML, now),
+		Holder: c.displayInfo(c.queue.currentHolder(), includeStack, detailsYAML, now),
 	}
-	// Show the waiting with oldest first, which will have the smallest
-	// map key.
-	for _, key := range sortedKeys(c.waiting) {
-		r.Waiting = append(r.Waiting, displayInfo(c.waiting[key], includeStack, detailsYAML, now))
+	// Show the waiting in the order they will get the lock.
+	for _, waiting := range c.queue.waiting() {
+		r.Waiting = append(r.Waiting, c.displayInfo(waiting, includeStack, detailsYAML, now))
 	}
 	if contains(opts, ShowHistory) {
 		iter := c.history.Iterator()
 		var v *info
 		for iter.Next(&v) {
-			r.History = append(r.History, displayInfo(v, includeStack, detailsYAML, now))
+			r.History = append(r.History, c.displayInfo(v, includeStack, detailsYAML, now))
 		}
 	}
 
@@ -315,15 +415,6 @@ func (c *lock) Report(opts ...ReportOption) (string, error) {
 	return string(out), nil
 }
 
-func sortedKeys(m map[int]*info) []int {
-	values := make([]int, 0, len(m))
-	for key := range m {
-		values = append(values, key)
-	}
-	sort.Ints(values)
-	return values
-}
-
 func timeOutput(t time.Time) string {
 	if t.IsZero() {
 		return ""
@@ -331,20 +422,29 @@ func timeOutput(t time.Time) string {
 	return t.String()
 }
 
-func displayInfo(info *info, includeStack, detailsYAML bool, now time.Time) interface{} {
+func (c *lock) displayInfo(info *info, includeStack, detailsYAML bool, now time.Time) interface{} {
+	// Only name the agent for the workers of other agents.
+	var agent string
+	if info != nil && info.agent != c.agent {
+		agent = info.agent
+	}
 	if !detailsYAML {
-		return simpleInfo("", info, now)
+		return simpleInfo(agent, info, now)
 	}
 	if info == nil {
 		return nil
 	}
 	output := reportInfo{
+		Agent:     agent,
 		Worker:    info.worker,
 		Comment:   info.comment,
 		Requested: timeOutput(info.requested),
 		Acquired:  timeOutput(info.acquired),
 		Released:  timeOutput(info.released),
 	}
+	if info.priority != PriorityNormal {
+		output.Priority = info.priority.String()
+	}
 	var other time.Time
 	if info.acquired.IsZero() {
 		other = now
@@ -370,14 +470,17 @@ func simpleInfo(agent string, info *info, now time.Time) string {
 	if info.comment != "" {
 		msg += " (" + info.comment + ")"
 	}
-	// We pass in agent when writing to the file, but not for the report.
-	// This allows us to have the agent in the file but keep the first column
-	// aligned for timestamps.
+	// We pass in agent when writing to the file, but not for the report
+	// unless the worker belongs to another agent. This allows us to have
+	// the agent in the file but keep the first column aligned for timestamps.
 	if agent != "" {
 		msg = agent + ": " + msg
 	}
 	if info.acquired.IsZero() {
 		waiting := now.Sub(info.requested).Round(time.Second)
+		if info.priority != PriorityNormal {
+			return fmt.Sprintf("%s, waiting %s, %s priority", msg, waiting, info.priority)
+		}
 		return fmt.Sprintf("%s, waiting %s", msg, waiting)
 	}
 	if info.released.IsZero() {
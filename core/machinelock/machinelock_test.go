@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
@@ -14,6 +15,8 @@ import (
 	"github.com/juju/mutex"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/core/machinelock"
@@ -24,6 +27,8 @@ import (
 type Lock interface {
 	Acquire(machinelock.Spec) (func(), error)
 	Report(...machinelock.ReportOption) (string, error)
+	Holder() (machinelock.HolderInfo, bool)
+	prometheus.Collector
 }
 
 type lockSuite struct {
@@ -35,6 +40,7 @@ type lockSuite struct {
 	notify       chan struct{}
 	allowAcquire chan struct{}
 	release      chan struct{}
+	cancelled    chan struct{}
 }
 
 var _ = gc.Suite(&lockSuite{})
@@ -48,13 +54,14 @@ func (s *lockSuite) SetUpTest(c *gc.C) {
 	s.notify = make(chan struct{})
 	s.allowAcquire = make(chan struct{})
 	s.release = make(chan struct{})
+	s.cancelled = make(chan struct{}, 10)
 
 	lock, err := machinelock.NewTestLock(machinelock.Config{
 		AgentName:   "test",
 		Clock:       s.clock,
 		Logger:      loggo.GetLogger("test"),
 		LogFilename: s.logfile,
-	}, s.acquireLock)
+	}, s.acquireLock())
 	c.Assert(err, jc.ErrorIsNil)
 	s.lock = lock
 
@@ -227,16 +234,214 @@ func (s *lockSuite) TestLogfileOutput(c *gc.C) {
 `[1:])
 }
 
-func (s *lockSuite) addWaiting(c *gc.C, worker, comment string) {
+func (s *lockSuite) TestPriorityOrder(c *gc.C) {
+	// Each new waiter makes the lower priority one stop trying.
+	s.addWaitingPriority(c, "worker", "low", machinelock.PriorityLow)
+	s.clock.Advance(time.Minute)
+	s.addWaiting(c, "worker", "normal")
+	s.waitCancelled(c)
+	s.clock.Advance(time.Minute)
+	s.addWaitingPriority(c, "worker", "high", machinelock.PriorityHigh)
+	s.waitCancelled(c)
+	s.clock.Advance(time.Minute)
+
+	output, err := s.lock.Report()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(output, gc.Equals, `
+test:
+  holder: none
+  waiting:
+  - worker (high), waiting 1m0s, high priority
+  - worker (normal), waiting 2m0s
+  - worker (low), waiting 3m0s, low priority
+`[1:])
+
+	output, err = s.lock.Report(machinelock.ShowDetailsYAML)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(output, gc.Equals, `
+test:
+  holder: null
+  waiting:
+  - worker: worker
+    comment: high
+    priority: high
+    requested: 2018-07-10 12:02:00 +0000 UTC
+    wait-time: 1m0s
+  - worker: worker
+    comment: normal
+    requested: 2018-07-10 12:01:00 +0000 UTC
+    wait-time: 2m0s
+  - worker: worker
+    comment: low
+    priority: low
+    requested: 2018-07-10 12:00:00 +0000 UTC
+    wait-time: 3m0s
+`[1:])
+
+	// Only the high priority worker is trying to take the lock. Once it
+	// has it, the normal priority worker tries next.
+	s.allowAcquire <- struct{}{}
+	s.waitNotify(c)
+	output, err = s.lock.Report()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(output, gc.Equals, `
+test:
+  holder: worker (high), holding 0s
+  waiting:
+  - worker (normal), waiting 2m0s
+  - worker (low), waiting 3m0s, low priority
+`[1:])
+}
+
+func (s *lockSuite) TestCancelWhileHeldBack(c *gc.C) {
+	s.addWaitingPriority(c, "worker", "high", machinelock.PriorityHigh)
+
+	cancel := make(chan struct{})
+	errs := make(chan error)
 	go func() {
 		_, err := s.lock.Acquire(machinelock.Spec{
+			Cancel:   cancel,
+			Worker:   "worker",
+			Priority: machinelock.PriorityLow,
+		})
+		errs <- err
+	}()
+	close(cancel)
+	select {
+	case err := <-errs:
+		c.Assert(errors.Cause(err), gc.Equals, mutex.ErrCancelled)
+	case <-time.After(jujutesting.LongWait):
+		c.Fatal("acquire not cancelled")
+	}
+}
+
+func (s *lockSuite) TestOtherAgentsInProcess(c *gc.C) {
+	other, err := machinelock.NewTestLock(machinelock.Config{
+		AgentName:      "other",
+		Clock:          s.clock,
+		Logger:         loggo.GetLogger("test"),
+		LogFilename:    s.logfile,
+		ShareQueueWith: s.lock,
+	}, s.acquireLock())
+	c.Assert(err, jc.ErrorIsNil)
+
+	releaser := make(chan func())
+	go func() {
+		r, err := other.Acquire(machinelock.Spec{
 			Cancel:  make(chan struct{}),
-			Worker:  worker,
-			Comment: comment,
+			Worker:  "uniter",
+			Comment: "install",
 		})
 		c.Check(err, jc.ErrorIsNil)
+		releaser <- r
 	}()
+	s.waitNotify(c)
+	s.allowAcquire <- struct{}{}
+	release := <-releaser
+	s.addWaiting(c, "worker", "")
+	s.clock.Advance(time.Minute)
+
+	output, err := s.lock.Report()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(output, gc.Equals, `
+test:
+  holder: 'other: uniter (install), holding 1m0s'
+  waiting:
+  - worker, waiting 1m0s
+`[1:])
+
+	holder, ok := s.lock.Holder()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(holder, jc.DeepEquals, machinelock.HolderInfo{
+		Agent:   "other",
+		Worker:  "uniter",
+		Comment: "install",
+		Held:    time.Minute,
+	})
+	c.Assert(holder.String(), gc.Equals, "other: uniter (install)")
 
+	release()
+	_, ok = s.lock.Holder()
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *lockSuite) TestLocksHaveSeparateQueues(c *gc.C) {
+	other, err := machinelock.NewTestLock(machinelock.Config{
+		AgentName:   "other",
+		Clock:       s.clock,
+		Logger:      loggo.GetLogger("test"),
+		LogFilename: s.logfile,
+	}, s.acquireLock())
+	c.Assert(err, jc.ErrorIsNil)
+
+	releaser := make(chan func())
+	go func() {
+		r, err := other.Acquire(machinelock.Spec{
+			Cancel:  make(chan struct{}),
+			Worker:  "uniter",
+			Comment: "install",
+		})
+		c.Check(err, jc.ErrorIsNil)
+		releaser <- r
+	}()
+	s.waitNotify(c)
+	s.allowAcquire <- struct{}{}
+	release := <-releaser
+	defer release()
+
+	_, ok := s.lock.Holder()
+	c.Assert(ok, jc.IsFalse)
+	_, ok = other.Holder()
+	c.Assert(ok, jc.IsTrue)
+}
+
+func (s *lockSuite) TestMetrics(c *gc.C) {
+	s.addHistory(c, "uniter", "config-changed", "2018-07-21 15:36:01", time.Second, time.Minute)
+	s.addAcquired(c, "uniter", "update-status", 2*time.Second)
+	s.addWaiting(c, "meterstatus", "")
+	s.clock.Advance(5 * time.Second)
+
+	err := testutil.CollectAndCompare(s.lock, strings.NewReader(`
+# HELP juju_machine_lock_held_seconds How long the current holder has held the machine lock
+# TYPE juju_machine_lock_held_seconds gauge
+juju_machine_lock_held_seconds 5
+# HELP juju_machine_lock_waiting Number of workers waiting for the machine lock
+# TYPE juju_machine_lock_waiting gauge
+juju_machine_lock_waiting 1
+`[1:]), "juju_machine_lock_held_seconds", "juju_machine_lock_waiting")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// One series for each worker and priority.
+	c.Assert(testutil.CollectAndCount(s.lock, "juju_machine_lock_wait_seconds"), gc.Equals, 1)
+	c.Assert(testutil.CollectAndCount(s.lock, "juju_machine_lock_hold_seconds"), gc.Equals, 1)
+}
+
+func (s *lockSuite) addWaiting(c *gc.C, worker, comment string) {
+	s.addWaitingPriority(c, worker, comment, machinelock.PriorityNormal)
+}
+
+func (s *lockSuite) addWaitingPriority(c *gc.C, worker, comment string, priority machinelock.Priority) {
+	go func() {
+		_, err := s.lock.Acquire(machinelock.Spec{
+			Cancel:   make(chan struct{}),
+			Worker:   worker,
+			Comment:  comment,
+			Priority: priority,
+		})
+		c.Check(err, jc.ErrorIsNil)
+	}()
+	s.waitNotify(c)
+}
+
+func (s *lockSuite) waitCancelled(c *gc.C) {
+	select {
+	case <-s.cancelled:
+	case <-time.After(jujutesting.LongWait):
+		c.Fatal("lock acquire wasn't cancelled")
+	}
+}
+
+func (s *lockSuite) waitNotify(c *gc.C) {
 	select {
 	case <-s.notify:
 	case <-time.After(jujutesting.LongWait):
@@ -289,14 +494,21 @@ func (s *lockSuite) addHistory(c *gc.C, worker, comment string, released string,
 	releaser()
 }
 
-func (s *lockSuite) acquireLock(spec mutex.Spec) (mutex.Releaser, error) {
-	s.notify <- struct{}{}
-	select {
-	case <-s.allowAcquire:
-	case <-spec.Cancel:
-		return nil, errors.New("cancelled")
+// acquireLock returns the acquire func for the test's locks. It uses
+// the test's channels, as lower priority waiters left behind by a test
+// may try again after it ends.
+func (s *lockSuite) acquireLock() func(mutex.Spec) (mutex.Releaser, error) {
+	notify, allowAcquire, cancelled := s.notify, s.allowAcquire, s.cancelled
+	return func(spec mutex.Spec) (mutex.Releaser, error) {
+		notify <- struct{}{}
+		select {
+		case <-allowAcquire:
+		case <-spec.Cancel:
+			cancelled <- struct{}{}
+			return nil, errors.New("cancelled")
+		}
+		return noOpReleaser{}, nil
 	}
-	return noOpReleaser{}, nil
 }
 
 type noOpReleaser struct{}
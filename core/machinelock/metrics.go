@@ -0,0 +1,86 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinelock
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	metricsNamespace = "juju_machine_lock"
+)
+
+var (
+	// secondsBuckets spans the time taken by the quickest hooks up to
+	// the time taken by a slow charm install.
+	secondsBuckets = []float64{0.1, 0.5, 1, 5, 10, 30, 60, 300, 600, 1800, 3600}
+
+	waitingDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "waiting"),
+		"Number of workers waiting for the machine lock",
+		nil, nil,
+	)
+	heldDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "held_seconds"),
+		"How long the current holder has held the machine lock",
+		nil, nil,
+	)
+)
+
+// metricsCollector holds the histograms of the times that workers wait
+// for and hold the machine lock.
+type metricsCollector struct {
+	waitTime *prometheus.HistogramVec
+	holdTime *prometheus.HistogramVec
+}
+
+func newMetricsCollector() *metricsCollector {
+	return &metricsCollector{
+		waitTime: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "wait_seconds",
+			Help:      "Time workers waited to acquire the machine lock",
+			Buckets:   secondsBuckets,
+		}, []string{
+			"worker",   // uniter, meterstatus, juju-exec...
+			"priority", // low, normal or high
+		}),
+		holdTime: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "hold_seconds",
+			Help:      "Time workers held the machine lock",
+			Buckets:   secondsBuckets,
+		}, []string{
+			"worker",
+		}),
+	}
+}
+
+func (c *metricsCollector) observeWait(info *info) {
+	wait := info.acquired.Sub(info.requested).Seconds()
+	c.waitTime.WithLabelValues(info.worker, info.priority.String()).Observe(wait)
+}
+
+func (c *metricsCollector) observeHold(info *info) {
+	hold := info.released.Sub(info.acquired).Seconds()
+	c.holdTime.WithLabelValues(info.worker).Observe(hold)
+}
+
+// Describe is part of prometheus.Collector.
+func (c *lock) Describe(ch chan<- *prometheus.Desc) {
+	c.queue.metrics.waitTime.Describe(ch)
+	c.queue.metrics.holdTime.Describe(ch)
+	ch <- waitingDesc
+	ch <- heldDesc
+}
+
+// Collect is part of prometheus.Collector. The metrics cover all of the
+// agents in this process.
+func (c *lock) Collect(ch chan<- prometheus.Metric) {
+	c.queue.metrics.waitTime.Collect(ch)
+	c.queue.metrics.holdTime.Collect(ch)
+	waiting, held := c.queue.status(c.clock.Now())
+	ch <- prometheus.MustNewConstMetric(waitingDesc, prometheus.GaugeValue, float64(waiting))
+	ch <- prometheus.MustNewConstMetric(heldDesc, prometheus.GaugeValue, held.Seconds())
+}
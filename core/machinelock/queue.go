@@ -0,0 +1,187 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinelock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Priority orders the workers waiting for the machine lock. While a
+// worker is waiting, no worker of a lower priority will attempt to take
+// the lock, so operator initiated work isn't stuck behind routine hooks.
+type Priority int
+
+const (
+	// PriorityLow is for routine work, such as the update-status hook,
+	// that can wait until everything else is done.
+	PriorityLow Priority = -1
+
+	// PriorityNormal is the priority of a Spec that doesn't ask for
+	// anything else.
+	PriorityNormal Priority = 0
+
+	// PriorityHigh is for work an operator is waiting on, such as
+	// actions and juju-exec commands.
+	PriorityHigh Priority = 1
+)
+
+// String returns the name of the priority.
+func (p Priority) String() string {
+	switch {
+	case p < PriorityNormal:
+		return "low"
+	case p > PriorityNormal:
+		return "high"
+	}
+	return "normal"
+}
+
+// waiter is an entry in the wait queue.
+type waiter struct {
+	id   int
+	info *info
+
+	// yield is closed to tell the waiter to stop trying to take
+	// the lock because a higher priority waiter has joined the
+	// queue. It is nil while the waiter isn't trying.
+	yield chan struct{}
+}
+
+// waitQueue orders the waiters for the machine lock. Units are run in
+// the machine agent process, so each of them has its own lock, but they
+// share the wait queue of the machine agent's lock.
+//
+// The underlying mutex is also taken by other processes, such as
+// juju-exec, but these don't take part in the ordering.
+type waitQueue struct {
+	mu      sync.Mutex
+	next    int
+	waiters map[*waiter]bool
+	holder  *info
+
+	// changed is closed and replaced when a waiter leaves the queue,
+	// to wake the waiters that were held back by it.
+	changed chan struct{}
+
+	metrics *metricsCollector
+}
+
+func newWaitQueue() *waitQueue {
+	return &waitQueue{
+		waiters: make(map[*waiter]bool),
+		changed: make(chan struct{}),
+		metrics: newMetricsCollector(),
+	}
+}
+
+// join adds a waiter to the queue for the lock. Waiters of a lower
+// priority that are trying to take the lock are told to yield.
+func (q *waitQueue) join(info *info) *waiter {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	w := &waiter{id: q.next, info: info}
+	q.next++
+	q.waiters[w] = true
+	for other := range q.waiters {
+		if other.yield != nil && other.info.priority < info.priority {
+			close(other.yield)
+			other.yield = nil
+		}
+	}
+	return w
+}
+
+// attempt reports whether the waiter may try to take the lock now. If
+// it may, the returned yield channel is closed when it should stop
+// trying. If not, the returned changed channel is closed when the
+// queue has changed and the waiter should ask again.
+func (q *waitQueue) attempt(w *waiter) (yield, changed <-chan struct{}) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for other := range q.waiters {
+		if other.info.priority > w.info.priority {
+			return nil, q.changed
+		}
+	}
+	w.yield = make(chan struct{})
+	return w.yield, nil
+}
+
+// attempted records that the waiter has stopped trying to take the lock.
+func (q *waitQueue) attempted(w *waiter) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	w.yield = nil
+}
+
+// leave removes the waiter from the queue, recording it as the holder
+// of the lock if it was acquired.
+func (q *waitQueue) leave(w *waiter, acquired bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(q.waiters, w)
+	close(q.changed)
+	q.changed = make(chan struct{})
+	if acquired {
+		q.holder = w.info
+		q.metrics.observeWait(w.info)
+	}
+}
+
+// released records that the holder has released the lock.
+func (q *waitQueue) released(info *info) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.holder == info {
+		q.holder = nil
+	}
+	q.metrics.observeHold(info)
+}
+
+// currentHolder returns the holder of the lock in this process, if any.
+func (q *waitQueue) currentHolder() *info {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.holder
+}
+
+// waiting returns the waiters in the order they will be given the
+// lock: highest priority first, then oldest first.
+func (q *waitQueue) waiting() []*info {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	waiters := make([]*waiter, 0, len(q.waiters))
+	for w := range q.waiters {
+		waiters = append(waiters, w)
+	}
+	sort.Slice(waiters, func(i, j int) bool {
+		a, b := waiters[i], waiters[j]
+		if a.info.priority != b.info.priority {
+			return a.info.priority > b.info.priority
+		}
+		if !a.info.requested.Equal(b.info.requested) {
+			return a.info.requested.Before(b.info.requested)
+		}
+		return a.id < b.id
+	})
+	result := make([]*info, len(waiters))
+	for i, w := range waiters {
+		result[i] = w.info
+	}
+	return result
+}
+
+// status returns the number of waiters, and how long the current
+// holder has held the lock.
+func (q *waitQueue) status(now time.Time) (int, time.Duration) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var held time.Duration
+	if q.holder != nil {
+		held = now.Sub(q.holder.acquired)
+	}
+	return len(q.waiters), held
+}
@@ -32,6 +32,17 @@ type MigrationStatus struct {
 	// TargetInfo contains the details of how to connect to the target
 	// controller.
 	TargetInfo TargetInfo
+
+	// KeepSource holds how long the model is kept, dormant, on the
+	// source controller after a successful migration so that the
+	// migration can be reverted. It is zero if the model is removed
+	// straight away.
+	KeepSource time.Duration
+
+	// Revert is true if the migration moves the model back to the
+	// controller it was migrated from, where it has been kept
+	// dormant.
+	Revert bool
 }
 
 // SerializedModel wraps a buffer contain a serialised Juju model as
@@ -68,6 +79,11 @@ type ModelInfo struct {
 	Name                   string
 	AgentVersion           version.Number
 	ControllerAgentVersion version.Number
+
+	// CloudName and CloudRegion identify where the model is hosted.
+	// They are not reported by older controllers.
+	CloudName   string
+	CloudRegion string
 }
 
 func (i *ModelInfo) Validate() error {
@@ -21,6 +21,8 @@ const (
 	DONE
 	ABORT
 	ABORTDONE
+	DORMANT
+	REVERTED
 )
 
 var phaseNames = []string{
@@ -37,6 +39,8 @@ var phaseNames = []string{
 	"DONE",
 	"ABORT",
 	"ABORTDONE",
+	"DORMANT",  // The source model is kept after a successful migration so it can be reverted.
+	"REVERTED", // The migrated model's agents were moved back to the kept source model.
 }
 
 // Those phases are only used to get a complete successful round for testing purposes.
@@ -113,7 +117,8 @@ var validTransitions = map[Phase][]Phase{
 	PROCESSRELATIONS: {VALIDATION, ABORT},
 	VALIDATION:       {SUCCESS, ABORT},
 	SUCCESS:          {LOGTRANSFER},
-	LOGTRANSFER:      {REAP},
+	LOGTRANSFER:      {REAP, DORMANT},
+	DORMANT:          {REAP, REVERTED},
 	REAP:             {DONE, REAPFAILED},
 	ABORT:            {ABORTDONE},
 }
@@ -53,6 +53,8 @@ func (s *PhaseSuite) TestIsTerminal(c *gc.C) {
 	c.Check(migration.ABORTDONE.IsTerminal(), jc.IsTrue)
 	c.Check(migration.REAPFAILED.IsTerminal(), jc.IsTrue)
 	c.Check(migration.DONE.IsTerminal(), jc.IsTrue)
+	c.Check(migration.DORMANT.IsTerminal(), jc.IsFalse)
+	c.Check(migration.REVERTED.IsTerminal(), jc.IsTrue)
 }
 
 func (s *PhaseSuite) TestIsRunning(c *gc.C) {
@@ -70,6 +72,8 @@ func (s *PhaseSuite) TestIsRunning(c *gc.C) {
 	c.Check(migration.DONE.IsRunning(), jc.IsFalse)
 	c.Check(migration.ABORT.IsRunning(), jc.IsFalse)
 	c.Check(migration.ABORTDONE.IsRunning(), jc.IsFalse)
+	c.Check(migration.DORMANT.IsRunning(), jc.IsFalse)
+	c.Check(migration.REVERTED.IsRunning(), jc.IsFalse)
 }
 
 func (s *PhaseSuite) TestCanTransitionTo(c *gc.C) {
@@ -79,4 +83,8 @@ func (s *PhaseSuite) TestCanTransitionTo(c *gc.C) {
 	c.Check(migration.QUIESCE.CanTransitionTo(migration.PROCESSRELATIONS), jc.IsFalse)
 	c.Check(migration.QUIESCE.CanTransitionTo(migration.Phase(-1)), jc.IsFalse)
 	c.Check(migration.ABORT.CanTransitionTo(migration.QUIESCE), jc.IsFalse)
+	c.Check(migration.LOGTRANSFER.CanTransitionTo(migration.DORMANT), jc.IsTrue)
+	c.Check(migration.DORMANT.CanTransitionTo(migration.REAP), jc.IsTrue)
+	c.Check(migration.DORMANT.CanTransitionTo(migration.REVERTED), jc.IsTrue)
+	c.Check(migration.REVERTED.CanTransitionTo(migration.DORMANT), jc.IsFalse)
 }
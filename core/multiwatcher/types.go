@@ -375,6 +375,9 @@ type UnitInfo struct {
 	WorkloadStatus  StatusInfo
 	AgentStatus     StatusInfo
 	ContainerStatus StatusInfo // For CAAS models.
+	// HealthChecks holds the workload health checks reported by
+	// the charm, sorted by name.
+	HealthChecks []status.HealthCheck
 }
 
 // EntityID returns a unique identifier for a unit across
@@ -400,6 +403,9 @@ func (i *UnitInfo) Clone() EntityInfo {
 	for subnetID, rangeList := range i.PortRangesBySubnet {
 		clone.PortRangesBySubnet[subnetID] = append([]network.PortRange(nil), rangeList...)
 	}
+	if len(i.HealthChecks) > 0 {
+		clone.HealthChecks = append([]status.HealthCheck(nil), i.HealthChecks...)
+	}
 	return &clone
 }
 
@@ -0,0 +1,50 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+// InternalBackendType is the type of the backend which keeps secret
+// content in the controller database.
+const InternalBackendType = "internal"
+
+// BackendTypes returns the types of backend which may be configured
+// to store secret content. Each has a backend registered with the
+// controller.
+func BackendTypes() []string {
+	return []string{InternalBackendType}
+}
+
+// IsValidBackendType reports whether the backend type may be
+// configured to store secret content.
+func IsValidBackendType(backendType string) bool {
+	for _, t := range BackendTypes() {
+		if t == backendType {
+			return true
+		}
+	}
+	return false
+}
+
+// ValueRef refers to the content of a secret revision held by a backend.
+type ValueRef struct {
+	BackendType string
+	ID          string
+}
+
+// Backend stores secret content. The controller records which backend
+// holds the content of each secret revision, so backends only need to
+// store and return content by the ID they allocate.
+type Backend interface {
+	// Type returns the backend type, as recorded in ValueRef.
+	Type() string
+
+	// SaveContent stores the content of a secret revision and returns
+	// the ID it may be retrieved with.
+	SaveContent(uri *URI, revision int, data SecretData) (string, error)
+
+	// GetContent returns the content stored with the given ID.
+	GetContent(id string) (SecretData, error)
+
+	// DeleteContent removes the content stored with the given ID.
+	DeleteContent(id string) error
+}
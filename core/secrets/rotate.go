@@ -0,0 +1,66 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"time"
+)
+
+// RotatePolicy defines how often a secret's owner is asked to rotate it.
+type RotatePolicy string
+
+const (
+	RotateNever     = RotatePolicy("never")
+	RotateHourly    = RotatePolicy("hourly")
+	RotateDaily     = RotatePolicy("daily")
+	RotateWeekly    = RotatePolicy("weekly")
+	RotateMonthly   = RotatePolicy("monthly")
+	RotateQuarterly = RotatePolicy("quarterly")
+	RotateYearly    = RotatePolicy("yearly")
+)
+
+// IsValid returns true if p is a known rotate policy.
+func (p RotatePolicy) IsValid() bool {
+	switch p {
+	case RotateNever, RotateHourly, RotateDaily, RotateWeekly,
+		RotateMonthly, RotateQuarterly, RotateYearly:
+		return true
+	}
+	return false
+}
+
+// WillRotate returns true if the policy asks for rotation.
+func (p RotatePolicy) WillRotate() bool {
+	return p != "" && p != RotateNever
+}
+
+// NextRotateTime returns when a secret last rotated at the given time
+// should next be rotated, or nil if the policy never rotates.
+func (p RotatePolicy) NextRotateTime(lastRotated time.Time) *time.Time {
+	var next time.Time
+	switch p {
+	case RotateHourly:
+		next = lastRotated.Add(time.Hour)
+	case RotateDaily:
+		next = lastRotated.AddDate(0, 0, 1)
+	case RotateWeekly:
+		next = lastRotated.AddDate(0, 0, 7)
+	case RotateMonthly:
+		next = lastRotated.AddDate(0, 1, 0)
+	case RotateQuarterly:
+		next = lastRotated.AddDate(0, 3, 0)
+	case RotateYearly:
+		next = lastRotated.AddDate(1, 0, 0)
+	default:
+		return nil
+	}
+	return &next
+}
+
+// RotationInfo holds when a secret is next due to be rotated.
+type RotationInfo struct {
+	URI            *URI
+	RotatePolicy   RotatePolicy
+	NextRotateTime *time.Time
+}
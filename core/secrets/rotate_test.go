@@ -0,0 +1,51 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/secrets"
+)
+
+type RotateSuite struct{}
+
+var _ = gc.Suite(&RotateSuite{})
+
+func (s *RotateSuite) TestIsValid(c *gc.C) {
+	for _, policy := range []secrets.RotatePolicy{
+		secrets.RotateNever, secrets.RotateHourly, secrets.RotateDaily, secrets.RotateWeekly,
+		secrets.RotateMonthly, secrets.RotateQuarterly, secrets.RotateYearly,
+	} {
+		c.Check(policy.IsValid(), jc.IsTrue, gc.Commentf("%q", policy))
+	}
+	c.Assert(secrets.RotatePolicy("").IsValid(), jc.IsFalse)
+	c.Assert(secrets.RotatePolicy("fortnightly").IsValid(), jc.IsFalse)
+}
+
+func (s *RotateSuite) TestWillRotate(c *gc.C) {
+	c.Assert(secrets.RotateDaily.WillRotate(), jc.IsTrue)
+	c.Assert(secrets.RotateNever.WillRotate(), jc.IsFalse)
+	c.Assert(secrets.RotatePolicy("").WillRotate(), jc.IsFalse)
+}
+
+func (s *RotateSuite) TestNextRotateTime(c *gc.C) {
+	last := time.Date(2020, 7, 31, 10, 0, 0, 0, time.UTC)
+	for policy, expected := range map[secrets.RotatePolicy]time.Time{
+		secrets.RotateHourly:    time.Date(2020, 7, 31, 11, 0, 0, 0, time.UTC),
+		secrets.RotateDaily:     time.Date(2020, 8, 1, 10, 0, 0, 0, time.UTC),
+		secrets.RotateWeekly:    time.Date(2020, 8, 7, 10, 0, 0, 0, time.UTC),
+		secrets.RotateMonthly:   time.Date(2020, 8, 31, 10, 0, 0, 0, time.UTC),
+		secrets.RotateQuarterly: time.Date(2020, 10, 31, 10, 0, 0, 0, time.UTC),
+		secrets.RotateYearly:    time.Date(2021, 7, 31, 10, 0, 0, 0, time.UTC),
+	} {
+		next := policy.NextRotateTime(last)
+		c.Assert(next, gc.NotNil)
+		c.Check(*next, gc.Equals, expected, gc.Commentf("%q", policy))
+	}
+	c.Assert(secrets.RotateNever.NextRotateTime(last), gc.IsNil)
+}
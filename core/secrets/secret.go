@@ -0,0 +1,131 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+)
+
+// URIScheme is the scheme used for secret URIs.
+const URIScheme = "secret"
+
+// URI identifies a secret.
+type URI struct {
+	ID string
+}
+
+// NewURI returns a URI for a new secret.
+func NewURI() (*URI, error) {
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &URI{ID: uuid.String()}, nil
+}
+
+// ParseURI parses a secret URI of the form "secret:<id>".
+func ParseURI(str string) (*URI, error) {
+	id := strings.TrimPrefix(str, URIScheme+":")
+	if id == str || !utils.IsValidUUIDString(id) {
+		return nil, errors.NotValidf("secret URI %q", str)
+	}
+	return &URI{ID: id}, nil
+}
+
+// String returns the URI in the form "secret:<id>".
+func (u *URI) String() string {
+	if u == nil {
+		return ""
+	}
+	return URIScheme + ":" + u.ID
+}
+
+var keyRegExp = regexp.MustCompile("^[a-z](?:-?[a-z0-9]){2,}$")
+
+// SecretData holds the key values making up a secret's content.
+type SecretData map[string]string
+
+// Validate returns an error if the secret data is empty or has an
+// invalid key.
+func (d SecretData) Validate() error {
+	if len(d) == 0 {
+		return errors.NotValidf("empty secret data")
+	}
+	for key := range d {
+		if !keyRegExp.MatchString(key) {
+			return errors.NotValidf("secret key %q", key)
+		}
+	}
+	return nil
+}
+
+// SecretRole is an access role on a secret.
+type SecretRole string
+
+const (
+	// RoleNone means no access to a secret.
+	RoleNone = SecretRole("")
+
+	// RoleView allows the content of a secret to be read.
+	RoleView = SecretRole("view")
+
+	// RoleManage allows the secret to be updated and shared; it is
+	// held by the secret's owner.
+	RoleManage = SecretRole("manage")
+)
+
+// IsValid returns true if r is a role which can be granted.
+func (r SecretRole) IsValid() bool {
+	switch r {
+	case RoleView, RoleManage:
+		return true
+	}
+	return false
+}
+
+// Allowed returns true if r includes the wanted role.
+func (r SecretRole) Allowed(wanted SecretRole) bool {
+	if wanted == RoleNone {
+		return false
+	}
+	switch r {
+	case RoleManage:
+		return true
+	case RoleView:
+		return wanted == RoleView
+	}
+	return false
+}
+
+// SecretMetadata holds the metadata for a secret and its latest revision;
+// the secret content is held by a Backend.
+type SecretMetadata struct {
+	URI *URI
+
+	// OwnerTag is the tag of the application owning the secret.
+	OwnerTag string
+
+	Description string
+	Label       string
+
+	// LatestRevision is the revision of the secret's current content.
+	LatestRevision int
+
+	// LatestExpireTime is when the current content expires, if ever.
+	LatestExpireTime *time.Time
+
+	RotatePolicy RotatePolicy
+
+	// NextRotateTime is when the owner is next asked to rotate the
+	// secret, if ever.
+	NextRotateTime *time.Time
+
+	CreateTime time.Time
+	UpdateTime time.Time
+}
@@ -0,0 +1,75 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/secrets"
+)
+
+type SecretSuite struct{}
+
+var _ = gc.Suite(&SecretSuite{})
+
+func (s *SecretSuite) TestNewURI(c *gc.C) {
+	uri, err := secrets.NewURI()
+	c.Assert(err, jc.ErrorIsNil)
+	parsed, err := secrets.ParseURI(uri.String())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(parsed, jc.DeepEquals, uri)
+}
+
+func (s *SecretSuite) TestParseURI(c *gc.C) {
+	uri, err := secrets.ParseURI("secret:9c5bd8e5-1d1c-4b44-8b8f-5a6a2fa5de2b")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(uri.ID, gc.Equals, "9c5bd8e5-1d1c-4b44-8b8f-5a6a2fa5de2b")
+	c.Assert(uri.String(), gc.Equals, "secret:9c5bd8e5-1d1c-4b44-8b8f-5a6a2fa5de2b")
+}
+
+func (s *SecretSuite) TestParseURIInvalid(c *gc.C) {
+	for _, str := range []string{
+		"",
+		"9c5bd8e5-1d1c-4b44-8b8f-5a6a2fa5de2b",
+		"secret:",
+		"secret:foo",
+		"vault:9c5bd8e5-1d1c-4b44-8b8f-5a6a2fa5de2b",
+	} {
+		_, err := secrets.ParseURI(str)
+		c.Check(err, jc.Satisfies, errors.IsNotValid, gc.Commentf("%q", str))
+	}
+}
+
+func (s *SecretSuite) TestSecretDataValidate(c *gc.C) {
+	c.Assert(secrets.SecretData{"password": "s3cret", "api-key": "x"}.Validate(), jc.ErrorIsNil)
+	c.Assert(secrets.SecretData{}.Validate(), gc.ErrorMatches, "empty secret data not valid")
+	for _, key := range []string{"pw", "Password", "1password", "pass--word", "password-", "pass_word"} {
+		err := secrets.SecretData{key: "x"}.Validate()
+		c.Check(err, gc.ErrorMatches, `secret key ".*" not valid`, gc.Commentf("%q", key))
+	}
+}
+
+func (s *SecretSuite) TestRoleAllowed(c *gc.C) {
+	c.Assert(secrets.RoleManage.Allowed(secrets.RoleView), jc.IsTrue)
+	c.Assert(secrets.RoleManage.Allowed(secrets.RoleManage), jc.IsTrue)
+	c.Assert(secrets.RoleView.Allowed(secrets.RoleView), jc.IsTrue)
+	c.Assert(secrets.RoleView.Allowed(secrets.RoleManage), jc.IsFalse)
+	c.Assert(secrets.RoleNone.Allowed(secrets.RoleView), jc.IsFalse)
+	c.Assert(secrets.RoleView.Allowed(secrets.RoleNone), jc.IsFalse)
+}
+
+func (s *SecretSuite) TestRoleIsValid(c *gc.C) {
+	c.Assert(secrets.RoleView.IsValid(), jc.IsTrue)
+	c.Assert(secrets.RoleManage.IsValid(), jc.IsTrue)
+	c.Assert(secrets.RoleNone.IsValid(), jc.IsFalse)
+	c.Assert(secrets.SecretRole("admin").IsValid(), jc.IsFalse)
+}
+
+func (s *SecretSuite) TestIsValidBackendType(c *gc.C) {
+	c.Assert(secrets.IsValidBackendType(secrets.InternalBackendType), jc.IsTrue)
+	c.Assert(secrets.IsValidBackendType("vault"), jc.IsFalse)
+	c.Assert(secrets.IsValidBackendType(""), jc.IsFalse)
+}
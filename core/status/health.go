@@ -0,0 +1,93 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// HealthStatus is the outcome of a workload health check reported
+// by a charm.
+type HealthStatus string
+
+const (
+	// HealthPass indicates the check found the workload healthy.
+	HealthPass HealthStatus = "pass"
+
+	// HealthWarn indicates the check found a problem which does not
+	// yet stop the workload from working.
+	HealthWarn HealthStatus = "warn"
+
+	// HealthFail indicates the check found the workload unhealthy.
+	HealthFail HealthStatus = "fail"
+)
+
+// Validate returns an error if the health status is not known.
+func (s HealthStatus) Validate() error {
+	switch s {
+	case HealthPass, HealthWarn, HealthFail:
+		return nil
+	}
+	return errors.NotValidf("health status %q", s)
+}
+
+// severity orders health statuses from healthy to unhealthy.
+func (s HealthStatus) severity() int {
+	switch s {
+	case HealthPass:
+		return 1
+	case HealthWarn:
+		return 2
+	case HealthFail:
+		return 3
+	}
+	return 0
+}
+
+var healthCheckNameRegexp = regexp.MustCompile("^[a-z][a-z0-9]*(?:[-_][a-z0-9]+)*$")
+
+// IsValidHealthCheckName returns whether name is a valid health
+// check name.
+func IsValidHealthCheckName(name string) bool {
+	return healthCheckNameRegexp.MatchString(name)
+}
+
+// HealthCheck holds the latest result of a named health check
+// reported by a unit.
+type HealthCheck struct {
+	// Name identifies the check within the unit.
+	Name string
+
+	// Status is the outcome of the check.
+	Status HealthStatus
+
+	// Message optionally explains the outcome.
+	Message string
+
+	// Since is when the check was last reported.
+	Since time.Time
+}
+
+// Validate returns an error if the health check is not valid.
+func (c HealthCheck) Validate() error {
+	if !IsValidHealthCheckName(c.Name) {
+		return errors.NotValidf("health check name %q", c.Name)
+	}
+	return errors.Trace(c.Status.Validate())
+}
+
+// AggregateHealth returns the least healthy status of the given
+// checks, or an empty status if there are no checks.
+func AggregateHealth(checks []HealthCheck) HealthStatus {
+	var result HealthStatus
+	for _, check := range checks {
+		if check.Status.severity() > result.severity() {
+			result = check.Status
+		}
+	}
+	return result
+}
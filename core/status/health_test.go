@@ -0,0 +1,57 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/status"
+)
+
+type HealthSuite struct{}
+
+var _ = gc.Suite(&HealthSuite{})
+
+func (s *HealthSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		check status.HealthCheck
+		err   string
+	}{{
+		check: status.HealthCheck{Name: "db-connection", Status: status.HealthPass},
+	}, {
+		check: status.HealthCheck{Name: "disk_space", Status: status.HealthWarn, Message: "80% full"},
+	}, {
+		check: status.HealthCheck{Name: "", Status: status.HealthPass},
+		err:   `health check name "" not valid`,
+	}, {
+		check: status.HealthCheck{Name: "db.connection", Status: status.HealthPass},
+		err:   `health check name "db.connection" not valid`,
+	}, {
+		check: status.HealthCheck{Name: "Disk", Status: status.HealthPass},
+		err:   `health check name "Disk" not valid`,
+	}, {
+		check: status.HealthCheck{Name: "disk", Status: "ok"},
+		err:   `health status "ok" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		err := test.check.Validate()
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *HealthSuite) TestAggregateHealth(c *gc.C) {
+	pass := status.HealthCheck{Name: "a", Status: status.HealthPass}
+	warn := status.HealthCheck{Name: "b", Status: status.HealthWarn}
+	fail := status.HealthCheck{Name: "c", Status: status.HealthFail}
+
+	c.Check(status.AggregateHealth(nil), gc.Equals, status.HealthStatus(""))
+	c.Check(status.AggregateHealth([]status.HealthCheck{pass}), gc.Equals, status.HealthPass)
+	c.Check(status.AggregateHealth([]status.HealthCheck{pass, warn}), gc.Equals, status.HealthWarn)
+	c.Check(status.AggregateHealth([]status.HealthCheck{fail, pass, warn}), gc.Equals, status.HealthFail)
+}
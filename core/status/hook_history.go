@@ -0,0 +1,71 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// HookResult describes how a hook run on a unit ended.
+type HookResult string
+
+const (
+	// HookSucceeded is the result of a hook which exited successfully.
+	HookSucceeded HookResult = "succeeded"
+
+	// HookFailed is the result of a hook which exited with an error.
+	HookFailed HookResult = "failed"
+
+	// HookTimedOut is the result of a hook which was killed because it
+	// ran for longer than the model's hook timeout.
+	HookTimedOut HookResult = "timed-out"
+)
+
+// Validate returns an error if the result is not known.
+func (r HookResult) Validate() error {
+	switch r {
+	case HookSucceeded, HookFailed, HookTimedOut:
+		return nil
+	}
+	return errors.NotValidf("hook result %q", r)
+}
+
+// HookExecution records a single run of a hook on a unit.
+type HookExecution struct {
+	// Hook is the name of the hook that was run.
+	Hook string
+
+	// Started is when the hook started running.
+	Started time.Time
+
+	// Finished is when the hook stopped running.
+	Finished time.Time
+
+	// Result describes how the hook ended.
+	Result HookResult
+
+	// Message holds the error the hook failed with, if any.
+	Message string
+}
+
+// Duration returns how long the hook ran for.
+func (e HookExecution) Duration() time.Duration {
+	return e.Finished.Sub(e.Started)
+}
+
+// Validate returns an error if the hook execution is not valid.
+func (e HookExecution) Validate() error {
+	if e.Hook == "" {
+		return errors.NotValidf("empty hook name")
+	}
+	if e.Started.IsZero() || e.Finished.IsZero() {
+		return errors.NotValidf("hook %q execution without start and finish times", e.Hook)
+	}
+	if e.Finished.Before(e.Started) {
+		return errors.NotValidf("hook %q execution finishing before it started", e.Hook)
+	}
+	return errors.Trace(e.Result.Validate())
+}
@@ -0,0 +1,55 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/status"
+)
+
+type HookHistorySuite struct{}
+
+var _ = gc.Suite(&HookHistorySuite{})
+
+func (s *HookHistorySuite) TestDuration(c *gc.C) {
+	started := time.Date(2020, 7, 1, 10, 0, 0, 0, time.UTC)
+	execution := status.HookExecution{
+		Hook:     "install",
+		Started:  started,
+		Finished: started.Add(90 * time.Second),
+		Result:   status.HookSucceeded,
+	}
+	c.Assert(execution.Duration(), gc.Equals, 90*time.Second)
+	c.Assert(execution.Validate(), jc.ErrorIsNil)
+}
+
+func (s *HookHistorySuite) TestValidate(c *gc.C) {
+	started := time.Date(2020, 7, 1, 10, 0, 0, 0, time.UTC)
+	for i, test := range []struct {
+		execution status.HookExecution
+		err       string
+	}{{
+		execution: status.HookExecution{Started: started, Finished: started, Result: status.HookFailed},
+		err:       "empty hook name not valid",
+	}, {
+		execution: status.HookExecution{Hook: "install", Result: status.HookFailed},
+		err:       `hook "install" execution without start and finish times not valid`,
+	}, {
+		execution: status.HookExecution{Hook: "install", Started: started, Finished: started.Add(-time.Second), Result: status.HookFailed},
+		err:       `hook "install" execution finishing before it started not valid`,
+	}, {
+		execution: status.HookExecution{Hook: "install", Started: started, Finished: started, Result: "exploded"},
+		err:       `hook result "exploded" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		err := test.execution.Validate()
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
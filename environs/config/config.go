@@ -226,6 +226,16 @@ const (
 	// UpdateStatusHookInterval is how often to run the update-status hook.
 	UpdateStatusHookInterval = "update-status-hook-interval"
 
+	// HookTimeout is how long a hook may run before it is killed and
+	// the unit is put into error, eg "30m". Hooks are not timed out if
+	// it is unset or zero.
+	HookTimeout = "hook-timeout"
+
+	// MachineLockHoldAlarm is how long a worker may hold the machine
+	// lock before the machine's status warns that it is stuck, eg "1h".
+	// There is no alarm if it is unset or zero.
+	MachineLockHoldAlarm = "machine-lock-hold-alarm"
+
 	// EgressSubnets are the source addresses from which traffic from this model
 	// originates if the model is deployed such that NAT or similar is in use.
 	EgressSubnets = "egress-subnets"
@@ -661,6 +671,22 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if v, ok := cfg.defined[HookTimeout].(string); ok && v != "" {
+		if f, err := time.ParseDuration(v); err != nil {
+			return errors.Annotate(err, "invalid hook timeout in model configuration")
+		} else if f < 0 {
+			return errors.Errorf("hook timeout %v cannot be negative", f)
+		}
+	}
+
+	if v, ok := cfg.defined[MachineLockHoldAlarm].(string); ok && v != "" {
+		if f, err := time.ParseDuration(v); err != nil {
+			return errors.Annotate(err, "invalid machine lock hold alarm in model configuration")
+		} else if f < 0 {
+			return errors.Errorf("machine lock hold alarm %v cannot be negative", f)
+		}
+	}
+
 	if v, ok := cfg.defined[EgressSubnets].(string); ok && v != "" {
 		cidrs := strings.Split(v, ",")
 		for _, cidr := range cidrs {
@@ -1414,6 +1440,31 @@ func (c *Config) UpdateStatusHookInterval() time.Duration {
 	return val
 }
 
+// HookTimeout is how long a charm hook may run before it is killed.
+// Zero means hooks are not timed out.
+func (c *Config) HookTimeout() time.Duration {
+	raw := c.asString(HookTimeout)
+	if raw == "" {
+		return 0
+	}
+	// Value has already been validated.
+	val, _ := time.ParseDuration(raw)
+	return val
+}
+
+// MachineLockHoldAlarm is how long a worker may hold the machine lock
+// before the machine's status warns that it is stuck. Zero means there
+// is no alarm.
+func (c *Config) MachineLockHoldAlarm() time.Duration {
+	raw := c.asString(MachineLockHoldAlarm)
+	if raw == "" {
+		return 0
+	}
+	// Value has already been validated.
+	val, _ := time.ParseDuration(raw)
+	return val
+}
+
 // EgressSubnets are the source addresses from which traffic from this model
 // originates if the model is deployed such that NAT or similar is in use.
 func (c *Config) EgressSubnets() []string {
@@ -1583,6 +1634,8 @@ var alwaysOptional = schema.Defaults{
 	MaxActionResultsAge:           schema.Omit,
 	MaxActionResultsSize:          schema.Omit,
 	UpdateStatusHookInterval:      schema.Omit,
+	HookTimeout:                   schema.Omit,
+	MachineLockHoldAlarm:          schema.Omit,
 	EgressSubnets:                 schema.Omit,
 	FanConfig:                     schema.Omit,
 	CloudInitUserDataKey:          schema.Omit,
@@ -2066,6 +2119,16 @@ data of the store. (default false)`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	HookTimeout: {
+		Description: "How long a charm hook may run before it is killed and the unit put into error, in human-readable time format, eg 30m (default no timeout)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	MachineLockHoldAlarm: {
+		Description: "How long a worker may hold the machine lock before the machine status warns that it is stuck, in human-readable time format, eg 1h (default no alarm)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	EgressSubnets: {
 		Description: "Source address(es) for traffic originating from this model",
 		Type:        environschema.Tstring,
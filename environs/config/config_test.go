@@ -161,6 +161,27 @@ var configTests = []configTest{
 		attrs: minimalConfigAttrs.Merge(testing.Attrs{
 			"disable-network-management": true,
 		}),
+	}, {
+		about:       "Invalid hook-timeout",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"hook-timeout": "a while",
+		}),
+		err: `invalid hook timeout in model configuration: time: invalid duration "?a while"?`,
+	}, {
+		about:       "Negative hook-timeout",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"hook-timeout": "-5m",
+		}),
+		err: `hook timeout -5m0s cannot be negative`,
+	}, {
+		about:       "Invalid machine-lock-hold-alarm",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"machine-lock-hold-alarm": "ages",
+		}),
+		err: `invalid machine lock hold alarm in model configuration: time: invalid duration "?ages"?`,
 	}, {
 		about:       "Invalid ignore-machine-addresses flag",
 		useDefaults: config.UseDefaults,
@@ -1373,6 +1394,30 @@ func (s *ConfigSuite) TestUpdateStatusHookIntervalConfigValue(c *gc.C) {
 	c.Assert(cfg.UpdateStatusHookInterval(), gc.Equals, 30*time.Minute)
 }
 
+func (s *ConfigSuite) TestHookTimeoutConfigDefault(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.HookTimeout(), gc.Equals, time.Duration(0))
+}
+
+func (s *ConfigSuite) TestHookTimeoutConfigValue(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"hook-timeout": "30m",
+	})
+	c.Assert(cfg.HookTimeout(), gc.Equals, 30*time.Minute)
+}
+
+func (s *ConfigSuite) TestMachineLockHoldAlarmConfigDefault(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.MachineLockHoldAlarm(), gc.Equals, time.Duration(0))
+}
+
+func (s *ConfigSuite) TestMachineLockHoldAlarmConfigValue(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"machine-lock-hold-alarm": "1h",
+	})
+	c.Assert(cfg.MachineLockHoldAlarm(), gc.Equals, time.Hour)
+}
+
 func (s *ConfigSuite) TestEgressSubnets(c *gc.C) {
 	cfg := newTestConfig(c, testing.Attrs{
 		"egress-subnets": "10.0.0.1/32, 192.168.1.1/16",
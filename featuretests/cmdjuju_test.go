@@ -113,12 +113,44 @@ func (s *cmdJujuSuite) TestApplicationUnset(c *gc.C) {
 func (s *cmdJujuSuite) TestApplicationGetIAASModel(c *gc.C) {
 	expected := `application: dummy-application
 application-config:
+  hook-retry-max-attempts:
+    description: The most automatic retries of a failed hook, 0 for no limit
+    source: unset
+    type: int
+  hook-retry-max-interval:
+    description: The longest wait between retries of a failed hook, such as 5m
+    source: unset
+    type: string
+  hook-retry-min-interval:
+    description: The wait before a failed hook is first retried, such as 10s
+    source: unset
+    type: string
+  maintenance-window:
+    description: A cron expression for when hooks start being deferred
+    source: unset
+    type: string
+  maintenance-window-duration:
+    description: How long hooks are deferred for, such as 1h
+    source: unset
+    type: string
   trust:
     default: false
     description: Does this application have access to trusted credentials
     source: default
     type: bool
     value: false
+  unit-cpu-shares:
+    description: The relative CPU weight given to the hooks of each unit, from 2 to 262144
+    source: unset
+    type: int
+  unit-memory-limit:
+    description: The most memory the hooks of each unit, and the processes they start, may use, such as 512M or 2G
+    source: unset
+    type: string
+  unit-tasks-limit:
+    description: The most processes and threads the hooks of each unit may run
+    source: unset
+    type: int
 charm: dummy
 settings:
   outlook:
@@ -167,6 +199,18 @@ application-config:
     source: user
     type: string
     value: ext-host
+  kubernetes-autoscale-cpu:
+    description: target average CPU utilisation percentage when autoscaling
+    source: unset
+    type: int
+  kubernetes-autoscale-max:
+    description: maximum number of pods; setting it enables autoscaling
+    source: unset
+    type: int
+  kubernetes-autoscale-min:
+    description: minimum number of pods when autoscaling is enabled
+    source: unset
+    type: int
   kubernetes-ingress-allow-http:
     default: false
     description: whether to allow HTTP traffic to the ingress controller
@@ -191,6 +235,11 @@ application-config:
     source: default
     type: bool
     value: false
+  kubernetes-pod-disruption-max-unavailable:
+    description: number or percentage of pods that may be unavailable during voluntary
+      disruptions, such as node drains
+    source: unset
+    type: string
   kubernetes-service-annotations:
     description: a space separated set of annotations to add to the service
     source: unset
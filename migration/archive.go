@@ -0,0 +1,507 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/charm/v7"
+	"github.com/juju/description/v2"
+	"github.com/juju/errors"
+	"github.com/juju/version"
+
+	"github.com/juju/juju/api/common"
+	"github.com/juju/juju/apiserver/params"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/tools"
+)
+
+// A model archive is a tar file holding everything needed to recreate
+// a model on a controller which can't reach the controller the model
+// was exported from. It contains:
+//
+//	manifest.json             details of the export (modelArchiveManifest)
+//	model.yaml                the serialized model description
+//	charms/<charm URL>        the charm archives used by the model
+//	tools/<version>.tar.gz    the agent binaries used by the model
+//	resources/<app>/<name>    the application resources used by the model
+const (
+	modelArchiveVersion = 1
+
+	archiveManifestName = "manifest.json"
+	archiveModelName    = "model.yaml"
+	archiveCharmsDir    = "charms"
+	archiveToolsDir     = "tools"
+	archiveResourcesDir = "resources"
+)
+
+type modelArchiveManifest struct {
+	Version                int                    `json:"version"`
+	ControllerAgentVersion version.Number         `json:"controller-agent-version"`
+	Model                  params.SerializedModel `json:"model"`
+}
+
+func archiveCharmPath(curl string) string {
+	return path.Join(archiveCharmsDir, url.PathEscape(curl))
+}
+
+func archiveToolsPath(v version.Binary) string {
+	return path.Join(archiveToolsDir, v.String()+".tar.gz")
+}
+
+func archiveResourcePath(application, name string) string {
+	return path.Join(archiveResourcesDir, url.PathEscape(application), url.PathEscape(name))
+}
+
+// ExportArchiveConfig provides all the configuration that
+// WriteModelArchive needs to operate.
+type ExportArchiveConfig struct {
+	// Model is the serialized model as returned by the source
+	// controller.
+	Model params.SerializedModel
+
+	// ControllerAgentVersion is the agent version of the source
+	// controller, used by the target controller's prechecks.
+	ControllerAgentVersion version.Number
+
+	CharmDownloader    CharmDownloader
+	ToolsDownloader    ToolsDownloader
+	ResourceDownloader ResourceDownloader
+}
+
+// Validate makes sure that all the config values are non-nil.
+func (c *ExportArchiveConfig) Validate() error {
+	if len(c.Model.Bytes) == 0 {
+		return errors.NotValidf("empty Model")
+	}
+	if c.CharmDownloader == nil {
+		return errors.NotValidf("missing CharmDownloader")
+	}
+	if c.ToolsDownloader == nil {
+		return errors.NotValidf("missing ToolsDownloader")
+	}
+	if c.ResourceDownloader == nil {
+		return errors.NotValidf("missing ResourceDownloader")
+	}
+	return nil
+}
+
+// WriteModelArchive downloads the charms, agent binaries and resources
+// used by the exported model and writes them, along with the model
+// description, to w as a model archive which can be read by
+// ReadModelArchive.
+func WriteModelArchive(w io.Writer, config ExportArchiveConfig) error {
+	if err := config.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	serialized, err := common.SerializedModelFromParams(config.Model)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	// The tools URIs given by the source controller are replaced with
+	// the location of the binaries in the archive.
+	manifest := modelArchiveManifest{
+		Version:                modelArchiveVersion,
+		ControllerAgentVersion: config.ControllerAgentVersion,
+		Model:                  config.Model,
+	}
+	manifest.Model.Bytes = nil
+	manifest.Model.Tools = make([]params.SerializedModelTools, len(config.Model.Tools))
+	for i, t := range config.Model.Tools {
+		v, err := version.ParseBinary(t.Version)
+		if err != nil {
+			return errors.Annotate(err, "error parsing agent binary version")
+		}
+		manifest.Model.Tools[i] = params.SerializedModelTools{
+			Version: t.Version,
+			URI:     archiveToolsPath(v),
+		}
+	}
+	manifestBytes, err := json.Marshal(manifest)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	tw := tar.NewWriter(w)
+	if err := writeArchiveBytes(tw, archiveManifestName, manifestBytes); err != nil {
+		return errors.Trace(err)
+	}
+	if err := writeArchiveBytes(tw, archiveModelName, config.Model.Bytes); err != nil {
+		return errors.Trace(err)
+	}
+
+	for _, charmURL := range serialized.Charms {
+		logger.Debugf("adding charm %s to archive", charmURL)
+		curl, err := charm.ParseURL(charmURL)
+		if err != nil {
+			return errors.Annotate(err, "bad charm URL")
+		}
+		reader, err := config.CharmDownloader.OpenCharm(curl)
+		if err != nil {
+			return errors.Annotate(err, "cannot open charm")
+		}
+		err = writeArchiveStream(tw, archiveCharmPath(charmURL), reader)
+		if err != nil {
+			return errors.Annotatef(err, "cannot archive charm %s", charmURL)
+		}
+	}
+
+	for v, uri := range serialized.Tools {
+		logger.Debugf("adding agent binaries %s to archive", v)
+		reader, err := config.ToolsDownloader.OpenURI(uri, nil)
+		if err != nil {
+			return errors.Annotate(err, "cannot open agent binaries")
+		}
+		err = writeArchiveStream(tw, archiveToolsPath(v), reader)
+		if err != nil {
+			return errors.Annotatef(err, "cannot archive agent binaries %s", v)
+		}
+	}
+
+	for _, res := range serialized.Resources {
+		rev := res.ApplicationRevision
+		if rev.IsPlaceholder() {
+			continue
+		}
+		logger.Debugf("adding resource %s for %s to archive", rev.Name, rev.ApplicationID)
+		reader, err := config.ResourceDownloader.OpenResource(rev.ApplicationID, rev.Name)
+		if err != nil {
+			return errors.Annotate(err, "cannot open resource")
+		}
+		err = writeArchiveStream(tw, archiveResourcePath(rev.ApplicationID, rev.Name), reader)
+		if err != nil {
+			return errors.Annotatef(err, "cannot archive resource %s for %s", rev.Name, rev.ApplicationID)
+		}
+	}
+	return errors.Trace(tw.Close())
+}
+
+func writeArchiveBytes(tw *tar.Writer, name string, data []byte) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0644,
+		Size:     int64(len(data)),
+	}); err != nil {
+		return errors.Trace(err)
+	}
+	_, err := tw.Write(data)
+	return errors.Trace(err)
+}
+
+// writeArchiveStream writes the content of the reader, which is always
+// closed, to the archive. The content is staged in a temporary file as
+// the size must be known before it is written.
+func writeArchiveStream(tw *tar.Writer, name string, reader io.ReadCloser) error {
+	defer reader.Close()
+	content, cleanup, err := streamThroughTempFile(reader)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer cleanup()
+
+	size, err := content.Seek(0, io.SeekEnd)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return errors.Trace(err)
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0644,
+		Size:     size,
+	}); err != nil {
+		return errors.Trace(err)
+	}
+	_, err = io.Copy(tw, content)
+	return errors.Trace(err)
+}
+
+// ModelArchive is a model archive which has been extracted to a
+// temporary directory. It provides the charms, agent binaries and
+// resources it holds through the same interfaces used to download them
+// from a source controller during a migration.
+type ModelArchive struct {
+	dir string
+
+	// ControllerAgentVersion is the agent version of the controller
+	// the model was exported from.
+	ControllerAgentVersion version.Number
+
+	// Model is the serialized model held in the archive. The tools
+	// URIs refer to locations within the archive.
+	Model coremigration.SerializedModel
+}
+
+// ReadModelArchive extracts the model archive read from r, as written
+// by WriteModelArchive. The returned archive must be closed when it is
+// no longer needed.
+func ReadModelArchive(r io.Reader) (_ *ModelArchive, err error) {
+	dir, err := ioutil.TempDir("", "juju-model-archive")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	archive := &ModelArchive{dir: dir}
+	defer func() {
+		if err != nil {
+			_ = archive.Close()
+		}
+	}()
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, errors.Annotate(err, "reading model archive")
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			return nil, errors.NotValidf("model archive entry %q", hdr.Name)
+		}
+		target, err := archive.path(hdr.Name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+			return nil, errors.Trace(err)
+		}
+		f, err := os.OpenFile(target, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		_, err = io.Copy(f, tr)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	manifestBytes, err := ioutil.ReadFile(filepath.Join(dir, archiveManifestName))
+	if os.IsNotExist(err) {
+		return nil, errors.NotValidf("model archive without manifest")
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var manifest modelArchiveManifest
+	if err := json.Unmarshal(manifestBytes, &manifest); err != nil {
+		return nil, errors.Annotate(err, "reading model archive manifest")
+	}
+	if manifest.Version != modelArchiveVersion {
+		return nil, errors.NotSupportedf("model archive version %d", manifest.Version)
+	}
+	manifest.Model.Bytes, err = ioutil.ReadFile(filepath.Join(dir, archiveModelName))
+	if os.IsNotExist(err) {
+		return nil, errors.NotValidf("model archive without model")
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	archive.Model, err = common.SerializedModelFromParams(manifest.Model)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	archive.ControllerAgentVersion = manifest.ControllerAgentVersion
+	return archive, nil
+}
+
+// path returns the location of the named archive entry, ensuring it
+// doesn't escape the archive directory.
+func (a *ModelArchive) path(name string) (string, error) {
+	clean := path.Clean(name)
+	if path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", errors.NotValidf("model archive entry %q", name)
+	}
+	return filepath.Join(a.dir, filepath.FromSlash(clean)), nil
+}
+
+func (a *ModelArchive) open(name string) (io.ReadCloser, error) {
+	target, err := a.path(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	f, err := os.Open(target)
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("model archive entry %q", name)
+	}
+	return f, errors.Trace(err)
+}
+
+// OpenCharm implements CharmDownloader.
+func (a *ModelArchive) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	return a.open(archiveCharmPath(curl.String()))
+}
+
+// OpenURI implements ToolsDownloader. The URI is the location of the
+// agent binaries within the archive.
+func (a *ModelArchive) OpenURI(uri string, _ url.Values) (io.ReadCloser, error) {
+	return a.open(uri)
+}
+
+// OpenResource implements ResourceDownloader.
+func (a *ModelArchive) OpenResource(application, name string) (io.ReadCloser, error) {
+	return a.open(archiveResourcePath(application, name))
+}
+
+// Close removes the extracted archive.
+func (a *ModelArchive) Close() error {
+	return errors.Trace(os.RemoveAll(a.dir))
+}
+
+// ImportTarget describes the target controller API used to import a
+// model archive. It is satisfied by *migrationtarget.Client.
+type ImportTarget interface {
+	Prechecks(coremigration.ModelInfo) error
+	Import([]byte) error
+	Abort(string) error
+	Activate(string) error
+	CheckMachines(string) ([]error, error)
+	AdoptResources(string) error
+
+	UploadCharm(string, *charm.URL, io.ReadSeeker) (*charm.URL, error)
+	UploadTools(string, io.ReadSeeker, version.Binary, ...string) (tools.List, error)
+	UploadResource(string, resource.Resource, io.ReadSeeker) error
+	SetPlaceholderResource(string, resource.Resource) error
+	SetUnitResource(string, string, resource.Resource) error
+}
+
+// ImportModelArchive imports the model held in the archive into the
+// target controller, running the same steps as the IMPORT, VALIDATION
+// and SUCCESS phases of a live migration: the target prechecks, the
+// model import, the upload of its binaries, the machine checks,
+// activation and the adoption of its cloud resources. If the model
+// can't be activated the import is aborted. The imported model's
+// details are returned.
+func ImportModelArchive(archive *ModelArchive, target ImportTarget) (coremigration.ModelInfo, error) {
+	modelInfo, err := archiveModelInfo(archive)
+	if err != nil {
+		return modelInfo, errors.Trace(err)
+	}
+	modelUUID := modelInfo.UUID
+
+	if err := target.Prechecks(modelInfo); err != nil {
+		return modelInfo, errors.Annotate(err, "target prechecks failed")
+	}
+	logger.Infof("importing model %s", modelUUID)
+	if err := target.Import(archive.Model.Bytes); err != nil {
+		return modelInfo, errors.Annotate(err, "failed to import model into target controller")
+	}
+
+	abort := func(err error) error {
+		if abortErr := target.Abort(modelUUID); abortErr != nil {
+			logger.Errorf("aborting import of model %s: %v", modelUUID, abortErr)
+		}
+		return err
+	}
+
+	logger.Infof("uploading binaries for model %s", modelUUID)
+	uploader := &archiveUploader{target: target, modelUUID: modelUUID}
+	err = UploadBinaries(UploadBinariesConfig{
+		Charms:          archive.Model.Charms,
+		CharmDownloader: archive,
+		CharmUploader:   uploader,
+
+		Tools:           archive.Model.Tools,
+		ToolsDownloader: archive,
+		ToolsUploader:   uploader,
+
+		Resources:          archive.Model.Resources,
+		ResourceDownloader: archive,
+		ResourceUploader:   uploader,
+	})
+	if err != nil {
+		return modelInfo, abort(errors.Annotate(err, "failed to upload binaries"))
+	}
+
+	logger.Infof("checking machines in model %s", modelUUID)
+	machineErrs, err := target.CheckMachines(modelUUID)
+	if err != nil {
+		return modelInfo, abort(errors.Trace(err))
+	}
+	if len(machineErrs) > 0 {
+		msgs := make([]string, len(machineErrs))
+		for i, machineErr := range machineErrs {
+			msgs[i] = machineErr.Error()
+		}
+		return modelInfo, abort(errors.Errorf("machine sanity check failed:\n  %s", strings.Join(msgs, "\n  ")))
+	}
+
+	logger.Infof("activating model %s", modelUUID)
+	if err := target.Activate(modelUUID); err != nil {
+		return modelInfo, abort(errors.Annotate(err, "model activation failed"))
+	}
+	// There's no turning back once the model is active.
+	if err := target.AdoptResources(modelUUID); err != nil {
+		return modelInfo, errors.Annotate(err, "model imported but transferring ownership of cloud resources failed")
+	}
+	return modelInfo, nil
+}
+
+func archiveModelInfo(archive *ModelArchive) (coremigration.ModelInfo, error) {
+	var info coremigration.ModelInfo
+	model, err := description.Deserialize(archive.Model.Bytes)
+	if err != nil {
+		return info, errors.Annotate(err, "reading model description")
+	}
+	config := model.Config()
+	name, _ := config["name"].(string)
+	agentVersion, _ := config["agent-version"].(string)
+	info = coremigration.ModelInfo{
+		UUID:                   model.Tag().Id(),
+		Owner:                  model.Owner(),
+		Name:                   name,
+		ControllerAgentVersion: archive.ControllerAgentVersion,
+		CloudName:              model.Cloud(),
+		CloudRegion:            model.CloudRegion(),
+	}
+	if info.AgentVersion, err = version.Parse(agentVersion); err != nil {
+		return info, errors.Annotate(err, "reading model agent version")
+	}
+	return info, errors.Trace(info.Validate())
+}
+
+// archiveUploader adds the model UUID to the uploads made to the
+// import target.
+type archiveUploader struct {
+	target    ImportTarget
+	modelUUID string
+}
+
+// UploadCharm implements CharmUploader.
+func (u *archiveUploader) UploadCharm(curl *charm.URL, content io.ReadSeeker) (*charm.URL, error) {
+	return u.target.UploadCharm(u.modelUUID, curl, content)
+}
+
+// UploadTools implements ToolsUploader.
+func (u *archiveUploader) UploadTools(r io.ReadSeeker, vers version.Binary, additionalSeries ...string) (tools.List, error) {
+	return u.target.UploadTools(u.modelUUID, r, vers, additionalSeries...)
+}
+
+// UploadResource implements ResourceUploader.
+func (u *archiveUploader) UploadResource(res resource.Resource, content io.ReadSeeker) error {
+	return u.target.UploadResource(u.modelUUID, res, content)
+}
+
+// SetPlaceholderResource implements ResourceUploader.
+func (u *archiveUploader) SetPlaceholderResource(res resource.Resource) error {
+	return u.target.SetPlaceholderResource(u.modelUUID, res)
+}
+
+// SetUnitResource implements ResourceUploader.
+func (u *archiveUploader) SetUnitResource(unitName string, res resource.Resource) error {
+	return u.target.SetUnitResource(u.modelUUID, unitName, res)
+}
@@ -0,0 +1,384 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/juju/charm/v7"
+	"github.com/juju/description/v2"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/resource"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/tools"
+)
+
+type ArchiveSuite struct {
+	testing.IsolationSuite
+	model      []byte
+	serialized params.SerializedModel
+	downloader *fakeDownloader
+}
+
+var _ = gc.Suite(&ArchiveSuite{})
+
+func (s *ArchiveSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	model := description.NewModel(description.ModelArgs{
+		Type:  "iaas",
+		Owner: names.NewUserTag("bob"),
+		Config: map[string]interface{}{
+			"name":          "prod",
+			"uuid":          coretesting.ModelTag.Id(),
+			"agent-version": "2.8.0",
+		},
+		Cloud:       "aws",
+		CloudRegion: "us-east-1",
+	})
+	model.SetStatus(description.StatusArgs{Value: "available"})
+	var err error
+	s.model, err = description.Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+
+	timestamp := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	s.serialized = params.SerializedModel{
+		Bytes:  s.model,
+		Charms: []string{"cs:trusty/postgresql-42", "local:trusty/magic-2"},
+		Tools: []params.SerializedModelTools{{
+			Version: "2.8.0-trusty-amd64",
+			URI:     "/tools/2.8.0-trusty-amd64",
+		}},
+		Resources: []params.SerializedModelResource{{
+			Application: "app0",
+			Name:        "blob0",
+			ApplicationRevision: params.SerializedModelResourceRevision{
+				Revision:  1,
+				Type:      "file",
+				Origin:    "upload",
+				Path:      "blob0.tgz",
+				Size:      5,
+				Timestamp: timestamp,
+			},
+			CharmStoreRevision: params.SerializedModelResourceRevision{
+				Type:   "file",
+				Origin: "store",
+				Path:   "blob0.tgz",
+			},
+			UnitRevisions: map[string]params.SerializedModelResourceRevision{
+				"app0/0": {
+					Revision:  1,
+					Type:      "file",
+					Origin:    "upload",
+					Path:      "blob0.tgz",
+					Size:      5,
+					Timestamp: timestamp,
+				},
+			},
+		}, {
+			Application: "app1",
+			Name:        "blob1",
+			ApplicationRevision: params.SerializedModelResourceRevision{
+				Type:   "file",
+				Origin: "upload",
+				Path:   "blob1.tgz",
+			},
+			CharmStoreRevision: params.SerializedModelResourceRevision{
+				Type:   "file",
+				Origin: "store",
+				Path:   "blob1.tgz",
+			},
+		}},
+	}
+	s.downloader = &fakeDownloader{}
+}
+
+func (s *ArchiveSuite) writeArchive(c *gc.C) []byte {
+	var buf bytes.Buffer
+	err := migration.WriteModelArchive(&buf, migration.ExportArchiveConfig{
+		Model:                  s.serialized,
+		ControllerAgentVersion: version.MustParse("2.8.1"),
+		CharmDownloader:        s.downloader,
+		ToolsDownloader:        s.downloader,
+		ResourceDownloader:     s.downloader,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return buf.Bytes()
+}
+
+func (s *ArchiveSuite) readArchive(c *gc.C) *migration.ModelArchive {
+	archive, err := migration.ReadModelArchive(bytes.NewReader(s.writeArchive(c)))
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { c.Check(archive.Close(), jc.ErrorIsNil) })
+	return archive
+}
+
+func (s *ArchiveSuite) TestExportArchiveConfigValidate(c *gc.C) {
+	type T migration.ExportArchiveConfig
+	check := func(modify func(*T), missing string) {
+		config := T{
+			Model:              s.serialized,
+			CharmDownloader:    s.downloader,
+			ToolsDownloader:    s.downloader,
+			ResourceDownloader: s.downloader,
+		}
+		modify(&config)
+		realConfig := migration.ExportArchiveConfig(config)
+		c.Check(migration.WriteModelArchive(ioutil.Discard, realConfig), gc.ErrorMatches, missing+" not valid")
+	}
+
+	check(func(c *T) { c.Model = params.SerializedModel{} }, "empty Model")
+	check(func(c *T) { c.CharmDownloader = nil }, "missing CharmDownloader")
+	check(func(c *T) { c.ToolsDownloader = nil }, "missing ToolsDownloader")
+	check(func(c *T) { c.ResourceDownloader = nil }, "missing ResourceDownloader")
+}
+
+func (s *ArchiveSuite) TestWriteModelArchiveDownloads(c *gc.C) {
+	s.writeArchive(c)
+	c.Check(s.downloader.charms, jc.SameContents, s.serialized.Charms)
+	c.Check(s.downloader.uris, jc.DeepEquals, []string{"/tools/2.8.0-trusty-amd64"})
+	// Placeholder resources have no content to download.
+	c.Check(s.downloader.resources, jc.DeepEquals, []string{"app0/blob0"})
+}
+
+func (s *ArchiveSuite) TestReadModelArchive(c *gc.C) {
+	archive := s.readArchive(c)
+
+	c.Check(archive.ControllerAgentVersion, gc.Equals, version.MustParse("2.8.1"))
+	c.Check(archive.Model.Bytes, jc.DeepEquals, s.model)
+	c.Check(archive.Model.Charms, jc.DeepEquals, s.serialized.Charms)
+	c.Check(archive.Model.Tools, jc.DeepEquals, map[version.Binary]string{
+		version.MustParseBinary("2.8.0-trusty-amd64"): "tools/2.8.0-trusty-amd64.tar.gz",
+	})
+	c.Assert(archive.Model.Resources, gc.HasLen, 2)
+	c.Check(archive.Model.Resources[0].ApplicationRevision.Name, gc.Equals, "blob0")
+	c.Check(archive.Model.Resources[0].UnitRevisions, gc.HasLen, 1)
+	c.Check(archive.Model.Resources[1].ApplicationRevision.IsPlaceholder(), jc.IsTrue)
+
+	assertContent := func(r io.ReadCloser, err error, expected string) {
+		c.Assert(err, jc.ErrorIsNil)
+		defer r.Close()
+		data, err := ioutil.ReadAll(r)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(string(data), gc.Equals, expected)
+	}
+	r, err := archive.OpenCharm(charm.MustParseURL("local:trusty/magic-2"))
+	assertContent(r, err, "local:trusty/magic-2 content")
+	r, err = archive.OpenURI("tools/2.8.0-trusty-amd64.tar.gz", nil)
+	assertContent(r, err, "/tools/2.8.0-trusty-amd64")
+	r, err = archive.OpenResource("app0", "blob0")
+	assertContent(r, err, "blob0")
+
+	_, err = archive.OpenResource("app1", "blob1")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	_, err = archive.OpenURI("../../etc/passwd", nil)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ArchiveSuite) TestReadModelArchiveBadEntry(c *gc.C) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     "../escape",
+		Size:     1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = tw.Write([]byte("x"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tw.Close(), jc.ErrorIsNil)
+
+	_, err = migration.ReadModelArchive(&buf)
+	c.Assert(err, gc.ErrorMatches, `model archive entry "../escape" not valid`)
+}
+
+func (s *ArchiveSuite) TestReadModelArchiveNoManifest(c *gc.C) {
+	var buf bytes.Buffer
+	c.Assert(tar.NewWriter(&buf).Close(), jc.ErrorIsNil)
+	_, err := migration.ReadModelArchive(&buf)
+	c.Assert(err, gc.ErrorMatches, "model archive without manifest not valid")
+}
+
+func (s *ArchiveSuite) TestImportModelArchive(c *gc.C) {
+	archive := s.readArchive(c)
+	target := newFakeImportTarget()
+
+	info, err := migration.ImportModelArchive(archive, target)
+	c.Assert(err, jc.ErrorIsNil)
+
+	expectedInfo := coremigration.ModelInfo{
+		UUID:                   coretesting.ModelTag.Id(),
+		Owner:                  names.NewUserTag("bob"),
+		Name:                   "prod",
+		AgentVersion:           version.MustParse("2.8.0"),
+		ControllerAgentVersion: version.MustParse("2.8.1"),
+		CloudName:              "aws",
+		CloudRegion:            "us-east-1",
+	}
+	c.Check(info, jc.DeepEquals, expectedInfo)
+
+	uuid := coretesting.ModelTag.Id()
+	target.CheckCallNames(c,
+		"Prechecks", "Import",
+		"UploadCharm", "UploadCharm", "UploadTools",
+		"UploadResource", "SetUnitResource",
+		"CheckMachines", "Activate", "AdoptResources",
+	)
+	target.CheckCall(c, 0, "Prechecks", expectedInfo)
+	target.CheckCall(c, 1, "Import", s.model)
+	target.CheckCall(c, 7, "CheckMachines", uuid)
+	target.CheckCall(c, 8, "Activate", uuid)
+	target.CheckCall(c, 9, "AdoptResources", uuid)
+
+	c.Check(target.uploader.charms, jc.DeepEquals, []string{"cs:trusty/postgresql-42", "local:trusty/magic-2"})
+	c.Check(target.uploader.tools, jc.DeepEquals, map[version.Binary]string{
+		version.MustParseBinary("2.8.0-trusty-amd64"): "/tools/2.8.0-trusty-amd64",
+	})
+	c.Check(target.uploader.resources, jc.DeepEquals, map[string]string{"app0/blob0": "blob0"})
+	c.Check(target.uploader.unitResources, jc.DeepEquals, []string{"app0/0-blob0"})
+}
+
+func (s *ArchiveSuite) TestImportModelArchivePrechecksFail(c *gc.C) {
+	archive := s.readArchive(c)
+	target := newFakeImportTarget()
+	target.SetErrors(errors.New("boom"))
+
+	_, err := migration.ImportModelArchive(archive, target)
+	c.Assert(err, gc.ErrorMatches, "target prechecks failed: boom")
+	target.CheckCallNames(c, "Prechecks")
+}
+
+func (s *ArchiveSuite) TestImportModelArchiveMachineCheckFails(c *gc.C) {
+	archive := s.readArchive(c)
+	target := newFakeImportTarget()
+	target.machineErrs = []error{errors.New("machine 0 not found")}
+
+	_, err := migration.ImportModelArchive(archive, target)
+	c.Assert(err, gc.ErrorMatches, "machine sanity check failed:\n  machine 0 not found")
+	target.CheckCallNames(c,
+		"Prechecks", "Import",
+		"UploadCharm", "UploadCharm", "UploadTools",
+		"UploadResource", "SetUnitResource",
+		"CheckMachines", "Abort",
+	)
+	target.CheckCall(c, 8, "Abort", coretesting.ModelTag.Id())
+}
+
+func (s *ArchiveSuite) TestImportModelArchiveActivateFails(c *gc.C) {
+	archive := s.readArchive(c)
+	target := newFakeImportTarget()
+	target.SetErrors(
+		nil, nil, // Prechecks, Import
+		nil, nil, nil, nil, nil, // uploads
+		nil,                // CheckMachines
+		errors.New("boom"), // Activate
+	)
+
+	_, err := migration.ImportModelArchive(archive, target)
+	c.Assert(err, gc.ErrorMatches, "model activation failed: boom")
+	target.CheckCallNames(c,
+		"Prechecks", "Import",
+		"UploadCharm", "UploadCharm", "UploadTools",
+		"UploadResource", "SetUnitResource",
+		"CheckMachines", "Activate", "Abort",
+	)
+}
+
+type fakeImportTarget struct {
+	testing.Stub
+	uploader    *fakeUploader
+	machineErrs []error
+}
+
+func newFakeImportTarget() *fakeImportTarget {
+	return &fakeImportTarget{
+		uploader: &fakeUploader{
+			tools:     make(map[version.Binary]string),
+			resources: make(map[string]string),
+		},
+	}
+}
+
+func (t *fakeImportTarget) Prechecks(info coremigration.ModelInfo) error {
+	t.AddCall("Prechecks", info)
+	return t.NextErr()
+}
+
+func (t *fakeImportTarget) Import(bytes []byte) error {
+	t.AddCall("Import", bytes)
+	return t.NextErr()
+}
+
+func (t *fakeImportTarget) Abort(modelUUID string) error {
+	t.AddCall("Abort", modelUUID)
+	return t.NextErr()
+}
+
+func (t *fakeImportTarget) Activate(modelUUID string) error {
+	t.AddCall("Activate", modelUUID)
+	return t.NextErr()
+}
+
+func (t *fakeImportTarget) CheckMachines(modelUUID string) ([]error, error) {
+	t.AddCall("CheckMachines", modelUUID)
+	return t.machineErrs, t.NextErr()
+}
+
+func (t *fakeImportTarget) AdoptResources(modelUUID string) error {
+	t.AddCall("AdoptResources", modelUUID)
+	return t.NextErr()
+}
+
+func (t *fakeImportTarget) UploadCharm(modelUUID string, curl *charm.URL, r io.ReadSeeker) (*charm.URL, error) {
+	t.AddCall("UploadCharm", modelUUID, curl)
+	if err := t.NextErr(); err != nil {
+		return nil, err
+	}
+	return t.uploader.UploadCharm(curl, r)
+}
+
+func (t *fakeImportTarget) UploadTools(modelUUID string, r io.ReadSeeker, v version.Binary, series ...string) (tools.List, error) {
+	t.AddCall("UploadTools", modelUUID, v)
+	if err := t.NextErr(); err != nil {
+		return nil, err
+	}
+	return t.uploader.UploadTools(r, v, series...)
+}
+
+func (t *fakeImportTarget) UploadResource(modelUUID string, res resource.Resource, r io.ReadSeeker) error {
+	t.AddCall("UploadResource", modelUUID, res.Name)
+	if err := t.NextErr(); err != nil {
+		return err
+	}
+	return t.uploader.UploadResource(res, r)
+}
+
+func (t *fakeImportTarget) SetPlaceholderResource(modelUUID string, res resource.Resource) error {
+	t.AddCall("SetPlaceholderResource", modelUUID, res.Name)
+	if err := t.NextErr(); err != nil {
+		return err
+	}
+	return t.uploader.SetPlaceholderResource(res)
+}
+
+func (t *fakeImportTarget) SetUnitResource(modelUUID, unitName string, res resource.Resource) error {
+	t.AddCall("SetUnitResource", modelUUID, unitName)
+	if err := t.NextErr(); err != nil {
+		return err
+	}
+	return t.uploader.SetUnitResource(unitName, res)
+}
@@ -0,0 +1,115 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+
+	"github.com/juju/description/v2"
+	"github.com/juju/errors"
+)
+
+// ModelFingerprint summarises the parts of a model which its users
+// change: its applications, with their charms, config, scale, units and
+// offers, along with its machines, relations and remote applications.
+// Two exports of a model have the same fingerprint unless one of these
+// has changed in between, so a model kept after migrating away can be
+// checked against the migrated model before reverting the migration.
+func ModelFingerprint(model description.Model) (string, error) {
+	summary := modelSummary{
+		Machines:           machineIds(model.Machines()),
+		Relations:          []string{},
+		RemoteApplications: []string{},
+	}
+	for _, app := range model.Applications() {
+		summary.Applications = append(summary.Applications, summariseApplication(app))
+	}
+	sort.Slice(summary.Applications, func(i, j int) bool {
+		return summary.Applications[i].Name < summary.Applications[j].Name
+	})
+	for _, rel := range model.Relations() {
+		summary.Relations = append(summary.Relations, rel.Key())
+	}
+	sort.Strings(summary.Relations)
+	for _, app := range model.RemoteApplications() {
+		summary.RemoteApplications = append(summary.RemoteApplications, app.Name())
+	}
+	sort.Strings(summary.RemoteApplications)
+
+	// Maps are marshalled with sorted keys, so the same summary
+	// always gives the same bytes.
+	data, err := json.Marshal(summary)
+	if err != nil {
+		return "", errors.Annotate(err, "summarising model")
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// ExportModelFingerprint returns the fingerprint of the model exported
+// by the StateExporter, serialized and read back in just as a model
+// being migrated would be.
+func ExportModelFingerprint(st StateExporter) (string, error) {
+	bytes, err := ExportModel(st)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	model, err := description.Deserialize(bytes)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return ModelFingerprint(model)
+}
+
+type modelSummary struct {
+	Applications       []applicationSummary `json:"applications"`
+	Machines           []string             `json:"machines"`
+	Relations          []string             `json:"relations"`
+	RemoteApplications []string             `json:"remote-applications"`
+}
+
+type applicationSummary struct {
+	Name              string                       `json:"name"`
+	CharmURL          string                       `json:"charm-url"`
+	Exposed           bool                         `json:"exposed"`
+	DesiredScale      int                          `json:"desired-scale"`
+	CharmConfig       map[string]interface{}       `json:"charm-config"`
+	ApplicationConfig map[string]interface{}       `json:"application-config"`
+	Units             []string                     `json:"units"`
+	Offers            map[string]map[string]string `json:"offers"`
+}
+
+func summariseApplication(app description.Application) applicationSummary {
+	summary := applicationSummary{
+		Name:              app.Name(),
+		CharmURL:          app.CharmURL(),
+		Exposed:           app.Exposed(),
+		DesiredScale:      app.DesiredScale(),
+		CharmConfig:       app.CharmConfig(),
+		ApplicationConfig: app.ApplicationConfig(),
+		Units:             []string{},
+		Offers:            make(map[string]map[string]string),
+	}
+	for _, unit := range app.Units() {
+		summary.Units = append(summary.Units, unit.Name())
+	}
+	sort.Strings(summary.Units)
+	for _, offer := range app.Offers() {
+		summary.Offers[offer.OfferName()] = offer.Endpoints()
+	}
+	return summary
+}
+
+func machineIds(machines []description.Machine) []string {
+	ids := []string{}
+	for _, m := range machines {
+		ids = append(ids, m.Id())
+		ids = append(ids, machineIds(m.Containers())...)
+	}
+	sort.Strings(ids)
+	return ids
+}
@@ -0,0 +1,64 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration_test
+
+import (
+	"github.com/juju/description/v2"
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/testing"
+)
+
+type FingerprintSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&FingerprintSuite{})
+
+func (s *FingerprintSuite) makeModel(bar int, units ...string) description.Model {
+	model := description.NewModel(description.ModelArgs{Owner: modelOwner})
+	model.AddMachine(description.MachineArgs{Id: names.NewMachineTag("0")})
+	app := model.AddApplication(description.ApplicationArgs{
+		Tag:         names.NewApplicationTag("foo"),
+		CharmURL:    "cs:foo-1",
+		CharmConfig: map[string]interface{}{"bar": bar},
+	})
+	for _, unit := range units {
+		app.AddUnit(description.UnitArgs{Tag: names.NewUnitTag(unit)})
+	}
+	return model
+}
+
+func (s *FingerprintSuite) fingerprint(c *gc.C, model description.Model) string {
+	fingerprint, err := migration.ModelFingerprint(model)
+	c.Assert(err, jc.ErrorIsNil)
+	return fingerprint
+}
+
+func (s *FingerprintSuite) TestSameModel(c *gc.C) {
+	c.Assert(
+		s.fingerprint(c, s.makeModel(1, "foo/0", "foo/1")),
+		gc.Equals,
+		s.fingerprint(c, s.makeModel(1, "foo/1", "foo/0")),
+	)
+}
+
+func (s *FingerprintSuite) TestUnitAdded(c *gc.C) {
+	c.Assert(
+		s.fingerprint(c, s.makeModel(1, "foo/0")),
+		gc.Not(gc.Equals),
+		s.fingerprint(c, s.makeModel(1, "foo/0", "foo/1")),
+	)
+}
+
+func (s *FingerprintSuite) TestConfigChanged(c *gc.C) {
+	c.Assert(
+		s.fingerprint(c, s.makeModel(1, "foo/0")),
+		gc.Not(gc.Equals),
+		s.fingerprint(c, s.makeModel(2, "foo/0")),
+	)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/version"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cloud"
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/core/status"
@@ -33,8 +34,11 @@ type PrecheckBackend interface {
 	AllApplications() ([]PrecheckApplication, error)
 	AllRelations() ([]PrecheckRelation, error)
 	ControllerBackend() (PrecheckBackend, error)
+	Cloud(name string) (cloud.Cloud, error)
 	CloudCredential(tag names.CloudCredentialTag) (state.Credential, error)
+	Charm(*charm.URL) (PrecheckCharm, error)
 	ListPendingResources(string) ([]resource.Resource, error)
+	SecretsCount() (int, error)
 }
 
 // Pool defines the interface to a StatePool used by the migration
@@ -55,6 +59,12 @@ type PrecheckModel interface {
 	CloudCredentialTag() (names.CloudCredentialTag, bool)
 }
 
+// PrecheckCharm describes the state interface for a charm needed by
+// migration prechecks.
+type PrecheckCharm interface {
+	StoragePath() string
+}
+
 // PrecheckMachine describes the state interface for a machine needed
 // by migration prechecks.
 type PrecheckMachine interface {
@@ -110,6 +120,22 @@ type ModelPresence interface {
 	AgentStatus(agent string) (presence.Status, error)
 }
 
+// PrecheckReport holds every problem found by a complete run of the
+// migration prechecks, rather than just the first.
+type PrecheckReport struct {
+	// Blockers are problems which would prevent the migration.
+	Blockers []string
+
+	// Warnings are problems which wouldn't prevent the migration,
+	// but which should be considered before starting it.
+	Warnings []string
+}
+
+// Blocked returns true if the migration would be prevented.
+func (r *PrecheckReport) Blocked() bool {
+	return len(r.Blockers) > 0
+}
+
 // SourcePrecheck checks the state of the source controller to make
 // sure that the preconditions for model migration are met. The
 // backend provided must be for the model to be migrated.
@@ -118,7 +144,26 @@ func SourcePrecheck(
 	modelPresence ModelPresence,
 	controllerPresence ModelPresence,
 ) error {
-	ctx := precheckContext{backend, modelPresence}
+	ctx := precheckContext{backend: backend, presence: modelPresence}
+	return errors.Trace(ctx.sourcePrecheck(controllerPresence))
+}
+
+// SourcePrecheckReport runs the same checks as SourcePrecheck, but
+// rather than stopping at the first problem it reports all of them.
+// An error is returned only if the checks could not be run.
+func SourcePrecheckReport(
+	backend PrecheckBackend,
+	modelPresence ModelPresence,
+	controllerPresence ModelPresence,
+) (*PrecheckReport, error) {
+	ctx := precheckContext{backend: backend, presence: modelPresence, report: &PrecheckReport{}}
+	if err := ctx.sourcePrecheck(controllerPresence); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ctx.report, nil
+}
+
+func (ctx *precheckContext) sourcePrecheck(controllerPresence ModelPresence) error {
 	if err := ctx.checkModel(); err != nil {
 		return errors.Trace(err)
 	}
@@ -136,18 +181,35 @@ func SourcePrecheck(
 		return errors.Trace(err)
 	}
 
-	if cleanupNeeded, err := backend.NeedsCleanup(); err != nil {
+	// Secrets aren't exported yet, so a model which has any can't be
+	// migrated without losing them.
+	if count, err := ctx.backend.SecretsCount(); err != nil {
+		return errors.Annotate(err, "checking secrets")
+	} else if count > 0 {
+		if err := ctx.blockf("model has %d secrets, which can't be migrated", count); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	if cleanupNeeded, err := ctx.backend.NeedsCleanup(); err != nil {
 		return errors.Annotate(err, "checking cleanups")
 	} else if cleanupNeeded {
-		return errors.New("cleanup needed")
+		if err := ctx.block(errors.New("cleanup needed")); err != nil {
+			return errors.Trace(err)
+		}
 	}
 
 	// Check the source controller.
-	controllerBackend, err := backend.ControllerBackend()
+	controllerBackend, err := ctx.backend.ControllerBackend()
 	if err != nil {
 		return errors.Trace(err)
 	}
-	controllerCtx := precheckContext{controllerBackend, controllerPresence}
+	controllerCtx := precheckContext{
+		backend:  controllerBackend,
+		presence: controllerPresence,
+		report:   ctx.report,
+		label:    "controller",
+	}
 	if err := controllerCtx.checkController(); err != nil {
 		return errors.Annotate(err, "controller")
 	}
@@ -157,6 +219,46 @@ func SourcePrecheck(
 type precheckContext struct {
 	backend  PrecheckBackend
 	presence ModelPresence
+
+	// report, if set, collects the problems found so that all
+	// of the checks are run rather than stopping at the first.
+	report *PrecheckReport
+
+	// label is used to qualify problems added to the report.
+	label string
+}
+
+// block handles a problem which would prevent the migration. If a
+// report is being collected the problem is added to it and nil is
+// returned so that checking continues, otherwise the problem is
+// returned as an error.
+func (ctx *precheckContext) block(err error) error {
+	if ctx.report == nil {
+		return err
+	}
+	if ctx.label != "" {
+		err = errors.Annotate(err, ctx.label)
+	}
+	ctx.report.Blockers = append(ctx.report.Blockers, err.Error())
+	return nil
+}
+
+// warn adds a problem which wouldn't prevent the migration to the
+// report, if one is being collected.
+func (ctx *precheckContext) warn(format string, args ...interface{}) {
+	if ctx.report == nil {
+		return
+	}
+	msg := fmt.Sprintf(format, args...)
+	if ctx.label != "" {
+		msg = ctx.label + ": " + msg
+	}
+	ctx.report.Warnings = append(ctx.report.Warnings, msg)
+}
+
+// blockf is like block but takes a format string and arguments.
+func (ctx *precheckContext) blockf(format string, args ...interface{}) error {
+	return ctx.block(errors.Errorf(format, args...))
 }
 
 func (ctx *precheckContext) checkModel() error {
@@ -165,10 +267,14 @@ func (ctx *precheckContext) checkModel() error {
 		return errors.Annotate(err, "retrieving model")
 	}
 	if model.Life() != state.Alive {
-		return errors.Errorf("model is %s", model.Life())
+		if err := ctx.blockf("model is %s", model.Life()); err != nil {
+			return err
+		}
 	}
 	if model.MigrationMode() == state.MigrationModeImporting {
-		return errors.New("model is being imported as part of another migration")
+		if err := ctx.blockf("model is being imported as part of another migration"); err != nil {
+			return err
+		}
 	}
 	if credTag, found := model.CloudCredentialTag(); found {
 		creds, err := ctx.backend.CloudCredential(credTag)
@@ -176,7 +282,11 @@ func (ctx *precheckContext) checkModel() error {
 			return errors.Trace(err)
 		}
 		if creds.Revoked {
-			return errors.New("model has revoked credentials")
+			if err := ctx.blockf("model has revoked credentials"); err != nil {
+				return err
+			}
+		} else if creds.Invalid {
+			ctx.warn("model credential %q is not valid: %s", credTag.Id(), creds.InvalidReason)
 		}
 	}
 	return nil
@@ -186,6 +296,24 @@ func (ctx *precheckContext) checkModel() error {
 // sure that the preconditions for model migration are met. The
 // backend provided must be for the target controller.
 func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.ModelInfo, presence ModelPresence) error {
+	ctx := precheckContext{backend: backend, presence: presence}
+	return errors.Trace(ctx.targetPrecheck(pool, modelInfo))
+}
+
+// TargetPrecheckReport runs the same checks as TargetPrecheck, but
+// rather than stopping at the first problem it reports all of them.
+// An error is returned only if the checks could not be run.
+func TargetPrecheckReport(
+	backend PrecheckBackend, pool Pool, modelInfo coremigration.ModelInfo, presence ModelPresence,
+) (*PrecheckReport, error) {
+	ctx := precheckContext{backend: backend, presence: presence, report: &PrecheckReport{}}
+	if err := ctx.targetPrecheck(pool, modelInfo); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ctx.report, nil
+}
+
+func (ctx *precheckContext) targetPrecheck(pool Pool, modelInfo coremigration.ModelInfo) error {
 	if err := modelInfo.Validate(); err != nil {
 		return errors.Trace(err)
 	}
@@ -197,34 +325,43 @@ func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.
 	// window can upset the migrationmaster worker.
 	//
 	// See also https://lpad.tv/1611391
-	if migrating, err := backend.IsMigrationActive(modelInfo.UUID); err != nil {
+	if migrating, err := ctx.backend.IsMigrationActive(modelInfo.UUID); err != nil {
 		return errors.Annotate(err, "checking for active migration")
 	} else if migrating {
-		return errors.New("model is being migrated out of target controller")
+		if err := ctx.blockf("model is being migrated out of target controller"); err != nil {
+			return err
+		}
 	}
 
-	controllerVersion, err := backend.AgentVersion()
+	controllerVersion, err := ctx.backend.AgentVersion()
 	if err != nil {
 		return errors.Annotate(err, "retrieving model version")
 	}
 
 	if controllerVersion.Compare(modelInfo.AgentVersion) < 0 {
-		return errors.Errorf("model has higher version than target controller (%s > %s)",
-			modelInfo.AgentVersion, controllerVersion)
+		if err := ctx.blockf("model has higher version than target controller (%s > %s)",
+			modelInfo.AgentVersion, controllerVersion); err != nil {
+			return err
+		}
 	}
 
 	if !controllerVersionCompatible(modelInfo.ControllerAgentVersion, controllerVersion) {
-		return errors.Errorf("source controller has higher version than target controller (%s > %s)",
-			modelInfo.ControllerAgentVersion, controllerVersion)
+		if err := ctx.blockf("source controller has higher version than target controller (%s > %s)",
+			modelInfo.ControllerAgentVersion, controllerVersion); err != nil {
+			return err
+		}
 	}
 
-	controllerCtx := precheckContext{backend, presence}
-	if err := controllerCtx.checkController(); err != nil {
+	if err := ctx.checkController(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if err := ctx.checkCloud(modelInfo); err != nil {
 		return errors.Trace(err)
 	}
 
 	// Check for conflicts with existing models
-	modelUUIDs, err := backend.AllModelUUIDs()
+	modelUUIDs, err := ctx.backend.AllModelUUIDs()
 	if err != nil {
 		return errors.Annotate(err, "retrieving models")
 	}
@@ -239,16 +376,44 @@ func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.
 		// from a previous migration attempt. It will be removed
 		// before the next import.
 		if model.UUID() == modelInfo.UUID && model.MigrationMode() != state.MigrationModeImporting {
-			return errors.Errorf("model with same UUID already exists (%s)", modelInfo.UUID)
+			if err := ctx.blockf("model with same UUID already exists (%s)", modelInfo.UUID); err != nil {
+				return err
+			}
 		}
 		if model.Name() == modelInfo.Name && model.Owner() == modelInfo.Owner {
-			return errors.Errorf("model named %q already exists", model.Name())
+			if err := ctx.blockf("model named %q already exists", model.Name()); err != nil {
+				return err
+			}
 		}
 	}
 
 	return nil
 }
 
+// checkCloud checks that the cloud and region hosting the model are
+// known to the target controller. Older source controllers don't
+// report the model's cloud, in which case there's nothing to check.
+func (ctx *precheckContext) checkCloud(modelInfo coremigration.ModelInfo) error {
+	if modelInfo.CloudName == "" {
+		return nil
+	}
+	modelCloud, err := ctx.backend.Cloud(modelInfo.CloudName)
+	if errors.IsNotFound(err) {
+		return ctx.blockf("cloud %q not found on target controller", modelInfo.CloudName)
+	} else if err != nil {
+		return errors.Annotatef(err, "retrieving cloud %q", modelInfo.CloudName)
+	}
+	if modelInfo.CloudRegion == "" {
+		return nil
+	}
+	for _, region := range modelCloud.Regions {
+		if region.Name == modelInfo.CloudRegion {
+			return nil
+		}
+	}
+	return ctx.blockf("cloud %q on target controller has no region %q", modelInfo.CloudName, modelInfo.CloudRegion)
+}
+
 func controllerVersionCompatible(sourceVersion, targetVersion version.Number) bool {
 	// Compare source controller version to target controller version, only
 	// considering major and minor version numbers. Downgrades between
@@ -272,13 +437,17 @@ func (ctx *precheckContext) checkController() error {
 		return errors.Annotate(err, "retrieving model")
 	}
 	if model.Life() != state.Alive {
-		return errors.Errorf("model is %s", model.Life())
+		if err := ctx.blockf("model is %s", model.Life()); err != nil {
+			return err
+		}
 	}
 
 	if upgrading, err := ctx.backend.IsUpgrading(); err != nil {
 		return errors.Annotate(err, "checking for upgrades")
 	} else if upgrading {
-		return errors.New("upgrade in progress")
+		if err := ctx.blockf("upgrade in progress"); err != nil {
+			return err
+		}
 	}
 
 	return errors.Trace(ctx.checkMachines())
@@ -297,29 +466,37 @@ func (ctx *precheckContext) checkMachines() error {
 	modelPresenceContext := common.ModelPresenceContext{Presence: ctx.presence}
 	for _, machine := range machines {
 		if machine.Life() != state.Alive {
-			return errors.Errorf("machine %s is %s", machine.Id(), machine.Life())
+			if err := ctx.blockf("machine %s is %s", machine.Id(), machine.Life()); err != nil {
+				return err
+			}
 		}
 
 		if statusInfo, err := machine.InstanceStatus(); err != nil {
 			return errors.Annotatef(err, "retrieving machine %s instance status", machine.Id())
 		} else if statusInfo.Status != status.Running {
-			return newStatusError("machine %s not running", machine.Id(), statusInfo.Status)
+			if err := ctx.block(newStatusError("machine %s not running", machine.Id(), statusInfo.Status)); err != nil {
+				return err
+			}
 		}
 
 		if statusInfo, err := modelPresenceContext.MachineStatus(machine); err != nil {
 			return errors.Annotatef(err, "retrieving machine %s status", machine.Id())
 		} else if statusInfo.Status != status.Started {
-			return newStatusError("machine %s agent not functioning at this time",
-				machine.Id(), statusInfo.Status)
+			if err := ctx.block(newStatusError("machine %s agent not functioning at this time",
+				machine.Id(), statusInfo.Status)); err != nil {
+				return err
+			}
 		}
 
 		if rebootAction, err := machine.ShouldRebootOrShutdown(); err != nil {
 			return errors.Annotatef(err, "retrieving machine %s reboot status", machine.Id())
 		} else if rebootAction != state.ShouldDoNothing {
-			return errors.Errorf("machine %s is scheduled to %s", machine.Id(), rebootAction)
+			if err := ctx.blockf("machine %s is scheduled to %s", machine.Id(), rebootAction); err != nil {
+				return err
+			}
 		}
 
-		if err := checkAgentTools(modelVersion, machine, "machine "+machine.Id()); err != nil {
+		if err := ctx.checkAgentTools(modelVersion, machine, "machine "+machine.Id()); err != nil {
 			return errors.Trace(err)
 		}
 	}
@@ -343,7 +520,12 @@ func (ctx *precheckContext) checkApplications() (map[string][]PrecheckUnit, erro
 	appUnits := make(map[string][]PrecheckUnit, len(apps))
 	for _, app := range apps {
 		if app.Life() != state.Alive {
-			return nil, errors.Errorf("application %s is %s", app.Name(), app.Life())
+			if err := ctx.blockf("application %s is %s", app.Name(), app.Life()); err != nil {
+				return nil, err
+			}
+		}
+		if err := ctx.checkCharm(app); err != nil {
+			return nil, errors.Trace(err)
 		}
 		units, err := app.AllUnits()
 		if err != nil {
@@ -354,20 +536,55 @@ func (ctx *precheckContext) checkApplications() (map[string][]PrecheckUnit, erro
 			return nil, errors.Trace(err)
 		}
 		appUnits[app.Name()] = units
+
+		if ctx.report != nil {
+			// Pending resources don't prevent a migration, but
+			// they won't be exported with the model.
+			pending, err := ctx.backend.ListPendingResources(app.Name())
+			if err != nil {
+				return nil, errors.Annotatef(err, "retrieving pending resources for %s", app.Name())
+			}
+			if len(pending) > 0 {
+				ctx.warn("application %s has %d pending resources which won't be migrated", app.Name(), len(pending))
+			}
+		}
 	}
 	return appUnits, nil
 }
 
+// checkCharm checks that the archive for the application's charm
+// is available, so that it can be transferred to the target.
+func (ctx *precheckContext) checkCharm(app PrecheckApplication) error {
+	curl, _ := app.CharmURL()
+	if curl == nil {
+		return nil
+	}
+	ch, err := ctx.backend.Charm(curl)
+	if errors.IsNotFound(err) {
+		return ctx.blockf("charm %s used by application %s is not available", curl, app.Name())
+	} else if err != nil {
+		return errors.Annotatef(err, "retrieving charm %s", curl)
+	}
+	if ch.StoragePath() == "" {
+		return ctx.blockf("charm %s used by application %s has not been stored", curl, app.Name())
+	}
+	return nil
+}
+
 func (ctx *precheckContext) checkUnits(app PrecheckApplication, units []PrecheckUnit, modelVersion version.Number, modelType state.ModelType) error {
 	if len(units) < app.MinUnits() {
-		return errors.Errorf("application %s is below its minimum units threshold", app.Name())
+		if err := ctx.blockf("application %s is below its minimum units threshold", app.Name()); err != nil {
+			return err
+		}
 	}
 
 	appCharmURL, _ := app.CharmURL()
 
 	for _, unit := range units {
 		if unit.Life() != state.Alive {
-			return errors.Errorf("unit %s is %s", unit.Name(), unit.Life())
+			if err := ctx.blockf("unit %s is %s", unit.Name(), unit.Life()); err != nil {
+				return err
+			}
 		}
 
 		if err := ctx.checkUnitAgentStatus(unit); err != nil {
@@ -375,14 +592,22 @@ func (ctx *precheckContext) checkUnits(app PrecheckApplication, units []Precheck
 		}
 
 		if modelType == state.ModelTypeIAAS {
-			if err := checkAgentTools(modelVersion, unit, "unit "+unit.Name()); err != nil {
+			if err := ctx.checkAgentTools(modelVersion, unit, "unit "+unit.Name()); err != nil {
 				return errors.Trace(err)
 			}
 		}
 
 		unitCharmURL, _ := unit.CharmURL()
 		if appCharmURL.String() != unitCharmURL.String() {
-			return errors.Errorf("unit %s is upgrading", unit.Name())
+			if err := ctx.blockf("unit %s is upgrading", unit.Name()); err != nil {
+				return err
+			}
+		}
+
+		if ctx.report != nil {
+			if err := ctx.checkUnitWorkloadStatus(unit); err != nil {
+				return errors.Trace(err)
+			}
 		}
 	}
 	return nil
@@ -399,19 +624,37 @@ func (ctx *precheckContext) checkUnitAgentStatus(unit PrecheckUnit) error {
 	case status.Idle, status.Executing:
 		// These two are fine.
 	default:
-		return newStatusError("unit %s not idle or executing", unit.Name(), agentStatus)
+		return ctx.block(newStatusError("unit %s not idle or executing", unit.Name(), agentStatus))
+	}
+	return nil
+}
+
+// checkUnitWorkloadStatus warns about workloads which need attention.
+// These don't prevent a migration, but are better resolved first.
+func (ctx *precheckContext) checkUnitWorkloadStatus(unit PrecheckUnit) error {
+	statusInfo, err := unit.Status()
+	if err != nil {
+		return errors.Annotatef(err, "retrieving unit %s workload status", unit.Name())
+	}
+	switch statusInfo.Status {
+	case status.Blocked, status.Error:
+		if statusInfo.Message == "" {
+			ctx.warn("unit %s workload is %s", unit.Name(), statusInfo.Status)
+		} else {
+			ctx.warn("unit %s workload is %s: %s", unit.Name(), statusInfo.Status, statusInfo.Message)
+		}
 	}
 	return nil
 }
 
-func checkAgentTools(modelVersion version.Number, agent agentToolsGetter, agentLabel string) error {
+func (ctx *precheckContext) checkAgentTools(modelVersion version.Number, agent agentToolsGetter, agentLabel string) error {
 	tools, err := agent.AgentTools()
 	if err != nil {
 		return errors.Annotatef(err, "retrieving agent binaries for %s", agentLabel)
 	}
 	agentVersion := tools.Version.Number
 	if agentVersion != modelVersion {
-		return errors.Errorf("%s agent binaries don't match model (%s != %s)",
+		return ctx.blockf("%s agent binaries don't match model (%s != %s)",
 			agentLabel, agentVersion, modelVersion)
 	}
 	return nil
@@ -463,7 +706,9 @@ func (ctx *precheckContext) checkRelations(appUnits map[string][]PrecheckUnit) e
 					return errors.Trace(err)
 				}
 				if !inScope {
-					return errors.Errorf("unit %s hasn't joined relation %s yet", unit.Name(), rel)
+					if err := ctx.blockf("unit %s hasn't joined relation %s yet", unit.Name(), rel); err != nil {
+						return err
+					}
 				}
 			}
 		}
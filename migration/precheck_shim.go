@@ -4,6 +4,7 @@
 package migration
 
 import (
+	"github.com/juju/charm/v7"
 	"github.com/juju/errors"
 	"github.com/juju/version"
 
@@ -99,12 +100,30 @@ func (s *precheckShim) AllRelations() ([]PrecheckRelation, error) {
 	return out, nil
 }
 
+// Charm implements PrecheckBackend.
+func (s *precheckShim) Charm(curl *charm.URL) (PrecheckCharm, error) {
+	ch, err := s.State.Charm(curl)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ch, nil
+}
+
 // ListPendingResources implements PrecheckBackend.
 func (s *precheckShim) ListPendingResources(app string) ([]resource.Resource, error) {
 	resources, err := s.resourcesSt.ListPendingResources(app)
 	return resources, errors.Trace(err)
 }
 
+// SecretsCount implements PrecheckBackend.
+func (s *precheckShim) SecretsCount() (int, error) {
+	secrets, err := state.NewSecretsStore(s.State).ListSecrets(state.SecretsFilter{})
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return len(secrets), nil
+}
+
 // ControllerBackend implements PrecheckBackend.
 func (s *precheckShim) ControllerBackend() (PrecheckBackend, error) {
 	return PrecheckShim(s.controllerState, s.controllerState)
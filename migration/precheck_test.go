@@ -11,6 +11,7 @@ import (
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/cloud"
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/core/status"
@@ -86,6 +87,80 @@ func (*SourcePrecheckSuite) TestPendingResources(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (*SourcePrecheckSuite) TestCharmNotAvailable(c *gc.C) {
+	backend := newHappyBackend()
+	backend.missingCharms = []string{"cs:foo-1"}
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "charm cs:foo-1 used by application foo is not available")
+}
+
+func (*SourcePrecheckSuite) TestCharmNotStored(c *gc.C) {
+	backend := newHappyBackend()
+	backend.unstoredCharms = []string{"cs:foo-1"}
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "charm cs:foo-1 used by application foo has not been stored")
+}
+
+func (*SourcePrecheckSuite) TestReportSuccess(c *gc.C) {
+	backend := newHappyBackend()
+	backend.controllerBackend = newHappyBackend()
+	report, err := migration.SourcePrecheckReport(backend, allAlivePresence(), allAlivePresence())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report.Blocked(), jc.IsFalse)
+	c.Assert(report.Blockers, gc.HasLen, 0)
+	c.Assert(report.Warnings, gc.HasLen, 0)
+}
+
+func (*SourcePrecheckSuite) TestReportCollectsAllProblems(c *gc.C) {
+	backend := newHappyBackend()
+	backend.model.migrationMode = state.MigrationModeImporting
+	backend.machines[1] = &fakeMachine{id: "1", rebootAction: state.ShouldReboot}
+	backend.apps = append(backend.apps, &fakeApp{
+		name:     "spanner",
+		charmURL: "cs:spanner-3",
+		units: []migration.PrecheckUnit{
+			&fakeUnit{name: "spanner/0", charmURL: "cs:spanner-3", agentStatus: status.Failed},
+			&fakeUnit{name: "spanner/1", charmURL: "cs:spanner-2", workloadStatus: status.Blocked},
+			&fakeUnit{name: "spanner/2", charmURL: "cs:spanner-3", workloadStatus: status.Error,
+				workloadMessage: `hook failed: "install"`},
+		},
+	})
+	backend.missingCharms = []string{"cs:spanner-3"}
+	backend.pendingResources = []resource.Resource{
+		resourcetesting.NewResource(c, nil, "blob", "foo", "body").Resource,
+	}
+	backend.cleanupNeeded = true
+	backend.controllerBackend = newHappyBackend()
+	backend.controllerBackend.isUpgrading = true
+
+	report, err := migration.SourcePrecheckReport(backend, allAlivePresence(), allAlivePresence())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report.Blocked(), jc.IsTrue)
+	c.Assert(report.Blockers, jc.DeepEquals, []string{
+		"model is being imported as part of another migration",
+		"machine 1 is scheduled to reboot",
+		"charm cs:spanner-3 used by application spanner is not available",
+		"unit spanner/0 not idle or executing (failed)",
+		"unit spanner/1 is upgrading",
+		"cleanup needed",
+		"controller: upgrade in progress",
+	})
+	c.Assert(report.Warnings, jc.DeepEquals, []string{
+		"application foo has 1 pending resources which won't be migrated",
+		"application bar has 1 pending resources which won't be migrated",
+		"unit spanner/1 workload is blocked",
+		`unit spanner/2 workload is error: hook failed: "install"`,
+		"application spanner has 1 pending resources which won't be migrated",
+	})
+}
+
+func (*SourcePrecheckSuite) TestReportError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.cleanupErr = errors.New("boom")
+	_, err := migration.SourcePrecheckReport(backend, allAlivePresence(), allAlivePresence())
+	c.Assert(err, gc.ErrorMatches, "checking cleanups: boom")
+}
+
 func (*SourcePrecheckSuite) TestImportingModel(c *gc.C) {
 	backend := newFakeBackend()
 	backend.model.migrationMode = state.MigrationModeImporting
@@ -107,6 +182,20 @@ func (*SourcePrecheckSuite) TestCleanupsNeeded(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "cleanup needed")
 }
 
+func (*SourcePrecheckSuite) TestSecrets(c *gc.C) {
+	backend := newFakeBackend()
+	backend.secretsCount = 2
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "model has 2 secrets, which can't be migrated")
+}
+
+func (*SourcePrecheckSuite) TestSecretsError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.secretsCountErr = errors.New("boom")
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "checking secrets: boom")
+}
+
 func (s *SourcePrecheckSuite) TestIsUpgradingError(c *gc.C) {
 	backend := newFakeBackend()
 	backend.controllerBackend.isUpgradingErr = errors.New("boom")
@@ -623,6 +712,59 @@ func (s *TargetPrecheckSuite) TestUUIDAlreadyExistsButImporting(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *TargetPrecheckSuite) TestCloudNotFound(c *gc.C) {
+	s.modelInfo.CloudName = "aws"
+	err := s.runPrecheck(newHappyBackend())
+	c.Assert(err, gc.ErrorMatches, `cloud "aws" not found on target controller`)
+}
+
+func (s *TargetPrecheckSuite) TestCloudRegionNotFound(c *gc.C) {
+	s.modelInfo.CloudName = "aws"
+	s.modelInfo.CloudRegion = "us-east-1"
+	backend := newHappyBackend()
+	backend.clouds = map[string]cloud.Cloud{
+		"aws": {Name: "aws", Regions: []cloud.Region{{Name: "eu-west-1"}}},
+	}
+	err := s.runPrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, `cloud "aws" on target controller has no region "us-east-1"`)
+}
+
+func (s *TargetPrecheckSuite) TestCloudRegionFound(c *gc.C) {
+	s.modelInfo.CloudName = "aws"
+	s.modelInfo.CloudRegion = "us-east-1"
+	backend := newHappyBackend()
+	backend.clouds = map[string]cloud.Cloud{
+		"aws": {Name: "aws", Regions: []cloud.Region{{Name: "us-east-1"}}},
+	}
+	c.Assert(s.runPrecheck(backend), jc.ErrorIsNil)
+}
+
+func (s *TargetPrecheckSuite) TestReportCollectsAllProblems(c *gc.C) {
+	pool := &fakePool{
+		models: []migration.PrecheckModel{
+			&fakeModel{uuid: modelUUID, name: modelName, owner: modelOwner, modelType: state.ModelTypeIAAS},
+		},
+	}
+	backend := newBackendWithDyingMachine()
+	backend.models = pool.uuids()
+	backend.isUpgrading = true
+	s.modelInfo.CloudName = "aws"
+	s.modelInfo.AgentVersion.Patch++
+
+	report, err := migration.TargetPrecheckReport(backend, pool, s.modelInfo, allAlivePresence())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report.Blocked(), jc.IsTrue)
+	c.Assert(report.Blockers, jc.DeepEquals, []string{
+		"model has higher version than target controller (1.2.4 > 1.2.3)",
+		"upgrade in progress",
+		"machine 0 is dying",
+		`cloud "aws" not found on target controller`,
+		"model with same UUID already exists (model-uuid)",
+		`model named "model-name" already exists`,
+	})
+	c.Assert(report.Warnings, gc.HasLen, 0)
+}
+
 type precheckRunner func(migration.PrecheckBackend) error
 
 type precheckBaseSuite struct {
@@ -749,6 +891,14 @@ type fakeBackend struct {
 	pendingResources    []resource.Resource
 	pendingResourcesErr error
 
+	secretsCount    int
+	secretsCountErr error
+
+	missingCharms  []string
+	unstoredCharms []string
+
+	clouds map[string]cloud.Cloud
+
 	controllerBackend *fakeBackend
 }
 
@@ -780,6 +930,27 @@ func (b *fakeBackend) CloudCredential(tag names.CloudCredentialTag) (state.Crede
 	return b.credentials, b.credentialsErr
 }
 
+func (b *fakeBackend) Cloud(name string) (cloud.Cloud, error) {
+	if c, ok := b.clouds[name]; ok {
+		return c, nil
+	}
+	return cloud.Cloud{}, errors.NotFoundf("cloud %q", name)
+}
+
+func (b *fakeBackend) Charm(curl *charm.URL) (migration.PrecheckCharm, error) {
+	for _, missing := range b.missingCharms {
+		if curl.String() == missing {
+			return nil, errors.NotFoundf("charm %q", curl)
+		}
+	}
+	for _, unstored := range b.unstoredCharms {
+		if curl.String() == unstored {
+			return &fakeCharm{}, nil
+		}
+	}
+	return &fakeCharm{storagePath: "charms/" + curl.Name}, nil
+}
+
 func (b *fakeBackend) AllMachines() ([]migration.PrecheckMachine, error) {
 	return b.machines, b.allMachinesErr
 }
@@ -796,6 +967,10 @@ func (b *fakeBackend) ListPendingResources(app string) ([]resource.Resource, err
 	return b.pendingResources, b.pendingResourcesErr
 }
 
+func (b *fakeBackend) SecretsCount() (int, error) {
+	return b.secretsCount, b.secretsCountErr
+}
+
 func (b *fakeBackend) ControllerBackend() (migration.PrecheckBackend, error) {
 	if b.controllerBackend == nil {
 		return b, nil
@@ -919,6 +1094,14 @@ func (m *fakeMachine) ShouldRebootOrShutdown() (state.RebootAction, error) {
 	return m.rebootAction, nil
 }
 
+type fakeCharm struct {
+	storagePath string
+}
+
+func (ch *fakeCharm) StoragePath() string {
+	return ch.storagePath
+}
+
 type fakeApp struct {
 	name     string
 	life     state.Life
@@ -952,12 +1135,14 @@ func (a *fakeApp) MinUnits() int {
 }
 
 type fakeUnit struct {
-	name        string
-	version     version.Binary
-	noTools     bool
-	life        state.Life
-	charmURL    string
-	agentStatus status.Status
+	name            string
+	version         version.Binary
+	noTools         bool
+	life            state.Life
+	charmURL        string
+	agentStatus     status.Status
+	workloadStatus  status.Status
+	workloadMessage string
 }
 
 func (u *fakeUnit) Name() string {
@@ -1005,7 +1190,12 @@ func (u *fakeUnit) AgentStatus() (status.StatusInfo, error) {
 }
 
 func (u *fakeUnit) Status() (status.StatusInfo, error) {
-	return status.StatusInfo{Status: status.Idle}, nil
+	s := u.workloadStatus
+	if s == "" {
+		// Avoid the need to specify this everywhere.
+		s = status.Idle
+	}
+	return status.StatusInfo{Status: s, Message: u.workloadMessage}, nil
 }
 
 type fakeRelation struct {
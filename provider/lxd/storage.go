@@ -105,6 +105,9 @@ func newLXDStorageConfig(attrs map[string]interface{}) (*lxdStorageConfig, error
 	lxdPool, _ := attrs[attrLXDStoragePool].(string)
 	delete(attrs, attrLXDStorageDriver)
 	delete(attrs, attrLXDStoragePool)
+	// Pool limits are enforced by Juju, not LXD.
+	delete(attrs, storage.ConfigMaxSize)
+	delete(attrs, storage.ConfigMaxVolumes)
 
 	var stringAttrs map[string]string
 	if len(attrs) > 0 {
@@ -0,0 +1,43 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resource
+
+import (
+	"strings"
+
+	charmresource "github.com/juju/charm/v7/resource"
+)
+
+// HelmChartType is the name used for Helm chart resources.
+//
+// The charm metadata only recognises "file" and "oci-image" resource
+// types, so a charm declares a Helm chart as a file resource whose
+// filename ends in HelmChartSuffix, for example:
+//
+//	resources:
+//	  chart:
+//	    type: file
+//	    filename: mariadb.helm.tgz
+//
+// The file is a packaged chart, as produced by "helm package".
+const HelmChartType = "helm-chart"
+
+// HelmChartSuffix is the filename suffix that marks a file resource as
+// a packaged Helm chart.
+const HelmChartSuffix = ".helm.tgz"
+
+// IsHelmChart reports whether the resource metadata describes a Helm
+// chart.
+func IsHelmChart(meta charmresource.Meta) bool {
+	return meta.Type == charmresource.TypeFile && strings.HasSuffix(meta.Path, HelmChartSuffix)
+}
+
+// TypeName returns the name of the resource's type, taking Helm charts
+// into account.
+func TypeName(meta charmresource.Meta) string {
+	if IsHelmChart(meta) {
+		return HelmChartType
+	}
+	return meta.Type.String()
+}
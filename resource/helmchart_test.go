@@ -0,0 +1,43 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resource_test
+
+import (
+	charmresource "github.com/juju/charm/v7/resource"
+	"github.com/juju/testing"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/resource"
+)
+
+type HelmChartSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&HelmChartSuite{})
+
+func (HelmChartSuite) TestIsHelmChart(c *gc.C) {
+	for i, t := range []struct {
+		meta     charmresource.Meta
+		expected bool
+	}{{
+		meta:     charmresource.Meta{Name: "chart", Type: charmresource.TypeFile, Path: "mariadb.helm.tgz"},
+		expected: true,
+	}, {
+		meta:     charmresource.Meta{Name: "chart", Type: charmresource.TypeFile, Path: "mariadb.tgz"},
+		expected: false,
+	}, {
+		meta:     charmresource.Meta{Name: "image", Type: charmresource.TypeContainerImage},
+		expected: false,
+	}} {
+		c.Logf("test %d: %v", i, t.meta)
+		c.Check(resource.IsHelmChart(t.meta), gc.Equals, t.expected)
+	}
+}
+
+func (HelmChartSuite) TestTypeName(c *gc.C) {
+	c.Check(resource.TypeName(charmresource.Meta{Type: charmresource.TypeFile, Path: "chart.helm.tgz"}), gc.Equals, "helm-chart")
+	c.Check(resource.TypeName(charmresource.Meta{Type: charmresource.TypeFile, Path: "chart.tgz"}), gc.Equals, "file")
+	c.Check(resource.TypeName(charmresource.Meta{Type: charmresource.TypeContainerImage}), gc.Equals, "oci-image")
+}
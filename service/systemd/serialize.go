@@ -39,9 +39,10 @@ func UnitSerialize(opts []*unit.UnitOption) io.Reader {
 	// CHANGED HERE: Output in the following order:
 	// - Unit
 	// - Service
+	// - Slice
 	// - Install
 	// rather than just iterating over the map in random order.
-	for _, curSection := range []string{"Unit", "Service", "Install"} {
+	for _, curSection := range []string{"Unit", "Service", "Slice", "Install"} {
 		curOpts, found := idx[curSection]
 		if !found {
 			continue
@@ -0,0 +1,175 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package systemd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/coreos/go-systemd/v22/unit"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/application"
+)
+
+// systemdRun is the command used to run a process in a transient
+// scope within a slice.
+const systemdRun = "/usr/bin/systemd-run"
+
+// UnitSliceName returns the name of the systemd slice holding the
+// processes started for the unit with the given name.
+func UnitSliceName(unitName string) string {
+	// Dashes in slice names denote nesting, so avoid them.
+	name := strings.NewReplacer("/", "_", "-", "_").Replace(unitName)
+	return "juju-unit_" + name + ".slice"
+}
+
+// Slice provides control over a systemd slice, used to limit the
+// resources used by all the processes started in it.
+type Slice struct {
+	Name    string
+	DirName string
+	Desc    string
+
+	fileOps FileSystemOps
+}
+
+// NewUnitSlice returns a reference to the slice holding the processes
+// started for the unit with the given name.
+func NewUnitSlice(unitName string) *Slice {
+	return NewSlice(UnitSliceName(unitName), EtcSystemdDir, "Resource limits for juju unit "+unitName, fileSystemOps{})
+}
+
+// NewSlice returns a reference to the slice with the given name, whose
+// unit file lives in the given directory.
+func NewSlice(name, dirName, desc string, fileOps FileSystemOps) *Slice {
+	return &Slice{
+		Name:    name,
+		DirName: renderer.Join(dirName),
+		Desc:    desc,
+		fileOps: fileOps,
+	}
+}
+
+// Path returns the path to the slice's unit file.
+func (s *Slice) Path() string {
+	return renderer.Join(s.DirName, s.Name)
+}
+
+// Installed returns whether the slice's unit file exists.
+func (s *Slice) Installed() bool {
+	_, err := os.Stat(s.Path())
+	return err == nil
+}
+
+// SetLimits writes the slice's unit file with the given limits and
+// applies them to any processes already running in the slice.
+func (s *Slice) SetLimits(limits application.ResourceLimits) error {
+	data, err := ioutil.ReadAll(UnitSerialize(s.unitOptions(limits)))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := s.fileOps.WriteFile(s.Path(), data, 0644); err != nil {
+		return errors.Annotatef(err, "writing slice %q", s.Name)
+	}
+	if err := (Cmdline{}).reload(); err != nil {
+		return errors.Trace(err)
+	}
+
+	// The unit file only takes effect when the slice is next started,
+	// so also set the limits on the running slice.
+	props := make([]string, len(sliceProperties))
+	for i, prop := range sliceProperties {
+		props[i] = prop.name + "=" + prop.value(limits)
+	}
+	cmd := cmds.resolve(fmt.Sprintf("set-property --runtime %s %s", s.Name, strings.Join(props, " ")))
+	_, err = Cmdline{}.runCommand(cmd, "set slice properties")
+	return errors.Trace(err)
+}
+
+// Remove deletes the slice's unit file. Processes still running in the
+// slice are left alone.
+func (s *Slice) Remove() error {
+	if err := s.fileOps.Remove(s.Path()); err != nil {
+		return errors.Annotatef(err, "removing slice %q", s.Name)
+	}
+	return errors.Trace((Cmdline{}).reload())
+}
+
+// ScopeCommand returns the given command wrapped so that it runs in a
+// transient scope within the slice, and so is subject to its limits.
+func (s *Slice) ScopeCommand(args []string) []string {
+	return append([]string{systemdRun, "--scope", "--quiet", "--slice=" + s.Name, "--"}, args...)
+}
+
+func (s *Slice) unitOptions(limits application.ResourceLimits) []*unit.UnitOption {
+	opts := []*unit.UnitOption{{
+		Section: "Unit",
+		Name:    "Description",
+		Value:   s.Desc,
+	}, {
+		Section: "Unit",
+		Name:    "Before",
+		Value:   "slices.target",
+	}}
+	for _, prop := range sliceProperties {
+		if !prop.set(limits) {
+			continue
+		}
+		opts = append(opts, &unit.UnitOption{
+			Section: "Slice",
+			Name:    prop.name,
+			Value:   prop.value(limits),
+		})
+	}
+	return opts
+}
+
+// sliceProperty maps a resource limit to a systemd slice property.
+type sliceProperty struct {
+	name  string
+	set   func(application.ResourceLimits) bool
+	value func(application.ResourceLimits) string
+}
+
+// sliceProperties holds the slice properties used to apply resource
+// limits. MemoryLimit is the cgroup v1 equivalent of MemoryMax, and
+// is ignored by systemd under cgroup v2.
+var sliceProperties = []sliceProperty{{
+	name:  "MemoryMax",
+	set:   func(l application.ResourceLimits) bool { return l.MemoryMB != 0 },
+	value: func(l application.ResourceLimits) string { return memoryValue(l.MemoryMB) },
+}, {
+	name:  "MemoryLimit",
+	set:   func(l application.ResourceLimits) bool { return l.MemoryMB != 0 },
+	value: func(l application.ResourceLimits) string { return memoryValue(l.MemoryMB) },
+}, {
+	name: "CPUShares",
+	set:  func(l application.ResourceLimits) bool { return l.CPUShares != 0 },
+	value: func(l application.ResourceLimits) string {
+		// An empty value resets the weight to the default.
+		if l.CPUShares == 0 {
+			return ""
+		}
+		return fmt.Sprint(l.CPUShares)
+	},
+}, {
+	name: "TasksMax",
+	set:  func(l application.ResourceLimits) bool { return l.Tasks != 0 },
+	value: func(l application.ResourceLimits) string {
+		if l.Tasks == 0 {
+			return "infinity"
+		}
+		return fmt.Sprint(l.Tasks)
+	},
+}}
+
+func memoryValue(mb uint64) string {
+	if mb == 0 {
+		return "infinity"
+	}
+	return fmt.Sprintf("%dM", mb)
+}
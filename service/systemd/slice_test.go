@@ -0,0 +1,122 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package systemd_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/mock/gomock"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/exec"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/service/systemd"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type sliceSuite struct {
+	coretesting.BaseSuite
+
+	fops *MockFileSystemOps
+	exec *systemd.MockShimExec
+}
+
+var _ = gc.Suite(&sliceSuite{})
+
+func (s *sliceSuite) patch(c *gc.C) *gomock.Controller {
+	ctrl := gomock.NewController(c)
+	s.fops = NewMockFileSystemOps(ctrl)
+	s.exec = systemd.PatchExec(s, ctrl)
+	return ctrl
+}
+
+func (s *sliceSuite) newSlice(dirName string) *systemd.Slice {
+	return systemd.NewSlice("juju-unit_mysql_0.slice", dirName, "Resource limits for juju unit mysql/0", s.fops)
+}
+
+func (s *sliceSuite) TestUnitSliceName(c *gc.C) {
+	c.Assert(systemd.UnitSliceName("mysql/0"), gc.Equals, "juju-unit_mysql_0.slice")
+	c.Assert(systemd.UnitSliceName("my-app/12"), gc.Equals, "juju-unit_my_app_12.slice")
+}
+
+func (s *sliceSuite) TestNewUnitSlice(c *gc.C) {
+	slice := systemd.NewUnitSlice("mysql/0")
+	c.Assert(slice.Name, gc.Equals, "juju-unit_mysql_0.slice")
+	c.Assert(slice.Path(), gc.Equals, "/etc/systemd/system/juju-unit_mysql_0.slice")
+}
+
+func (s *sliceSuite) TestInstalled(c *gc.C) {
+	dir := c.MkDir()
+	slice := s.newSlice(dir)
+	c.Assert(slice.Installed(), jc.IsFalse)
+
+	err := ioutil.WriteFile(filepath.Join(dir, slice.Name), nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(slice.Installed(), jc.IsTrue)
+}
+
+func (s *sliceSuite) TestSetLimits(c *gc.C) {
+	ctrl := s.patch(c)
+	defer ctrl.Finish()
+
+	expected := `
+[Unit]
+Description=Resource limits for juju unit mysql/0
+Before=slices.target
+
+[Slice]
+MemoryMax=512M
+MemoryLimit=512M
+TasksMax=100
+
+`[1:]
+	gomock.InOrder(
+		s.fops.EXPECT().WriteFile("/etc/systemd/system/juju-unit_mysql_0.slice", []byte(expected), os.FileMode(0644)).Return(nil),
+		s.exec.EXPECT().RunCommands(exec.RunParams{
+			Commands: "/bin/systemctl daemon-reload",
+		}).Return(&exec.ExecResponse{}, nil),
+		s.exec.EXPECT().RunCommands(exec.RunParams{
+			Commands: "/bin/systemctl set-property --runtime juju-unit_mysql_0.slice MemoryMax=512M MemoryLimit=512M CPUShares= TasksMax=100",
+		}).Return(&exec.ExecResponse{}, nil),
+	)
+
+	err := s.newSlice(systemd.EtcSystemdDir).SetLimits(application.ResourceLimits{MemoryMB: 512, Tasks: 100})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *sliceSuite) TestSetLimitsFails(c *gc.C) {
+	ctrl := s.patch(c)
+	defer ctrl.Finish()
+
+	s.fops.EXPECT().WriteFile(gomock.Any(), gomock.Any(), gomock.Any()).Return(errFailure)
+
+	err := s.newSlice(systemd.EtcSystemdDir).SetLimits(application.ResourceLimits{CPUShares: 512})
+	c.Assert(err, gc.ErrorMatches, `writing slice "juju-unit_mysql_0.slice": you-failed`)
+}
+
+func (s *sliceSuite) TestRemove(c *gc.C) {
+	ctrl := s.patch(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.fops.EXPECT().Remove("/etc/systemd/system/juju-unit_mysql_0.slice").Return(nil),
+		s.exec.EXPECT().RunCommands(exec.RunParams{
+			Commands: "/bin/systemctl daemon-reload",
+		}).Return(&exec.ExecResponse{}, nil),
+	)
+
+	err := s.newSlice(systemd.EtcSystemdDir).Remove()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *sliceSuite) TestScopeCommand(c *gc.C) {
+	cmd := s.newSlice(systemd.EtcSystemdDir).ScopeCommand([]string{"/var/lib/juju/charm/hooks/install"})
+	c.Assert(cmd, jc.DeepEquals, []string{
+		"/usr/bin/systemd-run", "--scope", "--quiet", "--slice=juju-unit_mysql_0.slice", "--",
+		"/var/lib/juju/charm/hooks/install",
+	})
+}
@@ -165,6 +165,17 @@ func (st *State) AddMachines(templates ...MachineTemplate) (_ []*Machine, err er
 		ms = append(ms, newMachine(st, mdoc))
 		ops = append(ops, addOps...)
 	}
+	if len(templates) > 1 {
+		// Each machine's storage was checked against the
+		// pool limits on its own; check it again as a whole.
+		sb, err := NewStorageBackend(st)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if ops, err = sb.storagePoolCapacityOps(ops); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
 	ssOps, err := st.maintainControllersOps(controllerIds, true)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -282,6 +282,20 @@ func allCollections() CollectionSchema {
 				Key: []string{"model-uuid"},
 			}},
 		},
+		// unitHealthChecksC holds the workload health checks reported
+		// by each unit's charm.
+		unitHealthChecksC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid"},
+			}},
+		},
+		// unitTracesC records until when the hook tool calls made by
+		// each traced unit's charm are logged.
+		unitTracesC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid"},
+			}},
+		},
 		minUnitsC: {},
 
 		// This collection holds documents that indicate units which are queued
@@ -480,6 +494,17 @@ func allCollections() CollectionSchema {
 				Key: []string{"-updated"},
 			}},
 		},
+		// unitHookHistoryC holds the hook executions recorded by unit
+		// agents, bounded per unit and pruned along with status history.
+		unitHookHistoryC: {
+			rawAccess: true,
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "unit", "-started"},
+			}, {
+				// used for pruning
+				Key: []string{"-started"},
+			}},
+		},
 
 		// This collection holds information about cloud image metadata.
 		cloudimagemetadataC: {
@@ -499,7 +524,18 @@ func allCollections() CollectionSchema {
 				{Key: []string{"model-uuid", "offer-uuid"}},
 			},
 		},
+		offerInvitationsC: {
+			indexes: []mgo.Index{
+				{Key: []string{"model-uuid", "offer-uuid"}},
+			},
+		},
 		remoteApplicationsC: {},
+		// remoteApplicationDiagnosticsC holds the health of the cross
+		// model relation traffic for remote applications, as recorded
+		// by the remote relations worker.
+		remoteApplicationDiagnosticsC: {
+			rawAccess: true,
+		},
 		// remoteEntitiesC holds information about entities involved in
 		// cross-model relations.
 		remoteEntitiesC: {
@@ -530,6 +566,36 @@ func allCollections() CollectionSchema {
 		// eg addresses.
 		cloudServicesC: {},
 
+		// secretMetadataC holds the metadata of the secrets owned by
+		// applications.
+		secretMetadataC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "owner-tag"},
+			}},
+		},
+
+		// secretRevisionsC records the backend holding the content
+		// of each secret revision.
+		secretRevisionsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "secret-id"},
+			}},
+		},
+
+		// secretPermissionsC holds the access to secrets granted to
+		// applications and units.
+		secretPermissionsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "secret-id"},
+			}, {
+				Key: []string{"model-uuid", "subject-tag"},
+			}},
+		},
+
+		// secretContentC holds the secret content stored by the
+		// internal secrets backend.
+		secretContentC: {},
+
 		// ----------------------
 
 		// Raw-access collections
@@ -603,6 +669,10 @@ const (
 	relationsC                 = "relations"
 	restoreInfoC               = "restoreInfo"
 	sequenceC                  = "sequence"
+	secretContentC             = "secretContent"
+	secretMetadataC            = "secretMetadata"
+	secretPermissionsC         = "secretPermissions"
+	secretRevisionsC           = "secretRevisions"
 	applicationsC              = "applications"
 	endpointBindingsC          = "endpointbindings"
 	settingsC                  = "settings"
@@ -625,6 +695,9 @@ const (
 	txnsC                      = "txns"
 	unitsC                     = "units"
 	unitStatesC                = "unitstates"
+	unitHealthChecksC          = "unithealthchecks"
+	unitHookHistoryC           = "unithookhistory"
+	unitTracesC                = "unittraces"
 	upgradeInfoC               = "upgradeInfo"
 	userLastLoginC             = "userLastLogin"
 	usermodelnameC             = "usermodelname"
@@ -636,11 +709,13 @@ const (
 	// "resources" (see resource/persistence/mongo.go)
 
 	// Cross model relations
-	applicationOffersC   = "applicationOffers"
-	remoteApplicationsC  = "remoteApplications"
-	offerConnectionsC    = "applicationOfferConnections"
-	remoteEntitiesC      = "remoteEntities"
-	externalControllersC = "externalControllers"
-	relationNetworksC    = "relationNetworks"
-	firewallRulesC       = "firewallRules"
+	applicationOffersC            = "applicationOffers"
+	remoteApplicationsC           = "remoteApplications"
+	remoteApplicationDiagnosticsC = "remoteApplicationDiagnostics"
+	offerConnectionsC             = "applicationOfferConnections"
+	offerInvitationsC             = "applicationOfferInvitations"
+	remoteEntitiesC               = "remoteEntities"
+	externalControllersC          = "externalControllers"
+	relationNetworksC             = "relationNetworks"
+	firewallRulesC                = "firewallRules"
 )
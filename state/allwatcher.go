@@ -110,6 +110,9 @@ func makeAllWatcherCollectionInfo(collNames []string) map[string]allWatcherState
 		case podSpecsC:
 			collection.docType = reflect.TypeOf(backingPodSpec{})
 			collection.subsidiary = true
+		case unitHealthChecksC:
+			collection.docType = reflect.TypeOf(backingUnitHealthChecks{})
+			collection.subsidiary = true
 		default:
 			allWatcherLogger.Criticalf("programming error: unknown collection %q", collName)
 		}
@@ -552,6 +555,10 @@ func (u *backingUnit) updated(ctx *allWatcherContext) error {
 			return errors.Trace(err)
 		}
 		info.PortRangesBySubnet = portRangesBySubnet
+		info.HealthChecks, err = ctx.getUnitHealthChecks(unitGlobalKey(u.Name))
+		if err != nil {
+			return errors.Annotatef(err, "retrieve health checks for %q", u.Name)
+		}
 		if modelType == ModelTypeCAAS {
 			containerStatus, err := ctx.getStatus(globalCloudContainerKey(u.Name), "cloud container")
 			if err == nil {
@@ -567,6 +574,7 @@ func (u *backingUnit) updated(ctx *allWatcherContext) error {
 		info.WorkloadStatus = oldInfo.WorkloadStatus
 		info.ContainerStatus = oldInfo.ContainerStatus
 		info.PortRangesBySubnet = oldInfo.PortRangesBySubnet
+		info.HealthChecks = oldInfo.HealthChecks
 	}
 
 	u.updateAgentVersion(info)
@@ -1361,6 +1369,41 @@ func (c *backingConstraints) mongoID() string {
 	return ""
 }
 
+type backingUnitHealthChecks unitHealthChecksDoc
+
+func (h *backingUnitHealthChecks) updated(ctx *allWatcherContext) error {
+	allWatcherLogger.Tracef(`unit health checks "%s:%s" updated`, ctx.modelUUID, ctx.id)
+	return h.updateUnit(ctx, (*unitHealthChecksDoc)(h).healthChecks())
+}
+
+func (h *backingUnitHealthChecks) removed(ctx *allWatcherContext) error {
+	allWatcherLogger.Tracef(`unit health checks "%s:%s" removed`, ctx.modelUUID, ctx.id)
+	return h.updateUnit(ctx, nil)
+}
+
+func (h *backingUnitHealthChecks) updateUnit(ctx *allWatcherContext, checks []status.HealthCheck) error {
+	parentID, _, ok := ctx.entityIDForGlobalKey(ctx.id)
+	if !ok {
+		return nil
+	}
+	info, ok := ctx.store.Get(parentID).(*multiwatcher.UnitInfo)
+	if !ok {
+		// The unit info doesn't exist, either because it hasn't
+		// been seen yet or it has already been removed. The checks
+		// are read when the unit is first added.
+		return nil
+	}
+	newInfo := *info
+	newInfo.HealthChecks = checks
+	ctx.store.Update(&newInfo)
+	return nil
+}
+
+func (h *backingUnitHealthChecks) mongoID() string {
+	allWatcherLogger.Criticalf("programming error: attempting to get mongoID from unit health checks document")
+	return ""
+}
+
 type backingSettings settingsDoc
 
 func (s *backingSettings) updated(ctx *allWatcherContext) error {
@@ -1637,6 +1680,7 @@ func NewAllWatcherBacking(pool *StatePool) AllWatcherBacking {
 		remoteApplicationsC,
 		statusesC,
 		settingsC,
+		unitHealthChecksC,
 		// And for CAAS we need to watch these...
 		podSpecsC,
 	}
@@ -1863,6 +1907,7 @@ type allWatcherContext struct {
 	// MachineSubnetPorts instance for each subnet with opened port ranges.
 	openPortRanges map[string][]*machineSubnetPorts
 	userAccess     map[string]map[string]permission.Access
+	healthChecks   map[string][]status.HealthCheck
 }
 
 func (ctx *allWatcherContext) loadSubsidiaryCollections() error {
@@ -1887,6 +1932,9 @@ func (ctx *allWatcherContext) loadSubsidiaryCollections() error {
 	if err := ctx.loadPermissions(); err != nil {
 		return errors.Annotatef(err, "permissions")
 	}
+	if err := ctx.loadUnitHealthChecks(); err != nil {
+		return errors.Annotatef(err, "cache unit health checks")
+	}
 	return nil
 }
 
@@ -1982,6 +2030,23 @@ func (ctx *allWatcherContext) loadOpenedPortRanges() error {
 	return nil
 }
 
+func (ctx *allWatcherContext) loadUnitHealthChecks() error {
+	col, closer := ctx.state.db().GetCollection(unitHealthChecksC)
+	defer closer()
+
+	var docs []unitHealthChecksDoc
+	if err := col.Find(nil).All(&docs); err != nil {
+		return errors.Annotate(err, "cannot read all unit health checks")
+	}
+
+	ctx.healthChecks = make(map[string][]status.HealthCheck)
+	for _, doc := range docs {
+		ctx.healthChecks[doc.DocID] = doc.healthChecks()
+	}
+
+	return nil
+}
+
 func (ctx *allWatcherContext) loadPermissions() error {
 	col, closer := ctx.state.db().GetCollection(permissionsC)
 	defer closer()
@@ -2053,6 +2118,25 @@ func (ctx *allWatcherContext) getAnnotations(key string) map[string]string {
 	return doc.Annotations
 }
 
+func (ctx *allWatcherContext) getUnitHealthChecks(key string) ([]status.HealthCheck, error) {
+	if ctx.healthChecks != nil {
+		// Most units don't report any health checks.
+		return ctx.healthChecks[ensureModelUUID(ctx.modelUUID, key)], nil
+	}
+
+	col, closer := ctx.state.db().GetCollection(unitHealthChecksC)
+	defer closer()
+
+	var doc unitHealthChecksDoc
+	err := col.FindId(key).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return doc.healthChecks(), nil
+}
+
 func (ctx *allWatcherContext) getSettings(key string) (map[string]interface{}, error) {
 	var doc *settingsDoc
 	var err error
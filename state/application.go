@@ -9,6 +9,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/juju/charm/v7"
 	csparams "github.com/juju/charmrepo/v5/csclient/params"
@@ -63,6 +64,11 @@ type applicationDoc struct {
 	TxnRevno             int64        `bson:"txn-revno"`
 	MetricCredentials    []byte       `bson:"metric-credentials"`
 
+	// DeferredHooksFlushed is when, in Unix nanoseconds, the hooks
+	// deferred by the application's maintenance window were last
+	// flushed.
+	DeferredHooksFlushed int64 `bson:"deferred-hooks-flushed,omitempty"`
+
 	// CAAS related attributes.
 	DesiredScale int    `bson:"scale"`
 	PasswordHash string `bson:"passwordhash"`
@@ -612,6 +618,17 @@ func (a *Application) removeOps(asserts bson.D, op *ForcedOperation) ([]txn.Op,
 	// so it's safe to do this additional cleanup.
 	ops = append(ops, finalAppCharmRemoveOps(name, curl)...)
 
+	secretOps, err := removeOwnedSecretsOps(a.st, name)
+	if op.FatalError(err) {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, secretOps...)
+	secretOps, err = removeSecretSubjectOps(a.st, a.Tag())
+	if op.FatalError(err) {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, secretOps...)
+
 	ops = append(ops, a.removeCloudServiceOps()...)
 	globalKey := a.globalKey()
 	ops = append(ops,
@@ -661,6 +678,35 @@ func (a *Application) setExposed(exposed bool) (err error) {
 	return nil
 }
 
+// FlushDeferredHooks arranges for the application's units to run the
+// hooks deferred by its maintenance window now, rather than waiting for
+// the window to close. Hooks are deferred again from the next start of
+// the window.
+func (a *Application) FlushDeferredHooks() error {
+	flushed := a.st.clock().Now().UnixNano()
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Assert: isAliveDoc,
+		Update: bson.D{{"$set", bson.D{{"deferred-hooks-flushed", flushed}}}},
+	}}
+	if err := a.st.db().RunTransaction(ops); err != nil {
+		return errors.Errorf("cannot flush deferred hooks for application %q: %v", a, onAbort(err, applicationNotAliveErr))
+	}
+	a.doc.DeferredHooksFlushed = flushed
+	return nil
+}
+
+// DeferredHooksFlushed returns when the hooks deferred by the
+// application's maintenance window were last flushed, or the zero time
+// if they never have been.
+func (a *Application) DeferredHooksFlushed() time.Time {
+	if a.doc.DeferredHooksFlushed == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, a.doc.DeferredHooksFlushed).UTC()
+}
+
 // Charm returns the application's charm and whether units should upgrade to that
 // charm even if they are in an error state.
 func (a *Application) Charm() (ch *Charm, force bool, err error) {
@@ -2283,6 +2329,8 @@ func (a *Application) removeUnitOps(u *Unit, asserts bson.D, op *ForcedOperation
 		removeStatusOp(a.st, u.globalAgentKey()),
 		removeStatusOp(a.st, u.globalKey()),
 		removeUnitStateOp(a.st, u.globalKey()),
+		removeUnitHealthChecksOp(a.st, u.globalKey()),
+		removeUnitTraceOp(a.st, u.globalKey()),
 		removeStatusOp(a.st, u.globalCloudContainerKey()),
 		removeConstraintsOp(u.globalAgentKey()),
 		annotationRemoveOp(a.st, u.globalKey()),
@@ -2308,6 +2356,12 @@ func (a *Application) removeUnitOps(u *Unit, asserts bson.D, op *ForcedOperation
 	}
 	ops = append(ops, branchOps...)
 
+	secretOps, err := removeSecretSubjectOps(a.st, u.Tag())
+	if op.FatalError(err) {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, secretOps...)
+
 	sb, err := NewStorageBackend(a.st)
 	if err != nil {
 		return nil, errors.Trace(err)
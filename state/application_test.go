@@ -2340,6 +2340,28 @@ func (s *ApplicationSuite) TestApplicationExposed(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, notAliveErr)
 }
 
+func (s *ApplicationSuite) TestFlushDeferredHooks(c *gc.C) {
+	c.Assert(s.mysql.DeferredHooksFlushed().IsZero(), jc.IsTrue)
+
+	now := s.Clock.Now().UTC()
+	err := s.mysql.FlushDeferredHooks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.DeferredHooksFlushed(), gc.Equals, now)
+
+	app, err := s.State.Application(s.mysql.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.DeferredHooksFlushed(), gc.Equals, now)
+}
+
+func (s *ApplicationSuite) TestFlushDeferredHooksDying(c *gc.C) {
+	_, err := s.mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.FlushDeferredHooks()
+	c.Assert(err, gc.ErrorMatches, `cannot flush deferred hooks for application "mysql": application is not found or not alive`)
+}
+
 func (s *ApplicationSuite) TestAddUnit(c *gc.C) {
 	// Check that principal units can be added on their own.
 	c.Assert(s.mysql.UnitCount(), gc.Equals, 0)
@@ -3792,6 +3814,30 @@ func (s *ApplicationSuite) TestWatchCharmConfig(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *ApplicationSuite) TestWatchApplicationConfig(c *gc.C) {
+	app := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	w := app.WatchApplicationConfig()
+	defer testing.AssertStop(c, w)
+
+	// Initial event.
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := app.UpdateApplicationConfig(application.ConfigAttributes{"title": "sir"}, nil, sampleApplicationConfigSchema(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Non-change is not reported.
+	err = app.UpdateApplicationConfig(application.ConfigAttributes{"title": "sir"}, nil, sampleApplicationConfigSchema(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	// Charm config changes are not reported.
+	err = app.UpdateCharmConfig(model.GenerationMaster, charm.Settings{"blog-title": "superhero paparazzi"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
 var updateApplicationConfigTests = []struct {
 	about   string
 	initial application.ConfigAttributes
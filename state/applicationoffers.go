@@ -53,6 +53,33 @@ type applicationOfferDoc struct {
 
 	// Endpoints are the charm endpoints supported by the application.
 	Endpoints map[string]string `bson:"endpoints"`
+
+	// Limits restrict how the offer may be consumed.
+	Limits *offerLimitsDoc `bson:"limits,omitempty"`
+
+	// TxnRevno is used to assert that neither the limits nor the
+	// connections to the offer have changed since they were checked.
+	// It is omitted when empty, since UpdateOffer sets the whole
+	// document.
+	TxnRevno int64 `bson:"txn-revno,omitempty"`
+}
+
+// offerLimitsDoc represents the limits on how an offer may be consumed.
+type offerLimitsDoc struct {
+	MaxConnections     int      `bson:"max-connections,omitempty"`
+	MaxRelations       int      `bson:"max-relations,omitempty"`
+	AllowedControllers []string `bson:"allowed-controllers,omitempty"`
+}
+
+func newOfferLimitsDoc(limits crossmodel.OfferLimits) *offerLimitsDoc {
+	if limits.IsZero() {
+		return nil
+	}
+	return &offerLimitsDoc{
+		MaxConnections:     limits.MaxConnections,
+		MaxRelations:       limits.MaxRelations,
+		AllowedControllers: limits.AllowedControllers,
+	}
 }
 
 var _ crossmodel.ApplicationOffers = (*applicationOffers)(nil)
@@ -402,6 +429,9 @@ func (s *applicationOffers) validateOfferArgs(offer crossmodel.AddApplicationOff
 			return errors.NotValidf("offer reader %q", readUser)
 		}
 	}
+	if offer.Limits != nil {
+		return errors.Trace(offer.Limits.Validate())
+	}
 	return nil
 }
 
@@ -499,6 +529,13 @@ func (s *applicationOffers) UpdateOffer(offerArgs crossmodel.AddApplicationOffer
 		return nil, errors.Trace(err)
 	}
 	doc := s.makeApplicationOfferDoc(s.st, offer.OfferUUID, offerArgs)
+	if offerArgs.Limits == nil {
+		doc.Limits = newOfferLimitsDoc(offer.Limits)
+	}
+	update := bson.D{{"$set", doc}}
+	if doc.Limits == nil {
+		update = append(update, bson.DocElem{"$unset", bson.D{{"limits", nil}}})
+	}
 	var refOps []txn.Op
 	if offerArgs.ApplicationName != offer.ApplicationName {
 		incRefOp, err := incApplicationOffersRefOp(s.st, offerArgs.ApplicationName)
@@ -531,7 +568,7 @@ func (s *applicationOffers) UpdateOffer(offerArgs crossmodel.AddApplicationOffer
 				C:      applicationOffersC,
 				Id:     doc.DocID,
 				Assert: txn.DocExists,
-				Update: bson.M{"$set": doc},
+				Update: update,
 			},
 		}
 		ops = append(ops, refOps...)
@@ -544,6 +581,29 @@ func (s *applicationOffers) UpdateOffer(offerArgs crossmodel.AddApplicationOffer
 	return s.makeApplicationOffer(doc)
 }
 
+// SetOfferLimits replaces the limits on how the named offer may be consumed.
+func (s *applicationOffers) SetOfferLimits(offerName string, limits crossmodel.OfferLimits) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set limits for application offer %q", offerName)
+
+	if err := limits.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := s.ApplicationOffer(offerName); err != nil {
+		return errors.Trace(err)
+	}
+	update := bson.D{{"$unset", bson.D{{"limits", nil}}}}
+	if doc := newOfferLimitsDoc(limits); doc != nil {
+		update = bson.D{{"$set", bson.D{{"limits", doc}}}}
+	}
+	ops := []txn.Op{{
+		C:      applicationOffersC,
+		Id:     s.st.docID(offerName),
+		Assert: txn.DocExists,
+		Update: update,
+	}}
+	return errors.Trace(s.st.db().RunTransaction(ops))
+}
+
 func (s *applicationOffers) makeApplicationOfferDoc(mb modelBackend, uuid string, offer crossmodel.AddApplicationOfferArgs) applicationOfferDoc {
 	doc := applicationOfferDoc{
 		DocID:                  mb.docID(offer.OfferName),
@@ -553,6 +613,9 @@ func (s *applicationOffers) makeApplicationOfferDoc(mb modelBackend, uuid string
 		ApplicationDescription: offer.ApplicationDescription,
 		Endpoints:              offer.Endpoints,
 	}
+	if offer.Limits != nil {
+		doc.Limits = newOfferLimitsDoc(*offer.Limits)
+	}
 	return doc
 }
 
@@ -737,6 +800,13 @@ func (s *applicationOffers) makeApplicationOffer(doc applicationOfferDoc) (*cros
 		ApplicationName:        doc.ApplicationName,
 		ApplicationDescription: doc.ApplicationDescription,
 	}
+	if doc.Limits != nil {
+		offer.Limits = crossmodel.OfferLimits{
+			MaxConnections:     doc.Limits.MaxConnections,
+			MaxRelations:       doc.Limits.MaxRelations,
+			AllowedControllers: doc.Limits.AllowedControllers,
+		}
+	}
 	app, err := s.st.Application(doc.ApplicationName)
 	if err != nil {
 		return nil, errors.Trace(err)
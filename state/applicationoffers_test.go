@@ -434,6 +434,74 @@ func (s *applicationOffersSuite) TestUpdateApplicationOffer(c *gc.C) {
 	assertOffersRef(c, s.State, "mysql", 1)
 }
 
+func (s *applicationOffersSuite) TestUpdateApplicationOfferKeepsLimits(c *gc.C) {
+	sd := state.NewApplicationOffers(s.State)
+	owner := s.Factory.MakeUser(c, nil)
+	limits := crossmodel.OfferLimits{MaxConnections: 2, MaxRelations: 5}
+	_, err := sd.AddOffer(crossmodel.AddApplicationOfferArgs{
+		OfferName:       "hosted-mysql",
+		ApplicationName: "mysql",
+		Owner:           owner.Name(),
+		Limits:          &limits,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	offer, err := sd.UpdateOffer(crossmodel.AddApplicationOfferArgs{
+		OfferName:              "hosted-mysql",
+		ApplicationName:        "mysql",
+		ApplicationDescription: "a better database",
+		Owner:                  owner.Name(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(offer.Limits, jc.DeepEquals, limits)
+
+	offer, err = sd.UpdateOffer(crossmodel.AddApplicationOfferArgs{
+		OfferName:       "hosted-mysql",
+		ApplicationName: "mysql",
+		Owner:           owner.Name(),
+		Limits:          &crossmodel.OfferLimits{},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(offer.Limits, jc.DeepEquals, crossmodel.OfferLimits{})
+	offer, err = sd.ApplicationOffer("hosted-mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(offer.Limits, jc.DeepEquals, crossmodel.OfferLimits{})
+}
+
+func (s *applicationOffersSuite) TestSetOfferLimits(c *gc.C) {
+	s.createDefaultOffer(c)
+	sd := state.NewApplicationOffers(s.State)
+	limits := crossmodel.OfferLimits{
+		MaxRelations:       3,
+		AllowedControllers: []string{testing.ControllerTag.Id()},
+	}
+	err := sd.SetOfferLimits("hosted-mysql", limits)
+	c.Assert(err, jc.ErrorIsNil)
+	offer, err := sd.ApplicationOffer("hosted-mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(offer.Limits, jc.DeepEquals, limits)
+
+	err = sd.SetOfferLimits("hosted-mysql", crossmodel.OfferLimits{})
+	c.Assert(err, jc.ErrorIsNil)
+	offer, err = sd.ApplicationOffer("hosted-mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(offer.Limits, jc.DeepEquals, crossmodel.OfferLimits{})
+}
+
+func (s *applicationOffersSuite) TestSetOfferLimitsInvalid(c *gc.C) {
+	s.createDefaultOffer(c)
+	sd := state.NewApplicationOffers(s.State)
+	err := sd.SetOfferLimits("hosted-mysql", crossmodel.OfferLimits{MaxRelations: -1})
+	c.Assert(err, gc.ErrorMatches, `cannot set limits for application offer "hosted-mysql": max relations -1 not valid`)
+	err = sd.SetOfferLimits("hosted-mysql", crossmodel.OfferLimits{AllowedControllers: []string{"foo"}})
+	c.Assert(err, gc.ErrorMatches, `cannot set limits for application offer "hosted-mysql": allowed controller "foo" not valid`)
+}
+
+func (s *applicationOffersSuite) TestSetOfferLimitsNotFound(c *gc.C) {
+	sd := state.NewApplicationOffers(s.State)
+	err := sd.SetOfferLimits("hosted-mysql", crossmodel.OfferLimits{MaxRelations: 1})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *applicationOffersSuite) TestUpdateApplicationOfferDifferentApp(c *gc.C) {
 	sd := state.NewApplicationOffers(s.State)
 	owner := s.Factory.MakeUser(c, nil)
@@ -40,6 +40,7 @@ const (
 	cleanupForceDestroyedUnit            cleanupKind = "forceDestroyUnit"
 	cleanupForceRemoveUnit               cleanupKind = "forceRemoveUnit"
 	cleanupRemovedUnit                   cleanupKind = "removedUnit"
+	cleanupRemovedRemoteApplication      cleanupKind = "removedRemoteApplication"
 	cleanupApplication                   cleanupKind = "application"
 	cleanupForceApplication              cleanupKind = "forceApplication"
 	cleanupApplicationsForDyingModel     cleanupKind = "applications"
@@ -180,6 +181,8 @@ func (st *State) Cleanup() (err error) {
 			err = st.cleanupDyingUnitResources(doc.Prefix, args)
 		case cleanupRemovedUnit:
 			err = st.cleanupRemovedUnit(doc.Prefix, args)
+		case cleanupRemovedRemoteApplication:
+			err = st.removeRemoteApplicationDiagnostics(doc.Prefix)
 		case cleanupApplicationsForDyingModel:
 			err = st.cleanupApplicationsForDyingModel(args)
 		case cleanupDyingMachine:
@@ -119,9 +119,11 @@ func (s *CleanupSuite) TestCleanupRemoteApplication(c *gc.C) {
 	err = app.Destroy()
 	c.Assert(err, jc.ErrorIsNil)
 	// Removed immediately since there are no relations yet.
-	s.assertDoesNotNeedCleanup(c)
 	_, err = s.State.RemoteApplication("remote-app")
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Its diagnostics are removed by a cleanup.
+	s.assertCleanupCount(c, 1)
 }
 
 func (s *CleanupSuite) TestCleanupRemoteApplicationWithRelations(c *gc.C) {
@@ -98,7 +98,27 @@ func (st *State) UpdateControllerConfig(updateAttrs map[string]interface{}, remo
 	if err := st.checkValidControllerConfig(updateAttrs, removeAttrs); err != nil {
 		return errors.Trace(err)
 	}
+	return errors.Trace(st.writeControllerConfig(updateAttrs, removeAttrs))
+}
+
+// SetMaintenanceMode puts the controller into maintenance mode, with
+// the message to show to users, or takes it out of maintenance mode.
+// The maintenance mode settings are held in the controller config but
+// can't be changed with UpdateControllerConfig.
+func (st *State) SetMaintenanceMode(enabled bool, message string) error {
+	if !enabled {
+		return errors.Trace(st.writeControllerConfig(nil, []string{
+			jujucontroller.MaintenanceMode,
+			jujucontroller.MaintenanceMessage,
+		}))
+	}
+	return errors.Trace(st.writeControllerConfig(map[string]interface{}{
+		jujucontroller.MaintenanceMode:    true,
+		jujucontroller.MaintenanceMessage: message,
+	}, nil))
+}
 
+func (st *State) writeControllerConfig(updateAttrs map[string]interface{}, removeAttrs []string) error {
 	settings, err := readSettings(st.db(), controllersC, ControllerSettingsGlobalKey)
 	if err != nil {
 		return errors.Annotatef(err, "controller %q", st.ControllerUUID())
@@ -128,6 +128,31 @@ func (s *ControllerSuite) TestUpdateControllerConfigRemoveYieldsDefaults(c *gc.C
 	c.Assert(newCfg.AuditLogCaptureArgs(), gc.Equals, false)
 }
 
+func (s *ControllerSuite) TestSetMaintenanceMode(c *gc.C) {
+	err := s.State.SetMaintenanceMode(true, "upgrading storage")
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.MaintenanceMode(), jc.IsTrue)
+	c.Check(cfg.MaintenanceMessage(), gc.Equals, "upgrading storage")
+
+	err = s.State.SetMaintenanceMode(false, "")
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err = s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.MaintenanceMode(), jc.IsFalse)
+	c.Check(cfg.MaintenanceMessage(), gc.Equals, "")
+}
+
+func (s *ControllerSuite) TestUpdateControllerConfigRejectsMaintenanceMode(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.MaintenanceMode: true,
+	}, nil)
+	c.Assert(err, gc.ErrorMatches, `can't change "maintenance-mode" after bootstrap`)
+}
+
 func (s *ControllerSuite) TestUpdateControllerConfigRejectsDisallowedUpdates(c *gc.C) {
 	// Sanity check.
 	c.Assert(controller.AllowedUpdateConfigAttributes.Contains(controller.APIPort), jc.IsFalse)
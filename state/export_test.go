@@ -52,6 +52,9 @@ const (
 	GUISettingsC      = guisettingsC
 	GlobalSettingsC   = globalSettingsC
 	SettingsC         = settingsC
+
+	MaxUnitHookHistory  = maxUnitHookHistory
+	MaxUnitHealthChecks = maxUnitHealthChecks
 )
 
 var (
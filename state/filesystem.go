@@ -948,6 +948,10 @@ func (sb *storageBackend) AddExistingFilesystem(
 		},
 	}}
 	ops = append(ops, fsOps...)
+	ops, err = sb.storagePoolCapacityOps(ops)
+	if err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
 	if err := sb.mb.db().RunTransaction(ops); err != nil {
 		return names.StorageTag{}, errors.Trace(err)
 	}
@@ -4,6 +4,7 @@
 package state
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -888,7 +889,11 @@ func (e *exporter) addApplication(ctx addApplicationContext) error {
 	}
 	exApplication.SetStatus(statusArgs)
 	exApplication.SetStatusHistory(e.statusHistoryArgs(globalKey))
-	exApplication.SetAnnotations(e.getAnnotations(globalKey))
+	annotations, err := offerLimitsAnnotations(e.getAnnotations(globalKey), ctx.offers)
+	if err != nil {
+		return errors.Annotatef(err, "offer limits for application %s", appName)
+	}
+	exApplication.SetAnnotations(annotations)
 
 	globalAppWorkloadKey := applicationGlobalOperatorKey(appName)
 	operatorStatusArgs, err := e.statusArgs(globalAppWorkloadKey)
@@ -1871,6 +1876,46 @@ func (e *exporter) readAllConstraints() error {
 // getAnnotations doesn't really care if there are any there or not
 // for the key, but if they were there, they are removed so we can
 // check at the end of the export for anything we have forgotten.
+// offerLimitsAnnotationPrefix prefixes the application annotations which
+// carry offer limits through a migration. The description package has no
+// field for offer limits, so they are exported alongside the application's
+// annotations and stripped out again on import.
+const offerLimitsAnnotationPrefix = "juju-offer-limits-"
+
+// migratedOfferLimits is the serialised form of an offer's limits.
+type migratedOfferLimits struct {
+	MaxConnections     int      `json:"max-connections,omitempty"`
+	MaxRelations       int      `json:"max-relations,omitempty"`
+	AllowedControllers []string `json:"allowed-controllers,omitempty"`
+}
+
+// offerLimitsAnnotations returns a copy of the application annotations with
+// the limits of any of the offers which have them added.
+func offerLimitsAnnotations(annotations map[string]string, offers []*crossmodel.ApplicationOffer) (map[string]string, error) {
+	result := make(map[string]string, len(annotations))
+	for k, v := range annotations {
+		result[k] = v
+	}
+	for _, offer := range offers {
+		if offer.Limits.IsZero() {
+			continue
+		}
+		data, err := json.Marshal(migratedOfferLimits{
+			MaxConnections:     offer.Limits.MaxConnections,
+			MaxRelations:       offer.Limits.MaxRelations,
+			AllowedControllers: offer.Limits.AllowedControllers,
+		})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result[offerLimitsAnnotationPrefix+offer.OfferUUID] = string(data)
+	}
+	if len(result) == 0 {
+		return nil, nil
+	}
+	return result, nil
+}
+
 func (e *exporter) getAnnotations(key string) map[string]string {
 	result, found := e.annotations[key]
 	if found {
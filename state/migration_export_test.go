@@ -45,6 +45,7 @@ import (
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/poolmanager"
 	"github.com/juju/juju/storage/provider"
+	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/testing/factory"
 	jujuversion "github.com/juju/juju/version"
 )
@@ -647,6 +648,57 @@ func (s *MigrationExportSuite) TestApplicationExposingOffers(c *gc.C) {
 	})
 }
 
+func (s *MigrationExportSuite) TestApplicationOfferLimits(c *gc.C) {
+	app := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+
+	stOffers := state.NewApplicationOffers(s.State)
+	stOffer, err := stOffers.AddOffer(
+		crossmodel.AddApplicationOfferArgs{
+			OfferName:       "my-offer",
+			Owner:           "admin",
+			ApplicationName: app.Name(),
+			Endpoints:       map[string]string{"server": "server"},
+			Limits: &crossmodel.OfferLimits{
+				MaxConnections:     2,
+				MaxRelations:       3,
+				AllowedControllers: []string{coretesting.ControllerTag.Id()},
+			},
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = stOffers.AddOffer(
+		crossmodel.AddApplicationOfferArgs{
+			OfferName:       "unlimited-offer",
+			Owner:           "admin",
+			ApplicationName: app.Name(),
+			Endpoints:       map[string]string{"server": "server"},
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Model.SetAnnotations(app, map[string]string{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	applications := model.Applications()
+	c.Assert(applications, gc.HasLen, 1)
+	c.Assert(applications[0].Offers(), gc.HasLen, 2)
+	// The description package has no field for offer limits, so they
+	// are carried in the application's annotations.
+	c.Assert(applications[0].Annotations(), jc.DeepEquals, map[string]string{
+		"foo": "bar",
+		"juju-offer-limits-" + stOffer.OfferUUID: fmt.Sprintf(
+			`{"max-connections":2,"max-relations":3,"allowed-controllers":[%q]}`,
+			coretesting.ControllerTag.Id()),
+	})
+
+	// The limits are not added to the application's stored annotations.
+	annotations, err := s.Model.Annotations(app)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(annotations, jc.DeepEquals, map[string]string{"foo": "bar"})
+}
+
 func (s *MigrationExportSuite) TestOfferConnections(c *gc.C) {
 	stOffer, err := s.State.AddOfferConnection(state.AddOfferConnectionParams{
 		OfferUUID:       "offer-uuid",
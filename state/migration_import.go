@@ -5,9 +5,11 @@ package state
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/juju/charm/v7"
@@ -22,6 +24,7 @@ import (
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/permission"
@@ -905,7 +908,11 @@ func (i *importer) application(a description.Application, ctrlCfg controller.Con
 		}
 	}
 
-	if annotations := a.Annotations(); len(annotations) > 0 {
+	annotations, offerLimits, err := splitOfferLimitsAnnotations(a.Annotations())
+	if err != nil {
+		return errors.Annotatef(err, "offer limits for application %s", a.Name())
+	}
+	if len(annotations) > 0 {
 		if err := i.dbModel.SetAnnotations(app, annotations); err != nil {
 			return errors.Trace(err)
 		}
@@ -920,7 +927,7 @@ func (i *importer) application(a description.Application, ctrlCfg controller.Con
 		}
 	}
 
-	if err := i.applicationOffers(a); err != nil {
+	if err := i.applicationOffers(a, offerLimits); err != nil {
 		i.logger.Errorf("error importing application %s: %s", app.Name(), err)
 		return errors.Annotate(err, app.Name())
 	}
@@ -928,7 +935,31 @@ func (i *importer) application(a description.Application, ctrlCfg controller.Con
 	return nil
 }
 
-func (i *importer) applicationOffers(app ApplicationDescription) error {
+// splitOfferLimitsAnnotations separates the offer limits carried in the
+// exported application annotations from the annotations themselves,
+// returning the limits keyed on offer UUID.
+func splitOfferLimitsAnnotations(in map[string]string) (map[string]string, map[string]*offerLimitsDoc, error) {
+	annotations := make(map[string]string, len(in))
+	offerLimits := make(map[string]*offerLimitsDoc)
+	for k, v := range in {
+		if !strings.HasPrefix(k, offerLimitsAnnotationPrefix) {
+			annotations[k] = v
+			continue
+		}
+		var limits migratedOfferLimits
+		if err := json.Unmarshal([]byte(v), &limits); err != nil {
+			return nil, nil, errors.Annotatef(err, "parsing %q", k)
+		}
+		offerLimits[strings.TrimPrefix(k, offerLimitsAnnotationPrefix)] = newOfferLimitsDoc(crossmodel.OfferLimits{
+			MaxConnections:     limits.MaxConnections,
+			MaxRelations:       limits.MaxRelations,
+			AllowedControllers: limits.AllowedControllers,
+		})
+	}
+	return annotations, offerLimits, nil
+}
+
+func (i *importer) applicationOffers(app ApplicationDescription, offerLimits map[string]*offerLimitsDoc) error {
 	i.logger.Debugf("importing application offer")
 	migration := &ImportStateMigration{
 		src: i.model,
@@ -946,6 +977,7 @@ func (i *importer) applicationOffers(app ApplicationDescription) error {
 					st:    i.st,
 				},
 				i,
+				offerLimits,
 			},
 			app,
 		}, migration.dst)
@@ -52,16 +52,21 @@ func (s stateModelNamspaceShim) DocID(localID string) string {
 type stateApplicationOfferDocumentFactoryShim struct {
 	stateModelNamspaceShim
 	importer *importer
+	// offerLimits holds the limits of the application's offers, keyed
+	// on offer UUID, since the description package can't carry them.
+	offerLimits map[string]*offerLimitsDoc
 }
 
 func (s stateApplicationOfferDocumentFactoryShim) MakeApplicationOfferDoc(app description.ApplicationOffer) (applicationOfferDoc, error) {
 	ao := &applicationOffers{st: s.importer.st}
-	return ao.makeApplicationOfferDoc(s.importer.st, app.OfferUUID(), crossmodel.AddApplicationOfferArgs{
+	doc := ao.makeApplicationOfferDoc(s.importer.st, app.OfferUUID(), crossmodel.AddApplicationOfferArgs{
 		OfferName:              app.OfferName(),
 		ApplicationName:        app.ApplicationName(),
 		ApplicationDescription: app.ApplicationDescription(),
 		Endpoints:              app.Endpoints(),
-	}), nil
+	})
+	doc.Limits = s.offerLimits[app.OfferUUID()]
+	return doc, nil
 }
 
 func (s stateApplicationOfferDocumentFactoryShim) MakeIncApplicationOffersRefOp(name string) (txn.Op, error) {
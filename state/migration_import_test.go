@@ -853,6 +853,48 @@ func (s *MigrationImportSuite) TestApplicationsWithExposedOffers(c *gc.C) {
 	})
 }
 
+func (s *MigrationImportSuite) TestApplicationOfferLimits(c *gc.C) {
+	application := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	err := s.Model.SetAnnotations(application, map[string]string{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	stOffers := state.NewApplicationOffers(s.State)
+	_, err = stOffers.AddOffer(
+		crossmodel.AddApplicationOfferArgs{
+			OfferName:       "my-offer",
+			Owner:           "admin",
+			ApplicationName: application.Name(),
+			Endpoints:       map[string]string{"server": "server"},
+			Limits: &crossmodel.OfferLimits{
+				MaxConnections:     2,
+				MaxRelations:       3,
+				AllowedControllers: []string{coretesting.ControllerTag.Id()},
+			},
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, newSt := s.importModel(c, s.State)
+	state.AddTestingCharm(c, newSt, "mysql")
+
+	imported, err := state.NewApplicationOffers(newSt).ApplicationOffer("my-offer")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(imported.Limits, jc.DeepEquals, crossmodel.OfferLimits{
+		MaxConnections:     2,
+		MaxRelations:       3,
+		AllowedControllers: []string{coretesting.ControllerTag.Id()},
+	})
+
+	// The annotations carrying the limits are not imported.
+	newModel, err := newSt.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	newApp, err := newSt.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	annotations, err := newModel.Annotations(newApp)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(annotations, jc.DeepEquals, map[string]string{"foo": "bar"})
+}
+
 func (s *MigrationImportSuite) TestExternalControllers(c *gc.C) {
 	remoteApp, err := s.State.AddRemoteApplication(state.AddRemoteApplicationParams{
 		Name:        "gravy-rainbow",
@@ -210,6 +210,26 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// running within a unit. This is a new feature that is not
 		// backwards compatible with older controllers.
 		unitStatesC,
+
+		// Remote application diagnostics are transient; the remote
+		// relations worker records them again after migration.
+		remoteApplicationDiagnosticsC,
+
+		// Offer invitations are signed with the controller's bakery
+		// keys, so they cannot be redeemed on another controller.
+		offerInvitationsC,
+
+		// Hook history is only kept to diagnose charms; the history
+		// starts again on the target controller.
+		unitHookHistoryC,
+
+		// Health checks are reported again by the charms on the
+		// target controller.
+		unitHealthChecksC,
+
+		// Tracing is short lived, and is enabled again on the
+		// target controller if needed.
+		unitTracesC,
 	)
 
 	// THIS SET WILL BE REMOVED WHEN MIGRATIONS ARE COMPLETE
@@ -221,6 +241,13 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// sure the leader units' leases are claimed in the target
 		// controller when leases are managed in raft.
 		leaseHoldersC,
+		// TODO(secrets) - secrets are not yet migrated, and the
+		// content held by the internal backend needs to move with them.
+		// Until then the source precheck refuses models with secrets.
+		secretMetadataC,
+		secretRevisionsC,
+		secretPermissionsC,
+		secretContentC,
 	)
 
 	modelCollections := set.NewStrings()
@@ -396,6 +423,9 @@ func (s *MigrationSuite) TestApplicationDocFields(c *gc.C) {
 		// RelationCount is handled by the number of times the application name
 		// appears in relation endpoints.
 		"RelationCount",
+		// Flushing deferred hooks only matters until the current
+		// maintenance window closes.
+		"DeferredHooksFlushed",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -901,6 +931,26 @@ func (s *MigrationSuite) TestEndpointBindingFields(c *gc.C) {
 	s.AssertExportedFields(c, endpointBindingsDoc{}, fields)
 }
 
+func (s *MigrationSuite) TestApplicationOfferFields(c *gc.C) {
+	definedThroughContainment := set.NewStrings(
+		"DocID",
+		"ApplicationName",
+	)
+	migrated := set.NewStrings(
+		"OfferUUID",
+		"OfferName",
+		"ApplicationDescription",
+		"Endpoints",
+		// Limits are carried in the application's annotations.
+		"Limits",
+	)
+	ignored := set.NewStrings(
+		"TxnRevno",
+	)
+	fields := definedThroughContainment.Union(migrated).Union(ignored)
+	s.AssertExportedFields(c, applicationOfferDoc{}, fields)
+}
+
 func (s *MigrationSuite) AssertExportedFields(c *gc.C, doc interface{}, fields set.Strings) {
 	expected := testing.GetExportedFields(doc)
 	unknown := expected.Difference(fields)
@@ -34,6 +34,10 @@ type ModelMigration interface {
 	// ModelUUID returns the UUID for the model being migrated.
 	ModelUUID() string
 
+	// ModelName returns the name the model had when the migration
+	// was started.
+	ModelName() string
+
 	// Attempt returns the migration attempt identifier. This
 	// increments for each migration attempt for the model.
 	Attempt() int
@@ -56,6 +60,10 @@ type ModelMigration interface {
 	// last changed.
 	PhaseChangedTime() time.Time
 
+	// FailedPhase returns the phase the migration was in when it
+	// failed, or UNKNOWN if it hasn't failed.
+	FailedPhase() migration.Phase
+
 	// StatusMessage returns human readable text about the current
 	// progress of the migration.
 	StatusMessage() string
@@ -67,6 +75,15 @@ type ModelMigration interface {
 	// migration's target controller.
 	TargetInfo() (*migration.TargetInfo, error)
 
+	// KeepSource returns how long the model is kept, dormant, on
+	// this controller after the migration succeeds, so that the
+	// migration can be reverted.
+	KeepSource() time.Duration
+
+	// Revert returns true if the migration moves the model back to
+	// the controller it was migrated from.
+	Revert() bool
+
 	// SetPhase sets the phase of the migration. An error will be
 	// returned if the new phase does not follow the current phase or
 	// if the migration is no longer active.
@@ -127,6 +144,11 @@ type modelMigDoc struct {
 	// The UUID of the model being migrated.
 	ModelUUID string `bson:"model-uuid"`
 
+	// The name of the model being migrated. The model is removed
+	// from the source controller once the migration completes, so
+	// the name is recorded here for reporting.
+	ModelName string `bson:"model-name,omitempty"`
+
 	// The attempt number of the model migration for this model.
 	Attempt int `bson:"attempt"`
 
@@ -162,6 +184,15 @@ type modelMigDoc struct {
 
 	// The list of users and their access-level to the model being migrated.
 	ModelUsers []modelMigUserDoc `bson:"model-users,omitempty"`
+
+	// KeepSource holds how long the model is kept in the DORMANT
+	// phase after a successful migration before it is reaped (stored
+	// in nanoseconds).
+	KeepSource int64 `bson:"keep-source,omitempty"`
+
+	// Revert is set when the migration moves the model back to the
+	// controller it was migrated from.
+	Revert bool `bson:"revert,omitempty"`
 }
 
 type modelMigUserDoc struct {
@@ -205,6 +236,11 @@ type modelMigStatusDoc struct {
 	// StatusMessage holds a human readable message about the
 	// migration's progress.
 	StatusMessage string `bson:"status-message"`
+
+	// FailedPhase holds the phase the migration was in when it
+	// moved to ABORT or REAPFAILED. It is empty if the migration
+	// hasn't failed.
+	FailedPhase string `bson:"failed-phase,omitempty"`
 }
 
 type modelMigMinionSyncDoc struct {
@@ -226,6 +262,11 @@ func (mig *modelMigration) ModelUUID() string {
 	return mig.doc.ModelUUID
 }
 
+// ModelName implements ModelMigration.
+func (mig *modelMigration) ModelName() string {
+	return mig.doc.ModelName
+}
+
 // Attempt implements ModelMigration.
 func (mig *modelMigration) Attempt() int {
 	return mig.doc.Attempt
@@ -260,6 +301,12 @@ func (mig *modelMigration) PhaseChangedTime() time.Time {
 	return unixNanoToTime0(mig.statusDoc.PhaseChangedTime)
 }
 
+// FailedPhase implements ModelMigration.
+func (mig *modelMigration) FailedPhase() migration.Phase {
+	phase, _ := migration.ParsePhase(mig.statusDoc.FailedPhase)
+	return phase
+}
+
 // StatusMessage implements ModelMigration.
 func (mig *modelMigration) StatusMessage() string {
 	return mig.statusDoc.StatusMessage
@@ -291,6 +338,16 @@ func (mig *modelMigration) TargetInfo() (*migration.TargetInfo, error) {
 	}, nil
 }
 
+// KeepSource implements ModelMigration.
+func (mig *modelMigration) KeepSource() time.Duration {
+	return time.Duration(mig.doc.KeepSource)
+}
+
+// Revert implements ModelMigration.
+func (mig *modelMigration) Revert() bool {
+	return mig.doc.Revert
+}
+
 // SetPhase implements ModelMigration.
 func (mig *modelMigration) SetPhase(nextPhase migration.Phase) error {
 	now := mig.st.clock().Now().UnixNano()
@@ -318,14 +375,19 @@ func (mig *modelMigration) SetPhase(nextPhase migration.Phase) error {
 		nextDoc.SuccessTime = now
 		update["success-time"] = now
 	}
+	if nextPhase == migration.ABORT || nextPhase == migration.REAPFAILED {
+		nextDoc.FailedPhase = phase.String()
+		update["failed-phase"] = nextDoc.FailedPhase
+	}
 
 	ops, err := migStatusHistoryAndOps(mig.st, nextPhase, now, mig.StatusMessage())
 	if err != nil {
 		return errors.Trace(err)
 	}
 
-	// If the migration aborted, make the model active again.
-	if nextPhase == migration.ABORTDONE {
+	// If the migration aborted, or was reverted, make the model
+	// active again.
+	if nextPhase == migration.ABORTDONE || nextPhase == migration.REVERTED {
 		ops = append(ops, txn.Op{
 			C:      modelsC,
 			Id:     mig.doc.ModelUUID,
@@ -638,6 +700,14 @@ func (mig *modelMigration) ModelUserAccess(tag names.Tag) permission.Access {
 type MigrationSpec struct {
 	InitiatedBy names.UserTag
 	TargetInfo  migration.TargetInfo
+
+	// KeepSource is how long the model is kept, dormant, after the
+	// migration succeeds so that it can be reverted.
+	KeepSource time.Duration
+
+	// Revert indicates that the model is being moved back to the
+	// controller it was migrated from.
+	Revert bool
 }
 
 // Validate returns an error if the MigrationSpec contains bad
@@ -646,6 +716,12 @@ func (spec *MigrationSpec) Validate() error {
 	if !names.IsValidUser(spec.InitiatedBy.Id()) {
 		return errors.NotValidf("InitiatedBy")
 	}
+	if spec.KeepSource < 0 {
+		return errors.NotValidf("negative KeepSource")
+	}
+	if spec.Revert && spec.KeepSource > 0 {
+		return errors.NotValidf("KeepSource for a revert")
+	}
 	return spec.TargetInfo.Validate()
 }
 
@@ -708,6 +784,7 @@ func (st *State) CreateMigration(spec MigrationSpec) (ModelMigration, error) {
 		doc = modelMigDoc{
 			Id:                    id,
 			ModelUUID:             modelUUID,
+			ModelName:             model.Name(),
 			Attempt:               attempt,
 			InitiatedBy:           spec.InitiatedBy.Id(),
 			TargetController:      spec.TargetInfo.ControllerTag.Id(),
@@ -718,6 +795,8 @@ func (st *State) CreateMigration(spec MigrationSpec) (ModelMigration, error) {
 			TargetPassword:        spec.TargetInfo.Password,
 			TargetMacaroons:       macsJSON,
 			ModelUsers:            userDocs,
+			KeepSource:            int64(spec.KeepSource),
+			Revert:                spec.Revert,
 		}
 
 		statusDoc = modelMigStatusDoc{
@@ -898,6 +977,59 @@ func (st *State) Migration(id string) (ModelMigration, error) {
 	return mig, nil
 }
 
+// LatestMigrations returns the most recent migration of each model
+// which has been migrated, or has had a migration attempted, from this
+// controller. Unlike LatestMigration, it includes the migrations of
+// models which have been removed after a successful migration to
+// another controller.
+func (st *State) LatestMigrations() ([]ModelMigration, error) {
+	migColl, closer := st.db().GetCollection(migrationsC)
+	defer closer()
+	var docs []modelMigDoc
+	if err := migColl.Find(nil).Sort("model-uuid", "-attempt").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "migration lookup failed")
+	}
+
+	statusColl, closer := st.db().GetCollection(migrationsStatusC)
+	defer closer()
+	models, closer := st.db().GetCollection(modelsC)
+	defer closer()
+
+	var result []ModelMigration
+	for i, doc := range docs {
+		if i > 0 && docs[i-1].ModelUUID == doc.ModelUUID {
+			// Only the most recent attempt is reported.
+			continue
+		}
+		var statusDoc modelMigStatusDoc
+		err := statusColl.FindId(doc.Id).One(&statusDoc)
+		if err == mgo.ErrNotFound {
+			return nil, errors.NotFoundf("migration status for %q", doc.Id)
+		} else if err != nil {
+			return nil, errors.Annotate(err, "migration status lookup failed")
+		}
+
+		// As with LatestMigration, hide previous migrations for
+		// models which have been migrated away and then migrated
+		// back.
+		if statusDoc.Phase == migration.DONE.String() {
+			var modelDoc modelDoc
+			err := models.FindId(doc.ModelUUID).Select(bson.M{"migration-mode": 1}).One(&modelDoc)
+			if err == nil && modelDoc.MigrationMode == MigrationModeNone {
+				continue
+			} else if err != nil && err != mgo.ErrNotFound {
+				return nil, errors.Annotate(err, "querying model")
+			}
+		}
+		result = append(result, &modelMigration{
+			doc:       doc,
+			statusDoc: statusDoc,
+			st:        st,
+		})
+	}
+	return result, nil
+}
+
 func (st *State) migrationFromQuery(query mongo.Query) (ModelMigration, error) {
 	var doc modelMigDoc
 	err := query.One(&doc)
@@ -943,6 +1075,39 @@ func IsMigrationActive(st *State, modelUUID string) (bool, error) {
 	return n > 0, nil
 }
 
+// RunningMigrationCount returns the number of model migrations in
+// progress on the controller. Migrations whose models are being kept
+// dormant on the controller after a successful migration aren't
+// counted.
+func (st *State) RunningMigrationCount() (int, error) {
+	active, closer := st.db().GetCollection(migrationsActiveC)
+	defer closer()
+	var docs []struct {
+		Id string `bson:"id"`
+	}
+	if err := active.Find(nil).All(&docs); err != nil {
+		return 0, errors.Annotate(err, "active migration lookup failed")
+	}
+	if len(docs) == 0 {
+		return 0, nil
+	}
+	ids := make([]string, len(docs))
+	for i, doc := range docs {
+		ids[i] = doc.Id
+	}
+
+	statusColl, closer := st.db().GetCollection(migrationsStatusC)
+	defer closer()
+	n, err := statusColl.Find(bson.M{
+		"_id":   bson.M{"$in": ids},
+		"phase": bson.M{"$ne": migration.DORMANT.String()},
+	}).Count()
+	if err != nil {
+		return 0, errors.Annotate(err, "migration status lookup failed")
+	}
+	return n, nil
+}
+
 func unixNanoToTime0(i int64) time.Time {
 	if i == 0 {
 		return time.Time{}
@@ -68,6 +68,7 @@ func (s *MigrationSuite) TestCreate(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	c.Check(mig.ModelUUID(), gc.Equals, s.State2.ModelUUID())
+	c.Check(mig.ModelName(), gc.Equals, model.Name())
 	checkIdAndAttempt(c, mig, 0)
 
 	c.Check(mig.StartTime(), gc.Equals, s.Clock.Now())
@@ -89,6 +90,7 @@ func (s *MigrationSuite) TestCreate(c *gc.C) {
 
 	assertPhase(c, mig, migration.QUIESCE)
 	c.Check(mig.PhaseChangedTime(), gc.Equals, mig.StartTime())
+	c.Check(mig.FailedPhase(), gc.Equals, migration.UNKNOWN)
 
 	assertMigrationActive(c, s.State2)
 
@@ -115,6 +117,29 @@ func (s *MigrationSuite) TestIsMigrationActive(c *gc.C) {
 	check(true)
 }
 
+func (s *MigrationSuite) TestRunningMigrationCount(c *gc.C) {
+	check := func(expected int) {
+		n, err := s.State.RunningMigrationCount()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(n, gc.Equals, expected)
+	}
+
+	check(0)
+
+	mig2, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	state3 := s.Factory.MakeModel(c, nil)
+	defer state3.Close()
+	_, err = state3.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	check(2)
+
+	c.Assert(mig2.SetPhase(migration.ABORT), jc.ErrorIsNil)
+	check(2)
+	c.Assert(mig2.SetPhase(migration.ABORTDONE), jc.ErrorIsNil)
+	check(1)
+}
+
 func (s *MigrationSuite) TestIdSequencesAreIndependent(c *gc.C) {
 	st2 := s.State2
 	st3 := s.Factory.MakeModel(c, nil)
@@ -179,6 +204,19 @@ func (s *MigrationSuite) TestSpecValidation(c *gc.C) {
 			spec.TargetInfo.Addrs = nil
 		},
 		"empty Addrs not valid",
+	}, {
+		"negative KeepSource",
+		func(spec *state.MigrationSpec) {
+			spec.KeepSource = -time.Hour
+		},
+		"negative KeepSource not valid",
+	}, {
+		"KeepSource with Revert",
+		func(spec *state.MigrationSpec) {
+			spec.KeepSource = time.Hour
+			spec.Revert = true
+		},
+		"KeepSource for a revert not valid",
 	}}
 	for _, test := range tests {
 		c.Logf("---- %s -----------", test.label)
@@ -282,6 +320,33 @@ func (s *MigrationSuite) TestGetsLatestAttempt(c *gc.C) {
 	}
 }
 
+func (s *MigrationSuite) TestLatestMigrations(c *gc.C) {
+	migs, err := s.State.LatestMigrations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(migs, gc.HasLen, 0)
+
+	// Abort a first attempt so that only the second is reported.
+	mig1, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig1.SetPhase(migration.ABORT), jc.ErrorIsNil)
+	c.Assert(mig1.SetPhase(migration.ABORTDONE), jc.ErrorIsNil)
+	mig2, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	state3 := s.Factory.MakeModel(c, nil)
+	defer state3.Close()
+	mig3, err := state3.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	migs, err = s.State.LatestMigrations()
+	c.Assert(err, jc.ErrorIsNil)
+	ids := make([]string, len(migs))
+	for i, mig := range migs {
+		ids[i] = mig.Id()
+	}
+	c.Check(ids, jc.SameContents, []string{mig2.Id(), mig3.Id()})
+}
+
 func (s *MigrationSuite) TestLatestMigrationWithPrevious(c *gc.C) {
 	// Check the scenario of a model having been migrated away and
 	// then migrated back several times. The previous migrations
@@ -440,6 +505,7 @@ func (s *MigrationSuite) TestABORTCleanup(c *gc.C) {
 	c.Assert(mig.SetPhase(migration.ABORTDONE), jc.ErrorIsNil)
 
 	s.assertMigrationCleanedUp(c, mig)
+	c.Check(mig.FailedPhase(), gc.Equals, migration.QUIESCE)
 
 	// Model should be set back to active.
 	model, err := s.State2.Model()
@@ -467,6 +533,60 @@ func (s *MigrationSuite) TestREAPFAILEDCleanup(c *gc.C) {
 	}
 
 	s.assertMigrationCleanedUp(c, mig)
+	c.Check(mig.FailedPhase(), gc.Equals, migration.REAP)
+}
+
+func (s *MigrationSuite) TestKeepSource(c *gc.C) {
+	s.stdSpec.KeepSource = 24 * time.Hour
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig.KeepSource(), gc.Equals, 24*time.Hour)
+	c.Check(mig.Revert(), jc.IsFalse)
+
+	mig2, err := s.State2.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig2.KeepSource(), gc.Equals, 24*time.Hour)
+}
+
+func (s *MigrationSuite) TestRevert(c *gc.C) {
+	s.stdSpec.Revert = true
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig.Revert(), jc.IsTrue)
+	c.Check(mig.KeepSource(), gc.Equals, time.Duration(0))
+}
+
+func (s *MigrationSuite) TestREVERTEDCleanup(c *gc.C) {
+	s.stdSpec.KeepSource = time.Hour
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Advance the migration to DORMANT and then revert it.
+	phases := []migration.Phase{
+		migration.IMPORT,
+		migration.PROCESSRELATIONS,
+		migration.VALIDATION,
+		migration.SUCCESS,
+		migration.LOGTRANSFER,
+		migration.DORMANT,
+	}
+	for _, phase := range phases {
+		s.Clock.Advance(time.Millisecond)
+		c.Assert(mig.SetPhase(phase), jc.ErrorIsNil)
+	}
+	assertMigrationActive(c, s.State2)
+	model, err := s.State2.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.MigrationMode(), gc.Equals, state.MigrationModeExporting)
+
+	s.Clock.Advance(time.Millisecond)
+	c.Assert(mig.SetPhase(migration.REVERTED), jc.ErrorIsNil)
+	s.assertMigrationCleanedUp(c, mig)
+	c.Check(mig.FailedPhase(), gc.Equals, migration.UNKNOWN)
+
+	// The kept model is usable again.
+	c.Assert(model.Refresh(), jc.ErrorIsNil)
+	c.Assert(model.MigrationMode(), gc.Equals, state.MigrationModeNone)
 }
 
 func (s *MigrationSuite) assertMigrationCleanedUp(c *gc.C, mig state.ModelMigration) {
@@ -6,6 +6,7 @@ package state
 import (
 	"fmt"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/names/v4"
@@ -95,6 +96,10 @@ type AddOfferConnectionParams struct {
 
 	// RelationKey is the key of the relation to which this offer pertains.
 	RelationKey string
+
+	// SourceControllerUUID is the UUID of the controller hosting
+	// the consuming model, if known.
+	SourceControllerUUID string
 }
 
 func validateOfferConnectionParams(args AddOfferConnectionParams) (err error) {
@@ -135,12 +140,21 @@ func (st *State) AddOfferConnection(args AddOfferConnectionParams) (_ *OfferConn
 	}
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		// If we've tried once already and failed, check that
-		// model may have been destroyed.
+		// model may have been destroyed, or the connection
+		// added concurrently.
 		if attempt > 0 {
 			if err := checkModelActive(st); err != nil {
 				return nil, errors.Trace(err)
 			}
-			return nil, errors.AlreadyExistsf("offer connection for relation id %d", args.RelationId)
+			if _, err := st.OfferConnectionForRelation(args.RelationKey); err == nil {
+				return nil, errors.AlreadyExistsf("offer connection for relation id %d", args.RelationId)
+			} else if !errors.IsNotFound(err) {
+				return nil, errors.Trace(err)
+			}
+		}
+		limitOps, err := st.checkOfferConnectionAllowed(args.OfferUUID, args.SourceModelUUID, args.SourceControllerUUID, args.RelationId)
+		if err != nil {
+			return nil, errors.Trace(err)
 		}
 		ops := []txn.Op{
 			model.assertActiveOp(),
@@ -151,7 +165,7 @@ func (st *State) AddOfferConnection(args AddOfferConnectionParams) (_ *OfferConn
 				Insert: &offerConnectionDoc,
 			},
 		}
-		return ops, nil
+		return append(ops, limitOps...), nil
 	}
 	if err = st.db().Run(buildTxn); err != nil {
 		return nil, errors.Trace(err)
@@ -159,6 +173,68 @@ func (st *State) AddOfferConnection(args AddOfferConnectionParams) (_ *OfferConn
 	return &OfferConnection{doc: offerConnectionDoc}, nil
 }
 
+// CheckOfferConnectionAllowed returns an error satisfying
+// errors.IsQuotaLimitExceeded if a new relation from the specified
+// consuming model would exceed the offer's connection limits, or
+// errors.IsForbidden if the consuming controller may not relate
+// to the offer.
+func (st *State) CheckOfferConnectionAllowed(offerUUID, sourceModelUUID, sourceControllerUUID string) error {
+	_, err := st.checkOfferConnectionAllowed(offerUUID, sourceModelUUID, sourceControllerUUID, -1)
+	return errors.Trace(err)
+}
+
+// checkOfferConnectionAllowed checks the connection against the offer's
+// limits, returning the ops needed to ensure they still hold when the
+// connection is added.
+func (st *State) checkOfferConnectionAllowed(offerUUID, sourceModelUUID, sourceControllerUUID string, relationId int) ([]txn.Op, error) {
+	offerDoc, err := (&applicationOffers{st: st}).offerQuery(bson.D{{"offer-uuid", offerUUID}})
+	if err == mgo.ErrNotFound {
+		// Offer connections for removed offers are still recorded
+		// so that the relation can be cleaned up.
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot load application offer %q", offerUUID)
+	}
+	// The offer document is updated whenever its limits change, and by
+	// every connection added while it has limits. Asserting it hasn't
+	// changed means neither can happen between this check and the
+	// connection being added.
+	ops := []txn.Op{{
+		C:      applicationOffersC,
+		Id:     offerDoc.DocID,
+		Assert: bson.D{{"txn-revno", offerDoc.TxnRevno}},
+	}}
+	if offerDoc.Limits == nil {
+		return ops, nil
+	}
+	limits := offerDoc.Limits
+	if len(limits.AllowedControllers) > 0 && !set.NewStrings(limits.AllowedControllers...).Contains(sourceControllerUUID) {
+		return nil, errors.Forbiddenf("offer %q does not accept relations from models on this controller", offerDoc.OfferName)
+	}
+	conns, err := st.OfferConnections(offerUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	connectedModels := set.NewStrings()
+	for _, conn := range conns {
+		if conn.RelationId() == relationId {
+			// The connection is already recorded.
+			return nil, errors.AlreadyExistsf("offer connection for relation id %d", relationId)
+		}
+		connectedModels.Add(conn.SourceModelUUID())
+	}
+	if limits.MaxRelations > 0 && len(conns) >= limits.MaxRelations {
+		return nil, errors.QuotaLimitExceededf("offer %q is limited to %d relations", offerDoc.OfferName, limits.MaxRelations)
+	}
+	if limits.MaxConnections > 0 && !connectedModels.Contains(sourceModelUUID) && connectedModels.Size() >= limits.MaxConnections {
+		return nil, errors.QuotaLimitExceededf("offer %q is limited to %d connected models", offerDoc.OfferName, limits.MaxConnections)
+	}
+	// Concurrent connections are serialised by counting them on the
+	// offer document, which increments its txn-revno.
+	ops[0].Update = bson.D{{"$inc", bson.D{{"connections-added", 1}}}}
+	return ops, nil
+}
+
 // AllOfferConnections returns all offer connections in the model.
 func (st *State) AllOfferConnections() ([]*OfferConnection, error) {
 	conns, err := st.offerConnections(nil)
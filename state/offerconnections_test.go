@@ -10,6 +10,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/errors"
+	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing"
@@ -114,6 +115,152 @@ func (s *offerConnectionsSuite) TestAddOfferConnectionTwice(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
 }
 
+func (s *offerConnectionsSuite) addLimitedOffer(c *gc.C, limits crossmodel.OfferLimits) string {
+	owner := s.Factory.MakeUser(c, nil)
+	offer, err := state.NewApplicationOffers(s.State).AddOffer(crossmodel.AddApplicationOfferArgs{
+		OfferName:       "hosted-mysql",
+		ApplicationName: "mysql",
+		Endpoints:       map[string]string{"server": "server"},
+		Owner:           owner.Name(),
+		Limits:          &limits,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return offer.OfferUUID
+}
+
+func (s *offerConnectionsSuite) TestAddOfferConnectionMaxRelations(c *gc.C) {
+	offerUUID := s.addLimitedOffer(c, crossmodel.OfferLimits{MaxRelations: 1})
+	_, err := s.State.AddOfferConnection(state.AddOfferConnectionParams{
+		SourceModelUUID: testing.ModelTag.Id(),
+		RelationId:      s.activeRel.Id(),
+		RelationKey:     s.activeRel.Tag().Id(),
+		Username:        "fred",
+		OfferUUID:       offerUUID,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Recording the same connection again is not subject to the limit.
+	_, err = s.State.AddOfferConnection(state.AddOfferConnectionParams{
+		SourceModelUUID: testing.ModelTag.Id(),
+		RelationId:      s.activeRel.Id(),
+		RelationKey:     s.activeRel.Tag().Id(),
+		Username:        "fred",
+		OfferUUID:       offerUUID,
+	})
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+
+	_, err = s.State.AddOfferConnection(state.AddOfferConnectionParams{
+		SourceModelUUID: testing.ModelTag.Id(),
+		RelationId:      s.suspendedRel.Id(),
+		RelationKey:     s.suspendedRel.Tag().Id(),
+		Username:        "fred",
+		OfferUUID:       offerUUID,
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add offer record for ".*": offer "hosted-mysql" is limited to 1 relations`)
+	c.Assert(err, jc.Satisfies, errors.IsQuotaLimitExceeded)
+}
+
+func (s *offerConnectionsSuite) TestAddOfferConnectionMaxRelationsConcurrent(c *gc.C) {
+	offerUUID := s.addLimitedOffer(c, crossmodel.OfferLimits{MaxRelations: 1})
+	defer state.SetBeforeHooks(c, s.State, func() {
+		_, err := s.State.AddOfferConnection(state.AddOfferConnectionParams{
+			SourceModelUUID: testing.ModelTag.Id(),
+			RelationId:      s.activeRel.Id(),
+			RelationKey:     s.activeRel.Tag().Id(),
+			Username:        "fred",
+			OfferUUID:       offerUUID,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	_, err := s.State.AddOfferConnection(state.AddOfferConnectionParams{
+		SourceModelUUID: testing.ModelTag.Id(),
+		RelationId:      s.suspendedRel.Id(),
+		RelationKey:     s.suspendedRel.Tag().Id(),
+		Username:        "fred",
+		OfferUUID:       offerUUID,
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add offer record for ".*": offer "hosted-mysql" is limited to 1 relations`)
+}
+
+func (s *offerConnectionsSuite) TestAddOfferConnectionLimitsSetConcurrently(c *gc.C) {
+	offerUUID := s.addLimitedOffer(c, crossmodel.OfferLimits{})
+	_, err := s.State.AddOfferConnection(state.AddOfferConnectionParams{
+		SourceModelUUID: testing.ModelTag.Id(),
+		RelationId:      s.activeRel.Id(),
+		RelationKey:     s.activeRel.Tag().Id(),
+		Username:        "fred",
+		OfferUUID:       offerUUID,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	defer state.SetBeforeHooks(c, s.State, func() {
+		err := state.NewApplicationOffers(s.State).SetOfferLimits("hosted-mysql", crossmodel.OfferLimits{MaxRelations: 1})
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	_, err = s.State.AddOfferConnection(state.AddOfferConnectionParams{
+		SourceModelUUID: testing.ModelTag.Id(),
+		RelationId:      s.suspendedRel.Id(),
+		RelationKey:     s.suspendedRel.Tag().Id(),
+		Username:        "fred",
+		OfferUUID:       offerUUID,
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add offer record for ".*": offer "hosted-mysql" is limited to 1 relations`)
+}
+
+func (s *offerConnectionsSuite) TestAddOfferConnectionMaxConnections(c *gc.C) {
+	offerUUID := s.addLimitedOffer(c, crossmodel.OfferLimits{MaxConnections: 1})
+	_, err := s.State.AddOfferConnection(state.AddOfferConnectionParams{
+		SourceModelUUID: testing.ModelTag.Id(),
+		RelationId:      s.activeRel.Id(),
+		RelationKey:     s.activeRel.Tag().Id(),
+		Username:        "fred",
+		OfferUUID:       offerUUID,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.CheckOfferConnectionAllowed(offerUUID, testing.ModelTag.Id(), "")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.CheckOfferConnectionAllowed(offerUUID, "deadbeef-0bad-400d-8000-4b1d0d06f00e", "")
+	c.Assert(err, gc.ErrorMatches, `offer "hosted-mysql" is limited to 1 connected models`)
+
+	_, err = s.State.AddOfferConnection(state.AddOfferConnectionParams{
+		SourceModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00e",
+		RelationId:      s.suspendedRel.Id(),
+		RelationKey:     s.suspendedRel.Tag().Id(),
+		Username:        "fred",
+		OfferUUID:       offerUUID,
+	})
+	c.Assert(err, jc.Satisfies, errors.IsQuotaLimitExceeded)
+}
+
+func (s *offerConnectionsSuite) TestAddOfferConnectionAllowedControllers(c *gc.C) {
+	offerUUID := s.addLimitedOffer(c, crossmodel.OfferLimits{
+		AllowedControllers: []string{testing.ControllerTag.Id()},
+	})
+	_, err := s.State.AddOfferConnection(state.AddOfferConnectionParams{
+		SourceModelUUID:      testing.ModelTag.Id(),
+		SourceControllerUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00e",
+		RelationId:           s.activeRel.Id(),
+		RelationKey:          s.activeRel.Tag().Id(),
+		Username:             "fred",
+		OfferUUID:            offerUUID,
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add offer record for ".*": offer "hosted-mysql" does not accept relations from models on this controller`)
+	c.Assert(err, jc.Satisfies, errors.IsForbidden)
+
+	_, err = s.State.AddOfferConnection(state.AddOfferConnectionParams{
+		SourceModelUUID:      testing.ModelTag.Id(),
+		SourceControllerUUID: testing.ControllerTag.Id(),
+		RelationId:           s.activeRel.Id(),
+		RelationKey:          s.activeRel.Tag().Id(),
+		Username:             "fred",
+		OfferUUID:            offerUUID,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *offerConnectionsSuite) TestOfferConnectionForRelation(c *gc.C) {
 	oc, err := s.State.AddOfferConnection(state.AddOfferConnectionParams{
 		SourceModelUUID: testing.ModelTag.Id(),
@@ -0,0 +1,198 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/utils"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/permission"
+)
+
+// offerInvitationDomain is the user domain of the users to
+// which invitations to consume an offer grant access.
+const offerInvitationDomain = "invitation"
+
+// OfferInvitation represents an invitation to consume an offer
+// hosted in this model, which can be redeemed once by a model
+// that is not necessarily known to this controller.
+type OfferInvitation struct {
+	st  *State
+	doc offerInvitationDoc
+}
+
+// offerInvitationDoc represents the internal state of an offer
+// invitation in MongoDB.
+type offerInvitationDoc struct {
+	DocID      string     `bson:"_id"`
+	Id         string     `bson:"id"`
+	ModelUUID  string     `bson:"model-uuid"`
+	OfferUUID  string     `bson:"offer-uuid"`
+	CreatedBy  string     `bson:"created-by"`
+	Expires    time.Time  `bson:"expires"`
+	RedeemedBy string     `bson:"redeemed-by,omitempty"`
+	Redeemed   *time.Time `bson:"redeemed,omitempty"`
+}
+
+// Id returns the id of the invitation.
+func (i *OfferInvitation) Id() string {
+	return i.doc.Id
+}
+
+// OfferUUID returns the UUID of the offer to which the invitation
+// grants access.
+func (i *OfferInvitation) OfferUUID() string {
+	return i.doc.OfferUUID
+}
+
+// CreatedBy returns the name of the user who created the invitation.
+func (i *OfferInvitation) CreatedBy() string {
+	return i.doc.CreatedBy
+}
+
+// Expires returns when the invitation can no longer be redeemed.
+func (i *OfferInvitation) Expires() time.Time {
+	return i.doc.Expires.UTC()
+}
+
+// Invitee returns the user to which consume access to the offer
+// is granted when the invitation is redeemed.
+func (i *OfferInvitation) Invitee() names.UserTag {
+	return names.NewUserTag(fmt.Sprintf("%s@%s", i.doc.Id, offerInvitationDomain))
+}
+
+// RedeemedBy returns the UUID of the model which redeemed the
+// invitation, or "" if it has not been redeemed.
+func (i *OfferInvitation) RedeemedBy() string {
+	return i.doc.RedeemedBy
+}
+
+// AddOfferInvitationParams contains the parameters for adding an
+// invitation to consume an offer.
+type AddOfferInvitationParams struct {
+	// OfferUUID is the UUID of the offer.
+	OfferUUID string
+
+	// CreatedBy is the user creating the invitation.
+	CreatedBy names.UserTag
+
+	// Expiry is how long the invitation can be redeemed for.
+	Expiry time.Duration
+}
+
+// AddOfferInvitation records a new invitation to consume an offer.
+func (st *State) AddOfferInvitation(args AddOfferInvitationParams) (_ *OfferInvitation, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add invitation for offer %q", args.OfferUUID)
+
+	if args.Expiry <= 0 {
+		return nil, errors.NotValidf("expiry %v", args.Expiry)
+	}
+	if _, err := NewApplicationOffers(st).ApplicationOfferForUUID(args.OfferUUID); err != nil {
+		return nil, errors.Trace(err)
+	}
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	id, err := newOfferInvitationId()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	doc := offerInvitationDoc{
+		DocID:     st.docID(id),
+		Id:        id,
+		ModelUUID: st.ModelUUID(),
+		OfferUUID: args.OfferUUID,
+		CreatedBy: args.CreatedBy.Id(),
+		Expires:   st.clock().Now().Add(args.Expiry).UTC(),
+	}
+	ops := []txn.Op{
+		model.assertActiveOp(),
+		{
+			C:      offerInvitationsC,
+			Id:     doc.DocID,
+			Assert: txn.DocMissing,
+			Insert: &doc,
+		},
+	}
+	if err := st.db().RunTransaction(ops); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &OfferInvitation{st: st, doc: doc}, nil
+}
+
+// OfferInvitation returns the invitation with the given id.
+func (st *State) OfferInvitation(id string) (*OfferInvitation, error) {
+	invitations, closer := st.db().GetCollection(offerInvitationsC)
+	defer closer()
+
+	var doc offerInvitationDoc
+	err := invitations.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("offer invitation %q", id)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get offer invitation %q", id)
+	}
+	return &OfferInvitation{st: st, doc: doc}, nil
+}
+
+// RedeemOfferInvitation redeems the invitation with the given id on
+// behalf of the specified model, granting consume access to the offer
+// to the invitation's invitee. An invitation can only be redeemed once,
+// even by the same model, since redeeming it mints a new credential.
+func (st *State) RedeemOfferInvitation(id, sourceModelUUID string) (_ *OfferInvitation, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot redeem offer invitation %q", id)
+
+	if !names.IsValidModel(sourceModelUUID) {
+		return nil, errors.NotValidf("source model %q", sourceModelUUID)
+	}
+	var invitation *OfferInvitation
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		invitation, err = st.OfferInvitation(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if invitation.doc.RedeemedBy != "" {
+			return nil, errors.Errorf("invitation has already been redeemed")
+		}
+		now := st.clock().Now()
+		if !now.Before(invitation.doc.Expires) {
+			return nil, errors.Errorf("invitation has expired")
+		}
+		if _, err := NewApplicationOffers(st).ApplicationOfferForUUID(invitation.doc.OfferUUID); err != nil {
+			return nil, errors.Trace(err)
+		}
+		invitee := userGlobalKey(userAccessID(invitation.Invitee()))
+		return []txn.Op{{
+			C:      offerInvitationsC,
+			Id:     invitation.doc.DocID,
+			Assert: bson.D{{"redeemed-by", bson.D{{"$exists", false}}}},
+			Update: bson.D{{"$set", bson.D{
+				{"redeemed-by", sourceModelUUID},
+				{"redeemed", now},
+			}}},
+		}, createPermissionOp(applicationOfferKey(invitation.doc.OfferUUID), invitee, permission.ConsumeAccess)}, nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return invitation, nil
+}
+
+func newOfferInvitationId() (string, error) {
+	b, err := utils.RandomBytes(8)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing"
+)
+
+type offerInvitationsSuite struct {
+	ConnSuite
+
+	offerUUID string
+}
+
+var _ = gc.Suite(&offerInvitationsSuite{})
+
+func (s *offerInvitationsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	owner := s.Factory.MakeUser(c, nil)
+	offer, err := state.NewApplicationOffers(s.State).AddOffer(crossmodel.AddApplicationOfferArgs{
+		OfferName:       "hosted-mysql",
+		ApplicationName: "mysql",
+		Endpoints:       map[string]string{"server": "server"},
+		Owner:           owner.Name(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.offerUUID = offer.OfferUUID
+}
+
+func (s *offerInvitationsSuite) addInvitation(c *gc.C) *state.OfferInvitation {
+	inv, err := s.State.AddOfferInvitation(state.AddOfferInvitationParams{
+		OfferUUID: s.offerUUID,
+		CreatedBy: s.Owner,
+		Expiry:    time.Hour,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return inv
+}
+
+func (s *offerInvitationsSuite) TestAddOfferInvitation(c *gc.C) {
+	inv := s.addInvitation(c)
+	c.Assert(inv.Id(), gc.Matches, "[0-9a-f]{16}")
+	c.Assert(inv.OfferUUID(), gc.Equals, s.offerUUID)
+	c.Assert(inv.CreatedBy(), gc.Equals, s.Owner.Id())
+	c.Assert(inv.Expires().Equal(s.Clock.Now().Add(time.Hour)), jc.IsTrue)
+	c.Assert(inv.Invitee().Id(), gc.Equals, inv.Id()+"@invitation")
+	c.Assert(inv.RedeemedBy(), gc.Equals, "")
+
+	got, err := s.State.OfferInvitation(inv.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got.OfferUUID(), gc.Equals, s.offerUUID)
+}
+
+func (s *offerInvitationsSuite) TestAddOfferInvitationOfferNotFound(c *gc.C) {
+	_, err := s.State.AddOfferInvitation(state.AddOfferInvitationParams{
+		OfferUUID: "missing",
+		CreatedBy: s.Owner,
+		Expiry:    time.Hour,
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *offerInvitationsSuite) TestOfferInvitationNotFound(c *gc.C) {
+	_, err := s.State.OfferInvitation("missing")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *offerInvitationsSuite) TestRedeemOfferInvitation(c *gc.C) {
+	inv := s.addInvitation(c)
+	inv, err := s.State.RedeemOfferInvitation(inv.Id(), testing.ModelTag.Id())
+	c.Assert(err, jc.ErrorIsNil)
+
+	access, err := s.State.GetOfferAccess(s.offerUUID, inv.Invitee())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.ConsumeAccess)
+
+	got, err := s.State.OfferInvitation(inv.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got.RedeemedBy(), gc.Equals, testing.ModelTag.Id())
+
+	// Even the same model can't redeem it again.
+	_, err = s.State.RedeemOfferInvitation(inv.Id(), testing.ModelTag.Id())
+	c.Assert(err, gc.ErrorMatches, `cannot redeem offer invitation ".*": invitation has already been redeemed`)
+}
+
+func (s *offerInvitationsSuite) TestRedeemOfferInvitationTwice(c *gc.C) {
+	inv := s.addInvitation(c)
+	_, err := s.State.RedeemOfferInvitation(inv.Id(), testing.ModelTag.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.RedeemOfferInvitation(inv.Id(), "deadbeef-0bad-400d-8000-4b1d0d06f00e")
+	c.Assert(err, gc.ErrorMatches, `cannot redeem offer invitation ".*": invitation has already been redeemed`)
+}
+
+func (s *offerInvitationsSuite) TestRedeemOfferInvitationExpired(c *gc.C) {
+	inv := s.addInvitation(c)
+	s.Clock.Advance(2 * time.Hour)
+	_, err := s.State.RedeemOfferInvitation(inv.Id(), testing.ModelTag.Id())
+	c.Assert(err, gc.ErrorMatches, `cannot redeem offer invitation ".*": invitation has expired`)
+
+	_, err = s.State.GetOfferAccess(s.offerUUID, inv.Invitee())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
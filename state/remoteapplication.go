@@ -467,6 +467,9 @@ func (s *RemoteApplication) removeOps(asserts bson.D) ([]txn.Op, error) {
 			Remove: true,
 		},
 		removeStatusOp(s.st, s.globalKey()),
+		// The diagnostics are written outside of transactions, so
+		// are removed by a cleanup rather than here.
+		newCleanupOp(cleanupRemovedRemoteApplication, s.doc.Name),
 	}
 	tokenOps := r.removeRemoteEntityOps(s.Tag())
 	ops = append(ops, tokenOps...)
@@ -908,6 +911,12 @@ func (st *State) AddRemoteApplication(args AddRemoteApplicationParams) (_ *Remot
 	if err = st.db().Run(buildTxn); err != nil {
 		return nil, errors.Trace(err)
 	}
+	// Diagnostics are removed by a cleanup after the remote
+	// application is, so make sure none are reported from a previous
+	// incarnation whose cleanup has yet to run.
+	if err := st.removeRemoteApplicationDiagnostics(args.Name); err != nil {
+		return nil, errors.Trace(err)
+	}
 	return app, nil
 }
 
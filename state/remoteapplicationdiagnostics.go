@@ -0,0 +1,104 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+
+	"github.com/juju/juju/core/crossmodel"
+)
+
+// remoteApplicationDiagnosticsDoc records the health of the cross
+// model relation traffic for a remote application. The documents are
+// written frequently and are not critical, so they are written outside
+// of transactions.
+type remoteApplicationDiagnosticsDoc struct {
+	DocID            string     `bson:"_id"`
+	ModelUUID        string     `bson:"model-uuid"`
+	Name             string     `bson:"name"`
+	LastRemoteEvent  *time.Time `bson:"last-remote-event,omitempty"`
+	MacaroonExpiry   *time.Time `bson:"macaroon-expiry,omitempty"`
+	SettingsSyncLag  int64      `bson:"settings-sync-lag"`
+	IngressAddresses []string   `bson:"ingress-addresses,omitempty"`
+	Updated          time.Time  `bson:"updated"`
+}
+
+// Diagnostics returns the cross model relation diagnostics last
+// recorded for the remote application. It returns an error satisfying
+// errors.IsNotFound if none have been recorded.
+func (s *RemoteApplication) Diagnostics() (*crossmodel.RemoteApplicationDiagnostics, error) {
+	diagnostics, closer := s.st.db().GetRawCollection(remoteApplicationDiagnosticsC)
+	defer closer()
+
+	var doc remoteApplicationDiagnosticsDoc
+	err := diagnostics.FindId(s.doc.DocID).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("diagnostics for remote application %q", s)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get diagnostics for remote application %q", s)
+	}
+	result := &crossmodel.RemoteApplicationDiagnostics{
+		SettingsSyncLag:  time.Duration(doc.SettingsSyncLag),
+		IngressAddresses: doc.IngressAddresses,
+		Updated:          doc.Updated.UTC(),
+	}
+	if doc.LastRemoteEvent != nil {
+		t := doc.LastRemoteEvent.UTC()
+		result.LastRemoteEvent = &t
+	}
+	if doc.MacaroonExpiry != nil {
+		t := doc.MacaroonExpiry.UTC()
+		result.MacaroonExpiry = &t
+	}
+	return result, nil
+}
+
+// SetDiagnostics records the cross model relation diagnostics for the
+// remote application, replacing any previously recorded.
+func (s *RemoteApplication) SetDiagnostics(info crossmodel.RemoteApplicationDiagnostics) error {
+	diagnostics, closer := s.st.db().GetCollection(remoteApplicationDiagnosticsC)
+	defer closer()
+
+	diagnosticsW := diagnostics.Writeable()
+
+	// As with the last connection times of model users, the
+	// diagnostics are not worth waiting on a write majority for.
+	session := diagnosticsW.Underlying().Database.Session
+	session.SetSafe(&mgo.Safe{})
+
+	updated := info.Updated
+	if updated.IsZero() {
+		updated = s.st.clock().Now()
+	}
+	doc := remoteApplicationDiagnosticsDoc{
+		DocID:            s.doc.DocID,
+		ModelUUID:        s.st.ModelUUID(),
+		Name:             s.doc.Name,
+		LastRemoteEvent:  info.LastRemoteEvent,
+		MacaroonExpiry:   info.MacaroonExpiry,
+		SettingsSyncLag:  int64(info.SettingsSyncLag),
+		IngressAddresses: info.IngressAddresses,
+		Updated:          updated,
+	}
+	_, err := diagnosticsW.UpsertId(doc.DocID, doc)
+	return errors.Annotatef(err, "cannot set diagnostics for remote application %q", s)
+}
+
+// removeRemoteApplicationDiagnostics removes any diagnostics recorded
+// for the remote application with the given name. It is run by the
+// cleanup scheduled when the remote application is removed.
+func (st *State) removeRemoteApplicationDiagnostics(name string) error {
+	diagnostics, closer := st.db().GetRawCollection(remoteApplicationDiagnosticsC)
+	defer closer()
+
+	err := diagnostics.RemoveId(st.docID(name))
+	if err != nil && err != mgo.ErrNotFound {
+		return errors.Annotatef(err, "cannot remove diagnostics for remote application %q", name)
+	}
+	return nil
+}
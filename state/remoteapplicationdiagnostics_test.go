@@ -0,0 +1,81 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/state"
+)
+
+func (s *remoteApplicationSuite) TestDiagnosticsNotFound(c *gc.C) {
+	_, err := s.application.Diagnostics()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *remoteApplicationSuite) TestSetDiagnostics(c *gc.C) {
+	lastEvent := time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC)
+	expiry := lastEvent.Add(24 * time.Hour)
+	info := crossmodel.RemoteApplicationDiagnostics{
+		LastRemoteEvent:  &lastEvent,
+		MacaroonExpiry:   &expiry,
+		SettingsSyncLag:  1500 * time.Millisecond,
+		IngressAddresses: []string{"10.0.0.1", "10.0.0.2"},
+		Updated:          lastEvent.Add(time.Minute),
+	}
+	err := s.application.SetDiagnostics(info)
+	c.Assert(err, jc.ErrorIsNil)
+
+	diagnostics, err := s.application.Diagnostics()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(diagnostics, jc.DeepEquals, &info)
+
+	// Setting the diagnostics again replaces them.
+	info = crossmodel.RemoteApplicationDiagnostics{
+		Updated: lastEvent.Add(2 * time.Minute),
+	}
+	err = s.application.SetDiagnostics(info)
+	c.Assert(err, jc.ErrorIsNil)
+
+	diagnostics, err = s.application.Diagnostics()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(diagnostics, jc.DeepEquals, &info)
+}
+
+func (s *remoteApplicationSuite) TestDiagnosticsRemovedWithApplication(c *gc.C) {
+	err := s.application.SetDiagnostics(crossmodel.RemoteApplicationDiagnostics{
+		SettingsSyncLag: time.Second,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	assertNeedsCleanup(c, s.State)
+	assertCleanupRuns(c, s.State)
+
+	_, err = s.application.Diagnostics()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *remoteApplicationSuite) TestDiagnosticsRemovedWhenReadded(c *gc.C) {
+	err := s.application.SetDiagnostics(crossmodel.RemoteApplicationDiagnostics{
+		SettingsSyncLag: time.Second,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	app, err := s.State.AddRemoteApplication(state.AddRemoteApplicationParams{
+		Name:        "mysql",
+		URL:         "me/model.mysql",
+		SourceModel: s.Model.ModelTag(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = app.Diagnostics()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
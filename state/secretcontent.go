@@ -0,0 +1,87 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/secrets"
+)
+
+type secretContentDoc struct {
+	DocID string `bson:"_id"`
+
+	Data map[string]string `bson:"data"`
+}
+
+// NewSecretContentStore returns the internal secrets backend, which
+// keeps secret content in the controller database.
+func NewSecretContentStore(st *State) secrets.Backend {
+	return &secretContentStore{st: st}
+}
+
+type secretContentStore struct {
+	st *State
+}
+
+// Type implements secrets.Backend.
+func (s *secretContentStore) Type() string {
+	return secrets.InternalBackendType
+}
+
+// SaveContent implements secrets.Backend.
+func (s *secretContentStore) SaveContent(uri *secrets.URI, revision int, data secrets.SecretData) (string, error) {
+	if err := data.Validate(); err != nil {
+		return "", errors.Trace(err)
+	}
+	id := secretRevisionKey(uri, revision)
+	ops := []txn.Op{{
+		C:      secretContentC,
+		Id:     id,
+		Assert: txn.DocMissing,
+		Insert: secretContentDoc{
+			DocID: id,
+			Data:  data,
+		},
+	}}
+	err := s.st.db().RunTransaction(ops)
+	if err == txn.ErrAborted {
+		return "", errors.AlreadyExistsf("content for secret %q revision %d", uri, revision)
+	} else if err != nil {
+		return "", errors.Annotatef(err, "cannot save content for secret %q", uri)
+	}
+	return id, nil
+}
+
+// GetContent implements secrets.Backend.
+func (s *secretContentStore) GetContent(id string) (secrets.SecretData, error) {
+	secretContentCollection, closer := s.st.db().GetCollection(secretContentC)
+	defer closer()
+
+	var doc secretContentDoc
+	err := secretContentCollection.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("secret content %q", id)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return doc.Data, nil
+}
+
+// DeleteContent implements secrets.Backend.
+func (s *secretContentStore) DeleteContent(id string) error {
+	ops := []txn.Op{{
+		C:      secretContentC,
+		Id:     id,
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	err := s.st.db().RunTransaction(ops)
+	if err == txn.ErrAborted {
+		return nil
+	}
+	return errors.Annotatef(err, "cannot delete secret content %q", id)
+}
@@ -0,0 +1,657 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/secrets"
+)
+
+// CreateSecretParams are used to create a secret.
+type CreateSecretParams struct {
+	// Owner is the application owning the secret.
+	Owner names.ApplicationTag
+
+	Description  string
+	Label        string
+	RotatePolicy secrets.RotatePolicy
+
+	// ExpireTime is when the first revision expires, if ever.
+	ExpireTime *time.Time
+
+	// ValueRef refers to the content of the first revision.
+	ValueRef secrets.ValueRef
+}
+
+// UpdateSecretParams are used to update a secret. Nil fields are left
+// unchanged; a non-nil ValueRef adds a new revision.
+type UpdateSecretParams struct {
+	Description  *string
+	Label        *string
+	RotatePolicy *secrets.RotatePolicy
+
+	// ExpireTime is when the new revision expires, if ever.
+	ExpireTime *time.Time
+
+	// ValueRef refers to the content of the new revision.
+	ValueRef *secrets.ValueRef
+}
+
+// SecretsFilter holds attributes to match when listing secrets.
+type SecretsFilter struct {
+	OwnerTag *names.ApplicationTag
+}
+
+// SecretsStore instances use mongo as a secrets metadata store.
+type SecretsStore interface {
+	// CreateSecret records a new secret and its first revision.
+	CreateSecret(*secrets.URI, CreateSecretParams) (*secrets.SecretMetadata, error)
+
+	// UpdateSecret updates a secret, adding a new revision if content
+	// is supplied.
+	UpdateSecret(*secrets.URI, UpdateSecretParams) (*secrets.SecretMetadata, error)
+
+	// GetSecret returns the metadata of a secret.
+	GetSecret(*secrets.URI) (*secrets.SecretMetadata, error)
+
+	// GetSecretValueRef returns where the content of a secret revision
+	// is held.
+	GetSecretValueRef(*secrets.URI, int) (*secrets.ValueRef, error)
+
+	// ListSecrets returns the metadata of the secrets matching the filter.
+	ListSecrets(SecretsFilter) ([]*secrets.SecretMetadata, error)
+
+	// GrantSecretAccess gives an application or unit a role on a secret.
+	GrantSecretAccess(*secrets.URI, SecretAccessParams) error
+
+	// RevokeSecretAccess removes an application or unit's role on a secret.
+	RevokeSecretAccess(*secrets.URI, names.Tag) error
+
+	// SecretAccess returns the role an application or unit has been
+	// granted on a secret.
+	SecretAccess(*secrets.URI, names.Tag) (secrets.SecretRole, error)
+
+	// TriggerSecretRotation asks the secret's owner to rotate it now.
+	TriggerSecretRotation(*secrets.URI) error
+
+	// SecretRotated records that the secret's owner rotated it at the
+	// given time, scheduling the next rotation according to its policy.
+	SecretRotated(*secrets.URI, time.Time) error
+
+	// WatchSecretsRotationChanges returns a watcher notifying of changes
+	// to the secrets owned by the application.
+	WatchSecretsRotationChanges(names.ApplicationTag) StringsWatcher
+}
+
+// SecretAccessParams are used to grant access to a secret.
+type SecretAccessParams struct {
+	// Subject is the application or unit being granted access.
+	Subject names.Tag
+	Role    secrets.SecretRole
+}
+
+// NewSecretsStore returns a new mongo backed secrets store.
+func NewSecretsStore(st *State) SecretsStore {
+	return &secretsStore{st: st}
+}
+
+type secretsStore struct {
+	st *State
+}
+
+type secretMetadataDoc struct {
+	DocID string `bson:"_id"`
+
+	OwnerTag         string     `bson:"owner-tag"`
+	Description      string     `bson:"description"`
+	Label            string     `bson:"label"`
+	LatestRevision   int        `bson:"latest-revision"`
+	LatestExpireTime *time.Time `bson:"latest-expire-time,omitempty"`
+	RotatePolicy     string     `bson:"rotate-policy"`
+	NextRotateTime   *time.Time `bson:"next-rotate-time,omitempty"`
+	CreateTime       time.Time  `bson:"create-time"`
+	UpdateTime       time.Time  `bson:"update-time"`
+}
+
+type secretValueRefDoc struct {
+	BackendType string `bson:"backend-type"`
+	ID          string `bson:"id"`
+}
+
+type secretRevisionDoc struct {
+	DocID string `bson:"_id"`
+
+	SecretID   string            `bson:"secret-id"`
+	Revision   int               `bson:"revision"`
+	CreateTime time.Time         `bson:"create-time"`
+	ExpireTime *time.Time        `bson:"expire-time,omitempty"`
+	ValueRef   secretValueRefDoc `bson:"value-ref"`
+}
+
+type secretPermissionDoc struct {
+	DocID string `bson:"_id"`
+
+	SecretID   string `bson:"secret-id"`
+	SubjectTag string `bson:"subject-tag"`
+	Role       string `bson:"role"`
+}
+
+func secretRevisionKey(uri *secrets.URI, revision int) string {
+	return fmt.Sprintf("%s/%d", uri.ID, revision)
+}
+
+func secretPermissionKey(uri *secrets.URI, subject string) string {
+	return fmt.Sprintf("%s#%s", uri.ID, subject)
+}
+
+func (s *secretsStore) now() time.Time {
+	return s.st.nowToTheSecond()
+}
+
+func (s *secretsStore) secretMetadataDoc(uri *secrets.URI) (*secretMetadataDoc, error) {
+	secretMetadataCollection, closer := s.st.db().GetCollection(secretMetadataC)
+	defer closer()
+
+	var doc secretMetadataDoc
+	err := secretMetadataCollection.FindId(uri.ID).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("secret %q", uri)
+	}
+	return &doc, errors.Trace(err)
+}
+
+func (s *secretsStore) toSecretMetadata(doc *secretMetadataDoc) *secrets.SecretMetadata {
+	return &secrets.SecretMetadata{
+		URI:              &secrets.URI{ID: s.st.localID(doc.DocID)},
+		OwnerTag:         doc.OwnerTag,
+		Description:      doc.Description,
+		Label:            doc.Label,
+		LatestRevision:   doc.LatestRevision,
+		LatestExpireTime: utcTimePtr(doc.LatestExpireTime),
+		RotatePolicy:     secrets.RotatePolicy(doc.RotatePolicy),
+		NextRotateTime:   utcTimePtr(doc.NextRotateTime),
+		CreateTime:       doc.CreateTime.UTC(),
+		UpdateTime:       doc.UpdateTime.UTC(),
+	}
+}
+
+func utcTimePtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
+
+// CreateSecret implements SecretsStore.
+func (s *secretsStore) CreateSecret(uri *secrets.URI, p CreateSecretParams) (*secrets.SecretMetadata, error) {
+	if p.RotatePolicy == "" {
+		p.RotatePolicy = secrets.RotateNever
+	}
+	if !p.RotatePolicy.IsValid() {
+		return nil, errors.NotValidf("secret rotate policy %q", p.RotatePolicy)
+	}
+	if p.ValueRef.BackendType == "" || p.ValueRef.ID == "" {
+		return nil, errors.NotValidf("secret with missing content")
+	}
+	now := s.now()
+	metadataDoc := secretMetadataDoc{
+		DocID:            uri.ID,
+		OwnerTag:         p.Owner.String(),
+		Description:      p.Description,
+		Label:            p.Label,
+		LatestRevision:   1,
+		LatestExpireTime: p.ExpireTime,
+		RotatePolicy:     string(p.RotatePolicy),
+		NextRotateTime:   p.RotatePolicy.NextRotateTime(now),
+		CreateTime:       now,
+		UpdateTime:       now,
+	}
+	revisionDoc := secretRevisionDoc{
+		DocID:      secretRevisionKey(uri, 1),
+		SecretID:   uri.ID,
+		Revision:   1,
+		CreateTime: now,
+		ExpireTime: p.ExpireTime,
+		ValueRef:   secretValueRefDoc{BackendType: p.ValueRef.BackendType, ID: p.ValueRef.ID},
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if _, err := s.secretMetadataDoc(uri); err == nil {
+				return nil, errors.AlreadyExistsf("secret %q", uri)
+			}
+			if err := checkApplicationAlive(s.st, p.Owner.Id()); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     p.Owner.Id(),
+			Assert: isAliveDoc,
+		}, {
+			C:      secretMetadataC,
+			Id:     metadataDoc.DocID,
+			Assert: txn.DocMissing,
+			Insert: metadataDoc,
+		}, {
+			C:      secretRevisionsC,
+			Id:     revisionDoc.DocID,
+			Assert: txn.DocMissing,
+			Insert: revisionDoc,
+		}}, nil
+	}
+	if err := s.st.db().Run(buildTxn); err != nil {
+		return nil, errors.Annotatef(err, "cannot create secret %q", uri)
+	}
+	return s.toSecretMetadata(&metadataDoc), nil
+}
+
+func checkApplicationAlive(st *State, name string) error {
+	app, err := st.Application(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if app.Life() != Alive {
+		return errors.Errorf("application %q is not alive", name)
+	}
+	return nil
+}
+
+// UpdateSecret implements SecretsStore.
+func (s *secretsStore) UpdateSecret(uri *secrets.URI, p UpdateSecretParams) (*secrets.SecretMetadata, error) {
+	if p.RotatePolicy != nil && !p.RotatePolicy.IsValid() {
+		return nil, errors.NotValidf("secret rotate policy %q", *p.RotatePolicy)
+	}
+	if p.ValueRef != nil && (p.ValueRef.BackendType == "" || p.ValueRef.ID == "") {
+		return nil, errors.NotValidf("secret with missing content")
+	}
+	var metadataDoc *secretMetadataDoc
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		var err error
+		if metadataDoc, err = s.secretMetadataDoc(uri); err != nil {
+			return nil, errors.Trace(err)
+		}
+		now := s.now()
+		update := bson.D{{"update-time", now}}
+		if p.Description != nil {
+			metadataDoc.Description = *p.Description
+			update = append(update, bson.DocElem{"description", *p.Description})
+		}
+		if p.Label != nil {
+			metadataDoc.Label = *p.Label
+			update = append(update, bson.DocElem{"label", *p.Label})
+		}
+		policy := secrets.RotatePolicy(metadataDoc.RotatePolicy)
+		if p.RotatePolicy != nil {
+			policy = *p.RotatePolicy
+			metadataDoc.RotatePolicy = string(policy)
+			update = append(update, bson.DocElem{"rotate-policy", metadataDoc.RotatePolicy})
+		}
+		if p.RotatePolicy != nil || p.ValueRef != nil {
+			// New content satisfies any pending rotation.
+			metadataDoc.NextRotateTime = policy.NextRotateTime(now)
+			update = append(update, bson.DocElem{"next-rotate-time", metadataDoc.NextRotateTime})
+		}
+		assert := bson.D{{"latest-revision", metadataDoc.LatestRevision}}
+		var ops []txn.Op
+		if p.ValueRef != nil {
+			metadataDoc.LatestRevision++
+			metadataDoc.LatestExpireTime = p.ExpireTime
+			update = append(update,
+				bson.DocElem{"latest-revision", metadataDoc.LatestRevision},
+				bson.DocElem{"latest-expire-time", p.ExpireTime},
+			)
+			ops = append(ops, txn.Op{
+				C:      secretRevisionsC,
+				Id:     secretRevisionKey(uri, metadataDoc.LatestRevision),
+				Assert: txn.DocMissing,
+				Insert: secretRevisionDoc{
+					DocID:      secretRevisionKey(uri, metadataDoc.LatestRevision),
+					SecretID:   uri.ID,
+					Revision:   metadataDoc.LatestRevision,
+					CreateTime: now,
+					ExpireTime: p.ExpireTime,
+					ValueRef:   secretValueRefDoc{BackendType: p.ValueRef.BackendType, ID: p.ValueRef.ID},
+				},
+			})
+		}
+		metadataDoc.UpdateTime = now
+		return append([]txn.Op{{
+			C:      secretMetadataC,
+			Id:     uri.ID,
+			Assert: assert,
+			Update: bson.D{{"$set", update}},
+		}}, ops...), nil
+	}
+	if err := s.st.db().Run(buildTxn); err != nil {
+		return nil, errors.Annotatef(err, "cannot update secret %q", uri)
+	}
+	return s.toSecretMetadata(metadataDoc), nil
+}
+
+// GetSecret implements SecretsStore.
+func (s *secretsStore) GetSecret(uri *secrets.URI) (*secrets.SecretMetadata, error) {
+	doc, err := s.secretMetadataDoc(uri)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return s.toSecretMetadata(doc), nil
+}
+
+// GetSecretValueRef implements SecretsStore.
+func (s *secretsStore) GetSecretValueRef(uri *secrets.URI, revision int) (*secrets.ValueRef, error) {
+	secretRevisionsCollection, closer := s.st.db().GetCollection(secretRevisionsC)
+	defer closer()
+
+	var doc secretRevisionDoc
+	err := secretRevisionsCollection.FindId(secretRevisionKey(uri, revision)).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("secret %q revision %d", uri, revision)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &secrets.ValueRef{
+		BackendType: doc.ValueRef.BackendType,
+		ID:          doc.ValueRef.ID,
+	}, nil
+}
+
+// ListSecrets implements SecretsStore.
+func (s *secretsStore) ListSecrets(filter SecretsFilter) ([]*secrets.SecretMetadata, error) {
+	secretMetadataCollection, closer := s.st.db().GetCollection(secretMetadataC)
+	defer closer()
+
+	query := bson.D{}
+	if filter.OwnerTag != nil {
+		query = append(query, bson.DocElem{"owner-tag", filter.OwnerTag.String()})
+	}
+	var docs []secretMetadataDoc
+	if err := secretMetadataCollection.Find(query).Sort("create-time").All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]*secrets.SecretMetadata, len(docs))
+	for i := range docs {
+		result[i] = s.toSecretMetadata(&docs[i])
+	}
+	return result, nil
+}
+
+// GrantSecretAccess implements SecretsStore.
+func (s *secretsStore) GrantSecretAccess(uri *secrets.URI, p SecretAccessParams) error {
+	if !p.Role.IsValid() {
+		return errors.NotValidf("secret role %q", p.Role)
+	}
+	subjectCollection, subjectID, err := secretSubjectDoc(p.Subject)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	key := secretPermissionKey(uri, p.Subject.String())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if _, err := s.secretMetadataDoc(uri); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if attempt > 0 {
+			if err := checkSecretSubjectAlive(s.st, p.Subject); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		ops := []txn.Op{{
+			C:      secretMetadataC,
+			Id:     uri.ID,
+			Assert: txn.DocExists,
+		}, {
+			C:      subjectCollection,
+			Id:     subjectID,
+			Assert: isAliveDoc,
+		}}
+		existing, err := s.SecretAccess(uri, p.Subject)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if existing == p.Role {
+			return nil, jujutxn.ErrNoOperations
+		}
+		if existing == secrets.RoleNone {
+			return append(ops, txn.Op{
+				C:      secretPermissionsC,
+				Id:     key,
+				Assert: txn.DocMissing,
+				Insert: secretPermissionDoc{
+					DocID:      key,
+					SecretID:   uri.ID,
+					SubjectTag: p.Subject.String(),
+					Role:       string(p.Role),
+				},
+			}), nil
+		}
+		return append(ops, txn.Op{
+			C:      secretPermissionsC,
+			Id:     key,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{{"role", string(p.Role)}}}},
+		}), nil
+	}
+	err = s.st.db().Run(buildTxn)
+	return errors.Annotatef(err, "cannot grant access to secret %q", uri)
+}
+
+func secretSubjectDoc(subject names.Tag) (string, string, error) {
+	switch subject.(type) {
+	case names.ApplicationTag:
+		return applicationsC, subject.Id(), nil
+	case names.UnitTag:
+		return unitsC, subject.Id(), nil
+	}
+	return "", "", errors.NotValidf("secret subject %q", names.ReadableString(subject))
+}
+
+func checkSecretSubjectAlive(st *State, subject names.Tag) error {
+	var entity Lifer
+	var err error
+	switch subject := subject.(type) {
+	case names.ApplicationTag:
+		entity, err = st.Application(subject.Id())
+	case names.UnitTag:
+		entity, err = st.Unit(subject.Id())
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if entity.Life() != Alive {
+		return errors.Errorf("%s is not alive", names.ReadableString(subject))
+	}
+	return nil
+}
+
+// RevokeSecretAccess implements SecretsStore.
+func (s *secretsStore) RevokeSecretAccess(uri *secrets.URI, subject names.Tag) error {
+	key := secretPermissionKey(uri, subject.String())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if _, err := s.secretMetadataDoc(uri); err != nil {
+			return nil, errors.Trace(err)
+		}
+		existing, err := s.SecretAccess(uri, subject)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if existing == secrets.RoleNone {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      secretPermissionsC,
+			Id:     key,
+			Assert: txn.DocExists,
+			Remove: true,
+		}}, nil
+	}
+	err := s.st.db().Run(buildTxn)
+	return errors.Annotatef(err, "cannot revoke access to secret %q", uri)
+}
+
+// SecretAccess implements SecretsStore.
+func (s *secretsStore) SecretAccess(uri *secrets.URI, subject names.Tag) (secrets.SecretRole, error) {
+	secretPermissionsCollection, closer := s.st.db().GetCollection(secretPermissionsC)
+	defer closer()
+
+	var doc secretPermissionDoc
+	err := secretPermissionsCollection.FindId(secretPermissionKey(uri, subject.String())).One(&doc)
+	if err == mgo.ErrNotFound {
+		return secrets.RoleNone, nil
+	} else if err != nil {
+		return secrets.RoleNone, errors.Trace(err)
+	}
+	return secrets.SecretRole(doc.Role), nil
+}
+
+// TriggerSecretRotation implements SecretsStore.
+func (s *secretsStore) TriggerSecretRotation(uri *secrets.URI) error {
+	return errors.Trace(s.setNextRotateTime(uri, func(*secretMetadataDoc) *time.Time {
+		now := s.now()
+		return &now
+	}))
+}
+
+// SecretRotated implements SecretsStore.
+func (s *secretsStore) SecretRotated(uri *secrets.URI, when time.Time) error {
+	return errors.Trace(s.setNextRotateTime(uri, func(doc *secretMetadataDoc) *time.Time {
+		return secrets.RotatePolicy(doc.RotatePolicy).NextRotateTime(when.UTC().Round(time.Second))
+	}))
+}
+
+func (s *secretsStore) setNextRotateTime(uri *secrets.URI, next func(*secretMetadataDoc) *time.Time) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		doc, err := s.secretMetadataDoc(uri)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      secretMetadataC,
+			Id:     uri.ID,
+			Assert: bson.D{{"rotate-policy", doc.RotatePolicy}},
+			Update: bson.D{{"$set", bson.D{{"next-rotate-time", next(doc)}}}},
+		}}, nil
+	}
+	err := s.st.db().Run(buildTxn)
+	return errors.Annotatef(err, "cannot update rotation of secret %q", uri)
+}
+
+// WatchSecretsRotationChanges implements SecretsStore. The watcher
+// reports the URIs of the changed secrets.
+func (s *secretsStore) WatchSecretsRotationChanges(owner names.ApplicationTag) StringsWatcher {
+	ownerTag := owner.String()
+	filter := func(id interface{}) bool {
+		secretMetadataCollection, closer := s.st.db().GetCollection(secretMetadataC)
+		defer closer()
+
+		var doc struct {
+			OwnerTag string `bson:"owner-tag"`
+		}
+		err := secretMetadataCollection.FindId(id).Select(bson.D{{"owner-tag", 1}}).One(&doc)
+		return err == nil && doc.OwnerTag == ownerTag
+	}
+	return newCollectionWatcher(s.st, colWCfg{
+		col:    secretMetadataC,
+		filter: filter,
+		idconv: func(id string) string {
+			return (&secrets.URI{ID: id}).String()
+		},
+	})
+}
+
+// removeOwnedSecretsOps returns the operations to remove the secrets
+// owned by the named application, along with any content held by the
+// internal backend. Content held by other backends is left in place.
+func removeOwnedSecretsOps(st *State, appName string) ([]txn.Op, error) {
+	secretMetadataCollection, closer := st.db().GetCollection(secretMetadataC)
+	defer closer()
+
+	var docs []secretMetadataDoc
+	err := secretMetadataCollection.Find(bson.D{{"owner-tag", names.NewApplicationTag(appName).String()}}).
+		Select(bson.D{{"_id", 1}}).All(&docs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ops []txn.Op
+	for _, doc := range docs {
+		id := st.localID(doc.DocID)
+		ops = append(ops, txn.Op{
+			C:      secretMetadataC,
+			Id:     id,
+			Remove: true,
+		})
+		revisionOps, err := removeSecretRevisionsOps(st, id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, revisionOps...)
+		permissionOps, err := removeSecretPermissionsOps(st, bson.D{{"secret-id", id}})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, permissionOps...)
+	}
+	return ops, nil
+}
+
+func removeSecretRevisionsOps(st *State, secretID string) ([]txn.Op, error) {
+	secretRevisionsCollection, closer := st.db().GetCollection(secretRevisionsC)
+	defer closer()
+
+	var docs []secretRevisionDoc
+	if err := secretRevisionsCollection.Find(bson.D{{"secret-id", secretID}}).All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ops []txn.Op
+	for _, doc := range docs {
+		ops = append(ops, txn.Op{
+			C:      secretRevisionsC,
+			Id:     st.localID(doc.DocID),
+			Remove: true,
+		})
+		if doc.ValueRef.BackendType == secrets.InternalBackendType {
+			ops = append(ops, txn.Op{
+				C:      secretContentC,
+				Id:     doc.ValueRef.ID,
+				Remove: true,
+			})
+		}
+	}
+	return ops, nil
+}
+
+// removeSecretPermissionsOps returns the operations to remove the
+// secret permissions matching the query.
+func removeSecretPermissionsOps(st *State, query bson.D) ([]txn.Op, error) {
+	secretPermissionsCollection, closer := st.db().GetCollection(secretPermissionsC)
+	defer closer()
+
+	var docs []secretPermissionDoc
+	if err := secretPermissionsCollection.Find(query).Select(bson.D{{"_id", 1}}).All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      secretPermissionsC,
+			Id:     st.localID(doc.DocID),
+			Remove: true,
+		}
+	}
+	return ops, nil
+}
+
+// removeSecretSubjectOps returns the operations to remove the access
+// granted to an application or unit on any secret.
+func removeSecretSubjectOps(st *State, subject names.Tag) ([]txn.Op, error) {
+	return removeSecretPermissionsOps(st, bson.D{{"subject-tag", subject.String()}})
+}
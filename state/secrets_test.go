@@ -0,0 +1,299 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type SecretsSuite struct {
+	statetesting.StateSuite
+	store   state.SecretsStore
+	content secrets.Backend
+	owner   *state.Application
+}
+
+var _ = gc.Suite(&SecretsSuite{})
+
+func (s *SecretsSuite) SetUpTest(c *gc.C) {
+	s.StateSuite.SetUpTest(c)
+	s.store = state.NewSecretsStore(s.State)
+	s.content = state.NewSecretContentStore(s.State)
+	s.owner = s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "mysql"})
+}
+
+func (s *SecretsSuite) createSecret(c *gc.C, p state.CreateSecretParams) *secrets.SecretMetadata {
+	uri, err := secrets.NewURI()
+	c.Assert(err, jc.ErrorIsNil)
+	id, err := s.content.SaveContent(uri, 1, secrets.SecretData{"password": "s3cret"})
+	c.Assert(err, jc.ErrorIsNil)
+	p.Owner = s.owner.ApplicationTag()
+	p.ValueRef = secrets.ValueRef{BackendType: s.content.Type(), ID: id}
+	md, err := s.store.CreateSecret(uri, p)
+	c.Assert(err, jc.ErrorIsNil)
+	return md
+}
+
+func (s *SecretsSuite) TestCreateSecret(c *gc.C) {
+	md := s.createSecret(c, state.CreateSecretParams{
+		Description:  "database password",
+		Label:        "db-password",
+		RotatePolicy: secrets.RotateDaily,
+	})
+	c.Assert(md.OwnerTag, gc.Equals, "application-mysql")
+	c.Assert(md.LatestRevision, gc.Equals, 1)
+	c.Assert(md.RotatePolicy, gc.Equals, secrets.RotateDaily)
+	c.Assert(md.NextRotateTime, gc.NotNil)
+	c.Assert(*md.NextRotateTime, gc.Equals, md.CreateTime.AddDate(0, 0, 1))
+
+	got, err := s.store.GetSecret(md.URI)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got, jc.DeepEquals, md)
+
+	ref, err := s.store.GetSecretValueRef(md.URI, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ref.BackendType, gc.Equals, secrets.InternalBackendType)
+	data, err := s.content.GetContent(ref.ID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data, jc.DeepEquals, secrets.SecretData{"password": "s3cret"})
+}
+
+func (s *SecretsSuite) TestCreateSecretDefaultsToNeverRotate(c *gc.C) {
+	md := s.createSecret(c, state.CreateSecretParams{})
+	c.Assert(md.RotatePolicy, gc.Equals, secrets.RotateNever)
+	c.Assert(md.NextRotateTime, gc.IsNil)
+}
+
+func (s *SecretsSuite) TestCreateSecretInvalid(c *gc.C) {
+	uri, err := secrets.NewURI()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.store.CreateSecret(uri, state.CreateSecretParams{
+		Owner:        s.owner.ApplicationTag(),
+		RotatePolicy: "fortnightly",
+		ValueRef:     secrets.ValueRef{BackendType: secrets.InternalBackendType, ID: "id"},
+	})
+	c.Assert(err, gc.ErrorMatches, `secret rotate policy "fortnightly" not valid`)
+	_, err = s.store.CreateSecret(uri, state.CreateSecretParams{
+		Owner: s.owner.ApplicationTag(),
+	})
+	c.Assert(err, gc.ErrorMatches, `secret with missing content not valid`)
+}
+
+func (s *SecretsSuite) TestCreateSecretOwnerNotFound(c *gc.C) {
+	uri, err := secrets.NewURI()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.store.CreateSecret(uri, state.CreateSecretParams{
+		Owner:    names.NewApplicationTag("wordpress"),
+		ValueRef: secrets.ValueRef{BackendType: secrets.InternalBackendType, ID: "id"},
+	})
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SecretsSuite) TestGetSecretNotFound(c *gc.C) {
+	uri, err := secrets.NewURI()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.store.GetSecret(uri)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SecretsSuite) TestUpdateSecretAddsRevision(c *gc.C) {
+	md := s.createSecret(c, state.CreateSecretParams{Description: "old"})
+	id, err := s.content.SaveContent(md.URI, 2, secrets.SecretData{"password": "n3w"})
+	c.Assert(err, jc.ErrorIsNil)
+	expire := time.Now().Add(time.Hour).UTC().Round(time.Second)
+	description := "new"
+	updated, err := s.store.UpdateSecret(md.URI, state.UpdateSecretParams{
+		Description: &description,
+		ExpireTime:  &expire,
+		ValueRef:    &secrets.ValueRef{BackendType: secrets.InternalBackendType, ID: id},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(updated.Description, gc.Equals, "new")
+	c.Assert(updated.LatestRevision, gc.Equals, 2)
+	c.Assert(updated.LatestExpireTime, jc.DeepEquals, &expire)
+
+	ref, err := s.store.GetSecretValueRef(md.URI, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ref.ID, gc.Equals, id)
+	_, err = s.store.GetSecretValueRef(md.URI, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.store.GetSecretValueRef(md.URI, 3)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SecretsSuite) TestUpdateSecretRotatePolicy(c *gc.C) {
+	md := s.createSecret(c, state.CreateSecretParams{})
+	policy := secrets.RotateHourly
+	updated, err := s.store.UpdateSecret(md.URI, state.UpdateSecretParams{RotatePolicy: &policy})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(updated.LatestRevision, gc.Equals, 1)
+	c.Assert(updated.RotatePolicy, gc.Equals, secrets.RotateHourly)
+	c.Assert(updated.NextRotateTime, gc.NotNil)
+	c.Assert(*updated.NextRotateTime, gc.Equals, updated.UpdateTime.Add(time.Hour))
+}
+
+func (s *SecretsSuite) TestListSecrets(c *gc.C) {
+	md1 := s.createSecret(c, state.CreateSecretParams{Label: "one"})
+	md2 := s.createSecret(c, state.CreateSecretParams{Label: "two"})
+	s.owner = s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "wordpress"})
+	md3 := s.createSecret(c, state.CreateSecretParams{Label: "three"})
+
+	all, err := s.store.ListSecrets(state.SecretsFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, jc.SameContents, []*secrets.SecretMetadata{md1, md2, md3})
+
+	owner := names.NewApplicationTag("mysql")
+	owned, err := s.store.ListSecrets(state.SecretsFilter{OwnerTag: &owner})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(owned, jc.SameContents, []*secrets.SecretMetadata{md1, md2})
+}
+
+func (s *SecretsSuite) TestGrantRevokeSecretAccess(c *gc.C) {
+	md := s.createSecret(c, state.CreateSecretParams{})
+	unit := s.Factory.MakeUnit(c, nil)
+
+	role, err := s.store.SecretAccess(md.URI, unit.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(role, gc.Equals, secrets.RoleNone)
+
+	err = s.store.GrantSecretAccess(md.URI, state.SecretAccessParams{Subject: unit.Tag(), Role: secrets.RoleView})
+	c.Assert(err, jc.ErrorIsNil)
+	// Granting the same role again is a no-op.
+	err = s.store.GrantSecretAccess(md.URI, state.SecretAccessParams{Subject: unit.Tag(), Role: secrets.RoleView})
+	c.Assert(err, jc.ErrorIsNil)
+	role, err = s.store.SecretAccess(md.URI, unit.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(role, gc.Equals, secrets.RoleView)
+
+	err = s.store.RevokeSecretAccess(md.URI, unit.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	role, err = s.store.SecretAccess(md.URI, unit.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(role, gc.Equals, secrets.RoleNone)
+
+	// Revoking access which was never granted is a no-op.
+	err = s.store.RevokeSecretAccess(md.URI, unit.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *SecretsSuite) TestGrantSecretAccessInvalid(c *gc.C) {
+	md := s.createSecret(c, state.CreateSecretParams{})
+	err := s.store.GrantSecretAccess(md.URI, state.SecretAccessParams{Subject: s.owner.Tag(), Role: "admin"})
+	c.Assert(err, gc.ErrorMatches, `secret role "admin" not valid`)
+	err = s.store.GrantSecretAccess(md.URI, state.SecretAccessParams{Subject: names.NewMachineTag("0"), Role: secrets.RoleView})
+	c.Assert(err, gc.ErrorMatches, `secret subject "machine 0" not valid`)
+}
+
+func (s *SecretsSuite) TestGrantSecretAccessSubjectNotFound(c *gc.C) {
+	md := s.createSecret(c, state.CreateSecretParams{})
+	err := s.store.GrantSecretAccess(md.URI, state.SecretAccessParams{
+		Subject: names.NewUnitTag("wordpress/0"),
+		Role:    secrets.RoleView,
+	})
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SecretsSuite) TestSecretRotation(c *gc.C) {
+	md := s.createSecret(c, state.CreateSecretParams{RotatePolicy: secrets.RotateWeekly})
+
+	err := s.store.TriggerSecretRotation(md.URI)
+	c.Assert(err, jc.ErrorIsNil)
+	got, err := s.store.GetSecret(md.URI)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got.NextRotateTime, gc.NotNil)
+	c.Assert(got.NextRotateTime.Before(*md.NextRotateTime), jc.IsTrue)
+
+	rotated := time.Date(2020, 8, 1, 10, 0, 0, 0, time.UTC)
+	err = s.store.SecretRotated(md.URI, rotated)
+	c.Assert(err, jc.ErrorIsNil)
+	got, err = s.store.GetSecret(md.URI)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got.NextRotateTime, jc.DeepEquals, secrets.RotateWeekly.NextRotateTime(rotated))
+}
+
+func (s *SecretsSuite) TestWatchSecretsRotationChanges(c *gc.C) {
+	w := s.store.WatchSecretsRotationChanges(s.owner.ApplicationTag())
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChange()
+	wc.AssertNoChange()
+
+	md := s.createSecret(c, state.CreateSecretParams{RotatePolicy: secrets.RotateDaily})
+	wc.AssertChange(md.URI.String())
+	wc.AssertNoChange()
+
+	err := s.store.TriggerSecretRotation(md.URI)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(md.URI.String())
+	wc.AssertNoChange()
+
+	// Secrets owned by other applications are not reported.
+	s.owner = s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "wordpress"})
+	s.createSecret(c, state.CreateSecretParams{RotatePolicy: secrets.RotateDaily})
+	wc.AssertNoChange()
+}
+
+func (s *SecretsSuite) TestContentStore(c *gc.C) {
+	uri, err := secrets.NewURI()
+	c.Assert(err, jc.ErrorIsNil)
+	id, err := s.content.SaveContent(uri, 1, secrets.SecretData{"password": "s3cret"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.content.SaveContent(uri, 1, secrets.SecretData{"password": "other"})
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+	_, err = s.content.SaveContent(uri, 2, secrets.SecretData{})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	data, err := s.content.GetContent(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data, jc.DeepEquals, secrets.SecretData{"password": "s3cret"})
+
+	err = s.content.DeleteContent(id)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.content.GetContent(id)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = s.content.DeleteContent(id)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *SecretsSuite) TestRemovingApplicationRemovesOwnedSecrets(c *gc.C) {
+	md := s.createSecret(c, state.CreateSecretParams{})
+	ref, err := s.store.GetSecretValueRef(md.URI, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.owner.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.store.GetSecret(md.URI)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.store.GetSecretValueRef(md.URI, 1)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.content.GetContent(ref.ID)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SecretsSuite) TestRemovingUnitRevokesAccess(c *gc.C) {
+	md := s.createSecret(c, state.CreateSecretParams{})
+	unit := s.Factory.MakeUnit(c, nil)
+	err := s.store.GrantSecretAccess(md.URI, state.SecretAccessParams{Subject: unit.Tag(), Role: secrets.RoleView})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	role, err := s.store.SecretAccess(md.URI, unit.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(role, gc.Equals, secrets.RoleNone)
+}
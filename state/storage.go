@@ -890,6 +890,12 @@ func createStorageOps(
 	// creation, because the only sane time to add storage attachments
 	// is when units are added to said application.
 
+	// The storage for each instance was checked against the pool limits
+	// on its own, so check it again as a whole.
+	ops, err = sb.storagePoolCapacityOps(ops)
+	if err != nil {
+		return fail(errors.Trace(err))
+	}
 	return ops, storageTags, numStorageAttachments, nil
 }
 
@@ -1166,6 +1172,102 @@ func (sb *storageBackend) attachStorageOps(
 	return ops, nil
 }
 
+// ResizeStorageInstance requests that the volume backing the storage
+// instance with the specified tag be grown to the given size, in MiB.
+// The new size must fit within the max-size of the volume's storage
+// pool and the volume size limits of its storage provider.
+// The resize is carried out by the storage provisioner responsible for
+// the volume; once it completes, the info for the volume, and for any
+// filesystem that it backs, will reflect the new size.
+func (sb *storageBackend) ResizeStorageInstance(tag names.StorageTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot resize %s", names.ReadableString(tag))
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		si, err := sb.storageInstance(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if si.Life() != Alive {
+			return nil, errors.New("storage is not alive")
+		}
+		v, err := sb.storageInstanceBackingVolume(si)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops, err := resizeVolumeOps(v, size)
+		if err != nil {
+			return nil, err
+		}
+		// resizeVolumeOps has checked that the volume is
+		// provisioned, and is being grown.
+		info, _ := v.Info()
+		if err := sb.validateVolumeSize(v.VolumeTag(), info.Pool, size); err != nil {
+			return nil, errors.Trace(err)
+		}
+		capacityOp, err := sb.storagePoolCapacityOp(info.Pool, StorageAllocation{Size: size - info.Size})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if capacityOp != nil {
+			ops = append(ops, *capacityOp)
+		}
+		ops = append(ops, txn.Op{
+			C:      storageInstancesC,
+			Id:     si.doc.Id,
+			Assert: isAliveDoc,
+		})
+		return ops, nil
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
+// validateVolumeSize checks that the storage provider of the named pool
+// supports volumes of the given size. The volume sources of
+// machine-scoped providers can only be created on the machines, so only
+// model-scoped providers are checked here.
+func (sb *storageBackend) validateVolumeSize(tag names.VolumeTag, poolName string, size uint64) error {
+	providerType, provider, attrs, err := poolStorageProvider(sb, poolName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if provider.Scope() != storage.ScopeEnviron || !provider.Supports(storage.StorageKindBlock) {
+		return nil
+	}
+	cfg, err := storage.NewConfig(poolName, providerType, attrs)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	source, err := provider.VolumeSource(cfg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(source.ValidateVolumeParams(storage.VolumeParams{
+		Tag:        tag,
+		Size:       size,
+		Provider:   providerType,
+		Attributes: attrs,
+	}))
+}
+
+// storageInstanceBackingVolume returns the volume assigned to a
+// block-kind storage instance, or the volume backing the filesystem
+// assigned to a filesystem-kind storage instance.
+func (sb *storageBackend) storageInstanceBackingVolume(si *storageInstance) (*volume, error) {
+	if si.Kind() == StorageKindBlock {
+		return sb.storageInstanceVolume(si.StorageTag())
+	}
+	f, err := sb.storageInstanceFilesystem(si.StorageTag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volumeTag, err := f.Volume()
+	if err == ErrNoBackingVolume {
+		return nil, errors.NotSupportedf("resizing filesystem without a backing volume")
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return getVolumeByTag(sb.mb, volumeTag)
+}
+
 // DetachStorage ensures that the existing storage attachments of
 // the specified unit are removed at some point.
 func (sb *storageBackend) DestroyUnitStorageAttachments(unit names.UnitTag) (err error) {
@@ -2340,6 +2442,10 @@ func (sb *storageBackend) hostStorageOps(
 		ops = append(ops, volumeOps...)
 		ops = append(ops, attachmentOps...)
 	}
+	ops, err := sb.storagePoolCapacityOps(ops)
+	if err != nil {
+		return nil, nil, nil, errors.Trace(err)
+	}
 	return ops, volumeAttachments, fsAttachments, nil
 }
 
@@ -0,0 +1,292 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/storage/poolmanager"
+)
+
+// StorageAllocation records the number and total size of the
+// volumes and filesystems allocated from a storage pool.
+type StorageAllocation struct {
+	// Count is the number of volumes and filesystems allocated.
+	// Filesystems backed by a volume are counted once.
+	Count int
+
+	// Size is the total size of the allocated storage, in MiB.
+	Size uint64
+}
+
+// StoragePoolUsage describes the storage allocated from a storage pool.
+type StoragePoolUsage struct {
+	// Pool is the name of the storage pool.
+	Pool string
+
+	// Total is the storage allocated from the pool for the model.
+	Total StorageAllocation
+
+	// Applications is the storage allocated from the pool, keyed
+	// by the name of the application that owns the storage. Storage
+	// not owned by an application or unit is included only in Total.
+	Applications map[string]StorageAllocation
+}
+
+func (u *StoragePoolUsage) add(application string, size uint64) {
+	u.Total.Count++
+	u.Total.Size += size
+	if application == "" {
+		return
+	}
+	allocation := u.Applications[application]
+	allocation.Count++
+	allocation.Size += size
+	u.Applications[application] = allocation
+}
+
+// StoragePoolUsage returns the storage allocated from the named pool,
+// aggregated from the volumes and filesystems in the model. Provisioned
+// storage is reported with its actual size; storage that is yet to be
+// provisioned is reported with its requested size.
+func (sb *storageBackend) StoragePoolUsage(poolName string) (*StoragePoolUsage, error) {
+	owners, err := sb.storageApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	usage := &StoragePoolUsage{
+		Pool:         poolName,
+		Applications: make(map[string]StorageAllocation),
+	}
+	poolQuery := bson.D{{"$or", []bson.D{
+		{{"info.pool", poolName}},
+		{{"params.pool", poolName}},
+	}}}
+
+	volumes, err := sb.volumes(poolQuery)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get volumes")
+	}
+	for _, v := range volumes {
+		var pool string
+		var size uint64
+		if v.doc.Info != nil {
+			pool, size = v.doc.Info.Pool, v.doc.Info.Size
+		} else if v.doc.Params != nil {
+			pool, size = v.doc.Params.Pool, v.doc.Params.Size
+		}
+		if pool != poolName {
+			continue
+		}
+		usage.add(owners[v.doc.StorageId], size)
+	}
+
+	filesystems, err := sb.filesystems(poolQuery)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get filesystems")
+	}
+	for _, f := range filesystems {
+		if f.doc.VolumeId != "" {
+			// The backing volume has already been counted.
+			continue
+		}
+		var pool string
+		var size uint64
+		if f.doc.Info != nil {
+			pool, size = f.doc.Info.Pool, f.doc.Info.Size
+		} else if f.doc.Params != nil {
+			pool, size = f.doc.Params.Pool, f.doc.Params.Size
+		}
+		if pool != poolName {
+			continue
+		}
+		usage.add(owners[f.doc.StorageId], size)
+	}
+	return usage, nil
+}
+
+// storageApplications returns the names of the applications owning
+// storage instances in the model, keyed by storage ID.
+func (sb *storageBackend) storageApplications() (map[string]string, error) {
+	instances, err := sb.storageInstances(nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	owners := make(map[string]string)
+	for _, si := range instances {
+		switch owner := si.maybeOwner().(type) {
+		case names.UnitTag:
+			application, err := names.UnitApplication(owner.Id())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			owners[si.doc.Id] = application
+		case names.ApplicationTag:
+			owners[si.doc.Id] = owner.Id()
+		}
+	}
+	return owners, nil
+}
+
+// storagePoolCapacityOps returns the supplied ops, along with ops that
+// ensure the volumes and filesystems they create do not take the storage
+// pools they are allocated from past the pools' max-size or max-volumes
+// limits. All of the storage created by the ops is counted, so that a
+// batch cannot exceed a limit that each of its volumes fits within.
+//
+// Ops previously added by storagePoolCapacityOps are replaced, so it may
+// be applied both to a part of a transaction and to the whole of it.
+func (sb *storageBackend) storagePoolCapacityOps(ops []txn.Op) ([]txn.Op, error) {
+	requested := make(map[string]StorageAllocation)
+	result := make([]txn.Op, 0, len(ops))
+	for _, op := range ops {
+		if isStoragePoolCapacityOp(op) {
+			continue
+		}
+		result = append(result, op)
+		if pool, size, ok := insertedStorage(op); ok {
+			allocation := requested[pool]
+			allocation.Count++
+			allocation.Size += size
+			requested[pool] = allocation
+		}
+	}
+
+	// Visit the pools in order, so the ops are the same on each attempt.
+	pools := make([]string, 0, len(requested))
+	for pool := range requested {
+		pools = append(pools, pool)
+	}
+	sort.Strings(pools)
+	for _, pool := range pools {
+		op, err := sb.storagePoolCapacityOp(pool, requested[pool])
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if op != nil {
+			result = append(result, *op)
+		}
+	}
+	return result, nil
+}
+
+// insertedStorage returns the pool and size of the volume or filesystem
+// inserted by op, if it inserts one. Filesystems backed by a volume are
+// accounted for by the volume.
+func insertedStorage(op txn.Op) (pool string, size uint64, ok bool) {
+	switch doc := op.Insert.(type) {
+	case *volumeDoc:
+		if doc.Info != nil {
+			return doc.Info.Pool, doc.Info.Size, true
+		} else if doc.Params != nil {
+			return doc.Params.Pool, doc.Params.Size, true
+		}
+	case *filesystemDoc:
+		if doc.VolumeId != "" {
+			return "", 0, false
+		}
+		if doc.Info != nil {
+			return doc.Info.Pool, doc.Info.Size, true
+		} else if doc.Params != nil {
+			return doc.Params.Pool, doc.Params.Size, true
+		}
+	}
+	return "", 0, false
+}
+
+func isStoragePoolCapacityOp(op txn.Op) bool {
+	id, ok := op.Id.(string)
+	return ok && op.C == settingsC && strings.HasPrefix(id, storagePoolSettingsPrefix) && op.Update != nil
+}
+
+// storagePoolSettingsPrefix is the prefix of the keys poolmanager
+// stores storage pools under in the settings collection.
+const storagePoolSettingsPrefix = "pool#"
+
+// storagePoolCapacityOp returns an error satisfying
+// errors.IsQuotaLimitExceeded if allocating the requested storage from
+// the named pool would exceed the pool's max-size or max-volumes limits.
+// Otherwise it returns an op that asserts the pool has not changed, or
+// nil if the pool has no limits.
+//
+// The usage is computed from the volumes and filesystems collections,
+// which cannot be asserted, so the op also updates the pool's settings
+// document. Concurrent allocations from the pool then invalidate each
+// other's assertion, and are checked again with the updated usage.
+func (sb *storageBackend) storagePoolCapacityOp(poolName string, requested StorageAllocation) (*txn.Op, error) {
+	registry, err := sb.registry()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	pool, err := poolmanager.New(sb.settings, registry).Get(poolName)
+	if errors.IsNotFound(err) {
+		// There are no limits for storage provider
+		// types used directly as pools.
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	maxSize, maxVolumes := pool.MaxSize(), pool.MaxVolumes()
+	if maxSize == 0 && maxVolumes == 0 {
+		return nil, nil
+	}
+
+	// The revision is read before the usage, so that any
+	// allocation made in between fails the assertion.
+	key := storagePoolSettingsPrefix + poolName
+	revno, err := sb.storagePoolSettingsRevno(key)
+	if err != nil {
+		return nil, errors.Annotatef(err, "reading storage pool %q", poolName)
+	}
+	usage, err := sb.StoragePoolUsage(poolName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if maxVolumes > 0 && usage.Total.Count+requested.Count > maxVolumes {
+		return nil, errors.QuotaLimitExceededf(
+			"storage pool %q has %d of %d volumes allocated, cannot allocate %d more",
+			poolName, usage.Total.Count, maxVolumes, requested.Count,
+		)
+	}
+	if maxSize > 0 && usage.Total.Size+requested.Size > maxSize {
+		return nil, errors.QuotaLimitExceededf(
+			"storage pool %q has %dMiB of %dMiB allocated, cannot allocate %dMiB more",
+			poolName, usage.Total.Size, maxSize, requested.Size,
+		)
+	}
+	return &txn.Op{
+		C:      settingsC,
+		Id:     key,
+		Assert: bson.D{{"txn-revno", revno}},
+		// Any update increments the txn-revno, so
+		// incrementing a counter of the allocations
+		// is enough for them to serialise.
+		Update: bson.D{{"$inc", bson.D{{"allocations", 1}}}},
+	}, nil
+}
+
+// storagePoolSettingsRevno returns the txn-revno of the storage pool
+// settings document with the given key.
+func (sb *storageBackend) storagePoolSettingsRevno(key string) (int64, error) {
+	settings, closer := sb.mb.db().GetCollection(settingsC)
+	defer closer()
+
+	var doc struct {
+		TxnRevno int64 `bson:"txn-revno"`
+	}
+	err := settings.FindId(key).Select(bson.D{{"txn-revno", 1}}).One(&doc)
+	if err == mgo.ErrNotFound {
+		return 0, errors.NotFoundf("settings %s", key)
+	} else if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return doc.TxnRevno, nil
+}
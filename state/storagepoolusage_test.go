@@ -0,0 +1,156 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/storage/provider"
+)
+
+type StoragePoolUsageSuite struct {
+	StorageStateSuiteBase
+}
+
+var _ = gc.Suite(&StoragePoolUsageSuite{})
+
+func (s *StoragePoolUsageSuite) TestStoragePoolUsageEmpty(c *gc.C) {
+	usage, err := s.storageBackend.StoragePoolUsage("loop-pool")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage, jc.DeepEquals, &state.StoragePoolUsage{
+		Pool:         "loop-pool",
+		Applications: map[string]state.StorageAllocation{},
+	})
+}
+
+func (s *StoragePoolUsageSuite) TestStoragePoolUsageVolumes(c *gc.C) {
+	app, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	u2, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AssignUnit(u2, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Provisioned volumes are reported with their actual size.
+	volume := s.storageInstanceVolume(c, storageTag)
+	err = s.storageBackend.SetVolumeInfo(volume.VolumeTag(), state.VolumeInfo{
+		VolumeId: "vol-123",
+		Pool:     "loop-pool",
+		Size:     2048,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	usage, err := s.storageBackend.StoragePoolUsage("loop-pool")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage, jc.DeepEquals, &state.StoragePoolUsage{
+		Pool:  "loop-pool",
+		Total: state.StorageAllocation{Count: 2, Size: 3072},
+		Applications: map[string]state.StorageAllocation{
+			"storage-block": {Count: 2, Size: 3072},
+		},
+	})
+}
+
+func (s *StoragePoolUsageSuite) TestStoragePoolUsageFilesystems(c *gc.C) {
+	_, u, _ := s.setupSingleStorage(c, "filesystem", "tmpfs-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+
+	usage, err := s.storageBackend.StoragePoolUsage("tmpfs-pool")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage, jc.DeepEquals, &state.StoragePoolUsage{
+		Pool:  "tmpfs-pool",
+		Total: state.StorageAllocation{Count: 1, Size: 1024},
+		Applications: map[string]state.StorageAllocation{
+			"storage-filesystem": {Count: 1, Size: 1024},
+		},
+	})
+}
+
+func (s *StoragePoolUsageSuite) TestMaxVolumes(c *gc.C) {
+	_, err := s.pm.Create("limited-pool", provider.LoopProviderType, map[string]interface{}{
+		"max-volumes": 1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	app, u, _ := s.setupSingleStorage(c, "block", "limited-pool")
+	err = s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+
+	u2, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AssignUnit(u2, state.AssignCleanEmpty)
+	c.Assert(err, gc.ErrorMatches, `.*storage pool "limited-pool" has 1 of 1 volumes allocated, cannot allocate 1 more`)
+}
+
+func (s *StoragePoolUsageSuite) TestMaxSize(c *gc.C) {
+	_, err := s.pm.Create("limited-pool", provider.TmpfsProviderType, map[string]interface{}{
+		"max-size": "1536M",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	app, u, _ := s.setupSingleStorage(c, "filesystem", "limited-pool")
+	err = s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+
+	u2, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AssignUnit(u2, state.AssignCleanEmpty)
+	c.Assert(err, gc.ErrorMatches, `.*storage pool "limited-pool" has 1024MiB of 1536MiB allocated, cannot allocate 1024MiB more`)
+}
+
+func (s *StoragePoolUsageSuite) TestMaxVolumesCountsBatch(c *gc.C) {
+	_, err := s.pm.Create("limited-pool", provider.LoopProviderType, map[string]interface{}{
+		"max-volumes": 2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	ch := s.AddTestingCharm(c, "storage-block2")
+	app := s.AddTestingApplicationWithStorage(c, "storage-block2", ch, map[string]state.StorageConstraints{
+		"multi1to10": makeStorageCons("limited-pool", 1024, 3),
+		"multi2up":   makeStorageCons("loop-pool", 2048, 2),
+	})
+	u, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Each of the volumes fits within the limit on its own.
+	err = s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, gc.ErrorMatches, `.*storage pool "limited-pool" has 0 of 2 volumes allocated, cannot allocate 3 more`)
+	c.Assert(err, jc.Satisfies, errors.IsQuotaLimitExceeded)
+}
+
+func (s *StoragePoolUsageSuite) TestMaxVolumesConcurrentAllocation(c *gc.C) {
+	_, err := s.pm.Create("limited-pool", provider.LoopProviderType, map[string]interface{}{
+		"max-volumes": 1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	app, u, _ := s.setupSingleStorage(c, "block", "limited-pool")
+	u2, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	defer state.SetBeforeHooks(c, s.State, func() {
+		err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+	err = s.State.AssignUnit(u2, state.AssignCleanEmpty)
+	c.Assert(err, gc.ErrorMatches, `.*storage pool "limited-pool" has 1 of 1 volumes allocated, cannot allocate 1 more`)
+}
+
+func (s *StoragePoolUsageSuite) TestMaxSizeConcurrentPoolChange(c *gc.C) {
+	_, err := s.pm.Create("limited-pool", provider.TmpfsProviderType, map[string]interface{}{
+		"max-size": "2G",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, u, _ := s.setupSingleStorage(c, "filesystem", "limited-pool")
+
+	defer state.SetBeforeHooks(c, s.State, func() {
+		err := s.pm.Replace("limited-pool", string(provider.TmpfsProviderType), map[string]interface{}{
+			"max-size": "512M",
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+	err = s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, gc.ErrorMatches, `.*storage pool "limited-pool" has 0MiB of 512MiB allocated, cannot allocate 1024MiB more`)
+}
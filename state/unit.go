@@ -573,6 +573,12 @@ func (op *DestroyUnitOperation) eraseHistory() error {
 			return one
 		}
 	}
+	if err := eraseHookHistory(op.unit.st, op.unit.Name()); err != nil {
+		one := errors.Annotate(err, "hooks")
+		if op.FatalError(one) {
+			return one
+		}
+	}
 	return nil
 }
 
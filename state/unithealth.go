@@ -0,0 +1,153 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/status"
+)
+
+// maxUnitHealthChecks is the number of distinct health checks a unit
+// may report; charms are expected to use a small fixed set of names.
+const maxUnitHealthChecks = 50
+
+// unitHealthChecksDoc holds the health checks reported by a unit,
+// keyed by check name. It is keyed by the unit's global key.
+type unitHealthChecksDoc struct {
+	DocID     string                    `bson:"_id"`
+	ModelUUID string                    `bson:"model-uuid"`
+	Unit      string                    `bson:"unit"`
+	Checks    map[string]healthCheckDoc `bson:"checks"`
+}
+
+type healthCheckDoc struct {
+	Status  string `bson:"status"`
+	Message string `bson:"message,omitempty"`
+	Updated int64  `bson:"updated"`
+}
+
+func (doc *unitHealthChecksDoc) healthChecks() []status.HealthCheck {
+	result := make([]status.HealthCheck, 0, len(doc.Checks))
+	for name, check := range doc.Checks {
+		result = append(result, status.HealthCheck{
+			Name:    name,
+			Status:  status.HealthStatus(check.Status),
+			Message: check.Message,
+			Since:   time.Unix(0, check.Updated).UTC(),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// SetHealthCheck records the latest result of the named health check
+// for the unit, replacing any earlier result of the same check.
+func (u *Unit) SetHealthCheck(check status.HealthCheck) error {
+	if err := check.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	checkDoc := healthCheckDoc{
+		Status:  string(check.Status),
+		Message: check.Message,
+		Updated: u.st.clock().Now().UnixNano(),
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := u.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if u.Life() == Dead {
+			return nil, errors.NotFoundf("unit %s", u.Name())
+		}
+		unitNotDeadOp := txn.Op{
+			C:      unitsC,
+			Id:     u.doc.DocID,
+			Assert: notDeadDoc,
+		}
+
+		coll, closer := u.st.db().GetCollection(unitHealthChecksC)
+		defer closer()
+
+		var doc unitHealthChecksDoc
+		err := coll.FindId(u.globalKey()).One(&doc)
+		if err == mgo.ErrNotFound {
+			return []txn.Op{unitNotDeadOp, {
+				C:      unitHealthChecksC,
+				Id:     u.globalKey(),
+				Assert: txn.DocMissing,
+				Insert: &unitHealthChecksDoc{
+					Unit:   u.Name(),
+					Checks: map[string]healthCheckDoc{check.Name: checkDoc},
+				},
+			}}, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if _, ok := doc.Checks[check.Name]; !ok && len(doc.Checks) >= maxUnitHealthChecks {
+			return nil, errors.QuotaLimitExceededf(
+				"unit %q already has %d health checks", u.Name(), len(doc.Checks))
+		}
+		return []txn.Op{unitNotDeadOp, {
+			C:      unitHealthChecksC,
+			Id:     u.globalKey(),
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{{"checks." + check.Name, checkDoc}}}},
+		}}, nil
+	}
+	err := u.st.db().Run(buildTxn)
+	return errors.Annotatef(err, "cannot set health check %q for unit %q", check.Name, u)
+}
+
+// HealthChecks returns the health checks reported by the unit,
+// sorted by name.
+func (u *Unit) HealthChecks() ([]status.HealthCheck, error) {
+	coll, closer := u.st.db().GetCollection(unitHealthChecksC)
+	defer closer()
+
+	var doc unitHealthChecksDoc
+	err := coll.FindId(u.globalKey()).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get health checks for unit %q", u)
+	}
+	return doc.healthChecks(), nil
+}
+
+// AllUnitHealthChecks returns the health checks reported by each unit
+// in the model, keyed by unit name.
+func (st *State) AllUnitHealthChecks() (map[string][]status.HealthCheck, error) {
+	coll, closer := st.db().GetCollection(unitHealthChecksC)
+	defer closer()
+
+	var docs []unitHealthChecksDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get unit health checks")
+	}
+	result := make(map[string][]status.HealthCheck, len(docs))
+	for _, doc := range docs {
+		result[doc.Unit] = doc.healthChecks()
+	}
+	return result, nil
+}
+
+// removeUnitHealthChecksOp returns the operation needed to remove the
+// health checks reported by the unit with the given global key.
+func removeUnitHealthChecksOp(mb modelBackend, globalKey string) txn.Op {
+	return txn.Op{
+		C:      unitHealthChecksC,
+		Id:     mb.docID(globalKey),
+		Remove: true,
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type UnitHealthSuite struct {
+	statetesting.StateSuite
+	unit *state.Unit
+}
+
+var _ = gc.Suite(&UnitHealthSuite{})
+
+func (s *UnitHealthSuite) SetUpTest(c *gc.C) {
+	s.StateSuite.SetUpTest(c)
+	s.unit = s.Factory.MakeUnit(c, nil)
+}
+
+func (s *UnitHealthSuite) TestNoHealthChecks(c *gc.C) {
+	checks, err := s.unit.HealthChecks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(checks, gc.HasLen, 0)
+}
+
+func (s *UnitHealthSuite) TestSetHealthCheck(c *gc.C) {
+	first := s.Clock.Now()
+	err := s.unit.SetHealthCheck(status.HealthCheck{
+		Name:   "db-connection",
+		Status: status.HealthPass,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.Clock.Advance(time.Minute)
+	err = s.unit.SetHealthCheck(status.HealthCheck{
+		Name:    "disk-space",
+		Status:  status.HealthWarn,
+		Message: "80% full",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	checks, err := s.unit.HealthChecks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(checks, jc.DeepEquals, []status.HealthCheck{{
+		Name:   "db-connection",
+		Status: status.HealthPass,
+		Since:  first.UTC(),
+	}, {
+		Name:    "disk-space",
+		Status:  status.HealthWarn,
+		Message: "80% full",
+		Since:   first.Add(time.Minute).UTC(),
+	}})
+}
+
+func (s *UnitHealthSuite) TestSetHealthCheckReplaces(c *gc.C) {
+	err := s.unit.SetHealthCheck(status.HealthCheck{
+		Name:    "disk-space",
+		Status:  status.HealthWarn,
+		Message: "80% full",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetHealthCheck(status.HealthCheck{
+		Name:    "disk-space",
+		Status:  status.HealthFail,
+		Message: "disk full",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	checks, err := s.unit.HealthChecks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(checks, gc.HasLen, 1)
+	c.Assert(checks[0].Status, gc.Equals, status.HealthFail)
+	c.Assert(checks[0].Message, gc.Equals, "disk full")
+}
+
+func (s *UnitHealthSuite) TestSetHealthCheckInvalid(c *gc.C) {
+	err := s.unit.SetHealthCheck(status.HealthCheck{
+		Name:   "disk.space",
+		Status: status.HealthPass,
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	err = s.unit.SetHealthCheck(status.HealthCheck{
+		Name:   "disk-space",
+		Status: "ok",
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *UnitHealthSuite) TestSetHealthCheckLimit(c *gc.C) {
+	for i := 0; i < state.MaxUnitHealthChecks; i++ {
+		err := s.unit.SetHealthCheck(status.HealthCheck{
+			Name:   fmt.Sprintf("check-%d", i),
+			Status: status.HealthPass,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err := s.unit.SetHealthCheck(status.HealthCheck{
+		Name:   "one-too-many",
+		Status: status.HealthPass,
+	})
+	c.Assert(err, jc.Satisfies, errors.IsQuotaLimitExceeded)
+
+	// Existing checks may still be updated.
+	err = s.unit.SetHealthCheck(status.HealthCheck{
+		Name:   "check-0",
+		Status: status.HealthFail,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *UnitHealthSuite) TestSetHealthCheckDeadUnit(c *gc.C) {
+	err := s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetHealthCheck(status.HealthCheck{
+		Name:   "db-connection",
+		Status: status.HealthPass,
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *UnitHealthSuite) TestAllUnitHealthChecks(c *gc.C) {
+	other := s.Factory.MakeUnit(c, nil)
+	err := s.unit.SetHealthCheck(status.HealthCheck{
+		Name:   "db-connection",
+		Status: status.HealthPass,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = other.SetHealthCheck(status.HealthCheck{
+		Name:   "db-connection",
+		Status: status.HealthFail,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	all, err := s.State.AllUnitHealthChecks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 2)
+	c.Assert(all[s.unit.Name()][0].Status, gc.Equals, status.HealthPass)
+	c.Assert(all[other.Name()][0].Status, gc.Equals, status.HealthFail)
+}
+
+func (s *UnitHealthSuite) TestRemoveUnitRemovesHealthChecks(c *gc.C) {
+	err := s.unit.SetHealthCheck(status.HealthCheck{
+		Name:   "db-connection",
+		Status: status.HealthPass,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	all, err := s.State.AllUnitHealthChecks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 0)
+}
@@ -0,0 +1,130 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/core/status"
+)
+
+// maxUnitHookHistory is the number of hook executions kept for each
+// unit; older executions are removed as new ones are recorded.
+const maxUnitHookHistory = 100
+
+// hookExecutionDoc records a single run of a hook on a unit. Like
+// status history, the documents are written outside of transactions.
+type hookExecutionDoc struct {
+	ModelUUID string `bson:"model-uuid"`
+	Unit      string `bson:"unit"`
+	Hook      string `bson:"hook"`
+	Started   int64  `bson:"started"`
+	Finished  int64  `bson:"finished"`
+	Result    string `bson:"result"`
+	Message   string `bson:"message,omitempty"`
+}
+
+// RecordHookExecution adds a hook execution to the unit's hook history,
+// removing the oldest executions once more than maxUnitHookHistory are
+// recorded.
+func (u *Unit) RecordHookExecution(execution status.HookExecution) error {
+	if err := execution.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	history, closer := u.st.db().GetCollection(unitHookHistoryC)
+	defer closer()
+
+	historyW := history.Writeable()
+	err := historyW.Insert(&hookExecutionDoc{
+		Unit:     u.Name(),
+		Hook:     execution.Hook,
+		Started:  execution.Started.UnixNano(),
+		Finished: execution.Finished.UnixNano(),
+		Result:   string(execution.Result),
+		Message:  execution.Message,
+	})
+	if err != nil {
+		return errors.Annotatef(err, "cannot record %q hook execution for unit %q", execution.Hook, u)
+	}
+
+	var expired []bson.M
+	err = history.Find(bson.D{{"unit", u.Name()}}).
+		Sort("-started", "-_id").
+		Skip(maxUnitHookHistory).
+		Select(bson.M{"_id": 1}).
+		All(&expired)
+	if err != nil {
+		return errors.Annotatef(err, "cannot prune hook history for unit %q", u)
+	}
+	if len(expired) == 0 {
+		return nil
+	}
+	ids := make([]interface{}, len(expired))
+	for i, doc := range expired {
+		ids[i] = doc["_id"]
+	}
+	_, err = historyW.RemoveAll(bson.D{{"_id", bson.D{{"$in", ids}}}})
+	return errors.Annotatef(err, "cannot prune hook history for unit %q", u)
+}
+
+// HookHistory returns the hook executions recorded for the unit, most
+// recent first.
+func (u *Unit) HookHistory() ([]status.HookExecution, error) {
+	history, closer := u.st.db().GetCollection(unitHookHistoryC)
+	defer closer()
+
+	var docs []hookExecutionDoc
+	err := history.Find(bson.D{{"unit", u.Name()}}).Sort("-started", "-_id").All(&docs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get hook history for unit %q", u)
+	}
+	result := make([]status.HookExecution, len(docs))
+	for i, doc := range docs {
+		result[i] = status.HookExecution{
+			Hook:     doc.Hook,
+			Started:  time.Unix(0, doc.Started).UTC(),
+			Finished: time.Unix(0, doc.Finished).UTC(),
+			Result:   status.HookResult(doc.Result),
+			Message:  doc.Message,
+		}
+	}
+	return result, nil
+}
+
+// eraseHookHistory removes all hook history documents for the named
+// unit, in batches as for eraseStatusHistory.
+func eraseHookHistory(mb modelBackend, unitName string) error {
+	history, closer := mb.db().GetCollection(unitHookHistoryC)
+	defer closer()
+
+	iter := history.Find(bson.D{{"unit", unitName}}).Select(bson.M{"_id": 1}).Iter()
+	defer iter.Close()
+
+	logFormat := "deleted %d hook history documents for " + fmt.Sprintf("%q", unitName)
+	deleted, err := deleteInBatches(
+		history.Writeable().Underlying(), nil, "", iter,
+		logFormat, loggo.DEBUG,
+		noEarlyFinish,
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if deleted > 0 {
+		logger.Debugf(logFormat, deleted)
+	}
+	return nil
+}
+
+// PruneHookHistory removes hook executions older than maxHistoryTime,
+// and the oldest executions until the collection is smaller than
+// maxHistoryMB. It is run along with PruneStatusHistory.
+func PruneHookHistory(st *State, maxHistoryTime time.Duration, maxHistoryMB int) error {
+	err := pruneCollection(st, maxHistoryTime, maxHistoryMB, unitHookHistoryC, "started", nil, NanoSeconds)
+	return errors.Trace(err)
+}
@@ -0,0 +1,120 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type UnitHookHistorySuite struct {
+	statetesting.StateSuite
+	unit *state.Unit
+}
+
+var _ = gc.Suite(&UnitHookHistorySuite{})
+
+func (s *UnitHookHistorySuite) SetUpTest(c *gc.C) {
+	s.StateSuite.SetUpTest(c)
+	s.unit = s.Factory.MakeUnit(c, nil)
+}
+
+func (s *UnitHookHistorySuite) execution(hook string, started time.Time, duration time.Duration) status.HookExecution {
+	return status.HookExecution{
+		Hook:     hook,
+		Started:  started,
+		Finished: started.Add(duration),
+		Result:   status.HookSucceeded,
+	}
+}
+
+func (s *UnitHookHistorySuite) TestRecordHookExecution(c *gc.C) {
+	started := time.Date(2020, 7, 1, 10, 0, 0, 0, time.UTC)
+	err := s.unit.RecordHookExecution(s.execution("install", started, 2*time.Minute))
+	c.Assert(err, jc.ErrorIsNil)
+	failed := s.execution("config-changed", started.Add(3*time.Minute), 5*time.Second)
+	failed.Result = status.HookFailed
+	failed.Message = "exit status 1"
+	err = s.unit.RecordHookExecution(failed)
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.unit.HookHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, jc.DeepEquals, []status.HookExecution{
+		failed,
+		s.execution("install", started, 2*time.Minute),
+	})
+}
+
+func (s *UnitHookHistorySuite) TestRecordHookExecutionInvalid(c *gc.C) {
+	err := s.unit.RecordHookExecution(status.HookExecution{Hook: "install"})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	history, err := s.unit.HookHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 0)
+}
+
+func (s *UnitHookHistorySuite) TestHookHistoryIsBounded(c *gc.C) {
+	started := time.Date(2020, 7, 1, 10, 0, 0, 0, time.UTC)
+	for i := 0; i < state.MaxUnitHookHistory+5; i++ {
+		err := s.unit.RecordHookExecution(s.execution("update-status", started.Add(time.Duration(i)*time.Minute), time.Second))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	history, err := s.unit.HookHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, state.MaxUnitHookHistory)
+	c.Assert(history[0].Started, gc.Equals, started.Add(time.Duration(state.MaxUnitHookHistory+4)*time.Minute))
+	c.Assert(history[len(history)-1].Started, gc.Equals, started.Add(5*time.Minute))
+}
+
+func (s *UnitHookHistorySuite) TestHookHistoryIsPerUnit(c *gc.C) {
+	other := s.Factory.MakeUnit(c, nil)
+	started := time.Date(2020, 7, 1, 10, 0, 0, 0, time.UTC)
+	err := other.RecordHookExecution(s.execution("install", started, time.Second))
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.unit.HookHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 0)
+}
+
+func (s *UnitHookHistorySuite) TestDestroyRemovesHookHistory(c *gc.C) {
+	err := s.unit.AssignToNewMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	started := time.Date(2020, 7, 1, 10, 0, 0, 0, time.UTC)
+	err = s.unit.RecordHookExecution(s.execution("install", started, time.Second))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.unit.HookHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 0)
+}
+
+func (s *UnitHookHistorySuite) TestPruneHookHistoryByAge(c *gc.C) {
+	now := time.Now().UTC().Round(time.Second)
+	err := s.unit.RecordHookExecution(s.execution("install", now.Add(-48*time.Hour), time.Second))
+	c.Assert(err, jc.ErrorIsNil)
+	recent := s.execution("config-changed", now.Add(-time.Hour), time.Second)
+	err = s.unit.RecordHookExecution(recent)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = state.PruneHookHistory(s.State, 24*time.Hour, 1024)
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.unit.HookHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, jc.DeepEquals, []status.HookExecution{recent})
+}
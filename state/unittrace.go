@@ -0,0 +1,117 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// unitTraceDoc records until when the hook tool calls made by a unit's
+// charm are traced. It is keyed by the unit's global key.
+type unitTraceDoc struct {
+	DocID         string `bson:"_id"`
+	ModelUUID     string `bson:"model-uuid"`
+	Unit          string `bson:"unit"`
+	Until         int64  `bson:"until"`
+	IncludeOutput bool   `bson:"include-output,omitempty"`
+}
+
+// SetTraceUntil arranges for the hook tool calls made by the unit's
+// charm to be traced until the given time. A zero time stops tracing.
+// Only the size of each tool's output is traced unless includeOutput
+// is true.
+func (u *Unit) SetTraceUntil(until time.Time, includeOutput bool) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := u.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if u.Life() == Dead {
+			return nil, errors.NotFoundf("unit %s", u.Name())
+		}
+
+		coll, closer := u.st.db().GetCollection(unitTracesC)
+		defer closer()
+		n, err := coll.FindId(u.globalKey()).Count()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		exists := n > 0
+
+		if until.IsZero() {
+			if !exists {
+				return nil, jujutxn.ErrNoOperations
+			}
+			return []txn.Op{{
+				C:      unitTracesC,
+				Id:     u.globalKey(),
+				Assert: txn.DocExists,
+				Remove: true,
+			}}, nil
+		}
+		ops := []txn.Op{{
+			C:      unitsC,
+			Id:     u.doc.DocID,
+			Assert: notDeadDoc,
+		}}
+		if !exists {
+			return append(ops, txn.Op{
+				C:      unitTracesC,
+				Id:     u.globalKey(),
+				Assert: txn.DocMissing,
+				Insert: &unitTraceDoc{
+					Unit:          u.Name(),
+					Until:         until.UnixNano(),
+					IncludeOutput: includeOutput,
+				},
+			}), nil
+		}
+		return append(ops, txn.Op{
+			C:      unitTracesC,
+			Id:     u.globalKey(),
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{
+				{"until", until.UnixNano()},
+				{"include-output", includeOutput},
+			}}},
+		}), nil
+	}
+	err := u.st.db().Run(buildTxn)
+	return errors.Annotatef(err, "cannot set trace for unit %q", u)
+}
+
+// TraceUntil returns the time until which the hook tool calls made by
+// the unit's charm are traced, and whether their output is traced. It
+// returns the zero time if the unit has never been traced, or tracing
+// was stopped.
+func (u *Unit) TraceUntil() (until time.Time, includeOutput bool, err error) {
+	coll, closer := u.st.db().GetCollection(unitTracesC)
+	defer closer()
+
+	var doc unitTraceDoc
+	err = coll.FindId(u.globalKey()).One(&doc)
+	if err == mgo.ErrNotFound {
+		return time.Time{}, false, nil
+	} else if err != nil {
+		return time.Time{}, false, errors.Annotatef(err, "cannot get trace for unit %q", u)
+	}
+	return time.Unix(0, doc.Until).UTC(), doc.IncludeOutput, nil
+}
+
+// removeUnitTraceOp returns the operation needed to remove the trace
+// record of the unit with the given global key.
+func removeUnitTraceOp(mb modelBackend, globalKey string) txn.Op {
+	return txn.Op{
+		C:      unitTracesC,
+		Id:     mb.docID(globalKey),
+		Remove: true,
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type UnitTraceSuite struct {
+	statetesting.StateSuite
+	unit *state.Unit
+}
+
+var _ = gc.Suite(&UnitTraceSuite{})
+
+func (s *UnitTraceSuite) SetUpTest(c *gc.C) {
+	s.StateSuite.SetUpTest(c)
+	s.unit = s.Factory.MakeUnit(c, nil)
+}
+
+func (s *UnitTraceSuite) TestNotTraced(c *gc.C) {
+	until, _, err := s.unit.TraceUntil()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(until.IsZero(), jc.IsTrue)
+}
+
+func (s *UnitTraceSuite) TestSetTraceUntil(c *gc.C) {
+	until := time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC)
+	err := s.unit.SetTraceUntil(until, false)
+	c.Assert(err, jc.ErrorIsNil)
+	got, includeOutput, err := s.unit.TraceUntil()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got, gc.Equals, until)
+	c.Assert(includeOutput, jc.IsFalse)
+
+	later := until.Add(10 * time.Minute)
+	err = s.unit.SetTraceUntil(later, true)
+	c.Assert(err, jc.ErrorIsNil)
+	got, includeOutput, err = s.unit.TraceUntil()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got, gc.Equals, later)
+	c.Assert(includeOutput, jc.IsTrue)
+}
+
+func (s *UnitTraceSuite) TestStopTrace(c *gc.C) {
+	err := s.unit.SetTraceUntil(time.Now().Add(time.Minute), false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetTraceUntil(time.Time{}, false)
+	c.Assert(err, jc.ErrorIsNil)
+	until, _, err := s.unit.TraceUntil()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(until.IsZero(), jc.IsTrue)
+
+	// Stopping a trace that isn't running is fine.
+	err = s.unit.SetTraceUntil(time.Time{}, false)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *UnitTraceSuite) TestSetTraceUntilDeadUnit(c *gc.C) {
+	err := s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetTraceUntil(time.Now().Add(time.Minute), false)
+	c.Assert(err, gc.ErrorMatches, `cannot set trace for unit ".*": unit .* not found`)
+}
+
+func (s *UnitTraceSuite) TestTraceRemovedWithUnit(c *gc.C) {
+	err := s.unit.SetTraceUntil(time.Now().Add(time.Minute), false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	until, _, err := s.unit.TraceUntil()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(until.IsZero(), jc.IsTrue)
+}
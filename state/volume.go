@@ -52,6 +52,11 @@ type Volume interface {
 	// Releasing reports whether or not the volume is to be released
 	// from the model when it is Dying/Dead.
 	Releasing() bool
+
+	// RequestedSize returns the size, in MiB, that the volume has been
+	// requested to grow to. RequestedSize returns false if there is no
+	// resize pending for the volume.
+	RequestedSize() (uint64, bool)
 }
 
 // VolumeAttachment describes an attachment of a volume to a machine.
@@ -132,6 +137,12 @@ type volumeDoc struct {
 	// the volume as being non-detachable, and to determine
 	// which volumes must be removed along with said machine.
 	HostId string `bson:"hostid,omitempty"`
+
+	// RequestedSize is the size, in MiB, that a provisioned
+	// volume has been requested to grow to. It is unset once
+	// the storage provisioner has recorded info with at least
+	// this size.
+	RequestedSize uint64 `bson:"requested-size,omitempty"`
 }
 
 // volumeAttachmentDoc records information about a volume attachment.
@@ -278,6 +289,11 @@ func (v *volume) Releasing() bool {
 	return v.doc.Releasing
 }
 
+// RequestedSize is required to implement Volume.
+func (v *volume) RequestedSize() (uint64, bool) {
+	return v.doc.RequestedSize, v.doc.RequestedSize > 0
+}
+
 // Status is required to implement StatusGetter.
 func (v *volume) Status() (status.StatusInfo, error) {
 	return getStatus(v.mb.db(), volumeGlobalKey(v.VolumeTag().Id()), "volume")
@@ -1454,7 +1470,7 @@ func (sb *storageBackend) SetVolumeInfo(tag names.VolumeTag, info VolumeInfo) (e
 		// If the volume has parameters, unset them when
 		// we set info for the first time, ensuring that
 		// params and info are mutually exclusive.
-		var unsetParams bool
+		var unsetParams, unsetRequestedSize bool
 		var ops []txn.Op
 		if params, ok := v.Params(); ok {
 			info.Pool = params.Pool
@@ -1468,13 +1484,74 @@ func (sb *storageBackend) SetVolumeInfo(tag names.VolumeTag, info VolumeInfo) (e
 			if err := validateVolumeInfoChange(info, oldInfo); err != nil {
 				return nil, err
 			}
+			if info.Size != oldInfo.Size {
+				resizeOps, err := sb.volumeResizedOps(v.VolumeTag(), info.Size)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				ops = append(ops, resizeOps...)
+			}
+			if requested, ok := v.RequestedSize(); ok && info.Size >= requested {
+				unsetRequestedSize = true
+			}
 		}
-		ops = append(ops, setVolumeInfoOps(tag, info, unsetParams)...)
+		ops = append(ops, setVolumeInfoOps(tag, info, unsetParams, unsetRequestedSize)...)
 		return ops, nil
 	}
 	return sb.mb.db().Run(buildTxn)
 }
 
+// volumeResizedOps returns txn.Ops to update the size of a provisioned
+// filesystem backed by the specified volume, which has been resized.
+func (sb *storageBackend) volumeResizedOps(tag names.VolumeTag, size uint64) ([]txn.Op, error) {
+	f, err := sb.volumeFilesystem(tag)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if f.doc.Info == nil || f.doc.Info.Size == size {
+		return nil, nil
+	}
+	return []txn.Op{{
+		C:      filesystemsC,
+		Id:     f.doc.FilesystemId,
+		Assert: bson.D{{"info", bson.D{{"$exists", true}}}},
+		Update: bson.D{{"$set", bson.D{{"info.size", size}}}},
+	}}, nil
+}
+
+// resizeVolumeOps returns txn.Ops to request that the specified
+// provisioned volume be grown to the given size, in MiB. If the
+// volume already has the requested size, jujutxn.ErrNoOperations
+// is returned.
+func resizeVolumeOps(v *volume, size uint64) ([]txn.Op, error) {
+	if v.Life() != Alive {
+		return nil, errors.Errorf("%s is not alive", names.ReadableString(v.VolumeTag()))
+	}
+	info, err := v.Info()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if size < info.Size {
+		return nil, errors.NotSupportedf(
+			"shrinking %s from %dMiB to %dMiB",
+			names.ReadableString(v.VolumeTag()), info.Size, size,
+		)
+	}
+	if requested, _ := v.RequestedSize(); size == info.Size || size == requested {
+		return nil, jujutxn.ErrNoOperations
+	}
+	return []txn.Op{{
+		C:  volumesC,
+		Id: v.doc.Name,
+		Assert: append(isAliveDoc,
+			bson.DocElem{"info.size", info.Size},
+		),
+		Update: bson.D{{"$set", bson.D{{"requested-size", size}}}},
+	}}, nil
+}
+
 func validateVolumeInfoChange(newInfo, oldInfo VolumeInfo) error {
 	if newInfo.Pool != oldInfo.Pool {
 		return errors.Errorf(
@@ -1491,15 +1568,23 @@ func validateVolumeInfoChange(newInfo, oldInfo VolumeInfo) error {
 	return nil
 }
 
-func setVolumeInfoOps(tag names.VolumeTag, info VolumeInfo, unsetParams bool) []txn.Op {
+func setVolumeInfoOps(tag names.VolumeTag, info VolumeInfo, unsetParams, unsetRequestedSize bool) []txn.Op {
 	asserts := isAliveDoc
 	update := bson.D{
 		{"$set", bson.D{{"info", &info}}},
 	}
+	var unset bson.D
 	if unsetParams {
 		asserts = append(asserts, bson.DocElem{"info", bson.D{{"$exists", false}}})
 		asserts = append(asserts, bson.DocElem{"params", bson.D{{"$exists", true}}})
-		update = append(update, bson.DocElem{"$unset", bson.D{{"params", nil}}})
+		unset = append(unset, bson.DocElem{"params", nil})
+	}
+	if unsetRequestedSize {
+		asserts = append(asserts, bson.DocElem{"requested-size", bson.D{{"$lte", info.Size}}})
+		unset = append(unset, bson.DocElem{"requested-size", nil})
+	}
+	if len(unset) > 0 {
+		update = append(update, bson.DocElem{"$unset", unset})
 	}
 	return []txn.Op{{
 		C:      volumesC,
@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/poolmanager"
 	"github.com/juju/juju/storage/provider"
+	dummystorage "github.com/juju/juju/storage/provider/dummy"
 )
 
 type VolumeStateSuite struct {
@@ -241,6 +242,106 @@ func (s *VolumeStateSuite) TestSetVolumeInfoImmutable(c *gc.C) {
 	s.assertVolumeInfo(c, volumeTag, volumeInfoSet)
 }
 
+func (s *VolumeStateSuite) TestResizeStorageInstance(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volume := s.storageInstanceVolume(c, storageTag)
+	volumeTag := volume.VolumeTag()
+
+	volumeInfoSet := state.VolumeInfo{Size: 123, VolumeId: "vol-ume"}
+	err = s.storageBackend.SetVolumeInfo(volumeTag, volumeInfoSet)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.storageBackend.ResizeStorageInstance(storageTag, 456)
+	c.Assert(err, jc.ErrorIsNil)
+	volume = s.volume(c, volumeTag)
+	size, ok := volume.RequestedSize()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(size, gc.Equals, uint64(456))
+
+	// Once the provisioner records the new size, the request is cleared.
+	volumeInfoSet.Pool = "loop-pool"
+	volumeInfoSet.Size = 456
+	err = s.storageBackend.SetVolumeInfo(volumeTag, volumeInfoSet)
+	c.Assert(err, jc.ErrorIsNil)
+	volume = s.volume(c, volumeTag)
+	_, ok = volume.RequestedSize()
+	c.Assert(ok, jc.IsFalse)
+	s.assertVolumeInfo(c, volumeTag, volumeInfoSet)
+}
+
+func (s *VolumeStateSuite) TestResizeStorageInstanceShrink(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volume := s.storageInstanceVolume(c, storageTag)
+
+	err = s.storageBackend.SetVolumeInfo(volume.VolumeTag(), state.VolumeInfo{Size: 123, VolumeId: "vol-ume"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.storageBackend.ResizeStorageInstance(storageTag, 100)
+	c.Assert(err, gc.ErrorMatches, `cannot resize storage data/0: shrinking volume "0/0" from 123MiB to 100MiB not supported`)
+}
+
+func (s *VolumeStateSuite) TestResizeStorageInstanceNotProvisioned(c *gc.C) {
+	_, _, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.storageBackend.ResizeStorageInstance(storageTag, 100)
+	c.Assert(err, gc.ErrorMatches, `cannot resize storage data/0: volume "0/0" not provisioned`)
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+}
+
+func (s *VolumeStateSuite) TestResizeStorageInstancePoolMaxSize(c *gc.C) {
+	_, err := s.pm.Create("limited-pool", provider.LoopProviderType, map[string]interface{}{
+		"max-size": "1536M",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, u, storageTag := s.setupSingleStorage(c, "block", "limited-pool")
+	err = s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volume := s.storageInstanceVolume(c, storageTag)
+	err = s.storageBackend.SetVolumeInfo(volume.VolumeTag(), state.VolumeInfo{Size: 1024, VolumeId: "vol-ume"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.storageBackend.ResizeStorageInstance(storageTag, 2048)
+	c.Assert(err, gc.ErrorMatches, `cannot resize storage data/0: storage pool "limited-pool" has 1024MiB of 1536MiB allocated, cannot allocate 1024MiB more`)
+	c.Assert(err, jc.Satisfies, errors.IsQuotaLimitExceeded)
+
+	err = s.storageBackend.ResizeStorageInstance(storageTag, 1536)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *VolumeStateSuite) TestResizeStorageInstanceProviderLimit(c *gc.C) {
+	registry, err := s.policy.GetStorageProviderRegistry()
+	c.Assert(err, jc.ErrorIsNil)
+	p, err := registry.StorageProvider("modelscoped-block")
+	c.Assert(err, jc.ErrorIsNil)
+	var validated []storage.VolumeParams
+	p.(*dummystorage.StorageProvider).VolumeSourceFunc = func(*storage.Config) (storage.VolumeSource, error) {
+		return &dummystorage.VolumeSource{
+			ValidateVolumeParamsFunc: func(params storage.VolumeParams) error {
+				validated = append(validated, params)
+				return errors.New("volume size exceeds the maximum of 2048MiB")
+			},
+		}, nil
+	}
+
+	_, u, storageTag := s.setupSingleStorage(c, "block", "persistent-block")
+	err = s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volume := s.storageInstanceVolume(c, storageTag)
+	err = s.storageBackend.SetVolumeInfo(volume.VolumeTag(), state.VolumeInfo{Size: 1024, VolumeId: "vol-ume"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.storageBackend.ResizeStorageInstance(storageTag, 4096)
+	c.Assert(err, gc.ErrorMatches, `cannot resize storage data/0: volume size exceeds the maximum of 2048MiB`)
+	c.Assert(validated, gc.HasLen, 1)
+	c.Assert(validated[0].Tag, gc.Equals, volume.VolumeTag())
+	c.Assert(validated[0].Size, gc.Equals, uint64(4096))
+	_, ok := s.volume(c, volume.VolumeTag()).RequestedSize()
+	c.Assert(ok, jc.IsFalse)
+}
+
 func (s *VolumeStateSuite) TestWatchVolumeAttachment(c *gc.C) {
 	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
 	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
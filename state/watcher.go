@@ -243,6 +243,38 @@ func (sb *storageBackend) WatchMachineVolumes(m names.MachineTag) StringsWatcher
 	return sb.watchHostStorage(m, volumesC)
 }
 
+// WatchModelVolumeResizes returns a StringsWatcher that notifies of
+// changes to model-scoped volumes, so that pending resize requests
+// may be observed. Receivers must check whether a resize is pending.
+func (sb *storageBackend) WatchModelVolumeResizes() StringsWatcher {
+	mb := sb.mb
+	filter := func(id interface{}) bool {
+		k, err := mb.strictLocalID(id.(string))
+		if err != nil {
+			return false
+		}
+		return !strings.Contains(k, "/")
+	}
+	return newCollectionWatcher(mb, colWCfg{col: volumesC, filter: filter})
+}
+
+// WatchMachineVolumeResizes returns a StringsWatcher that notifies of
+// changes to volumes scoped to the specified machine, so that pending
+// resize requests may be observed. Receivers must check whether a
+// resize is pending.
+func (sb *storageBackend) WatchMachineVolumeResizes(m names.MachineTag) StringsWatcher {
+	mb := sb.mb
+	matchExp := regexp.MustCompile(fmt.Sprintf("^%s/%s$", m.Id(), names.NumberSnippet))
+	filter := func(id interface{}) bool {
+		k, err := mb.strictLocalID(id.(string))
+		if err != nil {
+			return false
+		}
+		return matchExp.MatchString(k)
+	}
+	return newCollectionWatcher(mb, colWCfg{col: volumesC, filter: filter})
+}
+
 // WatchMachineFilesystems returns a StringsWatcher that notifies of changes
 // to the lifecycles of all filesystems scoped to the specified machine.
 func (sb *storageBackend) WatchMachineFilesystems(m names.MachineTag) StringsWatcher {
@@ -1996,6 +2028,16 @@ func (sb *storageBackend) WatchStorageAttachment(s names.StorageTag, u names.Uni
 	return newEntityWatcher(sb.mb, storageAttachmentsC, sb.mb.docID(id))
 }
 
+// WatchVolume returns a watcher for observing changes to a volume.
+func (sb *storageBackend) WatchVolume(v names.VolumeTag) NotifyWatcher {
+	return newEntityWatcher(sb.mb, volumesC, sb.mb.docID(v.Id()))
+}
+
+// WatchFilesystem returns a watcher for observing changes to a filesystem.
+func (sb *storageBackend) WatchFilesystem(f names.FilesystemTag) NotifyWatcher {
+	return newEntityWatcher(sb.mb, filesystemsC, sb.mb.docID(f.Id()))
+}
+
 // WatchVolumeAttachment returns a watcher for observing changes
 // to a volume attachment.
 func (sb *storageBackend) WatchVolumeAttachment(host names.Tag, v names.VolumeTag) NotifyWatcher {
@@ -2018,6 +2060,13 @@ func (a *Application) WatchCharmConfig() (NotifyWatcher, error) {
 	return newEntityWatcher(a.st, settingsC, a.st.docID(configKey)), nil
 }
 
+// WatchApplicationConfig returns a watcher for observing changes to the
+// application's own configuration settings, as opposed to its charm
+// configuration.
+func (a *Application) WatchApplicationConfig() NotifyWatcher {
+	return newEntityWatcher(a.st, settingsC, a.st.docID(a.applicationConfigKey()))
+}
+
 // WatchConfigSettings returns a watcher for observing changes to the
 // unit's application configuration settings. The unit must have a charm URL
 // set before this method is called, and the returned watcher will be
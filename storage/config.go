@@ -6,6 +6,7 @@ package storage
 import (
 	"github.com/juju/errors"
 	"github.com/juju/schema"
+	"github.com/juju/utils"
 )
 
 const (
@@ -19,33 +20,66 @@ const (
 	// should not be relied upon until a storage source is
 	// constructed.
 	ConfigStorageDir = "storage-dir"
+
+	// ConfigMaxSize is the name of the optional pool attribute that
+	// limits the total size of the volumes and filesystems allocated
+	// from the pool. The value is a size with an optional unit suffix,
+	// e.g. "500G"; a size without a suffix is in MiB.
+	ConfigMaxSize = "max-size"
+
+	// ConfigMaxVolumes is the name of the optional pool attribute that
+	// limits the number of volumes and filesystems allocated from the
+	// pool.
+	ConfigMaxVolumes = "max-volumes"
 )
 
 // Config defines the configuration for a storage source.
 type Config struct {
-	name     string
-	provider ProviderType
-	attrs    map[string]interface{}
+	name       string
+	provider   ProviderType
+	attrs      map[string]interface{}
+	maxSize    uint64
+	maxVolumes int
 }
 
-var fields = schema.Fields{}
+var fields = schema.Fields{
+	ConfigMaxSize:    schema.String(),
+	ConfigMaxVolumes: schema.ForceInt(),
+}
 
 var configChecker = schema.FieldMap(
 	fields,
-	schema.Defaults{},
+	schema.Defaults{
+		ConfigMaxSize:    schema.Omit,
+		ConfigMaxVolumes: schema.Omit,
+	},
 )
 
 // NewConfig creates a new Config for instantiating a storage source.
 func NewConfig(name string, provider ProviderType, attrs map[string]interface{}) (*Config, error) {
-	_, err := configChecker.Coerce(attrs, nil)
+	out, err := configChecker.Coerce(attrs, nil)
 	if err != nil {
 		return nil, errors.Annotate(err, "validating common storage config")
 	}
-	return &Config{
+	coerced := out.(map[string]interface{})
+	cfg := &Config{
 		name:     name,
 		provider: provider,
 		attrs:    attrs,
-	}, nil
+	}
+	if maxSize, ok := coerced[ConfigMaxSize].(string); ok {
+		cfg.maxSize, err = utils.ParseSize(maxSize)
+		if err != nil {
+			return nil, errors.Annotatef(err, "validating common storage config: invalid %s", ConfigMaxSize)
+		}
+	}
+	if maxVolumes, ok := coerced[ConfigMaxVolumes].(int); ok {
+		if maxVolumes < 0 {
+			return nil, errors.NotValidf("negative %s %d", ConfigMaxVolumes, maxVolumes)
+		}
+		cfg.maxVolumes = maxVolumes
+	}
+	return cfg, nil
 }
 
 // Name returns the name of a storage source. This is not necessarily unique,
@@ -71,6 +105,18 @@ func (c *Config) Attrs() map[string]interface{} {
 	return attrs
 }
 
+// MaxSize returns the maximum total size, in MiB, of the storage that
+// may be allocated from the pool, or zero if the size is not limited.
+func (c *Config) MaxSize() uint64 {
+	return c.maxSize
+}
+
+// MaxVolumes returns the maximum number of volumes and filesystems that
+// may be allocated from the pool, or zero if the number is not limited.
+func (c *Config) MaxVolumes() int {
+	return c.maxVolumes
+}
+
 // ValueString returns the named config attribute as a string.
 func (c *Config) ValueString(name string) (string, bool) {
 	v, ok := c.attrs[name].(string)
@@ -0,0 +1,44 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/storage"
+)
+
+type ConfigSuite struct{}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (*ConfigSuite) TestNewConfig(c *gc.C) {
+	cfg, err := storage.NewConfig("pool", "loop", map[string]interface{}{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.Name(), gc.Equals, "pool")
+	c.Assert(cfg.Provider(), gc.Equals, storage.ProviderType("loop"))
+	c.Assert(cfg.Attrs(), jc.DeepEquals, map[string]interface{}{"foo": "bar"})
+	c.Assert(cfg.MaxSize(), gc.Equals, uint64(0))
+	c.Assert(cfg.MaxVolumes(), gc.Equals, 0)
+}
+
+func (*ConfigSuite) TestNewConfigLimits(c *gc.C) {
+	cfg, err := storage.NewConfig("pool", "loop", map[string]interface{}{
+		"max-size":    "2G",
+		"max-volumes": "10",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.MaxSize(), gc.Equals, uint64(2048))
+	c.Assert(cfg.MaxVolumes(), gc.Equals, 10)
+}
+
+func (*ConfigSuite) TestNewConfigInvalidLimits(c *gc.C) {
+	_, err := storage.NewConfig("pool", "loop", map[string]interface{}{"max-size": "lots"})
+	c.Assert(err, gc.ErrorMatches, `validating common storage config: invalid max-size: .*`)
+	_, err = storage.NewConfig("pool", "loop", map[string]interface{}{"max-volumes": "many"})
+	c.Assert(err, gc.ErrorMatches, `validating common storage config: max-volumes: expected number, got string\("many"\)`)
+	_, err = storage.NewConfig("pool", "loop", map[string]interface{}{"max-volumes": -1})
+	c.Assert(err, gc.ErrorMatches, `negative max-volumes -1 not valid`)
+}
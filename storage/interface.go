@@ -215,6 +215,23 @@ type VolumeImporter interface {
 	) (VolumeInfo, error)
 }
 
+// VolumeResizer provides an interface for growing volumes that have
+// already been provisioned.
+//
+// TODO(storage) make this part of VolumeSource once all providers
+// support it.
+type VolumeResizer interface {
+	// ResizeVolumes grows the volumes with the specified parameters
+	// to at least the requested size, and returns the updated volume
+	// information. Shrinking a volume is not supported; implementations
+	// must return an error if the requested size is smaller than the
+	// current size.
+	//
+	// ResizeVolumes must be idempotent; it may be called again for a
+	// volume that has already been resized.
+	ResizeVolumes(ctx context.ProviderCallContext, params []VolumeResizeParams) ([]ResizeVolumesResult, error)
+}
+
 // VolumeParams is a fully specified set of parameters for volume creation,
 // derived from one or more of user-specified storage constraints, a
 // storage pool definition, and charm storage metadata.
@@ -265,6 +282,24 @@ type VolumeAttachmentParams struct {
 	VolumeId string
 }
 
+// VolumeResizeParams is a set of parameters for growing a provisioned
+// volume.
+type VolumeResizeParams struct {
+	// Tag is the unique tag assigned by Juju to the volume.
+	Tag names.VolumeTag
+
+	// VolumeId is the unique provider-supplied ID for the volume.
+	VolumeId string
+
+	// Provider is the name of the storage provider that manages
+	// the volume.
+	Provider ProviderType
+
+	// Size is the minimum size, in MiB, that the volume should
+	// have after it is resized.
+	Size uint64
+}
+
 // AttachmentParams describes the parameters for attaching a volume or
 // filesystem to a machine.
 type AttachmentParams struct {
@@ -354,6 +389,13 @@ type DescribeVolumesResult struct {
 	Error      error
 }
 
+// ResizeVolumesResult contains the result of a VolumeResizer.ResizeVolumes
+// call for one volume. VolumeInfo should only be used if Error is nil.
+type ResizeVolumesResult struct {
+	VolumeInfo *VolumeInfo
+	Error      error
+}
+
 // AttachVolumesResult contains the result of a VolumeSource.AttachVolumes call
 // for one volume. VolumeAttachment should only be used if Error is nil.
 type AttachVolumesResult struct {
@@ -47,4 +47,8 @@ type StorageAttachmentInfo struct {
 	// for a filesystem-kind storage attachment, and the device path
 	// for a block-kind.
 	Location string
+
+	// Size is the size of the underlying volume or filesystem, in MiB.
+	// Size may be zero if the size is not known.
+	Size uint64
 }
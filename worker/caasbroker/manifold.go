@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/worker/caasadmission"
+	"github.com/juju/juju/worker/caaseventlogger"
 	"github.com/juju/juju/worker/caasrbacmapper"
 )
 
@@ -74,6 +75,8 @@ func manifoldOutput(in worker.Worker, out interface{}) error {
 		*result = inTracker.Broker().(caasadmission.K8sBroker)
 	case *caasrbacmapper.K8sBroker:
 		*result = inTracker.Broker().(caasrbacmapper.K8sBroker)
+	case *caaseventlogger.K8sBroker:
+		*result = inTracker.Broker().(caaseventlogger.K8sBroker)
 	case *environs.CloudDestroyer:
 		*result = inTracker.Broker()
 	case *storage.ProviderRegistry:
@@ -0,0 +1,164 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caaseventlogger
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/worker/v2/catacomb"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	informercore "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// maxCachedObjects bounds the number of objects whose ownership is
+// remembered, as the UIDs of pods change each time they're recreated.
+const maxCachedObjects = 1000
+
+// nodeOOMReasons are the reasons of the node events recorded when the
+// kernel OOM killer kills a process, by the kubelet and by the node
+// problem detector. Other node events aren't logged.
+var nodeOOMReasons = set.NewStrings("SystemOOM", "OOMKilling")
+
+// EventLogger is a worker which writes the Kubernetes events for objects
+// created by Juju to the model's log.
+type EventLogger struct {
+	catacomb  catacomb.Catacomb
+	logger    Logger
+	checker   ObjectChecker
+	informers []informercore.EventInformer
+	started   time.Time
+
+	// lock guards managed, which caches whether the objects involved
+	// in events are managed by Juju.
+	lock    sync.Mutex
+	managed map[types.UID]bool
+}
+
+// NewEventLogger returns a worker logging the events observed by the
+// informers. Only events seen after the worker started are logged, so
+// restarting the worker doesn't replay old events.
+func NewEventLogger(
+	logger Logger,
+	clock clock.Clock,
+	checker ObjectChecker,
+	informers ...informercore.EventInformer,
+) (*EventLogger, error) {
+	el := &EventLogger{
+		logger:    logger,
+		checker:   checker,
+		informers: informers,
+		started:   clock.Now(),
+		managed:   make(map[types.UID]bool),
+	}
+
+	for _, informer := range el.informers {
+		informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: el.logEvent,
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				// Periodic resyncs deliver unchanged events as updates.
+				oldEvt, ok := oldObj.(*core.Event)
+				newEvt, _ := newObj.(*core.Event)
+				if ok && newEvt != nil && oldEvt.ResourceVersion == newEvt.ResourceVersion {
+					return
+				}
+				el.logEvent(newObj)
+			},
+		})
+	}
+
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &el.catacomb,
+		Work: el.loop,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return el, nil
+}
+
+func (el *EventLogger) loop() error {
+	for _, informer := range el.informers {
+		go informer.Informer().Run(el.catacomb.Dying())
+	}
+
+	<-el.catacomb.Dying()
+	return el.catacomb.ErrDying()
+}
+
+func (el *EventLogger) logEvent(obj interface{}) {
+	evt, ok := obj.(*core.Event)
+	if !ok {
+		el.logger.Errorf("expected event, got %T", obj)
+		return
+	}
+	if eventTime(evt).Before(el.started) {
+		return
+	}
+	if evt.InvolvedObject.Kind == "Node" && !nodeOOMReasons.Contains(evt.Reason) {
+		return
+	}
+	managed, err := el.isJujuManaged(evt.InvolvedObject)
+	if err != nil {
+		el.logger.Errorf("checking object for event %q: %v", evt.Name, err)
+		return
+	}
+	if !managed {
+		return
+	}
+
+	ref := evt.InvolvedObject
+	if evt.Type == core.EventTypeWarning {
+		el.logger.Warningf("%s %s %s: %s", ref.Kind, ref.Name, evt.Reason, evt.Message)
+		return
+	}
+	el.logger.Infof("%s %s %s: %s", ref.Kind, ref.Name, evt.Reason, evt.Message)
+}
+
+func (el *EventLogger) isJujuManaged(ref core.ObjectReference) (bool, error) {
+	el.lock.Lock()
+	defer el.lock.Unlock()
+	// Whether a node runs the model's pods changes as they're
+	// scheduled, so nodes are checked each time.
+	cacheable := ref.UID != "" && ref.Kind != "Node"
+	if managed, ok := el.managed[ref.UID]; ok && cacheable {
+		return managed, nil
+	}
+	managed, err := el.checker.IsJujuManagedObject(ref)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if cacheable {
+		if len(el.managed) >= maxCachedObjects {
+			el.managed = make(map[types.UID]bool)
+		}
+		el.managed[ref.UID] = managed
+	}
+	return managed, nil
+}
+
+// eventTime returns the time the event was last seen.
+func eventTime(evt *core.Event) time.Time {
+	if !evt.LastTimestamp.IsZero() {
+		return evt.LastTimestamp.Time
+	}
+	if !evt.EventTime.IsZero() {
+		return evt.EventTime.Time
+	}
+	return evt.FirstTimestamp.Time
+}
+
+// Kill is part of the worker.Worker interface.
+func (el *EventLogger) Kill() {
+	el.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (el *EventLogger) Wait() error {
+	return el.catacomb.Wait()
+}
@@ -0,0 +1,195 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caaseventlogger_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/juju/juju/caas/kubernetes/provider/mocks"
+	"github.com/juju/juju/worker/caaseventlogger"
+)
+
+func TestPackage(t *testing.T) { gc.TestingT(t) }
+
+type EventLoggerSuite struct {
+	ctrl                    *gomock.Controller
+	mockEventInformer       *mocks.MockEventInformer
+	mockSharedIndexInformer *mocks.MockSharedIndexInformer
+
+	clock    *testclock.Clock
+	checker  *fakeChecker
+	logger   *recordingLogger
+	handlers cache.ResourceEventHandlerFuncs
+}
+
+var _ = gc.Suite(&EventLoggerSuite{})
+
+var startTime = time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func (s *EventLoggerSuite) SetUpTest(c *gc.C) {
+	s.ctrl = gomock.NewController(c)
+	s.mockEventInformer = mocks.NewMockEventInformer(s.ctrl)
+	s.mockSharedIndexInformer = mocks.NewMockSharedIndexInformer(s.ctrl)
+	s.mockSharedIndexInformer.EXPECT().Run(gomock.Any()).AnyTimes()
+	s.mockEventInformer.EXPECT().Informer().AnyTimes().Return(s.mockSharedIndexInformer)
+	s.mockSharedIndexInformer.EXPECT().AddEventHandler(gomock.Any()).
+		Do(func(h cache.ResourceEventHandlerFuncs) {
+			s.handlers = h
+		})
+
+	s.clock = testclock.NewClock(startTime)
+	s.checker = &fakeChecker{managed: map[string]bool{"mariadb-0": true}}
+	s.logger = &recordingLogger{}
+}
+
+func (s *EventLoggerSuite) TearDownTest(c *gc.C) {
+	s.ctrl.Finish()
+}
+
+func (s *EventLoggerSuite) newEventLogger(c *gc.C) *caaseventlogger.EventLogger {
+	el, err := caaseventlogger.NewEventLogger(s.logger, s.clock, s.checker, s.mockEventInformer)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.handlers.AddFunc, gc.NotNil)
+	return el
+}
+
+func newEvent(name, eventType, reason, message string, seen time.Time) *core.Event {
+	return &core.Event{
+		ObjectMeta: meta.ObjectMeta{Name: name + ".1"},
+		InvolvedObject: core.ObjectReference{
+			Kind: "Pod",
+			Name: name,
+			UID:  types.UID(name + "-uid"),
+		},
+		Type:          eventType,
+		Reason:        reason,
+		Message:       message,
+		LastTimestamp: meta.NewTime(seen),
+	}
+}
+
+func (s *EventLoggerSuite) TestLogsEvents(c *gc.C) {
+	el := s.newEventLogger(c)
+	defer workertest.CleanKill(c, el)
+
+	seen := startTime.Add(time.Second)
+	s.handlers.OnAdd(newEvent("mariadb-0", core.EventTypeNormal, "Pulling", `Pulling image "mariadb"`, seen))
+	backOff := newEvent("mariadb-0", core.EventTypeWarning, "BackOff", `Back-off pulling image "mariadb"`, seen)
+	backOff.ResourceVersion = "2"
+	s.handlers.OnUpdate(nil, backOff)
+	// Resyncs of unchanged events are not logged again.
+	s.handlers.OnUpdate(backOff, backOff)
+
+	c.Assert(s.logger.entries, jc.DeepEquals, []string{
+		`INFO Pod mariadb-0 Pulling: Pulling image "mariadb"`,
+		`WARNING Pod mariadb-0 BackOff: Back-off pulling image "mariadb"`,
+	})
+	// The ownership of the pod is only checked once.
+	c.Assert(s.checker.calls, gc.Equals, 1)
+}
+
+func (s *EventLoggerSuite) TestIgnoresEventsBeforeStart(c *gc.C) {
+	el := s.newEventLogger(c)
+	defer workertest.CleanKill(c, el)
+
+	s.handlers.OnAdd(newEvent("mariadb-0", core.EventTypeWarning, "BackOff", "old news", startTime.Add(-time.Minute)))
+
+	c.Assert(s.logger.entries, gc.HasLen, 0)
+	c.Assert(s.checker.calls, gc.Equals, 0)
+}
+
+func (s *EventLoggerSuite) TestIgnoresUnmanagedObjects(c *gc.C) {
+	el := s.newEventLogger(c)
+	defer workertest.CleanKill(c, el)
+
+	s.handlers.OnAdd(newEvent("other-0", core.EventTypeWarning, "BackOff", "not ours", startTime.Add(time.Second)))
+
+	c.Assert(s.logger.entries, gc.HasLen, 0)
+	c.Assert(s.checker.calls, gc.Equals, 1)
+}
+
+func (s *EventLoggerSuite) TestLogsNodeOOMEvents(c *gc.C) {
+	s.checker.managed["node-1"] = true
+	el := s.newEventLogger(c)
+	defer workertest.CleanKill(c, el)
+
+	seen := startTime.Add(time.Second)
+	notReady := newEvent("node-1", core.EventTypeNormal, "NodeNotReady", "Node node-1 status is now: NodeNotReady", seen)
+	notReady.InvolvedObject.Kind = "Node"
+	s.handlers.OnAdd(notReady)
+	for i, reason := range []string{"SystemOOM", "OOMKilling"} {
+		oom := newEvent("node-1", core.EventTypeWarning, reason, "System OOM encountered, victim process: mysqld", seen)
+		oom.InvolvedObject.Kind = "Node"
+		oom.ResourceVersion = fmt.Sprint(i)
+		s.handlers.OnAdd(oom)
+	}
+
+	// Only OOM kills are logged for nodes.
+	c.Assert(s.logger.entries, jc.DeepEquals, []string{
+		`WARNING Node node-1 SystemOOM: System OOM encountered, victim process: mysqld`,
+		`WARNING Node node-1 OOMKilling: System OOM encountered, victim process: mysqld`,
+	})
+	// Whether the node runs the model's pods is checked each time.
+	c.Assert(s.checker.calls, gc.Equals, 2)
+}
+
+func (s *EventLoggerSuite) TestCheckError(c *gc.C) {
+	s.checker.err = errors.New("boom")
+	el := s.newEventLogger(c)
+	defer workertest.CleanKill(c, el)
+
+	s.handlers.OnAdd(newEvent("mariadb-0", core.EventTypeWarning, "BackOff", "failed", startTime.Add(time.Second)))
+
+	c.Assert(s.logger.entries, jc.DeepEquals, []string{
+		`ERROR checking object for event "mariadb-0.1": boom`,
+	})
+}
+
+type fakeChecker struct {
+	managed map[string]bool
+	err     error
+	calls   int
+}
+
+func (f *fakeChecker) IsJujuManagedObject(ref core.ObjectReference) (bool, error) {
+	f.calls++
+	return f.managed[ref.Name], f.err
+}
+
+type recordingLogger struct {
+	entries []string
+}
+
+func (l *recordingLogger) record(level, format string, args ...interface{}) {
+	l.entries = append(l.entries, level+" "+fmt.Sprintf(format, args...))
+}
+
+func (l *recordingLogger) Debugf(format string, args ...interface{}) {
+	l.record("DEBUG", format, args...)
+}
+
+func (l *recordingLogger) Infof(format string, args ...interface{}) {
+	l.record("INFO", format, args...)
+}
+
+func (l *recordingLogger) Warningf(format string, args ...interface{}) {
+	l.record("WARNING", format, args...)
+}
+
+func (l *recordingLogger) Errorf(format string, args ...interface{}) {
+	l.record("ERROR", format, args...)
+}
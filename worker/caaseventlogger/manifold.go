@@ -0,0 +1,87 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caaseventlogger
+
+import (
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/dependency"
+	core "k8s.io/api/core/v1"
+	"k8s.io/client-go/informers"
+	informercore "k8s.io/client-go/informers/core/v1"
+)
+
+// K8sBroker describes the broker methods needed to log the model's
+// Kubernetes events.
+type K8sBroker interface {
+	ObjectChecker
+	SharedInformerFactory() informers.SharedInformerFactory
+	NodeEventInformer() informercore.EventInformer
+}
+
+// ObjectChecker reports whether the object involved in an event was created
+// by Juju.
+type ObjectChecker interface {
+	IsJujuManagedObject(core.ObjectReference) (bool, error)
+}
+
+// Logger is the logger the Kubernetes events are written to.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Infof(string, ...interface{})
+	Warningf(string, ...interface{})
+	Errorf(string, ...interface{})
+}
+
+// ManifoldConfig describes the resources used by the event logger worker.
+type ManifoldConfig struct {
+	BrokerName string
+	Clock      clock.Clock
+	Logger     Logger
+}
+
+// Manifold returns a Manifold that encapsulates the event logger worker.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.BrokerName,
+		},
+		Start: config.Start,
+	}
+}
+
+// Start starts the event logger worker.
+func (c ManifoldConfig) Start(context dependency.Context) (worker.Worker, error) {
+	if err := c.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var broker K8sBroker
+	if err := context.Get(c.BrokerName, &broker); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	eventInformers := []informercore.EventInformer{broker.SharedInformerFactory().Core().V1().Events()}
+	// Node events are only available to models not limited to their
+	// namespace.
+	if nodeEvents := broker.NodeEventInformer(); nodeEvents != nil {
+		eventInformers = append(eventInformers, nodeEvents)
+	}
+	return NewEventLogger(c.Logger, c.Clock, broker, eventInformers...)
+}
+
+// Validate is called by Start to check for bad configuration.
+func (c ManifoldConfig) Validate() error {
+	if c.BrokerName == "" {
+		return errors.NotValidf("empty BrokerName")
+	}
+	if c.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if c.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	return nil
+}
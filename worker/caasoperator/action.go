@@ -49,6 +49,7 @@ func remoteExecute(logger Logger,
 			Env:        params.Env,
 			Stdout:     params.Stdout,
 			Stderr:     params.Stderr,
+			Signal:     params.Signal,
 		},
 		params.Cancel,
 	)
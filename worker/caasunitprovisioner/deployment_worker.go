@@ -4,6 +4,7 @@
 package caasunitprovisioner
 
 import (
+	"bytes"
 	"reflect"
 
 	"github.com/juju/errors"
@@ -16,6 +17,7 @@ import (
 	"github.com/juju/juju/caas"
 	k8sprovider "github.com/juju/juju/caas/kubernetes/provider"
 	k8sspecs "github.com/juju/juju/caas/kubernetes/provider/specs"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
 )
@@ -81,8 +83,9 @@ func (w *deploymentWorker) loop() error {
 		pw            watcher.NotifyWatcher
 		provisionChan watcher.NotifyChannel
 
-		currentScale int
-		currentInfo  *apicaasunitprovisioner.ProvisioningInfo
+		currentScale  int
+		currentInfo   *apicaasunitprovisioner.ProvisioningInfo
+		currentConfig application.ConfigAttributes
 	)
 
 	gotSpecNotify := false
@@ -144,7 +147,12 @@ func (w *deploymentWorker) loop() error {
 			continue
 		}
 
-		if desiredScale == currentScale && isProvisionInfoEqual(info, currentInfo) {
+		appConfig, err := w.applicationGetter.ApplicationConfig(w.application)
+		if err != nil {
+			return errors.Trace(err)
+		}
+
+		if desiredScale == currentScale && isProvisionInfoEqual(info, currentInfo) && reflect.DeepEqual(appConfig, currentConfig) {
 			continue
 		}
 
@@ -176,11 +184,7 @@ func (w *deploymentWorker) loop() error {
 
 		currentScale = desiredScale
 		currentInfo = info
-
-		appConfig, err := w.applicationGetter.ApplicationConfig(w.application)
-		if err != nil {
-			return errors.Trace(err)
-		}
+		currentConfig = appConfig
 
 		serviceParams, err := provisionInfoToServiceParams(info)
 		if err != nil {
@@ -240,11 +244,16 @@ func provisionInfoToServiceParams(info *apicaasunitprovisioner.ProvisioningInfo)
 		if serviceParams.RawK8sSpec, err = k8sspecs.ParseRawK8sSpec(info.RawK8sSpec); err != nil {
 			return nil, errors.Annotate(err, "cannot parse raw k8s spec")
 		}
+	} else if len(info.HelmChart) > 0 {
+		serviceParams.HelmChart = &caas.HelmChartParams{
+			Archive: info.HelmChart,
+			Values:  info.HelmValues,
+		}
 	}
 	return serviceParams, nil
 }
 
-// isProvisionInfoChanged checks if podspec or raw k8s spec changed or not.
+// isProvisionInfoChanged checks if podspec, raw k8s spec or helm chart changed or not.
 func isProvisionInfoEqual(newInfo, oldInfo *apicaasunitprovisioner.ProvisioningInfo) bool {
 	if newInfo == nil && oldInfo == nil {
 		return true
@@ -254,6 +263,8 @@ func isProvisionInfoEqual(newInfo, oldInfo *apicaasunitprovisioner.ProvisioningI
 
 	return newInfo.PodSpec == oldInfo.PodSpec &&
 		newInfo.RawK8sSpec == oldInfo.RawK8sSpec &&
+		bytes.Equal(newInfo.HelmChart, oldInfo.HelmChart) &&
+		reflect.DeepEqual(newInfo.HelmValues, oldInfo.HelmValues) &&
 		newInfo.CharmModifiedVersion == oldInfo.CharmModifiedVersion
 }
 
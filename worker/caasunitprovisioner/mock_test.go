@@ -151,6 +151,7 @@ type mockApplicationGetter struct {
 	scaleWatcher   *watchertest.MockNotifyWatcher
 	deploymentMode caas.DeploymentMode
 	scale          int
+	config         application.ConfigAttributes
 }
 
 func (m *mockApplicationGetter) WatchApplications() (watcher.StringsWatcher, error) {
@@ -163,6 +164,9 @@ func (m *mockApplicationGetter) WatchApplications() (watcher.StringsWatcher, err
 
 func (a *mockApplicationGetter) ApplicationConfig(appName string) (application.ConfigAttributes, error) {
 	a.MethodCall(a, "ApplicationConfig", appName)
+	if a.config != nil {
+		return a.config, a.NextErr()
+	}
 	return application.ConfigAttributes{
 		"juju-external-hostname": "exthost",
 	}, a.NextErr()
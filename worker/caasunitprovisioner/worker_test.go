@@ -519,6 +519,108 @@ containers:
 		"gitlab", expectedParams, 1, application.ConfigAttributes{"juju-external-hostname": "exthost"})
 }
 
+func (s *WorkerSuite) TestHelmChartValuesChange(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	w := s.setupNewUnitScenario(c)
+	defer workertest.CleanKill(c, w)
+
+	helmInfo := func(values map[string]interface{}) apicaasunitprovisioner.ProvisioningInfo {
+		return apicaasunitprovisioner.ProvisioningInfo{
+			HelmChart:  []byte("chart-data"),
+			HelmValues: values,
+			Tags:       map[string]string{"foo": "bar"},
+			DeploymentInfo: apicaasunitprovisioner.DeploymentInfo{
+				DeploymentType: "stateful",
+				ServiceType:    "loadbalancer",
+			},
+			Filesystems: []storage.KubernetesFilesystemParams{{
+				StorageName: "database",
+				Size:        100,
+			}},
+		}
+	}
+	expectedParams := func(values map[string]interface{}) *caas.ServiceParams {
+		return &caas.ServiceParams{
+			HelmChart: &caas.HelmChartParams{
+				Archive: []byte("chart-data"),
+				Values:  values,
+			},
+			ResourceTags: map[string]string{"foo": "bar"},
+			Deployment: caas.DeploymentParams{
+				DeploymentType: "stateful",
+				ServiceType:    "loadbalancer",
+			},
+			Filesystems: []storage.KubernetesFilesystemParams{{
+				StorageName: "database",
+				Size:        100,
+			}},
+		}
+	}
+	ensure := func(values map[string]interface{}) {
+		s.serviceBroker.ResetCalls()
+		s.podSpecGetter.setProvisioningInfo(helmInfo(values))
+		s.sendContainerSpecChange(c)
+		s.podSpecGetter.assertSpecRetrieved(c)
+		select {
+		case <-s.serviceEnsured:
+		case <-time.After(coretesting.LongWait):
+			c.Fatal("timed out waiting for service to be ensured")
+		}
+		s.serviceBroker.CheckCallNames(c, "EnsureService")
+		s.serviceBroker.CheckCall(c, 0, "EnsureService",
+			"gitlab", expectedParams(values), 1, application.ConfigAttributes{"juju-external-hostname": "exthost"})
+	}
+
+	ensure(map[string]interface{}{"image.tag": "10.5"})
+
+	// Same chart and values, nothing happens.
+	s.sendContainerSpecChange(c)
+	s.podSpecGetter.assertSpecRetrieved(c)
+	select {
+	case <-s.serviceEnsured:
+		c.Fatal("service/unit ensured unexpectedly")
+	case <-time.After(coretesting.ShortWait):
+	}
+
+	// A charm config change renders the chart again.
+	ensure(map[string]interface{}{"image.tag": "10.6"})
+}
+
+func (s *WorkerSuite) TestApplicationConfigChange(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	w := s.setupNewUnitScenario(c)
+	defer workertest.CleanKill(c, w)
+
+	s.serviceBroker.ResetCalls()
+
+	// Same spec and config, nothing happens.
+	s.sendContainerSpecChange(c)
+	s.podSpecGetter.assertSpecRetrieved(c)
+	select {
+	case <-s.serviceEnsured:
+		c.Fatal("service/unit ensured unexpectedly")
+	case <-time.After(coretesting.ShortWait):
+	}
+
+	// An application config change ensures the service again.
+	newConfig := application.ConfigAttributes{
+		"juju-external-hostname":   "exthost",
+		"kubernetes-autoscale-max": 10,
+	}
+	s.applicationGetter.config = newConfig
+	s.sendContainerSpecChange(c)
+	s.podSpecGetter.assertSpecRetrieved(c)
+	select {
+	case <-s.serviceEnsured:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be ensured")
+	}
+	s.serviceBroker.CheckCallNames(c, "EnsureService")
+	c.Assert(s.serviceBroker.Calls()[0].Args[3], jc.DeepEquals, newConfig)
+}
+
 func (s *WorkerSuite) TestInvalidDeploymentChange(c *gc.C) {
 	defer s.setupMocks(c).Finish()
 
@@ -5,6 +5,7 @@ package charmrunner
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 )
@@ -47,3 +48,23 @@ func IsMissingHookError(err error) bool {
 func NewMissingHookError(hookName string) error {
 	return &missingHookError{hookName}
 }
+
+// HookTimedOutError is returned when a hook is killed for running longer
+// than the model's hook timeout.
+type HookTimedOutError struct {
+	HookName string
+	Timeout  time.Duration
+}
+
+func (e *HookTimedOutError) Error() string {
+	return fmt.Sprintf("%s hook timed out after %v", e.HookName, e.Timeout)
+}
+
+func IsHookTimedOutError(err error) bool {
+	_, ok := errors.Cause(err).(*HookTimedOutError)
+	return ok
+}
+
+func NewHookTimedOutError(hookName string, timeout time.Duration) error {
+	return &HookTimedOutError{HookName: hookName, Timeout: timeout}
+}
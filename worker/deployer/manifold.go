@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/api/base"
 	apideployer "github.com/juju/juju/api/deployer"
 	"github.com/juju/juju/cmd/jujud/agent/engine"
+	"github.com/juju/juju/core/machinelock"
 )
 
 // ManifoldConfig defines the names of the manifolds on which a Manifold will depend.
@@ -24,6 +25,10 @@ type ManifoldConfig struct {
 	Clock         clock.Clock
 	Logger        Logger
 
+	// MachineLock is the machine agent's lock, whose wait queue
+	// is shared with the unit agents run in the same process.
+	MachineLock machinelock.Lock
+
 	UnitEngineConfig func() dependency.EngineConfig
 	SetupLogging     func(*loggo.Context, agent.Config)
 	NewDeployContext func(ContextConfig) (Context, error)
@@ -57,6 +62,7 @@ func (config ManifoldConfig) newWorker(a agent.Agent, apiCaller base.APICaller)
 		Agent:            a,
 		Clock:            config.Clock,
 		Logger:           config.Logger,
+		MachineLock:      config.MachineLock,
 		UnitEngineConfig: config.UnitEngineConfig,
 		SetupLogging:     config.SetupLogging,
 		UnitManifolds:    UnitManifolds,
@@ -24,6 +24,8 @@ import (
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/agent/tools"
 	agenterrors "github.com/juju/juju/cmd/jujud/agent/errors"
+	"github.com/juju/juju/core/machinelock"
+	"github.com/juju/juju/service/systemd"
 	jujuversion "github.com/juju/juju/version"
 	jworker "github.com/juju/juju/worker"
 )
@@ -73,6 +75,10 @@ type ContextConfig struct {
 	UnitEngineConfig func() dependency.EngineConfig
 	SetupLogging     func(*loggo.Context, agent.Config)
 	UnitManifolds    func(config UnitManifoldsConfig) dependency.Manifolds
+
+	// MachineLock is optional; if set, the unit agents' machine
+	// locks share its wait queue.
+	MachineLock machinelock.Lock
 }
 
 // Validate ensures all the required values are set.
@@ -114,6 +120,7 @@ func NewNestedContext(config ContextConfig) (*nestedContext, error) {
 			DataDir:          agentConfig.DataDir(),
 			Clock:            config.Clock,
 			Logger:           config.Logger,
+			MachineLock:      config.MachineLock,
 			UnitEngineConfig: config.UnitEngineConfig,
 			UnitManifolds:    config.UnitManifolds,
 			SetupLogging:     config.SetupLogging,
@@ -428,6 +435,13 @@ func (c *nestedContext) RecallUnit(unitName string) error {
 		}
 	}
 
+	// Remove the slice holding the unit's resource limits.
+	if slice := systemd.NewUnitSlice(unitName); slice.Installed() {
+		if err := slice.Remove(); err != nil {
+			c.logger.Warningf("unable to remove resource limits for %q: %v", unitName, err)
+		}
+	}
+
 	// Remove agent directory.
 	agentDir := agent.Dir(c.agentConfig.DataDir(), names.NewUnitTag(unitName))
 	if err := os.RemoveAll(agentDir); err != nil {
@@ -39,6 +39,7 @@ type UnitAgent struct {
 	setupLogging     func(*loggo.Context, agent.Config)
 	unitEngineConfig func() dependency.EngineConfig
 	unitManifolds    func(UnitManifoldsConfig) dependency.Manifolds
+	machineLock      machinelock.Lock
 
 	// Able to disable running units.
 	running bool
@@ -54,6 +55,10 @@ type UnitAgentConfig struct {
 	UnitEngineConfig func() dependency.EngineConfig
 	UnitManifolds    func(UnitManifoldsConfig) dependency.Manifolds
 	SetupLogging     func(*loggo.Context, agent.Config)
+
+	// MachineLock is optional; if set, the unit agent's machine
+	// lock shares its wait queue.
+	MachineLock machinelock.Lock
 }
 
 // Validate ensures all the required values are set.
@@ -109,6 +114,7 @@ func NewUnitAgent(config UnitAgentConfig) (*UnitAgent, error) {
 		setupLogging:     config.SetupLogging,
 		unitEngineConfig: config.UnitEngineConfig,
 		unitManifolds:    config.UnitManifolds,
+		machineLock:      config.MachineLock,
 	}
 	// Update the 'upgradedToVersion' in the agent.conf file if it is
 	// different to the current version.
@@ -141,6 +147,8 @@ func (a *UnitAgent) start() (worker.Worker, error) {
 		Clock:       a.clock,
 		Logger:      loggingContext.GetLogger("juju.machinelock"),
 		LogFilename: agent.MachineLockLogFilename(a.agentConf),
+
+		ShareQueueWith: a.machineLock,
 	})
 	// There will only be an error if the required configuration
 	// values are not passed in.
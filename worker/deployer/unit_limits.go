@@ -0,0 +1,165 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package deployer
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/dependency"
+
+	coreagent "github.com/juju/juju/agent"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/uniter"
+	"github.com/juju/juju/cmd/jujud/agent/engine"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/service/systemd"
+)
+
+// UnitLimitsFacade defines the capabilities the unit limits worker
+// requires from the API.
+type UnitLimitsFacade interface {
+	ResourceLimits() (application.ResourceLimits, error)
+	WatchResourceLimits() (watcher.NotifyWatcher, error)
+}
+
+// UnitSlice defines the systemd slice the unit's processes run in.
+type UnitSlice interface {
+	Installed() bool
+	SetLimits(application.ResourceLimits) error
+	Remove() error
+}
+
+// UnitLimitsConfig holds the dependencies of the unit limits worker.
+type UnitLimitsConfig struct {
+	Facade UnitLimitsFacade
+	Slice  UnitSlice
+	Logger Logger
+}
+
+// Validate returns an error if the config cannot start a unit limits
+// worker.
+func (config UnitLimitsConfig) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Slice == nil {
+		return errors.NotValidf("nil Slice")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	return nil
+}
+
+// NewUnitLimitsWorker returns a worker that keeps the resource limits
+// of the unit's systemd slice in line with its application config.
+// The uniter runs the unit's hooks in the slice while it is installed,
+// so the hooks and the processes they start are held to the limits.
+// Services a charm runs through systemd are started by systemd in their
+// own units, outside the slice, and are not limited.
+func NewUnitLimitsWorker(config UnitLimitsConfig) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w, err := watcher.NewNotifyWorker(watcher.NotifyConfig{
+		Handler: &unitLimitsHandler{config: config},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// unitLimitsHandler implements watcher.NotifyHandler.
+type unitLimitsHandler struct {
+	config  UnitLimitsConfig
+	applied *application.ResourceLimits
+}
+
+// SetUp is part of the watcher.NotifyHandler interface.
+func (h *unitLimitsHandler) SetUp() (watcher.NotifyWatcher, error) {
+	return h.config.Facade.WatchResourceLimits()
+}
+
+// Handle is part of the watcher.NotifyHandler interface.
+func (h *unitLimitsHandler) Handle(_ <-chan struct{}) error {
+	limits, err := h.config.Facade.ResourceLimits()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if h.applied != nil && *h.applied == limits {
+		return nil
+	}
+
+	slice := h.config.Slice
+	if limits.IsZero() {
+		if slice.Installed() {
+			h.config.Logger.Infof("removing resource limits")
+			if err := slice.Remove(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	} else {
+		h.config.Logger.Infof("setting resource limits to %+v", limits)
+		if err := slice.SetLimits(limits); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	h.applied = &limits
+	return nil
+}
+
+// TearDown is part of the watcher.NotifyHandler interface.
+func (h *unitLimitsHandler) TearDown() error {
+	return nil
+}
+
+// UnitLimitsManifoldConfig defines the names of the manifolds on which
+// the unit limits manifold depends.
+type UnitLimitsManifoldConfig struct {
+	AgentName     string
+	APICallerName string
+	Logger        Logger
+
+	// IsSystemd reports whether the machine runs systemd. Resource
+	// limits can't be applied without it.
+	IsSystemd func() bool
+}
+
+// UnitLimitsManifold returns a dependency manifold that runs a unit
+// limits worker for the agent's unit.
+func UnitLimitsManifold(config UnitLimitsManifoldConfig) dependency.Manifold {
+	typedConfig := engine.AgentAPIManifoldConfig{
+		AgentName:     config.AgentName,
+		APICallerName: config.APICallerName,
+	}
+	return engine.AgentAPIManifold(typedConfig, config.start)
+}
+
+func (config UnitLimitsManifoldConfig) start(a coreagent.Agent, apiCaller base.APICaller) (worker.Worker, error) {
+	if !config.IsSystemd() {
+		config.Logger.Debugf("systemd not running, unit resource limits not supported")
+		return nil, dependency.ErrUninstall
+	}
+	unitTag, ok := a.CurrentConfig().Tag().(names.UnitTag)
+	if !ok {
+		return nil, errors.Errorf("expected a unit tag, got %v", a.CurrentConfig().Tag())
+	}
+	st := uniter.NewState(apiCaller, unitTag)
+	if st.BestAPIVersion() < 21 {
+		config.Logger.Debugf("controller does not support unit resource limits")
+		return nil, dependency.ErrUninstall
+	}
+	unit, err := st.Unit(unitTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewUnitLimitsWorker(UnitLimitsConfig{
+		Facade: unit,
+		Slice:  systemd.NewUnitSlice(unitTag.Id()),
+		Logger: config.Logger,
+	})
+}
@@ -0,0 +1,153 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package deployer_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/deployer"
+)
+
+type UnitLimitsSuite struct {
+	testing.IsolationSuite
+
+	changes chan struct{}
+	facade  *fakeUnitLimitsFacade
+	slice   *fakeUnitSlice
+}
+
+var _ = gc.Suite(&UnitLimitsSuite{})
+
+func (s *UnitLimitsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.changes = make(chan struct{})
+	s.facade = &fakeUnitLimitsFacade{
+		changes: s.changes,
+		limits:  make(chan application.ResourceLimits, 10),
+	}
+	s.slice = &fakeUnitSlice{done: make(chan struct{}, 10)}
+}
+
+func (s *UnitLimitsSuite) config() deployer.UnitLimitsConfig {
+	return deployer.UnitLimitsConfig{
+		Facade: s.facade,
+		Slice:  s.slice,
+		Logger: loggo.GetLogger("test"),
+	}
+}
+
+func (s *UnitLimitsSuite) sendChange(c *gc.C, limits application.ResourceLimits) {
+	s.facade.limits <- limits
+	select {
+	case s.changes <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out sending change")
+	}
+}
+
+func (s *UnitLimitsSuite) waitSlice(c *gc.C) {
+	select {
+	case <-s.slice.done:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for slice change")
+	}
+}
+
+func (s *UnitLimitsSuite) TestValidate(c *gc.C) {
+	config := s.config()
+	config.Facade = nil
+	_, err := deployer.NewUnitLimitsWorker(config)
+	c.Check(err, gc.ErrorMatches, "nil Facade not valid")
+
+	config = s.config()
+	config.Slice = nil
+	_, err = deployer.NewUnitLimitsWorker(config)
+	c.Check(err, gc.ErrorMatches, "nil Slice not valid")
+}
+
+func (s *UnitLimitsSuite) TestSetsAndRemovesLimits(c *gc.C) {
+	w, err := deployer.NewUnitLimitsWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.slice.installed = true
+	s.sendChange(c, application.ResourceLimits{MemoryMB: 512})
+	s.waitSlice(c)
+	s.slice.CheckCall(c, 0, "SetLimits", application.ResourceLimits{MemoryMB: 512})
+
+	// Unchanged limits aren't applied again.
+	s.sendChange(c, application.ResourceLimits{MemoryMB: 512})
+	s.sendChange(c, application.ResourceLimits{})
+	s.waitSlice(c)
+	s.slice.CheckCallNames(c, "SetLimits", "Installed", "Remove")
+}
+
+func (s *UnitLimitsSuite) TestNoLimitsNoSlice(c *gc.C) {
+	w, err := deployer.NewUnitLimitsWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.sendChange(c, application.ResourceLimits{})
+	s.sendChange(c, application.ResourceLimits{})
+	workertest.CleanKill(c, w)
+	s.slice.CheckCallNames(c, "Installed")
+}
+
+func (s *UnitLimitsSuite) TestSetLimitsError(c *gc.C) {
+	s.slice.SetErrors(errors.New("boom"))
+	w, err := deployer.NewUnitLimitsWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	s.sendChange(c, application.ResourceLimits{Tasks: 10})
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type fakeUnitLimitsFacade struct {
+	changes chan struct{}
+	limits  chan application.ResourceLimits
+}
+
+func (f *fakeUnitLimitsFacade) ResourceLimits() (application.ResourceLimits, error) {
+	return <-f.limits, nil
+}
+
+func (f *fakeUnitLimitsFacade) WatchResourceLimits() (watcher.NotifyWatcher, error) {
+	return watchertest.NewMockNotifyWatcher(f.changes), nil
+}
+
+type fakeUnitSlice struct {
+	testing.Stub
+	installed bool
+	done      chan struct{}
+}
+
+func (f *fakeUnitSlice) Installed() bool {
+	f.MethodCall(f, "Installed")
+	return f.installed
+}
+
+func (f *fakeUnitSlice) SetLimits(limits application.ResourceLimits) error {
+	f.MethodCall(f, "SetLimits", limits)
+	f.done <- struct{}{}
+	return f.NextErr()
+}
+
+func (f *fakeUnitSlice) Remove() error {
+	f.MethodCall(f, "Remove")
+	f.done <- struct{}{}
+	return f.NextErr()
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/cmd/jujud/agent/engine"
 	"github.com/juju/juju/core/machinelock"
 	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/service/systemd"
 	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/agent"
 	"github.com/juju/juju/worker/apiaddressupdater"
@@ -213,6 +214,15 @@ func UnitManifolds(config UnitManifoldsConfig) dependency.Manifolds {
 			Logger:        config.LoggingContext.GetLogger("juju.worker.retrystrategy"),
 		})),
 
+		// The unit limits worker keeps the resource limits of the
+		// unit's systemd slice in line with its application config.
+		unitLimitsName: ifNotMigrating(UnitLimitsManifold(UnitLimitsManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			IsSystemd:     systemd.IsRunning,
+			Logger:        config.LoggingContext.GetLogger("juju.worker.deployer.unitlimits"),
+		})),
+
 		// The uniter installs charms; manages the unit's presence in its
 		// relations; creates subordinate units; runs all the hooks; sends
 		// metrics; etc etc etc. We expect to break it up further in the
@@ -304,6 +314,7 @@ const (
 	leadershipTrackerName = "leadership-tracker"
 	hookRetryStrategyName = "hook-retry-strategy"
 	uniterName            = "uniter"
+	unitLimitsName        = "unit-limits"
 	upgraderName          = "upgrader"
 
 	metricSpoolName   = "metric-spool"
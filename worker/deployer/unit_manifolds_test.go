@@ -61,6 +61,7 @@ func (s *ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		"migration-inactive-flag",
 		"migration-minion",
 		"uniter",
+		"unit-limits",
 		"upgrader",
 	}
 	keys := make([]string, 0, len(manifolds))
@@ -218,6 +219,14 @@ var expectedUnitManifoldsWithDependencies = map[string][]string{
 		"migration-inactive-flag",
 	},
 
+	"unit-limits": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"migration-fortress",
+		"migration-inactive-flag",
+	},
+
 	"upgrader": {
 		"agent",
 		"api-caller",
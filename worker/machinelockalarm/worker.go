@@ -0,0 +1,205 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinelockalarm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/worker/v2/catacomb"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/machinelock"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/environs/config"
+)
+
+// alarmDataKey is the key of the machine status data that holds the
+// alarm while it is raised.
+const alarmDataKey = "machine-lock-alarm"
+
+// checkInterval is how often the holder of the machine lock is checked
+// while the alarm is configured.
+const checkInterval = 30 * time.Second
+
+// Logger represents the methods used by the worker to log details.
+type Logger interface {
+	Infof(string, ...interface{})
+	Warningf(string, ...interface{})
+}
+
+// Lock reports which worker holds the machine lock.
+type Lock interface {
+	Holder() (machinelock.HolderInfo, bool)
+}
+
+// ModelConfigFacade provides the model config, which holds the alarm
+// threshold.
+type ModelConfigFacade interface {
+	ModelConfig() (*config.Config, error)
+	WatchForModelConfigChanges() (watcher.NotifyWatcher, error)
+}
+
+// Machine is the machine whose status shows the alarm.
+type Machine interface {
+	Status() (params.StatusResult, error)
+	SetStatus(machineStatus status.Status, info string, data map[string]interface{}) error
+}
+
+// Config holds the dependencies of the machine lock alarm worker.
+type Config struct {
+	Lock    Lock
+	Facade  ModelConfigFacade
+	Machine Machine
+	Clock   clock.Clock
+	Logger  Logger
+}
+
+// Validate returns an error if the config cannot start a worker.
+func (config Config) Validate() error {
+	if config.Lock == nil {
+		return errors.NotValidf("nil Lock")
+	}
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Machine == nil {
+		return errors.NotValidf("nil Machine")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	return nil
+}
+
+// Worker sets the machine's status message while a worker has held the
+// machine lock for longer than the model's machine-lock-hold-alarm, as
+// every unit on the machine is blocked until it's released. The status
+// itself is left alone, and the previous message is restored once the
+// alarm clears.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	threshold time.Duration
+	alarm     string
+	previous  params.StatusResult
+}
+
+// NewWorker returns a worker that raises the machine lock hold alarm.
+func NewWorker(config Config) (*Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+func (w *Worker) loop() error {
+	configWatcher, err := w.config.Facade.WatchForModelConfigChanges()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(configWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	var check <-chan time.Time
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-configWatcher.Changes():
+			if !ok {
+				return errors.New("model config watcher closed")
+			}
+			modelConfig, err := w.config.Facade.ModelConfig()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			w.threshold = modelConfig.MachineLockHoldAlarm()
+		case <-check:
+		}
+		if err := w.check(); err != nil {
+			return errors.Trace(err)
+		}
+		check = nil
+		if w.threshold > 0 {
+			check = w.config.Clock.After(checkInterval)
+		}
+	}
+}
+
+// check sets the alarm if the lock has been held for too long, and
+// clears it once the lock is released.
+func (w *Worker) check() error {
+	var alarm string
+	if w.threshold > 0 {
+		if holder, ok := w.config.Lock.Holder(); ok && holder.Held >= w.threshold {
+			alarm = fmt.Sprintf("machine lock held by %s for over %v", holder, w.threshold)
+		}
+	}
+	if alarm == w.alarm {
+		return nil
+	}
+	current, err := w.config.Machine.Status()
+	if err != nil {
+		return errors.Annotate(err, "getting machine status")
+	}
+	if alarm == "" {
+		w.config.Logger.Infof("machine lock hold alarm cleared")
+		w.alarm = ""
+		// Only restore the previous message if nothing else has set
+		// the machine status while the alarm was raised.
+		if current.Data[alarmDataKey] != nil {
+			if err := w.setStatus(w.previous.Status, w.previous.Info, w.previous.Data); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		return nil
+	}
+
+	w.config.Logger.Warningf("%s", alarm)
+	if w.alarm == "" || current.Data[alarmDataKey] == nil {
+		w.previous = current
+	}
+	data := make(map[string]interface{})
+	for k, v := range w.previous.Data {
+		data[k] = v
+	}
+	data[alarmDataKey] = alarm
+	if err := w.setStatus(current.Status, alarm, data); err != nil {
+		return errors.Trace(err)
+	}
+	w.alarm = alarm
+	return nil
+}
+
+func (w *Worker) setStatus(machineStatus, info string, data map[string]interface{}) error {
+	err := w.config.Machine.SetStatus(status.Status(machineStatus), info, data)
+	return errors.Annotate(err, "setting machine status")
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
@@ -0,0 +1,248 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinelockalarm_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/machinelock"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	"github.com/juju/juju/environs/config"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/machinelockalarm"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	clock   *testclock.Clock
+	lock    *fakeLock
+	facade  *fakeFacade
+	machine *fakeMachine
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Now())
+	s.lock = &fakeLock{}
+	s.facade = &fakeFacade{changes: make(chan struct{})}
+	s.machine = &fakeMachine{
+		current:  params.StatusResult{Status: status.Started.String()},
+		statuses: make(chan string, 10),
+	}
+}
+
+func (s *WorkerSuite) config() machinelockalarm.Config {
+	return machinelockalarm.Config{
+		Lock:    s.lock,
+		Facade:  s.facade,
+		Machine: s.machine,
+		Clock:   s.clock,
+		Logger:  loggo.GetLogger("test"),
+	}
+}
+
+func (s *WorkerSuite) setAlarm(c *gc.C, alarm string) {
+	s.facade.setConfig(coretesting.CustomModelConfig(c, coretesting.Attrs{
+		"machine-lock-hold-alarm": alarm,
+	}))
+	select {
+	case s.facade.changes <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out sending config change")
+	}
+}
+
+func (s *WorkerSuite) waitStatus(c *gc.C, expected string) {
+	select {
+	case info := <-s.machine.statuses:
+		c.Assert(info, gc.Equals, expected)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for machine status")
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	config := s.config()
+	config.Lock = nil
+	_, err := machinelockalarm.NewWorker(config)
+	c.Check(err, gc.ErrorMatches, "nil Lock not valid")
+
+	config = s.config()
+	config.Machine = nil
+	_, err = machinelockalarm.NewWorker(config)
+	c.Check(err, gc.ErrorMatches, "nil Machine not valid")
+}
+
+func (s *WorkerSuite) TestAlarmSetAndCleared(c *gc.C) {
+	s.lock.setHolder(&machinelock.HolderInfo{
+		Agent:   "unit-mysql-0",
+		Worker:  "uniter",
+		Comment: "run install hook",
+		Held:    2 * time.Hour,
+	})
+	w, err := machinelockalarm.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.setAlarm(c, "1h")
+	s.waitStatus(c, "started: machine lock held by unit-mysql-0: uniter (run install hook) for over 1h0m0s")
+
+	s.lock.setHolder(nil)
+	c.Assert(s.clock.WaitAdvance(30*time.Second, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.waitStatus(c, "started: ")
+}
+
+func (s *WorkerSuite) TestAlarmClearedWhenDisabled(c *gc.C) {
+	s.lock.setHolder(&machinelock.HolderInfo{Agent: "machine-0", Worker: "reboot", Held: time.Hour})
+	w, err := machinelockalarm.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.setAlarm(c, "10m")
+	s.waitStatus(c, "started: machine lock held by machine-0: reboot for over 10m0s")
+	s.setAlarm(c, "")
+	s.waitStatus(c, "started: ")
+}
+
+func (s *WorkerSuite) TestAlarmKeepsMachineStatus(c *gc.C) {
+	s.machine.setCurrent(params.StatusResult{
+		Status: status.Stopped.String(),
+		Info:   "agent stopped",
+		Data:   map[string]interface{}{"foo": "bar"},
+	})
+	s.lock.setHolder(&machinelock.HolderInfo{Agent: "machine-0", Worker: "reboot", Held: time.Hour})
+	w, err := machinelockalarm.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.setAlarm(c, "10m")
+	alarm := "machine lock held by machine-0: reboot for over 10m0s"
+	s.waitStatus(c, "stopped: "+alarm)
+	current, err := s.machine.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(current.Data, jc.DeepEquals, map[string]interface{}{
+		"foo":                "bar",
+		"machine-lock-alarm": alarm,
+	})
+
+	s.setAlarm(c, "")
+	s.waitStatus(c, "stopped: agent stopped")
+	current, err = s.machine.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(current.Data, jc.DeepEquals, map[string]interface{}{"foo": "bar"})
+}
+
+func (s *WorkerSuite) TestAlarmClearedKeepsNewerStatus(c *gc.C) {
+	s.lock.setHolder(&machinelock.HolderInfo{Agent: "machine-0", Worker: "reboot", Held: time.Hour})
+	w, err := machinelockalarm.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.setAlarm(c, "10m")
+	s.waitStatus(c, "started: machine lock held by machine-0: reboot for over 10m0s")
+
+	// Something else sets the machine status while the alarm is raised.
+	s.machine.setCurrent(params.StatusResult{Status: status.Stopped.String(), Info: "agent stopped"})
+	s.setAlarm(c, "")
+	workertest.CleanKill(c, w)
+	c.Assert(s.machine.statuses, gc.HasLen, 0)
+	current, err := s.machine.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(current.Info, gc.Equals, "agent stopped")
+}
+
+func (s *WorkerSuite) TestNoAlarmUnderThreshold(c *gc.C) {
+	s.lock.setHolder(&machinelock.HolderInfo{Agent: "machine-0", Worker: "reboot", Held: time.Minute})
+	w, err := machinelockalarm.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	s.setAlarm(c, "10m")
+	c.Assert(s.clock.WaitAdvance(30*time.Second, coretesting.LongWait, 1), jc.ErrorIsNil)
+	c.Assert(s.clock.WaitAdvance(30*time.Second, coretesting.LongWait, 1), jc.ErrorIsNil)
+	workertest.CleanKill(c, w)
+	c.Assert(s.machine.statuses, gc.HasLen, 0)
+}
+
+type fakeLock struct {
+	mu     sync.Mutex
+	holder *machinelock.HolderInfo
+}
+
+func (f *fakeLock) setHolder(holder *machinelock.HolderInfo) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.holder = holder
+}
+
+func (f *fakeLock) Holder() (machinelock.HolderInfo, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.holder == nil {
+		return machinelock.HolderInfo{}, false
+	}
+	return *f.holder, true
+}
+
+type fakeFacade struct {
+	mu      sync.Mutex
+	config  *config.Config
+	changes chan struct{}
+}
+
+func (f *fakeFacade) setConfig(config *config.Config) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.config = config
+}
+
+func (f *fakeFacade) ModelConfig() (*config.Config, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.config, nil
+}
+
+func (f *fakeFacade) WatchForModelConfigChanges() (watcher.NotifyWatcher, error) {
+	return watchertest.NewMockNotifyWatcher(f.changes), nil
+}
+
+type fakeMachine struct {
+	mu       sync.Mutex
+	current  params.StatusResult
+	statuses chan string
+}
+
+func (f *fakeMachine) setCurrent(current params.StatusResult) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.current = current
+}
+
+func (f *fakeMachine) Status() (params.StatusResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.current, nil
+}
+
+func (f *fakeMachine) SetStatus(machineStatus status.Status, info string, data map[string]interface{}) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.current = params.StatusResult{Status: machineStatus.String(), Info: info, Data: data}
+	f.statuses <- string(machineStatus) + ": " + info
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"math/rand"
 	"path"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -108,6 +109,12 @@ func (ctx *limitedContext) HasExecutionSetUnitStatus() bool { return false }
 // ResetExecutionSetUnitStatus implements runner.Context.
 func (ctx *limitedContext) ResetExecutionSetUnitStatus() {}
 
+// HookTimeout implements runner.Context.
+func (ctx *limitedContext) HookTimeout() time.Duration { return 0 }
+
+// TraceUntil implements runner.Context.
+func (ctx *limitedContext) TraceUntil() (time.Time, bool) { return time.Time{}, false }
+
 // Id implements runner.Context.
 func (ctx *limitedContext) Id() string { return ctx.id }
 
@@ -117,6 +117,12 @@ func (ctx *hookContext) HasExecutionSetUnitStatus() bool { return false }
 // ResetExecutionSetUnitStatus implements runner.Context.
 func (ctx *hookContext) ResetExecutionSetUnitStatus() {}
 
+// HookTimeout implements runner.Context.
+func (ctx *hookContext) HookTimeout() time.Duration { return 0 }
+
+// TraceUntil implements runner.Context.
+func (ctx *hookContext) TraceUntil() (time.Time, bool) { return time.Time{}, false }
+
 // Id implements runner.Context.
 func (ctx *hookContext) Id() string { return ctx.id }
 
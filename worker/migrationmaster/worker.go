@@ -11,6 +11,7 @@ import (
 
 	"github.com/juju/charm/v7"
 	"github.com/juju/clock"
+	"github.com/juju/description/v2"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/names/v4"
@@ -223,7 +224,7 @@ func (w *Worker) run() error {
 		case coremigration.QUIESCE:
 			phase, err = w.doQUIESCE(status)
 		case coremigration.IMPORT:
-			phase, err = w.doIMPORT(status.TargetInfo, status.ModelUUID)
+			phase, err = w.doIMPORT(status)
 		case coremigration.PROCESSRELATIONS:
 			phase, err = w.doPROCESSRELATIONS(status)
 		case coremigration.VALIDATION:
@@ -231,11 +232,13 @@ func (w *Worker) run() error {
 		case coremigration.SUCCESS:
 			phase, err = w.doSUCCESS(status)
 		case coremigration.LOGTRANSFER:
-			phase, err = w.doLOGTRANSFER(status.TargetInfo, status.ModelUUID)
+			phase, err = w.doLOGTRANSFER(status)
+		case coremigration.DORMANT:
+			phase, err = w.doDORMANT(status)
 		case coremigration.REAP:
 			phase, err = w.doREAP()
 		case coremigration.ABORT:
-			phase, err = w.doABORT(status.TargetInfo, status.ModelUUID)
+			phase, err = w.doABORT(status)
 		default:
 			return errors.Errorf("unknown phase: %v [%d]", phase.String(), phase)
 		}
@@ -258,6 +261,7 @@ func (w *Worker) run() error {
 			return errors.Annotate(err, "failed to set phase")
 		}
 		status.Phase = phase
+		status.PhaseChangedTime = w.config.Clock.Now()
 
 		if modelHasMigrated(phase) {
 			return ErrMigrated
@@ -353,12 +357,46 @@ func (w *Worker) prechecks(status coremigration.MigrationStatus) error {
 	}
 
 	targetClient := migrationtarget.NewClient(conn)
-	err = targetClient.Prechecks(model)
+	if status.Revert {
+		err = w.revertPrechecks(targetClient, model)
+	} else {
+		err = targetClient.Prechecks(model)
+	}
 	return errors.Annotate(err, "target prechecks failed")
 }
 
-func (w *Worker) doIMPORT(targetInfo coremigration.TargetInfo, modelUUID string) (coremigration.Phase, error) {
-	err := w.transferModel(targetInfo, modelUUID)
+// revertPrechecks checks that the target controller is keeping the
+// model and that the kept model hasn't diverged from this one, since
+// reverting the migration would lose any changes made since.
+func (w *Worker) revertPrechecks(targetClient *migrationtarget.Client, model coremigration.ModelInfo) error {
+	serialized, err := w.config.Facade.Export()
+	if err != nil {
+		return errors.Annotate(err, "model export failed")
+	}
+	exported, err := description.Deserialize(serialized.Bytes)
+	if err != nil {
+		return errors.Annotate(err, "reading exported model")
+	}
+	fingerprint, err := migration.ModelFingerprint(exported)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(targetClient.RevertPrechecks(model, fingerprint))
+}
+
+func (w *Worker) doIMPORT(status coremigration.MigrationStatus) (coremigration.Phase, error) {
+	if status.Revert {
+		// The target controller still has the model from when it
+		// was migrated away, so there's nothing to transfer. The
+		// model may only be moved back if it matches the kept copy.
+		if err := w.checkKeptModel(status); err != nil {
+			w.setErrorStatus("model kept by target controller can't be reverted to, %v", err)
+			return coremigration.ABORT, nil
+		}
+		w.setInfoStatus("reverting, model is kept by target controller")
+		return coremigration.PROCESSRELATIONS, nil
+	}
+	err := w.transferModel(status.TargetInfo, status.ModelUUID)
 	if err != nil {
 		w.setErrorStatus("model data transfer failed, %v", err)
 		return coremigration.ABORT, nil
@@ -366,6 +404,19 @@ func (w *Worker) doIMPORT(targetInfo coremigration.TargetInfo, modelUUID string)
 	return coremigration.PROCESSRELATIONS, nil
 }
 
+func (w *Worker) checkKeptModel(status coremigration.MigrationStatus) error {
+	model, err := w.config.Facade.ModelInfo()
+	if err != nil {
+		return errors.Annotate(err, "failed to obtain model info")
+	}
+	conn, err := w.openAPIConn(status.TargetInfo)
+	if err != nil {
+		return errors.Annotate(err, "failed to connect to target controller")
+	}
+	defer conn.Close()
+	return w.revertPrechecks(migrationtarget.NewClient(conn), model)
+}
+
 type uploadWrapper struct {
 	client    *migrationtarget.Client
 	modelUUID string
@@ -484,7 +535,7 @@ func (w *Worker) doVALIDATION(status coremigration.MigrationStatus) (coremigrati
 
 	// Once all agents have validated, activate the model in the
 	// target controller.
-	err = w.activateModel(client, status.ModelUUID)
+	err = w.activateModel(client, status)
 	if err != nil {
 		w.setErrorStatus("model activation failed, %v", err)
 		return coremigration.ABORT, nil
@@ -512,9 +563,12 @@ func (w *Worker) checkTargetMachines(targetClient *migrationtarget.Client, model
 	return true, nil
 }
 
-func (w *Worker) activateModel(targetClient *migrationtarget.Client, modelUUID string) error {
+func (w *Worker) activateModel(targetClient *migrationtarget.Client, status coremigration.MigrationStatus) error {
 	w.setInfoStatus("activating model in target controller")
-	return errors.Trace(targetClient.Activate(modelUUID))
+	if status.Revert {
+		return errors.Trace(targetClient.Reactivate(status.ModelUUID))
+	}
+	return errors.Trace(targetClient.Activate(status.ModelUUID))
 }
 
 func (w *Worker) doSUCCESS(status coremigration.MigrationStatus) (coremigration.Phase, error) {
@@ -545,11 +599,14 @@ func (w *Worker) transferResources(targetInfo coremigration.TargetInfo, modelUUI
 	return errors.Trace(err)
 }
 
-func (w *Worker) doLOGTRANSFER(targetInfo coremigration.TargetInfo, modelUUID string) (coremigration.Phase, error) {
-	err := w.transferLogs(targetInfo, modelUUID)
+func (w *Worker) doLOGTRANSFER(status coremigration.MigrationStatus) (coremigration.Phase, error) {
+	err := w.transferLogs(status.TargetInfo, status.ModelUUID)
 	if err != nil {
 		return coremigration.UNKNOWN, errors.Trace(err)
 	}
+	if status.KeepSource > 0 {
+		return coremigration.DORMANT, nil
+	}
 	return coremigration.REAP, nil
 }
 
@@ -628,6 +685,46 @@ func (w *Worker) transferLogs(targetInfo coremigration.TargetInfo, modelUUID str
 	}
 }
 
+func (w *Worker) doDORMANT(status coremigration.MigrationStatus) (coremigration.Phase, error) {
+	// The model stays import-locked, with its agents talking to the
+	// target controller, until either the migration is reverted from
+	// the target controller or the keep-source window closes.
+	reapTime := status.PhaseChangedTime.Add(status.KeepSource)
+	w.setInfoStatus("successful, model kept on source controller until %s so the migration can be reverted",
+		reapTime.UTC().Format(time.RFC3339))
+
+	watcher, err := w.config.Facade.Watch()
+	if err != nil {
+		return coremigration.UNKNOWN, errors.Annotate(err, "watching for migration")
+	}
+	if err := w.catacomb.Add(watcher); err != nil {
+		return coremigration.UNKNOWN, errors.Trace(err)
+	}
+	defer watcher.Kill()
+
+	clk := w.config.Clock
+	timeout := clk.After(reapTime.Sub(clk.Now()))
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return coremigration.UNKNOWN, w.catacomb.ErrDying()
+		case <-timeout:
+			return coremigration.REAP, nil
+		case <-watcher.Changes():
+			current, err := w.config.Facade.MigrationStatus()
+			if err != nil {
+				return coremigration.UNKNOWN, errors.Annotate(err, "retrieving migration status")
+			}
+			if current.MigrationId == status.MigrationId && current.Phase == coremigration.REVERTED {
+				// The model's agents are being moved back, so the
+				// model is active on this controller again.
+				w.logger.Infof("migration reverted, model is no longer dormant")
+				return coremigration.UNKNOWN, ErrInactive
+			}
+		}
+	}
+}
+
 func (w *Worker) doREAP() (coremigration.Phase, error) {
 	w.setInfoStatus("successful, removing model from source controller")
 	// NOTE(babbageclunk): Calling Reap will set the migration phase
@@ -642,9 +739,15 @@ func (w *Worker) doREAP() (coremigration.Phase, error) {
 	return coremigration.DONE, nil
 }
 
-func (w *Worker) doABORT(targetInfo coremigration.TargetInfo, modelUUID string) (coremigration.Phase, error) {
+func (w *Worker) doABORT(status coremigration.MigrationStatus) (coremigration.Phase, error) {
+	if status.Revert {
+		// The model kept by the target controller must stay there,
+		// dormant, so that the revert can be attempted again.
+		w.setInfoStatus("aborted revert: %s", w.lastFailure)
+		return coremigration.ABORTDONE, nil
+	}
 	w.setInfoStatus("aborted, removing model from target controller: %s", w.lastFailure)
-	if err := w.removeImportedModel(targetInfo, modelUUID); err != nil {
+	if err := w.removeImportedModel(status.TargetInfo, status.ModelUUID); err != nil {
 		// This isn't fatal. Removing the imported model is a best
 		// efforts attempt so just report the error and proceed.
 		w.logger.Warningf("failed to remove model from target controller, %v", err)
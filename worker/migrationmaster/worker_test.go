@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/juju/clock/testclock"
+	"github.com/juju/description/v2"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/names/v4"
@@ -51,7 +52,8 @@ type Suite struct {
 var _ = gc.Suite(&Suite{})
 
 var (
-	fakeModelBytes      = []byte("model")
+	fakeModel           = newFakeModel()
+	fakeModelBytes      = mustSerialize(fakeModel)
 	targetControllerTag = names.NewControllerTag("controller-uuid")
 	modelUUID           = "model-uuid"
 	modelTag            = names.NewModelTag(modelUUID)
@@ -142,6 +144,26 @@ var (
 		}}},
 		apiCloseCall,
 	}
+	revertPrechecksCall = jujutesting.StubCall{
+		"MigrationTarget.RevertPrechecks",
+		[]interface{}{params.RevertPrechecksArgs{
+			Model: params.MigrationModelInfo{
+				UUID:         modelUUID,
+				Name:         modelName,
+				OwnerTag:     ownerTag.String(),
+				AgentVersion: modelVersion,
+			},
+			Fingerprint: mustFingerprint(fakeModel),
+		}},
+	}
+	revertPrechecksCalls = []jujutesting.StubCall{
+		{"facade.Prechecks", nil},
+		{"facade.ModelInfo", nil},
+		apiOpenControllerCall,
+		{"facade.Export", nil},
+		revertPrechecksCall,
+		apiCloseCall,
+	}
 	abortCalls = []jujutesting.StubCall{
 		{"facade.SetPhase", []interface{}{coremigration.ABORT}},
 		apiOpenControllerCall,
@@ -158,15 +180,38 @@ var (
 	}}
 )
 
+func newFakeModel() description.Model {
+	model := description.NewModel(description.ModelArgs{Owner: names.NewUserTag("owner")})
+	model.SetStatus(description.StatusArgs{Value: "available"})
+	return model
+}
+
+func mustSerialize(model description.Model) []byte {
+	bytes, err := description.Serialize(model)
+	if err != nil {
+		panic(err)
+	}
+	return bytes
+}
+
+func mustFingerprint(model description.Model) string {
+	fingerprint, err := migration.ModelFingerprint(model)
+	if err != nil {
+		panic(err)
+	}
+	return fingerprint
+}
+
 func (s *Suite) SetUpTest(c *gc.C) {
 	s.BaseSuite.SetUpTest(c)
 
 	s.clock = testclock.NewClock(time.Now())
 	s.stub = new(jujutesting.Stub)
 	s.connection = &stubConnection{
-		stub:          s.stub,
-		controllerTag: targetControllerTag,
-		logStream:     &mockStream{},
+		stub:              s.stub,
+		bestFacadeVersion: 1,
+		controllerTag:     targetControllerTag,
+		logStream:         &mockStream{},
 	}
 	s.connectionErr = nil
 
@@ -292,6 +337,173 @@ func (s *Suite) TestSuccessfulMigration(c *gc.C) {
 	)
 }
 
+func (s *Suite) TestKeepSourceReapsAfterWindow(c *gc.C) {
+	status := s.makeStatus(coremigration.LOGTRANSFER)
+	status.KeepSource = time.Hour
+	s.facade.queueStatus(status)
+
+	worker, err := migrationmaster.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, worker)
+
+	// Wait for both the log transfer progress timer and the
+	// keep-source timer before moving time past the window.
+	err = s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.Equals, migrationmaster.ErrMigrated)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			apiOpenControllerCall,
+			latestLogTimeCall,
+			{"StreamModelLog", []interface{}{time.Time{}}},
+			openDestLogStreamCall,
+			{"facade.SetPhase", []interface{}{coremigration.DORMANT}},
+
+			// DORMANT
+			{"facade.Watch", nil},
+			{"facade.SetPhase", []interface{}{coremigration.REAP}},
+
+			// REAP
+			{"facade.Reap", nil},
+			{"facade.SetPhase", []interface{}{coremigration.DONE}},
+		},
+	))
+	c.Check(s.facade.statuses[len(s.facade.statuses)-2], gc.Matches,
+		"successful, model kept on source controller until .* so the migration can be reverted")
+}
+
+func (s *Suite) TestDormantModelReverted(c *gc.C) {
+	status := s.makeStatus(coremigration.DORMANT)
+	status.KeepSource = time.Hour
+	s.facade.queueStatus(status)
+	status.Phase = coremigration.REVERTED
+	s.facade.queueStatus(status)
+
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{"facade.Watch", nil},
+			{"facade.MigrationStatus", nil},
+		},
+	))
+}
+
+func (s *Suite) TestRevertMigration(c *gc.C) {
+	status := s.makeStatus(coremigration.QUIESCE)
+	status.Revert = true
+	s.facade.queueStatus(status)
+	s.facade.queueMinionReports(makeMinionReports(coremigration.QUIESCE))
+	s.facade.queueMinionReports(makeMinionReports(coremigration.VALIDATION))
+	s.facade.queueMinionReports(makeMinionReports(coremigration.SUCCESS))
+	s.connection.bestFacadeVersion = 3
+
+	s.checkWorkerReturns(c, migrationmaster.ErrMigrated)
+
+	// The model is only exported to check it against the copy kept by
+	// the target controller, and is reactivated there rather than
+	// imported and activated.
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+
+		// QUIESCE
+		revertPrechecksCalls,
+		[]jujutesting.StubCall{
+			{"facade.WatchMinionReports", nil},
+			{"facade.MinionReports", nil},
+		},
+		revertPrechecksCalls,
+		[]jujutesting.StubCall{
+			{"facade.SetPhase", []interface{}{coremigration.IMPORT}},
+
+			// IMPORT
+			{"facade.ModelInfo", nil},
+			apiOpenControllerCall,
+			{"facade.Export", nil},
+			revertPrechecksCall,
+			apiCloseCall,
+			{"facade.SetPhase", []interface{}{coremigration.PROCESSRELATIONS}},
+
+			// PROCESSRELATIONS
+			{"facade.ProcessRelations", []interface{}{""}},
+			{"facade.SetPhase", []interface{}{coremigration.VALIDATION}},
+
+			// VALIDATION
+			{"facade.WatchMinionReports", nil},
+			{"facade.MinionReports", nil},
+			apiOpenControllerCall,
+			checkMachinesCall,
+			{"MigrationTarget.Reactivate", []interface{}{
+				params.ModelArgs{ModelTag: modelTag.String()},
+			}},
+			apiCloseCall,
+			{"facade.SetPhase", []interface{}{coremigration.SUCCESS}},
+
+			// SUCCESS
+			{"facade.WatchMinionReports", nil},
+			{"facade.MinionReports", nil},
+			apiOpenControllerCall,
+			adoptResourcesCall,
+			apiCloseCall,
+			{"facade.SetPhase", []interface{}{coremigration.LOGTRANSFER}},
+
+			// LOGTRANSFER
+			apiOpenControllerCall,
+			latestLogTimeCall,
+			{"StreamModelLog", []interface{}{time.Time{}}},
+			openDestLogStreamCall,
+			{"facade.SetPhase", []interface{}{coremigration.REAP}},
+
+			// REAP
+			{"facade.Reap", nil},
+			{"facade.SetPhase", []interface{}{coremigration.DONE}},
+		}),
+	)
+}
+
+func (s *Suite) TestRevertAbortKeepsTargetModel(c *gc.C) {
+	status := s.makeStatus(coremigration.QUIESCE)
+	status.Revert = true
+	s.facade.queueStatus(status)
+	s.connection.bestFacadeVersion = 3
+	s.connection.prechecksErr = errors.New("boom")
+
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		revertPrechecksCalls,
+		[]jujutesting.StubCall{
+			{"facade.SetPhase", []interface{}{coremigration.ABORT}},
+			{"facade.SetPhase", []interface{}{coremigration.ABORTDONE}},
+		},
+	))
+}
+
+func (s *Suite) TestRevertExportFailure(c *gc.C) {
+	status := s.makeStatus(coremigration.QUIESCE)
+	status.Revert = true
+	s.facade.queueStatus(status)
+	s.facade.exportErr = errors.New("boom")
+	s.connection.bestFacadeVersion = 3
+
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{"facade.Prechecks", nil},
+			{"facade.ModelInfo", nil},
+			apiOpenControllerCall,
+			{"facade.Export", nil},
+			apiCloseCall,
+			{"facade.SetPhase", []interface{}{coremigration.ABORT}},
+			{"facade.SetPhase", []interface{}{coremigration.ABORTDONE}},
+		},
+	))
+}
+
 func (s *Suite) TestMigrationResume(c *gc.C) {
 	// Test that a partially complete migration can be resumed.
 	s.facade.queueStatus(s.makeStatus(coremigration.SUCCESS))
@@ -1276,6 +1488,8 @@ type stubConnection struct {
 	processRelationsErr error
 	controllerTag       names.ControllerTag
 
+	bestFacadeVersion int
+
 	streamErr error
 	logStream *mockStream
 
@@ -1287,7 +1501,7 @@ type stubConnection struct {
 }
 
 func (c *stubConnection) BestFacadeVersion(string) int {
-	return 1
+	return c.bestFacadeVersion
 }
 
 func (c *stubConnection) APICall(objType string, version int, id, request string, args, response interface{}) error {
@@ -1295,13 +1509,13 @@ func (c *stubConnection) APICall(objType string, version int, id, request string
 
 	if objType == "MigrationTarget" {
 		switch request {
-		case "Prechecks":
+		case "Prechecks", "RevertPrechecks":
 			return c.prechecksErr
 		case "Import":
 			return c.importErr
 		case "ProcessRelations":
 			return c.processRelationsErr
-		case "Activate", "AdoptResources":
+		case "Activate", "Reactivate", "AdoptResources":
 			return nil
 		case "LatestLogTime":
 			responseTime := response.(*time.Time)
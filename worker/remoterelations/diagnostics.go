@@ -0,0 +1,128 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoterelations
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/collections/set"
+	"gopkg.in/macaroon-bakery.v2/bakery/checkers"
+	"gopkg.in/macaroon.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/crossmodel"
+)
+
+// diagnosticsInterval is how often a remote application worker
+// records the diagnostics for its cross model relations.
+const diagnosticsInterval = time.Minute
+
+// ingressAddressKey is the relation settings key used by units to
+// advertise the address on which they can be reached.
+const ingressAddressKey = "ingress-address"
+
+// macaroonNamespace is used to interpret the expiry caveats
+// of the macaroons used to talk to the remote model.
+var macaroonNamespace = checkers.New(nil).Namespace()
+
+// diagnostics accumulates information about the health of the cross
+// model relation traffic for a remote application, so that it can be
+// recorded in the local model.
+type diagnostics struct {
+	lastRemoteEvent *time.Time
+	settingsSyncLag time.Duration
+
+	// macaroons holds the macaroons used to talk to the remote
+	// model, keyed by the offer UUID or relation key.
+	macaroons map[string]*macaroon.Macaroon
+
+	// ingressAddresses holds the ingress addresses of remote units,
+	// keyed by the relation key and unit ID.
+	ingressAddresses map[string]string
+}
+
+func newDiagnostics() *diagnostics {
+	return &diagnostics{
+		macaroons:        make(map[string]*macaroon.Macaroon),
+		ingressAddresses: make(map[string]string),
+	}
+}
+
+// remoteEventProcessed records that an event from the
+// remote model was processed successfully at the given time.
+func (d *diagnostics) remoteEventProcessed(now time.Time) {
+	d.lastRemoteEvent = &now
+}
+
+// settingsSynced records that a relation settings change first
+// observed at the given time has been applied to the other model.
+func (d *diagnostics) settingsSynced(observed, now time.Time) {
+	if observed.IsZero() {
+		return
+	}
+	d.settingsSyncLag = now.Sub(observed)
+}
+
+// setMacaroon records the macaroon used to talk to the
+// remote model on behalf of the offer or relation.
+func (d *diagnostics) setMacaroon(key string, mac *macaroon.Macaroon) {
+	if mac == nil {
+		delete(d.macaroons, key)
+		return
+	}
+	d.macaroons[key] = mac
+}
+
+// remoteUnitsChanged records the ingress addresses
+// of remote units joining or leaving the relation.
+func (d *diagnostics) remoteUnitsChanged(relationKey string, change params.RemoteRelationChangeEvent) {
+	for _, unit := range change.ChangedUnits {
+		if address, ok := unit.Settings[ingressAddressKey].(string); ok && address != "" {
+			d.ingressAddresses[unitAddressKey(relationKey, unit.UnitId)] = address
+		}
+	}
+	for _, unitId := range change.DepartedUnits {
+		delete(d.ingressAddresses, unitAddressKey(relationKey, unitId))
+	}
+}
+
+// relationRemoved forgets everything recorded for the relation.
+func (d *diagnostics) relationRemoved(relationKey string) {
+	delete(d.macaroons, relationKey)
+	prefix := relationKey + "#"
+	for key := range d.ingressAddresses {
+		if strings.HasPrefix(key, prefix) {
+			delete(d.ingressAddresses, key)
+		}
+	}
+}
+
+// info returns the diagnostics to record at the given time.
+func (d *diagnostics) info(now time.Time) crossmodel.RemoteApplicationDiagnostics {
+	info := crossmodel.RemoteApplicationDiagnostics{
+		LastRemoteEvent: d.lastRemoteEvent,
+		SettingsSyncLag: d.settingsSyncLag,
+		Updated:         now,
+	}
+	for _, mac := range d.macaroons {
+		expiry, ok := checkers.MacaroonsExpiryTime(macaroonNamespace, macaroon.Slice{mac})
+		if ok && (info.MacaroonExpiry == nil || expiry.Before(*info.MacaroonExpiry)) {
+			info.MacaroonExpiry = &expiry
+		}
+	}
+	addresses := set.NewStrings()
+	for _, address := range d.ingressAddresses {
+		addresses.Add(address)
+	}
+	if !addresses.IsEmpty() {
+		info.IngressAddresses = addresses.SortedValues()
+	}
+	return info
+}
+
+func unitAddressKey(relationKey string, unitId int) string {
+	return fmt.Sprintf("%s#%d", relationKey, unitId)
+}
@@ -80,6 +80,7 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 
 	w, err := config.NewWorker(Config{
 		ModelUUID:                agent.CurrentConfig().Model().Id(),
+		ControllerUUID:           agent.CurrentConfig().Controller().Id(),
 		RelationsFacade:          facade,
 		NewRemoteModelFacadeFunc: remoteRelationsFacadeForModelFunc(config.NewControllerConnection),
 		Clock:                    clock.WallClock,
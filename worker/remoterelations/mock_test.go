@@ -242,6 +242,11 @@ func (m *mockRelationsFacade) UpdateControllerForModel(controller crossmodel.Con
 	return nil
 }
 
+func (m *mockRelationsFacade) SetRemoteApplicationDiagnostics(applicationName string, diagnostics crossmodel.RemoteApplicationDiagnostics) error {
+	m.stub.MethodCall(m, "SetRemoteApplicationDiagnostics", applicationName, diagnostics)
+	return m.stub.NextErr()
+}
+
 type mockRemoteRelationsFacade struct {
 	mu                      sync.Mutex
 	stub                    *testing.Stub
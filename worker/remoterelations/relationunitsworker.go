@@ -4,6 +4,9 @@
 package remoterelations
 
 import (
+	"time"
+
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	"github.com/juju/worker/v2"
@@ -20,6 +23,9 @@ import (
 type RelationUnitChangeEvent struct {
 	Tag names.RelationTag
 	params.RemoteRelationChangeEvent
+
+	// Observed is when the change was received from the watcher.
+	Observed time.Time
 }
 
 // relationUnitsWorker uses instances of watcher.RelationUnitsWatcher to
@@ -34,6 +40,7 @@ type relationUnitsWorker struct {
 	macaroon    *macaroon.Macaroon
 	mode        string // mode is local or remote.
 
+	clock  clock.Clock
 	logger Logger
 }
 
@@ -42,6 +49,7 @@ func newRelationUnitsWorker(
 	macaroon *macaroon.Macaroon,
 	rrw watcher.RemoteRelationWatcher,
 	changes chan<- RelationUnitChangeEvent,
+	clock clock.Clock,
 	logger Logger,
 	mode string,
 ) (*relationUnitsWorker, error) {
@@ -50,6 +58,7 @@ func newRelationUnitsWorker(
 		macaroon:    macaroon,
 		rrw:         rrw,
 		changes:     changes,
+		clock:       clock,
 		logger:      logger,
 		mode:        mode,
 	}
@@ -101,6 +110,7 @@ func (w *relationUnitsWorker) loop() error {
 			event := RelationUnitChangeEvent{
 				Tag:                       w.relationTag,
 				RemoteRelationChangeEvent: change,
+				Observed:                  w.clock.Now(),
 			}
 			// Send in lockstep so we don't drop events (otherwise
 			// we'd need to merge them - not too hard in this
@@ -4,6 +4,9 @@
 package remoterelations
 
 import (
+	"time"
+
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	"github.com/juju/worker/v2"
@@ -33,6 +36,7 @@ type remoteApplicationWorker struct {
 	offerUUID             string
 	applicationName       string // name of the remote application proxy in the local model
 	localModelUUID        string // uuid of the model hosting the local application
+	localControllerUUID   string // uuid of the controller hosting the local model
 	remoteModelUUID       string // uuid of the model hosting the remote offer
 	isConsumerProxy       bool
 	localRelationChanges  chan RelationUnitChangeEvent
@@ -49,6 +53,11 @@ type remoteApplicationWorker struct {
 
 	newRemoteModelRelationsFacadeFunc newRemoteRelationsFacadeFunc
 
+	// diagnostics accumulates the health of the cross model relation
+	// traffic, which is periodically recorded in the local model.
+	diagnostics *diagnostics
+
+	clock  clock.Clock
 	logger Logger
 }
 
@@ -159,11 +168,26 @@ func (w *remoteApplicationWorker) loop() (err error) {
 		offerStatusChanges = offerStatusWatcher.Changes()
 	}
 
+	// The diagnostics are recorded a while after the remote
+	// application has seen some traffic, so that bursts of
+	// changes result in a single update.
+	w.diagnostics.setMacaroon(w.offerUUID, w.offerMacaroon)
+	recordDiagnostics := true
+	var diagnosticsTimer <-chan time.Time
+	diagnosticsChanged := func() {
+		if recordDiagnostics && diagnosticsTimer == nil {
+			diagnosticsTimer = w.clock.After(diagnosticsInterval)
+		}
+	}
+
 	relations := make(map[string]*relation)
 	for {
 		select {
 		case <-w.catacomb.Dying():
 			return w.catacomb.ErrDying()
+		case <-diagnosticsTimer:
+			diagnosticsTimer = nil
+			recordDiagnostics = w.recordDiagnostics()
 		case change, ok := <-relationsWatcher.Changes():
 			w.logger.Debugf("relations changed: %#v, %v", change, ok)
 			if !ok {
@@ -197,6 +221,8 @@ func (w *remoteApplicationWorker) loop() (err error) {
 				}
 				return errors.Annotatef(err, "publishing relation change %+v to remote model %v", change, w.remoteModelUUID)
 			}
+			w.diagnostics.settingsSynced(change.Observed, w.clock.Now())
+			diagnosticsChanged()
 			if err := w.localRelationChanged(change.Tag.Id(), change.UnitCount, relations); err != nil {
 				return errors.Annotatef(err, "processing local relation change for %v", change.Tag.Id())
 			}
@@ -209,6 +235,11 @@ func (w *remoteApplicationWorker) loop() (err error) {
 				}
 				return errors.Annotatef(err, "consuming relation change %+v from remote model %v", change, w.remoteModelUUID)
 			}
+			now := w.clock.Now()
+			w.diagnostics.remoteEventProcessed(now)
+			w.diagnostics.settingsSynced(change.Observed, now)
+			w.diagnostics.remoteUnitsChanged(change.Tag.Id(), change.RemoteRelationChangeEvent)
+			diagnosticsChanged()
 		case changes := <-offerStatusChanges:
 			w.logger.Debugf("offer status changed: %#v", changes)
 			for _, change := range changes {
@@ -216,10 +247,27 @@ func (w *remoteApplicationWorker) loop() (err error) {
 					return errors.Annotatef(err, "updating remote application %v status from remote model %v", w.applicationName, w.remoteModelUUID)
 				}
 			}
+			w.diagnostics.remoteEventProcessed(w.clock.Now())
+			diagnosticsChanged()
 		}
 	}
 }
 
+// recordDiagnostics records the health of the cross model relation
+// traffic in the local model, and reports whether it should be
+// recorded again after further traffic. The diagnostics are informational only, so
+// failing to record them does not stop the worker.
+func (w *remoteApplicationWorker) recordDiagnostics() bool {
+	err := w.localModelFacade.SetRemoteApplicationDiagnostics(w.applicationName, w.diagnostics.info(w.clock.Now()))
+	if errors.IsNotSupported(err) {
+		w.logger.Debugf("controller does not support recording diagnostics for remote application %v", w.applicationName)
+		return false
+	} else if err != nil {
+		w.logger.Warningf("recording diagnostics for remote application %v: %v", w.applicationName, err)
+	}
+	return true
+}
+
 // newRemoteRelationsFacadeWithRedirect attempts to open an API connection to
 // the remote model for the watcher's application.
 // If a redirect error is returned, we attempt to open a connection to the new
@@ -364,6 +412,7 @@ func (w *remoteApplicationWorker) localRelationChanged(
 		return nil
 	}
 	delete(relations, key)
+	w.diagnostics.relationRemoved(key)
 	w.logger.Debugf("local relation %v is dead", key)
 
 	// For the unit watchers, check to see if these are nil before stopping.
@@ -447,6 +496,7 @@ func (w *remoteApplicationWorker) startUnitsWorkers(
 		mac,
 		localRelationUnitsWatcher,
 		w.localRelationChanges,
+		w.clock,
 		w.logger,
 		"local",
 	)
@@ -473,6 +523,7 @@ func (w *remoteApplicationWorker) startUnitsWorkers(
 		mac,
 		remoteRelationUnitsWatcher,
 		w.remoteRelationChanges,
+		w.clock,
 		w.logger,
 		"remote",
 	)
@@ -507,6 +558,7 @@ func (w *remoteApplicationWorker) processConsumingRelation(
 		w.checkOfferPermissionDenied(err, "", "")
 		return errors.Annotatef(err, "registering application %v and relation %v", remoteRelation.ApplicationName, relationTag.Id())
 	}
+	w.diagnostics.setMacaroon(key, mac)
 
 	// Have we seen the relation before.
 	r, relationKnown := relations[key]
@@ -602,6 +654,9 @@ func (w *remoteApplicationWorker) registerRemoteRelation(
 		RemoteEndpoint:    localEndpointInfo,
 		LocalEndpointName: remoteEndpointName,
 	}
+	if w.localControllerUUID != "" {
+		arg.SourceControllerTag = names.NewControllerTag(w.localControllerUUID).String()
+	}
 	if w.offerMacaroon != nil {
 		arg.Macaroons = macaroon.Slice{w.offerMacaroon}
 		arg.BakeryVersion = bakery.LatestVersion
@@ -114,6 +114,10 @@ type RemoteRelationsFacade interface {
 	// UpdateControllerForModel ensures that there is an external controller record
 	// for the input info, associated with the input model ID.
 	UpdateControllerForModel(controller crossmodel.ControllerInfo, modelUUID string) error
+
+	// SetRemoteApplicationDiagnostics records the cross model relation
+	// diagnostics for the specified remote application.
+	SetRemoteApplicationDiagnostics(applicationName string, diagnostics crossmodel.RemoteApplicationDiagnostics) error
 }
 
 type newRemoteRelationsFacadeFunc func(*api.Info) (RemoteModelRelationsFacadeCloser, error)
@@ -121,6 +125,7 @@ type newRemoteRelationsFacadeFunc func(*api.Info) (RemoteModelRelationsFacadeClo
 // Config defines the operation of a Worker.
 type Config struct {
 	ModelUUID                string
+	ControllerUUID           string
 	RelationsFacade          RemoteRelationsFacade
 	NewRemoteModelFacadeFunc newRemoteRelationsFacadeFunc
 	Clock                    clock.Clock
@@ -256,6 +261,7 @@ func (w *Worker) handleApplicationChanges(applicationIds []string) error {
 				offerUUID:                         remoteApp.OfferUUID,
 				applicationName:                   remoteApp.Name,
 				localModelUUID:                    w.config.ModelUUID,
+				localControllerUUID:               w.config.ControllerUUID,
 				remoteModelUUID:                   remoteApp.ModelUUID,
 				isConsumerProxy:                   remoteApp.IsConsumerProxy,
 				offerMacaroon:                     remoteApp.Macaroon,
@@ -263,6 +269,8 @@ func (w *Worker) handleApplicationChanges(applicationIds []string) error {
 				remoteRelationChanges:             make(chan RelationUnitChangeEvent),
 				localModelFacade:                  w.config.RelationsFacade,
 				newRemoteModelRelationsFacadeFunc: w.config.NewRemoteModelFacadeFunc,
+				diagnostics:                       newDiagnostics(),
+				clock:                             w.config.Clock,
 				logger:                            logger,
 			}
 			if err := catacomb.Invoke(catacomb.Plan{
@@ -320,6 +320,54 @@ func (s *remoteRelationsSuite) TestRemoteNotFoundTerminatesOnChange(c *gc.C) {
 	s.waitForWorkerStubCalls(c, expected)
 }
 
+func (s *remoteRelationsSuite) TestRegisterRemoteRelationIncludesController(c *gc.C) {
+	s.config.ControllerUUID = coretesting.ControllerTag.Id()
+	s.relationsFacade.relations["db2:db django:db"] = newMockRelation(123)
+	w := s.assertRemoteApplicationWorkers(c)
+	defer workertest.CleanKill(c, w)
+
+	s.stub.ResetCalls()
+	s.stub.SetErrors(nil, nil, params.Error{Code: params.CodeNotFound})
+
+	s.relationsFacade.relationsEndpoints["db2:db django:db"] = &relationEndpointInfo{
+		localApplicationName: "django",
+		localEndpoint: params.RemoteEndpoint{
+			Name:      "db2",
+			Role:      "requires",
+			Interface: "db2",
+		},
+		remoteEndpointName: "data",
+	}
+
+	relWatcher, _ := s.relationsFacade.remoteApplicationRelationsWatcher("db2")
+	relWatcher.changes <- []string{"db2:db django:db"}
+
+	mac, err := apitesting.NewMacaroon("test")
+	c.Assert(err, jc.ErrorIsNil)
+	relTag := names.NewRelationTag("db2:db django:db")
+	expected := []jujutesting.StubCall{
+		{"Relations", []interface{}{[]string{"db2:db django:db"}}},
+		{"ExportEntities", []interface{}{
+			[]names.Tag{names.NewApplicationTag("django"), relTag}}},
+		{"RegisterRemoteRelations", []interface{}{[]params.RegisterRemoteRelationArg{{
+			ApplicationToken:    "token-django",
+			SourceModelTag:      "model-local-model-uuid",
+			SourceControllerTag: coretesting.ControllerTag.String(),
+			RelationToken:       "token-db2:db django:db",
+			RemoteEndpoint: params.RemoteEndpoint{
+				Name:      "db2",
+				Role:      "requires",
+				Interface: "db2",
+			},
+			OfferUUID:         "offer-db2-uuid",
+			LocalEndpointName: "data",
+			Macaroons:         macaroon.Slice{mac},
+			BakeryVersion:     bakery.LatestVersion,
+		}}}},
+	}
+	s.waitForWorkerStubCalls(c, expected)
+}
+
 func (s *remoteRelationsSuite) assertRemoteRelationsWorkers(c *gc.C) worker.Worker {
 	s.relationsFacade.relations["db2:db django:db"] = newMockRelation(123)
 	w := s.assertRemoteApplicationWorkers(c)
@@ -704,6 +752,43 @@ func (s *remoteRelationsSuite) TestRemoteRelationsChangedConsumes(c *gc.C) {
 	s.waitForWorkerStubCalls(c, expected)
 }
 
+func (s *remoteRelationsSuite) TestRemoteRelationsChangedRecordsDiagnostics(c *gc.C) {
+	now := time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC)
+	clock := testclock.NewClock(now)
+	s.config.Clock = clock
+	w := s.assertRemoteRelationsWorkers(c)
+	defer workertest.CleanKill(c, w)
+	s.stub.ResetCalls()
+
+	unitsWatcher, _ := s.remoteRelationsFacade.remoteRelationWatcher("token-db2:db django:db")
+	unitsWatcher.changes <- params.RemoteRelationChangeEvent{
+		ApplicationToken: "token-offer-db2-uuid",
+		RelationToken:    "token-db2:db django:db",
+		ChangedUnits: []params.RemoteRelationUnitChange{{
+			UnitId:   1,
+			Settings: map[string]interface{}{"ingress-address": "10.0.0.1"},
+		}},
+	}
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(s.stub.Calls()) > 0 {
+			break
+		}
+	}
+	s.stub.CheckCallNames(c, "ConsumeRemoteRelationChange")
+	s.stub.ResetCalls()
+
+	// The diagnostics are recorded a while after the change.
+	err := clock.WaitAdvance(remoterelations.DiagnosticsInterval, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitForWorkerStubCalls(c, []jujutesting.StubCall{
+		{"SetRemoteApplicationDiagnostics", []interface{}{"db2", crossmodel.RemoteApplicationDiagnostics{
+			LastRemoteEvent:  &now,
+			IngressAddresses: []string{"10.0.0.1"},
+			Updated:          now.Add(remoterelations.DiagnosticsInterval),
+		}}},
+	})
+}
+
 func (s *remoteRelationsSuite) TestRemoteRelationsDyingConsumes(c *gc.C) {
 	w := s.assertRemoteRelationsWorkers(c)
 	defer workertest.CleanKill(c, w)
@@ -1719,6 +1719,121 @@ func (s *storageProvisionerSuite) TestDestroyVolumesRetry(c *gc.C) {
 	})
 }
 
+func (s *storageProvisionerSuite) TestResizeVolumes(c *gc.C) {
+	volume := names.NewVolumeTag("1")
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionVolume(volume)
+	volumeAccessor.requestedSizes[volume.String()] = 2048
+
+	volumeInfoSet := make(chan interface{})
+	volumeAccessor.setVolumeInfo = func(volumes []params.Volume) ([]params.ErrorResult, error) {
+		volumeInfoSet <- volumes
+		return make([]params.ErrorResult, len(volumes)), nil
+	}
+
+	resizedChan := make(chan interface{}, 1)
+	s.provider.resizeVolumesFunc = func(args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+		resizedChan <- args
+		return []storage.ResizeVolumesResult{{
+			VolumeInfo: &storage.VolumeInfo{VolumeId: "vol-1", Size: 2048},
+		}}, nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.resizesWatcher.changes <- []string{volume.Id(), "2"}
+	resized := waitChannel(c, resizedChan, "waiting for volume to be resized")
+	c.Assert(resized, jc.DeepEquals, []storage.VolumeResizeParams{{
+		Tag:      volume,
+		VolumeId: "vol-1",
+		Provider: "dummy",
+		Size:     2048,
+	}})
+	info := waitChannel(c, volumeInfoSet, "waiting for volume info to be set")
+	c.Assert(info, jc.DeepEquals, []params.Volume{{
+		VolumeTag: "volume-1",
+		Info: params.VolumeInfo{
+			VolumeId: "vol-1",
+			Pool:     "dummy-pool",
+			Size:     2048,
+		},
+	}})
+}
+
+func (s *storageProvisionerSuite) TestResizeVolumesRetry(c *gc.C) {
+	volume := names.NewVolumeTag("1")
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionVolume(volume)
+	volumeAccessor.requestedSizes[volume.String()] = 2048
+
+	volumeInfoSet := make(chan interface{})
+	volumeAccessor.setVolumeInfo = func(volumes []params.Volume) ([]params.ErrorResult, error) {
+		volumeInfoSet <- volumes
+		return make([]params.ErrorResult, len(volumes)), nil
+	}
+
+	clock := &mockClock{}
+	var resizeVolumeTimes []time.Time
+	s.provider.resizeVolumesFunc = func(args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+		resizeVolumeTimes = append(resizeVolumeTimes, clock.Now())
+		if len(resizeVolumeTimes) < 3 {
+			return []storage.ResizeVolumesResult{{Error: errors.New("badness")}}, nil
+		}
+		return []storage.ResizeVolumesResult{{
+			VolumeInfo: &storage.VolumeInfo{VolumeId: "vol-1", Size: 2048},
+		}}, nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, clock: clock, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.resizesWatcher.changes <- []string{volume.Id()}
+	waitChannel(c, volumeInfoSet, "waiting for volume info to be set")
+	c.Assert(resizeVolumeTimes, gc.HasLen, 3)
+	c.Assert(args.statusSetter.args, jc.DeepEquals, []params.EntityStatusArgs{
+		{Tag: "volume-1", Status: "error", Info: "resizing volume: badness"},
+		{Tag: "volume-1", Status: "error", Info: "resizing volume: badness"},
+		{Tag: "volume-1", Status: "attached"},
+	})
+}
+
+func (s *storageProvisionerSuite) TestResizeVolumesNotSupported(c *gc.C) {
+	volume := names.NewVolumeTag("1")
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionVolume(volume)
+	volumeAccessor.requestedSizes[volume.String()] = 2048
+
+	s.provider.volumeSourceFunc = func(*storage.Config) (storage.VolumeSource, error) {
+		return struct{ storage.VolumeSource }{}, nil
+	}
+
+	statusSet := make(chan interface{}, 1)
+	args := &workerArgs{
+		volumes:  volumeAccessor,
+		registry: s.registry,
+		statusSetter: &mockStatusSetter{
+			setStatus: func(args []params.EntityStatusArgs) error {
+				statusSet <- args
+				return nil
+			},
+		},
+	}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.resizesWatcher.changes <- []string{volume.Id()}
+	statuses := waitChannel(c, statusSet, "waiting for status to be set")
+	c.Assert(statuses, jc.DeepEquals, []params.EntityStatusArgs{
+		{Tag: "volume-1", Status: "error", Info: `resizing "dummy" volumes not supported`},
+	})
+}
+
 func (s *storageProvisionerSuite) TestDestroyFilesystems(c *gc.C) {
 	unprovisionedFilesystem := names.NewFilesystemTag("0")
 	provisionedDestroyFilesystem := names.NewFilesystemTag("1")
@@ -57,6 +57,43 @@ func volumesChanged(ctx *context, changes []string) error {
 	return nil
 }
 
+// volumeResizesChanged is called when the volumes with the provided IDs
+// have been seen to have changed, and may have resize requests pending.
+func volumeResizesChanged(ctx *context, changes []string) error {
+	tags := make([]names.VolumeTag, len(changes))
+	for i, change := range changes {
+		tags[i] = names.NewVolumeTag(change)
+	}
+	results, err := ctx.config.Volumes.VolumeResizeParams(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting volume resize params")
+	}
+	for i, result := range results {
+		if result.Error != nil {
+			if params.IsCodeNotFound(result.Error) {
+				// The volume has been removed, or there
+				// is no resize pending for it.
+				continue
+			}
+			return errors.Annotatef(
+				result.Error, "getting resize parameters for %s",
+				names.ReadableString(tags[i]),
+			)
+		}
+		args, err := volumeResizeParamsFromParams(result.Result)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ctx.config.Logger.Debugf("volume %s to be resized to %dMiB", args.Tag.Id(), args.Size)
+		op := &resizeVolumeOp{args: args, pool: result.Result.Pool}
+		// Replace any resize already scheduled, as the
+		// requested size may have changed.
+		ctx.schedule.Remove(op.key())
+		scheduleOperations(ctx, op)
+	}
+	return nil
+}
+
 func sortVolumeAttachmentPlans(ctx *context, ids []params.MachineStorageId) (
 	alive, dying, dead []params.VolumeAttachmentPlanResult, err error) {
 	plans, err := ctx.config.Volumes.VolumeAttachmentPlans(ids)
@@ -654,6 +691,19 @@ func volumeParamsFromParams(in params.VolumeParams) (storage.VolumeParams, error
 	}, nil
 }
 
+func volumeResizeParamsFromParams(in params.VolumeResizeParams) (storage.VolumeResizeParams, error) {
+	volumeTag, err := names.ParseVolumeTag(in.VolumeTag)
+	if err != nil {
+		return storage.VolumeResizeParams{}, errors.Trace(err)
+	}
+	return storage.VolumeResizeParams{
+		Tag:      volumeTag,
+		VolumeId: in.VolumeId,
+		Provider: storage.ProviderType(in.Provider),
+		Size:     in.Size,
+	}, nil
+}
+
 func volumeAttachmentParamsFromParams(in params.VolumeAttachmentParams) (storage.VolumeAttachmentParams, error) {
 	hostTag, err := names.ParseTag(in.MachineTag)
 	if err != nil {
@@ -329,6 +329,108 @@ func removeVolumes(ctx *context, ops map[names.VolumeTag]*removeVolumeOp) error
 	return nil
 }
 
+// resizeVolumes grows volumes with the specified parameters.
+func resizeVolumes(ctx *context, ops map[names.VolumeTag]*resizeVolumeOp) error {
+	volumeParams := make([]storage.VolumeParams, 0, len(ops))
+	for _, op := range ops {
+		volumeParams = append(volumeParams, storage.VolumeParams{
+			Tag:      op.args.Tag,
+			Provider: op.args.Provider,
+		})
+	}
+	paramsBySource, volumeSources, err := volumeParamsBySource(
+		ctx.config.StorageDir, volumeParams, ctx.config.Registry,
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var reschedule []scheduleOp
+	var volumes []storage.Volume
+	var statuses []params.EntityStatusArgs
+	for sourceName, volumeParams := range paramsBySource {
+		resizer, ok := volumeSources[sourceName].(storage.VolumeResizer)
+		if !ok {
+			// The storage provider does not support resizing
+			// volumes; there is no point in retrying.
+			for _, p := range volumeParams {
+				statuses = append(statuses, params.EntityStatusArgs{
+					Tag:    p.Tag.String(),
+					Status: status.Error.String(),
+					Info:   errors.NotSupportedf("resizing %q volumes", sourceName).Error(),
+				})
+			}
+			continue
+		}
+		resizeParams := make([]storage.VolumeResizeParams, len(volumeParams))
+		for i, p := range volumeParams {
+			resizeParams[i] = ops[p.Tag].args
+		}
+		ctx.config.Logger.Debugf("resizing volumes: %v", resizeParams)
+		results, err := resizer.ResizeVolumes(ctx.config.CloudCallContext, resizeParams)
+		if err != nil {
+			return errors.Annotatef(err, "resizing volumes from source %q", sourceName)
+		}
+		for i, result := range results {
+			tag := resizeParams[i].Tag
+			op := ops[tag]
+			if result.Error != nil {
+				// Reschedule the resize, and record the failure
+				// in the volume's status.
+				reschedule = append(reschedule, op)
+				op.failed = true
+				statuses = append(statuses, params.EntityStatusArgs{
+					Tag:    tag.String(),
+					Status: status.Error.String(),
+					Info:   errors.Annotate(result.Error, "resizing volume").Error(),
+				})
+				ctx.config.Logger.Debugf(
+					"failed to resize %s: %v",
+					names.ReadableString(tag),
+					result.Error,
+				)
+				continue
+			}
+			if op.failed {
+				statuses = append(statuses, params.EntityStatusArgs{
+					Tag:    tag.String(),
+					Status: status.Attached.String(),
+				})
+			}
+			volumes = append(volumes, storage.Volume{
+				Tag:        tag,
+				VolumeInfo: *result.VolumeInfo,
+			})
+		}
+	}
+	scheduleOperations(ctx, reschedule...)
+	setStatus(ctx, statuses)
+	if len(volumes) == 0 {
+		return nil
+	}
+	volumeInfo := volumesFromStorage(volumes)
+	for i, v := range volumes {
+		// The pool is immutable, and must be echoed back.
+		volumeInfo[i].Info.Pool = ops[v.Tag].pool
+	}
+	errorResults, err := ctx.config.Volumes.SetVolumeInfo(volumeInfo)
+	if err != nil {
+		return errors.Annotate(err, "publishing resized volumes to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			ctx.config.Logger.Errorf(
+				"publishing resized volume %s to state: %v",
+				volumes[i].Tag.Id(),
+				result.Error,
+			)
+		}
+	}
+	for _, v := range volumes {
+		updateVolume(ctx, v)
+	}
+	return nil
+}
+
 func partitionRemoveVolumeParams(removeTags []names.VolumeTag, removeParams []params.RemoveVolumeParams) (
 	destroyTags []names.VolumeTag, destroyIds []string,
 	releaseTags []names.VolumeTag, releaseIds []string,
@@ -560,6 +662,24 @@ func (op *removeVolumeOp) key() interface{} {
 	return op.tag
 }
 
+type resizeVolumeOp struct {
+	exponentialBackoff
+	args   storage.VolumeResizeParams
+	pool   string
+	failed bool
+}
+
+// resizeVolumeKey is the schedule key for a resizeVolumeOp. It is
+// distinct from the volume tag, so that a volume may be resized
+// while other operations for it are pending.
+type resizeVolumeKey struct {
+	tag names.VolumeTag
+}
+
+func (op *resizeVolumeOp) key() interface{} {
+	return resizeVolumeKey{op.args.Tag}
+}
+
 type attachVolumeOp struct {
 	exponentialBackoff
 	args storage.VolumeAttachmentParams
@@ -9,6 +9,8 @@ import (
 
 	"github.com/juju/charm/v7/hooks"
 	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/core/secrets"
 )
 
 // TODO(fwereade): move these definitions to juju/charm/hooks.
@@ -16,6 +18,7 @@ const (
 	LeaderElected         hooks.Kind = "leader-elected"
 	LeaderDeposed         hooks.Kind = "leader-deposed"
 	LeaderSettingsChanged hooks.Kind = "leader-settings-changed"
+	SecretRotate          hooks.Kind = "secret-rotate"
 )
 
 // Info holds details required to execute a hook. Not all fields are
@@ -45,6 +48,10 @@ type Info struct {
 	// DepartingUnit is the name of the unit that goes away. It is only set
 	// when Kind indicates a relation-departed hook.
 	DepartingUnit string `yaml:"departee,omitempty"`
+
+	// SecretURI is the URI of the secret relevant to the hook. It is only
+	// set when Kind indicates a secret hook.
+	SecretURI string `yaml:"secret-uri,omitempty"`
 }
 
 // Validate returns an error if the info is not valid.
@@ -80,6 +87,11 @@ func (hi Info) Validate() error {
 	// TODO(fwereade): define these in charm/hooks...
 	case LeaderElected, LeaderDeposed, LeaderSettingsChanged:
 		return nil
+	case SecretRotate:
+		if _, err := secrets.ParseURI(hi.SecretURI); err != nil {
+			return fmt.Errorf("invalid secret URI %q", hi.SecretURI)
+		}
+		return nil
 	}
 	return fmt.Errorf("unknown hook kind %q", hi.Kind)
 }
@@ -61,6 +61,8 @@ var validateTests = []struct {
 	{hook.Info{Kind: hooks.StorageAttached}, `invalid storage ID ""`},
 	{hook.Info{Kind: hooks.StorageAttached, StorageId: "data/0"}, ""},
 	{hook.Info{Kind: hooks.StorageDetaching, StorageId: "data/0"}, ""},
+	{hook.Info{Kind: hook.SecretRotate}, `invalid secret URI ""`},
+	{hook.Info{Kind: hook.SecretRotate, SecretURI: "secret:7fa6cc1c-3f6b-4a04-8b27-9f3a1b9b5e21"}, ""},
 }
 
 func (s *InfoSuite) TestValidate(c *gc.C) {
@@ -5,9 +5,14 @@ package operation
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/core/machinelock"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/worker/common/charmrunner"
 	"github.com/juju/juju/worker/uniter/remotestate"
 )
 
@@ -26,25 +31,51 @@ var (
 	stepCommit  = executorStep{"committing", Operation.Commit}
 )
 
+// hookOperation is implemented by operations which run hooks, so the
+// executor can record the timing and outcome of each hook.
+type hookOperation interface {
+	// executedHook returns the name of the hook run by the operation,
+	// whether the hook was found and run, and the error it failed with.
+	executedHook() (string, bool, error)
+}
+
+// prioritisedOperation is implemented by operations which should wait
+// for the machine lock ahead of, or behind, other work on the machine.
+type prioritisedOperation interface {
+	// lockPriority returns the priority with which the operation
+	// waits for the machine lock.
+	lockPriority() machinelock.Priority
+}
+
 type executor struct {
-	stateOps           *StateOps
-	state              *State
-	acquireMachineLock func(string) (func(), error)
-	logger             Logger
+	stateOps            *StateOps
+	state               *State
+	acquireMachineLock  func(string, machinelock.Priority) (func(), error)
+	recordHookExecution func(status.HookExecution) error
+	clock               clock.Clock
+	logger              Logger
 }
 
 // ExecutorConfig defines configuration for an Executor.
 type ExecutorConfig struct {
 	StateReadWriter UnitStateReadWriter
 	InitialState    State
-	AcquireLock     func(string) (func(), error)
+	AcquireLock     func(string, machinelock.Priority) (func(), error)
+	Clock           clock.Clock
 	Logger          Logger
+
+	// RecordHookExecution, if set, is called with the timing and
+	// outcome of each hook run by the executor.
+	RecordHookExecution func(status.HookExecution) error
 }
 
 func (e ExecutorConfig) validate() error {
 	if e.StateReadWriter == nil {
 		return errors.NotValidf("executor config with nil state ops")
 	}
+	if e.Clock == nil {
+		return errors.NotValidf("executor config with nil clock")
+	}
 	if e.Logger == nil {
 		return errors.NotValidf("executor config with nil logger")
 	}
@@ -66,10 +97,12 @@ func NewExecutor(cfg ExecutorConfig) (Executor, error) {
 		return nil, err
 	}
 	return &executor{
-		stateOps:           stateOps,
-		state:              state,
-		acquireMachineLock: cfg.AcquireLock,
-		logger:             cfg.Logger,
+		stateOps:            stateOps,
+		state:               state,
+		acquireMachineLock:  cfg.AcquireLock,
+		recordHookExecution: cfg.RecordHookExecution,
+		clock:               cfg.Clock,
+		logger:              cfg.Logger,
 	}, nil
 }
 
@@ -83,7 +116,11 @@ func (x *executor) Run(op Operation, remoteStateChange <-chan remotestate.Snapsh
 	x.logger.Debugf("running operation %v", op)
 
 	if op.NeedsGlobalMachineLock() {
-		releaser, err := x.acquireMachineLock(op.String())
+		priority := machinelock.PriorityNormal
+		if prioritised, ok := Unwrap(op).(prioritisedOperation); ok {
+			priority = prioritised.lockPriority()
+		}
+		releaser, err := x.acquireMachineLock(op.String(), priority)
 		if err != nil {
 			return errors.Annotate(err, "could not acquire lock")
 		}
@@ -108,11 +145,13 @@ func (x *executor) Run(op Operation, remoteStateChange <-chan remotestate.Snapsh
 				}
 			}
 		}()
-		if err := x.do(op, stepExecute); err != nil {
-			close(done)
+		started := x.clock.Now()
+		err := x.do(op, stepExecute)
+		close(done)
+		x.recordHook(op, started)
+		if err != nil {
 			return err
 		}
-		close(done)
 	default:
 		return err
 	}
@@ -125,6 +164,38 @@ func (x *executor) Skip(op Operation) error {
 	return x.do(op, stepCommit)
 }
 
+// recordHook reports the timing and outcome of the hook run by the
+// operation, if any. Hooks which could not be recorded are only logged.
+func (x *executor) recordHook(op Operation, started time.Time) {
+	hookOp, ok := Unwrap(op).(hookOperation)
+	if !ok || x.recordHookExecution == nil {
+		return
+	}
+	hookName, ran, hookErr := hookOp.executedHook()
+	if !ran {
+		return
+	}
+	execution := status.HookExecution{
+		Hook:     hookName,
+		Started:  started,
+		Finished: x.clock.Now(),
+		Result:   status.HookSucceeded,
+	}
+	if hookErr != nil {
+		execution.Result = status.HookFailed
+		if charmrunner.IsHookTimedOutError(hookErr) {
+			execution.Result = status.HookTimedOut
+		}
+		execution.Message = hookErr.Error()
+	}
+	err := x.recordHookExecution(execution)
+	if errors.IsNotImplemented(err) {
+		x.logger.Tracef("not recording %q hook execution: %v", hookName, err)
+	} else if err != nil {
+		x.logger.Warningf("cannot record %q hook execution: %v", hookName, err)
+	}
+}
+
 func (x *executor) do(op Operation, step executorStep) (err error) {
 	message := step.message(op)
 	x.logger.Debugf(message)
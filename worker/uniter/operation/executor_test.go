@@ -8,6 +8,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/juju/charm/v7/hooks"
+	"github.com/juju/clock/testclock"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/testing"
@@ -16,6 +17,9 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/machinelock"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/worker/common/charmrunner"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/operation"
 	"github.com/juju/juju/worker/uniter/operation/mocks"
@@ -31,7 +35,7 @@ type NewExecutorSuite struct {
 
 var _ = gc.Suite(&NewExecutorSuite{})
 
-func failAcquireLock(_ string) (func(), error) {
+func failAcquireLock(_ string, _ machinelock.Priority) (func(), error) {
 	return nil, errors.New("wat")
 }
 
@@ -67,6 +71,7 @@ func (s *NewExecutorSuite) TestNewExecutorInvalidStateRead(c *gc.C) {
 		StateReadWriter: s.mockStateRW,
 		InitialState:    initialState,
 		AcquireLock:     failAcquireLock,
+		Clock:           testclock.NewClock(time.Time{}),
 		Logger:          loggo.GetLogger("test"),
 	}
 	executor, err := operation.NewExecutor(cfg)
@@ -74,6 +79,18 @@ func (s *NewExecutorSuite) TestNewExecutorInvalidStateRead(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `validation of uniter state: invalid operation state: .*`)
 }
 
+func (s *NewExecutorSuite) TestNewExecutorNilClock(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	cfg := operation.ExecutorConfig{
+		StateReadWriter: s.mockStateRW,
+		AcquireLock:     failAcquireLock,
+		Logger:          loggo.GetLogger("test"),
+	}
+	_, err := operation.NewExecutor(cfg)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, "executor config with nil clock not valid")
+}
+
 func (s *NewExecutorSuite) TestNewExecutorNoInitialState(c *gc.C) {
 	defer s.setupMocks(c).Finish()
 	s.expectStateNil()
@@ -82,6 +99,7 @@ func (s *NewExecutorSuite) TestNewExecutorNoInitialState(c *gc.C) {
 		StateReadWriter: s.mockStateRW,
 		InitialState:    initialState,
 		AcquireLock:     failAcquireLock,
+		Clock:           testclock.NewClock(time.Time{}),
 		Logger:          loggo.GetLogger("test"),
 	}
 	executor, err := operation.NewExecutor(cfg)
@@ -100,6 +118,7 @@ func (s *NewExecutorSuite) TestNewExecutorValidFile(c *gc.C) {
 		StateReadWriter: s.mockStateRW,
 		InitialState:    operation.State{Step: operation.Queued},
 		AcquireLock:     failAcquireLock,
+		Clock:           testclock.NewClock(time.Time{}),
 		Logger:          loggo.GetLogger("test"),
 	}
 	executor, err := operation.NewExecutor(cfg)
@@ -191,6 +210,7 @@ func (s *ExecutorSuite) newExecutor(c *gc.C, st *operation.State) operation.Exec
 		StateReadWriter: s.mockStateRW,
 		InitialState:    operation.State{Step: operation.Queued},
 		AcquireLock:     failAcquireLock,
+		Clock:           testclock.NewClock(time.Time{}),
 		Logger:          loggo.GetLogger("test"),
 	}
 	executor, err := operation.NewExecutor(cfg)
@@ -467,7 +487,7 @@ func (s *ExecutorSuite) TestFailCommitWithStateChange(c *gc.C) {
 	c.Assert(executor.State(), gc.DeepEquals, *commit.newState)
 }
 
-func (s *ExecutorSuite) initLockTest(c *gc.C, lockFunc func(string) (func(), error)) operation.Executor {
+func (s *ExecutorSuite) initLockTest(c *gc.C, lockFunc func(string, machinelock.Priority) (func(), error)) operation.Executor {
 	initialState := justInstalledState()
 	err := operation.NewStateOps(s.mockStateRW).Write(&initialState)
 	c.Assert(err, jc.ErrorIsNil)
@@ -475,6 +495,7 @@ func (s *ExecutorSuite) initLockTest(c *gc.C, lockFunc func(string) (func(), err
 		StateReadWriter: s.mockStateRW,
 		InitialState:    operation.State{Step: operation.Queued},
 		AcquireLock:     lockFunc,
+		Clock:           testclock.NewClock(time.Time{}),
 		Logger:          loggo.GetLogger("test"),
 	}
 	executor, err := operation.NewExecutor(cfg)
@@ -501,6 +522,7 @@ func (s *ExecutorSuite) TestLockSucceedsStepsCalled(c *gc.C) {
 	c.Assert(mockLock.calledLock, jc.IsTrue)
 	c.Assert(mockLock.calledUnlock, jc.IsTrue)
 	c.Assert(mockLock.noStepsCalledOnLock, jc.IsTrue)
+	c.Assert(mockLock.priority, gc.Equals, machinelock.PriorityNormal)
 
 	expectedStepsOnUnlock := []bool{true, true, true}
 	c.Assert(mockLock.stepsCalledOnUnlock, gc.DeepEquals, expectedStepsOnUnlock)
@@ -595,25 +617,141 @@ func (s *ExecutorSuite) TestLockUnlocksOnError_Commit(c *gc.C) {
 	c.Assert(mockLock.stepsCalledOnUnlock, gc.DeepEquals, expectedStepsOnUnlock)
 }
 
+func (s *ExecutorSuite) newHookRecordingExecutor(c *gc.C, clock *testclock.Clock, recorded *[]status.HookExecution) operation.Executor {
+	initialState := justInstalledState()
+	s.expectState(c, initialState)
+	cfg := operation.ExecutorConfig{
+		StateReadWriter: s.mockStateRW,
+		InitialState:    operation.State{Step: operation.Queued},
+		AcquireLock:     failAcquireLock,
+		Clock:           clock,
+		Logger:          loggo.GetLogger("test"),
+		RecordHookExecution: func(execution status.HookExecution) error {
+			*recorded = append(*recorded, execution)
+			return nil
+		},
+	}
+	executor, err := operation.NewExecutor(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	return executor
+}
+
+func (s *ExecutorSuite) TestRunRecordsHookExecution(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	started := time.Date(2020, 7, 1, 10, 0, 0, 0, time.UTC)
+	clock := testclock.NewClock(started)
+	var recorded []status.HookExecution
+	executor := s.newHookRecordingExecutor(c, clock, &recorded)
+
+	op := &mockOperation{
+		prepare: newStep(nil, nil),
+		execute: mockStepFunc(func(operation.State) (*operation.State, error) {
+			clock.Advance(90 * time.Second)
+			return nil, nil
+		}),
+		commit: newStep(nil, nil),
+	}
+	err := executor.Run(operation.NewHookOperation(op, "install", nil), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(recorded, jc.DeepEquals, []status.HookExecution{{
+		Hook:     "install",
+		Started:  started,
+		Finished: started.Add(90 * time.Second),
+		Result:   status.HookSucceeded,
+	}})
+}
+
+func (s *ExecutorSuite) TestRunRecordsFailedHookExecution(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	started := time.Date(2020, 7, 1, 10, 0, 0, 0, time.UTC)
+	clock := testclock.NewClock(started)
+	var recorded []status.HookExecution
+	executor := s.newHookRecordingExecutor(c, clock, &recorded)
+
+	op := &mockOperation{
+		prepare: newStep(nil, nil),
+		execute: newStep(nil, operation.ErrHookFailed),
+		commit:  newStep(nil, nil),
+	}
+	hookErr := charmrunner.NewHookTimedOutError("config-changed", time.Minute)
+	err := executor.Run(operation.NewHookOperation(op, "config-changed", hookErr), nil)
+	c.Assert(errors.Cause(err), gc.Equals, operation.ErrHookFailed)
+	c.Assert(recorded, jc.DeepEquals, []status.HookExecution{{
+		Hook:     "config-changed",
+		Started:  started,
+		Finished: started,
+		Result:   status.HookTimedOut,
+		Message:  "config-changed hook timed out after 1m0s",
+	}})
+}
+
+func (s *ExecutorSuite) TestRunRecordsWrappedHookExecution(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	started := time.Date(2020, 7, 1, 10, 0, 0, 0, time.UTC)
+	clock := testclock.NewClock(started)
+	var recorded []status.HookExecution
+	executor := s.newHookRecordingExecutor(c, clock, &recorded)
+
+	op := &mockOperation{
+		prepare: newStep(nil, nil),
+		execute: newStep(nil, nil),
+		commit:  newStep(nil, nil),
+	}
+	wrapped := wrappedOperation{wrappedOperation{operation.NewHookOperation(op, "start", nil)}}
+	err := executor.Run(wrapped, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(recorded, jc.DeepEquals, []status.HookExecution{{
+		Hook:     "start",
+		Started:  started,
+		Finished: started,
+		Result:   status.HookSucceeded,
+	}})
+}
+
+func (s *ExecutorSuite) TestRunDoesNotRecordOtherOperations(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	var recorded []status.HookExecution
+	executor := s.newHookRecordingExecutor(c, testclock.NewClock(time.Time{}), &recorded)
+
+	op := &mockOperation{
+		prepare: newStep(nil, nil),
+		execute: newStep(nil, nil),
+		commit:  newStep(nil, nil),
+	}
+	err := executor.Run(op, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(recorded, gc.HasLen, 0)
+}
+
+type wrappedOperation struct {
+	operation.Operation
+}
+
+func (op wrappedOperation) WrappedOperation() operation.Operation {
+	return op.Operation
+}
+
 type mockLockFunc struct {
 	noStepsCalledOnLock bool
 	stepsCalledOnUnlock []bool
 	calledLock          bool
 	calledUnlock        bool
+	priority            machinelock.Priority
 	op                  *mockOperation
 }
 
-func (mock *mockLockFunc) newFailingLock() func(string) (func(), error) {
-	return func(string) (func(), error) {
+func (mock *mockLockFunc) newFailingLock() func(string, machinelock.Priority) (func(), error) {
+	return func(string, machinelock.Priority) (func(), error) {
 		mock.noStepsCalledOnLock = mock.op.prepare.(*mockStep).called == false &&
 			mock.op.commit.(*mockStep).called == false
 		return nil, errors.New("wat")
 	}
 }
 
-func (mock *mockLockFunc) newSucceedingLock() func(string) (func(), error) {
-	return func(string) (func(), error) {
+func (mock *mockLockFunc) newSucceedingLock() func(string, machinelock.Priority) (func(), error) {
+	return func(_ string, priority machinelock.Priority) (func(), error) {
 		mock.calledLock = true
+		mock.priority = priority
 		// Ensure that when we lock no operation has been called
 		mock.noStepsCalledOnLock = mock.op.prepare.(*mockStep).called == false &&
 			mock.op.commit.(*mockStep).called == false
@@ -0,0 +1,36 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package operation
+
+import "github.com/juju/juju/core/machinelock"
+
+// NewHookOperation wraps an operation so that the executor treats it as
+// having run the named hook, which failed with the given error if any.
+func NewHookOperation(op Operation, hookName string, hookErr error) Operation {
+	return &hookOp{Operation: op, hookName: hookName, hookErr: hookErr}
+}
+
+type hookOp struct {
+	Operation
+	hookName string
+	hookErr  error
+}
+
+func (op *hookOp) executedHook() (string, bool, error) {
+	return op.hookName, true, op.hookErr
+}
+
+// ExecutedHook returns the hook run by a hook operation.
+func ExecutedHook(op Operation) (string, bool, error) {
+	return op.(hookOperation).executedHook()
+}
+
+// LockPriority returns the priority with which the executor waits for
+// the machine lock to run the operation.
+func LockPriority(op Operation) machinelock.Priority {
+	if prioritised, ok := Unwrap(op).(prioritisedOperation); ok {
+		return prioritised.lockPriority()
+	}
+	return machinelock.PriorityNormal
+}
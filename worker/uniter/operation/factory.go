@@ -5,6 +5,7 @@ package operation
 
 import (
 	corecharm "github.com/juju/charm/v7"
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 
@@ -22,6 +23,7 @@ type FactoryParams struct {
 	Abort          <-chan struct{}
 	MetricSpoolDir string
 	Logger         Logger
+	Clock          clock.Clock
 }
 
 // NewFactory returns a Factory that creates Operations backed by the supplied
@@ -105,6 +107,7 @@ func (f *factory) NewRunHook(hookInfo hook.Info) (Operation, error) {
 		callbacks:     f.config.Callbacks,
 		runnerFactory: f.config.RunnerFactory,
 		logger:        f.config.Logger,
+		clock:         f.config.Clock,
 	}, nil
 }
 
@@ -63,6 +63,27 @@ type Operation interface {
 	RemoteStateChanged(snapshot remotestate.Snapshot)
 }
 
+// WrappedOperation is implemented by operations which decorate another
+// operation, such as those returned by the resolver's operation factory.
+type WrappedOperation interface {
+	Operation
+
+	// WrappedOperation returns the decorated operation.
+	WrappedOperation() Operation
+}
+
+// Unwrap returns the innermost operation decorated by op, or op itself
+// if it does not wrap another operation.
+func Unwrap(op Operation) Operation {
+	for {
+		wrapped, ok := op.(WrappedOperation)
+		if !ok {
+			return op
+		}
+		op = wrapped.WrappedOperation()
+	}
+}
+
 // Executor records and exposes uniter state, and applies suitable changes as
 // operations are run or skipped.
 type Executor interface {
@@ -9,6 +9,7 @@ import (
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/machinelock"
 	"github.com/juju/juju/worker/common/charmrunner"
 	"github.com/juju/juju/worker/uniter/remotestate"
 	"github.com/juju/juju/worker/uniter/runner"
@@ -131,6 +132,12 @@ func (ra *runAction) Commit(state State) (*State, error) {
 	}.apply(state), nil
 }
 
+// lockPriority is part of the prioritisedOperation interface. Actions
+// are run for an operator, so they go ahead of hooks.
+func (ra *runAction) lockPriority() machinelock.Priority {
+	return machinelock.PriorityHigh
+}
+
 // RemoteStateChanged is called when the remote state changed during execution
 // of the operation.
 func (ra *runAction) RemoteStateChanged(snapshot remotestate.Snapshot) {
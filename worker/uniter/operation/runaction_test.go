@@ -7,12 +7,14 @@ import (
 	"time"
 
 	"github.com/juju/charm/v7/hooks"
+	"github.com/juju/clock/testclock"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/core/machinelock"
 	"github.com/juju/juju/worker/common/charmrunner"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/operation"
@@ -32,6 +34,7 @@ func newOpFactory(runnerFactory runner.Factory, callbacks operation.Callbacks) o
 		RunnerFactory: runnerFactory,
 		Callbacks:     callbacks,
 		Logger:        loggo.GetLogger("test"),
+		Clock:         testclock.NewClock(time.Time{}),
 	})
 }
 
@@ -126,6 +129,13 @@ func (s *RunActionSuite) TestPrepareCtxCalled(c *gc.C) {
 	ctx.CheckCall(c, 0, "Prepare")
 }
 
+func (s *RunActionSuite) TestLockPriority(c *gc.C) {
+	factory := newOpFactory(nil, nil)
+	op, err := factory.NewAction(someActionId)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operation.LockPriority(op), gc.Equals, machinelock.PriorityHigh)
+}
+
 func (s *RunActionSuite) TestPrepareCtxError(c *gc.C) {
 	ctx := &MockContext{actionData: &context.ActionData{Name: "some-action-name"}}
 	ctx.SetErrors(errors.New("ctx prepare error"))
@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/core/machinelock"
 	"github.com/juju/juju/worker/uniter/remotestate"
 	"github.com/juju/juju/worker/uniter/runner"
 	"github.com/juju/juju/worker/uniter/runner/context"
@@ -92,6 +93,12 @@ func (rc *runCommands) Commit(state State) (*State, error) {
 	return nil, nil
 }
 
+// lockPriority is part of the prioritisedOperation interface. Commands
+// are run for an operator, so they go ahead of hooks.
+func (rc *runCommands) lockPriority() machinelock.Priority {
+	return machinelock.PriorityHigh
+}
+
 // RemoteStateChanged is called when the remote state changed during execution
 // of the operation.
 func (rc *runCommands) RemoteStateChanged(snapshot remotestate.Snapshot) {
@@ -5,10 +5,13 @@ package operation
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/charm/v7/hooks"
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/core/machinelock"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/relation"
 	"github.com/juju/juju/core/status"
@@ -29,8 +32,10 @@ type runHook struct {
 	name   string
 	runner runner.Runner
 	logger Logger
+	clock  clock.Clock
 
 	hookFound bool
+	hookErr   error
 
 	RequiresMachineLock
 }
@@ -120,7 +125,9 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	rh.hookFound = true
 	step := Done
 
+	stopFlagging := rh.flagLongRunningHook()
 	handlerType, err := rh.runner.RunHook(rh.name)
+	stopFlagging()
 	cause := errors.Cause(err)
 	switch {
 	case charmrunner.IsMissingHookError(cause):
@@ -134,7 +141,18 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	case err == nil:
 	default:
 		rh.logger.Errorf("hook %q (via %s) failed: %v", rh.name, handlerType, err)
+		rh.hookErr = err
 		rh.callbacks.NotifyHookFailed(rh.name, rh.runner.Context())
+		if timedOut, ok := cause.(*charmrunner.HookTimedOutError); ok {
+			// Record the timeout so it can be reported in the
+			// unit's error status.
+			return stateChange{
+				Kind:        RunHook,
+				Step:        Pending,
+				Hook:        &rh.info,
+				HookTimeout: timedOut.Timeout,
+			}.apply(state), ErrHookFailed
+		}
 		return nil, ErrHookFailed
 	}
 
@@ -158,6 +176,54 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	}.apply(state), err
 }
 
+// executedHook is part of the hookOperation interface.
+func (rh *runHook) executedHook() (string, bool, error) {
+	return rh.name, rh.hookFound, rh.hookErr
+}
+
+// lockPriority is part of the prioritisedOperation interface. The
+// update-status hook is routine, so it waits for everything else.
+func (rh *runHook) lockPriority() machinelock.Priority {
+	if rh.info.Kind == hooks.UpdateStatus {
+		return machinelock.PriorityLow
+	}
+	return machinelock.PriorityNormal
+}
+
+// longRunningHookThreshold is how long a hook may run before it is
+// flagged in the unit's status, when there is no hook timeout.
+const longRunningHookThreshold = 30 * time.Minute
+
+// flagLongRunningHook updates the unit's agent status if the hook is still
+// running after half of the hook timeout, or after longRunningHookThreshold
+// if hooks aren't timed out. The returned func stops the check and must be
+// called once the hook has finished.
+func (rh *runHook) flagLongRunningHook() func() {
+	threshold := longRunningHookThreshold
+	if timeout := rh.runner.Context().HookTimeout(); timeout > 0 {
+		threshold = timeout / 2
+	}
+	done := make(chan struct{})
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		select {
+		case <-rh.clock.After(threshold):
+		case <-done:
+			return
+		}
+		rh.logger.Warningf("hook %q has been running for more than %v", rh.name, threshold)
+		message := fmt.Sprintf("%s (running for more than %v)", RunningHookMessage(rh.name), threshold)
+		if err := rh.callbacks.SetExecutingStatus(message); err != nil {
+			rh.logger.Errorf("cannot flag long-running hook %q: %v", rh.name, err)
+		}
+	}()
+	return func() {
+		close(done)
+		<-finished
+	}
+}
+
 func (rh *runHook) beforeHook(state State) error {
 	var err error
 	switch rh.info.Kind {
@@ -4,13 +4,19 @@
 package operation_test
 
 import (
+	"time"
+
 	"github.com/juju/charm/v7/hooks"
+	"github.com/juju/clock/testclock"
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/core/machinelock"
 	"github.com/juju/juju/core/relation"
+	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/common/charmrunner"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/operation"
@@ -73,6 +79,18 @@ func (s *RunHookSuite) TestPrepareHookCtxCalled(c *gc.C) {
 	ctx.CheckCall(c, 0, "Prepare")
 }
 
+func (s *RunHookSuite) TestLockPriority(c *gc.C) {
+	factory := newOpFactory(nil, nil)
+	op, err := factory.NewRunHook(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operation.LockPriority(op), gc.Equals, machinelock.PriorityNormal)
+
+	// Routine update-status hooks wait for everything else.
+	op, err = factory.NewRunHook(hook.Info{Kind: hooks.UpdateStatus})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operation.LockPriority(op), gc.Equals, machinelock.PriorityLow)
+}
+
 func (s *RunHookSuite) TestPrepareHookCtxError(c *gc.C) {
 	ctx := &MockContext{}
 	ctx.SetErrors(errors.New("ctx prepare error"))
@@ -219,6 +237,8 @@ func (s *RunHookSuite) TestExecuteMissingHookError(c *gc.C) {
 		c.Assert(*runnerFactory.MockNewHookRunner.runner.MockRunHook.gotName, gc.Equals, "some-hook-name")
 		c.Assert(callbacks.MockNotifyHookCompleted.gotName, gc.IsNil)
 		c.Assert(callbacks.MockNotifyHookFailed.gotName, gc.IsNil)
+		_, ran, _ := operation.ExecutedHook(op)
+		c.Assert(ran, jc.IsFalse)
 
 		status, err := runnerFactory.MockNewHookRunner.runner.Context().UnitStatus()
 		c.Assert(err, jc.ErrorIsNil)
@@ -258,6 +278,9 @@ func (s *RunHookSuite) TestExecuteRebootError(c *gc.C) {
 		Step: operation.Done,
 		Hook: &hook.Info{Kind: hooks.ConfigChanged},
 	})
+	_, ran, hookErr := operation.ExecutedHook(op)
+	c.Assert(ran, jc.IsTrue)
+	c.Assert(hookErr, jc.ErrorIsNil)
 	c.Assert(*runnerFactory.MockNewHookRunner.runner.MockRunHook.gotName, gc.Equals, "some-hook-name")
 	c.Assert(*callbacks.MockNotifyHookCompleted.gotName, gc.Equals, "some-hook-name")
 	c.Assert(*callbacks.MockNotifyHookCompleted.gotContext, gc.Equals, runnerFactory.MockNewHookRunner.runner.context)
@@ -277,6 +300,96 @@ func (s *RunHookSuite) TestExecuteOtherError(c *gc.C) {
 	c.Assert(*callbacks.MockNotifyHookFailed.gotName, gc.Equals, "some-hook-name")
 	c.Assert(*callbacks.MockNotifyHookFailed.gotContext, gc.Equals, runnerFactory.MockNewHookRunner.runner.context)
 	c.Assert(callbacks.MockNotifyHookCompleted.gotName, gc.IsNil)
+
+	hookName, ran, hookErr := operation.ExecutedHook(op)
+	c.Assert(hookName, gc.Equals, "some-hook-name")
+	c.Assert(ran, jc.IsTrue)
+	c.Assert(hookErr, gc.Equals, runErr)
+}
+
+func (s *RunHookSuite) TestExecuteHookTimedOut(c *gc.C) {
+	runErr := charmrunner.NewHookTimedOutError("some-hook-name", 10*time.Minute)
+	op, callbacks, runnerFactory := s.getExecuteRunnerTest(c, operation.Factory.NewRunHook, hooks.ConfigChanged, runErr)
+	_, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	newState, err := op.Execute(operation.State{})
+	c.Assert(err, gc.Equals, operation.ErrHookFailed)
+	c.Assert(newState, gc.DeepEquals, &operation.State{
+		Kind:        operation.RunHook,
+		Step:        operation.Pending,
+		Hook:        &hook.Info{Kind: hooks.ConfigChanged},
+		HookTimeout: 10 * time.Minute,
+	})
+	c.Assert(*runnerFactory.MockNewHookRunner.runner.MockRunHook.gotName, gc.Equals, "some-hook-name")
+	c.Assert(*callbacks.MockNotifyHookFailed.gotName, gc.Equals, "some-hook-name")
+	c.Assert(callbacks.MockNotifyHookCompleted.gotName, gc.IsNil)
+}
+
+type executingStatusCallbacks struct {
+	*ExecuteHookCallbacks
+	messages chan string
+}
+
+func (cb *executingStatusCallbacks) SetExecutingStatus(message string) error {
+	cb.messages <- message
+	return nil
+}
+
+func (s *RunHookSuite) TestExecuteFlagsLongRunningHook(c *gc.C) {
+	runnerFactory := NewRunHookRunnerFactory(nil, func(ctx *MockContext) {
+		ctx.hookTimeout = 10 * time.Minute
+	})
+	block := make(chan struct{})
+	runnerFactory.MockNewHookRunner.runner.MockRunHook.block = block
+	callbacks := &executingStatusCallbacks{
+		ExecuteHookCallbacks: &ExecuteHookCallbacks{
+			PrepareHookCallbacks:    NewPrepareHookCallbacks(),
+			MockNotifyHookCompleted: &MockNotify{},
+			MockNotifyHookFailed:    &MockNotify{},
+		},
+		messages: make(chan string, 2),
+	}
+	clock := testclock.NewClock(time.Time{})
+	factory := operation.NewFactory(operation.FactoryParams{
+		RunnerFactory: runnerFactory,
+		Callbacks:     callbacks,
+		Logger:        loggo.GetLogger("test"),
+		Clock:         clock,
+	})
+	op, err := factory.NewRunHook(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result := make(chan error, 1)
+	go func() {
+		_, err := op.Execute(operation.State{})
+		result <- err
+	}()
+	s.assertExecutingStatus(c, callbacks.messages, "running some-hook-name hook")
+
+	// The hook is flagged after half of the hook timeout.
+	c.Assert(clock.WaitAdvance(5*time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.assertExecutingStatus(c, callbacks.messages, "running some-hook-name hook (running for more than 5m0s)")
+
+	close(block)
+	select {
+	case err := <-result:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for hook to finish")
+	}
+	c.Assert(*callbacks.MockNotifyHookCompleted.gotName, gc.Equals, "some-hook-name")
+}
+
+func (s *RunHookSuite) assertExecutingStatus(c *gc.C, messages <-chan string, expected string) {
+	select {
+	case message := <-messages:
+		c.Assert(message, gc.Equals, expected)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for executing status %q", expected)
+	}
 }
 
 func (s *RunHookSuite) TestInstallHookPreservesStatus(c *gc.C) {
@@ -4,6 +4,8 @@
 package operation
 
 import (
+	"time"
+
 	"github.com/juju/charm/v7"
 	"github.com/juju/errors"
 	"gopkg.in/yaml.v2"
@@ -114,6 +116,10 @@ type State struct {
 	// machine/container addresses - it's used to determine whether we
 	// need to run config-changed.
 	AddressesHash string `yaml:"addresses-hash,omitempty"`
+
+	// HookTimeout is set when the pending hook failed by running for
+	// longer than the model's hook timeout, and holds that timeout.
+	HookTimeout time.Duration `yaml:"hook-timeout,omitempty"`
 }
 
 // Validate returns an error if the state violates expectations.
@@ -190,6 +196,7 @@ type stateChange struct {
 	ActionId        *string
 	CharmURL        *charm.URL
 	HasRunStatusSet bool
+	HookTimeout     time.Duration
 }
 
 func (change stateChange) apply(state State) *State {
@@ -199,6 +206,7 @@ func (change stateChange) apply(state State) *State {
 	state.ActionId = change.ActionId
 	state.CharmURL = change.CharmURL
 	state.StatusSet = state.StatusSet || change.HasRunStatusSet
+	state.HookTimeout = change.HookTimeout
 	return &state
 }
 
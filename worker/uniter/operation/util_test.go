@@ -5,6 +5,7 @@ package operation_test
 
 import (
 	"sync"
+	"time"
 
 	corecharm "github.com/juju/charm/v7"
 	"github.com/juju/charm/v7/hooks"
@@ -317,6 +318,7 @@ type MockContext struct {
 	status          jujuc.StatusInfo
 	isLeader        bool
 	relation        *MockRelation
+	hookTimeout     time.Duration
 }
 
 func (mock *MockContext) ActionData() (*context.ActionData, error) {
@@ -326,6 +328,10 @@ func (mock *MockContext) ActionData() (*context.ActionData, error) {
 	return mock.actionData, nil
 }
 
+func (mock *MockContext) HookTimeout() time.Duration {
+	return mock.hookTimeout
+}
+
 func (mock *MockContext) HasExecutionSetUnitStatus() bool {
 	return mock.setStatusCalled
 }
@@ -403,10 +409,14 @@ type MockRunHook struct {
 	gotName         *string
 	err             error
 	setStatusCalled bool
+	block           chan struct{}
 }
 
 func (mock *MockRunHook) Call(hookName string) error {
 	mock.gotName = &hookName
+	if mock.block != nil {
+		<-mock.block
+	}
 	return mock.err
 }
 
@@ -10,6 +10,7 @@ import (
 	"github.com/juju/charm/v7"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/model"
@@ -228,6 +229,8 @@ type mockUnit struct {
 	relationsWatcher                 *mockStringsWatcher
 	instanceDataWatcher              *mockNotifyWatcher
 	lxdProfileName                   string
+	maintenanceWindow                application.MaintenanceWindow
+	deferredHooksFlushed             time.Time
 }
 
 func (u *mockUnit) Life() life.Value {
@@ -298,6 +301,10 @@ func (u *mockUnit) UpgradeSeriesStatus() (model.UpgradeSeriesStatus, error) {
 	return model.UpgradeSeriesPrepareStarted, nil
 }
 
+func (u *mockUnit) MaintenanceWindow() (application.MaintenanceWindow, time.Time, error) {
+	return u.maintenanceWindow, u.deferredHooksFlushed, nil
+}
+
 func (u *mockUnit) SetUpgradeSeriesStatus(status model.UpgradeSeriesStatus) error {
 	return nil
 }
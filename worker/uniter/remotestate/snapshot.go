@@ -4,9 +4,12 @@
 package remotestate
 
 import (
+	"time"
+
 	"github.com/juju/charm/v7"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/names/v4"
@@ -74,6 +77,14 @@ type Snapshot struct {
 	// update-status hook is supposed to run.
 	UpdateStatusVersion int
 
+	// MaintenanceWindow is the maintenance window of the unit's
+	// application, during which non-essential hooks are deferred.
+	MaintenanceWindow application.MaintenanceWindow
+
+	// DeferredHooksFlushed is when the hooks deferred by the
+	// maintenance window were last flushed.
+	DeferredHooksFlushed time.Time
+
 	// ActionsPending is the list of pending actions to
 	// be performed by this unit.
 	ActionsPending []string
@@ -103,6 +114,10 @@ type Snapshot struct {
 
 	// CharmProfileRequired is true if the charm has a lxdprofile.yaml.
 	CharmProfileRequired bool
+
+	// SecretRotations is the list of URIs of secrets owned by the
+	// unit's application which are due to be rotated.
+	SecretRotations []string
 }
 
 // RelationSnapshot tracks the state of a relationship from the viewpoint of the local unit.
@@ -128,4 +143,5 @@ type StorageSnapshot struct {
 	Life     life.Value
 	Attached bool
 	Location string
+	Size     uint64
 }
@@ -11,6 +11,7 @@ import (
 
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/watcher"
@@ -54,6 +55,9 @@ type Unit interface {
 	// relevant for this unit change.
 	WatchRelations() (watcher.StringsWatcher, error)
 	UpgradeSeriesStatus() (model.UpgradeSeriesStatus, error)
+	// MaintenanceWindow returns the maintenance window of the unit's
+	// application, and when the hooks it deferred were last flushed.
+	MaintenanceWindow() (application.MaintenanceWindow, time.Time, error)
 }
 
 type Application interface {
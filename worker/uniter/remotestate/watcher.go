@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	"github.com/juju/worker/v2"
@@ -47,7 +48,9 @@ type RemoteStateWatcher struct {
 	leadershipTracker             leadership.Tracker
 	updateStatusChannel           UpdateStatusTimerFunc
 	commandChannel                <-chan string
+	secretRotateChannel           <-chan []string
 	retryHookChannel              watcher.NotifyChannel
+	deferredHooksChannel          watcher.NotifyChannel
 	applicationChannel            watcher.NotifyChannel
 	containerRunningStatusChannel watcher.NotifyChannel
 	containerRunningStatusFunc    ContainerRunningStatusFunc
@@ -79,7 +82,9 @@ type WatcherConfig struct {
 	LeadershipTracker             leadership.Tracker
 	UpdateStatusChannel           UpdateStatusTimerFunc
 	CommandChannel                <-chan string
+	SecretRotateChannel           <-chan []string
 	RetryHookChannel              watcher.NotifyChannel
+	DeferredHooksChannel          watcher.NotifyChannel
 	ApplicationChannel            watcher.NotifyChannel
 	ContainerRunningStatusChannel watcher.NotifyChannel
 	ContainerRunningStatusFunc    ContainerRunningStatusFunc
@@ -120,7 +125,9 @@ func NewWatcher(config WatcherConfig) (*RemoteStateWatcher, error) {
 		leadershipTracker:             config.LeadershipTracker,
 		updateStatusChannel:           config.UpdateStatusChannel,
 		commandChannel:                config.CommandChannel,
+		secretRotateChannel:           config.SecretRotateChannel,
 		retryHookChannel:              config.RetryHookChannel,
+		deferredHooksChannel:          config.DeferredHooksChannel,
 		applicationChannel:            config.ApplicationChannel,
 		containerRunningStatusChannel: config.ContainerRunningStatusChannel,
 		containerRunningStatusFunc:    config.ContainerRunningStatusFunc,
@@ -193,6 +200,8 @@ func (w *RemoteStateWatcher) Snapshot() Snapshot {
 	copy(snapshot.ActionsPending, w.current.ActionsPending)
 	snapshot.Commands = make([]string, len(w.current.Commands))
 	copy(snapshot.Commands, w.current.Commands)
+	snapshot.SecretRotations = make([]string, len(w.current.SecretRotations))
+	copy(snapshot.SecretRotations, w.current.SecretRotations)
 	snapshot.ActionChanged = make(map[string]int)
 	for k, v := range w.current.ActionChanged {
 		snapshot.ActionChanged[k] = v
@@ -221,6 +230,23 @@ func (w *RemoteStateWatcher) CommandCompleted(completed string) {
 	}
 }
 
+// RotateSecretCompleted is called when the secret-rotate hook has been
+// run for the secret with the given URI.
+func (w *RemoteStateWatcher) RotateSecretCompleted(rotatedURI string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for i, uri := range w.current.SecretRotations {
+		if uri != rotatedURI {
+			continue
+		}
+		w.current.SecretRotations = append(
+			w.current.SecretRotations[:i],
+			w.current.SecretRotations[i+1:]...,
+		)
+		break
+	}
+}
+
 func (w *RemoteStateWatcher) setUp(unitTag names.UnitTag) (err error) {
 	// TODO(axw) move this logic
 	defer func() {
@@ -529,6 +555,9 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 				return errors.New("expected one hash in trust config change")
 			}
 			w.trustHashChanged(hashes[0])
+			if err := w.maintenanceWindowChanged(); err != nil {
+				return errors.Trace(err)
+			}
 			observedEvent(&seenTrustConfigChange)
 
 		case _, ok := <-upgradeSeriesChanges:
@@ -645,12 +674,27 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 			w.logger.Debugf("command enqueued: %v", id)
 			w.commandsChanged(id)
 
+		case uris, ok := <-w.secretRotateChannel:
+			if !ok {
+				return errors.New("secretRotateChannel closed")
+			}
+			w.logger.Debugf("secrets to rotate: %v", uris)
+			w.secretRotationsChanged(uris)
+
 		case _, ok := <-w.retryHookChannel:
 			if !ok {
 				return errors.New("retryHookChannel closed")
 			}
 			w.logger.Debugf("retry hook timer triggered")
 			w.retryHookTimerTriggered()
+
+		case _, ok := <-w.deferredHooksChannel:
+			if !ok {
+				return errors.New("deferredHooksChannel closed")
+			}
+			// Nothing in the snapshot changes, but the resolver
+			// needs to look again to run the deferred hooks.
+			w.logger.Debugf("deferred hooks timer triggered")
 		}
 
 		// Something changed.
@@ -704,6 +748,17 @@ func (w *RemoteStateWatcher) commandsChanged(id string) {
 	w.mu.Unlock()
 }
 
+// secretRotationsChanged is called when secrets are due to be rotated.
+func (w *RemoteStateWatcher) secretRotationsChanged(uris []string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, uri := range uris {
+		if !set.NewStrings(w.current.SecretRotations...).Contains(uri) {
+			w.current.SecretRotations = append(w.current.SecretRotations, uri)
+		}
+	}
+}
+
 // retryHookTimerTriggered is called when the retry hook timer expires.
 func (w *RemoteStateWatcher) retryHookTimerTriggered() {
 	w.mu.Lock()
@@ -756,6 +811,21 @@ func (w *RemoteStateWatcher) applicationChanged() error {
 	w.current.CharmModifiedVersion = ver
 	w.current.CharmProfileRequired = required
 	w.mu.Unlock()
+	return errors.Trace(w.maintenanceWindowChanged())
+}
+
+// maintenanceWindowChanged refreshes the maintenance window of the
+// unit's application, which is held in the application config, and
+// when its deferred hooks were last flushed.
+func (w *RemoteStateWatcher) maintenanceWindowChanged() error {
+	window, flushed, err := w.unit.MaintenanceWindow()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	w.mu.Lock()
+	w.current.MaintenanceWindow = window
+	w.current.DeferredHooksFlushed = flushed
+	w.mu.Unlock()
 	return nil
 }
 
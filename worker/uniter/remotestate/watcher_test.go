@@ -14,6 +14,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/watcher"
@@ -33,6 +34,9 @@ type WatcherSuite struct {
 	applicationWatcher   *mockNotifyWatcher
 	runningStatusWatcher *mockNotifyWatcher
 	running              *remotestate.ContainerRunningStatus
+
+	secretRotateChannel  chan []string
+	deferredHooksChannel chan struct{}
 }
 
 type WatcherSuiteIAAS struct {
@@ -85,6 +89,8 @@ func (s *WatcherSuite) SetUpTest(c *gc.C) {
 	}
 
 	s.clock = testclock.NewClock(time.Now())
+	s.secretRotateChannel = make(chan []string)
+	s.deferredHooksChannel = make(chan struct{})
 }
 
 func (s *WatcherSuiteIAAS) SetUpTest(c *gc.C) {
@@ -130,6 +136,8 @@ func (s *WatcherSuite) setupWatcherConfig() remotestate.WatcherConfig {
 		LeadershipTracker:    s.leadership,
 		UnitTag:              s.st.unit.tag,
 		UpdateStatusChannel:  statusTicker,
+		SecretRotateChannel:  s.secretRotateChannel,
+		DeferredHooksChannel: s.deferredHooksChannel,
 		CanApplyCharmProfile: s.modelType == model.IAAS,
 	}
 }
@@ -388,6 +396,34 @@ func (s *WatcherSuite) TestRemoteStateChanged(c *gc.C) {
 	assertOneChange()
 }
 
+func (s *WatcherSuite) TestMaintenanceWindowChanged(c *gc.C) {
+	s.signalAll()
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().MaintenanceWindow.IsZero(), jc.IsTrue)
+
+	window := application.MaintenanceWindow{Schedule: "0 2 * * *", Duration: time.Hour}
+	s.st.unit.maintenanceWindow = window
+	s.st.unit.applicationConfigSettingsWatcher.changes <- []string{"trusthash2"}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().MaintenanceWindow, gc.Equals, window)
+
+	flushed := time.Date(2020, 7, 1, 2, 30, 0, 0, time.UTC)
+	s.st.unit.deferredHooksFlushed = flushed
+	s.applicationWatcher.changes <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().DeferredHooksFlushed, gc.Equals, flushed)
+}
+
+func (s *WatcherSuite) TestDeferredHooksTimer(c *gc.C) {
+	s.signalAll()
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	initial := s.watcher.Snapshot()
+
+	s.deferredHooksChannel <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot(), jc.DeepEquals, initial)
+}
+
 func (s *WatcherSuite) TestActionsReceived(c *gc.C) {
 	s.signalAll()
 	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
@@ -429,6 +465,26 @@ func (s *WatcherSuite) TestClearResolvedMode(c *gc.C) {
 	c.Assert(snap.ResolvedMode, gc.Equals, params.ResolvedNone)
 }
 
+func (s *WatcherSuite) TestSecretRotations(c *gc.C) {
+	s.signalAll()
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().SecretRotations, gc.HasLen, 0)
+
+	uri := "secret:7fa6cc1c-3f6b-4a04-8b27-9f3a1b9b5e21"
+	other := "secret:2d0c8f35-6a47-4c6e-9f1e-3b8d5a0e7c44"
+	s.secretRotateChannel <- []string{uri, other}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().SecretRotations, jc.DeepEquals, []string{uri, other})
+
+	// Secrets already pending rotation are not queued twice.
+	s.secretRotateChannel <- []string{uri}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().SecretRotations, jc.DeepEquals, []string{uri, other})
+
+	s.watcher.RotateSecretCompleted(uri)
+	c.Assert(s.watcher.Snapshot().SecretRotations, jc.DeepEquals, []string{other})
+}
+
 func (s *WatcherSuite) TestLeadershipChanged(c *gc.C) {
 	s.leadership.claimTicket.result = false
 	s.signalAll()
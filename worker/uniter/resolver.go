@@ -5,8 +5,10 @@ package uniter
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/charm/v7/hooks"
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/apiserver/params"
@@ -23,7 +25,7 @@ import (
 type ResolverConfig struct {
 	ModelType           model.ModelType
 	ClearResolved       func() error
-	ReportHookError     func(hook.Info) error
+	ReportHookError     func(operation.State) error
 	ShouldRetryHooks    bool
 	StartRetryHookTimer func()
 	StopRetryHookTimer  func()
@@ -35,13 +37,34 @@ type ResolverConfig struct {
 	Relations           resolver.Resolver
 	Storage             resolver.Resolver
 	Commands            resolver.Resolver
+	Secrets             resolver.Resolver
 	Container           resolver.Resolver
 	Logger              Logger
+
+	// MaxRetryHookAttempts is the most times a failed hook is retried
+	// automatically before the unit is left in error. Zero means there
+	// is no limit. The count is reset when the hook succeeds or is
+	// resolved, and when the uniter restarts.
+	MaxRetryHookAttempts int
+
+	// Clock is used to tell whether the application's maintenance
+	// window is open.
+	Clock clock.Clock
+
+	// ReportDeferredHooks, if set, is called when there is nothing
+	// left to do, with the number of hooks deferred by the maintenance
+	// window and when the window closes. The count is zero when no
+	// hooks are deferred.
+	ReportDeferredHooks func(count int, until time.Time)
 }
 
 type uniterResolver struct {
 	config                ResolverConfig
 	retryHookTimerStarted bool
+
+	// retryHookAttempts is the number of automatic retries of the
+	// failed hook so far.
+	retryHookAttempts int
 }
 
 // NewUniterResolver returns a new resolver.Resolver for the uniter.
@@ -89,12 +112,15 @@ func (s *uniterResolver) NextOp(
 		return nil, resolver.ErrRestart
 	}
 
-	if s.retryHookTimerStarted && (localState.Kind != operation.RunHook || localState.Step != operation.Pending) {
-		// The hook-retry timer is running, but there is no pending
-		// hook operation. We're not in an error state, so stop the
-		// timer now to reset the backoff state.
+	retrying := s.retryHookTimerStarted || s.retryHookAttempts > 0
+	if retrying && (localState.Kind != operation.RunHook || localState.Step != operation.Pending) {
+		// The hook-retry timer is running, or a retried hook has
+		// succeeded, but there is no pending hook operation. We're
+		// not in an error state, so stop the timer now to reset the
+		// backoff state and the count of attempts.
 		s.config.StopRetryHookTimer()
 		s.retryHookTimerStarted = false
+		s.retryHookAttempts = 0
 	}
 
 	op, err = s.config.CreatedRelations.NextOp(localState, remoteState, opFactory)
@@ -129,6 +155,11 @@ func (s *uniterResolver) NextOp(
 		return op, err
 	}
 
+	op, err = s.config.Secrets.NextOp(localState, remoteState, opFactory)
+	if errors.Cause(err) != resolver.ErrNoOperation {
+		return op, err
+	}
+
 	switch localState.Kind {
 	case operation.RunHook:
 		switch localState.Step {
@@ -223,7 +254,7 @@ func (s *uniterResolver) nextOpHookError(
 ) (operation.Operation, error) {
 
 	// Report the hook error.
-	if err := s.config.ReportHookError(*localState.Hook); err != nil {
+	if err := s.config.ReportHookError(localState.State); err != nil {
 		return nil, errors.Trace(err)
 	}
 
@@ -245,16 +276,26 @@ func (s *uniterResolver) nextOpHookError(
 			return opFactory.NewRunHook(*localState.Hook)
 		}
 		if !s.retryHookTimerStarted && s.config.ShouldRetryHooks {
+			maxAttempts := s.config.MaxRetryHookAttempts
+			if maxAttempts > 0 && s.retryHookAttempts >= maxAttempts {
+				if s.retryHookAttempts == maxAttempts {
+					s.config.Logger.Warningf("hook %q failed after %d retries, waiting to be resolved", localState.Hook.Kind, maxAttempts)
+					s.retryHookAttempts++
+				}
+				return nil, resolver.ErrNoOperation
+			}
 			// We haven't yet started a retry timer, so start one
 			// now. If we retry and fail, retryHookTimerStarted is
 			// cleared so that we'll still start it again.
 			s.config.StartRetryHookTimer()
 			s.retryHookTimerStarted = true
+			s.retryHookAttempts++
 		}
 		return nil, resolver.ErrNoOperation
 	case params.ResolvedRetryHooks:
 		s.config.StopRetryHookTimer()
 		s.retryHookTimerStarted = false
+		s.retryHookAttempts = 0
 		if err := s.config.ClearResolved(); err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -262,6 +303,7 @@ func (s *uniterResolver) nextOpHookError(
 	case params.ResolvedNoHooks:
 		s.config.StopRetryHookTimer()
 		s.retryHookTimerStarted = false
+		s.retryHookAttempts = 0
 		if err := s.config.ClearResolved(); err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -331,15 +373,27 @@ func (s *uniterResolver) nextOp(
 		return opFactory.NewRunHook(hook.Info{Kind: hooks.Install})
 	}
 
+	// While the application's maintenance window is open, charm
+	// upgrades and the config-changed and update-status hooks are
+	// deferred until it closes, unless they have been flushed.
+	deferring, deferUntil := s.deferringHooks(localState, remoteState)
+	var deferred int
+
 	if s.charmModified(localState, remoteState) {
-		return s.newUpgradeOperation(localState, remoteState, opFactory)
+		if !deferring || remoteState.ForceCharmUpgrade {
+			return s.newUpgradeOperation(localState, remoteState, opFactory)
+		}
+		deferred++
 	}
 
 	configHashChanged := localState.ConfigHash != remoteState.ConfigHash
 	trustHashChanged := localState.TrustHash != remoteState.TrustHash
 	addressesHashChanged := localState.AddressesHash != remoteState.AddressesHash
 	if configHashChanged || trustHashChanged || addressesHashChanged {
-		return opFactory.NewRunHook(hook.Info{Kind: hooks.ConfigChanged})
+		if !deferring {
+			return opFactory.NewRunHook(hook.Info{Kind: hooks.ConfigChanged})
+		}
+		deferred++
 	}
 
 	op, err := s.config.Relations.NextOp(localState, remoteState, opFactory)
@@ -349,8 +403,37 @@ func (s *uniterResolver) nextOp(
 
 	// UpdateStatus hook runs if nothing else needs to.
 	if localState.UpdateStatusVersion != remoteState.UpdateStatusVersion {
-		return opFactory.NewRunHook(hook.Info{Kind: hooks.UpdateStatus})
+		if !deferring {
+			return opFactory.NewRunHook(hook.Info{Kind: hooks.UpdateStatus})
+		}
+		deferred++
 	}
 
+	if s.config.ReportDeferredHooks != nil {
+		if deferred == 0 {
+			deferUntil = time.Time{}
+		}
+		s.config.ReportDeferredHooks(deferred, deferUntil)
+	}
 	return nil, resolver.ErrNoOperation
 }
+
+// deferringHooks reports whether non-essential hooks are deferred
+// because the application's maintenance window is open, and if so when
+// the window closes. Hooks are not deferred before the unit has
+// started, so that it can finish installing, or once they have been
+// flushed since the window opened.
+func (s *uniterResolver) deferringHooks(
+	localState resolver.LocalState,
+	remoteState remotestate.Snapshot,
+) (bool, time.Time) {
+	window := remoteState.MaintenanceWindow
+	if window.IsZero() || !localState.Started {
+		return false, time.Time{}
+	}
+	start, end, active := window.ActiveAt(s.config.Clock.Now())
+	if !active || !remoteState.DeferredHooksFlushed.Before(start) {
+		return false, time.Time{}
+	}
+	return true, end
+}
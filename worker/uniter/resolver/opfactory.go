@@ -204,6 +204,11 @@ type onCommitWrapper struct {
 	onCommit func(*operation.State)
 }
 
+// WrappedOperation is part of the operation.WrappedOperation interface.
+func (op onCommitWrapper) WrappedOperation() operation.Operation {
+	return op.Operation
+}
+
 func (op onCommitWrapper) Commit(state operation.State) (*operation.State, error) {
 	st, err := op.Operation.Commit(state)
 	if err != nil {
@@ -218,6 +223,11 @@ type onPrepareWrapper struct {
 	onPrepare func()
 }
 
+// WrappedOperation is part of the operation.WrappedOperation interface.
+func (op onPrepareWrapper) WrappedOperation() operation.Operation {
+	return op.Operation
+}
+
 func (op onPrepareWrapper) Prepare(state operation.State) (*operation.State, error) {
 	st, err := op.Operation.Prepare(state)
 	if err != nil {
@@ -5,9 +5,11 @@ package uniter_test
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/charm/v7"
 	"github.com/juju/charm/v7/hooks"
+	"github.com/juju/clock/testclock"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/names/v4"
@@ -16,6 +18,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/worker/uniter"
 	uniteractions "github.com/juju/juju/worker/uniter/actions"
@@ -81,7 +84,7 @@ func (s *baseResolverSuite) SetUpTest(c *gc.C, modelType model.ModelType) {
 	logger := loggo.GetLogger("test")
 	s.resolverConfig = uniter.ResolverConfig{
 		ClearResolved:       func() error { return s.clearResolved() },
-		ReportHookError:     func(info hook.Info) error { return s.reportHookError(info) },
+		ReportHookError:     func(state operation.State) error { return s.reportHookError(*state.Hook) },
 		StartRetryHookTimer: func() { s.stub.AddCall("StartRetryHookTimer") },
 		StopRetryHookTimer:  func() { s.stub.AddCall("StopRetryHookTimer") },
 		ShouldRetryHooks:    true,
@@ -93,6 +96,7 @@ func (s *baseResolverSuite) SetUpTest(c *gc.C, modelType model.ModelType) {
 		Relations:           nopResolver{},
 		Storage:             storage.NewResolver(logger, attachments, modelType),
 		Commands:            nopResolver{},
+		Secrets:             nopResolver{},
 		ModelType:           modelType,
 		Container:           container.NewResolver(),
 		Logger:              logger,
@@ -301,6 +305,78 @@ func (s *resolverSuite) TestHookErrorStartRetryTimerAgain(c *gc.C) {
 	s.stub.CheckCallNames(c, "StartRetryHookTimer", "StartRetryHookTimer")
 }
 
+func (s *resolverSuite) TestHookErrorMaxRetryAttempts(c *gc.C) {
+	s.resolverConfig.MaxRetryHookAttempts = 2
+	s.resolver = uniter.NewUniterResolver(s.resolverConfig)
+	localState := resolver.LocalState{
+		CharmURL: s.charmURL,
+		State: operation.State{
+			Kind:      operation.RunHook,
+			Step:      operation.Pending,
+			Installed: true,
+			Started:   true,
+			Hook: &hook.Info{
+				Kind: hooks.ConfigChanged,
+			},
+		},
+	}
+
+	for attempt := 1; attempt <= 2; attempt++ {
+		_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+		c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+
+		s.remoteState.RetryHookVersion = attempt
+		op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(op.String(), gc.Equals, "run config-changed hook")
+		localState.RetryHookVersion = attempt
+	}
+	s.stub.CheckCallNames(c, "StartRetryHookTimer", "StartRetryHookTimer")
+
+	// The hook has failed after both retries, so it's left in error.
+	for i := 0; i < 2; i++ {
+		_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+		c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	}
+	s.stub.CheckCallNames(c, "StartRetryHookTimer", "StartRetryHookTimer")
+}
+
+func (s *resolverSuite) TestHookSuccessResetsRetryAttempts(c *gc.C) {
+	s.resolverConfig.MaxRetryHookAttempts = 1
+	s.resolver = uniter.NewUniterResolver(s.resolverConfig)
+	localState := resolver.LocalState{
+		CharmURL: s.charmURL,
+		State: operation.State{
+			Kind:      operation.RunHook,
+			Step:      operation.Pending,
+			Installed: true,
+			Started:   true,
+			Hook: &hook.Info{
+				Kind: hooks.ConfigChanged,
+			},
+		},
+	}
+
+	_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	s.remoteState.RetryHookVersion = 1
+	_, err = s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	localState.RetryHookVersion = 1
+
+	// The retried hook succeeds.
+	localState.Kind = operation.Continue
+	_, err = s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	s.stub.CheckCallNames(c, "StartRetryHookTimer", "StopRetryHookTimer")
+
+	// A later failure is retried again.
+	localState.Kind = operation.RunHook
+	_, err = s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	s.stub.CheckCallNames(c, "StartRetryHookTimer", "StopRetryHookTimer", "StartRetryHookTimer")
+}
+
 func (s *resolverSuite) TestResolvedRetryHooksStopRetryTimer(c *gc.C) {
 	// Resolving a failed hook should stop the retry timer.
 	s.testResolveHookErrorStopRetryTimer(c, params.ResolvedRetryHooks)
@@ -434,6 +510,125 @@ func (s *resolverSuite) TestNoOperationIfHashesAllMatch(c *gc.C) {
 	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
 }
 
+func (s *resolverSuite) setupMaintenanceWindow(now time.Time) {
+	s.remoteState.MaintenanceWindow = application.MaintenanceWindow{
+		Schedule: "0 2 * * *",
+		Duration: time.Hour,
+	}
+	s.resolverConfig.Clock = testclock.NewClock(now)
+	s.resolverConfig.ReportDeferredHooks = func(count int, until time.Time) {
+		s.stub.AddCall("ReportDeferredHooks", count, until)
+	}
+	s.resolver = uniter.NewUniterResolver(s.resolverConfig)
+}
+
+func (s *resolverSuite) TestDefersHooksInMaintenanceWindow(c *gc.C) {
+	s.setupMaintenanceWindow(time.Date(2020, 7, 1, 2, 30, 0, 0, time.UTC))
+	localState := resolver.LocalState{
+		CharmURL:            s.charmURL,
+		UpdateStatusVersion: 1,
+		State: operation.State{
+			Kind:       operation.Continue,
+			Installed:  true,
+			Started:    true,
+			ConfigHash: "somehash",
+		},
+	}
+	s.remoteState.ConfigHash = "differenthash"
+	s.remoteState.UpdateStatusVersion = 2
+
+	_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	s.stub.CheckCalls(c, []testing.StubCall{{
+		"ReportDeferredHooks", []interface{}{2, time.Date(2020, 7, 1, 3, 0, 0, 0, time.UTC)},
+	}})
+}
+
+func (s *resolverSuite) TestDefersUpgradeInMaintenanceWindow(c *gc.C) {
+	s.setupMaintenanceWindow(time.Date(2020, 7, 1, 2, 30, 0, 0, time.UTC))
+	localState := resolver.LocalState{
+		CharmURL: s.charmURL,
+		State: operation.State{
+			Kind:      operation.Continue,
+			Installed: true,
+			Started:   true,
+		},
+	}
+	s.remoteState.CharmURL = charm.MustParseURL("cs:precise/mysql-3")
+
+	_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	s.stub.CheckCalls(c, []testing.StubCall{{
+		"ReportDeferredHooks", []interface{}{1, time.Date(2020, 7, 1, 3, 0, 0, 0, time.UTC)},
+	}})
+
+	s.remoteState.ForceCharmUpgrade = true
+	op, err := s.resolver.NextOp(localState, s.remoteState, setupUpgradeOpFactory())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "upgrade to cs:precise/mysql-3")
+}
+
+func (s *resolverSuite) TestRunsHooksOutsideMaintenanceWindow(c *gc.C) {
+	s.setupMaintenanceWindow(time.Date(2020, 7, 1, 3, 0, 0, 0, time.UTC))
+	localState := resolver.LocalState{
+		CharmURL: s.charmURL,
+		State: operation.State{
+			Kind:       operation.Continue,
+			Installed:  true,
+			Started:    true,
+			ConfigHash: "somehash",
+		},
+	}
+	s.remoteState.ConfigHash = "differenthash"
+
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run config-changed hook")
+
+	localState.ConfigHash = "differenthash"
+	_, err = s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	s.stub.CheckCalls(c, []testing.StubCall{{
+		"ReportDeferredHooks", []interface{}{0, time.Time{}},
+	}})
+}
+
+func (s *resolverSuite) TestRunsHooksOnceFlushed(c *gc.C) {
+	s.setupMaintenanceWindow(time.Date(2020, 7, 1, 2, 30, 0, 0, time.UTC))
+	localState := resolver.LocalState{
+		CharmURL: s.charmURL,
+		State: operation.State{
+			Kind:       operation.Continue,
+			Installed:  true,
+			Started:    true,
+			ConfigHash: "somehash",
+		},
+	}
+	s.remoteState.ConfigHash = "differenthash"
+	s.remoteState.DeferredHooksFlushed = time.Date(2020, 7, 1, 2, 10, 0, 0, time.UTC)
+
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run config-changed hook")
+}
+
+func (s *resolverSuite) TestDoesNotDeferHooksBeforeStarted(c *gc.C) {
+	s.setupMaintenanceWindow(time.Date(2020, 7, 1, 2, 30, 0, 0, time.UTC))
+	localState := resolver.LocalState{
+		CharmURL: s.charmURL,
+		State: operation.State{
+			Kind:       operation.Continue,
+			Installed:  true,
+			ConfigHash: "somehash",
+		},
+	}
+	s.remoteState.ConfigHash = "differenthash"
+
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run config-changed hook")
+}
+
 func (s *resolverSuite) TestUpgradeOperation(c *gc.C) {
 	opFactory := setupUpgradeOpFactory()
 	localState := resolver.LocalState{
@@ -112,6 +112,11 @@ type commandCompleter struct {
 	commandCompleted func()
 }
 
+// WrappedOperation is part of the operation.WrappedOperation interface.
+func (c *commandCompleter) WrappedOperation() operation.Operation {
+	return c.Operation
+}
+
 func (c *commandCompleter) Commit(st operation.State) (*operation.State, error) {
 	result, err := c.Operation.Commit(st)
 	if err == nil {
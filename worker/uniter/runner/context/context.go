@@ -28,6 +28,7 @@ import (
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/quota"
+	"github.com/juju/juju/core/secrets"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/juju/sockets"
 	"github.com/juju/juju/version"
@@ -134,6 +135,7 @@ type HookUnit interface {
 	RequestReboot() error
 	SetUnitStatus(unitStatus status.Status, info string, data map[string]interface{}) error
 	SetAgentStatus(agentStatus status.Status, info string, data map[string]interface{}) error
+	SetHealthCheck(check status.HealthCheck) error
 	State() (params.UnitStateResult, error)
 	Tag() names.UnitTag
 	UnitStatus() (params.StatusResult, error)
@@ -232,6 +234,14 @@ type HookContext struct {
 	// jujuProxySettings are the current juju proxy settings that the uniter knows about.
 	jujuProxySettings proxy.Settings
 
+	// hookTimeout is how long a hook may run before it is killed.
+	hookTimeout time.Duration
+
+	// traceUntil is when tracing of the hook tool calls made by the
+	// charm stops, and traceOutput is whether their output is traced.
+	traceUntil  time.Time
+	traceOutput bool
+
 	// meterStatus is the status of the unit's metering.
 	meterStatus *meterStatus
 
@@ -262,6 +272,9 @@ type HookContext struct {
 	// storageId is the tag of the storage instance associated with the running hook.
 	storageTag names.StorageTag
 
+	// secretURI is the URI of the secret associated with the running hook.
+	secretURI string
+
 	// hasRunSetStatus is true if a call to the status-set was made during the
 	// invocation of a hook.
 	// This attribute is persisted to local uniter state at the end of the hook
@@ -507,6 +520,20 @@ func (ctx *HookContext) ModelType() model.ModelType {
 	return ctx.modelType
 }
 
+// HookTimeout returns how long a hook may run before it is killed.
+// HookTimeout implements runner.Context.
+func (ctx *HookContext) HookTimeout() time.Duration {
+	return ctx.hookTimeout
+}
+
+// TraceUntil returns when tracing of the hook tool calls made by the
+// charm stops, and whether their output is traced. The zero time means
+// they are not traced.
+// TraceUntil implements runner.Context.
+func (ctx *HookContext) TraceUntil() (time.Time, bool) {
+	return ctx.traceUntil, ctx.traceOutput
+}
+
 // UnitStatus will return the status for the current Unit.
 // Implements jujuc.HookContext.ContextStatus, part of runner.Context.
 func (ctx *HookContext) UnitStatus() (*jujuc.StatusInfo, error) {
@@ -616,6 +643,14 @@ func (ctx *HookContext) SetApplicationStatus(applicationStatus jujuc.StatusInfo)
 	)
 }
 
+// SetHealthCheck records the latest result of one of this unit's
+// workload health checks.
+// Implements jujuc.HookContext.ContextStatus, part of runner.Context.
+func (ctx *HookContext) SetHealthCheck(check status.HealthCheck) error {
+	ctx.logger.Tracef("[HEALTH-CHECK] %s: %s %s", check.Name, check.Status, check.Message)
+	return ctx.unit.SetHealthCheck(check)
+}
+
 // HasExecutionSetUnitStatus implements runner.Context.
 func (ctx *HookContext) HasExecutionSetUnitStatus() bool {
 	return ctx.hasRunStatusSet
@@ -827,6 +862,46 @@ func (ctx *HookContext) CloudSpec() (*params.CloudSpec, error) {
 	return ctx.cloudSpec, nil
 }
 
+// CreateSecret creates a secret owned by the unit's application.
+// Implements jujuc.HookContext.ContextSecrets, part of runner.Context.
+func (ctx *HookContext) CreateSecret(args *jujuc.SecretUpsertArgs) (string, error) {
+	return ctx.state.CreateSecret(secretUpsertArgs(args))
+}
+
+// UpdateSecret updates a secret owned by the unit's application.
+// Implements jujuc.HookContext.ContextSecrets, part of runner.Context.
+func (ctx *HookContext) UpdateSecret(uri string, args *jujuc.SecretUpsertArgs) error {
+	return ctx.state.UpdateSecret(uri, secretUpsertArgs(args))
+}
+
+func secretUpsertArgs(args *jujuc.SecretUpsertArgs) *uniter.SecretUpsertArgs {
+	return &uniter.SecretUpsertArgs{
+		RotatePolicy: args.RotatePolicy,
+		ExpireTime:   args.ExpireTime,
+		Description:  args.Description,
+		Label:        args.Label,
+		Value:        args.Value,
+	}
+}
+
+// GetSecret returns the latest value of a secret.
+// Implements jujuc.HookContext.ContextSecrets, part of runner.Context.
+func (ctx *HookContext) GetSecret(uri string) (secrets.SecretData, error) {
+	return ctx.state.GetSecretValue(uri)
+}
+
+// GrantSecret gives an application or unit access to a secret.
+// Implements jujuc.HookContext.ContextSecrets, part of runner.Context.
+func (ctx *HookContext) GrantSecret(uri string, subject names.Tag) error {
+	return ctx.state.GrantSecret(uri, subject)
+}
+
+// RevokeSecret removes an application or unit's access to a secret.
+// Implements jujuc.HookContext.ContextSecrets, part of runner.Context.
+func (ctx *HookContext) RevokeSecret(uri string, subject names.Tag) error {
+	return ctx.state.RevokeSecret(uri, subject)
+}
+
 // ActionParams simply returns the arguments to the Action.
 // Implements jujuc.ActionHookContext.actionHookContext, part of runner.Context.
 func (ctx *HookContext) ActionParams() (map[string]interface{}, error) {
@@ -1024,6 +1099,11 @@ func (ctx *HookContext) HookVars(paths Paths, remote bool, getEnv GetEnvFunc) ([
 	} else if !errors.IsNotFound(err) {
 		return nil, errors.Trace(err)
 	}
+	if ctx.secretURI != "" {
+		vars = append(vars,
+			"JUJU_SECRET_URI="+ctx.secretURI,
+		)
+	}
 	if ctx.actionData != nil {
 		vars = append(vars,
 			"JUJU_ACTION_NAME="+ctx.actionData.Name,
@@ -242,6 +242,9 @@ func (f *contextFactory) HookContext(hookInfo hook.Info) (*HookContext, error) {
 		}
 		hookName = fmt.Sprintf("%s-%s", storageName, hookName)
 	}
+	if hookInfo.Kind == hook.SecretRotate {
+		ctx.secretURI = hookInfo.SecretURI
+	}
 	ctx.id = f.newId(hookName)
 	ctx.hookName = hookName
 	return ctx, nil
@@ -326,6 +329,12 @@ func (f *contextFactory) updateContext(ctx *HookContext) (err error) {
 	}
 	ctx.legacyProxySettings = modelConfig.LegacyProxySettings()
 	ctx.jujuProxySettings = modelConfig.JujuProxySettings()
+	ctx.hookTimeout = modelConfig.HookTimeout()
+
+	ctx.traceUntil, ctx.traceOutput, err = f.unit.TraceUntil()
+	if err != nil {
+		f.logger.Warningf("cannot get hook tool tracing for %v: %v", f.unit.Name(), err)
+	}
 
 	statusCode, statusInfo, err := f.unit.MeterStatus()
 	if err != nil {
@@ -194,6 +194,20 @@ func (mr *MockHookUnitMockRecorder) SetAgentStatus(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAgentStatus", reflect.TypeOf((*MockHookUnit)(nil).SetAgentStatus), arg0, arg1, arg2)
 }
 
+// SetHealthCheck mocks base method
+func (m *MockHookUnit) SetHealthCheck(arg0 status.HealthCheck) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHealthCheck", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHealthCheck indicates an expected call of SetHealthCheck
+func (mr *MockHookUnitMockRecorder) SetHealthCheck(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHealthCheck", reflect.TypeOf((*MockHookUnit)(nil).SetHealthCheck), arg0)
+}
+
 // SetUnitStatus mocks base method
 func (m *MockHookUnit) SetUnitStatus(arg0 status.Status, arg1 string, arg2 map[string]interface{}) error {
 	m.ctrl.T.Helper()
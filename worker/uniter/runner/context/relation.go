@@ -135,6 +135,35 @@ func (ctx *ContextRelation) ReadApplicationSettings(app string) (settings params
 	return ctx.cache.ApplicationSettings(app)
 }
 
+// ReadLocalSettings returns the unit's settings in the relation,
+// including any changes the hook has made to them. Unlike Settings, it
+// doesn't load them to be written back when the hook completes.
+func (ctx *ContextRelation) ReadLocalSettings() (params.Settings, error) {
+	if ctx.settings != nil {
+		return ctx.settings.Map(), nil
+	}
+	settings, err := ctx.ru.Settings()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return settings.Map(), nil
+}
+
+// ReadLocalApplicationSettings returns the application's settings in
+// the relation, including any changes the hook has made to them.
+// Unlike ApplicationSettings, it doesn't load them to be written back
+// when the hook completes.
+func (ctx *ContextRelation) ReadLocalApplicationSettings() (params.Settings, error) {
+	if ctx.applicationSettings != nil {
+		return ctx.applicationSettings.Map(), nil
+	}
+	settings, err := ctx.ru.ApplicationSettings()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return settings.Map(), nil
+}
+
 func (ctx *ContextRelation) Settings() (jujuc.Settings, error) {
 	if ctx.settings == nil {
 		node, err := ctx.ru.Settings()
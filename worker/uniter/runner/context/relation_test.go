@@ -167,6 +167,25 @@ func (s *ContextRelationSuite) TestLocalSettings(c *gc.C) {
 	c.Assert(settings, gc.DeepEquals, map[string]interface{}{"change": "exciting"})
 }
 
+func (s *ContextRelationSuite) TestReadLocalSettings(c *gc.C) {
+	ctx := context.NewContextRelation(s.relUnit, nil)
+
+	// Reading the settings doesn't load them to be written back...
+	m, err := ctx.ReadLocalSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m, gc.HasLen, 0)
+	unitSettings, _ := ctx.FinalSettings()
+	c.Assert(unitSettings, gc.IsNil)
+
+	// ...but does see changes made by the hook.
+	node, err := ctx.Settings()
+	c.Assert(err, jc.ErrorIsNil)
+	node.Set("change", "exciting")
+	m, err = ctx.ReadLocalSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m, gc.DeepEquals, params.Settings{"change": "exciting"})
+}
+
 func convertSettings(settings params.Settings) map[string]interface{} {
 	result := make(map[string]interface{})
 	for k, v := range settings {
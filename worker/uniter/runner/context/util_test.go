@@ -119,6 +119,7 @@ func (s *HookContextSuite) SetUpTest(c *gc.C) {
 				storageData0,
 				storage.StorageKindBlock,
 				"/dev/sdb",
+				1024,
 			},
 		},
 	}
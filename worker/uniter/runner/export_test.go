@@ -5,6 +5,7 @@ package runner
 
 import (
 	"github.com/juju/juju/worker/uniter/runner/context"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
 )
 
 var (
@@ -17,3 +18,15 @@ var (
 func RunnerPaths(rnr Runner) context.Paths {
 	return rnr.(*runner).paths
 }
+
+func TraceToolCall(rnr Runner, call jujuc.ToolCall, includeOutput bool) {
+	rnr.(*runner).traceToolCall(call, includeOutput)
+}
+
+func IsTraced(rnr Runner) bool {
+	return rnr.(*runner).toolCallObserver() != nil
+}
+
+func RunCharmProcessOnRemote(rnr Runner, hook, hookName, charmDir string, env []string) error {
+	return rnr.(*runner).runCharmProcessOnRemote(hook, hookName, charmDir, env)
+}
@@ -5,6 +5,7 @@ package runner
 
 import (
 	"github.com/juju/charm/v7"
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 
@@ -41,6 +42,7 @@ func NewFactory(
 	contextFactory context.ContextFactory,
 	newProcessRunner NewRunnerFunc,
 	remoteExecutor ExecFunc,
+	clock clock.Clock,
 ) (
 	Factory, error,
 ) {
@@ -50,6 +52,7 @@ func NewFactory(
 		contextFactory:   contextFactory,
 		newProcessRunner: newProcessRunner,
 		remoteExecutor:   remoteExecutor,
+		clock:            clock,
 	}
 
 	return f, nil
@@ -65,6 +68,7 @@ type factory struct {
 	paths            context.Paths
 	newProcessRunner NewRunnerFunc
 	remoteExecutor   ExecFunc
+	clock            clock.Clock
 }
 
 // NewCommandRunner exists to satisfy the Factory interface.
@@ -73,7 +77,7 @@ func (f *factory) NewCommandRunner(commandInfo context.CommandInfo) (Runner, err
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	runner := f.newProcessRunner(ctx, f.paths, f.remoteExecutor)
+	runner := f.newProcessRunner(ctx, f.paths, f.remoteExecutor, WithClock(f.clock))
 	return runner, nil
 }
 
@@ -87,7 +91,7 @@ func (f *factory) NewHookRunner(hookInfo hook.Info) (Runner, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	runner := f.newProcessRunner(ctx, f.paths, f.remoteExecutor)
+	runner := f.newProcessRunner(ctx, f.paths, f.remoteExecutor, WithClock(f.clock))
 	return runner, nil
 }
 
@@ -133,7 +137,7 @@ func (f *factory) NewActionRunner(actionId string, cancel <-chan struct{}) (Runn
 	if err != nil {
 		return nil, charmrunner.NewBadActionError(name, err.Error())
 	}
-	runner := f.newProcessRunner(ctx, f.paths, f.remoteExecutor)
+	runner := f.newProcessRunner(ctx, f.paths, f.remoteExecutor, WithClock(f.clock))
 	return runner, nil
 }
 
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/juju/charm/v7/hooks"
+	"github.com/juju/clock"
 	"github.com/juju/clock/testclock"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -185,6 +186,7 @@ func (s *FactorySuite) TestNewHookRunnerWithStorage(c *gc.C) {
 		contextFactory,
 		runner.NewRunner,
 		nil,
+		clock.WallClock,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 
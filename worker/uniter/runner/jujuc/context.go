@@ -18,6 +18,8 @@ import (
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/relation"
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/storage"
 )
 
@@ -44,6 +46,7 @@ type HookContext interface {
 	ContextComponents
 	ContextRelations
 	ContextVersion
+	ContextSecrets
 }
 
 // UnitHookContext is the context for a unit hook.
@@ -157,6 +160,42 @@ type ContextStatus interface {
 
 	// SetApplicationStatus updates the status for the unit's application.
 	SetApplicationStatus(StatusInfo) error
+
+	// SetHealthCheck records the latest result of one of the
+	// unit's workload health checks.
+	SetHealthCheck(status.HealthCheck) error
+}
+
+// SecretUpsertArgs holds the attributes used when creating or updating
+// a secret. Nil attributes are left unchanged on update, and a non
+// empty value adds a new revision.
+type SecretUpsertArgs struct {
+	RotatePolicy *secrets.RotatePolicy
+	ExpireTime   *time.Time
+	Description  *string
+	Label        *string
+	Value        secrets.SecretData
+}
+
+// ContextSecrets is the part of a hook context related to secrets.
+type ContextSecrets interface {
+	// CreateSecret creates a secret owned by the unit's application
+	// and returns its URI.
+	CreateSecret(*SecretUpsertArgs) (string, error)
+
+	// UpdateSecret updates a secret owned by the unit's application.
+	UpdateSecret(string, *SecretUpsertArgs) error
+
+	// GetSecret returns the latest value of a secret.
+	GetSecret(string) (secrets.SecretData, error)
+
+	// GrantSecret gives an application or unit access to a secret
+	// owned by the unit's application.
+	GrantSecret(string, names.Tag) error
+
+	// RevokeSecret removes an application or unit's access to a secret
+	// owned by the unit's application.
+	RevokeSecret(string, names.Tag) error
 }
 
 // RebootPriority is the type used for reboot requests.
@@ -313,6 +352,15 @@ type ContextRelation interface {
 	// ReadApplicationSettings returns the application settings of any remote unit in the relation.
 	ReadApplicationSettings(app string) (params.Settings, error)
 
+	// ReadLocalSettings returns the local unit's settings in this
+	// relation, without loading them to be changed by the hook.
+	ReadLocalSettings() (params.Settings, error)
+
+	// ReadLocalApplicationSettings returns the local application's
+	// settings in this relation, without loading them to be changed by
+	// the hook. Only the leader may read them.
+	ReadLocalApplicationSettings() (params.Settings, error)
+
 	// Suspended returns true if the relation is suspended.
 	Suspended() bool
 
@@ -341,6 +389,10 @@ type ContextStorageAttachment interface {
 	// Location returns the location of the storage: the mount point for
 	// filesystem-kind stores, and the device path for block-kind stores.
 	Location() string
+
+	// Size returns the size of the storage in MiB, or zero if
+	// the size is not known.
+	Size() uint64
 }
 
 // ContextVersion expresses the parts of a hook context related to
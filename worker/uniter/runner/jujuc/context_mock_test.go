@@ -121,6 +121,36 @@ func (mr *MockContextRelationMockRecorder) ReadApplicationSettings(arg0 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadApplicationSettings", reflect.TypeOf((*MockContextRelation)(nil).ReadApplicationSettings), arg0)
 }
 
+// ReadLocalApplicationSettings mocks base method
+func (m *MockContextRelation) ReadLocalApplicationSettings() (params.Settings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadLocalApplicationSettings")
+	ret0, _ := ret[0].(params.Settings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadLocalApplicationSettings indicates an expected call of ReadLocalApplicationSettings
+func (mr *MockContextRelationMockRecorder) ReadLocalApplicationSettings() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadLocalApplicationSettings", reflect.TypeOf((*MockContextRelation)(nil).ReadLocalApplicationSettings))
+}
+
+// ReadLocalSettings mocks base method
+func (m *MockContextRelation) ReadLocalSettings() (params.Settings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadLocalSettings")
+	ret0, _ := ret[0].(params.Settings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadLocalSettings indicates an expected call of ReadLocalSettings
+func (mr *MockContextRelationMockRecorder) ReadLocalSettings() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadLocalSettings", reflect.TypeOf((*MockContextRelation)(nil).ReadLocalSettings))
+}
+
 // ReadSettings mocks base method
 func (m *MockContextRelation) ReadSettings(arg0 string) (params.Settings, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,69 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/core/status"
+)
+
+type healthSetCommand struct {
+	cmd.CommandBase
+	ctx Context
+
+	check status.HealthCheck
+}
+
+// NewHealthSetCommand makes a jujuc health-set command.
+func NewHealthSetCommand(ctx Context) (cmd.Command, error) {
+	return &healthSetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *healthSetCommand) Info() *cmd.Info {
+	doc := `
+health-set records the result of a named health check of the
+workload, such as whether a database is accepting connections or
+a replica is keeping up. Each check keeps only its latest result,
+so charms are expected to use a small, fixed set of check names
+and report them regularly, for example from update-status.
+
+The unit's overall health is the worst result across its checks
+and is shown by "juju status --health".
+`
+	return jujucmd.Info(&cmd.Info{
+		Name:    "health-set",
+		Args:    "<check-name> <pass | warn | fail> [message]",
+		Purpose: "record the result of a workload health check",
+		Doc:     doc,
+	})
+}
+
+// Init is part of the cmd.Command interface.
+func (c *healthSetCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.Errorf("invalid args, require <check-name> <status> [message]")
+	}
+	if !status.IsValidHealthCheckName(args[0]) {
+		return errors.NotValidf("health check name %q", args[0])
+	}
+	c.check.Name = args[0]
+	c.check.Status = status.HealthStatus(args[1])
+	if err := c.check.Status.Validate(); err != nil {
+		return errors.Errorf("invalid health status %q, expected one of [pass warn fail]", args[1])
+	}
+	if len(args) > 2 {
+		c.check.Message = args[2]
+		return cmd.CheckEmpty(args[3:])
+	}
+	return nil
+}
+
+// Run is part of the cmd.Command interface.
+func (c *healthSetCommand) Run(ctx *cmd.Context) error {
+	return c.ctx.SetHealthCheck(c.check)
+}
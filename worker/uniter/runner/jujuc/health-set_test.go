@@ -0,0 +1,74 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type HealthSetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&HealthSetSuite{})
+
+func (s *HealthSetSuite) createCommand(c *gc.C, err error) (*Context, cmd.Command) {
+	hctx := s.GetHookContext(c, -1, "")
+	s.Stub.SetErrors(err)
+
+	com, err := jujuc.NewCommand(hctx, cmdString("health-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	return hctx, jujuc.NewJujucCommandWrappedForTest(com)
+}
+
+var healthSetInitTests = []struct {
+	args []string
+	err  string
+}{
+	{[]string{}, `invalid args, require <check-name> <status> \[message\]`},
+	{[]string{"db"}, `invalid args, require <check-name> <status> \[message\]`},
+	{[]string{"DB", "pass"}, `health check name "DB" not valid`},
+	{[]string{"db", "ok"}, `invalid health status "ok", expected one of \[pass warn fail\]`},
+	{[]string{"db", "pass", "fine", "extra"}, `unrecognized args: \["extra"\]`},
+}
+
+func (s *HealthSetSuite) TestHealthSetInit(c *gc.C) {
+	for i, t := range healthSetInitTests {
+		c.Logf("test %d: %#v", i, t.args)
+		_, com := s.createCommand(c, nil)
+		cmdtesting.TestInit(c, com, t.args, t.err)
+	}
+}
+
+func (s *HealthSetSuite) TestHealthSet(c *gc.C) {
+	hctx, com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"replication", "warn", "lagging by 30s"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(hctx.info.Status.HealthChecks, jc.DeepEquals, map[string]status.HealthCheck{
+		"replication": {
+			Name:    "replication",
+			Status:  status.HealthWarn,
+			Message: "lagging by 30s",
+		},
+	})
+}
+
+func (s *HealthSetSuite) TestHealthSetError(c *gc.C) {
+	hctx, com := s.createCommand(c, errors.New("boom"))
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"db", "fail"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR boom\n")
+	c.Check(hctx.info.Status.HealthChecks, gc.HasLen, 0)
+}
@@ -159,6 +159,30 @@ func (r *ContextRelation) ReadApplicationSettings(name string) (params.Settings,
 	return r.info.RemoteApplicationSettings.Map(), nil
 }
 
+// ReadLocalSettings implements jujuc.ContextRelation.
+func (r *ContextRelation) ReadLocalSettings() (params.Settings, error) {
+	r.stub.AddCall("ReadLocalSettings")
+	if err := r.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	settings, ok := r.info.Units[r.info.UnitName]
+	if !ok {
+		return nil, errors.Errorf("no settings for %q", r.info.UnitName)
+	}
+	return settings.Map(), nil
+}
+
+// ReadLocalApplicationSettings implements jujuc.ContextRelation.
+func (r *ContextRelation) ReadLocalApplicationSettings() (params.Settings, error) {
+	r.stub.AddCall("ReadLocalApplicationSettings")
+	if err := r.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return r.info.LocalApplicationSettings.Map(), nil
+}
+
 // Suspended implements jujuc.ContextRelation.
 func (r *ContextRelation) Suspended() bool {
 	return true
@@ -0,0 +1,59 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuctesting
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+// Secrets holds the values for the hook context.
+type Secrets struct {
+	SecretValue secrets.SecretData
+}
+
+// ContextSecrets is a test double for jujuc.ContextSecrets.
+type ContextSecrets struct {
+	contextBase
+	info *Secrets
+}
+
+// CreateSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) CreateSecret(args *jujuc.SecretUpsertArgs) (string, error) {
+	c.stub.AddCall("CreateSecret", args)
+	if err := c.stub.NextErr(); err != nil {
+		return "", errors.Trace(err)
+	}
+	return "secret:7fa6cc1c-3f6b-4a04-8b27-9f3a1b9b5e21", nil
+}
+
+// UpdateSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) UpdateSecret(uri string, args *jujuc.SecretUpsertArgs) error {
+	c.stub.AddCall("UpdateSecret", uri, args)
+	return errors.Trace(c.stub.NextErr())
+}
+
+// GetSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) GetSecret(uri string) (secrets.SecretData, error) {
+	c.stub.AddCall("GetSecret", uri)
+	if err := c.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return c.info.SecretValue, nil
+}
+
+// GrantSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) GrantSecret(uri string, subject names.Tag) error {
+	c.stub.AddCall("GrantSecret", uri, subject)
+	return errors.Trace(c.stub.NextErr())
+}
+
+// RevokeSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) RevokeSecret(uri string, subject names.Tag) error {
+	c.stub.AddCall("RevokeSecret", uri, subject)
+	return errors.Trace(c.stub.NextErr())
+}
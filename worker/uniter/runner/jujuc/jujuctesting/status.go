@@ -6,6 +6,7 @@ package jujuctesting
 import (
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
 )
 
@@ -13,6 +14,7 @@ import (
 type Status struct {
 	UnitStatus        jujuc.StatusInfo
 	ApplicationStatus jujuc.ApplicationStatusInfo
+	HealthChecks      map[string]status.HealthCheck
 }
 
 // SetApplicationStatus builds a application status and sets it on the Status.
@@ -70,3 +72,17 @@ func (c *ContextStatus) SetApplicationStatus(status jujuc.StatusInfo) error {
 	c.info.SetApplicationStatus(status, nil)
 	return nil
 }
+
+// SetHealthCheck implements jujuc.ContextStatus.
+func (c *ContextStatus) SetHealthCheck(check status.HealthCheck) error {
+	c.stub.AddCall("SetHealthCheck", check)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if c.info.HealthChecks == nil {
+		c.info.HealthChecks = make(map[string]status.HealthCheck)
+	}
+	c.info.HealthChecks[check.Name] = check
+	return nil
+}
@@ -37,12 +37,22 @@ func (s *Storage) SetAttachment(attach jujuc.ContextStorageAttachment) {
 func (s *Storage) SetNewAttachment(name, location string, kind storage.StorageKind, stub *testing.Stub) {
 	tag := names.NewStorageTag(name)
 	attachment := &ContextStorageAttachment{
-		info: &StorageAttachment{tag, kind, location},
+		info: &StorageAttachment{Tag: tag, Kind: kind, Location: location},
 	}
 	attachment.stub = stub
 	s.SetAttachment(attachment)
 }
 
+// SetStorageSize sets the size of the named storage attachment.
+func (s *Storage) SetStorageSize(name string, size uint64) {
+	tag := names.NewStorageTag(name)
+	attachment, ok := s.Storage[tag].(*ContextStorageAttachment)
+	if !ok {
+		panic(fmt.Sprintf("storage %q not added yet", name))
+	}
+	attachment.info.Size = size
+}
+
 // SetBlockStorage adds the attachment to the storage.
 func (s *Storage) SetBlockStorage(name, location string, stub *testing.Stub) {
 	s.SetNewAttachment(name, location, storage.StorageKindBlock, stub)
@@ -13,6 +13,7 @@ type StorageAttachment struct {
 	Tag      names.StorageTag
 	Kind     storage.StorageKind
 	Location string
+	Size     uint64
 }
 
 // ContextStorageAttachment is a test double for jujuc.ContextStorageAttachment.
@@ -44,3 +45,11 @@ func (c *ContextStorageAttachment) Location() string {
 
 	return c.info.Location
 }
+
+// Size implements jujuc.StorageAttachement.
+func (c *ContextStorageAttachment) Size() uint64 {
+	c.stub.AddCall("Size")
+	c.stub.NextErr()
+
+	return c.info.Size
+}
@@ -13,6 +13,8 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/core/status"
 )
 
 // ErrRestrictedContext indicates a method is not implemented in the given context.
@@ -94,6 +96,9 @@ func (*RestrictedContext) SetApplicationStatus(StatusInfo) error {
 	return ErrRestrictedContext
 }
 
+// SetHealthCheck implements hooks.Context.
+func (*RestrictedContext) SetHealthCheck(status.HealthCheck) error { return ErrRestrictedContext }
+
 // AvailabilityZone implements hooks.Context.
 func (*RestrictedContext) AvailabilityZone() (string, error) { return "", ErrRestrictedContext }
 
@@ -215,3 +220,28 @@ func (*RestrictedContext) UnitWorkloadVersion() (string, error) {
 func (*RestrictedContext) SetUnitWorkloadVersion(string) error {
 	return ErrRestrictedContext
 }
+
+// CreateSecret implements jujuc.ContextSecrets.
+func (*RestrictedContext) CreateSecret(*SecretUpsertArgs) (string, error) {
+	return "", ErrRestrictedContext
+}
+
+// UpdateSecret implements jujuc.ContextSecrets.
+func (*RestrictedContext) UpdateSecret(string, *SecretUpsertArgs) error {
+	return ErrRestrictedContext
+}
+
+// GetSecret implements jujuc.ContextSecrets.
+func (*RestrictedContext) GetSecret(string) (secrets.SecretData, error) {
+	return nil, ErrRestrictedContext
+}
+
+// GrantSecret implements jujuc.ContextSecrets.
+func (*RestrictedContext) GrantSecret(string, names.Tag) error {
+	return ErrRestrictedContext
+}
+
+// RevokeSecret implements jujuc.ContextSecrets.
+func (*RestrictedContext) RevokeSecret(string, names.Tag) error {
+	return ErrRestrictedContext
+}
@@ -0,0 +1,149 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/keyvalues"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/core/secrets"
+)
+
+type secretUpsertCommand struct {
+	cmd.CommandBase
+	ctx Context
+
+	rotatePolicy string
+	expireSpec   string
+	description  string
+	label        string
+	data         secrets.SecretData
+
+	expireTime *time.Time
+}
+
+func (c *secretUpsertCommand) setFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.rotatePolicy, "rotate", "", "the secret rotate policy")
+	f.StringVar(&c.expireSpec, "expire", "", "either a duration or time when the secret should expire")
+	f.StringVar(&c.description, "description", "", "the secret description")
+	f.StringVar(&c.label, "label", "", "a label used to identify the secret in hooks")
+}
+
+func (c *secretUpsertCommand) init(args []string) error {
+	if c.rotatePolicy != "" && !secrets.RotatePolicy(c.rotatePolicy).IsValid() {
+		return errors.NotValidf("rotate policy %q", c.rotatePolicy)
+	}
+	if c.expireSpec != "" {
+		expireTime, err := parseExpireTime(c.expireSpec, time.Now())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.expireTime = &expireTime
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	data, err := keyvalues.Parse(args, false)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.data = data
+	return c.data.Validate()
+}
+
+// parseExpireTime parses either a duration from now or an RFC3339 time.
+func parseExpireTime(spec string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(spec); err == nil {
+		if d <= 0 {
+			return time.Time{}, errors.NotValidf("negative expire duration %q", spec)
+		}
+		return now.Add(d).UTC(), nil
+	}
+	t, err := time.Parse(time.RFC3339, spec)
+	if err != nil {
+		return time.Time{}, errors.NotValidf("expire time or duration %q", spec)
+	}
+	return t.UTC(), nil
+}
+
+func (c *secretUpsertCommand) upsertArgs() *SecretUpsertArgs {
+	args := &SecretUpsertArgs{
+		ExpireTime: c.expireTime,
+		Value:      c.data,
+	}
+	if c.rotatePolicy != "" {
+		policy := secrets.RotatePolicy(c.rotatePolicy)
+		args.RotatePolicy = &policy
+	}
+	if c.description != "" {
+		args.Description = &c.description
+	}
+	if c.label != "" {
+		args.Label = &c.label
+	}
+	return args
+}
+
+// secretAddCommand implements the secret-add command.
+type secretAddCommand struct {
+	secretUpsertCommand
+}
+
+// NewSecretAddCommand returns a command to add a secret.
+func NewSecretAddCommand(ctx Context) (cmd.Command, error) {
+	return &secretAddCommand{secretUpsertCommand{ctx: ctx}}, nil
+}
+
+// Info implements cmd.Command.
+func (c *secretAddCommand) Info() *cmd.Info {
+	doc := `
+Add a secret with a list of key values.
+The secret is owned by the unit's application and only the
+application leader may add it. The URI of the new secret is printed.
+
+Keys must be at least 3 characters long, start with a lowercase letter
+and contain only lowercase letters, digits and single hyphens.
+
+Examples:
+    secret-add token=34ae35facd4
+    secret-add --rotate monthly --expire 24h \
+        --description "my database" --label db-password \
+        username=admin password=secret
+`
+	return jujucmd.Info(&cmd.Info{
+		Name:    "secret-add",
+		Args:    "[key=value...]",
+		Purpose: "add a new secret",
+		Doc:     doc,
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *secretAddCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.setFlags(f)
+}
+
+// Init implements cmd.Command.
+func (c *secretAddCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("missing secret value")
+	}
+	return c.init(args)
+}
+
+// Run implements cmd.Command.
+func (c *secretAddCommand) Run(ctx *cmd.Context) error {
+	uri, err := c.ctx.CreateSecret(c.upsertArgs())
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(ctx.Stdout, uri)
+	return nil
+}
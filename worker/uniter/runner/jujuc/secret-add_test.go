@@ -0,0 +1,111 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type SecretAddSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&SecretAddSuite{})
+
+func (s *SecretAddSuite) TestAddSecretInvalidArgs(c *gc.C) {
+	hctx, _ := s.ContextSuite.NewHookContext()
+
+	for _, t := range []struct {
+		args []string
+		err  string
+	}{
+		{
+			args: []string{},
+			err:  "ERROR missing secret value",
+		}, {
+			args: []string{"--rotate", "foo", "password=secret"},
+			err:  `ERROR rotate policy "foo" not valid`,
+		}, {
+			args: []string{"--expire", "-1h", "password=secret"},
+			err:  `ERROR negative expire duration "-1h" not valid`,
+		}, {
+			args: []string{"--expire", "tomorrow", "password=secret"},
+			err:  `ERROR expire time or duration "tomorrow" not valid`,
+		}, {
+			args: []string{"password"},
+			err:  `ERROR expected "key=value", got "password"`,
+		}, {
+			args: []string{"X=secret"},
+			err:  `ERROR secret key "X" not valid`,
+		},
+	} {
+		com, err := jujuc.NewCommand(hctx, cmdString("secret-add"))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, t.args)
+		c.Assert(code, gc.Equals, 2)
+		c.Assert(bufferString(ctx.Stderr), gc.Equals, t.err+"\n")
+	}
+	s.Stub.CheckNoCalls(c)
+}
+
+func (s *SecretAddSuite) TestAddSecret(c *gc.C) {
+	hctx, _ := s.ContextSuite.NewHookContext()
+
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-add"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, []string{
+		"--rotate", "daily",
+		"--expire", "2030-01-01T06:06:06Z",
+		"--description", "sssshhhh",
+		"--label", "foobar",
+		"password=secret", "username=admin",
+	})
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(bufferString(ctx.Stdout), gc.Equals, "secret:7fa6cc1c-3f6b-4a04-8b27-9f3a1b9b5e21\n")
+
+	policy := secrets.RotateDaily
+	expire := time.Date(2030, 1, 1, 6, 6, 6, 0, time.UTC)
+	description := "sssshhhh"
+	label := "foobar"
+	s.Stub.CheckCalls(c, []jujutesting.StubCall{{
+		FuncName: "CreateSecret",
+		Args: []interface{}{&jujuc.SecretUpsertArgs{
+			RotatePolicy: &policy,
+			ExpireTime:   &expire,
+			Description:  &description,
+			Label:        &label,
+			Value:        secrets.SecretData{"password": "secret", "username": "admin"},
+		}},
+	}})
+}
+
+func (s *SecretAddSuite) TestAddSecretExpireDuration(c *gc.C) {
+	hctx, _ := s.ContextSuite.NewHookContext()
+
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-add"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	before := time.Now()
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, []string{
+		"--expire", "1h", "password=secret",
+	})
+	c.Assert(code, gc.Equals, 0)
+	s.Stub.CheckCallNames(c, "CreateSecret")
+	args := s.Stub.Calls()[0].Args[0].(*jujuc.SecretUpsertArgs)
+	c.Assert(args.RotatePolicy, gc.IsNil)
+	c.Assert(args.ExpireTime, gc.NotNil)
+	c.Assert(args.ExpireTime.After(before.Add(time.Hour-time.Second)), jc.IsTrue)
+	c.Assert(args.ExpireTime.Before(time.Now().Add(time.Hour+time.Second)), jc.IsTrue)
+}
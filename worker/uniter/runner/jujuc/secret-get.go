@@ -0,0 +1,83 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/core/secrets"
+)
+
+// secretGetCommand implements the secret-get command.
+type secretGetCommand struct {
+	cmd.CommandBase
+	ctx Context
+	out cmd.Output
+
+	uri string
+	key string
+}
+
+// NewSecretGetCommand returns a command to get a secret value.
+func NewSecretGetCommand(ctx Context) (cmd.Command, error) {
+	return &secretGetCommand{ctx: ctx}, nil
+}
+
+// Info implements cmd.Command.
+func (c *secretGetCommand) Info() *cmd.Info {
+	doc := `
+Get the latest value of a secret, either owned by the unit's application
+or shared with the unit or its application.
+If a key is given, only the value of that key is printed.
+
+Examples:
+    secret-get secret:7fa6cc1c-3f6b-4a04-8b27-9f3a1b9b5e21
+    secret-get secret:7fa6cc1c-3f6b-4a04-8b27-9f3a1b9b5e21 password
+`
+	return jujucmd.Info(&cmd.Info{
+		Name:    "secret-get",
+		Args:    "<uri> [<key>]",
+		Purpose: "get the value of a secret",
+		Doc:     doc,
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *secretGetCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "yaml", cmd.DefaultFormatters.Formatters())
+}
+
+// Init implements cmd.Command.
+func (c *secretGetCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("missing secret URI")
+	}
+	if _, err := secrets.ParseURI(args[0]); err != nil {
+		return errors.Trace(err)
+	}
+	c.uri, args = args[0], args[1:]
+	if len(args) > 0 {
+		c.key, args = args[0], args[1:]
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements cmd.Command.
+func (c *secretGetCommand) Run(ctx *cmd.Context) error {
+	value, err := c.ctx.GetSecret(c.uri)
+	if err != nil {
+		return err
+	}
+	if c.key == "" {
+		return c.out.Write(ctx, value)
+	}
+	v, ok := value[c.key]
+	if !ok {
+		return errors.NotFoundf("key %q in secret %q", c.key, c.uri)
+	}
+	return c.out.Write(ctx, v)
+}
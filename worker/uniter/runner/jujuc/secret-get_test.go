@@ -0,0 +1,61 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type SecretGetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&SecretGetSuite{})
+
+func (s *SecretGetSuite) run(c *gc.C, args ...string) (int, *cmd.Context) {
+	hctx, info := s.ContextSuite.NewHookContext()
+	info.SecretValue = secrets.SecretData{"password": "secret", "username": "admin"}
+
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, args)
+	return code, ctx
+}
+
+func (s *SecretGetSuite) TestGetSecretInvalidArgs(c *gc.C) {
+	code, ctx := s.run(c)
+	c.Assert(code, gc.Equals, 2)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "ERROR missing secret URI\n")
+
+	code, ctx = s.run(c, testSecretURI, "password", "extra")
+	c.Assert(code, gc.Equals, 2)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, `ERROR unrecognized args: ["extra"]`+"\n")
+	s.Stub.CheckNoCalls(c)
+}
+
+func (s *SecretGetSuite) TestGetSecret(c *gc.C) {
+	code, ctx := s.run(c, testSecretURI)
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(bufferString(ctx.Stdout), gc.Equals, "password: secret\nusername: admin\n")
+	s.Stub.CheckCall(c, 0, "GetSecret", testSecretURI)
+}
+
+func (s *SecretGetSuite) TestGetSecretKey(c *gc.C) {
+	code, ctx := s.run(c, testSecretURI, "password")
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(bufferString(ctx.Stdout), gc.Equals, "secret\n")
+}
+
+func (s *SecretGetSuite) TestGetSecretKeyNotFound(c *gc.C) {
+	code, ctx := s.run(c, testSecretURI, "token")
+	c.Assert(code, gc.Equals, 1)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, `ERROR key "token" in secret "`+testSecretURI+`" not found`+"\n")
+}
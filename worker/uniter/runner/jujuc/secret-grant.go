@@ -0,0 +1,110 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/core/secrets"
+)
+
+type secretAccessCommand struct {
+	cmd.CommandBase
+	ctx Context
+
+	uri     string
+	subject names.Tag
+}
+
+func (c *secretAccessCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("missing secret URI")
+	}
+	if _, err := secrets.ParseURI(args[0]); err != nil {
+		return errors.Trace(err)
+	}
+	if len(args) < 2 {
+		return errors.New("missing application or unit")
+	}
+	c.uri = args[0]
+	switch subject := args[1]; {
+	case names.IsValidUnit(subject):
+		c.subject = names.NewUnitTag(subject)
+	case names.IsValidApplication(subject):
+		c.subject = names.NewApplicationTag(subject)
+	default:
+		return errors.NotValidf("application or unit %q", subject)
+	}
+	return cmd.CheckEmpty(args[2:])
+}
+
+// secretGrantCommand implements the secret-grant command.
+type secretGrantCommand struct {
+	secretAccessCommand
+}
+
+// NewSecretGrantCommand returns a command to grant access to a secret.
+func NewSecretGrantCommand(ctx Context) (cmd.Command, error) {
+	return &secretGrantCommand{secretAccessCommand{ctx: ctx}}, nil
+}
+
+// Info implements cmd.Command.
+func (c *secretGrantCommand) Info() *cmd.Info {
+	doc := `
+Grant an application, or a single unit, read access to a secret owned
+by the unit's application. Only the application leader may grant access.
+
+Examples:
+    secret-grant secret:7fa6cc1c-3f6b-4a04-8b27-9f3a1b9b5e21 wordpress
+    secret-grant secret:7fa6cc1c-3f6b-4a04-8b27-9f3a1b9b5e21 wordpress/0
+`
+	return jujucmd.Info(&cmd.Info{
+		Name:    "secret-grant",
+		Args:    "<uri> <application|unit>",
+		Purpose: "grant access to a secret",
+		Doc:     doc,
+	})
+}
+
+// Run implements cmd.Command.
+func (c *secretGrantCommand) Run(_ *cmd.Context) error {
+	return c.ctx.GrantSecret(c.uri, c.subject)
+}
+
+// secretRevokeCommand implements the secret-revoke command.
+type secretRevokeCommand struct {
+	secretAccessCommand
+}
+
+// NewSecretRevokeCommand returns a command to revoke access to a secret.
+func NewSecretRevokeCommand(ctx Context) (cmd.Command, error) {
+	return &secretRevokeCommand{secretAccessCommand{ctx: ctx}}, nil
+}
+
+// Info implements cmd.Command.
+func (c *secretRevokeCommand) Info() *cmd.Info {
+	doc := `
+Revoke access previously granted to an application or unit for a secret
+owned by the unit's application. Only the application leader may revoke
+access.
+
+Examples:
+    secret-revoke secret:7fa6cc1c-3f6b-4a04-8b27-9f3a1b9b5e21 wordpress
+    secret-revoke secret:7fa6cc1c-3f6b-4a04-8b27-9f3a1b9b5e21 wordpress/0
+`
+	return jujucmd.Info(&cmd.Info{
+		Name:    "secret-revoke",
+		Args:    "<uri> <application|unit>",
+		Purpose: "revoke access to a secret",
+		Doc:     doc,
+	})
+}
+
+// Run implements cmd.Command.
+func (c *secretRevokeCommand) Run(_ *cmd.Context) error {
+	return c.ctx.RevokeSecret(c.uri, c.subject)
+}
@@ -0,0 +1,78 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type SecretGrantRevokeSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&SecretGrantRevokeSuite{})
+
+func (s *SecretGrantRevokeSuite) run(c *gc.C, name string, args ...string) (int, *cmd.Context) {
+	hctx, _ := s.ContextSuite.NewHookContext()
+	com, err := jujuc.NewCommand(hctx, cmdString(name))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, args)
+	return code, ctx
+}
+
+func (s *SecretGrantRevokeSuite) TestInvalidArgs(c *gc.C) {
+	for _, name := range []string{"secret-grant", "secret-revoke"} {
+		for _, t := range []struct {
+			args []string
+			err  string
+		}{
+			{
+				args: []string{},
+				err:  "ERROR missing secret URI",
+			}, {
+				args: []string{"foo"},
+				err:  `ERROR secret URI "foo" not valid`,
+			}, {
+				args: []string{testSecretURI},
+				err:  "ERROR missing application or unit",
+			}, {
+				args: []string{testSecretURI, "Foo"},
+				err:  `ERROR application or unit "Foo" not valid`,
+			}, {
+				args: []string{testSecretURI, "wordpress", "extra"},
+				err:  `ERROR unrecognized args: ["extra"]`,
+			},
+		} {
+			code, ctx := s.run(c, name, t.args...)
+			c.Assert(code, gc.Equals, 2)
+			c.Assert(bufferString(ctx.Stderr), gc.Equals, t.err+"\n")
+		}
+	}
+	s.Stub.CheckNoCalls(c)
+}
+
+func (s *SecretGrantRevokeSuite) TestGrantApplication(c *gc.C) {
+	code, _ := s.run(c, "secret-grant", testSecretURI, "wordpress")
+	c.Assert(code, gc.Equals, 0)
+	s.Stub.CheckCall(c, 0, "GrantSecret", testSecretURI, names.NewApplicationTag("wordpress"))
+}
+
+func (s *SecretGrantRevokeSuite) TestGrantUnit(c *gc.C) {
+	code, _ := s.run(c, "secret-grant", testSecretURI, "wordpress/0")
+	c.Assert(code, gc.Equals, 0)
+	s.Stub.CheckCall(c, 0, "GrantSecret", testSecretURI, names.NewUnitTag("wordpress/0"))
+}
+
+func (s *SecretGrantRevokeSuite) TestRevoke(c *gc.C) {
+	code, _ := s.run(c, "secret-revoke", testSecretURI, "wordpress/0")
+	c.Assert(code, gc.Equals, 0)
+	s.Stub.CheckCall(c, 0, "RevokeSecret", testSecretURI, names.NewUnitTag("wordpress/0"))
+}
@@ -0,0 +1,73 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/core/secrets"
+)
+
+// secretUpdateCommand implements the secret-update command.
+type secretUpdateCommand struct {
+	secretUpsertCommand
+
+	uri string
+}
+
+// NewSecretUpdateCommand returns a command to update a secret.
+func NewSecretUpdateCommand(ctx Context) (cmd.Command, error) {
+	return &secretUpdateCommand{secretUpsertCommand: secretUpsertCommand{ctx: ctx}}, nil
+}
+
+// Info implements cmd.Command.
+func (c *secretUpdateCommand) Info() *cmd.Info {
+	doc := `
+Update a secret owned by the unit's application, which only the
+application leader may do. Supplying key values adds a new revision
+of the secret; other attributes are left unchanged unless specified.
+
+Examples:
+    secret-update secret:7fa6cc1c-3f6b-4a04-8b27-9f3a1b9b5e21 token=fb63a8cd23
+    secret-update secret:7fa6cc1c-3f6b-4a04-8b27-9f3a1b9b5e21 --rotate never
+`
+	return jujucmd.Info(&cmd.Info{
+		Name:    "secret-update",
+		Args:    "<uri> [key=value...]",
+		Purpose: "update an existing secret",
+		Doc:     doc,
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *secretUpdateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.setFlags(f)
+}
+
+// Init implements cmd.Command.
+func (c *secretUpdateCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("missing secret URI")
+	}
+	if _, err := secrets.ParseURI(args[0]); err != nil {
+		return errors.Trace(err)
+	}
+	c.uri = args[0]
+	if err := c.init(args[1:]); err != nil {
+		return errors.Trace(err)
+	}
+	if c.data == nil && c.rotatePolicy == "" && c.expireTime == nil &&
+		c.description == "" && c.label == "" {
+		return errors.New("nothing to update")
+	}
+	return nil
+}
+
+// Run implements cmd.Command.
+func (c *secretUpdateCommand) Run(_ *cmd.Context) error {
+	return c.ctx.UpdateSecret(c.uri, c.upsertArgs())
+}
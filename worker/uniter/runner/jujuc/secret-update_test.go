@@ -0,0 +1,75 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type SecretUpdateSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&SecretUpdateSuite{})
+
+const testSecretURI = "secret:7fa6cc1c-3f6b-4a04-8b27-9f3a1b9b5e21"
+
+func (s *SecretUpdateSuite) TestUpdateSecretInvalidArgs(c *gc.C) {
+	hctx, _ := s.ContextSuite.NewHookContext()
+
+	for _, t := range []struct {
+		args []string
+		err  string
+	}{
+		{
+			args: []string{},
+			err:  "ERROR missing secret URI",
+		}, {
+			args: []string{"foo"},
+			err:  `ERROR secret URI "foo" not valid`,
+		}, {
+			args: []string{testSecretURI},
+			err:  "ERROR nothing to update",
+		}, {
+			args: []string{testSecretURI, "--rotate", "foo"},
+			err:  `ERROR rotate policy "foo" not valid`,
+		},
+	} {
+		com, err := jujuc.NewCommand(hctx, cmdString("secret-update"))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, t.args)
+		c.Assert(code, gc.Equals, 2)
+		c.Assert(bufferString(ctx.Stderr), gc.Equals, t.err+"\n")
+	}
+	s.Stub.CheckNoCalls(c)
+}
+
+func (s *SecretUpdateSuite) TestUpdateSecret(c *gc.C) {
+	hctx, _ := s.ContextSuite.NewHookContext()
+
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-update"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, []string{
+		testSecretURI, "--rotate", "never", "password=another",
+	})
+	c.Assert(code, gc.Equals, 0)
+
+	policy := secrets.RotateNever
+	s.Stub.CheckCalls(c, []jujutesting.StubCall{{
+		FuncName: "UpdateSecret",
+		Args: []interface{}{testSecretURI, &jujuc.SecretUpsertArgs{
+			RotatePolicy: &policy,
+			Value:        secrets.SecretData{"password": "another"},
+		}},
+	}})
+}
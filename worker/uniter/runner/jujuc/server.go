@@ -16,6 +16,7 @@ import (
 	"path/filepath"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -61,6 +62,7 @@ var baseCommands = map[string]creator{
 	"juju-reboot" + cmdSuffix:             NewJujuRebootCommand,
 	"status-get" + cmdSuffix:              NewStatusGetCommand,
 	"status-set" + cmdSuffix:              NewStatusSetCommand,
+	"health-set" + cmdSuffix:              NewHealthSetCommand,
 	"network-get" + cmdSuffix:             NewNetworkGetCommand,
 	"application-version-set" + cmdSuffix: NewApplicationVersionSetCommand,
 	"k8s-spec-set" + cmdSuffix:            constructCommandCreator("k8s-spec-set", NewK8sSpecSetCommand),
@@ -84,6 +86,14 @@ var baseCommands = map[string]creator{
 	"state-set" + cmdSuffix:    NewStateSetCommand,
 }
 
+var secretCommands = map[string]creator{
+	"secret-add" + cmdSuffix:    NewSecretAddCommand,
+	"secret-update" + cmdSuffix: NewSecretUpdateCommand,
+	"secret-get" + cmdSuffix:    NewSecretGetCommand,
+	"secret-grant" + cmdSuffix:  NewSecretGrantCommand,
+	"secret-revoke" + cmdSuffix: NewSecretRevokeCommand,
+}
+
 type functionCmdCreator func(Context, string) (cmd.Command, error)
 
 func constructCommandCreator(name string, newCmd functionCmdCreator) creator {
@@ -114,6 +124,7 @@ func allEnabledCommands() map[string]creator {
 	add(baseCommands)
 	add(storageCommands)
 	add(leaderCommands)
+	add(secretCommands)
 	add(registeredCommands)
 	return all
 }
@@ -160,11 +171,28 @@ type Request struct {
 // CmdGetter looks up a Command implementation connected to a particular Context.
 type CmdGetter func(contextId, cmdName string) (cmd.Command, error)
 
+// ToolCall describes a single hook tool invocation handled by a Server.
+type ToolCall struct {
+	ContextId   string
+	CommandName string
+	Args        []string
+	Code        int
+	Stdout      []byte
+	Stderr      []byte
+	Started     time.Time
+	Duration    time.Duration
+}
+
+// ToolCallObserver is called after each hook tool invocation
+// handled by a Server.
+type ToolCallObserver func(ToolCall)
+
 // Jujuc implements the jujuc command in the form required by net/rpc.
 type Jujuc struct {
-	mu     sync.Mutex
-	getCmd CmdGetter
-	token  string
+	mu       sync.Mutex
+	getCmd   CmdGetter
+	token    string
+	observer ToolCallObserver
 }
 
 // badReqErrorf returns an error indicating a bad Request.
@@ -211,18 +239,32 @@ func (j *Jujuc) Main(req Request, resp *exec.ExecResponse) error {
 	logger.Debugf("running hook tool %q", req.CommandName)
 	logger.Tracef("hook context id %q; dir %q", req.ContextId, req.Dir)
 	wrapper := &cmdWrapper{c, nil}
+	started := time.Now()
 	resp.Code = cmd.Main(wrapper, ctx, req.Args)
 	if errors.Cause(wrapper.err) == ErrNoStdin {
 		return ErrNoStdin
 	}
 	resp.Stdout = stdout.Bytes()
 	resp.Stderr = stderr.Bytes()
+	if j.observer != nil {
+		j.observer(ToolCall{
+			ContextId:   req.ContextId,
+			CommandName: req.CommandName,
+			Args:        req.Args,
+			Code:        resp.Code,
+			Stdout:      resp.Stdout,
+			Stderr:      resp.Stderr,
+			Started:     started,
+			Duration:    time.Since(started),
+		})
+	}
 	return nil
 }
 
 // Server implements a server that serves command invocations via
 // a unix domain socket.
 type Server struct {
+	jujuc    *Jujuc
 	socket   sockets.Socket
 	listener net.Listener
 	server   *rpc.Server
@@ -236,7 +278,8 @@ type Server struct {
 // actually do so until Run is called.
 func NewServer(getCmd CmdGetter, socket sockets.Socket, token string) (*Server, error) {
 	server := rpc.NewServer()
-	if err := server.Register(&Jujuc{getCmd: getCmd, token: token}); err != nil {
+	jujuc := &Jujuc{getCmd: getCmd, token: token}
+	if err := server.Register(jujuc); err != nil {
 		return nil, err
 	}
 	listener, err := sockets.Listen(socket)
@@ -244,6 +287,7 @@ func NewServer(getCmd CmdGetter, socket sockets.Socket, token string) (*Server,
 		return nil, errors.Annotate(err, "listening to jujuc socket")
 	}
 	s := &Server{
+		jujuc:    jujuc,
 		socket:   socket,
 		listener: listener,
 		server:   server,
@@ -253,6 +297,14 @@ func NewServer(getCmd CmdGetter, socket sockets.Socket, token string) (*Server,
 	return s, nil
 }
 
+// SetToolCallObserver arranges for observer to be called after every
+// hook tool invocation handled by the server.
+func (s *Server) SetToolCallObserver(observer ToolCallObserver) {
+	s.jujuc.mu.Lock()
+	defer s.jujuc.mu.Unlock()
+	s.jujuc.observer = observer
+}
+
 // Run accepts new connections until it encounters an error, or until Close is
 // called, and then blocks until all existing connections have been closed.
 func (s *Server) Run() (err error) {
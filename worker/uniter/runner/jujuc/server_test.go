@@ -146,6 +146,30 @@ func (s *ServerSuite) TestHappyPath(c *gc.C) {
 	c.Assert(string(content), gc.Equals, "something")
 }
 
+func (s *ServerSuite) TestToolCallObserver(c *gc.C) {
+	var calls []jujuc.ToolCall
+	s.server.SetToolCallObserver(func(call jujuc.ToolCall) {
+		calls = append(calls, call)
+	})
+	dir := c.MkDir()
+	resp, err := s.Call(c, jujuc.Request{
+		ContextId:   "validCtx",
+		Dir:         dir,
+		CommandName: "remote",
+		Args:        []string{"--value", "error"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(calls, gc.HasLen, 1)
+	call := calls[0]
+	c.Check(call.ContextId, gc.Equals, "validCtx")
+	c.Check(call.CommandName, gc.Equals, "remote")
+	c.Check(call.Args, jc.DeepEquals, []string{"--value", "error"})
+	c.Check(call.Code, gc.Equals, resp.Code)
+	c.Check(call.Code, gc.Not(gc.Equals), 0)
+	c.Check(string(call.Stderr), gc.Equals, string(resp.Stderr))
+	c.Check(call.Started.IsZero(), jc.IsFalse)
+}
+
 func (s *ServerSuite) TestNoStdin(c *gc.C) {
 	dir := c.MkDir()
 	_, err := s.Call(c, jujuc.Request{
@@ -266,6 +290,7 @@ var newCommandTests = []struct {
 	{"storage-get", ""},
 	{"status-get", ""},
 	{"status-set", ""},
+	{"health-set", ""},
 	// The error message contains .exe on Windows
 	{"random", "unknown command: random(.exe)?"},
 }
@@ -0,0 +1,25 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner
+
+import (
+	"os"
+	"os/exec"
+)
+
+// setProcessGroup is a no-op on windows, where only the hook process
+// itself is killed.
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// killProcessTree kills the process.
+func killProcessTree(p *os.Process) error {
+	return p.Kill()
+}
+
+// killed reports whether the process could have been killed; windows
+// doesn't record how a process ended, so any process that has exited
+// might have been.
+func killed(state *os.ProcessState) bool {
+	return state != nil
+}
@@ -0,0 +1,421 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package replay
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/juju/charm/v7"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/relation"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+// stubContext is a jujuc.Context backed by a Snapshot. Changes made
+// by hook tools are kept in memory, and are never written anywhere;
+// anything that cannot be answered from the snapshot is rejected as
+// it would be by a restricted context.
+type stubContext struct {
+	jujuc.RestrictedContext
+
+	mu             sync.Mutex
+	snap           *Snapshot
+	relations      map[int]*stubRelation
+	leaderSettings map[string]string
+	charmState     map[string]string
+	unitStatus     *jujuc.StatusInfo
+	appStatus      *jujuc.StatusInfo
+	version        string
+	ports          []network.PortRange
+}
+
+func newStubContext(snap *Snapshot) *stubContext {
+	ctx := &stubContext{
+		snap:           snap,
+		relations:      make(map[int]*stubRelation),
+		leaderSettings: copyMap(snap.LeaderSettings),
+		charmState:     copyMap(snap.CharmState),
+	}
+	for _, rel := range snap.Relations {
+		r := &stubRelation{
+			unit:         snap.Unit,
+			snap:         rel,
+			unitSettings: make(map[string]*stubSettings),
+			appSettings:  make(map[string]*stubSettings),
+		}
+		for unit, settings := range rel.UnitSettings {
+			r.unitSettings[unit] = &stubSettings{copyMap(settings)}
+		}
+		for app, settings := range rel.ApplicationSettings {
+			r.appSettings[app] = &stubSettings{copyMap(settings)}
+		}
+		ctx.relations[rel.Id] = r
+	}
+	return ctx
+}
+
+// UnitName is part of jujuc.Context.
+func (ctx *stubContext) UnitName() string {
+	return ctx.snap.Unit
+}
+
+// ConfigSettings is part of jujuc.Context.
+func (ctx *stubContext) ConfigSettings() (charm.Settings, error) {
+	result := make(charm.Settings, len(ctx.snap.Config))
+	for k, v := range ctx.snap.Config {
+		result[k] = v
+	}
+	return result, nil
+}
+
+// IsLeader is part of jujuc.Context.
+func (ctx *stubContext) IsLeader() (bool, error) {
+	return ctx.snap.IsLeader, nil
+}
+
+// LeaderSettings is part of jujuc.Context.
+func (ctx *stubContext) LeaderSettings() (map[string]string, error) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	return copyMap(ctx.leaderSettings), nil
+}
+
+// WriteLeaderSettings is part of jujuc.Context.
+func (ctx *stubContext) WriteLeaderSettings(settings map[string]string) error {
+	if !ctx.snap.IsLeader {
+		return errors.New("cannot write settings: not the leader")
+	}
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	ctx.leaderSettings = copyMap(settings)
+	return nil
+}
+
+// GetCharmState is part of jujuc.Context.
+func (ctx *stubContext) GetCharmState() (map[string]string, error) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	return copyMap(ctx.charmState), nil
+}
+
+// GetCharmStateValue is part of jujuc.Context.
+func (ctx *stubContext) GetCharmStateValue(key string) (string, error) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	value, ok := ctx.charmState[key]
+	if !ok {
+		return "", errors.NotFoundf("%q", key)
+	}
+	return value, nil
+}
+
+// SetCharmStateValue is part of jujuc.Context.
+func (ctx *stubContext) SetCharmStateValue(key, value string) error {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	ctx.charmState[key] = value
+	return nil
+}
+
+// DeleteCharmStateValue is part of jujuc.Context.
+func (ctx *stubContext) DeleteCharmStateValue(key string) error {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	delete(ctx.charmState, key)
+	return nil
+}
+
+// UnitStatus is part of jujuc.Context.
+func (ctx *stubContext) UnitStatus() (*jujuc.StatusInfo, error) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	if ctx.unitStatus == nil {
+		return &jujuc.StatusInfo{Status: string(status.Unknown)}, nil
+	}
+	info := *ctx.unitStatus
+	return &info, nil
+}
+
+// SetUnitStatus is part of jujuc.Context.
+func (ctx *stubContext) SetUnitStatus(info jujuc.StatusInfo) error {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	ctx.unitStatus = &info
+	return nil
+}
+
+// ApplicationStatus is part of jujuc.Context.
+func (ctx *stubContext) ApplicationStatus() (jujuc.ApplicationStatusInfo, error) {
+	if !ctx.snap.IsLeader {
+		return jujuc.ApplicationStatusInfo{}, errors.New("this unit is not the leader")
+	}
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	info := jujuc.StatusInfo{Status: string(status.Unknown)}
+	if ctx.appStatus != nil {
+		info = *ctx.appStatus
+	}
+	return jujuc.ApplicationStatusInfo{Application: info}, nil
+}
+
+// SetApplicationStatus is part of jujuc.Context.
+func (ctx *stubContext) SetApplicationStatus(info jujuc.StatusInfo) error {
+	if !ctx.snap.IsLeader {
+		return errors.New("this unit is not the leader")
+	}
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	ctx.appStatus = &info
+	return nil
+}
+
+// SetHealthCheck is part of jujuc.Context.
+func (ctx *stubContext) SetHealthCheck(check status.HealthCheck) error {
+	return errors.Trace(check.Validate())
+}
+
+// UnitWorkloadVersion is part of jujuc.Context.
+func (ctx *stubContext) UnitWorkloadVersion() (string, error) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	return ctx.version, nil
+}
+
+// SetUnitWorkloadVersion is part of jujuc.Context.
+func (ctx *stubContext) SetUnitWorkloadVersion(version string) error {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	ctx.version = version
+	return nil
+}
+
+// PublicAddress is part of jujuc.Context.
+func (ctx *stubContext) PublicAddress() (string, error) {
+	if ctx.snap.PublicAddress == "" {
+		return "", errors.NotFoundf("public address")
+	}
+	return ctx.snap.PublicAddress, nil
+}
+
+// PrivateAddress is part of jujuc.Context.
+func (ctx *stubContext) PrivateAddress() (string, error) {
+	if ctx.snap.PrivateAddress == "" {
+		return "", errors.NotFoundf("private address")
+	}
+	return ctx.snap.PrivateAddress, nil
+}
+
+// OpenPorts is part of jujuc.Context.
+func (ctx *stubContext) OpenPorts(protocol string, fromPort, toPort int) error {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	ctx.ports = append(ctx.ports, network.PortRange{
+		Protocol: protocol,
+		FromPort: fromPort,
+		ToPort:   toPort,
+	})
+	return nil
+}
+
+// ClosePorts is part of jujuc.Context.
+func (ctx *stubContext) ClosePorts(protocol string, fromPort, toPort int) error {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	ports := ctx.ports[:0]
+	for _, p := range ctx.ports {
+		if p.Protocol != protocol || p.FromPort != fromPort || p.ToPort != toPort {
+			ports = append(ports, p)
+		}
+	}
+	ctx.ports = ports
+	return nil
+}
+
+// OpenedPorts is part of jujuc.Context.
+func (ctx *stubContext) OpenedPorts() []network.PortRange {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	return append([]network.PortRange(nil), ctx.ports...)
+}
+
+// Relation is part of jujuc.Context.
+func (ctx *stubContext) Relation(id int) (jujuc.ContextRelation, error) {
+	r, ok := ctx.relations[id]
+	if !ok {
+		return nil, errors.NotFoundf("relation")
+	}
+	return r, nil
+}
+
+// RelationIds is part of jujuc.Context.
+func (ctx *stubContext) RelationIds() ([]int, error) {
+	ids := make([]int, 0, len(ctx.relations))
+	for id := range ctx.relations {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids, nil
+}
+
+// HookRelation is part of jujuc.Context.
+func (ctx *stubContext) HookRelation() (jujuc.ContextRelation, error) {
+	if ctx.snap.HookRelation == nil {
+		return nil, errors.NotFoundf("relation")
+	}
+	return ctx.Relation(*ctx.snap.HookRelation)
+}
+
+// RemoteUnitName is part of jujuc.Context.
+func (ctx *stubContext) RemoteUnitName() (string, error) {
+	if ctx.snap.RemoteUnit == "" {
+		return "", errors.NotFoundf("remote unit")
+	}
+	return ctx.snap.RemoteUnit, nil
+}
+
+// RemoteApplicationName is part of jujuc.Context.
+func (ctx *stubContext) RemoteApplicationName() (string, error) {
+	if ctx.snap.RemoteApp == "" {
+		return "", errors.NotFoundf("remote application")
+	}
+	return ctx.snap.RemoteApp, nil
+}
+
+// stubRelation is a jujuc.ContextRelation backed by a RelationSnapshot.
+type stubRelation struct {
+	unit         string
+	snap         RelationSnapshot
+	unitSettings map[string]*stubSettings
+	appSettings  map[string]*stubSettings
+}
+
+// Id is part of jujuc.ContextRelation.
+func (r *stubRelation) Id() int {
+	return r.snap.Id
+}
+
+// Name is part of jujuc.ContextRelation.
+func (r *stubRelation) Name() string {
+	return r.snap.Name
+}
+
+// FakeId is part of jujuc.ContextRelation.
+func (r *stubRelation) FakeId() string {
+	return r.snap.FakeId
+}
+
+// UnitNames is part of jujuc.ContextRelation.
+func (r *stubRelation) UnitNames() []string {
+	return append([]string(nil), r.snap.Units...)
+}
+
+// Settings is part of jujuc.ContextRelation.
+func (r *stubRelation) Settings() (jujuc.Settings, error) {
+	return r.settingsFor(r.unitSettings, r.unit)
+}
+
+// ApplicationSettings is part of jujuc.ContextRelation.
+func (r *stubRelation) ApplicationSettings() (jujuc.Settings, error) {
+	app := strings.SplitN(r.unit, "/", 2)[0]
+	return r.settingsFor(r.appSettings, app)
+}
+
+// ReadSettings is part of jujuc.ContextRelation.
+func (r *stubRelation) ReadSettings(unit string) (params.Settings, error) {
+	s, ok := r.unitSettings[unit]
+	if !ok {
+		return nil, errors.NotFoundf("settings for unit %q (not captured)", unit)
+	}
+	return s.Map(), nil
+}
+
+// ReadApplicationSettings is part of jujuc.ContextRelation.
+func (r *stubRelation) ReadApplicationSettings(app string) (params.Settings, error) {
+	s, ok := r.appSettings[app]
+	if !ok {
+		return nil, errors.NotFoundf("settings for application %q (not captured)", app)
+	}
+	return s.Map(), nil
+}
+
+// ReadLocalSettings is part of jujuc.ContextRelation.
+func (r *stubRelation) ReadLocalSettings() (params.Settings, error) {
+	s, err := r.Settings()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return s.Map(), nil
+}
+
+// ReadLocalApplicationSettings is part of jujuc.ContextRelation.
+func (r *stubRelation) ReadLocalApplicationSettings() (params.Settings, error) {
+	s, err := r.ApplicationSettings()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return s.Map(), nil
+}
+
+// Suspended is part of jujuc.ContextRelation.
+func (r *stubRelation) Suspended() bool {
+	return false
+}
+
+// SetStatus is part of jujuc.ContextRelation.
+func (r *stubRelation) SetStatus(relation.Status) error {
+	return nil
+}
+
+// RemoteApplicationName is part of jujuc.ContextRelation.
+func (r *stubRelation) RemoteApplicationName() string {
+	return r.snap.RemoteApplication
+}
+
+// Life is part of jujuc.ContextRelation.
+func (r *stubRelation) Life() life.Value {
+	return life.Alive
+}
+
+func (r *stubRelation) settingsFor(settings map[string]*stubSettings, key string) (jujuc.Settings, error) {
+	s, ok := settings[key]
+	if !ok {
+		return nil, errors.NotFoundf("settings for %q (not captured)", key)
+	}
+	return s, nil
+}
+
+// stubSettings is an in-memory jujuc.Settings.
+type stubSettings struct {
+	values map[string]string
+}
+
+// Map is part of jujuc.Settings.
+func (s *stubSettings) Map() params.Settings {
+	return mapSettings(s.values)
+}
+
+// Set is part of jujuc.Settings.
+func (s *stubSettings) Set(key, value string) {
+	s.values[key] = value
+}
+
+// Delete is part of jujuc.Settings.
+func (s *stubSettings) Delete(key string) {
+	delete(s.values, key)
+}
+
+func copyMap(in map[string]string) map[string]string {
+	out := make(map[string]string, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,14 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package replay
+
+import (
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+// NewStubContext returns the context hook tools are run against when
+// replaying the snapshot.
+func NewStubContext(snap *Snapshot) jujuc.Context {
+	return newStubContext(snap)
+}
@@ -0,0 +1,172 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package replay
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/kballard/go-shellquote"
+
+	"github.com/juju/juju/juju/sockets"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+// Config holds the information needed to replay a hook.
+type Config struct {
+	// Snapshot holds the context to replay the hook with.
+	Snapshot *Snapshot
+
+	// CharmDir is the directory holding the charm to run the hook from.
+	CharmDir string
+
+	// JujucPath is the path to the jujuc binary, which is linked to
+	// under the name of every hook tool.
+	JujucPath string
+
+	// Stdout and Stderr receive the hook's output.
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// Validate returns an error if the config cannot be used to replay a hook.
+func (config Config) Validate() error {
+	if config.Snapshot == nil {
+		return errors.NotValidf("nil Snapshot")
+	}
+	if config.CharmDir == "" {
+		return errors.NotValidf("empty CharmDir")
+	}
+	if config.JujucPath == "" {
+		return errors.NotValidf("empty JujucPath")
+	}
+	if config.Stdout == nil {
+		return errors.NotValidf("nil Stdout")
+	}
+	if config.Stderr == nil {
+		return errors.NotValidf("nil Stderr")
+	}
+	return nil
+}
+
+// Run runs the snapshot's hook from the charm directory, answering
+// hook tool calls from the snapshot rather than from the controller.
+// It returns every hook tool call made by the hook, in order; if the
+// hook itself fails, the calls made up to that point are returned
+// along with the error.
+func Run(config Config) ([]jujuc.ToolCall, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	snap := config.Snapshot
+	hookPath, err := findHook(config.CharmDir, snap.Hook)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	dir, err := ioutil.TempDir("", "juju-replay-")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer os.RemoveAll(dir)
+	toolsDir := filepath.Join(dir, "tools")
+	if err := linkHookTools(toolsDir, config.JujucPath); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	ctx := newStubContext(snap)
+	contextId := fmt.Sprintf("replay-%s-%s", snap.Unit, snap.Hook)
+	getCmd := func(ctxId, cmdName string) (cmd.Command, error) {
+		if ctxId != contextId {
+			return nil, errors.Errorf("expected context id %q, got %q", contextId, ctxId)
+		}
+		return jujuc.NewCommand(ctx, cmdName)
+	}
+	socket := sockets.Socket{Network: "unix", Address: filepath.Join(dir, "jujuc.socket")}
+	srv, err := jujuc.NewServer(getCmd, socket, "")
+	if err != nil {
+		return nil, errors.Annotate(err, "starting jujuc server")
+	}
+	var (
+		mu    sync.Mutex
+		calls []jujuc.ToolCall
+	)
+	srv.SetToolCallObserver(func(call jujuc.ToolCall) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, call)
+	})
+	go srv.Run()
+	defer srv.Close()
+
+	env := os.Environ()
+	for k, v := range snap.Env {
+		env = append(env, k+"="+v)
+	}
+	env = append(env,
+		"CHARM_DIR="+config.CharmDir,
+		"JUJU_CHARM_DIR="+config.CharmDir,
+		"JUJU_CONTEXT_ID="+contextId,
+		"JUJU_HOOK_NAME="+snap.Hook,
+		"JUJU_AGENT_SOCKET_ADDRESS="+socket.Address,
+		"JUJU_AGENT_SOCKET_NETWORK="+socket.Network,
+		"JUJU_DISPATCH_PATH=hooks/"+snap.Hook,
+		"PATH="+toolsDir+string(os.PathListSeparator)+os.Getenv("PATH"),
+	)
+
+	hook := exec.Command(hookPath)
+	hook.Dir = config.CharmDir
+	hook.Env = env
+	hook.Stdout = config.Stdout
+	hook.Stderr = config.Stderr
+	err = hook.Run()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if err != nil {
+		return calls, errors.Annotatef(err, "running %s hook", snap.Hook)
+	}
+	return calls, nil
+}
+
+// findHook returns the path of the script to run for the named hook,
+// preferring the charm's dispatch script as the uniter does.
+func findHook(charmDir, hookName string) (string, error) {
+	for _, path := range []string{
+		filepath.Join(charmDir, "dispatch"),
+		filepath.Join(charmDir, "hooks", hookName),
+	} {
+		if info, err := os.Stat(path); err == nil && info.Mode()&0111 != 0 {
+			return path, nil
+		}
+	}
+	return "", errors.NotFoundf("%s hook in charm directory %q", hookName, charmDir)
+}
+
+// linkHookTools creates the named directory, holding a symlink to the
+// jujuc binary for every hook tool.
+func linkHookTools(dir, jujucPath string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.Trace(err)
+	}
+	for _, name := range jujuc.CommandNames() {
+		if err := os.Symlink(jujucPath, filepath.Join(dir, name)); err != nil {
+			return errors.Annotatef(err, "linking %s hook tool", name)
+		}
+	}
+	return nil
+}
+
+// FormatArgs returns the arguments of a hook tool call as they would
+// have been typed at a shell.
+func FormatArgs(call jujuc.ToolCall) string {
+	return shellquote.Join(append([]string{call.CommandName}, call.Args...)...)
+}
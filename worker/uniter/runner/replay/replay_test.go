@@ -0,0 +1,237 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package replay_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/exec"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/juju/sockets"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+	"github.com/juju/juju/worker/uniter/runner/replay"
+)
+
+type ReplaySuite struct {
+	testing.IsolationSuite
+	snap *replay.Snapshot
+}
+
+var _ = gc.Suite(&ReplaySuite{})
+
+func (s *ReplaySuite) SetUpTest(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("hook replay is not supported on windows")
+	}
+	s.IsolationSuite.SetUpTest(c)
+	hookRelation := 1
+	s.snap = &replay.Snapshot{
+		Unit:           "wordpress/0",
+		Hook:           "db-relation-changed",
+		Env:            map[string]string{"JUJU_RELATION": "db", "JUJU_REMOTE_UNIT": "mysql/0"},
+		Config:         map[string]interface{}{"title": "My Title"},
+		IsLeader:       true,
+		LeaderSettings: map[string]string{"secret": "sauce"},
+		CharmState:     map[string]string{"installed": "yes"},
+		HookRelation:   &hookRelation,
+		RemoteUnit:     "mysql/0",
+		RemoteApp:      "mysql",
+		Relations: []replay.RelationSnapshot{{
+			Id:                1,
+			Name:              "db",
+			FakeId:            "db:1",
+			RemoteApplication: "mysql",
+			Units:             []string{"mysql/0"},
+			UnitSettings: map[string]map[string]string{
+				"wordpress/0": {"database": "wp"},
+				"mysql/0":     {"host": "10.0.0.2"},
+			},
+			ApplicationSettings: map[string]map[string]string{
+				"mysql": {"user": "admin"},
+			},
+		}},
+	}
+}
+
+func (s *ReplaySuite) runTool(c *gc.C, name string, args ...string) exec.ExecResponse {
+	ctx := replay.NewStubContext(s.snap)
+	getCmd := func(ctxId, cmdName string) (cmd.Command, error) {
+		return jujuc.NewCommand(ctx, cmdName)
+	}
+	socket := sockets.Socket{Network: "unix", Address: filepath.Join(c.MkDir(), "test.sock")}
+	srv, err := jujuc.NewServer(getCmd, socket, "")
+	c.Assert(err, jc.ErrorIsNil)
+	go srv.Run()
+	defer srv.Close()
+
+	client, err := sockets.Dial(socket)
+	c.Assert(err, jc.ErrorIsNil)
+	defer client.Close()
+	var resp exec.ExecResponse
+	err = client.Call("Jujuc.Main", jujuc.Request{
+		ContextId:   "ctx",
+		Dir:         c.MkDir(),
+		CommandName: name,
+		Args:        args,
+	}, &resp)
+	c.Assert(err, jc.ErrorIsNil)
+	return resp
+}
+
+func (s *ReplaySuite) TestStubContextConfig(c *gc.C) {
+	resp := s.runTool(c, "config-get", "title")
+	c.Assert(resp.Code, gc.Equals, 0)
+	c.Assert(string(resp.Stdout), gc.Equals, "My Title\n")
+}
+
+func (s *ReplaySuite) TestStubContextLeadership(c *gc.C) {
+	resp := s.runTool(c, "leader-get", "secret")
+	c.Assert(resp.Code, gc.Equals, 0)
+	c.Assert(string(resp.Stdout), gc.Equals, "sauce\n")
+}
+
+func (s *ReplaySuite) TestStubContextCharmState(c *gc.C) {
+	resp := s.runTool(c, "state-get", "installed")
+	c.Assert(resp.Code, gc.Equals, 0)
+	c.Assert(string(resp.Stdout), gc.Equals, "yes\n")
+}
+
+func (s *ReplaySuite) TestStubContextRelationGet(c *gc.C) {
+	resp := s.runTool(c, "relation-get", "host")
+	c.Assert(resp.Code, gc.Equals, 0)
+	c.Assert(string(resp.Stdout), gc.Equals, "10.0.0.2\n")
+
+	resp = s.runTool(c, "relation-get", "--app", "user", "mysql")
+	c.Assert(resp.Code, gc.Equals, 0)
+	c.Assert(string(resp.Stdout), gc.Equals, "admin\n")
+
+	resp = s.runTool(c, "relation-get", "database", "wordpress/0")
+	c.Assert(resp.Code, gc.Equals, 0)
+	c.Assert(string(resp.Stdout), gc.Equals, "wp\n")
+}
+
+func (s *ReplaySuite) TestStubContextRelationList(c *gc.C) {
+	resp := s.runTool(c, "relation-ids", "db")
+	c.Assert(resp.Code, gc.Equals, 0)
+	c.Assert(string(resp.Stdout), gc.Equals, "db:1\n")
+
+	resp = s.runTool(c, "relation-list")
+	c.Assert(resp.Code, gc.Equals, 0)
+	c.Assert(string(resp.Stdout), gc.Equals, "mysql/0\n")
+}
+
+func (s *ReplaySuite) TestStubContextRestricted(c *gc.C) {
+	resp := s.runTool(c, "storage-list")
+	c.Assert(resp.Code, gc.Not(gc.Equals), 0)
+	c.Assert(string(resp.Stderr), jc.Contains, "not implemented for restricted context")
+}
+
+func (s *ReplaySuite) writeCharm(c *gc.C, hookPath, script string) string {
+	charmDir := c.MkDir()
+	path := filepath.Join(charmDir, hookPath)
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(path, []byte("#!/bin/sh\n"+script), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	return charmDir
+}
+
+func (s *ReplaySuite) TestRun(c *gc.C) {
+	out := filepath.Join(c.MkDir(), "out")
+	charmDir := s.writeCharm(c, "hooks/db-relation-changed", `
+echo "unit=$JUJU_UNIT_NAME" > `+out+`
+echo "relation=$JUJU_RELATION" >> `+out+`
+echo "remote=$JUJU_REMOTE_UNIT" >> `+out+`
+echo "hook=$JUJU_HOOK_NAME" >> `+out+`
+echo "dispatch=$JUJU_DISPATCH_PATH" >> `+out+`
+echo "charm=$JUJU_CHARM_DIR" >> `+out+`
+readlink "${PATH%%:*}/relation-get" >> `+out+`
+test -S "$JUJU_AGENT_SOCKET_ADDRESS" && echo "socket" >> `+out+`
+echo replaying
+`)
+	s.snap.Env["JUJU_UNIT_NAME"] = "wordpress/0"
+	s.PatchEnvironment("PATH", "/usr/bin:/bin")
+
+	var stdout, stderr bytes.Buffer
+	calls, err := replay.Run(replay.Config{
+		Snapshot:  s.snap,
+		CharmDir:  charmDir,
+		JujucPath: "/path/to/jujuc",
+		Stdout:    &stdout,
+		Stderr:    &stderr,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(calls, gc.HasLen, 0)
+	c.Assert(stdout.String(), gc.Equals, "replaying\n")
+
+	data, err := ioutil.ReadFile(out)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(strings.Split(string(data), "\n"), jc.DeepEquals, []string{
+		"unit=wordpress/0",
+		"relation=db",
+		"remote=mysql/0",
+		"hook=db-relation-changed",
+		"dispatch=hooks/db-relation-changed",
+		"charm=" + charmDir,
+		"/path/to/jujuc",
+		"socket",
+		"",
+	})
+}
+
+func (s *ReplaySuite) TestRunPrefersDispatch(c *gc.C) {
+	charmDir := s.writeCharm(c, "dispatch", "echo dispatched $JUJU_DISPATCH_PATH\n")
+	var stdout bytes.Buffer
+	_, err := replay.Run(replay.Config{
+		Snapshot:  s.snap,
+		CharmDir:  charmDir,
+		JujucPath: "/path/to/jujuc",
+		Stdout:    &stdout,
+		Stderr:    &stdout,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stdout.String(), gc.Equals, "dispatched hooks/db-relation-changed\n")
+}
+
+func (s *ReplaySuite) TestRunHookFails(c *gc.C) {
+	charmDir := s.writeCharm(c, "hooks/db-relation-changed", "exit 3\n")
+	var stdout bytes.Buffer
+	_, err := replay.Run(replay.Config{
+		Snapshot:  s.snap,
+		CharmDir:  charmDir,
+		JujucPath: "/path/to/jujuc",
+		Stdout:    &stdout,
+		Stderr:    &stdout,
+	})
+	c.Assert(err, gc.ErrorMatches, "running db-relation-changed hook: exit status 3")
+}
+
+func (s *ReplaySuite) TestRunMissingHook(c *gc.C) {
+	var stdout bytes.Buffer
+	_, err := replay.Run(replay.Config{
+		Snapshot:  s.snap,
+		CharmDir:  c.MkDir(),
+		JujucPath: "/path/to/jujuc",
+		Stdout:    &stdout,
+		Stderr:    &stdout,
+	})
+	c.Assert(err, gc.ErrorMatches, `db-relation-changed hook in charm directory ".*" not found`)
+}
+
+func (s *ReplaySuite) TestFormatArgs(c *gc.C) {
+	formatted := replay.FormatArgs(jujuc.ToolCall{
+		CommandName: "status-set",
+		Args:        []string{"active", "all good"},
+	})
+	c.Assert(formatted, gc.Equals, `status-set active 'all good'`)
+}
@@ -0,0 +1,244 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package replay captures the context a hook was run with, and replays
+// a hook locally against a stub jujuc server using such a capture.
+package replay
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+// Snapshot holds the context a hook was run with.
+type Snapshot struct {
+	Unit           string                 `yaml:"unit"`
+	Hook           string                 `yaml:"hook"`
+	Captured       time.Time              `yaml:"captured"`
+	Env            map[string]string      `yaml:"env,omitempty"`
+	Config         map[string]interface{} `yaml:"config,omitempty"`
+	IsLeader       bool                   `yaml:"is-leader"`
+	LeaderSettings map[string]string      `yaml:"leader-settings,omitempty"`
+	CharmState     map[string]string      `yaml:"charm-state,omitempty"`
+	PublicAddress  string                 `yaml:"public-address,omitempty"`
+	PrivateAddress string                 `yaml:"private-address,omitempty"`
+	HookRelation   *int                   `yaml:"hook-relation,omitempty"`
+	RemoteUnit     string                 `yaml:"remote-unit,omitempty"`
+	RemoteApp      string                 `yaml:"remote-application,omitempty"`
+	Relations      []RelationSnapshot     `yaml:"relations,omitempty"`
+}
+
+// RelationSnapshot holds a relation the unit was participating in when
+// a hook was run. Settings are only captured for the relation the hook
+// was run for.
+type RelationSnapshot struct {
+	Id                  int                          `yaml:"id"`
+	Name                string                       `yaml:"name"`
+	FakeId              string                       `yaml:"fake-id"`
+	RemoteApplication   string                       `yaml:"remote-application,omitempty"`
+	Units               []string                     `yaml:"units,omitempty"`
+	UnitSettings        map[string]map[string]string `yaml:"unit-settings,omitempty"`
+	ApplicationSettings map[string]map[string]string `yaml:"application-settings,omitempty"`
+}
+
+// SnapshotPath returns the path the snapshot for the named hook is
+// written to, relative to the supplied agent directory.
+func SnapshotPath(baseDir, hookName string) string {
+	return filepath.Join(baseDir, "state", "replay", hookName+".yaml")
+}
+
+// CaptureRequestPath returns the path of the file which, when present,
+// asks the unit agent to capture the next run of the named hook.
+func CaptureRequestPath(baseDir, hookName string) string {
+	return filepath.Join(baseDir, "state", "replay", hookName+".capture")
+}
+
+// CaptureRequested reports whether a capture of the next run of the
+// named hook has been requested.
+func CaptureRequested(baseDir, hookName string) bool {
+	_, err := os.Stat(CaptureRequestPath(baseDir, hookName))
+	return err == nil
+}
+
+// excludedEnv holds the environment variables that only make sense
+// for the agent that ran the hook, and which are replaced on replay.
+var excludedEnv = map[string]bool{
+	"JUJU_CONTEXT_ID":           true,
+	"JUJU_CHARM_DIR":            true,
+	"JUJU_AGENT_SOCKET_ADDRESS": true,
+	"JUJU_AGENT_SOCKET_NETWORK": true,
+	"JUJU_AGENT_TOKEN":          true,
+	"JUJU_AGENT_CA_CERT":        true,
+	"JUJU_DISPATCH_PATH":        true,
+}
+
+// Capture records the context the named hook is about to be run with.
+// The env is the environment the hook will be run with, in "key=value"
+// form; only the Juju specific variables are kept.
+func Capture(ctx jujuc.Context, hookName string, env []string, now time.Time) (*Snapshot, error) {
+	snap := &Snapshot{
+		Unit:     ctx.UnitName(),
+		Hook:     hookName,
+		Captured: now.UTC(),
+		Env:      make(map[string]string),
+	}
+	for _, kv := range env {
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) != 2 || !strings.HasPrefix(parts[0], "JUJU_") || excludedEnv[parts[0]] {
+			continue
+		}
+		snap.Env[parts[0]] = parts[1]
+	}
+
+	config, err := ctx.ConfigSettings()
+	if err != nil {
+		return nil, errors.Annotate(err, "reading config")
+	}
+	snap.Config = config
+	if snap.IsLeader, err = ctx.IsLeader(); err != nil {
+		return nil, errors.Annotate(err, "checking leadership")
+	}
+	if snap.LeaderSettings, err = ctx.LeaderSettings(); err != nil {
+		return nil, errors.Annotate(err, "reading leader settings")
+	}
+	if snap.CharmState, err = ctx.GetCharmState(); err != nil {
+		return nil, errors.Annotate(err, "reading charm state")
+	}
+	// Addresses are not available for every unit.
+	snap.PublicAddress, _ = ctx.PublicAddress()
+	snap.PrivateAddress, _ = ctx.PrivateAddress()
+
+	hookRelationId := -1
+	if r, err := ctx.HookRelation(); err == nil {
+		hookRelationId = r.Id()
+		snap.HookRelation = &hookRelationId
+	} else if !errors.IsNotFound(err) {
+		return nil, errors.Annotate(err, "reading hook relation")
+	}
+	if name, err := ctx.RemoteUnitName(); err == nil {
+		snap.RemoteUnit = name
+	} else if !errors.IsNotFound(err) {
+		return nil, errors.Annotate(err, "reading remote unit")
+	}
+	if name, err := ctx.RemoteApplicationName(); err == nil {
+		snap.RemoteApp = name
+	} else if !errors.IsNotFound(err) {
+		return nil, errors.Annotate(err, "reading remote application")
+	}
+
+	ids, err := ctx.RelationIds()
+	if err != nil {
+		return nil, errors.Annotate(err, "reading relations")
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		r, err := ctx.Relation(id)
+		if err != nil {
+			return nil, errors.Annotatef(err, "reading relation %d", id)
+		}
+		rel := RelationSnapshot{
+			Id:                r.Id(),
+			Name:              r.Name(),
+			FakeId:            r.FakeId(),
+			RemoteApplication: r.RemoteApplicationName(),
+			Units:             r.UnitNames(),
+		}
+		if id == hookRelationId {
+			if err := captureRelationSettings(ctx, snap, r, &rel); err != nil {
+				return nil, errors.Annotatef(err, "reading relation %d settings", id)
+			}
+		}
+		snap.Relations = append(snap.Relations, rel)
+	}
+	return snap, nil
+}
+
+func captureRelationSettings(ctx jujuc.Context, snap *Snapshot, r jujuc.ContextRelation, rel *RelationSnapshot) error {
+	rel.UnitSettings = make(map[string]map[string]string)
+	rel.ApplicationSettings = make(map[string]map[string]string)
+
+	// The local settings are read without loading them into the
+	// context, since loaded settings are written back after the hook.
+	local, err := r.ReadLocalSettings()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	rel.UnitSettings[snap.Unit] = local
+	if snap.IsLeader {
+		app, err := r.ReadLocalApplicationSettings()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		appName := strings.SplitN(snap.Unit, "/", 2)[0]
+		rel.ApplicationSettings[appName] = app
+	}
+	if snap.RemoteUnit != "" {
+		settings, err := r.ReadSettings(snap.RemoteUnit)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		rel.UnitSettings[snap.RemoteUnit] = settings
+	}
+	if snap.RemoteApp != "" {
+		settings, err := r.ReadApplicationSettings(snap.RemoteApp)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		rel.ApplicationSettings[snap.RemoteApp] = settings
+	}
+	return nil
+}
+
+// Write writes the snapshot as YAML to the named file. The file is only
+// readable by its owner, since the snapshot may contain credentials.
+func (s *Snapshot) Write(path string) error {
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(utils.AtomicWriteFile(path, data, 0600))
+}
+
+// ParseSnapshot parses a snapshot previously written by Write.
+func ParseSnapshot(data []byte) (*Snapshot, error) {
+	var snap Snapshot
+	if err := yaml.Unmarshal(data, &snap); err != nil {
+		return nil, errors.Annotate(err, "parsing hook snapshot")
+	}
+	if snap.Unit == "" || snap.Hook == "" {
+		return nil, errors.NotValidf("hook snapshot without unit or hook")
+	}
+	return &snap, nil
+}
+
+// ReadSnapshot reads a snapshot previously written by Write.
+func ReadSnapshot(path string) (*Snapshot, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ParseSnapshot(data)
+}
+
+// mapSettings returns settings as a map suitable for params.Settings.
+func mapSettings(settings map[string]string) params.Settings {
+	result := make(params.Settings, len(settings))
+	for k, v := range settings {
+		result[k] = v
+	}
+	return result
+}
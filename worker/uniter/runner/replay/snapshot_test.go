@@ -0,0 +1,160 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package replay_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/charm/v7"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc/jujuctesting"
+	"github.com/juju/juju/worker/uniter/runner/replay"
+)
+
+type SnapshotSuite struct {
+	testing.IsolationSuite
+	stub *testing.Stub
+}
+
+var _ = gc.Suite(&SnapshotSuite{})
+
+func (s *SnapshotSuite) context(c *gc.C) *jujuctesting.Context {
+	stub := &testing.Stub{}
+	s.stub = stub
+	info := &jujuctesting.ContextInfo{}
+	info.Name = "wordpress/0"
+	info.ConfigSettings = charm.Settings{"title": "My Title", "port": 8080}
+	info.IsLeader = true
+	info.LeaderSettings = map[string]string{"secret": "sauce"}
+	info.SetCharmState(map[string]string{"installed": "yes"})
+	info.PublicAddress = "203.0.113.1"
+	info.PrivateAddress = "10.0.0.1"
+
+	db := info.SetNewRelation(1, "db", stub)
+	db.UnitName = "wordpress/0"
+	db.RemoteApplicationName = "mysql"
+	db.SetRelated("wordpress/0", jujuctesting.Settings{"database": "wp"})
+	db.SetRelated("mysql/0", jujuctesting.Settings{"host": "10.0.0.2"})
+	db.SetLocalApplicationSettings(jujuctesting.Settings{"schema": "v2"})
+	db.SetRemoteApplicationSettings(jujuctesting.Settings{"user": "admin"})
+	cache := info.SetNewRelation(2, "cache", stub)
+	cache.SetRelated("memcached/0", jujuctesting.Settings{"port": "11211"})
+
+	info.SetAsRelationHook(1, "mysql/0")
+	info.SetRemoteApplicationName("mysql")
+	return info.Context(stub)
+}
+
+func (s *SnapshotSuite) TestCapture(c *gc.C) {
+	now := time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC)
+	env := []string{
+		"CHARM_DIR=/var/lib/juju/agents/unit-wordpress-0/charm",
+		"JUJU_CHARM_DIR=/var/lib/juju/agents/unit-wordpress-0/charm",
+		"JUJU_CONTEXT_ID=wordpress/0-db-relation-changed-123",
+		"JUJU_AGENT_SOCKET_ADDRESS=@/var/lib/juju/agents/unit-wordpress-0/agent.socket",
+		"JUJU_AGENT_SOCKET_NETWORK=unix",
+		"JUJU_UNIT_NAME=wordpress/0",
+		"JUJU_RELATION=db",
+		"JUJU_RELATION_ID=db:1",
+		"JUJU_REMOTE_UNIT=mysql/0",
+		"PATH=/usr/bin",
+	}
+	snap, err := replay.Capture(s.context(c), "db-relation-changed", env, now)
+	c.Assert(err, jc.ErrorIsNil)
+
+	hookRelation := 1
+	c.Assert(snap, jc.DeepEquals, &replay.Snapshot{
+		Unit:     "wordpress/0",
+		Hook:     "db-relation-changed",
+		Captured: now,
+		Env: map[string]string{
+			"JUJU_UNIT_NAME":   "wordpress/0",
+			"JUJU_RELATION":    "db",
+			"JUJU_RELATION_ID": "db:1",
+			"JUJU_REMOTE_UNIT": "mysql/0",
+		},
+		Config:         map[string]interface{}{"title": "My Title", "port": 8080},
+		IsLeader:       true,
+		LeaderSettings: map[string]string{"secret": "sauce"},
+		CharmState:     map[string]string{"installed": "yes"},
+		PublicAddress:  "203.0.113.1",
+		PrivateAddress: "10.0.0.1",
+		HookRelation:   &hookRelation,
+		RemoteUnit:     "mysql/0",
+		RemoteApp:      "mysql",
+		Relations: []replay.RelationSnapshot{{
+			Id:                1,
+			Name:              "db",
+			FakeId:            "db:1",
+			RemoteApplication: "mysql",
+			Units:             []string{"mysql/0", "wordpress/0"},
+			UnitSettings: map[string]map[string]string{
+				"wordpress/0": {"database": "wp"},
+				"mysql/0":     {"host": "10.0.0.2"},
+			},
+			ApplicationSettings: map[string]map[string]string{
+				"wordpress": {"schema": "v2"},
+				"mysql":     {"user": "admin"},
+			},
+		}, {
+			Id:     2,
+			Name:   "cache",
+			FakeId: "cache:2",
+			Units:  []string{"memcached/0"},
+		}},
+	})
+
+	// The local settings must not be loaded for writing, or the
+	// capture would cause them to be written back after the hook.
+	for _, call := range s.stub.Calls() {
+		c.Check(call.FuncName, gc.Not(gc.Equals), "Settings")
+		c.Check(call.FuncName, gc.Not(gc.Equals), "ApplicationSettings")
+	}
+}
+
+func (s *SnapshotSuite) TestWriteRead(c *gc.C) {
+	snap, err := replay.Capture(s.context(c), "db-relation-changed", []string{"JUJU_UNIT_NAME=wordpress/0"}, time.Now())
+	c.Assert(err, jc.ErrorIsNil)
+
+	path := replay.SnapshotPath(c.MkDir(), "db-relation-changed")
+	c.Assert(path, jc.HasSuffix, filepath.Join("state", "replay", "db-relation-changed.yaml"))
+	err = snap.Write(path)
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := os.Stat(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Mode().Perm(), gc.Equals, os.FileMode(0600))
+
+	read, err := replay.ReadSnapshot(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(read.Captured.Equal(snap.Captured), jc.IsTrue)
+	read.Captured = snap.Captured
+	c.Assert(read, jc.DeepEquals, snap)
+}
+
+func (s *SnapshotSuite) TestCaptureRequested(c *gc.C) {
+	baseDir := c.MkDir()
+	c.Assert(replay.CaptureRequested(baseDir, "install"), jc.IsFalse)
+
+	path := replay.CaptureRequestPath(baseDir, "install")
+	c.Assert(path, jc.HasSuffix, filepath.Join("state", "replay", "install.capture"))
+	err := os.MkdirAll(filepath.Dir(path), 0700)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(path, nil, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(replay.CaptureRequested(baseDir, "install"), jc.IsTrue)
+	c.Assert(replay.CaptureRequested(baseDir, "start"), jc.IsFalse)
+}
+
+func (s *SnapshotSuite) TestParseSnapshotInvalid(c *gc.C) {
+	_, err := replay.ParseSnapshot([]byte("hook: install\n"))
+	c.Assert(err, gc.ErrorMatches, "hook snapshot without unit or hook not valid")
+	_, err = replay.ParseSnapshot([]byte("]["))
+	c.Assert(err, gc.ErrorMatches, "parsing hook snapshot: .*")
+}
@@ -20,6 +20,7 @@ import (
 
 	"github.com/juju/clock"
 	"github.com/juju/cmd"
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	jujuos "github.com/juju/os"
@@ -29,10 +30,12 @@ import (
 
 	"github.com/juju/juju/core/actions"
 	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/service/systemd"
 	"github.com/juju/juju/worker/common/charmrunner"
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/debug"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
+	"github.com/juju/juju/worker/uniter/runner/replay"
 )
 
 // Logger is here to stop the desire of creating a package level Logger.
@@ -116,6 +119,15 @@ type Context interface {
 	ResetExecutionSetUnitStatus()
 	ModelType() model.ModelType
 
+	// HookTimeout returns how long a hook may run before it is killed,
+	// or zero if hooks are not timed out.
+	HookTimeout() time.Duration
+
+	// TraceUntil returns when tracing of the hook tool calls made by
+	// the charm stops, or the zero time if they are not traced, and
+	// whether the output of the tools is traced.
+	TraceUntil() (time.Time, bool)
+
 	Prepare() error
 	Flush(badge string, failure error) error
 
@@ -123,11 +135,32 @@ type Context interface {
 }
 
 // NewRunnerFunc returns a func used to create a Runner backed by the supplied context and paths.
-type NewRunnerFunc func(context Context, paths context.Paths, remoteExecutor ExecFunc) Runner
+type NewRunnerFunc func(context Context, paths context.Paths, remoteExecutor ExecFunc, options ...Option) Runner
+
+// Option configures a Runner created by NewRunner.
+type Option func(*runner)
+
+// WithClock has the runner use the given clock, rather than the wall
+// clock, to time out hooks and to decide whether hook tool calls are
+// traced.
+func WithClock(clock clock.Clock) Option {
+	return func(r *runner) {
+		r.clock = clock
+	}
+}
 
 // NewRunner returns a Runner backed by the supplied context and paths.
-func NewRunner(context Context, paths context.Paths, remoteExecutor ExecFunc) Runner {
-	return &runner{context, paths, remoteExecutor}
+func NewRunner(context Context, paths context.Paths, remoteExecutor ExecFunc, options ...Option) Runner {
+	r := &runner{
+		context:        context,
+		paths:          paths,
+		remoteExecutor: remoteExecutor,
+		clock:          clock.WallClock,
+	}
+	for _, option := range options {
+		option(r)
+	}
+	return r
 }
 
 // ExecParams holds all the necessary parameters for ExecFunc.
@@ -138,6 +171,9 @@ type ExecParams struct {
 	Clock         clock.Clock
 	ProcessSetter func(context.HookProcess)
 	Cancel        <-chan struct{}
+	// Signal, if set, delivers signals for the remote executor to send
+	// to the commands; SIGKILL is sent to their whole process group.
+	Signal <-chan syscall.Signal
 
 	Stdout       io.ReadWriter
 	StdoutLogger charmrunner.Stopper
@@ -173,8 +209,12 @@ type runner struct {
 	paths   context.Paths
 	// remoteExecutor executes commands on a remote workload pod for CAAS.
 	remoteExecutor ExecFunc
+	clock          clock.Clock
 }
 
+// traceModule is the logging module hook tool calls are traced to.
+const traceModule = "juju.jujuc.trace"
+
 func (runner *runner) logger() loggo.Logger {
 	return runner.context.GetLogger("juju.worker.uniter.runner")
 }
@@ -434,6 +474,9 @@ func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string, r
 		env = append(env, "JUJU_AGENT_TOKEN="+token)
 	}
 	env = append(env, "JUJU_DISPATCH_PATH="+charmLocation+"/"+hookName)
+	if charmLocation == "hooks" {
+		runner.captureHookContext(hookName, env)
+	}
 
 	defer func() {
 		err = runner.context.Flush(hookName, err)
@@ -468,6 +511,32 @@ func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string, r
 	return hookHandlerType, runner.runCharmProcessOnLocal(hookScript, hookName, charmDir, env)
 }
 
+// captureHookContext records the context the hook is about to run with,
+// if "juju replay-hook --capture" asked for it, so that the hook can be
+// replayed away from the unit. The request only applies to a single run
+// of the hook. Failing to capture never prevents the hook from running.
+func (runner *runner) captureHookContext(hookName string, env []string) {
+	baseDir := runner.paths.GetBaseDir()
+	if !replay.CaptureRequested(baseDir, hookName) {
+		return
+	}
+	logger := runner.logger()
+	snap, err := replay.Capture(runner.context, hookName, env, runner.clock.Now())
+	if err != nil {
+		logger.Warningf("cannot capture %s hook context for replay: %v", hookName, err)
+		return
+	}
+	path := replay.SnapshotPath(baseDir, hookName)
+	if err := snap.Write(path); err != nil {
+		logger.Warningf("cannot write %s hook context for replay: %v", hookName, err)
+		return
+	}
+	logger.Infof("captured %s hook context for replay", hookName)
+	if err := os.Remove(replay.CaptureRequestPath(baseDir, hookName)); err != nil && !os.IsNotExist(err) {
+		logger.Warningf("cannot remove %s hook capture request: %v", hookName, err)
+	}
+}
+
 // loggerAdaptor implements MessageReceiver and
 // sends messages to a logger.
 type loggerAdaptor struct {
@@ -549,6 +618,19 @@ func (runner *runner) runCharmProcessOnRemote(hook, hookName, charmDir string, e
 		go hookErrLogger.Run()
 	}
 
+	// Hooks, but not actions, are killed along with any processes
+	// they started if they run for longer than the hook timeout.
+	var signal chan syscall.Signal
+	timedOut := make(chan struct{})
+	if timeout := runner.context.HookTimeout(); timeout > 0 && !runningAction {
+		signal = make(chan syscall.Signal, 1)
+		timer := runner.clock.AfterFunc(timeout, func() {
+			close(timedOut)
+			signal <- syscall.SIGKILL
+		})
+		defer timer.Stop()
+	}
+
 	executor, err := runner.getExecutor(runOnRemote)
 	if err != nil {
 		return errors.Trace(err)
@@ -559,6 +641,7 @@ func (runner *runner) runCharmProcessOnRemote(hook, hookName, charmDir string, e
 			Env:          env,
 			WorkingDir:   charmDir,
 			Cancel:       cancel,
+			Signal:       signal,
 			Stdout:       actionOut,
 			StdoutLogger: hookOutLogger,
 			Stderr:       actionErr,
@@ -573,12 +656,27 @@ func (runner *runner) runCharmProcessOnRemote(hook, hookName, charmDir string, e
 		}
 	}
 
+	// A hook which finished just as the timer fired wasn't killed.
+	if err != nil {
+		select {
+		case <-timedOut:
+			return charmrunner.NewHookTimedOutError(hookName, runner.context.HookTimeout())
+		default:
+		}
+	}
 	return errors.Trace(err)
 }
 
 // Check still tested
 func (runner *runner) runCharmProcessOnLocal(hook, hookName, charmDir string, env []string) error {
 	hookCmd := hookCommand(hook)
+	// Run the hook in the unit's slice, if it has one, so that the hook
+	// and any processes it starts are held to the unit's resource limits.
+	// Services started through systemctl are started by systemd, not the
+	// hook, so they run outside the slice.
+	if slice := systemd.NewUnitSlice(runner.context.UnitName()); slice.Installed() {
+		hookCmd = slice.ScopeCommand(hookCmd)
+	}
 	ps := exec.Command(hookCmd[0], hookCmd[1:]...)
 	ps.Env = env
 	ps.Dir = charmDir
@@ -625,15 +723,37 @@ func (runner *runner) runCharmProcessOnLocal(hook, hookName, charmDir string, en
 		go hookErrLogger.Run()
 	}
 
+	// Hooks, but not actions, are killed along with any processes
+	// they started if they run for longer than the hook timeout.
+	var timeout time.Duration
+	if !runningAction {
+		timeout = runner.context.HookTimeout()
+	}
+	if timeout > 0 {
+		setProcessGroup(ps)
+	}
+
 	err = ps.Start()
 	var exitErr error
+	timedOut := make(chan struct{})
 	if err == nil {
 		done := make(chan struct{})
-		if cancel != nil {
+		if cancel != nil || timeout > 0 {
+			var timeoutC <-chan time.Time
+			if timeout > 0 {
+				timer := runner.clock.NewTimer(timeout)
+				defer timer.Stop()
+				timeoutC = timer.Chan()
+			}
 			go func() {
 				select {
 				case <-cancel:
 					ps.Process.Kill()
+				case <-timeoutC:
+					close(timedOut)
+					if err := killProcessTree(ps.Process); err != nil {
+						runner.logger().Warningf("killing %s hook: %v", hookName, err)
+					}
 				case <-done:
 				}
 			}()
@@ -646,6 +766,15 @@ func (runner *runner) runCharmProcessOnLocal(hook, hookName, charmDir string, en
 	} else {
 		exitErr = err
 	}
+	// The timer may fire just as the hook finishes, in which case
+	// the hook wasn't killed and its own result stands.
+	select {
+	case <-timedOut:
+		if exitErr != nil && killed(ps.ProcessState) {
+			exitErr = charmrunner.NewHookTimedOutError(hookName, timeout)
+		}
+	default:
+	}
 
 	// Ensure hook loggers are stopped before reading stdout/stderr
 	// so all the output is captured.
@@ -717,10 +846,85 @@ func (runner *runner) startJujucServer(token string, rMode runMode) (*jujuc.Serv
 	if err != nil {
 		return nil, errors.Annotate(err, "starting jujuc server")
 	}
+	if observer := runner.toolCallObserver(); observer != nil {
+		srv.SetToolCallObserver(observer)
+	}
 	go srv.Run()
 	return srv, nil
 }
 
+// toolCallObserver returns the observer that traces the hook tool calls
+// made by the charm, or nil if the unit isn't being traced.
+func (runner *runner) toolCallObserver() jujuc.ToolCallObserver {
+	until, includeOutput := runner.context.TraceUntil()
+	if !runner.clock.Now().Before(until) {
+		return nil
+	}
+	return func(call jujuc.ToolCall) {
+		runner.traceToolCall(call, includeOutput)
+	}
+}
+
+// maxTracedOutput is how much of a hook tool's output is traced.
+const maxTracedOutput = 1024
+
+// redactedValue replaces traced data that may be sensitive.
+const redactedValue = "REDACTED"
+
+// settingsTools are the hook tools whose key=value arguments are
+// settings, which are only traced along with the tools' output.
+var settingsTools = set.NewStrings("relation-set", "leader-set", "state-set")
+
+// traceToolCall logs a hook tool call made while the unit is traced.
+// The records are sent to the controller along with the rest of the
+// unit's logs, where debug-log can select them by module, so they are
+// only logged if the module is logged at INFO.
+//
+// The arguments and output of the secret tools are never traced. The
+// output of the other tools, and the settings passed to them, are only
+// traced if includeOutput is true; otherwise only the size of the
+// output is.
+func (runner *runner) traceToolCall(call jujuc.ToolCall, includeOutput bool) {
+	logger := runner.context.GetLogger(traceModule)
+	if !logger.IsInfoEnabled() {
+		return
+	}
+	name := strings.TrimSuffix(call.CommandName, jujuc.CmdSuffix)
+	secret := strings.HasPrefix(name, "secret-")
+	traced := call
+	switch {
+	case secret && len(call.Args) > 0:
+		traced.Args = []string{redactedValue}
+	case settingsTools.Contains(name) && !includeOutput:
+		traced.Args = make([]string, len(call.Args))
+		for i, arg := range call.Args {
+			if key := strings.SplitN(arg, "=", 2); len(key) == 2 {
+				arg = key[0] + "=" + redactedValue
+			}
+			traced.Args[i] = arg
+		}
+	}
+	message := fmt.Sprintf("%s (exit %d, %v)",
+		replay.FormatArgs(traced), call.Code, call.Duration.Round(time.Millisecond))
+	message += traceOutput("stdout", call.Stdout, includeOutput && !secret)
+	message += traceOutput("stderr", call.Stderr, includeOutput && !secret)
+	logger.Infof("%s", message)
+}
+
+// traceOutput describes a hook tool's output for the trace: the output
+// itself, truncated, if include is true, and otherwise only its size.
+func traceOutput(name string, output []byte, include bool) string {
+	switch {
+	case len(output) == 0:
+		return ""
+	case !include:
+		return fmt.Sprintf(" %s: %d bytes", name, len(output))
+	case len(output) > maxTracedOutput:
+		return fmt.Sprintf(" %s: %q", name, string(output[:maxTracedOutput])+"...")
+	}
+	return fmt.Sprintf(" %s: %q", name, output)
+}
+
 // getKigger returns the logger for a particular unit's hook.
 func (runner *runner) getLogger(hookName string) loggo.Logger {
 	return runner.context.GetLogger(fmt.Sprintf("unit.%s.%s", runner.context.UnitName(), hookName))
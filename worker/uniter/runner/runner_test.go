@@ -7,12 +7,16 @@ import (
 	"bytes"
 	"fmt"
 	"io/ioutil"
+	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
+	"syscall"
 	"time"
 
+	"github.com/juju/charm/v7"
 	"github.com/juju/charm/v7/hooks"
+	"github.com/juju/clock/testclock"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/proxy"
@@ -22,10 +26,13 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/core/model"
+	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/common/charmrunner"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner"
 	"github.com/juju/juju/worker/uniter/runner/context"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+	"github.com/juju/juju/worker/uniter/runner/replay"
 	runnertesting "github.com/juju/juju/worker/uniter/runner/testing"
 )
 
@@ -183,6 +190,61 @@ func (s *RunHookSuite) TestRunHookDispatchingHookHandler(c *gc.C) {
 	c.Assert(hookType, gc.Equals, runner.DispatchingHookHandler)
 }
 
+func (s *RunHookSuite) TestRunHookCapturesContextForReplay(c *gc.C) {
+	ctx, err := s.contextFactory.HookContext(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(err, jc.ErrorIsNil)
+
+	paths := runnertesting.NewRealPaths(c)
+	rnr := runner.NewRunner(ctx, paths, nil)
+	makeCharm(c, hookSpec{
+		dir:  "hooks",
+		name: hookName,
+		perm: 0700,
+	}, paths.GetCharmDir())
+
+	requestPath := replay.CaptureRequestPath(paths.GetBaseDir(), "something-happened")
+	err = os.MkdirAll(filepath.Dir(requestPath), 0700)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(requestPath, nil, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = rnr.RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+
+	snapPath := replay.SnapshotPath(paths.GetBaseDir(), "something-happened")
+	snap, err := replay.ReadSnapshot(snapPath)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snap.Unit, gc.Equals, ctx.UnitName())
+	c.Assert(snap.Hook, gc.Equals, "something-happened")
+	c.Assert(snap.Env["JUJU_UNIT_NAME"], gc.Equals, ctx.UnitName())
+	c.Assert(snap.Env["JUJU_CONTEXT_ID"], gc.Equals, "")
+
+	// The request only covers a single run of the hook.
+	c.Assert(requestPath, jc.DoesNotExist)
+	err = os.Remove(snapPath)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = rnr.RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapPath, jc.DoesNotExist)
+}
+
+func (s *RunHookSuite) TestRunHookDoesNotCaptureContextUnlessRequested(c *gc.C) {
+	ctx, err := s.contextFactory.HookContext(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(err, jc.ErrorIsNil)
+
+	paths := runnertesting.NewRealPaths(c)
+	rnr := runner.NewRunner(ctx, paths, nil)
+	makeCharm(c, hookSpec{
+		dir:  "hooks",
+		name: hookName,
+		perm: 0700,
+	}, paths.GetCharmDir())
+
+	_, err = rnr.RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(replay.SnapshotPath(paths.GetBaseDir(), "something-happened"), jc.DoesNotExist)
+}
+
 type MockContext struct {
 	runner.Context
 	actionData      *context.ActionData
@@ -195,6 +257,9 @@ type MockContext struct {
 	flushFailure    error
 	flushResult     error
 	modelType       model.ModelType
+	hookTimeout     time.Duration
+	traceUntil      time.Time
+	traceOutput     bool
 }
 
 func (ctx *MockContext) GetLogger(module string) loggo.Logger {
@@ -205,6 +270,10 @@ func (ctx *MockContext) UnitName() string {
 	return "some-unit/999"
 }
 
+func (ctx *MockContext) ConfigSettings() (charm.Settings, error) {
+	return nil, errors.New("no config in mock context")
+}
+
 func (ctx *MockContext) HookVars(paths context.Paths, _ bool, getEnv context.GetEnvFunc) ([]string, error) {
 	pathKey := ""
 	if runtime.GOOS == "windows" {
@@ -249,6 +318,14 @@ func (ctx *MockContext) UpdateActionResults(keys []string, value string) error {
 	return nil
 }
 
+func (ctx *MockContext) HookTimeout() time.Duration {
+	return ctx.hookTimeout
+}
+
+func (ctx *MockContext) TraceUntil() (time.Time, bool) {
+	return ctx.traceUntil, ctx.traceOutput
+}
+
 func (ctx *MockContext) ModelType() model.ModelType {
 	if ctx.modelType == "" {
 		return model.IAAS
@@ -311,6 +388,175 @@ func (s *RunMockContextSuite) TestRunHookFlushFailure(c *gc.C) {
 	s.assertRecordedPid(c, ctx.expectPid)
 }
 
+func (s *RunMockContextSuite) TestTraceWindow(c *gc.C) {
+	now := time.Date(2020, 7, 1, 10, 0, 0, 0, time.UTC)
+	clock := testclock.NewClock(now)
+	ctx := &MockContext{traceUntil: now.Add(time.Minute)}
+	rnr := runner.NewRunner(ctx, s.paths, nil, runner.WithClock(clock))
+	c.Assert(runner.IsTraced(rnr), jc.IsTrue)
+
+	clock.Advance(time.Minute)
+	c.Assert(runner.IsTraced(rnr), jc.IsFalse)
+
+	ctx.traceUntil = time.Time{}
+	c.Assert(runner.IsTraced(rnr), jc.IsFalse)
+}
+
+func (s *RunMockContextSuite) traceToolCalls(c *gc.C, includeOutput bool, calls ...jujuc.ToolCall) []loggo.Entry {
+	var tw loggo.TestWriter
+	c.Assert(loggo.RegisterWriter("trace-test", &tw), jc.ErrorIsNil)
+	defer loggo.RemoveWriter("trace-test")
+	loggo.GetLogger("").SetLogLevel(loggo.WARNING)
+	loggo.GetLogger("juju.jujuc.trace").SetLogLevel(loggo.INFO)
+
+	rnr := runner.NewRunner(&MockContext{}, s.paths, nil)
+	for _, call := range calls {
+		runner.TraceToolCall(rnr, call, includeOutput)
+	}
+	for _, entry := range tw.Log() {
+		c.Check(entry.Module, gc.Equals, "juju.jujuc.trace")
+	}
+	return tw.Log()
+}
+
+func (s *RunMockContextSuite) TestTraceToolCall(c *gc.C) {
+	log := s.traceToolCalls(c, false, jujuc.ToolCall{
+		CommandName: "relation-get",
+		Args:        []string{"-r", "db:1", "-", "mysql/0"},
+		Code:        0,
+		Stdout:      []byte("host: 10.0.0.2\n"),
+		Duration:    12 * time.Millisecond,
+	}, jujuc.ToolCall{
+		CommandName: "relation-set",
+		Args:        []string{"-r", "db:1", "password=sekrit"},
+		Duration:    5 * time.Millisecond,
+	})
+	c.Assert(log, jc.LogMatches, []jc.SimpleMessage{{
+		loggo.INFO, `relation-get -r db:1 - mysql/0 \(exit 0, 12ms\) stdout: 15 bytes`,
+	}, {
+		loggo.INFO, `relation-set -r db:1 password=REDACTED \(exit 0, 5ms\)`,
+	}})
+}
+
+func (s *RunMockContextSuite) TestTraceToolCallIncludeOutput(c *gc.C) {
+	log := s.traceToolCalls(c, true, jujuc.ToolCall{
+		CommandName: "relation-get",
+		Args:        []string{"-r", "db:1", "-", "mysql/0"},
+		Code:        0,
+		Stdout:      []byte("host: 10.0.0.2\n"),
+		Duration:    12 * time.Millisecond,
+	}, jujuc.ToolCall{
+		CommandName: "config-get",
+		Args:        []string{"missing key"},
+		Code:        1,
+		Stderr:      []byte(strings.Repeat("x", 2000)),
+		Duration:    3 * time.Millisecond,
+	})
+	c.Assert(log, jc.LogMatches, []jc.SimpleMessage{{
+		loggo.INFO, `relation-get -r db:1 - mysql/0 \(exit 0, 12ms\) stdout: "host: 10.0.0.2\\n"`,
+	}, {
+		loggo.INFO, `config-get 'missing key' \(exit 1, 3ms\) stderr: "x{1000}x{24}\.\.\."`,
+	}})
+}
+
+func (s *RunMockContextSuite) TestTraceSecretToolCall(c *gc.C) {
+	log := s.traceToolCalls(c, true, jujuc.ToolCall{
+		CommandName: "secret-get",
+		Args:        []string{"secret://app/mysql/password"},
+		Stdout:      []byte("sekrit\n"),
+		Duration:    12 * time.Millisecond,
+	}, jujuc.ToolCall{
+		CommandName: "secret-add",
+		Args:        []string{"password=sekrit"},
+		Stdout:      []byte("secret://app/mysql/password\n"),
+		Duration:    5 * time.Millisecond,
+	})
+	c.Assert(log, jc.LogMatches, []jc.SimpleMessage{{
+		loggo.INFO, `secret-get REDACTED \(exit 0, 12ms\) stdout: 7 bytes`,
+	}, {
+		loggo.INFO, `secret-add REDACTED \(exit 0, 5ms\) stdout: 28 bytes`,
+	}})
+}
+
+func (s *RunMockContextSuite) TestTraceToolCallNotLogged(c *gc.C) {
+	var tw loggo.TestWriter
+	c.Assert(loggo.RegisterWriter("trace-test", &tw), jc.ErrorIsNil)
+	defer loggo.RemoveWriter("trace-test")
+	loggo.GetLogger("").SetLogLevel(loggo.WARNING)
+
+	rnr := runner.NewRunner(&MockContext{}, s.paths, nil)
+	runner.TraceToolCall(rnr, jujuc.ToolCall{CommandName: "config-get"}, false)
+	c.Assert(tw.Log(), gc.HasLen, 0)
+	c.Assert(loggo.GetLogger("juju.jujuc.trace").LogLevel(), gc.Equals, loggo.UNSPECIFIED)
+}
+
+func (s *RunMockContextSuite) TestRunHookTimedOut(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("hook process trees are only killed on unix")
+	}
+	// The hook needs sleep and touch from the system path.
+	s.PatchEnvironment("PATH", "/usr/bin:/bin")
+	ctx := &MockContext{
+		hookTimeout: 100 * time.Millisecond,
+	}
+	makeCharm(c, hookSpec{
+		dir:   "hooks",
+		name:  hookName,
+		perm:  0700,
+		sleep: "1",
+	}, s.paths.GetCharmDir())
+	_, err := runner.NewRunner(ctx, s.paths, nil).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushBadge, gc.Equals, "something-happened")
+	c.Assert(ctx.flushFailure, jc.Satisfies, charmrunner.IsHookTimedOutError)
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "something-happened hook timed out after 100ms")
+
+	// The processes started by the hook are killed too.
+	time.Sleep(1500 * time.Millisecond)
+	_, err = os.Stat(filepath.Join(s.paths.GetCharmDir(), "finished"))
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *RunMockContextSuite) TestRunHookOnRemoteTimedOut(c *gc.C) {
+	ctx := &MockContext{
+		hookTimeout: 10 * time.Millisecond,
+		modelType:   model.CAAS,
+	}
+	execFunc := func(params runner.ExecParams) (*exec.ExecResponse, error) {
+		select {
+		case sig := <-params.Signal:
+			// The remote process group is killed.
+			c.Check(sig, gc.Equals, syscall.SIGKILL)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("hook not killed")
+		}
+		return nil, errors.New("command terminated with exit code 137")
+	}
+	rnr := runner.NewRunner(ctx, s.paths, execFunc)
+	err := runner.RunCharmProcessOnRemote(rnr, "hooks/"+hookName, "something-happened", s.paths.GetCharmDir(), nil)
+	c.Assert(err, jc.Satisfies, charmrunner.IsHookTimedOutError)
+	c.Assert(err, gc.ErrorMatches, "something-happened hook timed out after 10ms")
+}
+
+func (s *RunMockContextSuite) TestRunHookOnRemoteFinishedAsTimerFired(c *gc.C) {
+	ctx := &MockContext{
+		hookTimeout: 10 * time.Millisecond,
+		modelType:   model.CAAS,
+	}
+	execFunc := func(params runner.ExecParams) (*exec.ExecResponse, error) {
+		select {
+		case <-params.Signal:
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timer didn't fire")
+		}
+		// The hook finished before the kill reached it.
+		return &exec.ExecResponse{}, nil
+	}
+	rnr := runner.NewRunner(ctx, s.paths, execFunc)
+	err := runner.RunCharmProcessOnRemote(rnr, "hooks/"+hookName, "something-happened", s.paths.GetCharmDir(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *RunHookSuite) TestRunActionDispatchingHookHandler(c *gc.C) {
 	ctx := &MockContext{
 		actionData:    &context.ActionData{},
@@ -114,6 +114,7 @@ type ContextStorage struct {
 	CTag      names.StorageTag
 	CKind     storage.StorageKind
 	CLocation string
+	CSize     uint64
 }
 
 func (c *ContextStorage) Tag() names.StorageTag {
@@ -128,6 +129,10 @@ func (c *ContextStorage) Location() string {
 	return c.CLocation
 }
 
+func (c *ContextStorage) Size() uint64 {
+	return c.CSize
+}
+
 type FakeTracker struct {
 	leadership.Tracker
 	worker.Worker
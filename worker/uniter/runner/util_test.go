@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/juju/clock"
 	"github.com/juju/clock/testclock"
 	"github.com/juju/loggo"
 	"github.com/juju/names/v4"
@@ -72,6 +73,7 @@ func (s *ContextSuite) SetUpTest(c *gc.C) {
 				storageData0,
 				storage.StorageKindBlock,
 				"/dev/sdb",
+				1024,
 			},
 		},
 	}
@@ -121,6 +123,7 @@ func (s *ContextSuite) SetUpTest(c *gc.C) {
 		s.contextFactory,
 		runner.NewRunner,
 		nil,
+		clock.WallClock,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	s.factory = factory
@@ -209,6 +212,9 @@ type hookSpec struct {
 	background string
 	// missingShebang will omit the '#!/bin/bash' line
 	missingShebang bool
+	// sleep holds how long a child process of the hook sleeps before
+	// creating the "finished" file. The hook waits for the child.
+	sleep string
 }
 
 // makeCharm constructs a fake charm dir containing a single named hook
@@ -253,6 +259,9 @@ func makeCharm(c *gc.C, spec hookSpec, charmDir string) {
 		// expected.
 		printf("(sleep 0.2; echo %s; sleep 10) &", spec.background)
 	}
+	if spec.sleep != "" {
+		printf("(sleep %s; touch finished) & wait", spec.sleep)
+	}
 	printf("exit %d", spec.code)
 }
 
@@ -0,0 +1,80 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/remotestate"
+	"github.com/juju/juju/worker/uniter/resolver"
+)
+
+// secretsResolver is a Resolver that returns operations to run the
+// secret-rotate hook for secrets which are due to be rotated. Once the
+// hook operation is committed, the "rotatedSecret" callback is invoked
+// with the URI of the rotated secret.
+type secretsResolver struct {
+	logger        Logger
+	rotatedSecret func(uri string) error
+}
+
+// NewSecretsResolver returns a new Resolver that runs the secret-rotate
+// hook on the leader unit for each secret in the remote state's
+// "SecretRotations".
+func NewSecretsResolver(logger Logger, rotatedSecret func(string) error) resolver.Resolver {
+	return &secretsResolver{
+		logger:        logger,
+		rotatedSecret: rotatedSecret,
+	}
+}
+
+// NextOp is part of the resolver.Resolver interface.
+func (s *secretsResolver) NextOp(
+	localState resolver.LocalState,
+	remoteState remotestate.Snapshot,
+	opFactory operation.Factory,
+) (operation.Operation, error) {
+	// Only the leader may rotate secrets, and only once the
+	// charm is installed and nothing else is in progress.
+	if !localState.Installed || !remoteState.Leader || remoteState.Life != life.Alive {
+		return nil, resolver.ErrNoOperation
+	}
+	if localState.Kind != operation.Continue || len(remoteState.SecretRotations) == 0 {
+		return nil, resolver.ErrNoOperation
+	}
+	uri := remoteState.SecretRotations[0]
+	s.logger.Debugf("rotating secret %q", uri)
+	op, err := opFactory.NewRunHook(hook.Info{
+		Kind:      hook.SecretRotate,
+		SecretURI: uri,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &secretRotateCompleter{op, func() error {
+		return s.rotatedSecret(uri)
+	}}, nil
+}
+
+type secretRotateCompleter struct {
+	operation.Operation
+	rotatedSecret func() error
+}
+
+// WrappedOperation is part of the operation.WrappedOperation interface.
+func (c *secretRotateCompleter) WrappedOperation() operation.Operation {
+	return c.Operation
+}
+
+// Commit is part of the operation.Operation interface.
+func (c *secretRotateCompleter) Commit(st operation.State) (*operation.State, error) {
+	result, err := c.Operation.Commit(st)
+	if err == nil {
+		err = c.rotatedSecret()
+	}
+	return result, errors.Trace(err)
+}
@@ -0,0 +1,157 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/remotestate"
+	"github.com/juju/juju/worker/uniter/resolver"
+	"github.com/juju/juju/worker/uniter/secrets"
+)
+
+const (
+	secretURI      = "secret:7fa6cc1c-3f6b-4a04-8b27-9f3a1b9b5e21"
+	otherSecretURI = "secret:2d0c8f35-6a47-4c6e-9f1e-3b8d5a0e7c44"
+)
+
+type resolverSuite struct {
+	testing.IsolationSuite
+
+	rotated    []string
+	rotatedErr error
+}
+
+var _ = gc.Suite(&resolverSuite{})
+
+func (s *resolverSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.rotated = nil
+	s.rotatedErr = nil
+}
+
+func (s *resolverSuite) newResolver() resolver.Resolver {
+	return secrets.NewSecretsResolver(loggo.GetLogger("test"), func(uri string) error {
+		s.rotated = append(s.rotated, uri)
+		return s.rotatedErr
+	})
+}
+
+func (s *resolverSuite) states() (resolver.LocalState, remotestate.Snapshot) {
+	localState := resolver.LocalState{
+		State: operation.State{
+			Kind:      operation.Continue,
+			Installed: true,
+		},
+	}
+	remoteState := remotestate.Snapshot{
+		Life:            life.Alive,
+		Leader:          true,
+		SecretRotations: []string{secretURI, otherSecretURI},
+	}
+	return localState, remoteState
+}
+
+func (s *resolverSuite) TestNoRotations(c *gc.C) {
+	localState, remoteState := s.states()
+	remoteState.SecretRotations = nil
+	_, err := s.newResolver().NextOp(localState, remoteState, &mockOperations{})
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+}
+
+func (s *resolverSuite) TestNotLeader(c *gc.C) {
+	localState, remoteState := s.states()
+	remoteState.Leader = false
+	_, err := s.newResolver().NextOp(localState, remoteState, &mockOperations{})
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+}
+
+func (s *resolverSuite) TestNotInstalled(c *gc.C) {
+	localState, remoteState := s.states()
+	localState.Installed = false
+	_, err := s.newResolver().NextOp(localState, remoteState, &mockOperations{})
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+}
+
+func (s *resolverSuite) TestDying(c *gc.C) {
+	localState, remoteState := s.states()
+	remoteState.Life = life.Dying
+	_, err := s.newResolver().NextOp(localState, remoteState, &mockOperations{})
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+}
+
+func (s *resolverSuite) TestOperationInProgress(c *gc.C) {
+	localState, remoteState := s.states()
+	localState.Kind = operation.RunHook
+	localState.Step = operation.Pending
+	_, err := s.newResolver().NextOp(localState, remoteState, &mockOperations{})
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+}
+
+func (s *resolverSuite) TestRotateSecret(c *gc.C) {
+	localState, remoteState := s.states()
+	op, err := s.newResolver().NextOp(localState, remoteState, &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	wrapped, ok := op.(operation.WrappedOperation)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(wrapped.WrappedOperation(), jc.DeepEquals, &mockOperation{
+		hookInfo: hook.Info{
+			Kind:      hook.SecretRotate,
+			SecretURI: secretURI,
+		},
+	})
+	c.Assert(s.rotated, gc.HasLen, 0)
+
+	_, err = op.Commit(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.rotated, jc.DeepEquals, []string{secretURI})
+}
+
+func (s *resolverSuite) TestRotateSecretCommitFails(c *gc.C) {
+	localState, remoteState := s.states()
+	ops := &mockOperations{commitErr: errors.New("boom")}
+	op, err := s.newResolver().NextOp(localState, remoteState, ops)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = op.Commit(operation.State{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(s.rotated, gc.HasLen, 0)
+}
+
+func (s *resolverSuite) TestRotatedSecretFails(c *gc.C) {
+	localState, remoteState := s.states()
+	s.rotatedErr = errors.New("cannot record rotation")
+	op, err := s.newResolver().NextOp(localState, remoteState, &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = op.Commit(operation.State{})
+	c.Assert(err, gc.ErrorMatches, "cannot record rotation")
+}
+
+type mockOperations struct {
+	operation.Factory
+	commitErr error
+}
+
+func (m *mockOperations) NewRunHook(hookInfo hook.Info) (operation.Operation, error) {
+	return &mockOperation{hookInfo: hookInfo, commitErr: m.commitErr}, nil
+}
+
+type mockOperation struct {
+	operation.Operation
+	hookInfo  hook.Info
+	commitErr error
+}
+
+func (op *mockOperation) Commit(st operation.State) (*operation.State, error) {
+	return &st, op.commitErr
+}
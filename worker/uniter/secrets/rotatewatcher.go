@@ -0,0 +1,209 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/worker/v2/catacomb"
+
+	"github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/core/watcher"
+)
+
+// Logger represents the logging methods used in this package.
+type Logger interface {
+	Debugf(string, ...interface{})
+}
+
+// RotationClient provides the secrets rotation API used by the
+// rotate watcher.
+type RotationClient interface {
+	// WatchSecretsRotationChanges returns a watcher notifying of the
+	// URIs of secrets whose rotation schedule has changed.
+	WatchSecretsRotationChanges() (watcher.StringsWatcher, error)
+
+	// SecretsRotationInfo returns when each secret is next due
+	// to be rotated.
+	SecretsRotationInfo([]string) ([]secrets.RotationInfo, error)
+}
+
+// RotateWatcherConfig holds the configuration for a RotateWatcher.
+type RotateWatcherConfig struct {
+	Client RotationClient
+	Clock  clock.Clock
+	Logger Logger
+
+	// RotateChannel is sent the URIs of secrets which are due
+	// to be rotated.
+	RotateChannel chan<- []string
+}
+
+// Validate returns an error if the config is not valid.
+func (config RotateWatcherConfig) Validate() error {
+	if config.Client == nil {
+		return errors.NotValidf("nil Client")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	if config.RotateChannel == nil {
+		return errors.NotValidf("nil RotateChannel")
+	}
+	return nil
+}
+
+// RotateWatcher tracks when the secrets owned by a unit's application
+// are next due to be rotated, and reports them once they are due.
+type RotateWatcher struct {
+	catacomb catacomb.Catacomb
+	config   RotateWatcherConfig
+
+	// schedule holds the next rotate time of each secret which
+	// has not yet been reported as due.
+	schedule map[string]time.Time
+
+	timer clock.Timer
+}
+
+// NewRotateWatcher returns a RotateWatcher with the given config.
+func NewRotateWatcher(config RotateWatcherConfig) (*RotateWatcher, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &RotateWatcher{
+		config:   config,
+		schedule: make(map[string]time.Time),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	return w, errors.Trace(err)
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *RotateWatcher) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *RotateWatcher) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *RotateWatcher) loop() error {
+	changes, err := w.config.Client.WatchSecretsRotationChanges()
+	if errors.IsNotImplemented(err) {
+		// The controller does not support secrets, so there
+		// will never be any to rotate.
+		w.config.Logger.Debugf("secrets not supported: %v", err)
+		<-w.catacomb.Dying()
+		return w.catacomb.ErrDying()
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(changes); err != nil {
+		return errors.Trace(err)
+	}
+	defer w.stopTimer()
+
+	var (
+		timer <-chan time.Time
+		due   []string
+		out   chan<- []string
+	)
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+
+		case uris, ok := <-changes.Changes():
+			if !ok {
+				return errors.New("secret rotation watcher closed")
+			}
+			if err := w.updateSchedule(uris); err != nil {
+				return errors.Trace(err)
+			}
+
+		case <-timer:
+
+		case out <- due:
+			due = nil
+		}
+
+		due = append(due, w.takeDue()...)
+		out = nil
+		if len(due) > 0 {
+			out = w.config.RotateChannel
+		}
+		timer = w.resetTimer()
+	}
+}
+
+// updateSchedule refreshes the next rotate time of the given secrets.
+func (w *RotateWatcher) updateSchedule(uris []string) error {
+	if len(uris) == 0 {
+		return nil
+	}
+	info, err := w.config.Client.SecretsRotationInfo(uris)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, ri := range info {
+		uri := ri.URI.String()
+		if !ri.RotatePolicy.WillRotate() || ri.NextRotateTime == nil {
+			w.config.Logger.Debugf("secret %q is not scheduled for rotation", uri)
+			delete(w.schedule, uri)
+			continue
+		}
+		w.config.Logger.Debugf("secret %q is next rotated at %s", uri, *ri.NextRotateTime)
+		w.schedule[uri] = *ri.NextRotateTime
+	}
+	return nil
+}
+
+// takeDue removes the secrets which are due to be rotated from
+// the schedule and returns their URIs.
+func (w *RotateWatcher) takeDue() []string {
+	var due []string
+	now := w.config.Clock.Now()
+	for uri, next := range w.schedule {
+		if !next.After(now) {
+			due = append(due, uri)
+			delete(w.schedule, uri)
+		}
+	}
+	return due
+}
+
+func (w *RotateWatcher) stopTimer() {
+	if w.timer != nil {
+		w.timer.Stop()
+		w.timer = nil
+	}
+}
+
+// resetTimer returns a channel which fires when the next secret is due
+// to be rotated, or nil if no secrets are scheduled.
+func (w *RotateWatcher) resetTimer() <-chan time.Time {
+	w.stopTimer()
+	var earliest *time.Time
+	for uri := range w.schedule {
+		next := w.schedule[uri]
+		if earliest == nil || next.Before(*earliest) {
+			earliest = &next
+		}
+	}
+	if earliest == nil {
+		return nil
+	}
+	w.timer = w.config.Clock.NewTimer(earliest.Sub(w.config.Clock.Now()))
+	return w.timer.Chan()
+}
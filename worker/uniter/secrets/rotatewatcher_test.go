@@ -0,0 +1,181 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+
+	coresecrets "github.com/juju/juju/core/secrets"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/secrets"
+)
+
+type rotateWatcherSuite struct {
+	testing.IsolationSuite
+
+	clock         *testclock.Clock
+	client        *mockRotationClient
+	changes       chan []string
+	rotateChannel chan []string
+}
+
+var _ = gc.Suite(&rotateWatcherSuite{})
+
+func (s *rotateWatcherSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2020, 7, 1, 10, 0, 0, 0, time.UTC))
+	s.changes = make(chan []string, 1)
+	s.rotateChannel = make(chan []string, 1)
+	s.client = &mockRotationClient{
+		watcher: watchertest.NewMockStringsWatcher(s.changes),
+		info:    make(map[string]coresecrets.RotationInfo),
+		called:  make(chan []string, 10),
+	}
+}
+
+func (s *rotateWatcherSuite) config() secrets.RotateWatcherConfig {
+	return secrets.RotateWatcherConfig{
+		Client:        s.client,
+		Clock:         s.clock,
+		Logger:        loggo.GetLogger("test"),
+		RotateChannel: s.rotateChannel,
+	}
+}
+
+func (s *rotateWatcherSuite) setRotateTime(c *gc.C, uri string, policy coresecrets.RotatePolicy, next *time.Time) {
+	parsed, err := coresecrets.ParseURI(uri)
+	c.Assert(err, jc.ErrorIsNil)
+	s.client.setInfo(coresecrets.RotationInfo{
+		URI:            parsed,
+		RotatePolicy:   policy,
+		NextRotateTime: next,
+	})
+}
+
+func (s *rotateWatcherSuite) newWatcher(c *gc.C) *secrets.RotateWatcher {
+	w, err := secrets.NewRotateWatcher(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+	return w
+}
+
+func (s *rotateWatcherSuite) assertRotated(c *gc.C, expected ...string) {
+	select {
+	case uris := <-s.rotateChannel:
+		c.Assert(uris, jc.SameContents, expected)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for secrets to rotate")
+	}
+}
+
+func (s *rotateWatcherSuite) assertInfoRequested(c *gc.C, expected ...string) {
+	select {
+	case uris := <-s.client.called:
+		c.Assert(uris, jc.SameContents, expected)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for rotation info request")
+	}
+}
+
+func (s *rotateWatcherSuite) assertNotRotated(c *gc.C) {
+	select {
+	case uris := <-s.rotateChannel:
+		c.Fatalf("unexpected rotation of %v", uris)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *rotateWatcherSuite) TestValidate(c *gc.C) {
+	cfg := s.config()
+	cfg.Client = nil
+	_, err := secrets.NewRotateWatcher(cfg)
+	c.Assert(err, gc.ErrorMatches, "nil Client not valid")
+
+	cfg = s.config()
+	cfg.RotateChannel = nil
+	_, err = secrets.NewRotateWatcher(cfg)
+	c.Assert(err, gc.ErrorMatches, "nil RotateChannel not valid")
+}
+
+func (s *rotateWatcherSuite) TestAlreadyDue(c *gc.C) {
+	past := s.clock.Now().Add(-time.Minute)
+	s.setRotateTime(c, secretURI, coresecrets.RotateDaily, &past)
+	s.newWatcher(c)
+
+	s.changes <- []string{secretURI}
+	s.assertRotated(c, secretURI)
+}
+
+func (s *rotateWatcherSuite) TestRotateWhenDue(c *gc.C) {
+	soon := s.clock.Now().Add(time.Hour)
+	later := s.clock.Now().Add(2 * time.Hour)
+	s.setRotateTime(c, secretURI, coresecrets.RotateHourly, &soon)
+	s.setRotateTime(c, otherSecretURI, coresecrets.RotateDaily, &later)
+	s.newWatcher(c)
+
+	s.changes <- []string{secretURI, otherSecretURI}
+	err := s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertRotated(c, secretURI)
+
+	err = s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertRotated(c, otherSecretURI)
+}
+
+func (s *rotateWatcherSuite) TestRotationCancelled(c *gc.C) {
+	soon := s.clock.Now().Add(time.Hour)
+	s.setRotateTime(c, secretURI, coresecrets.RotateHourly, &soon)
+	s.newWatcher(c)
+
+	s.changes <- []string{secretURI}
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertInfoRequested(c, secretURI)
+
+	s.setRotateTime(c, secretURI, coresecrets.RotateNever, nil)
+	s.changes <- []string{secretURI}
+	s.assertInfoRequested(c, secretURI)
+	s.clock.Advance(time.Hour)
+	s.assertNotRotated(c)
+}
+
+type mockRotationClient struct {
+	watcher watcher.StringsWatcher
+	called  chan []string
+
+	mu   sync.Mutex
+	info map[string]coresecrets.RotationInfo
+}
+
+func (m *mockRotationClient) setInfo(info coresecrets.RotationInfo) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.info[info.URI.String()] = info
+}
+
+func (m *mockRotationClient) WatchSecretsRotationChanges() (watcher.StringsWatcher, error) {
+	return m.watcher, nil
+}
+
+func (m *mockRotationClient) SecretsRotationInfo(uris []string) ([]coresecrets.RotationInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.called <- uris
+	result := make([]coresecrets.RotationInfo, len(uris))
+	for i, uri := range uris {
+		result[i] = m.info[uri]
+	}
+	return result, nil
+}
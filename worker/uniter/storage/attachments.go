@@ -130,8 +130,17 @@ func (a *Attachments) init() error {
 				tag:      storageTag,
 				kind:     storage.StorageKind(attachment.Kind),
 				location: attachment.Location,
+				size:     attachment.Size,
 			}
 		newStateStorage.Attach(storageTag.Id())
+		// State written before storage could be resized has no
+		// sizes; take the current size as the one the charm has
+		// already seen.
+		if size, ok := existingStorageState.AttachedSize(storageTag.Id()); ok {
+			newStateStorage.SetAttachedSize(storageTag.Id(), size)
+		} else if attachment.Size > 0 {
+			newStateStorage.SetAttachedSize(storageTag.Id(), attachment.Size)
+		}
 	}
 	a.storageState = newStateStorage
 	if a.storageState.Empty() {
@@ -204,6 +213,11 @@ func (a *Attachments) CommitHook(hi hook.Info) error {
 		}
 	} else {
 		a.storageState.Attach(hi.StorageId)
+		// Record the size the hook was run with, so that it is run
+		// again if the storage grows, and a failed hook is retried.
+		if ctx, ok := a.storageAttachments[names.NewStorageTag(hi.StorageId)]; ok && ctx.size > 0 {
+			a.storageState.SetAttachedSize(hi.StorageId, ctx.size)
+		}
 	}
 	if err := a.stateOps.Write(a.storageState); err != nil {
 		return err
@@ -382,6 +382,135 @@ func (s *attachmentsSuite) TestAttachmentsCommitHook(c *gc.C) {
 	c.Assert(removed, jc.IsTrue)
 }
 
+func (s *attachmentsSuite) TestAttachmentsStorageResized(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	unitTag := names.NewUnitTag("mysql/0")
+	abort := make(chan struct{})
+
+	storageTag := names.NewStorageTag("data/0")
+	st := &mockStorageAccessor{
+		unitStorageAttachments: func(u names.UnitTag) ([]params.StorageAttachmentId, error) {
+			return nil, nil
+		},
+	}
+
+	att, err := storage.NewAttachments(st, unitTag, s.mockStateOps, abort)
+	c.Assert(err, jc.ErrorIsNil)
+	r := storage.NewResolver(loggo.GetLogger("test"), att, s.modelType)
+
+	snapshot := func(size uint64) remotestate.Snapshot {
+		return remotestate.Snapshot{
+			Life: life.Alive,
+			Storage: map[names.StorageTag]remotestate.StorageSnapshot{
+				storageTag: {
+					Kind:     params.StorageKindBlock,
+					Life:     life.Alive,
+					Location: "/dev/sdb",
+					Size:     size,
+					Attached: true,
+				},
+			},
+		}
+	}
+	localState := resolver.LocalState{State: operation.State{
+		Kind: operation.Continue,
+	}}
+	op, err := r.NextOp(localState, snapshot(1024), &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run hook storage-attached")
+
+	s.storSt.Attach(storageTag.Id())
+	s.storSt.SetAttachedSize(storageTag.Id(), 1024)
+	s.expectSetState(c, "")
+	err = att.CommitHook(hook.Info{
+		Kind:      hooks.StorageAttached,
+		StorageId: storageTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Nothing to do while the size is unchanged.
+	_, err = r.NextOp(localState, snapshot(1024), &mockOperations{})
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+
+	// Growing the storage runs storage-attached again.
+	op, err = r.NextOp(localState, snapshot(2048), &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run hook storage-attached")
+	c.Assert(att.Pending(), gc.Equals, 0)
+
+	ctx, err := att.Storage(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.Size(), gc.Equals, uint64(2048))
+
+	// Until the hook is committed, it is run again.
+	op, err = r.NextOp(localState, snapshot(2048), &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run hook storage-attached")
+
+	s.storSt.SetAttachedSize(storageTag.Id(), 2048)
+	s.expectSetState(c, "")
+	err = att.CommitHook(hook.Info{
+		Kind:      hooks.StorageAttached,
+		StorageId: storageTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = r.NextOp(localState, snapshot(2048), &mockOperations{})
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+}
+
+func (s *attachmentsSuite) TestAttachmentsStorageResizedWhileStopped(c *gc.C) {
+	unitTag := names.NewUnitTag("mysql/0")
+	abort := make(chan struct{})
+
+	storageTag := names.NewStorageTag("data/0")
+	s.storSt.Attach(storageTag.Id())
+	s.storSt.SetAttachedSize(storageTag.Id(), 1024)
+	defer s.setupMocks(c).Finish()
+	st := &mockStorageAccessor{
+		unitStorageAttachments: func(u names.UnitTag) ([]params.StorageAttachmentId, error) {
+			return []params.StorageAttachmentId{{
+				StorageTag: storageTag.String(),
+				UnitTag:    unitTag.String(),
+			}}, nil
+		},
+		storageAttachment: func(s names.StorageTag, u names.UnitTag) (params.StorageAttachment, error) {
+			return params.StorageAttachment{
+				Kind:     params.StorageKindBlock,
+				Life:     life.Alive,
+				Location: "/dev/sdb",
+				Size:     2048,
+			}, nil
+		},
+	}
+	// The recorded size is kept, rather than the current one.
+	s.expectSetState(c, "")
+
+	att, err := storage.NewAttachments(st, unitTag, s.mockStateOps, abort)
+	c.Assert(err, jc.ErrorIsNil)
+	r := storage.NewResolver(loggo.GetLogger("test"), att, s.modelType)
+
+	localState := resolver.LocalState{State: operation.State{
+		Kind:      operation.Continue,
+		Installed: true,
+		Started:   true,
+	}}
+	op, err := r.NextOp(localState, remotestate.Snapshot{
+		Life: life.Alive,
+		Storage: map[names.StorageTag]remotestate.StorageSnapshot{
+			storageTag: {
+				Kind:     params.StorageKindBlock,
+				Life:     life.Alive,
+				Location: "/dev/sdb",
+				Size:     2048,
+				Attached: true,
+			},
+		},
+	}, &mockOperations{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run hook storage-attached")
+}
+
 func (s *attachmentsSuite) TestAttachmentsSetDying(c *gc.C) {
 	defer s.setupMocks(c).Finish()
 
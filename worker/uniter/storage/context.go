@@ -13,6 +13,7 @@ type contextStorage struct {
 	tag      names.StorageTag
 	kind     storage.StorageKind
 	location string
+	size     uint64
 }
 
 func (ctx *contextStorage) Tag() names.StorageTag {
@@ -26,3 +27,7 @@ func (ctx *contextStorage) Kind() storage.StorageKind {
 func (ctx *contextStorage) Location() string {
 	return ctx.location
 }
+
+func (ctx *contextStorage) Size() uint64 {
+	return ctx.size
+}
@@ -6,3 +6,11 @@ package storage
 func Storage(st *State) map[string]bool {
 	return st.storage
 }
+
+func Sizes(st *State) map[string]uint64 {
+	return st.sizes
+}
+
+func StateData(st *State) (string, error) {
+	return st.marshal()
+}
@@ -10,7 +10,6 @@ import (
 	jc "github.com/juju/testing/checkers"
 	"gopkg.in/check.v1"
 	gc "gopkg.in/check.v1"
-	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/worker/uniter/operation/mocks"
@@ -30,9 +29,8 @@ func (s *mockStateOpsSuite) setupMocks(c *gc.C) *gomock.Controller {
 }
 
 func (s *mockStateOpsSuite) expectSetState(c *gc.C, errStr string) {
-	data, err := yaml.Marshal(storage.Storage(s.storSt))
+	strStorageState, err := storage.StateData(s.storSt)
 	c.Assert(err, jc.ErrorIsNil)
-	strStorageState := string(data)
 	if errStr != "" {
 		err = errors.New(`validation of uniter state: invalid operation state: ` + errStr)
 	}
@@ -48,9 +46,8 @@ func (s *mockStateOpsSuite) expectSetStateEmpty(c *gc.C) {
 }
 
 func (s *mockStateOpsSuite) expectState(c *check.C) {
-	data, err := yaml.Marshal(storage.Storage(s.storSt))
+	strStorageState, err := storage.StateData(s.storSt)
 	c.Assert(err, checkers.ErrorIsNil)
-	strStorageState := string(data)
 
 	mExp := s.mockStateOps.EXPECT()
 	mExp.State().Return(params.UnitStateResult{StorageState: strStorageState}, nil)
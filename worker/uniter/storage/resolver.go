@@ -183,20 +183,31 @@ func (s *storageResolver) nextHookOp(
 		attached, ok := s.storage.storageState.Attached(tag.Id())
 		if ok && attached {
 			// Once the storage is attached, we only care about
-			// lifecycle State changes.
-			return nil, resolver.ErrNoOperation
-		}
-		// The storage-attached hook has not been committed, so add the
-		// storage to the pending set.
-		s.storage.pending.Add(tag)
-		if !snap.Attached {
-			// The storage attachment has not been provisioned yet,
-			// so just ignore it for now. We'll be notified again
-			// when it has been provisioned.
-			return nil, resolver.ErrNoOperation
+			// lifecycle State changes and the storage growing.
+			// When the storage has grown since the size recorded
+			// by the last committed "storage-attached" hook, the
+			// hook is run again so that the charm can observe the
+			// new size. If no size was recorded, any known size
+			// is new to the charm.
+			attachedSize, _ := s.storage.storageState.AttachedSize(tag.Id())
+			if attachedSize >= snap.Size {
+				return nil, resolver.ErrNoOperation
+			}
+			s.logger.Debugf("storage %v resized from %dMiB to %dMiB", tag.Id(), attachedSize, snap.Size)
+		} else {
+			// The storage-attached hook has not been committed, so add the
+			// storage to the pending set.
+			s.storage.pending.Add(tag)
+			if !snap.Attached {
+				// The storage attachment has not been provisioned yet,
+				// so just ignore it for now. We'll be notified again
+				// when it has been provisioned.
+				return nil, resolver.ErrNoOperation
+			}
 		}
 		// The storage is alive, but we haven't previously run the
-		// "storage-attached" hook. Do so now.
+		// "storage-attached" hook, or the storage has grown since
+		// we last did. Do so now.
 		hookInfo.Kind = hooks.StorageAttached
 	case life.Dying:
 		attached, ok := s.storage.storageState.Attached(tag.Id())
@@ -217,6 +228,7 @@ func (s *storageResolver) nextHookOp(
 		tag:      tag,
 		kind:     storage.StorageKind(snap.Kind),
 		location: snap.Location,
+		size:     snap.Size,
 	}
 
 	return opFactory.NewRunHook(hookInfo)
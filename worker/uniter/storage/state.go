@@ -18,6 +18,11 @@ type State struct {
 	// key is the storage tag id, the value is attached
 	// or not.
 	storage map[string]bool
+
+	// sizes holds the size in MiB of each attached storage
+	// instance, as last reported to a committed storage-attached
+	// hook. The key is the storage tag id.
+	sizes map[string]uint64
 }
 
 func (s *State) Detach(storageID string) error {
@@ -25,6 +30,7 @@ func (s *State) Detach(storageID string) error {
 		return errors.NotFoundf("storage %q", storageID)
 	}
 	s.storage[storageID] = false
+	delete(s.sizes, storageID)
 	return nil
 }
 
@@ -37,12 +43,68 @@ func (s *State) Attached(storageID string) (bool, bool) {
 	return attached, ok
 }
 
+// SetAttachedSize records the size in MiB of the storage that was
+// reported to its storage-attached hook.
+func (s *State) SetAttachedSize(storageID string, size uint64) {
+	s.sizes[storageID] = size
+}
+
+// AttachedSize returns the size in MiB recorded by SetAttachedSize, and
+// whether one was recorded.
+func (s *State) AttachedSize(storageID string) (uint64, bool) {
+	size, ok := s.sizes[storageID]
+	return size, ok
+}
+
 func (s *State) Empty() bool {
 	return len(s.storage) == 0
 }
 
 func NewState() *State {
-	return &State{storage: make(map[string]bool)}
+	return &State{
+		storage: make(map[string]bool),
+		sizes:   make(map[string]uint64),
+	}
+}
+
+// stateDoc is the serialised form of a State. Agents that predate
+// storage resizing wrote the storage map on its own; that form is
+// still accepted when reading.
+type stateDoc struct {
+	Storage map[string]bool   `yaml:"storage"`
+	Sizes   map[string]uint64 `yaml:"sizes,omitempty"`
+}
+
+func (s *State) marshal() (string, error) {
+	if len(s.storage) == 0 {
+		return "", nil
+	}
+	data, err := yaml.Marshal(stateDoc{Storage: s.storage, Sizes: s.sizes})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return string(data), nil
+}
+
+func unmarshalState(data string) (*State, error) {
+	var doc stateDoc
+	if err := yaml.Unmarshal([]byte(data), &doc); err != nil || doc.Storage == nil {
+		// The storage map on its own, as written by older agents.
+		// Storage ids always contain a "/", so can't be mistaken for
+		// the document's keys.
+		doc = stateDoc{}
+		if err := yaml.Unmarshal([]byte(data), &doc.Storage); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	st := NewState()
+	for id, attached := range doc.Storage {
+		st.storage[id] = attached
+	}
+	for id, size := range doc.Sizes {
+		st.sizes[id] = size
+	}
+	return st, nil
 }
 
 // ValidateHook returns an error if the supplied hook.Info does not represent
@@ -86,7 +148,6 @@ func NewStateOps(rw UnitStateReadWriter) *stateOps {
 // Read reads a storage State from the controller. If the saved State
 // does not exist it returns NotFound and a new state.
 func (f *stateOps) Read() (*State, error) {
-	var stor map[string]bool
 	unitState, err := f.unitStateRW.State()
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -94,10 +155,7 @@ func (f *stateOps) Read() (*State, error) {
 	if unitState.StorageState == "" {
 		return NewState(), errors.NotFoundf("storage State")
 	}
-	if err = yaml.Unmarshal([]byte(unitState.StorageState), &stor); err != nil {
-		return nil, errors.Trace(err)
-	}
-	return &State{storage: stor}, nil
+	return unmarshalState(unitState.StorageState)
 }
 
 // Write stores the supplied State storage map on the controller.  If
@@ -106,13 +164,9 @@ func (f *stateOps) Write(st *State) error {
 	if st == nil {
 		return errors.Trace(errors.BadRequestf("arg is nil"))
 	}
-	var str string
-	if len(st.storage) > 0 {
-		data, err := yaml.Marshal(st.storage)
-		if err != nil {
-			return errors.Trace(err)
-		}
-		str = string(data)
+	str, err := st.marshal()
+	if err != nil {
+		return errors.Trace(err)
 	}
 	return f.unitStateRW.SetState(params.SetUnitStateArg{StorageState: &str})
 }
@@ -10,6 +10,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/storage"
 )
@@ -58,6 +59,21 @@ func (s *stateSuite) TestDetachErr(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
+func (s *stateSuite) TestAttachedSize(c *gc.C) {
+	_, found := s.st.AttachedSize(s.tag1.Id())
+	c.Assert(found, jc.IsFalse)
+	s.st.Attach(s.tag1.Id())
+	s.st.SetAttachedSize(s.tag1.Id(), 1024)
+	size, found := s.st.AttachedSize(s.tag1.Id())
+	c.Assert(found, jc.IsTrue)
+	c.Assert(size, gc.Equals, uint64(1024))
+
+	err := s.st.Detach(s.tag1.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	_, found = s.st.AttachedSize(s.tag1.Id())
+	c.Assert(found, jc.IsFalse)
+}
+
 func (s *stateSuite) TestEmpty(c *gc.C) {
 	c.Assert(s.st.Empty(), jc.IsTrue)
 }
@@ -117,6 +133,7 @@ func (s *stateOpsSuite) SetUpSuite(c *gc.C) {
 func (s *stateOpsSuite) SetUpTest(c *gc.C) {
 	s.storSt = storage.NewState()
 	s.storSt.Attach(s.tag1.Id())
+	s.storSt.SetAttachedSize(s.tag1.Id(), 1024)
 	s.storSt.Attach(s.tag2.Id())
 	c.Assert(s.storSt.Detach(s.tag2.Id()), jc.ErrorIsNil)
 	s.storSt.Attach(s.tag3.Id())
@@ -129,6 +146,23 @@ func (s *stateOpsSuite) TestRead(c *gc.C) {
 	obtainedSt, err := ops.Read()
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(storage.Storage(obtainedSt), gc.DeepEquals, storage.Storage(s.storSt))
+	c.Assert(storage.Sizes(obtainedSt), gc.DeepEquals, storage.Sizes(s.storSt))
+}
+
+func (s *stateOpsSuite) TestReadWithoutSizes(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	// State written by agents that predate storage resizing.
+	s.mockStateOps.EXPECT().State().Return(params.UnitStateResult{
+		StorageState: "test/1: true\ntest/2: false\n",
+	}, nil)
+	ops := storage.NewStateOps(s.mockStateOps)
+	obtainedSt, err := ops.Read()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storage.Storage(obtainedSt), gc.DeepEquals, map[string]bool{
+		"test/1": true,
+		"test/2": false,
+	})
+	c.Assert(storage.Sizes(obtainedSt), gc.HasLen, 0)
 }
 
 func (s *stateOpsSuite) TestReadNotFound(c *gc.C) {
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"sync"
+	"time"
 
 	corecharm "github.com/juju/charm/v7"
 	"github.com/juju/charm/v7/hooks"
@@ -43,6 +44,7 @@ import (
 	"github.com/juju/juju/worker/uniter/runner"
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
+	"github.com/juju/juju/worker/uniter/secrets"
 	"github.com/juju/juju/worker/uniter/storage"
 	"github.com/juju/juju/worker/uniter/upgradeseries"
 	"github.com/juju/juju/worker/uniter/verifycharmprofile"
@@ -113,6 +115,10 @@ type Uniter struct {
 	commands         runcommands.Commands
 	commandChannel   chan string
 
+	// secretRotateChannel is sent the URIs of secrets which are due
+	// to be rotated. It is passed to the remote state watcher.
+	secretRotateChannel chan []string
+
 	// The execution observer is only used in tests at this stage. Should this
 	// need to be extended, perhaps a list of observers would be needed.
 	observer UniterExecutionObserver
@@ -341,9 +347,15 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 		}
 	}
 
-	var watcher *remotestate.RemoteStateWatcher
+	var (
+		watcher       *remotestate.RemoteStateWatcher
+		rotateWatcher *secrets.RotateWatcher
+	)
 
 	u.logger.Infof("hooks are retried %v", u.hookRetryStrategy.ShouldRetry)
+	if u.hookRetryStrategy.ShouldRetry && u.hookRetryStrategy.MaxRetryAttempts > 0 {
+		u.logger.Infof("failed hooks are retried at most %d times", u.hookRetryStrategy.MaxRetryAttempts)
+	}
 	retryHookChan := make(chan struct{}, 1)
 	// TODO(katco): 2016-08-09: This type is deprecated: lp:1611427
 	retryHookTimer := utils.NewBackoffTimer(utils.BackoffTimerConfig{
@@ -368,11 +380,50 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 		retryHookTimer.Reset()
 	}()
 
+	// deferredHooks and deferredHooksUntil hold the number of hooks
+	// deferred by the application's maintenance window and when the
+	// window closes. deferredHooksChan is signalled when it closes, so
+	// that the deferred hooks are run.
+	var (
+		deferredHooks      int
+		deferredHooksUntil time.Time
+		deferredHooksTimer clock.Timer
+	)
+	deferredHooksChan := make(chan struct{}, 1)
+	defer func() {
+		if deferredHooksTimer != nil {
+			deferredHooksTimer.Stop()
+		}
+	}()
+	reportDeferredHooks := func(count int, until time.Time) {
+		if count == deferredHooks && until.Equal(deferredHooksUntil) {
+			return
+		}
+		deferredHooks, deferredHooksUntil = count, until
+		if deferredHooksTimer != nil {
+			deferredHooksTimer.Stop()
+			deferredHooksTimer = nil
+		}
+		if count == 0 {
+			return
+		}
+		u.logger.Infof("deferring %d hooks until the maintenance window closes at %s", count, until.UTC().Format(time.RFC3339))
+		deferredHooksTimer = u.clock.AfterFunc(until.Sub(u.clock.Now()), func() {
+			select {
+			case deferredHooksChan <- struct{}{}:
+			default:
+			}
+		})
+	}
+
 	restartWatcher := func() error {
 		if watcher != nil {
 			// watcher added to catacomb, will kill uniter if there's an error.
 			worker.Stop(watcher)
 		}
+		if rotateWatcher != nil {
+			worker.Stop(rotateWatcher)
+		}
 		var err error
 		watcher, err = remotestate.NewWatcher(
 			remotestate.WatcherConfig{
@@ -381,7 +432,9 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 				UnitTag:                       unitTag,
 				UpdateStatusChannel:           u.updateStatusAt,
 				CommandChannel:                u.commandChannel,
+				SecretRotateChannel:           u.secretRotateChannel,
 				RetryHookChannel:              retryHookChan,
+				DeferredHooksChannel:          deferredHooksChan,
 				ApplicationChannel:            u.applicationChannel,
 				ContainerRunningStatusChannel: u.containerRunningStatusChannel,
 				ContainerRunningStatusFunc:    u.containerRunningStatusFunc,
@@ -395,6 +448,29 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 		if err := u.catacomb.Add(watcher); err != nil {
 			return errors.Trace(err)
 		}
+		// The rotate watcher is restarted along with the remote state
+		// watcher so that rotations pending in the old snapshot are
+		// reported again.
+		rotateWatcher, err = secrets.NewRotateWatcher(secrets.RotateWatcherConfig{
+			Client:        u.st,
+			Clock:         u.clock,
+			Logger:        u.logger.Child("secrets"),
+			RotateChannel: u.secretRotateChannel,
+		})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := u.catacomb.Add(rotateWatcher); err != nil {
+			return errors.Trace(err)
+		}
+		return nil
+	}
+
+	rotatedSecret := func(uri string) error {
+		if err := u.st.SecretRotated(uri, u.clock.Now()); err != nil {
+			return errors.Annotatef(err, "recording rotation of secret %q", uri)
+		}
+		watcher.RotateSecretCompleted(uri)
 		return nil
 	}
 
@@ -407,7 +483,11 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 			// error state.
 			return nil
 		}
-		return setAgentStatus(u, status.Idle, "", nil)
+		var message string
+		if deferredHooks > 0 {
+			message = fmt.Sprintf("deferred: %d hooks until %s", deferredHooks, deferredHooksUntil.UTC().Format("15:04"))
+		}
+		return setAgentStatus(u, status.Idle, message, nil)
 	}
 
 	clearResolved := func() error {
@@ -452,12 +532,15 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 		}
 
 		cfg := ResolverConfig{
-			ModelType:           u.modelType,
-			ClearResolved:       clearResolved,
-			ReportHookError:     u.reportHookError,
-			ShouldRetryHooks:    u.hookRetryStrategy.ShouldRetry,
-			StartRetryHookTimer: retryHookTimer.Start,
-			StopRetryHookTimer:  retryHookTimer.Reset,
+			ModelType:            u.modelType,
+			ClearResolved:        clearResolved,
+			ReportHookError:      u.reportHookError,
+			ShouldRetryHooks:     u.hookRetryStrategy.ShouldRetry,
+			StartRetryHookTimer:  retryHookTimer.Start,
+			StopRetryHookTimer:   retryHookTimer.Reset,
+			MaxRetryHookAttempts: u.hookRetryStrategy.MaxRetryAttempts,
+			Clock:                u.clock,
+			ReportDeferredHooks:  reportDeferredHooks,
 			Actions: actions.NewResolver(
 				u.logger.Child("actions"),
 			),
@@ -477,6 +560,9 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 			Commands: runcommands.NewCommandsResolver(
 				u.commands, watcher.CommandCompleted,
 			),
+			Secrets: secrets.NewSecretsResolver(
+				u.logger.Child("secrets"), rotatedSecret,
+			),
 			Logger: u.logger,
 		}
 		if u.modelType == model.CAAS && u.isRemoteUnit {
@@ -695,6 +781,7 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 	u.relationStateTracker = relStateTracker
 	u.commands = runcommands.NewCommands()
 	u.commandChannel = make(chan string)
+	u.secretRotateChannel = make(chan []string)
 
 	storageAttachments, err := storage.NewAttachments(
 		u.st, unitTag, u.unit, u.catacomb.Dying(),
@@ -738,7 +825,7 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 		remoteExecutor = u.newRemoteRunnerExecutor(u.unit, u.paths)
 	}
 	runnerFactory, err := runner.NewFactory(
-		u.st, u.paths, contextFactory, u.newProcessRunner, remoteExecutor,
+		u.st, u.paths, contextFactory, u.newProcessRunner, remoteExecutor, u.clock,
 	)
 	if err != nil {
 		return errors.Trace(err)
@@ -750,6 +837,7 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 		Abort:          u.catacomb.Dying(),
 		MetricSpoolDir: u.paths.GetMetricsSpoolDir(),
 		Logger:         u.logger.Child("operation"),
+		Clock:          u.clock,
 	})
 
 	charmURL, err := u.getApplicationCharmURL()
@@ -764,10 +852,12 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 	}
 
 	operationExecutor, err := u.newOperationExecutor(operation.ExecutorConfig{
-		StateReadWriter: u.unit,
-		InitialState:    initialState,
-		AcquireLock:     u.acquireExecutionLock,
-		Logger:          u.logger.Child("operation"),
+		StateReadWriter:     u.unit,
+		InitialState:        initialState,
+		AcquireLock:         u.acquireExecutionLock,
+		Clock:               u.clock,
+		Logger:              u.logger.Child("operation"),
+		RecordHookExecution: u.unit.RecordHookExecution,
 	})
 	if err != nil {
 		return errors.Trace(err)
@@ -832,13 +922,14 @@ func (u *Uniter) RunCommands(args RunCommandsArgs) (results *exec.ExecResponse,
 // acquireExecutionLock acquires the machine-level execution lock, and
 // returns a func that must be called to unlock it. It's used by operation.Executor
 // when running operations that execute external code.
-func (u *Uniter) acquireExecutionLock(action string) (func(), error) {
+func (u *Uniter) acquireExecutionLock(action string, priority machinelock.Priority) (func(), error) {
 	// We want to make sure we don't block forever when locking, but take the
 	// Uniter's catacomb into account.
 	spec := machinelock.Spec{
-		Cancel:  u.catacomb.Dying(),
-		Worker:  "uniter",
-		Comment: action,
+		Cancel:   u.catacomb.Dying(),
+		Worker:   "uniter",
+		Comment:  action,
+		Priority: priority,
 	}
 	releaser, err := u.hookLock.Acquire(spec)
 	if err != nil {
@@ -847,10 +938,11 @@ func (u *Uniter) acquireExecutionLock(action string) (func(), error) {
 	return releaser, nil
 }
 
-func (u *Uniter) reportHookError(hookInfo hook.Info) error {
+func (u *Uniter) reportHookError(state operation.State) error {
 	// Set the agent status to "error". We must do this here in case the
 	// hook is interrupted (e.g. unit agent crashes), rather than immediately
 	// after attempting a runHookOp.
+	hookInfo := *state.Hook
 	hookName := string(hookInfo.Kind)
 	statusData := map[string]interface{}{}
 	if hookInfo.Kind.IsRelation() {
@@ -866,5 +958,8 @@ func (u *Uniter) reportHookError(hookInfo hook.Info) error {
 	}
 	statusData["hook"] = hookName
 	statusMessage := fmt.Sprintf("hook failed: %q", hookName)
+	if state.HookTimeout > 0 {
+		statusMessage = fmt.Sprintf("%s hook timed out after %v", hookName, state.HookTimeout)
+	}
 	return setAgentStatus(u, status.Error, statusMessage, statusData)
 }